@@ -60,7 +60,9 @@ func init() {
 		operatorCmd,
 		osdCmd,
 		mgrCmd,
-		configCmd)
+		configCmd,
+		seedCmd,
+		explainCmd)
 }
 
 func createContext() *clusterd.Context {
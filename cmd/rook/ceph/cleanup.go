@@ -38,6 +38,7 @@ var (
 	sanitizeMethod     string
 	sanitizeDataSource string
 	sanitizeIteration  int32
+	osdDirs            []string
 )
 
 var cleanUpCmd = &cobra.Command{
@@ -50,6 +51,11 @@ var cleanUpHostCmd = &cobra.Command{
 	Short: "Starts the cleanup process on a host after the ceph cluster is deleted",
 }
 
+var cleanUpOSDDirsCmd = &cobra.Command{
+	Use:   "osd-dirs",
+	Short: "Removes the directories of the purged OSDs on a host after they are migrated to PVCs",
+}
+
 var cleanUpSubVolumeGroupCmd = &cobra.Command{
 	// the subcommand matches CRD kind of the custom resource to be cleaned up
 	Use:   "CephFilesystemSubVolumeGroup",
@@ -77,12 +83,18 @@ func init() {
 	cleanUpHostCmd.Flags().StringVar(&sanitizeDataSource, "sanitize-data-source", string(cephv1.SanitizeDataSourceZero), "data source to sanitize the disk (zero or random)")
 	cleanUpHostCmd.Flags().Int32Var(&sanitizeIteration, "sanitize-iteration", 1, "overwrite N times the disk")
 
+	cleanUpOSDDirsCmd.Flags().StringVar(&dataDirHostPath, "data-dir-host-path", "", "dataDirHostPath on the node")
+	cleanUpOSDDirsCmd.Flags().StringVar(&namespaceDir, "namespace-dir", "", "directory of the cluster in the dataDirHostPath")
+	cleanUpOSDDirsCmd.Flags().StringSliceVar(&osdDirs, "osd-dirs", nil, "directories of the purged OSDs in the directory of the cluster")
+
 	flags.SetFlagsFromEnv(cleanUpHostCmd.Flags(), rook.RookEnvVarPrefix)
+	flags.SetFlagsFromEnv(cleanUpOSDDirsCmd.Flags(), rook.RookEnvVarPrefix)
 	flags.SetFlagsFromEnv(cleanUpSubVolumeGroupCmd.Flags(), rook.RookEnvVarPrefix)
 
-	cleanUpCmd.AddCommand(cleanUpHostCmd, cleanUpSubVolumeGroupCmd, cleanUpRadosNamespaceCmd, cleanUpBlockPoolCmd)
+	cleanUpCmd.AddCommand(cleanUpHostCmd, cleanUpOSDDirsCmd, cleanUpSubVolumeGroupCmd, cleanUpRadosNamespaceCmd, cleanUpBlockPoolCmd)
 
 	cleanUpHostCmd.RunE = startHostCleanUp
+	cleanUpOSDDirsCmd.RunE = startOSDDirsCleanUp
 	cleanUpSubVolumeGroupCmd.RunE = startSubVolumeGroupCleanUp
 	cleanUpRadosNamespaceCmd.RunE = startRadosNamespaceCleanup
 	cleanUpBlockPoolCmd.RunE = startBlockPoolCleanup
@@ -121,6 +133,20 @@ func startHostCleanUp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func startOSDDirsCleanUp(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(cleanUpOSDDirsCmd.Flags())
+
+	if dataDirHostPath == "" || namespaceDir == "" {
+		rook.TerminateFatal(fmt.Errorf("the dataDirHostPath and the directory of the cluster are required"))
+	}
+	if err := cleanup.CleanOSDDirs(namespaceDir, dataDirHostPath, osdDirs); err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to clean up the osd directories. %v", err))
+	}
+
+	return nil
+}
+
 func startSubVolumeGroupCleanUp(cmd *cobra.Command, args []string) error {
 	rook.SetLogLevel()
 	rook.LogStartupInfo(cleanUpSubVolumeGroupCmd.Flags())
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [kind] [field]",
+	Short: "Prints the resources affected by the fields of the custom resources and whether a change restarts the daemons",
+	Long: `Prints the resources applied by the controllers from the fields of the custom resources, and whether
+a change of the field restarts the daemons. The fields can be filtered by kind, for example "CephCluster",
+and by a field prefix, for example "spec.mon".`,
+	Args: cobra.MaximumNArgs(2),
+}
+
+var explainJSON bool
+
+func init() {
+	explainCmd.Flags().BoolVar(&explainJSON, "json", false, "print the field impacts in JSON")
+	explainCmd.RunE = explain
+}
+
+func explain(cmd *cobra.Command, args []string) error {
+	kind, prefix := "", ""
+	if len(args) > 0 {
+		kind = args[0]
+	}
+	if len(args) > 1 {
+		prefix = args[1]
+	}
+
+	impacts := []opcontroller.FieldImpact{}
+	for _, impact := range opcontroller.FieldImpacts(kind) {
+		if prefix == "" || impact.Field == prefix || strings.HasPrefix(impact.Field, prefix+".") {
+			impacts = append(impacts, impact)
+		}
+	}
+	if len(impacts) == 0 {
+		return fmt.Errorf("no field impacts found for kind %q and field %q", kind, prefix)
+	}
+
+	if explainJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(impacts)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tFIELD\tRESTART\tRESOURCES\tDESCRIPTION")
+	for _, impact := range impacts {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", impact.Kind, impact.Field, impact.Restart, strings.Join(impact.Resources, ","), impact.Description)
+	}
+	return w.Flush()
+}
@@ -42,6 +42,7 @@ https://github.com/rook/rook`,
 
 func init() {
 	operatorCmd.Flags().BoolVar(&operator.EnableMachineDisruptionBudget, "enable-machine-disruption-budget", false, "enable fencing controllers")
+	operatorCmd.Flags().BoolVar(&opcontroller.ObserverMode, "observer-mode", false, "run the controllers in read-only mode, reporting the changes they would make without making them")
 
 	flags.SetFlagsFromEnv(operatorCmd.Flags(), rook.RookEnvVarPrefix)
 	operatorCmd.Flags().AddGoFlagSet(flag.CommandLine)
@@ -56,8 +57,17 @@ func startOperator(cmd *cobra.Command, args []string) error {
 	rook.LogStartupInfo(operatorCmd.Flags())
 
 	logger.Info("starting Rook-Ceph operator")
+	if opcontroller.ObserverMode {
+		logger.Warningf("running in observer mode, the changes to the resources are reported in the %q ConfigMap and not made", opcontroller.ObserverActionsConfigMapName)
+	}
 	context := createContext()
 	context.ConfigDir = k8sutil.DataDir
+	if opcontroller.ObserverMode {
+		// in observer mode, the operator reports the changes it would make without making them
+		opcontroller.WrapObserverConfig(context.KubeConfig)
+		context.Executor = opcontroller.NewObserverExecutor(context.Executor)
+		rook.SetContextClients(context)
+	}
 
 	// Fail if operator namespace is not provided
 	if os.Getenv(k8sutil.PodNamespaceEnvVar) == "" {
@@ -23,6 +23,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 
@@ -65,9 +66,21 @@ var osdRemoveCmd = &cobra.Command{
 	Short: "Removes a set of OSDs from the cluster",
 }
 
+var osdRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Rotates the encryption key of an encrypted osd on a node",
+}
+
+var osdActivationGateCmd = &cobra.Command{
+	Use:   "activation-gate",
+	Short: "Waits for an activation slot of the node before the osd is activated",
+}
+
 var (
 	osdDataDeviceFilter          string
 	osdDataDevicePathFilter      string
+	osdDataDeviceIdentityFilter  string
+	osdQuarantinedDevices        string
 	ownerRefID                   string
 	clusterName                  string
 	osdID                        int
@@ -83,6 +96,12 @@ var (
 	preservePVC                  string
 	forceOSDRemoval              string
 	wipeDevicesFromOtherClusters bool
+	ephemeralMetadataDevice      bool
+	activationDeviceClass        string
+	activationMaxConcurrent      int
+	activationTimeout            time.Duration
+	rotateKeyOSDUUID             string
+	rotateKeyBlockPaths          string
 )
 
 const (
@@ -99,11 +118,14 @@ func addOSDFlags(command *cobra.Command) {
 	provisionCmd.Flags().StringVar(&cfg.devices, "data-devices", "", "comma separated list of devices to use for storage")
 	provisionCmd.Flags().StringVar(&osdDataDeviceFilter, "data-device-filter", "", "a regex filter for the device names to use, or \"all\"")
 	provisionCmd.Flags().StringVar(&osdDataDevicePathFilter, "data-device-path-filter", "", "a regex filter for the device path names to use")
+	provisionCmd.Flags().StringVar(&osdDataDeviceIdentityFilter, "data-device-identity-filter", "", "a JSON filter allowing or denying devices by WWN, serial, vendor or model")
+	provisionCmd.Flags().StringVar(&osdQuarantinedDevices, "quarantined-devices", "", "comma separated list of the devices not to provision since their OSD prepare jobs failed")
 	provisionCmd.Flags().StringVar(&cfg.metadataDevice, "metadata-device", "", "device to use for metadata (e.g. a high performance SSD/NVMe device)")
 	provisionCmd.Flags().BoolVar(&cfg.forceFormat, "force-format", false,
 		"true to force the format of any specified devices, even if they already have a filesystem.  BE CAREFUL!")
 	provisionCmd.Flags().BoolVar(&cfg.pvcBacked, "pvc-backed-osd", false, "true to specify a block mode pvc is backing the OSD")
 	provisionCmd.Flags().BoolVar(&wipeDevicesFromOtherClusters, "wipe-devices-from-other-clusters", false, "wipe the OSD devices that are configured for a different ceph cluster")
+	provisionCmd.Flags().BoolVar(&ephemeralMetadataDevice, "ephemeral-metadata-device", false, "rebuild the OSD on the data device if its ephemeral metadata device was lost")
 	// flags for generating the osd config
 	osdConfigCmd.Flags().IntVar(&osdID, "osd-id", -1, "osd id for which to generate config")
 	osdConfigCmd.Flags().BoolVar(&osdIsDevice, "is-device", false, "whether the osd is a device")
@@ -121,11 +143,22 @@ func addOSDFlags(command *cobra.Command) {
 	osdRemoveCmd.Flags().StringVar(&preservePVC, "preserve-pvc", "false", "Whether PVCs for OSDs will be deleted")
 	osdRemoveCmd.Flags().StringVar(&forceOSDRemoval, "force-osd-removal", "false", "Whether to force remove the OSD")
 
+	// flags for limiting the OSDs of a node activated in parallel
+	osdActivationGateCmd.Flags().StringVar(&activationDeviceClass, "device-class", "", "the device class with its own activation slots, empty for the slots shared by the osds of the node")
+	osdActivationGateCmd.Flags().IntVar(&activationMaxConcurrent, "max-concurrent", 0, "the maximum number of osds of the node activated in parallel")
+	osdActivationGateCmd.Flags().DurationVar(&activationTimeout, "timeout", 10*time.Minute, "how long an osd that does not become ready holds its activation slot")
+
+	// flags for rotating the encryption key of an osd
+	osdRotateKeyCmd.Flags().StringVar(&rotateKeyOSDUUID, "osd-uuid", "", "the UUID of the osd whose key is rotated")
+	osdRotateKeyCmd.Flags().StringVar(&rotateKeyBlockPaths, "block-paths", "", "comma separated list of the encrypted devices of the osd")
+
 	// add the subcommands to the parent osd command
 	osdCmd.AddCommand(osdConfigCmd,
 		provisionCmd,
 		osdStartCmd,
-		osdRemoveCmd)
+		osdRemoveCmd,
+		osdRotateKeyCmd,
+		osdActivationGateCmd)
 }
 
 func addOSDConfigFlags(command *cobra.Command) {
@@ -138,6 +171,9 @@ func addOSDConfigFlags(command *cobra.Command) {
 	command.Flags().IntVar(&cfg.storeConfig.WalSizeMB, "osd-wal-size", osdcfg.WalDefaultSizeMB, "default size (MB) for OSD write ahead log (WAL) (bluestore)")
 	command.Flags().IntVar(&cfg.storeConfig.DatabaseSizeMB, "osd-database-size", 0, "default size (MB) for OSD database (bluestore)")
 	command.Flags().IntVar(&cfg.storeConfig.OSDsPerDevice, "osds-per-device", 1, "the number of OSDs per device")
+	command.Flags().StringVar(&cfg.storeConfig.OSDsPerDeviceMode, "osds-per-device-mode", "", "how the OSDs of a device with more than one OSD are created, lvm or raw")
+	command.Flags().IntVar(&cfg.storeConfig.MetadataDeviceSlots, "osd-metadata-device-slots", 0, "the number of DB volumes of the same size the metadata device is carved into")
+	command.Flags().BoolVar(&cfg.storeConfig.ZapPurgedOSDs, "osd-zap-purged-osds", false, "whether to remove the logical volumes on the node of the OSDs purged from the cluster")
 	command.Flags().BoolVar(&cfg.storeConfig.EncryptedDevice, "encrypted-device", false, "whether to encrypt the OSD with dmcrypt")
 	command.Flags().StringVar(&cfg.storeConfig.DeviceClass, "osd-crush-device-class", "", "The device class for all OSDs configured on this node")
 	command.Flags().StringVar(&cfg.storeConfig.InitialWeight, "osd-crush-initial-weight", "", "The initial weight of OSD in TiB units")
@@ -152,11 +188,40 @@ func init() {
 	flags.SetFlagsFromEnv(provisionCmd.Flags(), rook.RookEnvVarPrefix)
 	flags.SetFlagsFromEnv(osdStartCmd.Flags(), rook.RookEnvVarPrefix)
 	flags.SetFlagsFromEnv(osdRemoveCmd.Flags(), rook.RookEnvVarPrefix)
+	flags.SetFlagsFromEnv(osdRotateKeyCmd.Flags(), rook.RookEnvVarPrefix)
+	flags.SetFlagsFromEnv(osdActivationGateCmd.Flags(), rook.RookEnvVarPrefix)
 
 	osdConfigCmd.RunE = writeOSDConfig
 	provisionCmd.RunE = prepareOSD
 	osdStartCmd.RunE = startOSD
 	osdRemoveCmd.RunE = removeOSDs
+	osdRotateKeyCmd.RunE = rotateOSDKey
+	osdActivationGateCmd.RunE = waitForActivationSlot
+}
+
+// Wait for an activation slot of the node before the osd is activated
+func waitForActivationSlot(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(osdActivationGateCmd.Flags())
+	if activationMaxConcurrent <= 0 {
+		logger.Info("the osds of the node are not limited, skipping the wait for an activation slot")
+		return nil
+	}
+
+	context := createContext()
+	slot := &osddaemon.ActivationSlot{
+		Clientset:     context.Clientset,
+		Namespace:     os.Getenv(k8sutil.PodNamespaceEnvVar),
+		NodeName:      os.Getenv(k8sutil.NodeNameEnvVar),
+		DeviceClass:   activationDeviceClass,
+		PodName:       os.Getenv(k8sutil.PodNameEnvVar),
+		MaxConcurrent: activationMaxConcurrent,
+		Timeout:       activationTimeout,
+	}
+	if err := slot.Wait(cmd.Context()); err != nil {
+		rook.TerminateFatal(errors.Wrap(err, "failed to wait for an activation slot"))
+	}
+	return nil
 }
 
 // Start the osd daemon if provisioned by ceph-volume
@@ -225,7 +290,7 @@ func prepareOSD(cmd *cobra.Command, args []string) error {
 		}
 
 		dataDevices = []osddaemon.DesiredDevice{
-			{Name: osdDataDeviceFilter, IsFilter: true, OSDsPerDevice: cfg.storeConfig.OSDsPerDevice},
+			{Name: osdDataDeviceFilter, IsFilter: true, OSDsPerDevice: cfg.storeConfig.OSDsPerDevice, OSDsPerDeviceMode: cfg.storeConfig.OSDsPerDeviceMode},
 		}
 
 		deviceFilter = osdDataDeviceFilter
@@ -235,7 +300,7 @@ func prepareOSD(cmd *cobra.Command, args []string) error {
 		}
 
 		dataDevices = []osddaemon.DesiredDevice{
-			{Name: osdDataDevicePathFilter, IsDevicePathFilter: true, OSDsPerDevice: cfg.storeConfig.OSDsPerDevice},
+			{Name: osdDataDevicePathFilter, IsDevicePathFilter: true, OSDsPerDevice: cfg.storeConfig.OSDsPerDevice, OSDsPerDeviceMode: cfg.storeConfig.OSDsPerDeviceMode},
 		}
 	} else {
 		var err error
@@ -273,10 +338,26 @@ func prepareOSD(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			rook.TerminateFatal(errors.Wrapf(err, "failed to destroy OSD %d.", replaceOSDID))
 		}
+	} else if cfg.pvcBacked && ephemeralMetadataDevice {
+		// the OSD cannot start without its metadata device, so it is rebuilt with the same ID
+		replaceOSD, err = osddaemon.DestroyOSDWithLostMetadata(context, &clusterInfo, dataDevices, cfg.storeConfig.EncryptedDevice)
+		if err != nil {
+			rook.TerminateFatal(errors.Wrap(err, "failed to rebuild the OSD with a lost metadata device"))
+		}
 	}
 
 	agent := osddaemon.NewAgent(context, dataDevices, cfg.metadataDevice, forceFormat,
 		cfg.storeConfig, &clusterInfo, cfg.nodeName, kv, replaceOSD, cfg.pvcBacked, wipeDevicesFromOtherClusters)
+	if osdDataDeviceIdentityFilter != "" {
+		var filter cephv1.DeviceIdentityFilter
+		if err := json.Unmarshal([]byte(osdDataDeviceIdentityFilter), &filter); err != nil {
+			rook.TerminateFatal(errors.Wrapf(err, "failed to parse device identity filter (%q)", osdDataDeviceIdentityFilter))
+		}
+		agent.SetDeviceIdentityFilter(&filter)
+	}
+	if osdQuarantinedDevices != "" {
+		agent.SetQuarantinedDevices(strings.Split(osdQuarantinedDevices, ","))
+	}
 
 	if cfg.metadataDevice != "" {
 		metaDevice = cfg.metadataDevice
@@ -290,6 +371,10 @@ func prepareOSD(cmd *cobra.Command, args []string) error {
 			Message:      err.Error(),
 			PvcBackedOSD: cfg.pvcBacked,
 		}
+		var devicesErr *osddaemon.DevicesError
+		if errors.As(err, &devicesErr) {
+			status.Devices = devicesErr.Devices
+		}
 		oposd.UpdateNodeOrPVCStatus(clusterInfo.Context, kv, cfg.nodeName, status)
 
 		rook.TerminateFatal(err)
@@ -345,6 +430,35 @@ func removeOSDs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Rotate the encryption key of an osd whose key is stored in the mon config-key store
+func rotateOSDKey(cmd *cobra.Command, args []string) error {
+	required := []string{"osd-uuid", "block-paths"}
+	if err := flags.VerifyRequiredFlags(osdRotateKeyCmd, required); err != nil {
+		return err
+	}
+	required = []string{"mon-endpoints", "ceph-username"}
+	if err := flags.VerifyRequiredFlags(osdCmd, required); err != nil {
+		return err
+	}
+
+	if err := readCephSecret(path.Join(mon.CephSecretMountPath, mon.CephSecretFilename)); err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	commonOSDInit(osdRotateKeyCmd)
+
+	context := createContext()
+
+	clusterInfo.Context = cmd.Context()
+
+	err := osddaemon.RotateCephKeyEncryptionKey(context, &clusterInfo, rotateKeyOSDUUID, strings.Split(rotateKeyBlockPaths, ","))
+	if err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	return nil
+}
+
 func commonOSDInit(cmd *cobra.Command) {
 	rook.SetLogLevel()
 	rook.LogStartupInfo(cmd.Flags())
@@ -384,6 +498,7 @@ func parseDevices(devices string) ([]osddaemon.DesiredDevice, error) {
 			Name: cd.ID,
 		}
 		d.OSDsPerDevice = cd.StoreConfig.OSDsPerDevice
+		d.OSDsPerDeviceMode = cd.StoreConfig.OSDsPerDeviceMode
 		d.DatabaseSizeMB = cd.StoreConfig.DatabaseSizeMB
 		d.DeviceClass = cd.StoreConfig.DeviceClass
 		d.InitialWeight = cd.StoreConfig.InitialWeight
@@ -392,6 +507,9 @@ func parseDevices(devices string) ([]osddaemon.DesiredDevice, error) {
 		if d.OSDsPerDevice < 1 {
 			return nil, errors.Errorf("osds per device should be greater than 0 (%q)", d.OSDsPerDevice)
 		}
+		if err := osdcfg.ValidateOSDsPerDeviceMode(d.OSDsPerDeviceMode); err != nil {
+			return nil, err
+		}
 
 		result = append(result, d)
 	}
@@ -169,6 +169,29 @@ func TestParseDesiredDevices(t *testing.T) {
 	assert.False(t, result[2].IsDevicePathFilter)
 	assert.False(t, result[3].IsDevicePathFilter)
 
+	// the osds per device mode must be lvm or raw
+	configuredDevices = []osdcfg.ConfiguredDevice{
+		{
+			ID: "nvme01",
+			StoreConfig: osdcfg.StoreConfig{
+				OSDsPerDevice:     2,
+				OSDsPerDeviceMode: "raw",
+			},
+		},
+	}
+	marshalledDevices, err = json.Marshal(configuredDevices)
+	assert.NoError(t, err)
+	result, err = parseDevices(string(marshalledDevices))
+	assert.NoError(t, err)
+	assert.Equal(t, "raw", result[0].OSDsPerDeviceMode)
+
+	configuredDevices[0].StoreConfig.OSDsPerDeviceMode = "partition"
+	marshalledDevices, err = json.Marshal(configuredDevices)
+	assert.NoError(t, err)
+	result, err = parseDevices(string(marshalledDevices))
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
 	// check empty devices list
 	result, err = parseDevices("")
 	assert.NoError(t, err)
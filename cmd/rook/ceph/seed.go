@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/rook/rook/cmd/rook/rook"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/daemon/ceph/seed"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Creates the seed data of a resource",
+}
+
+var seedBlockPoolCmd = &cobra.Command{
+	// the subcommand matches CRD kind of the custom resource to be seeded
+	Use:   "CephBlockPool",
+	Short: "Creates the seed images of a CephBlockPool",
+}
+
+var seedFilesystemCmd = &cobra.Command{
+	// the subcommand matches CRD kind of the custom resource to be seeded
+	Use:   "CephFilesystem",
+	Short: "Creates the seed directories of a CephFilesystem",
+}
+
+var seedObjectStoreCmd = &cobra.Command{
+	// the subcommand matches CRD kind of the custom resource to be seeded
+	Use:   "CephObjectStore",
+	Short: "Creates the seed users and buckets of a CephObjectStore",
+}
+
+func init() {
+	seedCmd.AddCommand(seedBlockPoolCmd, seedFilesystemCmd, seedObjectStoreCmd)
+
+	seedBlockPoolCmd.RunE = startBlockPoolSeed
+	seedFilesystemCmd.RunE = startFilesystemSeed
+	seedObjectStoreCmd.RunE = startObjectStoreSeed
+}
+
+// seedSpec parses the seed settings of the custom resource from the pod environment variables
+func seedSpec(spec interface{}) {
+	rawSpec := os.Getenv(opcontroller.SeedSpecEnv)
+	if rawSpec == "" {
+		rook.TerminateFatal(fmt.Errorf("seed settings are not available in the pod environment variables"))
+	}
+	if err := json.Unmarshal([]byte(rawSpec), spec); err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to parse the seed settings. %v", err))
+	}
+}
+
+func startBlockPoolSeed(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(seedBlockPoolCmd.Flags())
+
+	ctx := cmd.Context()
+	context := createContext()
+	namespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	clusterInfo := client.AdminClusterInfo(ctx, namespace, "")
+
+	poolName := os.Getenv(opcontroller.CephBlockPoolNameEnv)
+	if poolName == "" {
+		rook.TerminateFatal(fmt.Errorf("cephblockpool name is not available in the pod environment variables"))
+	}
+	spec := &cephv1.BlockPoolSeedSpec{}
+	seedSpec(spec)
+
+	err := seed.BlockPoolSeed(context, clusterInfo, poolName, spec)
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to seed cephblockpool %q. %v", poolName, err))
+	}
+
+	return nil
+}
+
+func startFilesystemSeed(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(seedFilesystemCmd.Flags())
+
+	ctx := cmd.Context()
+	context := createContext()
+	namespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	clusterInfo := client.AdminClusterInfo(ctx, namespace, "")
+
+	fsName := os.Getenv(opcontroller.CephFSNameEnv)
+	if fsName == "" {
+		rook.TerminateFatal(fmt.Errorf("ceph filesystem name is not available in the pod environment variables"))
+	}
+	spec := &cephv1.FilesystemSeedSpec{}
+	seedSpec(spec)
+
+	err := seed.FilesystemSeed(context, clusterInfo, fsName, spec)
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to seed ceph filesystem %q. %v", fsName, err))
+	}
+
+	return nil
+}
+
+func startObjectStoreSeed(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(seedObjectStoreCmd.Flags())
+
+	ctx := cmd.Context()
+	context := createContext()
+	namespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	clusterInfo := client.AdminClusterInfo(ctx, namespace, "")
+
+	storeName := os.Getenv(opcontroller.CephObjectStoreNameEnv)
+	if storeName == "" {
+		rook.TerminateFatal(fmt.Errorf("ceph object store name is not available in the pod environment variables"))
+	}
+	endpoint := os.Getenv(opcontroller.CephObjectEndpointEnv)
+	if endpoint == "" {
+		rook.TerminateFatal(fmt.Errorf("ceph object store endpoint is not available in the pod environment variables"))
+	}
+	spec := &cephv1.ObjectStoreSeedSpec{}
+	seedSpec(spec)
+
+	objContext := object.NewContext(context, clusterInfo, storeName)
+	objContext.Realm = os.Getenv(opcontroller.CephObjectRealmEnv)
+	objContext.ZoneGroup = os.Getenv(opcontroller.CephObjectZoneGroupEnv)
+	objContext.Zone = os.Getenv(opcontroller.CephObjectZoneEnv)
+	conn := seed.ObjectStoreConnection{
+		Endpoint: endpoint,
+		TLSCert:  []byte(os.Getenv(opcontroller.CephObjectTLSCACertEnv)),
+	}
+	if insecure := os.Getenv(opcontroller.CephObjectTLSInsecureEnv); insecure != "" {
+		var err error
+		conn.InsecureTLS, err = strconv.ParseBool(insecure)
+		if err != nil {
+			rook.TerminateFatal(fmt.Errorf("failed to parse the insecure TLS setting of the ceph object store. %v", err))
+		}
+	}
+
+	err := seed.ObjectStoreSeed(objContext, conn, spec)
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to seed ceph object store %q. %v", storeName, err))
+	}
+
+	return nil
+}
@@ -127,6 +127,15 @@ func NewContext() *clusterd.Context {
 		TerminateOnError(err, "failed to get k8s cluster config")
 	}
 
+	SetContextClients(context)
+
+	return context
+}
+
+// SetContextClients creates the clients of the context from its kube config
+func SetContextClients(context *clusterd.Context) {
+	var err error
+
 	context.Clientset, err = kubernetes.NewForConfig(context.KubeConfig)
 	TerminateOnError(err, "failed to create k8s clientset")
 
@@ -141,8 +150,6 @@ func NewContext() *clusterd.Context {
 
 	context.ApiExtensionsClient, err = apiextensionsclient.NewForConfig(context.KubeConfig)
 	TerminateOnError(err, "failed to create crd extensions client")
-
-	return context
 }
 
 func GetOperatorImage(ctx context.Context, clientset kubernetes.Interface, containerName string) string {
@@ -16,6 +16,23 @@ subjects:
     name: rook-ceph-mgr
     namespace: {{ .Release.Namespace }} # namespace:cluster
 ---
+# Allow the mgr and ceph exporter to serve the metrics over TLS to the authorized clients
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: rook-ceph-metrics-auth{{ template "library.suffix-cluster-namespace" . }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: rook-ceph-metrics-auth
+subjects:
+  - kind: ServiceAccount
+    name: rook-ceph-mgr
+    namespace: {{ .Release.Namespace }} # namespace:cluster
+  - kind: ServiceAccount
+    name: rook-ceph-default
+    namespace: {{ .Release.Namespace }} # namespace:cluster
+---
 # Allow the ceph osd to access cluster-wide resources necessary for determining their topology location
 kind: ClusterRoleBinding
 apiVersion: rbac.authorization.k8s.io/v1
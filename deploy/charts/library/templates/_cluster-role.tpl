@@ -19,6 +19,14 @@ rules:
   - apiGroups: ["ceph.rook.io"]
     resources: ["cephclusters", "cephclusters/finalizers"]
     verbs: ["get", "list", "create", "update", "delete"]
+  # the osd pods hold the activation slots of their node in leases until they are ready, when the
+  # number of osds of a node activated in parallel is limited
+  - apiGroups: ["coordination.k8s.io"]
+    resources: ["leases"]
+    verbs: ["get", "create", "update"]
+  - apiGroups: [""]
+    resources: ["pods"]
+    verbs: ["get"]
 ---
 # Aspects of ceph-mgr that operate within the cluster's namespace
 kind: Role
@@ -11,3 +11,15 @@ Return the target Kubernetes version.
 {{- define "capabilities.kubeVersion" -}}
 {{- default .Capabilities.KubeVersion.Version .Values.kubeVersion -}}
 {{- end }}
+
+{{/*
+The RBD storage class parameters of the kubevirt profile, the parameters set on the storage class
+take precedence. The exclusive-lock feature is cooperative with krbd, so the image can be mapped on
+both nodes during a live migration, and rxbounce avoids the CRC errors of the Windows guests.
+*/}}
+{{- define "kubevirtRBDParameters" -}}
+imageFormat: "2"
+imageFeatures: layering,fast-diff,object-map,deep-flatten,exclusive-lock
+mounter: rbd
+mapOptions: krbd:rxbounce
+{{- end }}
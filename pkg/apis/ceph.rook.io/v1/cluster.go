@@ -37,6 +37,37 @@ func (c *ClusterSpec) IsStretchCluster() bool {
 	return c.Mon.StretchCluster != nil && len(c.Mon.StretchCluster.Zones) > 0
 }
 
+// StretchDataZoneCount returns the number of zones of the stretch cluster that are not the arbiter
+func (c *ClusterSpec) StretchDataZoneCount() int {
+	if !c.IsStretchCluster() {
+		return 0
+	}
+	count := 0
+	for _, zone := range c.Mon.StretchCluster.Zones {
+		if !zone.Arbiter {
+			count++
+		}
+	}
+	return count
+}
+
+// StretchMonsPerDataZone returns the number of mons in each data zone of the stretch cluster, the
+// arbiter zone having a single mon
+func (c *ClusterSpec) StretchMonsPerDataZone() int {
+	dataZones := c.StretchDataZoneCount()
+	if dataZones == 0 {
+		return 0
+	}
+	return (c.Mon.Count - 1) / dataZones
+}
+
+// IsStretchMode returns whether the stretch cluster runs in the stretch mode of ceph, which only
+// supports two data zones. The stretch clusters with more data zones rely on the connectivity
+// election strategy and the stretch CRUSH rule without the stretch mode.
+func (c *ClusterSpec) IsStretchMode() bool {
+	return c.StretchDataZoneCount() == 2
+}
+
 func (c *ClusterSpec) ZonesRequired() bool {
 	return c.IsStretchCluster() || len(c.Mon.Zones) > 0
 }
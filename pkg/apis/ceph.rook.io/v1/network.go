@@ -48,6 +48,11 @@ func (n *NetworkSpec) IsHost() bool {
 	return enforceHostNetwork || (n.HostNetwork && n.Provider == NetworkProviderDefault) || n.Provider == NetworkProviderHost
 }
 
+// NetworkPoliciesEnabled returns whether the operator should generate NetworkPolicies for the Ceph daemons
+func (n *NetworkSpec) NetworkPoliciesEnabled() bool {
+	return n.NetworkPolicies != nil && n.NetworkPolicies.Enabled
+}
+
 func ValidateNetworkSpec(clusterNamespace string, spec NetworkSpec) error {
 	if spec.HostNetwork && (spec.Provider != NetworkProviderDefault) {
 		return errors.Errorf(`the legacy hostNetwork setting is only valid with the default network provider ("") and not with '%q'`, spec.Provider)
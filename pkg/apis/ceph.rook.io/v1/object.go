@@ -118,9 +118,41 @@ func ValidateObjectSpec(gs *CephObjectStore) error {
 		}
 	}
 
+	// check gateway groups
+	groups := map[string]bool{}
+	for _, group := range gs.Spec.Gateway.Groups {
+		if groups[group.Name] {
+			return errors.Errorf("gateway group %q is defined more than once", group.Name)
+		}
+		groups[group.Name] = true
+		// the deployment of the gateways without groups is suffixed with "a"
+		if group.Name == "a" {
+			return errors.New(`gateway group name "a" is reserved`)
+		}
+		if errs := validation.IsDNS1035Label(gs.GetGroupServiceName(group.Name)); len(errs) > 0 {
+			return errors.Errorf("gateway group %q service name is not a valid DNS-1035 label: %v", group.Name, errs)
+		}
+	}
+	if len(gs.Spec.Gateway.Groups) > 0 && gs.Spec.IsExternal() {
+		return errors.New("gateway groups cannot be set for an external object store")
+	}
+
 	return nil
 }
 
+// TotalInstances returns the number of gateway pods of the object store, in all the gateway groups
+// if the store has groups
+func (s *GatewaySpec) TotalInstances() int32 {
+	if len(s.Groups) == 0 {
+		return s.Instances
+	}
+	var instances int32
+	for _, group := range s.Groups {
+		instances += max(group.Instances, 1)
+	}
+	return instances
+}
+
 func (s *ObjectStoreSpec) GetServiceServingCert() string {
 	if s.Gateway.Service != nil {
 		return s.Gateway.Service.Annotations[ServiceServingCertKey]
@@ -134,6 +166,12 @@ func (c *CephObjectStore) GetServiceName() string {
 	return "rook-ceph-rgw-" + c.GetName()
 }
 
+// GetGroupServiceName gets the name of the Rook-created service of a gateway group of the
+// CephObjectStore.
+func (c *CephObjectStore) GetGroupServiceName(group string) string {
+	return c.GetServiceName() + "-" + group
+}
+
 // GetServiceDomainName gets the domain name of the Rook-created CephObjectStore service.
 // This method helps ensure adherence to stable, documented behavior (API).
 func (c *CephObjectStore) GetServiceDomainName() string {
@@ -127,6 +127,26 @@ func TestValidateObjectStoreSpec(t *testing.T) {
 		assert.ErrorContains(t, err, `"-invalid.dns.name"`)
 		assert.ErrorContains(t, err, `"*.invalid.dns.name"`)
 	})
+	t.Run("gateway groups", func(t *testing.T) {
+		s := &CephObjectStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph"},
+			Spec:       ObjectStoreSpec{Gateway: GatewaySpec{Port: 1}},
+		}
+		s.Spec.Gateway.Groups = []GatewayGroupSpec{{Name: "zone-a"}, {Name: "zone-b"}}
+		assert.NoError(t, ValidateObjectSpec(s))
+
+		// duplicated group
+		s.Spec.Gateway.Groups = []GatewayGroupSpec{{Name: "zone-a"}, {Name: "zone-a"}}
+		assert.ErrorContains(t, ValidateObjectSpec(s), "more than once")
+
+		// the group of the gateways without groups
+		s.Spec.Gateway.Groups = []GatewayGroupSpec{{Name: "a"}}
+		assert.ErrorContains(t, ValidateObjectSpec(s), "reserved")
+
+		// the service name is too long
+		s.Spec.Gateway.Groups = []GatewayGroupSpec{{Name: "a-very-long-zone-name-for-the-gateways-of-the-store"}}
+		assert.ErrorContains(t, ValidateObjectSpec(s), "DNS-1035")
+	})
 }
 
 func TestIsTLSEnabled(t *testing.T) {
@@ -40,6 +40,21 @@ func ValidateCephBlockPool(p *CephBlockPool) error {
 		}
 	}
 
+	if schedule := p.Spec.ImageSnapshotSchedule; schedule != nil {
+		if schedule.Interval == nil || schedule.Interval.Duration <= 0 {
+			return errors.Errorf("invalid CephBlockPool spec: the image snapshot schedule of pool %q must have a positive interval", p.Name)
+		}
+		if schedule.Retention < 1 {
+			return errors.Errorf("invalid CephBlockPool spec: the image snapshot schedule of pool %q must retain at least one snapshot", p.Name)
+		}
+	}
+
+	if p.Spec.Migration != nil && p.Spec.Migration.Enabled {
+		if !p.Spec.IsReplicated() || p.Spec.IsHybridStoragePool() || p.Spec.Replicated.ReplicasPerFailureDomain > 1 {
+			return errors.Errorf("invalid CephBlockPool spec: the data of pool %q can only be migrated if it is a replicated pool with a single replica per failure domain and without hybrid storage", p.Name)
+		}
+	}
+
 	return validatePoolSpec(p.ToNamedPoolSpec())
 }
 
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +46,47 @@ func TestValidatePoolSpec(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestValidateCephBlockPoolImageSnapshotSchedule(t *testing.T) {
+	p := &CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "vms"},
+		Spec: NamedBlockPoolSpec{
+			PoolSpec: PoolSpec{Replicated: ReplicatedSpec{Size: 3}},
+			ImageSnapshotSchedule: &ImageSnapshotScheduleSpec{
+				Interval:  &metav1.Duration{Duration: time.Hour},
+				Retention: 3,
+			},
+		},
+	}
+	assert.NoError(t, ValidateCephBlockPool(p))
+
+	p.Spec.ImageSnapshotSchedule.Retention = 0
+	assert.Error(t, ValidateCephBlockPool(p))
+
+	p.Spec.ImageSnapshotSchedule.Retention = 3
+	p.Spec.ImageSnapshotSchedule.Interval = nil
+	assert.Error(t, ValidateCephBlockPool(p))
+}
+
+func TestValidateCephBlockPoolMigration(t *testing.T) {
+	p := &CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicapool"},
+		Spec: NamedBlockPoolSpec{
+			PoolSpec:  PoolSpec{Replicated: ReplicatedSpec{Size: 3}},
+			Migration: &PoolMigrationSpec{Enabled: true},
+		},
+	}
+	assert.NoError(t, ValidateCephBlockPool(p))
+
+	p.Spec.Replicated.ReplicasPerFailureDomain = 2
+	assert.Error(t, ValidateCephBlockPool(p))
+
+	p.Spec.PoolSpec = PoolSpec{ErasureCoded: ErasureCodedSpec{DataChunks: 2, CodingChunks: 1}}
+	assert.Error(t, ValidateCephBlockPool(p))
+
+	p.Spec.Migration.Enabled = false
+	assert.NoError(t, ValidateCephBlockPool(p))
+}
+
 func TestMirroringSpec_SnapshotSchedulesEnabled(t *testing.T) {
 	type fields struct {
 		Enabled           bool
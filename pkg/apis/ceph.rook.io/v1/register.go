@@ -57,6 +57,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CephClient{},
 		&CephClientList{},
+		&CephClientTemplate{},
+		&CephClientTemplateList{},
 		&CephCluster{},
 		&CephClusterList{},
 		&CephBlockPool{},
@@ -89,6 +91,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CephBlockPoolRadosNamespaceList{},
 		&CephCOSIDriver{},
 		&CephCOSIDriverList{},
+		&CephDeviceInventory{},
+		&CephDeviceInventoryList{},
+		&CephOSDRemoval{},
+		&CephOSDRemovalList{},
+		&CephClientBlocklist{},
+		&CephClientBlocklistList{},
+		&RookClusterProfile{},
+		&RookClusterProfileList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	scheme.AddKnownTypes(bktv1alpha1.SchemeGroupVersion,
@@ -15,7 +15,11 @@ limitations under the License.
 */
 package v1
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 type StoreType string
 
@@ -105,6 +109,9 @@ func (s *StorageScopeSpec) resolveNodeSelection(node *Node) {
 
 	resolveString(&(node.Selection.DeviceFilter), s.Selection.DeviceFilter, "")
 	resolveString(&(node.Selection.DevicePathFilter), s.Selection.DevicePathFilter, "")
+	if node.Selection.DeviceIdentityFilter == nil {
+		node.Selection.DeviceIdentityFilter = s.Selection.DeviceIdentityFilter
+	}
 
 	if len(node.Selection.Devices) == 0 {
 		node.Selection.Devices = s.Devices
@@ -113,6 +120,10 @@ func (s *StorageScopeSpec) resolveNodeSelection(node *Node) {
 	if len(node.Selection.VolumeClaimTemplates) == 0 {
 		node.Selection.VolumeClaimTemplates = s.VolumeClaimTemplates
 	}
+
+	if node.Selection.Compression == nil {
+		node.Selection.Compression = s.Selection.Compression
+	}
 }
 
 func (s *StorageScopeSpec) resolveNodeConfig(node *Node) {
@@ -196,3 +207,84 @@ func (s *StorageScopeSpec) GetOSDStoreFlag() string {
 	}
 	return fmt.Sprintf("--%s", s.Store.Type)
 }
+
+// GetActivationLimit returns the maximum number of OSDs of the device class activated in parallel
+// on a node, and the device class whose activation slots the OSD waits for. The device class is
+// empty when the OSD shares the slots of the node with the other OSDs. The OSDs are not limited if
+// the maximum is 0.
+func (s *StorageScopeSpec) GetActivationLimit(deviceClass string) (int, string) {
+	if s.ActivationLimit == nil {
+		return 0, ""
+	}
+	if limit, ok := s.ActivationLimit.DeviceClasses[deviceClass]; ok && deviceClass != "" {
+		return limit, deviceClass
+	}
+	return s.ActivationLimit.MaxConcurrent, ""
+}
+
+// Matches returns whether the device with the given identity passes the filter. If it does not,
+// the reason is returned.
+func (f *DeviceIdentityFilter) Matches(wwn, serial, vendor, model string) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+	for _, identity := range f.Deny {
+		if identity.matches(wwn, serial, vendor, model) {
+			return false, fmt.Sprintf("the device matches the denied identity %+v", identity)
+		}
+	}
+	if len(f.Allow) == 0 {
+		return true, ""
+	}
+	for _, identity := range f.Allow {
+		if identity.matches(wwn, serial, vendor, model) {
+			return true, ""
+		}
+	}
+	return false, "the device does not match any allowed identity"
+}
+
+func (i *DeviceIdentity) matches(wwn, serial, vendor, model string) bool {
+	if i.WWN == "" && i.Serial == "" && i.Vendor == "" && i.Model == "" {
+		// an empty identity does not select any device
+		return false
+	}
+	if i.WWN != "" && normalizeWWN(i.WWN) != normalizeWWN(wwn) {
+		return false
+	}
+	if i.Serial != "" && !strings.EqualFold(i.Serial, strings.TrimSpace(serial)) {
+		return false
+	}
+	if i.Vendor != "" && !matchesRegex(i.Vendor, vendor) {
+		return false
+	}
+	if i.Model != "" && !matchesRegex(i.Model, model) {
+		return false
+	}
+	return true
+}
+
+// normalizeWWN ignores the case and the optional "0x" prefix of the world wide names
+func normalizeWWN(wwn string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(wwn)), "0x")
+}
+
+func matchesRegex(expr, value string) bool {
+	matched, err := regexp.MatchString(expr, strings.TrimSpace(value))
+	return err == nil && matched
+}
+
+// Validate returns an error if a regular expression of the filter is invalid
+func (f *DeviceIdentityFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, identity := range append(append([]DeviceIdentity{}, f.Allow...), f.Deny...) {
+		for _, expr := range []string{identity.Vendor, identity.Model} {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid device identity regular expression %q. %v", expr, err)
+			}
+		}
+	}
+	return nil
+}
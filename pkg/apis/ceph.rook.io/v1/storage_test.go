@@ -83,6 +83,7 @@ func TestResolveNodeInherentFromCluster(t *testing.T) {
 			DeviceFilter:     "^sd.",
 			DevicePathFilter: "^/dev/disk/by-path/pci-.*",
 			Devices:          []Device{{Name: "sda"}},
+			Compression:      &OSDCompressionSpec{Mode: "passive"},
 		},
 		Config: map[string]string{
 			"foo": "bar",
@@ -99,6 +100,7 @@ func TestResolveNodeInherentFromCluster(t *testing.T) {
 	assert.False(t, node.Selection.GetUseAllDevices())
 	assert.Equal(t, "bar", node.Config["foo"])
 	assert.Equal(t, []Device{{Name: "sda"}}, node.Devices)
+	assert.Equal(t, &OSDCompressionSpec{Mode: "passive"}, node.Compression)
 }
 
 func TestResolveNodeSpecificProperties(t *testing.T) {
@@ -107,6 +109,7 @@ func TestResolveNodeSpecificProperties(t *testing.T) {
 		Selection: Selection{
 			DeviceFilter:     "^sd.",
 			DevicePathFilter: "^/dev/disk/by-path/pci-.*",
+			Compression:      &OSDCompressionSpec{Mode: "passive"},
 		},
 		Config: map[string]string{
 			"foo": "bar",
@@ -119,6 +122,7 @@ func TestResolveNodeSpecificProperties(t *testing.T) {
 					DeviceFilter:     "nvme.*",
 					DevicePathFilter: "^/dev/disk/by-id/.*foo.*",
 					Devices:          []Device{{Name: "device026"}},
+					Compression:      &OSDCompressionSpec{Mode: "force", Algorithm: "zstd"},
 				},
 				Config: map[string]string{
 					"foo": "node1bar",
@@ -135,6 +139,7 @@ func TestResolveNodeSpecificProperties(t *testing.T) {
 	assert.Equal(t, []Device{{Name: "device026"}}, node.Devices)
 	assert.Equal(t, "node1bar", node.Config["foo"])
 	assert.Equal(t, "biz", node.Config["baz"])
+	assert.Equal(t, &OSDCompressionSpec{Mode: "force", Algorithm: "zstd"}, node.Compression)
 }
 
 func TestResolveNodeUseAllDevices(t *testing.T) {
@@ -289,3 +294,68 @@ func TestIsOnPVCEncrypted(t *testing.T) {
 	}
 	assert.True(t, s.IsOnPVCEncrypted())
 }
+
+func TestDeviceIdentityFilter(t *testing.T) {
+	var filter *DeviceIdentityFilter
+	allowed, _ := filter.Matches("0x5000c500a1b2c3d4", "ABC123", "ATA", "ST4000")
+	assert.True(t, allowed)
+
+	filter = &DeviceIdentityFilter{
+		Allow: []DeviceIdentity{{WWN: "0x5000C500A1B2C3D4"}, {Serial: "xyz789"}, {Vendor: "^SEAGATE$", Model: "^ST8"}},
+		Deny:  []DeviceIdentity{{Model: "(?i)virtual"}},
+	}
+	assert.NoError(t, filter.Validate())
+
+	// wwn without prefix and in a different case
+	allowed, _ = filter.Matches("5000c500a1b2c3d4", "", "", "")
+	assert.True(t, allowed)
+	allowed, _ = filter.Matches("", " XYZ789 ", "", "")
+	assert.True(t, allowed)
+	allowed, _ = filter.Matches("", "", "SEAGATE", "ST8000NM")
+	assert.True(t, allowed)
+	// all the fields of an identity must match
+	allowed, reason := filter.Matches("", "", "SEAGATE", "ST4000NM")
+	assert.False(t, allowed)
+	assert.Contains(t, reason, "does not match any allowed identity")
+	// the denylist takes precedence
+	allowed, reason = filter.Matches("0x5000c500a1b2c3d4", "", "QEMU", "Virtual disk")
+	assert.False(t, allowed)
+	assert.Contains(t, reason, "denied identity")
+
+	// without allowlist, the devices not denied are allowed
+	filter.Allow = nil
+	allowed, _ = filter.Matches("", "", "ATA", "ST4000")
+	assert.True(t, allowed)
+
+	// an empty identity matches nothing
+	filter = &DeviceIdentityFilter{Allow: []DeviceIdentity{{}}}
+	allowed, _ = filter.Matches("0x1", "a", "b", "c")
+	assert.False(t, allowed)
+
+	filter = &DeviceIdentityFilter{Deny: []DeviceIdentity{{Model: "["}}}
+	assert.Error(t, filter.Validate())
+}
+
+func TestGetActivationLimit(t *testing.T) {
+	storage := StorageScopeSpec{}
+	limit, deviceClass := storage.GetActivationLimit("hdd")
+	assert.Equal(t, 0, limit)
+	assert.Equal(t, "", deviceClass)
+
+	storage.ActivationLimit = &OSDActivationLimitSpec{MaxConcurrent: 4, DeviceClasses: map[string]int{"hdd": 2, "nvme": 0}}
+	// the device class with its own limit has its own slots
+	limit, deviceClass = storage.GetActivationLimit("hdd")
+	assert.Equal(t, 2, limit)
+	assert.Equal(t, "hdd", deviceClass)
+	// the device class can be excluded from the limit
+	limit, deviceClass = storage.GetActivationLimit("nvme")
+	assert.Equal(t, 0, limit)
+	assert.Equal(t, "nvme", deviceClass)
+	// the other OSDs share the slots of the node
+	limit, deviceClass = storage.GetActivationLimit("ssd")
+	assert.Equal(t, 4, limit)
+	assert.Equal(t, "", deviceClass)
+	limit, deviceClass = storage.GetActivationLimit("")
+	assert.Equal(t, 4, limit)
+	assert.Equal(t, "", deviceClass)
+}
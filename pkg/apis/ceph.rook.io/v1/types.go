@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,6 +46,7 @@ import (
 // +kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.ceph.health`,description="Ceph Health"
 // +kubebuilder:printcolumn:name="External",type=boolean,JSONPath=`.spec.external.enable`
 // +kubebuilder:printcolumn:name="FSID",type=string,JSONPath=`.status.ceph.fsid`,description="Ceph FSID"
+// +kubebuilder:printcolumn:name="Progress",type=string,JSONPath=`.status.ceph.progress`,description="Ceph progress events"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=ceph
 type CephCluster struct {
@@ -69,6 +71,129 @@ type CephClusterHealthCheckSpec struct {
 	// StartupProbe allows changing the startupProbe configuration for a given daemon
 	// +optional
 	StartupProbe map[KeyType]*ProbeSpec `json:"startupProbe,omitempty"`
+	// OSDMemoryPressure lowers the memory target of the OSDs under memory pressure
+	// +optional
+	// +nullable
+	OSDMemoryPressure *OSDMemoryPressureSpec `json:"osdMemoryPressure,omitempty"`
+	// OSDReplacement replaces the OSDs on the devices predicted to fail
+	// +optional
+	// +nullable
+	OSDReplacement *OSDReplacementSpec `json:"osdReplacement,omitempty"`
+	// OSDFlapping detects the OSDs repeatedly marked down and up again
+	// +optional
+	// +nullable
+	OSDFlapping *OSDFlappingSpec `json:"osdFlapping,omitempty"`
+	// PlacementDrift checks whether the running daemons still satisfy their placement
+	// +optional
+	// +nullable
+	PlacementDrift *PlacementDriftSpec `json:"placementDrift,omitempty"`
+	// RecoveryDeferral defers the optional maintenance actions of the operator while the cluster is
+	// recovering or backfilling
+	// +optional
+	// +nullable
+	RecoveryDeferral *RecoveryDeferralSpec `json:"recoveryDeferral,omitempty"`
+}
+
+// OSDMemoryPressureSpec lowers the memory target of the OSDs whose pods are under memory
+// pressure, before the kubelet kills them for exceeding their memory limit. The memory pressure
+// is reported by the kubelet for the pods on the cgroup v2 nodes.
+type OSDMemoryPressureSpec struct {
+	// Enabled lowers the memory target of the OSDs under memory pressure
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is the percentage of the last 10 seconds the OSD pod was stalled waiting for
+	// memory above which its memory target is lowered. The memory target is restored once the
+	// pressure is below half the threshold. The default is 20.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// TargetReduction is the percentage the memory target is lowered by. The default is 25.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=90
+	// +optional
+	TargetReduction int `json:"targetReduction,omitempty"`
+}
+
+// OSDReplacementSpec replaces the OSDs on the devices predicted to fail by the device health
+// metrics collected by the devicehealth mgr module. One OSD at a time is marked out, purged once
+// its data is moved to the other OSDs, and replaced by a new OSD on a spare device of the node
+// matching the storage settings.
+type OSDReplacementSpec struct {
+	// Enabled replaces the OSDs on the devices predicted to fail
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// LifeExpectancyThreshold replaces the OSDs on the devices predicted to fail within this
+	// duration. The default is 4 weeks.
+	// +optional
+	LifeExpectancyThreshold *metav1.Duration `json:"lifeExpectancyThreshold,omitempty"`
+}
+
+// OSDFlappingSpec detects the OSDs that are repeatedly marked down and up again, for example
+// because of a faulty device or network link. Every time a flapping OSD is marked down and up, its
+// placement groups peer again and the client I/O stalls.
+type OSDFlappingSpec struct {
+	// Enabled detects the flapping OSDs and records an OSDFlapping warning event on the CephCluster
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is the number of times an OSD is marked up again within the period above which the
+	// OSD is flapping. The default is 5.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// Period is the sliding window the times an OSD is marked up again are counted in. The default
+	// is 10m.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// AutoOut marks the flapping OSDs out so that their data is moved to the other OSDs, one OSD per
+	// health check
+	// +optional
+	AutoOut bool `json:"autoOut,omitempty"`
+	// Replace replaces the flapping OSDs on the nodes marked out with AutoOut, like the OSDs on the
+	// devices predicted to fail: the OSD is purged once it is safe to destroy and a new OSD is
+	// provisioned on a spare device of the node
+	// +optional
+	Replace bool `json:"replace,omitempty"`
+}
+
+// PlacementDriftSpec checks whether the nodes the daemons are running on still satisfy the node
+// selector, the node affinity and the tolerations of the daemons. The placement is only checked
+// by Kubernetes when the daemons are scheduled, the nodes may be relabeled or tainted since.
+type PlacementDriftSpec struct {
+	// Enabled checks the placement of the daemons periodically and reports the daemons whose node
+	// no longer satisfies their placement in the PlacementDrift condition of the CephCluster
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is the interval the placement of the daemons is checked at. The default is 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// AutoRemediate moves the daemons whose node no longer satisfies their placement, one daemon
+	// per check. The mons are failed over and the pods of the other daemons not bound to their
+	// node are deleted to be rescheduled. The OSDs and the daemons bound to their node are only
+	// reported.
+	// +optional
+	AutoRemediate bool `json:"autoRemediate,omitempty"`
+}
+
+// RecoveryDeferralSpec defers the optional maintenance actions of the operator while the recovery
+// or backfill load of the cluster exceeds the thresholds: the OSD restarts to apply config changes,
+// the mon failbacks, the migrations of the mons to PVCs and the compactions of the mon stores. The
+// OSD restarts of Ceph upgrades are not deferred.
+type RecoveryDeferralSpec struct {
+	// Enabled defers the optional maintenance actions while the recovery load exceeds the thresholds
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxRecoveringPGsPercent is the percentage of the PGs recovering or backfilling above which the
+	// actions are deferred. The default is 0, the actions are deferred while any PG is recovering or
+	// backfilling.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxRecoveringPGsPercent int `json:"maxRecoveringPGsPercent,omitempty"`
+	// MaxRecoveryBytesPerSecond is the recovery throughput above which the actions are deferred. The
+	// throughput is not checked if not set.
+	// +optional
+	MaxRecoveryBytesPerSecond *resource.Quantity `json:"maxRecoveryBytesPerSecond,omitempty"`
 }
 
 // DaemonHealthSpec is a daemon health check
@@ -80,7 +205,7 @@ type DaemonHealthSpec struct {
 	// Monitor represents the health check settings for the Ceph monitor
 	// +optional
 	// +nullable
-	Monitor HealthCheckSpec `json:"mon,omitempty"`
+	Monitor MonHealthCheckSpec `json:"mon,omitempty"`
 	// ObjectStorageDaemon represents the health check settings for the Ceph OSDs
 	// +optional
 	// +nullable
@@ -96,8 +221,65 @@ type CephClusterList struct {
 	Items           []CephCluster `json:"items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RookClusterProfile is a set of defaults shared by the CephClusters referencing it
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:scope=Cluster,shortName=rookprofile
+type RookClusterProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the defaults of the CephClusters referencing the profile
+	Spec RookClusterProfileSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RookClusterProfileList is a list of RookClusterProfile
+type RookClusterProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []RookClusterProfile `json:"items"`
+}
+
+// RookClusterProfileSpec represents the defaults of the CephClusters referencing a profile. The
+// settings of a CephCluster override the settings of its profile.
+type RookClusterProfileSpec struct {
+	// CephVersion is the default Ceph image of the clusters
+	// +optional
+	// +nullable
+	CephVersion *CephVersionSpec `json:"cephVersion,omitempty"`
+	// Resources are the default resources of the daemons of the clusters
+	// +optional
+	// +nullable
+	Resources ResourceSpec `json:"resources,omitempty"`
+	// Placement is the default placement of the daemons of the clusters
+	// +optional
+	// +nullable
+	Placement PlacementSpec `json:"placement,omitempty"`
+	// Monitoring are the default monitoring settings of the clusters
+	// +optional
+	// +nullable
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+}
+
+// ClusterProfileReference references a RookClusterProfile
+type ClusterProfileReference struct {
+	// Name is the name of the RookClusterProfile
+	Name string `json:"name"`
+}
+
 // ClusterSpec represents the specification of Ceph Cluster
 type ClusterSpec struct {
+	// ProfileRef is a reference to a RookClusterProfile whose defaults are inherited by the cluster.
+	// The settings of the cluster override the settings of the profile.
+	// +optional
+	// +nullable
+	ProfileRef *ClusterProfileReference `json:"profileRef,omitempty"`
+
 	// The version information that instructs Rook to orchestrate a particular version of Ceph.
 	// +optional
 	// +nullable
@@ -171,6 +353,14 @@ type ClusterSpec struct {
 	// +optional
 	UpgradeOSDRequiresHealthyPGs bool `json:"upgradeOSDRequiresHealthyPGs,omitempty"`
 
+	// DaemonRestartBudget is the maximum number of pods of each daemon type (for example the mons or the OSDs)
+	// that the operator restarts within an hour. The restarts over the budget are delayed until the budget allows them
+	// and the CephCluster reports the RestartBudgetExceeded reason meanwhile. The upgrades and the restarts recovering
+	// the daemons are not limited. If zero, the restarts are not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DaemonRestartBudget int `json:"daemonRestartBudget,omitempty"`
+
 	// A spec for configuring disruption management.
 	// +nullable
 	// +optional
@@ -245,6 +435,52 @@ type ClusterSpec struct {
 	// +optional
 	// +nullable
 	CephConfigFromSecret map[string]map[string]v1.SecretKeySelector `json:"cephConfigFromSecret,omitempty"`
+
+	// CephConfigRollout applies the changes of the osd section of CephConfig to a single canary OSD
+	// first, and to all the OSDs only after the canary stayed healthy for the soak time
+	// +optional
+	// +nullable
+	CephConfigRollout *CephConfigRolloutSpec `json:"cephConfigRollout,omitempty"`
+
+	// DebugLogging raises the debug levels of the ceph daemons and the log level of the operator
+	// for a bounded duration, after which the previous levels are restored
+	// +optional
+	// +nullable
+	DebugLogging *DebugLoggingSpec `json:"debugLogging,omitempty"`
+}
+
+// CephConfigRolloutSpec rolls out the changes of the osd section of CephConfig through a canary OSD.
+// The changes are rolled back on the canary if it goes down, the cluster health is HEALTH_ERR, or the
+// commit latency of the canary regresses during the soak time.
+type CephConfigRolloutSpec struct {
+	// Enabled applies the changes of the osd section of CephConfig to a canary OSD first
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// SoakTime is how long the canary OSD must stay healthy before the changes are applied to all
+	// the OSDs. The default is 10m.
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+	// MaxLatencyRatio is how many times the median commit latency of the other OSDs the commit
+	// latency of the canary OSD may reach before the changes are rolled back. The default is 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxLatencyRatio int `json:"maxLatencyRatio,omitempty"`
+}
+
+// DebugLoggingSpec raises the debug levels for a support session. The levels are set in the ceph
+// config when the settings are applied, and the previous levels are restored after the duration.
+type DebugLoggingSpec struct {
+	// Duration is how long the debug levels are raised after the settings are applied. Changing
+	// the settings raises the levels again for the duration.
+	// +kubebuilder:validation:Type=string
+	Duration metav1.Duration `json:"duration"`
+	// Daemons are the debug levels by daemon type or daemon, for example "osd" or "osd.3", then by
+	// debug subsystem, for example "osd": "20/20" to set debug_osd
+	// +optional
+	Daemons map[string]map[string]string `json:"daemons,omitempty"`
+	// Operator raises the log level of the operator to DEBUG
+	// +optional
+	Operator bool `json:"operator,omitempty"`
 }
 
 // CSIDriverSpec defines CSI Driver settings applied per cluster.
@@ -259,6 +495,16 @@ type CSIDriverSpec struct {
 	// If set to true, the user must manually manage these secrets.
 	// +optional
 	SkipUserCreation bool `json:"skipUserCreation,omitempty"`
+	// ProvisionerPlacement is the placement of the CSI provisioner pods. When set, it replaces the
+	// provisioner tolerations and node affinity of the operator settings.
+	// +optional
+	// +nullable
+	ProvisionerPlacement *Placement `json:"provisionerPlacement,omitempty"`
+	// PluginPlacement is the placement of the CSI node plugin pods. When set, it replaces the
+	// plugin tolerations and node affinity of the operator settings.
+	// +optional
+	// +nullable
+	PluginPlacement *Placement `json:"pluginPlacement,omitempty"`
 }
 
 // CSICephFSSpec defines the settings for CephFS CSI driver.
@@ -315,7 +561,9 @@ type ClusterSecuritySpec struct {
 	// +optional
 	// +nullable
 	KeyManagementService KeyManagementServiceSpec `json:"kms,omitempty"`
-	// KeyRotation defines options for rotation of OSD disk encryption keys.
+	// KeyRotation defines options for rotation of OSD disk encryption keys. The keys of the
+	// encrypted OSDs on PVCs are rotated in the KMS, and the keys of the encrypted OSDs on nodes are
+	// rotated in the mon config-key store.
 	// +optional
 	// +nullable
 	KeyRotation KeyRotationSpec `json:"keyRotation,omitempty"`
@@ -329,6 +577,20 @@ type ClusterCephxConfig struct {
 	// Daemon configures CephX key settings for local Ceph daemons managed by Rook and part of the
 	// Ceph cluster. Daemon CephX keys can be rotated without affecting client connections.
 	Daemon CephxConfig `json:"daemon,omitempty"`
+	// Mon configures the rotation of the `mon.` key shared by all the mons. When the key is rotated,
+	// the mons are restarted one at a time while keeping quorum.
+	// +optional
+	Mon MonCephxConfig `json:"mon,omitempty"`
+}
+
+// MonCephxConfig configures the rotation of the `mon.` key shared by all the mons
+type MonCephxConfig struct {
+	CephxConfig `json:",inline"`
+
+	// RotationInterval rotates the mon key when it was last rotated longer ago than the interval,
+	// for example "2160h" to rotate it every 90 days. The interval starts when it is first set.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
 }
 
 type CephxConfig struct {
@@ -433,8 +695,55 @@ type DashboardSpec struct {
 	// Whether to verify the ssl endpoint for prometheus. Set to false for a self-signed cert.
 	// +optional
 	PrometheusEndpointSSLVerify bool `json:"prometheusEndpointSSLVerify,omitempty"`
+	// Users are the dashboard users managed by the operator in addition to the admin user. The users
+	// removed from the list are deleted from the dashboard.
+	// +kubebuilder:validation:XValidation:message="the admin user is managed by the operator",rule="self.all(u, u.name != 'admin')"
+	// +optional
+	Users []DashboardUserSpec `json:"users,omitempty"`
+	// Roles are the custom dashboard roles managed by the operator. The roles removed from the list
+	// are deleted from the dashboard.
+	// +optional
+	Roles []DashboardRoleSpec `json:"roles,omitempty"`
+}
+
+// DashboardUserSpec represents a dashboard user managed by the operator
+type DashboardUserSpec struct {
+	// Name is the name of the user
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._@-]+$`
+	Name string `json:"name"`
+	// Role is the role of the user, either a built-in role of the dashboard such as "read-only" or
+	// "block-manager", or a custom role of the dashboard spec
+	Role string `json:"role"`
+	// PasswordSecret is the key of the secret holding the password of the user
+	PasswordSecret v1.SecretKeySelector `json:"passwordSecret"`
+}
+
+// DashboardRoleSpec represents a custom dashboard role managed by the operator
+type DashboardRoleSpec struct {
+	// Name is the name of the role
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	Name string `json:"name"`
+	// Description is the description of the role, set when the role is created
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Scopes are the permissions of the role on the dashboard scopes
+	// +kubebuilder:validation:MinItems=1
+	Scopes []DashboardRoleScope `json:"scopes"`
+}
+
+// DashboardRoleScope represents the permissions of a dashboard role on a scope
+type DashboardRoleScope struct {
+	// Scope is the dashboard scope, for example "pool", "rbd-image" or "cephfs"
+	Scope string `json:"scope"`
+	// Permissions are the permissions on the scope
+	// +kubebuilder:validation:MinItems=1
+	Permissions []DashboardPermission `json:"permissions"`
 }
 
+// DashboardPermission is a permission of a dashboard role on a scope
+// +kubebuilder:validation:Enum=read;create;update;delete
+type DashboardPermission string
+
 // MonitoringSpec represents the settings for Prometheus based Ceph monitoring
 type MonitoringSpec struct {
 	// Enabled determines whether to create the prometheus rules for the ceph cluster. If true, the prometheus
@@ -471,6 +780,40 @@ type MonitoringSpec struct {
 	// Ceph exporter configuration
 	// +optional
 	Exporter *CephExporterSpec `json:"exporter,omitempty"`
+
+	// TLS serves the metrics of the prometheus mgr module and Ceph exporter over TLS, only to the
+	// clients authenticated with a bearer token or a client certificate
+	// +optional
+	TLS *MetricsTLSSpec `json:"tls,omitempty"`
+}
+
+// MetricsTLSSpec represents the TLS and authentication settings of the metrics endpoints. The
+// metrics are served by a kube-rbac-proxy sidecar of the mgr and Ceph exporter pods, which
+// authorizes the clients that may get the /metrics non-resource URL.
+type MetricsTLSSpec struct {
+	// Enabled serves the metrics over TLS
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SecretName is the name of the kubernetes.io/tls secret with the certificate of the metrics
+	// endpoints. The CA certificate of the secret (ca.crt), if any, is trusted by the ServiceMonitors.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ClientCASecretName is the name of the secret with the CA certificate (ca.crt) that verifies the
+	// client certificates. If not set, the clients can only authenticate with a bearer token.
+	// +optional
+	ClientCASecretName string `json:"clientCASecretName,omitempty"`
+
+	// ClientCertSecretName is the name of the kubernetes.io/tls secret with the client certificate
+	// that Prometheus presents to the metrics endpoints. If not set, the ServiceMonitors authenticate
+	// with the service account token of Prometheus.
+	// +optional
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+
+	// ProxyImage is the image of the kube-rbac-proxy sidecar serving the metrics
+	// +optional
+	ProxyImage string `json:"proxyImage,omitempty"`
 }
 
 type CephExporterSpec struct {
@@ -501,6 +844,424 @@ type ClusterStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MonFailoverPreview is where the replacement of a mon would be placed, as requested by the
+	// "ceph.rook.io/preview-mon-failover" annotation
+	// +optional
+	// +nullable
+	MonFailoverPreview *MonFailoverPreviewStatus `json:"monFailoverPreview,omitempty"`
+	// SupportBundle is the last support bundle generated, as requested by the
+	// "ceph.rook.io/support-bundle" annotation
+	// +optional
+	// +nullable
+	SupportBundle *SupportBundleStatus `json:"supportBundle,omitempty"`
+	// MonFailoverBackoff is the state of the rate limit of the mon failovers
+	// +optional
+	// +nullable
+	MonFailoverBackoff *MonFailoverBackoffStatus `json:"monFailoverBackoff,omitempty"`
+	// MonVolumeExpansion is the progress of the expansion of the mon PVCs whose capacity is smaller
+	// than the storage request of the mon volume claim template
+	// +optional
+	MonVolumeExpansion []MonVolumeExpansionStatus `json:"monVolumeExpansion,omitempty"`
+	// MonPVCMigration is the progress of the migration of the mons from the host path to PVCs
+	// +optional
+	// +nullable
+	MonPVCMigration *MonPVCMigrationStatus `json:"monPVCMigration,omitempty"`
+	// OSDPVCMigration is the progress of the migration of the OSDs from the nodes to PVCs
+	// +optional
+	// +nullable
+	OSDPVCMigration *OSDPVCMigrationStatus `json:"osdPVCMigration,omitempty"`
+	// StorageChanges is the report of the OSDs created and orphaned by the last change of the
+	// selection of the nodes and devices of the storage spec
+	// +optional
+	// +nullable
+	StorageChanges *StorageChangesStatus `json:"storageChanges,omitempty"`
+	// OSDPrepareFailures are the devices whose OSD prepare jobs failed, with their last error
+	// +optional
+	OSDPrepareFailures []OSDPrepareFailureStatus `json:"osdPrepareFailures,omitempty"`
+	// MonClockSkew are the mons whose clock is skewed, as reported by the MON_CLOCK_SKEW health
+	// warning
+	// +optional
+	MonClockSkew []MonClockSkewStatus `json:"monClockSkew,omitempty"`
+	// Mgr is the active mgr and the mgr failovers, as watched by the operator
+	// +optional
+	// +nullable
+	Mgr *MgrStatus `json:"mgr,omitempty"`
+	// MonDiskUsage are the mons whose disk is low on space, as reported by the MON_DISK_LOW and
+	// MON_DISK_CRIT health warnings
+	// +optional
+	MonDiskUsage []MonDiskUsageStatus `json:"monDiskUsage,omitempty"`
+	// Mons are the rank, address, quorum and placement of each mon, as seen by the last mon health
+	// check
+	// +optional
+	Mons []MonStatus `json:"mons,omitempty"`
+	// EndpointChecks are the results of the checks of the endpoints declared in the spec: the
+	// external mons and the KMS address
+	// +optional
+	EndpointChecks []EndpointCheckStatus `json:"endpointChecks,omitempty"`
+	// Creation is the progress of the creation of the cluster by phase, for an interrupted creation
+	// to be resumed from the last completed phase
+	// +optional
+	// +nullable
+	Creation *ClusterCreationStatus `json:"creation,omitempty"`
+	// MonZones is the readiness of the zones of the mons declared in the spec, as seen by the last
+	// mon health check: the nodes with the label of each zone and the nodes schedulable for its mons
+	// +optional
+	MonZones []MonZoneStatus `json:"monZones,omitempty"`
+	// DeferredMaintenance are the optional maintenance actions of the operator deferred while the
+	// recovery load of the cluster exceeds the thresholds of the recovery deferral
+	// +optional
+	DeferredMaintenance []DeferredMaintenanceStatus `json:"deferredMaintenance,omitempty"`
+	// Dashboard is the dashboard users and roles of the spec managed by the operator
+	// +optional
+	// +nullable
+	Dashboard *DashboardStatus `json:"dashboard,omitempty"`
+}
+
+// DashboardStatus represents the dashboard users and roles managed by the operator
+type DashboardStatus struct {
+	// Users are the dashboard users managed by the operator
+	// +optional
+	Users []DashboardUserStatus `json:"users,omitempty"`
+	// Roles are the names of the custom dashboard roles managed by the operator
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+}
+
+// DashboardUserStatus represents a dashboard user managed by the operator
+type DashboardUserStatus struct {
+	// Name is the name of the user
+	Name string `json:"name"`
+	// Role is the role of the user
+	// +optional
+	Role string `json:"role,omitempty"`
+	// Message is the error of the last configuration of the user
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DeferredMaintenanceStatus represents an optional maintenance action of the operator deferred
+// while the cluster is recovering
+type DeferredMaintenanceStatus struct {
+	// Action is the deferred action, for example "OSDUpdate" or "MonStoreCompaction"
+	Action string `json:"action"`
+	// Message is the recovery load that defers the action
+	Message string `json:"message"`
+	// Since is when the action was first deferred
+	Since metav1.Time `json:"since"`
+}
+
+// ClusterCreationPhase is a phase of the creation of the cluster
+type ClusterCreationPhase string
+
+const (
+	// ClusterCreationPhaseMons is the creation of the mons
+	ClusterCreationPhaseMons ClusterCreationPhase = "Mons"
+	// ClusterCreationPhaseMgr is the creation of the mgrs
+	ClusterCreationPhaseMgr ClusterCreationPhase = "Mgr"
+	// ClusterCreationPhaseOSDs is the creation of the OSDs
+	ClusterCreationPhaseOSDs ClusterCreationPhase = "OSDs"
+	// ClusterCreationPhaseAuxDaemons is the configuration of the stretch arbiter and the adoption of
+	// the pools
+	ClusterCreationPhaseAuxDaemons ClusterCreationPhase = "AuxDaemons"
+	// ClusterCreationPhaseCSI is the configuration of the CSI driver
+	ClusterCreationPhaseCSI ClusterCreationPhase = "CSI"
+)
+
+// ClusterCreationStatus represents the progress of the creation of the cluster
+type ClusterCreationStatus struct {
+	// Checkpoints are the completed phases of the creation, in order
+	// +optional
+	Checkpoints []ClusterCreationCheckpoint `json:"checkpoints,omitempty"`
+	// CompletionTime is when all the phases of the creation were completed. The phases are not
+	// skipped anymore once the creation is completed.
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ClusterCreationCheckpoint represents a completed phase of the creation of the cluster
+type ClusterCreationCheckpoint struct {
+	// Phase is the completed phase
+	Phase ClusterCreationPhase `json:"phase"`
+	// ObservedGeneration is the generation of the cluster the phase was completed for. The phase is
+	// run again if the generation changed.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// CephVersion is the ceph version the phase was completed with. The phase is run again if the
+	// version changed.
+	// +optional
+	CephVersion string `json:"cephVersion,omitempty"`
+	// CompletionTime is when the phase was completed
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// MonStatus represents a mon of the cluster
+type MonStatus struct {
+	// Name is the name of the mon
+	Name string `json:"name"`
+	// Rank is the rank of the mon in the monmap, or -1 if the mon is not in the monmap yet
+	Rank int `json:"rank"`
+	// Address is the endpoint of the mon
+	// +optional
+	Address string `json:"address,omitempty"`
+	// InQuorum is whether the mon is in quorum
+	InQuorum bool `json:"inQuorum"`
+	// Node is the node the mon is pinned to, empty when the mon is placed by the scheduler
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Zone is the zone of the mon
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// LastFailoverTime is when the mon was created to replace a failed over mon
+	// +optional
+	// +nullable
+	LastFailoverTime *metav1.Time `json:"lastFailoverTime,omitempty"`
+}
+
+// MgrStatus represents the active mgr, the modules it serves and the mgr failovers
+type MgrStatus struct {
+	// ActiveMgr is the name of the active mgr
+	// +optional
+	ActiveMgr string `json:"activeMgr,omitempty"`
+	// Standbys are the names of the standby mgrs
+	// +optional
+	Standbys []string `json:"standbys,omitempty"`
+	// Services are the URLs of the services of the mgr modules served by the active mgr, by module
+	// +optional
+	Services map[string]string `json:"services,omitempty"`
+	// FailoverCount is the number of the changes of the active mgr observed by the operator
+	// +optional
+	FailoverCount int `json:"failoverCount,omitempty"`
+	// LastFailoverTime is the time of the last change of the active mgr
+	// +optional
+	// +nullable
+	LastFailoverTime *metav1.Time `json:"lastFailoverTime,omitempty"`
+}
+
+// MonClockSkewStatus represents a mon whose clock is skewed
+type MonClockSkewStatus struct {
+	// Name is the name of the mon
+	Name string `json:"name"`
+	// Skew is the skew of the clock of the mon reported by ceph, for example "0.5s"
+	Skew string `json:"skew"`
+	// Since is when the skew of the clock of the mon was first detected
+	Since metav1.Time `json:"since"`
+}
+
+// MonZoneStatus represents the readiness of a zone of the mons
+type MonZoneStatus struct {
+	// Name is the name of the zone
+	Name string `json:"name"`
+	// Nodes is the number of nodes with the failure domain label of the zone
+	Nodes int `json:"nodes"`
+	// SchedulableNodes is the number of nodes of the zone that are ready, not cordoned, and match
+	// the placement of the mons of the zone
+	SchedulableNodes int `json:"schedulableNodes"`
+	// RequiredNodes is the number of schedulable nodes needed to run the mons of the zone
+	RequiredNodes int `json:"requiredNodes"`
+	// Ready is whether the zone has the schedulable nodes needed to run its mons
+	Ready bool `json:"ready"`
+	// Message explains why the zone is not ready
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MonDiskUsageStatus represents a mon whose disk is low on space
+type MonDiskUsageStatus struct {
+	// Name is the name of the mon
+	Name string `json:"name"`
+	// AvailablePercent is the percentage of available space on the disk of the mon
+	AvailablePercent int `json:"availablePercent"`
+	// HealthCheck is the health warning reporting the mon, MON_DISK_LOW or MON_DISK_CRIT
+	HealthCheck string `json:"healthCheck"`
+}
+
+// MonPVCMigrationStatus represents the mons left to migrate from the host path to PVCs
+type MonPVCMigrationStatus struct {
+	// HostPathMons are the mons still storing their data on the host path, in the order they are
+	// migrated
+	HostPathMons []string `json:"hostPathMons"`
+	// Message is the mon being migrated, or what the migration of the next mon waits for
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OSDPVCMigrationStatus represents the failure domains of the OSDs left to migrate from the nodes
+// to PVCs
+type OSDPVCMigrationStatus struct {
+	// FailureDomains are the failure domains with OSDs on the nodes, in the order they are migrated
+	FailureDomains []string `json:"failureDomains"`
+	// Message is the failure domain being migrated, or what the migration of the next failure
+	// domain waits for
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// StorageChangesStatus represents the OSDs that a change of the selection of the nodes and devices
+// of the storage spec creates or orphans. A change orphaning OSDs is not applied until it is
+// confirmed with the ID of the report.
+type StorageChangesStatus struct {
+	// ID identifies the storage selection of the report
+	ID string `json:"id"`
+	// Phase is PendingConfirmation while the change orphaning OSDs waits for its confirmation, or
+	// Applied once the change is applied
+	Phase string `json:"phase"`
+	// Created are the nodes where OSDs would be created, with the devices newly selected on them
+	// +optional
+	Created []StorageChangeNodeStatus `json:"created,omitempty"`
+	// Orphaned are the existing OSDs on nodes or devices that are no longer selected. The OSDs keep
+	// running but are no longer managed by the storage spec.
+	// +optional
+	Orphaned []OrphanedOSDStatus `json:"orphaned,omitempty"`
+	// ReportTime is when the change was reported
+	// +optional
+	ReportTime metav1.Time `json:"reportTime,omitempty"`
+}
+
+// StorageChangeNodeStatus represents a node where a change of the storage selection creates OSDs
+type StorageChangeNodeStatus struct {
+	Node string `json:"node"`
+	// Devices are the devices newly selected by name on the node
+	// +optional
+	Devices []string `json:"devices,omitempty"`
+	// Selection is the device filter newly selecting the devices of the node, whose devices are
+	// only known once the OSD prepare job ran
+	// +optional
+	Selection string `json:"selection,omitempty"`
+}
+
+// OSDPrepareFailureStatus represents a device whose OSD prepare jobs failed
+type OSDPrepareFailureStatus struct {
+	// Node is the node or PVC the OSD prepare job ran for
+	Node string `json:"node"`
+	// Device is the device the OSD prepare job failed to provision, empty if the job failed before
+	// provisioning the devices of the node
+	// +optional
+	Device string `json:"device,omitempty"`
+	// Failures is the number of consecutive failures of the OSD prepare job
+	Failures int `json:"failures"`
+	// LastError is the error of the last failure
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastFailureTime is the time of the last failure
+	// +optional
+	LastFailureTime metav1.Time `json:"lastFailureTime,omitempty"`
+	// NextRetryTime is the time the OSD prepare job is retried after
+	// +optional
+	// +nullable
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// Quarantined is whether the device is no longer prepared after failing too many times, until
+	// it is released
+	// +optional
+	Quarantined bool `json:"quarantined,omitempty"`
+}
+
+// OrphanedOSDStatus represents an existing OSD that a change of the storage selection no longer
+// selects
+type OrphanedOSDStatus struct {
+	ID   int    `json:"id"`
+	Node string `json:"node"`
+	// +optional
+	Device string `json:"device,omitempty"`
+	// Reason is why the OSD is no longer selected
+	Reason string `json:"reason"`
+}
+
+// MonVolumeExpansionPhase is the progress of the expansion of the PVC of a mon
+type MonVolumeExpansionPhase string
+
+const (
+	// MonVolumeExpansionBlocked means the PVC cannot be expanded, for example because its storage
+	// class does not allow the expansion
+	MonVolumeExpansionBlocked MonVolumeExpansionPhase = "Blocked"
+	// MonVolumeExpansionResizing means the volume of the PVC is being expanded
+	MonVolumeExpansionResizing MonVolumeExpansionPhase = "Resizing"
+	// MonVolumeExpansionFileSystemResizePending means the volume was expanded and its filesystem is
+	// waiting to be resized on the node of the mon
+	MonVolumeExpansionFileSystemResizePending MonVolumeExpansionPhase = "FileSystemResizePending"
+	// MonVolumeExpansionFailed means the expansion of the volume failed
+	MonVolumeExpansionFailed MonVolumeExpansionPhase = "Failed"
+)
+
+// MonVolumeExpansionStatus represents the expansion of the PVC of a mon after the storage request
+// of the mon volume claim template was increased
+type MonVolumeExpansionStatus struct {
+	// Name is the name of the mon
+	Name string `json:"name"`
+	// PVC is the name of the PVC of the mon
+	PVC string `json:"pvc"`
+	// RequestedSize is the storage request of the mon volume claim template
+	RequestedSize string `json:"requestedSize"`
+	// CurrentSize is the capacity of the volume of the PVC
+	// +optional
+	CurrentSize string `json:"currentSize,omitempty"`
+	// Phase is the progress of the expansion
+	Phase MonVolumeExpansionPhase `json:"phase"`
+	// Message is why the expansion is blocked or failed, or what it is waiting for
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MonFailoverBackoffStatus represents the failover budget used in the current window and the
+// mons waiting for their backoff before they can be failed over again
+type MonFailoverBackoffStatus struct {
+	// FailoversInWindow is the number of mon failovers in the current window
+	// +optional
+	FailoversInWindow int `json:"failoversInWindow"`
+	// BudgetResetTime is the time a failover is allowed again when the budget is exhausted
+	// +optional
+	// +nullable
+	BudgetResetTime *metav1.Time `json:"budgetResetTime,omitempty"`
+	// Mons are the mons whose failover is backed off
+	// +optional
+	Mons []MonFailoverBackoff `json:"mons,omitempty"`
+}
+
+// MonFailoverBackoff represents the backoff of the failovers of a mon
+type MonFailoverBackoff struct {
+	// Name is the name of the mon
+	Name string `json:"name"`
+	// Failovers is the number of failovers of the mon since it was last in quorum
+	Failovers int `json:"failovers"`
+	// NextFailoverTime is the earliest time the mon can be failed over again
+	NextFailoverTime metav1.Time `json:"nextFailoverTime"`
+}
+
+// MonFailoverPreviewStatus is the planned placement of the replacement of a mon, found by scheduling
+// a canary of the replacement mon without failing over the mon
+type MonFailoverPreviewStatus struct {
+	// Mon is the name of the mon the failover was previewed for
+	Mon string `json:"mon"`
+	// Replacement is the name the replacement mon would get
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+	// Node is the node the replacement mon would be scheduled on
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Zone is the zone the replacement mon would be placed in, if the mons are spread across zones
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Message is the reason the failover could not be previewed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// PreviewTime is the time the failover was previewed
+	// +optional
+	PreviewTime metav1.Time `json:"previewTime,omitempty"`
+}
+
+// SupportBundleStatus is the support bundle generated with the resources, the logs and the ceph
+// status of the cluster
+type SupportBundleStatus struct {
+	// Destination is where the bundle was requested to be stored, "pvc/<claim>" or "s3/<secret>"
+	Destination string `json:"destination"`
+	// Name is the file name of the bundle in the PVC or its object key in the bucket
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Message is the reason the bundle could not be generated or stored
+	// +optional
+	Message string `json:"message,omitempty"`
+	// GeneratedTime is the time the bundle was generated
+	// +optional
+	GeneratedTime metav1.Time `json:"generatedTime,omitempty"`
 }
 
 // CephDaemonsVersions show the current ceph version for different ceph daemons
@@ -542,6 +1303,29 @@ type CephStatus struct {
 	// +optional
 	Versions *CephDaemonsVersions `json:"versions,omitempty"`
 	FSID     string               `json:"fsid,omitempty"`
+	// Progress summarizes the events of the mgr progress module in progress, such as the recovery
+	// after an OSD went out, for example "Global Recovery Event 37% (ETA 2h5m)"
+	// +optional
+	Progress string `json:"progress,omitempty"`
+	// ProgressEvents are the events of the mgr progress module in progress
+	// +optional
+	ProgressEvents []ProgressEventStatus `json:"progressEvents,omitempty"`
+}
+
+// ProgressEventStatus is an event in progress of the mgr progress module
+type ProgressEventStatus struct {
+	// ID is the ID of the event in the mgr progress module
+	ID string `json:"id"`
+	// Message describes the event, for example "Global Recovery Event"
+	Message string `json:"message"`
+	// Percent is the completion of the event in percent
+	Percent int `json:"percent"`
+	// StartedAt is the time the event started
+	// +optional
+	StartedAt string `json:"startedAt,omitempty"`
+	// EstimatedTimeRemaining is the estimated time until the event completes, for example "2h5m"
+	// +optional
+	EstimatedTimeRemaining string `json:"estimatedTimeRemaining,omitempty"`
 }
 
 // Capacity is the capacity information of a Ceph Cluster
@@ -569,6 +1353,26 @@ type OSDStatus struct {
 	// StoreType is a mapping between the OSD backend stores and number of OSDs using these stores
 	StoreType       map[string]int  `json:"storeType,omitempty"`
 	MigrationStatus MigrationStatus `json:"migrationStatus,omitempty"`
+	// MemoryTargets are the osd_memory_target in bytes applied to the running OSDs when their memory
+	// limit changed, keyed by OSD
+	// +optional
+	MemoryTargets map[string]string `json:"memoryTargets,omitempty"`
+	// KeyRotation is the status of the rotation of the encryption keys of the encrypted OSDs, keyed
+	// by OSD
+	// +optional
+	KeyRotation map[string]OSDKeyRotationStatus `json:"keyRotation,omitempty"`
+}
+
+// OSDKeyRotationStatus represents the status of the rotation of the encryption key of an OSD
+type OSDKeyRotationStatus struct {
+	// LastScheduleTime is when the key rotation was last scheduled
+	// +optional
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is when the key was last rotated successfully
+	// +optional
+	// +nullable
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
 }
 
 // MigrationStatus status represents the current status of any OSD migration.
@@ -612,6 +1416,56 @@ const (
 	ClusterDeletingReason ConditionReason = "ClusterDeleting"
 	// ClusterConnectingReason is cluster connecting reason
 	ClusterConnectingReason ConditionReason = "ClusterConnecting"
+	// RestartBudgetExceededReason is the reason when daemon restarts are delayed by the restart budget
+	RestartBudgetExceededReason ConditionReason = "RestartBudgetExceeded"
+	// RestartBudgetAvailableReason is the reason when no daemon restart is delayed by the restart budget
+	RestartBudgetAvailableReason ConditionReason = "RestartBudgetAvailable"
+	// MonCountEvenReason represents reason for the cluster having an even number of mons
+	MonCountEvenReason ConditionReason = "MonCountEven"
+	// MonCountOddReason represents reason for the cluster having an odd number of mons again
+	MonCountOddReason ConditionReason = "MonCountOdd"
+	// MonStoreCheckFailedReason represents reason for a mon store failing the integrity check
+	MonStoreCheckFailedReason ConditionReason = "MonStoreCheckFailed"
+	// MonStoreCheckPassedReason represents reason for the mons being in quorum again after a store check failure
+	MonStoreCheckPassedReason ConditionReason = "MonStoreCheckPassed"
+	// StaleMonEndpointsReason represents reason for volumes mounted on nodes before a mon was removed
+	StaleMonEndpointsReason ConditionReason = "StaleMonEndpoints"
+	// MonEndpointsCurrentReason represents reason for all the volumes being mounted after the last mon removal
+	MonEndpointsCurrentReason ConditionReason = "MonEndpointsCurrent"
+	// ExternalMonMismatchReason represents reason for external mons not matching their expected endpoints
+	ExternalMonMismatchReason ConditionReason = "ExternalMonMismatch"
+	// ExternalMonsMatchReason represents reason for all the external mons matching their expected endpoints
+	ExternalMonsMatchReason ConditionReason = "ExternalMonsMatch"
+	// CephCommandsFailingReason represents reason for the ceph commands failing to reach the cluster
+	CephCommandsFailingReason ConditionReason = "CephCommandsFailing"
+	// CephCommandsSucceedingReason represents reason for the ceph commands reaching the cluster again
+	CephCommandsSucceedingReason ConditionReason = "CephCommandsSucceeding"
+	// CephConfigRolledBackReason represents reason for a config change rolled back on the canary OSD
+	CephConfigRolledBackReason ConditionReason = "CephConfigRolledBack"
+	// CephConfigRolledOutReason represents reason for a config change applied to all the OSDs
+	CephConfigRolledOutReason ConditionReason = "CephConfigRolledOut"
+	// PlacementDriftReason represents reason for daemons running on nodes that no longer satisfy their placement
+	PlacementDriftReason ConditionReason = "PlacementDrift"
+	// PlacementSatisfiedReason represents reason for all the daemons running on nodes that satisfy their placement
+	PlacementSatisfiedReason ConditionReason = "PlacementSatisfied"
+	// MonSchedulingBlockedReason represents reason for the new mons missing nodes or zones to be scheduled
+	MonSchedulingBlockedReason ConditionReason = "MonSchedulingBlocked"
+	// MonSchedulingPossibleReason represents reason for the nodes and zones being available for the new mons again
+	MonSchedulingPossibleReason ConditionReason = "MonSchedulingPossible"
+	// MultisiteConfigDriftReason represents reason for the multisite config in RGW differing from the CR
+	MultisiteConfigDriftReason ConditionReason = "MultisiteConfigDrift"
+	// MultisiteConfigReconciledReason represents reason for the drift of the multisite config reverted to the CR
+	MultisiteConfigReconciledReason ConditionReason = "MultisiteConfigReconciled"
+	// OSDScaleDownProgressingReason represents reason for the OSDs above the count of the device sets being removed
+	OSDScaleDownProgressingReason ConditionReason = "OSDScaleDownProgressing"
+	// OSDScaleDownCompletedReason represents reason for the OSDs above the count of the device sets being purged
+	OSDScaleDownCompletedReason ConditionReason = "OSDScaleDownCompleted"
+	// MultisiteConfigInSyncReason represents reason for the multisite config in RGW matching the CR
+	MultisiteConfigInSyncReason ConditionReason = "MultisiteConfigInSync"
+	// DebugLoggingActiveReason represents reason for the debug levels of the spec being raised
+	DebugLoggingActiveReason ConditionReason = "DebugLoggingActive"
+	// DebugLoggingExpiredReason represents reason for the debug levels being restored after the duration
+	DebugLoggingExpiredReason ConditionReason = "DebugLoggingExpired"
 
 	// ReconcileSucceeded represents when a resource reconciliation was successful.
 	ReconcileSucceeded ConditionReason = "ReconcileSucceeded"
@@ -666,6 +1520,43 @@ const (
 	ConditionPoolDeletionIsBlocked ConditionType = "PoolDeletionIsBlocked"
 	// ConditionRadosNSDeletionIsBlocked represents when deletion of the object is blocked.
 	ConditionRadosNSDeletionIsBlocked ConditionType = "RadosNamespaceDeletionIsBlocked"
+	// ConditionMonCountEven represents when the cluster has an even number of mons, which cannot
+	// tolerate more mon failures than the odd count below it
+	ConditionMonCountEven ConditionType = "MonCountEven"
+	// ConditionRestartBudgetExceeded represents when daemon restarts are delayed by the restart budget
+	ConditionRestartBudgetExceeded ConditionType = "RestartBudgetExceeded"
+	// ConditionMonStoreCorrupted represents when the store of a mon out of quorum failed the integrity check
+	ConditionMonStoreCorrupted ConditionType = "MonStoreCorrupted"
+	// ConditionCephUnreachable represents when the ceph commands keep failing to reach the cluster and
+	// the controllers not critical to the cluster health reconcile less often
+	ConditionCephUnreachable ConditionType = "CephUnreachable"
+	// ConditionStaleMonEndpoints represents when nodes have volumes of the cluster that were mounted
+	// before a mon was removed, which may still use the endpoints of the removed mon
+	ConditionStaleMonEndpoints ConditionType = "StaleMonEndpoints"
+	// ConditionCephConfigRolledBack represents when a change of the osd config was rolled back
+	// because the canary OSD regressed
+	ConditionCephConfigRolledBack ConditionType = "CephConfigRolledBack"
+	// ConditionExternalMonMismatch represents when the endpoints of external mons in the monmap do
+	// not match the endpoints expected in the cluster spec
+	ConditionExternalMonMismatch ConditionType = "ExternalMonMismatch"
+	// ConditionPlacementDrift represents when daemons run on nodes that no longer satisfy their
+	// placement since the nodes were relabeled or tainted
+	ConditionPlacementDrift ConditionType = "PlacementDrift"
+	// ConditionMonSchedulingBlocked represents when the mon count cannot be increased because the
+	// schedulable nodes or zones for the new mons are missing
+	ConditionMonSchedulingBlocked ConditionType = "MonSchedulingBlocked"
+	// ConditionMultisiteConfigDrift represents when the multisite config in RGW differs from the
+	// zone or zone group CR, for example after a change with radosgw-admin
+	ConditionMultisiteConfigDrift ConditionType = "MultisiteConfigDrift"
+	// ConditionOSDScaleDown represents when the OSDs above the count of the storageClassDeviceSets
+	// are reweighted to 0 and wait for their data to migrate before they are purged
+	ConditionOSDScaleDown ConditionType = "OSDScaleDown"
+	// ConditionDebugLogging represents when the debug levels of the ceph daemons or the operator
+	// are raised for the duration of the debugLogging settings
+	ConditionDebugLogging ConditionType = "DebugLogging"
+	// ConditionExpired represents when the credential of a CephClient was revoked after its TTL
+	// lapsed
+	ConditionExpired ConditionType = "Expired"
 )
 
 // ClusterState represents the state of a Ceph Cluster
@@ -717,6 +1608,19 @@ type LocalCephxStatus struct {
 type ClusterCephxStatus struct {
 	// RBDMirrorPeer show the cephx key rotation status of the `rbd-mirror-peer` user
 	RBDMirrorPeer *CephxStatus `json:"rbdMirrorPeer,omitempty"`
+	// Mon shows the cephx key rotation status of the `mon.` key shared by all the mons
+	// +optional
+	Mon *MonCephxStatus `json:"mon,omitempty"`
+}
+
+// MonCephxStatus is the cephx key rotation status of the `mon.` key
+type MonCephxStatus struct {
+	CephxStatus `json:",inline"`
+
+	// LastRotationTime is the time the mon key was last rotated or created, or the time the rotation
+	// interval was first set for the keys created before the rotation was tracked
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
 }
 
 // MonSpec represents the specification of the monitor
@@ -750,6 +1654,127 @@ type MonSpec struct {
 	// leading
 	// +optional
 	ExternalMonIDs []string `json:"externalMonIDs,omitempty"`
+	// ExternalMons are the endpoints expected for the mons listed in externalMonIDs. The endpoints
+	// of these mons in the monmap are validated during the mon health checks, and a mon whose
+	// endpoints do not match is not added to the mon endpoints until the mismatch is resolved.
+	// +optional
+	ExternalMons []ExternalMonSpec `json:"externalMons,omitempty"`
+	// VerifyStore adds an init container to the mon pods that checks the mon store is readable and
+	// belongs to this cluster before the mon starts, so that a corrupted store is reported clearly
+	// instead of the mon crash-looping
+	// +optional
+	VerifyStore bool `json:"verifyStore,omitempty"`
+	// TieBreaker is the name of a mon (for example "a") that is never removed when the number of
+	// mons is reduced from an even count to the desired odd count
+	// +optional
+	TieBreaker string `json:"tieBreaker,omitempty"`
+	// Overrides are the placement and resources of specific mons, keyed by the mon ID (for example
+	// "a") or by the name of a mon zone. The override of a mon ID takes precedence over the
+	// override of its zone.
+	// +optional
+	Overrides map[string]MonOverrideSpec `json:"overrides,omitempty"`
+	// TopologySpreadConstraints spread the mons across the topology domains, for example racks or
+	// zones, when they are scheduled. The mon pods are selected if no label selector is set.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// MigrateHostPathToPVC fails over the mons storing their data on the host path to new mons on PVCs
+	// created from the volume claim template, one mon at a time. The next mon is failed over only
+	// once all the mons are back in quorum.
+	// +optional
+	MigrateHostPathToPVC bool `json:"migrateHostPathToPVC,omitempty"`
+	// Ports are the ports the mons listen on, when the default ports conflict with other services on
+	// the host network. The existing mons are moved to the new ports one mon at a time.
+	// +optional
+	Ports *MonPortsSpec `json:"ports,omitempty"`
+	// Failback moves the mons failed over from a node that was not ready back to that node once it is
+	// ready and schedulable again
+	// +optional
+	Failback *MonFailbackSpec `json:"failback,omitempty"`
+	// BindAddresses select the public and cluster addresses of the mons with host networking, on the
+	// hosts with several network interfaces
+	// +optional
+	BindAddresses *MonBindAddressesSpec `json:"bindAddresses,omitempty"`
+	// ReuseIDs reuses the IDs of the removed mons for the new mons once the resources of the removed
+	// mons are deleted, instead of always creating the new mons with the next letter
+	// +optional
+	ReuseIDs bool `json:"reuseIDs,omitempty"`
+}
+
+// MonBindAddressesSpec selects the addresses of the node a mon binds to
+type MonBindAddressesSpec struct {
+	// Public selects the public address of the mon, which is its endpoint. The internal IP of the node
+	// is used by default.
+	// +optional
+	Public *MonAddressSelector `json:"public,omitempty"`
+	// Cluster selects the cluster address of the mon. Ceph picks the address by default.
+	// +optional
+	Cluster *MonAddressSelector `json:"cluster,omitempty"`
+}
+
+// MonAddressSelector selects an address of the node by network interface, by CIDR, or both
+type MonAddressSelector struct {
+	// Interface is the name of the network interface of the node, for example "eth1"
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// CIDR is the network of the address, for example "192.168.100.0/24"
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+}
+
+// MonFailbackSpec is the policy to move the mons back to their original node after a node outage
+type MonFailbackSpec struct {
+	// Enabled moves the mons back to their original node, one mon at a time while all the mons are in
+	// quorum
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Delay is how long the original node of a mon must be ready and schedulable before the mon is
+	// moved back. Defaults to 10 minutes.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+	// Interval is the minimum time between two mons moved back. Defaults to 30 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// MonPortsSpec are the ports of the messenger protocols of the mons
+type MonPortsSpec struct {
+	// Msgr2 is the port of the messenger v2 protocol, 3300 by default
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Msgr2 int32 `json:"msgr2,omitempty"`
+	// Msgr1 is the port of the legacy messenger v1 protocol, 6789 by default. The port is not used
+	// when msgr2 is required.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Msgr1 int32 `json:"msgr1,omitempty"`
+}
+
+// ExternalMonSpec is the endpoint expected for an external mon
+type ExternalMonSpec struct {
+	// ID of the external mon, which must be listed in externalMonIDs
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+	// Address is the IP address the mon is expected to listen on
+	// +optional
+	Address string `json:"address,omitempty"`
+	// Ports are the ports the mon is expected to listen on, for example 3300 and 6789
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+}
+
+// MonOverrideSpec is the placement and resources of a mon, or of the mons of a zone
+type MonOverrideSpec struct {
+	// Placement is merged with the mon placement, its settings replace those of the mon placement
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources replace the mon resources
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// BindAddresses replace the bind addresses of the mon spec
+	// +optional
+	BindAddresses *MonBindAddressesSpec `json:"bindAddresses,omitempty"`
 }
 
 // VolumeClaimTemplate is a simplified version of K8s corev1's PVC. It has no type meta or status.
@@ -777,6 +1802,29 @@ type StretchClusterSpec struct {
 	// +optional
 	// +nullable
 	Zones []MonZoneSpec `json:"zones,omitempty"`
+	// ZoneFailover reschedules the mons of a failed data zone into the surviving data zones
+	// +optional
+	// +nullable
+	ZoneFailover *StretchZoneFailoverSpec `json:"zoneFailover,omitempty"`
+}
+
+// StretchZoneFailoverSpec represents the rescheduling of the mons of a failed stretch cluster zone
+type StretchZoneFailoverSpec struct {
+	// Enabled reschedules the mons of a data zone into the surviving data zones when all the mons of
+	// the zone are out of quorum
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Timeout is how long all the mons of a zone must be out of quorum before the zone is considered
+	// failed and its mons are rescheduled. Defaults to the mon out timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailbackDelay is how long the nodes of a failed zone must be ready again before its mons are
+	// moved back into the zone. Defaults to 10 minutes.
+	// +optional
+	FailbackDelay *metav1.Duration `json:"failbackDelay,omitempty"`
+	// DisableFailback keeps the rescheduled mons in the surviving zones when the failed zone recovers
+	// +optional
+	DisableFailback bool `json:"disableFailback,omitempty"`
 }
 
 // MonZoneSpec represents the specification of a zone in a Ceph Cluster
@@ -787,6 +1835,17 @@ type MonZoneSpec struct {
 	// Arbiter determines if the zone contains the arbiter used for stretch cluster mode
 	// +optional
 	Arbiter bool `json:"arbiter,omitempty"`
+	// Weight is the weight applied to the mons in the zone with "ceph mon set-weight". Clients
+	// prefer to connect to the mons with a higher weight. If not set, the weight is not changed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Weight *int `json:"weight,omitempty"`
+	// DisallowLeader prevents the mons in the zone from being elected leader of the quorum, for
+	// example to prefer the mons in the primary site of a stretch cluster as leader. The "classic"
+	// election strategy is switched to "disallow" since it does not honor the disallowed leaders.
+	// +optional
+	DisallowLeader bool `json:"disallowLeader,omitempty"`
 	// VolumeClaimTemplate is the PVC template
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
@@ -936,26 +1995,109 @@ type PoolSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	StatusCheck MirrorHealthCheckSpec `json:"statusCheck,omitempty"`
 
-	// The quota settings
+	// The quota settings
+	// +optional
+	// +nullable
+	Quotas QuotaSpec `json:"quotas,omitempty"`
+
+	// The application name to set on the pool. Only expected to be set for rgw pools.
+	// +optional
+	Application string `json:"application"`
+}
+
+// NamedBlockPoolSpec allows a block pool to be created with a non-default name.
+// This is more specific than the NamedPoolSpec so we get schema validation on the
+// allowed pool names that can be specified.
+type NamedBlockPoolSpec struct {
+	// The desired name of the pool if different from the CephBlockPool CR name.
+	// +kubebuilder:validation:Enum=.rgw.root;.nfs;.mgr
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The core pool configuration
+	PoolSpec `json:",inline"`
+	// ImageSnapshotSchedule takes periodic snapshots of the RBD images of the pool that are not
+	// managed by the CSI driver, for example the images consumed directly by virtual machines
+	// +optional
+	// +nullable
+	ImageSnapshotSchedule *ImageSnapshotScheduleSpec `json:"imageSnapshotSchedule,omitempty"`
+	// Seed is the data created in the pool once it is ready, so that the applications do not need
+	// to bootstrap it out of band
+	// +optional
+	// +nullable
+	Seed *BlockPoolSeedSpec `json:"seed,omitempty"`
+	// Migration moves the data of the pool gradually when its failure domain or device class
+	// changes, instead of moving all the data of the pool at once
+	// +optional
+	// +nullable
+	Migration *PoolMigrationSpec `json:"migration,omitempty"`
+	// PGAdvisor recommends the number of PGs of the pool from its share of the used capacity of the
+	// OSDs of its device class, and applies the recommendation once it is approved
+	// +optional
+	// +nullable
+	PGAdvisor *PGAdvisorSpec `json:"pgAdvisor,omitempty"`
+}
+
+// PGAdvisorSpec represents the advisor recommending the number of PGs of a pool
+type PGAdvisorSpec struct {
+	// Enabled publishes the recommended number of PGs of the pool in its status
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// TargetPGsPerOSD is the number of PGs per OSD the recommendation aims for, counting each
+	// replica or erasure coded chunk of a PG. Default is 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetPGsPerOSD int `json:"targetPGsPerOSD,omitempty"`
+}
+
+// PoolMigrationSpec represents the gradual migration of the data of a pool to a new CRUSH rule
+type PoolMigrationSpec struct {
+	// Enabled migrates the data of the pool gradually when its CRUSH rule changes
 	// +optional
-	// +nullable
-	Quotas QuotaSpec `json:"quotas,omitempty"`
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxMovingPGsPercent is the maximum percentage of the PGs of the pool moving at the same time
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=5
+	// +optional
+	MaxMovingPGsPercent int `json:"maxMovingPGsPercent,omitempty"`
+	// MaxRecoveryBytesPerSecond is the recovery throughput of the cluster above which no more PGs
+	// are moved. The throughput is not checked if not set.
+	// +optional
+	MaxRecoveryBytesPerSecond *resource.Quantity `json:"maxRecoveryBytesPerSecond,omitempty"`
+	// RollbackOnFailure restores the previous CRUSH rule of the pool if PGs of the pool become
+	// inactive during the migration
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
 
-	// The application name to set on the pool. Only expected to be set for rgw pools.
+// BlockPoolSeedSpec represents the data created in a block pool once it is ready
+type BlockPoolSeedSpec struct {
+	// Images are the RBD images to create in the pool. The existing images are not modified.
 	// +optional
-	Application string `json:"application"`
+	Images []SeedImageSpec `json:"images,omitempty"`
 }
 
-// NamedBlockPoolSpec allows a block pool to be created with a non-default name.
-// This is more specific than the NamedPoolSpec so we get schema validation on the
-// allowed pool names that can be specified.
-type NamedBlockPoolSpec struct {
-	// The desired name of the pool if different from the CephBlockPool CR name.
-	// +kubebuilder:validation:Enum=.rgw.root;.nfs;.mgr
+// SeedImageSpec represents an RBD image created in a pool
+type SeedImageSpec struct {
+	// Name of the image
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Size of the image, for example 10Gi
+	Size resource.Quantity `json:"size"`
+}
+
+// ImageSnapshotScheduleSpec represents the snapshot schedule of the RBD images of a pool
+type ImageSnapshotScheduleSpec struct {
+	// Interval between the snapshots of the images, for example 24h
+	Interval *metav1.Duration `json:"interval"`
+	// Retention is the number of scheduled snapshots kept for each image. The oldest scheduled
+	// snapshots are removed once the retention is exceeded.
+	// +kubebuilder:validation:Minimum=1
+	Retention int `json:"retention"`
+	// Images are the names of the images to snapshot. If empty, all the images of the pool that are
+	// not managed by the CSI driver are snapshotted.
 	// +optional
-	Name string `json:"name,omitempty"`
-	// The core pool configuration
-	PoolSpec `json:",inline"`
+	Images []string `json:"images,omitempty"`
 }
 
 // NamedPoolSpec represents the named ceph pool spec
@@ -986,6 +2128,28 @@ type CephBlockPoolStatus struct {
 	// +optional
 	SnapshotScheduleStatus *SnapshotScheduleStatusSpec `json:"snapshotScheduleStatus,omitempty"`
 	// +optional
+	ImageSnapshotScheduleStatus *ImageSnapshotScheduleStatus `json:"imageSnapshotScheduleStatus,omitempty"`
+	// Seed is the status of the job creating the seed data of the pool
+	// +optional
+	// +nullable
+	Seed *SeedStatus `json:"seed,omitempty"`
+	// ProgressEvents are the events of the mgr progress module in progress that refer to the pool,
+	// such as the change of its number of PGs
+	// +optional
+	ProgressEvents []ProgressEventStatus `json:"progressEvents,omitempty"`
+	// BackupQuiesce is the status of the quiesce of the pool requested by a backup tool
+	// +optional
+	// +nullable
+	BackupQuiesce *BackupQuiesceStatus `json:"backupQuiesce,omitempty"`
+	// Migration is the status of the migration of the data of the pool to a new CRUSH rule
+	// +optional
+	// +nullable
+	Migration *PoolMigrationStatus `json:"migration,omitempty"`
+	// PGRecommendation is the number of PGs the PG advisor recommends for the pool
+	// +optional
+	// +nullable
+	PGRecommendation *PGRecommendationStatus `json:"pgRecommendation,omitempty"`
+	// +optional
 	// +nullable
 	Info map[string]string `json:"info,omitempty"`
 	// ObservedGeneration is the latest generation observed by the controller.
@@ -994,6 +2158,96 @@ type CephBlockPoolStatus struct {
 	Conditions         []Condition `json:"conditions,omitempty"`
 }
 
+// PoolMigrationStatus is the status of the migration of the data of a pool to a new CRUSH rule
+type PoolMigrationStatus struct {
+	// Phase is Migrating, Completed or RolledBack
+	Phase string `json:"phase"`
+	// FromCrushRule is the CRUSH rule of the pool before the migration
+	FromCrushRule string `json:"fromCrushRule"`
+	// ToCrushRule is the CRUSH rule the data of the pool is migrated to
+	ToCrushRule string `json:"toCrushRule"`
+	// PGs is the number of PGs of the pool whose data is migrated
+	// +optional
+	PGs int `json:"pgs,omitempty"`
+	// RemainingPGs is the number of PGs of the pool whose data is not migrated yet
+	// +optional
+	RemainingPGs int `json:"remainingPGs,omitempty"`
+	// StartTime is the time the migration started
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+	// CompletionTime is the time the migration completed or was rolled back
+	// +optional
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Message is the reason the migration was rolled back
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PGRecommendationStatus is the number of PGs the PG advisor recommends for a pool
+type PGRecommendationStatus struct {
+	// Phase is Optimal, PendingApproval until the recommendation is approved, or Applying until the
+	// pool has the recommended number of PGs
+	Phase string `json:"phase"`
+	// CurrentPGs is the number of PGs of the pool
+	CurrentPGs int `json:"currentPGs"`
+	// RecommendedPGs is the recommended number of PGs of the pool
+	RecommendedPGs int `json:"recommendedPGs"`
+	// AppliedPGs is the number of PGs of the last approved recommendation
+	// +optional
+	AppliedPGs int `json:"appliedPGs,omitempty"`
+	// OSDs is the number of OSDs of the device class of the pool
+	OSDs int `json:"osds"`
+	// DeviceClass is the device class of the pool, empty if the pool uses the OSDs of all the
+	// device classes
+	// +optional
+	DeviceClass string `json:"deviceClass,omitempty"`
+	// UsagePercent is the percentage of the used capacity of the OSDs of the device class that the
+	// recommendation assumes for the pool
+	// +optional
+	UsagePercent int `json:"usagePercent,omitempty"`
+	// Message explains the recommendation
+	// +optional
+	Message string `json:"message,omitempty"`
+	// UpdateTime is the time the recommendation was computed
+	// +optional
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// BackupQuiesceStatus is the status of the quiesce of a pool requested by a backup tool
+type BackupQuiesceStatus struct {
+	// Backup is the name of the backup the pool is quiesced for
+	Backup string `json:"backup"`
+	// Phase is Quiesced once the mirroring snapshot schedules are paused and the rbd groups are
+	// snapshotted, or Failed
+	Phase string `json:"phase"`
+	// QuiesceTime is the time the pool was quiesced
+	// +optional
+	QuiesceTime string `json:"quiesceTime,omitempty"`
+	// GroupSnapshots are the snapshots of the rbd groups of the pool taken for the backup, in the
+	// group@snapshot format
+	// +optional
+	GroupSnapshots []string `json:"groupSnapshots,omitempty"`
+	// Message is the reason the pool failed to be quiesced
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ImageSnapshotScheduleStatus is the status of the last scheduled snapshots of the pool images
+type ImageSnapshotScheduleStatus struct {
+	// LastSnapshotTime is the time the images were last snapshotted
+	// +optional
+	LastSnapshotTime string `json:"lastSnapshotTime,omitempty"`
+	// SnapshottedImages is the number of images snapshotted by the last schedule
+	// +optional
+	SnapshottedImages int `json:"snapshottedImages,omitempty"`
+	// FailedImages is the number of images that failed to be snapshotted by the last schedule
+	// +optional
+	FailedImages int `json:"failedImages,omitempty"`
+	// LastFailure is the error of the last image that failed to be snapshotted
+	// +optional
+	LastFailure string `json:"lastFailure,omitempty"`
+}
+
 // MirroringStatusSpec is the status of the pool/radosNamespace mirroring
 type MirroringStatusSpec struct {
 	// MirroringStatus is the mirroring status of a pool/radosNamespace
@@ -1343,6 +2597,62 @@ type FilesystemSpec struct {
 	// The mirroring statusCheck
 	// +kubebuilder:pruning:PreserveUnknownFields
 	StatusCheck MirrorHealthCheckSpec `json:"statusCheck,omitempty"`
+
+	// PinnedSubtrees are the subvolume groups and subvolumes of the filesystem to pin to the active MDS
+	// ranks, distributing their metadata load predictably between the ranks.
+	// +optional
+	// +nullable
+	PinnedSubtrees []FilesystemPinnedSubtreeSpec `json:"pinnedSubtrees,omitempty"`
+
+	// Seed is the data created in the filesystem once it is ready, so that the applications do not
+	// need to bootstrap it out of band
+	// +optional
+	// +nullable
+	Seed *FilesystemSeedSpec `json:"seed,omitempty"`
+}
+
+// FilesystemSeedSpec represents the data created in a filesystem once it is ready
+type FilesystemSeedSpec struct {
+	// Directories are the directories to create in the filesystem. The mode and ownership of the
+	// existing directories are updated.
+	// +optional
+	Directories []SeedDirectorySpec `json:"directories,omitempty"`
+}
+
+// SeedDirectorySpec represents a directory created in a filesystem
+type SeedDirectorySpec struct {
+	// Path of the directory from the root of the filesystem, for example /apps/web. The missing
+	// parent directories are created.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+	// Mode is the octal permission bits of the directory, for example "0755"
+	// +kubebuilder:validation:Pattern=`^0?[0-7]{3}$`
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// UID is the user owning the directory
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UID *int64 `json:"uid,omitempty"`
+	// GID is the group owning the directory
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GID *int64 `json:"gid,omitempty"`
+}
+
+// FilesystemPinnedSubtreeSpec represents the pinning of a subvolume group or subvolume of a filesystem
+type FilesystemPinnedSubtreeSpec struct {
+	// SubVolumeGroup is the name of the subvolume group to pin, or the group of the subvolume to pin
+	// +kubebuilder:validation:MinLength=1
+	SubVolumeGroup string `json:"subVolumeGroup"`
+	// SubVolume is the name of the subvolume to pin. If not set, the whole subvolume group is pinned.
+	// +optional
+	SubVolume string `json:"subVolume,omitempty"`
+	// Pinning is the pinning policy of the subtree, reference
+	// https://docs.ceph.com/en/latest/cephfs/fs-volumes/#pinning-subvolumes-and-subvolume-groups.
+	// Only one out of (export, distributed, random) can be set at a time. If none is set, the ephemeral
+	// distributed pinning is enabled.
+	// +optional
+	Pinning CephFilesystemSubVolumeGroupSpecPinning `json:"pinning,omitempty"`
 }
 
 // MetadataServerSpec represents the specification of a Ceph Metadata Server
@@ -1435,6 +2745,10 @@ type CephFilesystemStatus struct {
 	// +optional
 	// +nullable
 	Info map[string]string `json:"info,omitempty"`
+	// Seed is the status of the job creating the seed data of the filesystem
+	// +optional
+	// +nullable
+	Seed *SeedStatus `json:"seed,omitempty"`
 	// MirroringStatus is the filesystem mirroring status
 	// +optional
 	MirroringStatus *FilesystemMirroringInfoSpec `json:"mirroringStatus,omitempty"`
@@ -1678,6 +2992,103 @@ type ObjectStoreSpec struct {
 	// +nullable
 	// +optional
 	Hosting *ObjectStoreHostingSpec `json:"hosting,omitempty"`
+
+	// EndpointPublication publishes the endpoints of the object store so that applications can
+	// discover them instead of hardcoding the service DNS names.
+	// +nullable
+	// +optional
+	EndpointPublication *ObjectEndpointPublicationSpec `json:"endpointPublication,omitempty"`
+
+	// GarbageCollection tunes the garbage collection of the deleted objects and runs it on demand.
+	// +nullable
+	// +optional
+	GarbageCollection *ObjectGarbageCollectionSpec `json:"garbageCollection,omitempty"`
+
+	// Seed is the data created in the object store once it is ready, so that the applications do
+	// not need to bootstrap it out of band. The seed is not supported for external object stores.
+	// +optional
+	// +nullable
+	Seed *ObjectStoreSeedSpec `json:"seed,omitempty"`
+}
+
+// ObjectStoreSeedSpec represents the data created in an object store once it is ready
+type ObjectStoreSeedSpec struct {
+	// Users are the users to create in the object store. The existing users are not modified.
+	// +optional
+	Users []SeedObjectUserSpec `json:"users,omitempty"`
+	// Buckets are the buckets to create in the object store
+	// +optional
+	Buckets []SeedBucketSpec `json:"buckets,omitempty"`
+}
+
+// SeedObjectUserSpec represents a user created in an object store
+type SeedObjectUserSpec struct {
+	// UID is the ID of the user
+	// +kubebuilder:validation:MinLength=1
+	UID string `json:"uid"`
+	// DisplayName of the user, the UID is used if not set
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// SeedBucketSpec represents a bucket created in an object store
+type SeedBucketSpec struct {
+	// Name of the bucket
+	// +kubebuilder:validation:MinLength=3
+	Name string `json:"name"`
+	// Owner is the UID of the user owning the bucket, it must be one of the seeded users
+	// +kubebuilder:validation:MinLength=1
+	Owner string `json:"owner"`
+}
+
+// SeedStatus represents the status of the job creating the seed data of a resource
+type SeedStatus struct {
+	// Phase of the seed job: Running, Succeeded or Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// SpecHash is the hash of the seed settings applied by the job
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+	// Message details the failure of the seed job
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the seed job was started
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+	// CompletionTime is the time the seed job succeeded or failed
+	// +optional
+	CompletionTime string `json:"completionTime,omitempty"`
+}
+
+// EndpointCheckType is a check of an endpoint declared in a spec
+type EndpointCheckType string
+
+const (
+	// EndpointCheckDNS resolves the host name of the endpoint
+	EndpointCheckDNS EndpointCheckType = "DNS"
+	// EndpointCheckTCP connects to the port of the endpoint
+	EndpointCheckTCP EndpointCheckType = "TCP"
+	// EndpointCheckTLS checks the TLS handshake and the certificate of the endpoint
+	EndpointCheckTLS EndpointCheckType = "TLS"
+)
+
+// EndpointCheckStatus is the result of the checks of an endpoint declared in the spec, run by the
+// operator in the background
+type EndpointCheckStatus struct {
+	// Source is where the endpoint is declared in the spec, for example "hosting.dnsNames"
+	Source string `json:"source"`
+	// Endpoint is the host name or address, with the port when the connection is checked
+	Endpoint string `json:"endpoint"`
+	// Healthy is whether all the checks of the endpoint passed
+	Healthy bool `json:"healthy"`
+	// FailedCheck is the check that failed: DNS, TCP or TLS
+	// +optional
+	FailedCheck EndpointCheckType `json:"failedCheck,omitempty"`
+	// Message is the error of the failed check
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastChecked is when the endpoint was last checked
+	LastChecked metav1.Time `json:"lastChecked"`
 }
 
 // ObjectSharedPoolsSpec represents object store pool info when configuring RADOS namespaces in existing pools.
@@ -1782,6 +3193,93 @@ type HealthCheckSpec struct {
 	Timeout string `json:"timeout,omitempty"`
 }
 
+// MonHealthCheckSpec represents the health check settings for the Ceph monitors
+type MonHealthCheckSpec struct {
+	HealthCheckSpec `json:",inline"`
+	// CompactionSchedule is the cron schedule in UTC, for example "0 3 * * 0", at which the mon
+	// health checker compacts the stores of the mons, one mon per health check
+	// +optional
+	CompactionSchedule string `json:"compactionSchedule,omitempty"`
+	// TimeoutOverrides are the timeouts of specific mons overriding the timeout of the mons, for
+	// example a longer timeout for mons on flaky nodes. The keys are the names of the mons, or
+	// "arbiter" for the arbiter mon of a stretch cluster.
+	// +optional
+	TimeoutOverrides map[string]string `json:"timeoutOverrides,omitempty"`
+	// FailoverRateLimit limits the mon failovers of the health checker, so that a flapping node
+	// does not churn the mon map with repeated failovers
+	// +optional
+	// +nullable
+	FailoverRateLimit *MonFailoverRateLimitSpec `json:"failoverRateLimit,omitempty"`
+	// ClockSkew is the remediation of the mons whose clock is skewed, as reported by the
+	// MON_CLOCK_SKEW health warning
+	// +optional
+	// +nullable
+	ClockSkew *MonClockSkewSpec `json:"clockSkew,omitempty"`
+	// DiskUsage is the failover of the mons whose disk is low on space, as reported by the
+	// MON_DISK_LOW and MON_DISK_CRIT health warnings
+	// +optional
+	// +nullable
+	DiskUsage *MonDiskUsageSpec `json:"diskUsage,omitempty"`
+}
+
+// MonFailoverRateLimitSpec represents the budget of mon failovers and the backoff of the failovers
+// of each mon
+type MonFailoverRateLimitSpec struct {
+	// MaxFailovers is the number of mon failovers allowed in the window. If zero, the number of
+	// failovers is not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailovers int `json:"maxFailovers,omitempty"`
+	// Window is the time window of the failover budget. Defaults to 1 hour.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+	// InitialBackoff is the time to wait after a failover of a mon before the mon is failed over
+	// again, doubled for each further failover of the mon until it is back in quorum. Defaults to
+	// 10 minutes.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// MaxBackoff is the maximum time to wait between the failovers of a mon. Defaults to 2 hours.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// MonClockSkewAction is the remediation of a mon whose clock is skewed
+type MonClockSkewAction string
+
+const (
+	// MonClockSkewActionNone only reports the mons whose clock is skewed
+	MonClockSkewActionNone MonClockSkewAction = "None"
+	// MonClockSkewActionRestart restarts the pod of the mon whose clock is skewed
+	MonClockSkewActionRestart MonClockSkewAction = "Restart"
+	// MonClockSkewActionFailover fails over the mon whose clock is skewed to another node
+	MonClockSkewActionFailover MonClockSkewAction = "Failover"
+)
+
+// MonClockSkewSpec represents the remediation of the mons whose clock is skewed
+type MonClockSkewSpec struct {
+	// Action is the remediation of a mon whose clock is skewed for longer than the threshold.
+	// Defaults to "None", the skewed mons are only reported.
+	// +kubebuilder:validation:Enum=None;Restart;Failover
+	// +optional
+	Action MonClockSkewAction `json:"action,omitempty"`
+	// Threshold is how long the clock of a mon is skewed before the mon is remediated. Defaults to
+	// 10 minutes.
+	// +optional
+	Threshold *metav1.Duration `json:"threshold,omitempty"`
+}
+
+// MonDiskUsageSpec represents the failover of the mons whose disk is low on space
+type MonDiskUsageSpec struct {
+	// FailoverAvailablePercent is the percentage of available space on the disk of a mon below
+	// which the mon is failed over to another node or a new PVC, before the mon stops from a full
+	// disk. The available space is reported by ceph from the mon_data_avail_warn setting, 30% by
+	// default, so the threshold must be lower. The mons are not failed over if zero.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	FailoverAvailablePercent int `json:"failoverAvailablePercent,omitempty"`
+}
+
 // GatewaySpec represents the specification of Ceph Object Store Gateway
 type GatewaySpec struct {
 	// The port the rgw service will be listening on (http)
@@ -1909,6 +3407,35 @@ type GatewaySpec struct {
 	// Note: Only supported from Ceph Tentacle (v20)
 	// +optional
 	ReadAffinity *RgwReadAffinity `json:"readAffinity,omitempty"`
+
+	// Groups of gateways, each with its own instance count, placement and service, for example to
+	// run local gateways in each zone. When set, the groups replace the single deployment of the
+	// gateways and the gateway instances are ignored. The other gateway settings apply to all the
+	// groups.
+	// +listType=map
+	// +listMapKey=name
+	// +nullable
+	// +optional
+	Groups []GatewayGroupSpec `json:"groups,omitempty"`
+}
+
+// GatewayGroupSpec represents a group of gateways of the object store
+type GatewayGroupSpec struct {
+	// Name of the group, the gateways of the group are served by the object store service suffixed
+	// with the name of the group
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// The number of pods in the group
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Instances int32 `json:"instances,omitempty"`
+
+	// The affinity to place the gateways of the group, merged with the placement of the gateway
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +nullable
+	// +optional
+	Placement Placement `json:"placement,omitempty"`
 }
 
 type RgwReadAffinity struct {
@@ -2055,6 +3582,33 @@ type ObjectStoreStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// GatewayGroups is the status of the gateway groups
+	// +optional
+	// +nullable
+	GatewayGroups []GatewayGroupStatus `json:"gatewayGroups,omitempty"`
+	// GarbageCollection is the status of the garbage collection of the deleted objects
+	// +optional
+	// +nullable
+	GarbageCollection *ObjectGarbageCollectionStatus `json:"garbageCollection,omitempty"`
+	// Seed is the status of the job creating the seed data of the object store
+	// +optional
+	// +nullable
+	Seed *SeedStatus `json:"seed,omitempty"`
+	// EndpointChecks are the results of the checks of the endpoints declared in the spec: the DNS
+	// names, the advertise endpoint and the KMS addresses
+	// +optional
+	EndpointChecks []EndpointCheckStatus `json:"endpointChecks,omitempty"`
+}
+
+// GatewayGroupStatus represents the status of a group of gateways
+type GatewayGroupStatus struct {
+	Name string `json:"name"`
+	// The number of desired pods in the group
+	// +optional
+	Instances int32 `json:"instances,omitempty"`
+	// The number of ready pods in the group
+	// +optional
+	ReadyInstances int32 `json:"readyInstances,omitempty"`
 }
 
 type ObjectEndpoints struct {
@@ -2108,6 +3662,107 @@ type ObjectEndpointSpec struct {
 	UseTls bool `json:"useTls"`
 }
 
+// ObjectEndpointPublicationSpec represents the publication of the object store endpoints
+type ObjectEndpointPublicationSpec struct {
+	// ConfigMap publishes the endpoints and the TLS CA of the object store in the
+	// "rook-ceph-rgw-<store>-endpoints" ConfigMap of the object store namespace.
+	// +optional
+	ConfigMap bool `json:"configMap,omitempty"`
+	// HTTPRoute generates a Gateway API HTTPRoute routing to the object store service.
+	// The Gateway API CRDs must be installed in the cluster.
+	// +nullable
+	// +optional
+	HTTPRoute *ObjectHTTPRouteSpec `json:"httpRoute,omitempty"`
+}
+
+// ObjectHTTPRouteSpec represents the Gateway API HTTPRoute generated for the object store
+type ObjectHTTPRouteSpec struct {
+	// ParentRefs are the gateways the route is attached to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []ObjectHTTPRouteParentRef `json:"parentRefs"`
+	// Hostnames are the host names matched by the route. If empty, the route matches all the
+	// host names of the gateway listeners.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// ObjectHTTPRouteParentRef references a gateway the HTTPRoute is attached to
+type ObjectHTTPRouteParentRef struct {
+	// Name is the name of the gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace is the namespace of the gateway. Defaults to the object store namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the gateway listener the route is attached to.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ObjectGarbageCollectionSpec represents the tuning and the on demand runs of the garbage collection
+// of the deleted objects of the object store
+type ObjectGarbageCollectionSpec struct {
+	// MaxObjects is the number of shards of the garbage collection queue, set as rgw_gc_max_objs.
+	// More shards let the gateways process the queue in parallel. Changing it leaves the entries
+	// of the removed shards unprocessed, so it should be set before the store is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxObjects *int32 `json:"maxObjects,omitempty"`
+	// ProcessorPeriod is the period of the garbage collection cycles, set as rgw_gc_processor_period
+	// +optional
+	ProcessorPeriod *metav1.Duration `json:"processorPeriod,omitempty"`
+	// ObjectMinWait is the time the deleted objects wait before they can be collected, set as
+	// rgw_gc_obj_min_wait
+	// +optional
+	ObjectMinWait *metav1.Duration `json:"objectMinWait,omitempty"`
+	// Run requests a run of the garbage collection of all the pending objects, including the
+	// objects that have not waited the min wait yet. Each new value requests a new run, for
+	// example the current time.
+	// +optional
+	Run string `json:"run,omitempty"`
+	// BacklogCheckInterval is the interval to report the garbage collection backlog in the status.
+	// Defaults to 10 minutes.
+	// +optional
+	BacklogCheckInterval *metav1.Duration `json:"backlogCheckInterval,omitempty"`
+}
+
+// ObjectGarbageCollectionStatus represents the garbage collection backlog and the last on demand
+// run of the garbage collection
+type ObjectGarbageCollectionStatus struct {
+	// PendingObjects is the number of deleted objects that have waited the min wait and are not
+	// collected yet
+	// +optional
+	PendingObjects int64 `json:"pendingObjects"`
+	// BacklogCheckTime is the time the backlog was last checked
+	// +optional
+	BacklogCheckTime metav1.Time `json:"backlogCheckTime,omitempty"`
+	// LastRun is the last on demand run of the garbage collection
+	// +optional
+	// +nullable
+	LastRun *ObjectGarbageCollectionRunStatus `json:"lastRun,omitempty"`
+}
+
+// ObjectGarbageCollectionRunStatus represents an on demand run of the garbage collection
+type ObjectGarbageCollectionRunStatus struct {
+	// Run is the value of the run request
+	Run string `json:"run"`
+	// Phase is Running, Completed or Failed
+	Phase string `json:"phase"`
+	// PendingObjectsAtStart is the number of objects pending collection when the run started, to
+	// follow the progress of the run against the pending objects
+	// +optional
+	PendingObjectsAtStart int64 `json:"pendingObjectsAtStart"`
+	// StartTime is the time the run started
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the run completed or failed
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+	// Message is the reason the run failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +genclient
 // +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -2139,6 +3794,10 @@ type ObjectStoreUserStatus struct {
 	// +optional
 	// +nullable
 	Keys []SecretReference `json:"keys,omitempty"`
+	// Buckets is the list of the buckets owned by the user
+	// +optional
+	// +nullable
+	Buckets []string `json:"buckets,omitempty"`
 }
 
 type SecretReference struct {
@@ -2177,6 +3836,11 @@ type ObjectStoreUserSpec struct {
 	// The namespace where the parent CephCluster and CephObjectStore are found
 	// +optional
 	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// TransferBucketsTo is the ID of another user of the object store that becomes the owner of the
+	// buckets of this user when this user is deleted. If not set, the user cannot be deleted while
+	// it owns buckets.
+	// +optional
+	TransferBucketsTo string `json:"transferBucketsTo,omitempty"`
 }
 
 // Additional admin-level capabilities for the Ceph object store user
@@ -2340,6 +4004,35 @@ type CephObjectZoneGroupList struct {
 type ObjectZoneGroupSpec struct {
 	// The display name for the ceph users
 	Realm string `json:"realm"`
+
+	// DriftDetection periodically compares the zone group in RGW with the CR, and reverts or reports
+	// the changes made outside of Rook, for example with radosgw-admin
+	// +optional
+	// +nullable
+	DriftDetection *MultisiteDriftDetectionSpec `json:"driftDetection,omitempty"`
+}
+
+// MultisiteDriftMode is how the drift of the multisite config in RGW from the CRs is handled
+type MultisiteDriftMode string
+
+const (
+	// MultisiteDriftModeReconcile reverts the drift of the multisite config to the CR
+	MultisiteDriftModeReconcile MultisiteDriftMode = "Reconcile"
+	// MultisiteDriftModeDryRun only reports the drift of the multisite config
+	MultisiteDriftModeDryRun MultisiteDriftMode = "DryRun"
+)
+
+// MultisiteDriftDetectionSpec represents the periodic comparison of the multisite config in RGW
+// with the zone and zone group CRs
+type MultisiteDriftDetectionSpec struct {
+	// Mode is "Reconcile" to revert the drift of the config in RGW to the CR, or "DryRun" to only
+	// report the drift in the MultisiteConfigDrift condition of the CR. Defaults to "Reconcile".
+	// +kubebuilder:validation:Enum=Reconcile;DryRun
+	// +optional
+	Mode MultisiteDriftMode `json:"mode,omitempty"`
+	// Interval is the interval to compare the config in RGW with the CR. Defaults to 10 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // +genclient
@@ -2406,6 +4099,12 @@ type ObjectZoneSpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	PreservePoolsOnDelete bool `json:"preservePoolsOnDelete"`
+
+	// DriftDetection periodically compares the zone in RGW with the CR, and reverts or reports the
+	// changes made outside of Rook, for example with radosgw-admin
+	// +optional
+	// +nullable
+	DriftDetection *MultisiteDriftDetectionSpec `json:"driftDetection,omitempty"`
 }
 
 // +genclient
@@ -2944,6 +4643,13 @@ type NetworkSpec struct {
 	// Enable multiClusterService to export the Services between peer clusters
 	// +optional
 	MultiClusterService MultiClusterServiceSpec `json:"multiClusterService,omitempty"`
+
+	// NetworkPolicies are the settings for the NetworkPolicies the operator generates to only allow
+	// the traffic required by the Ceph daemons. Policies are not generated with host networking
+	// since they do not apply to pods on the host network.
+	// +nullable
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
 }
 
 // NetworkProviderType defines valid network providers for Rook.
@@ -2996,6 +4702,27 @@ type MultiClusterServiceSpec struct {
 	// services. For example: <clusterid>.<svc>.<ns>.svc.clusterset.local
 	ClusterID string `json:"clusterID,omitempty"`
 }
+
+// NetworkPoliciesSpec represents the settings for the NetworkPolicies generated for the Ceph daemons
+type NetworkPoliciesSpec struct {
+	// Enabled determines whether the operator generates and maintains NetworkPolicies that restrict
+	// ingress to the ceph daemon pods to the ports those daemons serve and to the required sources.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RGWIngressFrom is the list of sources allowed to connect to the object store gateways.
+	// If empty, connections to the gateways are allowed from any source.
+	// +optional
+	RGWIngressFrom []networkingv1.NetworkPolicyPeer `json:"rgwIngressFrom,omitempty"`
+
+	// ClientIngressFrom is the list of additional sources allowed to connect to the mon, mgr, osd
+	// and mds pods, besides the ceph daemons of the cluster and the pods of the operator namespace.
+	// Clients with host networking, such as the csi node plugins and the kernel clients, must be
+	// allowed by their node IPs.
+	// +optional
+	ClientIngressFrom []networkingv1.NetworkPolicyPeer `json:"clientIngressFrom,omitempty"`
+}
+
 type ConnectionsSpec struct {
 	// Encryption settings for the network connections.
 	// +nullable
@@ -3060,6 +4787,28 @@ type DisruptionManagementSpec struct {
 	// Deprecated. Namespace to look for MDBs by the machineDisruptionBudgetController
 	// +optional
 	MachineDisruptionBudgetNamespace string `json:"machineDisruptionBudgetNamespace,omitempty"`
+
+	// DisruptionBudget limits the daemon disruptions that the operator runs at the same time across all
+	// the daemon types: the OSD updates, the mon failovers and the MDS restarts. If not set, the
+	// disruptions are not limited.
+	// +optional
+	// +nullable
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty"`
+}
+
+// DisruptionBudgetSpec limits the concurrent disruptions of the daemons initiated by the operator
+type DisruptionBudgetSpec struct {
+	// MaxConcurrent is the maximum number of disruptions in progress at the same time. An OSD update
+	// counts as one disruption for all the OSDs updated in parallel. The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+
+	// Priorities of the daemon types whose disruptions wait for the budget, by daemon type ("mon",
+	// "osd" or "mds"). The waiting disruption with the highest priority is started first. The defaults
+	// are 300 for the mons, 200 for the OSDs and 100 for the MDSs.
+	// +optional
+	Priorities map[string]int `json:"priorities,omitempty"`
 }
 
 // +genclient
@@ -3068,6 +4817,7 @@ type DisruptionManagementSpec struct {
 
 // CephClient represents a Ceph Client
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=string,JSONPath=`.status.expiresAt`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=cephcl
@@ -3105,8 +4855,29 @@ type ClientSpec struct {
 	// If true, the K8s secret will be deleted, but the cephx keyring will remain until the CR is deleted.
 	// +optional
 	RemoveSecret bool `json:"removeSecret,omitempty"`
+	// TTL is how long the credential of the client is valid after the CephClient is created, to
+	// issue a temporary credential such as a "break glass" admin credential. Once the TTL lapsed,
+	// the operator revokes the cephx key and deletes the secret, and the client is not issued again.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Template is a reference to a CephClientTemplate in the same namespace whose caps are
+	// expanded with the given parameters. Caps set on the client override the template caps
+	// for the same entity.
+	// +optional
+	Template *ClientTemplateReference `json:"template,omitempty"`
 	// +kubebuilder:pruning:PreserveUnknownFields
-	Caps map[string]string `json:"caps"`
+	// +optional
+	Caps map[string]string `json:"caps,omitempty"`
+}
+
+// ClientTemplateReference references a CephClientTemplate and the parameters to expand it with
+type ClientTemplateReference struct {
+	// Name is the name of the CephClientTemplate
+	Name string `json:"name"`
+	// Parameters are the values substituted for the $(name) references in the template caps,
+	// for example "pool", "namespace" or "path"
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // CephClientStatus represents the Status of Ceph Client
@@ -3119,6 +4890,328 @@ type CephClientStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ExpiresAt is the time the credential of a client with a TTL is revoked
+	// +optional
+	// +nullable
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// RevokedAt is the time the credential of the client was revoked after its TTL lapsed
+	// +optional
+	// +nullable
+	RevokedAt *metav1.Time `json:"revokedAt,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephClientTemplate represents a set of parameterized caps shared by Ceph Clients
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=cephclt
+type CephClientTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the specification of a Ceph Client template
+	Spec ClientTemplateSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephClientTemplateList represents a list of Ceph Client templates
+type CephClientTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephClientTemplate `json:"items"`
+}
+
+// ClientTemplateSpec represents the specification of a Ceph Client template
+type ClientTemplateSpec struct {
+	// Caps are the caps of the clients created from the template. The values may reference
+	// the parameters of the client as $(name), e.g. "profile rbd pool=$(pool)".
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Caps map[string]string `json:"caps"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephOSDRemoval represents a request to remove OSDs from the cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cephosdrm
+type CephOSDRemoval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the specification of the OSD removal
+	Spec OSDRemovalSpec `json:"spec"`
+	// Status represents the progress of the OSD removal
+	// +optional
+	Status *OSDRemovalStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephOSDRemovalList represents a list of OSD removals
+type CephOSDRemovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephOSDRemoval `json:"items"`
+}
+
+// OSDRemovalSpec represents the specification of an OSD removal
+type OSDRemovalSpec struct {
+	// OSDIDs are the IDs of the OSDs to remove. The OSDs must be down to be removed.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:message="osdIDs are immutable",rule="self == oldSelf"
+	OSDIDs []int `json:"osdIDs"`
+	// PreservePVC detaches the PVCs of the OSDs from Rook instead of deleting them
+	// +optional
+	PreservePVC bool `json:"preservePVC,omitempty"`
+	// ForceOSDRemoval removes the OSDs even if they are not safe to destroy, at the risk of losing data
+	// +optional
+	ForceOSDRemoval bool `json:"forceOSDRemoval,omitempty"`
+}
+
+// OSDRemovalPhase is the phase of the removal of the OSDs
+type OSDRemovalPhase string
+
+const (
+	// OSDRemovalPending means the OSD was not checked yet
+	OSDRemovalPending OSDRemovalPhase = "Pending"
+	// OSDRemovalWaitingForDown means the OSD is up and is removed once it is down
+	OSDRemovalWaitingForDown OSDRemovalPhase = "WaitingForDown"
+	// OSDRemovalDraining means the OSD is marked out and is removed once it is safe to destroy
+	OSDRemovalDraining OSDRemovalPhase = "Draining"
+	// OSDRemovalRemoved means the OSD was purged from the cluster
+	OSDRemovalRemoved OSDRemovalPhase = "Removed"
+	// OSDRemovalProgressing means some of the OSDs are not removed yet
+	OSDRemovalProgressing OSDRemovalPhase = "Progressing"
+	// OSDRemovalCompleted means all the OSDs are removed
+	OSDRemovalCompleted OSDRemovalPhase = "Completed"
+	// OSDRemovalFailed means the removal failed to be reconciled
+	OSDRemovalFailed OSDRemovalPhase = "Failed"
+)
+
+// OSDRemovalStatus represents the progress of an OSD removal
+type OSDRemovalStatus struct {
+	// Phase is Progressing until all the OSDs are removed, then Completed
+	// +optional
+	Phase OSDRemovalPhase `json:"phase,omitempty"`
+	// OSDs is the progress of the removal of each OSD
+	// +optional
+	OSDs []OSDRemovalProgress `json:"osds,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// OSDRemovalProgress represents the progress of the removal of an OSD
+type OSDRemovalProgress struct {
+	// ID is the ID of the OSD
+	ID int `json:"id"`
+	// Phase is the phase of the removal of the OSD
+	Phase OSDRemovalPhase `json:"phase"`
+	// Message explains what the removal of the OSD is waiting for
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephClientBlocklist represents the addresses of the Ceph clients to blocklist
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cephblocklist
+type CephClientBlocklist struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the addresses to blocklist
+	Spec ClientBlocklistSpec `json:"spec"`
+	// Status represents the addresses blocklisted by Rook
+	// +optional
+	Status *ClientBlocklistStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephClientBlocklistList represents a list of client blocklists
+type CephClientBlocklistList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephClientBlocklist `json:"items"`
+}
+
+// ClientBlocklistSpec represents the addresses of the Ceph clients to blocklist. The addresses
+// removed from the entries are removed from the blocklist.
+type ClientBlocklistSpec struct {
+	// Entries are the addresses to blocklist
+	// +optional
+	Entries []ClientBlocklistEntry `json:"entries,omitempty"`
+}
+
+// ClientBlocklistEntry represents an address to blocklist
+type ClientBlocklistEntry struct {
+	// Address is the IP of the clients to blocklist, the address of a single client as
+	// IP:port/nonce, or a CIDR range of IPs
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// TTL is the duration the address is blocklisted for. If not set, the address is blocklisted
+	// until it is removed from the entries.
+	// +optional
+	// +nullable
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// ClientBlocklistStatus represents the addresses blocklisted by Rook
+type ClientBlocklistStatus struct {
+	// Phase is Ready once the blocklist matches the entries
+	// +optional
+	Phase ConditionType `json:"phase,omitempty"`
+	// Entries are the addresses blocklisted by Rook
+	// +optional
+	Entries []ClientBlocklistEntryStatus `json:"entries,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ClientBlocklistEntryStatus represents an address blocklisted by Rook
+type ClientBlocklistEntryStatus struct {
+	// Address is the address blocklisted
+	Address string `json:"address"`
+	// TTL is the duration the address was blocklisted for
+	// +optional
+	// +nullable
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// AddedTime is when the address was added to the blocklist
+	AddedTime metav1.Time `json:"addedTime"`
+	// ExpirationTime is when the address is removed from the blocklist by Ceph, not set if the
+	// address is blocklisted until it is removed from the entries
+	// +optional
+	// +nullable
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// Expired is whether the TTL of the address elapsed. An expired address is not blocklisted
+	// again unless its TTL changes.
+	// +optional
+	Expired bool `json:"expired,omitempty"`
+	// PreExisting is whether the address was already blocklisted when the entry was added, for
+	// example by the network fencing of the CSI driver. The address is then left in the blocklist
+	// when the entry is removed.
+	// +optional
+	PreExisting bool `json:"preExisting,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephDeviceInventory represents the devices discovered on a node by the discovery daemon
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:printcolumn:name="Devices",type=integer,JSONPath=`.status.deviceCount`
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cephdevinv
+type CephDeviceInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the node of the device inventory
+	Spec DeviceInventorySpec `json:"spec"`
+	// Status represents the devices discovered on the node
+	// +optional
+	Status *DeviceInventoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephDeviceInventoryList represents a list of device inventories
+type CephDeviceInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephDeviceInventory `json:"items"`
+}
+
+// DeviceInventorySpec represents the node of a device inventory
+type DeviceInventorySpec struct {
+	// NodeName is the name of the node the devices were discovered on
+	NodeName string `json:"nodeName"`
+}
+
+// DeviceInventoryStatus represents the devices discovered on a node
+type DeviceInventoryStatus struct {
+	// Devices are the devices discovered on the node
+	// +optional
+	Devices []InventoryDevice `json:"devices,omitempty"`
+	// DeviceCount is the number of devices discovered on the node
+	// +optional
+	DeviceCount int `json:"deviceCount"`
+	// AvailableCount is the number of devices available to be consumed by OSDs
+	// +optional
+	AvailableCount int `json:"availableCount"`
+	// LastUpdateTime is when the devices were last updated from the discovery daemon
+	// +optional
+	// +nullable
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// InventoryDevice represents a device discovered on a node
+type InventoryDevice struct {
+	// Name is the kernel name of the device, for example sdb
+	Name string `json:"name"`
+	// DevLinks are the persistent paths of the device on the node
+	// +optional
+	DevLinks []string `json:"devLinks,omitempty"`
+	// Type is the type of the device, for example disk, part, lvm or crypt
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Vendor is the vendor of the device
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+	// Model is the model of the device
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Serial is the serial number of the device
+	// +optional
+	Serial string `json:"serial,omitempty"`
+	// WWN is the world wide name of the device
+	// +optional
+	WWN string `json:"wwn,omitempty"`
+	// Size is the capacity of the device in bytes
+	// +optional
+	Size uint64 `json:"size,omitempty"`
+	// Rotational is whether the device is rotational, true for hdd and false for ssd and nvme
+	// +optional
+	Rotational bool `json:"rotational,omitempty"`
+	// Filesystem is the filesystem on the device, if any
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+	// Available is whether the device can be consumed by an OSD
+	Available bool `json:"available"`
+	// RejectedReasons are the reasons why the device cannot be consumed by an OSD
+	// +optional
+	RejectedReasons []string `json:"rejectedReasons,omitempty"`
+	// OSDs are the OSDs using the device
+	// +optional
+	OSDs []InventoryDeviceOSD `json:"osds,omitempty"`
+	// SmartStatus is the overall SMART health of the device, PASSED or FAILED, empty if unknown
+	// +optional
+	SmartStatus string `json:"smartStatus,omitempty"`
+}
+
+// InventoryDeviceOSD represents an OSD using a device
+type InventoryDeviceOSD struct {
+	// ID is the ID of the OSD
+	ID int `json:"id"`
+	// ClusterFSID is the FSID of the ceph cluster of the OSD
+	// +optional
+	ClusterFSID string `json:"clusterFSID,omitempty"`
+	// Type is how the OSD uses the device, for example block, db or wal
+	// +optional
+	Type string `json:"type,omitempty"`
 }
 
 // CleanupPolicySpec represents a Ceph Cluster cleanup policy
@@ -3360,6 +5453,146 @@ type StorageScopeSpec struct {
 	// The default is false since data rebalancing can cause temporary cluster slowdown.
 	// +optional
 	AllowOsdCrushWeightUpdate bool `json:"allowOsdCrushWeightUpdate,omitempty"`
+	// ActivationLimit limits the number of OSDs of a node activated in parallel, for example when all
+	// the OSDs of a node start after the node rebooted
+	// +optional
+	ActivationLimit *OSDActivationLimitSpec `json:"activationLimit,omitempty"`
+	// DeviceClassResources are the resource profiles of the OSDs of the device classes, keyed by device
+	// class, so that the OSDs of different device classes on the same node get different resources
+	// +optional
+	DeviceClassResources map[string]OSDResourceProfile `json:"deviceClassResources,omitempty"`
+	// WeightRampUp provisions the new OSDs with a CRUSH weight of 0 and raises their weight in steps
+	// up to the weight of their size, so that the data moves to the new OSDs gradually
+	// +optional
+	// +nullable
+	WeightRampUp *OSDWeightRampUpSpec `json:"weightRampUp,omitempty"`
+	// PrepareConcurrency limits the number of OSD prepare jobs running at the same time. The other
+	// prepare jobs are queued and started as the running jobs complete.
+	// +optional
+	// +nullable
+	PrepareConcurrency *OSDPrepareConcurrencySpec `json:"prepareConcurrency,omitempty"`
+	// PrepareRetry retries the failed OSD prepare jobs with a backoff, and quarantines the devices
+	// whose prepare jobs keep failing
+	// +optional
+	// +nullable
+	PrepareRetry *OSDPrepareRetrySpec `json:"prepareRetry,omitempty"`
+	// UpdateStrategy updates the OSDs of a failure domain in parallel, for example during upgrades
+	// +optional
+	// +nullable
+	UpdateStrategy *OSDUpdateStrategySpec `json:"updateStrategy,omitempty"`
+	// ConfigOverrides set ceph config options for selected OSDs or the OSDs of a device class in the
+	// centralized config store
+	// +optional
+	ConfigOverrides []OSDConfigOverride `json:"configOverrides,omitempty"`
+}
+
+// OSDConfigOverride sets ceph config options for a selection of OSDs. The options of an OSD ID take
+// precedence over the options of its device class.
+type OSDConfigOverride struct {
+	// OSDs are the IDs of the OSDs the options are set for
+	// +optional
+	OSDs []int `json:"osds,omitempty"`
+	// DeviceClass sets the options for all the OSDs of the device class
+	// +optional
+	DeviceClass string `json:"deviceClass,omitempty"`
+	// Config are the ceph config options and their values, for example osd_recovery_max_active
+	Config map[string]string `json:"config"`
+}
+
+// OSDUpdateStrategySpec represents how the OSD deployments are updated
+type OSDUpdateStrategySpec struct {
+	// FailureDomain is the CRUSH bucket type, such as host, rack or zone, whose OSDs are updated in
+	// parallel. The OSDs of a single failure domain are updated at a time. The default is host.
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+	// MaxUnavailable is the maximum number of OSDs of the failure domain updated in parallel. Only
+	// the OSDs that Ceph reports ok-to-stop are updated in parallel. If 0, up to 20 OSDs are updated
+	// in parallel.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+}
+
+// OSDPrepareRetrySpec represents the retries of the failed OSD prepare jobs
+type OSDPrepareRetrySpec struct {
+	// MaxRetries is the number of times the OSD prepare job of a device is retried after a failure
+	// before the device is quarantined. If 0, the devices are never quarantined.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// InitialBackoff is the time to wait before retrying the OSD prepare job of a device after its
+	// first failure. The backoff doubles after each failure. Default is 1m.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// MaxBackoff is the maximum time to wait before retrying the OSD prepare job of a device.
+	// Default is 30m.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// OSDPrepareConcurrencySpec limits the number of OSD prepare jobs running at the same time
+type OSDPrepareConcurrencySpec struct {
+	// MaxJobs is the maximum number of OSD prepare jobs running at the same time in the cluster. If 0,
+	// the number of jobs is not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxJobs int `json:"maxJobs,omitempty"`
+	// MaxJobsPerNode is the maximum number of OSD prepare jobs running at the same time on a node. The
+	// node of a prepare job on a PVC is only known in advance if its PVC is bound to a local volume.
+	// If 0, the number of jobs per node is not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxJobsPerNode int `json:"maxJobsPerNode,omitempty"`
+}
+
+// OSDWeightRampUpSpec raises the CRUSH weight of the new OSDs in steps, to avoid moving the data of
+// a large expansion to the new OSDs at once
+type OSDWeightRampUpSpec struct {
+	// Enabled provisions the new OSDs with a CRUSH weight of 0 and raises their weight in steps
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// StepPercent is the percentage of the weight of the size of an OSD its CRUSH weight is raised
+	// by at each step. The default is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepPercent int `json:"stepPercent,omitempty"`
+	// Interval is the minimum time between two steps. The weight is only raised once the placement
+	// groups are clean again. The default is 30m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// OSDResourceProfile is the resource profile of the OSDs of a device class
+type OSDResourceProfile struct {
+	// Resources are the resource requests and limits of the OSD pods of the device class. The resources
+	// of a node or a device set take precedence.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// MemoryTarget is the osd_memory_target of the OSDs of the device class. Ceph derives the memory
+	// target from the memory limit of the OSD pods if not set.
+	// +optional
+	MemoryTarget *resource.Quantity `json:"memoryTarget,omitempty"`
+}
+
+// OSDActivationLimitSpec limits the number of OSDs of a node activated in parallel. Each OSD pod
+// waits for an activation slot of its node before activating the OSD, and frees the slot once the
+// pod is ready.
+type OSDActivationLimitSpec struct {
+	// MaxConcurrent is the maximum number of OSDs of a node activated in parallel. The OSDs are not
+	// limited if 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// DeviceClasses are the maximum number of OSDs of a device class activated in parallel on a node,
+	// keyed by device class. The OSDs of these device classes have their own activation slots, apart
+	// from the slots of the other OSDs of the node.
+	// +optional
+	DeviceClasses map[string]int `json:"deviceClasses,omitempty"`
+	// Timeout is how long an OSD pod that does not become ready holds its activation slot, after which
+	// the slot is given to the next OSD of the node. Defaults to 10 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // Migration handles the OSD migration
@@ -3369,6 +5602,27 @@ type Migration struct {
 	// +optional
 	// +kubebuilder:validation:Pattern=`^$|^yes-really-migrate-osds$`
 	Confirmation string `json:"confirmation,omitempty"`
+	// HostToPVC migrates the OSDs on the nodes to the OSDs on the PVCs of the storageClassDeviceSets
+	// +optional
+	// +nullable
+	HostToPVC *OSDHostToPVCMigrationSpec `json:"hostToPVC,omitempty"`
+}
+
+// OSDHostToPVCMigrationSpec migrates the OSDs on the nodes to the OSDs on the PVCs of the
+// storageClassDeviceSets, one failure domain at a time. The OSDs on the nodes of a failure domain
+// are marked out, and purged once their data is moved to the other OSDs. The directories of the
+// purged OSDs are then removed from the dataDirHostPath of their nodes. The next failure domain is
+// migrated once the PGs are healthy again.
+type OSDHostToPVCMigrationSpec struct {
+	// A user confirmation to migrate the OSDs on the nodes to PVCs. It destroys all the OSDs on the
+	// nodes, the storageClassDeviceSets must have the capacity to store their data.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^$|^yes-really-migrate-osds-to-pvcs$`
+	Confirmation string `json:"confirmation,omitempty"`
+	// FailureDomain is the type of the CRUSH bucket of the OSDs migrated together, or "osd" to
+	// migrate one OSD at a time. The default is "host".
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
 }
 
 // OSDStore is the backend storage type used for creating the OSDs
@@ -3422,6 +5676,11 @@ type Selection struct {
 	// A regular expression to allow more fine-grained selection of devices with path names
 	// +optional
 	DevicePathFilter string `json:"devicePathFilter,omitempty"`
+	// DeviceIdentityFilter allows or denies devices by their WWN, serial, vendor or model, which remain stable across
+	// reboots unlike the device names. It applies in addition to the other device selection settings.
+	// +optional
+	// +nullable
+	DeviceIdentityFilter *DeviceIdentityFilter `json:"deviceIdentityFilter,omitempty"`
 	// List of devices to use as storage devices
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +nullable
@@ -3430,6 +5689,53 @@ type Selection struct {
 	// PersistentVolumeClaims to use as storage
 	// +optional
 	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+	// Compression is the bluestore compression of the OSDs, applied in the ceph config of each OSD.
+	// The settings of a node override the settings of the cluster.
+	// +optional
+	// +nullable
+	Compression *OSDCompressionSpec `json:"compression,omitempty"`
+}
+
+// OSDCompressionSpec represents the bluestore compression settings of OSDs
+type OSDCompressionSpec struct {
+	// Mode is the bluestore compression mode of the OSDs. The compression mode of a pool overrides it.
+	// +kubebuilder:validation:Enum=none;passive;aggressive;force
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// Algorithm is the bluestore compression algorithm of the OSDs
+	// +kubebuilder:validation:Enum=snappy;zlib;zstd;lz4
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+	// MinBlobSize is the size of the smallest chunks compressed by the OSDs. If not set, the default
+	// of the device type of the OSDs applies.
+	// +optional
+	MinBlobSize *resource.Quantity `json:"minBlobSize,omitempty"`
+}
+
+// DeviceIdentityFilter allows or denies devices by their identity
+type DeviceIdentityFilter struct {
+	// Allow restricts the devices to the devices matching one of the identities. If empty, all the devices are allowed.
+	// +optional
+	Allow []DeviceIdentity `json:"allow,omitempty"`
+	// Deny excludes the devices matching one of the identities, even if they are allowed
+	// +optional
+	Deny []DeviceIdentity `json:"deny,omitempty"`
+}
+
+// DeviceIdentity identifies devices. A device matches the identity if it matches all the fields that are set.
+type DeviceIdentity struct {
+	// WWN is the world wide name of the device, for example "0x5000c500a1b2c3d4"
+	// +optional
+	WWN string `json:"wwn,omitempty"`
+	// Serial is the serial number of the device
+	// +optional
+	Serial string `json:"serial,omitempty"`
+	// Vendor is a regular expression matching the vendor of the device
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+	// Model is a regular expression matching the model of the device
+	// +optional
+	Model string `json:"model,omitempty"`
 }
 
 // PlacementSpec is the placement for core ceph daemons part of the CephCluster CRD
@@ -3514,6 +5820,22 @@ type StorageClassDeviceSet struct {
 	// Whether to encrypt the deviceSet
 	// +optional
 	Encrypted bool `json:"encrypted,omitempty"`
+	// EphemeralMetadataDevice indicates that the "metadata" and "wal" volume claim templates are
+	// provisioned from ephemeral storage such as the local NVMe of a cloud instance, which is lost
+	// when the instance is replaced. When the metadata device of an OSD is lost, the OSD is rebuilt
+	// on its data PVC with the same OSD ID.
+	// +optional
+	EphemeralMetadataDevice bool `json:"ephemeralMetadataDevice,omitempty"`
+	// ScaleDownOSDs removes the OSDs of the highest indexes when the count is reduced. The OSDs are
+	// reweighted to 0 until their data migrated to the other OSDs, then they are purged and their
+	// PVCs are deleted. If false, the OSDs above the count are not removed.
+	// +optional
+	ScaleDownOSDs bool `json:"scaleDownOSDs,omitempty"`
+	// Compression is the bluestore compression of the OSDs of the device set, applied in the ceph
+	// config of each OSD
+	// +optional
+	// +nullable
+	Compression *OSDCompressionSpec `json:"compression,omitempty"`
 }
 
 // +genclient
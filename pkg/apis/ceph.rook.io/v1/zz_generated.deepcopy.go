@@ -23,6 +23,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -187,6 +188,50 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupQuiesceStatus) DeepCopyInto(out *BackupQuiesceStatus) {
+	*out = *in
+	if in.GroupSnapshots != nil {
+		in, out := &in.GroupSnapshots, &out.GroupSnapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupQuiesceStatus.
+func (in *BackupQuiesceStatus) DeepCopy() *BackupQuiesceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupQuiesceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockPoolSeedSpec) DeepCopyInto(out *BlockPoolSeedSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]SeedImageSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockPoolSeedSpec.
+func (in *BlockPoolSeedSpec) DeepCopy() *BlockPoolSeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockPoolSeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketNotificationSpec) DeepCopyInto(out *BucketNotificationSpec) {
 	*out = *in
@@ -297,6 +342,16 @@ func (in *CSIDriverSpec) DeepCopyInto(out *CSIDriverSpec) {
 	*out = *in
 	in.ReadAffinity.DeepCopyInto(&out.ReadAffinity)
 	out.CephFS = in.CephFS
+	if in.ProvisionerPlacement != nil {
+		in, out := &in.ProvisionerPlacement, &out.ProvisionerPlacement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginPlacement != nil {
+		in, out := &in.PluginPlacement, &out.PluginPlacement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -540,6 +595,36 @@ func (in *CephBlockPoolStatus) DeepCopyInto(out *CephBlockPoolStatus) {
 		*out = new(SnapshotScheduleStatusSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImageSnapshotScheduleStatus != nil {
+		in, out := &in.ImageSnapshotScheduleStatus, &out.ImageSnapshotScheduleStatus
+		*out = new(ImageSnapshotScheduleStatus)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(SeedStatus)
+		**out = **in
+	}
+	if in.ProgressEvents != nil {
+		in, out := &in.ProgressEvents, &out.ProgressEvents
+		*out = make([]ProgressEventStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackupQuiesce != nil {
+		in, out := &in.BackupQuiesce, &out.BackupQuiesce
+		*out = new(BackupQuiesceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(PoolMigrationStatus)
+		**out = **in
+	}
+	if in.PGRecommendation != nil {
+		in, out := &in.PGRecommendation, &out.PGRecommendation
+		*out = new(PGRecommendationStatus)
+		**out = **in
+	}
 	if in.Info != nil {
 		in, out := &in.Info, &out.Info
 		*out = make(map[string]string, len(*in))
@@ -807,6 +892,71 @@ func (in *CephClient) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephClientBlocklist) DeepCopyInto(out *CephClientBlocklist) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ClientBlocklistStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephClientBlocklist.
+func (in *CephClientBlocklist) DeepCopy() *CephClientBlocklist {
+	if in == nil {
+		return nil
+	}
+	out := new(CephClientBlocklist)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephClientBlocklist) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephClientBlocklistList) DeepCopyInto(out *CephClientBlocklistList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephClientBlocklist, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephClientBlocklistList.
+func (in *CephClientBlocklistList) DeepCopy() *CephClientBlocklistList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephClientBlocklistList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephClientBlocklistList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephClientList) DeepCopyInto(out *CephClientList) {
 	*out = *in
@@ -850,6 +1000,14 @@ func (in *CephClientStatus) DeepCopyInto(out *CephClientStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RevokedAt != nil {
+		in, out := &in.RevokedAt, &out.RevokedAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -863,6 +1021,66 @@ func (in *CephClientStatus) DeepCopy() *CephClientStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephClientTemplate) DeepCopyInto(out *CephClientTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephClientTemplate.
+func (in *CephClientTemplate) DeepCopy() *CephClientTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CephClientTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephClientTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephClientTemplateList) DeepCopyInto(out *CephClientTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephClientTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephClientTemplateList.
+func (in *CephClientTemplateList) DeepCopy() *CephClientTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephClientTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephClientTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephCluster) DeepCopyInto(out *CephCluster) {
 	*out = *in
@@ -925,6 +1143,31 @@ func (in *CephClusterHealthCheckSpec) DeepCopyInto(out *CephClusterHealthCheckSp
 			(*out)[key] = outVal
 		}
 	}
+	if in.OSDMemoryPressure != nil {
+		in, out := &in.OSDMemoryPressure, &out.OSDMemoryPressure
+		*out = new(OSDMemoryPressureSpec)
+		**out = **in
+	}
+	if in.OSDReplacement != nil {
+		in, out := &in.OSDReplacement, &out.OSDReplacement
+		*out = new(OSDReplacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSDFlapping != nil {
+		in, out := &in.OSDFlapping, &out.OSDFlapping
+		*out = new(OSDFlappingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlacementDrift != nil {
+		in, out := &in.PlacementDrift, &out.PlacementDrift
+		*out = new(PlacementDriftSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryDeferral != nil {
+		in, out := &in.RecoveryDeferral, &out.RecoveryDeferral
+		*out = new(RecoveryDeferralSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -971,6 +1214,27 @@ func (in *CephClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephConfigRolloutSpec) DeepCopyInto(out *CephConfigRolloutSpec) {
+	*out = *in
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephConfigRolloutSpec.
+func (in *CephConfigRolloutSpec) DeepCopy() *CephConfigRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CephConfigRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephDaemonsVersions) DeepCopyInto(out *CephDaemonsVersions) {
 	*out = *in
@@ -1064,6 +1328,71 @@ func (in *CephExporterSpec) DeepCopy() *CephExporterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephDeviceInventory) DeepCopyInto(out *CephDeviceInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(DeviceInventoryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephDeviceInventory.
+func (in *CephDeviceInventory) DeepCopy() *CephDeviceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(CephDeviceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephDeviceInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephDeviceInventoryList) DeepCopyInto(out *CephDeviceInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephDeviceInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephDeviceInventoryList.
+func (in *CephDeviceInventoryList) DeepCopy() *CephDeviceInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephDeviceInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephDeviceInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephFilesystem) DeepCopyInto(out *CephFilesystem) {
 	*out = *in
@@ -1209,6 +1538,11 @@ func (in *CephFilesystemStatus) DeepCopyInto(out *CephFilesystemStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(SeedStatus)
+		**out = **in
+	}
 	if in.MirroringStatus != nil {
 		in, out := &in.MirroringStatus, &out.MirroringStatus
 		*out = new(FilesystemMirroringInfoSpec)
@@ -1457,31 +1791,31 @@ func (in *CephNFSList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectRealm) DeepCopyInto(out *CephObjectRealm) {
+func (in *CephOSDRemoval) DeepCopyInto(out *CephOSDRemoval) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(Status)
+		*out = new(OSDRemovalStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealm.
-func (in *CephObjectRealm) DeepCopy() *CephObjectRealm {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephOSDRemoval.
+func (in *CephOSDRemoval) DeepCopy() *CephOSDRemoval {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectRealm)
+	out := new(CephOSDRemoval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
+func (in *CephOSDRemoval) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1489,13 +1823,13 @@ func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
+func (in *CephOSDRemovalList) DeepCopyInto(out *CephOSDRemovalList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CephObjectRealm, len(*in))
+		*out = make([]CephOSDRemoval, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1503,18 +1837,18 @@ func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealmList.
-func (in *CephObjectRealmList) DeepCopy() *CephObjectRealmList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephOSDRemovalList.
+func (in *CephOSDRemovalList) DeepCopy() *CephOSDRemovalList {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectRealmList)
+	out := new(CephOSDRemovalList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
+func (in *CephOSDRemovalList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1522,22 +1856,87 @@ func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStore) DeepCopyInto(out *CephObjectStore) {
+func (in *CephObjectRealm) DeepCopyInto(out *CephObjectRealm) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(ObjectStoreStatus)
+		*out = new(Status)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStore.
-func (in *CephObjectStore) DeepCopy() *CephObjectStore {
-	if in == nil {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealm.
+func (in *CephObjectRealm) DeepCopy() *CephObjectRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealmList.
+func (in *CephObjectRealmList) DeepCopy() *CephObjectRealmList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectRealmList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStore) DeepCopyInto(out *CephObjectStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ObjectStoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStore.
+func (in *CephObjectStore) DeepCopy() *CephObjectStore {
+	if in == nil {
 		return nil
 	}
 	out := new(CephObjectStore)
@@ -1688,7 +2087,7 @@ func (in *CephObjectZoneGroup) DeepCopyInto(out *CephObjectZoneGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(Status)
@@ -1862,6 +2261,11 @@ func (in *CephStatus) DeepCopyInto(out *CephStatus) {
 		*out = new(CephDaemonsVersions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProgressEvents != nil {
+		in, out := &in.ProgressEvents, &out.ProgressEvents
+		*out = make([]ProgressEventStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1977,9 +2381,112 @@ func (in *CleanupPolicySpec) DeepCopy() *CleanupPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientBlocklistEntry) DeepCopyInto(out *ClientBlocklistEntry) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientBlocklistEntry.
+func (in *ClientBlocklistEntry) DeepCopy() *ClientBlocklistEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientBlocklistEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientBlocklistEntryStatus) DeepCopyInto(out *ClientBlocklistEntryStatus) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.AddedTime.DeepCopyInto(&out.AddedTime)
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientBlocklistEntryStatus.
+func (in *ClientBlocklistEntryStatus) DeepCopy() *ClientBlocklistEntryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientBlocklistEntryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientBlocklistSpec) DeepCopyInto(out *ClientBlocklistSpec) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ClientBlocklistEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientBlocklistSpec.
+func (in *ClientBlocklistSpec) DeepCopy() *ClientBlocklistSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientBlocklistSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientBlocklistStatus) DeepCopyInto(out *ClientBlocklistStatus) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ClientBlocklistEntryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientBlocklistStatus.
+func (in *ClientBlocklistStatus) DeepCopy() *ClientBlocklistStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientBlocklistStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientSpec) DeepCopyInto(out *ClientSpec) {
 	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ClientTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Caps != nil {
 		in, out := &in.Caps, &out.Caps
 		*out = make(map[string]string, len(*in))
@@ -2000,10 +2507,57 @@ func (in *ClientSpec) DeepCopy() *ClientSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTemplateReference) DeepCopyInto(out *ClientTemplateReference) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTemplateReference.
+func (in *ClientTemplateReference) DeepCopy() *ClientTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTemplateSpec) DeepCopyInto(out *ClientTemplateSpec) {
+	*out = *in
+	if in.Caps != nil {
+		in, out := &in.Caps, &out.Caps
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTemplateSpec.
+func (in *ClientTemplateSpec) DeepCopy() *ClientTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCephxConfig) DeepCopyInto(out *ClusterCephxConfig) {
 	*out = *in
 	out.Daemon = in.Daemon
+	in.Mon.DeepCopyInto(&out.Mon)
 	return
 }
 
@@ -2025,6 +2579,11 @@ func (in *ClusterCephxStatus) DeepCopyInto(out *ClusterCephxStatus) {
 		*out = new(CephxStatus)
 		**out = **in
 	}
+	if in.Mon != nil {
+		in, out := &in.Mon, &out.Mon
+		*out = new(MonCephxStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2038,12 +2597,28 @@ func (in *ClusterCephxStatus) DeepCopy() *ClusterCephxStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileReference) DeepCopyInto(out *ClusterProfileReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileReference.
+func (in *ClusterProfileReference) DeepCopy() *ClusterProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSecuritySpec) DeepCopyInto(out *ClusterSecuritySpec) {
 	*out = *in
 	in.KeyManagementService.DeepCopyInto(&out.KeyManagementService)
 	out.KeyRotation = in.KeyRotation
-	out.CephX = in.CephX
+	in.CephX.DeepCopyInto(&out.CephX)
 	return
 }
 
@@ -2057,9 +2632,58 @@ func (in *ClusterSecuritySpec) DeepCopy() *ClusterSecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCreationCheckpoint) DeepCopyInto(out *ClusterCreationCheckpoint) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCreationCheckpoint.
+func (in *ClusterCreationCheckpoint) DeepCopy() *ClusterCreationCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCreationCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCreationStatus) DeepCopyInto(out *ClusterCreationStatus) {
+	*out = *in
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]ClusterCreationCheckpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCreationStatus.
+func (in *ClusterCreationStatus) DeepCopy() *ClusterCreationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCreationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
+	if in.ProfileRef != nil {
+		in, out := &in.ProfileRef, &out.ProfileRef
+		*out = new(ClusterProfileReference)
+		**out = **in
+	}
 	out.CephVersion = in.CephVersion
 	in.Storage.DeepCopyInto(&out.Storage)
 	if in.Annotations != nil {
@@ -2118,10 +2742,10 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*out)[key] = val
 		}
 	}
-	out.DisruptionManagement = in.DisruptionManagement
+	in.DisruptionManagement.DeepCopyInto(&out.DisruptionManagement)
 	in.Mon.DeepCopyInto(&out.Mon)
 	out.CrashCollector = in.CrashCollector
-	out.Dashboard = in.Dashboard
+	in.Dashboard.DeepCopyInto(&out.Dashboard)
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	out.External = in.External
 	in.Mgr.DeepCopyInto(&out.Mgr)
@@ -2164,6 +2788,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CephConfigRollout != nil {
+		in, out := &in.CephConfigRollout, &out.CephConfigRollout
+		*out = new(CephConfigRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DebugLogging != nil {
+		in, out := &in.DebugLogging, &out.DebugLogging
+		*out = new(DebugLoggingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2207,32 +2841,127 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ClusterVersion)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
-func (in *ClusterStatus) DeepCopy() *ClusterStatus {
-	if in == nil {
-		return nil
+	if in.MonFailoverPreview != nil {
+		in, out := &in.MonFailoverPreview, &out.MonFailoverPreview
+		*out = new(MonFailoverPreviewStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(ClusterStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterVersion) DeepCopyInto(out *ClusterVersion) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersion.
-func (in *ClusterVersion) DeepCopy() *ClusterVersion {
-	if in == nil {
-		return nil
+	if in.SupportBundle != nil {
+		in, out := &in.SupportBundle, &out.SupportBundle
+		*out = new(SupportBundleStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(ClusterVersion)
-	in.DeepCopyInto(out)
+	if in.MonFailoverBackoff != nil {
+		in, out := &in.MonFailoverBackoff, &out.MonFailoverBackoff
+		*out = new(MonFailoverBackoffStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonVolumeExpansion != nil {
+		in, out := &in.MonVolumeExpansion, &out.MonVolumeExpansion
+		*out = make([]MonVolumeExpansionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonPVCMigration != nil {
+		in, out := &in.MonPVCMigration, &out.MonPVCMigration
+		*out = new(MonPVCMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSDPVCMigration != nil {
+		in, out := &in.OSDPVCMigration, &out.OSDPVCMigration
+		*out = new(OSDPVCMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageChanges != nil {
+		in, out := &in.StorageChanges, &out.StorageChanges
+		*out = new(StorageChangesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSDPrepareFailures != nil {
+		in, out := &in.OSDPrepareFailures, &out.OSDPrepareFailures
+		*out = make([]OSDPrepareFailureStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MonClockSkew != nil {
+		in, out := &in.MonClockSkew, &out.MonClockSkew
+		*out = make([]MonClockSkewStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Mgr != nil {
+		in, out := &in.Mgr, &out.Mgr
+		*out = new(MgrStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonDiskUsage != nil {
+		in, out := &in.MonDiskUsage, &out.MonDiskUsage
+		*out = make([]MonDiskUsageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mons != nil {
+		in, out := &in.Mons, &out.Mons
+		*out = make([]MonStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EndpointChecks != nil {
+		in, out := &in.EndpointChecks, &out.EndpointChecks
+		*out = make([]EndpointCheckStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Creation != nil {
+		in, out := &in.Creation, &out.Creation
+		*out = new(ClusterCreationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonZones != nil {
+		in, out := &in.MonZones, &out.MonZones
+		*out = make([]MonZoneStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeferredMaintenance != nil {
+		in, out := &in.DeferredMaintenance, &out.DeferredMaintenance
+		*out = make([]DeferredMaintenanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dashboard != nil {
+		in, out := &in.Dashboard, &out.Dashboard
+		*out = new(DashboardStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersion) DeepCopyInto(out *ClusterVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersion.
+func (in *ClusterVersion) DeepCopy() *ClusterVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersion)
+	in.DeepCopyInto(out)
 	return out
 }
 
@@ -2378,1540 +3107,3177 @@ func (in *DaemonHealthSpec) DeepCopy() *DaemonHealthSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+func (in *DashboardRoleScope) DeepCopyInto(out *DashboardRoleScope) {
 	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]DashboardPermission, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
-func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardRoleScope.
+func (in *DashboardRoleScope) DeepCopy() *DashboardRoleScope {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardSpec)
+	out := new(DashboardRoleScope)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Device) DeepCopyInto(out *Device) {
+func (in *DashboardRoleSpec) DeepCopyInto(out *DashboardRoleSpec) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]DashboardRoleScope, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
-func (in *Device) DeepCopy() *Device {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardRoleSpec.
+func (in *DashboardRoleSpec) DeepCopy() *DashboardRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Device)
+	out := new(DashboardRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeviceClasses) DeepCopyInto(out *DeviceClasses) {
+func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
 	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]DashboardUserSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]DashboardRoleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClasses.
-func (in *DeviceClasses) DeepCopy() *DeviceClasses {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
+func (in *DashboardSpec) DeepCopy() *DashboardSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeviceClasses)
+	out := new(DashboardSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DisruptionManagementSpec) DeepCopyInto(out *DisruptionManagementSpec) {
+func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
 	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]DashboardUserStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionManagementSpec.
-func (in *DisruptionManagementSpec) DeepCopy() *DisruptionManagementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardStatus.
+func (in *DashboardStatus) DeepCopy() *DashboardStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DisruptionManagementSpec)
+	out := new(DashboardStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EncryptionSpec) DeepCopyInto(out *EncryptionSpec) {
+func (in *DashboardUserSpec) DeepCopyInto(out *DashboardUserSpec) {
 	*out = *in
+	in.PasswordSecret.DeepCopyInto(&out.PasswordSecret)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSpec.
-func (in *EncryptionSpec) DeepCopy() *EncryptionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserSpec.
+func (in *DashboardUserSpec) DeepCopy() *DashboardUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EncryptionSpec)
+	out := new(DashboardUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointAddress) DeepCopyInto(out *EndpointAddress) {
+func (in *DashboardUserStatus) DeepCopyInto(out *DashboardUserStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointAddress.
-func (in *EndpointAddress) DeepCopy() *EndpointAddress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardUserStatus.
+func (in *DashboardUserStatus) DeepCopy() *DashboardUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointAddress)
+	out := new(DashboardUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ErasureCodedSpec) DeepCopyInto(out *ErasureCodedSpec) {
+func (in *DebugLoggingSpec) DeepCopyInto(out *DebugLoggingSpec) {
 	*out = *in
+	out.Duration = in.Duration
+	if in.Daemons != nil {
+		in, out := &in.Daemons, &out.Daemons
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErasureCodedSpec.
-func (in *ErasureCodedSpec) DeepCopy() *ErasureCodedSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugLoggingSpec.
+func (in *DebugLoggingSpec) DeepCopy() *DebugLoggingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ErasureCodedSpec)
+	out := new(DebugLoggingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalSpec) DeepCopyInto(out *ExternalSpec) {
+func (in *DeferredMaintenanceStatus) DeepCopyInto(out *DeferredMaintenanceStatus) {
 	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSpec.
-func (in *ExternalSpec) DeepCopy() *ExternalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredMaintenanceStatus.
+func (in *DeferredMaintenanceStatus) DeepCopy() *DeferredMaintenanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalSpec)
+	out := new(DeferredMaintenanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FSMirroringSpec) DeepCopyInto(out *FSMirroringSpec) {
+func (in *Device) DeepCopyInto(out *Device) {
 	*out = *in
-	if in.Peers != nil {
-		in, out := &in.Peers, &out.Peers
-		*out = new(MirroringPeerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SnapshotSchedules != nil {
-		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
-		*out = make([]SnapshotScheduleSpec, len(*in))
-		copy(*out, *in)
-	}
-	if in.SnapshotRetention != nil {
-		in, out := &in.SnapshotRetention, &out.SnapshotRetention
-		*out = make([]SnapshotScheduleRetentionSpec, len(*in))
-		copy(*out, *in)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSMirroringSpec.
-func (in *FSMirroringSpec) DeepCopy() *FSMirroringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
+func (in *Device) DeepCopy() *Device {
 	if in == nil {
 		return nil
 	}
-	out := new(FSMirroringSpec)
+	out := new(Device)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemMirrorInfoPeerSpec) DeepCopyInto(out *FilesystemMirrorInfoPeerSpec) {
+func (in *DeviceClasses) DeepCopyInto(out *DeviceClasses) {
 	*out = *in
-	if in.Remote != nil {
-		in, out := &in.Remote, &out.Remote
-		*out = new(PeerRemoteSpec)
-		**out = **in
-	}
-	if in.Stats != nil {
-		in, out := &in.Stats, &out.Stats
-		*out = new(PeerStatSpec)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirrorInfoPeerSpec.
-func (in *FilesystemMirrorInfoPeerSpec) DeepCopy() *FilesystemMirrorInfoPeerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClasses.
+func (in *DeviceClasses) DeepCopy() *DeviceClasses {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemMirrorInfoPeerSpec)
+	out := new(DeviceClasses)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemMirroringInfo) DeepCopyInto(out *FilesystemMirroringInfo) {
+func (in *DeviceIdentity) DeepCopyInto(out *DeviceIdentity) {
 	*out = *in
-	if in.Filesystems != nil {
-		in, out := &in.Filesystems, &out.Filesystems
-		*out = make([]FilesystemsSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringInfo.
-func (in *FilesystemMirroringInfo) DeepCopy() *FilesystemMirroringInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceIdentity.
+func (in *DeviceIdentity) DeepCopy() *DeviceIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemMirroringInfo)
+	out := new(DeviceIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemMirroringInfoSpec) DeepCopyInto(out *FilesystemMirroringInfoSpec) {
+func (in *DeviceIdentityFilter) DeepCopyInto(out *DeviceIdentityFilter) {
 	*out = *in
-	if in.FilesystemMirroringAllInfo != nil {
-		in, out := &in.FilesystemMirroringAllInfo, &out.FilesystemMirroringAllInfo
-		*out = make([]FilesystemMirroringInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]DeviceIdentity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]DeviceIdentity, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringInfoSpec.
-func (in *FilesystemMirroringInfoSpec) DeepCopy() *FilesystemMirroringInfoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceIdentityFilter.
+func (in *DeviceIdentityFilter) DeepCopy() *DeviceIdentityFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemMirroringInfoSpec)
+	out := new(DeviceIdentityFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemMirroringSpec) DeepCopyInto(out *FilesystemMirroringSpec) {
+func (in *DeviceInventorySpec) DeepCopyInto(out *DeviceInventorySpec) {
 	*out = *in
-	in.Placement.DeepCopyInto(&out.Placement)
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(Annotations, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringSpec.
-func (in *FilesystemMirroringSpec) DeepCopy() *FilesystemMirroringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInventorySpec.
+func (in *DeviceInventorySpec) DeepCopy() *DeviceInventorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemMirroringSpec)
+	out := new(DeviceInventorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemSnapshotScheduleStatusRetention) DeepCopyInto(out *FilesystemSnapshotScheduleStatusRetention) {
+func (in *DeviceInventoryStatus) DeepCopyInto(out *DeviceInventoryStatus) {
 	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]InventoryDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotScheduleStatusRetention.
-func (in *FilesystemSnapshotScheduleStatusRetention) DeepCopy() *FilesystemSnapshotScheduleStatusRetention {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInventoryStatus.
+func (in *DeviceInventoryStatus) DeepCopy() *DeviceInventoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemSnapshotScheduleStatusRetention)
+	out := new(DeviceInventoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemSnapshotScheduleStatusSpec) DeepCopyInto(out *FilesystemSnapshotScheduleStatusSpec) {
+func (in *DisruptionBudgetSpec) DeepCopyInto(out *DisruptionBudgetSpec) {
 	*out = *in
-	if in.SnapshotSchedules != nil {
-		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
-		*out = make([]FilesystemSnapshotSchedulesSpec, len(*in))
-		copy(*out, *in)
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotScheduleStatusSpec.
-func (in *FilesystemSnapshotScheduleStatusSpec) DeepCopy() *FilesystemSnapshotScheduleStatusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetSpec.
+func (in *DisruptionBudgetSpec) DeepCopy() *DisruptionBudgetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemSnapshotScheduleStatusSpec)
+	out := new(DisruptionBudgetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemSnapshotSchedulesSpec) DeepCopyInto(out *FilesystemSnapshotSchedulesSpec) {
+func (in *DisruptionManagementSpec) DeepCopyInto(out *DisruptionManagementSpec) {
 	*out = *in
-	out.Retention = in.Retention
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionManagementSpec.
+func (in *DisruptionManagementSpec) DeepCopy() *DisruptionManagementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionManagementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionSpec) DeepCopyInto(out *EncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSpec.
+func (in *EncryptionSpec) DeepCopy() *EncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointAddress) DeepCopyInto(out *EndpointAddress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointAddress.
+func (in *EndpointAddress) DeepCopy() *EndpointAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointCheckStatus) DeepCopyInto(out *EndpointCheckStatus) {
+	*out = *in
+	in.LastChecked.DeepCopyInto(&out.LastChecked)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointCheckStatus.
+func (in *EndpointCheckStatus) DeepCopy() *EndpointCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErasureCodedSpec) DeepCopyInto(out *ErasureCodedSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErasureCodedSpec.
+func (in *ErasureCodedSpec) DeepCopy() *ErasureCodedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ErasureCodedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMonSpec) DeepCopyInto(out *ExternalMonSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMonSpec.
+func (in *ExternalMonSpec) DeepCopy() *ExternalMonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSpec) DeepCopyInto(out *ExternalSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSpec.
+func (in *ExternalSpec) DeepCopy() *ExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FSMirroringSpec) DeepCopyInto(out *FSMirroringSpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = new(MirroringPeerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotSchedules != nil {
+		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
+		*out = make([]SnapshotScheduleSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SnapshotRetention != nil {
+		in, out := &in.SnapshotRetention, &out.SnapshotRetention
+		*out = make([]SnapshotScheduleRetentionSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSMirroringSpec.
+func (in *FSMirroringSpec) DeepCopy() *FSMirroringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FSMirroringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMirrorInfoPeerSpec) DeepCopyInto(out *FilesystemMirrorInfoPeerSpec) {
+	*out = *in
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(PeerRemoteSpec)
+		**out = **in
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(PeerStatSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirrorInfoPeerSpec.
+func (in *FilesystemMirrorInfoPeerSpec) DeepCopy() *FilesystemMirrorInfoPeerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMirrorInfoPeerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMirroringInfo) DeepCopyInto(out *FilesystemMirroringInfo) {
+	*out = *in
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]FilesystemsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringInfo.
+func (in *FilesystemMirroringInfo) DeepCopy() *FilesystemMirroringInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMirroringInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMirroringInfoSpec) DeepCopyInto(out *FilesystemMirroringInfoSpec) {
+	*out = *in
+	if in.FilesystemMirroringAllInfo != nil {
+		in, out := &in.FilesystemMirroringAllInfo, &out.FilesystemMirroringAllInfo
+		*out = make([]FilesystemMirroringInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringInfoSpec.
+func (in *FilesystemMirroringInfoSpec) DeepCopy() *FilesystemMirroringInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMirroringInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMirroringSpec) DeepCopyInto(out *FilesystemMirroringSpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMirroringSpec.
+func (in *FilesystemMirroringSpec) DeepCopy() *FilesystemMirroringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMirroringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemPinnedSubtreeSpec) DeepCopyInto(out *FilesystemPinnedSubtreeSpec) {
+	*out = *in
+	in.Pinning.DeepCopyInto(&out.Pinning)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemPinnedSubtreeSpec.
+func (in *FilesystemPinnedSubtreeSpec) DeepCopy() *FilesystemPinnedSubtreeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemPinnedSubtreeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSeedSpec) DeepCopyInto(out *FilesystemSeedSpec) {
+	*out = *in
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]SeedDirectorySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSeedSpec.
+func (in *FilesystemSeedSpec) DeepCopy() *FilesystemSeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSnapshotScheduleStatusRetention) DeepCopyInto(out *FilesystemSnapshotScheduleStatusRetention) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotScheduleStatusRetention.
+func (in *FilesystemSnapshotScheduleStatusRetention) DeepCopy() *FilesystemSnapshotScheduleStatusRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSnapshotScheduleStatusRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSnapshotScheduleStatusSpec) DeepCopyInto(out *FilesystemSnapshotScheduleStatusSpec) {
+	*out = *in
+	if in.SnapshotSchedules != nil {
+		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
+		*out = make([]FilesystemSnapshotSchedulesSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotScheduleStatusSpec.
+func (in *FilesystemSnapshotScheduleStatusSpec) DeepCopy() *FilesystemSnapshotScheduleStatusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSnapshotScheduleStatusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSnapshotSchedulesSpec) DeepCopyInto(out *FilesystemSnapshotSchedulesSpec) {
+	*out = *in
+	out.Retention = in.Retention
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotSchedulesSpec.
+func (in *FilesystemSnapshotSchedulesSpec) DeepCopy() *FilesystemSnapshotSchedulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSnapshotSchedulesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSpec) DeepCopyInto(out *FilesystemSpec) {
+	*out = *in
+	in.MetadataPool.DeepCopyInto(&out.MetadataPool)
+	if in.DataPools != nil {
+		in, out := &in.DataPools, &out.DataPools
+		*out = make([]NamedPoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.MetadataServer.DeepCopyInto(&out.MetadataServer)
+	if in.Mirroring != nil {
+		in, out := &in.Mirroring, &out.Mirroring
+		*out = new(FSMirroringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StatusCheck.DeepCopyInto(&out.StatusCheck)
+	if in.PinnedSubtrees != nil {
+		in, out := &in.PinnedSubtrees, &out.PinnedSubtrees
+		*out = make([]FilesystemPinnedSubtreeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(FilesystemSeedSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSpec.
+func (in *FilesystemSpec) DeepCopy() *FilesystemSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemsSpec) DeepCopyInto(out *FilesystemsSpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]FilesystemMirrorInfoPeerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemsSpec.
+func (in *FilesystemsSpec) DeepCopy() *FilesystemsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaneshaRADOSSpec) DeepCopyInto(out *GaneshaRADOSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaneshaRADOSSpec.
+func (in *GaneshaRADOSSpec) DeepCopy() *GaneshaRADOSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GaneshaRADOSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaneshaServerSpec) DeepCopyInto(out *GaneshaServerSpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaneshaServerSpec.
+func (in *GaneshaServerSpec) DeepCopy() *GaneshaServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GaneshaServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayGroupSpec) DeepCopyInto(out *GatewayGroupSpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayGroupSpec.
+func (in *GatewayGroupSpec) DeepCopy() *GatewayGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayGroupStatus) DeepCopyInto(out *GatewayGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayGroupStatus.
+func (in *GatewayGroupStatus) DeepCopy() *GatewayGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ExternalRgwEndpoints != nil {
+		in, out := &in.ExternalRgwEndpoints, &out.ExternalRgwEndpoints
+		*out = make([]EndpointAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(RGWServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpsLogSidecar != nil {
+		in, out := &in.OpsLogSidecar, &out.OpsLogSidecar
+		*out = new(OpsLogSidecar)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DashboardEnabled != nil {
+		in, out := &in.DashboardEnabled, &out.DashboardEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalVolumeMounts != nil {
+		in, out := &in.AdditionalVolumeMounts, &out.AdditionalVolumeMounts
+		*out = make(AdditionalVolumeMounts, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RgwConfig != nil {
+		in, out := &in.RgwConfig, &out.RgwConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RgwConfigFromSecret != nil {
+		in, out := &in.RgwConfigFromSecret, &out.RgwConfigFromSecret
+		*out = make(map[string]corev1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RgwCommandFlags != nil {
+		in, out := &in.RgwCommandFlags, &out.RgwCommandFlags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReadAffinity != nil {
+		in, out := &in.ReadAffinity, &out.ReadAffinity
+		*out = new(RgwReadAffinity)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]GatewayGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
+func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPEndpointSpec) DeepCopyInto(out *HTTPEndpointSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPEndpointSpec.
+func (in *HTTPEndpointSpec) DeepCopy() *HTTPEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
+func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HybridStorageSpec) DeepCopyInto(out *HybridStorageSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HybridStorageSpec.
+func (in *HybridStorageSpec) DeepCopy() *HybridStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HybridStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSnapshotScheduleSpec) DeepCopyInto(out *ImageSnapshotScheduleSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSnapshotScheduleSpec.
+func (in *ImageSnapshotScheduleSpec) DeepCopy() *ImageSnapshotScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSnapshotScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSnapshotScheduleStatus) DeepCopyInto(out *ImageSnapshotScheduleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSnapshotScheduleStatus.
+func (in *ImageSnapshotScheduleStatus) DeepCopy() *ImageSnapshotScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSnapshotScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryDevice) DeepCopyInto(out *InventoryDevice) {
+	*out = *in
+	if in.DevLinks != nil {
+		in, out := &in.DevLinks, &out.DevLinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RejectedReasons != nil {
+		in, out := &in.RejectedReasons, &out.RejectedReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OSDs != nil {
+		in, out := &in.OSDs, &out.OSDs
+		*out = make([]InventoryDeviceOSD, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryDevice.
+func (in *InventoryDevice) DeepCopy() *InventoryDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryDeviceOSD) DeepCopyInto(out *InventoryDeviceOSD) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryDeviceOSD.
+func (in *InventoryDeviceOSD) DeepCopy() *InventoryDeviceOSD {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryDeviceOSD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaEndpointSpec) DeepCopyInto(out *KafkaEndpointSpec) {
+	*out = *in
+	if in.UserSecretRef != nil {
+		in, out := &in.UserSecretRef, &out.UserSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaEndpointSpec.
+func (in *KafkaEndpointSpec) DeepCopy() *KafkaEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosConfigFiles) DeepCopyInto(out *KerberosConfigFiles) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(ConfigFileVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosConfigFiles.
+func (in *KerberosConfigFiles) DeepCopy() *KerberosConfigFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosConfigFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosKeytabFile) DeepCopyInto(out *KerberosKeytabFile) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(ConfigFileVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosKeytabFile.
+func (in *KerberosKeytabFile) DeepCopy() *KerberosKeytabFile {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosKeytabFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosSpec) DeepCopyInto(out *KerberosSpec) {
+	*out = *in
+	in.ConfigFiles.DeepCopyInto(&out.ConfigFiles)
+	in.KeytabFile.DeepCopyInto(&out.KeytabFile)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosSpec.
+func (in *KerberosSpec) DeepCopy() *KerberosSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyManagementServiceSpec) DeepCopyInto(out *KeyManagementServiceSpec) {
+	*out = *in
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyManagementServiceSpec.
+func (in *KeyManagementServiceSpec) DeepCopy() *KeyManagementServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyManagementServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationSpec) DeepCopyInto(out *KeyRotationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationSpec.
+func (in *KeyRotationSpec) DeepCopy() *KeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeystoneSpec) DeepCopyInto(out *KeystoneSpec) {
+	*out = *in
+	if in.AcceptedRoles != nil {
+		in, out := &in.AcceptedRoles, &out.AcceptedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenCacheSize != nil {
+		in, out := &in.TokenCacheSize, &out.TokenCacheSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.RevocationInterval != nil {
+		in, out := &in.RevocationInterval, &out.RevocationInterval
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystoneSpec.
+func (in *KeystoneSpec) DeepCopy() *KeystoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeystoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Labels) DeepCopyInto(out *Labels) {
+	{
+		in := &in
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
+func (in Labels) DeepCopy() Labels {
+	if in == nil {
+		return nil
+	}
+	out := new(Labels)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in LabelsSpec) DeepCopyInto(out *LabelsSpec) {
+	{
+		in := &in
+		*out = make(LabelsSpec, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(Labels, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelsSpec.
+func (in LabelsSpec) DeepCopy() LabelsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelsSpec)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalCephxStatus) DeepCopyInto(out *LocalCephxStatus) {
+	*out = *in
+	out.Daemon = in.Daemon
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalCephxStatus.
+func (in *LocalCephxStatus) DeepCopy() *LocalCephxStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalCephxStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+	*out = *in
+	if in.MaxLogSize != nil {
+		in, out := &in.MaxLogSize, &out.MaxLogSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
+func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataServerSpec) DeepCopyInto(out *MetadataServerSpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataServerSpec.
+func (in *MetadataServerSpec) DeepCopy() *MetadataServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsTLSSpec) DeepCopyInto(out *MetricsTLSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsTLSSpec.
+func (in *MetricsTLSSpec) DeepCopy() *MetricsTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MgrSpec) DeepCopyInto(out *MgrSpec) {
+	*out = *in
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]Module, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MgrSpec.
+func (in *MgrSpec) DeepCopy() *MgrSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MgrSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MgrStatus) DeepCopyInto(out *MgrStatus) {
+	*out = *in
+	if in.Standbys != nil {
+		in, out := &in.Standbys, &out.Standbys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastFailoverTime != nil {
+		in, out := &in.LastFailoverTime, &out.LastFailoverTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MgrStatus.
+func (in *MgrStatus) DeepCopy() *MgrStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MgrStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Migration) DeepCopyInto(out *Migration) {
+	*out = *in
+	if in.HostToPVC != nil {
+		in, out := &in.HostToPVC, &out.HostToPVC
+		*out = new(OSDHostToPVCMigrationSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migration.
+func (in *Migration) DeepCopy() *Migration {
+	if in == nil {
+		return nil
+	}
+	out := new(Migration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorHealthCheckSpec) DeepCopyInto(out *MirrorHealthCheckSpec) {
+	*out = *in
+	in.Mirror.DeepCopyInto(&out.Mirror)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorHealthCheckSpec.
+func (in *MirrorHealthCheckSpec) DeepCopy() *MirrorHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringInfo) DeepCopyInto(out *MirroringInfo) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]PeersSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringInfo.
+func (in *MirroringInfo) DeepCopy() *MirroringInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringInfoSpec) DeepCopyInto(out *MirroringInfoSpec) {
+	*out = *in
+	if in.MirroringInfo != nil {
+		in, out := &in.MirroringInfo, &out.MirroringInfo
+		*out = new(MirroringInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringInfoSpec.
+func (in *MirroringInfoSpec) DeepCopy() *MirroringInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringPeerSpec) DeepCopyInto(out *MirroringPeerSpec) {
+	*out = *in
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringPeerSpec.
+func (in *MirroringPeerSpec) DeepCopy() *MirroringPeerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringPeerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringSpec) DeepCopyInto(out *MirroringSpec) {
+	*out = *in
+	if in.SnapshotSchedules != nil {
+		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
+		*out = make([]SnapshotScheduleSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = new(MirroringPeerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringSpec.
+func (in *MirroringSpec) DeepCopy() *MirroringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringStatus) DeepCopyInto(out *MirroringStatus) {
+	*out = *in
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(MirroringStatusSummarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatus.
+func (in *MirroringStatus) DeepCopy() *MirroringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringStatusSpec) DeepCopyInto(out *MirroringStatusSpec) {
+	*out = *in
+	in.MirroringStatus.DeepCopyInto(&out.MirroringStatus)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSnapshotSchedulesSpec.
-func (in *FilesystemSnapshotSchedulesSpec) DeepCopy() *FilesystemSnapshotSchedulesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatusSpec.
+func (in *MirroringStatusSpec) DeepCopy() *MirroringStatusSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemSnapshotSchedulesSpec)
+	out := new(MirroringStatusSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemSpec) DeepCopyInto(out *FilesystemSpec) {
+func (in *MirroringStatusSummarySpec) DeepCopyInto(out *MirroringStatusSummarySpec) {
 	*out = *in
-	in.MetadataPool.DeepCopyInto(&out.MetadataPool)
-	if in.DataPools != nil {
-		in, out := &in.DataPools, &out.DataPools
-		*out = make([]NamedPoolSpec, len(*in))
+	out.States = in.States
+	if in.ImageStates != nil {
+		in, out := &in.ImageStates, &out.ImageStates
+		*out = new(StatesSpec)
+		**out = **in
+	}
+	out.GroupStates = in.GroupStates
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatusSummarySpec.
+func (in *MirroringStatusSummarySpec) DeepCopy() *MirroringStatusSummarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringStatusSummarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Module) DeepCopyInto(out *Module) {
+	*out = *in
+	out.Settings = in.Settings
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Module.
+func (in *Module) DeepCopy() *Module {
+	if in == nil {
+		return nil
+	}
+	out := new(Module)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleSettings) DeepCopyInto(out *ModuleSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleSettings.
+func (in *ModuleSettings) DeepCopy() *ModuleSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonAddressSelector) DeepCopyInto(out *MonAddressSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonAddressSelector.
+func (in *MonAddressSelector) DeepCopy() *MonAddressSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(MonAddressSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonBindAddressesSpec) DeepCopyInto(out *MonBindAddressesSpec) {
+	*out = *in
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
+		*out = new(MonAddressSelector)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(MonAddressSelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonBindAddressesSpec.
+func (in *MonBindAddressesSpec) DeepCopy() *MonBindAddressesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonBindAddressesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonCephxConfig) DeepCopyInto(out *MonCephxConfig) {
+	*out = *in
+	out.CephxConfig = in.CephxConfig
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonCephxConfig.
+func (in *MonCephxConfig) DeepCopy() *MonCephxConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonCephxConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonCephxStatus) DeepCopyInto(out *MonCephxStatus) {
+	*out = *in
+	out.CephxStatus = in.CephxStatus
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonCephxStatus.
+func (in *MonCephxStatus) DeepCopy() *MonCephxStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonCephxStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonClockSkewSpec) DeepCopyInto(out *MonClockSkewSpec) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonClockSkewSpec.
+func (in *MonClockSkewSpec) DeepCopy() *MonClockSkewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonClockSkewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonClockSkewStatus) DeepCopyInto(out *MonClockSkewStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonClockSkewStatus.
+func (in *MonClockSkewStatus) DeepCopy() *MonClockSkewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonClockSkewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonDiskUsageSpec) DeepCopyInto(out *MonDiskUsageSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonDiskUsageSpec.
+func (in *MonDiskUsageSpec) DeepCopy() *MonDiskUsageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonDiskUsageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonDiskUsageStatus) DeepCopyInto(out *MonDiskUsageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonDiskUsageStatus.
+func (in *MonDiskUsageStatus) DeepCopy() *MonDiskUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonDiskUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonFailbackSpec) DeepCopyInto(out *MonFailbackSpec) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonFailbackSpec.
+func (in *MonFailbackSpec) DeepCopy() *MonFailbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonFailbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonFailoverBackoff) DeepCopyInto(out *MonFailoverBackoff) {
+	*out = *in
+	in.NextFailoverTime.DeepCopyInto(&out.NextFailoverTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonFailoverBackoff.
+func (in *MonFailoverBackoff) DeepCopy() *MonFailoverBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(MonFailoverBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonFailoverBackoffStatus) DeepCopyInto(out *MonFailoverBackoffStatus) {
+	*out = *in
+	if in.BudgetResetTime != nil {
+		in, out := &in.BudgetResetTime, &out.BudgetResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Mons != nil {
+		in, out := &in.Mons, &out.Mons
+		*out = make([]MonFailoverBackoff, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.MetadataServer.DeepCopyInto(&out.MetadataServer)
-	if in.Mirroring != nil {
-		in, out := &in.Mirroring, &out.Mirroring
-		*out = new(FSMirroringSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonFailoverBackoffStatus.
+func (in *MonFailoverBackoffStatus) DeepCopy() *MonFailoverBackoffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonFailoverBackoffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonFailoverPreviewStatus) DeepCopyInto(out *MonFailoverPreviewStatus) {
+	*out = *in
+	in.PreviewTime.DeepCopyInto(&out.PreviewTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonFailoverPreviewStatus.
+func (in *MonFailoverPreviewStatus) DeepCopy() *MonFailoverPreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonFailoverPreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonFailoverRateLimitSpec) DeepCopyInto(out *MonFailoverRateLimitSpec) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonFailoverRateLimitSpec.
+func (in *MonFailoverRateLimitSpec) DeepCopy() *MonFailoverRateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonFailoverRateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonHealthCheckSpec) DeepCopyInto(out *MonHealthCheckSpec) {
+	*out = *in
+	in.HealthCheckSpec.DeepCopyInto(&out.HealthCheckSpec)
+	if in.TimeoutOverrides != nil {
+		in, out := &in.TimeoutOverrides, &out.TimeoutOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailoverRateLimit != nil {
+		in, out := &in.FailoverRateLimit, &out.FailoverRateLimit
+		*out = new(MonFailoverRateLimitSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.StatusCheck.DeepCopyInto(&out.StatusCheck)
+	if in.ClockSkew != nil {
+		in, out := &in.ClockSkew, &out.ClockSkew
+		*out = new(MonClockSkewSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(MonDiskUsageSpec)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSpec.
-func (in *FilesystemSpec) DeepCopy() *FilesystemSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonHealthCheckSpec.
+func (in *MonHealthCheckSpec) DeepCopy() *MonHealthCheckSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemSpec)
+	out := new(MonHealthCheckSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemsSpec) DeepCopyInto(out *FilesystemsSpec) {
+func (in *MonOverrideSpec) DeepCopyInto(out *MonOverrideSpec) {
 	*out = *in
-	if in.Peers != nil {
-		in, out := &in.Peers, &out.Peers
-		*out = make([]FilesystemMirrorInfoPeerSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindAddresses != nil {
+		in, out := &in.BindAddresses, &out.BindAddresses
+		*out = new(MonBindAddressesSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemsSpec.
-func (in *FilesystemsSpec) DeepCopy() *FilesystemsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonOverrideSpec.
+func (in *MonOverrideSpec) DeepCopy() *MonOverrideSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemsSpec)
+	out := new(MonOverrideSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GaneshaRADOSSpec) DeepCopyInto(out *GaneshaRADOSSpec) {
+func (in *MonPVCMigrationStatus) DeepCopyInto(out *MonPVCMigrationStatus) {
 	*out = *in
+	if in.HostPathMons != nil {
+		in, out := &in.HostPathMons, &out.HostPathMons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaneshaRADOSSpec.
-func (in *GaneshaRADOSSpec) DeepCopy() *GaneshaRADOSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonPVCMigrationStatus.
+func (in *MonPVCMigrationStatus) DeepCopy() *MonPVCMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GaneshaRADOSSpec)
+	out := new(MonPVCMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GaneshaServerSpec) DeepCopyInto(out *GaneshaServerSpec) {
+func (in *MonPortsSpec) DeepCopyInto(out *MonPortsSpec) {
 	*out = *in
-	in.Placement.DeepCopyInto(&out.Placement)
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(Annotations, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
-	}
-	if in.LivenessProbe != nil {
-		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaneshaServerSpec.
-func (in *GaneshaServerSpec) DeepCopy() *GaneshaServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonPortsSpec.
+func (in *MonPortsSpec) DeepCopy() *MonPortsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GaneshaServerSpec)
+	out := new(MonPortsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+func (in *MonSpec) DeepCopyInto(out *MonSpec) {
 	*out = *in
-	in.Placement.DeepCopyInto(&out.Placement)
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(Annotations, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]MonZoneSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.ExternalRgwEndpoints != nil {
-		in, out := &in.ExternalRgwEndpoints, &out.ExternalRgwEndpoints
-		*out = make([]EndpointAddress, len(*in))
-		copy(*out, *in)
-	}
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(RGWServiceSpec)
+	if in.StretchCluster != nil {
+		in, out := &in.StretchCluster, &out.StretchCluster
+		*out = new(StretchClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.OpsLogSidecar != nil {
-		in, out := &in.OpsLogSidecar, &out.OpsLogSidecar
-		*out = new(OpsLogSidecar)
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(VolumeClaimTemplate)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DashboardEnabled != nil {
-		in, out := &in.DashboardEnabled, &out.DashboardEnabled
-		*out = new(bool)
-		**out = **in
+	if in.ExternalMonIDs != nil {
+		in, out := &in.ExternalMonIDs, &out.ExternalMonIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.AdditionalVolumeMounts != nil {
-		in, out := &in.AdditionalVolumeMounts, &out.AdditionalVolumeMounts
-		*out = make(AdditionalVolumeMounts, len(*in))
+	if in.ExternalMons != nil {
+		in, out := &in.ExternalMons, &out.ExternalMons
+		*out = make([]ExternalMonSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RgwConfig != nil {
-		in, out := &in.RgwConfig, &out.RgwConfig
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.RgwConfigFromSecret != nil {
-		in, out := &in.RgwConfigFromSecret, &out.RgwConfigFromSecret
-		*out = make(map[string]corev1.SecretKeySelector, len(*in))
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make(map[string]MonOverrideSpec, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
-	if in.RgwCommandFlags != nil {
-		in, out := &in.RgwCommandFlags, &out.RgwCommandFlags
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ReadAffinity != nil {
-		in, out := &in.ReadAffinity, &out.ReadAffinity
-		*out = new(RgwReadAffinity)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(MonPortsSpec)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
-func (in *GatewaySpec) DeepCopy() *GatewaySpec {
-	if in == nil {
-		return nil
+	if in.Failback != nil {
+		in, out := &in.Failback, &out.Failback
+		*out = new(MonFailbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindAddresses != nil {
+		in, out := &in.BindAddresses, &out.BindAddresses
+		*out = new(MonBindAddressesSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(GatewaySpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPEndpointSpec) DeepCopyInto(out *HTTPEndpointSpec) {
-	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPEndpointSpec.
-func (in *HTTPEndpointSpec) DeepCopy() *HTTPEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonSpec.
+func (in *MonSpec) DeepCopy() *MonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPEndpointSpec)
+	out := new(MonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+func (in *MonStatus) DeepCopyInto(out *MonStatus) {
 	*out = *in
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.LastFailoverTime != nil {
+		in, out := &in.LastFailoverTime, &out.LastFailoverTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
-func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonStatus.
+func (in *MonStatus) DeepCopy() *MonStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheckSpec)
+	out := new(MonStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HybridStorageSpec) DeepCopyInto(out *HybridStorageSpec) {
+func (in *MonVolumeExpansionStatus) DeepCopyInto(out *MonVolumeExpansionStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HybridStorageSpec.
-func (in *HybridStorageSpec) DeepCopy() *HybridStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonVolumeExpansionStatus.
+func (in *MonVolumeExpansionStatus) DeepCopy() *MonVolumeExpansionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HybridStorageSpec)
+	out := new(MonVolumeExpansionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaEndpointSpec) DeepCopyInto(out *KafkaEndpointSpec) {
+func (in *MonZoneSpec) DeepCopyInto(out *MonZoneSpec) {
 	*out = *in
-	if in.UserSecretRef != nil {
-		in, out := &in.UserSecretRef, &out.UserSecretRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
 	}
-	if in.PasswordSecretRef != nil {
-		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
-		*out = new(corev1.SecretKeySelector)
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(VolumeClaimTemplate)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaEndpointSpec.
-func (in *KafkaEndpointSpec) DeepCopy() *KafkaEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonZoneSpec.
+func (in *MonZoneSpec) DeepCopy() *MonZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaEndpointSpec)
+	out := new(MonZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KerberosConfigFiles) DeepCopyInto(out *KerberosConfigFiles) {
+func (in *MonZoneStatus) DeepCopyInto(out *MonZoneStatus) {
 	*out = *in
-	if in.VolumeSource != nil {
-		in, out := &in.VolumeSource, &out.VolumeSource
-		*out = new(ConfigFileVolumeSource)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosConfigFiles.
-func (in *KerberosConfigFiles) DeepCopy() *KerberosConfigFiles {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonZoneStatus.
+func (in *MonZoneStatus) DeepCopy() *MonZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KerberosConfigFiles)
+	out := new(MonZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KerberosKeytabFile) DeepCopyInto(out *KerberosKeytabFile) {
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 	*out = *in
-	if in.VolumeSource != nil {
-		in, out := &in.VolumeSource, &out.VolumeSource
-		*out = new(ConfigFileVolumeSource)
+	if in.ExternalMgrEndpoints != nil {
+		in, out := &in.ExternalMgrEndpoints, &out.ExternalMgrEndpoints
+		*out = make([]corev1.EndpointAddress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(CephExporterSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosKeytabFile.
-func (in *KerberosKeytabFile) DeepCopy() *KerberosKeytabFile {
-	if in == nil {
-		return nil
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MetricsTLSSpec)
+		**out = **in
 	}
-	out := new(KerberosKeytabFile)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KerberosSpec) DeepCopyInto(out *KerberosSpec) {
-	*out = *in
-	in.ConfigFiles.DeepCopyInto(&out.ConfigFiles)
-	in.KeytabFile.DeepCopyInto(&out.KeytabFile)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosSpec.
-func (in *KerberosSpec) DeepCopy() *KerberosSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KerberosSpec)
+	out := new(MonitoringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyManagementServiceSpec) DeepCopyInto(out *KeyManagementServiceSpec) {
+func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
 	*out = *in
-	if in.ConnectionDetails != nil {
-		in, out := &in.ConnectionDetails, &out.ConnectionDetails
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyManagementServiceSpec.
-func (in *KeyManagementServiceSpec) DeepCopy() *KeyManagementServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceSpec.
+func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyManagementServiceSpec)
+	out := new(MultiClusterServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyRotationSpec) DeepCopyInto(out *KeyRotationSpec) {
+func (in *MultisiteDriftDetectionSpec) DeepCopyInto(out *MultisiteDriftDetectionSpec) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationSpec.
-func (in *KeyRotationSpec) DeepCopy() *KeyRotationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultisiteDriftDetectionSpec.
+func (in *MultisiteDriftDetectionSpec) DeepCopy() *MultisiteDriftDetectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyRotationSpec)
+	out := new(MultisiteDriftDetectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeystoneSpec) DeepCopyInto(out *KeystoneSpec) {
+func (in *NFSGaneshaSpec) DeepCopyInto(out *NFSGaneshaSpec) {
 	*out = *in
-	if in.AcceptedRoles != nil {
-		in, out := &in.AcceptedRoles, &out.AcceptedRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.TokenCacheSize != nil {
-		in, out := &in.TokenCacheSize, &out.TokenCacheSize
-		*out = new(int)
-		**out = **in
-	}
-	if in.RevocationInterval != nil {
-		in, out := &in.RevocationInterval, &out.RevocationInterval
-		*out = new(int)
-		**out = **in
+	out.RADOS = in.RADOS
+	in.Server.DeepCopyInto(&out.Server)
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(NFSSecuritySpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystoneSpec.
-func (in *KeystoneSpec) DeepCopy() *KeystoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSGaneshaSpec.
+func (in *NFSGaneshaSpec) DeepCopy() *NFSGaneshaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeystoneSpec)
+	out := new(NFSGaneshaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Labels) DeepCopyInto(out *Labels) {
-	{
-		in := &in
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-		return
+func (in *NFSSecuritySpec) DeepCopyInto(out *NFSSecuritySpec) {
+	*out = *in
+	if in.SSSD != nil {
+		in, out := &in.SSSD, &out.SSSD
+		*out = new(SSSDSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosSpec)
+		(*in).DeepCopyInto(*out)
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
-func (in Labels) DeepCopy() Labels {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSSecuritySpec.
+func (in *NFSSecuritySpec) DeepCopy() *NFSSecuritySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Labels)
+	out := new(NFSSecuritySpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in LabelsSpec) DeepCopyInto(out *LabelsSpec) {
-	{
-		in := &in
-		*out = make(LabelsSpec, len(*in))
-		for key, val := range *in {
-			var outVal map[string]string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make(Labels, len(*in))
-				for key, val := range *in {
-					(*out)[key] = val
-				}
-			}
-			(*out)[key] = outVal
-		}
-		return
+func (in *NamedBlockPoolSpec) DeepCopyInto(out *NamedBlockPoolSpec) {
+	*out = *in
+	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
+	if in.ImageSnapshotSchedule != nil {
+		in, out := &in.ImageSnapshotSchedule, &out.ImageSnapshotSchedule
+		*out = new(ImageSnapshotScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(BlockPoolSeedSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(PoolMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PGAdvisor != nil {
+		in, out := &in.PGAdvisor, &out.PGAdvisor
+		*out = new(PGAdvisorSpec)
+		**out = **in
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelsSpec.
-func (in LabelsSpec) DeepCopy() LabelsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedBlockPoolSpec.
+func (in *NamedBlockPoolSpec) DeepCopy() *NamedBlockPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LabelsSpec)
+	out := new(NamedBlockPoolSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalCephxStatus) DeepCopyInto(out *LocalCephxStatus) {
+func (in *NamedPoolSpec) DeepCopyInto(out *NamedPoolSpec) {
 	*out = *in
-	out.Daemon = in.Daemon
+	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalCephxStatus.
-func (in *LocalCephxStatus) DeepCopy() *LocalCephxStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedPoolSpec.
+func (in *NamedPoolSpec) DeepCopy() *NamedPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalCephxStatus)
+	out := new(NamedPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
 	*out = *in
-	if in.MaxLogSize != nil {
-		in, out := &in.MaxLogSize, &out.MaxLogSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.RGWIngressFrom != nil {
+		in, out := &in.RGWIngressFrom, &out.RGWIngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientIngressFrom != nil {
+		in, out := &in.ClientIngressFrom, &out.ClientIngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
-func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollectorSpec)
+	out := new(NetworkPoliciesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetadataServerSpec) DeepCopyInto(out *MetadataServerSpec) {
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
-	in.Placement.DeepCopyInto(&out.Placement)
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(Annotations, len(*in))
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make(map[CephNetworkType]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.AddressRanges != nil {
+		in, out := &in.AddressRanges, &out.AddressRanges
+		*out = new(AddressRangesSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.LivenessProbe != nil {
-		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(ProbeSpec)
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = new(ConnectionsSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.StartupProbe != nil {
-		in, out := &in.StartupProbe, &out.StartupProbe
-		*out = new(ProbeSpec)
+	out.MultiClusterService = in.MultiClusterService
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataServerSpec.
-func (in *MetadataServerSpec) DeepCopy() *MetadataServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetadataServerSpec)
+	out := new(NetworkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MgrSpec) DeepCopyInto(out *MgrSpec) {
+func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
-	if in.Modules != nil {
-		in, out := &in.Modules, &out.Modules
-		*out = make([]Module, len(*in))
-		copy(*out, *in)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
+	in.Selection.DeepCopyInto(&out.Selection)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MgrSpec.
-func (in *MgrSpec) DeepCopy() *MgrSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
 	if in == nil {
 		return nil
 	}
-	out := new(MgrSpec)
+	out := new(Node)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Migration) DeepCopyInto(out *Migration) {
-	*out = *in
-	return
+func (in NodesByName) DeepCopyInto(out *NodesByName) {
+	{
+		in := &in
+		*out = make(NodesByName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migration.
-func (in *Migration) DeepCopy() *Migration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesByName.
+func (in NodesByName) DeepCopy() NodesByName {
 	if in == nil {
 		return nil
 	}
-	out := new(Migration)
+	out := new(NodesByName)
 	in.DeepCopyInto(out)
-	return out
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+func (in *NotificationFilterRule) DeepCopyInto(out *NotificationFilterRule) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
-func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterRule.
+func (in *NotificationFilterRule) DeepCopy() *NotificationFilterRule {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrationStatus)
+	out := new(NotificationFilterRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirrorHealthCheckSpec) DeepCopyInto(out *MirrorHealthCheckSpec) {
+func (in *NotificationFilterSpec) DeepCopyInto(out *NotificationFilterSpec) {
 	*out = *in
-	in.Mirror.DeepCopyInto(&out.Mirror)
+	if in.KeyFilters != nil {
+		in, out := &in.KeyFilters, &out.KeyFilters
+		*out = make([]NotificationKeyFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetadataFilters != nil {
+		in, out := &in.MetadataFilters, &out.MetadataFilters
+		*out = make([]NotificationFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagFilters != nil {
+		in, out := &in.TagFilters, &out.TagFilters
+		*out = make([]NotificationFilterRule, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorHealthCheckSpec.
-func (in *MirrorHealthCheckSpec) DeepCopy() *MirrorHealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterSpec.
+func (in *NotificationFilterSpec) DeepCopy() *NotificationFilterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MirrorHealthCheckSpec)
+	out := new(NotificationFilterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringInfo) DeepCopyInto(out *MirroringInfo) {
+func (in *NotificationKeyFilterRule) DeepCopyInto(out *NotificationKeyFilterRule) {
 	*out = *in
-	if in.Peers != nil {
-		in, out := &in.Peers, &out.Peers
-		*out = make([]PeersSpec, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringInfo.
-func (in *MirroringInfo) DeepCopy() *MirroringInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationKeyFilterRule.
+func (in *NotificationKeyFilterRule) DeepCopy() *NotificationKeyFilterRule {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringInfo)
+	out := new(NotificationKeyFilterRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringInfoSpec) DeepCopyInto(out *MirroringInfoSpec) {
+func (in *OSDActivationLimitSpec) DeepCopyInto(out *OSDActivationLimitSpec) {
 	*out = *in
-	if in.MirroringInfo != nil {
-		in, out := &in.MirroringInfo, &out.MirroringInfo
-		*out = new(MirroringInfo)
-		(*in).DeepCopyInto(*out)
+	if in.DeviceClasses != nil {
+		in, out := &in.DeviceClasses, &out.DeviceClasses
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringInfoSpec.
-func (in *MirroringInfoSpec) DeepCopy() *MirroringInfoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDActivationLimitSpec.
+func (in *OSDActivationLimitSpec) DeepCopy() *OSDActivationLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringInfoSpec)
+	out := new(OSDActivationLimitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringPeerSpec) DeepCopyInto(out *MirroringPeerSpec) {
+func (in *OSDCompressionSpec) DeepCopyInto(out *OSDCompressionSpec) {
 	*out = *in
-	if in.SecretNames != nil {
-		in, out := &in.SecretNames, &out.SecretNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MinBlobSize != nil {
+		in, out := &in.MinBlobSize, &out.MinBlobSize
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringPeerSpec.
-func (in *MirroringPeerSpec) DeepCopy() *MirroringPeerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDCompressionSpec.
+func (in *OSDCompressionSpec) DeepCopy() *OSDCompressionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringPeerSpec)
+	out := new(OSDCompressionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringSpec) DeepCopyInto(out *MirroringSpec) {
+func (in *OSDConfigOverride) DeepCopyInto(out *OSDConfigOverride) {
 	*out = *in
-	if in.SnapshotSchedules != nil {
-		in, out := &in.SnapshotSchedules, &out.SnapshotSchedules
-		*out = make([]SnapshotScheduleSpec, len(*in))
+	if in.OSDs != nil {
+		in, out := &in.OSDs, &out.OSDs
+		*out = make([]int, len(*in))
 		copy(*out, *in)
 	}
-	if in.Peers != nil {
-		in, out := &in.Peers, &out.Peers
-		*out = new(MirroringPeerSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringSpec.
-func (in *MirroringSpec) DeepCopy() *MirroringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDConfigOverride.
+func (in *OSDConfigOverride) DeepCopy() *OSDConfigOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringSpec)
+	out := new(OSDConfigOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringStatus) DeepCopyInto(out *MirroringStatus) {
+func (in *OSDFlappingSpec) DeepCopyInto(out *OSDFlappingSpec) {
 	*out = *in
-	if in.Summary != nil {
-		in, out := &in.Summary, &out.Summary
-		*out = new(MirroringStatusSummarySpec)
-		(*in).DeepCopyInto(*out)
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatus.
-func (in *MirroringStatus) DeepCopy() *MirroringStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDFlappingSpec.
+func (in *OSDFlappingSpec) DeepCopy() *OSDFlappingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringStatus)
+	out := new(OSDFlappingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringStatusSpec) DeepCopyInto(out *MirroringStatusSpec) {
+func (in *OSDHostToPVCMigrationSpec) DeepCopyInto(out *OSDHostToPVCMigrationSpec) {
 	*out = *in
-	in.MirroringStatus.DeepCopyInto(&out.MirroringStatus)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatusSpec.
-func (in *MirroringStatusSpec) DeepCopy() *MirroringStatusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDHostToPVCMigrationSpec.
+func (in *OSDHostToPVCMigrationSpec) DeepCopy() *OSDHostToPVCMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringStatusSpec)
+	out := new(OSDHostToPVCMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirroringStatusSummarySpec) DeepCopyInto(out *MirroringStatusSummarySpec) {
+func (in *OSDKeyRotationStatus) DeepCopyInto(out *OSDKeyRotationStatus) {
 	*out = *in
-	out.States = in.States
-	if in.ImageStates != nil {
-		in, out := &in.ImageStates, &out.ImageStates
-		*out = new(StatesSpec)
-		**out = **in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
 	}
-	out.GroupStates = in.GroupStates
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringStatusSummarySpec.
-func (in *MirroringStatusSummarySpec) DeepCopy() *MirroringStatusSummarySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDKeyRotationStatus.
+func (in *OSDKeyRotationStatus) DeepCopy() *OSDKeyRotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MirroringStatusSummarySpec)
+	out := new(OSDKeyRotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Module) DeepCopyInto(out *Module) {
+func (in *OSDMemoryPressureSpec) DeepCopyInto(out *OSDMemoryPressureSpec) {
 	*out = *in
-	out.Settings = in.Settings
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Module.
-func (in *Module) DeepCopy() *Module {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDMemoryPressureSpec.
+func (in *OSDMemoryPressureSpec) DeepCopy() *OSDMemoryPressureSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Module)
+	out := new(OSDMemoryPressureSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModuleSettings) DeepCopyInto(out *ModuleSettings) {
+func (in *OSDPVCMigrationStatus) DeepCopyInto(out *OSDPVCMigrationStatus) {
 	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleSettings.
-func (in *ModuleSettings) DeepCopy() *ModuleSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDPVCMigrationStatus.
+func (in *OSDPVCMigrationStatus) DeepCopy() *OSDPVCMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModuleSettings)
+	out := new(OSDPVCMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonSpec) DeepCopyInto(out *MonSpec) {
+func (in *OSDPrepareConcurrencySpec) DeepCopyInto(out *OSDPrepareConcurrencySpec) {
 	*out = *in
-	if in.Zones != nil {
-		in, out := &in.Zones, &out.Zones
-		*out = make([]MonZoneSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.StretchCluster != nil {
-		in, out := &in.StretchCluster, &out.StretchCluster
-		*out = new(StretchClusterSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VolumeClaimTemplate != nil {
-		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(VolumeClaimTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExternalMonIDs != nil {
-		in, out := &in.ExternalMonIDs, &out.ExternalMonIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonSpec.
-func (in *MonSpec) DeepCopy() *MonSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDPrepareConcurrencySpec.
+func (in *OSDPrepareConcurrencySpec) DeepCopy() *OSDPrepareConcurrencySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MonSpec)
+	out := new(OSDPrepareConcurrencySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonZoneSpec) DeepCopyInto(out *MonZoneSpec) {
+func (in *OSDPrepareFailureStatus) DeepCopyInto(out *OSDPrepareFailureStatus) {
 	*out = *in
-	if in.VolumeClaimTemplate != nil {
-		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(VolumeClaimTemplate)
-		(*in).DeepCopyInto(*out)
+	in.LastFailureTime.DeepCopyInto(&out.LastFailureTime)
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonZoneSpec.
-func (in *MonZoneSpec) DeepCopy() *MonZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDPrepareFailureStatus.
+func (in *OSDPrepareFailureStatus) DeepCopy() *OSDPrepareFailureStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MonZoneSpec)
+	out := new(OSDPrepareFailureStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+func (in *OSDPrepareRetrySpec) DeepCopyInto(out *OSDPrepareRetrySpec) {
 	*out = *in
-	if in.ExternalMgrEndpoints != nil {
-		in, out := &in.ExternalMgrEndpoints, &out.ExternalMgrEndpoints
-		*out = make([]corev1.EndpointAddress, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
 		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.Exporter != nil {
-		in, out := &in.Exporter, &out.Exporter
-		*out = new(CephExporterSpec)
-		(*in).DeepCopyInto(*out)
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
-func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDPrepareRetrySpec.
+func (in *OSDPrepareRetrySpec) DeepCopy() *OSDPrepareRetrySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringSpec)
+	out := new(OSDPrepareRetrySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
+func (in *OSDRemovalProgress) DeepCopyInto(out *OSDRemovalProgress) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceSpec.
-func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDRemovalProgress.
+func (in *OSDRemovalProgress) DeepCopy() *OSDRemovalProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterServiceSpec)
+	out := new(OSDRemovalProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NFSGaneshaSpec) DeepCopyInto(out *NFSGaneshaSpec) {
+func (in *OSDRemovalSpec) DeepCopyInto(out *OSDRemovalSpec) {
 	*out = *in
-	out.RADOS = in.RADOS
-	in.Server.DeepCopyInto(&out.Server)
-	if in.Security != nil {
-		in, out := &in.Security, &out.Security
-		*out = new(NFSSecuritySpec)
-		(*in).DeepCopyInto(*out)
+	if in.OSDIDs != nil {
+		in, out := &in.OSDIDs, &out.OSDIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSGaneshaSpec.
-func (in *NFSGaneshaSpec) DeepCopy() *NFSGaneshaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDRemovalSpec.
+func (in *OSDRemovalSpec) DeepCopy() *OSDRemovalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NFSGaneshaSpec)
+	out := new(OSDRemovalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NFSSecuritySpec) DeepCopyInto(out *NFSSecuritySpec) {
+func (in *OSDRemovalStatus) DeepCopyInto(out *OSDRemovalStatus) {
 	*out = *in
-	if in.SSSD != nil {
-		in, out := &in.SSSD, &out.SSSD
-		*out = new(SSSDSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Kerberos != nil {
-		in, out := &in.Kerberos, &out.Kerberos
-		*out = new(KerberosSpec)
-		(*in).DeepCopyInto(*out)
+	if in.OSDs != nil {
+		in, out := &in.OSDs, &out.OSDs
+		*out = make([]OSDRemovalProgress, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSSecuritySpec.
-func (in *NFSSecuritySpec) DeepCopy() *NFSSecuritySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDRemovalStatus.
+func (in *OSDRemovalStatus) DeepCopy() *OSDRemovalStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NFSSecuritySpec)
+	out := new(OSDRemovalStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedBlockPoolSpec) DeepCopyInto(out *NamedBlockPoolSpec) {
+func (in *OSDReplacementSpec) DeepCopyInto(out *OSDReplacementSpec) {
 	*out = *in
-	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
+	if in.LifeExpectancyThreshold != nil {
+		in, out := &in.LifeExpectancyThreshold, &out.LifeExpectancyThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedBlockPoolSpec.
-func (in *NamedBlockPoolSpec) DeepCopy() *NamedBlockPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDReplacementSpec.
+func (in *OSDReplacementSpec) DeepCopy() *OSDReplacementSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedBlockPoolSpec)
+	out := new(OSDReplacementSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedPoolSpec) DeepCopyInto(out *NamedPoolSpec) {
+func (in *OSDResourceProfile) DeepCopyInto(out *OSDResourceProfile) {
 	*out = *in
-	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.MemoryTarget != nil {
+		in, out := &in.MemoryTarget, &out.MemoryTarget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedPoolSpec.
-func (in *NamedPoolSpec) DeepCopy() *NamedPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDResourceProfile.
+func (in *OSDResourceProfile) DeepCopy() *OSDResourceProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedPoolSpec)
+	out := new(OSDResourceProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
 	*out = *in
-	if in.Selectors != nil {
-		in, out := &in.Selectors, &out.Selectors
-		*out = make(map[CephNetworkType]string, len(*in))
+	if in.StoreType != nil {
+		in, out := &in.StoreType, &out.StoreType
+		*out = make(map[string]int, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.AddressRanges != nil {
-		in, out := &in.AddressRanges, &out.AddressRanges
-		*out = new(AddressRangesSpec)
-		(*in).DeepCopyInto(*out)
+	out.MigrationStatus = in.MigrationStatus
+	if in.MemoryTargets != nil {
+		in, out := &in.MemoryTargets, &out.MemoryTargets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Connections != nil {
-		in, out := &in.Connections, &out.Connections
-		*out = new(ConnectionsSpec)
-		(*in).DeepCopyInto(*out)
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = make(map[string]OSDKeyRotationStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
 	}
-	out.MultiClusterService = in.MultiClusterService
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
+func (in *OSDStatus) DeepCopy() *OSDStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkSpec)
+	out := new(OSDStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
+func (in *OSDStore) DeepCopyInto(out *OSDStore) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
+func (in *OSDStore) DeepCopy() *OSDStore {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDUpdateStrategySpec) DeepCopyInto(out *OSDUpdateStrategySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDUpdateStrategySpec.
+func (in *OSDUpdateStrategySpec) DeepCopy() *OSDUpdateStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDUpdateStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDWeightRampUpSpec) DeepCopyInto(out *OSDWeightRampUpSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	in.Selection.DeepCopyInto(&out.Selection)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
-func (in *Node) DeepCopy() *Node {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDWeightRampUpSpec.
+func (in *OSDWeightRampUpSpec) DeepCopy() *OSDWeightRampUpSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Node)
+	out := new(OSDWeightRampUpSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in NodesByName) DeepCopyInto(out *NodesByName) {
-	{
-		in := &in
-		*out = make(NodesByName, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-		return
+func (in *ObjectEndpointPublicationSpec) DeepCopyInto(out *ObjectEndpointPublicationSpec) {
+	*out = *in
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(ObjectHTTPRouteSpec)
+		(*in).DeepCopyInto(*out)
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesByName.
-func (in NodesByName) DeepCopy() NodesByName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointPublicationSpec.
+func (in *ObjectEndpointPublicationSpec) DeepCopy() *ObjectEndpointPublicationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodesByName)
+	out := new(ObjectEndpointPublicationSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationFilterRule) DeepCopyInto(out *NotificationFilterRule) {
+func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterRule.
-func (in *NotificationFilterRule) DeepCopy() *NotificationFilterRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
+func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationFilterRule)
+	out := new(ObjectEndpointSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationFilterSpec) DeepCopyInto(out *NotificationFilterSpec) {
+func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
 	*out = *in
-	if in.KeyFilters != nil {
-		in, out := &in.KeyFilters, &out.KeyFilters
-		*out = make([]NotificationKeyFilterRule, len(*in))
-		copy(*out, *in)
-	}
-	if in.MetadataFilters != nil {
-		in, out := &in.MetadataFilters, &out.MetadataFilters
-		*out = make([]NotificationFilterRule, len(*in))
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.TagFilters != nil {
-		in, out := &in.TagFilters, &out.TagFilters
-		*out = make([]NotificationFilterRule, len(*in))
+	if in.Secure != nil {
+		in, out := &in.Secure, &out.Secure
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterSpec.
-func (in *NotificationFilterSpec) DeepCopy() *NotificationFilterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
+func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationFilterSpec)
+	out := new(ObjectEndpoints)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationKeyFilterRule) DeepCopyInto(out *NotificationKeyFilterRule) {
+func (in *ObjectGarbageCollectionRunStatus) DeepCopyInto(out *ObjectGarbageCollectionRunStatus) {
 	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationKeyFilterRule.
-func (in *NotificationKeyFilterRule) DeepCopy() *NotificationKeyFilterRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectGarbageCollectionRunStatus.
+func (in *ObjectGarbageCollectionRunStatus) DeepCopy() *ObjectGarbageCollectionRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationKeyFilterRule)
+	out := new(ObjectGarbageCollectionRunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
+func (in *ObjectGarbageCollectionSpec) DeepCopyInto(out *ObjectGarbageCollectionSpec) {
 	*out = *in
-	if in.StoreType != nil {
-		in, out := &in.StoreType, &out.StoreType
-		*out = make(map[string]int, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.MaxObjects != nil {
+		in, out := &in.MaxObjects, &out.MaxObjects
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProcessorPeriod != nil {
+		in, out := &in.ProcessorPeriod, &out.ProcessorPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ObjectMinWait != nil {
+		in, out := &in.ObjectMinWait, &out.ObjectMinWait
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BacklogCheckInterval != nil {
+		in, out := &in.BacklogCheckInterval, &out.BacklogCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	out.MigrationStatus = in.MigrationStatus
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
-func (in *OSDStatus) DeepCopy() *OSDStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectGarbageCollectionSpec.
+func (in *ObjectGarbageCollectionSpec) DeepCopy() *ObjectGarbageCollectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStatus)
+	out := new(ObjectGarbageCollectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStore) DeepCopyInto(out *OSDStore) {
+func (in *ObjectGarbageCollectionStatus) DeepCopyInto(out *ObjectGarbageCollectionStatus) {
 	*out = *in
+	in.BacklogCheckTime.DeepCopyInto(&out.BacklogCheckTime)
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(ObjectGarbageCollectionRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
-func (in *OSDStore) DeepCopy() *OSDStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectGarbageCollectionStatus.
+func (in *ObjectGarbageCollectionStatus) DeepCopy() *ObjectGarbageCollectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStore)
+	out := new(ObjectGarbageCollectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
+func (in *ObjectHTTPRouteParentRef) DeepCopyInto(out *ObjectHTTPRouteParentRef) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
-func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHTTPRouteParentRef.
+func (in *ObjectHTTPRouteParentRef) DeepCopy() *ObjectHTTPRouteParentRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpointSpec)
+	out := new(ObjectHTTPRouteParentRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
+func (in *ObjectHTTPRouteSpec) DeepCopyInto(out *ObjectHTTPRouteSpec) {
 	*out = *in
-	if in.Insecure != nil {
-		in, out := &in.Insecure, &out.Insecure
-		*out = make([]string, len(*in))
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]ObjectHTTPRouteParentRef, len(*in))
 		copy(*out, *in)
 	}
-	if in.Secure != nil {
-		in, out := &in.Secure, &out.Secure
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
-func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHTTPRouteSpec.
+func (in *ObjectHTTPRouteSpec) DeepCopy() *ObjectHTTPRouteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpoints)
+	out := new(ObjectHTTPRouteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4026,6 +6392,32 @@ func (in *ObjectStoreSecuritySpec) DeepCopy() *ObjectStoreSecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreSeedSpec) DeepCopyInto(out *ObjectStoreSeedSpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]SeedObjectUserSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]SeedBucketSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSeedSpec.
+func (in *ObjectStoreSeedSpec) DeepCopy() *ObjectStoreSeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreSeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStoreSpec) DeepCopyInto(out *ObjectStoreSpec) {
 	*out = *in
@@ -4052,6 +6444,21 @@ func (in *ObjectStoreSpec) DeepCopyInto(out *ObjectStoreSpec) {
 		*out = new(ObjectStoreHostingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EndpointPublication != nil {
+		in, out := &in.EndpointPublication, &out.EndpointPublication
+		*out = new(ObjectEndpointPublicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(ObjectGarbageCollectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(ObjectStoreSeedSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4084,6 +6491,28 @@ func (in *ObjectStoreStatus) DeepCopyInto(out *ObjectStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GatewayGroups != nil {
+		in, out := &in.GatewayGroups, &out.GatewayGroups
+		*out = make([]GatewayGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(ObjectGarbageCollectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(SeedStatus)
+		**out = **in
+	}
+	if in.EndpointChecks != nil {
+		in, out := &in.EndpointChecks, &out.EndpointChecks
+		*out = make([]EndpointCheckStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -4145,6 +6574,11 @@ func (in *ObjectStoreUserStatus) DeepCopyInto(out *ObjectStoreUserStatus) {
 		*out = make([]SecretReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4234,6 +6668,11 @@ func (in *ObjectUserQuotaSpec) DeepCopy() *ObjectUserQuotaSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectZoneGroupSpec) DeepCopyInto(out *ObjectZoneGroupSpec) {
 	*out = *in
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(MultisiteDriftDetectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4258,6 +6697,11 @@ func (in *ObjectZoneSpec) DeepCopyInto(out *ObjectZoneSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(MultisiteDriftDetectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4288,6 +6732,54 @@ func (in *OpsLogSidecar) DeepCopy() *OpsLogSidecar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedOSDStatus) DeepCopyInto(out *OrphanedOSDStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedOSDStatus.
+func (in *OrphanedOSDStatus) DeepCopy() *OrphanedOSDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedOSDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGAdvisorSpec) DeepCopyInto(out *PGAdvisorSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGAdvisorSpec.
+func (in *PGAdvisorSpec) DeepCopy() *PGAdvisorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGAdvisorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGRecommendationStatus) DeepCopyInto(out *PGRecommendationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGRecommendationStatus.
+func (in *PGRecommendationStatus) DeepCopy() *PGRecommendationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGRecommendationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeerRemoteSpec) DeepCopyInto(out *PeerRemoteSpec) {
 	*out = *in
@@ -4403,6 +6895,27 @@ func (in PlacementSpec) DeepCopy() PlacementSpec {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementDriftSpec) DeepCopyInto(out *PlacementDriftSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementDriftSpec.
+func (in *PlacementDriftSpec) DeepCopy() *PlacementDriftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementDriftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementStorageClassSpec) DeepCopyInto(out *PlacementStorageClassSpec) {
 	*out = *in
@@ -4419,6 +6932,43 @@ func (in *PlacementStorageClassSpec) DeepCopy() *PlacementStorageClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolMigrationSpec) DeepCopyInto(out *PoolMigrationSpec) {
+	*out = *in
+	if in.MaxRecoveryBytesPerSecond != nil {
+		in, out := &in.MaxRecoveryBytesPerSecond, &out.MaxRecoveryBytesPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolMigrationSpec.
+func (in *PoolMigrationSpec) DeepCopy() *PoolMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolMigrationStatus) DeepCopyInto(out *PoolMigrationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolMigrationStatus.
+func (in *PoolMigrationStatus) DeepCopy() *PoolMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolPlacementSpec) DeepCopyInto(out *PoolPlacementSpec) {
 	*out = *in
@@ -4511,6 +7061,22 @@ func (in *ProbeSpec) DeepCopy() *ProbeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProgressEventStatus) DeepCopyInto(out *ProgressEventStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProgressEventStatus.
+func (in *ProgressEventStatus) DeepCopy() *ProgressEventStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProgressEventStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtocolSpec) DeepCopyInto(out *ProtocolSpec) {
 	*out = *in
@@ -4666,28 +7232,49 @@ func (in *RadosNamespaceMirroring) DeepCopy() *RadosNamespaceMirroring {
 	if in == nil {
 		return nil
 	}
-	out := new(RadosNamespaceMirroring)
+	out := new(RadosNamespaceMirroring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadAffinitySpec) DeepCopyInto(out *ReadAffinitySpec) {
+	*out = *in
+	if in.CrushLocationLabels != nil {
+		in, out := &in.CrushLocationLabels, &out.CrushLocationLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadAffinitySpec.
+func (in *ReadAffinitySpec) DeepCopy() *ReadAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadAffinitySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadAffinitySpec) DeepCopyInto(out *ReadAffinitySpec) {
+func (in *RecoveryDeferralSpec) DeepCopyInto(out *RecoveryDeferralSpec) {
 	*out = *in
-	if in.CrushLocationLabels != nil {
-		in, out := &in.CrushLocationLabels, &out.CrushLocationLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxRecoveryBytesPerSecond != nil {
+		in, out := &in.MaxRecoveryBytesPerSecond, &out.MaxRecoveryBytesPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadAffinitySpec.
-func (in *ReadAffinitySpec) DeepCopy() *ReadAffinitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryDeferralSpec.
+func (in *RecoveryDeferralSpec) DeepCopy() *RecoveryDeferralSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadAffinitySpec)
+	out := new(RecoveryDeferralSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4777,6 +7364,106 @@ func (in *S3Spec) DeepCopy() *S3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RookClusterProfile) DeepCopyInto(out *RookClusterProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RookClusterProfile.
+func (in *RookClusterProfile) DeepCopy() *RookClusterProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(RookClusterProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RookClusterProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RookClusterProfileList) DeepCopyInto(out *RookClusterProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RookClusterProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RookClusterProfileList.
+func (in *RookClusterProfileList) DeepCopy() *RookClusterProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(RookClusterProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RookClusterProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RookClusterProfileSpec) DeepCopyInto(out *RookClusterProfileSpec) {
+	*out = *in
+	if in.CephVersion != nil {
+		in, out := &in.CephVersion, &out.CephVersion
+		*out = new(CephVersionSpec)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(ResourceSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = make(PlacementSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RookClusterProfileSpec.
+func (in *RookClusterProfileSpec) DeepCopy() *RookClusterProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RookClusterProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSSDSidecar) DeepCopyInto(out *SSSDSidecar) {
 	*out = *in
@@ -4895,6 +7582,97 @@ func (in *SecuritySpec) DeepCopy() *SecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedBucketSpec) DeepCopyInto(out *SeedBucketSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedBucketSpec.
+func (in *SeedBucketSpec) DeepCopy() *SeedBucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedBucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDirectorySpec) DeepCopyInto(out *SeedDirectorySpec) {
+	*out = *in
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDirectorySpec.
+func (in *SeedDirectorySpec) DeepCopy() *SeedDirectorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDirectorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedImageSpec) DeepCopyInto(out *SeedImageSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedImageSpec.
+func (in *SeedImageSpec) DeepCopy() *SeedImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedObjectUserSpec) DeepCopyInto(out *SeedObjectUserSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedObjectUserSpec.
+func (in *SeedObjectUserSpec) DeepCopy() *SeedObjectUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedObjectUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedStatus) DeepCopyInto(out *SeedStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedStatus.
+func (in *SeedStatus) DeepCopy() *SeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Selection) DeepCopyInto(out *Selection) {
 	*out = *in
@@ -4903,6 +7681,11 @@ func (in *Selection) DeepCopyInto(out *Selection) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeviceIdentityFilter != nil {
+		in, out := &in.DeviceIdentityFilter, &out.DeviceIdentityFilter
+		*out = new(DeviceIdentityFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Devices != nil {
 		in, out := &in.Devices, &out.Devices
 		*out = make([]Device, len(*in))
@@ -4917,6 +7700,11 @@ func (in *Selection) DeepCopyInto(out *Selection) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(OSDCompressionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5061,6 +7849,56 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageChangeNodeStatus) DeepCopyInto(out *StorageChangeNodeStatus) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageChangeNodeStatus.
+func (in *StorageChangeNodeStatus) DeepCopy() *StorageChangeNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageChangeNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageChangesStatus) DeepCopyInto(out *StorageChangesStatus) {
+	*out = *in
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = make([]StorageChangeNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Orphaned != nil {
+		in, out := &in.Orphaned, &out.Orphaned
+		*out = make([]OrphanedOSDStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.ReportTime.DeepCopyInto(&out.ReportTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageChangesStatus.
+func (in *StorageChangesStatus) DeepCopy() *StorageChangesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageChangesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageClassDeviceSet) DeepCopyInto(out *StorageClassDeviceSet) {
 	*out = *in
@@ -5085,6 +7923,11 @@ func (in *StorageClassDeviceSet) DeepCopyInto(out *StorageClassDeviceSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(OSDCompressionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5123,7 +7966,7 @@ func (in *StorageScopeSpec) DeepCopyInto(out *StorageScopeSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Migration = in.Migration
+	in.Migration.DeepCopyInto(&out.Migration)
 	out.Store = in.Store
 	if in.FullRatio != nil {
 		in, out := &in.FullRatio, &out.FullRatio
@@ -5140,6 +7983,45 @@ func (in *StorageScopeSpec) DeepCopyInto(out *StorageScopeSpec) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.ActivationLimit != nil {
+		in, out := &in.ActivationLimit, &out.ActivationLimit
+		*out = new(OSDActivationLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeviceClassResources != nil {
+		in, out := &in.DeviceClassResources, &out.DeviceClassResources
+		*out = make(map[string]OSDResourceProfile, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.WeightRampUp != nil {
+		in, out := &in.WeightRampUp, &out.WeightRampUp
+		*out = new(OSDWeightRampUpSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrepareConcurrency != nil {
+		in, out := &in.PrepareConcurrency, &out.PrepareConcurrency
+		*out = new(OSDPrepareConcurrencySpec)
+		**out = **in
+	}
+	if in.PrepareRetry != nil {
+		in, out := &in.PrepareRetry, &out.PrepareRetry
+		*out = new(OSDPrepareRetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(OSDUpdateStrategySpec)
+		**out = **in
+	}
+	if in.ConfigOverrides != nil {
+		in, out := &in.ConfigOverrides, &out.ConfigOverrides
+		*out = make([]OSDConfigOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -5163,6 +8045,11 @@ func (in *StretchClusterSpec) DeepCopyInto(out *StretchClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ZoneFailover != nil {
+		in, out := &in.ZoneFailover, &out.ZoneFailover
+		*out = new(StretchZoneFailoverSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5176,6 +8063,49 @@ func (in *StretchClusterSpec) DeepCopy() *StretchClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StretchZoneFailoverSpec) DeepCopyInto(out *StretchZoneFailoverSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailbackDelay != nil {
+		in, out := &in.FailbackDelay, &out.FailbackDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StretchZoneFailoverSpec.
+func (in *StretchZoneFailoverSpec) DeepCopy() *StretchZoneFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StretchZoneFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleStatus) DeepCopyInto(out *SupportBundleStatus) {
+	*out = *in
+	in.GeneratedTime.DeepCopyInto(&out.GeneratedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleStatus.
+func (in *SupportBundleStatus) DeepCopy() *SupportBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftSpec) DeepCopyInto(out *SwiftSpec) {
 	*out = *in
@@ -34,17 +34,22 @@ type CephV1Interface interface {
 	CephBucketTopicsGetter
 	CephCOSIDriversGetter
 	CephClientsGetter
+	CephClientBlocklistsGetter
+	CephClientTemplatesGetter
 	CephClustersGetter
+	CephDeviceInventoriesGetter
 	CephFilesystemsGetter
 	CephFilesystemMirrorsGetter
 	CephFilesystemSubVolumeGroupsGetter
 	CephNFSesGetter
+	CephOSDRemovalsGetter
 	CephObjectRealmsGetter
 	CephObjectStoresGetter
 	CephObjectStoreUsersGetter
 	CephObjectZonesGetter
 	CephObjectZoneGroupsGetter
 	CephRBDMirrorsGetter
+	RookClusterProfilesGetter
 }
 
 // CephV1Client is used to interact with features provided by the ceph.rook.io group.
@@ -76,10 +81,22 @@ func (c *CephV1Client) CephClients(namespace string) CephClientInterface {
 	return newCephClients(c, namespace)
 }
 
+func (c *CephV1Client) CephClientBlocklists(namespace string) CephClientBlocklistInterface {
+	return newCephClientBlocklists(c, namespace)
+}
+
+func (c *CephV1Client) CephClientTemplates(namespace string) CephClientTemplateInterface {
+	return newCephClientTemplates(c, namespace)
+}
+
 func (c *CephV1Client) CephClusters(namespace string) CephClusterInterface {
 	return newCephClusters(c, namespace)
 }
 
+func (c *CephV1Client) CephDeviceInventories(namespace string) CephDeviceInventoryInterface {
+	return newCephDeviceInventories(c, namespace)
+}
+
 func (c *CephV1Client) CephFilesystems(namespace string) CephFilesystemInterface {
 	return newCephFilesystems(c, namespace)
 }
@@ -96,6 +113,10 @@ func (c *CephV1Client) CephNFSes(namespace string) CephNFSInterface {
 	return newCephNFSes(c, namespace)
 }
 
+func (c *CephV1Client) CephOSDRemovals(namespace string) CephOSDRemovalInterface {
+	return newCephOSDRemovals(c, namespace)
+}
+
 func (c *CephV1Client) CephObjectRealms(namespace string) CephObjectRealmInterface {
 	return newCephObjectRealms(c, namespace)
 }
@@ -120,6 +141,10 @@ func (c *CephV1Client) CephRBDMirrors(namespace string) CephRBDMirrorInterface {
 	return newCephRBDMirrors(c, namespace)
 }
 
+func (c *CephV1Client) RookClusterProfiles() RookClusterProfileInterface {
+	return newRookClusterProfiles(c)
+}
+
 // NewForConfig creates a new CephV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -52,10 +52,22 @@ func (c *FakeCephV1) CephClients(namespace string) v1.CephClientInterface {
 	return &FakeCephClients{c, namespace}
 }
 
+func (c *FakeCephV1) CephClientBlocklists(namespace string) v1.CephClientBlocklistInterface {
+	return &FakeCephClientBlocklists{c, namespace}
+}
+
+func (c *FakeCephV1) CephClientTemplates(namespace string) v1.CephClientTemplateInterface {
+	return &FakeCephClientTemplates{c, namespace}
+}
+
 func (c *FakeCephV1) CephClusters(namespace string) v1.CephClusterInterface {
 	return &FakeCephClusters{c, namespace}
 }
 
+func (c *FakeCephV1) CephDeviceInventories(namespace string) v1.CephDeviceInventoryInterface {
+	return &FakeCephDeviceInventories{c, namespace}
+}
+
 func (c *FakeCephV1) CephFilesystems(namespace string) v1.CephFilesystemInterface {
 	return &FakeCephFilesystems{c, namespace}
 }
@@ -72,6 +84,10 @@ func (c *FakeCephV1) CephNFSes(namespace string) v1.CephNFSInterface {
 	return &FakeCephNFSes{c, namespace}
 }
 
+func (c *FakeCephV1) CephOSDRemovals(namespace string) v1.CephOSDRemovalInterface {
+	return &FakeCephOSDRemovals{c, namespace}
+}
+
 func (c *FakeCephV1) CephObjectRealms(namespace string) v1.CephObjectRealmInterface {
 	return &FakeCephObjectRealms{c, namespace}
 }
@@ -96,6 +112,10 @@ func (c *FakeCephV1) CephRBDMirrors(namespace string) v1.CephRBDMirrorInterface
 	return &FakeCephRBDMirrors{c, namespace}
 }
 
+func (c *FakeCephV1) RookClusterProfiles() v1.RookClusterProfileInterface {
+	return &FakeRookClusterProfiles{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCephV1) RESTClient() rest.Interface {
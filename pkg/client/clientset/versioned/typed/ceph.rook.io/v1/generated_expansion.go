@@ -30,8 +30,14 @@ type CephCOSIDriverExpansion interface{}
 
 type CephClientExpansion interface{}
 
+type CephClientBlocklistExpansion interface{}
+
+type CephClientTemplateExpansion interface{}
+
 type CephClusterExpansion interface{}
 
+type CephDeviceInventoryExpansion interface{}
+
 type CephFilesystemExpansion interface{}
 
 type CephFilesystemMirrorExpansion interface{}
@@ -40,6 +46,8 @@ type CephFilesystemSubVolumeGroupExpansion interface{}
 
 type CephNFSExpansion interface{}
 
+type CephOSDRemovalExpansion interface{}
+
 type CephObjectRealmExpansion interface{}
 
 type CephObjectStoreExpansion interface{}
@@ -51,3 +59,5 @@ type CephObjectZoneExpansion interface{}
 type CephObjectZoneGroupExpansion interface{}
 
 type CephRBDMirrorExpansion interface{}
+
+type RookClusterProfileExpansion interface{}
@@ -36,8 +36,14 @@ type Interface interface {
 	CephCOSIDrivers() CephCOSIDriverInformer
 	// CephClients returns a CephClientInformer.
 	CephClients() CephClientInformer
+	// CephClientBlocklists returns a CephClientBlocklistInformer.
+	CephClientBlocklists() CephClientBlocklistInformer
+	// CephClientTemplates returns a CephClientTemplateInformer.
+	CephClientTemplates() CephClientTemplateInformer
 	// CephClusters returns a CephClusterInformer.
 	CephClusters() CephClusterInformer
+	// CephDeviceInventories returns a CephDeviceInventoryInformer.
+	CephDeviceInventories() CephDeviceInventoryInformer
 	// CephFilesystems returns a CephFilesystemInformer.
 	CephFilesystems() CephFilesystemInformer
 	// CephFilesystemMirrors returns a CephFilesystemMirrorInformer.
@@ -46,6 +52,8 @@ type Interface interface {
 	CephFilesystemSubVolumeGroups() CephFilesystemSubVolumeGroupInformer
 	// CephNFSes returns a CephNFSInformer.
 	CephNFSes() CephNFSInformer
+	// CephOSDRemovals returns a CephOSDRemovalInformer.
+	CephOSDRemovals() CephOSDRemovalInformer
 	// CephObjectRealms returns a CephObjectRealmInformer.
 	CephObjectRealms() CephObjectRealmInformer
 	// CephObjectStores returns a CephObjectStoreInformer.
@@ -58,6 +66,8 @@ type Interface interface {
 	CephObjectZoneGroups() CephObjectZoneGroupInformer
 	// CephRBDMirrors returns a CephRBDMirrorInformer.
 	CephRBDMirrors() CephRBDMirrorInformer
+	// RookClusterProfiles returns a RookClusterProfileInformer.
+	RookClusterProfiles() RookClusterProfileInformer
 }
 
 type version struct {
@@ -101,11 +111,26 @@ func (v *version) CephClients() CephClientInformer {
 	return &cephClientInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephClientBlocklists returns a CephClientBlocklistInformer.
+func (v *version) CephClientBlocklists() CephClientBlocklistInformer {
+	return &cephClientBlocklistInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CephClientTemplates returns a CephClientTemplateInformer.
+func (v *version) CephClientTemplates() CephClientTemplateInformer {
+	return &cephClientTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephClusters returns a CephClusterInformer.
 func (v *version) CephClusters() CephClusterInformer {
 	return &cephClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephDeviceInventories returns a CephDeviceInventoryInformer.
+func (v *version) CephDeviceInventories() CephDeviceInventoryInformer {
+	return &cephDeviceInventoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephFilesystems returns a CephFilesystemInformer.
 func (v *version) CephFilesystems() CephFilesystemInformer {
 	return &cephFilesystemInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -126,6 +151,11 @@ func (v *version) CephNFSes() CephNFSInformer {
 	return &cephNFSInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephOSDRemovals returns a CephOSDRemovalInformer.
+func (v *version) CephOSDRemovals() CephOSDRemovalInformer {
+	return &cephOSDRemovalInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephObjectRealms returns a CephObjectRealmInformer.
 func (v *version) CephObjectRealms() CephObjectRealmInformer {
 	return &cephObjectRealmInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -155,3 +185,8 @@ func (v *version) CephObjectZoneGroups() CephObjectZoneGroupInformer {
 func (v *version) CephRBDMirrors() CephRBDMirrorInformer {
 	return &cephRBDMirrorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// RookClusterProfiles returns a RookClusterProfileInformer.
+func (v *version) RookClusterProfiles() RookClusterProfileInformer {
+	return &rookClusterProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
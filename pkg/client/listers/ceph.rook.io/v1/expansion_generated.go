@@ -66,6 +66,22 @@ type CephClientListerExpansion interface{}
 // CephClientNamespaceLister.
 type CephClientNamespaceListerExpansion interface{}
 
+// CephClientBlocklistListerExpansion allows custom methods to be added to
+// CephClientBlocklistLister.
+type CephClientBlocklistListerExpansion interface{}
+
+// CephClientBlocklistNamespaceListerExpansion allows custom methods to be added to
+// CephClientBlocklistNamespaceLister.
+type CephClientBlocklistNamespaceListerExpansion interface{}
+
+// CephClientTemplateListerExpansion allows custom methods to be added to
+// CephClientTemplateLister.
+type CephClientTemplateListerExpansion interface{}
+
+// CephClientTemplateNamespaceListerExpansion allows custom methods to be added to
+// CephClientTemplateNamespaceLister.
+type CephClientTemplateNamespaceListerExpansion interface{}
+
 // CephClusterListerExpansion allows custom methods to be added to
 // CephClusterLister.
 type CephClusterListerExpansion interface{}
@@ -74,6 +90,14 @@ type CephClusterListerExpansion interface{}
 // CephClusterNamespaceLister.
 type CephClusterNamespaceListerExpansion interface{}
 
+// CephDeviceInventoryListerExpansion allows custom methods to be added to
+// CephDeviceInventoryLister.
+type CephDeviceInventoryListerExpansion interface{}
+
+// CephDeviceInventoryNamespaceListerExpansion allows custom methods to be added to
+// CephDeviceInventoryNamespaceLister.
+type CephDeviceInventoryNamespaceListerExpansion interface{}
+
 // CephFilesystemListerExpansion allows custom methods to be added to
 // CephFilesystemLister.
 type CephFilesystemListerExpansion interface{}
@@ -106,6 +130,14 @@ type CephNFSListerExpansion interface{}
 // CephNFSNamespaceLister.
 type CephNFSNamespaceListerExpansion interface{}
 
+// CephOSDRemovalListerExpansion allows custom methods to be added to
+// CephOSDRemovalLister.
+type CephOSDRemovalListerExpansion interface{}
+
+// CephOSDRemovalNamespaceListerExpansion allows custom methods to be added to
+// CephOSDRemovalNamespaceLister.
+type CephOSDRemovalNamespaceListerExpansion interface{}
+
 // CephObjectRealmListerExpansion allows custom methods to be added to
 // CephObjectRealmLister.
 type CephObjectRealmListerExpansion interface{}
@@ -153,3 +185,7 @@ type CephRBDMirrorListerExpansion interface{}
 // CephRBDMirrorNamespaceListerExpansion allows custom methods to be added to
 // CephRBDMirrorNamespaceLister.
 type CephRBDMirrorNamespaceListerExpansion interface{}
+
+// RookClusterProfileListerExpansion allows custom methods to be added to
+// RookClusterProfileLister.
+type RookClusterProfileListerExpansion interface{}
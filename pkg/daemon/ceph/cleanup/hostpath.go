@@ -20,6 +20,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
@@ -37,6 +39,23 @@ func StartHostPathCleanup(namespaceDir, dataDirHostPath, monSecret string) {
 	cleanMonDirs(dataDirHostPath, monSecret)
 }
 
+// CleanOSDDirs removes the directories of the purged OSDs from the dataDirHostPath of the cluster
+func CleanOSDDirs(namespaceDir, dataDirHostPath string, osdDirs []string) error {
+	for _, osdDir := range osdDirs {
+		// the directories are named after the fsid and the uuid of the OSD, they must not escape
+		// the directory of the cluster
+		if osdDir == "" || osdDir != filepath.Base(osdDir) || osdDir == "." || osdDir == ".." {
+			return errors.Errorf("invalid osd directory %q", osdDir)
+		}
+		cleanupDirPath := path.Join(dataDirHostPath, namespaceDir, osdDir)
+		if err := os.RemoveAll(cleanupDirPath); err != nil {
+			return errors.Wrapf(err, "failed to clean up osd directory %q", cleanupDirPath)
+		}
+		logger.Infof("successfully cleaned up osd directory %q", cleanupDirPath)
+	}
+	return nil
+}
+
 func cleanMonDirs(dataDirHostPath, monSecret string) {
 	monDirs, err := filepath.Glob(path.Join(dataDirHostPath, "mon-*"))
 	if err != nil {
@@ -82,5 +101,6 @@ func secretKeyMatch(monDir, monSecret string) (bool, error) {
 		return false, errors.Wrapf(err, "failed to extract secret key from the keyring %q for the mon directory %q", keyringDirPath, monDir)
 	}
 
-	return monSecret == extractedKey, nil
+	// the mon secret lists the previous keys after the mon key was rotated, comma-separated
+	return slices.Contains(strings.Split(monSecret, ","), extractedKey), nil
 }
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanOSDDirs(t *testing.T) {
+	dataDirHostPath := t.TempDir()
+	for _, dir := range []string{"fsid_uuid0", "fsid_uuid1", "log"} {
+		require.NoError(t, os.MkdirAll(path.Join(dataDirHostPath, "rook-ceph", dir), 0o700))
+	}
+
+	// only the directories of the purged osds are removed
+	require.NoError(t, CleanOSDDirs("rook-ceph", dataDirHostPath, []string{"fsid_uuid0", "fsid_uuid2"}))
+	assert.NoDirExists(t, path.Join(dataDirHostPath, "rook-ceph", "fsid_uuid0"))
+	assert.DirExists(t, path.Join(dataDirHostPath, "rook-ceph", "fsid_uuid1"))
+	assert.DirExists(t, path.Join(dataDirHostPath, "rook-ceph", "log"))
+
+	// the directories cannot escape the directory of the cluster
+	for _, dir := range []string{"", "..", "../rook-ceph", "log/../fsid_uuid1"} {
+		assert.Error(t, CleanOSDDirs("rook-ceph", dataDirHostPath, []string{dir}), dir)
+	}
+	assert.DirExists(t, path.Join(dataDirHostPath, "rook-ceph", "fsid_uuid1"))
+}
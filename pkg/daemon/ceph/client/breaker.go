@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rook/rook/pkg/util/exec"
+)
+
+// The command breaker detects when the ceph commands of a cluster keep failing because the cluster
+// cannot be reached, for example when the mons lost quorum. While the breaker is open, the
+// controllers that are not critical to the health of the cluster reconcile less often so that
+// their retries do not add load to the degraded cluster. The breaker closes as soon as a command
+// succeeds, which the health checkers keep attempting.
+
+var (
+	// CommandBreakerFailureThreshold is the number of consecutive commands that must fail to reach
+	// the cluster before the breaker opens
+	CommandBreakerFailureThreshold = 10
+	// CommandBreakerFailureDuration is how long the commands must have been failing before the
+	// breaker opens
+	CommandBreakerFailureDuration = 2 * time.Minute
+
+	commandBreakers     = map[string]*commandBreaker{}
+	commandBreakerMutex sync.Mutex
+)
+
+type commandBreaker struct {
+	consecutiveFailures int
+	firstFailure        time.Time
+	open                bool
+}
+
+// recordCommandResult updates the breaker of the cluster namespace with the result of a command
+func recordCommandResult(namespace, output string, err error) {
+	if err != nil && !isClusterUnreachableError(output, err) {
+		// the command reached the cluster, even if it failed
+		err = nil
+	}
+
+	commandBreakerMutex.Lock()
+	defer commandBreakerMutex.Unlock()
+
+	breaker, ok := commandBreakers[namespace]
+	if !ok {
+		breaker = &commandBreaker{}
+		commandBreakers[namespace] = breaker
+	}
+
+	if err == nil {
+		if breaker.open {
+			logger.Infof("ceph commands in namespace %q are succeeding again, resuming the reconcile of all controllers", namespace)
+		}
+		*breaker = commandBreaker{}
+		return
+	}
+
+	if breaker.consecutiveFailures == 0 {
+		breaker.firstFailure = time.Now()
+	}
+	breaker.consecutiveFailures++
+	if !breaker.open && breaker.consecutiveFailures >= CommandBreakerFailureThreshold && time.Since(breaker.firstFailure) >= CommandBreakerFailureDuration {
+		breaker.open = true
+		logger.Warningf("the last %d ceph commands in namespace %q failed to reach the cluster since %s. reducing the reconcile frequency of the controllers not critical to the cluster health until the cluster is reachable. %v",
+			breaker.consecutiveFailures, namespace, breaker.firstFailure.Format(time.RFC3339), err)
+	}
+}
+
+// IsCommandBreakerOpen returns whether the ceph commands of the cluster in the namespace have been
+// failing for long enough that the controllers should shed their load
+func IsCommandBreakerOpen(namespace string) bool {
+	commandBreakerMutex.Lock()
+	defer commandBreakerMutex.Unlock()
+
+	breaker, ok := commandBreakers[namespace]
+	return ok && breaker.open
+}
+
+// ResetCommandBreaker closes and forgets the breaker of the cluster in the namespace
+func ResetCommandBreaker(namespace string) {
+	commandBreakerMutex.Lock()
+	defer commandBreakerMutex.Unlock()
+
+	delete(commandBreakers, namespace)
+}
+
+func isClusterUnreachableError(output string, err error) bool {
+	if exec.IsTimeout(err) {
+		return true
+	}
+	// the ceph cli fails this way when the mons cannot be reached within the connect timeout
+	return strings.Contains(output, "error connecting to the cluster") || strings.Contains(err.Error(), "error connecting to the cluster")
+}
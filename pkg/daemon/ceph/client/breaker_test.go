@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/util/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandBreaker(t *testing.T) {
+	namespace := "breaker-ns"
+	threshold, duration := CommandBreakerFailureThreshold, CommandBreakerFailureDuration
+	defer func() {
+		CommandBreakerFailureThreshold, CommandBreakerFailureDuration = threshold, duration
+		ResetCommandBreaker(namespace)
+	}()
+	CommandBreakerFailureThreshold = 3
+	CommandBreakerFailureDuration = 0
+
+	timeoutErr := errors.Errorf("%s the command ceph to return", exec.TimeoutWaitingForMessage)
+	connectErr := errors.New("exit status 1")
+	connectOutput := "[errno 110] RADOS timed out (error connecting to the cluster)"
+
+	t.Run("failures reaching the cluster open the breaker", func(t *testing.T) {
+		recordCommandResult(namespace, "", timeoutErr)
+		recordCommandResult(namespace, connectOutput, connectErr)
+		assert.False(t, IsCommandBreakerOpen(namespace))
+		recordCommandResult(namespace, "", timeoutErr)
+		assert.True(t, IsCommandBreakerOpen(namespace))
+		assert.False(t, IsCommandBreakerOpen("other-ns"))
+	})
+
+	t.Run("a successful command closes the breaker", func(t *testing.T) {
+		recordCommandResult(namespace, "", nil)
+		assert.False(t, IsCommandBreakerOpen(namespace))
+	})
+
+	t.Run("commands failing in ceph do not open the breaker", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			recordCommandResult(namespace, "Error ENOENT: unrecognized pool", errors.New("exit status 2"))
+		}
+		assert.False(t, IsCommandBreakerOpen(namespace))
+	})
+
+	t.Run("failures must last long enough", func(t *testing.T) {
+		CommandBreakerFailureDuration = time.Hour
+		for i := 0; i < 5; i++ {
+			recordCommandResult(namespace, "", timeoutErr)
+		}
+		assert.False(t, IsCommandBreakerOpen(namespace))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		CommandBreakerFailureDuration = 0
+		recordCommandResult(namespace, "", timeoutErr)
+		assert.True(t, IsCommandBreakerOpen(namespace))
+		ResetCommandBreaker(namespace)
+		assert.False(t, IsCommandBreakerOpen(namespace))
+	})
+}
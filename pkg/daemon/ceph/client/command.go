@@ -230,6 +230,7 @@ func (c *CephToolCommand) run() ([]byte, error) {
 	} else {
 		output, err = c.context.Executor.ExecuteCommandWithTimeout(c.timeout, command, args...)
 	}
+	recordCommandResult(c.clusterInfo.Namespace, output, err)
 
 	return []byte(output), err
 }
@@ -256,12 +256,13 @@ func PopulateMonHostMembers(clusterInfo *ClusterInfo) ([]string, []string) {
 		// Detect the current port if the mon already exists
 		// so the same msgr1 port can be preserved if needed (6789 or 6790)
 		currentMonPort := cephutil.GetPortFromEndpoint(monitor.Endpoint)
+		msgr2Port := monitor.GetMsgr2Port()
 
-		if currentMonPort == Msgr2port {
-			msgr2Endpoint := net.JoinHostPort(monIP, strconv.Itoa(int(Msgr2port)))
+		if currentMonPort == msgr2Port {
+			msgr2Endpoint := net.JoinHostPort(monIP, strconv.Itoa(int(msgr2Port)))
 			monHosts = append(monHosts, "[v2:"+msgr2Endpoint+"]")
 		} else {
-			msgr2Endpoint := net.JoinHostPort(monIP, strconv.Itoa(int(Msgr2port)))
+			msgr2Endpoint := net.JoinHostPort(monIP, strconv.Itoa(int(msgr2Port)))
 			msgr1Endpoint := net.JoinHostPort(monIP, strconv.Itoa(int(currentMonPort)))
 			monHosts = append(monHosts, "[v2:"+msgr2Endpoint+",v1:"+msgr1Endpoint+"]")
 		}
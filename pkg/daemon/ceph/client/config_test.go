@@ -158,3 +158,22 @@ func verifyConfigValue(t *testing.T, actualConf *ini.File, section, key, expecte
 	actualVal := k.Value()
 	assert.Equal(t, expectedVal, actualVal)
 }
+
+func TestPopulateMonHostMembers(t *testing.T) {
+	clusterInfo := &ClusterInfo{
+		InternalMonitors: map[string]*MonInfo{
+			"a": {Name: "a", Endpoint: "10.0.0.1:6789"},
+			"b": {Name: "b", Endpoint: "10.0.0.2:3300"},
+			"c": {Name: "c", Endpoint: "10.0.0.3:6790", Msgr2Port: 3301},
+			"d": {Name: "d", Endpoint: "10.0.0.4:3301", Msgr2Port: 3301},
+		},
+	}
+	members, hosts := PopulateMonHostMembers(clusterInfo)
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, members)
+	assert.ElementsMatch(t, []string{
+		"[v2:10.0.0.1:3300,v1:10.0.0.1:6789]",
+		"[v2:10.0.0.2:3300]",
+		"[v2:10.0.0.3:3301,v1:10.0.0.3:6790]",
+		"[v2:10.0.0.4:3301]",
+	}, hosts)
+}
@@ -25,6 +25,10 @@ import (
 	"github.com/rook/rook/pkg/clusterd"
 )
 
+// StretchMaxDataZones is the max number of data zones of a stretch cluster. The stretch CRUSH rule
+// places two replicas in each data zone and allows at most ruleMaxSizeDefault replicas.
+const StretchMaxDataZones = ruleMaxSizeDefault / 2
+
 const (
 	crushReplicatedType      = 1
 	ruleMinSizeDefault       = 1
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// the life expectancy is formatted as a ceph utime, with or without the time zone
+var lifeExpectancyLayouts = []string{
+	"2006-01-02T15:04:05.000000-0700",
+	"2006-01-02T15:04:05.000000Z",
+	"2006-01-02 15:04:05.000000",
+	time.RFC3339,
+}
+
+// Device is a device of the cluster tracked by the devicehealth mgr module, as reported by
+// "ceph device ls"
+type Device struct {
+	ID       string           `json:"devid"`
+	Location []DeviceLocation `json:"location"`
+	Daemons  []string         `json:"daemons"`
+	// LifeExpectancyMin and LifeExpectancyMax are the range of the predicted failure of the
+	// device, they are empty when no failure is predicted
+	LifeExpectancyMin string `json:"life_expectancy_min,omitempty"`
+	LifeExpectancyMax string `json:"life_expectancy_max,omitempty"`
+}
+
+// DeviceLocation is a host and name of a device
+type DeviceLocation struct {
+	Host string `json:"host"`
+	Dev  string `json:"dev"`
+	Path string `json:"path"`
+}
+
+// PredictedFailure returns the latest time the device is predicted to fail, and whether a failure
+// is predicted
+func (d *Device) PredictedFailure() (time.Time, bool) {
+	if d.LifeExpectancyMax == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range lifeExpectancyLayouts {
+		if t, err := time.Parse(layout, d.LifeExpectancyMax); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// OSDs returns the IDs of the OSDs using the device
+func (d *Device) OSDs() []string {
+	var osds []string
+	for _, daemon := range d.Daemons {
+		if id, ok := strings.CutPrefix(daemon, "osd."); ok {
+			osds = append(osds, id)
+		}
+	}
+	return osds
+}
+
+// ListDevices returns the devices tracked by the devicehealth mgr module
+func ListDevices(context *clusterd.Context, clusterInfo *ClusterInfo) ([]Device, error) {
+	args := []string{"device", "ls"}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the devices")
+	}
+
+	var devices []Device
+	if err := json.Unmarshal(buf, &devices); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the devices. %s", string(buf))
+	}
+	return devices, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDevices(t *testing.T) {
+	executor := &exectest.MockExecutor{}
+	context := &clusterd.Context{Executor: executor}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		if args[0] == "device" && args[1] == "ls" {
+			return `[
+				{"devid":"ATA_DISK_1","location":[{"host":"node1","dev":"sdb","path":"/dev/disk/by-path/pci-0000:00:1f.2-ata-2"}],"daemons":["mon.a","osd.3"],"life_expectancy_min":"2025-03-01T00:00:00.000000+0000","life_expectancy_max":"2025-03-08T00:00:00.000000+0000"},
+				{"devid":"ATA_DISK_2","location":[{"host":"node1","dev":"sdc","path":""}],"daemons":["osd.4"]}]`, nil
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+
+	devices, err := ListDevices(context, AdminTestClusterInfo("mycluster"))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, []string{"3"}, devices[0].OSDs())
+	failure, ok := devices[0].PredictedFailure()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), failure.UTC())
+	_, ok = devices[1].PredictedFailure()
+	assert.False(t, ok)
+
+	// the life expectancy may be formatted without the time zone
+	device := Device{LifeExpectancyMax: "2025-03-08 12:00:00.000000"}
+	failure, ok = device.PredictedFailure()
+	assert.True(t, ok)
+	assert.Equal(t, 12, failure.Hour())
+}
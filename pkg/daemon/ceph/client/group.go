@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// ListImageGroups returns the names of the rbd groups of the pool, such as the groups of the
+// volume groups created by the CSI driver
+func ListImageGroups(context *clusterd.Context, clusterInfo *ClusterInfo, poolName string) ([]string, error) {
+	args := []string{"group", "list", "--pool", poolName}
+	cmd := NewRBDCommand(context, clusterInfo, args)
+	cmd.JsonOutput = true
+	buf, err := cmd.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the rbd groups of pool %q. %s", poolName, string(buf))
+	}
+
+	var groups []string
+	if err := json.Unmarshal(buf, &groups); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the rbd groups of pool %q. %s", poolName, string(buf))
+	}
+	return groups, nil
+}
+
+// CreateGroupSnapshot takes a crash-consistent snapshot of all the images of the rbd group. The
+// clients of the images are asked to quiesce and flush their caches before the snapshot is taken.
+func CreateGroupSnapshot(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, groupName, snapshot string) error {
+	args := []string{"group", "snap", "create", getGroupSnapshotSpec(poolName, groupName, snapshot)}
+	buf, err := NewRBDCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create snapshot %q of rbd group %q in pool %q. %s", snapshot, groupName, poolName, string(buf))
+	}
+	return nil
+}
+
+// DeleteGroupSnapshot deletes a snapshot of the rbd group
+func DeleteGroupSnapshot(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, groupName, snapshot string) error {
+	args := []string{"group", "snap", "rm", getGroupSnapshotSpec(poolName, groupName, snapshot)}
+	buf, err := NewRBDCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete snapshot %q of rbd group %q in pool %q. %s", snapshot, groupName, poolName, string(buf))
+	}
+	return nil
+}
+
+func getGroupSnapshotSpec(poolName, groupName, snapshot string) string {
+	return fmt.Sprintf("%s/%s@%s", poolName, groupName, snapshot)
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -93,6 +94,32 @@ func ListSnapshotsInRadosNamespace(context *clusterd.Context, clusterInfo *Clust
 	return snapshots, nil
 }
 
+// CreateImageInPool creates an image of the given size in MiB in a cephblockpool
+func CreateImageInPool(context *clusterd.Context, clusterInfo *ClusterInfo, name, poolName string, sizeMiB uint64) error {
+	logger.Infof("creating rbd image %q of %dMiB in pool %q", name, sizeMiB, poolName)
+	args := []string{"create", getImageSpec(name, poolName), "--size", strconv.FormatUint(sizeMiB, 10)}
+	buf, err := NewRBDCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create image %s in pool %s, output: %s",
+			name, poolName, string(buf))
+	}
+	return nil
+}
+
+// CreateSnapshotInRadosNamespace creates a snapshot of an image in a cephblockpool in a given rados namespace
+func CreateSnapshotInRadosNamespace(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, imageName, snapshot, namespace string) error {
+	args := []string{"snap", "create", getImageSnapshotSpec(poolName, imageName, snapshot)}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	cmd := NewRBDCommand(context, clusterInfo, args)
+	_, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create snapshot %q of image %q in cephblockpool %q", snapshot, imageName, poolName)
+	}
+	return nil
+}
+
 // DeleteSnapshotInRadosNamespace deletes a image snapshot created in block pool in a given rados namespace
 func DeleteSnapshotInRadosNamespace(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, imageName, snapshot, namespace string) error {
 	args := []string{"snap", "rm", getImageSnapshotSpec(poolName, imageName, snapshot)}
@@ -82,6 +82,9 @@ type MonInfo struct {
 	Endpoint string `json:"endpoint"`
 	// Whether detected out of quorum by rook. May be different from actual ceph quorum.
 	OutOfQuorum bool `json:"outOfQuorum"`
+	// Msgr2Port is the port of the messenger v2 protocol when the mon does not listen on the default
+	// port. The endpoint has the msgr2 port when the mon does not listen on the msgr1 port.
+	Msgr2Port int32 `json:"msgr2Port,omitempty"`
 }
 
 // CephCred represents the Ceph cluster username and key used by the operator.
@@ -163,6 +166,14 @@ func NewMonInfo(name, ip string, port int32) *MonInfo {
 	return &MonInfo{Name: name, Endpoint: net.JoinHostPort(ip, fmt.Sprintf("%d", port))}
 }
 
+// GetMsgr2Port returns the port of the messenger v2 protocol of the mon
+func (m *MonInfo) GetMsgr2Port() int32 {
+	if m.Msgr2Port == 0 {
+		return Msgr2port
+	}
+	return m.Msgr2Port
+}
+
 func NewMinimumOwnerInfo(t *testing.T) *k8sutil.OwnerInfo {
 	cluster := &cephv1.CephCluster{}
 	scheme := runtime.NewScheme()
@@ -49,6 +49,46 @@ type MirroredImages struct {
 type Images struct {
 	// Name of the pool image
 	Name string
+	// State of the image mirroring on the local site, for example "up+replaying"
+	State string `json:"state"`
+	// Description of the state, with the replay status when the image is replaying
+	Description string `json:"description"`
+	// LastUpdate is the time the state was last updated
+	LastUpdate string `json:"last_update"`
+	// PeerSites are the states of the image mirroring on the peer sites
+	PeerSites []ImagePeerSite `json:"peer_sites"`
+}
+
+// ImagePeerSite is the state of the mirroring of an image on a peer site
+type ImagePeerSite struct {
+	SiteName    string `json:"site_name"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	LastUpdate  string `json:"last_update"`
+}
+
+// ImageReplayStatus is the replay status in the description of a replaying image
+type ImageReplayStatus struct {
+	LastSnapshotSyncSeconds int64  `json:"last_snapshot_sync_seconds"`
+	LocalSnapshotTimestamp  int64  `json:"local_snapshot_timestamp"`
+	RemoteSnapshotTimestamp int64  `json:"remote_snapshot_timestamp"`
+	ReplayState             string `json:"replay_state"`
+}
+
+// ParseImageReplayStatus returns the replay status in the description of a replaying image, for
+// example `replaying, {"remote_snapshot_timestamp":1710734899,"replay_state":"idle"}`, or nil if
+// the description has no replay status
+func ParseImageReplayStatus(description string) *ImageReplayStatus {
+	i := strings.Index(description, "{")
+	if i < 0 {
+		return nil
+	}
+	var status ImageReplayStatus
+	if err := json.Unmarshal([]byte(description[i:]), &status); err != nil {
+		logger.Debugf("failed to parse the image replay status %q. %v", description, err)
+		return nil
+	}
+	return &status
 }
 
 const (
@@ -313,7 +353,7 @@ func removeSnapshotSchedule(context *clusterd.Context, clusterInfo *ClusterInfo,
 func EnableSnapshotSchedules(context *clusterd.Context, clusterInfo *ClusterInfo, poolName string, snapshotSchedules []cephv1.SnapshotScheduleSpec) error {
 	logger.Info("resetting current snapshot schedules in cluster namespace %q", clusterInfo.Namespace)
 	// Reset any existing schedules
-	err := removeSnapshotSchedules(context, clusterInfo, poolName)
+	err := RemoveSnapshotSchedules(context, clusterInfo, poolName)
 	if err != nil {
 		logger.Errorf("failed to remove snapshot schedules. %v", err)
 	}
@@ -329,8 +369,8 @@ func EnableSnapshotSchedules(context *clusterd.Context, clusterInfo *ClusterInfo
 	return nil
 }
 
-// removeSnapshotSchedules removes all the existing snapshot schedules
-func removeSnapshotSchedules(context *clusterd.Context, clusterInfo *ClusterInfo, pool string) error {
+// RemoveSnapshotSchedules removes all the existing snapshot schedules of the pool or the pool/radosNamespace
+func RemoveSnapshotSchedules(context *clusterd.Context, clusterInfo *ClusterInfo, pool string) error {
 	// Get the list of existing snapshot schedule
 	existingSnapshotSchedules, err := listSnapshotSchedules(context, clusterInfo, pool)
 	if err != nil {
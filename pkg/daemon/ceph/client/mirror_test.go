@@ -130,6 +130,16 @@ func TestGetMirroredPoolImages(t *testing.T) {
 	mirroredImages, err := GetMirroredPoolImages(context, AdminTestClusterInfo("mycluster"), pool)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(*mirroredImages.Images))
+	image := (*mirroredImages.Images)[0]
+	assert.Equal(t, "up+stopped", image.State)
+	assert.Len(t, image.PeerSites, 1)
+	assert.Equal(t, "up+replaying", image.PeerSites[0].State)
+
+	replayStatus := ParseImageReplayStatus(image.PeerSites[0].Description)
+	assert.NotNil(t, replayStatus)
+	assert.Equal(t, int64(1710734899), replayStatus.RemoteSnapshotTimestamp)
+	assert.Equal(t, "idle", replayStatus.ReplayState)
+	assert.Nil(t, ParseImageReplayStatus(image.Description))
 }
 
 func TestImportRBDMirrorBootstrapPeer(t *testing.T) {
@@ -341,7 +351,7 @@ func TestRemoveSnapshotSchedules(t *testing.T) {
 			},
 		},
 	}
-	err := removeSnapshotSchedules(context, AdminTestClusterInfo("mycluster"), pool.Name)
+	err := RemoveSnapshotSchedules(context, AdminTestClusterInfo("mycluster"), pool.Name)
 	assert.NoError(t, err)
 }
 
@@ -17,6 +17,7 @@ package client
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -65,12 +66,15 @@ type MonDump struct {
 	Mons             []MonDumpEntry `json:"mons"`
 	Quorum           []int          `json:"quorum"`
 	TiebreakerMon    string         `json:"tiebreaker_mon"`
+	// The key of the disallowed leaders in the ceph output includes a trailing colon
+	DisallowedLeaders string `json:"disallowed_leaders: "`
 }
 
 type MonDumpEntry struct {
 	Name          string `json:"name"`
 	Rank          int    `json:"rank"`
 	CrushLocation string `json:"crush_location"`
+	Weight        int    `json:"weight"`
 }
 
 // GetMonQuorumStatus calls quorum_status mon_command
@@ -109,6 +113,23 @@ func GetMonDump(context *clusterd.Context, clusterInfo *ClusterInfo) (MonDump, e
 	return response, nil
 }
 
+// The election strategies reported by the mon dump
+const (
+	MonElectionStrategyClassic      = 1
+	MonElectionStrategyDisallow     = 2
+	MonElectionStrategyConnectivity = 3
+)
+
+// EnableDisallowElectionStrategy enables the mon election algorithm that honors the disallowed leaders
+func EnableDisallowElectionStrategy(context *clusterd.Context, clusterInfo *ClusterInfo) error {
+	args := []string{"mon", "set", "election_strategy", "disallow"}
+	if _, err := NewCephCommand(context, clusterInfo, args).Run(); err != nil {
+		return errors.Wrap(err, "failed to enable the disallow election strategy")
+	}
+	logger.Info("successfully enabled the disallow election strategy")
+	return nil
+}
+
 // EnableStretchElectionStrategy enables the mon connectivity algorithm for stretch clusters
 func EnableStretchElectionStrategy(context *clusterd.Context, clusterInfo *ClusterInfo) error {
 	args := []string{"mon", "set", "election_strategy", "connectivity"}
@@ -164,3 +185,48 @@ func SetNewTiebreaker(context *clusterd.Context, clusterInfo *ClusterInfo, monNa
 	logger.Infof("successfully set new mon tiebreaker %q in arbiter zone", monName)
 	return nil
 }
+
+// IsDisallowedLeader returns whether the mon is in the list of mons that cannot be elected leader
+func (d *MonDump) IsDisallowedLeader(monName string) bool {
+	for _, name := range strings.Split(d.DisallowedLeaders, ",") {
+		if strings.TrimSpace(name) == monName {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMonWeight sets the weight of a mon, which clients use to choose the mon they connect to
+func SetMonWeight(context *clusterd.Context, clusterInfo *ClusterInfo, monName string, weight int) error {
+	args := []string{"mon", "set-weight", monName, strconv.Itoa(weight)}
+	if _, err := NewCephCommand(context, clusterInfo, args).Run(); err != nil {
+		return errors.Wrapf(err, "failed to set weight %d on mon %q", weight, monName)
+	}
+	logger.Infof("successfully set weight %d on mon %q", weight, monName)
+	return nil
+}
+
+// SetMonAddrs sets the addresses of a mon in the monmap, for example to drop the msgr1 address of
+// a mon that only listens on the msgr2 port
+func SetMonAddrs(context *clusterd.Context, clusterInfo *ClusterInfo, monName, addrs string) error {
+	args := []string{"mon", "set-addrs", monName, addrs}
+	if _, err := NewCephCommand(context, clusterInfo, args).Run(); err != nil {
+		return errors.Wrapf(err, "failed to set addrs %q on mon %q", addrs, monName)
+	}
+	logger.Infof("successfully set addrs %q on mon %q", addrs, monName)
+	return nil
+}
+
+// SetMonDisallowedLeader adds or removes a mon from the list of mons that cannot be elected leader
+func SetMonDisallowedLeader(context *clusterd.Context, clusterInfo *ClusterInfo, monName string, disallow bool) error {
+	action := "rm"
+	if disallow {
+		action = "add"
+	}
+	args := []string{"mon", action, "disallowed_leader", monName}
+	if _, err := NewCephCommand(context, clusterInfo, args).Run(); err != nil {
+		return errors.Wrapf(err, "failed to %s mon %q in the disallowed leaders", action, monName)
+	}
+	logger.Infof("successfully set mon %q disallowed leader to %t", monName, disallow)
+	return nil
+}
@@ -133,8 +133,8 @@ func TestMonDump(t *testing.T) {
 		"election_strategy":1,"mons":[
 		{"rank":0,"name":"a","crush_location":"{zone=a}","public_addrs":{"addrvec":[{"type":"v2","addr":"10.109.80.104:3300","nonce":0},{"type":"v1","addr":"10.109.80.104:6789","nonce":0}]},"addr":"10.109.80.104:6789/0","public_addr":"10.109.80.104:6789/0","priority":0,"weight":0},
 		{"rank":1,"name":"b","crush_location":"{zone=b}","public_addrs":{"addrvec":[{"type":"v2","addr":"10.107.12.199:3300","nonce":0},{"type":"v1","addr":"10.107.12.199:6789","nonce":0}]},"addr":"10.107.12.199:6789/0","public_addr":"10.107.12.199:6789/0","priority":0,"weight":0},
-		{"rank":2,"name":"c","crush_location":"{zone=c}","public_addrs":{"addrvec":[{"type":"v2","addr":"10.107.5.207:3300","nonce":0},{"type":"v1","addr":"10.107.5.207:6789","nonce":0}]},"addr":"10.107.5.207:6789/0","public_addr":"10.107.5.207:6789/0","priority":0,"weight":0}],
-		"quorum":[0,1,2]}`, nil
+		{"rank":2,"name":"c","crush_location":"{zone=c}","public_addrs":{"addrvec":[{"type":"v2","addr":"10.107.5.207:3300","nonce":0},{"type":"v1","addr":"10.107.5.207:6789","nonce":0}]},"addr":"10.107.5.207:6789/0","public_addr":"10.107.5.207:6789/0","priority":0,"weight":10}],
+		"disallowed_leaders: ":"a,c","quorum":[0,1,2]}`, nil
 		}
 		return "", errors.Errorf("unexpected ceph command %q", args)
 	}
@@ -151,4 +151,54 @@ func TestMonDump(t *testing.T) {
 	assert.Equal(t, 1, dump.Mons[1].Rank)
 	assert.Equal(t, 3, len(dump.Mons))
 	assert.Equal(t, 3, len(dump.Quorum))
+	assert.Equal(t, 10, dump.Mons[2].Weight)
+	assert.True(t, dump.IsDisallowedLeader("a"))
+	assert.False(t, dump.IsDisallowedLeader("b"))
+	assert.True(t, dump.IsDisallowedLeader("c"))
+}
+
+func TestMonElectionPreferences(t *testing.T) {
+	var calledArgs []string
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		if args[0] == "mon" {
+			calledArgs = args
+			return "", nil
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := AdminTestClusterInfo("mycluster")
+
+	err := SetMonWeight(context, clusterInfo, "a", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mon", "set-weight", "a", "100"}, calledArgs[:4])
+
+	err = SetMonDisallowedLeader(context, clusterInfo, "b", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mon", "add", "disallowed_leader", "b"}, calledArgs[:4])
+
+	err = SetMonDisallowedLeader(context, clusterInfo, "b", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mon", "rm", "disallowed_leader", "b"}, calledArgs[:4])
+}
+
+func TestSetMonAddrs(t *testing.T) {
+	var calledArgs []string
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		if args[0] == "mon" && args[1] == "set-addrs" {
+			calledArgs = args
+			return "", nil
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := AdminTestClusterInfo("mycluster")
+
+	err := SetMonAddrs(context, clusterInfo, "c", "[v2:10.0.0.3:3300]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mon", "set-addrs", "c", "[v2:10.0.0.3:3300]"}, calledArgs[:4])
 }
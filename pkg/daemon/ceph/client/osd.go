@@ -63,9 +63,12 @@ type OSDPerfStats struct {
 
 type OSDDump struct {
 	OSDs []struct {
-		OSD json.Number `json:"osd"`
-		Up  json.Number `json:"up"`
-		In  json.Number `json:"in"`
+		OSD  json.Number `json:"osd"`
+		Up   json.Number `json:"up"`
+		In   json.Number `json:"in"`
+		UUID string      `json:"uuid"`
+		// UpFrom is the epoch the OSD was last marked up
+		UpFrom json.Number `json:"up_from"`
 	} `json:"osds"`
 	Flags             string              `json:"flags"`
 	CrushNodeFlags    map[string][]string `json:"crush_node_flags"`
@@ -262,6 +265,16 @@ func ResizeOsdCrushWeight(actualOSD OSDNodeUsage, ctx *clusterd.Context, cluster
 	return true, nil
 }
 
+// SetOSDCrushWeight sets the CRUSH weight of the OSD, in TiB
+func SetOSDCrushWeight(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, weight float64) error {
+	args := []string{"osd", "crush", "reweight", fmt.Sprintf("osd.%d", osdID), fmt.Sprintf("%f", weight)}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the crush weight of osd.%d to %f. %s", osdID, weight, string(buf))
+	}
+	return nil
+}
+
 func SetDeviceClass(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, deviceClass string) error {
 	// First remove the existing device class
 	args := []string{"osd", "crush", "rm-device-class", fmt.Sprintf("osd.%d", osdID)}
@@ -406,6 +419,20 @@ type OSDOkToStopStats struct {
 	OkBecomeDegraded  []string `json:"ok_become_degraded"`
 }
 
+// OSDsOkToStop returns an error if the OSDs cannot all be stopped at the same time without making
+// placement groups unavailable
+func OSDsOkToStop(context *clusterd.Context, clusterInfo *ClusterInfo, osdIDs []int) error {
+	args := []string{"osd", "ok-to-stop"}
+	for _, id := range osdIDs {
+		args = append(args, strconv.Itoa(id))
+	}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "osds %v are not ok to stop. %s", osdIDs, string(buf))
+	}
+	return nil
+}
+
 // OSDOkToStop returns a list of OSDs that can be stopped that includes the OSD ID given.
 // This is relevant, for example, when checking which OSDs can be updated.
 // The number of OSDs returned is limited by the value set in maxReturned.
@@ -476,3 +503,60 @@ func BlocklistIP(context *clusterd.Context, clusterInfo *ClusterInfo, ip, durati
 	}
 	return nil
 }
+
+// BlocklistRange blocklists the IPs of a CIDR range for the duration in seconds
+func BlocklistRange(context *clusterd.Context, clusterInfo *ClusterInfo, cidr, duration string) error {
+	args := []string{"osd", "blocklist", "range", "add", cidr, duration}
+	_, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to blocklist range %q", cidr)
+	}
+	return nil
+}
+
+// ListBlocklist returns the addresses and the CIDR ranges in the blocklist, in the legacy format
+// of ceph, for example "10.0.0.1:0/0" for an IP and "10.0.0.0:0/24" for a range
+func ListBlocklist(context *clusterd.Context, clusterInfo *ClusterInfo) ([]string, error) {
+	args := []string{"osd", "blocklist", "ls"}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the blocklist")
+	}
+	var entries []struct {
+		Addr  string `json:"addr"`
+		Range string `json:"range"`
+	}
+	if err := json.Unmarshal(buf, &entries); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the blocklist %q", string(buf))
+	}
+	addresses := []string{}
+	for _, entry := range entries {
+		if entry.Addr != "" {
+			addresses = append(addresses, entry.Addr)
+		}
+		if entry.Range != "" {
+			addresses = append(addresses, entry.Range)
+		}
+	}
+	return addresses, nil
+}
+
+// UnblocklistIP removes the IP or the address of a client from the blocklist
+func UnblocklistIP(context *clusterd.Context, clusterInfo *ClusterInfo, ip string) error {
+	args := []string{"osd", "blocklist", "rm", ip}
+	_, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove IP %q from the blocklist", ip)
+	}
+	return nil
+}
+
+// UnblocklistRange removes a CIDR range of IPs from the blocklist
+func UnblocklistRange(context *clusterd.Context, clusterInfo *ClusterInfo, cidr string) error {
+	args := []string{"osd", "blocklist", "range", "rm", cidr}
+	_, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove range %q from the blocklist", cidr)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// OSDSafetyCheck selects the checks of CheckOSDSafety
+type OSDSafetyCheck struct {
+	// OSDs are the OSDs to stop or remove. Only the health of the cluster is checked if empty.
+	OSDs []int
+	// Remove also checks that the OSDs are safe to destroy, that the PGs they store have all their
+	// copies on other OSDs
+	Remove bool
+	// RequireCleanPGs checks that all the PGs are in a state matching PGHealthyRegex
+	RequireCleanPGs bool
+	// PGHealthyRegex is the regex of the healthy PG states, the default regex if empty
+	PGHealthyRegex string
+}
+
+// OSDSafety is the result of the safety checks of OSDs
+type OSDSafety struct {
+	// Safe is whether the OSDs can be stopped, or removed, without making data unavailable or losing
+	// copies of the data
+	Safe bool
+	// Reasons are the checks that failed
+	Reasons []string
+	// PGHealthMsg describes the state of the PGs
+	PGHealthMsg     string
+	DegradedObjects uint64
+	UnfoundObjects  uint64
+}
+
+func (s *OSDSafety) String() string {
+	if s.Safe {
+		return "safe"
+	}
+	return strings.Join(s.Reasons, "; ")
+}
+
+func (s *OSDSafety) addReason(format string, args ...interface{}) {
+	s.Safe = false
+	s.Reasons = append(s.Reasons, fmt.Sprintf(format, args...))
+}
+
+// CheckOSDSafety checks whether the OSDs can be stopped, or removed with check.Remove. The purge of
+// OSDs, the node maintenance and the disruption budgets of the OSDs share the checks so that they
+// agree on when OSDs can be disrupted. The OSDs are not safe to disrupt if:
+//   - objects are unfound or degraded, since the OSDs may hold their last copies
+//   - the PGs are not clean, if check.RequireCleanPGs is set
+//   - stopping the OSDs makes PGs inactive, as reported by 'ceph osd ok-to-stop'
+//   - the OSDs store PGs without copies on other OSDs, as reported by 'ceph osd safe-to-destroy',
+//     if check.Remove is set
+//
+// An error is returned if the checks cannot be run.
+func CheckOSDSafety(context *clusterd.Context, clusterInfo *ClusterInfo, check OSDSafetyCheck) (*OSDSafety, error) {
+	status, err := Status(context, clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+	pgHealthyRegex := defaultPgHealthyRegexCompiled
+	if check.PGHealthyRegex != "" {
+		pgHealthyRegex, err = regexp.Compile(check.PGHealthyRegex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile pg healthy regex %q", check.PGHealthyRegex)
+		}
+	}
+
+	safety := &OSDSafety{Safe: true, DegradedObjects: status.PgMap.DegradedObjects, UnfoundObjects: status.PgMap.UnfoundObjects}
+	if safety.UnfoundObjects > 0 {
+		safety.addReason("%d objects are unfound", safety.UnfoundObjects)
+	}
+	if safety.DegradedObjects > 0 {
+		safety.addReason("%d objects are degraded", safety.DegradedObjects)
+	}
+	msg, clean := isClusterClean(status, pgHealthyRegex)
+	safety.PGHealthMsg = msg
+	if check.RequireCleanPGs && !clean {
+		safety.addReason("%s", msg)
+	}
+	if len(check.OSDs) == 0 {
+		return safety, nil
+	}
+
+	if err := OSDsOkToStop(context, clusterInfo, check.OSDs); err != nil {
+		safety.addReason("stopping osds %v would make PGs unavailable. %v", check.OSDs, err)
+	}
+	if !check.Remove {
+		return safety, nil
+	}
+	for _, osdID := range check.OSDs {
+		safe, err := OsdSafeToDestroy(context, clusterInfo, osdID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check if osd.%d is safe to destroy", osdID)
+		}
+		if !safe {
+			safety.addReason("osd.%d stores PGs whose data is not copied to the other osds yet", osdID)
+		}
+	}
+	return safety, nil
+}
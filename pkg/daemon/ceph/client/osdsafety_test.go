@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOSDSafety(t *testing.T) {
+	pgs := `{"state_name":"active+clean","count":10}`
+	degraded := 0
+	okToStop := true
+	safeToDestroy := true
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "status":
+				return fmt.Sprintf(`{"pgmap":{"pgs_by_state":[%s],"num_pgs":10,"degraded_objects":%d}}`, pgs, degraded), nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				commands = append(commands, "ok-to-stop")
+				if !okToStop {
+					return "", errors.New("Error EBUSY: unsafe to stop osd(s)")
+				}
+				return "", nil
+			case args[0] == "osd" && args[1] == "safe-to-destroy":
+				commands = append(commands, "safe-to-destroy "+args[2])
+				if !safeToDestroy {
+					return `{"safe_to_destroy":[]}`, nil
+				}
+				return fmt.Sprintf(`{"safe_to_destroy":[%s]}`, args[2]), nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := AdminTestClusterInfo("mycluster")
+	check := func(check OSDSafetyCheck) *OSDSafety {
+		commands = nil
+		safety, err := CheckOSDSafety(context, clusterInfo, check)
+		require.NoError(t, err)
+		return safety
+	}
+
+	// the cluster health is checked without osds
+	safety := check(OSDSafetyCheck{RequireCleanPGs: true})
+	assert.True(t, safety.Safe)
+	assert.Equal(t, "all PGs in cluster are clean", safety.PGHealthMsg)
+	assert.Empty(t, commands)
+
+	// the osds to stop are ok to stop, the osds to remove are also safe to destroy
+	assert.True(t, check(OSDSafetyCheck{OSDs: []int{1, 2}}).Safe)
+	assert.Equal(t, []string{"ok-to-stop"}, commands)
+	assert.True(t, check(OSDSafetyCheck{OSDs: []int{1, 2}, Remove: true}).Safe)
+	assert.Equal(t, []string{"ok-to-stop", "safe-to-destroy 1", "safe-to-destroy 2"}, commands)
+
+	safeToDestroy = false
+	safety = check(OSDSafetyCheck{OSDs: []int{1}, Remove: true})
+	assert.False(t, safety.Safe)
+	assert.Equal(t, []string{"osd.1 stores PGs whose data is not copied to the other osds yet"}, safety.Reasons)
+	assert.True(t, check(OSDSafetyCheck{OSDs: []int{1}}).Safe)
+
+	okToStop = false
+	safety = check(OSDSafetyCheck{OSDs: []int{1}})
+	assert.False(t, safety.Safe)
+	assert.Len(t, safety.Reasons, 1)
+
+	// the osds are not safe to disrupt while objects are degraded
+	okToStop = true
+	degraded = 5
+	pgs = `{"state_name":"active+clean","count":8},{"state_name":"active+undersized+degraded","count":2}`
+	safety = check(OSDSafetyCheck{})
+	assert.False(t, safety.Safe)
+	assert.Equal(t, uint64(5), safety.DegradedObjects)
+	assert.Equal(t, []string{"5 objects are degraded"}, safety.Reasons)
+
+	// the unclean pgs are only checked if required
+	degraded = 0
+	assert.True(t, check(OSDSafetyCheck{}).Safe)
+	safety = check(OSDSafetyCheck{RequireCleanPGs: true})
+	assert.False(t, safety.Safe)
+	assert.Contains(t, safety.String(), "cluster is not fully clean")
+	assert.True(t, check(OSDSafetyCheck{RequireCleanPGs: true, PGHealthyRegex: `^active\+`}).Safe)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// PGStat is the state of a placement group in the output of "ceph pg ls-by-pool"
+type PGStat struct {
+	PGID   string `json:"pgid"`
+	State  string `json:"state"`
+	Up     []int  `json:"up"`
+	Acting []int  `json:"acting"`
+}
+
+// IsActive returns whether the placement group serves IO
+func (pg *PGStat) IsActive() bool {
+	return strings.Contains(pg.State, "active")
+}
+
+// IsRemapped returns whether the placement group is mapped to other OSDs than the OSDs it is
+// stored on, so that its data is moving
+func (pg *PGStat) IsRemapped() bool {
+	if len(pg.Up) != len(pg.Acting) {
+		return true
+	}
+	for i := range pg.Up {
+		if pg.Up[i] != pg.Acting[i] {
+			return true
+		}
+	}
+	return strings.Contains(pg.State, "remapped") || strings.Contains(pg.State, "backfill")
+}
+
+type pgLsOutput struct {
+	PGStats []PGStat `json:"pg_stats"`
+}
+
+// PGUpmapItems are the exceptions to the CRUSH mapping of a placement group in the OSD map
+type PGUpmapItems struct {
+	PGID     string `json:"pgid"`
+	Mappings []struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	} `json:"mappings"`
+}
+
+type osdDumpUpmaps struct {
+	PGUpmapItems []PGUpmapItems `json:"pg_upmap_items"`
+}
+
+// GetPoolPGStats returns the state of the placement groups of the pool
+func GetPoolPGStats(context *clusterd.Context, clusterInfo *ClusterInfo, poolName string) ([]PGStat, error) {
+	args := []string{"pg", "ls-by-pool", poolName}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the pgs of pool %q. %s", poolName, string(buf))
+	}
+	var output pgLsOutput
+	if err := json.Unmarshal(buf, &output); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the pgs of pool %q", poolName)
+	}
+	return output.PGStats, nil
+}
+
+// GetPoolPGUpmapItems returns the upmap items of the placement groups of the pool with the given ID
+func GetPoolPGUpmapItems(context *clusterd.Context, clusterInfo *ClusterInfo, poolID int) ([]PGUpmapItems, error) {
+	args := []string{"osd", "dump"}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get osd dump. %s", string(buf))
+	}
+	var dump osdDumpUpmaps
+	if err := json.Unmarshal(buf, &dump); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal osd dump")
+	}
+	prefix := strconv.Itoa(poolID) + "."
+	items := []PGUpmapItems{}
+	for _, item := range dump.PGUpmapItems {
+		if strings.HasPrefix(item.PGID, prefix) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// SetPGUpmapItems maps the placement group to other OSDs than the OSDs of its CRUSH mapping, the
+// mappings are pairs of the OSD of the CRUSH mapping and the OSD to use instead
+func SetPGUpmapItems(context *clusterd.Context, clusterInfo *ClusterInfo, pgID string, mappings [][2]int) error {
+	args := []string{"osd", "pg-upmap-items", pgID}
+	for _, mapping := range mappings {
+		args = append(args, strconv.Itoa(mapping[0]), strconv.Itoa(mapping[1]))
+	}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the upmap items of pg %q. %s", pgID, string(buf))
+	}
+	return nil
+}
+
+// RemovePGUpmapItems maps the placement group to the OSDs of its CRUSH mapping again
+func RemovePGUpmapItems(context *clusterd.Context, clusterInfo *ClusterInfo, pgID string) error {
+	args := []string{"osd", "rm-pg-upmap-items", pgID}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove the upmap items of pg %q. %s", pgID, string(buf))
+	}
+	return nil
+}
@@ -55,6 +55,7 @@ type CephStoragePoolDetails struct {
 	TargetSizeRatio        float64 `json:"target_size_ratio,omitempty"`
 	RequireSafeReplicaSize bool    `json:"requireSafeReplicaSize,omitempty"`
 	CrushRule              string  `json:"crush_rule"`
+	PGNum                  int     `json:"pg_num,omitempty"`
 }
 
 type CephStoragePoolStats struct {
@@ -116,7 +117,8 @@ func GetPoolNamesByID(context *clusterd.Context, clusterInfo *ClusterInfo) (map[
 	return names, nil
 }
 
-func getPoolApplication(context *clusterd.Context, clusterInfo *ClusterInfo, poolName string) (string, error) {
+// GetPoolApplication returns the application enabled on the pool, or an empty string if none is enabled
+func GetPoolApplication(context *clusterd.Context, clusterInfo *ClusterInfo, poolName string) (string, error) {
 	args := []string{"osd", "pool", "application", "get", poolName}
 	appDetails, err := NewCephCommand(context, clusterInfo, args).Run()
 	if err != nil {
@@ -303,7 +305,7 @@ func DeletePool(context *clusterd.Context, clusterInfo *ClusterInfo, name string
 }
 
 func givePoolAppTag(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, appName string) error {
-	currentAppName, err := getPoolApplication(context, clusterInfo, poolName)
+	currentAppName, err := GetPoolApplication(context, clusterInfo, poolName)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get application for pool %q", poolName)
 	}
@@ -500,31 +502,54 @@ func updatePoolCrushRule(context *clusterd.Context, clusterInfo *ClusterInfo, cl
 		logger.Debugf("Skipping crush rule update for pool %q: EnableCrushUpdates is disabled", pool.Name)
 		return nil
 	}
+
+	_, crushRuleName, err := PreparePoolCrushRuleUpdate(context, clusterInfo, clusterSpec, pool)
+	if err != nil {
+		return err
+	}
+	if crushRuleName == "" {
+		return nil
+	}
+
+	// Update the crush rule on the pool
+	if err := setCrushRule(context, clusterInfo, pool.Name, crushRuleName); err != nil {
+		return errors.Wrapf(err, "failed to set crush rule on pool %q", pool.Name)
+	}
+
+	logger.Infof("Successfully updated pool %q failure domain to %q", pool.Name, pool.FailureDomain)
+	return nil
+}
+
+// PreparePoolCrushRuleUpdate creates a crush rule for the failure domain and the device class of
+// the replicated pool if its crush rule has a different failure domain or device class. It returns
+// the current crush rule of the pool and the new crush rule, which is empty if the crush rule of
+// the pool does not need to be updated.
+func PreparePoolCrushRuleUpdate(context *clusterd.Context, clusterInfo *ClusterInfo, clusterSpec *cephv1.ClusterSpec, pool cephv1.NamedPoolSpec) (string, string, error) {
 	if clusterSpec.IsStretchCluster() {
 		logger.Debugf("skipping crush rule update for pool %q in a stretch cluster", pool.Name)
-		return nil
+		return "", "", nil
 	}
 
 	if pool.FailureDomain == "" && pool.DeviceClass == "" {
 		logger.Debugf("skipping check for failure domain and deviceClass on pool %q as it is not specified", pool.Name)
-		return nil
+		return "", "", nil
 	}
 
 	logger.Debugf("checking that pool %q has the failure domain %q and deviceClass %q", pool.Name, pool.FailureDomain, pool.DeviceClass)
 	details, err := GetPoolDetails(context, clusterInfo, pool.Name)
 	if err != nil {
-		return errors.Wrapf(err, "failed to get pool %q details", pool.Name)
+		return "", "", errors.Wrapf(err, "failed to get pool %q details", pool.Name)
 	}
 
 	// Find the failure domain for the current crush rule
 	rule, err := getCrushRule(context, clusterInfo, details.CrushRule)
 	if err != nil {
-		return errors.Wrapf(err, "failed to get crush rule %q", details.CrushRule)
+		return "", "", errors.Wrapf(err, "failed to get crush rule %q", details.CrushRule)
 	}
 	currentFailureDomain, currentDeviceClass := extractPoolDetails(rule)
 	if currentFailureDomain == pool.FailureDomain && currentDeviceClass == pool.DeviceClass {
 		logger.Infof("pool %q has the expected failure domain %q and deviceClass %q", pool.Name, pool.FailureDomain, pool.DeviceClass)
-		return nil
+		return details.CrushRule, "", nil
 	}
 
 	// Use a crush rule name that is unique to the desired failure domain
@@ -534,7 +559,7 @@ func updatePoolCrushRule(context *clusterd.Context, clusterInfo *ClusterInfo, cl
 	}
 	if crushRuleName == details.CrushRule {
 		logger.Debugf("crush rule already set to %q for pool %q (failureDomain=%s, deviceClass=%s)", crushRuleName, pool.Name, currentFailureDomain, currentDeviceClass)
-		return nil
+		return details.CrushRule, "", nil
 	}
 
 	if currentFailureDomain != pool.FailureDomain {
@@ -549,16 +574,9 @@ func updatePoolCrushRule(context *clusterd.Context, clusterInfo *ClusterInfo, cl
 
 	// Create a new crush rule for the expected failure domain
 	if err := createReplicationCrushRule(context, clusterInfo, clusterSpec, crushRuleName, pool); err != nil {
-		return errors.Wrapf(err, "failed to create replicated crush rule %q", crushRuleName)
+		return "", "", errors.Wrapf(err, "failed to create replicated crush rule %q", crushRuleName)
 	}
-
-	// Update the crush rule on the pool
-	if err := setCrushRule(context, clusterInfo, pool.Name, crushRuleName); err != nil {
-		return errors.Wrapf(err, "failed to set crush rule on pool %q", pool.Name)
-	}
-
-	logger.Infof("Successfully updated pool %q failure domain to %q", pool.Name, pool.FailureDomain)
-	return nil
+	return details.CrushRule, crushRuleName, nil
 }
 
 func extractPoolDetails(rule ruleSpec) (string, string) {
@@ -590,6 +608,43 @@ func extractPoolDetails(rule ruleSpec) (string, string) {
 	return failureDomain, deviceClass
 }
 
+// PoolCrushRuleSettings are the settings of the crush rule of a pool that can be expressed in a
+// pool spec
+type PoolCrushRuleSettings struct {
+	FailureDomain string
+	CrushRoot     string
+	// DeviceClass is empty if the rule is not restricted to a device class
+	DeviceClass string
+}
+
+// GetPoolCrushRuleSettings returns the failure domain, the crush root and the device class of the
+// crush rule used by the pool
+func GetPoolCrushRuleSettings(context *clusterd.Context, clusterInfo *ClusterInfo, details CephStoragePoolDetails) (PoolCrushRuleSettings, error) {
+	rule, err := getCrushRule(context, clusterInfo, details.CrushRule)
+	if err != nil {
+		return PoolCrushRuleSettings{}, errors.Wrapf(err, "failed to get crush rule %q of pool %q", details.CrushRule, details.Name)
+	}
+	settings := PoolCrushRuleSettings{}
+	settings.FailureDomain, _ = extractPoolDetails(rule)
+	for _, step := range rule.Steps {
+		if step.Operation != "take" {
+			continue
+		}
+		// a rule restricted to a device class takes the shadow root of the class, e.g. "default~ssd"
+		parts := strings.SplitN(step.ItemName, "~", 2)
+		settings.CrushRoot = parts[0]
+		if len(parts) == 2 {
+			settings.DeviceClass = parts[1]
+		}
+	}
+	return settings, nil
+}
+
+// SetPoolCrushRule sets the crush rule of the pool
+func SetPoolCrushRule(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, crushRule string) error {
+	return setCrushRule(context, clusterInfo, poolName, crushRule)
+}
+
 func setCrushRule(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, crushRule string) error {
 	args := []string{"osd", "pool", "set", poolName, "crush_rule", crushRule}
 
@@ -663,3 +663,32 @@ func hasCrushtool() bool {
 	_, err := exec.LookPath("crushtool")
 	return err == nil
 }
+
+func TestGetPoolCrushRuleSettings(t *testing.T) {
+	ruleOutput := ""
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		if args[0] == "osd" && args[1] == "crush" && args[2] == "rule" && args[3] == "dump" {
+			assert.Equal(t, "replicated_rule", args[4])
+			return ruleOutput, nil
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+	context := &clusterd.Context{Executor: executor}
+	details := CephStoragePoolDetails{Name: "mypool", CrushRule: "replicated_rule"}
+
+	t.Run("no device class", func(t *testing.T) {
+		ruleOutput = `{"rule_name":"replicated_rule","steps":[{"op":"take","item":-1,"item_name":"default"},{"op":"chooseleaf_firstn","num":0,"type":"host"},{"op":"emit"}]}`
+		settings, err := GetPoolCrushRuleSettings(context, AdminTestClusterInfo("mycluster"), details)
+		assert.NoError(t, err)
+		assert.Equal(t, PoolCrushRuleSettings{FailureDomain: "host", CrushRoot: "default"}, settings)
+	})
+
+	t.Run("device class", func(t *testing.T) {
+		ruleOutput = `{"rule_name":"replicated_rule","steps":[{"op":"take","item":-2,"item_name":"myroot~ssd"},{"op":"chooseleaf_firstn","num":0,"type":"zone"},{"op":"emit"}]}`
+		settings, err := GetPoolCrushRuleSettings(context, AdminTestClusterInfo("mycluster"), details)
+		assert.NoError(t, err)
+		assert.Equal(t, PoolCrushRuleSettings{FailureDomain: "zone", CrushRoot: "myroot", DeviceClass: "ssd"}, settings)
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// ProgressEvents is the output of "ceph progress json"
+type ProgressEvents struct {
+	Events []ProgressEvent `json:"events"`
+}
+
+// ProgressEvent is an event in progress of the mgr progress module, such as the recovery after an
+// OSD went out or the change of the number of PGs of a pool
+type ProgressEvent struct {
+	ID       string  `json:"id"`
+	Message  string  `json:"message"`
+	Progress float64 `json:"progress"`
+	// StartedAt is the unix time the event started
+	StartedAt float64 `json:"started_at"`
+	// TimeRemaining is the estimated number of seconds until the event completes, if known
+	TimeRemaining *float64 `json:"time_remaining,omitempty"`
+	// Refs are the objects the event refers to as pairs of type and ID, for example ["pool", 2]
+	Refs [][]interface{} `json:"refs"`
+}
+
+// Pools returns the IDs of the pools the event refers to
+func (e *ProgressEvent) Pools() []int {
+	var pools []int
+	for _, ref := range e.Refs {
+		if len(ref) != 2 || ref[0] != "pool" {
+			continue
+		}
+		if id, ok := ref[1].(float64); ok {
+			pools = append(pools, int(id))
+		}
+	}
+	return pools
+}
+
+// GetProgressEvents returns the events in progress of the mgr progress module
+func GetProgressEvents(context *clusterd.Context, clusterInfo *ClusterInfo) (*ProgressEvents, error) {
+	args := []string{"progress", "json"}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the progress events. %s", string(buf))
+	}
+
+	var events ProgressEvents
+	if err := json.Unmarshal(buf, &events); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the progress events")
+	}
+	return &events, nil
+}
@@ -56,6 +56,14 @@ type CephStatus struct {
 	PgMap         PgMap        `json:"pgmap"`
 	MgrMap        MgrMap       `json:"mgrmap"`
 	Fsmap         Fsmap        `json:"fsmap"`
+	// ProgressEvents are the events of the mgr progress module in progress, by event ID
+	ProgressEvents map[string]ProgressEventSummary `json:"progress_events"`
+}
+
+// ProgressEventSummary is an event of the mgr progress module as reported by "ceph status"
+type ProgressEventSummary struct {
+	Message  string  `json:"message"`
+	Progress float64 `json:"progress"`
 }
 
 type HealthStatus struct {
@@ -66,6 +74,8 @@ type HealthStatus struct {
 type CheckMessage struct {
 	Severity string  `json:"severity"`
 	Summary  Summary `json:"summary"`
+	// Detail is only reported by "ceph health detail"
+	Detail []Summary `json:"detail,omitempty"`
 }
 
 type Summary struct {
@@ -95,6 +105,9 @@ type MgrMap struct {
 	ActiveAddr string       `json:"active_addr"`
 	Available  bool         `json:"available"`
 	Standbys   []MgrStandby `json:"standbys"`
+	// Services are the URLs of the services of the modules of the active mgr, only reported by
+	// "ceph mgr dump"
+	Services map[string]string `json:"services,omitempty"`
 }
 
 type MgrStandby struct {
@@ -130,6 +143,8 @@ type PgMap struct {
 	CacheFlushBps         uint64         `json:"flush_bytes_sec"`
 	CacheEvictBps         uint64         `json:"evict_bytes_sec"`
 	CachePromoteBps       uint64         `json:"promote_op_per_sec"`
+	DegradedObjects       uint64         `json:"degraded_objects,omitempty"`
+	UnfoundObjects        uint64         `json:"unfound_objects,omitempty"`
 }
 
 type PgStateEntry struct {
@@ -170,6 +185,23 @@ func Status(context *clusterd.Context, clusterInfo *ClusterInfo) (CephStatus, er
 	return status, nil
 }
 
+// HealthDetail returns the health checks of the cluster with their details
+func HealthDetail(context *clusterd.Context, clusterInfo *ClusterInfo) (HealthStatus, error) {
+	args := []string{"health", "detail"}
+	cmd := NewCephCommand(context, clusterInfo, args)
+	buf, err := cmd.Run()
+	if err != nil {
+		return HealthStatus{}, errors.Wrapf(err, "failed to get health detail. %s", string(buf))
+	}
+
+	var health HealthStatus
+	if err := json.Unmarshal(buf, &health); err != nil {
+		return HealthStatus{}, errors.Wrap(err, "failed to unmarshal health detail response")
+	}
+
+	return health, nil
+}
+
 func StatusWithUser(context *clusterd.Context, clusterInfo *ClusterInfo) (CephStatus, error) {
 	args := []string{"status", "--format", "json"}
 	command, args := FinalizeCephCommandArgs("ceph", clusterInfo, args, context.ConfigDir)
@@ -144,19 +144,7 @@ func PinCephFSSubVolumeGroup(context *clusterd.Context, clusterInfo *ClusterInfo
 
 	logger.Infof("pinning cephfs subvolume group %v of filesystem %q", namespaceName, volName)
 	args := []string{"fs", "subvolumegroup", "pin", volName, cephFilesystemSubVolumeGroupName}
-	if cephFilesystemSubVolumeGroup.Spec.Pinning.Distributed != nil {
-		setting := strconv.Itoa(*cephFilesystemSubVolumeGroup.Spec.Pinning.Distributed)
-		args = append(args, "distributed", setting)
-	} else if cephFilesystemSubVolumeGroup.Spec.Pinning.Export != nil {
-		setting := strconv.Itoa(*cephFilesystemSubVolumeGroup.Spec.Pinning.Export)
-		args = append(args, "export", setting)
-	} else if cephFilesystemSubVolumeGroup.Spec.Pinning.Random != nil {
-		setting := strconv.FormatFloat(*cephFilesystemSubVolumeGroup.Spec.Pinning.Random, 'f', -1, 64)
-		args = append(args, "random", setting)
-	} else {
-		// set by default value
-		args = append(args, "distributed", "1")
-	}
+	args = append(args, pinningArgs(cephFilesystemSubVolumeGroup.Spec.Pinning)...)
 	logger.Infof("subvolume group pinning args %v", args)
 
 	cmd := NewCephCommand(context, clusterInfo, args)
@@ -170,6 +158,54 @@ func PinCephFSSubVolumeGroup(context *clusterd.Context, clusterInfo *ClusterInfo
 	return nil
 }
 
+// PinCephFSSubtree pins the cephfs subvolume group, or the subvolume of the group if subVolName is
+// not empty
+func PinCephFSSubtree(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName string, pinning cephv1.CephFilesystemSubVolumeGroupSpecPinning) error {
+	subtree := fmt.Sprintf("subvolume group %q", groupName)
+	if subVolName != "" {
+		subtree = fmt.Sprintf("subvolume %q of group %q", subVolName, groupName)
+	}
+	err := validatePinningValues(pinning)
+	if err != nil {
+		return errors.Wrapf(err, "failed to pin %s", subtree)
+	}
+
+	logger.Infof("pinning cephfs %s of filesystem %q", subtree, volName)
+	args := []string{"fs", "subvolumegroup", "pin", volName, groupName}
+	if subVolName != "" {
+		args = []string{"fs", "subvolume", "pin", volName, subVolName}
+	}
+	args = append(args, pinningArgs(pinning)...)
+	if subVolName != "" {
+		args = append(args, "--group_name", groupName)
+	}
+
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		// Intentionally don't wrap the error so the caller can inspect the return code
+		logger.Debugf("failed to pin %s. %s", subtree, output)
+		return err
+	}
+
+	logger.Infof("successfully pinned cephfs %s of filesystem %q", subtree, volName)
+	return nil
+}
+
+// pinningArgs returns the pin type and setting arguments of the pinning configuration
+func pinningArgs(pinning cephv1.CephFilesystemSubVolumeGroupSpecPinning) []string {
+	if pinning.Distributed != nil {
+		return []string{"distributed", strconv.Itoa(*pinning.Distributed)}
+	} else if pinning.Export != nil {
+		return []string{"export", strconv.Itoa(*pinning.Export)}
+	} else if pinning.Random != nil {
+		return []string{"random", strconv.FormatFloat(*pinning.Random, 'f', -1, 64)}
+	}
+	// set by default value
+	return []string{"distributed", "1"}
+}
+
 // validateConfiguration validates the provided pinning configuration.
 // despite CRD validation, this ensures no duplicate values are set programmatically
 // and to safeguard against potential internal changes of the configuration.
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,3 +81,33 @@ func TestValidatePinningValues(t *testing.T) {
 	err = validatePinningValues(testData1)
 	assert.NoError(t, err)
 }
+
+func TestPinCephFSSubtree(t *testing.T) {
+	var args []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, arg ...string) (string, error) {
+			args = arg
+			return "", nil
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := AdminTestClusterInfo("mycluster")
+
+	export := 2
+	err := PinCephFSSubtree(context, clusterInfo, "myfs", "group", "", cephv1.CephFilesystemSubVolumeGroupSpecPinning{Export: &export})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fs", "subvolumegroup", "pin", "myfs", "group", "export", "2"}, args[:7])
+
+	random := 0.5
+	err = PinCephFSSubtree(context, clusterInfo, "myfs", "group", "subvol", cephv1.CephFilesystemSubVolumeGroupSpecPinning{Random: &random})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fs", "subvolume", "pin", "myfs", "subvol", "random", "0.5", "--group_name", "group"}, args[:9])
+
+	// ephemeral distributed pinning by default
+	err = PinCephFSSubtree(context, clusterInfo, "myfs", "group", "", cephv1.CephFilesystemSubVolumeGroupSpecPinning{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"distributed", "1"}, args[5:7])
+
+	err = PinCephFSSubtree(context, clusterInfo, "myfs", "group", "", cephv1.CephFilesystemSubVolumeGroupSpecPinning{Export: &export, Random: &random})
+	assert.Error(t, err)
+}
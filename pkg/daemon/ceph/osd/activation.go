@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// activationLeaseAppName is the app label of the leases of the activation slots
+	activationLeaseAppName = "rook-ceph-osd-activation"
+)
+
+// activationSlotRetryInterval is the interval to look for a free activation slot again while all
+// the slots of the node are held
+var activationSlotRetryInterval = 5 * time.Second
+
+// ActivationSlot is an activation slot of the OSDs of a node, held by an OSD pod until the pod is
+// ready or the slot timeout expires. The slots are leases in the namespace of the cluster.
+type ActivationSlot struct {
+	Clientset kubernetes.Interface
+	Namespace string
+	NodeName  string
+	// DeviceClass is the device class with its own slots, or empty for the slots shared by the other
+	// OSDs of the node
+	DeviceClass string
+	// PodName is the OSD pod waiting for a slot
+	PodName       string
+	MaxConcurrent int
+	Timeout       time.Duration
+}
+
+// activationLeaseName returns the name of the lease of a slot. The node name and the device class
+// are hashed since the device class is not a valid resource name.
+func (s *ActivationSlot) activationLeaseName(slot int) string {
+	return fmt.Sprintf("%s-%s-%d", activationLeaseAppName, k8sutil.Hash(s.NodeName+"/"+s.DeviceClass), slot)
+}
+
+// Wait blocks until the OSD pod holds an activation slot of its node
+func (s *ActivationSlot) Wait(ctx context.Context) error {
+	for {
+		acquired, err := s.acquire(ctx)
+		if err != nil {
+			logger.Warningf("failed to acquire an activation slot. %v", err)
+		} else if acquired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(activationSlotRetryInterval):
+		}
+	}
+}
+
+// acquire takes the first free slot, it returns whether a slot was acquired
+func (s *ActivationSlot) acquire(ctx context.Context) (bool, error) {
+	leases := s.Clientset.CoordinationV1().Leases(s.Namespace)
+	for slot := 0; slot < s.MaxConcurrent; slot++ {
+		name := s.activationLeaseName(slot)
+		now := metav1.NewMicroTime(time.Now())
+		lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.Namespace,
+				Labels:    map[string]string{k8sutil.AppAttr: activationLeaseAppName},
+			}}
+			s.hold(lease, now)
+			if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err != nil {
+				if kerrors.IsAlreadyExists(err) {
+					// another OSD created the slot first
+					continue
+				}
+				return false, errors.Wrapf(err, "failed to create activation slot lease %q", name)
+			}
+			logger.Infof("acquired activation slot %d of node %q", slot, s.NodeName)
+			return true, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get activation slot lease %q", name)
+		}
+
+		free, err := s.isFree(ctx, lease, now.Time)
+		if err != nil {
+			return false, err
+		}
+		if !free {
+			continue
+		}
+		s.hold(lease, now)
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			if kerrors.IsConflict(err) {
+				// another OSD acquired the slot first
+				continue
+			}
+			return false, errors.Wrapf(err, "failed to update activation slot lease %q", name)
+		}
+		logger.Infof("acquired activation slot %d of node %q", slot, s.NodeName)
+		return true, nil
+	}
+
+	logger.Infof("all the %d activation slots of node %q are held by other osds, waiting", s.MaxConcurrent, s.NodeName)
+	return false, nil
+}
+
+// hold sets the OSD pod as the holder of the slot
+func (s *ActivationSlot) hold(lease *coordinationv1.Lease, now metav1.MicroTime) {
+	holder := s.PodName
+	duration := int32(s.Timeout.Seconds())
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+}
+
+// isFree returns whether the slot can be acquired: the slot is not held, its timeout expired, or
+// its holder is ready or deleted. The slot held by the pod itself is free again when the init
+// container of the pod is restarted.
+func (s *ActivationSlot) isFree(ctx context.Context, lease *coordinationv1.Lease, now time.Time) (bool, error) {
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" || *lease.Spec.HolderIdentity == s.PodName {
+		return true, nil
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil ||
+		now.After(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds)*time.Second)) {
+		return true, nil
+	}
+
+	pod, err := s.Clientset.CoreV1().Pods(s.Namespace).Get(ctx, *lease.Spec.HolderIdentity, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to get pod %q holding activation slot lease %q", *lease.Spec.HolderIdentity, lease.Name)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue {
+			return true, nil
+		}
+	}
+	return false, nil
+}
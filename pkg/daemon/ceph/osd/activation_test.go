@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestActivationSlot(t *testing.T) {
+	ctx := context.TODO()
+	clientset := fake.NewSimpleClientset()
+	newSlot := func(podName, deviceClass string) *ActivationSlot {
+		_, err := clientset.CoreV1().Pods("ns").Create(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "ns"}}, metav1.CreateOptions{})
+		if err != nil {
+			require.Contains(t, err.Error(), "already exists")
+		}
+		return &ActivationSlot{
+			Clientset:     clientset,
+			Namespace:     "ns",
+			NodeName:      "node1",
+			DeviceClass:   deviceClass,
+			PodName:       podName,
+			MaxConcurrent: 2,
+			Timeout:       10 * time.Minute,
+		}
+	}
+
+	// the first OSDs take the slots of the node
+	for _, pod := range []string{"osd-0", "osd-1"} {
+		acquired, err := newSlot(pod, "").acquire(ctx)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+	}
+	osd2 := newSlot("osd-2", "")
+	acquired, err := osd2.acquire(ctx)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// the OSDs of a device class with its own limit have their own slots
+	acquired, err = newSlot("osd-3", "hdd").acquire(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// the slot of a restarted init container is acquired again
+	acquired, err = newSlot("osd-1", "").acquire(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// the slot of a ready OSD is freed
+	pod, err := clientset.CoreV1().Pods("ns").Get(ctx, "osd-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	_, err = clientset.CoreV1().Pods("ns").Update(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	acquired, err = osd2.acquire(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	lease, err := clientset.CoordinationV1().Leases("ns").Get(ctx, osd2.activationLeaseName(0), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "osd-2", *lease.Spec.HolderIdentity)
+	assert.Equal(t, int32(600), *lease.Spec.LeaseDurationSeconds)
+
+	// the slot of a deleted OSD pod is freed
+	osd4 := newSlot("osd-4", "")
+	acquired, err = osd4.acquire(ctx)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	require.NoError(t, clientset.CoreV1().Pods("ns").Delete(ctx, "osd-1", metav1.DeleteOptions{}))
+	acquired, err = osd4.acquire(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// the slot of an OSD that did not become ready is freed after the timeout
+	osd5 := newSlot("osd-5", "")
+	acquired, err = osd5.acquire(ctx)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	lease, err = clientset.CoordinationV1().Leases("ns").Get(ctx, osd5.activationLeaseName(1), metav1.GetOptions{})
+	require.NoError(t, err)
+	expired := metav1.NewMicroTime(time.Now().Add(-11 * time.Minute))
+	lease.Spec.RenewTime = &expired
+	_, err = clientset.CoordinationV1().Leases("ns").Update(ctx, lease, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	acquired, err = osd5.acquire(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// the wait stops when its context is cancelled
+	activationSlotRetryInterval = time.Millisecond
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, newSlot("osd-6", "").Wait(cancelledCtx))
+	assert.NoError(t, newSlot("osd-7", "ssd").Wait(ctx))
+}
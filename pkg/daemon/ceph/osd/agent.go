@@ -17,6 +17,7 @@ limitations under the License.
 package osd
 
 import (
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
@@ -40,6 +41,8 @@ type OsdAgent struct {
 	pvcBacked                    bool
 	replaceOSD                   *oposd.OSDInfo
 	wipeDevicesFromOtherClusters bool
+	deviceIdentityFilter         *cephv1.DeviceIdentityFilter
+	quarantinedDevices           []string
 }
 
 // NewAgent is the instantiation of the OSD agent
@@ -61,6 +64,18 @@ func NewAgent(context *clusterd.Context, devices []DesiredDevice, metadataDevice
 	}
 }
 
+// SetDeviceIdentityFilter restricts the devices the agent provisions to the devices allowed by the
+// identity filter
+func (a *OsdAgent) SetDeviceIdentityFilter(filter *cephv1.DeviceIdentityFilter) {
+	a.deviceIdentityFilter = filter
+}
+
+// SetQuarantinedDevices excludes the devices whose OSD prepare jobs kept failing from the devices
+// the agent provisions
+func (a *OsdAgent) SetQuarantinedDevices(devices []string) {
+	a.quarantinedDevices = devices
+}
+
 func getDeviceLVPath(context *clusterd.Context, deviceName string) string {
 	output, err := context.Executor.ExecuteCommandWithOutput("pvdisplay", "-C", "-o", "lvpath", "--noheadings", deviceName)
 	if err != nil {
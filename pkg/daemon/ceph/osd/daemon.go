@@ -25,6 +25,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -46,6 +47,20 @@ const (
 
 var logger = capnslog.NewPackageLogger("github.com/rook/rook", "cephosd")
 
+// DevicesError is a failure to provision the OSDs on the devices
+type DevicesError struct {
+	Devices []string
+	Err     error
+}
+
+func (e *DevicesError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DevicesError) Unwrap() error {
+	return e.Err
+}
+
 // StartOSD starts an OSD on a device that was provisioned by ceph-volume
 func StartOSD(context *clusterd.Context, osdType, osdID, osdUUID, lvPath string, pvcBackedOSD, lvBackedPV bool, cephArgs []string) error {
 	// ensure the config mount point exists
@@ -176,6 +191,15 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	status := oposd.OrchestrationStatus{Status: oposd.OrchestrationStatusOrchestrating}
 	oposd.UpdateNodeOrPVCStatus(agent.clusterInfo.Context, agent.kv, agent.nodeName, status)
 
+	// The volumes of the purged OSDs are removed before the discovery so that their devices are
+	// available for the new OSDs
+	if !agent.pvcBacked && agent.storeConfig.ZapPurgedOSDs {
+		logger.Info("removing the volumes of the purged OSDs")
+		if err := agent.zapPurgedOSDs(context); err != nil {
+			return errors.Wrap(err, "failed to remove the volumes of the purged OSDs")
+		}
+	}
+
 	logger.Infof("discovering hardware")
 
 	var rawDevices []*sys.LocalDisk
@@ -227,7 +251,7 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 
 	deviceOSDs, err := agent.configureCVDevices(context, devices)
 	if err != nil {
-		return errors.Wrap(err, "failed to configure devices")
+		return &DevicesError{Devices: devices.deviceNames(), Err: errors.Wrap(err, "failed to configure devices")}
 	}
 
 	// Let's fail if no OSDs were configured
@@ -399,6 +423,17 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 			}
 		}
 
+		if !agent.pvcBacked {
+			if allowed, reason := agent.deviceIdentityFilter.Matches(device.WWN, device.Serial, device.Vendor, device.Model); !allowed {
+				logger.Infof("skipping device %q (wwn=%q, serial=%q, vendor=%q, model=%q): %s", device.Name, device.WWN, device.Serial, device.Vendor, device.Model, reason)
+				continue
+			}
+			if slices.Contains(agent.quarantinedDevices, device.Name) {
+				logger.Infof("skipping device %q, its OSD prepare jobs failed and it is quarantined or waiting for its retry", device.Name)
+				continue
+			}
+		}
+
 		// Check if the desired device is available
 		//
 		// We need to use the /dev path, provided by the NAME property from "lsblk --paths",
@@ -19,6 +19,7 @@ package osd
 import (
 	"encoding/json"
 	"os"
+	"sort"
 
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	"github.com/rook/rook/pkg/util/sys"
@@ -43,6 +44,7 @@ type Device struct {
 type DesiredDevice struct {
 	Name               string
 	OSDsPerDevice      int
+	OSDsPerDeviceMode  string
 	MetadataDevice     string
 	DatabaseSizeMB     int
 	DeviceClass        string
@@ -71,6 +73,16 @@ func (m *DeviceOsdMapping) String() string {
 	return string(b)
 }
 
+// deviceNames returns the sorted names of the devices of the mapping
+func (m *DeviceOsdMapping) deviceNames() []string {
+	names := make([]string, 0, len(m.Entries))
+	for name := range m.Entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (d *DesiredDevice) UpdateDeviceClass(agent *OsdAgent, device *sys.LocalDisk) {
 	// Rook sets the storage class of a device with the following priority.
 	//
@@ -17,21 +17,94 @@ limitations under the License.
 package osd
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/daemon/ceph/osd/kms"
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
 )
 
 const (
 	slotZero string = "0"
 	slotOne  string = "1"
+	// dmcryptConfigKeyFmt is the key of the mon config-key store where ceph-volume stores the
+	// dmcrypt key of the OSDs it encrypted
+	dmcryptConfigKeyFmt = "dm-crypt/osd/%s/luks"
 )
 
+// keyStore stores the key encryption key of the devices of an OSD
+type keyStore interface {
+	GetSecret(secretName string) (string, error)
+	UpdateSecret(secretName, secretValue string) error
+}
+
+// cephKeyStore stores the dmcrypt keys of the OSDs encrypted by ceph-volume in the mon config-key store
+type cephKeyStore struct {
+	monStore *opconfig.MonStore
+}
+
+func (s *cephKeyStore) GetSecret(secretName string) (string, error) {
+	return s.monStore.GetKeyValue(secretName)
+}
+
+func (s *cephKeyStore) UpdateSecret(secretName, secretValue string) error {
+	return s.monStore.SetKeyValue(secretName, secretValue)
+}
+
+// RotateKeyEncryptionKey rotates the key of the encrypted devices of an OSD on a PVC stored in the
+// KMS, and destroys the previous key in the KMS once the devices no longer accept it.
 func RotateKeyEncryptionKey(context *clusterd.Context, kms *kms.Config, secretName string, devicePaths []string) error {
+	if err := rotateKey(context, kms, secretName, devicePaths); err != nil {
+		return err
+	}
+
+	// the devices are only opened with the new key from now on
+	logger.Info("destroying the previous keys in the KMS")
+	if err := kms.DestroyPreviousSecretVersions(secretName); err != nil {
+		// the key was rotated, the previous keys are destroyed by the next rotation
+		logger.Warningf("failed to destroy the previous keys of secret %q in the KMS. %v", secretName, err)
+	}
+	return nil
+}
+
+// RotateCephKeyEncryptionKey rotates the key of the devices of an OSD encrypted by ceph-volume,
+// which is stored in the mon config-key store.
+func RotateCephKeyEncryptionKey(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, osdUUID string, blockPaths []string) error {
+	if err := cephclient.WriteCephConfig(context, clusterInfo); err != nil {
+		return errors.Wrap(err, "failed to write the ceph config")
+	}
+
+	devicePaths := []string{}
+	for _, blockPath := range blockPaths {
+		devicePath, err := luksDevice(context, blockPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the encrypted device of block %q", blockPath)
+		}
+		devicePaths = append(devicePaths, devicePath)
+	}
+
+	store := &cephKeyStore{monStore: opconfig.GetMonStore(context, clusterInfo)}
+	return rotateKey(context, store, fmt.Sprintf(dmcryptConfigKeyFmt, osdUUID), devicePaths)
+}
+
+// luksDevice returns the LUKS device of a block of an OSD, which is the block itself for the OSDs
+// on a logical volume, or the device behind the dmcrypt mapping of the block otherwise
+func luksDevice(context *clusterd.Context, blockPath string) (string, error) {
+	if err := context.Executor.ExecuteCommand(cryptsetupBinary, "isLuks", blockPath); err == nil {
+		return blockPath, nil
+	}
+	return GetBackingDeviceForEncryptedBlock(context, blockPath)
+}
+
+// rotateKey replaces the key of the devices with a new key, keeping the current key in a second
+// slot until the new key is stored
+func rotateKey(context *clusterd.Context, store keyStore, secretName string, devicePaths []string) error {
 	logger.Info("fetching the current key")
 	// Fetch the currentKey.
-	currentKey, err := kms.GetSecret(secretName)
+	currentKey, err := store.GetSecret(secretName)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get secret %q", secretName)
 	}
@@ -72,14 +145,14 @@ func RotateKeyEncryptionKey(context *clusterd.Context, kms *kms.Config, secretNa
 
 	logger.Info("updating the new key in the KMS")
 	// Update new key.
-	err = kms.UpdateSecret(secretName, newKey)
+	err = store.UpdateSecret(secretName, newKey)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update secret %q with new key", secretName)
 	}
 
 	logger.Info("fetching the key from the KMS to verify it.")
 	// Fetch key to verify its the new key.
-	keyInKMS, err := kms.GetSecret(secretName)
+	keyInKMS, err := store.GetSecret(secretName)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get secret %q", secretName)
 	}
@@ -343,6 +343,20 @@ func (c *Config) DeleteSecret(secretName string) error {
 	return nil
 }
 
+// DestroyPreviousSecretVersions destroys the previous versions of an encrypted key kept by the KMS,
+// so that a rotated key cannot be retrieved from the KMS anymore
+func (c *Config) DestroyPreviousSecretVersions(secretName string) error {
+	// The other KMS overwrite the encrypted key when it is updated
+	if c.IsVault() {
+		err := destroyPreviousVaultSecretVersions(c.ClusterInfo.Context, c.context, c.ClusterInfo.Namespace, c.clusterSpec.Security.KeyManagementService.ConnectionDetails, GenerateOSDEncryptionSecretName(secretName))
+		if err != nil {
+			return errors.Wrap(err, "failed to destroy the previous versions of the secret in vault")
+		}
+	}
+
+	return nil
+}
+
 // GetParam returns the value of the KMS config option
 func GetParam(kmsConfig map[string]string, param string) string {
 	if val, ok := kmsConfig[param]; ok && val != "" {
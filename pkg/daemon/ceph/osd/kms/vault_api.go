@@ -18,6 +18,10 @@ package kms
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/libopenstorage/secrets/vault"
@@ -153,6 +157,64 @@ func BackendVersion(ctx context.Context, clusterdContext *clusterd.Context, name
 	return "", errors.Errorf("secrets engine with mount path %q not found", backendPath)
 }
 
+// destroyPreviousVaultSecretVersions destroys the versions of a secret older than its current
+// version. Only the K/V version 2 backend keeps the previous versions of the secrets.
+func destroyPreviousVaultSecretVersions(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string, secretName string) error {
+	version, err := BackendVersion(ctx, clusterdContext, namespace, secretConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the vault kv secret engine version")
+	}
+	if version != "v2" {
+		return nil
+	}
+
+	backendPath := GetParam(secretConfig, vault.VaultBackendPathKey)
+	if backendPath == "" {
+		backendPath = vault.DefaultBackendPath
+	}
+
+	vaultClient, err := vaultClient(ctx, clusterdContext, namespace, secretConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize vault client")
+	}
+
+	metadata, err := vaultClient.Logical().Read(path.Join(backendPath, "metadata", secretName))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the metadata of secret %q", secretName)
+	}
+	if metadata == nil || metadata.Data == nil {
+		return errors.Errorf("secret %q not found", secretName)
+	}
+	currentVersion, err := strconv.Atoi(fmt.Sprint(metadata.Data["current_version"]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the current version of secret %q", secretName)
+	}
+
+	previousVersions := []int{}
+	versions, _ := metadata.Data["versions"].(map[string]interface{})
+	for v, details := range versions {
+		version, err := strconv.Atoi(v)
+		if err != nil || version >= currentVersion {
+			continue
+		}
+		if d, ok := details.(map[string]interface{}); ok && d["destroyed"] == true {
+			continue
+		}
+		previousVersions = append(previousVersions, version)
+	}
+	if len(previousVersions) == 0 {
+		return nil
+	}
+	sort.Ints(previousVersions)
+
+	_, err = vaultClient.Logical().Write(path.Join(backendPath, "destroy", secretName), map[string]interface{}{"versions": previousVersions})
+	if err != nil {
+		return errors.Wrapf(err, "failed to destroy versions %v of secret %q", previousVersions, secretName)
+	}
+	logger.Infof("destroyed versions %v of secret %q", previousVersions, secretName)
+	return nil
+}
+
 func trimSlash(in string) string {
 	return strings.Trim(in, "/")
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/libopenstorage/secrets/vault"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestroyPreviousVaultSecretVersions(t *testing.T) {
+	var destroyed []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/rook/metadata/rook-ceph-osd-encryption-key-set1-data-0":
+			_, _ = w.Write([]byte(`{"data":{"current_version":3,"versions":{"1":{"destroyed":true},"2":{"destroyed":false},"3":{"destroyed":false}}}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/rook/destroy/rook-ceph-osd-encryption-key-set1-data-0":
+			var body struct {
+				Versions []int `json:"versions"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			destroyed = append(destroyed, body.Versions...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalVaultClient := vaultClient
+	t.Cleanup(func() { vaultClient = originalVaultClient })
+	vaultClient = func(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string) (*api.Client, error) {
+		client, err := api.NewClient(&api.Config{Address: server.URL})
+		if err != nil {
+			return nil, err
+		}
+		client.SetToken("token")
+		return client, nil
+	}
+	ctx := context.TODO()
+	secretConfig := map[string]string{vault.VaultBackendPathKey: "rook", vault.VaultBackendKey: "v2"}
+
+	// only the versions older than the current version not yet destroyed are destroyed
+	err := destroyPreviousVaultSecretVersions(ctx, &clusterd.Context{}, "rook-ceph", secretConfig, "rook-ceph-osd-encryption-key-set1-data-0")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, destroyed)
+
+	// the secrets of a K/V version 1 backend have no previous versions
+	destroyed = nil
+	secretConfig[vault.VaultBackendKey] = "v1"
+	err = destroyPreviousVaultSecretVersions(ctx, &clusterd.Context{}, "rook-ceph", secretConfig, "rook-ceph-osd-encryption-key-set1-data-0")
+	require.NoError(t, err)
+	assert.Empty(t, destroyed)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -70,53 +71,62 @@ func RemoveOSDs(context *clusterd.Context, clusterInfo *client.ClusterInfo, osds
 }
 
 func removeOSD(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInfo, osdID int, preservePVC, forceOSDRemoval bool) {
-	// Get the host where the OSD is found
-	hostName, err := client.GetCrushHostName(clusterdContext, clusterInfo, osdID)
-	if err != nil {
-		logger.Errorf("failed to get the host where osd.%d is running. %v", osdID, err)
-	}
-
 	// Mark the OSD as out.
 	logger.Infof("marking osd.%d out", osdID)
 	args := []string{"osd", "out", fmt.Sprintf("osd.%d", osdID)}
-	_, err = client.NewCephCommand(clusterdContext, clusterInfo, args).Run()
+	_, err := client.NewCephCommand(clusterdContext, clusterInfo, args).Run()
 	if err != nil {
 		logger.Errorf("failed to exclude osd.%d out of the crush map. %v", osdID, err)
 	}
 
 	// Check if we can safely remove the OSD
-	// Loop forever until the osd is safe-to-destroy
+	// Loop forever until the osd is safe to remove
 	for {
-		isSafeToDestroy, err := client.OsdSafeToDestroy(clusterdContext, clusterInfo, osdID)
+		safety, err := client.CheckOSDSafety(clusterdContext, clusterInfo, client.OSDSafetyCheck{OSDs: []int{osdID}, Remove: true})
 		if err != nil {
 			// If we want to force remove the OSD and there was an error let's break outside of
 			// the loop and proceed with the OSD removal
 			if forceOSDRemoval {
-				logger.Errorf("failed to check if osd %d is safe to destroy, but force removal is enabled so proceeding with removal. %v", osdID, err)
+				logger.Errorf("failed to check if osd %d is safe to remove, but force removal is enabled so proceeding with removal. %v", osdID, err)
 				break
 			} else {
-				logger.Errorf("failed to check if osd %d is safe to destroy, retrying in 1m. %v", osdID, err)
+				logger.Errorf("failed to check if osd %d is safe to remove, retrying in 1m. %v", osdID, err)
 				time.Sleep(1 * time.Minute)
 				continue
 			}
 		}
 
-		// If no error and the OSD is safe to destroy, we can proceed with the OSD removal
-		if isSafeToDestroy {
-			logger.Infof("osd.%d is safe to destroy, proceeding", osdID)
+		// If no error and the OSD is safe to remove, we can proceed with the OSD removal
+		if safety.Safe {
+			logger.Infof("osd.%d is safe to remove, proceeding", osdID)
 			break
 		} else {
 			// If we arrive here and forceOSDRemoval is true, we should proceed with the OSD removal
 			if forceOSDRemoval {
-				logger.Infof("osd.%d is NOT ok to destroy but force removal is enabled so proceeding with removal", osdID)
+				logger.Infof("osd.%d is NOT safe to remove but force removal is enabled so proceeding with removal. %s", osdID, safety)
 				break
 			}
 			// Else we wait until the OSD can be removed
-			logger.Warningf("osd.%d is NOT ok to destroy, retrying in 15s until success", osdID)
+			logger.Warningf("osd.%d is NOT safe to remove, retrying in 15s until success. %s", osdID, safety)
 			time.Sleep(15 * time.Second)
 		}
 	}
 
+	if err := PurgeOSD(clusterdContext, clusterInfo, osdID, preservePVC); err != nil {
+		logger.Errorf("failed to purge osd.%d. %v", osdID, err)
+	}
+}
+
+// PurgeOSD removes the deployment, the prepare job and the PVCs of an OSD marked out, purges it from
+// the cluster and removes its host from the crush map if not in use anymore. The PVCs are detached
+// from Rook instead of deleted when preservePVC is set.
+func PurgeOSD(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInfo, osdID int, preservePVC bool) error {
+	// Get the host where the OSD is found
+	hostName, err := client.GetCrushHostName(clusterdContext, clusterInfo, osdID)
+	if err != nil {
+		logger.Errorf("failed to get the host where osd.%d is running. %v", osdID, err)
+	}
+
 	// Remove the OSD deployment
 	deploymentName := fmt.Sprintf("rook-ceph-osd-%d", osdID)
 	deployment, err := clusterdContext.Clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(clusterInfo.Context, deploymentName, metav1.GetOptions{})
@@ -141,7 +151,7 @@ func removeOSD(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInf
 	purgeOSDArgs := []string{"osd", "purge", fmt.Sprintf("osd.%d", osdID), "--force", "--yes-i-really-mean-it"}
 	_, err = client.NewCephCommand(clusterdContext, clusterInfo, purgeOSDArgs).Run()
 	if err != nil {
-		logger.Errorf("failed to purge osd.%d. %v", osdID, err)
+		return errors.Wrapf(err, "failed to purge osd.%d", osdID)
 	}
 
 	// Attempting to remove the parent host. Errors can be ignored if there are other OSDs on the same host
@@ -158,6 +168,7 @@ func removeOSD(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInf
 	archiveCrash(clusterdContext, clusterInfo, osdID)
 
 	logger.Infof("completed removal of OSD %d", osdID)
+	return nil
 }
 
 func removeOSDPrepareJob(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInfo, pvcName string) {
@@ -288,3 +299,75 @@ func DestroyOSD(context *clusterd.Context, clusterInfo *client.ClusterInfo, id i
 
 	return osdInfo, nil
 }
+
+// DestroyOSDWithLostMetadata destroys the OSD on the data PVC if its ephemeral metadata or wal
+// device was replaced by a blank device, so that it can be prepared again with the same ID. The OSD
+// is only destroyed when ceph-bluestore-tool reports that the metadata device has no label.
+func DestroyOSDWithLostMetadata(context *clusterd.Context, clusterInfo *client.ClusterInfo, devices []DesiredDevice, encrypted bool) (*oposd.OSDInfo, error) {
+	if encrypted {
+		// the raw devices of the encrypted OSDs never have a bluestore label, so a lost metadata
+		// device cannot be told apart from a valid one
+		logger.Debug("not checking the metadata devices of the encrypted OSD for a lost label")
+		return nil, nil
+	}
+
+	var dataDevice string
+	var metadataDevices []string
+	for _, device := range devices {
+		switch {
+		case strings.HasPrefix(device.Name, "/mnt"):
+			dataDevice = device.Name
+		case strings.HasPrefix(device.Name, "/srv"), strings.HasPrefix(device.Name, "/wal"):
+			metadataDevices = append(metadataDevices, device.Name)
+		}
+	}
+	if dataDevice == "" || len(metadataDevices) == 0 {
+		return nil, nil
+	}
+
+	osds, err := GetCephVolumeRawOSDs(context, clusterInfo, clusterInfo.FSID, dataDevice, "", "", false, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the OSD on %q", dataDevice)
+	}
+	if len(osds) == 0 {
+		// the OSD was never prepared
+		return nil, nil
+	}
+
+	for _, metadataDevice := range metadataDevices {
+		hasLabel, err := hasBluestoreLabel(context, metadataDevice)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check whether the metadata device of osd.%d was lost", osds[0].ID)
+		}
+		if hasLabel {
+			continue
+		}
+		logger.Warningf("metadata device %q of osd.%d has no bluestore label since the ephemeral device was lost, rebuilding the OSD", metadataDevice, osds[0].ID)
+		return DestroyOSD(context, clusterInfo, osds[0].ID, true)
+	}
+	return nil, nil
+}
+
+// hasBluestoreLabel returns whether the device was prepared for an OSD. The device has no label
+// only if it exists and ceph-bluestore-tool reports that the label cannot be found on it, any
+// other failure to read the label is returned.
+func hasBluestoreLabel(context *clusterd.Context, device string) (bool, error) {
+	if _, err := os.Stat(device); err != nil {
+		return false, errors.Wrapf(err, "failed to find device %q", device)
+	}
+
+	output, err := context.Executor.ExecuteCommandWithCombinedOutput("ceph-bluestore-tool", "show-label", "--dev", device)
+	if err == nil {
+		return true, nil
+	}
+	if isBluestoreLabelMissing(output) {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "failed to read the bluestore label of device %q. %s", device, output)
+}
+
+// isBluestoreLabelMissing returns whether the output of ceph-bluestore-tool show-label reports that
+// there is no label on the device
+func isBluestoreLabelMissing(output string) bool {
+	return strings.Contains(output, "unable to read label for") && strings.Contains(output, "(2) No such file or directory")
+}
@@ -19,13 +19,17 @@ package osd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	testexec "github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -161,3 +165,76 @@ func testVolumeClaim(name string) cephv1.VolumeClaimTemplate {
 	claim.Name = name
 	return claim
 }
+
+func TestHasBluestoreLabel(t *testing.T) {
+	device := filepath.Join(t.TempDir(), "metadata")
+	assert.NoError(t, os.WriteFile(device, []byte{}, 0o600))
+
+	showLabel := func(output string, err error) *clusterd.Context {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithCombinedOutput: func(command string, args ...string) (string, error) {
+				assert.Equal(t, "ceph-bluestore-tool", command)
+				assert.Equal(t, []string{"show-label", "--dev", device}, args)
+				return output, err
+			},
+		}
+		return &clusterd.Context{Executor: executor}
+	}
+
+	t.Run("label found", func(t *testing.T) {
+		hasLabel, err := hasBluestoreLabel(showLabel(`{"/srv/metadata": {"osd_uuid": "0bb5da7c"}}`, nil), device)
+		assert.NoError(t, err)
+		assert.True(t, hasLabel)
+	})
+
+	t.Run("label missing", func(t *testing.T) {
+		output := fmt.Sprintf("unable to read label for %s: (2) No such file or directory", device)
+		hasLabel, err := hasBluestoreLabel(showLabel(output, errors.New("exit status 1")), device)
+		assert.NoError(t, err)
+		assert.False(t, hasLabel)
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		hasLabel, err := hasBluestoreLabel(showLabel("", errors.New("executable file not found in $PATH")), device)
+		assert.Error(t, err)
+		assert.False(t, hasLabel)
+	})
+
+	t.Run("label not readable", func(t *testing.T) {
+		output := fmt.Sprintf("unable to read label for %s: (5) Input/output error", device)
+		hasLabel, err := hasBluestoreLabel(showLabel(output, errors.New("exit status 1")), device)
+		assert.Error(t, err)
+		assert.False(t, hasLabel)
+	})
+
+	t.Run("device not found", func(t *testing.T) {
+		context := &clusterd.Context{Executor: &exectest.MockExecutor{
+			MockExecuteCommandWithCombinedOutput: func(command string, args ...string) (string, error) {
+				t.Fatalf("unexpected command %s %v", command, args)
+				return "", nil
+			},
+		}}
+		hasLabel, err := hasBluestoreLabel(context, filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+		assert.False(t, hasLabel)
+	})
+}
+
+func TestDestroyOSDWithLostMetadataEncrypted(t *testing.T) {
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithCombinedOutput: func(command string, args ...string) (string, error) {
+			t.Fatalf("unexpected command %s %v", command, args)
+			return "", nil
+		},
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			t.Fatalf("unexpected command %s %v", command, args)
+			return "", nil
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	devices := []DesiredDevice{{Name: "/mnt/set1-data-0"}, {Name: "/srv/set1-metadata-0"}}
+
+	osdInfo, err := DestroyOSDWithLostMetadata(context, client.AdminTestClusterInfo("ns"), devices, true)
+	assert.NoError(t, err)
+	assert.Nil(t, osdInfo)
+}
@@ -25,8 +25,10 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
@@ -35,6 +37,7 @@ import (
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/osd/config"
 	"github.com/rook/rook/pkg/util/display"
+	"github.com/rook/rook/pkg/util/exec"
 	"github.com/rook/rook/pkg/util/sys"
 )
 
@@ -43,6 +46,7 @@ const (
 	crushDeviceClassFlag = "--crush-device-class"
 	encryptedFlag        = "--dmcrypt"
 	databaseSizeFlag     = "--block-db-size"
+	dbSlotsFlag          = "--block-db-slots"
 	dbDeviceFlag         = "--db-devices"
 	cephVolumeCmd        = "ceph-volume"
 	cephVolumeMinDBSize  = 1024 // 1GB
@@ -424,13 +428,14 @@ func (a *OsdAgent) allowRawMode(context *clusterd.Context) (bool, error) {
 		allowRawMode = false
 	}
 
-	// ceph-volume raw mode does not support more than one OSD per disk
+	// ceph-volume raw mode does not support more than one OSD per disk, unless the disks are carved
+	// into a partition per OSD
 	osdsPerDeviceCountString := sanitizeOSDsPerDevice(a.storeConfig.OSDsPerDevice)
 	osdsPerDeviceCount, err := strconv.Atoi(osdsPerDeviceCountString)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to convert string %q to integer", osdsPerDeviceCountString)
 	}
-	if osdsPerDeviceCount > 1 {
+	if osdsPerDeviceCount > 1 && a.storeConfig.OSDsPerDeviceMode != config.OSDsPerDeviceModeRaw {
 		logger.Debugf("won't use raw mode since osd per device is %d", osdsPerDeviceCount)
 		allowRawMode = false
 	}
@@ -445,11 +450,18 @@ func (a *OsdAgent) allowRawMode(context *clusterd.Context) (bool, error) {
 }
 
 // test if safe to use raw mode for a particular device
-func isSafeToUseRawMode(device *DeviceOsdIDEntry) bool {
-	// ceph-volume raw mode does not support more than one OSD per disk
-	if device.Config.OSDsPerDevice > 1 {
-		logger.Debugf("won't use raw mode for disk %q since osd per device is %d", device.Config.Name, device.Config.OSDsPerDevice)
-		return false
+func isSafeToUseRawMode(device *DeviceOsdIDEntry, storeConfig *config.StoreConfig) bool {
+	// ceph-volume raw mode does not support more than one OSD per disk, unless the disk is carved
+	// into a partition per OSD
+	if count := osdsPerRawDevice(device, storeConfig); count > 1 {
+		if osdsPerDeviceMode(device, storeConfig) != config.OSDsPerDeviceModeRaw {
+			logger.Debugf("won't use raw mode for disk %q since osd per device is %d", device.Config.Name, count)
+			return false
+		}
+		if device.DeviceInfo == nil || device.DeviceInfo.Type != sys.DiskType {
+			logger.Debugf("won't use raw mode for %q since only disks are carved into partitions for %d osds", device.Config.Name, count)
+			return false
+		}
 	}
 
 	// ceph-volume raw mode does not support metadata device if not running on PVC because the user has specified a whole device
@@ -461,6 +473,24 @@ func isSafeToUseRawMode(device *DeviceOsdIDEntry) bool {
 	return true
 }
 
+// osdsPerRawDevice returns the number of OSDs of the device, the count of the device overriding
+// the count of the node
+func osdsPerRawDevice(device *DeviceOsdIDEntry, storeConfig *config.StoreConfig) int {
+	if device.Config.OSDsPerDevice > 1 {
+		return device.Config.OSDsPerDevice
+	}
+	return max(storeConfig.OSDsPerDevice, 1)
+}
+
+// osdsPerDeviceMode returns how the OSDs of a device with more than one OSD are created, the mode
+// of the device overriding the mode of the node
+func osdsPerDeviceMode(device *DeviceOsdIDEntry, storeConfig *config.StoreConfig) string {
+	if device.Config.OSDsPerDeviceMode != "" {
+		return device.Config.OSDsPerDeviceMode
+	}
+	return storeConfig.OSDsPerDeviceMode
+}
+
 func lvmModeAllowed(device *DeviceOsdIDEntry, storeConfig *config.StoreConfig) bool {
 	if device.DeviceInfo.Type == sys.LVMType {
 		logger.Infof("skipping device %q for lvm mode since LVM logical volumes don't support `metadataDevice` or `osdsPerDevice` > 1", device.Config.Name)
@@ -508,7 +538,7 @@ func (a *OsdAgent) initializeDevices(context *clusterd.Context, devices *DeviceO
 		// which reports only the phantom partitions (and malformed OSD info) when they exist and
 		// ignores the original (correct) OSDs created on the raw disk.
 		// See: https://github.com/rook/rook/issues/7940
-		if allowRawMode && isSafeToUseRawMode(device) {
+		if allowRawMode && isSafeToUseRawMode(device, &a.storeConfig) {
 			rawDevices.Entries[name] = device
 			continue
 		}
@@ -531,56 +561,112 @@ func (a *OsdAgent) initializeDevices(context *clusterd.Context, devices *DeviceO
 }
 
 func (a *OsdAgent) initializeDevicesRawMode(context *clusterd.Context, devices *DeviceOsdMapping) error {
+	for name, device := range devices.Entries {
+		deviceArg := path.Join("/dev", name)
+		if device.Data != -1 {
+			logger.Infof("skipping device %q with osd %d already configured", deviceArg, device.Data)
+			continue
+		}
+
+		dataDevices := []string{deviceArg}
+		if count := osdsPerRawDevice(device, &a.storeConfig); count > 1 {
+			partitions, err := partitionRawDevice(context.Executor, device, name, count)
+			if err != nil {
+				return err
+			}
+			dataDevices = partitions
+		}
+		for _, dataDevice := range dataDevices {
+			if err := a.prepareRawDevice(context, device, dataDevice); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareRawDevice creates an OSD on the data device with ceph-volume raw
+func (a *OsdAgent) prepareRawDevice(context *clusterd.Context, device *DeviceOsdIDEntry, dataDevice string) error {
 	baseCommand := "stdbuf"
 	cephVolumeMode := "raw"
 	storeFlag := a.storeConfig.GetStoreFlag()
 
-	baseArgs := []string{"-oL", cephVolumeCmd, cephVolumeMode, "prepare", storeFlag}
+	logger.Infof("configuring new raw device %q", dataDevice)
 
-	for name, device := range devices.Entries {
-		deviceArg := path.Join("/dev", name)
-		if device.Data == -1 {
-			logger.Infof("configuring new raw device %q", deviceArg)
+	immediateExecuteArgs := []string{"-oL", cephVolumeCmd, cephVolumeMode, "prepare", storeFlag, "--data", dataDevice}
 
-			immediateExecuteArgs := append(baseArgs, []string{
-				"--data",
-				deviceArg,
+	if a.replaceOSD != nil {
+		restoreOSDID := a.GetReplaceOSDId(dataDevice)
+		if restoreOSDID != -1 {
+			immediateExecuteArgs = append(immediateExecuteArgs, []string{
+				"--osd-id",
+				fmt.Sprintf("%d", restoreOSDID),
 			}...)
+		}
+	}
 
-			if a.replaceOSD != nil {
-				restoreOSDID := a.GetReplaceOSDId(deviceArg)
-				if restoreOSDID != -1 {
-					immediateExecuteArgs = append(immediateExecuteArgs, []string{
-						"--osd-id",
-						fmt.Sprintf("%d", restoreOSDID),
-					}...)
-				}
-			}
+	// assign the device class specific to the device
+	immediateExecuteArgs = a.appendDeviceClassArg(device, immediateExecuteArgs)
 
-			// assign the device class specific to the device
-			immediateExecuteArgs = a.appendDeviceClassArg(device, immediateExecuteArgs)
+	// execute ceph-volume with the device
+	op, err := context.Executor.ExecuteCommandWithCombinedOutput(baseCommand, immediateExecuteArgs...)
+	if err != nil {
+		cvLogFilePath := path.Join(cephLogDir, "ceph-volume.log")
 
-			// execute ceph-volume with the device
-			op, err := context.Executor.ExecuteCommandWithCombinedOutput(baseCommand, immediateExecuteArgs...)
-			if err != nil {
-				cvLogFilePath := path.Join(cephLogDir, "ceph-volume.log")
+		// Print c-v log before exiting
+		cvLog := readCVLogContent(cvLogFilePath)
+		if cvLog != "" {
+			logger.Errorf("%s", cvLog)
+		}
 
-				// Print c-v log before exiting
-				cvLog := readCVLogContent(cvLogFilePath)
-				if cvLog != "" {
-					logger.Errorf("%s", cvLog)
-				}
+		// Return failure
+		return errors.Wrapf(err, "failed to run ceph-volume raw command. %s", op) // fail return here as validation provided by ceph-volume
+	}
+	logger.Infof("%v", op)
+	return nil
+}
 
-				// Return failure
-				return errors.Wrapf(err, "failed to run ceph-volume raw command. %s", op) // fail return here as validation provided by ceph-volume
-			}
-			logger.Infof("%v", op)
-		} else {
-			logger.Infof("skipping device %q with osd %d already configured", deviceArg, device.Data)
+// partitionRawDevice carves the disk into a GPT partition of equal size for each OSD, and returns
+// the paths of the partitions
+func partitionRawDevice(executor exec.Executor, device *DeviceOsdIDEntry, name string, count int) ([]string, error) {
+	deviceArg := path.Join("/dev", name)
+	partitionSizeMB := device.DeviceInfo.Size / uint64(count) / (1024 * 1024)
+	if partitionSizeMB == 0 {
+		return nil, errors.Errorf("device %q is too small to be carved into %d partitions", deviceArg, count)
+	}
+	logger.Infof("carving device %q into %d partitions of %d MiB for its osds", deviceArg, count, partitionSizeMB)
+
+	// the last partition takes the remaining space, which is reduced by the alignment of the
+	// partitions and the backup GPT
+	args := []string{"--clear"}
+	for i := 1; i <= count; i++ {
+		end := fmt.Sprintf("+%dM", partitionSizeMB)
+		if i == count {
+			end = "0"
 		}
+		args = append(args, fmt.Sprintf("--new=%d:0:%s", i, end), fmt.Sprintf("--change-name=%d:ceph-osd-%d", i, i))
+	}
+	args = append(args, deviceArg)
+	if op, err := executor.ExecuteCommandWithCombinedOutput("sgdisk", args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to carve device %q into %d partitions. %s", deviceArg, count, op)
+	}
+	// wait for the device nodes of the partitions
+	if err := executor.ExecuteCommand("udevadm", "settle", "--timeout=30"); err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for the partitions of device %q", deviceArg)
 	}
 
-	return nil
+	// the partitions of the devices whose name ends with a digit, like nvme0n1, are named with a "p"
+	// separator, like nvme0n1p1
+	separator := ""
+	if name != "" && unicode.IsDigit(rune(name[len(name)-1])) {
+		separator = "p"
+	}
+	partitions := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		partitions = append(partitions, fmt.Sprintf("%s%s%d", deviceArg, separator, i))
+	}
+	return partitions, nil
 }
 
 func (a *OsdAgent) initializeDevicesLVMMode(context *clusterd.Context, devices *DeviceOsdMapping) error {
@@ -776,6 +862,13 @@ func (a *OsdAgent) initializeDevicesLVMMode(context *clusterd.Context, devices *
 					databaseSizeFlag,
 					conf["databasesizemb"],
 				}...)
+			} else if a.storeConfig.MetadataDeviceSlots > 0 {
+				// carve the metadata device in volumes of the same size for all the data devices that
+				// will use it, including the ones added or replaced later
+				mdArgs = append(mdArgs, []string{
+					dbSlotsFlag,
+					strconv.Itoa(a.storeConfig.MetadataDeviceSlots),
+				}...)
 			}
 			mdArgs = append(mdArgs, strings.Split(conf["devices"], " ")...)
 			mdArgs = append(mdArgs, []string{
@@ -912,6 +1005,71 @@ func (a *OsdAgent) WipeDevicesFromOtherClusters(context *clusterd.Context) error
 	return nil
 }
 
+// zapPurgedOSDs removes the logical volumes on this node of the OSDs of the cluster that were
+// purged, so that their data device is available again and their slot on the shared metadata
+// device is used by the next OSD. The OSDs replaced because their device is predicted to fail are
+// left on their device.
+func (a *OsdAgent) zapPurgedOSDs(context *clusterd.Context) error {
+	result, err := callCephVolume(context, "lvm", "list", "--format", "json")
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve ceph-volume lvm list results")
+	}
+	var lvmOSDs map[string][]osdInfo
+	if err := json.Unmarshal([]byte(result), &lvmOSDs); err != nil {
+		return errors.Wrap(err, "failed to unmarshal ceph-volume lvm list results")
+	}
+	if len(lvmOSDs) == 0 {
+		return nil
+	}
+
+	osdDump, err := client.GetOSDDump(context, a.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	existing := map[string]bool{}
+	for _, osd := range osdDump.OSDs {
+		existing[osd.OSD.String()] = true
+	}
+	replaced, err := oposd.GetReplacedOSDUUIDs(context, a.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the replaced osds")
+	}
+
+	ids := make([]string, 0, len(lvmOSDs))
+	for id := range lvmOSDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if existing[id] {
+			continue
+		}
+		var osdFSID string
+		for _, volume := range lvmOSDs[id] {
+			if volume.Tags.ClusterFSID == a.clusterInfo.FSID {
+				osdFSID = volume.Tags.OSDFSID
+			}
+		}
+		if osdFSID == "" {
+			// the volumes of the other clusters are not touched
+			continue
+		}
+		if replaced.Has(osdFSID) {
+			logger.Infof("not zapping the volumes of purged osd.%s which is tracked in configmap %q for its replacement", id, oposd.OSDReplacementsConfigMap)
+			continue
+		}
+
+		// the block, db and wal volumes of the osd are found by their tags
+		logger.Infof("zapping the volumes of osd.%s which was purged from the cluster", id)
+		output, err := context.Executor.ExecuteCommandWithCombinedOutput("stdbuf", "-oL", cephVolumeCmd, "lvm", "zap", "--osd-id", id, "--osd-fsid", osdFSID, "--destroy")
+		if err != nil {
+			return errors.Wrapf(err, "failed to zap the volumes of purged osd.%s. %s", id, output)
+		}
+		logger.Infof("ceph-volume output: %s", output)
+	}
+	return nil
+}
+
 // getOSDDiskToBeWiped returns OSD disk path and the dmcrypt block in case of encrypted OSDs
 func getOSDDiskToBeWiped(context *clusterd.Context, existingOSDDevice string) (*sys.LocalDisk, string, error) {
 	var err error
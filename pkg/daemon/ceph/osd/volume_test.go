@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -34,6 +35,8 @@ import (
 	"github.com/rook/rook/pkg/util/sys"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var initializeBlockPVCTestResult = `
@@ -2095,21 +2098,97 @@ func TestIsSafeToUseRawMode(t *testing.T) {
 		},
 	}
 
+	storeConfig := &config.StoreConfig{OSDsPerDevice: 1}
+
 	t.Run("safe if OSDs per device == 1 and metadata device is not specified", func(t *testing.T) {
-		assert.True(t, isSafeToUseRawMode(device))
+		assert.True(t, isSafeToUseRawMode(device, storeConfig))
 	})
 
 	t.Run("not safe if OSDs per device > 1", func(t *testing.T) {
 		device.Config.OSDsPerDevice = 2
-		assert.False(t, isSafeToUseRawMode(device))
+		assert.False(t, isSafeToUseRawMode(device, storeConfig))
+	})
+
+	t.Run("safe if OSDs per device > 1 in raw mode", func(t *testing.T) {
+		device.Config.OSDsPerDeviceMode = config.OSDsPerDeviceModeRaw
+		assert.True(t, isSafeToUseRawMode(device, storeConfig))
+		device.Config.OSDsPerDeviceMode = ""
+		storeConfig.OSDsPerDeviceMode = config.OSDsPerDeviceModeRaw
+		assert.True(t, isSafeToUseRawMode(device, storeConfig))
+		device.Config.OSDsPerDeviceMode = config.OSDsPerDeviceModeLVM
+		assert.False(t, isSafeToUseRawMode(device, storeConfig))
+		device.Config.OSDsPerDeviceMode = ""
+	})
+
+	t.Run("not safe if a partition has OSDs per device > 1 in raw mode", func(t *testing.T) {
+		device.DeviceInfo.Type = sys.PartType
+		assert.False(t, isSafeToUseRawMode(device, storeConfig))
+		device.DeviceInfo.Type = sys.DiskType
 	})
 
 	t.Run("not safe if metadata device specified", func(t *testing.T) {
 		device.Config.MetadataDevice = "vdb1"
-		assert.False(t, isSafeToUseRawMode(device))
+		assert.False(t, isSafeToUseRawMode(device, storeConfig))
 	})
 }
 
+func TestInitializeDevicesRawModeOSDsPerDevice(t *testing.T) {
+	var sgdiskArgs []string
+	prepared := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithCombinedOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("%s %v", command, args)
+			switch command {
+			case "sgdisk":
+				sgdiskArgs = args
+				return "", nil
+			case "stdbuf":
+				assert.Equal(t, []string{"-oL", cephVolumeCmd, "raw", "prepare", "--bluestore", "--data"}, args[:6])
+				prepared = append(prepared, args[6])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %q", command)
+		},
+		MockExecuteCommand: func(command string, args ...string) error {
+			assert.Equal(t, "udevadm", command)
+			return nil
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	agent := &OsdAgent{storeConfig: config.StoreConfig{StoreType: "bluestore", OSDsPerDevice: 2, OSDsPerDeviceMode: config.OSDsPerDeviceModeRaw}}
+	devices := &DeviceOsdMapping{
+		Entries: map[string]*DeviceOsdIDEntry{
+			"nvme0n1": {Data: -1, Config: DesiredDevice{Name: "nvme0n1"}, DeviceInfo: &sys.LocalDisk{Name: "nvme0n1", Type: sys.DiskType, Size: 4 * 1024 * 1024 * 1024}},
+		},
+	}
+
+	// the device is carved into a partition per osd
+	require.NoError(t, agent.initializeDevicesRawMode(context, devices))
+	assert.Equal(t, []string{"--clear", "--new=1:0:+2048M", "--change-name=1:ceph-osd-1", "--new=2:0:0", "--change-name=2:ceph-osd-2", "/dev/nvme0n1"}, sgdiskArgs)
+	assert.Equal(t, []string{"/dev/nvme0n1p1", "/dev/nvme0n1p2"}, prepared)
+
+	// the count of the device overrides the count of the node
+	sgdiskArgs = nil
+	prepared = []string{}
+	devices.Entries = map[string]*DeviceOsdIDEntry{
+		"sdb": {Data: -1, Config: DesiredDevice{Name: "sdb", OSDsPerDevice: 3}, DeviceInfo: &sys.LocalDisk{Name: "sdb", Type: sys.DiskType, Size: 3 * 1024 * 1024 * 1024}},
+	}
+	require.NoError(t, agent.initializeDevicesRawMode(context, devices))
+	assert.Contains(t, sgdiskArgs, "--new=1:0:+1024M")
+	assert.Equal(t, []string{"/dev/sdb1", "/dev/sdb2", "/dev/sdb3"}, prepared)
+
+	// a device with a single osd is not partitioned
+	sgdiskArgs = nil
+	prepared = []string{}
+	agent.storeConfig.OSDsPerDevice = 1
+	devices.Entries = map[string]*DeviceOsdIDEntry{
+		"sdc": {Data: -1, Config: DesiredDevice{Name: "sdc"}, DeviceInfo: &sys.LocalDisk{Name: "sdc", Type: sys.DiskType, Size: 1024 * 1024 * 1024}},
+	}
+	require.NoError(t, agent.initializeDevicesRawMode(context, devices))
+	assert.Nil(t, sgdiskArgs)
+	assert.Equal(t, []string{"/dev/sdc"}, prepared)
+}
+
 func TestLVMModeAllowed(t *testing.T) {
 	device := &DeviceOsdIDEntry{
 		Config: DesiredDevice{
@@ -2199,3 +2278,59 @@ func TestWipeDevicesFromOtherClusters(t *testing.T) {
 	err = agent.WipeDevicesFromOtherClusters(context)
 	assert.NoError(t, err)
 }
+
+func TestZapPurgedOSDs(t *testing.T) {
+	fsid := "c03d7353-96e5-4a41-98de-830dfff97d06"
+	clusterInfo := cephclient.AdminTestClusterInfo("ns")
+	clusterInfo.FSID = fsid
+	agent := &OsdAgent{clusterInfo: clusterInfo}
+	volume := func(clusterFSID, osdFSID, volumeType string) string {
+		return fmt.Sprintf(`{"name": "osd-%s", "path": "/dev/ceph/osd-%s", "type": %q, "tags": {"ceph.cluster_fsid": %q, "ceph.osd_fsid": %q}}`,
+			volumeType, osdFSID, volumeType, clusterFSID, osdFSID)
+	}
+	lvmList := fmt.Sprintf(`{"0": [%s, %s], "1": [%s, %s], "2": [%s], "3": [%s]}`,
+		volume(fsid, "fsid-0", "block"), volume(fsid, "fsid-0", "db"),
+		volume(fsid, "fsid-1", "block"), volume(fsid, "fsid-1", "db"),
+		volume("other-cluster", "fsid-2", "block"),
+		volume(fsid, "fsid-3", "block"))
+
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("%s %v", command, args)
+		if contains(args, "lvm") && contains(args, "list") {
+			return lvmList, nil
+		}
+		if args[0] == "osd" && args[1] == "dump" {
+			// osd.1 was purged, osd.2 belongs to another cluster, osd.3 was replaced
+			return `{"osds":[{"osd":0,"up":1,"in":1}]}`, nil
+		}
+		return "", errors.Errorf("unknown command %s %s", command, args)
+	}
+	var zapped []string
+	executor.MockExecuteCommandWithCombinedOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("%s %v", command, args)
+		if contains(args, "zap") {
+			zapped = append(zapped, strings.Join(args[3:], " "))
+			return "", nil
+		}
+		return "", errors.Errorf("unknown command %s %s", command, args)
+	}
+	clientset := test.New(t, 1)
+	replacements := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: oposd.OSDReplacementsConfigMap, Namespace: "ns"},
+		Data:       map[string]string{"replacements": `[{"osd": 3, "uuid": "fsid-3", "phase": "Replaced"}]`},
+	}
+	_, err := clientset.CoreV1().ConfigMaps("ns").Create(context.TODO(), replacements, metav1.CreateOptions{})
+	require.NoError(t, err)
+	clusterdContext := &clusterd.Context{Executor: executor, Clientset: clientset}
+
+	// only the volumes of the purged osd of the cluster are zapped, the replaced osd is left on its device
+	require.NoError(t, agent.zapPurgedOSDs(clusterdContext))
+	assert.Equal(t, []string{"zap --osd-id 1 --osd-fsid fsid-1 --destroy"}, zapped)
+
+	// nothing is zapped when there is no lvm osd on the node
+	zapped = nil
+	lvmList = "{}"
+	require.NoError(t, agent.zapPurgedOSDs(clusterdContext))
+	assert.Empty(t, zapped)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package seed creates the seed data of the pools, filesystems and object stores once they are ready
+package seed
+
+import (
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "seed")
+
+const mib = 1024 * 1024
+
+// BlockPoolSeed creates the seed images of a cephblockpool that do not exist yet
+func BlockPoolSeed(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, poolName string, spec *cephv1.BlockPoolSeedSpec) error {
+	logger.Infof("starting seed of cephblockpool %q", poolName)
+
+	images, err := cephclient.ListImagesInPool(context, clusterInfo, poolName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list images in cephblockpool %q", poolName)
+	}
+	existing := map[string]bool{}
+	for _, image := range images {
+		existing[image.Name] = true
+	}
+
+	for _, image := range spec.Images {
+		if existing[image.Name] {
+			logger.Infof("image %q already exists in cephblockpool %q", image.Name, poolName)
+			continue
+		}
+		size := image.Size.Value()
+		if size < mib {
+			return errors.Errorf("size %q of image %q is smaller than 1Mi", image.Size.String(), image.Name)
+		}
+		// rbd sizes the images in MiB, round up to the next MiB
+		sizeMiB := uint64((size + mib - 1) / mib)
+		if err := cephclient.CreateImageInPool(context, clusterInfo, image.Name, poolName, sizeMiB); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("successfully seeded cephblockpool %q", poolName)
+	return nil
+}
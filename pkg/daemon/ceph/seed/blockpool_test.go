@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestBlockPoolSeed(t *testing.T) {
+	created := map[string]string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case command == "rbd" && args[0] == "ls":
+				return `[{"image":"existing","size":1048576,"format":2}]`, nil
+			case command == "rbd" && args[0] == "create":
+				created[args[1]] = args[3]
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %q %q", command, args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+
+	spec := &cephv1.BlockPoolSeedSpec{Images: []cephv1.SeedImageSpec{
+		{Name: "existing", Size: resource.MustParse("1Gi")},
+		{Name: "disk", Size: resource.MustParse("10Gi")},
+		{Name: "small", Size: resource.MustParse("1500Ki")},
+	}}
+	assert.NoError(t, BlockPoolSeed(context, clusterInfo, "replicapool", spec))
+	assert.Equal(t, map[string]string{"replicapool/disk": "10240", "replicapool/small": "2"}, created)
+
+	spec.Images = []cephv1.SeedImageSpec{{Name: "tiny", Size: resource.MustParse("1Ki")}}
+	err := BlockPoolSeed(context, clusterInfo, "replicapool", spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "smaller than 1Mi")
+}
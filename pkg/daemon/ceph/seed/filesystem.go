@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// FilesystemSeed mounts the root of the filesystem to create its seed directories
+func FilesystemSeed(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, fsName string, spec *cephv1.FilesystemSeedSpec) error {
+	logger.Infof("starting seed of filesystem %q", fsName)
+
+	mountPath, err := os.MkdirTemp("", "seed-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the mount point of the filesystem")
+	}
+	defer os.Remove(mountPath)
+
+	keyringFile := fmt.Sprintf("%s.keyring", clusterInfo.CephCred.Username)
+	args := []string{
+		mountPath,
+		fmt.Sprintf("--client_fs=%s", fsName),
+		fmt.Sprintf("--conf=%s", cephclient.CephConfFilePath(context.ConfigDir, clusterInfo.Namespace)),
+		fmt.Sprintf("--name=%s", clusterInfo.CephCred.Username),
+		fmt.Sprintf("--keyring=%s", path.Join(context.ConfigDir, clusterInfo.Namespace, keyringFile)),
+	}
+	if err := context.Executor.ExecuteCommand("ceph-fuse", args...); err != nil {
+		return errors.Wrapf(err, "failed to mount filesystem %q", fsName)
+	}
+	defer func() {
+		if err := context.Executor.ExecuteCommand("umount", mountPath); err != nil {
+			logger.Warningf("failed to unmount filesystem %q. %v", fsName, err)
+		}
+	}()
+
+	for _, dir := range spec.Directories {
+		if err := seedDirectory(mountPath, dir); err != nil {
+			return errors.Wrapf(err, "failed to seed directory %q of filesystem %q", dir.Path, fsName)
+		}
+	}
+
+	logger.Infof("successfully seeded filesystem %q", fsName)
+	return nil
+}
+
+// seedDirectory creates a directory and its missing parents under the root of the filesystem, then
+// sets its mode and ownership
+func seedDirectory(root string, dir cephv1.SeedDirectorySpec) error {
+	// the cleaned absolute path cannot escape the root of the filesystem
+	target := filepath.Join(root, filepath.Clean("/"+dir.Path))
+
+	mode := os.FileMode(0o755)
+	if dir.Mode != "" {
+		parsed, err := strconv.ParseUint(dir.Mode, 8, 32)
+		if err != nil || parsed > 0o777 {
+			return errors.Errorf("invalid mode %q", dir.Mode)
+		}
+		mode = os.FileMode(parsed)
+	}
+
+	if err := os.MkdirAll(target, mode); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+	// the mode of the created directory is masked by the umask, and the existing directory is updated
+	if dir.Mode != "" {
+		if err := os.Chmod(target, mode); err != nil {
+			return errors.Wrap(err, "failed to set the mode of the directory")
+		}
+	}
+	if dir.UID != nil || dir.GID != nil {
+		uid, gid := -1, -1
+		if dir.UID != nil {
+			uid = int(*dir.UID)
+		}
+		if dir.GID != nil {
+			gid = int(*dir.GID)
+		}
+		if err := os.Lchown(target, uid, gid); err != nil {
+			return errors.Wrap(err, "failed to set the ownership of the directory")
+		}
+	}
+	logger.Infof("seeded directory %q", dir.Path)
+	return nil
+}
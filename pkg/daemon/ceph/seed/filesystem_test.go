@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedDirectory(t *testing.T) {
+	root := t.TempDir()
+
+	t.Run("nested directory with mode", func(t *testing.T) {
+		require.NoError(t, seedDirectory(root, cephv1.SeedDirectorySpec{Path: "/apps/web", Mode: "0750"}))
+		info, err := os.Stat(filepath.Join(root, "apps", "web"))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+		assert.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+
+		// the mode of the existing directory is updated
+		require.NoError(t, seedDirectory(root, cephv1.SeedDirectorySpec{Path: "/apps/web", Mode: "775"}))
+		info, err = os.Stat(filepath.Join(root, "apps", "web"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o775), info.Mode().Perm())
+	})
+
+	t.Run("path does not escape the root", func(t *testing.T) {
+		require.NoError(t, seedDirectory(root, cephv1.SeedDirectorySpec{Path: "/../../escaped"}))
+		_, err := os.Stat(filepath.Join(root, "escaped"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("ownership", func(t *testing.T) {
+		gid := int64(os.Getgid())
+		require.NoError(t, seedDirectory(root, cephv1.SeedDirectorySpec{Path: "/shared", GID: &gid}))
+		info, err := os.Stat(filepath.Join(root, "shared"))
+		require.NoError(t, err)
+		assert.Equal(t, uint32(gid), info.Sys().(*syscall.Stat_t).Gid)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		err := seedDirectory(root, cephv1.SeedDirectorySpec{Path: "/invalid", Mode: "0999"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid mode "0999"`)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+)
+
+// ObjectStoreConnection holds the settings to reach an object store with S3
+type ObjectStoreConnection struct {
+	Endpoint    string
+	TLSCert     []byte
+	InsecureTLS bool
+}
+
+// newS3Agent creates the S3 client of a user, it can be replaced in the tests
+var newS3Agent = func(accessKey, secretKey string, conn ObjectStoreConnection) (s3BucketCreator, error) {
+	return object.NewS3Agent(accessKey, secretKey, conn.Endpoint, false, conn.TLSCert, conn.InsecureTLS, nil)
+}
+
+type s3BucketCreator interface {
+	CreateBucket(name string) error
+}
+
+// ObjectStoreSeed creates the seed users of an object store that do not exist yet, and the seed
+// buckets with the credentials of their owners
+func ObjectStoreSeed(objContext *object.Context, conn ObjectStoreConnection, spec *cephv1.ObjectStoreSeedSpec) error {
+	logger.Infof("starting seed of object store %q", objContext.Name)
+
+	users := map[string]*object.ObjectUser{}
+	for _, seedUser := range spec.Users {
+		user, err := seedObjectUser(objContext, seedUser)
+		if err != nil {
+			return errors.Wrapf(err, "failed to seed user %q", seedUser.UID)
+		}
+		users[seedUser.UID] = user
+	}
+
+	for _, bucket := range spec.Buckets {
+		owner, ok := users[bucket.Owner]
+		if !ok {
+			return errors.Errorf("owner %q of bucket %q is not a seeded user", bucket.Owner, bucket.Name)
+		}
+		s3Agent, err := newS3Agent(*owner.AccessKey, *owner.SecretKey, conn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the s3 client of user %q", bucket.Owner)
+		}
+		if err := s3Agent.CreateBucket(bucket.Name); err != nil {
+			return errors.Wrapf(err, "failed to seed bucket %q", bucket.Name)
+		}
+	}
+
+	logger.Infof("successfully seeded object store %q", objContext.Name)
+	return nil
+}
+
+// seedObjectUser returns the user of the object store, creating it if it does not exist
+func seedObjectUser(objContext *object.Context, seedUser cephv1.SeedObjectUserSpec) (*object.ObjectUser, error) {
+	user, errCode, err := object.GetUser(objContext, seedUser.UID)
+	if err == nil {
+		logger.Infof("user %q already exists in object store %q", seedUser.UID, objContext.Name)
+		return user, nil
+	}
+	if errCode != object.RGWErrorNotFound {
+		return nil, err
+	}
+
+	displayName := seedUser.DisplayName
+	if displayName == "" {
+		displayName = seedUser.UID
+	}
+	logger.Infof("creating user %q in object store %q", seedUser.UID, objContext.Name)
+	user, _, err = object.CreateUser(objContext, object.ObjectUser{UserID: seedUser.UID, DisplayName: &displayName}, false)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeS3Agent struct {
+	accessKey string
+	buckets   *[]string
+}
+
+func (f *fakeS3Agent) CreateBucket(name string) error {
+	*f.buckets = append(*f.buckets, f.accessKey+"/"+name)
+	return nil
+}
+
+func TestObjectStoreSeed(t *testing.T) {
+	//nolint:gosec // only test values, not a real secret
+	userJSON := `{"user_id":"app","display_name":"app","keys":[{"user":"app","access_key":"APPKEY","secret_key":"APPSECRET"}]}`
+	createdUsers := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "user" && args[1] == "info" {
+				if args[3] == "app" {
+					return userJSON, nil
+				}
+				return "could not fetch user info: no user info saved", nil
+			}
+			if args[0] == "user" && args[1] == "create" {
+				createdUsers = append(createdUsers, args[3]+":"+args[5])
+				return `{"user_id":"` + args[3] + `","keys":[{"user":"` + args[3] + `","access_key":"NEWKEY","secret_key":"NEWSECRET"}]}`, nil
+			}
+			return "", nil
+		},
+	}
+	objContext := object.NewContext(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("rook-ceph"), "my-store")
+
+	buckets := []string{}
+	defaultS3Agent := newS3Agent
+	defer func() { newS3Agent = defaultS3Agent }()
+	newS3Agent = func(accessKey, secretKey string, conn ObjectStoreConnection) (s3BucketCreator, error) {
+		assert.Equal(t, "http://rook-ceph-rgw-my-store.rook-ceph.svc:80", conn.Endpoint)
+		return &fakeS3Agent{accessKey: accessKey, buckets: &buckets}, nil
+	}
+	conn := ObjectStoreConnection{Endpoint: "http://rook-ceph-rgw-my-store.rook-ceph.svc:80"}
+
+	spec := &cephv1.ObjectStoreSeedSpec{
+		Users: []cephv1.SeedObjectUserSpec{{UID: "app"}, {UID: "backup", DisplayName: "Backup"}},
+		Buckets: []cephv1.SeedBucketSpec{
+			{Name: "assets", Owner: "app"},
+			{Name: "archives", Owner: "backup"},
+		},
+	}
+	assert.NoError(t, ObjectStoreSeed(objContext, conn, spec))
+	assert.Equal(t, []string{"backup:Backup"}, createdUsers)
+	assert.Equal(t, []string{"APPKEY/assets", "NEWKEY/archives"}, buckets)
+
+	spec.Buckets = []cephv1.SeedBucketSpec{{Name: "orphan", Owner: "unknown"}}
+	err := ObjectStoreSeed(objContext, conn, spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `owner "unknown" of bucket "orphan" is not a seeded user`)
+}
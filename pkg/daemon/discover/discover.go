@@ -32,6 +32,8 @@ import (
 	"time"
 
 	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/rook/rook/pkg/util/sys"
@@ -43,6 +45,9 @@ import (
 
 const (
 	DiscoverDaemonUdev = "DISCOVER_DAEMON_UDEV_BLACKLIST"
+	// DiscoverDaemonDeviceIdentityFilter is the JSON filter allowing or denying the discovered
+	// devices by WWN, serial, vendor or model
+	DiscoverDaemonDeviceIdentityFilter = "DISCOVER_DAEMON_DEVICE_IDENTITY_FILTER"
 )
 
 var (
@@ -62,6 +67,7 @@ var (
 	cm              *v1.ConfigMap
 	udevEventPeriod = time.Duration(5) * time.Second
 	useCVInventory  bool
+	identityFilter  *cephv1.DeviceIdentityFilter
 )
 
 // CephVolumeInventory is the Go struct representation of the json output
@@ -84,10 +90,15 @@ func Run(ctx context.Context, context *clusterd.Context, probeInterval time.Dura
 	namespace = os.Getenv(k8sutil.PodNamespaceEnvVar)
 	cmName = k8sutil.TruncateNodeName(LocalDiskCMName, nodeName)
 	useCVInventory = useCV
+	var err error
+	identityFilter, err = parseDeviceIdentityFilter(os.Getenv(DiscoverDaemonDeviceIdentityFilter))
+	if err != nil {
+		return err
+	}
 	sigc := make(chan os.Signal, 1)
 	signal.Notify(sigc, syscall.SIGTERM)
 
-	err := updateDeviceCM(ctx, context)
+	err = updateDeviceCM(ctx, context)
 	if err != nil {
 		logger.Infof("failed to update device configmap: %v", err)
 		return err
@@ -241,6 +252,21 @@ func ignoreDevice(dev sys.LocalDisk) bool {
 	return strings.Contains(strings.ToUpper(dev.DevLinks), "USB")
 }
 
+func parseDeviceIdentityFilter(value string) (*cephv1.DeviceIdentityFilter, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var filter cephv1.DeviceIdentityFilter
+	if err := json.Unmarshal([]byte(value), &filter); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s %q", DiscoverDaemonDeviceIdentityFilter, value)
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", DiscoverDaemonDeviceIdentityFilter)
+	}
+	logger.Infof("using the device identity filter %+v", filter)
+	return &filter, nil
+}
+
 func checkMatchingDevice(checkDev sys.LocalDisk, devices []sys.LocalDisk) *sys.LocalDisk {
 	for i, dev := range devices {
 		if ignoreDevice(dev) {
@@ -384,11 +410,10 @@ func updateDeviceCM(ctx context.Context, clusterdContext *clusterd.Context) erro
 		}
 		lastDevice = deviceStr
 	}
-	devicesEqual, err := DeviceListsEqual(lastDevice, deviceStr)
-	if err != nil {
-		return fmt.Errorf("failed to compare device lists: %v", err)
-	}
-	if !devicesEqual {
+	// the configmap is also updated when only the inventory of the devices changed, such as their
+	// ceph-volume data or SMART status. the operator only orchestrates the OSDs when the device
+	// lists are not equal.
+	if deviceStr != lastDevice {
 		data := make(map[string]string, 1)
 		data[LocalDiskCMData] = deviceStr
 		cm.Data = data
@@ -434,6 +459,10 @@ func probeDevices(context *clusterd.Context) ([]sys.LocalDisk, error) {
 		if device == nil {
 			continue
 		}
+		if allowed, reason := identityFilter.Matches(device.WWN, device.Serial, device.Vendor, device.Model); !allowed {
+			logger.Infof("skipping device %q: %s", device.Name, reason)
+			continue
+		}
 
 		partitions, _, err := sys.GetDevicePartitions(device.Name, context.Executor)
 		if err != nil {
@@ -450,6 +479,9 @@ func probeDevices(context *clusterd.Context) ([]sys.LocalDisk, error) {
 		device.Partitions = partitions
 		device.Filesystem = fs
 		device.Empty = clusterd.GetDeviceEmpty(device)
+		if device.Type == sys.DiskType {
+			device.SmartStatus = getSmartStatus(context, device.Name)
+		}
 
 		// Add the information provided by ceph-volume inventory
 		if cvInventory != nil {
@@ -470,6 +502,30 @@ func probeDevices(context *clusterd.Context) ([]sys.LocalDisk, error) {
 	return devices, nil
 }
 
+// smartctlHealth is the health of a device in the json output of smartctl
+type smartctlHealth struct {
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+}
+
+// getSmartStatus returns the overall SMART health of a disk, PASSED or FAILED, or an empty string if
+// the disk does not report it
+func getSmartStatus(context *clusterd.Context, device string) string {
+	// smartctl exits with a non-zero status when the disk is failing, so its output is parsed even
+	// on error. the output is followed by the error, only the leading json is decoded.
+	out, err := context.Executor.ExecuteCommandWithOutput("smartctl", "--json", "--health", path.Join("/dev/", device))
+	var health smartctlHealth
+	if decodeErr := json.NewDecoder(strings.NewReader(out)).Decode(&health); decodeErr != nil || health.SmartStatus == nil {
+		logger.Debugf("smart status of device %q is not available. %v", device, err)
+		return ""
+	}
+	if health.SmartStatus.Passed {
+		return "PASSED"
+	}
+	return "FAILED"
+}
+
 // getCephVolumeInventory: Return a map of strings indexed by device with the
 // information about the device returned by the command <ceph-volume inventory>
 func getCephVolumeInventory(context *clusterd.Context) (*map[string]string, error) {
@@ -472,3 +472,18 @@ func TestGetCephVolumeInventory(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, len(*cvdata), 1)
 }
+
+func TestParseDeviceIdentityFilter(t *testing.T) {
+	filter, err := parseDeviceIdentityFilter("")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = parseDeviceIdentityFilter(`{"deny":[{"serial":"abc"}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", filter.Deny[0].Serial)
+
+	_, err = parseDeviceIdentityFilter(`{"deny":[{"model":"["}]}`)
+	assert.Error(t, err)
+	_, err = parseDeviceIdentityFilter(`deny`)
+	assert.Error(t, err)
+}
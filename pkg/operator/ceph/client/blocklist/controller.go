@@ -0,0 +1,388 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blocklist reconciles the CephClientBlocklist resources to manage the blocklist of the Ceph clients.
+package blocklist
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "ceph-client-blocklist-controller"
+	// the addresses without a TTL are blocklisted until they are removed from the entries, ceph
+	// does not support blocklisting an address forever
+	permanentBlocklistDuration = 10 * 365 * 24 * time.Hour
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+var cephClientBlocklistKind = reflect.TypeOf(cephv1.CephClientBlocklist{}).Name()
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       cephClientBlocklistKind,
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// ReconcileCephClientBlocklist reconciles a CephClientBlocklist object
+type ReconcileCephClientBlocklist struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// Add creates a new CephClientBlocklist Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileCephClientBlocklist{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor("rook-" + controllerName),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephClientBlocklist CRD object
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephClientBlocklist{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephClientBlocklist]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephClientBlocklist](mgr.GetScheme()),
+		),
+	)
+}
+
+// Reconcile reads that state of the cluster for a CephClientBlocklist object and makes the blocklist
+// of the cluster match the CephClientBlocklist.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileCephClientBlocklist) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, cephClientBlocklist, err := r.reconcile(request)
+	if err != nil {
+		r.updateStatus(cephClientBlocklist.Generation, request.NamespacedName, cephv1.ConditionFailure, nil)
+		logger.Errorf("failed to reconcile %v", err)
+	}
+
+	return reporting.ReportReconcileResult(logger, r.recorder, request, &cephClientBlocklist, reconcileResponse, err)
+}
+
+func (r *ReconcileCephClientBlocklist) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephClientBlocklist, error) {
+	// Fetch the CephClientBlocklist instance
+	cephClientBlocklist := &cephv1.CephClientBlocklist{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephClientBlocklist)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephClientBlocklist resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, *cephClientBlocklist, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, *cephClientBlocklist, errors.Wrap(err, "failed to get CephClientBlocklist")
+	}
+
+	// Set a finalizer so the addresses are removed from the blocklist before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(r.opManagerContext, r.client, cephClientBlocklist)
+	if err != nil {
+		return reconcile.Result{}, *cephClientBlocklist, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		logger.Infof("reconciling the client blocklist %q after adding finalizer", cephClientBlocklist.Name)
+		return reconcile.Result{}, *cephClientBlocklist, nil
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		// The blocklist is gone with the CephCluster, so only the finalizer is removed
+		if !cephClientBlocklist.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephClientBlocklist)
+			if err != nil {
+				return opcontroller.ImmediateRetryResult, *cephClientBlocklist, errors.Wrap(err, "failed to remove finalizer")
+			}
+			return reconcile.Result{}, *cephClientBlocklist, nil
+		}
+		logger.Debugf("CephCluster resource not ready in namespace %q, retrying in %q.", request.NamespacedName.Namespace, reconcileResponse.RequeueAfter.String())
+		return reconcileResponse, *cephClientBlocklist, nil
+	}
+
+	// Populate clusterInfo
+	clusterInfo, _, _, err := opcontroller.LoadClusterInfo(r.context, r.opManagerContext, request.NamespacedName.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, *cephClientBlocklist, errors.Wrap(err, "failed to populate cluster info")
+	}
+
+	var previous []cephv1.ClientBlocklistEntryStatus
+	if cephClientBlocklist.Status != nil {
+		previous = cephClientBlocklist.Status.Entries
+	}
+	listedByOthers, err := r.addressesOfOtherBlocklists(cephClientBlocklist)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, *cephClientBlocklist, err
+	}
+
+	// DELETE: the CR was deleted, the addresses still blocklisted are removed from the blocklist
+	if !cephClientBlocklist.GetDeletionTimestamp().IsZero() {
+		if _, _, err := syncBlocklist(r.context, clusterInfo, nil, previous, listedByOthers, time.Now()); err != nil {
+			return opcontroller.ImmediateRetryResult, *cephClientBlocklist, errors.Wrap(err, "failed to remove the addresses from the blocklist")
+		}
+		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephClientBlocklist)
+		if err != nil {
+			return reconcile.Result{}, *cephClientBlocklist, errors.Wrap(err, "failed to remove finalizer")
+		}
+		logger.Infof("removed the addresses of CephClientBlocklist %q from the blocklist", request.NamespacedName)
+		return reconcile.Result{}, *cephClientBlocklist, nil
+	}
+
+	now := time.Now()
+	entries, nextExpiration, err := syncBlocklist(r.context, clusterInfo, cephClientBlocklist.Spec.Entries, previous, listedByOthers, now)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, *cephClientBlocklist, errors.Wrap(err, "failed to update the blocklist")
+	}
+	r.updateStatus(cephClientBlocklist.Generation, request.NamespacedName, cephv1.ConditionReady, entries)
+	logger.Debugf("updated the blocklist of CephClientBlocklist %q", request.NamespacedName)
+
+	// the status of the entries is updated once their TTL elapsed
+	if nextExpiration != nil {
+		return reconcile.Result{RequeueAfter: nextExpiration.Sub(now)}, *cephClientBlocklist, nil
+	}
+	return reconcile.Result{}, *cephClientBlocklist, nil
+}
+
+// addressesOfOtherBlocklists returns the addresses listed by the other CephClientBlocklists of the
+// namespace, which are kept in the blocklist when this CephClientBlocklist no longer lists them
+func (r *ReconcileCephClientBlocklist) addressesOfOtherBlocklists(cephClientBlocklist *cephv1.CephClientBlocklist) (sets.Set[string], error) {
+	blocklists := &cephv1.CephClientBlocklistList{}
+	if err := r.client.List(r.opManagerContext, blocklists, client.InNamespace(cephClientBlocklist.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list the CephClientBlocklists")
+	}
+	addresses := sets.New[string]()
+	for _, other := range blocklists.Items {
+		if other.Name == cephClientBlocklist.Name || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		for _, entry := range other.Spec.Entries {
+			addresses.Insert(entry.Address)
+		}
+	}
+	return addresses, nil
+}
+
+// syncBlocklist blocklists the addresses of the entries and removes from the blocklist the addresses
+// previously blocklisted that are no longer in the entries. The addresses that were already
+// blocklisted when their entry was added and the addresses listed by other CephClientBlocklists are
+// left in the blocklist, so that a client fenced by the CSI driver or by another CephClientBlocklist
+// stays fenced. It returns the status of the entries with the earliest expiration of the addresses
+// still blocklisted.
+func syncBlocklist(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, entries []cephv1.ClientBlocklistEntry, previous []cephv1.ClientBlocklistEntryStatus, listedByOthers sets.Set[string], now time.Time) ([]cephv1.ClientBlocklistEntryStatus, *time.Time, error) {
+	previousEntries := map[string]cephv1.ClientBlocklistEntryStatus{}
+	for _, entry := range previous {
+		previousEntries[entry.Address] = entry
+	}
+
+	// the blocklist is only listed when an entry is added
+	var blocklisted sets.Set[string]
+	isBlocklisted := func(address string) (bool, error) {
+		if blocklisted == nil {
+			addresses, err := cephclient.ListBlocklist(context, clusterInfo)
+			if err != nil {
+				return false, err
+			}
+			blocklisted = sets.New(addresses...)
+		}
+		return blocklisted.Has(address) || blocklisted.Has(blocklistedForm(address)), nil
+	}
+
+	var nextExpiration *time.Time
+	result := []cephv1.ClientBlocklistEntryStatus{}
+	checked := map[string]bool{}
+	for _, entry := range entries {
+		if checked[entry.Address] {
+			continue
+		}
+		checked[entry.Address] = true
+
+		status, ok := previousEntries[entry.Address]
+		if !ok || !sameTTL(status.TTL, entry.TTL) {
+			preExisting := status.PreExisting
+			if !ok {
+				var err error
+				preExisting, err = isBlocklisted(entry.Address)
+				if err != nil {
+					return nil, nil, err
+				}
+				if preExisting {
+					logger.Infof("%q is already blocklisted, it is left in the blocklist when its entry is removed", entry.Address)
+				}
+			}
+			// the TTL of a new or changed entry starts now
+			status = cephv1.ClientBlocklistEntryStatus{Address: entry.Address, TTL: entry.TTL, AddedTime: metav1.NewTime(now), PreExisting: preExisting}
+			if entry.TTL != nil {
+				expiration := metav1.NewTime(now.Add(entry.TTL.Duration))
+				status.ExpirationTime = &expiration
+			}
+		}
+		if status.ExpirationTime != nil && !now.Before(status.ExpirationTime.Time) {
+			// the address was removed from the blocklist by ceph
+			status.Expired = true
+		}
+		if status.Expired {
+			result = append(result, status)
+			continue
+		}
+
+		// the address is blocklisted again in case it was removed from the blocklist manually
+		duration := permanentBlocklistDuration
+		if status.ExpirationTime != nil {
+			duration = status.ExpirationTime.Sub(now)
+			if nextExpiration == nil || status.ExpirationTime.Time.Before(*nextExpiration) {
+				nextExpiration = &status.ExpirationTime.Time
+			}
+		}
+		if err := blocklistAddress(context, clusterInfo, entry.Address, duration); err != nil {
+			return nil, nil, err
+		}
+		result = append(result, status)
+	}
+
+	for _, entry := range previous {
+		if checked[entry.Address] || entry.Expired {
+			continue
+		}
+		if entry.ExpirationTime != nil && !now.Before(entry.ExpirationTime.Time) {
+			continue
+		}
+		if entry.PreExisting {
+			logger.Infof("leaving %q in the blocklist since it was blocklisted before its entry was added", entry.Address)
+			continue
+		}
+		if listedByOthers.Has(entry.Address) {
+			logger.Infof("leaving %q in the blocklist since another CephClientBlocklist lists it", entry.Address)
+			continue
+		}
+		if err := unblocklistAddress(context, clusterInfo, entry.Address); err != nil {
+			return nil, nil, err
+		}
+		logger.Infof("removed %q from the blocklist", entry.Address)
+	}
+	return result, nextExpiration, nil
+}
+
+// blocklistedForm returns the address as listed in the blocklist by ceph, with the port and the
+// nonce of an IP set to 0 and the prefix length of a range as nonce
+func blocklistedForm(address string) string {
+	if ip, network, err := net.ParseCIDR(address); err == nil {
+		ones, _ := network.Mask.Size()
+		return fmt.Sprintf("%s/%d", net.JoinHostPort(ip.String(), "0"), ones)
+	}
+	if ip := net.ParseIP(address); ip != nil {
+		return net.JoinHostPort(ip.String(), "0") + "/0"
+	}
+	return address
+}
+
+func blocklistAddress(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, address string, duration time.Duration) error {
+	seconds := strconv.FormatInt(int64(math.Ceil(duration.Seconds())), 10)
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return cephclient.BlocklistRange(context, clusterInfo, address, seconds)
+	}
+	return cephclient.BlocklistIP(context, clusterInfo, address, seconds)
+}
+
+func unblocklistAddress(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, address string) error {
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return cephclient.UnblocklistRange(context, clusterInfo, address)
+	}
+	return cephclient.UnblocklistIP(context, clusterInfo, address)
+}
+
+func sameTTL(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Duration == b.Duration
+}
+
+// updateStatus updates the phase of the blocklist, and the status of the entries if not nil
+func (r *ReconcileCephClientBlocklist) updateStatus(observedGeneration int64, name types.NamespacedName, phase cephv1.ConditionType, entries []cephv1.ClientBlocklistEntryStatus) {
+	cephClientBlocklist := &cephv1.CephClientBlocklist{}
+	err := r.client.Get(r.opManagerContext, name, cephClientBlocklist)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephClientBlocklist resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve CephClientBlocklist %q to update status to %q. %v", name, phase, err)
+		return
+	}
+
+	if cephClientBlocklist.Status == nil {
+		cephClientBlocklist.Status = &cephv1.ClientBlocklistStatus{}
+	}
+	cephClientBlocklist.Status.Phase = phase
+	if entries != nil {
+		cephClientBlocklist.Status.Entries = entries
+	}
+	cephClientBlocklist.Status.ObservedGeneration = observedGeneration
+	if err := reporting.UpdateStatus(r.client, cephClientBlocklist); err != nil {
+		logger.Errorf("failed to set CephClientBlocklist %q status to %q. %v", name, phase, err)
+		return
+	}
+	logger.Debugf("CephClientBlocklist %q status updated to %q", name, phase)
+}
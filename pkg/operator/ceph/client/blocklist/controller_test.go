@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blocklist
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestSyncBlocklist(t *testing.T) {
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	var commands []string
+	blocklistOutput := "[]"
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "blocklist" && args[2] == "ls" {
+				return blocklistOutput, nil
+			}
+			if args[0] == "osd" && args[1] == "blocklist" {
+				// the blocklist arguments are followed by the flags of the ceph command
+				blocklistArgs := []string{}
+				for _, arg := range args[2:] {
+					if strings.HasPrefix(arg, "--") {
+						break
+					}
+					blocklistArgs = append(blocklistArgs, arg)
+				}
+				commands = append(commands, strings.Join(blocklistArgs, " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []cephv1.ClientBlocklistEntry{
+		{Address: "192.168.1.10"},
+		{Address: "10.0.0.0/24", TTL: &metav1.Duration{Duration: time.Hour}},
+		{Address: "192.168.1.10"},
+	}
+
+	// the addresses are blocklisted for their TTL
+	status, nextExpiration, err := syncBlocklist(context, clusterInfo, entries, nil, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add 192.168.1.10 315360000", "range add 10.0.0.0/24 3600"}, commands)
+	require.Len(t, status, 2)
+	assert.Nil(t, status[0].ExpirationTime)
+	require.NotNil(t, status[1].ExpirationTime)
+	assert.Equal(t, now.Add(time.Hour), status[1].ExpirationTime.Time)
+	require.NotNil(t, nextExpiration)
+	assert.Equal(t, now.Add(time.Hour), *nextExpiration)
+
+	// the addresses are blocklisted again for the rest of their TTL
+	commands = nil
+	now = now.Add(30 * time.Minute)
+	status, _, err = syncBlocklist(context, clusterInfo, entries, status, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add 192.168.1.10 315360000", "range add 10.0.0.0/24 1800"}, commands)
+	assert.Equal(t, now.Add(-30*time.Minute), status[1].AddedTime.Time)
+
+	// the expired address is not blocklisted again
+	commands = nil
+	now = now.Add(30 * time.Minute)
+	status, nextExpiration, err = syncBlocklist(context, clusterInfo, entries, status, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add 192.168.1.10 315360000"}, commands)
+	assert.True(t, status[1].Expired)
+	assert.Nil(t, nextExpiration)
+
+	// the expired address is blocklisted again when its TTL changes
+	commands = nil
+	entries[1].TTL = &metav1.Duration{Duration: 2 * time.Hour}
+	status, _, err = syncBlocklist(context, clusterInfo, entries, status, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add 192.168.1.10 315360000", "range add 10.0.0.0/24 7200"}, commands)
+	assert.False(t, status[1].Expired)
+
+	// the addresses removed from the entries are removed from the blocklist
+	commands = nil
+	status, _, err = syncBlocklist(context, clusterInfo, entries[1:2], status, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"range add 10.0.0.0/24 7200", "rm 192.168.1.10"}, commands)
+	require.Len(t, status, 1)
+
+	// all the addresses are removed from the blocklist when the blocklist is deleted
+	commands = nil
+	status, _, err = syncBlocklist(context, clusterInfo, nil, status, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"range rm 10.0.0.0/24"}, commands)
+	assert.Empty(t, status)
+}
+
+func TestSyncBlocklistKeepsOtherFencing(t *testing.T) {
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "blocklist" && args[2] == "ls" {
+				// the node was fenced by the csi driver before the entry was added
+				return `[{"addr":"192.168.1.10:0/0","until":"2035-06-01T12:00:00.000000+0000"},{"range":"10.0.1.0:0/24","until":"2035-06-01T12:00:00.000000+0000"}]`, nil
+			}
+			if args[0] == "osd" && args[1] == "blocklist" {
+				commands = append(commands, strings.Join(args[2:4], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []cephv1.ClientBlocklistEntry{
+		{Address: "192.168.1.10"},
+		{Address: "10.0.1.0/24"},
+		{Address: "192.168.1.11"},
+		{Address: "192.168.1.12"},
+	}
+
+	status, _, err := syncBlocklist(context, clusterInfo, entries, nil, nil, now)
+	require.NoError(t, err)
+	require.Len(t, status, 4)
+	assert.True(t, status[0].PreExisting)
+	assert.True(t, status[1].PreExisting)
+	assert.False(t, status[2].PreExisting)
+	assert.False(t, status[3].PreExisting)
+
+	// only the address added by the entries and not listed by another blocklist is removed
+	commands = nil
+	status, _, err = syncBlocklist(context, clusterInfo, nil, status, sets.New("192.168.1.12"), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rm 192.168.1.11"}, commands)
+	assert.Empty(t, status)
+}
+
+func TestBlocklistedForm(t *testing.T) {
+	assert.Equal(t, "192.168.1.10:0/0", blocklistedForm("192.168.1.10"))
+	assert.Equal(t, "10.0.0.0:0/24", blocklistedForm("10.0.0.0/24"))
+	assert.Equal(t, "[fd00::1]:0/0", blocklistedForm("fd00::1"))
+	assert.Equal(t, "192.168.1.10:6789/1234", blocklistedForm("192.168.1.10:6789/1234"))
+}
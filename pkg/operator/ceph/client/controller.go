@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +51,10 @@ import (
 
 const (
 	controllerName = "ceph-client-controller"
+
+	// the reasons of the events recorded as the audit record of the credentials with a TTL
+	credentialIssuedReason  = "CredentialIssued"
+	credentialRevokedReason = "CredentialRevoked"
 )
 
 var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
@@ -127,6 +132,18 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch the client templates to keep the clients created from them in sync
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephClientTemplate{TypeMeta: metav1.TypeMeta{Kind: "CephClientTemplate", APIVersion: controllerTypeMeta.APIVersion}},
+			handler.TypedEnqueueRequestsFromMapFunc(mapTemplateToClients(mgr.GetClient())),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -221,6 +238,25 @@ func (r *ReconcileCephClient) reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, *cephClient, nil
 	}
 
+	// revoke the credential of the client once its TTL lapsed
+	if clientExpired(cephClient, time.Now()) {
+		if cephClient.Status == nil || cephClient.Status.RevokedAt == nil {
+			err = r.revokeClient(cephClient)
+			if err != nil {
+				return reconcile.Result{}, *cephClient, errors.Wrapf(err, "failed to revoke expired client %q", cephClient.Name)
+			}
+		}
+		r.updateStatus(observedGeneration, request.NamespacedName, cephv1.ConditionExpired)
+		return reconcile.Result{}, *cephClient, nil
+	}
+
+	// expand the template of the client, if any
+	cephClient.Spec.Caps, err = r.resolveClientCaps(cephClient)
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionFailure)
+		return reconcile.Result{}, *cephClient, errors.Wrapf(err, "failed to resolve the caps of client %q", cephClient.Name)
+	}
+
 	// validate the client settings
 	err = ValidateClient(r.context, cephClient)
 	if err != nil {
@@ -242,6 +278,12 @@ func (r *ReconcileCephClient) reconcile(request reconcile.Request) (reconcile.Re
 	// Success! Let's update the status
 	r.updateStatus(observedGeneration, request.NamespacedName, cephv1.ConditionReady)
 
+	// Requeue to revoke the credential when its TTL lapses
+	if expiration := clientExpiration(cephClient); expiration != nil {
+		logger.Debugf("done reconciling, client %q expires at %s", cephClient.Name, expiration.String())
+		return reconcile.Result{RequeueAfter: time.Until(expiration.Time)}, *cephClient, nil
+	}
+
 	// Return and do not requeue
 	logger.Debug("done reconciling")
 	return reconcile.Result{}, *cephClient, nil
@@ -261,6 +303,10 @@ func (r *ReconcileCephClient) createOrUpdateClient(cephClient *cephv1.CephClient
 		if err != nil {
 			return errors.Wrapf(err, "failed to create client %q", cephClient.Name)
 		}
+		if expiration := clientExpiration(cephClient); expiration != nil {
+			logger.Infof("issued credential %q with caps %v expiring at %s", clientEntity, caps, expiration.String())
+			r.recorder.Eventf(cephClient, v1.EventTypeNormal, credentialIssuedReason, "issued credential %q with caps %v expiring at %s", clientEntity, caps, expiration.String())
+		}
 	} else {
 		err = cephclient.AuthUpdateCaps(r.context, r.clusterInfo, clientEntity, caps)
 		if err != nil {
@@ -337,6 +383,45 @@ func (r *ReconcileCephClient) deleteClient(cephClient *cephv1.CephClient) error
 	return nil
 }
 
+// clientExpiration returns the time the credential of the client expires, or nil if the client has
+// no TTL
+func clientExpiration(cephClient *cephv1.CephClient) *metav1.Time {
+	if cephClient.Spec.TTL == nil {
+		return nil
+	}
+	return &metav1.Time{Time: cephClient.CreationTimestamp.Add(cephClient.Spec.TTL.Duration)}
+}
+
+// clientExpired returns whether the TTL of the client lapsed. A revoked client is not issued again,
+// even if its TTL is extended.
+func clientExpired(cephClient *cephv1.CephClient, now time.Time) bool {
+	if cephClient.Status != nil && cephClient.Status.RevokedAt != nil {
+		return true
+	}
+	expiration := clientExpiration(cephClient)
+	return expiration != nil && !now.Before(expiration.Time)
+}
+
+// revokeClient deletes the cephx key and the secret of the client whose TTL lapsed
+func (r *ReconcileCephClient) revokeClient(cephClient *cephv1.CephClient) error {
+	clientEntity := generateClientName(cephClient.Name)
+	if err := cephclient.AuthDelete(r.context, r.clusterInfo, clientEntity); err != nil {
+		return errors.Wrapf(err, "failed to revoke client %q", cephClient.Name)
+	}
+
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: generateCephUserSecretName(cephClient), Namespace: cephClient.Namespace}}
+	if err := controllerutil.SetControllerReference(cephClient, secret, r.scheme); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on secret %q", secret.Name)
+	}
+	if err := k8sutil.DeleteSecretIfOwnedBy(r.clusterInfo.Context, r.context.Clientset, secret.Name, secret.Namespace, *metav1.GetControllerOf(secret)); err != nil {
+		return errors.Wrapf(err, "failed to delete the secret of client %q", cephClient.Name)
+	}
+
+	logger.Infof("revoked credential %q after its TTL %s lapsed", clientEntity, cephClient.Spec.TTL.Duration.String())
+	r.recorder.Eventf(cephClient, v1.EventTypeNormal, credentialRevokedReason, "revoked credential %q after its TTL %s lapsed", clientEntity, cephClient.Spec.TTL.Duration.String())
+	return nil
+}
+
 // ValidateClient the client arguments
 func ValidateClient(context *clusterd.Context, cephClient *cephv1.CephClient) error {
 	// Validate name
@@ -393,6 +478,14 @@ func (r *ReconcileCephClient) updateStatus(observedGeneration int64, name types.
 	if cephClient.Status.Phase == cephv1.ConditionReady {
 		cephClient.Status.Info = generateStatusInfo(cephClient)
 	}
+	cephClient.Status.ExpiresAt = clientExpiration(cephClient)
+	if cephClient.Status.Phase == cephv1.ConditionExpired {
+		// the secret of the revoked client is deleted
+		cephClient.Status.Info = nil
+		if cephClient.Status.RevokedAt == nil {
+			cephClient.Status.RevokedAt = &metav1.Time{Time: time.Now()}
+		}
+	}
 	if observedGeneration != k8sutil.ObservedGenerationNotAvailable {
 		cephClient.Status.ObservedGeneration = observedGeneration
 	}
@@ -21,8 +21,10 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
 	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
@@ -34,6 +36,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -305,6 +308,97 @@ func TestCephClientController(t *testing.T) {
 	assert.Contains(t, cephClientSecret.StringData, "adminKey")
 }
 
+func TestCephClientExpiration(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	cephClient := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "break-glass",
+			Namespace:         namespace,
+			UID:               types.UID("c47cac40-9bee-4d52-823b-ccd803ba5bfe"),
+			Finalizers:        []string{"cephclient.ceph.rook.io"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: cephv1.ClientSpec{
+			Caps: map[string]string{"mon": "allow *", "osd": "allow *", "mgr": "allow *"},
+			TTL:  &metav1.Duration{Duration: 2 * time.Hour},
+		},
+		Status: &cephv1.CephClientStatus{},
+	}
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Status: cephv1.ClusterStatus{
+			Phase:       cephv1.ConditionReady,
+			CephVersion: &cephv1.ClusterVersion{Version: "14.2.9-0"},
+			CephStatus:  &cephv1.CephStatus{Health: "HEALTH_OK"},
+		},
+	}
+
+	deleted := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "auth" && args[1] == "get-key" {
+				return "", errors.New("not found")
+			}
+			if args[0] == "auth" && args[1] == "get-or-create-key" {
+				return `{"key":"AQCvzWBeIV9lFRAAninzm+8XFxbSfTiPwoX50g=="}`, nil
+			}
+			if args[0] == "auth" && args[1] == "del" {
+				deleted = append(deleted, args[2])
+			}
+			return "", nil
+		},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephClient{}, &cephv1.CephCluster{}, &cephv1.CephClusterList{})
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(cephClient, cephCluster).WithStatusSubresource(cephClient).Build()
+	c := &clusterd.Context{Executor: executor, Clientset: testop.New(t, 1), Client: cl}
+	_, err := c.Clientset.CoreV1().Secrets(namespace).Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon", Namespace: namespace},
+		Data:       map[string][]byte{"fsid": []byte("fsid"), "mon-secret": []byte("monsecret"), "admin-secret": []byte("adminsecret")},
+		Type:       k8sutil.RookType,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	recorder := record.NewFakeRecorder(10)
+	r := &ReconcileCephClient{client: cl, scheme: s, context: c, opManagerContext: ctx, recorder: recorder}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: cephClient.Name, Namespace: namespace}}
+
+	// the credential is issued and the client is reconciled again when it expires
+	res, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour.Seconds(), res.RequeueAfter.Seconds(), 60)
+	require.NoError(t, cl.Get(ctx, req.NamespacedName, cephClient))
+	assert.Equal(t, cephv1.ConditionReady, cephClient.Status.Phase)
+	require.NotNil(t, cephClient.Status.ExpiresAt)
+	assert.Nil(t, cephClient.Status.RevokedAt)
+	assert.Contains(t, <-recorder.Events, credentialIssuedReason)
+	_, err = c.Clientset.CoreV1().Secrets(namespace).Get(ctx, "rook-ceph-client-break-glass", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// the credential is revoked once the ttl lapsed
+	cephClient.Spec.TTL = &metav1.Duration{Duration: 30 * time.Minute}
+	require.NoError(t, cl.Update(ctx, cephClient))
+	res, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Equal(t, []string{"client.break-glass"}, deleted)
+	require.NoError(t, cl.Get(ctx, req.NamespacedName, cephClient))
+	assert.Equal(t, cephv1.ConditionExpired, cephClient.Status.Phase)
+	assert.NotNil(t, cephClient.Status.RevokedAt)
+	assert.Empty(t, cephClient.Status.Info)
+	_, err = c.Clientset.CoreV1().Secrets(namespace).Get(ctx, "rook-ceph-client-break-glass", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// the revoked client is not issued again when its ttl is extended
+	cephClient.Spec.TTL = &metav1.Duration{Duration: 24 * time.Hour}
+	require.NoError(t, cl.Update(ctx, cephClient))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	require.NoError(t, cl.Get(ctx, req.NamespacedName, cephClient))
+	assert.Equal(t, cephv1.ConditionExpired, cephClient.Status.Phase)
+}
+
 func TestBuildUpdateStatusInfo(t *testing.T) {
 	cephClient := &cephv1.CephClient{
 		ObjectMeta: metav1.ObjectMeta{
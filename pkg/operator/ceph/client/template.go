@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// templateParameter matches the $(name) references to the client parameters in the template caps
+var templateParameter = regexp.MustCompile(`\$\(([a-zA-Z0-9_-]+)\)`)
+
+// mapTemplateToClients enqueues the clients referencing a template so they are kept in sync with it
+func mapTemplateToClients(k8sClient client.Client) handler.TypedMapFunc[*cephv1.CephClientTemplate, reconcile.Request] {
+	return func(ctx context.Context, template *cephv1.CephClientTemplate) []reconcile.Request {
+		cephClients := cephv1.CephClientList{}
+		err := k8sClient.List(ctx, &cephClients, client.InNamespace(template.Namespace))
+		if err != nil {
+			logger.Errorf("failed to list ceph clients for template %q. %v", template.Name, err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cephClient := range cephClients.Items {
+			if cephClient.Spec.Template != nil && cephClient.Spec.Template.Name == template.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      cephClient.Name,
+						Namespace: cephClient.Namespace,
+					},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+// resolveClientCaps returns the caps of the client, expanding the template it references if any
+func (r *ReconcileCephClient) resolveClientCaps(cephClient *cephv1.CephClient) (map[string]string, error) {
+	if cephClient.Spec.Template == nil {
+		return cephClient.Spec.Caps, nil
+	}
+
+	template := &cephv1.CephClientTemplate{}
+	name := types.NamespacedName{Name: cephClient.Spec.Template.Name, Namespace: cephClient.Namespace}
+	if err := r.client.Get(r.opManagerContext, name, template); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ceph client template %q", name.Name)
+	}
+	return expandClientTemplate(template, cephClient)
+}
+
+// expandClientTemplate substitutes the client parameters in the template caps. The caps set on the
+// client override the template caps of the same entity.
+func expandClientTemplate(template *cephv1.CephClientTemplate, cephClient *cephv1.CephClient) (map[string]string, error) {
+	parameters := cephClient.Spec.Template.Parameters
+	caps := map[string]string{}
+	for entity, cap := range template.Spec.Caps {
+		var missing []string
+		caps[entity] = templateParameter.ReplaceAllStringFunc(cap, func(ref string) string {
+			name := templateParameter.FindStringSubmatch(ref)[1]
+			value, ok := parameters[name]
+			if !ok {
+				missing = append(missing, name)
+			}
+			return value
+		})
+		if len(missing) > 0 {
+			return nil, errors.Errorf("template %q requires the parameters %v for the %q caps", template.Name, missing, entity)
+		}
+	}
+	for entity, cap := range cephClient.Spec.Caps {
+		caps[entity] = cap
+	}
+	return caps, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExpandClientTemplate(t *testing.T) {
+	template := &cephv1.CephClientTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "rbd-tenant", Namespace: "rook-ceph"},
+		Spec: cephv1.ClientTemplateSpec{
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=$(pool) namespace=$(namespace)",
+			},
+		},
+	}
+	cephClient := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Namespace: "rook-ceph"},
+		Spec: cephv1.ClientSpec{
+			Template: &cephv1.ClientTemplateReference{
+				Name:       "rbd-tenant",
+				Parameters: map[string]string{"pool": "replicapool", "namespace": "tenant-a"},
+			},
+		},
+	}
+
+	t.Run("parameters substituted", func(t *testing.T) {
+		caps, err := expandClientTemplate(template, cephClient)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"mon": "profile rbd",
+			"osd": "profile rbd pool=replicapool namespace=tenant-a",
+		}, caps)
+	})
+
+	t.Run("client caps override the template", func(t *testing.T) {
+		c := cephClient.DeepCopy()
+		c.Spec.Caps = map[string]string{"mon": "allow r", "mgr": "profile rbd"}
+		caps, err := expandClientTemplate(template, c)
+		assert.NoError(t, err)
+		assert.Equal(t, "allow r", caps["mon"])
+		assert.Equal(t, "profile rbd", caps["mgr"])
+		assert.Equal(t, "profile rbd pool=replicapool namespace=tenant-a", caps["osd"])
+	})
+
+	t.Run("missing parameter", func(t *testing.T) {
+		c := cephClient.DeepCopy()
+		delete(c.Spec.Template.Parameters, "namespace")
+		_, err := expandClientTemplate(template, c)
+		assert.ErrorContains(t, err, "[namespace]")
+	})
+}
+
+func TestTemplateClients(t *testing.T) {
+	ctx := context.TODO()
+	template := &cephv1.CephClientTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "cephfs-tenant", Namespace: "rook-ceph"},
+		Spec: cephv1.ClientTemplateSpec{
+			Caps: map[string]string{"mds": "allow rw path=$(path)"},
+		},
+	}
+	fromTemplate := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Namespace: "rook-ceph"},
+		Spec: cephv1.ClientSpec{
+			Template: &cephv1.ClientTemplateReference{Name: "cephfs-tenant", Parameters: map[string]string{"path": "/volumes/a"}},
+		},
+	}
+	otherTemplate := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Namespace: "rook-ceph"},
+		Spec: cephv1.ClientSpec{
+			Template: &cephv1.ClientTemplateReference{Name: "other"},
+		},
+	}
+	noTemplate := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-c", Namespace: "rook-ceph"},
+		Spec:       cephv1.ClientSpec{Caps: map[string]string{"mon": "allow r"}},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephClient{}, &cephv1.CephClientList{}, &cephv1.CephClientTemplate{}, &cephv1.CephClientTemplateList{})
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(template, fromTemplate, otherTemplate, noTemplate).Build()
+	r := &ReconcileCephClient{client: cl, scheme: s, opManagerContext: ctx}
+
+	t.Run("clients referencing the template are enqueued", func(t *testing.T) {
+		requests := mapTemplateToClients(cl)(ctx, template)
+		require.Len(t, requests, 1)
+		assert.Equal(t, types.NamespacedName{Name: "tenant-a", Namespace: "rook-ceph"}, requests[0].NamespacedName)
+	})
+
+	t.Run("caps resolved from the template", func(t *testing.T) {
+		caps, err := r.resolveClientCaps(fromTemplate)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"mds": "allow rw path=/volumes/a"}, caps)
+
+		caps, err = r.resolveClientCaps(noTemplate)
+		assert.NoError(t, err)
+		assert.Equal(t, noTemplate.Spec.Caps, caps)
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		_, err := r.resolveClientCaps(otherTemplate)
+		assert.ErrorContains(t, err, `failed to get ceph client template "other"`)
+	})
+}
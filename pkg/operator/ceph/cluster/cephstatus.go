@@ -49,6 +49,8 @@ type cephStatusChecker struct {
 	interval    *time.Duration
 	client      client.Client
 	isExternal  bool
+	// whether the CephUnreachable condition was last reported as true
+	commandBreakerOpen bool
 }
 
 // newCephStatusChecker creates a new HealthChecker object
@@ -133,16 +135,20 @@ func (c *cephStatusChecker) checkStatus(ctx context.Context) {
 			message = "Failed to configure external ceph cluster"
 		}
 		status := cephStatusOnError(err.Error())
-		c.updateCephStatus(status, condition, reason, message, v1.ConditionFalse)
+		c.updateCephStatus(status, nil, condition, reason, message, v1.ConditionFalse)
+		c.reportCommandBreaker(ctx)
 		return
 	}
+	c.reportCommandBreaker(ctx)
 
 	logger.Debugf("cluster status: %+v", status)
 	message := "Cluster created successfully"
 	if c.isExternal {
 		message = "Cluster connected successfully"
 	}
-	c.updateCephStatus(&status, condition, reason, message, v1.ConditionTrue)
+	progressEvents := c.getProgressEvents(&status)
+	c.updateCephStatus(&status, progressEvents, condition, reason, message, v1.ConditionTrue)
+	c.reportProgress(ctx, progressEvents)
 
 	if status.Health.Status != "HEALTH_OK" {
 		logger.Debug("checking for stuck pods on not ready nodes")
@@ -152,6 +158,32 @@ func (c *cephStatusChecker) checkStatus(ctx context.Context) {
 	}
 
 	c.configureHealthSettings(status)
+
+	if !c.isExternal {
+		if err := expireDebugLogging(c.context, c.clusterInfo); err != nil {
+			logger.Errorf("failed to check the expiration of the debug logging. %v", err)
+		}
+	}
+}
+
+// reportCommandBreaker sets the CephUnreachable condition on the CephCluster when the ceph commands
+// breaker of the cluster opens, and clears it when the breaker closes
+func (c *cephStatusChecker) reportCommandBreaker(ctx context.Context) {
+	open := cephclient.IsCommandBreakerOpen(c.clusterInfo.Namespace)
+	if open == c.commandBreakerOpen {
+		return
+	}
+	c.commandBreakerOpen = open
+
+	status := v1.ConditionFalse
+	reason := cephv1.CephCommandsSucceedingReason
+	message := "Ceph commands reach the cluster"
+	if open {
+		status = v1.ConditionTrue
+		reason = cephv1.CephCommandsFailingReason
+		message = "Ceph commands keep failing to reach the cluster, the controllers not critical to the cluster health reconcile less often"
+	}
+	opcontroller.UpdateCondition(ctx, c.context, c.clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionCephUnreachable, status, reason, message)
 }
 
 func (c *cephStatusChecker) configureHealthSettings(status cephclient.CephStatus) {
@@ -172,7 +204,7 @@ func (c *cephStatusChecker) configureHealthSettings(status cephclient.CephStatus
 }
 
 // updateCephStatus updates an object with a given status
-func (c *cephStatusChecker) updateCephStatus(status *cephclient.CephStatus, condition cephv1.ConditionType, reason cephv1.ConditionReason, message string, conditionStatus v1.ConditionStatus) {
+func (c *cephStatusChecker) updateCephStatus(status *cephclient.CephStatus, progressEvents []progressEvent, condition cephv1.ConditionType, reason cephv1.ConditionReason, message string, conditionStatus v1.ConditionStatus) {
 	clusterName := c.clusterInfo.NamespacedName()
 	cephCluster, err := c.context.RookClientset.CephV1().CephClusters(clusterName.Namespace).Get(c.clusterInfo.Context, clusterName.Name, metav1.GetOptions{})
 	if err != nil {
@@ -186,6 +218,8 @@ func (c *cephStatusChecker) updateCephStatus(status *cephclient.CephStatus, cond
 
 	// Update with Ceph Status
 	cephCluster.Status.CephStatus = toCustomResourceStatus(cephCluster.Status, status)
+	cephCluster.Status.CephStatus.Progress = progressSummary(progressEvents)
+	cephCluster.Status.CephStatus.ProgressEvents = progressEventStatuses(progressEvents)
 
 	// versions store the ceph version of all the ceph daemons and overall cluster version
 	versions, err := cephclient.GetAllCephDaemonVersions(c.context, c.clusterInfo)
@@ -35,6 +35,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestCephStatus(t *testing.T) {
@@ -146,9 +148,9 @@ func TestNewCephStatusChecker(t *testing.T) {
 		args args
 		want *cephStatusChecker
 	}{
-		{"default-interval", args{c, clusterInfo, &cephv1.ClusterSpec{}}, &cephStatusChecker{c, clusterInfo, &defaultStatusCheckInterval, c.Client, false}},
-		{"10s-interval", args{c, clusterInfo, &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Status: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}}, &cephStatusChecker{c, clusterInfo, &time10s, c.Client, false}},
-		{"10s-interval-external", args{c, clusterInfo, &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}, HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Status: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}}, &cephStatusChecker{c, clusterInfo, &time10s, c.Client, true}},
+		{"default-interval", args{c, clusterInfo, &cephv1.ClusterSpec{}}, &cephStatusChecker{c, clusterInfo, &defaultStatusCheckInterval, c.Client, false, false}},
+		{"10s-interval", args{c, clusterInfo, &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Status: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}}, &cephStatusChecker{c, clusterInfo, &time10s, c.Client, false, false}},
+		{"10s-interval-external", args{c, clusterInfo, &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}, HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Status: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}}, &cephStatusChecker{c, clusterInfo, &time10s, c.Client, true, false}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -159,6 +161,55 @@ func TestNewCephStatusChecker(t *testing.T) {
 	}
 }
 
+func TestReportCommandBreaker(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("breaker-ns")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace},
+		Status: cephv1.ClusterStatus{
+			Phase: cephv1.ConditionReady,
+			Conditions: []cephv1.Condition{
+				{Type: cephv1.ConditionReady, Status: v1.ConditionTrue, Reason: cephv1.ClusterCreatedReason},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	c := &clusterd.Context{Client: client}
+	checker := &cephStatusChecker{context: c, clusterInfo: clusterInfo}
+
+	getCondition := func() *cephv1.Condition {
+		cluster := &cephv1.CephCluster{}
+		assert.NoError(t, client.Get(ctx, nsName, cluster))
+		assert.Equal(t, cephv1.ConditionReady, cluster.Status.Phase)
+		return cephv1.FindStatusCondition(cluster.Status.Conditions, cephv1.ConditionCephUnreachable)
+	}
+
+	t.Run("closed breaker is not reported", func(t *testing.T) {
+		checker.reportCommandBreaker(ctx)
+		assert.Nil(t, getCondition())
+	})
+
+	t.Run("open breaker sets the condition", func(t *testing.T) {
+		openCommandBreaker(t, nsName.Namespace)
+		checker.reportCommandBreaker(ctx)
+		condition := getCondition()
+		assert.NotNil(t, condition)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, cephv1.CephCommandsFailingReason, condition.Reason)
+	})
+
+	t.Run("closed breaker clears the condition", func(t *testing.T) {
+		checker.reportCommandBreaker(ctx)
+		condition := getCondition()
+		assert.NotNil(t, condition)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, cephv1.CephCommandsSucceedingReason, condition.Reason)
+	})
+}
+
 func TestConfigureHealthSettings(t *testing.T) {
 	c := &cephStatusChecker{
 		context:     &clusterd.Context{},
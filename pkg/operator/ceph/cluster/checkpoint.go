@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// creationPhases are the phases of the creation of the cluster, in order
+var creationPhases = []cephv1.ClusterCreationPhase{
+	cephv1.ClusterCreationPhaseMons,
+	cephv1.ClusterCreationPhaseMgr,
+	cephv1.ClusterCreationPhaseOSDs,
+	cephv1.ClusterCreationPhaseAuxDaemons,
+	cephv1.ClusterCreationPhaseCSI,
+}
+
+// loadCreationStatus loads the progress of the creation of the cluster from the CephCluster status
+func (c *cluster) loadCreationStatus() error {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.namespacedName, cephCluster); err != nil {
+		return errors.Wrapf(err, "failed to get cluster %q to load the progress of its creation", c.namespacedName.Name)
+	}
+	c.creation = cephCluster.Status.Creation
+	return nil
+}
+
+// creationPhaseCompleted returns whether the phase and all the phases before it were completed for
+// the current generation of the cluster and ceph version. Once the creation is completed, the phases
+// are always run.
+func (c *cluster) creationPhaseCompleted(phase cephv1.ClusterCreationPhase, cephVersion cephver.CephVersion) bool {
+	if c.creation == nil || c.creation.CompletionTime != nil {
+		return false
+	}
+	index := slices.Index(creationPhases, phase)
+	if index < 0 || len(c.creation.Checkpoints) <= index {
+		return false
+	}
+	for i, checkpoint := range c.creation.Checkpoints[:index+1] {
+		if checkpoint.Phase != creationPhases[i] || checkpoint.ObservedGeneration != c.observedGeneration || checkpoint.CephVersion != cephVersion.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkpointCreationPhase records the completion of the phase in the CephCluster status. The
+// checkpoints of the phases after it are dropped since they must be run again after it, and the
+// creation is completed with its last phase.
+func (c *cluster) checkpointCreationPhase(phase cephv1.ClusterCreationPhase, cephVersion cephver.CephVersion) {
+	if c.creation != nil && c.creation.CompletionTime != nil {
+		return
+	}
+	if c.creationPhaseCompleted(phase, cephVersion) {
+		return
+	}
+
+	creation := &cephv1.ClusterCreationStatus{}
+	index := slices.Index(creationPhases, phase)
+	for i := 0; i < index; i++ {
+		if !c.creationPhaseCompleted(creationPhases[i], cephVersion) {
+			break
+		}
+		creation.Checkpoints = append(creation.Checkpoints, c.creation.Checkpoints[i])
+	}
+	if len(creation.Checkpoints) < index {
+		// a phase was run out of order, it is recorded once the phases before it are completed
+		logger.Debugf("not recording the completion of the %s phase of the creation of cluster %q before the phases before it", phase, c.namespacedName.Name)
+		return
+	}
+	now := metav1.NewTime(time.Now().UTC())
+	creation.Checkpoints = append(creation.Checkpoints, cephv1.ClusterCreationCheckpoint{
+		Phase:              phase,
+		ObservedGeneration: c.observedGeneration,
+		CephVersion:        cephVersion.String(),
+		CompletionTime:     now,
+	})
+	if index == len(creationPhases)-1 {
+		creation.CompletionTime = &now
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.namespacedName, cephCluster); err != nil {
+		logger.Warningf("failed to get cluster %q to record the completion of the %s phase of its creation. %v", c.namespacedName.Name, phase, err)
+		return
+	}
+	cephCluster.Status.Creation = creation
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to record the completion of the %s phase of the creation of cluster %q. %v", phase, c.namespacedName.Name, err)
+		return
+	}
+	c.creation = creation
+	if creation.CompletionTime != nil {
+		logger.Infof("completed the creation of cluster %q", c.namespacedName.Name)
+	} else {
+		logger.Infof("completed the %s phase of the creation of cluster %q", phase, c.namespacedName.Name)
+	}
+}
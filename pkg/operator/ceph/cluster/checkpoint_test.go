@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreationCheckpoints(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace, Generation: 1}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+
+	newTestCluster := func(generation int64) *cluster {
+		c := &cluster{
+			ClusterInfo:        clusterInfo,
+			context:            &clusterd.Context{Client: client},
+			namespacedName:     nsName,
+			observedGeneration: generation,
+		}
+		require.NoError(t, c.loadCreationStatus())
+		return c
+	}
+	reef := cephver.Reef
+	squid := cephver.Squid
+
+	c := newTestCluster(1)
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, reef))
+
+	c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMons, reef)
+	c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMgr, reef)
+	// a phase is not recorded before the phases before it are completed
+	c.checkpointCreationPhase(cephv1.ClusterCreationPhaseAuxDaemons, reef)
+
+	// the checkpoints are resumed by the next orchestration
+	c = newTestCluster(1)
+	assert.True(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, reef))
+	assert.True(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMgr, reef))
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseOSDs, reef))
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseAuxDaemons, reef))
+
+	// the phases are run again when the ceph version or the generation changed
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, squid))
+	assert.False(t, newTestCluster(2).creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, reef))
+
+	// running a phase again drops the checkpoints of the phases after it
+	c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMons, squid)
+	c = newTestCluster(1)
+	assert.True(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, squid))
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMgr, squid))
+	assert.Len(t, c.creation.Checkpoints, 1)
+
+	for _, phase := range creationPhases[1:] {
+		c.checkpointCreationPhase(phase, squid)
+	}
+	assert.NotNil(t, c.creation.CompletionTime)
+
+	// the phases are not skipped anymore once the creation is completed
+	c = newTestCluster(1)
+	assert.Len(t, c.creation.Checkpoints, len(creationPhases))
+	assert.False(t, c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, squid))
+	c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMons, squid)
+
+	stored := &cephv1.CephCluster{}
+	require.NoError(t, client.Get(ctx, nsName, stored))
+	assert.Len(t, stored.Status.Creation.Checkpoints, len(creationPhases))
+	assert.NotNil(t, stored.Status.Creation.CompletionTime)
+}
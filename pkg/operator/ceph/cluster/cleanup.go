@@ -260,5 +260,15 @@ func (c *ClusterController) getCleanUpDetails(cephClusterSpec *cephv1.ClusterSpe
 		return "", "", errors.Wrap(err, "failed to get cluster info")
 	}
 
-	return clusterInfo.MonitorSecret, clusterInfo.FSID, nil
+	// the mon dirs created before the mon key was rotated are cleaned up with the previous keys
+	monSecret := clusterInfo.MonitorSecret
+	secret, err := c.context.Clientset.CoreV1().Secrets(namespace).Get(c.OpManagerCtx, opcontroller.AppName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get the mon secrets")
+	}
+	if previousKeys := string(secret.Data[opcontroller.MonPreviousSecretsNameKey]); previousKeys != "" {
+		monSecret = strings.Join([]string{monSecret, previousKeys}, ",")
+	}
+
+	return monSecret, clusterInfo.FSID, nil
 }
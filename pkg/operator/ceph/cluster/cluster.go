@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
 	"os"
 	"os/exec"
 	"path"
+	"slices"
 	"strconv"
 	"sync"
 	"syscall"
@@ -50,6 +52,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -70,6 +73,8 @@ type cluster struct {
 	isUpgrade          bool
 	monitoringRoutines map[string]*controller.ClusterHealth
 	observedGeneration int64
+	creation           *cephv1.ClusterCreationStatus
+	recorder           record.EventRecorder
 }
 
 func newCluster(ctx context.Context, c *cephv1.CephCluster, context *clusterd.Context, ownerInfo *k8sutil.OwnerInfo) *cluster {
@@ -101,20 +106,42 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 		return errors.Wrap(err, "failed to populate config override config map")
 	}
 	c.ClusterInfo.SetName(c.namespacedName.Name)
+	k8sutil.SetRestartBudget(c.Namespace, c.Spec.DaemonRestartBudget)
+	setDisruptionBudget(c.Namespace, c.Spec.DisruptionManagement.DisruptionBudget)
 
-	// Execute actions before the monitors are up and running, if needed during upgrades.
-	// These actions would be skipped in a new cluster.
-	logger.Debug("monitors are about to reconcile, executing pre actions")
-	err = c.preMonStartupActions(cephVersion)
-	if err != nil {
-		return errors.Wrap(err, "failed to execute actions before reconciling the ceph monitors")
+	// Generate the network policies before any daemon is started so they are never left unprotected
+	if err := c.reconcileNetworkPolicies(); err != nil {
+		return errors.Wrap(err, "failed to reconcile network policies")
 	}
 
-	// Start the mon pods
-	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mons")
-	clusterInfo, err := c.mons.Start(c.ClusterInfo, rookImage, cephVersion, *c.Spec)
-	if err != nil {
-		return errors.Wrap(err, "failed to start ceph monitors")
+	// The creation of the cluster is resumed from its last completed phase
+	if err := c.loadCreationStatus(); err != nil {
+		return err
+	}
+
+	var clusterInfo *client.ClusterInfo
+	monsCreated := c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMons, cephVersion)
+	if monsCreated {
+		logger.Infof("skipping the mons phase already completed in the creation of cluster %q", c.namespacedName.Name)
+		clusterInfo, err = c.mons.Init(c.ClusterInfo, rookImage, cephVersion, *c.Spec)
+		if err != nil {
+			return errors.Wrap(err, "failed to initialize the cluster info")
+		}
+	} else {
+		// Execute actions before the monitors are up and running, if needed during upgrades.
+		// These actions would be skipped in a new cluster.
+		logger.Debug("monitors are about to reconcile, executing pre actions")
+		err = c.preMonStartupActions(cephVersion)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute actions before reconciling the ceph monitors")
+		}
+
+		// Start the mon pods
+		controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mons")
+		clusterInfo, err = c.mons.Start(c.ClusterInfo, rookImage, cephVersion, *c.Spec)
+		if err != nil {
+			return errors.Wrap(err, "failed to start ceph monitors")
+		}
 	}
 	clusterInfo.OwnerInfo = c.ownerInfo
 	clusterInfo.SetName(c.namespacedName.Name)
@@ -131,41 +158,69 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 		return c.ClusterInfo.Context.Err()
 	}
 
-	// Execute actions after the monitors are up and running
-	logger.Debug("monitors are up and running, executing post actions")
-	err = c.postMonStartupActions()
-	if err != nil {
-		return errors.Wrap(err, "failed to execute post actions after all the ceph monitors started")
+	if !monsCreated {
+		// Execute actions after the monitors are up and running
+		logger.Debug("monitors are up and running, executing post actions")
+		err = c.postMonStartupActions()
+		if err != nil {
+			return errors.Wrap(err, "failed to execute post actions after all the ceph monitors started")
+		}
+		c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMons, cephVersion)
 	}
 
-	// Start Ceph manager
-	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mgr(s)")
-	mgrs := mgr.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
-	err = mgrs.Start()
-	if err != nil {
-		return errors.Wrap(err, "failed to start ceph mgr")
+	// The preview only places a canary of the replacement mon, the mons are not changed
+	if err := c.mons.PreviewFailoverIfRequested(c.clusterMetadata.Annotations); err != nil {
+		logger.Warningf("failed to preview the mon failover. %v", err)
 	}
 
-	// Execute actions after the managers are up and running
-	logger.Debug("managers are up and running, executing post actions")
-	err = c.postMgrStartupActions()
-	if err != nil {
-		return errors.Wrap(err, "failed to execute post actions after all the ceph managers started")
+	if c.creationPhaseCompleted(cephv1.ClusterCreationPhaseMgr, cephVersion) {
+		logger.Infof("skipping the mgr phase already completed in the creation of cluster %q", c.namespacedName.Name)
+	} else {
+		// Start Ceph manager
+		controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mgr(s)")
+		mgrs := mgr.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
+		err = mgrs.Start()
+		if err != nil {
+			return errors.Wrap(err, "failed to start ceph mgr")
+		}
+
+		// Execute actions after the managers are up and running
+		logger.Debug("managers are up and running, executing post actions")
+		err = c.postMgrStartupActions()
+		if err != nil {
+			return errors.Wrap(err, "failed to execute post actions after all the ceph managers started")
+		}
+		c.checkpointCreationPhase(cephv1.ClusterCreationPhaseMgr, cephVersion)
 	}
 
-	// Start the OSDs
-	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph OSDs")
-	osds := osd.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
-	err = osds.Start()
-	if err != nil {
-		return errors.Wrap(err, "failed to start ceph osds")
+	if c.creationPhaseCompleted(cephv1.ClusterCreationPhaseOSDs, cephVersion) {
+		logger.Infof("skipping the osds phase already completed in the creation of cluster %q", c.namespacedName.Name)
+	} else {
+		// Start the OSDs
+		controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph OSDs")
+		osds := osd.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
+		err = osds.Start()
+		if err != nil {
+			return errors.Wrap(err, "failed to start ceph osds")
+		}
+		c.checkpointCreationPhase(cephv1.ClusterCreationPhaseOSDs, cephVersion)
 	}
 
-	// If a stretch cluster, enable the arbiter after the OSDs are created with the CRUSH map
-	if c.Spec.IsStretchCluster() {
-		if err := c.mons.ConfigureArbiter(); err != nil {
-			return errors.Wrap(err, "failed to configure stretch arbiter")
+	if c.creationPhaseCompleted(cephv1.ClusterCreationPhaseAuxDaemons, cephVersion) {
+		logger.Infof("skipping the aux daemons phase already completed in the creation of cluster %q", c.namespacedName.Name)
+	} else {
+		// If a stretch cluster, enable the arbiter after the OSDs are created with the CRUSH map
+		if c.Spec.IsStretchCluster() {
+			if err := c.mons.ConfigureArbiter(); err != nil {
+				return errors.Wrap(err, "failed to configure stretch arbiter")
+			}
 		}
+
+		// Generate the CephBlockPools of the pools created outside of rook that are requested for adoption
+		if err := c.reconcilePoolAdoption(); err != nil {
+			logger.Warningf("failed to reconcile the adoption of pools. %v", err)
+		}
+		c.checkpointCreationPhase(cephv1.ClusterCreationPhaseAuxDaemons, cephVersion)
 	}
 
 	logger.Infof("done reconciling ceph cluster in namespace %q", c.Namespace)
@@ -181,6 +236,15 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 	return nil
 }
 
+// setDisruptionBudget limits the disruptions that the operator runs at the same time in the cluster
+func setDisruptionBudget(namespace string, budget *cephv1.DisruptionBudgetSpec) {
+	if budget == nil {
+		k8sutil.SetDisruptionBudget(namespace, 0, nil)
+		return
+	}
+	k8sutil.SetDisruptionBudget(namespace, max(budget.MaxConcurrent, 1), budget.Priorities)
+}
+
 func (c *ClusterController) initializeCluster(cluster *cluster) error {
 	// Check if the dataDirHostPath is located in the disallowed paths list
 	cleanDataDirHostPath := path.Clean(cluster.Spec.DataDirHostPath)
@@ -234,6 +298,9 @@ func (c *ClusterController) initializeCluster(cluster *cluster) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to save CSI driver options")
 	}
+	if !cluster.Spec.External.Enable {
+		cluster.checkpointCreationPhase(cephv1.ClusterCreationPhaseCSI, cluster.ClusterInfo.CephVersion)
+	}
 
 	// Populate ClusterInfo with the last value
 	cluster.mons.ClusterInfo = cluster.ClusterInfo
@@ -298,11 +365,28 @@ func preClusterStartValidation(cluster *cluster) error {
 	if err := validateStretchCluster(cluster); err != nil {
 		return err
 	}
+	if err := validateMonZones(cluster.Spec.Mon); err != nil {
+		return err
+	}
+	if err := validateMonPorts(cluster.Spec); err != nil {
+		return err
+	}
+	if err := validateMonBindAddresses(cluster.Spec); err != nil {
+		return err
+	}
+
+	if err := csi.ValidateCSIPlacement(cluster.Spec.CSI); err != nil {
+		return err
+	}
 
 	if err := cephv1.ValidateNetworkSpec(cluster.Namespace, cluster.Spec.Network); err != nil {
 		return errors.Wrapf(err, "failed to validate network spec for cluster in namespace %q", cluster.Namespace)
 	}
 
+	if err := controller.ValidateMetricsTLS(cluster.Spec.Monitoring); err != nil {
+		return err
+	}
+
 	// Validate on-PVC cluster encryption KMS settings
 	if cluster.Spec.Storage.IsOnPVCEncrypted() && cluster.Spec.Security.KeyManagementService.IsEnabled() {
 		// Validate the KMS details
@@ -320,11 +404,8 @@ func validateStretchCluster(cluster *cluster) error {
 	if !cluster.Spec.IsStretchCluster() {
 		return nil
 	}
-	if len(cluster.Spec.Mon.StretchCluster.Zones) != 3 {
-		return errors.Errorf("expecting exactly three zones for the stretch cluster, but found %d", len(cluster.Spec.Mon.StretchCluster.Zones))
-	}
-	if cluster.Spec.Mon.Count != 3 && cluster.Spec.Mon.Count != 5 {
-		return errors.Errorf("invalid number of mons %d for a stretch cluster, expecting 5 (recommended) or 3 (minimal)", cluster.Spec.Mon.Count)
+	if len(cluster.Spec.Mon.StretchCluster.Zones) < 3 {
+		return errors.Errorf("expecting at least three zones for the stretch cluster, but found %d", len(cluster.Spec.Mon.StretchCluster.Zones))
 	}
 	arbitersFound := 0
 	for _, zone := range cluster.Spec.Mon.StretchCluster.Zones {
@@ -338,6 +419,93 @@ func validateStretchCluster(cluster *cluster) error {
 	if arbitersFound != 1 {
 		return errors.Errorf("expecting to find exactly one arbiter zone, but found %d", arbitersFound)
 	}
+	// the pools have two replicas in each data zone, within the max size of the stretch CRUSH rule
+	dataZones := cluster.Spec.StretchDataZoneCount()
+	if dataZones > client.StretchMaxDataZones {
+		return errors.Errorf("expecting at most %d data zones for the stretch cluster, but found %d", client.StretchMaxDataZones, dataZones)
+	}
+	// the data zones have the same number of mons, one or two, plus the mon of the arbiter zone
+	validCounts := stretchMonCounts(dataZones)
+	if !slices.Contains(validCounts, cluster.Spec.Mon.Count) {
+		if len(validCounts) == 1 {
+			return errors.Errorf("invalid number of mons %d for a stretch cluster with %d data zones, expecting %d", cluster.Spec.Mon.Count, dataZones, validCounts[0])
+		}
+		return errors.Errorf("invalid number of mons %d for a stretch cluster with %d data zones, expecting %d (recommended) or %d (minimal)", cluster.Spec.Mon.Count, dataZones, validCounts[0], validCounts[1])
+	}
+	return nil
+}
+
+// stretchMonCounts returns the odd mon counts of a stretch cluster with two or one mons in each data
+// zone and a mon in the arbiter zone, the recommended count first
+func stretchMonCounts(dataZones int) []int {
+	var counts []int
+	for _, monsPerZone := range []int{2, 1} {
+		if count := monsPerZone*dataZones + 1; count%2 == 1 {
+			counts = append(counts, count)
+		}
+	}
+	return counts
+}
+
+// validateMonZones checks that at least one zone allows its mons to be elected leader
+func validateMonZones(monSpec cephv1.MonSpec) error {
+	zones := monSpec.Zones
+	if monSpec.StretchCluster != nil && len(monSpec.StretchCluster.Zones) > 0 {
+		zones = monSpec.StretchCluster.Zones
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+	for _, zone := range zones {
+		// the arbiter of a stretch cluster is never the leader
+		if !zone.Arbiter && !zone.DisallowLeader {
+			return nil
+		}
+	}
+	return errors.New("the mons of every zone are disallowed as leader, at least one zone must allow its mons to be elected leader")
+}
+
+// validateMonPorts checks that the mons do not listen on the same port for both messenger protocols
+func validateMonPorts(spec *cephv1.ClusterSpec) error {
+	if spec.Mon.Ports == nil {
+		return nil
+	}
+	if !spec.Network.IsHost() {
+		logger.Warning("the mon ports only apply to the mons on the host network, the mons on the pod network keep the default ports")
+		return nil
+	}
+	if !spec.RequireMsgr2() && mon.Msgr1Port(spec) == mon.Msgr2Port(spec) {
+		return errors.Errorf("the mons cannot listen on the same port %d for msgr1 and msgr2", mon.Msgr2Port(spec))
+	}
+	return nil
+}
+
+// validateMonBindAddresses checks the networks of the mon bind addresses
+func validateMonBindAddresses(spec *cephv1.ClusterSpec) error {
+	bindAddresses := map[string]*cephv1.MonBindAddressesSpec{"": spec.Mon.BindAddresses}
+	for name, override := range spec.Mon.Overrides {
+		bindAddresses[name] = override.BindAddresses
+	}
+	for name, addresses := range bindAddresses {
+		if addresses == nil {
+			continue
+		}
+		if !spec.Network.IsHost() {
+			logger.Warning("the mon bind addresses only apply to the mons on the host network")
+			return nil
+		}
+		for _, selector := range []*cephv1.MonAddressSelector{addresses.Public, addresses.Cluster} {
+			if selector == nil || selector.CIDR == "" {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(selector.CIDR); err != nil {
+				if name == "" {
+					return errors.Wrapf(err, "invalid cidr %q of the mon bind addresses", selector.CIDR)
+				}
+				return errors.Wrapf(err, "invalid cidr %q of the bind addresses of mon override %q", selector.CIDR, name)
+			}
+		}
+	}
 	return nil
 }
 
@@ -576,9 +744,25 @@ func (c *cluster) updateConfigStoreFromCRD() error {
 	if err := monStore.SetAllMultiple(cephConfigFromSecret); err != nil {
 		return err
 	}
-	if err := monStore.SetAllMultiple(c.Spec.CephConfig); err != nil {
+	// the osd section is applied separately since it may be rolled out through a canary osd
+	cephConfig := map[string]map[string]string{}
+	for who, options := range c.Spec.CephConfig {
+		if who != "osd" {
+			cephConfig[who] = options
+		}
+	}
+	if err := monStore.SetAllMultiple(cephConfig); err != nil {
 		return err
 	}
+	if err := osd.ApplyOSDConfig(c.context, c.ClusterInfo, c.Spec.CephConfig["osd"], c.Spec.CephConfigRollout); err != nil {
+		return errors.Wrap(err, "failed to apply the osd config")
+	}
+	if err := osd.ApplyOSDConfigOverrides(c.context, c.ClusterInfo, c.Spec.Storage.ConfigOverrides); err != nil {
+		return errors.Wrap(err, "failed to apply the osd config overrides")
+	}
+	if err := applyDebugLogging(c.context, c.ClusterInfo, c.Spec.DebugLogging); err != nil {
+		return errors.Wrap(err, "failed to apply the debug logging settings")
+	}
 	return nil
 }
 
@@ -799,6 +983,7 @@ func initClusterCephxStatus(c *clusterd.Context, cluster *cephv1.CephCluster) er
 	uninitializedStatus := keyring.UninitializedCephxStatus()
 	cluster.Status.Cephx = &cephv1.ClusterCephxStatus{
 		RBDMirrorPeer: &uninitializedStatus,
+		Mon:           &cephv1.MonCephxStatus{CephxStatus: uninitializedStatus},
 	}
 
 	if err := reporting.UpdateStatus(c.Client, cluster); err != nil {
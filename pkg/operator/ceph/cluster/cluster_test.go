@@ -77,6 +77,33 @@ func TestPreClusterStartValidation(t *testing.T) {
 			{Name: "b"},
 			{Name: "c"},
 		}}}}}}, true},
+		{"valid stretch cluster with three data zones", args{&cluster{ClusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"), context: &clusterd.Context{Clientset: testop.New(t, 7)}, Spec: &cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 7, StretchCluster: &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+			{Name: "a", Arbiter: true},
+			{Name: "b"},
+			{Name: "c"},
+			{Name: "d"},
+		}}}}}}, false},
+		{"uneven mons in three data zones", args{&cluster{ClusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"), context: &clusterd.Context{Clientset: testop.New(t, 7)}, Spec: &cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 5, StretchCluster: &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+			{Name: "a", Arbiter: true},
+			{Name: "b"},
+			{Name: "c"},
+			{Name: "d"},
+		}}}}}}, true},
+		{"too many data zones", args{&cluster{ClusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"), context: &clusterd.Context{Clientset: testop.New(t, 13)}, Spec: &cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 13, StretchCluster: &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+			{Name: "a", Arbiter: true},
+			{Name: "b"},
+			{Name: "c"},
+			{Name: "d"},
+			{Name: "e"},
+			{Name: "f"},
+			{Name: "g"},
+		}}}}}}, true},
+		{"two arbiters", args{&cluster{ClusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"), context: &clusterd.Context{Clientset: testop.New(t, 7)}, Spec: &cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 5, StretchCluster: &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+			{Name: "a", Arbiter: true},
+			{Name: "b", Arbiter: true},
+			{Name: "c"},
+			{Name: "d"},
+		}}}}}}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,6 +114,12 @@ func TestPreClusterStartValidation(t *testing.T) {
 	}
 }
 
+func TestStretchMonCounts(t *testing.T) {
+	assert.Equal(t, []int{5, 3}, stretchMonCounts(2))
+	assert.Equal(t, []int{7}, stretchMonCounts(3))
+	assert.Equal(t, []int{9, 5}, stretchMonCounts(4))
+}
+
 func TestConfigureMsgr2(t *testing.T) {
 	type fields struct {
 		expectedGlobalConfigSettings map[string]string
@@ -594,3 +627,53 @@ func TestFetchCephConfigFromSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMonZones(t *testing.T) {
+	// no zones
+	assert.NoError(t, validateMonZones(cephv1.MonSpec{}))
+
+	zones := []cephv1.MonZoneSpec{{Name: "a", DisallowLeader: true}, {Name: "b"}, {Name: "c", DisallowLeader: true}}
+	assert.NoError(t, validateMonZones(cephv1.MonSpec{Zones: zones}))
+
+	// no mon can be the leader
+	zones[1].DisallowLeader = true
+	assert.Error(t, validateMonZones(cephv1.MonSpec{Zones: zones}))
+
+	// the arbiter of a stretch cluster is not a leader candidate
+	stretchZones := []cephv1.MonZoneSpec{{Name: "a", Arbiter: true}, {Name: "b", DisallowLeader: true}, {Name: "c", DisallowLeader: true}}
+	assert.Error(t, validateMonZones(cephv1.MonSpec{StretchCluster: &cephv1.StretchClusterSpec{Zones: stretchZones}}))
+	stretchZones[2].DisallowLeader = false
+	assert.NoError(t, validateMonZones(cephv1.MonSpec{StretchCluster: &cephv1.StretchClusterSpec{Zones: stretchZones}}))
+}
+
+func TestValidateMonPorts(t *testing.T) {
+	hostNetwork := cephv1.NetworkSpec{Provider: cephv1.NetworkProviderHost}
+	assert.NoError(t, validateMonPorts(&cephv1.ClusterSpec{}))
+	assert.NoError(t, validateMonPorts(&cephv1.ClusterSpec{Network: hostNetwork, Mon: cephv1.MonSpec{Ports: &cephv1.MonPortsSpec{Msgr2: 3301, Msgr1: 6790}}}))
+
+	// the msgr2 port cannot also be the msgr1 port
+	assert.Error(t, validateMonPorts(&cephv1.ClusterSpec{Network: hostNetwork, Mon: cephv1.MonSpec{Ports: &cephv1.MonPortsSpec{Msgr2: 6789}}}))
+	assert.NoError(t, validateMonPorts(&cephv1.ClusterSpec{
+		Network: cephv1.NetworkSpec{Provider: cephv1.NetworkProviderHost, Connections: &cephv1.ConnectionsSpec{RequireMsgr2: true}},
+		Mon:     cephv1.MonSpec{Ports: &cephv1.MonPortsSpec{Msgr2: 6789}},
+	}))
+
+	// the ports are ignored on the pod network
+	assert.NoError(t, validateMonPorts(&cephv1.ClusterSpec{Mon: cephv1.MonSpec{Ports: &cephv1.MonPortsSpec{Msgr2: 6789}}}))
+}
+
+func TestValidateMonBindAddresses(t *testing.T) {
+	hostNetwork := cephv1.NetworkSpec{Provider: cephv1.NetworkProviderHost}
+	valid := &cephv1.MonBindAddressesSpec{Public: &cephv1.MonAddressSelector{CIDR: "10.0.0.0/24"}, Cluster: &cephv1.MonAddressSelector{Interface: "eth1"}}
+	invalid := &cephv1.MonBindAddressesSpec{Cluster: &cephv1.MonAddressSelector{CIDR: "10.0.0.0"}}
+	assert.NoError(t, validateMonBindAddresses(&cephv1.ClusterSpec{}))
+	assert.NoError(t, validateMonBindAddresses(&cephv1.ClusterSpec{Network: hostNetwork, Mon: cephv1.MonSpec{BindAddresses: valid}}))
+	assert.Error(t, validateMonBindAddresses(&cephv1.ClusterSpec{Network: hostNetwork, Mon: cephv1.MonSpec{BindAddresses: invalid}}))
+	assert.Error(t, validateMonBindAddresses(&cephv1.ClusterSpec{Network: hostNetwork, Mon: cephv1.MonSpec{
+		BindAddresses: valid,
+		Overrides:     map[string]cephv1.MonOverrideSpec{"a": {BindAddresses: invalid}},
+	}}))
+
+	// the addresses are ignored on the pod network
+	assert.NoError(t, validateMonBindAddresses(&cephv1.ClusterSpec{Mon: cephv1.MonSpec{BindAddresses: invalid}}))
+}
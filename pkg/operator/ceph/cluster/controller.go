@@ -32,6 +32,7 @@ import (
 	"github.com/rook/rook/pkg/daemon/ceph/osd/kms"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/supportbundle"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/ceph/csi"
 	"github.com/rook/rook/pkg/operator/ceph/reporting"
@@ -206,6 +207,18 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 		return err
 	}
 
+	// Watch the cluster profiles to keep the clusters inheriting them in sync
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.RookClusterProfile{TypeMeta: metav1.TypeMeta{Kind: "RookClusterProfile", APIVersion: ControllerTypeMeta.APIVersion}},
+			handler.TypedEnqueueRequestsFromMapFunc(mapProfileToClusters(mgr.GetClient())),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	cmHandler, err := opcontroller.ObjectToCRMapper[*cephv1.CephClusterList, *corev1.ConfigMap](
 		opManagerContext,
 		mgr.GetClient(),
@@ -216,6 +229,19 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 		return err
 	}
 
+	// Watch the OSD replacements to provision the OSDs once an OSD on a failing device is purged
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: corev1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(cmHandler),
+			predicateForOSDReplacementCMWatcher(),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes on the hotplug config map
 	// TODO: to improve, can we run this against the operator namespace only?
 	disableVal := k8sutil.GetOperatorSetting(disableHotplugEnv, "false")
@@ -288,6 +314,23 @@ func (r *ReconcileCephCluster) reconcile(request reconcile.Request) (reconcile.R
 		return r.reconcileDelete(cephCluster)
 	}
 
+	// The support bundle is generated in the background, started before the orchestration so it
+	// can be collected even when the orchestration fails
+	var clusterInfo *cephclient.ClusterInfo
+	if existing, ok := r.clusterController.clusterMap[cephCluster.Namespace]; ok {
+		clusterInfo = existing.ClusterInfo
+	}
+	supportbundle.GenerateIfRequested(r.opManagerContext, r.clusterController.context, cephCluster, clusterInfo)
+
+	// The cluster inherits the defaults of its profile
+	if err := opcontroller.ApplyClusterProfile(r.opManagerContext, r.client, cephCluster); err != nil {
+		return reconcile.Result{}, *cephCluster, err
+	}
+
+	// The endpoints declared in the spec are checked in the background so that an unreachable
+	// endpoint is reported even when the orchestration fails
+	r.checkEndpoints(cephCluster)
+
 	// Do reconcile here!
 	ownerInfo := k8sutil.NewOwnerInfo(cephCluster, r.scheme)
 	if err := r.clusterController.reconcileCephCluster(cephCluster, ownerInfo); err != nil {
@@ -303,13 +346,46 @@ func (r *ReconcileCephCluster) reconcile(request reconcile.Request) (reconcile.R
 			return reconcile.Result{}, *cephCluster, nil
 		}
 
+		r.reportRestartBudget(cephCluster)
+		if errors.Is(err, k8sutil.ErrRestartBudgetExceeded) {
+			// the daemons after the delayed restart are reconciled once the budget allows the restart
+			logger.Warningf("reconcile of cluster %q is delayed by the restart budget, requeuing in %s. %v", cephCluster.Name, opcontroller.WaitForRequeueIfRestartBudgetExceeded.RequeueAfter.String(), err)
+			return opcontroller.WaitForRequeueIfRestartBudgetExceeded, *cephCluster, nil
+		}
 		return reconcile.Result{}, *cephCluster, errors.Wrapf(err, "failed to reconcile cluster %q", cephCluster.Name)
 	}
 
+	if r.reportRestartBudget(cephCluster) {
+		logger.Infof("some daemon restarts of cluster %q were delayed by the restart budget, requeuing in %s", cephCluster.Name, opcontroller.WaitForRequeueIfRestartBudgetExceeded.RequeueAfter.String())
+		return opcontroller.WaitForRequeueIfRestartBudgetExceeded, *cephCluster, nil
+	}
+
 	// Return and do not requeue
 	return reconcile.Result{}, *cephCluster, nil
 }
 
+// reportRestartBudget sets the RestartBudgetExceeded condition on the CephCluster while some daemon
+// restarts are delayed by the restart budget, and returns whether they are
+func (r *ReconcileCephCluster) reportRestartBudget(cephCluster *cephv1.CephCluster) bool {
+	exceeded := k8sutil.IsRestartBudgetExceeded(cephCluster.Namespace)
+	status := corev1.ConditionFalse
+	reason := cephv1.RestartBudgetAvailableReason
+	message := "no daemon restart is delayed by the restart budget"
+	if exceeded {
+		status = corev1.ConditionTrue
+		reason = cephv1.RestartBudgetExceededReason
+		message = "some daemon restarts are delayed until the restart budget allows them"
+	}
+
+	current := cephv1.FindStatusCondition(cephCluster.Status.Conditions, cephv1.ConditionRestartBudgetExceeded)
+	if (current == nil && !exceeded) || (current != nil && current.Status == status) {
+		return exceeded
+	}
+	nsName := types.NamespacedName{Name: cephCluster.Name, Namespace: cephCluster.Namespace}
+	opcontroller.UpdateCondition(r.opManagerContext, r.context, nsName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionRestartBudgetExceeded, status, reason, message)
+	return exceeded
+}
+
 func (r *ReconcileCephCluster) reconcileDelete(cephCluster *cephv1.CephCluster) (reconcile.Result, cephv1.CephCluster, error) {
 	nsName := r.clusterController.namespacedName
 	var err error
@@ -364,6 +440,7 @@ func (r *ReconcileCephCluster) reconcileDelete(cephCluster *cephv1.CephCluster)
 	if err != nil {
 		return reconcile.Result{}, *cephCluster, errors.Wrap(err, "failed to remove finalizers")
 	}
+	opcontroller.ForgetEndpointChecks(endpointCheckResource(nsName))
 
 	// Return and do not requeue. Successful deletion.
 	return reconcile.Result{}, *cephCluster, nil
@@ -395,6 +472,8 @@ func (c *ClusterController) reconcileCephCluster(clusterObj *cephv1.CephCluster,
 	if !ok {
 		// It's a new cluster so let's populate the struct
 		cluster = newCluster(c.OpManagerCtx, clusterObj, c.context, ownerInfo)
+		cluster.recorder = c.recorder
+		cluster.mons.SetEventRecorder(c.recorder)
 	}
 	cluster.namespacedName = c.namespacedName
 	// updating observedGeneration in cluster if it's not the first reconcile
@@ -404,8 +483,10 @@ func (c *ClusterController) reconcileCephCluster(clusterObj *cephv1.CephCluster,
 	// This will be used later down by spec code to create objects like deployment, services etc
 	cluster.context.Client = c.client
 
-	// Set the spec
+	// Set the spec and the latest metadata
 	cluster.Spec = &clusterObj.Spec
+	cluster.clusterMetadata = clusterObj.ObjectMeta
+	cluster.mons.SetKeyStatus(clusterObj.Status.Cephx)
 
 	c.clusterMap[cluster.Namespace] = cluster
 	logger.Infof("reconciling ceph cluster in namespace %q", cluster.Namespace)
@@ -470,6 +551,8 @@ func (c *ClusterController) requestClusterDelete(cluster *cephv1.CephCluster) (r
 				delete(cluster.monitoringRoutines, daemon)
 			}
 		}
+		mon.DeleteMetrics(cluster.Namespace)
+		deleteProgressMetrics(cluster.Namespace)
 	}
 
 	if cluster.Spec.CleanupPolicy.AllowUninstallWithVolumes {
@@ -495,6 +578,9 @@ func (c *ClusterController) requestClusterDelete(cluster *cephv1.CephCluster) (r
 	if cluster, ok := c.clusterMap[cluster.Namespace]; ok {
 		delete(c.clusterMap, cluster.Namespace)
 	}
+	cephclient.ResetCommandBreaker(cluster.Namespace)
+	k8sutil.ResetRestartBudget(cluster.Namespace)
+	k8sutil.ResetDisruptionBudget(cluster.Namespace)
 
 	return reconcile.Result{}, nil
 }
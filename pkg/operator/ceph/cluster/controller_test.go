@@ -22,10 +22,15 @@ import (
 	"time"
 
 	addonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
 	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
 	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/exec"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apifake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
@@ -129,6 +134,9 @@ func TestReconcileDeleteCephCluster(t *testing.T) {
 		// delete blocking dependency
 		err = client.Delete(ctx, fakePool)
 		assert.NoError(t, err)
+		// the command breaker of the deleted cluster must not outlive it
+		openCommandBreaker(t, cephNs)
+		assert.True(t, cephclient.IsCommandBreakerOpen(cephNs))
 
 		resp, err = reconcileCephCluster.Reconcile(ctx, req)
 		assert.NoError(t, err)
@@ -140,9 +148,65 @@ func TestReconcileDeleteCephCluster(t *testing.T) {
 		err = client.Get(ctx, nsName, unblockedCluster)
 		assert.Error(t, err)
 		assert.True(t, kerrors.IsNotFound(err))
+		assert.False(t, cephclient.IsCommandBreakerOpen(cephNs))
 	})
 }
 
+func TestReportRestartBudget(t *testing.T) {
+	ctx := context.TODO()
+	nsName := types.NamespacedName{Name: "my-cluster", Namespace: "budget-ns"}
+	defer k8sutil.ResetRestartBudget(nsName.Namespace)
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	r := &ReconcileCephCluster{client: client, context: &clusterd.Context{Client: client}, opManagerContext: ctx}
+
+	getCondition := func() *cephv1.Condition {
+		assert.NoError(t, client.Get(ctx, nsName, cephCluster))
+		return cephv1.FindStatusCondition(cephCluster.Status.Conditions, cephv1.ConditionRestartBudgetExceeded)
+	}
+
+	// nothing is reported while the restarts are within the budget
+	assert.False(t, r.reportRestartBudget(cephCluster))
+	assert.Nil(t, getCondition())
+
+	k8sutil.SetRestartBudget(nsName.Namespace, 1)
+	assert.NoError(t, k8sutil.ConsumeRestartBudget(nsName.Namespace, "rook-ceph-mon", k8sutil.RestartReasonSpecChange))
+	assert.Error(t, k8sutil.ConsumeRestartBudget(nsName.Namespace, "rook-ceph-mon", k8sutil.RestartReasonSpecChange))
+	assert.True(t, r.reportRestartBudget(cephCluster))
+	condition := getCondition()
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, cephv1.RestartBudgetExceededReason, condition.Reason)
+
+	k8sutil.ResetRestartBudget(nsName.Namespace)
+	assert.False(t, r.reportRestartBudget(cephCluster))
+	condition = getCondition()
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, cephv1.RestartBudgetAvailableReason, condition.Reason)
+}
+
+// openCommandBreaker opens the command breaker of the cluster in the namespace with a command
+// failing to reach the cluster
+func openCommandBreaker(t *testing.T, namespace string) {
+	threshold, duration := cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration
+	cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration = 1, 0
+	t.Cleanup(func() {
+		cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration = threshold, duration
+		cephclient.ResetCommandBreaker(namespace)
+	})
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			return "", errors.New(exec.TimeoutWaitingForMessage)
+		},
+	}
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	_, err := cephclient.NewCephCommand(&clusterd.Context{Executor: executor}, clusterInfo, []string{"status"}).Run()
+	assert.Error(t, err)
+}
+
 func TestRemoveFinalizers(t *testing.T) {
 	reconcileCephCluster := &ReconcileCephCluster{
 		opManagerContext: context.TODO(),
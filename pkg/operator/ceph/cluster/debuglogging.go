@@ -0,0 +1,286 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// debugLoggingConfigMap records the debug levels raised by the debugLogging settings and the
+	// levels to restore once they expire
+	debugLoggingConfigMap = "rook-ceph-debug-logging"
+	debugLoggingKey       = "session"
+
+	debugLoggingActive  = "Active"
+	debugLoggingExpired = "Expired"
+)
+
+var (
+	debugLoggingDaemonRegex    = regexp.MustCompile(`^(mon|mgr|osd|mds|client)(\.[a-zA-Z0-9._-]+)?$`)
+	debugLoggingSubsystemRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+	// debugLoggingNow is replaced by the unit tests to expire the debug levels
+	debugLoggingNow = time.Now
+)
+
+// debugLoggingSession is the state of the debug levels raised by the debugLogging settings
+type debugLoggingSession struct {
+	// Levels are the debug options set by daemon type or daemon
+	Levels   map[string]map[string]string `json:"levels"`
+	Operator bool                         `json:"operator,omitempty"`
+	Duration time.Duration                `json:"duration"`
+	// Previous are the values the daemon types or daemons had for the debug options before they
+	// were raised, an empty value if they had none
+	Previous   map[string]map[string]string `json:"previous"`
+	Expiration time.Time                    `json:"expiration"`
+	Phase      string                       `json:"phase"`
+}
+
+// applyDebugLogging raises the debug levels of the debugLogging settings for their duration. The
+// levels are only raised again when the settings change, so the levels restored once expired stay
+// restored until the settings are changed or removed.
+func applyDebugLogging(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.DebugLoggingSpec) error {
+	monStore := config.GetMonStore(context, clusterInfo)
+	session, err := getDebugLogging(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+
+	if spec == nil {
+		if session == nil {
+			return nil
+		}
+		if session.Phase == debugLoggingActive {
+			logger.Infof("restoring the debug levels since the debug logging settings were removed")
+			if err := restoreDebugLevels(monStore, session); err != nil {
+				return err
+			}
+			opcontroller.UpdateCondition(clusterInfo.Context, context, clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionDebugLogging,
+				v1.ConditionFalse, cephv1.DebugLoggingExpiredReason, "the debug levels were restored since the debug logging settings were removed")
+		}
+		return deleteDebugLogging(context, clusterInfo)
+	}
+
+	levels, err := debugLevels(spec)
+	if err != nil {
+		return err
+	}
+	if spec.Duration.Duration <= 0 {
+		return errors.Errorf("invalid debug logging duration %q, must be positive", spec.Duration.Duration.String())
+	}
+	if session != nil && reflect.DeepEqual(session.Levels, levels) && session.Operator == spec.Operator && session.Duration == spec.Duration.Duration {
+		logger.Debugf("the debug logging settings did not change (%s)", session.Phase)
+		return nil
+	}
+	if session != nil && session.Phase == debugLoggingActive {
+		logger.Infof("restoring the debug levels before raising the changed debug logging settings")
+		if err := restoreDebugLevels(monStore, session); err != nil {
+			return err
+		}
+	}
+
+	session = &debugLoggingSession{
+		Levels:     levels,
+		Operator:   spec.Operator,
+		Duration:   spec.Duration.Duration,
+		Previous:   map[string]map[string]string{},
+		Expiration: debugLoggingNow().Add(spec.Duration.Duration),
+		Phase:      debugLoggingActive,
+	}
+	for who, options := range levels {
+		current, err := monStore.GetDaemon(who)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the config of %q", who)
+		}
+		session.Previous[who] = map[string]string{}
+		for option := range options {
+			session.Previous[who][option] = ""
+		}
+		for _, option := range current {
+			if _, ok := options[option.Option]; ok {
+				session.Previous[who][option.Option] = option.Value
+			}
+		}
+	}
+
+	// the levels to restore must be recorded before the levels are raised
+	if err := saveDebugLogging(context, clusterInfo, session); err != nil {
+		return err
+	}
+	for _, who := range sortedDebugDaemons(levels) {
+		for option, value := range levels[who] {
+			if err := monStore.Set(who, option, value); err != nil {
+				return errors.Wrapf(err, "failed to raise the debug level %s on %q", option, who)
+			}
+		}
+	}
+	if session.Operator {
+		util.SetGlobalLogLevel("DEBUG", logger)
+	}
+	message := fmt.Sprintf("the debug levels of %v are raised until %s", sortedDebugDaemons(levels), session.Expiration.UTC().Format(time.RFC3339))
+	if session.Operator {
+		message = fmt.Sprintf("the debug levels of %v and the operator are raised until %s", sortedDebugDaemons(levels), session.Expiration.UTC().Format(time.RFC3339))
+	}
+	logger.Info(message)
+	opcontroller.UpdateCondition(clusterInfo.Context, context, clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionDebugLogging,
+		v1.ConditionTrue, cephv1.DebugLoggingActiveReason, message)
+	return nil
+}
+
+// expireDebugLogging restores the debug levels raised by the debugLogging settings once their
+// duration elapsed. The operator log level is raised again until then since it may be reset by a
+// change of the operator settings.
+func expireDebugLogging(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) error {
+	session, err := getDebugLogging(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.Phase != debugLoggingActive {
+		return nil
+	}
+	if debugLoggingNow().Before(session.Expiration) {
+		if session.Operator {
+			util.SetGlobalLogLevel("DEBUG", logger)
+		}
+		return nil
+	}
+
+	logger.Infof("the debug logging duration of %s elapsed, restoring the debug levels", session.Duration.String())
+	if err := restoreDebugLevels(config.GetMonStore(context, clusterInfo), session); err != nil {
+		return err
+	}
+	session.Phase = debugLoggingExpired
+	if err := saveDebugLogging(context, clusterInfo, session); err != nil {
+		return err
+	}
+	opcontroller.UpdateCondition(clusterInfo.Context, context, clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionDebugLogging,
+		v1.ConditionFalse, cephv1.DebugLoggingExpiredReason, fmt.Sprintf("the debug levels were restored after %s", session.Duration.String()))
+	return nil
+}
+
+// restoreDebugLevels restores the values the daemon types or daemons had for the debug options
+// before they were raised, or removes them if they had none, and resets the operator log level
+func restoreDebugLevels(monStore *config.MonStore, session *debugLoggingSession) error {
+	for _, who := range sortedDebugDaemons(session.Previous) {
+		for option, previous := range session.Previous[who] {
+			if previous == "" {
+				if err := monStore.Delete(who, option); err != nil {
+					return errors.Wrapf(err, "failed to restore the debug level %s on %q", option, who)
+				}
+				continue
+			}
+			if err := monStore.Set(who, option, previous); err != nil {
+				return errors.Wrapf(err, "failed to restore the debug level %s on %q", option, who)
+			}
+		}
+	}
+	if session.Operator {
+		util.SetGlobalLogLevel(k8sutil.GetOperatorSetting("ROOK_LOG_LEVEL", util.DefaultLogLevel.String()), logger)
+	}
+	return nil
+}
+
+// debugLevels returns the debug options of the debugLogging settings by daemon type or daemon
+func debugLevels(spec *cephv1.DebugLoggingSpec) (map[string]map[string]string, error) {
+	levels := map[string]map[string]string{}
+	for who, subsystems := range spec.Daemons {
+		if !debugLoggingDaemonRegex.MatchString(who) {
+			return nil, errors.Errorf("invalid debug logging daemon %q, must be a daemon type (mon, mgr, osd, mds, client) or a daemon such as osd.3", who)
+		}
+		for subsystem, level := range subsystems {
+			if !debugLoggingSubsystemRegex.MatchString(subsystem) {
+				return nil, errors.Errorf("invalid debug logging subsystem %q of %q", subsystem, who)
+			}
+			if level == "" {
+				return nil, errors.Errorf("empty debug level of subsystem %q of %q", subsystem, who)
+			}
+			if levels[who] == nil {
+				levels[who] = map[string]string{}
+			}
+			levels[who]["debug_"+subsystem] = level
+		}
+	}
+	return levels, nil
+}
+
+func sortedDebugDaemons(levels map[string]map[string]string) []string {
+	daemons := make([]string, 0, len(levels))
+	for who := range levels {
+		daemons = append(daemons, who)
+	}
+	sort.Strings(daemons)
+	return daemons
+}
+
+func getDebugLogging(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) (*debugLoggingSession, error) {
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, debugLoggingConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get configmap %q", debugLoggingConfigMap)
+	}
+	session := &debugLoggingSession{}
+	if err := json.Unmarshal([]byte(cm.Data[debugLoggingKey]), session); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the debug logging session from configmap %q", debugLoggingConfigMap)
+	}
+	return session, nil
+}
+
+func saveDebugLogging(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, session *debugLoggingSession) error {
+	raw, err := json.Marshal(session)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the debug logging session")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: debugLoggingConfigMap, Namespace: clusterInfo.Namespace},
+		Data:       map[string]string{debugLoggingKey: string(raw)},
+	}
+	if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on configmap %q", debugLoggingConfigMap)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", debugLoggingConfigMap)
+	}
+	return nil
+}
+
+func deleteDebugLogging(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) error {
+	err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Delete(clusterInfo.Context, debugLoggingConfigMap, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete configmap %q", debugLoggingConfigMap)
+	}
+	return nil
+}
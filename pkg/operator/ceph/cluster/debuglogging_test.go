@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDebugLogging(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+
+	// the mon store has debug_osd set for the osds before the debug levels are raised
+	store := map[string]map[string]string{"osd": {"debug_osd": "1/5"}}
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] != "config" {
+				return "", errors.Errorf("unexpected ceph command %q", args)
+			}
+			switch args[1] {
+			case "get":
+				options := []string{}
+				for option, value := range store[args[2]] {
+					options = append(options, fmt.Sprintf(`%q:{"section":%q,"value":%q}`, option, args[2], value))
+				}
+				return "{" + strings.Join(options, ",") + "}", nil
+			case "set":
+				commands = append(commands, strings.Join(args[1:5], " "))
+				if store[args[2]] == nil {
+					store[args[2]] = map[string]string{}
+				}
+				store[args[2]][args[3]] = args[4]
+				return "", nil
+			case "rm":
+				commands = append(commands, strings.Join(args[1:4], " "))
+				delete(store[args[2]], args[3])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	c := &clusterd.Context{Clientset: fake.NewSimpleClientset(), Client: cl, Executor: executor}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := debugLoggingNow
+	t.Cleanup(func() { debugLoggingNow = originalNow })
+	debugLoggingNow = func() time.Time { return now }
+	condition := func() *cephv1.Condition {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(ctx, nsName, cluster))
+		for i := range cluster.Status.Conditions {
+			if cluster.Status.Conditions[i].Type == cephv1.ConditionDebugLogging {
+				return &cluster.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+	sorted := func(commands []string) []string {
+		sort.Strings(commands)
+		return commands
+	}
+
+	spec := &cephv1.DebugLoggingSpec{
+		Duration: metav1.Duration{Duration: time.Hour},
+		Daemons: map[string]map[string]string{
+			"osd":   {"osd": "20/20", "bluestore": "10"},
+			"mon.a": {"mon": "20", "paxos": "20"},
+		},
+	}
+
+	// invalid daemons are rejected
+	err := applyDebugLogging(c, clusterInfo, &cephv1.DebugLoggingSpec{Duration: spec.Duration, Daemons: map[string]map[string]string{"rgw": {"rgw": "20"}}})
+	assert.Error(t, err)
+	assert.Empty(t, commands)
+
+	// the debug levels are raised
+	require.NoError(t, applyDebugLogging(c, clusterInfo, spec))
+	assert.Equal(t, []string{
+		"set mon.a debug_mon 20",
+		"set mon.a debug_paxos 20",
+		"set osd debug_bluestore 10",
+		"set osd debug_osd 20/20",
+	}, sorted(commands))
+	require.NotNil(t, condition())
+	assert.Equal(t, v1.ConditionTrue, condition().Status)
+	assert.Contains(t, condition().Message, "2025-06-01T13:00:00Z")
+
+	// the unchanged settings are not raised again
+	commands = nil
+	require.NoError(t, applyDebugLogging(c, clusterInfo, spec))
+	assert.Empty(t, commands)
+
+	// the debug levels are kept until the duration elapsed
+	now = now.Add(59 * time.Minute)
+	require.NoError(t, expireDebugLogging(c, clusterInfo))
+	assert.Empty(t, commands)
+
+	// the previous levels are restored once the duration elapsed
+	now = now.Add(time.Minute)
+	require.NoError(t, expireDebugLogging(c, clusterInfo))
+	assert.Equal(t, []string{
+		"rm mon.a debug_mon",
+		"rm mon.a debug_paxos",
+		"rm osd debug_bluestore",
+		"set osd debug_osd 1/5",
+	}, sorted(commands))
+	assert.Equal(t, map[string]string{"debug_osd": "1/5"}, store["osd"])
+	assert.Empty(t, store["mon.a"])
+	assert.Equal(t, v1.ConditionFalse, condition().Status)
+	assert.Equal(t, cephv1.DebugLoggingExpiredReason, condition().Reason)
+
+	// the expired levels are not raised again until the settings change
+	commands = nil
+	require.NoError(t, applyDebugLogging(c, clusterInfo, spec))
+	require.NoError(t, expireDebugLogging(c, clusterInfo))
+	assert.Empty(t, commands)
+
+	// the changed settings are raised again
+	spec.Daemons = map[string]map[string]string{"osd.3": {"osd": "20"}}
+	require.NoError(t, applyDebugLogging(c, clusterInfo, spec))
+	assert.Equal(t, []string{"set osd.3 debug_osd 20"}, commands)
+	assert.Equal(t, v1.ConditionTrue, condition().Status)
+
+	// the levels are restored when the settings are removed
+	commands = nil
+	require.NoError(t, applyDebugLogging(c, clusterInfo, nil))
+	assert.Equal(t, []string{"rm osd.3 debug_osd"}, commands)
+	assert.Equal(t, v1.ConditionFalse, condition().Status)
+	_, err = c.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(ctx, debugLoggingConfigMap, metav1.GetOptions{})
+	assert.Error(t, err)
+}
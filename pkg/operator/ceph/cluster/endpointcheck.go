@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// endpointCheckResource is the key of the endpoint checks of a CephCluster
+func endpointCheckResource(nsName types.NamespacedName) string {
+	return "CephCluster/" + nsName.String()
+}
+
+// clusterEndpointChecks returns the endpoints declared in the spec of the cluster: the external
+// mons with an address, on their ports or else on the default mon ports, and the KMS server
+func clusterEndpointChecks(spec *cephv1.ClusterSpec) []opcontroller.EndpointCheck {
+	var endpoints []opcontroller.EndpointCheck
+	for _, externalMon := range spec.Mon.ExternalMons {
+		if externalMon.Address == "" {
+			continue
+		}
+		ports := externalMon.Ports
+		if len(ports) == 0 {
+			ports = []int32{mon.DefaultMsgr2Port, mon.DefaultMsgr1Port}
+		}
+		for _, port := range ports {
+			endpoints = append(endpoints, opcontroller.EndpointCheck{
+				Source: fmt.Sprintf("mon.externalMons[%s]", externalMon.ID),
+				Host:   externalMon.Address,
+				Port:   port,
+			})
+		}
+	}
+
+	kmsEndpoint, err := opcontroller.KMSEndpointCheck("security.kms", spec.Security.KeyManagementService)
+	if err != nil {
+		logger.Warningf("failed to get the kms endpoint to check. %v", err)
+	} else if kmsEndpoint.Host != "" {
+		endpoints = append(endpoints, kmsEndpoint)
+	}
+	return endpoints
+}
+
+// checkEndpoints starts the checks of the endpoints declared in the spec of the cluster in the
+// background, the results are reported in the CephCluster status
+func (r *ReconcileCephCluster) checkEndpoints(cephCluster *cephv1.CephCluster) {
+	nsName := types.NamespacedName{Namespace: cephCluster.Namespace, Name: cephCluster.Name}
+	endpoints := clusterEndpointChecks(&cephCluster.Spec)
+	if len(endpoints) == 0 && len(cephCluster.Status.EndpointChecks) == 0 {
+		return
+	}
+	opcontroller.CheckEndpoints(r.opManagerContext, endpointCheckResource(nsName), endpoints, func(results []cephv1.EndpointCheckStatus) {
+		if err := r.updateEndpointCheckStatus(nsName, results); err != nil {
+			logger.Warningf("failed to report the endpoint checks of cluster %q. %v", nsName.String(), err)
+		}
+	})
+}
+
+// updateEndpointCheckStatus updates the endpoint checks in the CephCluster status
+func (r *ReconcileCephCluster) updateEndpointCheckStatus(nsName types.NamespacedName, results []cephv1.EndpointCheckStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := r.client.Get(r.opManagerContext, nsName, cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve ceph cluster %q to update the endpoint checks", nsName.String())
+		}
+		cephCluster.Status.EndpointChecks = results
+		if err := reporting.UpdateStatus(r.client, cephCluster); err != nil {
+			return errors.Wrapf(err, "failed to update the endpoint checks of ceph cluster %q", nsName.String())
+		}
+		return nil
+	})
+}
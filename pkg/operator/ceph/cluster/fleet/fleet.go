@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fleet summarizes the health, capacity, version and pending upgrades of all the
+// CephClusters managed by the operator on a single endpoint, so that the operators of a fleet of
+// clusters do not have to aggregate the status of each CephCluster themselves.
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// StatusPath is the path of the fleet status on the metrics endpoint of the operator
+	StatusPath = "/fleet/status"
+
+	// healthUnknown is the health of a cluster whose ceph status was not checked yet
+	healthUnknown = "HEALTH_UNKNOWN"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "ceph-fleet")
+
+// healthOrder ranks the health of the clusters from the best to the worst
+var healthOrder = []string{cephclient.CephHealthOK, healthUnknown, cephclient.CephHealthWarn, cephclient.CephHealthErr}
+
+// Status summarizes the clusters of the fleet
+type Status struct {
+	// Health is the worst health of the clusters
+	Health string `json:"health,omitempty"`
+	// ClusterCount is the number of clusters
+	ClusterCount int `json:"clusterCount"`
+	// PendingUpgrades is the number of clusters whose daemons do not all run the desired version
+	PendingUpgrades int `json:"pendingUpgrades"`
+	// Capacity is the capacity of the Ceph clusters, counted once for the CephClusters connected
+	// to the same Ceph cluster
+	Capacity Capacity `json:"capacity"`
+	// Clusters are the summaries of the clusters, sorted by namespace and name
+	Clusters []ClusterStatus `json:"clusters"`
+}
+
+// Capacity is the capacity of one or more Ceph clusters
+type Capacity struct {
+	TotalBytes     uint64 `json:"bytesTotal"`
+	UsedBytes      uint64 `json:"bytesUsed"`
+	AvailableBytes uint64 `json:"bytesAvailable"`
+}
+
+// ClusterStatus summarizes a CephCluster
+type ClusterStatus struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// External is whether the CephCluster connects to an external Ceph cluster
+	External bool                 `json:"external,omitempty"`
+	Phase    cephv1.ConditionType `json:"phase,omitempty"`
+	Message  string               `json:"message,omitempty"`
+	Health   string               `json:"health"`
+	FSID     string               `json:"fsid,omitempty"`
+	Capacity Capacity             `json:"capacity"`
+	// Version is the Ceph version of the cluster
+	Version string `json:"version,omitempty"`
+	// Image is the Ceph image the cluster runs
+	Image string `json:"image,omitempty"`
+	// DesiredImage is the Ceph image of the spec of the cluster
+	DesiredImage string `json:"desiredImage,omitempty"`
+	// UpgradePending is whether the cluster does not run the desired image yet, or its daemons run
+	// different Ceph versions
+	UpgradePending bool `json:"upgradePending"`
+}
+
+// Add serves the status of the fleet on the metrics endpoint of the operator
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	h := &handler{context: context, namespace: opConfig.NamespaceToWatch}
+	if err := mgr.AddMetricsServerExtraHandler(StatusPath, h); err != nil {
+		return errors.Wrapf(err, "failed to serve the fleet status on %q", StatusPath)
+	}
+	return nil
+}
+
+// handler serves the status of the clusters of the namespace, or of all the namespaces when empty
+type handler struct {
+	context   *clusterd.Context
+	namespace string
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clusters, err := h.context.RookClientset.CephV1().CephClusters(h.namespace).List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		logger.Errorf("failed to list the ceph clusters of the fleet. %v", err)
+		http.Error(w, "failed to list the ceph clusters", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(Summarize(clusters.Items)); err != nil {
+		logger.Errorf("failed to write the fleet status. %v", err)
+	}
+}
+
+// Summarize returns the status of the fleet of the clusters
+func Summarize(clusters []cephv1.CephCluster) Status {
+	status := Status{Clusters: []ClusterStatus{}}
+	countedFSIDs := map[string]bool{}
+	for i := range clusters {
+		cluster := summarizeCluster(&clusters[i])
+		status.Clusters = append(status.Clusters, cluster)
+
+		if status.Health == "" || slices.Index(healthOrder, cluster.Health) > slices.Index(healthOrder, status.Health) {
+			status.Health = cluster.Health
+		}
+		if cluster.UpgradePending {
+			status.PendingUpgrades++
+		}
+		// the consumers of the same external cluster report the capacity of the same Ceph cluster
+		if cluster.FSID != "" {
+			if countedFSIDs[cluster.FSID] {
+				continue
+			}
+			countedFSIDs[cluster.FSID] = true
+		}
+		status.Capacity.TotalBytes += cluster.Capacity.TotalBytes
+		status.Capacity.UsedBytes += cluster.Capacity.UsedBytes
+		status.Capacity.AvailableBytes += cluster.Capacity.AvailableBytes
+	}
+	status.ClusterCount = len(status.Clusters)
+
+	slices.SortFunc(status.Clusters, func(a, b ClusterStatus) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return status
+}
+
+func summarizeCluster(cluster *cephv1.CephCluster) ClusterStatus {
+	status := ClusterStatus{
+		Namespace:    cluster.Namespace,
+		Name:         cluster.Name,
+		External:     cluster.Spec.External.Enable,
+		Phase:        cluster.Status.Phase,
+		Message:      cluster.Status.Message,
+		Health:       healthUnknown,
+		DesiredImage: cluster.Spec.CephVersion.Image,
+	}
+
+	if cephStatus := cluster.Status.CephStatus; cephStatus != nil {
+		if slices.Contains(healthOrder, cephStatus.Health) {
+			status.Health = cephStatus.Health
+		}
+		status.FSID = cephStatus.FSID
+		status.Capacity = Capacity{
+			TotalBytes:     cephStatus.Capacity.TotalBytes,
+			UsedBytes:      cephStatus.Capacity.UsedBytes,
+			AvailableBytes: cephStatus.Capacity.AvailableBytes,
+		}
+		// the daemons run different versions during an upgrade
+		if cephStatus.Versions != nil && len(cephStatus.Versions.Overall) > 1 {
+			status.UpgradePending = true
+		}
+	}
+
+	if version := cluster.Status.CephVersion; version != nil {
+		status.Version = version.Version
+		status.Image = version.Image
+	}
+	if status.DesiredImage != "" && status.Image != status.DesiredImage {
+		status.UpgradePending = true
+	}
+	return status
+}
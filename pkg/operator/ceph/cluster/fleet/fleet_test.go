@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	fakeclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCluster(namespace, health, fsid string, totalBytes, usedBytes uint64) *cephv1.CephCluster {
+	return &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Spec:       cephv1.ClusterSpec{CephVersion: cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v19.2.2"}},
+		Status: cephv1.ClusterStatus{
+			Phase: cephv1.ConditionReady,
+			CephStatus: &cephv1.CephStatus{
+				Health:   health,
+				FSID:     fsid,
+				Capacity: cephv1.Capacity{TotalBytes: totalBytes, UsedBytes: usedBytes, AvailableBytes: totalBytes - usedBytes},
+			},
+			CephVersion: &cephv1.ClusterVersion{Image: "quay.io/ceph/ceph:v19.2.2", Version: "19.2.2-0"},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	// an empty fleet has no health
+	status := Summarize(nil)
+	assert.Equal(t, Status{Clusters: []ClusterStatus{}}, status)
+
+	healthy := newCluster("b", "HEALTH_OK", "fsid-b", 100, 10)
+	warning := newCluster("a", "HEALTH_WARN", "fsid-a", 200, 20)
+	// the upgrade to the image of the spec has not started
+	upgrading := newCluster("c", "HEALTH_OK", "fsid-c", 300, 30)
+	upgrading.Spec.CephVersion.Image = "quay.io/ceph/ceph:v20.2.0"
+	// the consumer of cluster "b" reports the same capacity
+	consumer := newCluster("d", "HEALTH_OK", "fsid-b", 100, 10)
+	consumer.Spec = cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}}
+
+	status = Summarize([]cephv1.CephCluster{*healthy, *warning, *upgrading, *consumer})
+	assert.Equal(t, "HEALTH_WARN", status.Health)
+	assert.Equal(t, 4, status.ClusterCount)
+	assert.Equal(t, 1, status.PendingUpgrades)
+	assert.Equal(t, Capacity{TotalBytes: 600, UsedBytes: 60, AvailableBytes: 540}, status.Capacity)
+	require.Len(t, status.Clusters, 4)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, []string{status.Clusters[0].Name, status.Clusters[1].Name, status.Clusters[2].Name, status.Clusters[3].Name})
+	assert.True(t, status.Clusters[2].UpgradePending)
+	assert.Equal(t, "quay.io/ceph/ceph:v20.2.0", status.Clusters[2].DesiredImage)
+	assert.True(t, status.Clusters[3].External)
+	assert.False(t, status.Clusters[3].UpgradePending)
+
+	// the daemons run different versions during an upgrade
+	upgrading.Spec.CephVersion.Image = "quay.io/ceph/ceph:v19.2.2"
+	upgrading.Status.CephStatus.Versions = &cephv1.CephDaemonsVersions{Overall: map[string]int{"19.2.2-0": 3, "19.2.1-0": 2}}
+	status = Summarize([]cephv1.CephCluster{*upgrading})
+	assert.Equal(t, 1, status.PendingUpgrades)
+
+	// a cluster whose health was not checked yet is worse than a healthy cluster
+	creating := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "e"}}
+	status = Summarize([]cephv1.CephCluster{*healthy, *creating})
+	assert.Equal(t, "HEALTH_UNKNOWN", status.Health)
+	status = Summarize([]cephv1.CephCluster{*creating, *newCluster("f", "HEALTH_ERR", "fsid-f", 0, 0)})
+	assert.Equal(t, "HEALTH_ERR", status.Health)
+}
+
+func TestHandler(t *testing.T) {
+	rookClientset := fakeclient.NewSimpleClientset(newCluster("a", "HEALTH_OK", "fsid-a", 100, 10), newCluster("b", "HEALTH_WARN", "fsid-b", 100, 10))
+	h := &handler{context: &clusterd.Context{RookClientset: rookClientset}}
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var status Status
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "HEALTH_WARN", status.Health)
+	assert.Equal(t, 2, status.ClusterCount)
+
+	// the status of the clusters of the watched namespace only
+	h.namespace = "a"
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, 1, status.ClusterCount)
+
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, StatusPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
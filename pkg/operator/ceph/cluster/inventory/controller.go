@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory publishes the devices discovered on each node as CephDeviceInventory resources.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	discoverDaemon "github.com/rook/rook/pkg/daemon/discover"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/sys"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "ceph-device-inventory-controller"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+// ReconcileDeviceInventory publishes the devices of the configmaps of the discovery daemon as a
+// CephDeviceInventory per node
+type ReconcileDeviceInventory struct {
+	client           client.Client
+	context          *clusterd.Context
+	opManagerContext context.Context
+}
+
+// cephVolumeInventory is the ceph-volume inventory of a device recorded by the discovery daemon
+type cephVolumeInventory struct {
+	Available       bool     `json:"available"`
+	RejectedReasons []string `json:"rejected_reasons"`
+	LVS             []struct {
+		OSDID       string `json:"osd_id"`
+		ClusterFSID string `json:"cluster_fsid"`
+		Type        string `json:"type"`
+	} `json:"lvs"`
+}
+
+// Add creates a new device inventory Controller and adds it to the Manager. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, &ReconcileDeviceInventory{
+		client:           mgr.GetClient(),
+		context:          context,
+		opManagerContext: opManagerContext,
+	})
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the configmaps of the discovery daemon
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: corev1.SchemeGroupVersion.String()}},
+			&handler.TypedEnqueueRequestForObject[*corev1.ConfigMap]{},
+			discoveryCMPredicate(),
+		),
+	)
+}
+
+func discoveryCMPredicate[T *corev1.ConfigMap]() predicate.TypedFuncs[T] {
+	isDiscoveryCM := func(cm *corev1.ConfigMap) bool {
+		return cm.GetLabels()[k8sutil.AppAttr] == discoverDaemon.AppName
+	}
+	return predicate.TypedFuncs[T]{
+		CreateFunc: func(e event.TypedCreateEvent[T]) bool {
+			return isDiscoveryCM((*corev1.ConfigMap)(e.Object))
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			objOld := (*corev1.ConfigMap)(e.ObjectOld)
+			objNew := (*corev1.ConfigMap)(e.ObjectNew)
+			return isDiscoveryCM(objNew) && !reflect.DeepEqual(objOld.Data, objNew.Data)
+		},
+		// the inventory is garbage collected with the configmap
+		DeleteFunc: func(e event.TypedDeleteEvent[T]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
+// Reconcile updates the CephDeviceInventory of the node of a configmap of the discovery daemon
+func (r *ReconcileDeviceInventory) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if err := r.reconcile(request); err != nil {
+		logger.Errorf("failed to reconcile the device inventory of configmap %q. %v", request.NamespacedName, err)
+		return opcontroller.ImmediateRetryResult, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileDeviceInventory) reconcile(request reconcile.Request) error {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(r.opManagerContext, request.NamespacedName, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debugf("configmap %q not found. ignoring since the inventory is deleted with it", request.NamespacedName)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get configmap %q", request.NamespacedName)
+	}
+	nodeName := cm.Labels[discoverDaemon.NodeAttr]
+	if nodeName == "" {
+		return errors.Errorf("configmap %q has no %q label", request.NamespacedName, discoverDaemon.NodeAttr)
+	}
+	var devices []sys.LocalDisk
+	if err := json.Unmarshal([]byte(cm.Data[discoverDaemon.LocalDiskCMData]), &devices); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal the devices of configmap %q", request.NamespacedName)
+	}
+
+	name := types.NamespacedName{Namespace: cm.Namespace, Name: nodeName}
+	deviceInventory := &cephv1.CephDeviceInventory{}
+	err := r.client.Get(r.opManagerContext, name, deviceInventory)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get CephDeviceInventory %q", name)
+		}
+		deviceInventory = &cephv1.CephDeviceInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nodeName,
+				Namespace: cm.Namespace,
+				// the inventory is deleted with the configmap when the discovery daemon is disabled
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       cm.Name,
+					UID:        cm.UID,
+				}},
+			},
+			Spec: cephv1.DeviceInventorySpec{NodeName: nodeName},
+		}
+		if err := r.client.Create(r.opManagerContext, deviceInventory); err != nil {
+			return errors.Wrapf(err, "failed to create CephDeviceInventory %q", name)
+		}
+		logger.Infof("created CephDeviceInventory %q", name)
+	}
+
+	inventoryDevices := deviceInventoryDevices(devices)
+	status := &cephv1.DeviceInventoryStatus{
+		Devices:        inventoryDevices,
+		DeviceCount:    len(inventoryDevices),
+		LastUpdateTime: &metav1.Time{Time: metav1.Now().Time},
+	}
+	for _, device := range inventoryDevices {
+		if device.Available {
+			status.AvailableCount++
+		}
+	}
+	deviceInventory.Status = status
+	if err := reporting.UpdateStatus(r.client, deviceInventory); err != nil {
+		return errors.Wrapf(err, "failed to update the status of CephDeviceInventory %q", name)
+	}
+	logger.Debugf("updated CephDeviceInventory %q with %d devices", name, status.DeviceCount)
+	return nil
+}
+
+// deviceInventoryDevices returns the inventory of the devices discovered on a node
+func deviceInventoryDevices(devices []sys.LocalDisk) []cephv1.InventoryDevice {
+	result := []cephv1.InventoryDevice{}
+	for i := range devices {
+		device := &devices[i]
+		inventoryDevice := cephv1.InventoryDevice{
+			Name:        device.Name,
+			DevLinks:    strings.Fields(device.DevLinks),
+			Type:        device.Type,
+			Vendor:      device.Vendor,
+			Model:       device.Model,
+			Serial:      device.Serial,
+			WWN:         device.WWN,
+			Size:        device.Size,
+			Rotational:  device.Rotational,
+			Filesystem:  device.Filesystem,
+			SmartStatus: device.SmartStatus,
+		}
+		if device.CephVolumeData != "" {
+			var cvInventory cephVolumeInventory
+			if err := json.Unmarshal([]byte(device.CephVolumeData), &cvInventory); err != nil {
+				logger.Warningf("failed to unmarshal the ceph-volume inventory of device %q. %v", device.Name, err)
+			} else {
+				inventoryDevice.Available = cvInventory.Available
+				inventoryDevice.RejectedReasons = cvInventory.RejectedReasons
+				for _, lv := range cvInventory.LVS {
+					osdID, err := strconv.Atoi(lv.OSDID)
+					if err != nil {
+						// the logical volume is not used by an osd
+						continue
+					}
+					inventoryDevice.OSDs = append(inventoryDevice.OSDs, cephv1.InventoryDeviceOSD{ID: osdID, ClusterFSID: lv.ClusterFSID, Type: lv.Type})
+				}
+				result = append(result, inventoryDevice)
+				continue
+			}
+		}
+		// without the ceph-volume inventory, the device is only available if it is empty
+		inventoryDevice.RejectedReasons = rejectedReasons(device)
+		inventoryDevice.Available = len(inventoryDevice.RejectedReasons) == 0
+		result = append(result, inventoryDevice)
+	}
+	return result
+}
+
+func rejectedReasons(device *sys.LocalDisk) []string {
+	var reasons []string
+	if device.Readonly {
+		reasons = append(reasons, "read-only")
+	}
+	if len(device.Partitions) > 0 {
+		reasons = append(reasons, "has partitions")
+	}
+	if device.Filesystem != "" {
+		reasons = append(reasons, fmt.Sprintf("has a %s filesystem", device.Filesystem))
+	}
+	if !device.Empty && len(reasons) == 0 {
+		reasons = append(reasons, fmt.Sprintf("device type %q is not supported", device.Type))
+	}
+	return reasons
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileDeviceInventory(t *testing.T) {
+	ctx := context.TODO()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "local-device-node1",
+			Namespace: "rook-ceph",
+			UID:       "cm-uid",
+			Labels:    map[string]string{"app": "rook-discover", "rook.io/node": "node1"},
+		},
+		Data: map[string]string{"devices": `[
+{"name":"sda","type":"disk","vendor":"ATA","model":"SSD1","size":1000,"rotational":false,"empty":true,"devLinks":"/dev/disk/by-id/a /dev/disk/by-path/b","smartStatus":"PASSED"},
+{"name":"sdb","type":"disk","size":2000,"rotational":true,"filesystem":"ext4","empty":false},
+{"name":"sdc","type":"disk","size":3000,"empty":false,"cephVolumeData":"{\"path\":\"/dev/sdc\",\"available\":false,\"rejected_reasons\":[\"LVM detected\"],\"lvs\":[{\"name\":\"osd-block\",\"osd_id\":\"3\",\"cluster_fsid\":\"fsid\",\"type\":\"block\"},{\"name\":\"other\"}]}"}
+]`},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cm).WithStatusSubresource(&cephv1.CephDeviceInventory{}).Build()
+	r := &ReconcileDeviceInventory{client: cl, context: &clusterd.Context{Client: cl}, opManagerContext: ctx}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "rook-ceph", Name: "local-device-node1"}}
+
+	// the inventory of the node is created from the configmap
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	inventory := &cephv1.CephDeviceInventory{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "rook-ceph", Name: "node1"}, inventory))
+	assert.Equal(t, "node1", inventory.Spec.NodeName)
+	require.Len(t, inventory.OwnerReferences, 1)
+	assert.Equal(t, types.UID("cm-uid"), inventory.OwnerReferences[0].UID)
+	require.NotNil(t, inventory.Status)
+	assert.Equal(t, 3, inventory.Status.DeviceCount)
+	assert.Equal(t, 1, inventory.Status.AvailableCount)
+	require.Len(t, inventory.Status.Devices, 3)
+
+	sda := inventory.Status.Devices[0]
+	assert.True(t, sda.Available)
+	assert.Empty(t, sda.RejectedReasons)
+	assert.Equal(t, []string{"/dev/disk/by-id/a", "/dev/disk/by-path/b"}, sda.DevLinks)
+	assert.Equal(t, "SSD1", sda.Model)
+	assert.Equal(t, "PASSED", sda.SmartStatus)
+
+	sdb := inventory.Status.Devices[1]
+	assert.False(t, sdb.Available)
+	assert.Equal(t, []string{"has a ext4 filesystem"}, sdb.RejectedReasons)
+	assert.True(t, sdb.Rotational)
+
+	sdc := inventory.Status.Devices[2]
+	assert.False(t, sdc.Available)
+	assert.Equal(t, []string{"LVM detected"}, sdc.RejectedReasons)
+	assert.Equal(t, []cephv1.InventoryDeviceOSD{{ID: 3, ClusterFSID: "fsid", Type: "block"}}, sdc.OSDs)
+
+	// the inventory is updated when the devices change
+	cm.Data["devices"] = `[{"name":"sda","type":"disk","size":1000,"empty":true}]`
+	require.NoError(t, cl.Update(ctx, cm))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "rook-ceph", Name: "node1"}, inventory))
+	assert.Equal(t, 1, inventory.Status.DeviceCount)
+	assert.Equal(t, 1, inventory.Status.AvailableCount)
+
+	// nothing is reconciled once the configmap is deleted
+	require.NoError(t, cl.Delete(ctx, cm))
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+}
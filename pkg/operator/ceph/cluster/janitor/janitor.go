@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package janitor removes the resources left behind by the operator and the debug tools, which
+// are not owned by a running orchestration anymore.
+package janitor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	controllerName = "ceph-janitor"
+
+	intervalSetting = "ROOK_JANITOR_INTERVAL"
+	ttlSetting      = "ROOK_JANITOR_TTL"
+	defaultInterval = time.Hour
+	defaultTTL      = 24 * time.Hour
+
+	// the mon canaries are removed by the mon orchestration within minutes, the canaries older
+	// than the sleep of their pods were abandoned by an interrupted orchestration
+	canaryTTL = time.Hour
+
+	monCanaryLabelSelector = "app=rook-ceph-mon,mon_canary=true"
+	prepareLabelSelector   = "app=rook-ceph-osd-prepare"
+	debugDeploymentSuffix  = "-debug"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+// janitor removes the stale resources of the clusters
+type janitor struct {
+	context *clusterd.Context
+	// ttl is the age of the completed prepare jobs and the debug deployments that are removed
+	ttl time.Duration
+	now func() time.Time
+}
+
+// Add runs the janitor periodically while the operator is the leader
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		run(ctx, context)
+		return nil
+	}))
+}
+
+func run(ctx context.Context, context *clusterd.Context) {
+	for {
+		interval := durationSetting(intervalSetting, defaultInterval)
+		if interval <= 0 {
+			logger.Infof("the janitor is disabled by %q", intervalSetting)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		j := &janitor{context: context, ttl: durationSetting(ttlSetting, defaultTTL), now: time.Now}
+		if err := j.collect(ctx); err != nil {
+			logger.Errorf("failed to remove the stale resources. %v", err)
+		}
+	}
+}
+
+// durationSetting returns the duration of the operator setting, or the default when the setting
+// is not valid
+func durationSetting(name string, defaultValue time.Duration) time.Duration {
+	value := k8sutil.GetOperatorSetting(name, defaultValue.String())
+	if value == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warningf("invalid duration %q of setting %q, using the default %s. %v", value, name, defaultValue, err)
+		return defaultValue
+	}
+	return d
+}
+
+// collect removes the stale resources of all the clusters
+func (j *janitor) collect(ctx context.Context) error {
+	clusters, err := j.context.RookClientset.CephV1().CephClusters("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the ceph clusters")
+	}
+
+	namespaces := sets.New[string]()
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		namespaces.Insert(cluster.Namespace)
+		if cluster.DeletionTimestamp != nil {
+			// the resources are removed with the cluster
+			continue
+		}
+		j.collectCluster(ctx, cluster)
+	}
+
+	removed, err := csi.RemoveStaleClusterConfigs(ctx, j.context.Clientset, namespaces)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove the stale csi config entries")
+	}
+	for _, namespace := range removed {
+		collected.WithLabelValues(namespace, kindCSIConfigEntry).Inc()
+	}
+	return nil
+}
+
+// collectCluster removes the stale resources in the namespace of the cluster
+func (j *janitor) collectCluster(ctx context.Context, cluster *cephv1.CephCluster) {
+	collectors := []struct {
+		kind    string
+		collect func(context.Context, string) error
+	}{
+		{kindMonCanaryDeployment, j.collectMonCanaryDeployments},
+		{kindMonCanaryPVC, j.collectMonCanaryPVCs},
+		{kindDebugDeployment, j.collectDebugDeployments},
+		{kindOSDPrepareJob, j.collectPrepareJobs},
+	}
+	for _, c := range collectors {
+		if err := c.collect(ctx, cluster.Namespace); err != nil {
+			logger.Errorf("failed to remove the stale %s resources of cluster %q. %v", c.kind, cluster.Namespace, err)
+		}
+	}
+}
+
+// olderThan returns whether the object was created longer than the given duration ago
+func (j *janitor) olderThan(meta metav1.ObjectMeta, age time.Duration) bool {
+	return j.now().Sub(meta.CreationTimestamp.Time) > age
+}
+
+func (j *janitor) collectMonCanaryDeployments(ctx context.Context, namespace string) error {
+	deployments, err := j.context.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: monCanaryLabelSelector})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the mon canary deployments")
+	}
+	for _, d := range deployments.Items {
+		if !j.olderThan(d.ObjectMeta, canaryTTL) {
+			continue
+		}
+		logger.Infof("removing the abandoned mon canary deployment %q in namespace %q", d.Name, namespace)
+		if err := k8sutil.DeleteDeployment(ctx, j.context.Clientset, namespace, d.Name); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the mon canary deployment %q", d.Name)
+		}
+		collected.WithLabelValues(namespace, kindMonCanaryDeployment).Inc()
+	}
+	return nil
+}
+
+// collectMonCanaryPVCs removes the PVCs created for the canaries of mons that were never started.
+// The PVC of a canary is reattached to the mon once it starts, so the PVCs of the mons in the mon
+// endpoints or with a deployment are kept.
+func (j *janitor) collectMonCanaryPVCs(ctx context.Context, namespace string) error {
+	pvcs, err := j.context.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: monCanaryLabelSelector})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the mon canary pvcs")
+	}
+	if len(pvcs.Items) == 0 {
+		return nil
+	}
+
+	cm, err := j.context.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, opcontroller.EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		// the mons cannot be told apart from the abandoned canaries without the endpoints
+		return errors.Wrap(err, "failed to get the mon endpoints")
+	}
+	mons := opcontroller.ParseMonEndpoints(cm.Data[opcontroller.EndpointDataKey])
+
+	for _, pvc := range pvcs.Items {
+		if !j.olderThan(pvc.ObjectMeta, canaryTTL) {
+			continue
+		}
+		if _, ok := mons[pvc.Labels[opcontroller.DaemonIDLabel]]; ok {
+			continue
+		}
+		// the pvc is named after the mon deployment
+		_, err := j.context.Clientset.AppsV1().Deployments(namespace).Get(ctx, pvc.Name, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the mon deployment of pvc %q", pvc.Name)
+		}
+
+		logger.Infof("removing the abandoned mon canary pvc %q in namespace %q", pvc.Name, namespace)
+		if err := j.context.Clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the mon canary pvc %q", pvc.Name)
+		}
+		collected.WithLabelValues(namespace, kindMonCanaryPVC).Inc()
+	}
+	return nil
+}
+
+// collectDebugDeployments removes the debug deployments of the daemons that were left running
+// longer than the TTL, and scales the daemons stopped for the debugging back up
+func (j *janitor) collectDebugDeployments(ctx context.Context, namespace string) error {
+	deployments, err := j.context.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the deployments")
+	}
+	for _, d := range deployments.Items {
+		name, ok := strings.CutSuffix(d.Name, debugDeploymentSuffix)
+		if !ok || !strings.HasPrefix(d.Labels[k8sutil.AppAttr], "rook-ceph-") || !j.olderThan(d.ObjectMeta, j.ttl) {
+			continue
+		}
+
+		logger.Infof("removing the debug deployment %q in namespace %q", d.Name, namespace)
+		if err := k8sutil.DeleteDeployment(ctx, j.context.Clientset, namespace, d.Name); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the debug deployment %q", d.Name)
+		}
+		collected.WithLabelValues(namespace, kindDebugDeployment).Inc()
+
+		original, err := j.context.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get the deployment %q of debug deployment %q", name, d.Name)
+		}
+		if original.Spec.Replicas == nil || *original.Spec.Replicas != 0 {
+			continue
+		}
+		logger.Infof("scaling up deployment %q stopped for debugging in namespace %q", name, namespace)
+		replicas := int32(1)
+		original.Spec.Replicas = &replicas
+		if _, err := j.context.Clientset.AppsV1().Deployments(namespace).Update(ctx, original, metav1.UpdateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to scale up deployment %q", name)
+		}
+	}
+	return nil
+}
+
+// collectPrepareJobs removes the osd prepare jobs that completed longer than the TTL ago
+func (j *janitor) collectPrepareJobs(ctx context.Context, namespace string) error {
+	jobs, err := j.context.Clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: prepareLabelSelector})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the osd prepare jobs")
+	}
+	for _, job := range jobs.Items {
+		if job.Status.CompletionTime == nil || j.now().Sub(job.Status.CompletionTime.Time) <= j.ttl {
+			continue
+		}
+		logger.Infof("removing the osd prepare job %q completed at %s in namespace %q", job.Name, job.Status.CompletionTime, namespace)
+		propagation := metav1.DeletePropagationBackground
+		if err := j.context.Clientset.BatchV1().Jobs(namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the osd prepare job %q", job.Name)
+		}
+		collected.WithLabelValues(namespace, kindOSDPrepareJob).Inc()
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	rookfake "github.com/rook/rook/pkg/client/clientset/versioned/fake"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollect(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	old := metav1.NewTime(now.Add(-48 * time.Hour))
+	recent := metav1.NewTime(now.Add(-10 * time.Minute))
+	canaryLabels := func(id string) map[string]string {
+		return map[string]string{k8sutil.AppAttr: "rook-ceph-mon", "mon_canary": "true", "ceph_daemon_id": id}
+	}
+	meta := func(name string, created metav1.Time, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "ns", CreationTimestamp: created, Labels: labels}
+	}
+	replicas := func(n int32) *int32 { return &n }
+	job := func(name string, completed *metav1.Time) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: meta(name, old, map[string]string{k8sutil.AppAttr: "rook-ceph-osd-prepare"}),
+			Status:     batchv1.JobStatus{CompletionTime: completed},
+		}
+	}
+
+	objects := []runtime.Object{
+		&v1.ConfigMap{ObjectMeta: meta("rook-ceph-mon-endpoints", old, nil), Data: map[string]string{"data": "a=1.1.1.1:6789,b=2.2.2.2:6789"}},
+		// the canaries
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-mon-d-canary", old, canaryLabels("d"))},
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-mon-e-canary", recent, canaryLabels("e"))},
+		// the pvcs of the mons keep the canary labels
+		&v1.PersistentVolumeClaim{ObjectMeta: meta("rook-ceph-mon-a", old, canaryLabels("a"))},
+		&v1.PersistentVolumeClaim{ObjectMeta: meta("rook-ceph-mon-c", old, canaryLabels("c"))},
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-mon-c", old, map[string]string{k8sutil.AppAttr: "rook-ceph-mon"})},
+		&v1.PersistentVolumeClaim{ObjectMeta: meta("rook-ceph-mon-d", old, canaryLabels("d"))},
+		&v1.PersistentVolumeClaim{ObjectMeta: meta("rook-ceph-mon-e", recent, canaryLabels("e"))},
+		// the debug deployments
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-osd-0", old, map[string]string{k8sutil.AppAttr: "rook-ceph-osd"}), Spec: appsv1.DeploymentSpec{Replicas: replicas(0)}},
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-osd-0-debug", old, map[string]string{k8sutil.AppAttr: "rook-ceph-osd"})},
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-osd-1", old, map[string]string{k8sutil.AppAttr: "rook-ceph-osd"}), Spec: appsv1.DeploymentSpec{Replicas: replicas(0)}},
+		&appsv1.Deployment{ObjectMeta: meta("rook-ceph-osd-1-debug", recent, map[string]string{k8sutil.AppAttr: "rook-ceph-osd"})},
+		&appsv1.Deployment{ObjectMeta: meta("myapp-debug", old, map[string]string{k8sutil.AppAttr: "myapp"})},
+		// the prepare jobs
+		job("rook-ceph-osd-prepare-node1", &old),
+		job("rook-ceph-osd-prepare-node2", &recent),
+		job("rook-ceph-osd-prepare-node3", nil),
+	}
+	clientset := fake.NewSimpleClientset(objects...)
+	rookClientset := rookfake.NewSimpleClientset(
+		&cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ns"}},
+	)
+	j := &janitor{
+		context: &clusterd.Context{Clientset: clientset, RookClientset: rookClientset},
+		ttl:     24 * time.Hour,
+		now:     func() time.Time { return now },
+	}
+	require.NoError(t, j.collect(ctx))
+
+	exists := func(err error) bool {
+		if err != nil {
+			require.True(t, kerrors.IsNotFound(err), err)
+			return false
+		}
+		return true
+	}
+	deploymentExists := func(name string) bool {
+		_, err := clientset.AppsV1().Deployments("ns").Get(ctx, name, metav1.GetOptions{})
+		return exists(err)
+	}
+	pvcExists := func(name string) bool {
+		_, err := clientset.CoreV1().PersistentVolumeClaims("ns").Get(ctx, name, metav1.GetOptions{})
+		return exists(err)
+	}
+	jobExists := func(name string) bool {
+		_, err := clientset.BatchV1().Jobs("ns").Get(ctx, name, metav1.GetOptions{})
+		return exists(err)
+	}
+
+	// only the abandoned canaries are removed
+	assert.False(t, deploymentExists("rook-ceph-mon-d-canary"))
+	assert.True(t, deploymentExists("rook-ceph-mon-e-canary"))
+	assert.True(t, pvcExists("rook-ceph-mon-a"))
+	assert.True(t, pvcExists("rook-ceph-mon-c"))
+	assert.False(t, pvcExists("rook-ceph-mon-d"))
+	assert.True(t, pvcExists("rook-ceph-mon-e"))
+
+	// the old debug deployment is removed and its osd is started
+	assert.False(t, deploymentExists("rook-ceph-osd-0-debug"))
+	osd, err := clientset.AppsV1().Deployments("ns").Get(ctx, "rook-ceph-osd-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *osd.Spec.Replicas)
+	assert.True(t, deploymentExists("rook-ceph-osd-1-debug"))
+	osd, err = clientset.AppsV1().Deployments("ns").Get(ctx, "rook-ceph-osd-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *osd.Spec.Replicas)
+	assert.True(t, deploymentExists("myapp-debug"))
+
+	// only the jobs completed before the ttl are removed
+	assert.False(t, jobExists("rook-ceph-osd-prepare-node1"))
+	assert.True(t, jobExists("rook-ceph-osd-prepare-node2"))
+	assert.True(t, jobExists("rook-ceph-osd-prepare-node3"))
+}
+
+func TestCollectDeletedCluster(t *testing.T) {
+	ctx := context.TODO()
+	old := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	canary := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name: "rook-ceph-mon-d-canary", Namespace: "ns", CreationTimestamp: old,
+		Labels: map[string]string{k8sutil.AppAttr: "rook-ceph-mon", "mon_canary": "true"},
+	}}
+	clientset := fake.NewSimpleClientset(canary)
+	rookClientset := rookfake.NewSimpleClientset(
+		&cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ns", DeletionTimestamp: &old, Finalizers: []string{"cephcluster.ceph.rook.io"}}},
+	)
+	j := &janitor{
+		context: &clusterd.Context{Clientset: clientset, RookClientset: rookClientset},
+		ttl:     24 * time.Hour,
+		now:     time.Now,
+	}
+
+	// the resources of a cluster being deleted are left to the cleanup of the cluster
+	require.NoError(t, j.collect(ctx))
+	_, err := clientset.AppsV1().Deployments("ns").Get(ctx, canary.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestDurationSetting(t *testing.T) {
+	assert.Equal(t, time.Hour, durationSetting(intervalSetting, time.Hour))
+	t.Setenv(intervalSetting, "10m")
+	assert.Equal(t, 10*time.Minute, durationSetting(intervalSetting, time.Hour))
+	t.Setenv(intervalSetting, "0")
+	assert.Equal(t, time.Duration(0), durationSetting(intervalSetting, time.Hour))
+	t.Setenv(intervalSetting, "invalid")
+	assert.Equal(t, time.Hour, durationSetting(intervalSetting, time.Hour))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "rook_ceph"
+	metricsSubsystem = "janitor"
+
+	kindMonCanaryDeployment = "mon-canary-deployment"
+	kindMonCanaryPVC        = "mon-canary-pvc"
+	kindDebugDeployment     = "debug-deployment"
+	kindOSDPrepareJob       = "osd-prepare-job"
+	kindCSIConfigEntry      = "csi-config-entry"
+)
+
+// The metrics of the janitor, exposed on the metrics endpoint of the operator
+var collected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsSubsystem,
+	Name:      "collected_total",
+	Help:      "Number of stale resources removed by the janitor by kind",
+}, []string{"namespace", "kind"})
+
+func init() {
+	metrics.Registry.MustRegister(collected)
+}
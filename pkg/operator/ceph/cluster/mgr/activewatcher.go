@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mgr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// activeMgrFollowUpInterval is the interval to check the active mgr after a change of the mgr
+	// pods, until the failover completes
+	activeMgrFollowUpInterval = 2 * time.Second
+	// activeMgrFollowUpPeriod is how long the active mgr is checked after a change of the mgr pods
+	activeMgrFollowUpPeriod = 30 * time.Second
+	// activeMgrResyncInterval is the interval to check the active mgr without a change of the mgr
+	// pods, for the failovers of the mgr daemons that do not restart their pod
+	activeMgrResyncInterval = time.Minute
+	// activeMgrWatchRetryInterval is the interval to watch again the mgr pods when the watch failed
+	activeMgrWatchRetryInterval = 5 * time.Second
+
+	// hook for tests to override
+	getMgrMap = func(w *ActiveMgrWatcher) (*cephclient.MgrMap, error) {
+		return cephclient.CephMgrMap(w.context, w.clusterInfo)
+	}
+)
+
+// ActiveMgrWatcher watches the mgr pods to follow the failovers of the active mgr. The mgr pods
+// are relabeled as soon as the active mgr changes so that the services of the mgr modules select
+// the new active mgr, and the active mgr is reported in the CephCluster status.
+type ActiveMgrWatcher struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	status      cephv1.MgrStatus
+	// the status last reported in the CephCluster
+	reported *cephv1.MgrStatus
+}
+
+// NewActiveMgrWatcher creates a watcher of the active mgr
+func NewActiveMgrWatcher(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) *ActiveMgrWatcher {
+	return &ActiveMgrWatcher{
+		context:     context,
+		clusterInfo: clusterInfo,
+	}
+}
+
+// Watch follows the active mgr until the monitoring routine is cancelled
+func (w *ActiveMgrWatcher) Watch(monitoringRoutines map[string]*controller.ClusterHealth, daemon string) {
+	w.loadStatus(monitoringRoutines[daemon].InternalCtx)
+	for {
+		// We must perform this check otherwise the case will check an index that does not exist anymore and
+		// we will get an invalid pointer error and the go routine will panic
+		if _, ok := monitoringRoutines[daemon]; !ok {
+			logger.Infof("ceph cluster %q has been deleted. stopping the watch of the active mgr", w.clusterInfo.Namespace)
+			return
+		}
+		if err := w.watchPods(monitoringRoutines[daemon].InternalCtx); err != nil {
+			logger.Warningf("failed to watch the mgr pods. %v", err)
+		}
+		select {
+		case <-monitoringRoutines[daemon].InternalCtx.Done():
+			logger.Infof("stopping the watch of the active mgr")
+			delete(monitoringRoutines, daemon)
+			return
+
+		case <-time.After(activeMgrWatchRetryInterval):
+		}
+	}
+}
+
+// loadStatus starts from the active mgr and the failovers reported in the CephCluster status
+func (w *ActiveMgrWatcher) loadStatus(ctx context.Context) {
+	cephCluster := &cephv1.CephCluster{}
+	if err := w.context.Client.Get(ctx, w.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to load the active mgr. %v", err)
+		return
+	}
+	if cephCluster.Status.Mgr != nil {
+		w.status = *cephCluster.Status.Mgr.DeepCopy()
+		w.reported = cephCluster.Status.Mgr.DeepCopy()
+	}
+}
+
+// watchPods checks the active mgr on every change of the mgr pods, and follows up until the
+// failover completes since the new active mgr is elected after the pod of the former active mgr
+// stopped. It returns when the watch is closed or the context is cancelled.
+func (w *ActiveMgrWatcher) watchPods(ctx context.Context) error {
+	watcher, err := w.context.Clientset.CoreV1().Pods(w.clusterInfo.Namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to start the watch of the mgr pods")
+	}
+	defer watcher.Stop()
+
+	ticker := time.NewTicker(activeMgrFollowUpInterval)
+	defer ticker.Stop()
+	w.checkActiveMgr(ctx)
+	lastCheck := time.Now()
+	var followUntil time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case _, ok := <-watcher.ResultChan():
+			if !ok {
+				logger.Debugf("watch of the mgr pods closed, watching again")
+				return nil
+			}
+			followUntil = time.Now().Add(activeMgrFollowUpPeriod)
+			w.checkActiveMgr(ctx)
+			lastCheck = time.Now()
+
+		case now := <-ticker.C:
+			if now.Before(followUntil) || now.Sub(lastCheck) >= activeMgrResyncInterval {
+				w.checkActiveMgr(ctx)
+				lastCheck = now
+			}
+		}
+	}
+}
+
+// checkActiveMgr relabels the mgr pods when the active mgr changed, and reports the active mgr
+func (w *ActiveMgrWatcher) checkActiveMgr(ctx context.Context) {
+	mgrMap, err := getMgrMap(w)
+	if err != nil {
+		logger.Warningf("failed to get the active mgr. %v", err)
+		return
+	}
+	if mgrMap.ActiveName == "" {
+		// no mgr is active during the election of the new active mgr
+		logger.Debugf("no active mgr")
+		return
+	}
+
+	if w.status.ActiveMgr != "" && w.status.ActiveMgr != mgrMap.ActiveName {
+		logger.Infof("active mgr failed over from %q to %q", w.status.ActiveMgr, mgrMap.ActiveName)
+		w.status.FailoverCount++
+		now := metav1.Now()
+		w.status.LastFailoverTime = &now
+	}
+	w.status.ActiveMgr = mgrMap.ActiveName
+	w.status.Standbys = nil
+	for _, standby := range mgrMap.Standbys {
+		w.status.Standbys = append(w.status.Standbys, standby.Name)
+	}
+	sort.Strings(w.status.Standbys)
+	w.status.Services = nil
+	if len(mgrMap.Services) > 0 {
+		w.status.Services = mgrMap.Services
+	}
+
+	if err := w.setMgrRoleLabels(ctx, mgrMap.ActiveName); err != nil {
+		logger.Warningf("failed to label the active mgr %q. %v", mgrMap.ActiveName, err)
+	}
+	w.reportStatus(ctx)
+}
+
+// setMgrRoleLabels labels the pods of the active mgr as active and the pods of the other mgrs as
+// standby, so that the services of the mgr modules select the active mgr
+func (w *ActiveMgrWatcher) setMgrRoleLabels(ctx context.Context, activeMgr string) error {
+	pods, err := w.context.Clientset.CoreV1().Pods(w.clusterInfo.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the mgr pods")
+	}
+
+	var podLabelUpdErr error
+	for i, pod := range pods.Items {
+		role := standbyMgrStatus
+		if pod.Labels[controller.DaemonIDLabel] == activeMgr {
+			role = activeMgrStatus
+		}
+		if pod.Labels[mgrRoleLabelName] == role {
+			continue
+		}
+		logger.Infof("updating %s label of mgr pod %q to %q. active mgr is %q", mgrRoleLabelName, pod.Name, role, activeMgr)
+		if pods.Items[i].Labels == nil {
+			pods.Items[i].Labels = map[string]string{}
+		}
+		pods.Items[i].Labels[mgrRoleLabelName] = role
+		if _, err := w.context.Clientset.CoreV1().Pods(w.clusterInfo.Namespace).Update(ctx, &pods.Items[i], metav1.UpdateOptions{}); err != nil {
+			// try to update the other pods first, the pod is updated again on the next check
+			podLabelUpdErr = errors.Wrapf(err, "failed to update mgr pod %q", pod.Name)
+		}
+	}
+	return podLabelUpdErr
+}
+
+// reportStatus publishes the active mgr on the CephCluster status when it changed
+func (w *ActiveMgrWatcher) reportStatus(ctx context.Context) {
+	if w.reported != nil && reflect.DeepEqual(w.status, *w.reported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := w.context.Client.Get(ctx, w.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the active mgr. %v", err)
+		return
+	}
+	cephCluster.Status.Mgr = w.status.DeepCopy()
+	if err := reporting.UpdateStatus(w.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the active mgr in the CephCluster status. %v", err)
+		return
+	}
+	w.reported = w.status.DeepCopy()
+}
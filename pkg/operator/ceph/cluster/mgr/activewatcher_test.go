@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mgr
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckActiveMgr(t *testing.T) {
+	mgrMap := &cephclient.MgrMap{
+		ActiveName: "a",
+		Standbys:   []cephclient.MgrStandby{{Name: "b"}},
+		Services:   map[string]string{"dashboard": "https://10.0.0.1:8443/"},
+	}
+	originalGetMgrMap := getMgrMap
+	t.Cleanup(func() { getMgrMap = originalGetMgrMap })
+	getMgrMap = func(w *ActiveMgrWatcher) (*cephclient.MgrMap, error) { return mgrMap, nil }
+
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	mgrPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rook-ceph-mgr-" + name,
+			Namespace: clusterInfo.Namespace,
+			// the pods are created with the active label
+			Labels: map[string]string{k8sutil.AppAttr: AppName, controller.DaemonIDLabel: name, mgrRoleLabelName: activeMgrStatus},
+		}}
+	}
+	clientset := k8sfake.NewSimpleClientset(mgrPod("a"), mgrPod("b"))
+	w := NewActiveMgrWatcher(&clusterd.Context{Client: cl, Clientset: clientset}, clusterInfo)
+	w.loadStatus(context.TODO())
+
+	assertRoles := func(roleA, roleB string) {
+		for name, role := range map[string]string{"a": roleA, "b": roleB} {
+			pod, err := clientset.CoreV1().Pods(clusterInfo.Namespace).Get(context.TODO(), "rook-ceph-mgr-"+name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, role, pod.Labels[mgrRoleLabelName], name)
+		}
+	}
+
+	// the standby mgr is relabeled and the active mgr is reported
+	w.checkActiveMgr(context.TODO())
+	assertRoles(activeMgrStatus, standbyMgrStatus)
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	require.NotNil(t, cephCluster.Status.Mgr)
+	assert.Equal(t, "a", cephCluster.Status.Mgr.ActiveMgr)
+	assert.Equal(t, []string{"b"}, cephCluster.Status.Mgr.Standbys)
+	assert.Equal(t, mgrMap.Services, cephCluster.Status.Mgr.Services)
+	assert.Equal(t, 0, cephCluster.Status.Mgr.FailoverCount)
+	assert.Nil(t, cephCluster.Status.Mgr.LastFailoverTime)
+
+	// no failover is counted while the new active mgr is elected
+	mgrMap = &cephclient.MgrMap{}
+	w.checkActiveMgr(context.TODO())
+	assertRoles(activeMgrStatus, standbyMgrStatus)
+
+	// the failover is counted and the mgrs are relabeled
+	mgrMap = &cephclient.MgrMap{ActiveName: "b", Standbys: []cephclient.MgrStandby{{Name: "a"}}}
+	w.checkActiveMgr(context.TODO())
+	assertRoles(standbyMgrStatus, activeMgrStatus)
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	assert.Equal(t, "b", cephCluster.Status.Mgr.ActiveMgr)
+	assert.Equal(t, []string{"a"}, cephCluster.Status.Mgr.Standbys)
+	assert.Empty(t, cephCluster.Status.Mgr.Services)
+	assert.Equal(t, 1, cephCluster.Status.Mgr.FailoverCount)
+	assert.NotNil(t, cephCluster.Status.Mgr.LastFailoverTime)
+
+	// the failovers are counted from the reported status after a restart of the operator
+	w = NewActiveMgrWatcher(&clusterd.Context{Client: cl, Clientset: clientset}, clusterInfo)
+	w.loadStatus(context.TODO())
+	mgrMap = &cephclient.MgrMap{ActiveName: "a", Standbys: []cephclient.MgrStandby{{Name: "b"}}}
+	w.checkActiveMgr(context.TODO())
+	assertRoles(activeMgrStatus, standbyMgrStatus)
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	assert.Equal(t, 2, cephCluster.Status.Mgr.FailoverCount)
+}
@@ -19,6 +19,7 @@ package mgr
 import (
 	"fmt"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/operator/ceph/config"
 	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -46,32 +47,41 @@ type mgrConfig struct {
 
 // dashboardInternalPort gets the port to be used by the service targetPort
 // and the container ports on the mgr pod.
+func (c *Cluster) dashboardInternalPort() int {
+	return DashboardInternalPort(c.spec.Dashboard)
+}
+
+// dashboardPublicPort is the desired port to be exposed on the service
+func (c *Cluster) dashboardPublicPort() int {
+	return dashboardPublicPort(c.spec.Dashboard)
+}
+
+// DashboardInternalPort gets the port the dashboard listens on in the mgr pod.
 // If the port is greater than 1024, for backward compatibility the port and
 // targetPort should be the same value. If the port is less than 1024,
 // the internal port must use a higher port number. In that case, the internal
 // port will be the default port numbers and only the public port will be
 // the desired port in the cluster CR.
-func (c *Cluster) dashboardInternalPort() int {
-	port := c.dashboardPublicPort()
+func DashboardInternalPort(dashboard cephv1.DashboardSpec) int {
+	port := dashboardPublicPort(dashboard)
 	if port <= minPortWithoutPrivileges {
 		// If the port is less than the allowed range, set it back to the default
-		return c.dashboardDefaultPort()
+		return dashboardDefaultPort(dashboard)
 	}
 	return port
 }
 
-// dashboardPublicPort is the desired port to be exposed on the service
-func (c *Cluster) dashboardPublicPort() int {
-	if c.spec.Dashboard.Port == 0 {
-		return c.dashboardDefaultPort()
+func dashboardPublicPort(dashboard cephv1.DashboardSpec) int {
+	if dashboard.Port == 0 {
+		return dashboardDefaultPort(dashboard)
 	}
 	// crd validates port >= 0
-	return c.spec.Dashboard.Port
+	return dashboard.Port
 }
 
-func (c *Cluster) dashboardDefaultPort() int {
+func dashboardDefaultPort(dashboard cephv1.DashboardSpec) int {
 	// default port for HTTP/HTTPS
-	if c.spec.Dashboard.SSL {
+	if dashboard.SSL {
 		return dashboardPortHTTPS
 	}
 	return dashboardPortHTTP
@@ -105,6 +105,12 @@ func (c *Cluster) configureDashboardModules() error {
 	if err != nil {
 		return err
 	}
+
+	// the users of the spec are stored by the dashboard module and do not require a restart
+	if err := c.configureDashboardUsers(); err != nil {
+		logger.Errorf("failed to configure the dashboard users. %v", err)
+	}
+
 	if secureRequiresRestart || configChanged {
 		logger.Info("dashboard config has changed. restarting the dashboard module")
 		return c.restartMgrModule(dashboardModuleName)
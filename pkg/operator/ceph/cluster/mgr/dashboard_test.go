@@ -32,6 +32,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestGeneratePassword(t *testing.T) {
@@ -119,8 +121,13 @@ func TestStartSecureDashboard(t *testing.T) {
 		OwnerInfo:   ownerInfo,
 		Context:     ctx,
 	}
+	clusterInfo.SetName("testing")
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: clusterInfo.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
 	c := &Cluster{
-		clusterInfo: clusterInfo, context: &clusterd.Context{Clientset: clientset, Executor: executor},
+		clusterInfo: clusterInfo, context: &clusterd.Context{Clientset: clientset, Client: cl, Executor: executor},
 		spec: cephv1.ClusterSpec{
 			Dashboard:   cephv1.DashboardSpec{Port: 443, Enabled: true, SSL: true},
 			CephVersion: cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v15"},
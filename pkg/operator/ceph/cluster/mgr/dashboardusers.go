@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mgr
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util"
+	"github.com/rook/rook/pkg/util/exec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dashboardRole is the output of the "ceph dashboard ac-role-show" command
+type dashboardRole struct {
+	ScopesPermissions map[string][]string `json:"scopes_permissions"`
+}
+
+// configureDashboardUsers creates or updates the dashboard users and custom roles of the spec, and
+// deletes the ones managed by the operator that were removed from the spec. The users and roles
+// created manually are left unchanged. The users and roles managed by the operator are reported in
+// the CephCluster status.
+func (c *Cluster) configureDashboardUsers() error {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		return errors.Wrap(err, "failed to get the CephCluster to load the dashboard users")
+	}
+	managed := &cephv1.DashboardStatus{}
+	if cephCluster.Status.Dashboard != nil {
+		managed = cephCluster.Status.Dashboard
+	}
+	status := &cephv1.DashboardStatus{}
+	failed := 0
+
+	// the roles are configured before the users that reference them
+	for _, role := range c.spec.Dashboard.Roles {
+		if err := c.configureDashboardRole(role); err != nil {
+			return errors.Wrapf(err, "failed to configure dashboard role %q", role.Name)
+		}
+		status.Roles = append(status.Roles, role.Name)
+	}
+
+	for _, user := range c.spec.Dashboard.Users {
+		userStatus := cephv1.DashboardUserStatus{Name: user.Name, Role: user.Role}
+		if err := c.configureDashboardUser(user); err != nil {
+			logger.Errorf("failed to configure dashboard user %q. %v", user.Name, err)
+			userStatus.Message = err.Error()
+			failed++
+		}
+		status.Users = append(status.Users, userStatus)
+	}
+
+	for _, user := range managed.Users {
+		if slices.ContainsFunc(c.spec.Dashboard.Users, func(u cephv1.DashboardUserSpec) bool { return u.Name == user.Name }) {
+			continue
+		}
+		logger.Infof("deleting dashboard user %q removed from the spec", user.Name)
+		if _, err := c.runDashboardCommand("ac-user-delete", user.Name); err != nil {
+			logger.Errorf("failed to delete dashboard user %q. %v", user.Name, err)
+			user.Message = err.Error()
+			status.Users = append(status.Users, user)
+			failed++
+		}
+	}
+
+	for _, role := range managed.Roles {
+		if slices.ContainsFunc(c.spec.Dashboard.Roles, func(r cephv1.DashboardRoleSpec) bool { return r.Name == role }) {
+			continue
+		}
+		logger.Infof("deleting dashboard role %q removed from the spec", role)
+		if _, err := c.runDashboardCommand("ac-role-delete", role); err != nil {
+			logger.Errorf("failed to delete dashboard role %q. %v", role, err)
+			status.Roles = append(status.Roles, role)
+			failed++
+		}
+	}
+
+	c.reportDashboardStatus(cephCluster, status)
+	if failed > 0 {
+		return errors.Errorf("failed to configure %d dashboard users or roles", failed)
+	}
+	return nil
+}
+
+// configureDashboardRole creates the custom role if it does not exist and sets its permissions
+func (c *Cluster) configureDashboardRole(role cephv1.DashboardRoleSpec) error {
+	current := dashboardRole{}
+	output, err := c.runDashboardCommand("ac-role-show", role.Name)
+	if err != nil {
+		logger.Infof("creating dashboard role %q", role.Name)
+		args := []string{"ac-role-create", role.Name}
+		if role.Description != "" {
+			args = append(args, role.Description)
+		}
+		if _, err := c.runDashboardCommand(args...); err != nil {
+			return errors.Wrap(err, "failed to create role")
+		}
+	} else if err := json.Unmarshal(output, &current); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal role %q. %s", role.Name, string(output))
+	}
+
+	for _, scope := range role.Scopes {
+		permissions := make([]string, 0, len(scope.Permissions))
+		for _, permission := range scope.Permissions {
+			permissions = append(permissions, string(permission))
+		}
+		if sameElements(permissions, current.ScopesPermissions[scope.Scope]) {
+			continue
+		}
+		logger.Infof("setting the permissions %v of dashboard role %q on scope %q", permissions, role.Name, scope.Scope)
+		if _, err := c.runDashboardCommand(append([]string{"ac-role-add-scope-perms", role.Name, scope.Scope}, permissions...)...); err != nil {
+			return errors.Wrapf(err, "failed to set the permissions on scope %q", scope.Scope)
+		}
+	}
+
+	for scope := range current.ScopesPermissions {
+		if slices.ContainsFunc(role.Scopes, func(s cephv1.DashboardRoleScope) bool { return s.Scope == scope }) {
+			continue
+		}
+		logger.Infof("removing the permissions of dashboard role %q on scope %q", role.Name, scope)
+		if _, err := c.runDashboardCommand("ac-role-del-scope-perms", role.Name, scope); err != nil {
+			return errors.Wrapf(err, "failed to remove the permissions on scope %q", scope)
+		}
+	}
+	return nil
+}
+
+// configureDashboardUser creates the user if it does not exist, and sets its password from the
+// secret and its role
+func (c *Cluster) configureDashboardUser(user cephv1.DashboardUserSpec) error {
+	secret, err := c.context.Clientset.CoreV1().Secrets(c.clusterInfo.Namespace).Get(c.clusterInfo.Context, user.PasswordSecret.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the password secret %q", user.PasswordSecret.Name)
+	}
+	password, ok := secret.Data[user.PasswordSecret.Key]
+	if !ok || len(password) == 0 {
+		return errors.Errorf("password not found in key %q of secret %q", user.PasswordSecret.Key, user.PasswordSecret.Name)
+	}
+
+	file, err := util.CreateTempFile(string(password))
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary dashboard password file")
+	}
+	defer func() {
+		if err := os.Remove(file.Name()); err != nil {
+			logger.Errorf("failed to clean up dashboard password file %q. %v", file.Name(), err)
+		}
+	}()
+
+	// the user is created if it does not exist, the password and the role of an existing user are
+	// set by the following commands
+	if _, err := c.runDashboardCommand("ac-user-create", user.Name, "-i", file.Name(), user.Role); err != nil {
+		return errors.Wrap(err, "failed to create user")
+	}
+	if _, err := c.runDashboardCommand("ac-user-set-password", user.Name, "-i", file.Name()); err != nil {
+		return errors.Wrap(err, "failed to set password")
+	}
+	if _, err := c.runDashboardCommand("ac-user-set-roles", user.Name, user.Role); err != nil {
+		return errors.Wrapf(err, "failed to set role %q", user.Role)
+	}
+	logger.Debugf("configured dashboard user %q with role %q", user.Name, user.Role)
+	return nil
+}
+
+// reportDashboardStatus publishes the dashboard users and roles managed by the operator on the
+// CephCluster status when they changed
+func (c *Cluster) reportDashboardStatus(cephCluster *cephv1.CephCluster, status *cephv1.DashboardStatus) {
+	if len(status.Users) == 0 && len(status.Roles) == 0 {
+		status = nil
+	}
+	if reflect.DeepEqual(status, cephCluster.Status.Dashboard) {
+		return
+	}
+	cephCluster.Status.Dashboard = status
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the dashboard users in the CephCluster status. %v", err)
+	}
+}
+
+func (c *Cluster) runDashboardCommand(args ...string) ([]byte, error) {
+	return client.NewCephCommand(c.context, c.clusterInfo, append([]string{"dashboard"}, args...)).RunWithTimeout(exec.CephCommandsTimeout)
+}
+
+// sameElements returns whether the lists have the same elements regardless of their order
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mgr
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigureDashboardUsers(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard-users", Namespace: nsName.Namespace},
+		Data:       map[string][]byte{"alice": []byte("secret")},
+	}
+	clientset := k8sfake.NewSimpleClientset(secret)
+
+	roleScopes := `{"name":"pool-viewer","scopes_permissions":{"pool":["read"],"rbd-image":["read"]}}`
+	roleExists := false
+	var commands []string
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithTimeout = func(timeout time.Duration, command string, args ...string) (string, error) {
+		// ignore the standard flags of the ceph commands
+		args = args[:slices.IndexFunc(args, func(arg string) bool { return strings.HasPrefix(arg, "--") })]
+		if args[0] != "dashboard" {
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		}
+		if args[1] == "ac-role-show" {
+			if roleExists {
+				return roleScopes, nil
+			}
+			return "", errors.New("role does not exist")
+		}
+		// the path of the password file is not deterministic
+		if len(args) > 3 && args[3] == "-i" {
+			args[4] = "<file>"
+		}
+		commands = append(commands, strings.Join(args[1:], " "))
+		return "", nil
+	}
+	c := &Cluster{
+		clusterInfo: clusterInfo,
+		context:     &clusterd.Context{Clientset: clientset, Client: cl, Executor: executor},
+		spec: cephv1.ClusterSpec{Dashboard: cephv1.DashboardSpec{
+			Roles: []cephv1.DashboardRoleSpec{{Name: "pool-viewer", Description: "views pools", Scopes: []cephv1.DashboardRoleScope{
+				{Scope: "pool", Permissions: []cephv1.DashboardPermission{"read"}},
+			}}},
+			Users: []cephv1.DashboardUserSpec{
+				{Name: "alice", Role: "pool-viewer", PasswordSecret: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "dashboard-users"}, Key: "alice"}},
+				{Name: "bob", Role: "read-only", PasswordSecret: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "dashboard-users"}, Key: "bob"}},
+			},
+		}},
+	}
+	status := func() *cephv1.DashboardStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(ctx, nsName, cluster))
+		return cluster.Status.Dashboard
+	}
+
+	// the role is created before the users, and the user without password is reported
+	err := c.configureDashboardUsers()
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"ac-role-create pool-viewer views pools",
+		"ac-role-add-scope-perms pool-viewer pool read",
+		"ac-user-create alice -i <file> pool-viewer",
+		"ac-user-set-password alice -i <file>",
+		"ac-user-set-roles alice pool-viewer",
+	}, commands)
+	require.NotNil(t, status())
+	assert.Equal(t, []string{"pool-viewer"}, status().Roles)
+	require.Len(t, status().Users, 2)
+	assert.Equal(t, cephv1.DashboardUserStatus{Name: "alice", Role: "pool-viewer"}, status().Users[0])
+	assert.Contains(t, status().Users[1].Message, `password not found in key "bob"`)
+
+	// the permissions of the existing role on the scopes removed from the spec are removed, and the
+	// user removed from the spec is deleted
+	roleExists = true
+	commands = nil
+	c.spec.Dashboard.Users = c.spec.Dashboard.Users[:1]
+	require.NoError(t, c.configureDashboardUsers())
+	assert.Equal(t, []string{
+		"ac-role-del-scope-perms pool-viewer rbd-image",
+		"ac-user-create alice -i <file> pool-viewer",
+		"ac-user-set-password alice -i <file>",
+		"ac-user-set-roles alice pool-viewer",
+		"ac-user-delete bob",
+	}, commands)
+	require.Len(t, status().Users, 1)
+
+	// the users and roles removed from the spec are deleted and the status is cleared
+	commands = nil
+	c.spec.Dashboard = cephv1.DashboardSpec{}
+	require.NoError(t, c.configureDashboardUsers())
+	assert.Equal(t, []string{"ac-user-delete alice", "ac-role-delete pool-viewer"}, commands)
+	assert.Nil(t, status())
+}
@@ -54,10 +54,13 @@ const (
 	monitoringPath            = "/etc/ceph-monitoring/"
 	serviceMonitorFile        = "service-monitor.yaml"
 	serviceMonitorPort        = "http-metrics"
+	prometheusServerAddr      = "mgr/prometheus/server_addr"
 	// minimum amount of memory in MB to run the pod
 	cephMgrPodMinimumMemory uint64 = 512
 	// DefaultMetricsPort prometheus exporter port
 	DefaultMetricsPort uint16 = 9283
+	// DefaultMetricsTLSPort is the port of the proxy serving the prometheus exporter over TLS
+	DefaultMetricsTLSPort uint16 = 9284
 )
 
 // Cluster represents the Rook and environment configuration settings needed to set up Ceph mgrs.
@@ -415,6 +418,7 @@ func (c *Cluster) configurePrometheusModule() error {
 		err                error
 		portHasChanged     bool
 		intervalHasChanged bool
+		addrHasChanged     bool
 		daemonID           = "mgr"
 	)
 	monStore := config.GetMonStore(c.context, c.clusterInfo)
@@ -436,14 +440,53 @@ func (c *Cluster) configurePrometheusModule() error {
 		}
 		logger.Infof("prometheus config will change, interval: %v", interval)
 	}
+	// address
+	addrHasChanged, err = c.configurePrometheusServerAddr(monStore, daemonID)
+	if err != nil {
+		return err
+	}
 
-	if portHasChanged || intervalHasChanged {
+	if portHasChanged || intervalHasChanged || addrHasChanged {
 		logger.Info("prometheus config has changed. restarting the prometheus module")
 		return c.restartMgrModule(PrometheusModuleName)
 	}
 	return nil
 }
 
+// configurePrometheusServerAddr binds the prometheus module to the localhost when the metrics are
+// served over TLS by the proxy sidecar, and restores the default address otherwise
+func (c *Cluster) configurePrometheusServerAddr(monStore *config.MonStore, daemonID string) (bool, error) {
+	if controller.MetricsTLSEnabled(c.spec.Monitoring) {
+		addrHasChanged, err := monStore.SetIfChanged(daemonID, prometheusServerAddr, controller.MetricsUpstreamAddr)
+		if err != nil {
+			return false, err
+		}
+		if addrHasChanged {
+			logger.Infof("prometheus config will change, address: %s", controller.MetricsUpstreamAddr)
+		}
+		return addrHasChanged, nil
+	}
+
+	addr, err := monStore.Get(daemonID, prometheusServerAddr)
+	if err != nil || addr != controller.MetricsUpstreamAddr {
+		// the address was not set by rook
+		return false, nil
+	}
+	if err := monStore.Delete(daemonID, prometheusServerAddr); err != nil {
+		return false, err
+	}
+	logger.Info("prometheus config will change, the metrics are no longer served over TLS")
+	return true, nil
+}
+
+// metricsPort returns the port the prometheus module serves the metrics on
+func (c *Cluster) metricsPort() uint16 {
+	if c.spec.Monitoring.Port != 0 {
+		return uint16(c.spec.Monitoring.Port) // nolint:gosec // G115 the port is validated by the CRD
+	}
+	return DefaultMetricsPort
+}
+
 func (c *Cluster) restartMgrModule(name string) error {
 	logger.Infof("restarting the mgr module: %s", name)
 	if err := cephclient.MgrDisableModule(c.context, c.clusterInfo, name); err != nil {
@@ -547,6 +590,12 @@ func (c *Cluster) EnableServiceMonitor() error {
 		duration := c.spec.Monitoring.Interval.Duration.String()
 		serviceMonitor.Spec.Endpoints[0].Interval = monitoringv1.Duration(duration)
 	}
+	if !c.spec.External.Enable {
+		err := controller.ApplyMetricsTLSToEndpoint(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace, AppName, c.spec.Monitoring, &serviceMonitor.Spec.Endpoints[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to configure the tls of the service monitor")
+		}
+	}
 	err := c.clusterInfo.OwnerInfo.SetControllerReference(serviceMonitor)
 	if err != nil {
 		return errors.Wrapf(err, "failed to set owner reference to service monitor %q", serviceMonitor.Name)
@@ -537,4 +537,14 @@ func TestCluster_configurePrometheusModule(t *testing.T) {
 	assert.Equal(t, 1, modulesDisabled)
 	assert.Equal(t, "30002", configSettings["mgr/prometheus/server_port"])
 	assert.Equal(t, "60", configSettings["mgr/prometheus/scrape_interval"])
+
+	// Enable prometheus module, metrics served over TLS on the localhost
+	modulesEnabled = 0
+	modulesDisabled = 0
+	c.spec.Monitoring.TLS = &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"}
+	err = c.configurePrometheusModule()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, modulesEnabled)
+	assert.Equal(t, 1, modulesDisabled)
+	assert.Equal(t, "127.0.0.1", configSettings["mgr/prometheus/server_addr"])
 }
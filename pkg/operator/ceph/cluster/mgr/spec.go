@@ -40,6 +40,26 @@ const (
 	serviceMetricName = "http-metrics"
 )
 
+func init() {
+	mgrDeployments := []string{"deployment/rook-ceph-mgr-*"}
+	controller.RegisterFieldImpacts(&cephv1.CephCluster{},
+		controller.FieldImpact{Field: "spec.cephVersion.image", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.dataDirHostPath", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.network", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.resources.mgr", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.resources.mgr-sidecar", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.placement.mgr", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.priorityClassNames.mgr", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.annotations.mgr", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.labels.mgr", Resources: mgrDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.mgr.count", Resources: mgrDeployments, Description: "The mgrs are added or removed, the existing mgrs are not restarted."},
+		controller.FieldImpact{Field: "spec.mgr.modules", Description: "The mgr modules are enabled or disabled in ceph without a restart."},
+		controller.FieldImpact{Field: "spec.dashboard", Resources: []string{"service/rook-ceph-mgr-dashboard"}, Description: "The dashboard module is configured in ceph without a restart."},
+		controller.FieldImpact{Field: "spec.monitoring.enabled", Resources: []string{"service/rook-ceph-mgr", "servicemonitor/rook-ceph-mgr"}},
+		controller.FieldImpact{Field: "spec.monitoring.tls", Resources: []string{"deployment/rook-ceph-mgr-*", "service/rook-ceph-mgr", "servicemonitor/rook-ceph-mgr"}, Restart: true},
+	)
+}
+
 func (c *Cluster) makeDeployment(mgrConfig *mgrConfig) (*apps.Deployment, error) {
 	logger.Debugf("mgrConfig: %+v", mgrConfig)
 
@@ -106,6 +126,12 @@ func (c *Cluster) makeDeployment(mgrConfig *mgrConfig) (*apps.Deployment, error)
 		podSpec.Spec.Containers = append(podSpec.Spec.Containers, *controller.LogCollectorContainer(fmt.Sprintf("ceph-mgr.%s", mgrConfig.DaemonID), c.clusterInfo.Namespace, c.spec, nil))
 	}
 
+	// If the metrics are served over TLS we add the proxy side-car container
+	if controller.MetricsTLSEnabled(c.spec.Monitoring) {
+		podSpec.Spec.Containers = append(podSpec.Spec.Containers, controller.MetricsProxyContainer(c.spec, DefaultMetricsTLSPort, c.metricsPort()))
+		podSpec.Spec.Volumes = append(podSpec.Spec.Volumes, controller.MetricsProxyVolumes(c.spec.Monitoring)...)
+	}
+
 	// Replace default unreachable node toleration
 	k8sutil.AddUnreachableNodeToleration(&podSpec.Spec)
 
@@ -307,6 +333,10 @@ func (c *Cluster) MakeMetricsService(name, servicePortMetricName string) (*v1.Se
 	// If the cluster is external we don't need to add the selector
 	if name != controller.ExternalMgrAppName {
 		svc.Spec.Selector = selectorLabels
+		// the metrics served over TLS are scraped from the proxy side-car
+		if controller.MetricsTLSEnabled(c.spec.Monitoring) {
+			svc.Spec.Ports[0].TargetPort = intstr.FromString(controller.MetricsTLSPortName)
+		}
 	}
 
 	err := c.clusterInfo.OwnerInfo.SetControllerReference(svc)
@@ -367,12 +397,7 @@ func (c *Cluster) getPodLabels(mgrConfig *mgrConfig, includeNewLabels bool) map[
 
 func (c *Cluster) applyPrometheusAnnotations(objectMeta *metav1.ObjectMeta) {
 	if len(cephv1.GetMgrAnnotations(c.spec.Annotations)) == 0 {
-		t := cephv1.Annotations{
-			"prometheus.io/scrape": "true",
-			"prometheus.io/port":   strconv.Itoa(int(DefaultMetricsPort)),
-		}
-
-		t.ApplyToObjectMeta(objectMeta)
+		controller.ApplyMetricsPrometheusAnnotations(c.spec.Monitoring, DefaultMetricsPort, DefaultMetricsTLSPort, objectMeta)
 	}
 }
 
@@ -127,6 +127,41 @@ func TestServiceSpec(t *testing.T) {
 	assert.Equal(t, s.Spec.Selector["rook_cluster"], "ns")
 }
 
+func TestMetricsTLS(t *testing.T) {
+	clientset := optest.New(t, 1)
+	ownerInfo := cephclient.NewMinimumOwnerInfoWithOwnerRef()
+	clusterInfo := &cephclient.ClusterInfo{Namespace: "ns", FSID: "myfsid", OwnerInfo: ownerInfo}
+	clusterInfo.SetName("test")
+	clusterSpec := cephv1.ClusterSpec{
+		DataDirHostPath: "/var/lib/rook/",
+		Monitoring: cephv1.MonitoringSpec{
+			Port: 30001,
+			TLS:  &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"},
+		},
+	}
+	c := New(&clusterd.Context{Clientset: clientset}, clusterInfo, clusterSpec, "myversion")
+
+	mgrTestConfig := mgrConfig{
+		DaemonID:     "a",
+		ResourceName: "rook-ceph-mgr-a",
+		DataPathMap:  config.NewStatelessDaemonDataPathMap(config.MgrType, "a", "rook-ceph", "/var/lib/rook/"),
+	}
+	d, err := c.makeDeployment(&mgrTestConfig)
+	assert.NoError(t, err)
+	proxy := d.Spec.Template.Spec.Containers[len(d.Spec.Template.Spec.Containers)-1]
+	assert.Equal(t, "metrics-proxy", proxy.Name)
+	assert.Contains(t, proxy.Args, "--upstream=http://127.0.0.1:30001/")
+	assert.Equal(t, int32(DefaultMetricsTLSPort), proxy.Ports[0].ContainerPort)
+	assert.Equal(t, "9284", d.Spec.Template.Annotations["prometheus.io/port"])
+	assert.Equal(t, "https", d.Spec.Template.Annotations["prometheus.io/scheme"])
+
+	// the metrics are scraped from the proxy
+	s, err := c.MakeMetricsService(AppName, serviceMetricName)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(DefaultMetricsPort), s.Spec.Ports[0].Port)
+	assert.Equal(t, "https-metrics", s.Spec.Ports[0].TargetPort.String())
+}
+
 func TestHostNetwork(t *testing.T) {
 	clientset := optest.New(t, 1)
 	ownerInfo := cephclient.NewMinimumOwnerInfoWithOwnerRef()
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// relocateArbiterMon fails over the tiebreaker mon of the stretch cluster into the arbiter zone when
+// the arbiter zone was changed in the spec. The new mon in the arbiter zone is set as the tiebreaker
+// with `ceph mon set_new_tiebreaker` before the previous tiebreaker is removed. It returns whether
+// the tiebreaker was failed over, since only one mon is failed over per health check.
+func (c *Cluster) relocateArbiterMon() (bool, error) {
+	if !c.spec.IsStretchCluster() {
+		return false, nil
+	}
+	monDump, err := cephclient.GetMonDump(c.context, c.ClusterInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get mon dump")
+	}
+	tiebreaker := c.tiebreakerToRelocate(monDump)
+	if tiebreaker == nil {
+		return false, nil
+	}
+
+	arbiterZone := c.getArbiterZone()
+	logger.Infof("the arbiter zone of the stretch cluster changed from %q to %q, failing over the tiebreaker mon %q", tiebreaker.Zone, arbiterZone, tiebreaker.DaemonName)
+	if err := c.failoverMonToZone(tiebreaker.DaemonName, arbiterZone, ""); err != nil {
+		// the new mon was removed, the previous mon is still the tiebreaker
+		c.arbiterMon = tiebreaker.DaemonName
+		return true, errors.Wrapf(err, "failed to fail over the tiebreaker mon %q to the arbiter zone %q", tiebreaker.DaemonName, arbiterZone)
+	}
+	return true, nil
+}
+
+// tiebreakerToRelocate returns the tiebreaker mon if it is not in the arbiter zone of the spec, or
+// nil if it does not need to move
+func (c *Cluster) tiebreakerToRelocate(monDump cephclient.MonDump) *monConfig {
+	// the stretch mode is enabled by the cluster reconcile with the mon of the arbiter zone
+	if !monDump.StretchMode || monDump.TiebreakerMon == "" {
+		return nil
+	}
+	arbiterZone := c.getArbiterZone()
+	if arbiterZone == "" {
+		return nil
+	}
+	for _, mon := range c.clusterInfoToMonConfig() {
+		if mon.DaemonName != monDump.TiebreakerMon {
+			continue
+		}
+		if mon.Zone == "" || mon.Zone == arbiterZone {
+			return nil
+		}
+		if c.failedStretchZones().Has(arbiterZone) {
+			logger.Warningf("not moving the tiebreaker mon %q to the arbiter zone %q while the zone is failed", mon.DaemonName, arbiterZone)
+			return nil
+		}
+		return mon
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiebreakerToRelocate(t *testing.T) {
+	c := newZoneFailoverTestCluster(nil)
+	monDump := cephclient.MonDump{StretchMode: true, TiebreakerMon: "a"}
+
+	// the tiebreaker is in the arbiter zone
+	assert.Nil(t, c.tiebreakerToRelocate(monDump))
+
+	// the stretch mode is not enabled yet
+	c.spec.Mon.StretchCluster.Zones = []cephv1.MonZoneSpec{{Name: "x", Arbiter: true}, {Name: "b"}, {Name: "c"}}
+	assert.Nil(t, c.tiebreakerToRelocate(cephclient.MonDump{}))
+
+	// the arbiter zone changed
+	mon := c.tiebreakerToRelocate(monDump)
+	require.NotNil(t, mon)
+	assert.Equal(t, "a", mon.DaemonName)
+	assert.Equal(t, "a", mon.Zone)
+
+	// the tiebreaker is unknown
+	assert.Nil(t, c.tiebreakerToRelocate(cephclient.MonDump{StretchMode: true, TiebreakerMon: "z"}))
+
+	// the tiebreaker is not moved to a failed arbiter zone
+	c.mapping.Schedule["d"].HomeZone = "x"
+	assert.Nil(t, c.tiebreakerToRelocate(monDump))
+}
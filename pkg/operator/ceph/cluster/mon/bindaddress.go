@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/k8sutil/cmdreporter"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	monAddressesAppName       = "rook-ceph-mon-addresses"
+	monAddressesJobNameFmt    = "rook-ceph-mon-addresses-%s"
+	detectMonAddressesTimeout = 5 * time.Minute
+)
+
+// hook for tests to override
+var discoverNodeAddresses = realDiscoverNodeAddresses
+
+// getMonBindAddresses returns the bind addresses of the mon override, or else of the mon spec
+func (c *Cluster) getMonBindAddresses(monName, zone string) *cephv1.MonBindAddressesSpec {
+	if override := c.getMonOverride(monName, zone); override.BindAddresses != nil {
+		return override.BindAddresses
+	}
+	return c.spec.Mon.BindAddresses
+}
+
+// selectMonAddresses sets the public and cluster addresses of a mon with host networking from the
+// addresses of its node selected by the bind addresses. The addresses are only selected when the mon
+// is scheduled on a node, so the existing mons keep their addresses.
+func (c *Cluster) selectMonAddresses(monName, zone string, node v1.Node, schedule *controller.MonScheduleInfo) error {
+	if !c.spec.Network.IsHost() {
+		return nil
+	}
+	bindAddresses := c.getMonBindAddresses(monName, zone)
+	if bindAddresses == nil {
+		return nil
+	}
+
+	var interfaces []k8sutil.LinuxIpAddrResult
+	selectAddress := func(selector *cephv1.MonAddressSelector) (string, error) {
+		if selector.Interface == "" {
+			// the addresses known to kubernetes do not need a job on the node
+			if address, err := selectNodeAddress(selector, nodeAddresses(node)); err == nil {
+				return address, nil
+			}
+		}
+		if interfaces == nil {
+			var err error
+			interfaces, err = discoverNodeAddresses(c, monName, zone, node)
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to discover the addresses of node %q", node.Name)
+			}
+		}
+		return selectNodeAddress(selector, interfaces)
+	}
+
+	if bindAddresses.Public != nil {
+		address, err := selectAddress(bindAddresses.Public)
+		if err != nil {
+			return errors.Wrapf(err, "failed to select the public address of mon %q on node %q", monName, node.Name)
+		}
+		logger.Infof("mon %q public address on node %q is %s", monName, node.Name, address)
+		schedule.Address = address
+	}
+	if bindAddresses.Cluster != nil {
+		address, err := selectAddress(bindAddresses.Cluster)
+		if err != nil {
+			return errors.Wrapf(err, "failed to select the cluster address of mon %q on node %q", monName, node.Name)
+		}
+		logger.Infof("mon %q cluster address on node %q is %s", monName, node.Name, address)
+		schedule.ClusterAddress = address
+	}
+	return nil
+}
+
+// nodeAddresses returns the addresses of the node known to kubernetes, with no interface name
+func nodeAddresses(node v1.Node) []k8sutil.LinuxIpAddrResult {
+	result := k8sutil.LinuxIpAddrResult{}
+	if customIP, ok := node.Annotations[monIPAnnotation]; ok {
+		result.AddrInfo = append(result.AddrInfo, k8sutil.LinuxIpAddrInfo{Local: customIP})
+	}
+	for _, address := range node.Status.Addresses {
+		if address.Type == v1.NodeInternalIP || address.Type == v1.NodeExternalIP {
+			result.AddrInfo = append(result.AddrInfo, k8sutil.LinuxIpAddrInfo{Local: address.Address})
+		}
+	}
+	return []k8sutil.LinuxIpAddrResult{result}
+}
+
+// selectNodeAddress returns the first address of the interfaces matching the selector. The loopback
+// and link-local addresses are never selected.
+func selectNodeAddress(selector *cephv1.MonAddressSelector, interfaces []k8sutil.LinuxIpAddrResult) (string, error) {
+	var network *net.IPNet
+	if selector.CIDR != "" {
+		var err error
+		_, network, err = net.ParseCIDR(selector.CIDR)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse cidr %q", selector.CIDR)
+		}
+	}
+	for _, iface := range interfaces {
+		if selector.Interface != "" && iface.InterfaceName != selector.Interface {
+			continue
+		}
+		for _, info := range iface.AddrInfo {
+			ip := net.ParseIP(info.Local)
+			if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+				continue
+			}
+			if network != nil && !network.Contains(ip) {
+				continue
+			}
+			return ip.String(), nil
+		}
+	}
+	return "", errors.Errorf("no address matches interface %q and cidr %q", selector.Interface, selector.CIDR)
+}
+
+// realDiscoverNodeAddresses lists the addresses of the network interfaces of a node with a job on
+// the host network of the node
+func realDiscoverNodeAddresses(c *Cluster, monName, zone string, node v1.Node) ([]k8sutil.LinuxIpAddrResult, error) {
+	job, err := cmdreporter.New(
+		c.context.Clientset,
+		c.ownerInfo,
+		monAddressesAppName,
+		k8sutil.TruncateNodeNameForJob(monAddressesJobNameFmt, node.Name),
+		c.Namespace,
+		[]string{"ip"},
+		[]string{"--json", "address", "show"},
+		c.rookImage,
+		c.rookImage,
+		c.spec.CephVersion.ImagePullPolicy,
+		c.spec.Resources,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up the mon addresses job")
+	}
+
+	podSpec := &job.Job().Spec.Template.Spec
+	podSpec.ServiceAccountName = "rook-ceph-cmd-reporter"
+	podSpec.HostNetwork = true
+	podSpec.DNSPolicy = v1.DNSClusterFirstWithHostNet
+	hostname := node.Labels[k8sutil.LabelHostname()]
+	if hostname == "" {
+		hostname = node.Name
+	}
+	podSpec.NodeSelector = map[string]string{k8sutil.LabelHostname(): hostname}
+	// the job runs where the mon runs, the node affinity of the mon is not needed
+	podSpec.Tolerations = c.getMonPlacement(monName, zone).Tolerations
+	cephv1.GetCmdReporterAnnotations(c.spec.Annotations).ApplyToObjectMeta(&job.Job().Spec.Template.ObjectMeta)
+	cephv1.GetCmdReporterLabels(c.spec.Labels).ApplyToObjectMeta(&job.Job().Spec.Template.ObjectMeta)
+
+	stdout, stderr, retcode, err := job.Run(c.ClusterInfo.Context, detectMonAddressesTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete the mon addresses job")
+	}
+	if retcode != 0 {
+		return nil, errors.Errorf("mon addresses job returned failure code %d: stdout: %q: stderr: %q", retcode, stdout, stderr)
+	}
+	return k8sutil.ParseLinuxIpAddrOutput(stdout)
+}
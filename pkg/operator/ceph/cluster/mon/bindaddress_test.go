@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectNodeAddress(t *testing.T) {
+	interfaces := []k8sutil.LinuxIpAddrResult{
+		{InterfaceName: "lo", AddrInfo: []k8sutil.LinuxIpAddrInfo{{Local: "127.0.0.1", PrefixLen: 8}}},
+		{InterfaceName: "eth0", AddrInfo: []k8sutil.LinuxIpAddrInfo{{Local: "fe80::1", PrefixLen: 64}, {Local: "10.0.0.1", PrefixLen: 24}}},
+		{InterfaceName: "eth1", AddrInfo: []k8sutil.LinuxIpAddrInfo{{Local: "192.168.100.1", PrefixLen: 24}, {Local: "192.168.200.1", PrefixLen: 24}}},
+	}
+	tests := []struct {
+		name     string
+		selector cephv1.MonAddressSelector
+		address  string
+	}{
+		{"any address", cephv1.MonAddressSelector{}, "10.0.0.1"},
+		{"interface", cephv1.MonAddressSelector{Interface: "eth1"}, "192.168.100.1"},
+		{"cidr", cephv1.MonAddressSelector{CIDR: "192.168.200.0/24"}, "192.168.200.1"},
+		{"interface and cidr", cephv1.MonAddressSelector{Interface: "eth1", CIDR: "192.168.200.0/24"}, "192.168.200.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := selectNodeAddress(&tt.selector, interfaces)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.address, address)
+		})
+	}
+
+	// no address matches
+	_, err := selectNodeAddress(&cephv1.MonAddressSelector{Interface: "eth0", CIDR: "192.168.100.0/24"}, interfaces)
+	assert.Error(t, err)
+	_, err = selectNodeAddress(&cephv1.MonAddressSelector{Interface: "lo"}, interfaces)
+	assert.Error(t, err)
+	_, err = selectNodeAddress(&cephv1.MonAddressSelector{CIDR: "192.168.100.0"}, interfaces)
+	assert.Error(t, err)
+}
+
+func TestSelectMonAddresses(t *testing.T) {
+	originalDiscoverNodeAddresses := discoverNodeAddresses
+	t.Cleanup(func() { discoverNodeAddresses = originalDiscoverNodeAddresses })
+	discovered := 0
+	discoverNodeAddresses = func(c *Cluster, monName, zone string, node corev1.Node) ([]k8sutil.LinuxIpAddrResult, error) {
+		discovered++
+		return []k8sutil.LinuxIpAddrResult{
+			{InterfaceName: "eth0", AddrInfo: []k8sutil.LinuxIpAddrInfo{{Local: "10.0.0.1", PrefixLen: 24}}},
+			{InterfaceName: "eth1", AddrInfo: []k8sutil.LinuxIpAddrInfo{{Local: "192.168.100.1", PrefixLen: 24}}},
+		}, nil
+	}
+
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}},
+	}
+	c := newFailbackTestCluster(t)
+	bindAddresses := &cephv1.MonBindAddressesSpec{
+		Public:  &cephv1.MonAddressSelector{CIDR: "10.0.0.0/24"},
+		Cluster: &cephv1.MonAddressSelector{Interface: "eth1"},
+	}
+	c.spec.Mon.BindAddresses = bindAddresses
+
+	// the addresses only apply with host networking
+	schedule := &controller.MonScheduleInfo{Name: "node1", Address: "10.0.0.1"}
+	require.NoError(t, c.selectMonAddresses("a", "", node, schedule))
+	assert.Equal(t, "", schedule.ClusterAddress)
+	assert.Equal(t, 0, discovered)
+
+	c.spec.Network.Provider = cephv1.NetworkProviderHost
+	require.NoError(t, c.selectMonAddresses("a", "", node, schedule))
+	assert.Equal(t, "10.0.0.1", schedule.Address)
+	assert.Equal(t, "192.168.100.1", schedule.ClusterAddress)
+	assert.Equal(t, 1, discovered)
+
+	// the public cidr matches an address of the node without a job on the node
+	bindAddresses.Cluster = nil
+	schedule = &controller.MonScheduleInfo{Name: "node1", Address: "10.0.0.1"}
+	require.NoError(t, c.selectMonAddresses("a", "", node, schedule))
+	assert.Equal(t, "10.0.0.1", schedule.Address)
+	assert.Equal(t, "", schedule.ClusterAddress)
+	assert.Equal(t, 1, discovered)
+
+	// the addresses of the override replace the addresses of the spec
+	c.spec.Mon.Overrides = map[string]cephv1.MonOverrideSpec{"zone1": {BindAddresses: &cephv1.MonBindAddressesSpec{
+		Public: &cephv1.MonAddressSelector{CIDR: "192.168.100.0/24"},
+	}}}
+	schedule = &controller.MonScheduleInfo{Name: "node1", Address: "10.0.0.1"}
+	require.NoError(t, c.selectMonAddresses("a", "zone1", node, schedule))
+	assert.Equal(t, "192.168.100.1", schedule.Address)
+	assert.Equal(t, 2, discovered)
+
+	// the mon is not scheduled when no address matches
+	c.spec.Mon.Overrides["a"] = cephv1.MonOverrideSpec{BindAddresses: &cephv1.MonBindAddressesSpec{
+		Public: &cephv1.MonAddressSelector{Interface: "eth2"},
+	}}
+	assert.Error(t, c.selectMonAddresses("a", "zone1", node, schedule))
+
+	// the mon is not scheduled when the addresses of the node are not discovered
+	discoverNodeAddresses = func(c *Cluster, monName, zone string, node corev1.Node) ([]k8sutil.LinuxIpAddrResult, error) {
+		return nil, errors.New("job failed")
+	}
+	node.Status.Addresses = nil
+	assert.Error(t, c.selectMonAddresses("b", "", node, &controller.MonScheduleInfo{Name: "node1"}))
+}
+
+func TestMonClusterAddrFlag(t *testing.T) {
+	c := newFailbackTestCluster(t)
+	m := &monConfig{
+		ResourceName: "rook-ceph-mon-a",
+		DaemonName:   "a",
+		PublicIP:     "10.0.0.1",
+		ClusterIP:    "192.168.100.1",
+		Port:         6789,
+		Msgr2Port:    3300,
+		DataPathMap:  config.NewStatefulDaemonDataPathMap("/var/lib/rook", "/mon-a/data", config.MonType, "a", "ns"),
+	}
+
+	// the cluster address only applies with host networking
+	container := c.makeMonDaemonContainer(m)
+	assert.NotContains(t, container.Args, "--cluster-addr=192.168.100.1")
+
+	m.UseHostNetwork = true
+	container = c.makeMonDaemonContainer(m)
+	assert.Contains(t, container.Args, "--cluster-addr=192.168.100.1")
+}
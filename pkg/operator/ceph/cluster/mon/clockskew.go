@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// monClockSkewCheck is the health check of ceph reporting the mons whose clock is skewed
+	monClockSkewCheck = "MON_CLOCK_SKEW"
+	// defaultClockSkewThreshold is how long the clock of a mon is skewed before it is remediated
+	defaultClockSkewThreshold = 10 * time.Minute
+)
+
+// the skew of a mon in the details of the MON_CLOCK_SKEW health check, for example
+// "mon.b clock skew 0.0553586s > max 0.05s (latency 0.000929698s)"
+var clockSkewDetailRegex = regexp.MustCompile(`^mon\.(\S+) clock skew (\S+s) `)
+
+// parseClockSkew returns the skew of the clock of the mons in the details of the MON_CLOCK_SKEW
+// health check, by mon name
+func parseClockSkew(health cephclient.HealthStatus) map[string]string {
+	skews := map[string]string{}
+	check, ok := health.Checks[monClockSkewCheck]
+	if !ok {
+		return skews
+	}
+	for _, detail := range check.Detail {
+		if match := clockSkewDetailRegex.FindStringSubmatch(detail.Message); match != nil {
+			skews[match[1]] = match[2]
+		}
+	}
+	return skews
+}
+
+// checkClockSkew detects the mons whose clock is skewed from the health details, and remediates a
+// mon skewed for longer than the threshold with the action of the clock skew settings. The mons
+// are only remediated while all the mons are in quorum. It returns whether a mon was failed over.
+func (c *Cluster) checkClockSkew(health cephclient.HealthStatus, allMonsInQuorum bool, monCount, desiredMonCount int) bool {
+	skews := parseClockSkew(health)
+	now := time.Now()
+
+	since := map[string]metav1.Time{}
+	for _, status := range c.clockSkew {
+		since[status.Name] = status.Since
+		if _, ok := skews[status.Name]; !ok {
+			logger.Infof("clock of mon %q is no longer skewed", status.Name)
+			c.recordEvent(v1.EventTypeNormal, MonClockSkewResolvedReason, "clock of mon %q is no longer skewed", status.Name)
+		}
+	}
+	var skewed []cephv1.MonClockSkewStatus
+	for name, skew := range skews {
+		status := cephv1.MonClockSkewStatus{Name: name, Skew: skew, Since: metav1.NewTime(now)}
+		if t, ok := since[name]; ok {
+			status.Since = t
+		} else {
+			logger.Warningf("clock of mon %q is skewed by %s", name, skew)
+			c.recordEvent(v1.EventTypeWarning, MonClockSkewReason, "clock of mon %q is skewed by %s", name, skew)
+		}
+		skewed = append(skewed, status)
+	}
+	sort.Slice(skewed, func(i, j int) bool { return skewed[i].Name < skewed[j].Name })
+	c.clockSkew = skewed
+
+	if !allMonsInQuorum {
+		return false
+	}
+	return c.remediateClockSkew(now, monCount, desiredMonCount)
+}
+
+// remediateClockSkew restarts or fails over the first mon whose clock is skewed for longer than the
+// threshold. The skew of a restarted mon is detected again from the restart, so that the mon is
+// restarted at most once per threshold.
+func (c *Cluster) remediateClockSkew(now time.Time, monCount, desiredMonCount int) bool {
+	spec := c.spec.HealthCheck.DaemonHealth.Monitor.ClockSkew
+	if spec == nil || spec.Action == "" || spec.Action == cephv1.MonClockSkewActionNone {
+		return false
+	}
+	threshold := defaultClockSkewThreshold
+	if spec.Threshold != nil {
+		threshold = spec.Threshold.Duration
+	}
+
+	for i := range c.clockSkew {
+		status := &c.clockSkew[i]
+		if now.Sub(status.Since.Time) < threshold {
+			continue
+		}
+		if c.maintenanceMons.Has(status.Name) {
+			logger.Infof("clock of mon %q is skewed but it is in maintenance, skipping its remediation", status.Name)
+			continue
+		}
+
+		switch spec.Action {
+		case cephv1.MonClockSkewActionRestart:
+			logger.Warningf("clock of mon %q is skewed by %s for more than %s, restarting the mon", status.Name, status.Skew, threshold)
+			if err := c.restartMonPods(status.Name); err != nil {
+				logger.Warningf("failed to restart mon %q with a skewed clock. %v", status.Name, err)
+				continue
+			}
+			status.Since = metav1.NewTime(now)
+			c.recordEvent(v1.EventTypeNormal, MonClockSkewRemediatedReason, "restarted mon %q whose clock was skewed by %s", status.Name, status.Skew)
+			// only restart one mon per health check
+			return false
+		case cephv1.MonClockSkewActionFailover:
+			logger.Warningf("clock of mon %q is skewed by %s for more than %s, failing over the mon", status.Name, status.Skew, threshold)
+			if !c.failMon(monCount, desiredMonCount, status.Name) {
+				continue
+			}
+			c.recordEvent(v1.EventTypeNormal, MonClockSkewRemediatedReason, "failed over mon %q whose clock was skewed by %s", status.Name, status.Skew)
+			return true
+		}
+	}
+	return false
+}
+
+// restartMonPods deletes the pods of the mon so that they are recreated by its deployment
+func (c *Cluster) restartMonPods(name string) error {
+	selector := fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, controller.DaemonIDLabel, name)
+	pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the pods of mon %q", name)
+	}
+	for _, pod := range pods.Items {
+		if err := c.context.Clientset.CoreV1().Pods(c.Namespace).Delete(c.ClusterInfo.Context, pod.Name, metav1.DeleteOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to delete pod %q of mon %q", pod.Name, name)
+		}
+	}
+	return nil
+}
+
+// reportMonClockSkew publishes the mons whose clock is skewed on the CephCluster status when they
+// changed
+func (c *Cluster) reportMonClockSkew() {
+	if reflect.DeepEqual(c.clockSkew, c.clockSkewReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the mons whose clock is skewed. %v", err)
+		return
+	}
+	cephCluster.Status.MonClockSkew = c.clockSkew
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the mons whose clock is skewed in the CephCluster status. %v", err)
+		return
+	}
+	c.clockSkewReported = c.clockSkew
+}
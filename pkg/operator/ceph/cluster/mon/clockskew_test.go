@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const clockSkewHealthDetail = `{"status":"HEALTH_WARN","checks":{"MON_CLOCK_SKEW":{"severity":"HEALTH_WARN",
+"summary":{"message":"clock skew detected on mon.b"},
+"detail":[{"message":"mon.b clock skew 0.553586s > max 0.05s (latency 0.000929698s)"}]}}}`
+
+func TestParseClockSkew(t *testing.T) {
+	var health cephclient.HealthStatus
+	require.NoError(t, json.Unmarshal([]byte(clockSkewHealthDetail), &health))
+	assert.Equal(t, map[string]string{"b": "0.553586s"}, parseClockSkew(health))
+
+	assert.Empty(t, parseClockSkew(cephclient.HealthStatus{}))
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	health := cephclient.HealthStatus{}
+	require.NoError(t, json.Unmarshal([]byte(clockSkewHealthDetail), &health))
+
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "rook-ceph-mon-b-1234",
+		Namespace: "ns",
+		Labels:    map[string]string{k8sutil.AppAttr: AppName, controller.DaemonIDLabel: "b"},
+	}}
+	clientset := k8sfake.NewSimpleClientset(pod)
+	recorder := record.NewFakeRecorder(10)
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   "ns",
+		context:     &clusterd.Context{Client: cl, Clientset: clientset},
+		recorder:    recorder,
+	}
+
+	// the skewed mon is reported without a remediation
+	assert.False(t, c.checkClockSkew(health, true, 3, 3))
+	require.Len(t, c.clockSkew, 1)
+	assert.Equal(t, "b", c.clockSkew[0].Name)
+	assert.Equal(t, "0.553586s", c.clockSkew[0].Skew)
+	assert.Contains(t, <-recorder.Events, MonClockSkewReason)
+	c.reportMonClockSkew()
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	require.Len(t, cephCluster.Status.MonClockSkew, 1)
+	assert.Equal(t, "b", cephCluster.Status.MonClockSkew[0].Name)
+
+	// the mon is restarted once skewed for longer than the threshold
+	c.spec.HealthCheck.DaemonHealth.Monitor.ClockSkew = &cephv1.MonClockSkewSpec{
+		Action:    cephv1.MonClockSkewActionRestart,
+		Threshold: &metav1.Duration{Duration: time.Minute},
+	}
+	assert.False(t, c.checkClockSkew(health, true, 3, 3))
+	_, err := clientset.CoreV1().Pods("ns").Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	c.clockSkew[0].Since = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	// no mon is remediated while a mon is out of quorum
+	assert.False(t, c.checkClockSkew(health, false, 3, 3))
+	_, err = clientset.CoreV1().Pods("ns").Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.False(t, c.checkClockSkew(health, true, 3, 3))
+	_, err = clientset.CoreV1().Pods("ns").Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, <-recorder.Events, MonClockSkewRemediatedReason)
+	// the skew is detected again from the restart
+	assert.WithinDuration(t, time.Now(), c.clockSkew[0].Since.Time, time.Minute)
+
+	// the skew was resolved
+	health = cephclient.HealthStatus{Status: "HEALTH_OK"}
+	assert.False(t, c.checkClockSkew(health, true, 3, 3))
+	assert.Empty(t, c.clockSkew)
+	assert.Contains(t, <-recorder.Events, MonClockSkewResolvedReason)
+	c.reportMonClockSkew()
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	assert.Empty(t, cephCluster.Status.MonClockSkew)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	v1 "k8s.io/api/core/v1"
+)
+
+// the macros of the compaction schedule
+var compactionScheduleMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// monStoreCompaction tracks the scheduled compaction of the mon stores
+type monStoreCompaction struct {
+	// spec is the compaction schedule of the CephCluster the schedule was parsed from
+	spec     string
+	schedule *compactionSchedule
+	// next is the time the mon stores are compacted next
+	next time.Time
+	// pending are the mons whose store is left to compact, one per health check
+	pending []string
+}
+
+// compactionSchedule is a cron schedule with the minute, hour, day of month, month and day of week
+// fields
+type compactionSchedule struct {
+	minutes  []bool
+	hours    []bool
+	days     []bool
+	months   []bool
+	weekdays []bool
+	// the day matches either the day of month or the day of week when both are restricted
+	anyDay     bool
+	anyWeekday bool
+}
+
+func parseCompactionSchedule(spec string) (*compactionSchedule, error) {
+	if macro, ok := compactionScheduleMacros[spec]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expected 5 fields in the schedule %q, found %d", spec, len(fields))
+	}
+	s := &compactionSchedule{anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	var err error
+	if s.minutes, err = parseScheduleField(fields[0], 0, 59); err != nil {
+		return nil, errors.Wrap(err, "invalid minute")
+	}
+	if s.hours, err = parseScheduleField(fields[1], 0, 23); err != nil {
+		return nil, errors.Wrap(err, "invalid hour")
+	}
+	if s.days, err = parseScheduleField(fields[2], 1, 31); err != nil {
+		return nil, errors.Wrap(err, "invalid day of month")
+	}
+	if s.months, err = parseScheduleField(fields[3], 1, 12); err != nil {
+		return nil, errors.Wrap(err, "invalid month")
+	}
+	// both 0 and 7 are sunday
+	if s.weekdays, err = parseScheduleField(fields[4], 0, 7); err != nil {
+		return nil, errors.Wrap(err, "invalid day of week")
+	}
+	s.weekdays[0] = s.weekdays[0] || s.weekdays[7]
+
+	if s.nextAfter(time.Now()).IsZero() {
+		return nil, errors.Errorf("the schedule %q never runs", spec)
+	}
+	return s, nil
+}
+
+// parseScheduleField parses a field of a list of values, ranges and steps like "1,5-10,*/15"
+func parseScheduleField(field string, minValue, maxValue int) ([]bool, error) {
+	values := make([]bool, maxValue+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, errors.Errorf("invalid step in %q", part)
+			}
+			rangePart = part[:i]
+		}
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, errors.Errorf("invalid value in %q", part)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, errors.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				end = maxValue
+			}
+		}
+		if start < minValue || end > maxValue || start > end {
+			return nil, errors.Errorf("%q is out of the range %d-%d", part, minValue, maxValue)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+func (s *compactionSchedule) dayMatches(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[t.Weekday()]
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+// nextAfter returns the first time in UTC after the given time that matches the schedule, or the
+// zero time if the schedule never matches
+func (s *compactionSchedule) nextAfter(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	// any valid schedule matches within four years, including on february 29th
+	limit := t.AddDate(4, 0, 1)
+	for t.Before(limit) {
+		switch {
+		case !s.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case !s.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// compactMonStores compacts the store of one mon per health check once the compaction schedule is
+// due, so that a single mon at a time is slowed down by the compaction
+func (c *Cluster) compactMonStores(quorumStatus cephclient.MonStatusResponse) {
+	spec := c.spec.HealthCheck.DaemonHealth.Monitor.CompactionSchedule
+	now := time.Now()
+	if spec != c.compaction.spec {
+		c.compaction = monStoreCompaction{spec: spec}
+		if spec == "" {
+			return
+		}
+		schedule, err := parseCompactionSchedule(spec)
+		if err != nil {
+			logger.Errorf("invalid mon store compaction schedule %q. %v", spec, err)
+			return
+		}
+		c.compaction.schedule = schedule
+		c.compaction.next = schedule.nextAfter(now)
+		logger.Infof("the mon stores are compacted next at %s", c.compaction.next.Format(time.RFC3339))
+	}
+	if c.compaction.schedule == nil {
+		return
+	}
+
+	if len(c.compaction.pending) == 0 {
+		if now.Before(c.compaction.next) {
+			return
+		}
+		for _, mon := range quorumStatus.MonMap.Mons {
+			c.compaction.pending = append(c.compaction.pending, mon.Name)
+		}
+		c.compaction.next = c.compaction.schedule.nextAfter(now)
+	}
+
+	// the compaction slows down the mon, it waits until the cluster is done recovering
+	if controller.DeferForRecovery(c.context, c.ClusterInfo, c.spec.HealthCheck.RecoveryDeferral, controller.MaintenanceMonStoreCompaction) {
+		return
+	}
+	mon := c.compaction.pending[0]
+	c.compaction.pending = c.compaction.pending[1:]
+	logger.Infof("compacting the store of mon %q", mon)
+	start := time.Now()
+	if err := compactMonStore(c, mon); err != nil {
+		logger.Warningf("failed to compact the store of mon %q. %v", mon, err)
+		c.recordEvent(v1.EventTypeWarning, MonStoreCompactionFailedReason, "failed to compact the store of mon %q: %v", mon, err)
+		return
+	}
+	c.recordEvent(v1.EventTypeNormal, MonStoreCompactedReason, "compacted the store of mon %q in %s", mon, time.Since(start).Round(time.Second).String())
+	if len(c.compaction.pending) == 0 {
+		logger.Infof("compacted the stores of all the mons, the mon stores are compacted next at %s", c.compaction.next.Format(time.RFC3339))
+	}
+}
+
+var compactMonStore = func(c *Cluster, mon string) error {
+	args := []string{"tell", fmt.Sprintf("mon.%s", mon), "compact"}
+	// the compaction of a large store may take minutes
+	if _, err := cephclient.NewCephCommand(c.context, c.ClusterInfo, args).Run(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestParseCompactionSchedule(t *testing.T) {
+	// a wednesday
+	after := time.Date(2025, time.January, 1, 10, 7, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		next     time.Time
+	}{
+		{"0 3 * * 0", time.Date(2025, time.January, 5, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2025, time.January, 5, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.January, 1, 10, 15, 0, 0, time.UTC)},
+		{"5,50 9-11 * * *", time.Date(2025, time.January, 1, 10, 50, 0, 0, time.UTC)},
+		// either the day of month or the day of week matches
+		{"0 0 15 * 1", time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			s, err := parseCompactionSchedule(tt.schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, s.nextAfter(after))
+		})
+	}
+
+	for _, schedule := range []string{"", "* * *", "0 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 30 2 *"} {
+		_, err := parseCompactionSchedule(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
+
+func TestCompactMonStores(t *testing.T) {
+	compacted := []string{}
+	originalCompactMonStore := compactMonStore
+	t.Cleanup(func() { compactMonStore = originalCompactMonStore })
+	compactMonStore = func(c *Cluster, mon string) error {
+		compacted = append(compacted, mon)
+		if mon == "b" {
+			return errors.New("failed to compact")
+		}
+		return nil
+	}
+
+	c := &Cluster{ClusterInfo: cephclient.AdminTestClusterInfo("ns")}
+	recorder := record.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
+	quorumStatus := cephclient.MonStatusResponse{}
+	quorumStatus.MonMap.Mons = []cephclient.MonMapEntry{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	// nothing is compacted without a schedule
+	c.compactMonStores(quorumStatus)
+	assert.Empty(t, compacted)
+
+	// nothing is compacted with an invalid schedule
+	c.spec.HealthCheck.DaemonHealth.Monitor.CompactionSchedule = "0 25 * * *"
+	c.compactMonStores(quorumStatus)
+	assert.Empty(t, compacted)
+	assert.Nil(t, c.compaction.schedule)
+
+	// nothing is compacted before the schedule is due
+	c.spec.HealthCheck.DaemonHealth.Monitor.CompactionSchedule = "@hourly"
+	c.compactMonStores(quorumStatus)
+	assert.Empty(t, compacted)
+	assert.True(t, c.compaction.next.After(time.Now()))
+	assert.Zero(t, c.compaction.next.Minute())
+
+	// one mon is compacted per health check once the schedule is due
+	c.compaction.next = time.Now().Add(-time.Minute)
+	c.compactMonStores(quorumStatus)
+	assert.Equal(t, []string{"a"}, compacted)
+	assert.True(t, c.compaction.next.After(time.Now()))
+	assert.Contains(t, <-recorder.Events, "Normal MonStoreCompacted compacted the store of mon \"a\"")
+	c.compactMonStores(quorumStatus)
+	assert.Equal(t, []string{"a", "b"}, compacted)
+	assert.Contains(t, <-recorder.Events, "Warning MonStoreCompactionFailed failed to compact the store of mon \"b\"")
+	c.compactMonStores(quorumStatus)
+	assert.Equal(t, []string{"a", "b", "c"}, compacted)
+	<-recorder.Events
+	c.compactMonStores(quorumStatus)
+	assert.Equal(t, []string{"a", "b", "c"}, compacted)
+
+	// the pending compactions are dropped when the schedule is removed
+	c.compaction.next = time.Now().Add(-time.Minute)
+	c.compactMonStores(quorumStatus)
+	assert.Len(t, c.compaction.pending, 2)
+	c.spec.HealthCheck.DaemonHealth.Monitor.CompactionSchedule = ""
+	c.compactMonStores(quorumStatus)
+	assert.Empty(t, c.compaction.pending)
+	assert.Len(t, compacted, 4)
+}
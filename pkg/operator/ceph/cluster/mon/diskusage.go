@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// monDiskLowCheck is the health check of ceph reporting the mons whose disk is low on space
+	monDiskLowCheck = "MON_DISK_LOW"
+	// monDiskCritCheck is the health check of ceph reporting the mons whose disk is critically low
+	// on space
+	monDiskCritCheck = "MON_DISK_CRIT"
+)
+
+// the available space of a mon in the details of the MON_DISK_LOW and MON_DISK_CRIT health checks,
+// for example "mon.a has 28% avail"
+var diskUsageDetailRegex = regexp.MustCompile(`^mon\.(\S+) has (\d+)% avail`)
+
+// parseMonDiskUsage returns the mons whose disk is low on space in the details of the MON_DISK_LOW
+// and MON_DISK_CRIT health checks, sorted by name
+func parseMonDiskUsage(health cephclient.HealthStatus) []cephv1.MonDiskUsageStatus {
+	var usage []cephv1.MonDiskUsageStatus
+	for _, checkName := range []string{monDiskCritCheck, monDiskLowCheck} {
+		check, ok := health.Checks[checkName]
+		if !ok {
+			continue
+		}
+		for _, detail := range check.Detail {
+			match := diskUsageDetailRegex.FindStringSubmatch(detail.Message)
+			if match == nil {
+				continue
+			}
+			available, err := strconv.Atoi(match[2])
+			if err != nil {
+				continue
+			}
+			usage = append(usage, cephv1.MonDiskUsageStatus{Name: match[1], AvailablePercent: available, HealthCheck: checkName})
+		}
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	return usage
+}
+
+// checkMonDiskUsage detects the mons whose disk is low on space from the health details, and fails
+// over the mon with the least available space when it is below the failover threshold of the disk
+// usage settings. The mons are only failed over while all the mons are in quorum. It returns
+// whether a mon was failed over.
+func (c *Cluster) checkMonDiskUsage(health cephclient.HealthStatus, allMonsInQuorum bool, monCount, desiredMonCount int) bool {
+	usage := parseMonDiskUsage(health)
+
+	previous := map[string]cephv1.MonDiskUsageStatus{}
+	for _, status := range c.diskUsage {
+		previous[status.Name] = status
+	}
+	current := map[string]bool{}
+	for _, status := range usage {
+		current[status.Name] = true
+		if p, ok := previous[status.Name]; ok && p.HealthCheck == status.HealthCheck {
+			continue
+		}
+		logger.Warningf("disk of mon %q is low on space with %d%% available (%s)", status.Name, status.AvailablePercent, status.HealthCheck)
+		c.recordEvent(v1.EventTypeWarning, MonDiskLowReason, "disk of mon %q is low on space with %d%% available (%s)", status.Name, status.AvailablePercent, status.HealthCheck)
+	}
+	for _, status := range c.diskUsage {
+		if !current[status.Name] {
+			logger.Infof("disk of mon %q is no longer low on space", status.Name)
+			c.recordEvent(v1.EventTypeNormal, MonDiskLowResolvedReason, "disk of mon %q is no longer low on space", status.Name)
+		}
+	}
+	c.diskUsage = usage
+
+	if !allMonsInQuorum {
+		return false
+	}
+	return c.failoverMonDiskLow(monCount, desiredMonCount)
+}
+
+// failoverMonDiskLow fails over the mon with the least available space on its disk below the
+// failover threshold. The mon is kept running until the new mon is in quorum, so that the new mon
+// is scheduled on another node, or on a new PVC.
+func (c *Cluster) failoverMonDiskLow(monCount, desiredMonCount int) bool {
+	spec := c.spec.HealthCheck.DaemonHealth.Monitor.DiskUsage
+	if spec == nil || spec.FailoverAvailablePercent <= 0 {
+		return false
+	}
+
+	candidates := []cephv1.MonDiskUsageStatus{}
+	for _, status := range c.diskUsage {
+		if status.AvailablePercent >= spec.FailoverAvailablePercent {
+			continue
+		}
+		if c.maintenanceMons.Has(status.Name) {
+			logger.Infof("disk of mon %q is low on space but it is in maintenance, skipping its failover", status.Name)
+			continue
+		}
+		candidates = append(candidates, status)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].AvailablePercent < candidates[j].AvailablePercent })
+
+	for _, status := range candidates {
+		logger.Warningf("disk of mon %q has %d%% available, below the failover threshold of %d%%. failing over the mon", status.Name, status.AvailablePercent, spec.FailoverAvailablePercent)
+		c.preemptiveFailover = status.Name
+		failedOver := c.failMon(monCount, desiredMonCount, status.Name)
+		c.preemptiveFailover = ""
+		if !failedOver {
+			continue
+		}
+		c.recordEvent(v1.EventTypeNormal, MonDiskLowFailoverReason, "failed over mon %q whose disk had %d%% available", status.Name, status.AvailablePercent)
+		// only fail over one mon per health check
+		return true
+	}
+	return false
+}
+
+// reportMonDiskUsage publishes the mons whose disk is low on space on the CephCluster status when
+// they changed
+func (c *Cluster) reportMonDiskUsage() {
+	if reflect.DeepEqual(c.diskUsage, c.diskUsageReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the mons whose disk is low on space. %v", err)
+		return
+	}
+	cephCluster.Status.MonDiskUsage = c.diskUsage
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the mons whose disk is low on space in the CephCluster status. %v", err)
+		return
+	}
+	c.diskUsageReported = c.diskUsage
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const diskUsageHealthDetail = `{"status":"HEALTH_ERR","checks":{
+"MON_DISK_LOW":{"severity":"HEALTH_WARN","summary":{"message":"mon b is low on available space"},
+"detail":[{"message":"mon.b has 21% avail"}]},
+"MON_DISK_CRIT":{"severity":"HEALTH_ERR","summary":{"message":"mon a is very low on available space"},
+"detail":[{"message":"mon.a has 4% avail"}]}}}`
+
+func TestParseMonDiskUsage(t *testing.T) {
+	var health cephclient.HealthStatus
+	require.NoError(t, json.Unmarshal([]byte(diskUsageHealthDetail), &health))
+	assert.Equal(t, []cephv1.MonDiskUsageStatus{
+		{Name: "a", AvailablePercent: 4, HealthCheck: "MON_DISK_CRIT"},
+		{Name: "b", AvailablePercent: 21, HealthCheck: "MON_DISK_LOW"},
+	}, parseMonDiskUsage(health))
+
+	assert.Empty(t, parseMonDiskUsage(cephclient.HealthStatus{}))
+}
+
+func TestCheckMonDiskUsage(t *testing.T) {
+	var health cephclient.HealthStatus
+	require.NoError(t, json.Unmarshal([]byte(diskUsageHealthDetail), &health))
+
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	recorder := record.NewFakeRecorder(10)
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   "ns",
+		context:     &clusterd.Context{Client: cl},
+		recorder:    recorder,
+	}
+
+	// the mons are reported without a failover threshold
+	assert.False(t, c.checkMonDiskUsage(health, true, 3, 3))
+	require.Len(t, c.diskUsage, 2)
+	assert.Contains(t, <-recorder.Events, MonDiskLowReason)
+	assert.Contains(t, <-recorder.Events, MonDiskLowReason)
+	c.reportMonDiskUsage()
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	assert.Equal(t, c.diskUsage, cephCluster.Status.MonDiskUsage)
+
+	// the mons are not reported again while their health check did not change
+	assert.False(t, c.checkMonDiskUsage(health, true, 3, 3))
+	assert.Empty(t, recorder.Events)
+
+	// the mons below the threshold in maintenance are not failed over
+	c.spec.HealthCheck.DaemonHealth.Monitor.DiskUsage = &cephv1.MonDiskUsageSpec{FailoverAvailablePercent: 10}
+	c.maintenanceMons = sets.New("a")
+	assert.False(t, c.checkMonDiskUsage(health, true, 3, 3))
+
+	// the disk of a mon was freed
+	health.Checks = map[string]cephclient.CheckMessage{monDiskLowCheck: health.Checks[monDiskLowCheck]}
+	assert.False(t, c.checkMonDiskUsage(health, true, 3, 3))
+	assert.Equal(t, []cephv1.MonDiskUsageStatus{{Name: "b", AvailablePercent: 21, HealthCheck: "MON_DISK_LOW"}}, c.diskUsage)
+	assert.Contains(t, <-recorder.Events, MonDiskLowResolvedReason)
+	c.reportMonDiskUsage()
+	require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+	assert.Len(t, cephCluster.Status.MonDiskUsage, 1)
+}
+
+func TestStopMonDuringPreemptiveFailover(t *testing.T) {
+	c := &Cluster{}
+	assert.True(t, c.stopMonDuringFailover("a"))
+	// the mon whose disk is low on space keeps running so the new mon is scheduled on another node
+	c.preemptiveFailover = "a"
+	assert.False(t, c.stopMonDuringFailover("a"))
+	assert.True(t, c.stopMonDuringFailover("b"))
+}
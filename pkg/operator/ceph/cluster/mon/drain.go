@@ -98,11 +98,11 @@ func (c *Cluster) allowMonDrain(request types.NamespacedName) error {
 	return nil
 }
 
+// getMaxUnavailableMonPodCount returns how many mons can be drained at once while a majority of the
+// mons remains up, which is 1 for 3 mons, 2 for 5 mons, 3 for 7 mons and 4 for 9 mons
 func (c *Cluster) getMaxUnavailableMonPodCount() int32 {
-	if c.spec.Mon.Count >= 5 {
-		logger.Debug("setting the mon pdb max unavailable count to 2 in case there are 5 or more mons")
-		return 2
-	}
-
-	return 1
+	maxUnavailable := max((c.spec.Mon.Count-1)/2, 1)
+	logger.Debugf("setting the mon pdb max unavailable count to %d for %d mons", maxUnavailable, c.spec.Mon.Count)
+	// nolint:gosec // G115 the mon count is at most 9
+	return int32(maxUnavailable)
 }
@@ -102,6 +102,38 @@ func TestReconcileMonPDB(t *testing.T) {
 			expectedMaxUnAvailable: 2,
 			errorExpected:          false,
 		},
+		{
+			name: "7 mons",
+			cephCluster: &cephv1.CephCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "rook", Namespace: mockNamespace},
+				Spec: cephv1.ClusterSpec{
+					Mon: cephv1.MonSpec{
+						Count: 7,
+					},
+					DisruptionManagement: cephv1.DisruptionManagementSpec{
+						ManagePodBudgets: true,
+					},
+				},
+			},
+			expectedMaxUnAvailable: 3,
+			errorExpected:          false,
+		},
+		{
+			name: "9 mons",
+			cephCluster: &cephv1.CephCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "rook", Namespace: mockNamespace},
+				Spec: cephv1.ClusterSpec{
+					Mon: cephv1.MonSpec{
+						Count: 9,
+					},
+					DisruptionManagement: cephv1.DisruptionManagementSpec{
+						ManagePodBudgets: true,
+					},
+				},
+			},
+			expectedMaxUnAvailable: 4,
+			errorExpected:          false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// configureMonElectionPreferences applies the weight and leader settings of the mon zones to the
+// mons in each zone. The settings are stored in the monmap per mon, so they must be applied again
+// every time a new mon is created, for example after a failover.
+func (c *Cluster) configureMonElectionPreferences() error {
+	if !c.spec.ZonesRequired() {
+		return nil
+	}
+
+	zones := map[string]cephv1.MonZoneSpec{}
+	for _, zone := range c.getMonZones() {
+		zones[zone.Name] = zone
+	}
+
+	monDump, err := cephclient.GetMonDump(c.context, c.ClusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mon dump")
+	}
+
+	// the classic election strategy rejects the disallowed leaders, while the stretch clusters
+	// already use the connectivity strategy
+	if monDump.ElectionStrategy == cephclient.MonElectionStrategyClassic && anyZoneDisallowsLeader(zones) {
+		if err := cephclient.EnableDisallowElectionStrategy(c.context, c.ClusterInfo); err != nil {
+			return err
+		}
+	}
+
+	for _, mon := range monDump.Mons {
+		schedule, ok := c.mapping.Schedule[mon.Name]
+		if !ok || schedule == nil {
+			// the mon is not managed by rook
+			continue
+		}
+		zone, ok := zones[schedule.Zone]
+		if !ok {
+			logger.Debugf("mon %q is not assigned to a known zone, skipping its election preferences", mon.Name)
+			continue
+		}
+
+		if zone.Weight != nil && *zone.Weight != mon.Weight {
+			if err := cephclient.SetMonWeight(c.context, c.ClusterInfo, mon.Name, *zone.Weight); err != nil {
+				return err
+			}
+		}
+
+		// ceph always disallows the tiebreaker of a stretch cluster from being the leader
+		if mon.Name == monDump.TiebreakerMon {
+			continue
+		}
+		if zone.DisallowLeader != monDump.IsDisallowedLeader(mon.Name) {
+			if err := cephclient.SetMonDisallowedLeader(c.context, c.ClusterInfo, mon.Name, zone.DisallowLeader); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func anyZoneDisallowsLeader(zones map[string]cephv1.MonZoneSpec) bool {
+	for _, zone := range zones {
+		if zone.DisallowLeader {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureMonElectionPreferences(t *testing.T) {
+	weight := 10
+	c := &Cluster{
+		spec: cephv1.ClusterSpec{
+			Mon: cephv1.MonSpec{
+				StretchCluster: &cephv1.StretchClusterSpec{
+					Zones: []cephv1.MonZoneSpec{
+						{Name: "a", Arbiter: true, DisallowLeader: true},
+						{Name: "b", Weight: &weight},
+						{Name: "c", DisallowLeader: true},
+					},
+				},
+			},
+		},
+		mapping: &opcontroller.Mapping{
+			Schedule: map[string]*opcontroller.MonScheduleInfo{
+				"a": {Zone: "a"},
+				"b": {Zone: "b"},
+				"c": {Zone: "c"},
+				"d": {Zone: "c"},
+			},
+		},
+	}
+
+	disallowedLeaders := "a,d"
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("%s %v", command, args)
+			if args[0] == "mon" {
+				if args[1] == "dump" {
+					return fmt.Sprintf(`{"tiebreaker_mon": "a", "stretch_mode": true, "disallowed_leaders: ": %q, "mons": [
+						{"name": "a", "weight": 0}, {"name": "b", "weight": 0}, {"name": "c", "weight": 0},
+						{"name": "d", "weight": 0}, {"name": "external", "weight": 0}]}`, disallowedLeaders), nil
+				}
+				commands = append(commands, fmt.Sprintf("%s %s %s", args[1], args[2], args[3]))
+				return "", nil
+			}
+			return "", fmt.Errorf("unrecognized command: %s %v", command, args)
+		},
+	}
+	c.context = &clusterd.Context{Executor: executor}
+	c.ClusterInfo = clienttest.CreateTestClusterInfo(4)
+
+	t.Run("preferences applied", func(t *testing.T) {
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"set-weight b 10", "add disallowed_leader c"}, commands)
+	})
+
+	t.Run("leader allowed again", func(t *testing.T) {
+		commands = []string{}
+		disallowedLeaders = "a,c,d"
+		c.spec.Mon.StretchCluster.Zones[2].DisallowLeader = false
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		// the tiebreaker is never allowed as leader
+		assert.ElementsMatch(t, []string{"set-weight b 10", "rm disallowed_leader c", "rm disallowed_leader d"}, commands)
+	})
+
+	t.Run("no zones", func(t *testing.T) {
+		commands = []string{}
+		c.spec.Mon.StretchCluster = nil
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		assert.Empty(t, commands)
+	})
+}
+
+func TestConfigureMonElectionPreferencesWithoutStretch(t *testing.T) {
+	c := &Cluster{
+		spec: cephv1.ClusterSpec{
+			Mon: cephv1.MonSpec{
+				Zones: []cephv1.MonZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "c", DisallowLeader: true}},
+			},
+		},
+		mapping: &opcontroller.Mapping{
+			Schedule: map[string]*opcontroller.MonScheduleInfo{
+				"a": {Zone: "a"},
+				"b": {Zone: "b"},
+				"c": {Zone: "c"},
+			},
+		},
+	}
+
+	electionStrategy := 1
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "mon" {
+				if args[1] == "dump" {
+					return fmt.Sprintf(`{"election_strategy": %d, "disallowed_leaders: ": "", "mons": [
+						{"name": "a", "weight": 0}, {"name": "b", "weight": 0}, {"name": "c", "weight": 0}]}`, electionStrategy), nil
+				}
+				commands = append(commands, fmt.Sprintf("%s %s %s", args[1], args[2], args[3]))
+				return "", nil
+			}
+			return "", fmt.Errorf("unrecognized command: %s %v", command, args)
+		},
+	}
+	c.context = &clusterd.Context{Executor: executor}
+	c.ClusterInfo = clienttest.CreateTestClusterInfo(3)
+
+	t.Run("classic strategy switched to disallow", func(t *testing.T) {
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"set election_strategy disallow", "add disallowed_leader c"}, commands)
+	})
+
+	t.Run("disallow strategy already set", func(t *testing.T) {
+		commands = []string{}
+		electionStrategy = 2
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"add disallowed_leader c"}, commands)
+	})
+
+	t.Run("classic strategy kept without disallowed leaders", func(t *testing.T) {
+		commands = []string{}
+		electionStrategy = 1
+		c.spec.Mon.Zones[2].DisallowLeader = false
+		err := c.configureMonElectionPreferences()
+		assert.NoError(t, err)
+		assert.Empty(t, commands)
+	})
+}
@@ -18,6 +18,7 @@ package mon
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
@@ -32,3 +33,16 @@ func flattenMonEndpoints(mons map[string]*cephclient.MonInfo) string {
 	}
 	return strings.Join(endpoints, ",")
 }
+
+// flattenMonMsgr2Ports returns a comma-delimited string of the mons not listening on the default
+// msgr2 port in the form <mon-name>=<msgr2-port>
+func flattenMonMsgr2Ports(mons map[string]*cephclient.MonInfo) string {
+	ports := []string{}
+	for _, m := range mons {
+		if m.Msgr2Port != 0 && m.Msgr2Port != DefaultMsgr2Port {
+			ports = append(ports, fmt.Sprintf("%s=%d", m.Name, m.Msgr2Port))
+		}
+	}
+	sort.Strings(ports)
+	return strings.Join(ports, ",")
+}
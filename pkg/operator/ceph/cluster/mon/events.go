@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"reflect"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// The reasons of the events recorded on the CephCluster for the mon health
+const (
+	MonFailoverStartedReason       = "MonFailoverStarted"
+	MonFailoverSucceededReason     = "MonFailoverSucceeded"
+	MonFailoverFailedReason        = "MonFailoverFailed"
+	MonFailoverDeferredReason      = "MonFailoverDeferred"
+	MonOutOfQuorumReason           = "MonOutOfQuorum"
+	MonBackInQuorumReason          = "MonBackInQuorum"
+	MonRemovedReason               = "MonRemoved"
+	MonStoreCompactedReason        = "MonStoreCompacted"
+	MonStoreCompactionFailedReason = "MonStoreCompactionFailed"
+	MonPVCMigrationReason          = "MonPVCMigration"
+	MonMaintenanceStartedReason    = "MonMaintenanceStarted"
+	MonMaintenanceEndedReason      = "MonMaintenanceEnded"
+	MonClockSkewReason             = "MonClockSkew"
+	MonClockSkewResolvedReason     = "MonClockSkewResolved"
+	MonClockSkewRemediatedReason   = "MonClockSkewRemediated"
+	MonDiskLowReason               = "MonDiskLow"
+	MonDiskLowResolvedReason       = "MonDiskLowResolved"
+	MonDiskLowFailoverReason       = "MonDiskLowFailover"
+	MonPortMigrationReason         = "MonPortMigration"
+	MonFailbackReason              = "MonFailback"
+	MonZoneNotReadyReason          = "MonZoneNotReady"
+	MonZoneReadyReason             = "MonZoneReady"
+)
+
+// SetEventRecorder sets the recorder of the events of the mon health on the CephCluster
+func (c *Cluster) SetEventRecorder(recorder record.EventRecorder) {
+	c.recorder = recorder
+}
+
+// recordEvent records an event on the CephCluster so that admins can alert on the mon health
+// without scraping the operator log
+func (c *Cluster) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if c.recorder == nil {
+		return
+	}
+	name := c.ClusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       reflect.TypeOf(cephv1.CephCluster{}).Name(),
+			APIVersion: cephv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+	}
+	if c.ownerInfo != nil {
+		cephCluster.UID = c.ownerInfo.GetUID()
+	}
+	c.recorder.Eventf(cephCluster, eventType, reason, messageFmt, args...)
+}
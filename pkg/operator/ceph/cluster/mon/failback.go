@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// defaultMonFailbackDelay is how long the original node of a mon must be ready again before the
+	// mon is moved back
+	defaultMonFailbackDelay = 10 * time.Minute
+	// defaultMonFailbackInterval is the minimum time between two mons moved back to their node
+	defaultMonFailbackInterval = 30 * time.Minute
+)
+
+// monHomeNode returns the node the mon must be moved back to after its failover. The original node
+// is kept when a mon is failed over again, and the node of the mon is its home only when the node is
+// not ready or not schedulable, since only the failovers caused by a node outage are moved back.
+func (c *Cluster) monHomeNode(name string) string {
+	schedule := c.mapping.Schedule[name]
+	if schedule == nil || schedule.Name == "" {
+		// the mon is not pinned to a node
+		return ""
+	}
+	if schedule.HomeNode != "" {
+		return schedule.HomeNode
+	}
+	node, err := c.context.Clientset.CoreV1().Nodes().Get(c.ClusterInfo.Context, schedule.Name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to get node %q of mon %q to check if it is ready. %v", schedule.Name, name, err)
+		}
+		// a deleted node will not come back
+		return ""
+	}
+	if k8sutil.NodeIsReady(*node) && k8sutil.GetNodeSchedulable(*node, false) {
+		return ""
+	}
+	return schedule.Name
+}
+
+// getMonFailbackNode returns the home node of the mon if the mon can be moved back to it: the node
+// must be ready and schedulable, match the placement and the zone of the mon, and not run another
+// mon.
+func (c *Cluster) getMonFailbackNode(name string, schedule *controller.MonScheduleInfo) *v1.Node {
+	node, err := c.context.Clientset.CoreV1().Nodes().Get(c.ClusterInfo.Context, schedule.HomeNode, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to get node %q to move mon %q back. %v", schedule.HomeNode, name, err)
+		}
+		return nil
+	}
+	if !k8sutil.NodeIsReady(*node) || !k8sutil.GetNodeSchedulable(*node, false) {
+		return nil
+	}
+	if schedule.Zone != "" && node.Labels[GetFailureDomainLabel(c.spec)] != schedule.Zone {
+		logger.Debugf("node %q is no longer in zone %q of mon %q, not moving the mon back", node.Name, schedule.Zone, name)
+		return nil
+	}
+	placeable, err := k8sutil.NodeMeetsPlacementTerms(*node, c.getMonPlacement(name, schedule.Zone), false)
+	if err != nil || !placeable {
+		logger.Debugf("node %q no longer matches the placement of mon %q, not moving the mon back. %v", node.Name, name, err)
+		return nil
+	}
+	if !c.spec.Mon.AllowMultiplePerNode {
+		for other, otherSchedule := range c.mapping.Schedule {
+			if other != name && otherSchedule != nil && otherSchedule.Name == node.Name {
+				logger.Debugf("node %q already runs mon %q, not moving mon %q back", node.Name, other, name)
+				return nil
+			}
+		}
+	}
+	return node
+}
+
+// checkMonFailback moves a mon failed over from a node that was not ready back to that node once
+// the node is ready and schedulable for the failback delay. Only one mon is moved back per failback
+// interval, and only while all the mons are in quorum. It returns whether a mon was moved back.
+func (c *Cluster) checkMonFailback(monCount, desiredMonCount int) bool {
+	spec := c.spec.Mon.Failback
+	if spec == nil || !spec.Enabled || c.mapping == nil {
+		return false
+	}
+	delay := defaultMonFailbackDelay
+	if spec.Delay != nil {
+		delay = spec.Delay.Duration
+	}
+	interval := defaultMonFailbackInterval
+	if spec.Interval != nil {
+		interval = spec.Interval.Duration
+	}
+
+	now := time.Now()
+	homeNodes := sets.New[string]()
+	defer func() {
+		// forget the nodes that are no longer the home of a mon
+		for node := range c.homeNodeReadySince {
+			if !homeNodes.Has(node) {
+				delete(c.homeNodeReadySince, node)
+			}
+		}
+	}()
+
+	for _, name := range sets.List(sets.KeySet(c.mapping.Schedule)) {
+		schedule := c.mapping.Schedule[name]
+		if schedule == nil || schedule.HomeNode == "" || schedule.HomeZone != "" {
+			// the mons rescheduled from a failed stretch zone are moved back with their zone
+			continue
+		}
+		homeNodes.Insert(schedule.HomeNode)
+		if c.maintenanceMons.Has(name) {
+			continue
+		}
+
+		node := c.getMonFailbackNode(name, schedule)
+		if node == nil {
+			delete(c.homeNodeReadySince, schedule.HomeNode)
+			continue
+		}
+		if _, ok := c.homeNodeReadySince[node.Name]; !ok {
+			c.homeNodeReadySince[node.Name] = now
+		}
+		if elapsed := now.Sub(c.homeNodeReadySince[node.Name]); elapsed <= delay {
+			logger.Infof("node %q of mon %q is ready again, waiting %d seconds before moving the mon back", node.Name, name, int((delay - elapsed).Seconds()))
+			continue
+		}
+		if elapsed := now.Sub(c.lastMonFailback); elapsed < interval {
+			logger.Infof("waiting %d seconds since the last mon was moved back before moving mon %q back to node %q", int((interval - elapsed).Seconds()), name, node.Name)
+			return false
+		}
+
+		if controller.DeferForRecovery(c.context, c.ClusterInfo, c.spec.HealthCheck.RecoveryDeferral, controller.MaintenanceMonFailback) {
+			return false
+		}
+
+		logger.Infof("moving mon %q back to its node %q", name, node.Name)
+		// the mon is healthy, it keeps running until the new mon on its node is in quorum
+		c.preemptiveFailover = name
+		c.failbackNode = node
+		failedOver := c.failMon(monCount, desiredMonCount, name)
+		c.preemptiveFailover = ""
+		c.failbackNode = nil
+		if !failedOver {
+			continue
+		}
+		c.lastMonFailback = now
+		delete(c.homeNodeReadySince, node.Name)
+		if _, ok := c.ClusterInfo.InternalMonitors[name]; ok {
+			// the failover was reverted, the mon is moved back after the failback interval
+			return true
+		}
+		c.recordEvent(v1.EventTypeNormal, MonFailbackReason, "moved mon %q back to node %q", name, node.Name)
+		return true
+	}
+	return false
+}
+
+// pinMonToFailbackNode schedules the new mon on the node the failed over mon is moved back to
+func (c *Cluster) pinMonToFailbackNode(m *monConfig) error {
+	schedule, err := getNodeInfoFromNode(*c.failbackNode)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the info of node %q", c.failbackNode.Name)
+	}
+	schedule.Zone = m.Zone
+	if err := c.selectMonAddresses(m.DaemonName, m.Zone, *c.failbackNode, schedule); err != nil {
+		return err
+	}
+	c.mapping.Schedule[m.DaemonName] = schedule
+	return nil
+}
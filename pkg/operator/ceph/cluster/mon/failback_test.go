@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func failbackTestNode(name string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "zone1"}},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}},
+		},
+	}
+}
+
+func newFailbackTestCluster(t *testing.T, nodes ...*corev1.Node) *Cluster {
+	clientset := k8sfake.NewSimpleClientset()
+	for _, node := range nodes {
+		_, err := clientset.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	return &Cluster{
+		ClusterInfo: clienttest.CreateTestClusterInfo(3),
+		Namespace:   "ns",
+		context:     &clusterd.Context{Clientset: clientset},
+		mapping: &controller.Mapping{Schedule: map[string]*controller.MonScheduleInfo{
+			"a": {Name: "node1"},
+			"b": {Name: "node2"},
+			"c": {Name: "node3", HomeNode: "node4"},
+		}},
+		homeNodeReadySince: map[string]time.Time{},
+	}
+}
+
+func TestMonHomeNode(t *testing.T) {
+	c := newFailbackTestCluster(t, failbackTestNode("node1", false), failbackTestNode("node2", true))
+
+	// the mon failed over from a node that is not ready is moved back to it
+	assert.Equal(t, "node1", c.monHomeNode("a"))
+	// the mon failed over from a ready node is not moved back
+	assert.Equal(t, "", c.monHomeNode("b"))
+	// the original node is kept when the mon is failed over again
+	assert.Equal(t, "node4", c.monHomeNode("c"))
+	// the mon on a deleted node or not pinned to a node has no home
+	c.mapping.Schedule["d"] = &controller.MonScheduleInfo{Name: "node5"}
+	c.mapping.Schedule["e"] = nil
+	assert.Equal(t, "", c.monHomeNode("d"))
+	assert.Equal(t, "", c.monHomeNode("e"))
+
+	// the node of the mon is not its home when it is cordoned but ready
+	node := failbackTestNode("node2", true)
+	node.Spec.Unschedulable = true
+	_, err := c.context.Clientset.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", c.monHomeNode("b"))
+}
+
+func TestGetMonFailbackNode(t *testing.T) {
+	c := newFailbackTestCluster(t, failbackTestNode("node4", false))
+	schedule := c.mapping.Schedule["c"]
+
+	// the node is not ready
+	assert.Nil(t, c.getMonFailbackNode("c", schedule))
+
+	_, err := c.context.Clientset.CoreV1().Nodes().Update(context.TODO(), failbackTestNode("node4", true), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	node := c.getMonFailbackNode("c", schedule)
+	require.NotNil(t, node)
+	assert.Equal(t, "node4", node.Name)
+
+	// the node is in another zone than the mon
+	schedule.Zone = "zone2"
+	assert.Nil(t, c.getMonFailbackNode("c", schedule))
+	schedule.Zone = "zone1"
+	assert.NotNil(t, c.getMonFailbackNode("c", schedule))
+
+	// the node no longer matches the mon placement
+	c.spec.Placement = cephv1.PlacementSpec{cephv1.KeyMon: {NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "role", Operator: corev1.NodeSelectorOpIn, Values: []string{"mon"}}},
+		}}},
+	}}}
+	assert.Nil(t, c.getMonFailbackNode("c", schedule))
+	c.spec.Placement = nil
+
+	// another mon runs on the node
+	c.mapping.Schedule["b"].Name = "node4"
+	assert.Nil(t, c.getMonFailbackNode("c", schedule))
+	c.spec.Mon.AllowMultiplePerNode = true
+	assert.NotNil(t, c.getMonFailbackNode("c", schedule))
+}
+
+func TestCheckMonFailbackWaits(t *testing.T) {
+	c := newFailbackTestCluster(t, failbackTestNode("node4", false))
+
+	// the failback is disabled by default
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Empty(t, c.homeNodeReadySince)
+
+	c.spec.Mon.Failback = &cephv1.MonFailbackSpec{Enabled: true}
+	// the home node is not ready
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Empty(t, c.homeNodeReadySince)
+
+	// the mon waits for the failback delay once the node is ready
+	_, err := c.context.Clientset.CoreV1().Nodes().Update(context.TODO(), failbackTestNode("node4", true), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.False(t, c.checkMonFailback(3, 3))
+	require.Contains(t, c.homeNodeReadySince, "node4")
+	since := c.homeNodeReadySince["node4"]
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Equal(t, since, c.homeNodeReadySince["node4"])
+
+	// the mon waits for the failback interval after the last mon moved back
+	c.spec.Mon.Failback.Delay = &metav1.Duration{Duration: time.Minute}
+	c.homeNodeReadySince["node4"] = time.Now().Add(-2 * time.Minute)
+	c.lastMonFailback = time.Now().Add(-time.Minute)
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Contains(t, c.homeNodeReadySince, "node4")
+
+	// the ready time is forgotten when the node is not ready again
+	_, err = c.context.Clientset.CoreV1().Nodes().Update(context.TODO(), failbackTestNode("node4", false), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Empty(t, c.homeNodeReadySince)
+
+	// the ready time of a node that is no longer the home of a mon is forgotten
+	c.homeNodeReadySince["node9"] = time.Now()
+	assert.False(t, c.checkMonFailback(3, 3))
+	assert.Empty(t, c.homeNodeReadySince)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// defaultFailoverWindow is the window of the mon failover budget
+	defaultFailoverWindow = time.Hour
+	// defaultFailoverInitialBackoff is the time to wait after the first failover of a mon
+	defaultFailoverInitialBackoff = 10 * time.Minute
+	// defaultFailoverMaxBackoff is the maximum time to wait between the failovers of a mon
+	defaultFailoverMaxBackoff = 2 * time.Hour
+)
+
+// monFailoverBackoff tracks the mon failovers of the health checker to rate limit them
+type monFailoverBackoff struct {
+	// failovers are the times of the mon failovers in the current window
+	failovers []time.Time
+	// mons are the backoffs of the mons failed over since they were last in quorum
+	mons map[string]*monBackoff
+	// deferredMessage is the deferred failover last recorded in an event
+	deferredMessage string
+	// reported is the backoff status last reported on the CephCluster
+	reported *cephv1.MonFailoverBackoffStatus
+}
+
+// monBackoff is the backoff of the failovers of a mon
+type monBackoff struct {
+	failovers int
+	next      time.Time
+}
+
+// failoverRateLimitDuration returns the duration of a rate limit setting, or its default if unset
+func failoverRateLimitDuration(d *metav1.Duration, defaultDuration time.Duration) time.Duration {
+	if d == nil || d.Duration <= 0 {
+		return defaultDuration
+	}
+	return d.Duration
+}
+
+// failoverDeferred returns why the failover of the mon must wait, or an empty string if the mon
+// can be failed over now
+func (c *Cluster) failoverDeferred(name string, now time.Time) string {
+	limit := c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit
+	if limit == nil {
+		return ""
+	}
+	c.pruneFailovers(now)
+
+	if backoff, ok := c.failoverBackoff.mons[name]; ok && now.Before(backoff.next) {
+		return fmt.Sprintf("mon %q was failed over %d time(s) since it was last in quorum, its next failover is allowed at %s",
+			name, backoff.failovers, backoff.next.UTC().Format(time.RFC3339))
+	}
+	if limit.MaxFailovers > 0 && len(c.failoverBackoff.failovers) >= limit.MaxFailovers {
+		window := failoverRateLimitDuration(limit.Window, defaultFailoverWindow)
+		return fmt.Sprintf("the budget of %d mon failovers per %s is exhausted, the failover of mon %q is allowed at %s",
+			limit.MaxFailovers, window.String(), name, c.failoverBackoff.failovers[0].Add(window).UTC().Format(time.RFC3339))
+	}
+	return ""
+}
+
+// deferFailover logs why the failover of the mon waits, and records an event once per deferral
+func (c *Cluster) deferFailover(message string) {
+	logger.Warningf("mon failover deferred: %s", message)
+	if message == c.failoverBackoff.deferredMessage {
+		return
+	}
+	c.failoverBackoff.deferredMessage = message
+	c.recordEvent(v1.EventTypeWarning, MonFailoverDeferredReason, "%s", message)
+}
+
+// recordFailover counts the failover in the budget of the window and backs off the next failover
+// of the mon, doubling the backoff for each failover until the mon is back in quorum
+func (c *Cluster) recordFailover(name string, now time.Time) {
+	limit := c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit
+	if limit == nil {
+		return
+	}
+	c.failoverBackoff.failovers = append(c.failoverBackoff.failovers, now)
+	if c.failoverBackoff.mons == nil {
+		c.failoverBackoff.mons = map[string]*monBackoff{}
+	}
+	backoff, ok := c.failoverBackoff.mons[name]
+	if !ok {
+		backoff = &monBackoff{}
+		c.failoverBackoff.mons[name] = backoff
+	}
+	backoff.failovers++
+
+	maxBackoff := failoverRateLimitDuration(limit.MaxBackoff, defaultFailoverMaxBackoff)
+	delay := failoverRateLimitDuration(limit.InitialBackoff, defaultFailoverInitialBackoff)
+	for i := 1; i < backoff.failovers && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	backoff.next = now.Add(delay)
+	logger.Infof("mon %q failed over %d time(s) since it was last in quorum, its next failover is allowed at %s",
+		name, backoff.failovers, backoff.next.UTC().Format(time.RFC3339))
+}
+
+// resetFailoverBackoff forgets the backoff of a mon back in quorum
+func (c *Cluster) resetFailoverBackoff(name string) {
+	if _, ok := c.failoverBackoff.mons[name]; ok {
+		delete(c.failoverBackoff.mons, name)
+		logger.Infof("mon %q is back in quorum, reset its failover backoff", name)
+	}
+}
+
+// pruneFailovers forgets the failovers out of the window and the backoffs of the removed mons
+func (c *Cluster) pruneFailovers(now time.Time) {
+	window := defaultFailoverWindow
+	if limit := c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit; limit != nil {
+		window = failoverRateLimitDuration(limit.Window, defaultFailoverWindow)
+	}
+	i := 0
+	for i < len(c.failoverBackoff.failovers) && !now.Before(c.failoverBackoff.failovers[i].Add(window)) {
+		i++
+	}
+	c.failoverBackoff.failovers = c.failoverBackoff.failovers[i:]
+
+	for name := range c.failoverBackoff.mons {
+		if _, ok := c.ClusterInfo.InternalMonitors[name]; !ok {
+			delete(c.failoverBackoff.mons, name)
+		}
+	}
+}
+
+// failoverBackoffStatus returns the state of the rate limit of the mon failovers to report on the
+// CephCluster, or nil if the failovers are not rate limited
+func (c *Cluster) failoverBackoffStatus(now time.Time) *cephv1.MonFailoverBackoffStatus {
+	limit := c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit
+	if limit == nil {
+		return nil
+	}
+	c.pruneFailovers(now)
+
+	status := &cephv1.MonFailoverBackoffStatus{FailoversInWindow: len(c.failoverBackoff.failovers)}
+	if limit.MaxFailovers > 0 && len(c.failoverBackoff.failovers) >= limit.MaxFailovers {
+		window := failoverRateLimitDuration(limit.Window, defaultFailoverWindow)
+		status.BudgetResetTime = &metav1.Time{Time: c.failoverBackoff.failovers[0].Add(window).UTC()}
+	}
+	for name, backoff := range c.failoverBackoff.mons {
+		status.Mons = append(status.Mons, cephv1.MonFailoverBackoff{
+			Name:             name,
+			Failovers:        backoff.failovers,
+			NextFailoverTime: metav1.Time{Time: backoff.next.UTC()},
+		})
+	}
+	sort.Slice(status.Mons, func(i, j int) bool { return status.Mons[i].Name < status.Mons[j].Name })
+	return status
+}
+
+// reportFailoverBackoff publishes the state of the rate limit of the mon failovers in the
+// CephCluster status when it changed since it was last reported
+func (c *Cluster) reportFailoverBackoff() {
+	status := c.failoverBackoffStatus(time.Now())
+	if reflect.DeepEqual(status, c.failoverBackoff.reported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the mon failover backoff. %v", err)
+		return
+	}
+	cephCluster.Status.MonFailoverBackoff = status
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the mon failover backoff in the CephCluster status. %v", err)
+		return
+	}
+	c.failoverBackoff.reported = status
+}
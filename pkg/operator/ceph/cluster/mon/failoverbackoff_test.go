@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMonFailoverRateLimit(t *testing.T) {
+	newBackoffTestCluster := func(limit *cephv1.MonFailoverRateLimitSpec) *Cluster {
+		c := &Cluster{ClusterInfo: clienttest.CreateTestClusterInfo(3)}
+		c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit = limit
+		return c
+	}
+	now := time.Now()
+
+	t.Run("not rate limited", func(t *testing.T) {
+		c := newBackoffTestCluster(nil)
+		for i := 0; i < 5; i++ {
+			assert.Empty(t, c.failoverDeferred("a", now))
+			c.recordFailover("a", now)
+		}
+		assert.Nil(t, c.failoverBackoffStatus(now))
+	})
+
+	t.Run("exponential backoff of a mon", func(t *testing.T) {
+		c := newBackoffTestCluster(&cephv1.MonFailoverRateLimitSpec{
+			InitialBackoff: &metav1.Duration{Duration: time.Minute},
+			MaxBackoff:     &metav1.Duration{Duration: 3 * time.Minute},
+		})
+		assert.Empty(t, c.failoverDeferred("a", now))
+		c.recordFailover("a", now)
+		assert.Equal(t, now.Add(time.Minute), c.failoverBackoff.mons["a"].next)
+		assert.Contains(t, c.failoverDeferred("a", now.Add(30*time.Second)), `mon "a" was failed over 1 time(s)`)
+		// the other mons are not backed off
+		assert.Empty(t, c.failoverDeferred("b", now))
+
+		failoverTime := now.Add(time.Minute)
+		assert.Empty(t, c.failoverDeferred("a", failoverTime))
+		c.recordFailover("a", failoverTime)
+		assert.Equal(t, failoverTime.Add(2*time.Minute), c.failoverBackoff.mons["a"].next)
+
+		// the backoff is capped
+		failoverTime = failoverTime.Add(2 * time.Minute)
+		c.recordFailover("a", failoverTime)
+		assert.Equal(t, failoverTime.Add(3*time.Minute), c.failoverBackoff.mons["a"].next)
+
+		status := c.failoverBackoffStatus(failoverTime)
+		assert.Equal(t, 3, status.FailoversInWindow)
+		assert.Nil(t, status.BudgetResetTime)
+		require.Len(t, status.Mons, 1)
+		assert.Equal(t, "a", status.Mons[0].Name)
+		assert.Equal(t, 3, status.Mons[0].Failovers)
+
+		// the backoff is reset when the mon is back in quorum
+		c.resetFailoverBackoff("a")
+		assert.Empty(t, c.failoverDeferred("a", failoverTime))
+	})
+
+	t.Run("failover budget", func(t *testing.T) {
+		c := newBackoffTestCluster(&cephv1.MonFailoverRateLimitSpec{
+			MaxFailovers: 2,
+			Window:       &metav1.Duration{Duration: time.Hour},
+		})
+		c.recordFailover("a", now)
+		c.recordFailover("b", now.Add(time.Minute))
+		assert.Contains(t, c.failoverDeferred("c", now.Add(2*time.Minute)), "the budget of 2 mon failovers per 1h0m0s is exhausted")
+		status := c.failoverBackoffStatus(now.Add(2 * time.Minute))
+		require.NotNil(t, status.BudgetResetTime)
+		assert.True(t, status.BudgetResetTime.Time.Equal(now.Add(time.Hour)))
+
+		// the first failover leaves the window
+		assert.Empty(t, c.failoverDeferred("c", now.Add(time.Hour)))
+		assert.Equal(t, 1, len(c.failoverBackoff.failovers))
+	})
+
+	t.Run("backoff of removed mons is forgotten", func(t *testing.T) {
+		c := newBackoffTestCluster(&cephv1.MonFailoverRateLimitSpec{})
+		c.recordFailover("a", now)
+		delete(c.ClusterInfo.InternalMonitors, "a")
+		assert.Empty(t, c.failoverBackoffStatus(now).Mons)
+	})
+
+	t.Run("status is reported when changed", func(t *testing.T) {
+		c := newBackoffTestCluster(&cephv1.MonFailoverRateLimitSpec{})
+		nsName := c.ClusterInfo.NamespacedName()
+		cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+		scheme := runtime.NewScheme()
+		require.NoError(t, cephv1.AddToScheme(scheme))
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+		c.context = &clusterd.Context{Client: cl}
+
+		c.recordFailover("b", time.Now())
+		c.reportFailoverBackoff()
+		require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+		require.NotNil(t, cephCluster.Status.MonFailoverBackoff)
+		assert.Equal(t, 1, cephCluster.Status.MonFailoverBackoff.FailoversInWindow)
+		require.Len(t, cephCluster.Status.MonFailoverBackoff.Mons, 1)
+		assert.Equal(t, "b", cephCluster.Status.MonFailoverBackoff.Mons[0].Name)
+
+		// the rate limit is removed
+		c.spec.HealthCheck.DaemonHealth.Monitor.FailoverRateLimit = nil
+		c.reportFailoverBackoff()
+		require.NoError(t, cl.Get(context.TODO(), nsName, cephCluster))
+		assert.Nil(t, cephCluster.Status.MonFailoverBackoff)
+	})
+}
+
+func TestMonFailoverDisruptionBudget(t *testing.T) {
+	c := &Cluster{ClusterInfo: clienttest.CreateTestClusterInfo(3)}
+	c.Namespace = c.ClusterInfo.Namespace
+	k8sutil.SetDisruptionBudget(c.Namespace, 1, nil)
+	defer k8sutil.ResetDisruptionBudget(c.Namespace)
+
+	// the failover waits for the OSD update in progress
+	release, err := k8sutil.AcquireDisruption(c.Namespace, k8sutil.DisruptionOSD, "osd")
+	require.NoError(t, err)
+	assert.False(t, c.failMon(3, 3, "d"))
+	assert.Contains(t, c.failoverBackoff.deferredMessage, `delaying the disruption of "mon.d" while the disruption of osd is in progress`)
+	release()
+
+	// the waiting mon failover is granted before the OSD update
+	_, err = k8sutil.AcquireDisruption(c.Namespace, k8sutil.DisruptionOSD, "osd")
+	assert.ErrorIs(t, err, k8sutil.ErrDisruptionBudgetExceeded)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreviewMonFailoverAnnotation is set on the CephCluster with the name of a mon to find where its
+// replacement would be placed without failing it over
+const PreviewMonFailoverAnnotation = "ceph.rook.io/preview-mon-failover"
+
+// PreviewFailoverIfRequested previews the failover of the mon named in the preview annotation of
+// the CephCluster, publishes the planned placement in the CephCluster status and removes the
+// annotation. It is called by the cluster reconcile with the annotations of the CephCluster.
+func (c *Cluster) PreviewFailoverIfRequested(annotations map[string]string) error {
+	name := strings.TrimSpace(annotations[PreviewMonFailoverAnnotation])
+	if name == "" {
+		return nil
+	}
+
+	// the preview must not run concurrently with a failover of the health check
+	c.acquireOrchestrationLock()
+	preview := c.previewFailover(name)
+	c.releaseOrchestrationLock()
+
+	ctx := c.ClusterInfo.Context
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(ctx, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		return errors.Wrap(err, "failed to get the CephCluster to publish the mon failover preview")
+	}
+	cephCluster.Status.MonFailoverPreview = preview
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		return errors.Wrap(err, "failed to publish the mon failover preview in the CephCluster status")
+	}
+
+	// the failover is previewed once per request
+	original := cephCluster.DeepCopy()
+	delete(cephCluster.Annotations, PreviewMonFailoverAnnotation)
+	if err := c.context.Client.Patch(ctx, cephCluster, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "failed to remove the %q annotation from the CephCluster", PreviewMonFailoverAnnotation)
+	}
+	return nil
+}
+
+// previewFailover schedules a canary of the replacement of the mon the same way as a failover, and
+// returns where it was placed. The mon is not stopped and the canary is removed once scheduled.
+func (c *Cluster) previewFailover(name string) *cephv1.MonFailoverPreviewStatus {
+	preview := &cephv1.MonFailoverPreviewStatus{Mon: name, PreviewTime: metav1.Now()}
+	if _, ok := c.ClusterInfo.InternalMonitors[name]; !ok {
+		preview.Message = fmt.Sprintf("mon %q is not a mon of the cluster", name)
+		return preview
+	}
+
+	zone, err := c.findAvailableZone(c.clusterInfoToMonConfigWithExclude(name))
+	if err != nil {
+		preview.Message = fmt.Sprintf("failed to find an available zone. %v", err)
+		return preview
+	}
+	m := c.newMonConfig(c.nextMonID(c.clusterInfoToMonConfig()), zone)
+	preview.Replacement = m.DaemonName
+	preview.Zone = zone
+	logger.Infof("previewing the failover of mon %q with replacement mon %q", name, m.DaemonName)
+
+	deployment, err := scheduleMonitor(c, m)
+	defer c.removeFailoverPreviewCanary(m)
+	if err != nil {
+		preview.Message = fmt.Sprintf("failed to schedule the canary of mon %q. %v", m.DaemonName, err)
+		return preview
+	}
+	node, err := c.scheduler().WaitForScheduling(deployment)
+	if err != nil || node == nil {
+		preview.Message = fmt.Sprintf("the canary of mon %q could not be scheduled. %v", m.DaemonName, err)
+		return preview
+	}
+	preview.Node = node.Name
+	logger.Infof("the replacement mon %q of mon %q would be placed on node %q", m.DaemonName, name, preview.Node)
+	return preview
+}
+
+// removeFailoverPreviewCanary removes the canary deployment and the PVC created for the replacement mon
+func (c *Cluster) removeFailoverPreviewCanary(m *monConfig) {
+	canaryName := m.ResourceName + "-canary"
+	if err := k8sutil.DeleteDeployment(c.ClusterInfo.Context, c.context.Clientset, c.Namespace, canaryName); err != nil && !kerrors.IsNotFound(err) {
+		logger.Warningf("failed to delete the failover preview canary %q. %v", canaryName, err)
+	}
+	if c.monVolumeClaimTemplate(m) == nil {
+		return
+	}
+	// the replacement mon does not exist, so its PVC was only created for the canary
+	err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.Namespace).Delete(c.ClusterInfo.Context, m.ResourceName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		logger.Warningf("failed to delete the failover preview canary pvc %q. %v", m.ResourceName, err)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPreviewFailoverIfRequested(t *testing.T) {
+	ctx := context.TODO()
+	originalWaitForMonitorScheduling := waitForMonitorScheduling
+	t.Cleanup(func() { waitForMonitorScheduling = originalWaitForMonitorScheduling })
+
+	// newPreviewTestCluster returns a mon cluster whose CephCluster has the annotations
+	newPreviewTestCluster := func(t *testing.T, annotations map[string]string) *Cluster {
+		c := newQuorumRestoreTestCluster(t, nil)
+		nsName := c.ClusterInfo.NamespacedName()
+		cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace, Annotations: annotations}}
+		scheme := runtime.NewScheme()
+		require.NoError(t, cephv1.AddToScheme(scheme))
+		c.context.Client = fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+		return c
+	}
+	getCephCluster := func(c *Cluster) *cephv1.CephCluster {
+		cephCluster := &cephv1.CephCluster{}
+		require.NoError(t, c.context.Client.Get(ctx, c.ClusterInfo.NamespacedName(), cephCluster))
+		return cephCluster
+	}
+
+	t.Run("not requested", func(t *testing.T) {
+		c := newPreviewTestCluster(t, nil)
+		assert.NoError(t, c.PreviewFailoverIfRequested(nil))
+		assert.Nil(t, getCephCluster(c).Status.MonFailoverPreview)
+	})
+
+	t.Run("unknown mon", func(t *testing.T) {
+		annotations := map[string]string{PreviewMonFailoverAnnotation: "z"}
+		c := newPreviewTestCluster(t, annotations)
+		assert.NoError(t, c.PreviewFailoverIfRequested(annotations))
+		cephCluster := getCephCluster(c)
+		require.NotNil(t, cephCluster.Status.MonFailoverPreview)
+		assert.Equal(t, "z", cephCluster.Status.MonFailoverPreview.Mon)
+		assert.Contains(t, cephCluster.Status.MonFailoverPreview.Message, "not a mon of the cluster")
+		assert.NotContains(t, cephCluster.Annotations, PreviewMonFailoverAnnotation)
+	})
+
+	t.Run("previewed", func(t *testing.T) {
+		annotations := map[string]string{PreviewMonFailoverAnnotation: "b", "other": "kept"}
+		c := newPreviewTestCluster(t, annotations)
+		c.maxMonID = 2
+		var canaryName string
+		waitForMonitorScheduling = func(c *Cluster, d *apps.Deployment) (SchedulingResult, error) {
+			canaryName = d.Name
+			node, err := c.context.Clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+			return SchedulingResult{Node: node}, err
+		}
+
+		assert.NoError(t, c.PreviewFailoverIfRequested(annotations))
+		cephCluster := getCephCluster(c)
+		preview := cephCluster.Status.MonFailoverPreview
+		require.NotNil(t, preview)
+		assert.Equal(t, "b", preview.Mon)
+		assert.Equal(t, "d", preview.Replacement)
+		assert.Equal(t, "node1", preview.Node)
+		assert.Empty(t, preview.Message)
+		assert.Equal(t, map[string]string{"other": "kept"}, cephCluster.Annotations)
+
+		// the canary is removed and the mons are not changed
+		assert.Equal(t, "rook-ceph-mon-d-canary", canaryName)
+		_, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, canaryName, metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+		assert.Len(t, c.ClusterInfo.InternalMonitors, 3)
+		assert.Equal(t, 2, c.maxMonID)
+	})
+}
@@ -18,8 +18,10 @@ package mon
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/config"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -46,8 +49,17 @@ var (
 	timeZero                       = time.Duration(0)
 	// Check whether mons are on the same node once per operator restart since it's a rare scheduling condition
 	needToCheckMonsOnSameNode = true
+	// updateCondition is a variable so the unit tests can stub the CephCluster status updates
+	updateCondition = controller.UpdateCondition
+	// getHealthDetail is a variable so the unit tests can stub the health details of the cluster
+	getHealthDetail = func(c *Cluster) (cephclient.HealthStatus, error) {
+		return cephclient.HealthDetail(c.context, c.ClusterInfo)
+	}
 )
 
+// arbiterTimeoutOverride is the key of the timeout override of the arbiter mon of a stretch cluster
+const arbiterTimeoutOverride = "arbiter"
+
 // HealthChecker aggregates the mon/cluster info needed to check the health of the monitors
 type HealthChecker struct {
 	monCluster *Cluster
@@ -79,6 +91,25 @@ func updateMonTimeout(monCluster *Cluster) {
 	// A third case is when the CRD is not set, in which case we use the default from MonOutTimeout
 }
 
+// monOutTimeout returns the timeout of the mon before it is failed over, from the timeout
+// overrides of the mon if any
+func (c *Cluster) monOutTimeout(name string) time.Duration {
+	overrides := c.spec.HealthCheck.DaemonHealth.Monitor.TimeoutOverrides
+	override, ok := overrides[name]
+	if !ok && name == c.arbiterMon && c.arbiterMon != "" {
+		override, ok = overrides[arbiterTimeoutOverride]
+	}
+	if !ok {
+		return MonOutTimeout
+	}
+	timeout, err := time.ParseDuration(override)
+	if err != nil {
+		logger.Warningf("invalid timeout override %q of mon %q, using the mon timeout %q. %v", override, name, MonOutTimeout.String(), err)
+		return MonOutTimeout
+	}
+	return timeout
+}
+
 func updateMonInterval(monCluster *Cluster, h *HealthChecker) {
 	// If the env was passed by the operator config, use that value
 	// This is an old behavior where we maintain backward compatibility
@@ -148,6 +179,11 @@ func (hc *HealthChecker) Check(monitoringRoutines map[string]*controller.Cluster
 	}
 }
 
+// CheckHealth runs a single check of the health of the mons, as done periodically by Check
+func (hc *HealthChecker) CheckHealth(ctx context.Context) error {
+	return hc.monCluster.checkHealth(ctx)
+}
+
 func (c *Cluster) checkHealth(ctx context.Context) error {
 	c.acquireOrchestrationLock()
 	defer c.releaseOrchestrationLock()
@@ -175,7 +211,7 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 
 	// For an external connection we use a special function to get the status
 	if c.spec.External.Enable {
-		quorumStatus, err := cephclient.GetMonQuorumStatus(c.context, c.ClusterInfo)
+		quorumStatus, err := c.quorumFetcher().MonQuorumStatus(c.context, c.ClusterInfo)
 		if err != nil {
 			return errors.Wrap(err, "failed to get external mon quorum status")
 		}
@@ -194,10 +230,40 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 		return nil
 	}
 
+	// publish the rate limit of the failovers once the mons are checked
+	defer c.reportFailoverBackoff()
+	// publish the progress of the expansion of the mon PVCs requested by the last reconcile
+	defer c.reportMonVolumeExpansion()
+	// publish the progress of the migration of the mons to PVCs
+	defer c.reportMonPVCMigration()
+	// publish the mons whose clock is skewed
+	defer c.reportMonClockSkew()
+	// publish the mons whose disk is low on space
+	defer c.reportMonDiskUsage()
+	// publish the rank, quorum and placement of the mons
+	defer c.reportMonStatus()
+	// publish the readiness of the mon zones
+	defer c.reportMonZones()
+
+	// the zones are checked even without quorum since mislabeled nodes can prevent the mons from
+	// starting
+	c.checkMonZones(ctx)
+
 	// connect to the mons
 	// get the status and check for quorum
-	quorumStatus, err := cephclient.GetMonQuorumStatus(c.context, c.ClusterInfo)
+	quorumStatus, err := c.quorumFetcher().MonQuorumStatus(c.context, c.ClusterInfo)
 	if err != nil {
+		// the quorum is restored from a surviving mon only on request, the other mons might be
+		// down only temporarily
+		if controller.FeatureEnabled(controller.MonQuorumRestore) {
+			restored, restoreErr := c.restoreQuorumIfRequested(ctx)
+			if restoreErr != nil {
+				return errors.Wrap(restoreErr, "failed to restore the mon quorum")
+			}
+			if restored {
+				return nil
+			}
+		}
 		return errors.Wrap(err, "failed to get mon quorum status")
 	}
 	logger.Debugf("Mon quorum status: %+v", quorumStatus)
@@ -207,11 +273,24 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to check external mons health")
 	}
+	c.recordQuorumMetrics(len(quorumStatus.MonMap.Mons), len(quorumStatus.Quorum))
+	c.updateMonQuorum(quorumStatus)
+	c.updateMonsInMaintenance(ctx)
 
 	// Use a local mon count in case the user updates the crd in another goroutine.
 	// We need to complete a health check with a consistent value.
 	desiredMonCount := c.spec.Mon.Count
 	logger.Debugf("targeting the mon count %d", desiredMonCount)
+	c.reportEvenMonCount(len(quorumStatus.MonMap.Mons), desiredMonCount)
+
+	// Reschedule the mons of a failed stretch zone, or move them back once the zone recovered
+	zoneFailedOver, zoneWaitingMons, err := c.checkStretchZoneFailure(ctx, quorumStatus)
+	if err != nil {
+		logger.Warningf("failed to check the stretch zone failures. %v", err)
+	} else if zoneFailedOver {
+		// only deal with one mon per health check
+		return nil
+	}
 
 	// Source of truth of which mons should exist is our *clusterInfo*
 	monsNotFound := map[string]interface{}{}
@@ -235,6 +314,8 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 				logger.Warningf("mon %q not in source of truth but in quorum, removing", mon.Name)
 				if err := c.removeMon(mon.Name); err != nil {
 					logger.Warningf("failed to remove mon %q. %v", mon.Name, err)
+				} else {
+					c.recordEvent(v1.EventTypeNormal, MonRemovedReason, "removed mon %q that is not in the source of truth", mon.Name)
 				}
 				// only remove one extra mon per health check
 				return nil
@@ -257,6 +338,7 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 				delete(c.monTimeoutList, mon.Name)
 				logger.Infof("mon %q is back in quorum, removed from mon out timeout list", mon.Name)
 			}
+			c.resetFailoverBackoff(mon.Name)
 			continue
 		}
 
@@ -266,8 +348,30 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 			return errors.Wrapf(err, "failed to track out of quorum mon %q", mon.Name)
 		}
 
+		if c.spec.Mon.VerifyStore {
+			reason, err := c.getMonStoreCheckFailure(ctx, mon.Name)
+			if err != nil {
+				logger.Warningf("failed to check the store verification of mon %q. %v", mon.Name, err)
+			} else if reason != "" {
+				c.reportMonStoreCheckFailure(fmt.Sprintf("mon %q is out of quorum because its store failed the integrity check: %s", mon.Name, reason))
+			}
+		}
+
+		if c.maintenanceMons.Has(mon.Name) {
+			// the admin is debugging the mon, its out of quorum timeout starts once the maintenance ends
+			logger.Warningf("mon %q NOT found in quorum but it is in maintenance, skipping its failover", mon.Name)
+			delete(c.monTimeoutList, mon.Name)
+			continue
+		}
+
+		if zoneWaitingMons.Has(mon.Name) {
+			// the whole zone of the mon is out of quorum, its mons are rescheduled together
+			continue
+		}
+
 		// if the time out is set to 0 this indicate that we don't want to trigger mon failover
-		if MonOutTimeout == timeZero {
+		monOutTimeout := c.monOutTimeout(mon.Name)
+		if monOutTimeout == timeZero {
 			logger.Warningf("mon %q NOT found in quorum and health timeout is 0, mon will never fail over", mon.Name)
 			continue
 		}
@@ -280,8 +384,8 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 
 		// when the timeout for the mon has been reached, continue to the
 		// normal failover mon pod part of the code
-		if time.Since(c.monTimeoutList[mon.Name]) <= MonOutTimeout {
-			timeToFailover := int(MonOutTimeout.Seconds() - time.Since(c.monTimeoutList[mon.Name]).Seconds())
+		if time.Since(c.monTimeoutList[mon.Name]) <= monOutTimeout {
+			timeToFailover := int(monOutTimeout.Seconds() - time.Since(c.monTimeoutList[mon.Name]).Seconds())
 			logger.Warningf("mon %q not found in quorum, waiting for timeout (%d seconds left) before failover", mon.Name, timeToFailover)
 			continue
 		}
@@ -292,7 +396,7 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 		if err != nil {
 			logger.Warningf("failed to check if mon %q is assigned to a node, continuing with mon failover. %v", mon.Name, err)
 		} else if !isScheduled && retriesBeforeNodeDrainFailover > 0 {
-			logger.Warningf("mon %q NOT found in quorum after timeout. Mon pod is not scheduled. Retrying with a timeout of %.2f seconds before failover", mon.Name, MonOutTimeout.Seconds())
+			logger.Warningf("mon %q NOT found in quorum after timeout. Mon pod is not scheduled. Retrying with a timeout of %.2f seconds before failover", mon.Name, monOutTimeout.Seconds())
 			delete(c.monTimeoutList, mon.Name)
 			retriesBeforeNodeDrainFailover = retriesBeforeNodeDrainFailover - 1
 			return nil
@@ -314,11 +418,27 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 		if _, err := c.trackMonInOrOutOfQuorum("", true); err != nil {
 			return errors.Wrap(err, "failed to track all mons in quorum")
 		}
+		c.reportMonStoreCheckFailure("")
+		c.reportStaleMonEndpoints()
+		c.compactMonStores(quorumStatus)
+	}
+
+	// detect the mons whose clock is skewed or whose disk is low on space, and remediate them while
+	// all the mons are in quorum
+	if health, err := getHealthDetail(c); err != nil {
+		logger.Warningf("failed to get the health details to check the clock and the disk of the mons. %v", err)
+	} else if c.checkClockSkew(health, allMonsInQuorum, len(quorumStatus.MonMap.Mons), desiredMonCount) ||
+		c.checkMonDiskUsage(health, allMonsInQuorum, len(quorumStatus.MonMap.Mons), desiredMonCount) {
+		return nil
 	}
 
 	// after all unhealthy mons have been removed or failed over
 	// handle all mons that haven't been in the Ceph mon map
 	for mon := range monsNotFound {
+		if c.maintenanceMons.Has(mon) {
+			logger.Warningf("mon %s NOT found in ceph mon map but it is in maintenance, skipping its failover", mon)
+			continue
+		}
 		logger.Warningf("mon %s NOT found in ceph mon map, failover", mon)
 		c.failMon(len(c.ClusterInfo.InternalMonitors), desiredMonCount, mon)
 		// only deal with one "not found in ceph mon map" mon per health check
@@ -333,12 +453,7 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 
 	// remove extra mons if the desired count has decreased in the CRD and all the mons are currently healthy
 	if allMonsInQuorum && len(quorumStatus.MonMap.Mons) > desiredMonCount {
-		if desiredMonCount < 2 && len(quorumStatus.MonMap.Mons) == 2 {
-			logger.Warningf("cannot reduce mon quorum size from 2 to 1")
-		} else {
-			logger.Infof("removing an extra mon. currently %d are in quorum and only %d are desired", len(quorumStatus.MonMap.Mons), desiredMonCount)
-			return c.removeMon(c.determineExtraMonToRemove())
-		}
+		return c.removeExtraMons(len(quorumStatus.MonMap.Mons), desiredMonCount)
 	}
 
 	if allMonsInQuorum && len(quorumStatus.MonMap.Mons) == desiredMonCount {
@@ -370,9 +485,38 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 		}
 	}
 
+	// move the tiebreaker mon if the arbiter zone of the stretch cluster changed
+	if allMonsInQuorum {
+		relocated, err := c.relocateArbiterMon()
+		if err != nil {
+			return errors.Wrap(err, "failed to relocate the arbiter mon")
+		}
+		if relocated {
+			return nil
+		}
+	}
+
+	// move a mon failed over during a node outage back to its node
+	if allMonsInQuorum && len(quorumStatus.MonMap.Mons) == desiredMonCount && c.checkMonFailback(len(quorumStatus.MonMap.Mons), desiredMonCount) {
+		return nil
+	}
+
+	// migrate the next mon from the host path to a PVC
+	migrated, err := c.migrateNextMonToPVC(allMonsInQuorum, len(quorumStatus.MonMap.Mons), desiredMonCount)
+	if err != nil {
+		return errors.Wrap(err, "failed to migrate the mons to PVCs")
+	}
+	if migrated {
+		return nil
+	}
+
 	// failover any mons present in the mon fail over list
 	for _, mon := range c.ClusterInfo.InternalMonitors {
 		if _, ok := c.monsToFailover[mon.Name]; ok {
+			if c.maintenanceMons.Has(mon.Name) {
+				logger.Infof("mon %q from the mon fail over list is in maintenance, failing it over once the maintenance ends", mon.Name)
+				continue
+			}
 			logger.Infof("fail over mon %q from the mon fail over list", mon.Name)
 			c.failMon(len(c.ClusterInfo.InternalMonitors), desiredMonCount, mon.Name)
 			delete(c.monsToFailover, mon.Name)
@@ -383,6 +527,21 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 	return nil
 }
 
+// RequestFailover adds the mon to the mon fail over list, the mon is failed over by a next health
+// check. It returns false if the mon is not known.
+func (c *Cluster) RequestFailover(name string) bool {
+	c.acquireOrchestrationLock()
+	defer c.releaseOrchestrationLock()
+
+	for _, mon := range c.clusterInfoToMonConfig() {
+		if mon.DaemonName == name {
+			c.monsToFailover[name] = mon
+			return true
+		}
+	}
+	return false
+}
+
 // reconcileExternalMons handling external monitors defined in CephCluster.spec.mon.externalMonIDs when Rook managing local cluster.
 func (c *Cluster) reconcileExternalMons(ctx context.Context, quorumStatus cephclient.MonStatusResponse) (cephclient.MonStatusResponse, error) {
 	if len(c.spec.Mon.ExternalMonIDs) != 0 {
@@ -413,8 +572,18 @@ func (c *Cluster) reconcileExternalMons(ctx context.Context, quorumStatus cephcl
 		extMonsChanged = true
 	}
 
+	expectedMons := map[string]cephv1.ExternalMonSpec{}
+	for _, extMon := range c.spec.Mon.ExternalMons {
+		if !slices.Contains(extMonIDs, extMon.ID) {
+			logger.Warningf("ignore the expected endpoints of external mon %q: the mon is not in the external mon IDs", extMon.ID)
+			continue
+		}
+		expectedMons[extMon.ID] = extMon
+	}
+
 	// handle external monitors if configured in cluster CRD:
 	logger.Debugf("external mon IDs: %v", extMonIDs)
+	mismatches := []string{}
 	for _, extID := range extMonIDs {
 		monStatus, inQuorum := getMonByID(extID, quorumStatus)
 		if inQuorum {
@@ -422,6 +591,13 @@ func (c *Cluster) reconcileExternalMons(ctx context.Context, quorumStatus cephcl
 		} else {
 			logger.Debugf("external mon %q not in quorum %+v, %+v", extID, quorumStatus.Quorum, quorumStatus.MonMap.Mons)
 		}
+		if expected, ok := expectedMons[extID]; ok && monStatus.Name != "" {
+			// the endpoints of an external mon are not trusted when they differ from the expected ones
+			if mismatch := externalMonMismatch(expected, monStatus); mismatch != "" {
+				mismatches = append(mismatches, mismatch)
+				inQuorum = false
+			}
+		}
 		_, inInfo := c.ClusterInfo.ExternalMons[extID]
 		if inQuorum && !inInfo {
 			// add newly discovered external mon to cluster info:
@@ -436,6 +612,7 @@ func (c *Cluster) reconcileExternalMons(ctx context.Context, quorumStatus cephcl
 			logger.Infof("new external mon %q not in quorum: removing it", extID)
 		}
 	}
+	c.reportExternalMonMismatch(mismatches)
 	if extMonsChanged {
 		// update config if external mon was removed or added:
 		if err := c.saveMonConfig(); err != nil {
@@ -449,6 +626,76 @@ func (c *Cluster) reconcileExternalMons(ctx context.Context, quorumStatus cephcl
 	return quorumStatus, nil
 }
 
+// externalMonMismatch returns why the endpoints of the external mon in the monmap do not match the
+// expected endpoints, or an empty string if they match
+func externalMonMismatch(expected cephv1.ExternalMonSpec, mon cephclient.MonMapEntry) string {
+	endpoints := monMapEndpoints(mon)
+	if len(endpoints) == 0 {
+		return fmt.Sprintf("external mon %q has no public address in the monmap", mon.Name)
+	}
+	ports := map[string]bool{}
+	for _, endpoint := range endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return fmt.Sprintf("external mon %q has the invalid endpoint %q in the monmap", mon.Name, endpoint)
+		}
+		if expected.Address != "" && host != expected.Address {
+			return fmt.Sprintf("external mon %q listens on %q instead of the expected address %q", mon.Name, endpoint, expected.Address)
+		}
+		ports[port] = true
+	}
+	for _, port := range expected.Ports {
+		if !ports[strconv.Itoa(int(port))] {
+			return fmt.Sprintf("external mon %q does not listen on the expected port %d, its endpoints are %s", mon.Name, port, strings.Join(endpoints, ", "))
+		}
+	}
+	return ""
+}
+
+// monMapEndpoints returns the public endpoints of the mon in the monmap for all the messenger
+// versions, without the nonce
+func monMapEndpoints(mon cephclient.MonMapEntry) []string {
+	endpoints := []string{}
+	for _, addr := range mon.PublicAddrs.Addrvec {
+		endpoints = append(endpoints, addr.Addr)
+	}
+	if len(endpoints) > 0 {
+		return endpoints
+	}
+	for _, addr := range []string{mon.PublicAddr, mon.Address} {
+		if addr != "" {
+			// the legacy addresses are formatted as "10.97.171.131:6789/0"
+			return []string{strings.Split(addr, "/")[0]}
+		}
+	}
+	return endpoints
+}
+
+// reportExternalMonMismatch sets the ExternalMonMismatch condition on the CephCluster when the
+// endpoints of external mons in the monmap do not match their expected endpoints
+func (c *Cluster) reportExternalMonMismatch(mismatches []string) {
+	status := v1.ConditionTrue
+	reason := cephv1.ExternalMonMismatchReason
+	message := strings.Join(mismatches, "; ")
+	if len(mismatches) == 0 {
+		if c.externalMonMismatchMessage == "" {
+			// no mismatch was reported
+			return
+		}
+		status = v1.ConditionFalse
+		reason = cephv1.ExternalMonsMatchReason
+		message = "all the external mons match their expected endpoints"
+	}
+	if message == c.externalMonMismatchMessage {
+		return
+	}
+	c.externalMonMismatchMessage = message
+	if len(mismatches) > 0 {
+		logger.Warningf("external mons are not added to the mon endpoints. %s", message)
+	}
+	updateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionExternalMonMismatch, status, reason, message)
+}
+
 func removeMonsFromQuorumStatusResponse(quorumStatus cephclient.MonStatusResponse, idsToRemove []string) cephclient.MonStatusResponse {
 	var removeFromQuorum []int
 	var keepMons []cephclient.MonMapEntry
@@ -481,6 +728,7 @@ func (c *Cluster) trackMonInOrOutOfQuorum(monName string, inQuorum bool) (bool,
 		for monName, mon := range c.ClusterInfo.InternalMonitors {
 			if mon.OutOfQuorum {
 				logger.Infof("resetting mon %q to be back in quorum", monName)
+				c.recordEvent(v1.EventTypeNormal, MonBackInQuorumReason, "mon %q is back in quorum", monName)
 				mon.OutOfQuorum = false
 				updateNeeded = true
 			}
@@ -494,12 +742,14 @@ func (c *Cluster) trackMonInOrOutOfQuorum(monName string, inQuorum bool) (bool,
 		// Mark the mon in quorum
 		if inQuorum && mon.OutOfQuorum {
 			logger.Infof("marking mon %q back in quorum", monName)
+			c.recordEvent(v1.EventTypeNormal, MonBackInQuorumReason, "mon %q is back in quorum", monName)
 			mon.OutOfQuorum = false
 			updateNeeded = true
 		}
 		// Mark the mon out of quorum
 		if !inQuorum && !mon.OutOfQuorum {
 			logger.Infof("marking mon %q out of quorum", monName)
+			c.recordEvent(v1.EventTypeWarning, MonOutOfQuorumReason, "mon %q is out of quorum", monName)
 			mon.OutOfQuorum = true
 			updateNeeded = true
 		}
@@ -522,11 +772,106 @@ func (c *Cluster) trackMonInOrOutOfQuorum(monName string, inQuorum bool) (bool,
 	return updateNeeded, nil
 }
 
+// removeExtraMons removes all the extra mons in the same health check until the desired count is
+// reached, so that the mons converge to the desired odd count instead of staying at an even count
+// until the next health check
+func (c *Cluster) removeExtraMons(currentMonCount, desiredMonCount int) error {
+	targetMonCount := desiredMonCount
+	if desiredMonCount == 1 {
+		// Reducing the mons to a single mon would go through two mons, which cannot lose any mon
+		// and which cannot be reduced to one mon. Keep three mons, or two if only two remain.
+		targetMonCount = min(currentMonCount, 3)
+		c.warnMonScaleDown(fmt.Sprintf("cannot reduce mon quorum size from %d to 1, keeping %d mons", currentMonCount, targetMonCount))
+	}
+
+	for monCount := currentMonCount; monCount > targetMonCount; monCount-- {
+		monToRemove := c.determineExtraMonToRemove()
+		if monToRemove == "" {
+			c.warnMonScaleDown(fmt.Sprintf("did not find an extra mon to remove, keeping %d mons while %d are desired", monCount, targetMonCount))
+			return nil
+		}
+		logger.Infof("removing an extra mon. currently %d are in quorum and only %d are desired", monCount, targetMonCount)
+		if err := c.removeMon(monToRemove); err != nil {
+			return errors.Wrapf(err, "failed to remove extra mon %q", monToRemove)
+		}
+		c.recordEvent(v1.EventTypeNormal, MonRemovedReason, "removed extra mon %q since only %d mons are desired", monToRemove, targetMonCount)
+	}
+	return nil
+}
+
+// warnMonScaleDown logs why the extra mons are not removed, only once as long as the reason does
+// not change since the health check runs periodically
+func (c *Cluster) warnMonScaleDown(message string) {
+	if message == c.monScaleDownWarning {
+		logger.Debug(message)
+		return
+	}
+	c.monScaleDownWarning = message
+	logger.Warning(message)
+}
+
+// reportEvenMonCount sets the MonCountEven condition on the CephCluster while the mon map or the
+// desired mon count is even, and clears it once the mons are back to an odd count
+func (c *Cluster) reportEvenMonCount(currentMonCount, desiredMonCount int) {
+	message := ""
+	if currentMonCount > 0 && currentMonCount%2 == 0 {
+		message = fmt.Sprintf("%d mons are in the mon map, which does not tolerate more mon failures than %d mons", currentMonCount, currentMonCount-1)
+	} else if desiredMonCount > 0 && desiredMonCount%2 == 0 {
+		message = fmt.Sprintf("the desired mon count %d is even, which does not tolerate more mon failures than %d mons", desiredMonCount, desiredMonCount-1)
+	}
+	if message == c.evenMonCountMessage {
+		// only update the condition when the mon count changes between even and odd
+		return
+	}
+	c.evenMonCountMessage = message
+
+	status := v1.ConditionTrue
+	reason := cephv1.MonCountEvenReason
+	if message == "" {
+		status = v1.ConditionFalse
+		reason = cephv1.MonCountOddReason
+		message = fmt.Sprintf("%d mons are in the mon map", currentMonCount)
+	} else {
+		logger.Warning(message)
+	}
+	updateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionMonCountEven, status, reason, message)
+}
+
+// reportMonStoreCheckFailure sets the MonStoreCorrupted condition on the CephCluster when the store
+// of a mon out of quorum failed the integrity check, and clears it when the message is empty
+func (c *Cluster) reportMonStoreCheckFailure(message string) {
+	if message == c.storeCheckFailureMessage {
+		return
+	}
+	c.storeCheckFailureMessage = message
+
+	status := v1.ConditionTrue
+	reason := cephv1.MonStoreCheckFailedReason
+	if message == "" {
+		status = v1.ConditionFalse
+		reason = cephv1.MonStoreCheckPassedReason
+		message = "all mons are in quorum"
+	} else {
+		logger.Error(message)
+	}
+	updateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionMonStoreCorrupted, status, reason, message)
+}
+
+// isTieBreakerMon returns whether the mon is the tie-breaker that must be kept when removing mons
+func (c *Cluster) isTieBreakerMon(mon *monConfig) bool {
+	tieBreaker := c.spec.Mon.TieBreaker
+	if tieBreaker == "" {
+		return false
+	}
+	return mon.DaemonName == tieBreaker
+}
+
 // determineExtraMonToRemove assumes all mons are in quorum and that there are more mons
 // that required for desired state. One mon will be picked for removal in this priority:
 // 1. If a stretch cluster, remove the extra mon according to the stretch topology
-// 2. If more than one mon on a node, remove one of them
-// 3. If no criteria require for 1 or 2, pick an arbitrary mon
+// 2. If the mons are spread across zones, remove a mon from the zone with the most mons
+// 3. If more than one mon on a node, remove one of them
+// 4. If no criteria require for 1, 2 or 3, pick an arbitrary mon
 func (c *Cluster) determineExtraMonToRemove() string {
 	mons := c.clusterInfoToMonConfig()
 	if c.spec.IsStretchCluster() {
@@ -537,10 +882,19 @@ func (c *Cluster) determineExtraMonToRemove() string {
 		logger.Infof("did not find an extra mon to remove from the stretch cluster")
 		return ""
 	}
+	if len(c.spec.Mon.Zones) > 0 {
+		if zoneMonToRemove := c.findExtraMonToRemoveFromZones(mons); zoneMonToRemove != "" {
+			return zoneMonToRemove
+		}
+	}
 
 	nodesWithMons := map[string]string{}
 	arbitraryMon := ""
 	for _, mon := range mons {
+		if c.isTieBreakerMon(mon) {
+			logger.Debugf("not removing tie-breaker mon %q", mon.DaemonName)
+			continue
+		}
 		if mon.NodeName == "" {
 			logger.Debugf("mon %q is not scheduled to a specific host", mon.DaemonName)
 			arbitraryMon = mon.DaemonName
@@ -561,6 +915,38 @@ func (c *Cluster) determineExtraMonToRemove() string {
 	return arbitraryMon
 }
 
+// findExtraMonToRemoveFromZones returns a mon of the zone with the most mons, so that the remaining
+// mons stay spread evenly across the zones
+func (c *Cluster) findExtraMonToRemoveFromZones(mons []*monConfig) string {
+	zoneCount := map[string]int{}
+	monInZones := map[string]string{}
+	for _, m := range mons {
+		if m.Zone == "" {
+			continue
+		}
+		zoneCount[m.Zone]++
+		if c.isTieBreakerMon(m) {
+			continue
+		}
+		monInZones[m.Zone] = m.DaemonName
+	}
+
+	target := ""
+	for _, zone := range c.spec.Mon.Zones {
+		if _, ok := monInZones[zone.Name]; !ok {
+			continue
+		}
+		if target == "" || zoneCount[zone.Name] > zoneCount[target] {
+			target = zone.Name
+		}
+	}
+	if target == "" {
+		return ""
+	}
+	logger.Infof("removing extra mon %q in zone %q with %d mons", monInZones[target], target, zoneCount[target])
+	return monInZones[target]
+}
+
 func (c *Cluster) findExtraMonToRemoveFromStretchCluster(mons []*monConfig) string {
 	// Build the count of current mons per zone
 	zoneCount := map[string]int{}
@@ -571,6 +957,9 @@ func (c *Cluster) findExtraMonToRemoveFromStretchCluster(mons []*monConfig) stri
 			continue
 		}
 		zoneCount[m.Zone]++
+		if c.isTieBreakerMon(m) {
+			continue
+		}
 		// We just need the name of one of the mons in the zone in case there are extra
 		monInZones[m.Zone] = m.DaemonName
 	}
@@ -582,13 +971,17 @@ func (c *Cluster) findExtraMonToRemoveFromStretchCluster(mons []*monConfig) stri
 			// The zone isn't currently assigned to any mon, so skip it
 			continue
 		}
+		if _, ok := monInZones[zone.Name]; !ok {
+			// The only mons of the zone are the tie-breaker
+			continue
+		}
 		if zone.Arbiter {
 			if count > 1 {
 				logger.Infof("removing extra mon %q in arbiter zone %q", monInZones[zone.Name], zone.Name)
 				return monInZones[zone.Name]
 			}
 		} else {
-			if count > 2 {
+			if count > c.spec.StretchMonsPerDataZone() {
 				logger.Infof("removing extra mon %q in zone %q", monInZones[zone.Name], zone.Name)
 				return monInZones[zone.Name]
 			}
@@ -601,6 +994,11 @@ func (c *Cluster) findExtraMonToRemoveFromStretchCluster(mons []*monConfig) stri
 // Returns whether the failover request was attempted. If false,
 // the operator should check for other mons to failover.
 func (c *Cluster) failMon(monCount, desiredMonCount int, name string) bool {
+	if c.maintenanceMons.Has(name) {
+		logger.Infof("skipping the failover of mon %q since it is in maintenance", name)
+		return false
+	}
+
 	// make sure the failed mon is marked out of quorum
 	if _, err := c.trackMonInOrOutOfQuorum(name, false); err != nil {
 		logger.Errorf("failed to track failed mon %q. %v", name, err)
@@ -610,10 +1008,27 @@ func (c *Cluster) failMon(monCount, desiredMonCount int, name string) bool {
 		// no need to create a new mon since we have an extra
 		if err := c.removeMon(name); err != nil {
 			logger.Errorf("failed to remove mon %q. %v", name, err)
+		} else {
+			c.recordEvent(v1.EventTypeNormal, MonRemovedReason, "removed mon %q out of quorum instead of failing it over since only %d mons are desired", name, desiredMonCount)
 		}
 		return true
 	}
 
+	// a flapping mon must not churn the mon map with repeated failovers
+	now := time.Now()
+	if message := c.failoverDeferred(name, now); message != "" {
+		c.deferFailover(message)
+		return false
+	}
+	// the failover waits for the disruptions started by the other controllers of the cluster
+	release, err := k8sutil.AcquireDisruption(c.Namespace, k8sutil.DisruptionMon, "mon."+name)
+	if err != nil {
+		c.deferFailover(err.Error())
+		return false
+	}
+	defer release()
+	c.recordFailover(name, now)
+
 	// prevent any voluntary mon drain while failing over
 	if err := c.blockMonDrain(types.NamespacedName{Name: monPDBName, Namespace: c.Namespace}); err != nil {
 		logger.Errorf("failed to block mon drain. %v", err)
@@ -704,10 +1119,21 @@ func (c *Cluster) failoverMon(name string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to find available stretch zone")
 	}
+	return c.failoverMonToZone(name, zone, "")
+}
+
+// failoverMonToZone replaces the mon with a new mon in the given zone. The home zone is the failed
+// stretch zone the mon is rescheduled from, if any.
+func (c *Cluster) failoverMonToZone(name, zone, homeZone string) error {
+	// the node to move the new mon back to once the node of the failed mon is ready again
+	homeNode := c.monHomeNode(name)
 
 	// Start a new monitor
-	m := c.newMonConfig(c.maxMonID+1, zone)
+	id := c.nextMonID(c.clusterInfoToMonConfig())
+	m := c.newMonConfig(id, zone)
+	m.RestartReason = k8sutil.RestartReasonFailover
 	logger.Infof("starting new mon: %+v", m)
+	c.recordEvent(v1.EventTypeWarning, MonFailoverStartedReason, "failing over mon %q to new mon %q", name, m.DaemonName)
 
 	// Scale down the failed mon to allow a new one to start
 	if c.stopMonDuringFailover(name) {
@@ -726,6 +1152,8 @@ func (c *Cluster) failoverMon(name string) error {
 			return
 		}
 		logger.Warningf("failover of mon %q unsuccessful, cleaning up replacement mon %q", name, m.DaemonName)
+		c.recordEvent(v1.EventTypeWarning, MonFailoverFailedReason, "failed to fail over mon %q to new mon %q", name, m.DaemonName)
+		c.recordFailoverMetric(failoverFailed)
 		if err := c.updateMonDeploymentReplica(name, true); err != nil {
 			// attempt to continue even if the bad mon could not be restarted
 			logger.Warningf("failed to restart failed mon %q after new mon wouldn't start. %v", name, err)
@@ -744,10 +1172,23 @@ func (c *Cluster) failoverMon(name string) error {
 	}()
 
 	// Assign the pod to a node
+	if c.failbackNode != nil {
+		if err := c.pinMonToFailbackNode(m); err != nil {
+			return errors.Wrap(err, "failed to place new mon on the node of the mon moved back")
+		}
+	}
 	mConf := []*monConfig{m}
 	if err := c.assignMons(mConf); err != nil {
 		return errors.Wrap(err, "failed to place new mon on a node")
 	}
+	if schedule := c.mapping.Schedule[m.DaemonName]; schedule != nil {
+		if homeZone != "" {
+			schedule.HomeZone = homeZone
+		}
+		if homeNode != schedule.Name {
+			schedule.HomeNode = homeNode
+		}
+	}
 
 	if c.spec.Network.IsHost() {
 		schedule, ok := c.mapping.Schedule[m.DaemonName]
@@ -755,6 +1196,7 @@ func (c *Cluster) failoverMon(name string) error {
 			return errors.Errorf("mon %s doesn't exist in assignment map", m.DaemonName)
 		}
 		m.PublicIP = schedule.Address
+		m.ClusterIP = schedule.ClusterAddress
 		m.UseHostNetwork = true
 	} else {
 		// Create the service endpoint
@@ -773,7 +1215,7 @@ func (c *Cluster) failoverMon(name string) error {
 			m.PublicIP = monService.Spec.ClusterIP
 		}
 	}
-	c.ClusterInfo.InternalMonitors[m.DaemonName] = cephclient.NewMonInfo(m.DaemonName, m.PublicIP, m.Port)
+	c.ClusterInfo.InternalMonitors[m.DaemonName] = m.newMonInfo()
 
 	// Start the deployment
 	newMonMightBeInQuorum = true
@@ -790,13 +1232,29 @@ func (c *Cluster) failoverMon(name string) error {
 	}
 
 	// Only increment the max mon id if the new pod started successfully
-	c.maxMonID++
+	c.maxMonID = max(c.maxMonID, id)
 	newMonSucceeded = true
+	c.recordMonFailoverTime(m.DaemonName)
 
-	return c.removeMon(name)
+	if err := c.removeMon(name); err != nil {
+		return err
+	}
+	c.recordEvent(v1.EventTypeNormal, MonFailoverSucceededReason, "failed over mon %q to new mon %q", name, m.DaemonName)
+	c.recordFailoverMetric(failoverSucceeded)
+
+	// The new mon must get the same election preferences as the other mons in its zone
+	if err := c.configureMonElectionPreferences(); err != nil {
+		logger.Warningf("failed to configure mon election preferences after failing over mon %q. %v", name, err)
+	}
+	return nil
 }
 
 func (c *Cluster) stopMonDuringFailover(name string) bool {
+	if name == c.preemptiveFailover {
+		// the mon is in quorum, it keeps running on its node until the new mon is in quorum
+		logger.Infof("keeping mon %q running during its preemptive failover", name)
+		return false
+	}
 	if !c.spec.Network.IsHost() {
 		return true
 	}
@@ -834,7 +1292,11 @@ func (c *Cluster) removeMonWithOptionalQuorum(daemonName string, shouldRemoveFro
 		}
 	}
 	delete(c.ClusterInfo.InternalMonitors, daemonName)
+	c.retireMonID(daemonName)
 	delete(c.mapping.Schedule, daemonName)
+	// the volumes mounted until now may still use the endpoints of the removed mon
+	c.monRemovedTime = time.Now().UTC().Truncate(time.Second)
+	c.monEndpointsCurrent = false
 
 	if err := c.saveMonConfig(); err != nil {
 		return errors.Wrapf(err, "failed to save mon config after failing over mon %s", daemonName)
@@ -38,13 +38,28 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
+// conditionUpdatesStub records the CephCluster conditions set by the mon health checker since the
+// unit tests have no client to update the CephCluster status
+func conditionUpdatesStub(t *testing.T) *[]cephv1.Condition {
+	conditions := []cephv1.Condition{}
+	originalUpdateCondition := updateCondition
+	t.Cleanup(func() { updateCondition = originalUpdateCondition })
+	updateCondition = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status v1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+		conditions = append(conditions, cephv1.Condition{Type: conditionType, Status: status, Reason: reason, Message: message})
+	}
+	return &conditions
+}
+
 func TestCheckHealth(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdatesStub(t)
 
 	executor := &exectest.MockExecutor{
 		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
@@ -177,6 +192,7 @@ func TestRemoveExtraMon(t *testing.T) {
 	assert.NotEqual(t, "", removedMon)
 
 	// Don't remove any extra mon from a proper stretch cluster
+	c.spec.Mon.Count = 5
 	c.spec.Mon.StretchCluster = &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
 		{Name: "x", Arbiter: true},
 		{Name: "y"},
@@ -204,6 +220,147 @@ func TestRemoveExtraMon(t *testing.T) {
 	if removedMon != "b" && removedMon != "c" && removedMon != "d" {
 		assert.Fail(t, fmt.Sprintf("removed mon %q instead of b, c, or d from the non-arbiter zone", removedMon))
 	}
+
+	// Remove a mon from a data zone when the mon count is reduced
+	c.spec.Mon.Count = 3
+	c.mapping.Schedule["d"].Zone = "z"
+	removedMon = c.determineExtraMonToRemove()
+	assert.Contains(t, []string{"b", "c", "d", "e"}, removedMon)
+
+	// Don't remove any extra mon from a stretch cluster with three data zones
+	c.spec.Mon.Count = 7
+	c.spec.Mon.StretchCluster.Zones = append(c.spec.Mon.StretchCluster.Zones, cephv1.MonZoneSpec{Name: "w"})
+	c.ClusterInfo.InternalMonitors["f"] = &cephclient.MonInfo{Name: "f", Endpoint: endpoint}
+	c.ClusterInfo.InternalMonitors["g"] = &cephclient.MonInfo{Name: "g", Endpoint: endpoint}
+	c.mapping.Schedule["f"] = &opcontroller.MonScheduleInfo{Name: "node6", Zone: "w"}
+	c.mapping.Schedule["g"] = &opcontroller.MonScheduleInfo{Name: "node7", Zone: "w"}
+	removedMon = c.determineExtraMonToRemove()
+	assert.Equal(t, "", removedMon)
+
+	// Remove an extra mon from the data zone with three mons
+	c.ClusterInfo.InternalMonitors["h"] = &cephclient.MonInfo{Name: "h", Endpoint: endpoint}
+	c.mapping.Schedule["h"] = &opcontroller.MonScheduleInfo{Name: "node8", Zone: "w"}
+	removedMon = c.determineExtraMonToRemove()
+	assert.Contains(t, []string{"f", "g", "h"}, removedMon)
+}
+
+func TestRemoveExtraMonTieBreaker(t *testing.T) {
+	endpoint := "1.2.3.4:6789"
+	c := &Cluster{mapping: &opcontroller.Mapping{}}
+	c.ClusterInfo = &cephclient.ClusterInfo{InternalMonitors: map[string]*cephclient.MonInfo{
+		"a": {Name: "a", Endpoint: endpoint},
+		"b": {Name: "b", Endpoint: endpoint},
+	}}
+	c.mapping.Schedule = map[string]*opcontroller.MonScheduleInfo{
+		"a": {Name: "node1", Zone: "x"},
+		"b": {Name: "node1", Zone: "y"},
+	}
+
+	// the tie-breaker mon is never removed
+	c.spec.Mon.TieBreaker = "a"
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "b", c.determineExtraMonToRemove())
+	}
+	c.spec.Mon.TieBreaker = "b"
+	assert.Equal(t, "a", c.determineExtraMonToRemove())
+
+	// the tie-breaker is the only mon of its zone in a stretch cluster
+	c.spec.Mon.StretchCluster = &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+		{Name: "x", Arbiter: true},
+		{Name: "y"},
+		{Name: "z"},
+	}}
+	c.mapping.Schedule["b"].Zone = "x"
+	c.spec.Mon.TieBreaker = "a"
+	assert.Equal(t, "b", c.determineExtraMonToRemove())
+	c.spec.Mon.TieBreaker = "b"
+	assert.Equal(t, "a", c.determineExtraMonToRemove())
+}
+
+func TestRemoveExtraMonFromZones(t *testing.T) {
+	endpoint := "1.2.3.4:6789"
+	c := &Cluster{mapping: &opcontroller.Mapping{Schedule: map[string]*opcontroller.MonScheduleInfo{}}}
+	c.spec.Mon.Zones = []cephv1.MonZoneSpec{{Name: "x"}, {Name: "y"}, {Name: "z"}}
+	c.ClusterInfo = &cephclient.ClusterInfo{InternalMonitors: map[string]*cephclient.MonInfo{}}
+	for i, zone := range []string{"x", "x", "y", "y", "y", "z", "z"} {
+		name := string(rune('a' + i))
+		c.ClusterInfo.InternalMonitors[name] = &cephclient.MonInfo{Name: name, Endpoint: endpoint}
+		c.mapping.Schedule[name] = &opcontroller.MonScheduleInfo{Name: fmt.Sprintf("node%d", i), Zone: zone}
+	}
+
+	// a mon of the zone with the most mons is removed
+	removedMon := c.determineExtraMonToRemove()
+	assert.Contains(t, []string{"c", "d", "e"}, removedMon)
+
+	// the tie-breaker is not removed, even from the zone with the most mons
+	delete(c.ClusterInfo.InternalMonitors, "c")
+	delete(c.ClusterInfo.InternalMonitors, "d")
+	c.spec.Mon.TieBreaker = "e"
+	removedMon = c.determineExtraMonToRemove()
+	assert.Contains(t, []string{"a", "b"}, removedMon)
+}
+
+func TestRemoveExtraMons(t *testing.T) {
+	ctx := context.TODO()
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "auth" && args[1] == "get-or-create-key" {
+				return "{\"key\":\"mysecurekey\"}", nil
+			}
+			return clienttest.MonInQuorumResponse(), nil
+		},
+	}
+	clientset := test.New(t, 1)
+	context := &clusterd.Context{Clientset: clientset, ConfigDir: t.TempDir(), Executor: executor}
+	c := New(ctx, context, "ns", cephv1.ClusterSpec{}, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	setCommonMonProperties(c, 4, cephv1.MonSpec{Count: 3, AllowMultiplePerNode: true}, "myversion")
+	recorder := record.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
+
+	// an even count of mons converges to the desired count in a single call
+	assert.NoError(t, c.removeExtraMons(4, 3))
+	assert.Equal(t, 3, len(c.ClusterInfo.InternalMonitors))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal MonRemoved removed extra mon")
+
+	// the mons are not reduced to an even count
+	assert.NoError(t, c.removeExtraMons(3, 1))
+	assert.Equal(t, 3, len(c.ClusterInfo.InternalMonitors))
+
+	// a single call removes the mons down to 3 on the way to a single mon
+	setCommonMonProperties(c, 5, cephv1.MonSpec{Count: 1, AllowMultiplePerNode: true}, "myversion")
+	assert.NoError(t, c.removeExtraMons(5, 1))
+	assert.Equal(t, 3, len(c.ClusterInfo.InternalMonitors))
+}
+
+func TestReportEvenMonCount(t *testing.T) {
+	conditionUpdates := conditionUpdatesStub(t)
+	c := &Cluster{ClusterInfo: clienttest.CreateTestClusterInfo(1)}
+
+	// odd counts are not reported
+	c.reportEvenMonCount(3, 3)
+	assert.Equal(t, 0, len(*conditionUpdates))
+
+	// an even mon map is reported once
+	c.reportEvenMonCount(4, 3)
+	c.reportEvenMonCount(4, 3)
+	require.Equal(t, 1, len(*conditionUpdates))
+	assert.Equal(t, cephv1.ConditionMonCountEven, (*conditionUpdates)[0].Type)
+	assert.Equal(t, v1.ConditionTrue, (*conditionUpdates)[0].Status)
+	assert.Equal(t, cephv1.MonCountEvenReason, (*conditionUpdates)[0].Reason)
+
+	// an even desired count is reported
+	c.reportEvenMonCount(3, 4)
+	require.Equal(t, 2, len(*conditionUpdates))
+	assert.Equal(t, v1.ConditionTrue, (*conditionUpdates)[1].Status)
+	assert.Contains(t, (*conditionUpdates)[1].Message, "desired mon count 4")
+
+	// the condition is cleared once the mons are back to an odd count
+	c.reportEvenMonCount(3, 3)
+	c.reportEvenMonCount(3, 3)
+	require.Equal(t, 3, len(*conditionUpdates))
+	assert.Equal(t, v1.ConditionFalse, (*conditionUpdates)[2].Status)
+	assert.Equal(t, cephv1.MonCountOddReason, (*conditionUpdates)[2].Reason)
 }
 
 func TestTrackMonsOutOfQuorum(t *testing.T) {
@@ -224,10 +381,14 @@ func TestTrackMonsOutOfQuorum(t *testing.T) {
 		"b": {Name: "b", Endpoint: endpoint},
 		"c": {Name: "c", Endpoint: endpoint},
 	}}
+	c.ClusterInfo.SetName("my-cluster")
+	recorder := record.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
 	// No change since all mons are in quorum
 	updated, err := c.trackMonInOrOutOfQuorum("a", true)
 	assert.False(t, updated)
 	assert.NoError(t, err)
+	assert.Empty(t, recorder.Events)
 
 	// initialize the configmap
 	err = c.persistExpectedMonDaemonsInConfigMap()
@@ -237,6 +398,8 @@ func TestTrackMonsOutOfQuorum(t *testing.T) {
 	updated, err = c.trackMonInOrOutOfQuorum("a", false)
 	assert.True(t, updated)
 	assert.NoError(t, err)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, `Warning MonOutOfQuorum mon "a" is out of quorum`, <-recorder.Events)
 
 	cm, err := clientset.CoreV1().ConfigMaps(c.Namespace).Get(context.TODO(), EndpointConfigMapName, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -246,6 +409,8 @@ func TestTrackMonsOutOfQuorum(t *testing.T) {
 	updated, err = c.trackMonInOrOutOfQuorum("a", true)
 	assert.True(t, updated)
 	assert.NoError(t, err)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, `Normal MonBackInQuorum mon "a" is back in quorum`, <-recorder.Events)
 
 	cm, err = clientset.CoreV1().ConfigMaps(c.Namespace).Get(context.TODO(), EndpointConfigMapName, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -379,6 +544,7 @@ func TestCheckHealthNotFound(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdatesStub(t)
 
 	executor := &exectest.MockExecutor{
 		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
@@ -439,6 +605,7 @@ func TestAddRemoveMons(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdates := conditionUpdatesStub(t)
 
 	monQuorumResponse := clienttest.MonInQuorumResponse()
 	executor := &exectest.MockExecutor{
@@ -477,17 +644,18 @@ func TestAddRemoveMons(t *testing.T) {
 		testopk8s.DeploymentNamesUpdated(deploymentsUpdated))
 	testopk8s.ClearDeploymentsUpdated(deploymentsUpdated)
 
-	// reducing the mon count to 3 will reduce the mon count once each time we call checkHealth
+	// reducing the mon count to 3 will remove the extra mons in a single health check without
+	// stopping at an even count of mons
 	monQuorumResponse = clienttest.MonInQuorumResponseFromMons(c.ClusterInfo.InternalMonitors)
 	c.spec.Mon.Count = 3
 	err = c.checkHealth(ctx)
 	assert.Nil(t, err)
-	assert.Equal(t, 4, len(c.ClusterInfo.InternalMonitors))
+	assert.Equal(t, 3, len(c.ClusterInfo.InternalMonitors))
 	// No updates in unit tests w/ workaround
 	assert.ElementsMatch(t, []string{}, testopk8s.DeploymentNamesUpdated(deploymentsUpdated))
 	testopk8s.ClearDeploymentsUpdated(deploymentsUpdated)
 
-	// after the second call we will be down to the expected count of 3
+	// the next call keeps the expected count of 3
 	monQuorumResponse = clienttest.MonInQuorumResponseFromMons(c.ClusterInfo.InternalMonitors)
 	err = c.checkHealth(ctx)
 	assert.Nil(t, err)
@@ -496,17 +664,21 @@ func TestAddRemoveMons(t *testing.T) {
 	assert.ElementsMatch(t, []string{}, testopk8s.DeploymentNamesUpdated(deploymentsUpdated))
 	testopk8s.ClearDeploymentsUpdated(deploymentsUpdated)
 
-	// now attempt to reduce the mons down to quorum size 1
+	// now attempt to reduce the mons down to quorum size 1, which would go through 2 mons
 	monQuorumResponse = clienttest.MonInQuorumResponseFromMons(c.ClusterInfo.InternalMonitors)
 	c.spec.Mon.Count = 1
 	err = c.checkHealth(ctx)
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(c.ClusterInfo.InternalMonitors))
+	assert.Equal(t, 3, len(c.ClusterInfo.InternalMonitors))
 	// No updates in unit tests w/ workaround
 	assert.ElementsMatch(t, []string{}, testopk8s.DeploymentNamesUpdated(deploymentsUpdated))
 	testopk8s.ClearDeploymentsUpdated(deploymentsUpdated)
 
-	// cannot reduce from quorum size of 2 to 1
+	// cannot reduce from quorum size of 2 to 1, for example if an earlier operator left 2 mons
+	for name := range c.ClusterInfo.InternalMonitors {
+		delete(c.ClusterInfo.InternalMonitors, name)
+		break
+	}
 	monQuorumResponse = clienttest.MonInQuorumResponseFromMons(c.ClusterInfo.InternalMonitors)
 	err = c.checkHealth(ctx)
 	assert.Nil(t, err)
@@ -514,6 +686,11 @@ func TestAddRemoveMons(t *testing.T) {
 	// No updates in unit tests w/ workaround
 	assert.ElementsMatch(t, []string{}, testopk8s.DeploymentNamesUpdated(deploymentsUpdated))
 	testopk8s.ClearDeploymentsUpdated(deploymentsUpdated)
+
+	// the even mon count is only reported once the mons are stuck at 2
+	require.Equal(t, 1, len(*conditionUpdates))
+	assert.Equal(t, cephv1.ConditionMonCountEven, (*conditionUpdates)[0].Type)
+	assert.Equal(t, v1.ConditionTrue, (*conditionUpdates)[0].Status)
 }
 
 func TestAddOrRemoveExternalMonitor(t *testing.T) {
@@ -611,12 +788,37 @@ func TestUpdateMonTimeout(t *testing.T) {
 		assert.Equal(t, time.Second*10, MonOutTimeout)
 	})
 	t.Run("using spec mon timeout", func(t *testing.T) {
-		m := &Cluster{spec: cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.HealthCheckSpec{Timeout: "1m"}}}}}
+		m := &Cluster{spec: cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.MonHealthCheckSpec{HealthCheckSpec: cephv1.HealthCheckSpec{Timeout: "1m"}}}}}}
 		updateMonTimeout(m)
 		assert.Equal(t, time.Minute, MonOutTimeout)
 	})
 }
 
+func TestMonOutTimeoutOverrides(t *testing.T) {
+	defaultTimeout := MonOutTimeout
+	MonOutTimeout = 10 * time.Minute
+	t.Cleanup(func() { MonOutTimeout = defaultTimeout })
+
+	m := &Cluster{arbiterMon: "e"}
+	m.spec.HealthCheck.DaemonHealth.Monitor.TimeoutOverrides = map[string]string{
+		"a":       "30m",
+		"b":       "0",
+		"c":       "invalid",
+		"arbiter": "1h",
+	}
+	assert.Equal(t, 30*time.Minute, m.monOutTimeout("a"))
+	// a zero timeout disables the failover of the mon
+	assert.Equal(t, timeZero, m.monOutTimeout("b"))
+	assert.Equal(t, 10*time.Minute, m.monOutTimeout("c"))
+	assert.Equal(t, 10*time.Minute, m.monOutTimeout("d"))
+	assert.Equal(t, time.Hour, m.monOutTimeout("e"))
+
+	// the override of the mon name has precedence over the override of the arbiter
+	m.arbiterMon = "a"
+	assert.Equal(t, 30*time.Minute, m.monOutTimeout("a"))
+	assert.Equal(t, 10*time.Minute, m.monOutTimeout("e"))
+}
+
 func TestUpdateMonInterval(t *testing.T) {
 	t.Run("using default mon interval", func(t *testing.T) {
 		m := &Cluster{}
@@ -634,7 +836,7 @@ func TestUpdateMonInterval(t *testing.T) {
 	t.Run("using spec mon timeout", func(t *testing.T) {
 		tm, err := time.ParseDuration("1m")
 		assert.NoError(t, err)
-		m := &Cluster{spec: cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: tm}}}}}}
+		m := &Cluster{spec: cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.MonHealthCheckSpec{HealthCheckSpec: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: tm}}}}}}}
 		h := &HealthChecker{m, HealthCheckInterval}
 		updateMonInterval(m, h)
 		assert.Equal(t, time.Minute, h.interval)
@@ -758,6 +960,7 @@ func TestExternalMons_notInSpec_InQuorum(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdatesStub(t)
 
 	monQuorumResponse := clienttest.MonInQuorumResponse()
 	executor := &exectest.MockExecutor{
@@ -889,6 +1092,7 @@ func TestExternalMons_inSpec_notInQuorum(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdatesStub(t)
 
 	monQuorumResponse := clienttest.MonInQuorumResponse()
 	executor := &exectest.MockExecutor{
@@ -1012,6 +1216,7 @@ func TestExternalMons_inSpec_inQuorum(t *testing.T) {
 	ctx := context.TODO()
 	var deploymentsUpdated *[]*apps.Deployment
 	updateDeploymentAndWait, deploymentsUpdated = testopk8s.UpdateDeploymentAndWaitStub()
+	conditionUpdatesStub(t)
 
 	monQuorumResponse := clienttest.MonInQuorumResponse()
 	executor := &exectest.MockExecutor{
@@ -1160,3 +1365,76 @@ func TestExternalMons_inSpec_inQuorum(t *testing.T) {
 		}
 	}
 }
+
+func TestExternalMonsExpectedEndpoints(t *testing.T) {
+	ctx := context.TODO()
+	conditions := conditionUpdatesStub(t)
+	context := &clusterd.Context{
+		Clientset: test.New(t, 1),
+		ConfigDir: t.TempDir(),
+		Executor:  &exectest.MockExecutor{},
+	}
+	c := New(ctx, context, "ns", cephv1.ClusterSpec{}, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	setCommonMonProperties(c, 1, cephv1.MonSpec{Count: 1}, "myversion")
+	c.spec.Mon.ExternalMonIDs = []string{"ext"}
+	c.spec.Mon.ExternalMons = []cephv1.ExternalMonSpec{{ID: "ext", Address: "10.0.0.5", Ports: []int32{3300, 6789}}}
+
+	extMon := cephclient.MonMapEntry{Name: "ext", Rank: 1}
+	extMon.PublicAddrs.Addrvec = []cephclient.AddrvecEntry{
+		{Type: "v2", Addr: "10.0.0.5:3300"},
+		{Type: "v1", Addr: "10.0.0.5:6789"},
+	}
+	quorumStatus := cephclient.MonStatusResponse{Quorum: []int{0, 1}}
+	quorumStatus.MonMap.Mons = []cephclient.MonMapEntry{{Name: "a", Rank: 0}, extMon}
+
+	// the external mon listens on the expected endpoints
+	internalStatus, err := c.reconcileExternalMons(ctx, quorumStatus)
+	assert.NoError(t, err)
+	assert.Len(t, internalStatus.MonMap.Mons, 1)
+	assert.Contains(t, c.ClusterInfo.ExternalMons, "ext")
+	assert.Empty(t, *conditions)
+
+	// the external mon moved to another address
+	quorumStatus.MonMap.Mons[1].PublicAddrs.Addrvec[0].Addr = "10.0.0.6:3300"
+	internalStatus, err = c.reconcileExternalMons(ctx, quorumStatus)
+	assert.NoError(t, err)
+	assert.Len(t, internalStatus.MonMap.Mons, 1)
+	assert.NotContains(t, c.ClusterInfo.ExternalMons, "ext")
+	require.Len(t, *conditions, 1)
+	assert.Equal(t, cephv1.ConditionExternalMonMismatch, (*conditions)[0].Type)
+	assert.Equal(t, v1.ConditionTrue, (*conditions)[0].Status)
+	assert.Equal(t, `external mon "ext" listens on "10.0.0.6:3300" instead of the expected address "10.0.0.5"`, (*conditions)[0].Message)
+
+	// the same mismatch is not reported again
+	_, err = c.reconcileExternalMons(ctx, quorumStatus)
+	assert.NoError(t, err)
+	assert.Len(t, *conditions, 1)
+
+	// the external mon does not listen on the msgr2 port
+	quorumStatus.MonMap.Mons[1].PublicAddrs.Addrvec = quorumStatus.MonMap.Mons[1].PublicAddrs.Addrvec[1:]
+	_, err = c.reconcileExternalMons(ctx, quorumStatus)
+	assert.NoError(t, err)
+	require.Len(t, *conditions, 2)
+	assert.Equal(t, `external mon "ext" does not listen on the expected port 3300, its endpoints are 10.0.0.5:6789`, (*conditions)[1].Message)
+
+	// the mismatch is resolved when the expected ports are updated
+	c.spec.Mon.ExternalMons[0].Ports = []int32{6789}
+	_, err = c.reconcileExternalMons(ctx, quorumStatus)
+	assert.NoError(t, err)
+	assert.Contains(t, c.ClusterInfo.ExternalMons, "ext")
+	require.Len(t, *conditions, 3)
+	assert.Equal(t, v1.ConditionFalse, (*conditions)[2].Status)
+	assert.Equal(t, cephv1.ExternalMonsMatchReason, (*conditions)[2].Reason)
+}
+
+func TestMonMapEndpoints(t *testing.T) {
+	mon := cephclient.MonMapEntry{Name: "a", PublicAddr: "10.97.171.131:6789/0", Address: "10.97.171.131:6789/0"}
+	assert.Equal(t, []string{"10.97.171.131:6789"}, monMapEndpoints(mon))
+
+	mon.PublicAddrs.Addrvec = []cephclient.AddrvecEntry{{Type: "v2", Addr: "[fd00::1]:3300"}, {Type: "v1", Addr: "[fd00::1]:6789"}}
+	assert.Equal(t, []string{"[fd00::1]:3300", "[fd00::1]:6789"}, monMapEndpoints(mon))
+	assert.Empty(t, externalMonMismatch(cephv1.ExternalMonSpec{ID: "a", Address: "fd00::1", Ports: []int32{3300}}, mon))
+
+	assert.Empty(t, monMapEndpoints(cephclient.MonMapEntry{Name: "a"}))
+	assert.Equal(t, `external mon "a" has no public address in the monmap`, externalMonMismatch(cephv1.ExternalMonSpec{ID: "a"}, cephclient.MonMapEntry{Name: "a"}))
+}
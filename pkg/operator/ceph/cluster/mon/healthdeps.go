@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// QuorumFetcher gets the quorum status of the mons from Ceph
+type QuorumFetcher interface {
+	MonQuorumStatus(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) (cephclient.MonStatusResponse, error)
+}
+
+// MonScheduler waits for Kubernetes to schedule the canary deployment of a mon, and returns the node
+// the canary was scheduled on
+type MonScheduler interface {
+	WaitForScheduling(canary *apps.Deployment) (*corev1.Node, error)
+}
+
+// DeploymentUpdater updates the deployment of a mon and waits for the mon to be ready
+type DeploymentUpdater interface {
+	UpdateDeploymentAndWait(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, deployment *apps.Deployment, daemonType, daemonName string, skipUpgradeChecks, continueUpgradeAfterChecksEvenIfNotHealthy bool) error
+}
+
+// HealthCheckDependencies are the dependencies of the mon health checker on Ceph and Kubernetes.
+// They can be replaced to test custom health behavior without a Ceph cluster, for example with the
+// fakes of the mon test package.
+type HealthCheckDependencies struct {
+	QuorumFetcher     QuorumFetcher
+	Scheduler         MonScheduler
+	DeploymentUpdater DeploymentUpdater
+}
+
+// SetHealthCheckDependencies replaces the dependencies of the mon health checker. The dependencies
+// left nil keep their default implementation.
+func (c *Cluster) SetHealthCheckDependencies(deps HealthCheckDependencies) {
+	c.healthDeps = deps
+}
+
+// cephQuorumFetcher gets the quorum status with the ceph cli
+type cephQuorumFetcher struct{}
+
+func (cephQuorumFetcher) MonQuorumStatus(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) (cephclient.MonStatusResponse, error) {
+	return cephclient.GetMonQuorumStatus(context, clusterInfo)
+}
+
+// canaryScheduler waits for the canary pods to be scheduled by Kubernetes
+type canaryScheduler struct {
+	c *Cluster
+}
+
+func (s canaryScheduler) WaitForScheduling(canary *apps.Deployment) (*corev1.Node, error) {
+	result, err := waitForMonitorScheduling(s.c, canary)
+	return result.Node, err
+}
+
+// cephDeploymentUpdater updates the deployments with the checks of the Ceph daemons
+type cephDeploymentUpdater struct{}
+
+func (cephDeploymentUpdater) UpdateDeploymentAndWait(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, deployment *apps.Deployment, daemonType, daemonName string, skipUpgradeChecks, continueUpgradeAfterChecksEvenIfNotHealthy bool) error {
+	return updateDeploymentAndWait(context, clusterInfo, deployment, daemonType, daemonName, skipUpgradeChecks, continueUpgradeAfterChecksEvenIfNotHealthy)
+}
+
+func (c *Cluster) quorumFetcher() QuorumFetcher {
+	if c.healthDeps.QuorumFetcher != nil {
+		return c.healthDeps.QuorumFetcher
+	}
+	return cephQuorumFetcher{}
+}
+
+func (c *Cluster) scheduler() MonScheduler {
+	if c.healthDeps.Scheduler != nil {
+		return c.healthDeps.Scheduler
+	}
+	return canaryScheduler{c: c}
+}
+
+func (c *Cluster) deploymentUpdater() DeploymentUpdater {
+	if c.healthDeps.DeploymentUpdater != nil {
+		return c.healthDeps.DeploymentUpdater
+	}
+	return cephDeploymentUpdater{}
+}
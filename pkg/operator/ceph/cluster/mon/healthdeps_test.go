@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	montest "github.com/rook/rook/pkg/operator/ceph/cluster/mon/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+)
+
+func TestHealthCheckDependencies(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("defaults", func(t *testing.T) {
+		c := &Cluster{}
+		assert.IsType(t, cephQuorumFetcher{}, c.quorumFetcher())
+		assert.IsType(t, canaryScheduler{}, c.scheduler())
+		assert.IsType(t, cephDeploymentUpdater{}, c.deploymentUpdater())
+
+		// the dependencies left nil keep their default
+		c.SetHealthCheckDependencies(HealthCheckDependencies{QuorumFetcher: montest.NewFakeQuorum("a")})
+		assert.IsType(t, &montest.FakeQuorum{}, c.quorumFetcher())
+		assert.IsType(t, canaryScheduler{}, c.scheduler())
+	})
+
+	t.Run("mon failure scenario", func(t *testing.T) {
+		conditionUpdatesStub(t)
+		c := newQuorumRestoreTestCluster(t, nil)
+		c.spec.Mon.Count = 3
+		quorum := montest.NewFakeQuorum("a", "b", "c")
+		c.SetHealthCheckDependencies(HealthCheckDependencies{QuorumFetcher: quorum})
+		hc := NewHealthChecker(c)
+
+		scenario := montest.NewScenario(quorum,
+			montest.Unchanged(),
+			montest.MonsDown("b"),
+			montest.MonsUp("b"),
+			montest.QuorumLost(),
+		)
+
+		// all the mons are in quorum
+		require.True(t, scenario.Next())
+		assert.NoError(t, hc.CheckHealth(ctx))
+		assert.Equal(t, 1, quorum.Calls())
+		assert.Empty(t, c.monTimeoutList)
+
+		// mon b is out of quorum, its failover waits for the timeout
+		require.True(t, scenario.Next())
+		assert.NoError(t, hc.CheckHealth(ctx))
+		assert.True(t, c.ClusterInfo.InternalMonitors["b"].OutOfQuorum)
+		assert.Contains(t, c.monTimeoutList, "b")
+
+		// mon b is back in quorum before the timeout
+		require.True(t, scenario.Next())
+		assert.NoError(t, hc.CheckHealth(ctx))
+		assert.False(t, c.ClusterInfo.InternalMonitors["b"].OutOfQuorum)
+		assert.NotContains(t, c.monTimeoutList, "b")
+
+		// no mon answers
+		require.True(t, scenario.Next())
+		assert.Error(t, hc.CheckHealth(ctx))
+		assert.False(t, scenario.Next())
+	})
+
+	t.Run("deterministic scheduler", func(t *testing.T) {
+		originalWaitForMonitorScheduling := waitForMonitorScheduling
+		t.Cleanup(func() { waitForMonitorScheduling = originalWaitForMonitorScheduling })
+		waitForMonitorScheduling = func(c *Cluster, d *apps.Deployment) (SchedulingResult, error) {
+			t.Fatal("the kubernetes scheduler must not be used")
+			return SchedulingResult{}, nil
+		}
+
+		c := newQuorumRestoreTestCluster(t, nil)
+		c.maxMonID = 2
+		scheduler := montest.NewFakeScheduler("node-x", "node-y")
+		c.SetHealthCheckDependencies(HealthCheckDependencies{Scheduler: scheduler})
+
+		preview := c.previewFailover("b")
+		assert.Empty(t, preview.Message)
+		assert.Equal(t, "d", preview.Replacement)
+		assert.Equal(t, "node-x", preview.Node)
+		preview = c.previewFailover("c")
+		assert.Equal(t, "node-y", preview.Node)
+		assert.Equal(t, []string{"rook-ceph-mon-d-canary", "rook-ceph-mon-d-canary"}, scheduler.Scheduled())
+	})
+}
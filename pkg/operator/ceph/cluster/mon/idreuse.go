@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"slices"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nextMonID returns the ID of the next mon to create. The next letter after the highest ID is
+// returned unless the IDs are reused, in which case the lowest ID of a mon removed by the operator
+// is returned once the mon is gone from the monmap and its resources are deleted.
+func (c *Cluster) nextMonID(mons []*monConfig) int {
+	next := c.maxMonID + 1
+	if !c.spec.Mon.ReuseIDs || len(c.mapping.Retired) == 0 {
+		return next
+	}
+
+	inUse := map[string]bool{}
+	for _, m := range mons {
+		inUse[m.DaemonName] = true
+	}
+	for name := range c.ClusterInfo.InternalMonitors {
+		inUse[name] = true
+	}
+	for name := range c.mapping.Schedule {
+		inUse[name] = true
+	}
+	for _, name := range c.spec.Mon.ExternalMonIDs {
+		inUse[name] = true
+	}
+
+	var monmap []string
+	for id := 0; id <= c.maxMonID; id++ {
+		name := k8sutil.IndexToName(id)
+		if _, ok := c.mapping.Retired[name]; !ok || inUse[name] {
+			continue
+		}
+		// the monmap is only read when an ID may be reused
+		if monmap == nil {
+			dump, err := cephclient.GetMonDump(c.context, c.ClusterInfo)
+			if err != nil {
+				logger.Warningf("failed to get the mon dump to reuse the ID of a removed mon, creating mon %q instead. %v", k8sutil.IndexToName(next), err)
+				return next
+			}
+			monmap = []string{}
+			for _, mon := range dump.Mons {
+				monmap = append(monmap, mon.Name)
+			}
+		}
+		if slices.Contains(monmap, name) {
+			logger.Infof("not reusing the ID of removed mon %q still in the monmap", name)
+			continue
+		}
+		if remaining := c.remainingMonResource(name); remaining != "" {
+			logger.Infof("not reusing the ID of removed mon %q until its %s is deleted", name, remaining)
+			continue
+		}
+		logger.Infof("reusing the ID of removed mon %q", name)
+		return id
+	}
+	return next
+}
+
+// remainingMonResource returns the kind of a resource of the mon that is not deleted yet, or an
+// empty string once they are all deleted
+func (c *Cluster) remainingMonResource(name string) string {
+	ctx := c.ClusterInfo.Context
+	resourceName := resourceName(name)
+	exists := func(err error) bool {
+		// the resource is assumed to exist if it cannot be read
+		return !kerrors.IsNotFound(err)
+	}
+	if _, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, resourceName, metav1.GetOptions{}); exists(err) {
+		return "deployment"
+	}
+	if _, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, resourceName+"-canary", metav1.GetOptions{}); exists(err) {
+		return "canary deployment"
+	}
+	if _, err := c.context.Clientset.CoreV1().Services(c.Namespace).Get(ctx, resourceName, metav1.GetOptions{}); exists(err) {
+		return "service"
+	}
+	if _, err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.Namespace).Get(ctx, resourceName, metav1.GetOptions{}); exists(err) {
+		return "pvc"
+	}
+	return ""
+}
+
+// retireMonID records the removed mon so that its ID can be reused, with the host of the mon since
+// the data dir of the removed mon is left on the host path
+func (c *Cluster) retireMonID(name string) {
+	if !c.spec.Mon.ReuseIDs {
+		return
+	}
+	if c.mapping.Retired == nil {
+		c.mapping.Retired = map[string][]string{}
+	}
+	hosts := c.mapping.Retired[name]
+	if schedule := c.mapping.Schedule[name]; schedule != nil && schedule.Hostname != "" && !slices.Contains(hosts, schedule.Hostname) {
+		hosts = append(hosts, schedule.Hostname)
+	}
+	c.mapping.Retired[name] = hosts
+}
+
+// excludeRetiredMonHosts prevents a mon with a reused ID from being scheduled on the hosts where
+// the removed mons with the same ID left their data dir
+func (c *Cluster) excludeRetiredMonHosts(monName string, p cephv1.Placement) cephv1.Placement {
+	if c.mapping == nil || len(c.mapping.Retired[monName]) == 0 {
+		return p
+	}
+	p = *p.DeepCopy()
+	requirement := v1.NodeSelectorRequirement{Key: v1.LabelHostname, Operator: v1.NodeSelectorOpNotIn, Values: c.mapping.Retired[monName]}
+	if p.NodeAffinity == nil {
+		p.NodeAffinity = &v1.NodeAffinity{}
+	}
+	if p.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		p.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	selector := p.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []v1.NodeSelectorTerm{{}}
+	}
+	// the terms are ORed, so each term must exclude the hosts
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return p
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNextMonID(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := clienttest.CreateTestClusterInfo(4)
+	delete(clusterInfo.InternalMonitors, "a")
+	clientset := k8sfake.NewSimpleClientset(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon-a", Namespace: "ns"}})
+	monmap := `{"mons":[{"name":"b"},{"name":"c"},{"name":"d"}]}`
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "mon" && args[1] == "dump" {
+				return monmap, nil
+			}
+			return "", errors.Errorf("unexpected command %s %v", command, args)
+		},
+	}
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   "ns",
+		context:     &clusterd.Context{Clientset: clientset, Executor: executor},
+		maxMonID:    3,
+		mapping: &controller.Mapping{
+			Schedule: map[string]*controller.MonScheduleInfo{"b": {}, "c": {}, "d": {}},
+			Retired:  map[string][]string{"a": {"host1"}},
+		},
+	}
+
+	// the next letter is used by default
+	assert.Equal(t, 4, c.nextMonID(nil))
+
+	// the ID is not reused until the resources of the removed mon are deleted
+	c.spec.Mon.ReuseIDs = true
+	assert.Equal(t, 4, c.nextMonID(nil))
+	require.NoError(t, clientset.CoreV1().Services("ns").Delete(ctx, "rook-ceph-mon-a", metav1.DeleteOptions{}))
+	assert.Equal(t, 0, c.nextMonID(nil))
+
+	// the new mons and the external mons keep their IDs
+	assert.Equal(t, 4, c.nextMonID([]*monConfig{{DaemonName: "a"}}))
+	c.spec.Mon.ExternalMonIDs = []string{"a"}
+	assert.Equal(t, 4, c.nextMonID(nil))
+	c.spec.Mon.ExternalMonIDs = nil
+
+	// the ID is not reused while the removed mon is in the monmap or the monmap cannot be read
+	monmap = `{"mons":[{"name":"a"},{"name":"b"},{"name":"c"},{"name":"d"}]}`
+	assert.Equal(t, 4, c.nextMonID(nil))
+	monmap = "invalid"
+	assert.Equal(t, 4, c.nextMonID(nil))
+}
+
+func TestRetireMonID(t *testing.T) {
+	c := &Cluster{
+		spec:    cephv1.ClusterSpec{Mon: cephv1.MonSpec{ReuseIDs: true}},
+		mapping: &controller.Mapping{Schedule: map[string]*controller.MonScheduleInfo{"a": {Name: "node1", Hostname: "host1"}, "b": {}}},
+	}
+	c.retireMonID("a")
+	c.retireMonID("b")
+	assert.Equal(t, map[string][]string{"a": {"host1"}, "b": nil}, c.mapping.Retired)
+
+	// the reused ID is not scheduled on the hosts with the data dirs of the removed mons
+	placement := c.getMonPlacement("a", "")
+	require.NotNil(t, placement.NodeAffinity)
+	terms := placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 1)
+	assert.Equal(t, []v1.NodeSelectorRequirement{{Key: v1.LabelHostname, Operator: v1.NodeSelectorOpNotIn, Values: []string{"host1"}}}, terms[0].MatchExpressions)
+	assert.Nil(t, c.getMonPlacement("b", "").NodeAffinity)
+
+	// each term of the mon placement excludes the hosts
+	c.mapping.Schedule["a"].Hostname = "host2"
+	c.retireMonID("a")
+	c.spec.Placement = cephv1.PlacementSpec{cephv1.KeyMon: cephv1.Placement{NodeAffinity: &v1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+			{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "role", Operator: v1.NodeSelectorOpIn, Values: []string{"mon"}}}},
+			{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "role", Operator: v1.NodeSelectorOpIn, Values: []string{"storage"}}}},
+		}},
+	}}}
+	terms = c.getMonPlacement("a", "").NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	for _, term := range terms {
+		assert.Equal(t, []string{"host1", "host2"}, term.MatchExpressions[1].Values)
+	}
+	// the spec is not changed
+	assert.Len(t, c.spec.Placement[cephv1.KeyMon].NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+
+	// the removed mons are not recorded without the reuse
+	c.spec.Mon.ReuseIDs = false
+	c.retireMonID("c")
+	assert.NotContains(t, c.mapping.Retired, "c")
+}
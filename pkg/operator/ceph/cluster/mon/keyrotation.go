@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// monKeyUser is the cephx user of the key shared by all the mons
+const monKeyUser = "mon."
+
+// SetKeyStatus sets the status of the rotation of the mon key from the CephCluster status
+func (c *Cluster) SetKeyStatus(status *cephv1.ClusterCephxStatus) {
+	c.keyStatus = nil
+	if status != nil && status.Mon != nil {
+		c.keyStatus = status.Mon.DeepCopy()
+	}
+}
+
+// reconcileMonKey rotates the mon key when the rotation policy or the rotation interval of the mon
+// key requires it. The new key is saved in the mon secrets before the mon deployments are updated,
+// the mons are then restarted one at a time by the update of their deployments while keeping quorum.
+func (c *Cluster) reconcileMonKey() error {
+	secret, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Get(c.ClusterInfo.Context, AppName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get the mon secrets")
+	}
+	c.keyIdentifier = monKeyIdentifier(secret)
+
+	status := cephv1.MonCephxStatus{}
+	if c.keyStatus != nil {
+		status = *c.keyStatus
+	}
+	if status.KeyCephVersion == cephv1.UninitializedCephxKeyCephVersion {
+		// the key of a new cluster is not rotated before the mons are created
+		return nil
+	}
+
+	cfg := c.spec.Security.CephX.Mon
+	now := time.Now()
+	rotate, err := c.shouldRotateMonKey(cfg, status, now)
+	if err != nil {
+		return err
+	}
+	if rotate {
+		if err := c.rotateMonKey(secret); err != nil {
+			return err
+		}
+	}
+
+	// the rotation is reported right away so that the key is not rotated again while the mons restart
+	return c.saveMonKeyStatus(updatedMonKeyStatus(rotate, cfg, c.ClusterInfo.CephVersion, status, now))
+}
+
+// initMonKeyStatus reports the key of a new cluster once the mons are created
+func (c *Cluster) initMonKeyStatus() error {
+	if c.keyStatus == nil || c.keyStatus.KeyCephVersion != cephv1.UninitializedCephxKeyCephVersion {
+		return nil
+	}
+	return c.saveMonKeyStatus(updatedMonKeyStatus(false, c.spec.Security.CephX.Mon, c.ClusterInfo.CephVersion, *c.keyStatus, time.Now()))
+}
+
+// shouldRotateMonKey returns whether the mon key must be rotated, either because the key generation
+// was increased or because the key is older than the rotation interval
+func (c *Cluster) shouldRotateMonKey(cfg cephv1.MonCephxConfig, status cephv1.MonCephxStatus, now time.Time) (bool, error) {
+	intervalElapsed := cfg.RotationInterval != nil && cfg.RotationInterval.Duration > 0 &&
+		status.LastRotationTime != nil && now.Sub(status.LastRotationTime.Time) >= cfg.RotationInterval.Duration
+	generationIncreased := cfg.KeyRotationPolicy == cephv1.KeyGenerationCephxKeyRotationPolicy && cfg.KeyGeneration > status.KeyGeneration
+	if !intervalElapsed && !generationIncreased {
+		return false, nil
+	}
+
+	runningCephVersion, err := cephclient.LeastUptodateDaemonVersion(c.context, c.ClusterInfo, config.MonType)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get the ceph version of the mons to rotate the mon key")
+	}
+	if !runningCephVersion.IsAtLeast(keyring.CephAuthRotateSupportedVersion) {
+		logger.Warningf("not rotating the mon key, the mons run ceph version %q and the rotation requires ceph version %q", runningCephVersion.String(), keyring.CephAuthRotateSupportedVersion.String())
+		return false, nil
+	}
+	if intervalElapsed {
+		logger.Infof("rotating the mon key last rotated on %s, the rotation interval is %s", status.LastRotationTime.UTC().Format(time.RFC3339), cfg.RotationInterval.Duration.String())
+		return true, nil
+	}
+	return keyring.ShouldRotateCephxKeys(cfg.CephxConfig, runningCephVersion, c.ClusterInfo.CephVersion, status.CephxStatus)
+}
+
+// rotateMonKey rotates the mon key and saves it in the mon secrets and in the keyring the mons load
+// when they start. The previous keys are kept to clean up the data of the mons created with them
+// until all the mons restarted with the new key.
+func (c *Cluster) rotateMonKey(secret *corev1.Secret) error {
+	key, err := cephclient.AuthRotate(c.context, c.ClusterInfo, monKeyUser)
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate the mon key")
+	}
+
+	previousKeys := []string{}
+	if existing := string(secret.Data[controller.MonPreviousSecretsNameKey]); existing != "" {
+		previousKeys = strings.Split(existing, ",")
+	}
+	if !slices.Contains(previousKeys, c.ClusterInfo.MonitorSecret) {
+		previousKeys = append(previousKeys, c.ClusterInfo.MonitorSecret)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[controller.MonSecretNameKey] = []byte(key)
+	secret.Data[controller.MonPreviousSecretsNameKey] = []byte(strings.Join(previousKeys, ","))
+	if _, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Update(c.ClusterInfo.Context, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to save the rotated mon key in the mon secrets")
+	}
+	c.ClusterInfo.MonitorSecret = key
+
+	k := keyring.GetSecretStore(c.context, c.ClusterInfo, c.ownerInfo)
+	if _, err := k.CreateOrUpdate(keyringStoreName, c.genMonSharedKeyring()); err != nil {
+		return errors.Wrap(err, "failed to save the rotated mon key in the mon keyring secret")
+	}
+	c.keyIdentifier = monKeyIdentifier(secret)
+	logger.Info("rotated the mon key, the mons restart one at a time to load the new key")
+	return nil
+}
+
+// releasePreviousMonKeys clears the previous mon keys once all the mon deployments carry the
+// identifier of the current key and were rolled out. The mons refresh the keyring of their data
+// dir when they restart, so the previous keys are no longer needed to clean up the mon dirs.
+func (c *Cluster) releasePreviousMonKeys() error {
+	secret, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Get(c.ClusterInfo.Context, AppName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get the mon secrets")
+	}
+	if len(secret.Data[controller.MonPreviousSecretsNameKey]) == 0 || c.keyIdentifier == "" {
+		return nil
+	}
+
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the mon deployments")
+	}
+	if len(deployments.Items) == 0 {
+		return nil
+	}
+	for _, d := range deployments.Items {
+		if d.Spec.Template.Annotations[keyring.CephxKeyIdentifierAnnotation] != c.keyIdentifier {
+			logger.Debugf("keeping the previous mon keys until mon deployment %q is updated with the rotated key", d.Name)
+			return nil
+		}
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		if d.Status.ObservedGeneration < d.Generation || d.Status.UpdatedReplicas != replicas || d.Status.ReadyReplicas != replicas {
+			logger.Debugf("keeping the previous mon keys until mon deployment %q restarted with the rotated key", d.Name)
+			return nil
+		}
+	}
+
+	// the key is kept empty since its presence tells the key was rotated
+	secret.Data[controller.MonPreviousSecretsNameKey] = []byte{}
+	if _, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Update(c.ClusterInfo.Context, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to clear the previous mon keys")
+	}
+	logger.Info("all the mons restarted with the rotated mon key, cleared the previous mon keys")
+	return nil
+}
+
+// updatedMonKeyStatus returns the status of the mon key after the reconcile. The rotation interval
+// starts from the key creation, or from when the interval is first set for the keys created before.
+func updatedMonKeyStatus(didRotate bool, cfg cephv1.MonCephxConfig, cephVersion cephver.CephVersion, status cephv1.MonCephxStatus, now time.Time) cephv1.MonCephxStatus {
+	newStatus := cephv1.MonCephxStatus{
+		CephxStatus:      keyring.UpdatedCephxStatus(didRotate, cfg.CephxConfig, cephVersion, status.CephxStatus),
+		LastRotationTime: status.LastRotationTime,
+	}
+	if didRotate || status.KeyCephVersion == cephv1.UninitializedCephxKeyCephVersion || (newStatus.LastRotationTime == nil && cfg.RotationInterval != nil) {
+		rotationTime := metav1.NewTime(now.UTC().Truncate(time.Second))
+		newStatus.LastRotationTime = &rotationTime
+	}
+	return newStatus
+}
+
+// saveMonKeyStatus reports the status of the mon key on the CephCluster when it changed
+func (c *Cluster) saveMonKeyStatus(status cephv1.MonCephxStatus) error {
+	if c.keyStatus != nil && reflect.DeepEqual(status, *c.keyStatus) {
+		return nil
+	}
+	if c.keyStatus == nil && reflect.DeepEqual(status, cephv1.MonCephxStatus{}) {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+			return errors.Wrap(err, "failed to get the CephCluster")
+		}
+		if cephCluster.Status.Cephx == nil {
+			cephCluster.Status.Cephx = &cephv1.ClusterCephxStatus{}
+		}
+		cephCluster.Status.Cephx.Mon = status.DeepCopy()
+		return reporting.UpdateStatus(c.context.Client, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to report the status of the mon key")
+	}
+	c.keyStatus = status.DeepCopy()
+	return nil
+}
+
+// monKeyIdentifier identifies the mon key to restart the mons when it is rotated. The mons of the
+// clusters whose key was never rotated are not annotated so that they don't restart for nothing.
+func monKeyIdentifier(secret *corev1.Secret) string {
+	if _, rotated := secret.Data[controller.MonPreviousSecretsNameKey]; !rotated {
+		return ""
+	}
+	hash := sha256.Sum256(secret.Data[controller.MonSecretNameKey])
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileMonKey(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	clusterInfo.MonitorSecret = "oldkey"
+	clusterInfo.CephVersion = cephver.CephVersion{Major: 20, Minor: 2, Extra: 0}
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clientset := k8sfake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: AppName, Namespace: nsName.Namespace},
+		Data:       map[string][]byte{controller.MonSecretNameKey: []byte("oldkey")},
+	})
+	rotations := 0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "versions" {
+				return `{"mon":{"ceph version 20.2.0 (0000000000000000000000000000000000000000) tentacle (stable)":3}}`, nil
+			}
+			if args[0] == "auth" && args[1] == "rotate" && args[2] == monKeyUser {
+				rotations++
+				return fmt.Sprintf(`[{"key":"key%d"}]`, rotations), nil
+			}
+			return "", nil
+		},
+	}
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   nsName.Namespace,
+		context:     &clusterd.Context{Client: cl, Clientset: clientset, Executor: executor},
+		ownerInfo:   cephclient.NewMinimumOwnerInfoWithOwnerRef(),
+	}
+	lastRotation := metav1.NewTime(time.Now().Add(-time.Hour).UTC().Truncate(time.Second))
+
+	t.Run("disabled", func(t *testing.T) {
+		c.SetKeyStatus(&cephv1.ClusterCephxStatus{Mon: &cephv1.MonCephxStatus{CephxStatus: cephv1.CephxStatus{KeyGeneration: 1, KeyCephVersion: "20.2.0-0"}, LastRotationTime: &lastRotation}})
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 0, rotations)
+		assert.Empty(t, c.keyIdentifier)
+	})
+
+	t.Run("interval not elapsed", func(t *testing.T) {
+		c.spec.Security.CephX.Mon.RotationInterval = &metav1.Duration{Duration: 2 * time.Hour}
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 0, rotations)
+	})
+
+	t.Run("interval elapsed", func(t *testing.T) {
+		c.spec.Security.CephX.Mon.RotationInterval = &metav1.Duration{Duration: 30 * time.Minute}
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 1, rotations)
+		assert.Equal(t, "key1", c.ClusterInfo.MonitorSecret)
+		assert.NotEmpty(t, c.keyIdentifier)
+
+		// the previous key is kept to clean up the mon dirs
+		secret, err := clientset.CoreV1().Secrets(nsName.Namespace).Get(ctx, AppName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "key1", string(secret.Data[controller.MonSecretNameKey]))
+		assert.Equal(t, "oldkey", string(secret.Data[controller.MonPreviousSecretsNameKey]))
+
+		// the mons restart with the new key
+		pod, err := c.makeMonPod(&monConfig{ResourceName: "rook-ceph-mon-a", DaemonName: "a", DataPathMap: &config.DataPathMap{}}, false)
+		require.NoError(t, err)
+		assert.Equal(t, c.keyIdentifier, pod.Annotations[keyring.CephxKeyIdentifierAnnotation])
+		assert.Equal(t, "init-mon-keyring", pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1].Name)
+
+		require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+		require.NotNil(t, cephCluster.Status.Cephx.Mon)
+		assert.Equal(t, uint32(2), cephCluster.Status.Cephx.Mon.KeyGeneration)
+		assert.True(t, cephCluster.Status.Cephx.Mon.LastRotationTime.After(lastRotation.Time))
+	})
+
+	t.Run("key generation", func(t *testing.T) {
+		c.spec.Security.CephX.Mon = cephv1.MonCephxConfig{CephxConfig: cephv1.CephxConfig{KeyRotationPolicy: cephv1.KeyGenerationCephxKeyRotationPolicy, KeyGeneration: 4}}
+		previousIdentifier := c.keyIdentifier
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 2, rotations)
+		assert.NotEqual(t, previousIdentifier, c.keyIdentifier)
+		secret, err := clientset.CoreV1().Secrets(nsName.Namespace).Get(ctx, AppName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "oldkey,key1", string(secret.Data[controller.MonPreviousSecretsNameKey]))
+		require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+		assert.Equal(t, uint32(4), cephCluster.Status.Cephx.Mon.KeyGeneration)
+
+		// the key is not rotated again for the same generation
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 2, rotations)
+	})
+
+	t.Run("new cluster", func(t *testing.T) {
+		uninitialized := keyring.UninitializedCephxStatus()
+		c.SetKeyStatus(&cephv1.ClusterCephxStatus{Mon: &cephv1.MonCephxStatus{CephxStatus: uninitialized}})
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 2, rotations)
+		require.NoError(t, c.initMonKeyStatus())
+		require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+		assert.Equal(t, uint32(4), cephCluster.Status.Cephx.Mon.KeyGeneration)
+		assert.Equal(t, "20.2.0-0", cephCluster.Status.Cephx.Mon.KeyCephVersion)
+		assert.NotNil(t, cephCluster.Status.Cephx.Mon.LastRotationTime)
+	})
+
+	t.Run("previous keys released", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon-a", Namespace: nsName.Namespace, Labels: map[string]string{k8sutil.AppAttr: AppName}},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{keyring.CephxKeyIdentifierAnnotation: "old"}}},
+			},
+		}
+		_, err := clientset.AppsV1().Deployments(nsName.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+		require.NoError(t, err)
+		previousKeys := func() string {
+			secret, err := clientset.CoreV1().Secrets(nsName.Namespace).Get(ctx, AppName, metav1.GetOptions{})
+			require.NoError(t, err)
+			return string(secret.Data[controller.MonPreviousSecretsNameKey])
+		}
+
+		// the mon still has the previous key
+		require.NoError(t, c.releasePreviousMonKeys())
+		assert.Equal(t, "oldkey,key1", previousKeys())
+
+		// the mon is updated but not restarted yet
+		deployment.Spec.Template.Annotations[keyring.CephxKeyIdentifierAnnotation] = c.keyIdentifier
+		_, err = clientset.AppsV1().Deployments(nsName.Namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.releasePreviousMonKeys())
+		assert.Equal(t, "oldkey,key1", previousKeys())
+
+		// the mon restarted with the rotated key
+		deployment.Status = appsv1.DeploymentStatus{UpdatedReplicas: 1, ReadyReplicas: 1}
+		_, err = clientset.AppsV1().Deployments(nsName.Namespace).UpdateStatus(ctx, deployment, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.releasePreviousMonKeys())
+		assert.Empty(t, previousKeys())
+
+		// the mons keep the identifier of the rotated key
+		identifier := c.keyIdentifier
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, identifier, c.keyIdentifier)
+
+		// only the key replaced by the next rotation is kept
+		c.spec.Security.CephX.Mon.KeyGeneration = 5
+		require.NoError(t, c.reconcileMonKey())
+		assert.Equal(t, 3, rotations)
+		assert.Equal(t, "key2", previousKeys())
+	})
+}
+
+func TestUpdatedMonKeyStatus(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	rotated := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	interval := cephv1.MonCephxConfig{RotationInterval: &metav1.Duration{Duration: time.Hour}}
+	version := cephver.CephVersion{Major: 20, Minor: 2}
+
+	// the keys created before the rotation was tracked are not timed without an interval
+	status := updatedMonKeyStatus(false, cephv1.MonCephxConfig{}, version, cephv1.MonCephxStatus{}, now)
+	assert.Equal(t, cephv1.MonCephxStatus{}, status)
+
+	// the interval starts when it is first set
+	status = updatedMonKeyStatus(false, interval, version, cephv1.MonCephxStatus{}, now)
+	require.NotNil(t, status.LastRotationTime)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status.LastRotationTime.Format(time.RFC3339))
+
+	// the time is kept until the key is rotated
+	status = updatedMonKeyStatus(false, interval, version, cephv1.MonCephxStatus{LastRotationTime: &rotated}, now)
+	assert.Equal(t, &rotated, status.LastRotationTime)
+	status = updatedMonKeyStatus(true, interval, version, cephv1.MonCephxStatus{LastRotationTime: &rotated}, now)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status.LastRotationTime.Format(time.RFC3339))
+	assert.Equal(t, uint32(1), status.KeyGeneration)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// MonMaintenanceAnnotation is the annotation of a mon deployment that pauses the failover of the mon
+// while it is out of quorum, so that admins can debug the mon without the operator replacing it
+const MonMaintenanceAnnotation = "ceph.rook.io/maintenance"
+
+// inMaintenance returns whether the mon deployment is annotated for maintenance
+func inMaintenance(d *apps.Deployment) bool {
+	value, ok := d.Annotations[MonMaintenanceAnnotation]
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.Warningf("ignoring the invalid value %q of the annotation %q on deployment %q", value, MonMaintenanceAnnotation, d.Name)
+		return false
+	}
+	return enabled
+}
+
+// keepMaintenanceAnnotation copies the maintenance annotation of the existing mon deployment to its
+// updated deployment, since the annotation is set by the admin and not by the operator
+func keepMaintenanceAnnotation(existing, d *apps.Deployment) {
+	value, ok := existing.Annotations[MonMaintenanceAnnotation]
+	if !ok {
+		return
+	}
+	if d.Annotations == nil {
+		d.Annotations = map[string]string{}
+	}
+	d.Annotations[MonMaintenanceAnnotation] = value
+}
+
+// updateMonsInMaintenance finds the mons whose deployment is annotated for maintenance. The mons
+// whose maintenance ended wait for the full out of quorum timeout again before they are failed over.
+func (c *Cluster) updateMonsInMaintenance(ctx context.Context) {
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(ctx, opts)
+	if err != nil {
+		logger.Warningf("failed to check the mons in maintenance. %v", err)
+		return
+	}
+
+	mons := sets.New[string]()
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if name := d.Labels[controller.DaemonIDLabel]; name != "" && inMaintenance(d) {
+			mons.Insert(name)
+		}
+	}
+	for _, name := range sets.List(mons.Difference(c.maintenanceMons)) {
+		logger.Warningf("mon %q is in maintenance, it will not be failed over until the annotation %q is removed", name, MonMaintenanceAnnotation)
+		c.recordEvent(v1.EventTypeWarning, MonMaintenanceStartedReason, "mon %q is in maintenance, its failover is paused", name)
+	}
+	for _, name := range sets.List(c.maintenanceMons.Difference(mons)) {
+		logger.Infof("mon %q is no longer in maintenance", name)
+		c.recordEvent(v1.EventTypeNormal, MonMaintenanceEndedReason, "mon %q is no longer in maintenance", name)
+		delete(c.monTimeoutList, name)
+	}
+	c.maintenanceMons = mons
+}
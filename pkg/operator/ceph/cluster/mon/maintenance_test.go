@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestMonsInMaintenance(t *testing.T) {
+	clientset := k8sfake.NewSimpleClientset()
+	for name, maintenance := range map[string]string{"a": "true", "b": "", "c": "not-a-bool"} {
+		d := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(name),
+			Namespace: "ns",
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, controller.DaemonIDLabel: name},
+		}}
+		if maintenance != "" {
+			d.Annotations = map[string]string{MonMaintenanceAnnotation: maintenance}
+		}
+		_, err := clientset.AppsV1().Deployments("ns").Create(context.TODO(), d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	recorder := record.NewFakeRecorder(10)
+	c := &Cluster{
+		ClusterInfo:    clienttest.CreateTestClusterInfo(3),
+		Namespace:      "ns",
+		context:        &clusterd.Context{Clientset: clientset},
+		monTimeoutList: map[string]time.Time{"a": time.Now()},
+		recorder:       recorder,
+	}
+
+	c.updateMonsInMaintenance(context.TODO())
+	assert.True(t, c.maintenanceMons.Has("a"))
+	assert.False(t, c.maintenanceMons.Has("b"))
+	assert.False(t, c.maintenanceMons.Has("c"))
+	assert.Contains(t, <-recorder.Events, MonMaintenanceStartedReason)
+
+	// the failover of the mon in maintenance is skipped
+	assert.False(t, c.failMon(3, 3, "a"))
+
+	// the out of quorum timeout restarts once the maintenance ended
+	d, err := clientset.AppsV1().Deployments("ns").Get(context.TODO(), resourceName("a"), metav1.GetOptions{})
+	require.NoError(t, err)
+	d.Annotations[MonMaintenanceAnnotation] = "false"
+	_, err = clientset.AppsV1().Deployments("ns").Update(context.TODO(), d, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	c.updateMonsInMaintenance(context.TODO())
+	assert.Empty(t, c.maintenanceMons)
+	assert.NotContains(t, c.monTimeoutList, "a")
+	assert.Contains(t, <-recorder.Events, MonMaintenanceEndedReason)
+}
+
+func TestKeepMaintenanceAnnotation(t *testing.T) {
+	existing := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{MonMaintenanceAnnotation: "true"}}}
+	d := &apps.Deployment{}
+	keepMaintenanceAnnotation(existing, d)
+	assert.Equal(t, "true", d.Annotations[MonMaintenanceAnnotation])
+	assert.True(t, inMaintenance(d))
+
+	d = &apps.Deployment{}
+	keepMaintenanceAnnotation(&apps.Deployment{}, d)
+	assert.Nil(t, d.Annotations)
+	assert.False(t, inMaintenance(d))
+}
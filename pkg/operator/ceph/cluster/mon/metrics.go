@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "rook_ceph"
+	metricsSubsystem = "mon"
+
+	failoverSucceeded = "succeeded"
+	failoverFailed    = "failed"
+)
+
+// The metrics of the mon health checker, exposed on the metrics endpoint of the operator
+var (
+	monFailovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "failovers_total",
+		Help:      "Number of mon failovers by result",
+	}, []string{"namespace", "result"})
+
+	monsOutOfQuorum = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "out_of_quorum",
+		Help:      "Number of mons in the mon map that are out of quorum at the last health check",
+	}, []string{"namespace"})
+
+	monLastQuorumTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "last_quorum_timestamp_seconds",
+		Help:      "Unix time of the last health check that found all the mons in quorum",
+	}, []string{"namespace"})
+
+	monSchedulingFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "scheduling_failures_total",
+		Help:      "Number of times new mons failed to be scheduled on a node",
+	}, []string{"namespace"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(monFailovers, monsOutOfQuorum, monLastQuorumTime, monSchedulingFailures)
+}
+
+// recordQuorumMetrics records the mons out of quorum found by a health check
+func (c *Cluster) recordQuorumMetrics(monCount, quorumCount int) {
+	outOfQuorum := monCount - quorumCount
+	if outOfQuorum < 0 {
+		outOfQuorum = 0
+	}
+	monsOutOfQuorum.WithLabelValues(c.Namespace).Set(float64(outOfQuorum))
+	if outOfQuorum == 0 {
+		monLastQuorumTime.WithLabelValues(c.Namespace).Set(float64(time.Now().Unix()))
+	}
+}
+
+func (c *Cluster) recordFailoverMetric(result string) {
+	monFailovers.WithLabelValues(c.Namespace, result).Inc()
+}
+
+func (c *Cluster) recordSchedulingFailureMetric() {
+	monSchedulingFailures.WithLabelValues(c.Namespace).Inc()
+}
+
+// DeleteMetrics removes the metrics of the mons of the cluster when the cluster is deleted
+func DeleteMetrics(namespace string) {
+	labels := prometheus.Labels{"namespace": namespace}
+	monFailovers.DeletePartialMatch(labels)
+	monsOutOfQuorum.DeletePartialMatch(labels)
+	monLastQuorumTime.DeletePartialMatch(labels)
+	monSchedulingFailures.DeletePartialMatch(labels)
+}
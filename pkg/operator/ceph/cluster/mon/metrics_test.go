@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonMetrics(t *testing.T) {
+	c := &Cluster{Namespace: "metrics-ns"}
+	t.Cleanup(func() { DeleteMetrics(c.Namespace) })
+
+	c.recordQuorumMetrics(3, 2)
+	assert.Equal(t, float64(1), testutil.ToFloat64(monsOutOfQuorum.WithLabelValues(c.Namespace)))
+	assert.Zero(t, testutil.ToFloat64(monLastQuorumTime.WithLabelValues(c.Namespace)))
+
+	c.recordQuorumMetrics(3, 3)
+	assert.Zero(t, testutil.ToFloat64(monsOutOfQuorum.WithLabelValues(c.Namespace)))
+	assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(monLastQuorumTime.WithLabelValues(c.Namespace)), 5)
+
+	c.recordFailoverMetric(failoverSucceeded)
+	c.recordFailoverMetric(failoverSucceeded)
+	c.recordFailoverMetric(failoverFailed)
+	assert.Equal(t, float64(2), testutil.ToFloat64(monFailovers.WithLabelValues(c.Namespace, failoverSucceeded)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(monFailovers.WithLabelValues(c.Namespace, failoverFailed)))
+
+	c.recordSchedulingFailureMetric()
+	assert.Equal(t, float64(1), testutil.ToFloat64(monSchedulingFailures.WithLabelValues(c.Namespace)))
+
+	// the metrics of a deleted cluster are removed
+	DeleteMetrics(c.Namespace)
+	assert.Zero(t, testutil.ToFloat64(monFailovers.WithLabelValues(c.Namespace, failoverSucceeded)))
+	assert.Zero(t, testutil.ToFloat64(monSchedulingFailures.WithLabelValues(c.Namespace)))
+}
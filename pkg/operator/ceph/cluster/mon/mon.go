@@ -51,6 +51,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
 	cephcsi "github.com/ceph/ceph-csi/api/deploy/kubernetes"
@@ -67,6 +68,10 @@ const (
 	EndpointDataKey = "data"
 	// EndpointExternalMonsKey key in EndpointConfigMapName configmap containing IDs of external mons
 	EndpointExternalMonsKey = "externalMons"
+	// EndpointMonRemovedTimeKey key in EndpointConfigMapName configmap containing the time a mon was last removed
+	EndpointMonRemovedTimeKey = "monRemovedTime"
+	// EndpointMonStatusKey key in EndpointConfigMapName configmap containing the json status of each mon
+	EndpointMonStatusKey = "monStatus"
 	// AppName is the name of the secret storing cluster mon.admin key, fsid and name
 	AppName = "rook-ceph-mon"
 	//nolint:gosec // OperatorCreds is the name of the secret
@@ -133,6 +138,72 @@ type Cluster struct {
 	arbiterMon         string
 	// list of mons to be failed over
 	monsToFailover map[string]*monConfig
+	// the even mon count warning last reported on the CephCluster
+	evenMonCountMessage string
+	// the reason last logged for not removing the extra mons
+	monScaleDownWarning string
+	// the mon store check failure last reported on the CephCluster
+	storeCheckFailureMessage string
+	// the external mon endpoint mismatches last reported on the CephCluster
+	externalMonMismatchMessage string
+	// the missing nodes or zones to add mons last reported on the CephCluster
+	schedulingBlockedMessage string
+	// the time since all the mons of a stretch zone are out of quorum
+	zoneOutOfQuorumSince map[string]time.Time
+	// the time since the nodes of a failed stretch zone are ready again
+	zoneRecoveredSince map[string]time.Time
+	// the time a mon was last removed, after which the volumes mounted before may use stale mon endpoints
+	monRemovedTime time.Time
+	// the nodes with stale mon endpoints last reported on the CephCluster
+	staleMonEndpointsMessage string
+	// whether all the volumes were found mounted after the last mon removal
+	monEndpointsCurrent bool
+	// the claims by namespace of the volumes of the cluster provisioned before the last mon removal
+	staleMonEndpointClaims map[string]sets.Set[string]
+	// the mon removal time the claims were listed for
+	staleMonEndpointClaimsTime time.Time
+	// the recorder of the events of the mon health on the CephCluster
+	recorder record.EventRecorder
+	// the scheduled compaction of the mon stores
+	compaction monStoreCompaction
+	// the rate limit of the mon failovers
+	failoverBackoff monFailoverBackoff
+	// the mons whose deployment is annotated for maintenance at the last health check
+	maintenanceMons sets.Set[string]
+	// the expansion of the mon PVCs last reported on the CephCluster
+	volumeExpansionReported []cephv1.MonVolumeExpansionStatus
+	// the migration of the mons to PVCs, and the migration last reported on the CephCluster
+	pvcMigration         *cephv1.MonPVCMigrationStatus
+	pvcMigrationReported *cephv1.MonPVCMigrationStatus
+	// the mons whose clock is skewed, and the skewed mons last reported on the CephCluster
+	clockSkew         []cephv1.MonClockSkewStatus
+	clockSkewReported []cephv1.MonClockSkewStatus
+	// the mons whose disk is low on space, and the mons last reported on the CephCluster
+	diskUsage         []cephv1.MonDiskUsageStatus
+	diskUsageReported []cephv1.MonDiskUsageStatus
+	// the readiness of the mon zones, and the readiness last reported on the CephCluster
+	monZones         []cephv1.MonZoneStatus
+	monZonesReported []cephv1.MonZoneStatus
+	// the mon failed over while in quorum, kept running until the new mon is in quorum
+	preemptiveFailover string
+	// the node the new mon is scheduled on when a mon is moved back to its node
+	failbackNode *corev1.Node
+	// the time since the node of a mon failed over during a node outage is ready again, and the time
+	// a mon was last moved back to its node
+	homeNodeReadySince map[string]time.Time
+	lastMonFailback    time.Time
+	// the rank of the mons and the mons in quorum at the last health check, the time each mon was
+	// created to replace a failed over mon, and the status of the mons last reported on the CephCluster
+	monRanks          map[string]int
+	monsInQuorum      sets.Set[string]
+	monFailoverTimes  map[string]metav1.Time
+	monStatusReported []cephv1.MonStatus
+	// the status of the rotation of the mon key from the CephCluster, and the identifier of the mon
+	// key annotated on the mon pods once the key was rotated
+	keyStatus     *cephv1.MonCephxStatus
+	keyIdentifier string
+	// the dependencies of the health checker replaced in the tests
+	healthDeps HealthCheckDependencies
 }
 
 // monConfig for a single monitor
@@ -143,8 +214,14 @@ type monConfig struct {
 	DaemonName string
 	// PublicIP is the IP of the mon's service that the mon will receive connections on
 	PublicIP string
+	// ClusterIP is the cluster address of a mon with host networking, Ceph picks the address when
+	// it is empty
+	ClusterIP string
 	// Port is the port on which the mon will listen for connections
 	Port int32
+	// Msgr2Port is the port of the messenger v2 protocol, the mon listens only on this port when it
+	// is the same as Port
+	Msgr2Port int32
 	// The zone used for a stretch cluster
 	Zone string
 	// The node where the mon is assigned
@@ -156,6 +233,8 @@ type monConfig struct {
 	// from the cephcluster host network setting. If the cluster setting changes,
 	// each individual mon must keep running with the same network settings.
 	UseHostNetwork bool
+	// RestartReason is annotated on the pod template of a new mon deployment
+	RestartReason string
 }
 
 type SchedulingResult struct {
@@ -180,7 +259,10 @@ func New(ctx context.Context, clusterdContext *clusterd.Context, namespace strin
 		ClusterInfo: &cephclient.ClusterInfo{
 			Context: ctx,
 		},
-		monsToFailover: map[string]*monConfig{},
+		monsToFailover:       map[string]*monConfig{},
+		zoneOutOfQuorumSince: map[string]time.Time{},
+		zoneRecoveredSince:   map[string]time.Time{},
+		homeNodeReadySince:   map[string]time.Time{},
 	}
 }
 
@@ -194,6 +276,57 @@ func (c *Cluster) Start(clusterInfo *cephclient.ClusterInfo, rookImage string, c
 	c.acquireOrchestrationLock()
 	defer c.releaseOrchestrationLock()
 
+	logger.Infof("start running mons")
+	if err := c.init(clusterInfo, rookImage, cephVersion, spec); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("targeting the mon count %d", c.spec.Mon.Count)
+
+	monsToSkipReconcile, err := controller.GetDaemonsToSkipReconcile(c.ClusterInfo.Context, c.context, c.Namespace, config.MonType, AppName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for mons to skip reconcile")
+	}
+	if monsToSkipReconcile.Len() > 0 {
+		logger.Warningf("skipping mon reconcile since mons are labeled with %s: %v", cephv1.SkipReconcileLabelKey, sets.List(monsToSkipReconcile))
+		return c.ClusterInfo, nil
+	}
+
+	// Rotate the mon key before the mon deployments are updated so the mons restart with the new key
+	if err := c.reconcileMonKey(); err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile the mon key")
+	}
+
+	// create the mons for a new cluster or ensure mons are running in an existing cluster
+	if err := c.startMons(c.spec.Mon.Count); err != nil {
+		return c.ClusterInfo, err
+	}
+
+	// The key of a new cluster is tracked once the mons are created
+	if err := c.initMonKeyStatus(); err != nil {
+		return c.ClusterInfo, errors.Wrap(err, "failed to initialize the status of the mon key")
+	}
+
+	// The previous mon keys are not needed anymore once the mons restarted with the rotated key
+	if err := c.releasePreviousMonKeys(); err != nil {
+		logger.Warningf("failed to release the previous mon keys. %v", err)
+	}
+	return c.ClusterInfo, nil
+}
+
+// Init establishes the cluster info without reconciling the mons, when the creation of the mons was
+// already completed
+func (c *Cluster) Init(clusterInfo *cephclient.ClusterInfo, rookImage string, cephVersion cephver.CephVersion, spec cephv1.ClusterSpec) (*cephclient.ClusterInfo, error) {
+	c.acquireOrchestrationLock()
+	defer c.releaseOrchestrationLock()
+
+	if err := c.init(clusterInfo, rookImage, cephVersion, spec); err != nil {
+		return nil, err
+	}
+	return c.ClusterInfo, nil
+}
+
+func (c *Cluster) init(clusterInfo *cephclient.ClusterInfo, rookImage string, cephVersion cephver.CephVersion, spec cephv1.ClusterSpec) error {
 	clusterInfo.OwnerInfo = c.ownerInfo
 	c.ClusterInfo = clusterInfo
 	if c.ClusterInfo.Context == nil {
@@ -204,35 +337,20 @@ func (c *Cluster) Start(clusterInfo *cephclient.ClusterInfo, rookImage string, c
 
 	// fail if we were instructed to deploy more than one mon on the same machine with host networking
 	if c.spec.Network.IsHost() && c.spec.Mon.AllowMultiplePerNode && c.spec.Mon.Count > 1 {
-		return nil, errors.Errorf("refusing to deploy %d monitors on the same host with host networking and allowMultiplePerNode is %t. only one monitor per node is allowed", c.spec.Mon.Count, c.spec.Mon.AllowMultiplePerNode)
+		return errors.Errorf("refusing to deploy %d monitors on the same host with host networking and allowMultiplePerNode is %t. only one monitor per node is allowed", c.spec.Mon.Count, c.spec.Mon.AllowMultiplePerNode)
 	}
 
 	// Validate pod's memory if specified
 	err := controller.CheckPodMemory(cephv1.ResourcesKeyMon, cephv1.GetMonResources(c.spec.Resources), cephMonPodMinimumMemory)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to check pod memory")
+		return errors.Wrap(err, "failed to check pod memory")
 	}
 
-	logger.Infof("start running mons")
-
 	logger.Debugf("establishing ceph cluster info")
 	if err := c.initClusterInfo(cephVersion, c.ClusterInfo.NamespacedName().Name); err != nil {
-		return nil, errors.Wrap(err, "failed to initialize ceph cluster info")
-	}
-
-	logger.Infof("targeting the mon count %d", c.spec.Mon.Count)
-
-	monsToSkipReconcile, err := controller.GetDaemonsToSkipReconcile(c.ClusterInfo.Context, c.context, c.Namespace, config.MonType, AppName)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to check for mons to skip reconcile")
+		return errors.Wrap(err, "failed to initialize ceph cluster info")
 	}
-	if monsToSkipReconcile.Len() > 0 {
-		logger.Warningf("skipping mon reconcile since mons are labeled with %s: %v", cephv1.SkipReconcileLabelKey, sets.List(monsToSkipReconcile))
-		return c.ClusterInfo, nil
-	}
-
-	// create the mons for a new cluster or ensure mons are running in an existing cluster
-	return c.ClusterInfo, c.startMons(c.spec.Mon.Count)
+	return nil
 }
 
 func (c *Cluster) startMons(targetCount int) error {
@@ -242,11 +360,29 @@ func (c *Cluster) startMons(targetCount int) error {
 		return errors.Wrap(err, "failed to init mon config")
 	}
 
+	// Check that the new mons can be scheduled before creating their canaries, which would stay
+	// pending and block the health checks if the nodes or zones are missing
+	if existingCount > 0 && existingCount < len(mons) {
+		if err := c.checkMonScheduling(mons[existingCount:]); err != nil {
+			return err
+		}
+	}
+
 	// Assign the mons to nodes
 	if err := c.assignMons(mons); err != nil {
 		return errors.Wrap(err, "failed to assign pods to mons")
 	}
 
+	// Drop the msgr1 address of the existing mons before their deployments are updated
+	if err := c.migrateMonsToMsgr2(mons[0:existingCount]); err != nil {
+		return errors.Wrap(err, "failed to move mons to the msgr2 port")
+	}
+
+	// Move the existing mons to the ports of the mon spec one mon at a time
+	if err := c.migrateMonPorts(mons[0:existingCount]); err != nil {
+		return errors.Wrap(err, "failed to move mons to the desired ports")
+	}
+
 	// The centralized mon config database can only be used if there is at least one mon
 	// operational. If we are starting mons, and one is already up, then there is a cluster already
 	// created, and we can immediately set values in the config database. The goal is to set configs
@@ -317,6 +453,10 @@ func (c *Cluster) startMons(targetCount int) error {
 		}
 	}
 
+	if err := c.configureMonElectionPreferences(); err != nil {
+		return errors.Wrap(err, "failed to configure mon election preferences")
+	}
+
 	logger.Debugf("mon endpoints used are: %s", flattenMonEndpoints(c.ClusterInfo.AllMonitors()))
 
 	// reconcile mon PDB
@@ -358,6 +498,15 @@ func (c *Cluster) getArbiterZone() string {
 	return ""
 }
 
+// getMonZones returns the zones the mons are spread across, either of the stretch cluster or of
+// the mon spec
+func (c *Cluster) getMonZones() []cephv1.MonZoneSpec {
+	if c.spec.IsStretchCluster() {
+		return c.spec.Mon.StretchCluster.Zones
+	}
+	return c.spec.Mon.Zones
+}
+
 func (c *Cluster) isArbiterZone(zone string) bool {
 	if !c.spec.IsStretchCluster() {
 		return false
@@ -366,11 +515,21 @@ func (c *Cluster) isArbiterZone(zone string) bool {
 }
 
 func (c *Cluster) ConfigureArbiter() error {
+	if !c.spec.IsStretchMode() {
+		// the connectivity election strategy and the stretch CRUSH rule spread the mons and the data
+		logger.Infof("not enabling the ceph stretch mode for the %d data zones of the stretch cluster, the stretch mode supports two data zones", c.spec.StretchDataZoneCount())
+		return nil
+	}
+	monDump, err := cephclient.GetMonDump(c.context, c.ClusterInfo)
+	if err == nil && monDump.StretchMode && c.arbiterMon == "" {
+		// the arbiter zone changed, the mon health check moves the tiebreaker to the new arbiter zone
+		logger.Infof("no mon found in the arbiter zone %q, keeping tiebreaker mon %q", c.getArbiterZone(), monDump.TiebreakerMon)
+		return nil
+	}
 	if c.arbiterMon == "" {
 		return errors.New("arbiter not specified for the stretch cluster")
 	}
 
-	monDump, err := cephclient.GetMonDump(c.context, c.ClusterInfo)
 	if err != nil {
 		logger.Warningf("attempting to enable arbiter after failed to detect if already enabled. %v", err)
 	} else if monDump.StretchMode {
@@ -568,12 +727,13 @@ func (c *Cluster) initMonConfig(size int) (int, []*monConfig, error) {
 	// initialize mon info if we don't have enough mons (at first startup)
 	existingCount := len(c.ClusterInfo.InternalMonitors)
 	for i := len(c.ClusterInfo.InternalMonitors); i < size; i++ {
-		c.maxMonID++
+		id := c.nextMonID(mons)
+		c.maxMonID = max(c.maxMonID, id)
 		zone, err := c.findAvailableZone(mons)
 		if err != nil {
 			return existingCount, mons, errors.Wrap(err, "zone not available")
 		}
-		mons = append(mons, c.newMonConfig(c.maxMonID, zone))
+		mons = append(mons, c.newMonConfig(id, zone))
 	}
 
 	return existingCount, mons, nil
@@ -592,6 +752,7 @@ func (c *Cluster) clusterInfoToMonConfigWithExclude(excludedMon string) []*monCo
 		}
 		var zone string
 		var nodeName string
+		var clusterIP string
 		isHostNetwork := false
 		monPublicIP := cephutil.GetIPFromEndpoint(monitor.Endpoint)
 		schedule := c.mapping.Schedule[monitor.Name]
@@ -600,6 +761,7 @@ func (c *Cluster) clusterInfoToMonConfigWithExclude(excludedMon string) []*monCo
 			nodeName = schedule.Name
 			if schedule.Address == monPublicIP {
 				isHostNetwork = true
+				clusterIP = schedule.ClusterAddress
 			}
 		}
 		logger.Debugf("Host network for mon %q is %t", monitor.Name, isHostNetwork)
@@ -608,7 +770,9 @@ func (c *Cluster) clusterInfoToMonConfigWithExclude(excludedMon string) []*monCo
 			ResourceName:   resourceName(monitor.Name),
 			DaemonName:     monitor.Name,
 			Port:           cephutil.GetPortFromEndpoint(monitor.Endpoint),
+			Msgr2Port:      monitor.GetMsgr2Port(),
 			PublicIP:       monPublicIP,
+			ClusterIP:      clusterIP,
 			Zone:           zone,
 			NodeName:       nodeName,
 			DataPathMap:    config.NewStatefulDaemonDataPathMap(c.spec.DataDirHostPath, dataDirRelativeHostPath(monitor.Name), config.MonType, monitor.Name, c.Namespace),
@@ -620,15 +784,16 @@ func (c *Cluster) clusterInfoToMonConfigWithExclude(excludedMon string) []*monCo
 
 func (c *Cluster) newMonConfig(monID int, zone string) *monConfig {
 	daemonName := k8sutil.IndexToName(monID)
-	defaultPort := DefaultMsgr1Port
+	defaultPort := Msgr1Port(&c.spec)
 	if c.spec.RequireMsgr2() {
-		defaultPort = DefaultMsgr2Port
+		defaultPort = Msgr2Port(&c.spec)
 	}
 
 	return &monConfig{
 		ResourceName:   resourceName(daemonName),
 		DaemonName:     daemonName,
 		Port:           defaultPort,
+		Msgr2Port:      Msgr2Port(&c.spec),
 		Zone:           zone,
 		UseHostNetwork: c.spec.Network.IsHost(),
 		DataPathMap: config.NewStatefulDaemonDataPathMap(
@@ -650,28 +815,56 @@ func (c *Cluster) findAvailableZone(mons []*monConfig) (string, error) {
 		zoneCount[m.Zone]++
 	}
 
-	var zones []cephv1.MonZoneSpec
-	if c.spec.IsStretchCluster() {
-		zones = c.spec.Mon.StretchCluster.Zones
-	} else {
-		zones = c.spec.Mon.Zones
+	zones := c.getMonZones()
+	if !c.spec.IsStretchCluster() {
+		return c.findLeastLoadedZone(zones, zoneCount)
 	}
+	failedZones := c.failedStretchZones()
 
 	// Find a zone in the stretch cluster that still needs an assignment
 	for _, zone := range zones {
+		if failedZones.Has(zone.Name) {
+			// the mons of a failed zone are hosted by the surviving zones until it recovers
+			continue
+		}
 		count, ok := zoneCount[zone.Name]
 		if !ok {
 			// The zone isn't currently assigned to any mon, so return it
 			return zone.Name, nil
 		}
-		if c.spec.IsStretchCluster() && c.spec.Mon.Count == 5 && count == 1 && !zone.Arbiter {
-			// The zone only has 1 mon assigned, but needs 2 mons since it is not the arbiter
+		if !zone.Arbiter && count < c.spec.StretchMonsPerDataZone() {
+			// The data zone needs more mons than it has assigned
 			return zone.Name, nil
 		}
 	}
+	if failedZones.Len() > 0 {
+		return c.findSurvivingDataZone(mons, failedZones)
+	}
 	return "", errors.New("A zone is not available to assign a new mon")
 }
 
+// findLeastLoadedZone returns the zone with the fewest mons, as long as it has fewer mons than its
+// share of the mon count, so that the mons are spread evenly when there are more mons than zones
+func (c *Cluster) findLeastLoadedZone(zones []cephv1.MonZoneSpec, zoneCount map[string]int) (string, error) {
+	if len(zones) == 0 {
+		return "", errors.New("A zone is not available to assign a new mon")
+	}
+	maxPerZone := (c.spec.Mon.Count + len(zones) - 1) / len(zones)
+	target := ""
+	for _, zone := range zones {
+		if zoneCount[zone.Name] >= maxPerZone {
+			continue
+		}
+		if target == "" || zoneCount[zone.Name] < zoneCount[target] {
+			target = zone.Name
+		}
+	}
+	if target == "" {
+		return "", errors.New("A zone is not available to assign a new mon")
+	}
+	return target, nil
+}
+
 // resourceName ensures the mon name has the rook-ceph-mon prefix
 func resourceName(name string) string {
 	if strings.HasPrefix(name, AppName) {
@@ -703,8 +896,9 @@ func scheduleMonitor(c *Cluster, mon *monConfig) (*apps.Deployment, error) {
 	d.Spec.Template.Spec.Containers[0].LivenessProbe = nil
 
 	// setup affinity settings for pod scheduling
-	p := c.getMonPlacement(mon.Zone)
+	p := c.getMonPlacement(mon.DaemonName, mon.Zone)
 	p.ApplyToPodSpec(&d.Spec.Template.Spec)
+	c.applyMonTopologySpread(&d.Spec.Template.Spec)
 	k8sutil.SetNodeAntiAffinityForPod(&d.Spec.Template.Spec, requiredDuringScheduling(&c.spec), k8sutil.LabelHostname(),
 		map[string]string{k8sutil.AppAttr: AppName}, nil)
 
@@ -772,7 +966,28 @@ func scheduleMonitor(c *Cluster, mon *monConfig) (*apps.Deployment, error) {
 }
 
 // GetMonPlacement returns the placement for the MON service
-func (c *Cluster) getMonPlacement(zone string) cephv1.Placement {
+func (c *Cluster) getMonPlacement(monName, zone string) cephv1.Placement {
+	p := c.getZonePlacement(zone)
+	if override := c.getMonOverride(monName, zone); override.Placement != nil {
+		p = p.Merge(*override.Placement)
+	}
+	return c.excludeRetiredMonHosts(monName, p)
+}
+
+// applyMonTopologySpread adds the topology spread constraints of the mon spec to the pod spec of a
+// mon or of its canary. The constraints without a label selector select all the mon pods, including
+// the canaries, so that the mons are spread as they are scheduled.
+func (c *Cluster) applyMonTopologySpread(podSpec *corev1.PodSpec) {
+	for _, constraint := range c.spec.Mon.TopologySpreadConstraints {
+		constraint := *constraint.DeepCopy()
+		if constraint.LabelSelector == nil {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{k8sutil.AppAttr: AppName}}
+		}
+		podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, constraint)
+	}
+}
+
+func (c *Cluster) getZonePlacement(zone string) cephv1.Placement {
 	// If the mon is the arbiter in a stretch cluster and its placement is specified, return it
 	// without merging with the "all" placement so it can be handled separately from all other daemons
 	if c.isArbiterZone(zone) {
@@ -787,6 +1002,25 @@ func (c *Cluster) getMonPlacement(zone string) cephv1.Placement {
 	return cephv1.GetMonPlacement(c.spec.Placement)
 }
 
+// getMonResources returns the resources of the mon, from its override if any
+func (c *Cluster) getMonResources(monConfig *monConfig) corev1.ResourceRequirements {
+	if override := c.getMonOverride(monConfig.DaemonName, monConfig.Zone); override.Resources != nil {
+		return *override.Resources
+	}
+	return cephv1.GetMonResources(c.spec.Resources)
+}
+
+// getMonOverride returns the override of the mon ID, or else the override of its zone
+func (c *Cluster) getMonOverride(monName, zone string) cephv1.MonOverrideSpec {
+	if override, ok := c.spec.Mon.Overrides[monName]; ok {
+		return override
+	}
+	if zone != "" {
+		return c.spec.Mon.Overrides[zone]
+	}
+	return cephv1.MonOverrideSpec{}
+}
+
 func realWaitForMonitorScheduling(c *Cluster, d *apps.Deployment) (SchedulingResult, error) {
 	// target node decision, and deployment/pvc to cleanup
 	result := SchedulingResult{}
@@ -845,6 +1079,7 @@ func (c *Cluster) initMonIPs(mons []*monConfig) error {
 				return errors.Errorf("failed to find node for mon %q in assignment map", m.DaemonName)
 			}
 			m.PublicIP = node.Address
+			m.ClusterIP = node.ClusterAddress
 		} else {
 			monService, err := c.createService(m)
 			if err != nil {
@@ -864,7 +1099,7 @@ func (c *Cluster) initMonIPs(mons []*monConfig) error {
 				}
 			}
 		}
-		c.ClusterInfo.InternalMonitors[m.DaemonName] = cephclient.NewMonInfo(m.DaemonName, m.PublicIP, m.Port)
+		c.ClusterInfo.InternalMonitors[m.DaemonName] = m.newMonInfo()
 	}
 
 	return nil
@@ -928,6 +1163,7 @@ func (c *Cluster) assignMons(mons []*monConfig) error {
 		// before a decision is stored in the node mapping.
 		deployment, err := scheduleMonitor(c, mon)
 		if err != nil {
+			c.recordSchedulingFailureMetric()
 			return errors.Wrap(err, "failed to schedule monitor")
 		}
 
@@ -938,14 +1174,13 @@ func (c *Cluster) assignMons(mons []*monConfig) error {
 			// signal that the mon is done scheduling
 			defer monSchedulingWait.Done()
 
-			result, err := waitForMonitorScheduling(c, deployment)
+			nodeChoice, err := c.scheduler().WaitForScheduling(deployment)
 			if err != nil {
 				logger.Errorf("failed to schedule mon %q. %v", mon.DaemonName, err)
 				failedMonSchedule = true
 				return
 			}
 
-			nodeChoice := result.Node
 			if nodeChoice == nil {
 				logger.Errorf("failed to schedule monitor %q", mon.DaemonName)
 				failedMonSchedule = true
@@ -964,6 +1199,11 @@ func (c *Cluster) assignMons(mons []*monConfig) error {
 					failedMonSchedule = true
 					return
 				}
+				if err := c.selectMonAddresses(mon.DaemonName, mon.Zone, *nodeChoice, schedule); err != nil {
+					logger.Errorf("failed to select the addresses of mon %q. %v", mon.DaemonName, err)
+					failedMonSchedule = true
+					return
+				}
 			} else {
 				logger.Infof("mon %q placement using native scheduler", mon.DaemonName)
 			}
@@ -984,6 +1224,7 @@ func (c *Cluster) assignMons(mons []*monConfig) error {
 
 	monSchedulingWait.Wait()
 	if failedMonSchedule {
+		c.recordSchedulingFailureMetric()
 		return errors.New("failed to schedule mons")
 	}
 
@@ -994,14 +1235,7 @@ func (c *Cluster) assignMons(mons []*monConfig) error {
 func (c *Cluster) monVolumeClaimTemplate(mon *monConfig) *corev1.PersistentVolumeClaim {
 	if c.spec.ZonesRequired() {
 		// If a stretch cluster, a zone can override the template from the default.
-
-		var zones []cephv1.MonZoneSpec
-		if c.spec.IsStretchCluster() {
-			zones = c.spec.Mon.StretchCluster.Zones
-		} else {
-			zones = c.spec.Mon.Zones
-		}
-		for _, zone := range zones {
+		for _, zone := range c.getMonZones() {
 			if zone.Name == mon.Zone {
 				if zone.VolumeClaimTemplate != nil {
 					// Found an override for the volume claim template in the zone
@@ -1148,7 +1382,7 @@ func (c *Cluster) waitForMonsToJoin(mons []*monConfig, requireAllInQuorum bool)
 
 	// wait for the monitors to join quorum
 	sleepTime := 5
-	err := waitForQuorumWithMons(c.context, c.ClusterInfo, starting, sleepTime, requireAllInQuorum)
+	err := waitForQuorumWithMons(c.context, c.ClusterInfo, c.quorumFetcher(), starting, sleepTime, requireAllInQuorum)
 	if err != nil {
 		return errors.Wrap(err, "failed to wait for mon quorum")
 	}
@@ -1274,13 +1508,13 @@ func (c *Cluster) createEndpointSliceForAddresses(addresses []string, addressTyp
 	endpointSlicePorts := []discoveryv1.EndpointPort{}
 	endpointSlicePorts = append(endpointSlicePorts, discoveryv1.EndpointPort{
 		Name:     ptr.To(DefaultMsgr2PortName),
-		Port:     ptr.To(DefaultMsgr2Port),
+		Port:     ptr.To(Msgr2Port(&c.spec)),
 		Protocol: ptr.To(corev1.ProtocolTCP),
 	})
 	if !c.spec.RequireMsgr2() {
 		endpointSlicePorts = append(endpointSlicePorts, discoveryv1.EndpointPort{
 			Name:     ptr.To(DefaultMsgr1PortName),
-			Port:     ptr.To(DefaultMsgr1Port),
+			Port:     ptr.To(Msgr1Port(&c.spec)),
 			Protocol: ptr.To(corev1.ProtocolTCP),
 		})
 	}
@@ -1378,8 +1612,25 @@ func (c *Cluster) persistExpectedMonDaemonsInConfigMap() error {
 		controller.MaxMonIDKey:    maxMonID,
 		controller.MappingKey:     string(monMapping),
 		controller.OutOfQuorumKey: strings.Join(monsOutOfQuorum, ","),
+		controller.Msgr2PortsKey:  flattenMonMsgr2Ports(c.ClusterInfo.InternalMonitors),
 		csi.ConfigKey:             csiConfigValue,
 	}
+	monRemovedTime, err := c.getMonRemovedTime()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the time a mon was last removed")
+	}
+	if !monRemovedTime.IsZero() {
+		configMap.Data[EndpointMonRemovedTimeKey] = monRemovedTime.Format(time.RFC3339)
+	}
+	monStatus, err := c.monStatus()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the status of the mons")
+	}
+	rawMonStatus, err := json.Marshal(monStatus)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the status of the mons")
+	}
+	configMap.Data[EndpointMonStatusKey] = string(rawMonStatus)
 
 	if _, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Create(c.ClusterInfo.Context, configMap, metav1.CreateOptions{}); err != nil {
 		if !kerrors.IsAlreadyExists(err) {
@@ -1452,23 +1703,14 @@ var updateDeploymentAndWait = UpdateCephDeploymentAndWait
 
 func (c *Cluster) updateMon(m *monConfig, d *apps.Deployment) error {
 	// Expand mon PVC if storage request for mon has increased in cephcluster crd
-	if c.monVolumeClaimTemplate(m) != nil {
-		desiredPvc, err := c.makeDeploymentPVC(m, false)
-		if err != nil {
-			return errors.Wrapf(err, "failed to make mon %q pvc", d.Name)
-		}
-
-		existingPvc, err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
-		if err != nil {
-			return errors.Wrapf(err, "failed to fetch pvc for mon %q", m.ResourceName)
-		}
-		_ = k8sutil.ExpandPVCIfRequired(c.ClusterInfo.Context, c.context.Client, desiredPvc, existingPvc)
+	if err := c.expandMonPVC(m); err != nil {
+		return err
 	}
 
 	logger.Infof("deployment for mon %s already exists. updating if needed",
 		d.Name)
 
-	err := updateDeploymentAndWait(c.context, c.ClusterInfo, d, config.MonType, m.DaemonName, c.spec.SkipUpgradeChecks, false)
+	err := c.deploymentUpdater().UpdateDeploymentAndWait(c.context, c.ClusterInfo, d, config.MonType, m.DaemonName, c.spec.SkipUpgradeChecks, false)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update mon deployment %s", m.ResourceName)
 	}
@@ -1515,21 +1757,26 @@ func (c *Cluster) startMon(m *monConfig, schedule *controller.MonScheduleInfo) e
 	if err != nil {
 		return err
 	}
-
-	// Set the deployment hash as an annotation
-	err = patch.DefaultAnnotator.SetLastAppliedAnnotation(d)
-	if err != nil {
-		return errors.Wrapf(err, "failed to set annotation for deployment %q", d.Name)
+	if m.RestartReason != "" {
+		k8sutil.SetRestartReason(&d.Spec.Template, m.RestartReason)
 	}
 
 	existingDeployment, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(c.ClusterInfo.Context, d.Name, metav1.GetOptions{})
 	if err == nil {
 		deploymentExists = true
 		pvcExists = controller.DaemonVolumesContainsPVC(existingDeployment.Spec.Template.Spec.Volumes)
+		// the maintenance of the mon set by the admin must survive the updates of the deployment
+		keepMaintenanceAnnotation(existingDeployment, d)
 	} else if !kerrors.IsNotFound(err) {
 		return errors.Wrapf(err, "failed to get mon deployment %s", d.Name)
 	}
 
+	// Set the deployment hash as an annotation
+	err = patch.DefaultAnnotator.SetLastAppliedAnnotation(d)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set annotation for deployment %q", d.Name)
+	}
+
 	// persistent storage is not altered after the deployment is created. this
 	// means we need to be careful when updating the deployment to avoid new
 	// changes to the crd to change an existing pod's persistent storage. the
@@ -1551,13 +1798,17 @@ func (c *Cluster) startMon(m *monConfig, schedule *controller.MonScheduleInfo) e
 	if schedule != nil {
 		zone = schedule.Zone
 	}
-	p := c.getMonPlacement(zone)
+	p := c.getMonPlacement(m.DaemonName, zone)
 
 	p.ApplyToPodSpec(&d.Spec.Template.Spec)
+	c.applyMonTopologySpread(&d.Spec.Template.Spec)
 	if deploymentExists {
 		// skip update if mon path has changed
 		if hasMonPathChanged(existingDeployment, c.spec.Mon.VolumeClaimTemplate.ToPVC()) {
-			c.monsToFailover[m.DaemonName] = m
+			// the migration to PVCs fails over the mons on the host path once the quorum is verified
+			if pvcExists || !c.spec.Mon.MigrateHostPathToPVC {
+				c.monsToFailover[m.DaemonName] = m
+			}
 			return nil
 		}
 
@@ -1663,7 +1914,7 @@ func hasMonPathChanged(d *apps.Deployment, claim *corev1.PersistentVolumeClaim)
 	return false
 }
 
-func waitForQuorumWithMons(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, mons []string, sleepTime int, requireAllInQuorum bool) error {
+func waitForQuorumWithMons(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, quorum QuorumFetcher, mons []string, sleepTime int, requireAllInQuorum bool) error {
 	logger.Infof("waiting for mon quorum with %v", mons)
 
 	// wait for monitors to establish quorum
@@ -1709,7 +1960,7 @@ func waitForQuorumWithMons(context *clusterd.Context, clusterInfo *cephclient.Cl
 
 		// get the quorum_status response that contains info about all monitors in the mon map and
 		// their quorum status
-		monQuorumStatusResp, err := cephclient.GetMonQuorumStatus(context, clusterInfo)
+		monQuorumStatusResp, err := quorum.MonQuorumStatus(context, clusterInfo)
 		if err != nil {
 			logger.Debugf("failed to get quorum_status. %v", err)
 			continue
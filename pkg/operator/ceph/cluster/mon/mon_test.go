@@ -44,6 +44,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -582,7 +583,7 @@ func TestWaitForQuorum(t *testing.T) {
 	requireAllInQuorum := false
 	expectedMons := []string{"a"}
 	clusterInfo := cephclient.AdminTestClusterInfo("mycluster")
-	err = waitForQuorumWithMons(context, clusterInfo, expectedMons, 0, requireAllInQuorum)
+	err = waitForQuorumWithMons(context, clusterInfo, cephQuorumFetcher{}, expectedMons, 0, requireAllInQuorum)
 	assert.NoError(t, err)
 }
 
@@ -654,6 +655,20 @@ func TestConfigureArbiter(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, setNewTiebreaker)
 	})
+	t.Run("no mon in the arbiter zone after the arbiter zone changed", func(t *testing.T) {
+		setNewTiebreaker = false
+		c.arbiterMon = ""
+		err := c.ConfigureArbiter()
+		assert.NoError(t, err)
+		assert.False(t, setNewTiebreaker)
+	})
+	t.Run("no stretch mode with three data zones", func(t *testing.T) {
+		c.spec.Mon.StretchCluster.Zones = append(c.spec.Mon.StretchCluster.Zones, cephv1.MonZoneSpec{Name: "d"})
+		c.arbiterMon = "changed"
+		err := c.ConfigureArbiter()
+		assert.NoError(t, err)
+		assert.False(t, setNewTiebreaker)
+	})
 }
 
 func TestFindAvailableZoneMon(t *testing.T) {
@@ -693,6 +708,38 @@ func TestFindAvailableZoneMon(t *testing.T) {
 	availableZone, err = c.findAvailableZone(existingMons)
 	assert.Error(t, err)
 	assert.Equal(t, "", availableZone)
+
+	// With 7 mons, the zone with the fewest mons is available
+	existingMons = []*monConfig{
+		{ResourceName: "u", Zone: "a"},
+		{ResourceName: "v", Zone: "a"},
+		{ResourceName: "w", Zone: "b"},
+		{ResourceName: "x", Zone: "c"},
+		{ResourceName: "y", Zone: "c"},
+	}
+	c.spec.Mon.Count = 7
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", availableZone)
+
+	// With 7 mons, a zone has one more mon than the others
+	existingMons = append(existingMons, &monConfig{ResourceName: "z", Zone: "b"})
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", availableZone)
+
+	// With 7 mons, a zone already has its share of the mons
+	existingMons = append(existingMons, &monConfig{ResourceName: "t", Zone: "a"})
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", availableZone)
+
+	// With 9 mons and no available zones
+	c.spec.Mon.Count = 9
+	existingMons = append(existingMons, &monConfig{ResourceName: "s", Zone: "b"}, &monConfig{ResourceName: "r", Zone: "c"})
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.Error(t, err)
+	assert.Equal(t, "", availableZone)
 }
 
 func TestFindAvailableZoneForStretchedMon(t *testing.T) {
@@ -769,6 +816,27 @@ func TestFindAvailableZoneForStretchedMon(t *testing.T) {
 	availableZone, err = c.findAvailableZone(existingMons)
 	assert.NoError(t, err)
 	assert.Equal(t, "a", availableZone)
+
+	// With 7 mons and three data zones, the last data zone needs a second mon
+	c.spec.Mon.Count = 7
+	c.spec.Mon.StretchCluster.Zones = append(c.spec.Mon.StretchCluster.Zones, cephv1.MonZoneSpec{Name: "d"})
+	existingMons = []*monConfig{
+		{ResourceName: "u", Zone: "a"},
+		{ResourceName: "v", Zone: "b"},
+		{ResourceName: "w", Zone: "b"},
+		{ResourceName: "x", Zone: "c"},
+		{ResourceName: "y", Zone: "c"},
+		{ResourceName: "z", Zone: "d"},
+	}
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.NoError(t, err)
+	assert.Equal(t, "d", availableZone)
+
+	// With 7 mons and no available zones
+	existingMons = append(existingMons, &monConfig{ResourceName: "q", Zone: "d"})
+	availableZone, err = c.findAvailableZone(existingMons)
+	assert.Error(t, err)
+	assert.Equal(t, "", availableZone)
 }
 
 func TestMonVolumeClaimTemplate(t *testing.T) {
@@ -969,22 +1037,80 @@ func TestArbiterPlacement(t *testing.T) {
 	c.spec.Placement[cephv1.KeyMonArbiter] = placement
 
 	// No placement is found if not requesting the arbiter placement
-	result := c.getMonPlacement("c")
+	result := c.getMonPlacement("", "c")
 	assert.Equal(t, cephv1.Placement{}, result)
 
 	// Placement is found if requesting the arbiter
-	result = c.getMonPlacement("a")
+	result = c.getMonPlacement("", "a")
 	assert.Equal(t, placement, result)
 
 	// Arbiter and all mons have the same placement if no arbiter placement is specified
 	c.spec.Placement = cephv1.PlacementSpec{}
 	c.spec.Placement[cephv1.KeyMon] = placement
-	result = c.getMonPlacement("a")
+	result = c.getMonPlacement("", "a")
 	assert.Equal(t, placement, result)
-	result = c.getMonPlacement("c")
+	result = c.getMonPlacement("", "c")
 	assert.Equal(t, placement, result)
 }
 
+func TestMonOverrides(t *testing.T) {
+	monPlacement := cephv1.Placement{
+		Tolerations: []v1.Toleration{{Key: "mon", Operator: v1.TolerationOpExists}},
+	}
+	nodePlacement := cephv1.Placement{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{
+						Key:      v1.LabelHostname,
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{"node1"},
+					}},
+				}},
+			},
+		},
+	}
+	monResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	arbiterResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+	c := &Cluster{spec: cephv1.ClusterSpec{
+		Placement: cephv1.PlacementSpec{cephv1.KeyMon: monPlacement},
+		Resources: cephv1.ResourceSpec{cephv1.ResourcesKeyMon: monResources},
+		Mon: cephv1.MonSpec{
+			Overrides: map[string]cephv1.MonOverrideSpec{
+				"b":       {Placement: &nodePlacement},
+				"arbiter": {Resources: &arbiterResources},
+			},
+		},
+	}}
+
+	t.Run("no override", func(t *testing.T) {
+		assert.Equal(t, monPlacement, c.getMonPlacement("a", "zone1"))
+		assert.Equal(t, monResources, c.getMonResources(&monConfig{DaemonName: "a", Zone: "zone1"}))
+	})
+
+	t.Run("override of the mon", func(t *testing.T) {
+		p := c.getMonPlacement("b", "zone1")
+		assert.Equal(t, nodePlacement.NodeAffinity, p.NodeAffinity)
+		assert.Equal(t, monPlacement.Tolerations, p.Tolerations)
+		assert.Equal(t, monResources, c.getMonResources(&monConfig{DaemonName: "b", Zone: "zone1"}))
+	})
+
+	t.Run("override of the zone", func(t *testing.T) {
+		assert.Equal(t, monPlacement, c.getMonPlacement("c", "arbiter"))
+		assert.Equal(t, arbiterResources, c.getMonResources(&monConfig{DaemonName: "c", Zone: "arbiter"}))
+	})
+
+	t.Run("the override of the mon takes precedence over its zone", func(t *testing.T) {
+		p := c.getMonPlacement("b", "arbiter")
+		assert.Equal(t, nodePlacement.NodeAffinity, p.NodeAffinity)
+		assert.Equal(t, monResources, c.getMonResources(&monConfig{DaemonName: "b", Zone: "arbiter"}))
+	})
+}
+
 func TestCheckIfArbiterReady(t *testing.T) {
 	c := &Cluster{
 		Namespace: "ns",
@@ -1165,3 +1291,31 @@ func TestIsMonIPUpdateRequiredForHostNetwork(t *testing.T) {
 		assert.True(t, isMonIPUpdateRequiredForHostNetwork("a", monUsingHostNetwork, hostNetwork))
 	})
 }
+
+func TestMonTopologySpread(t *testing.T) {
+	rackSpread := v1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: "topology.rook.io/rack", WhenUnsatisfiable: v1.DoNotSchedule}
+	zoneSpread := v1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       v1.LabelTopologyZone,
+		WhenUnsatisfiable: v1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "custom"}},
+	}
+	placementSpread := v1.TopologySpreadConstraint{MaxSkew: 2, TopologyKey: v1.LabelHostname, WhenUnsatisfiable: v1.ScheduleAnyway}
+
+	c := newQuorumRestoreTestCluster(t, nil)
+	c.spec.Placement = cephv1.PlacementSpec{cephv1.KeyMon: {TopologySpreadConstraints: []v1.TopologySpreadConstraint{placementSpread}}}
+	c.spec.Mon.TopologySpreadConstraints = []v1.TopologySpreadConstraint{rackSpread, zoneSpread}
+
+	// the canary is spread with the constraints of the placement and of the mon spec
+	d, err := scheduleMonitor(c, testGenMonConfig("d"))
+	assert.NoError(t, err)
+	constraints := d.Spec.Template.Spec.TopologySpreadConstraints
+	assert.Len(t, constraints, 3)
+	assert.Equal(t, placementSpread, constraints[0])
+	// the constraint without selector selects the mon pods, which includes the canaries
+	assert.Equal(t, "topology.rook.io/rack", constraints[1].TopologyKey)
+	assert.Equal(t, map[string]string{"app": AppName}, constraints[1].LabelSelector.MatchLabels)
+	assert.Equal(t, "rook-ceph-mon", d.Spec.Template.Labels["app"])
+	assert.Equal(t, zoneSpread, constraints[2])
+	assert.Nil(t, c.spec.Mon.TopologySpreadConstraints[0].LabelSelector)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Msgr1Port returns the port of the messenger v1 protocol of the mons. The ports of the mon spec
+// only apply to the mons on the host network, the mons on the pod network are reached through
+// their service on the default ports.
+func Msgr1Port(spec *cephv1.ClusterSpec) int32 {
+	if spec.Mon.Ports == nil || spec.Mon.Ports.Msgr1 == 0 || !spec.Network.IsHost() {
+		return DefaultMsgr1Port
+	}
+	return spec.Mon.Ports.Msgr1
+}
+
+// Msgr2Port returns the port of the messenger v2 protocol of the mons
+func Msgr2Port(spec *cephv1.ClusterSpec) int32 {
+	if spec.Mon.Ports == nil || spec.Mon.Ports.Msgr2 == 0 || !spec.Network.IsHost() {
+		return DefaultMsgr2Port
+	}
+	return spec.Mon.Ports.Msgr2
+}
+
+// msgr2Port returns the port of the messenger v2 protocol of the mon
+func (m *monConfig) msgr2Port() int32 {
+	if m.Msgr2Port == 0 {
+		return DefaultMsgr2Port
+	}
+	return m.Msgr2Port
+}
+
+// addrvec returns the addresses of the mon in the monmap, for example
+// "[v2:10.0.0.1:3300,v1:10.0.0.1:6789]"
+func (m *monConfig) addrvec() string {
+	msgr2Endpoint := net.JoinHostPort(m.PublicIP, strconv.Itoa(int(m.msgr2Port())))
+	if m.Port == m.msgr2Port() {
+		return fmt.Sprintf("[v2:%s]", msgr2Endpoint)
+	}
+	msgr1Endpoint := net.JoinHostPort(m.PublicIP, strconv.Itoa(int(m.Port)))
+	return fmt.Sprintf("[v2:%s,v1:%s]", msgr2Endpoint, msgr1Endpoint)
+}
+
+// newMonInfo returns the mon info of the mon in the cluster info
+func (m *monConfig) newMonInfo() *cephclient.MonInfo {
+	info := cephclient.NewMonInfo(m.DaemonName, m.PublicIP, m.Port)
+	if m.msgr2Port() != DefaultMsgr2Port {
+		info.Msgr2Port = m.msgr2Port()
+	}
+	return info
+}
+
+// publicAddrFlag returns the flag of the address the mon advertises. The mons on the host network
+// advertise all their addresses when the ports of the mon spec are set, so that a new mon is added
+// to the monmap with its ports instead of the default ports.
+func (c *Cluster) publicAddrFlag(m *monConfig) string {
+	if c.spec.Mon.Ports != nil && m.UseHostNetwork {
+		return config.NewFlag("public-addrv", m.addrvec())
+	}
+	return config.NewFlag("public-addr", m.PublicIP)
+}
+
+// migrateMonPorts moves the existing mons on the host network to the ports of the mon spec, one mon
+// at a time. The addresses of the mon are updated in the monmap, then the mon endpoints, the
+// config of the daemons and the CSI config are saved before the mon is restarted on its new ports.
+// The next mon is only moved once all the mons are back in quorum.
+func (c *Cluster) migrateMonPorts(mons []*monConfig) error {
+	if c.spec.Mon.Ports == nil {
+		return nil
+	}
+	msgr2Port := Msgr2Port(&c.spec)
+	desiredPort := Msgr1Port(&c.spec)
+	if c.spec.RequireMsgr2() {
+		desiredPort = msgr2Port
+	}
+
+	for _, m := range mons {
+		monInfo, ok := c.ClusterInfo.InternalMonitors[m.DaemonName]
+		if !ok || !m.UseHostNetwork || m.PublicIP == "" {
+			// the mon is new or its service keeps the default ports
+			continue
+		}
+		if m.Port == desiredPort && m.msgr2Port() == msgr2Port {
+			continue
+		}
+
+		if err := c.waitForMonsToJoin(mons, true); err != nil {
+			return errors.Wrapf(err, "failed to wait for the mons in quorum before moving mon %q to the new ports", m.DaemonName)
+		}
+
+		oldAddrs := m.addrvec()
+		oldPort, oldMsgr2Port := m.Port, m.Msgr2Port
+		m.Port, m.Msgr2Port = desiredPort, msgr2Port
+		logger.Infof("moving mon %q from %q to %q", m.DaemonName, oldAddrs, m.addrvec())
+		if err := cephclient.SetMonAddrs(c.context, c.ClusterInfo, m.DaemonName, m.addrvec()); err != nil {
+			// the mon keeps its ports until the monmap is updated
+			m.Port, m.Msgr2Port = oldPort, oldMsgr2Port
+			return errors.Wrapf(err, "failed to move mon %q to the new ports", m.DaemonName)
+		}
+		info := m.newMonInfo()
+		info.OutOfQuorum = monInfo.OutOfQuorum
+		c.ClusterInfo.InternalMonitors[m.DaemonName] = info
+
+		// the daemons and the CSI driver must find the mon on its new ports when they restart
+		if err := c.saveMonConfig(); err != nil {
+			return errors.Wrapf(err, "failed to save the new ports of mon %q", m.DaemonName)
+		}
+		if err := c.startMon(m, c.mapping.Schedule[m.DaemonName]); err != nil {
+			return errors.Wrapf(err, "failed to restart mon %q on the new ports", m.DaemonName)
+		}
+		if err := c.waitForMonsToJoin(mons, true); err != nil {
+			return errors.Wrapf(err, "failed to wait for mon %q in quorum on the new ports", m.DaemonName)
+		}
+		c.recordEvent(v1.EventTypeNormal, MonPortMigrationReason, "moved mon %q from %q to %q", m.DaemonName, oldAddrs, m.addrvec())
+	}
+
+	return nil
+}
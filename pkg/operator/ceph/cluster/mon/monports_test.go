@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestMonPorts(t *testing.T) {
+	spec := &cephv1.ClusterSpec{}
+	assert.Equal(t, DefaultMsgr1Port, Msgr1Port(spec))
+	assert.Equal(t, DefaultMsgr2Port, Msgr2Port(spec))
+
+	// the ports only apply to the mons on the host network
+	spec.Mon.Ports = &cephv1.MonPortsSpec{Msgr1: 6790, Msgr2: 3301}
+	assert.Equal(t, DefaultMsgr1Port, Msgr1Port(spec))
+	assert.Equal(t, DefaultMsgr2Port, Msgr2Port(spec))
+
+	spec.Network.Provider = cephv1.NetworkProviderHost
+	assert.Equal(t, int32(6790), Msgr1Port(spec))
+	assert.Equal(t, int32(3301), Msgr2Port(spec))
+
+	spec.Mon.Ports = &cephv1.MonPortsSpec{Msgr2: 3301}
+	assert.Equal(t, DefaultMsgr1Port, Msgr1Port(spec))
+}
+
+func TestMonAddrs(t *testing.T) {
+	m := &monConfig{DaemonName: "a", PublicIP: "1.2.3.1", Port: DefaultMsgr1Port}
+	assert.Equal(t, "[v2:1.2.3.1:3300,v1:1.2.3.1:6789]", m.addrvec())
+	assert.Equal(t, &cephclient.MonInfo{Name: "a", Endpoint: "1.2.3.1:6789"}, m.newMonInfo())
+
+	m = &monConfig{DaemonName: "a", PublicIP: "fd00::1", Port: 3301, Msgr2Port: 3301}
+	assert.Equal(t, "[v2:[fd00::1]:3301]", m.addrvec())
+	assert.Equal(t, &cephclient.MonInfo{Name: "a", Endpoint: "[fd00::1]:3301", Msgr2Port: 3301}, m.newMonInfo())
+
+	c := &Cluster{}
+	m = &monConfig{DaemonName: "a", PublicIP: "1.2.3.1", Port: 6790, Msgr2Port: 3301, UseHostNetwork: true}
+	assert.Equal(t, "--public-addr=1.2.3.1", c.publicAddrFlag(m))
+	c.spec.Mon.Ports = &cephv1.MonPortsSpec{Msgr1: 6790, Msgr2: 3301}
+	assert.Equal(t, "--public-addrv=[v2:1.2.3.1:3301,v1:1.2.3.1:6790]", c.publicAddrFlag(m))
+	m.UseHostNetwork = false
+	assert.Equal(t, "--public-addr=1.2.3.1", c.publicAddrFlag(m))
+}
+
+func TestMigrateMonPorts(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "ns"
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "mon" && args[1] == "set-addrs" {
+				commands = append(commands, fmt.Sprintf("%s %s", args[2], args[3]))
+				return "", nil
+			}
+			return "", fmt.Errorf("unrecognized command: %s %v", command, args)
+		},
+	}
+	clusterdContext := &clusterd.Context{Clientset: test.New(t, 3), Executor: executor, ConfigDir: t.TempDir()}
+	c := newCluster(clusterdContext, namespace, false, v1.ResourceRequirements{})
+	c.ClusterInfo = clienttest.CreateTestClusterInfo(3)
+	recorder := record.NewFakeRecorder(10)
+	c.recorder = recorder
+	mons := []*monConfig{}
+	for _, name := range []string{"a", "b", "c"} {
+		m := testGenMonConfig(name)
+		m.PublicIP = fmt.Sprintf("1.2.3.%d", len(mons)+1)
+		m.UseHostNetwork = true
+		c.ClusterInfo.InternalMonitors[name].Endpoint = fmt.Sprintf("%s:6789", m.PublicIP)
+		mons = append(mons, m)
+	}
+	// the mon on the pod network keeps the default ports of its service
+	mons[2].UseHostNetwork = false
+
+	t.Run("no ports", func(t *testing.T) {
+		require.NoError(t, c.migrateMonPorts(mons))
+		assert.Empty(t, commands)
+	})
+
+	c.spec.Network.Provider = cephv1.NetworkProviderHost
+	c.spec.Mon.Ports = &cephv1.MonPortsSpec{Msgr1: 6790, Msgr2: 3301}
+
+	t.Run("mons moved to the new ports", func(t *testing.T) {
+		require.NoError(t, c.migrateMonPorts(mons))
+		assert.Equal(t, []string{"a [v2:1.2.3.1:3301,v1:1.2.3.1:6790]", "b [v2:1.2.3.2:3301,v1:1.2.3.2:6790]"}, commands)
+		assert.Equal(t, int32(6790), mons[0].Port)
+		assert.Equal(t, int32(3301), mons[0].Msgr2Port)
+		assert.Equal(t, "1.2.3.1:6790", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+		assert.Equal(t, int32(3301), c.ClusterInfo.InternalMonitors["a"].Msgr2Port)
+		assert.Equal(t, "1.2.3.3:6789", c.ClusterInfo.InternalMonitors["c"].Endpoint)
+		assert.Contains(t, <-recorder.Events, MonPortMigrationReason)
+		assert.Contains(t, <-recorder.Events, MonPortMigrationReason)
+
+		// the new ports are saved for the daemons and the CSI driver
+		cm, err := c.context.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, EndpointConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a=3301,b=3301", cm.Data[opcontroller.Msgr2PortsKey])
+		assert.Contains(t, cm.Data[EndpointDataKey], "a=1.2.3.1:6790")
+
+		// the mons are restarted on the new ports
+		d, err := c.context.Clientset.AppsV1().Deployments(namespace).Get(ctx, "rook-ceph-mon-a", metav1.GetOptions{})
+		require.NoError(t, err)
+		container := d.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Args, "--public-addrv=[v2:1.2.3.1:3301,v1:1.2.3.1:6790]")
+		assert.Equal(t, int32(3301), container.Ports[0].ContainerPort)
+		assert.Equal(t, int32(6790), container.Ports[1].ContainerPort)
+
+		// the mons are not moved again
+		commands = []string{}
+		require.NoError(t, c.migrateMonPorts(mons))
+		assert.Empty(t, commands)
+	})
+
+	t.Run("failure to update the monmap", func(t *testing.T) {
+		c.spec.Mon.Ports.Msgr2 = 3302
+		c.context.Executor = &exectest.MockExecutor{
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				return "", fmt.Errorf("mons not in quorum")
+			},
+		}
+		assert.Error(t, c.migrateMonPorts(mons))
+		// the mon keeps its ports until the monmap is updated
+		assert.Equal(t, int32(3301), mons[0].Msgr2Port)
+		assert.Equal(t, int32(3301), c.ClusterInfo.InternalMonitors["a"].Msgr2Port)
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// updateMonQuorum remembers the rank of the mons in the monmap and the mons in quorum from the
+// quorum status of the last health check
+func (c *Cluster) updateMonQuorum(quorumStatus cephclient.MonStatusResponse) {
+	c.monRanks = map[string]int{}
+	c.monsInQuorum = sets.New[string]()
+	for _, mon := range quorumStatus.MonMap.Mons {
+		c.monRanks[mon.Name] = mon.Rank
+		if monInQuorum(mon, quorumStatus.Quorum) {
+			c.monsInQuorum.Insert(mon.Name)
+		}
+	}
+}
+
+// getMonFailoverTimes returns the time each mon was created to replace a failed over mon, loaded
+// from the mon endpoints configmap if not known yet
+func (c *Cluster) getMonFailoverTimes() (map[string]metav1.Time, error) {
+	if c.monFailoverTimes != nil {
+		return c.monFailoverTimes, nil
+	}
+	failoverTimes := map[string]metav1.Time{}
+	configmap, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(c.ClusterInfo.Context, EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			c.monFailoverTimes = failoverTimes
+			return failoverTimes, nil
+		}
+		return nil, errors.Wrap(err, "failed to get mon endpoints configmap")
+	}
+	if val, ok := configmap.Data[EndpointMonStatusKey]; ok && val != "" {
+		var status []cephv1.MonStatus
+		if err := json.Unmarshal([]byte(val), &status); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the status of the mons %q", val)
+		}
+		for _, mon := range status {
+			if mon.LastFailoverTime != nil {
+				failoverTimes[mon.Name] = *mon.LastFailoverTime
+			}
+		}
+	}
+	c.monFailoverTimes = failoverTimes
+	return failoverTimes, nil
+}
+
+// recordMonFailoverTime remembers that the mon was created to replace a failed over mon
+func (c *Cluster) recordMonFailoverTime(name string) {
+	failoverTimes, err := c.getMonFailoverTimes()
+	if err != nil {
+		logger.Warningf("failed to load the failover times of the mons, only the failover of mon %q is kept. %v", name, err)
+		failoverTimes = map[string]metav1.Time{}
+		c.monFailoverTimes = failoverTimes
+	}
+	failoverTimes[name] = metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+}
+
+// monStatus returns the status of the mons of the cluster info, sorted by name. The rank and the
+// quorum come from the last health check, the mons are reported out of the monmap until then.
+func (c *Cluster) monStatus() ([]cephv1.MonStatus, error) {
+	failoverTimes, err := c.getMonFailoverTimes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the failover times of the mons")
+	}
+	var status []cephv1.MonStatus
+	for _, name := range sets.List(sets.KeySet(c.ClusterInfo.InternalMonitors)) {
+		monInfo := c.ClusterInfo.InternalMonitors[name]
+		mon := cephv1.MonStatus{Name: name, Rank: -1, Address: monInfo.Endpoint, InQuorum: !monInfo.OutOfQuorum}
+		if rank, ok := c.monRanks[name]; ok {
+			mon.Rank = rank
+		}
+		if c.monsInQuorum != nil {
+			mon.InQuorum = c.monsInQuorum.Has(name)
+		}
+		if schedule := c.mapping.Schedule[name]; schedule != nil {
+			mon.Node = schedule.Name
+			mon.Zone = schedule.Zone
+		}
+		if failoverTime, ok := failoverTimes[name]; ok {
+			mon.LastFailoverTime = &failoverTime
+		}
+		status = append(status, mon)
+	}
+	return status, nil
+}
+
+// reportMonStatus publishes the status of the mons on the CephCluster and in the mon endpoints
+// configmap when it changed
+func (c *Cluster) reportMonStatus() {
+	status, err := c.monStatus()
+	if err != nil {
+		logger.Warningf("failed to get the status of the mons. %v", err)
+		return
+	}
+	if reflect.DeepEqual(status, c.monStatusReported) {
+		return
+	}
+
+	if err := c.saveMonStatus(status); err != nil {
+		logger.Warningf("failed to save the status of the mons in the mon endpoints configmap. %v", err)
+		return
+	}
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the status of the mons. %v", err)
+		return
+	}
+	cephCluster.Status.Mons = status
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the status of the mons in the CephCluster status. %v", err)
+		return
+	}
+	c.monStatusReported = status
+}
+
+// saveMonStatus updates the status of the mons in the mon endpoints configmap
+func (c *Cluster) saveMonStatus(status []cephv1.MonStatus) error {
+	configmap, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(c.ClusterInfo.Context, EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get mon endpoints configmap")
+	}
+	rawStatus, err := json.Marshal(status)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the status of the mons")
+	}
+	if configmap.Data[EndpointMonStatusKey] == string(rawStatus) {
+		return nil
+	}
+	if configmap.Data == nil {
+		configmap.Data = map[string]string{}
+	}
+	configmap.Data[EndpointMonStatusKey] = string(rawStatus)
+	if _, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Update(c.ClusterInfo.Context, configmap, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update mon endpoints configmap")
+	}
+	return nil
+}
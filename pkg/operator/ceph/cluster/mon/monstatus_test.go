@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReportMonStatus(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clientset := k8sfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: EndpointConfigMapName, Namespace: "ns"},
+		Data:       map[string]string{EndpointMonStatusKey: `[{"name":"c","rank":2,"inQuorum":true,"lastFailoverTime":"2025-01-02T03:04:05Z"}]`},
+	})
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   "ns",
+		context:     &clusterd.Context{Client: cl, Clientset: clientset},
+		mapping: &controller.Mapping{Schedule: map[string]*controller.MonScheduleInfo{
+			"a": {Name: "node1", Zone: "zone1"},
+			"b": nil,
+		}},
+	}
+	clusterInfo.InternalMonitors["b"].OutOfQuorum = true
+
+	// the mons are reported out of the monmap before the first health check
+	status, err := c.monStatus()
+	require.NoError(t, err)
+	require.Len(t, status, 3)
+	assert.Equal(t, cephv1.MonStatus{Name: "a", Rank: -1, Address: "1.2.3.1:3300", InQuorum: true, Node: "node1", Zone: "zone1"}, status[0])
+	assert.Equal(t, cephv1.MonStatus{Name: "b", Rank: -1, Address: "1.2.3.2:3300"}, status[1])
+	// the failover time of the mon is loaded from the mon endpoints
+	require.NotNil(t, status[2].LastFailoverTime)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status[2].LastFailoverTime.UTC().Format("2006-01-02T15:04:05Z"))
+
+	// the rank and the quorum come from the health check
+	quorumStatus := cephclient.MonStatusResponse{Quorum: []int{0, 2}}
+	quorumStatus.MonMap.Mons = []cephclient.MonMapEntry{{Name: "a", Rank: 0}, {Name: "b", Rank: 1}, {Name: "c", Rank: 2}}
+	c.updateMonQuorum(quorumStatus)
+	c.recordMonFailoverTime("b")
+	c.reportMonStatus()
+
+	require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+	require.Len(t, cephCluster.Status.Mons, 3)
+	assert.Equal(t, 0, cephCluster.Status.Mons[0].Rank)
+	assert.True(t, cephCluster.Status.Mons[0].InQuorum)
+	assert.Equal(t, 1, cephCluster.Status.Mons[1].Rank)
+	assert.False(t, cephCluster.Status.Mons[1].InQuorum)
+	assert.NotNil(t, cephCluster.Status.Mons[1].LastFailoverTime)
+	assert.Nil(t, cephCluster.Status.Mons[0].LastFailoverTime)
+
+	// the status is saved in the mon endpoints for the tools
+	cm, err := clientset.CoreV1().ConfigMaps("ns").Get(ctx, EndpointConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	var saved []cephv1.MonStatus
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[EndpointMonStatusKey]), &saved))
+	assert.Equal(t, cephCluster.Status.Mons, saved)
+
+	// the failover times are loaded again after an operator restart
+	c.monFailoverTimes = nil
+	failoverTimes, err := c.getMonFailoverTimes()
+	require.NoError(t, err)
+	assert.Contains(t, failoverTimes, "b")
+	assert.Contains(t, failoverTimes, "c")
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// migrateMonsToMsgr2 moves the existing mons that still listen on the msgr1 port to the msgr2 port
+// when the cluster requires msgr2. New mons are already created on the msgr2 port, but the port of
+// the existing mons is kept from their endpoint, which would keep the msgr1 listener forever.
+// The addresses in the monmap are updated first while the mons still listen on both ports, then the
+// services, deployments and mon endpoints are updated with the new port when the mons are started.
+func (c *Cluster) migrateMonsToMsgr2(mons []*monConfig) error {
+	if !c.spec.RequireMsgr2() {
+		return nil
+	}
+
+	for _, m := range mons {
+		monInfo, ok := c.ClusterInfo.InternalMonitors[m.DaemonName]
+		if !ok || m.Port == m.msgr2Port() || m.PublicIP == "" {
+			// the mon is new or already on the msgr2 port
+			continue
+		}
+
+		endpoint := net.JoinHostPort(m.PublicIP, strconv.Itoa(int(m.msgr2Port())))
+		logger.Infof("moving mon %q from the msgr1 port %d to the msgr2 endpoint %q", m.DaemonName, m.Port, endpoint)
+		if err := cephclient.SetMonAddrs(c.context, c.ClusterInfo, m.DaemonName, fmt.Sprintf("[v2:%s]", endpoint)); err != nil {
+			return errors.Wrapf(err, "failed to move mon %q to the msgr2 port", m.DaemonName)
+		}
+		m.Port = m.msgr2Port()
+		monInfo.Endpoint = endpoint
+	}
+
+	return nil
+}
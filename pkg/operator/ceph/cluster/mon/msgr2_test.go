@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateMonsToMsgr2(t *testing.T) {
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("%s %v", command, args)
+			if args[0] == "mon" && args[1] == "set-addrs" {
+				commands = append(commands, fmt.Sprintf("%s %s", args[2], args[3]))
+				return "", nil
+			}
+			return "", fmt.Errorf("unrecognized command: %s %v", command, args)
+		},
+	}
+	newCluster := func(spec cephv1.ClusterSpec) (*Cluster, []*monConfig) {
+		c := &Cluster{spec: spec, context: &clusterd.Context{Executor: executor}}
+		c.ClusterInfo = clienttest.CreateTestClusterInfo(3)
+		c.ClusterInfo.InternalMonitors["a"].Endpoint = "1.2.3.1:6789"
+		c.ClusterInfo.InternalMonitors["b"].Endpoint = "1.2.3.2:6790"
+		mons := []*monConfig{
+			{DaemonName: "a", PublicIP: "1.2.3.1", Port: DefaultMsgr1Port},
+			{DaemonName: "b", PublicIP: "1.2.3.2", Port: 6790},
+			{DaemonName: "c", PublicIP: "1.2.3.3", Port: DefaultMsgr2Port},
+		}
+		return c, mons
+	}
+
+	t.Run("msgr2 not required", func(t *testing.T) {
+		commands = []string{}
+		c, mons := newCluster(cephv1.ClusterSpec{})
+		err := c.migrateMonsToMsgr2(mons)
+		assert.NoError(t, err)
+		assert.Empty(t, commands)
+		assert.Equal(t, DefaultMsgr1Port, mons[0].Port)
+		assert.Equal(t, "1.2.3.1:6789", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+	})
+
+	t.Run("msgr1 mons moved to msgr2", func(t *testing.T) {
+		commands = []string{}
+		c, mons := newCluster(cephv1.ClusterSpec{
+			Network: cephv1.NetworkSpec{Connections: &cephv1.ConnectionsSpec{RequireMsgr2: true}},
+		})
+		err := c.migrateMonsToMsgr2(mons)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a [v2:1.2.3.1:3300]", "b [v2:1.2.3.2:3300]"}, commands)
+		for _, m := range mons {
+			assert.Equal(t, DefaultMsgr2Port, m.Port)
+		}
+		assert.Equal(t, "1.2.3.1:3300", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+		assert.Equal(t, "1.2.3.2:3300", c.ClusterInfo.InternalMonitors["b"].Endpoint)
+		assert.Equal(t, "1.2.3.3:3300", c.ClusterInfo.InternalMonitors["c"].Endpoint)
+
+		// the mons are not moved again
+		commands = []string{}
+		err = c.migrateMonsToMsgr2(mons)
+		assert.NoError(t, err)
+		assert.Empty(t, commands)
+	})
+
+	t.Run("ipv6 mon moved to msgr2", func(t *testing.T) {
+		commands = []string{}
+		c, mons := newCluster(cephv1.ClusterSpec{
+			Network: cephv1.NetworkSpec{Connections: &cephv1.ConnectionsSpec{Encryption: &cephv1.EncryptionSpec{Enabled: true}}},
+		})
+		c.ClusterInfo.InternalMonitors["a"].Endpoint = "[fd00::1]:6789"
+		mons[0].PublicIP = "fd00::1"
+		err := c.migrateMonsToMsgr2(mons[0:1])
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a [v2:[fd00::1]:3300]"}, commands)
+		assert.Equal(t, "[fd00::1]:3300", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+	})
+
+	t.Run("failure to update the monmap", func(t *testing.T) {
+		c, mons := newCluster(cephv1.ClusterSpec{
+			Network: cephv1.NetworkSpec{Connections: &cephv1.ConnectionsSpec{RequireMsgr2: true}},
+		})
+		c.context.Executor = &exectest.MockExecutor{
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				return "", fmt.Errorf("mons not in quorum")
+			},
+		}
+		err := c.migrateMonsToMsgr2(mons[0:1])
+		assert.Error(t, err)
+		// the mon keeps its msgr1 port until the monmap is updated
+		assert.Equal(t, DefaultMsgr1Port, mons[0].Port)
+		assert.Equal(t, "1.2.3.1:6789", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+	})
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// expandMonPVC requests the expansion of the PVC of a mon when the storage request of the mon
+// volume claim template was increased in the CephCluster, if the storage class of the PVC allows it
+func (c *Cluster) expandMonPVC(m *monConfig) error {
+	if c.monVolumeClaimTemplate(m) == nil {
+		return nil
+	}
+	desiredPVC, err := c.makeDeploymentPVC(m, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make mon %q pvc", m.ResourceName)
+	}
+
+	existingPVC, err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch pvc for mon %q", m.ResourceName)
+	}
+	_ = k8sutil.ExpandPVCIfRequired(c.ClusterInfo.Context, c.context.Client, desiredPVC, existingPVC)
+	return nil
+}
+
+// monVolumeExpansionStatus returns the progress of the expansion of the PVC of a mon, or nil if the
+// mon has no PVC or if the capacity of its PVC is already the storage request of the template
+func (c *Cluster) monVolumeExpansionStatus(m *monConfig) (*cephv1.MonVolumeExpansionStatus, error) {
+	if c.monVolumeClaimTemplate(m) == nil {
+		return nil, nil
+	}
+	desiredPVC, err := c.makeDeploymentPVC(m, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to make mon %q pvc", m.ResourceName)
+	}
+	desiredSize, ok := desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil, nil
+	}
+
+	pvc, err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			// the mon is running on the host path
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to fetch pvc for mon %q", m.ResourceName)
+	}
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok || capacity.Cmp(desiredSize) >= 0 {
+		// the volume is not bound yet, or is already expanded
+		return nil, nil
+	}
+
+	status := &cephv1.MonVolumeExpansionStatus{
+		Name:          m.DaemonName,
+		PVC:           pvc.Name,
+		RequestedSize: desiredSize.String(),
+		CurrentSize:   capacity.String(),
+		Phase:         cephv1.MonVolumeExpansionResizing,
+	}
+	requestedSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if requestedSize.Cmp(desiredSize) < 0 {
+		status.Phase = cephv1.MonVolumeExpansionBlocked
+		status.Message = c.monVolumeExpansionBlockedReason(pvc)
+		return status, nil
+	}
+
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case corev1.PersistentVolumeClaimControllerResizeError, corev1.PersistentVolumeClaimNodeResizeError:
+			status.Phase = cephv1.MonVolumeExpansionFailed
+			status.Message = condition.Message
+			return status, nil
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			status.Phase = cephv1.MonVolumeExpansionFileSystemResizePending
+			status.Message = condition.Message
+		case corev1.PersistentVolumeClaimResizing:
+			status.Message = condition.Message
+		}
+	}
+	return status, nil
+}
+
+// monVolumeExpansionBlockedReason returns why the PVC of a mon was not expanded to the storage
+// request of the template
+func (c *Cluster) monVolumeExpansionBlockedReason(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "the pvc has no storage class"
+	}
+	storageClass := &storagev1.StorageClass{}
+	err := c.context.Client.Get(c.ClusterInfo.Context, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageClass)
+	if err != nil {
+		return fmt.Sprintf("failed to get storage class %q. %v", *pvc.Spec.StorageClassName, err)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return fmt.Sprintf("storage class %q does not allow volume expansion", storageClass.Name)
+	}
+	return "the pvc was not updated yet with the storage request, see the operator log"
+}
+
+// monVolumeExpansion returns the progress of the expansion of the mon PVCs to report on the
+// CephCluster, or nil if no mon PVC is smaller than the storage request of the template
+func (c *Cluster) monVolumeExpansion() []cephv1.MonVolumeExpansionStatus {
+	var expansions []cephv1.MonVolumeExpansionStatus
+	for _, m := range c.clusterInfoToMonConfig() {
+		status, err := c.monVolumeExpansionStatus(m)
+		if err != nil {
+			logger.Warningf("failed to check the expansion of the pvc of mon %q. %v", m.DaemonName, err)
+			continue
+		}
+		if status != nil {
+			expansions = append(expansions, *status)
+		}
+	}
+	sort.Slice(expansions, func(i, j int) bool { return expansions[i].Name < expansions[j].Name })
+	return expansions
+}
+
+// reportMonVolumeExpansion publishes the progress of the expansion of the mon PVCs in the
+// CephCluster status when it changed since it was last reported
+func (c *Cluster) reportMonVolumeExpansion() {
+	expansions := c.monVolumeExpansion()
+	if reflect.DeepEqual(expansions, c.volumeExpansionReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the mon pvc expansion. %v", err)
+		return
+	}
+	cephCluster.Status.MonVolumeExpansion = expansions
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the mon pvc expansion in the CephCluster status. %v", err)
+		return
+	}
+	for _, expansion := range expansions {
+		logger.Infof("expansion of the pvc of mon %q to %s: %s. %s", expansion.Name, expansion.RequestedSize, expansion.Phase, expansion.Message)
+	}
+	c.volumeExpansionReported = expansions
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMonVolumeExpansion(t *testing.T) {
+	newPVC := func(name, storageClass, request, capacity string, conditions ...corev1.PersistentVolumeClaimCondition) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(request)},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity:   corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+				Conditions: conditions,
+			},
+		}
+	}
+	fixed := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}}
+
+	newExpansionTestCluster := func(t *testing.T, pvcs ...*corev1.PersistentVolumeClaim) (*Cluster, *cephv1.CephCluster) {
+		clusterInfo := clienttest.CreateTestClusterInfo(3)
+		nsName := clusterInfo.NamespacedName()
+		cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+		scheme := runtime.NewScheme()
+		require.NoError(t, cephv1.AddToScheme(scheme))
+		require.NoError(t, storagev1.AddToScheme(scheme))
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster, fixed).WithStatusSubresource(cephCluster).Build()
+		clientset := k8sfake.NewSimpleClientset()
+		for _, pvc := range pvcs {
+			_, err := clientset.CoreV1().PersistentVolumeClaims("default").Create(context.TODO(), pvc, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+
+		c := &Cluster{
+			ClusterInfo: clusterInfo,
+			Namespace:   "default",
+			context:     &clusterd.Context{Client: cl, Clientset: clientset},
+			ownerInfo:   cephclient.NewMinimumOwnerInfoWithOwnerRef(),
+			mapping:     &opcontroller.Mapping{Schedule: map[string]*opcontroller.MonScheduleInfo{}},
+		}
+		c.spec.Mon.VolumeClaimTemplate = &cephv1.VolumeClaimTemplate{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+		}
+		return c, cephCluster
+	}
+
+	t.Run("no template", func(t *testing.T) {
+		c, _ := newExpansionTestCluster(t)
+		c.spec.Mon.VolumeClaimTemplate = nil
+		assert.Empty(t, c.monVolumeExpansion())
+	})
+
+	t.Run("progress of each mon", func(t *testing.T) {
+		c, _ := newExpansionTestCluster(t,
+			// mon a is already expanded
+			newPVC("rook-ceph-mon-a", "expandable", "10Gi", "10Gi"),
+			// mon b cannot be expanded by its storage class
+			newPVC("rook-ceph-mon-b", "fixed", "5Gi", "5Gi"),
+			// mon c waits for its filesystem to be resized
+			newPVC("rook-ceph-mon-c", "expandable", "10Gi", "5Gi", corev1.PersistentVolumeClaimCondition{
+				Type:    corev1.PersistentVolumeClaimFileSystemResizePending,
+				Status:  corev1.ConditionTrue,
+				Message: "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+			}),
+		)
+
+		expansions := c.monVolumeExpansion()
+		require.Len(t, expansions, 2)
+		assert.Equal(t, cephv1.MonVolumeExpansionStatus{
+			Name:          "b",
+			PVC:           "rook-ceph-mon-b",
+			RequestedSize: "10Gi",
+			CurrentSize:   "5Gi",
+			Phase:         cephv1.MonVolumeExpansionBlocked,
+			Message:       `storage class "fixed" does not allow volume expansion`,
+		}, expansions[0])
+		assert.Equal(t, "c", expansions[1].Name)
+		assert.Equal(t, cephv1.MonVolumeExpansionFileSystemResizePending, expansions[1].Phase)
+		assert.Contains(t, expansions[1].Message, "file system resize")
+	})
+
+	t.Run("resize failure", func(t *testing.T) {
+		c, _ := newExpansionTestCluster(t,
+			newPVC("rook-ceph-mon-a", "expandable", "10Gi", "5Gi",
+				corev1.PersistentVolumeClaimCondition{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue},
+				corev1.PersistentVolumeClaimCondition{Type: corev1.PersistentVolumeClaimControllerResizeError, Status: corev1.ConditionTrue, Message: "out of space"},
+			),
+		)
+		expansions := c.monVolumeExpansion()
+		require.Len(t, expansions, 1)
+		assert.Equal(t, cephv1.MonVolumeExpansionFailed, expansions[0].Phase)
+		assert.Equal(t, "out of space", expansions[0].Message)
+	})
+
+	t.Run("status is reported when changed", func(t *testing.T) {
+		c, cephCluster := newExpansionTestCluster(t, newPVC("rook-ceph-mon-a", "expandable", "10Gi", "5Gi"))
+		nsName := c.ClusterInfo.NamespacedName()
+
+		c.reportMonVolumeExpansion()
+		require.NoError(t, c.context.Client.Get(context.TODO(), nsName, cephCluster))
+		require.Len(t, cephCluster.Status.MonVolumeExpansion, 1)
+		assert.Equal(t, cephv1.MonVolumeExpansionResizing, cephCluster.Status.MonVolumeExpansion[0].Phase)
+
+		// the volume is expanded
+		pvc, err := c.context.Clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "rook-ceph-mon-a", metav1.GetOptions{})
+		require.NoError(t, err)
+		pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("10Gi")
+		_, err = c.context.Clientset.CoreV1().PersistentVolumeClaims("default").Update(context.TODO(), pvc, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		c.reportMonVolumeExpansion()
+		require.NoError(t, c.context.Client.Get(context.TODO(), nsName, cephCluster))
+		assert.Empty(t, cephCluster.Status.MonVolumeExpansion)
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// migrateNextMonToPVC fails over the first mon still on the host path to a new mon on a PVC when
+// the migration is enabled. A single mon is migrated per health check, and only once all the
+// desired mons are in quorum so that the quorum is verified between the steps. It returns whether
+// a mon was failed over.
+func (c *Cluster) migrateNextMonToPVC(allMonsInQuorum bool, monCount, desiredMonCount int) (bool, error) {
+	if !c.spec.Mon.MigrateHostPathToPVC {
+		c.pvcMigration = nil
+		return false, nil
+	}
+	mons, err := c.hostPathMons()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to find the mons on the host path")
+	}
+	if len(mons) == 0 {
+		if c.pvcMigration != nil {
+			logger.Info("all the mons were migrated from the host path to PVCs")
+			c.recordEvent(v1.EventTypeNormal, MonPVCMigrationReason, "all the mons were migrated from the host path to PVCs")
+		}
+		c.pvcMigration = nil
+		return false, nil
+	}
+
+	status := &cephv1.MonPVCMigrationStatus{HostPathMons: mons}
+	c.pvcMigration = status
+	name := mons[0]
+	if !allMonsInQuorum || monCount != desiredMonCount {
+		status.Message = fmt.Sprintf("waiting for the %d mons to be in quorum before migrating mon %q", desiredMonCount, name)
+		logger.Infof("migration of the mons to PVCs is %s", status.Message)
+		return false, nil
+	}
+
+	if controller.DeferForRecovery(c.context, c.ClusterInfo, c.spec.HealthCheck.RecoveryDeferral, controller.MaintenanceMonPVCMigration) {
+		status.Message = fmt.Sprintf("the migration of mon %q to a PVC is deferred while the cluster is recovering", name)
+		return false, nil
+	}
+
+	logger.Infof("migrating mon %q from the host path to a PVC", name)
+	c.recordEvent(v1.EventTypeNormal, MonPVCMigrationReason, "migrating mon %q from the host path to a PVC", name)
+	if !c.failMon(monCount, desiredMonCount, name) {
+		status.Message = fmt.Sprintf("the failover of mon %q to a PVC is deferred", name)
+		return false, nil
+	}
+	status.Message = fmt.Sprintf("mon %q was failed over to a PVC", name)
+	return true, nil
+}
+
+// hostPathMons returns the mons that store their data on the host path although a volume claim
+// template applies to them, sorted by name
+func (c *Cluster) hostPathMons() ([]string, error) {
+	mons := []string{}
+	for _, m := range c.clusterInfoToMonConfig() {
+		if c.monVolumeClaimTemplate(m) == nil {
+			continue
+		}
+		d, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get the deployment of mon %q", m.DaemonName)
+		}
+		if !controller.DaemonVolumesContainsPVC(d.Spec.Template.Spec.Volumes) {
+			mons = append(mons, m.DaemonName)
+		}
+	}
+	sort.Strings(mons)
+	return mons, nil
+}
+
+// reportMonPVCMigration publishes the progress of the migration of the mons to PVCs on the
+// CephCluster status when it changed
+func (c *Cluster) reportMonPVCMigration() {
+	if reflect.DeepEqual(c.pvcMigration, c.pvcMigrationReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the migration of the mons to PVCs. %v", err)
+		return
+	}
+	cephCluster.Status.MonPVCMigration = c.pvcMigration
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the migration of the mons to PVCs in the CephCluster status. %v", err)
+		return
+	}
+	c.pvcMigrationReported = c.pvcMigration
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMigrateMonsToPVC(t *testing.T) {
+	newMigrationTestCluster := func(t *testing.T, pvcMons ...string) (*Cluster, *cephv1.CephCluster) {
+		clusterInfo := clienttest.CreateTestClusterInfo(3)
+		nsName := clusterInfo.NamespacedName()
+		cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+		scheme := runtime.NewScheme()
+		require.NoError(t, cephv1.AddToScheme(scheme))
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+
+		clientset := k8sfake.NewSimpleClientset()
+		onPVC := map[string]bool{}
+		for _, name := range pvcMons {
+			onPVC[name] = true
+		}
+		for _, name := range []string{"a", "b", "c"} {
+			d := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: resourceName(name), Namespace: "default"}}
+			if onPVC[name] {
+				d.Spec.Template.Spec.Volumes = []corev1.Volume{opcontroller.DaemonVolumesDataPVC(resourceName(name))}
+			} else {
+				d.Spec.Template.Spec.Volumes = []corev1.Volume{{
+					Name:         "ceph-daemon-data",
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/rook/mon-" + name}},
+				}}
+			}
+			_, err := clientset.AppsV1().Deployments("default").Create(context.TODO(), d, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+
+		c := &Cluster{
+			ClusterInfo:    clusterInfo,
+			Namespace:      "default",
+			context:        &clusterd.Context{Client: cl, Clientset: clientset, ConfigDir: t.TempDir()},
+			ownerInfo:      cephclient.NewMinimumOwnerInfoWithOwnerRef(),
+			mapping:        &opcontroller.Mapping{Schedule: map[string]*opcontroller.MonScheduleInfo{}},
+			monsToFailover: map[string]*monConfig{},
+		}
+		c.spec.Mon.VolumeClaimTemplate = &cephv1.VolumeClaimTemplate{}
+		c.spec.Mon.MigrateHostPathToPVC = true
+		return c, cephCluster
+	}
+
+	t.Run("migration disabled", func(t *testing.T) {
+		c, _ := newMigrationTestCluster(t, "a")
+		c.spec.Mon.MigrateHostPathToPVC = false
+		migrated, err := c.migrateNextMonToPVC(true, 3, 3)
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+		assert.Nil(t, c.pvcMigration)
+	})
+
+	t.Run("waiting for the quorum", func(t *testing.T) {
+		c, _ := newMigrationTestCluster(t, "a")
+		migrated, err := c.migrateNextMonToPVC(false, 3, 3)
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+		require.NotNil(t, c.pvcMigration)
+		assert.Equal(t, []string{"b", "c"}, c.pvcMigration.HostPathMons)
+		assert.Equal(t, `waiting for the 3 mons to be in quorum before migrating mon "b"`, c.pvcMigration.Message)
+
+		// a mon missing from the quorum also waits
+		migrated, err = c.migrateNextMonToPVC(true, 2, 3)
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+	})
+
+	t.Run("next mon failed over", func(t *testing.T) {
+		c, _ := newMigrationTestCluster(t, "a")
+		// the failover is deferred by the disruption budget, so that it is not started in the test
+		k8sutil.SetDisruptionBudget(c.Namespace, 1, nil)
+		defer k8sutil.ResetDisruptionBudget(c.Namespace)
+		release, err := k8sutil.AcquireDisruption(c.Namespace, k8sutil.DisruptionOSD, "osd")
+		require.NoError(t, err)
+		defer release()
+
+		migrated, err := c.migrateNextMonToPVC(true, 3, 3)
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+		assert.Equal(t, `the failover of mon "b" to a PVC is deferred`, c.pvcMigration.Message)
+		assert.Contains(t, c.failoverBackoff.deferredMessage, `"mon.b"`)
+	})
+
+	t.Run("migration completed", func(t *testing.T) {
+		c, cephCluster := newMigrationTestCluster(t, "b")
+		_, err := c.migrateNextMonToPVC(false, 3, 3)
+		assert.NoError(t, err)
+		c.reportMonPVCMigration()
+		require.NoError(t, c.context.Client.Get(context.TODO(), c.ClusterInfo.NamespacedName(), cephCluster))
+		require.NotNil(t, cephCluster.Status.MonPVCMigration)
+		assert.Equal(t, []string{"a", "c"}, cephCluster.Status.MonPVCMigration.HostPathMons)
+
+		for _, name := range []string{"a", "c"} {
+			d, err := c.context.Clientset.AppsV1().Deployments("default").Get(context.TODO(), resourceName(name), metav1.GetOptions{})
+			require.NoError(t, err)
+			d.Spec.Template.Spec.Volumes = []corev1.Volume{opcontroller.DaemonVolumesDataPVC(resourceName(name))}
+			_, err = c.context.Clientset.AppsV1().Deployments("default").Update(context.TODO(), d, metav1.UpdateOptions{})
+			require.NoError(t, err)
+		}
+		migrated, err := c.migrateNextMonToPVC(true, 3, 3)
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+		assert.Nil(t, c.pvcMigration)
+		c.reportMonPVCMigration()
+		require.NoError(t, c.context.Client.Get(context.TODO(), c.ClusterInfo.NamespacedName(), cephCluster))
+		assert.Nil(t, cephCluster.Status.MonPVCMigration)
+	})
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// restoreQuorumAnnotation is set on the CephCluster with the name of the surviving mon to
+	// restore the quorum from when the quorum is lost
+	restoreQuorumAnnotation       = "ceph.rook.io/restore-mon-quorum"
+	monQuorumRestoreContainerName = "restore-mon-quorum"
+)
+
+//go:embed quorumrestore.sh
+var monQuorumRestoreScript string
+
+var (
+	// quorumRestoreWaitInterval and quorumRestoreWaitAttempts bound the wait for the surviving mon
+	// to run the init container restoring its monmap
+	quorumRestoreWaitInterval = 5 * time.Second
+	quorumRestoreWaitAttempts = 60
+)
+
+// restoreQuorumIfRequested restores the quorum from the mon named in the restore annotation of the
+// CephCluster. It is only called while the quorum is lost, and returns whether the quorum was
+// restored.
+func (c *Cluster) restoreQuorumIfRequested(ctx context.Context) (bool, error) {
+	nsName := c.ClusterInfo.NamespacedName()
+	cephCluster, err := c.context.RookClientset.CephV1().CephClusters(nsName.Namespace).Get(ctx, nsName.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get the CephCluster to check the mon quorum restore request")
+	}
+	survivor := strings.TrimSpace(cephCluster.Annotations[restoreQuorumAnnotation])
+	if survivor == "" {
+		return false, nil
+	}
+
+	if err := c.restoreQuorum(survivor); err != nil {
+		return false, errors.Wrapf(err, "failed to restore the mon quorum from mon %q", survivor)
+	}
+
+	// the quorum must not be restored again the next time it is lost without a new request
+	delete(cephCluster.Annotations, restoreQuorumAnnotation)
+	if _, err := c.context.RookClientset.CephV1().CephClusters(nsName.Namespace).Update(ctx, cephCluster, metav1.UpdateOptions{}); err != nil {
+		return true, errors.Wrapf(err, "failed to remove the %q annotation from the CephCluster", restoreQuorumAnnotation)
+	}
+	return true, nil
+}
+
+// restoreQuorum removes all the other mons from the monmap of the surviving mon so that it forms a
+// quorum on its own, then removes the other mons. The mons are added back to the desired count by
+// the next health checks.
+func (c *Cluster) restoreQuorum(survivor string) error {
+	if _, ok := c.ClusterInfo.InternalMonitors[survivor]; !ok {
+		return errors.Errorf("mon %q is not a mon of the cluster", survivor)
+	}
+	var survivorConfig *monConfig
+	removedMons := []string{}
+	for _, m := range c.clusterInfoToMonConfig() {
+		if m.DaemonName == survivor {
+			survivorConfig = m
+			continue
+		}
+		removedMons = append(removedMons, m.DaemonName)
+	}
+	sort.Strings(removedMons)
+	logger.Warningf("restoring the mon quorum from mon %q, removing the mons %v", survivor, removedMons)
+
+	// Restart the surviving mon with the init container removing the other mons from its monmap. The
+	// deployment is updated directly since the ok-to-stop checks of the regular update cannot succeed
+	// without quorum, and the restart budget must not delay the recovery.
+	ctx := c.ClusterInfo.Context
+	restoreContainer := c.makeMonQuorumRestoreInitContainer(survivorConfig, removedMons)
+	err := c.updateMonPodSpecForRecovery(ctx, survivor, func(podSpec *corev1.PodSpec) {
+		podSpec.InitContainers = append(withoutQuorumRestoreInitContainer(podSpec.InitContainers), restoreContainer)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to restart mon %q with the restored monmap", survivor)
+	}
+
+	// the other mons must not be removed unless the monmap of the surviving mon was restored
+	if err := c.waitForQuorumRestore(ctx, survivor, removedMons); err != nil {
+		return errors.Wrapf(err, "the monmap of mon %q was not restored, no mon was removed", survivor)
+	}
+
+	for _, name := range removedMons {
+		if err := c.removeMonWithOptionalQuorum(name, false); err != nil {
+			return errors.Wrapf(err, "failed to remove mon %q", name)
+		}
+		delete(c.monTimeoutList, name)
+	}
+
+	// the init container is not needed anymore once the monmap is restored
+	err = c.updateMonPodSpecForRecovery(ctx, survivor, func(podSpec *corev1.PodSpec) {
+		podSpec.InitContainers = withoutQuorumRestoreInitContainer(podSpec.InitContainers)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update mon %q after restoring the quorum", survivor)
+	}
+	logger.Infof("restored the mon quorum from mon %q, the mons will be added back to the desired count %d", survivor, c.spec.Mon.Count)
+	return nil
+}
+
+// updateMonPodSpecForRecovery updates the pod spec of the mon deployment and restarts the mon with
+// the recovery restart reason
+func (c *Cluster) updateMonPodSpecForRecovery(ctx context.Context, monName string, update func(*corev1.PodSpec)) error {
+	name := resourceName(monName)
+	d, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get mon deployment %q", name)
+	}
+	update(&d.Spec.Template.Spec)
+	k8sutil.SetRestartReason(&d.Spec.Template, k8sutil.RestartReasonRecovery)
+	if _, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update mon deployment %q", name)
+	}
+	return nil
+}
+
+// waitForQuorumRestore waits until a pod of the surviving mon ran the init container restoring its
+// monmap successfully, which confirms that the lost mons were removed from the monmap
+func (c *Cluster) waitForQuorumRestore(ctx context.Context, survivor string, removedMons []string) error {
+	selector := fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, controller.DaemonIDLabel, survivor)
+	monsToRemove := strings.Join(removedMons, " ")
+	for i := 0; i < quorumRestoreWaitAttempts; i++ {
+		if i > 0 {
+			time.Sleep(quorumRestoreWaitInterval)
+		}
+		pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			logger.Warningf("failed to list the pods of mon %q. %v", survivor, err)
+			continue
+		}
+		for _, pod := range pods.Items {
+			if !runsQuorumRestore(pod.Spec, monsToRemove) {
+				// the pod was started before the restore was requested
+				continue
+			}
+			for _, status := range pod.Status.InitContainerStatuses {
+				if status.Name != monQuorumRestoreContainerName || status.State.Terminated == nil {
+					continue
+				}
+				if status.State.Terminated.ExitCode != 0 {
+					return errors.Errorf("init container %q of pod %q failed. %s", monQuorumRestoreContainerName, pod.Name, strings.TrimSpace(status.State.Terminated.Message))
+				}
+				logger.Infof("mon %q restored its monmap without the mons %v", survivor, removedMons)
+				return nil
+			}
+		}
+		logger.Infof("waiting for mon %q to restore its monmap", survivor)
+	}
+	return errors.Errorf("timed out waiting for mon %q to restore its monmap", survivor)
+}
+
+// runsQuorumRestore returns whether the pod spec has the init container removing the mons from the
+// monmap
+func runsQuorumRestore(podSpec corev1.PodSpec, monsToRemove string) bool {
+	for _, container := range podSpec.InitContainers {
+		if container.Name != monQuorumRestoreContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == "ROOK_MONS_TO_REMOVE" && env.Value == monsToRemove {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withoutQuorumRestoreInitContainer returns the init containers without the one restoring the monmap
+func withoutQuorumRestoreInitContainer(containers []corev1.Container) []corev1.Container {
+	result := []corev1.Container{}
+	for _, container := range containers {
+		if container.Name != monQuorumRestoreContainerName {
+			result = append(result, container)
+		}
+	}
+	return result
+}
+
+// makeMonQuorumRestoreInitContainer returns an init container that removes the lost mons from the
+// monmap of the mon the quorum is restored from
+func (c *Cluster) makeMonQuorumRestoreInitContainer(monConfig *monConfig, removedMons []string) corev1.Container {
+	// the store is modified with the same user as the mon daemon
+	securityContext := controller.CephSecurityContext()
+	if controller.CephMonRunAsRoot() {
+		securityContext = controller.DefaultContainerSecurityContext()
+	}
+	return corev1.Container{
+		Name: monQuorumRestoreContainerName,
+		Command: []string{
+			"/bin/bash",
+			"-c",
+			monQuorumRestoreScript,
+			monQuorumRestoreContainerName,
+		},
+		Args:            controller.DaemonFlags(c.ClusterInfo, &c.spec, monConfig.DaemonName),
+		Image:           c.spec.CephVersion.Image,
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
+		VolumeMounts:    controller.DaemonVolumeMounts(monConfig.DataPathMap, keyringStoreName, c.spec.DataDirHostPath),
+		SecurityContext: securityContext,
+		Env: append(
+			controller.DaemonEnvVars(&c.spec),
+			corev1.EnvVar{Name: "ROOK_MON_DATA_DIR", Value: monConfig.DataPathMap.ContainerDataDir},
+			corev1.EnvVar{Name: "ROOK_MONS_TO_REMOVE", Value: strings.Join(removedMons, " ")},
+		),
+		Resources:                c.getMonResources(monConfig),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
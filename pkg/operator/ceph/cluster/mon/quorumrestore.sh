@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+# Removes the lost mons from the monmap of the mon the quorum is restored from, so that the mon forms
+# a quorum on its own when it starts. The flags of the mon daemon are the arguments of the script so
+# that the monmap is injected with the same settings as the daemon. The script can run again after the
+# monmap was injected, the mons already removed are skipped.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+MONMAP=/tmp/monmap
+
+ceph-monstore-tool "$ROOK_MON_DATA_DIR" get monmap -- --out "$MONMAP"
+
+for mon in $ROOK_MONS_TO_REMOVE; do
+  if monmaptool --print "$MONMAP" | grep -q " mon\.${mon}$"; then
+    echo "removing mon.$mon from the monmap"
+    monmaptool "$MONMAP" --rm "$mon"
+  fi
+done
+
+ceph-mon "$@" --inject-monmap "$MONMAP"
+echo "injected the monmap without the lost mons"
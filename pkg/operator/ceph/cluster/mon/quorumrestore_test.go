@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	rookfake "github.com/rook/rook/pkg/client/clientset/versioned/fake"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newQuorumRestoreTestCluster(t *testing.T, annotations map[string]string) *Cluster {
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "auth" && args[1] == "get-or-create-key" {
+				return "{\"key\":\"mysecurekey\"}", nil
+			}
+			if args[0] == "versions" {
+				// the ok-to-stop checks cannot succeed without quorum
+				return "", errors.New("no quorum")
+			}
+			return clienttest.MonInQuorumResponse(), nil
+		},
+	}
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: clusterInfo.Namespace, Annotations: annotations},
+	}
+	context := &clusterd.Context{
+		Clientset:     test.New(t, 3),
+		RookClientset: rookfake.NewSimpleClientset(cephCluster),
+		ConfigDir:     t.TempDir(),
+		Executor:      executor,
+	}
+	c := newCluster(context, "ns", true, corev1.ResourceRequirements{})
+	c.ClusterInfo = clusterInfo
+	for _, name := range []string{"a", "b", "c"} {
+		c.mapping.Schedule[name] = &opcontroller.MonScheduleInfo{Hostname: "node-" + name}
+	}
+	return c
+}
+
+// runMonPodsOnUpdate simulates the pods started for the updated mon deployments, with their init
+// containers terminated with the exit code
+func runMonPodsOnUpdate(t *testing.T, clientset *fake.Clientset, initExitCode int32) {
+	podCount := 0
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		d := action.(k8stesting.UpdateAction).GetObject().(*apps.Deployment)
+		podCount++
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", d.Name, podCount), Namespace: d.Namespace, Labels: d.Spec.Template.Labels},
+			Spec:       d.Spec.Template.Spec,
+		}
+		for _, container := range pod.Spec.InitContainers {
+			terminated := &corev1.ContainerStateTerminated{ExitCode: initExitCode, Message: "failed to inject the monmap"}
+			pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses, corev1.ContainerStatus{Name: container.Name, State: corev1.ContainerState{Terminated: terminated}})
+		}
+		assert.NoError(t, clientset.Tracker().Add(pod))
+		// the deployment is updated by the default reactor
+		return false, nil, nil
+	})
+}
+
+func TestMonQuorumRestoreInitContainer(t *testing.T) {
+	c := newQuorumRestoreTestCluster(t, nil)
+	container := c.makeMonQuorumRestoreInitContainer(testGenMonConfig("a"), []string{"b", "c"})
+	assert.Equal(t, monQuorumRestoreContainerName, container.Name)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "ROOK_MONS_TO_REMOVE", Value: "b c"})
+	assert.Contains(t, container.Args, "--id=a")
+
+	podSpec := corev1.PodSpec{InitContainers: []corev1.Container{{Name: "init-mon-fs"}, container}}
+	assert.True(t, runsQuorumRestore(podSpec, "b c"))
+	assert.False(t, runsQuorumRestore(podSpec, "c"))
+	assert.Equal(t, []corev1.Container{{Name: "init-mon-fs"}}, withoutQuorumRestoreInitContainer(podSpec.InitContainers))
+}
+
+func TestRestoreQuorumIfRequested(t *testing.T) {
+	ctx := context.TODO()
+	originalInterval := quorumRestoreWaitInterval
+	originalAttempts := quorumRestoreWaitAttempts
+	t.Cleanup(func() {
+		quorumRestoreWaitInterval = originalInterval
+		quorumRestoreWaitAttempts = originalAttempts
+	})
+	quorumRestoreWaitInterval = 0
+	quorumRestoreWaitAttempts = 2
+
+	createMonDeployments := func(t *testing.T, c *Cluster) {
+		for _, m := range c.clusterInfoToMonConfig() {
+			d, err := c.makeDeployment(m, false)
+			assert.NoError(t, err)
+			_, err = c.context.Clientset.AppsV1().Deployments(c.Namespace).Create(ctx, d, metav1.CreateOptions{})
+			assert.NoError(t, err)
+		}
+	}
+	assertMonsNotRemoved := func(t *testing.T, c *Cluster) {
+		assert.Len(t, c.ClusterInfo.InternalMonitors, 3)
+		for _, name := range []string{"a", "b", "c"} {
+			_, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, resourceName(name), metav1.GetOptions{})
+			assert.NoError(t, err)
+		}
+		cephCluster, err := c.context.RookClientset.CephV1().CephClusters(c.ClusterInfo.Namespace).Get(ctx, c.ClusterInfo.NamespacedName().Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Contains(t, cephCluster.Annotations, restoreQuorumAnnotation)
+	}
+
+	t.Run("not requested", func(t *testing.T) {
+		c := newQuorumRestoreTestCluster(t, nil)
+		restored, err := c.restoreQuorumIfRequested(ctx)
+		assert.NoError(t, err)
+		assert.False(t, restored)
+		assert.Len(t, c.ClusterInfo.InternalMonitors, 3)
+	})
+
+	t.Run("unknown mon", func(t *testing.T) {
+		c := newQuorumRestoreTestCluster(t, map[string]string{restoreQuorumAnnotation: "z"})
+		restored, err := c.restoreQuorumIfRequested(ctx)
+		assert.Error(t, err)
+		assert.False(t, restored)
+		assert.Len(t, c.ClusterInfo.InternalMonitors, 3)
+	})
+
+	t.Run("restore init container did not run", func(t *testing.T) {
+		c := newQuorumRestoreTestCluster(t, map[string]string{restoreQuorumAnnotation: "b"})
+		createMonDeployments(t, c)
+
+		restored, err := c.restoreQuorumIfRequested(ctx)
+		assert.ErrorContains(t, err, "timed out")
+		assert.False(t, restored)
+		assertMonsNotRemoved(t, c)
+	})
+
+	t.Run("restore init container failed", func(t *testing.T) {
+		c := newQuorumRestoreTestCluster(t, map[string]string{restoreQuorumAnnotation: "b"})
+		createMonDeployments(t, c)
+		runMonPodsOnUpdate(t, c.context.Clientset.(*fake.Clientset), 1)
+
+		restored, err := c.restoreQuorumIfRequested(ctx)
+		assert.ErrorContains(t, err, "failed to inject the monmap")
+		assert.False(t, restored)
+		assertMonsNotRemoved(t, c)
+	})
+
+	t.Run("restored", func(t *testing.T) {
+		c := newQuorumRestoreTestCluster(t, map[string]string{restoreQuorumAnnotation: "b"})
+		createMonDeployments(t, c)
+		runMonPodsOnUpdate(t, c.context.Clientset.(*fake.Clientset), 0)
+		c.monTimeoutList["a"] = time.Now()
+
+		// the recovery is not delayed by an exhausted restart budget
+		k8sutil.SetRestartBudget(c.Namespace, 1)
+		t.Cleanup(func() { k8sutil.ResetRestartBudget(c.Namespace) })
+		assert.NoError(t, k8sutil.ConsumeRestartBudget(c.Namespace, AppName, k8sutil.RestartReasonSpecChange))
+
+		restored, err := c.restoreQuorumIfRequested(ctx)
+		assert.NoError(t, err)
+		assert.True(t, restored)
+
+		// the surviving mon ran the init container, then is restarted without it
+		pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, pods.Items, 2)
+		d, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, resourceName("b"), metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.False(t, runsQuorumRestore(d.Spec.Template.Spec, "a c"))
+		assert.Equal(t, k8sutil.RestartReasonRecovery, d.Spec.Template.Annotations[k8sutil.RestartReasonAnnotation])
+
+		// the other mons are removed
+		assert.Len(t, c.ClusterInfo.InternalMonitors, 1)
+		assert.Contains(t, c.ClusterInfo.InternalMonitors, "b")
+		assert.Empty(t, c.monTimeoutList)
+		for _, name := range []string{"a", "c"} {
+			_, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(ctx, resourceName(name), metav1.GetOptions{})
+			assert.True(t, kerrors.IsNotFound(err))
+		}
+
+		// the request is removed
+		cephCluster, err := c.context.RookClientset.CephV1().CephClusters(c.ClusterInfo.Namespace).Get(ctx, c.ClusterInfo.NamespacedName().Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NotContains(t, cephCluster.Annotations, restoreQuorumAnnotation)
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkMonScheduling simulates the scheduling of the new mons on the nodes before their canaries are
+// created. When the nodes or zones to schedule the new mons are missing, the MonSchedulingBlocked
+// condition lists them and an error is returned instead of waiting for canaries that stay pending.
+func (c *Cluster) checkMonScheduling(newMons []*monConfig) error {
+	nodes, err := c.context.Clientset.CoreV1().Nodes().List(c.ClusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		// the canaries still check the scheduling if the nodes cannot be listed
+		logger.Warningf("failed to list the nodes to check the scheduling of the new mons. %v", err)
+		return nil
+	}
+	monNodes, err := c.getMonNodes()
+	if err != nil {
+		logger.Warningf("failed to find the nodes of the mons to check the scheduling of the new mons. %v", err)
+		return nil
+	}
+
+	message := c.simulateMonScheduling(nodes.Items, monNodes, newMons)
+	c.reportMonSchedulingBlocked(message)
+	if message != "" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// getMonNodes returns the mons by the name of the node they are assigned to or running on
+func (c *Cluster) getMonNodes() (map[string]string, error) {
+	monNodes := map[string]string{}
+	for name, schedule := range c.mapping.Schedule {
+		if schedule != nil && schedule.Name != "" {
+			monNodes[schedule.Name] = name
+		}
+	}
+
+	// the mons on PVCs without host network are placed by the native scheduler
+	pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the mon pods")
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Labels["mon_canary"] == "true" {
+			continue
+		}
+		if _, ok := monNodes[pod.Spec.NodeName]; !ok {
+			monNodes[pod.Spec.NodeName] = pod.Labels[config.MonType]
+		}
+	}
+	return monNodes, nil
+}
+
+// simulateMonScheduling assigns the new mons to the nodes that are ready, schedulable, in the zone of
+// the mon, match the mon placement and tolerate its taints, and that host no other mon unless
+// multiple mons are allowed per node. It returns what is missing to schedule the new mons, or an
+// empty string when they can all be scheduled.
+func (c *Cluster) simulateMonScheduling(nodes []v1.Node, monNodes map[string]string, newMons []*monConfig) string {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	oneMonPerNode := requiredDuringScheduling(&c.spec)
+	assigned := map[string]string{}
+	for node, mon := range monNodes {
+		assigned[node] = mon
+	}
+
+	// the mons that cannot be scheduled for the same reasons are reported together
+	var details []string
+	unscheduled := map[string][]string{}
+	for _, mon := range newMons {
+		placement := c.getMonPlacement(mon.DaemonName, mon.Zone)
+		scheduled := false
+		var reasons []string
+		for i := range nodes {
+			reason := c.monNodeExclusionReason(&nodes[i], mon, placement)
+			if reason == "" && oneMonPerNode && assigned[nodes[i].Name] != "" {
+				reason = fmt.Sprintf("hosts mon %q", assigned[nodes[i].Name])
+			}
+			if reason == "" {
+				assigned[nodes[i].Name] = mon.DaemonName
+				scheduled = true
+				break
+			}
+			reasons = append(reasons, fmt.Sprintf("node %q %s", nodes[i].Name, reason))
+		}
+		if scheduled {
+			continue
+		}
+		detail := strings.Join(reasons, ", ")
+		if len(nodes) == 0 {
+			detail = "no node was found"
+		}
+		if _, ok := unscheduled[detail]; !ok {
+			details = append(details, detail)
+		}
+		unscheduled[detail] = append(unscheduled[detail], monDescription(mon))
+	}
+	if len(details) == 0 {
+		return ""
+	}
+
+	var messages []string
+	for _, detail := range details {
+		messages = append(messages, fmt.Sprintf("no node is available for %s: %s", strings.Join(unscheduled[detail], ", "), detail))
+	}
+	return fmt.Sprintf("cannot schedule the new mons to reach the mon count %d. %s", c.spec.Mon.Count, strings.Join(messages, "; "))
+}
+
+// monNodeExclusionReason returns why the mon cannot be scheduled on the node, or an empty string
+func (c *Cluster) monNodeExclusionReason(node *v1.Node, mon *monConfig, placement cephv1.Placement) string {
+	if node.Spec.Unschedulable {
+		return "is cordoned"
+	}
+	if !k8sutil.NodeIsReady(*node) {
+		return "is not ready"
+	}
+	if c.spec.ZonesRequired() {
+		label := GetFailureDomainLabel(c.spec)
+		if node.Labels[label] != mon.Zone {
+			return fmt.Sprintf("is not in zone %q with label %q", mon.Zone, label)
+		}
+	}
+	matches, err := k8sutil.NodeMeetsAffinityTerms(*node, placement.NodeAffinity)
+	if err != nil {
+		return fmt.Sprintf("cannot be matched with the mon node affinity. %v", err)
+	}
+	if !matches {
+		return "does not match the mon node affinity"
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !taintTolerated(taint, placement.Tolerations) {
+			return fmt.Sprintf("has the taint %q not tolerated by the mons", taint.ToString())
+		}
+	}
+	return ""
+}
+
+func taintTolerated(taint v1.Taint, tolerations []v1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func monDescription(mon *monConfig) string {
+	if mon.Zone == "" {
+		return fmt.Sprintf("mon %q", mon.DaemonName)
+	}
+	return fmt.Sprintf("mon %q in zone %q", mon.DaemonName, mon.Zone)
+}
+
+// reportMonSchedulingBlocked sets the MonSchedulingBlocked condition on the CephCluster while the
+// new mons cannot be scheduled, and clears it once they can
+func (c *Cluster) reportMonSchedulingBlocked(message string) {
+	if message == c.schedulingBlockedMessage {
+		return
+	}
+	c.schedulingBlockedMessage = message
+
+	status := v1.ConditionTrue
+	reason := cephv1.MonSchedulingBlockedReason
+	if message == "" {
+		status = v1.ConditionFalse
+		reason = cephv1.MonSchedulingPossibleReason
+		message = "the nodes are available for the new mons"
+	} else {
+		logger.Error(message)
+	}
+	updateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionMonSchedulingBlocked, status, reason, message)
+}
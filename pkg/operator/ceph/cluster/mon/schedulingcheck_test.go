@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func testSchedulingNode(name, zone string) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{v1.LabelHostname: name, v1.LabelTopologyZone: zone}},
+		Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}},
+	}
+}
+
+func TestSimulateMonScheduling(t *testing.T) {
+	c := &Cluster{spec: cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 5}}}
+	monNodes := map[string]string{"node1": "a", "node2": "b", "node3": "c"}
+	newMons := []*monConfig{{DaemonName: "d"}, {DaemonName: "e"}}
+
+	t.Run("enough nodes", func(t *testing.T) {
+		nodes := []v1.Node{testSchedulingNode("node1", ""), testSchedulingNode("node2", ""), testSchedulingNode("node3", ""), testSchedulingNode("node4", ""), testSchedulingNode("node5", "")}
+		assert.Equal(t, "", c.simulateMonScheduling(nodes, monNodes, newMons))
+	})
+
+	t.Run("missing nodes", func(t *testing.T) {
+		cordoned := testSchedulingNode("node4", "")
+		cordoned.Spec.Unschedulable = true
+		tainted := testSchedulingNode("node5", "")
+		tainted.Spec.Taints = []v1.Taint{{Key: "storage", Value: "false", Effect: v1.TaintEffectNoSchedule}}
+		preferred := testSchedulingNode("node6", "")
+		preferred.Spec.Taints = []v1.Taint{{Key: "busy", Effect: v1.TaintEffectPreferNoSchedule}}
+		nodes := []v1.Node{testSchedulingNode("node1", ""), testSchedulingNode("node2", ""), testSchedulingNode("node3", ""), cordoned, tainted, preferred}
+
+		// mon d is scheduled on the node with the preferred taint, mon e is missing a node
+		message := c.simulateMonScheduling(nodes, monNodes, newMons)
+		assert.Equal(t, `cannot schedule the new mons to reach the mon count 5. no node is available for mon "e": node "node1" hosts mon "a", node "node2" hosts mon "b", node "node3" hosts mon "c", node "node4" is cordoned, node "node5" has the taint "storage=false:NoSchedule" not tolerated by the mons, node "node6" hosts mon "d"`, message)
+
+		// the tolerated taint and multiple mons per node let both mons be scheduled
+		c.spec.Placement = cephv1.PlacementSpec{cephv1.KeyMon: cephv1.Placement{Tolerations: []v1.Toleration{{Key: "storage", Operator: v1.TolerationOpExists}}}}
+		assert.Equal(t, "", c.simulateMonScheduling(nodes, monNodes, newMons))
+		c.spec.Placement = nil
+		c.spec.Mon.AllowMultiplePerNode = true
+		assert.Equal(t, "", c.simulateMonScheduling(nodes, monNodes, newMons))
+		c.spec.Mon.AllowMultiplePerNode = false
+	})
+
+	t.Run("missing zone", func(t *testing.T) {
+		zones := &Cluster{spec: cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 3, Zones: []cephv1.MonZoneSpec{{Name: "z1"}, {Name: "z2"}, {Name: "z3"}}}}}
+		notReady := testSchedulingNode("node3", "z3")
+		notReady.Status.Conditions = nil
+		nodes := []v1.Node{testSchedulingNode("node1", "z1"), testSchedulingNode("node2", "z2"), notReady}
+		message := zones.simulateMonScheduling(nodes, map[string]string{"node1": "a"}, []*monConfig{{DaemonName: "b", Zone: "z2"}, {DaemonName: "c", Zone: "z3"}})
+		assert.Equal(t, `cannot schedule the new mons to reach the mon count 3. no node is available for mon "c" in zone "z3": node "node1" is not in zone "z3" with label "topology.kubernetes.io/zone", node "node2" is not in zone "z3" with label "topology.kubernetes.io/zone", node "node3" is not ready`, message)
+	})
+
+	t.Run("no nodes", func(t *testing.T) {
+		assert.Equal(t, `cannot schedule the new mons to reach the mon count 5. no node is available for mon "d", mon "e": no node was found`, c.simulateMonScheduling(nil, monNodes, newMons))
+	})
+}
+
+func TestCheckMonScheduling(t *testing.T) {
+	conditions := conditionUpdatesStub(t)
+	node := testSchedulingNode("node1", "")
+	clientset := k8sfake.NewSimpleClientset(&node)
+	c := &Cluster{
+		ClusterInfo: clienttest.CreateTestClusterInfo(1),
+		Namespace:   "ns",
+		context:     &clusterd.Context{Clientset: clientset},
+		spec:        cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 3}},
+		mapping:     &controller.Mapping{Schedule: map[string]*controller.MonScheduleInfo{"a": {Name: "node1"}}},
+	}
+	newMons := []*monConfig{{DaemonName: "b"}}
+
+	err := c.checkMonScheduling(newMons)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `node "node1" hosts mon "a"`)
+	require.Len(t, *conditions, 1)
+	assert.Equal(t, cephv1.ConditionMonSchedulingBlocked, (*conditions)[0].Type)
+	assert.Equal(t, v1.ConditionTrue, (*conditions)[0].Status)
+
+	// the condition is only updated when the missing nodes change
+	require.Error(t, c.checkMonScheduling(newMons))
+	assert.Len(t, *conditions, 1)
+
+	// the condition is cleared once a node is added
+	second := testSchedulingNode("node2", "")
+	_, err = clientset.CoreV1().Nodes().Create(context.TODO(), &second, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.checkMonScheduling(newMons))
+	require.Len(t, *conditions, 2)
+	assert.Equal(t, v1.ConditionFalse, (*conditions)[1].Status)
+	assert.Equal(t, cephv1.MonSchedulingPossibleReason, (*conditions)[1].Reason)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	apps "k8s.io/api/apps/v1"
@@ -40,6 +41,28 @@ const (
 	cephMonCommand = "ceph-mon"
 )
 
+func init() {
+	monDeployments := []string{"deployment/rook-ceph-mon-*"}
+	controller.RegisterFieldImpacts(&cephv1.CephCluster{},
+		controller.FieldImpact{Field: "spec.cephVersion.image", Resources: monDeployments, Restart: true, Description: "The mons are updated one at a time."},
+		controller.FieldImpact{Field: "spec.dataDirHostPath", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.network", Resources: append([]string{"service/rook-ceph-mon-*"}, monDeployments...), Restart: true},
+		controller.FieldImpact{Field: "spec.resources.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.placement.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.priorityClassNames.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.annotations.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.labels.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.healthCheck.livenessProbe.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.healthCheck.startupProbe.mon", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.mon.count", Resources: monDeployments, Description: "The mons are added or removed, the existing mons are not restarted."},
+		controller.FieldImpact{Field: "spec.mon.allowMultiplePerNode", Resources: monDeployments, Description: "Applies to the mons scheduled after the change."},
+		controller.FieldImpact{Field: "spec.mon.volumeClaimTemplate", Resources: []string{"persistentvolumeclaim/rook-ceph-mon-*"}, Description: "Applies to the new mons, the existing mons are migrated only with migrateHostPathToPVC."},
+		controller.FieldImpact{Field: "spec.mon.migrateHostPathToPVC", Resources: monDeployments, Restart: true, Description: "The mons on the host path are failed over to a PVC one at a time."},
+		controller.FieldImpact{Field: "spec.mon.verifyStore", Resources: monDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.security.cephx.mon", Resources: append([]string{"secret/rook-ceph-mon"}, monDeployments...), Restart: true, Description: "The mons restart one at a time when the mon key is rotated."},
+	)
+}
+
 func (c *Cluster) getLabels(monConfig *monConfig, canary, includeNewLabels bool) map[string]string {
 	// Mons have a service for each mon, so the additional pod data is relevant for its services
 	// Use pod labels to keep "mon: id" for legacy
@@ -206,6 +229,16 @@ func (c *Cluster) makeMonPod(monConfig *monConfig, canary bool) (*corev1.Pod, er
 		ServiceAccountName: k8sutil.DefaultServiceAccount,
 	}
 
+	// after the mon key was rotated, the mons refresh the keyring of their data dir so that the
+	// previous keys are not needed to clean up the mon dirs
+	if c.keyIdentifier != "" {
+		podSpec.InitContainers = append(podSpec.InitContainers, c.makeMonKeyringInitContainer(monConfig))
+	}
+
+	if c.spec.Mon.VerifyStore {
+		podSpec.InitContainers = append(podSpec.InitContainers, c.makeMonStoreCheckInitContainer(monConfig))
+	}
+
 	// If the log collector is enabled we add the side-car container
 	if c.spec.LogCollector.Enabled {
 		shareProcessNamespace := true
@@ -229,6 +262,14 @@ func (c *Cluster) makeMonPod(monConfig *monConfig, canary bool) (*corev1.Pod, er
 	cephv1.GetMonAnnotations(c.spec.Annotations).ApplyToObjectMeta(&pod.ObjectMeta)
 	cephv1.GetMonLabels(c.spec.Labels).ApplyToObjectMeta(&pod.ObjectMeta)
 
+	// apply the identifier of the mon key to the pod to ensure the mons restart when the key is rotated
+	if c.keyIdentifier != "" {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[keyring.CephxKeyIdentifierAnnotation] = c.keyIdentifier
+	}
+
 	if monConfig.UseHostNetwork {
 		pod.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
 	} else if c.spec.Network.IsMultus() {
@@ -266,7 +307,7 @@ func (c *Cluster) makeChownInitContainer(monConfig *monConfig) corev1.Container
 		c.spec.CephVersion.Image,
 		controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
 		controller.DaemonVolumeMounts(monConfig.DataPathMap, keyringStoreName, c.spec.DataDirHostPath),
-		cephv1.GetMonResources(c.spec.Resources),
+		c.getMonResources(monConfig),
 		controller.DefaultContainerSecurityContext(),
 		"",
 	)
@@ -282,7 +323,7 @@ func (c *Cluster) makeMonFSInitContainer(monConfig *monConfig) corev1.Container
 			controller.DaemonFlags(c.ClusterInfo, &c.spec, monConfig.DaemonName),
 			// needed so we can generate an initial monmap
 			// otherwise the mkfs will say: "0  no local addrs match monmap"
-			config.NewFlag("public-addr", monConfig.PublicIP),
+			c.publicAddrFlag(monConfig),
 			"--mkfs",
 		),
 		Image:           c.spec.CephVersion.Image,
@@ -291,7 +332,27 @@ func (c *Cluster) makeMonFSInitContainer(monConfig *monConfig) corev1.Container
 		SecurityContext: controller.DefaultContainerSecurityContext(),
 		// filesystem creation does not require ports to be exposed
 		Env:       controller.DaemonEnvVars(&c.spec),
-		Resources: cephv1.GetMonResources(c.spec.Resources),
+		Resources: c.getMonResources(monConfig),
+	}
+}
+
+// makeMonKeyringInitContainer returns an init container that copies the current mon keyring to the
+// data dir of the mon, which otherwise keeps the key the mon was created with
+func (c *Cluster) makeMonKeyringInitContainer(monConfig *monConfig) corev1.Container {
+	return corev1.Container{
+		Name: "init-mon-keyring",
+		Command: []string{
+			"cp",
+		},
+		Args: []string{
+			keyring.VolumeMount().KeyringFilePath(),
+			path.Join(monConfig.DataPathMap.ContainerDataDir, "keyring"),
+		},
+		Image:           c.spec.CephVersion.Image,
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
+		VolumeMounts:    controller.DaemonVolumeMounts(monConfig.DataPathMap, keyringStoreName, c.spec.DataDirHostPath),
+		SecurityContext: controller.DefaultContainerSecurityContext(),
+		Resources:       c.getMonResources(monConfig),
 	}
 }
 
@@ -308,7 +369,7 @@ func (c *Cluster) makeMonDaemonContainer(monConfig *monConfig) corev1.Container
 			"--foreground",
 			// If the mon is already in the monmap, when the port is left off of --public-addr,
 			// it will still advertise on the previous port b/c monmap is saved to mon database.
-			config.NewFlag("public-addr", monConfig.PublicIP),
+			c.publicAddrFlag(monConfig),
 			// Set '--setuser-match-path' so that existing directory owned by root won't affect the daemon startup.
 			// For existing data store owned by root, the daemon will continue to run as root
 			//
@@ -324,7 +385,7 @@ func (c *Cluster) makeMonDaemonContainer(monConfig *monConfig) corev1.Container
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          DefaultMsgr2PortName,
-				ContainerPort: DefaultMsgr2Port,
+				ContainerPort: monConfig.msgr2Port(),
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
@@ -332,14 +393,14 @@ func (c *Cluster) makeMonDaemonContainer(monConfig *monConfig) corev1.Container
 			controller.DaemonEnvVars(&c.spec),
 			k8sutil.PodIPEnvVar(podIPEnvVar),
 		),
-		Resources:     cephv1.GetMonResources(c.spec.Resources),
+		Resources:     c.getMonResources(monConfig),
 		StartupProbe:  controller.GenerateStartupProbeExecDaemon(config.MonType, monConfig.DaemonName),
 		LivenessProbe: controller.GenerateLivenessProbeExecDaemon(config.MonType, monConfig.DaemonName),
 		WorkingDir:    config.VarLogCephDir,
 	}
 
 	bindaddr := controller.ContainerEnvVarReference(podIPEnvVar)
-	if monConfig.Port == DefaultMsgr2Port {
+	if monConfig.Port == monConfig.msgr2Port() {
 		container.Args = append(container.Args, config.NewFlag("ms_bind_msgr1", "false"))
 
 		// mons don't use --ms-bind-msgr1 to control whether they bind to v1 port or not.
@@ -351,11 +412,11 @@ func (c *Cluster) makeMonDaemonContainer(monConfig *monConfig) corev1.Container
 			// don't crash than to forcefully disable msgr1
 		} else if c.spec.Network.IPFamily == cephv1.IPv6 {
 			// IPv6 addrs have to be surrounded in square brackets when a port is given
-			bindaddr = fmt.Sprintf("[%s]:%d", bindaddr, DefaultMsgr2Port)
+			bindaddr = fmt.Sprintf("[%s]:%d", bindaddr, monConfig.msgr2Port())
 		} else if c.spec.Network.IPFamily == cephv1.IPv4 || c.spec.Network.IPFamily == "" {
 			// IPv4 addrs must have the port added without any special syntax
 			// if the IP family is unset, IPv4 is a safe assumption
-			bindaddr = fmt.Sprintf("%s:%d", bindaddr, DefaultMsgr2Port)
+			bindaddr = fmt.Sprintf("%s:%d", bindaddr, monConfig.msgr2Port())
 		}
 	} else {
 		// Add messenger 1 port
@@ -384,6 +445,10 @@ func (c *Cluster) makeMonDaemonContainer(monConfig *monConfig) corev1.Container
 		// port, which makes sense because this is the pod IP, which changes with every new pod.
 		container.Args = append(container.Args, config.NewFlag("public-bind-addr", bindaddr))
 	}
+	if monConfig.UseHostNetwork && monConfig.ClusterIP != "" {
+		// the cluster address selected by the mon bind addresses
+		container.Args = append(container.Args, config.NewFlag("cluster-addr", monConfig.ClusterIP))
+	}
 
 	return container
 }
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// getMonRemovedTime returns the time a mon was last removed, loaded from the mon endpoints configmap
+// if not known yet. The time is zero if no mon was removed.
+func (c *Cluster) getMonRemovedTime() (time.Time, error) {
+	if !c.monRemovedTime.IsZero() {
+		return c.monRemovedTime, nil
+	}
+	configmap, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(c.ClusterInfo.Context, EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "failed to get mon endpoints configmap")
+	}
+	val, ok := configmap.Data[EndpointMonRemovedTimeKey]
+	if !ok || val == "" {
+		return time.Time{}, nil
+	}
+	removedTime, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to parse the time a mon was last removed %q", val)
+	}
+	c.monRemovedTime = removedTime
+	return removedTime, nil
+}
+
+// reportStaleMonEndpoints sets the StaleMonEndpoints condition on the CephCluster with the nodes where
+// volumes of the cluster were mounted before a mon was last removed. The mounts of these volumes keep
+// the mon endpoints they were mounted with and may hang once the mons they know about are all gone.
+func (c *Cluster) reportStaleMonEndpoints() {
+	if c.monEndpointsCurrent {
+		// the volumes mounted since then use the current mon endpoints
+		return
+	}
+	monRemovedTime, err := c.getMonRemovedTime()
+	if err != nil {
+		logger.Warningf("failed to check the nodes with stale mon endpoints. %v", err)
+		return
+	}
+	if monRemovedTime.IsZero() {
+		c.monEndpointsCurrent = true
+		return
+	}
+
+	nodes, err := c.nodesWithStaleMonEndpoints(monRemovedTime)
+	if err != nil {
+		logger.Warningf("failed to check the nodes with stale mon endpoints. %v", err)
+		return
+	}
+
+	status := corev1.ConditionTrue
+	reason := cephv1.StaleMonEndpointsReason
+	message := fmt.Sprintf("volumes on nodes [%s] were mounted before a mon was removed at %s and may still use the endpoints of the removed mon, restart the pods using the volumes to mount them with the current mon endpoints",
+		strings.Join(nodes, " "), monRemovedTime.Format(time.RFC3339))
+	if len(nodes) == 0 {
+		c.monEndpointsCurrent = true
+		status = corev1.ConditionFalse
+		reason = cephv1.MonEndpointsCurrentReason
+		message = "all volumes were mounted with the current mon endpoints"
+	}
+	if message == c.staleMonEndpointsMessage {
+		return
+	}
+	c.staleMonEndpointsMessage = message
+	if len(nodes) > 0 {
+		logger.Warning(message)
+	}
+	updateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionStaleMonEndpoints, status, reason, message)
+}
+
+// nodesWithStaleMonEndpoints returns the nodes running pods that were started before the mon removal
+// time with the RBD or CephFS volumes of the cluster
+func (c *Cluster) nodesWithStaleMonEndpoints(monRemovedTime time.Time) ([]string, error) {
+	claims, err := c.claimsMountedBeforeMonRemoval(monRemovedTime)
+	if err != nil {
+		return nil, err
+	}
+
+	staleNodes := map[string]bool{}
+	for _, namespace := range sets.List(sets.KeySet(claims)) {
+		// only the namespaces with the claims of the cluster are listed, the volumes of the other pods cannot be stale
+		pods, err := c.context.Clientset.CoreV1().Pods(namespace).List(c.ClusterInfo.Context, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodRunning)).String(),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list running pods in namespace %q", namespace)
+		}
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == "" || pod.Status.StartTime == nil || !pod.Status.StartTime.Time.Before(monRemovedTime) {
+				continue
+			}
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim != nil && claims[namespace].Has(volume.PersistentVolumeClaim.ClaimName) {
+					staleNodes[pod.Spec.NodeName] = true
+					break
+				}
+			}
+		}
+	}
+
+	nodes := []string{}
+	for node := range staleNodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// claimsMountedBeforeMonRemoval returns the claims of the RBD and CephFS volumes of the cluster by namespace.
+// The volumes provisioned after the mon removal cannot have been mounted before it, so the claims are only
+// listed once per mon removal.
+func (c *Cluster) claimsMountedBeforeMonRemoval(monRemovedTime time.Time) (map[string]sets.Set[string], error) {
+	if c.staleMonEndpointClaims != nil && c.staleMonEndpointClaimsTime.Equal(monRemovedTime) {
+		return c.staleMonEndpointClaims, nil
+	}
+
+	clusterIDs, err := csi.GetClusterIDs(c.ClusterInfo.Context, c.context.Clientset, c.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the csi cluster IDs")
+	}
+	isClusterID := map[string]bool{}
+	for _, clusterID := range clusterIDs {
+		isClusterID[clusterID] = true
+	}
+
+	// PVs cannot be filtered server-side by their CSI driver
+	pvs, err := c.context.Clientset.CoreV1().PersistentVolumes().List(c.ClusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list PVs")
+	}
+	// the claims of the volumes that keep the mon endpoints in their kernel mounts
+	claims := map[string]sets.Set[string]{}
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.ClaimRef == nil {
+			continue
+		}
+		if pv.Spec.CSI.Driver != csi.RBDDriverName && pv.Spec.CSI.Driver != csi.CephFSDriverName {
+			continue
+		}
+		if !isClusterID[pv.Spec.CSI.VolumeAttributes["clusterID"]] {
+			continue
+		}
+		if pv.CreationTimestamp.Time.After(monRemovedTime) {
+			continue
+		}
+		if claims[pv.Spec.ClaimRef.Namespace] == nil {
+			claims[pv.Spec.ClaimRef.Namespace] = sets.New[string]()
+		}
+		claims[pv.Spec.ClaimRef.Namespace].Insert(pv.Spec.ClaimRef.Name)
+	}
+
+	c.staleMonEndpointClaims = claims
+	c.staleMonEndpointClaimsTime = monRemovedTime
+	return claims, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReportStaleMonEndpoints(t *testing.T) {
+	ctx := context.TODO()
+	conditionUpdates := conditionUpdatesStub(t)
+	t.Setenv(k8sutil.PodNamespaceEnvVar, "")
+	originalRBDDriverName := csi.RBDDriverName
+	t.Cleanup(func() { csi.RBDDriverName = originalRBDDriverName })
+	csi.RBDDriverName = "rook-ceph.rbd.csi.ceph.com"
+
+	clientset := fake.NewSimpleClientset()
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	c := &Cluster{Namespace: clusterInfo.Namespace, ClusterInfo: clusterInfo, context: &clusterd.Context{Clientset: clientset}}
+	monRemovedTime := time.Now().UTC().Truncate(time.Second)
+
+	createPV := func(name, clusterID string) {
+		pv := &v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PersistentVolumeSpec{
+				ClaimRef: &v1.ObjectReference{Namespace: "app", Name: name},
+				PersistentVolumeSource: v1.PersistentVolumeSource{
+					CSI: &v1.CSIPersistentVolumeSource{
+						Driver:           csi.RBDDriverName,
+						VolumeAttributes: map[string]string{"clusterID": clusterID},
+					},
+				},
+			},
+		}
+		_, err := clientset.CoreV1().PersistentVolumes().Create(ctx, pv, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	createPod := func(name, node, claim string, startTime time.Time) {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Spec: v1.PodSpec{
+				NodeName: node,
+				Volumes: []v1.Volume{{
+					Name:         "data",
+					VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+				}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &metav1.Time{Time: startTime}},
+		}
+		_, err := clientset.CoreV1().Pods("app").Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	createPV("data", clusterInfo.Namespace)
+	createPV("other-cluster", "other")
+	createPod("mounted-before", "node1", "data", monRemovedTime.Add(-time.Hour))
+	createPod("mounted-after", "node2", "data", monRemovedTime.Add(time.Minute))
+	createPod("other-cluster", "node3", "other-cluster", monRemovedTime.Add(-time.Hour))
+
+	t.Run("no mon removed", func(t *testing.T) {
+		c.reportStaleMonEndpoints()
+		assert.Equal(t, 0, len(*conditionUpdates))
+		assert.True(t, c.monEndpointsCurrent)
+	})
+
+	t.Run("volumes mounted before the mon removal", func(t *testing.T) {
+		c.monRemovedTime = monRemovedTime
+		c.monEndpointsCurrent = false
+		c.reportStaleMonEndpoints()
+		c.reportStaleMonEndpoints()
+		require.Equal(t, 1, len(*conditionUpdates))
+		assert.Equal(t, cephv1.ConditionStaleMonEndpoints, (*conditionUpdates)[0].Type)
+		assert.Equal(t, v1.ConditionTrue, (*conditionUpdates)[0].Status)
+		assert.Equal(t, cephv1.StaleMonEndpointsReason, (*conditionUpdates)[0].Reason)
+		assert.Contains(t, (*conditionUpdates)[0].Message, "[node1]")
+		assert.False(t, c.monEndpointsCurrent)
+	})
+
+	t.Run("volumes mounted again", func(t *testing.T) {
+		err := clientset.CoreV1().Pods("app").Delete(ctx, "mounted-before", metav1.DeleteOptions{})
+		require.NoError(t, err)
+		c.reportStaleMonEndpoints()
+		c.reportStaleMonEndpoints()
+		require.Equal(t, 2, len(*conditionUpdates))
+		assert.Equal(t, v1.ConditionFalse, (*conditionUpdates)[1].Status)
+		assert.Equal(t, cephv1.MonEndpointsCurrentReason, (*conditionUpdates)[1].Reason)
+		assert.True(t, c.monEndpointsCurrent)
+	})
+
+	t.Run("PVs listed once per mon removal", func(t *testing.T) {
+		pvLists := func() int {
+			count := 0
+			for _, action := range clientset.Actions() {
+				if action.GetVerb() == "list" && action.GetResource().Resource == "persistentvolumes" {
+					count++
+				}
+			}
+			return count
+		}
+		listed := pvLists()
+		_, err := c.nodesWithStaleMonEndpoints(monRemovedTime)
+		assert.NoError(t, err)
+		assert.Equal(t, listed, pvLists())
+
+		_, err = c.nodesWithStaleMonEndpoints(monRemovedTime.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, listed+1, pvLists())
+		assert.Equal(t, []string{"app"}, sets.List(sets.KeySet(c.staleMonEndpointClaims)))
+	})
+
+	t.Run("removal time loaded from the mon endpoints configmap", func(t *testing.T) {
+		c.monRemovedTime = time.Time{}
+		cm := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: EndpointConfigMapName, Namespace: c.Namespace},
+			Data:       map[string]string{EndpointMonRemovedTimeKey: monRemovedTime.Format(time.RFC3339)},
+		}
+		_, err := clientset.CoreV1().ConfigMaps(c.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		require.NoError(t, err)
+		removedTime, err := c.getMonRemovedTime()
+		assert.NoError(t, err)
+		assert.True(t, monRemovedTime.Equal(removedTime))
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	monStoreCheckContainerName = "verify-mon-store"
+)
+
+//go:embed storecheck.sh
+var monStoreCheckScript string
+
+// makeMonStoreCheckInitContainer returns an init container that verifies the mon store is readable
+// and belongs to this cluster. A mon with a corrupted store would otherwise crash-loop with rocksdb
+// errors that are hard to attribute.
+func (c *Cluster) makeMonStoreCheckInitContainer(monConfig *monConfig) corev1.Container {
+	// The store is opened with the same user as the mon daemon so that the files rocksdb writes
+	// when opening the store are not left owned by root after the chown init container ran.
+	securityContext := controller.CephSecurityContext()
+	if controller.CephMonRunAsRoot() {
+		securityContext = controller.DefaultContainerSecurityContext()
+	}
+	return corev1.Container{
+		Name: monStoreCheckContainerName,
+		Command: []string{
+			"/bin/bash",
+			"-c",
+			monStoreCheckScript,
+		},
+		Image:           c.spec.CephVersion.Image,
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
+		VolumeMounts:    controller.DaemonVolumeMounts(monConfig.DataPathMap, keyringStoreName, c.spec.DataDirHostPath),
+		SecurityContext: securityContext,
+		Env: append(
+			controller.DaemonEnvVars(&c.spec),
+			corev1.EnvVar{Name: "ROOK_MON_DATA_DIR", Value: monConfig.DataPathMap.ContainerDataDir},
+			corev1.EnvVar{Name: "ROOK_CEPH_FSID", Value: c.ClusterInfo.FSID},
+		),
+		Resources:                c.getMonResources(monConfig),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+// getMonStoreCheckFailure returns the reason the store check of the mon failed, or an empty string
+// if the store check of the mon pod has not failed
+func (c *Cluster) getMonStoreCheckFailure(ctx context.Context, monName string) (string, error) {
+	selector := fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, controller.DaemonIDLabel, monName)
+	pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list pods of mon %q", monName)
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.InitContainerStatuses {
+			if status.Name != monStoreCheckContainerName {
+				continue
+			}
+			// the last failure is kept while the container waits to be restarted
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				if state.Terminated != nil && state.Terminated.ExitCode != 0 {
+					return strings.TrimSpace(state.Terminated.Message), nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,31 @@
+#!/usr/bin/env bash
+# Checks that the mon store can be read and that it belongs to the expected cluster before the mon
+# starts. Any failure is reported in the termination message of the container so the operator can
+# tell a corrupted store apart from other mon failures. The store is only read, the monmap is
+# extracted to a temporary file outside of the mon data dir.
+
+set -o nounset
+set -o pipefail
+
+fail() {
+  echo "mon store check failed: $*" | tee /dev/termination-log >&2
+  exit 1
+}
+
+STORE_DIR="$ROOK_MON_DATA_DIR/store.db"
+MONMAP=/tmp/monmap
+
+if [ ! -r "$STORE_DIR/CURRENT" ]; then
+  fail "store $STORE_DIR is missing or not readable"
+fi
+
+if ! OUTPUT=$(ceph-monstore-tool "$ROOK_MON_DATA_DIR" get monmap -- --out "$MONMAP" 2>&1); then
+  fail "failed to read the monmap from store $STORE_DIR: $(echo "$OUTPUT" | tail -n 1)"
+fi
+
+FSID=$(monmaptool --print "$MONMAP" 2>/dev/null | awk '$1 == "fsid" {print $2}')
+if [ "$FSID" != "$ROOK_CEPH_FSID" ]; then
+  fail "store $STORE_DIR belongs to cluster fsid \"$FSID\" instead of \"$ROOK_CEPH_FSID\""
+fi
+
+echo "mon store $STORE_DIR is healthy"
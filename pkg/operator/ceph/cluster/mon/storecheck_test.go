@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	testop "github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMonStoreCheckInitContainer(t *testing.T) {
+	clientset := testop.New(t, 1)
+	c := New(context.TODO(), &clusterd.Context{Clientset: clientset, ConfigDir: "/var/lib/rook"}, "ns", cephv1.ClusterSpec{}, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	setCommonMonProperties(c, 0, cephv1.MonSpec{Count: 3, AllowMultiplePerNode: true}, "rook/rook:myversion")
+	monConfig := testGenMonConfig("a")
+
+	t.Run("store check disabled by default", func(t *testing.T) {
+		pod, err := c.makeMonPod(monConfig, false)
+		assert.NoError(t, err)
+		assert.Len(t, pod.Spec.InitContainers, 2)
+	})
+
+	t.Run("store check enabled", func(t *testing.T) {
+		c.spec.Mon.VerifyStore = true
+		pod, err := c.makeMonPod(monConfig, false)
+		assert.NoError(t, err)
+		assert.Len(t, pod.Spec.InitContainers, 3)
+		container := pod.Spec.InitContainers[2]
+		assert.Equal(t, monStoreCheckContainerName, container.Name)
+		assert.Contains(t, container.Env, v1.EnvVar{Name: "ROOK_MON_DATA_DIR", Value: monConfig.DataPathMap.ContainerDataDir})
+		assert.Contains(t, container.Env, v1.EnvVar{Name: "ROOK_CEPH_FSID", Value: c.ClusterInfo.FSID})
+		// the store must be opened as the ceph user after the chown
+		assert.Equal(t, controller.CephUserID, *container.SecurityContext.RunAsUser)
+	})
+
+	t.Run("store check as root with mons running as root", func(t *testing.T) {
+		t.Setenv("ROOK_CEPH_MON_RUN_AS_ROOT", "true")
+		container := c.makeMonStoreCheckInitContainer(monConfig)
+		assert.Nil(t, container.SecurityContext.RunAsUser)
+	})
+}
+
+func TestReportMonStoreCheckFailure(t *testing.T) {
+	conditionUpdates := conditionUpdatesStub(t)
+	c := &Cluster{ClusterInfo: clienttest.CreateTestClusterInfo(1)}
+
+	// nothing is reported while no store check failed
+	c.reportMonStoreCheckFailure("")
+	assert.Equal(t, 0, len(*conditionUpdates))
+
+	// a failure is reported once
+	c.reportMonStoreCheckFailure(`mon "a" is out of quorum because its store failed the integrity check: corrupted`)
+	c.reportMonStoreCheckFailure(`mon "a" is out of quorum because its store failed the integrity check: corrupted`)
+	require.Equal(t, 1, len(*conditionUpdates))
+	assert.Equal(t, cephv1.ConditionMonStoreCorrupted, (*conditionUpdates)[0].Type)
+	assert.Equal(t, v1.ConditionTrue, (*conditionUpdates)[0].Status)
+	assert.Equal(t, cephv1.MonStoreCheckFailedReason, (*conditionUpdates)[0].Reason)
+	assert.Contains(t, (*conditionUpdates)[0].Message, "corrupted")
+
+	// the condition is cleared once the mons are back in quorum
+	c.reportMonStoreCheckFailure("")
+	require.Equal(t, 2, len(*conditionUpdates))
+	assert.Equal(t, v1.ConditionFalse, (*conditionUpdates)[1].Status)
+	assert.Equal(t, cephv1.MonStoreCheckPassedReason, (*conditionUpdates)[1].Reason)
+}
+
+func TestGetMonStoreCheckFailure(t *testing.T) {
+	ctx := context.TODO()
+	clientset := testop.New(t, 1)
+	c := &Cluster{Namespace: "ns", context: &clusterd.Context{Clientset: clientset}}
+
+	reason, err := c.getMonStoreCheckFailure(ctx, "a")
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rook-ceph-mon-a",
+			Namespace: "ns",
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, controller.DaemonIDLabel: "a"},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "init-mon-fs", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+				{
+					Name:  monStoreCheckContainerName,
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  "mon store check failed: store is missing or not readable\n",
+					}},
+				},
+			},
+		},
+	}
+	_, err = clientset.CoreV1().Pods("ns").Create(ctx, pod, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	reason, err = c.getMonStoreCheckFailure(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "mon store check failed: store is missing or not readable", reason)
+
+	// the store of another mon is not attributed
+	reason, err = c.getMonStoreCheckFailure(ctx, "b")
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
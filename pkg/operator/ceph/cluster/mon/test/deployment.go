@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"sync"
+
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	apps "k8s.io/api/apps/v1"
+)
+
+// FakeDeploymentUpdater records the mon deployments updated by the health checker instead of
+// waiting for the mons to be ready
+type FakeDeploymentUpdater struct {
+	mutex   sync.Mutex
+	updated []string
+	// Err fails the update of the deployments when set
+	Err error
+}
+
+// UpdateDeploymentAndWait records the update of the deployment, it implements the deployment
+// updater of the mon health checker
+func (u *FakeDeploymentUpdater) UpdateDeploymentAndWait(context *clusterd.Context, clusterInfo *client.ClusterInfo, deployment *apps.Deployment, daemonType, daemonName string, skipUpgradeChecks, continueUpgradeAfterChecksEvenIfNotHealthy bool) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if u.Err != nil {
+		return u.Err
+	}
+	u.updated = append(u.updated, deployment.Name)
+	return nil
+}
+
+// Updated returns the names of the deployments updated so far
+func (u *FakeDeploymentUpdater) Updated() []string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return append([]string{}, u.updated...)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package test provides fakes of the dependencies of the mon health checker on Ceph and Kubernetes,
+// to test the health behavior of the mons without a Ceph cluster. The fakes are set on the mon
+// cluster with SetHealthCheckDependencies.
+package test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// FakeQuorum is a fake Ceph backend answering the quorum status of the mons. The mons are all in
+// quorum until they are set out of quorum.
+type FakeQuorum struct {
+	mutex       sync.Mutex
+	mons        []string
+	outOfQuorum map[string]bool
+	err         error
+	calls       int
+}
+
+// NewFakeQuorum creates a fake quorum of the mons with the given names, all in quorum
+func NewFakeQuorum(mons ...string) *FakeQuorum {
+	q := &FakeQuorum{outOfQuorum: map[string]bool{}}
+	q.AddMons(mons...)
+	return q
+}
+
+// AddMons adds mons in quorum to the monmap, for example the mons created by a failover
+func (q *FakeQuorum) AddMons(mons ...string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, name := range mons {
+		if !q.hasMon(name) {
+			q.mons = append(q.mons, name)
+		}
+	}
+	sort.Strings(q.mons)
+}
+
+// RemoveMons removes mons from the monmap
+func (q *FakeQuorum) RemoveMons(mons ...string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, name := range mons {
+		for i, mon := range q.mons {
+			if mon == name {
+				q.mons = append(q.mons[:i], q.mons[i+1:]...)
+				break
+			}
+		}
+		delete(q.outOfQuorum, name)
+	}
+}
+
+// SetOutOfQuorum sets mons of the monmap out of quorum
+func (q *FakeQuorum) SetOutOfQuorum(mons ...string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, name := range mons {
+		q.outOfQuorum[name] = true
+	}
+}
+
+// SetInQuorum sets mons of the monmap back in quorum
+func (q *FakeQuorum) SetInQuorum(mons ...string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, name := range mons {
+		delete(q.outOfQuorum, name)
+	}
+}
+
+// Fail makes the quorum status fail with the error, as when no mon is reachable, until it is
+// called with a nil error
+func (q *FakeQuorum) Fail(err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.err = err
+}
+
+// Calls returns how many times the quorum status was requested
+func (q *FakeQuorum) Calls() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.calls
+}
+
+// Response returns the quorum status of the mons, with the mons ranked in the order of their names
+func (q *FakeQuorum) Response() client.MonStatusResponse {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.response()
+}
+
+// MonQuorumStatus answers the quorum status of the mons, it implements the quorum fetcher of the
+// mon health checker
+func (q *FakeQuorum) MonQuorumStatus(context *clusterd.Context, clusterInfo *client.ClusterInfo) (client.MonStatusResponse, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.calls++
+	if q.err != nil {
+		return client.MonStatusResponse{}, q.err
+	}
+	return q.response(), nil
+}
+
+func (q *FakeQuorum) hasMon(name string) bool {
+	for _, mon := range q.mons {
+		if mon == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *FakeQuorum) response() client.MonStatusResponse {
+	resp := client.MonStatusResponse{Quorum: []int{}}
+	for rank, name := range q.mons {
+		resp.MonMap.Mons = append(resp.MonMap.Mons, client.MonMapEntry{
+			Name:    name,
+			Rank:    rank,
+			Address: fmt.Sprintf("1.2.3.%d", rank+1),
+		})
+		if !q.outOfQuorum[name] {
+			resp.Quorum = append(resp.Quorum, rank)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import "github.com/pkg/errors"
+
+// ScenarioStep changes the fake quorum before a health check of a scenario
+type ScenarioStep func(q *FakeQuorum)
+
+// MonsDown sets the mons out of quorum
+func MonsDown(mons ...string) ScenarioStep {
+	return func(q *FakeQuorum) { q.SetOutOfQuorum(mons...) }
+}
+
+// MonsUp sets the mons back in quorum
+func MonsUp(mons ...string) ScenarioStep {
+	return func(q *FakeQuorum) { q.SetInQuorum(mons...) }
+}
+
+// MonsAdded adds the mons in quorum to the monmap
+func MonsAdded(mons ...string) ScenarioStep {
+	return func(q *FakeQuorum) { q.AddMons(mons...) }
+}
+
+// MonsRemoved removes the mons from the monmap
+func MonsRemoved(mons ...string) ScenarioStep {
+	return func(q *FakeQuorum) { q.RemoveMons(mons...) }
+}
+
+// QuorumLost makes the quorum status fail as when no mon is reachable
+func QuorumLost() ScenarioStep {
+	return func(q *FakeQuorum) { q.Fail(errors.New("timed out waiting for the mon quorum")) }
+}
+
+// QuorumRestored makes the quorum status succeed again
+func QuorumRestored() ScenarioStep {
+	return func(q *FakeQuorum) { q.Fail(nil) }
+}
+
+// Unchanged leaves the quorum as it is, for example to let the timeout of a mon expire
+func Unchanged() ScenarioStep {
+	return func(q *FakeQuorum) {}
+}
+
+// Scenario is a script of mon failures, applying one step to the fake quorum before each health
+// check
+type Scenario struct {
+	Quorum *FakeQuorum
+	steps  []ScenarioStep
+	next   int
+}
+
+// NewScenario creates a scenario of the steps applied to the fake quorum
+func NewScenario(quorum *FakeQuorum, steps ...ScenarioStep) *Scenario {
+	return &Scenario{Quorum: quorum, steps: steps}
+}
+
+// Next applies the next step of the scenario, and returns false when all the steps were applied
+func (s *Scenario) Next() bool {
+	if s.next >= len(s.steps) {
+		return false
+	}
+	s.steps[s.next](s.Quorum)
+	s.next++
+	return true
+}
+
+// Run applies each step of the scenario followed by the health check, and stops at the first
+// failure of the check
+func (s *Scenario) Run(check func(step int) error) error {
+	for s.Next() {
+		if err := check(s.next - 1); err != nil {
+			return errors.Wrapf(err, "failed health check at step %d", s.next-1)
+		}
+	}
+	return nil
+}
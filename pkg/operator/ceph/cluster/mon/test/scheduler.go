@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FakeScheduler is a deterministic scheduler of the mon canaries. The canaries are placed on the
+// nodes in turn, in the order the canaries are scheduled.
+type FakeScheduler struct {
+	mutex     sync.Mutex
+	nodes     []*corev1.Node
+	next      int
+	scheduled []string
+	// Err fails the scheduling of the canaries when set
+	Err error
+}
+
+// NewFakeScheduler creates a scheduler placing the canaries on the nodes with the given names. The
+// nodes get the internal IPs 10.0.0.1, 10.0.0.2... in the order of the names.
+func NewFakeScheduler(nodes ...string) *FakeScheduler {
+	s := &FakeScheduler{}
+	for i, name := range nodes {
+		s.nodes = append(s.nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{corev1.LabelHostname: name},
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: fmt.Sprintf("10.0.0.%d", i+1)}},
+			},
+		})
+	}
+	return s
+}
+
+// WaitForScheduling returns the next node for the canary, it implements the scheduler of the mon
+// health checker
+func (s *FakeScheduler) WaitForScheduling(canary *apps.Deployment) (*corev1.Node, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if len(s.nodes) == 0 {
+		return nil, errors.Errorf("no node to schedule canary %q", canary.Name)
+	}
+	node := s.nodes[s.next%len(s.nodes)]
+	s.next++
+	s.scheduled = append(s.scheduled, canary.Name)
+	return node.DeepCopy(), nil
+}
+
+// Scheduled returns the names of the canaries scheduled so far
+func (s *FakeScheduler) Scheduled() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.scheduled...)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// defaultZoneFailbackDelay is how long the nodes of a failed zone must be ready again before its
+// mons are moved back
+var defaultZoneFailbackDelay = 10 * time.Minute
+
+// failedStretchZones returns the zones whose mons were rescheduled into the surviving zones. The
+// zone a mon was rescheduled from is kept in the mon mapping, so the failed zones are known again
+// after an operator restart.
+func (c *Cluster) failedStretchZones() sets.Set[string] {
+	zones := sets.New[string]()
+	if !c.spec.IsStretchCluster() || c.mapping == nil {
+		return zones
+	}
+	for _, schedule := range c.mapping.Schedule {
+		if schedule != nil && schedule.HomeZone != "" {
+			zones.Insert(schedule.HomeZone)
+		}
+	}
+	return zones
+}
+
+// findSurvivingDataZone returns the data zone that is not failed and has the fewest mons
+func (c *Cluster) findSurvivingDataZone(mons []*monConfig, failedZones sets.Set[string]) (string, error) {
+	zoneCount := map[string]int{}
+	for _, m := range mons {
+		zoneCount[m.Zone]++
+	}
+	target := ""
+	for _, zone := range c.getMonZones() {
+		if zone.Arbiter || failedZones.Has(zone.Name) {
+			continue
+		}
+		if target == "" || zoneCount[zone.Name] < zoneCount[target] {
+			target = zone.Name
+		}
+	}
+	if target == "" {
+		return "", errors.New("no surviving data zone is available to reschedule the mons of the failed zones")
+	}
+	return target, nil
+}
+
+// checkStretchZoneFailure reschedules the mons of a data zone into the surviving data zones once
+// all the mons of the zone are out of quorum for the zone failover timeout, and moves them back
+// once the nodes of the zone are ready again. It returns whether a mon was failed over, since
+// only one mon is failed over per health check, and the mons of the zones waiting for the
+// timeout, which must not be failed over individually.
+func (c *Cluster) checkStretchZoneFailure(ctx context.Context, quorumStatus cephclient.MonStatusResponse) (bool, sets.Set[string], error) {
+	waitingMons := sets.New[string]()
+	if !c.spec.IsStretchCluster() {
+		return false, waitingMons, nil
+	}
+	zoneFailover := c.spec.Mon.StretchCluster.ZoneFailover
+	if zoneFailover == nil || !zoneFailover.Enabled {
+		return false, waitingMons, nil
+	}
+
+	timeout := MonOutTimeout
+	if zoneFailover.Timeout != nil {
+		timeout = zoneFailover.Timeout.Duration
+	}
+	if timeout == timeZero {
+		// like the failover of a single mon, a zero timeout disables the zone failover
+		return false, waitingMons, nil
+	}
+
+	inQuorum := sets.New[string]()
+	for _, mon := range quorumStatus.MonMap.Mons {
+		if monInQuorum(mon, quorumStatus.Quorum) {
+			inQuorum.Insert(mon.Name)
+		}
+	}
+
+	mons := c.clusterInfoToMonConfig()
+	failedZones := c.failedStretchZones()
+	for _, zone := range c.spec.Mon.StretchCluster.Zones {
+		if zone.Arbiter {
+			// the arbiter mon is failed over like any other mon, it cannot move to a data zone
+			continue
+		}
+
+		zoneMons := []string{}
+		zoneInQuorum := false
+		for _, m := range mons {
+			if m.Zone != zone.Name {
+				continue
+			}
+			zoneMons = append(zoneMons, m.DaemonName)
+			if inQuorum.Has(m.DaemonName) {
+				zoneInQuorum = true
+			}
+		}
+		if len(zoneMons) == 0 || zoneInQuorum {
+			delete(c.zoneOutOfQuorumSince, zone.Name)
+			continue
+		}
+
+		if !failedZones.Has(zone.Name) {
+			if _, ok := c.zoneOutOfQuorumSince[zone.Name]; !ok {
+				c.zoneOutOfQuorumSince[zone.Name] = time.Now()
+			}
+			if elapsed := time.Since(c.zoneOutOfQuorumSince[zone.Name]); elapsed <= timeout {
+				logger.Warningf("all the mons %v of zone %q are out of quorum, waiting for timeout (%d seconds left) before rescheduling them into the surviving zones",
+					zoneMons, zone.Name, int((timeout - elapsed).Seconds()))
+				waitingMons.Insert(zoneMons...)
+				continue
+			}
+			logger.Warningf("zone %q failed, all its mons %v are out of quorum for more than %s", zone.Name, zoneMons, timeout.String())
+		}
+
+		// reschedule the mons of the failed zone one at a time
+		name := zoneMons[0]
+		target, err := c.findSurvivingDataZone(c.clusterInfoToMonConfigWithExclude(name), failedZones.Clone().Insert(zone.Name))
+		if err != nil {
+			return false, waitingMons, err
+		}
+		logger.Warningf("rescheduling mon %q of failed zone %q into zone %q", name, zone.Name, target)
+		if err := c.failoverMonToZone(name, target, zone.Name); err != nil {
+			return false, waitingMons, errors.Wrapf(err, "failed to reschedule mon %q of failed zone %q", name, zone.Name)
+		}
+		delete(c.zoneOutOfQuorumSince, zone.Name)
+		return true, waitingMons, nil
+	}
+
+	if zoneFailover.DisableFailback || len(failedZones) == 0 || len(inQuorum) != len(quorumStatus.MonMap.Mons) {
+		// only move the mons back while all the mons are healthy
+		return false, waitingMons, nil
+	}
+
+	failbackDelay := defaultZoneFailbackDelay
+	if zoneFailover.FailbackDelay != nil {
+		failbackDelay = zoneFailover.FailbackDelay.Duration
+	}
+	for _, zone := range sets.List(failedZones) {
+		ready, err := c.isZoneReady(ctx, zone)
+		if err != nil {
+			return false, waitingMons, err
+		}
+		if !ready {
+			delete(c.zoneRecoveredSince, zone)
+			continue
+		}
+		if _, ok := c.zoneRecoveredSince[zone]; !ok {
+			c.zoneRecoveredSince[zone] = time.Now()
+		}
+		if elapsed := time.Since(c.zoneRecoveredSince[zone]); elapsed <= failbackDelay {
+			logger.Infof("zone %q recovered, waiting %d seconds before moving its mons back", zone, int((failbackDelay - elapsed).Seconds()))
+			continue
+		}
+
+		for _, name := range sets.List(sets.KeySet(c.mapping.Schedule)) {
+			schedule := c.mapping.Schedule[name]
+			if schedule == nil || schedule.HomeZone != zone {
+				continue
+			}
+			logger.Infof("moving mon %q back into recovered zone %q", name, zone)
+			if err := c.failoverMonToZone(name, zone, ""); err != nil {
+				return false, waitingMons, errors.Wrapf(err, "failed to move mon %q back into zone %q", name, zone)
+			}
+			return true, waitingMons, nil
+		}
+		delete(c.zoneRecoveredSince, zone)
+	}
+	return false, waitingMons, nil
+}
+
+// isZoneReady returns whether a node of the zone is ready to run a mon
+func (c *Cluster) isZoneReady(ctx context.Context, zone string) (bool, error) {
+	nodes, err := c.context.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", GetFailureDomainLabel(c.spec), zone),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list the nodes of zone %q", zone)
+	}
+	for _, node := range nodes.Items {
+		if k8sutil.NodeIsReady(node) && k8sutil.GetNodeSchedulable(node, false) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newZoneFailoverTestCluster(zoneFailover *cephv1.StretchZoneFailoverSpec) *Cluster {
+	spec := cephv1.ClusterSpec{
+		Mon: cephv1.MonSpec{
+			Count: 5,
+			StretchCluster: &cephv1.StretchClusterSpec{
+				Zones: []cephv1.MonZoneSpec{
+					{Name: "a", Arbiter: true},
+					{Name: "b"},
+					{Name: "c"},
+				},
+				ZoneFailover: zoneFailover,
+			},
+		},
+	}
+	c := New(context.TODO(), &clusterd.Context{Clientset: fake.NewSimpleClientset()}, "ns", spec, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	c.ClusterInfo.InternalMonitors = map[string]*cephclient.MonInfo{}
+	for name, zone := range map[string]string{"a": "a", "b": "b", "c": "b", "d": "c", "e": "c"} {
+		c.ClusterInfo.InternalMonitors[name] = &cephclient.MonInfo{Name: name, Endpoint: "1.2.3.4:3300"}
+		c.mapping.Schedule[name] = &opcontroller.MonScheduleInfo{Zone: zone}
+	}
+	return c
+}
+
+// quorumWithout returns a quorum status where the given mons are out of quorum
+func quorumWithout(outOfQuorum ...string) cephclient.MonStatusResponse {
+	status := cephclient.MonStatusResponse{}
+	for rank, name := range []string{"a", "b", "c", "d", "e"} {
+		status.MonMap.Mons = append(status.MonMap.Mons, cephclient.MonMapEntry{Name: name, Rank: rank})
+		if !sets.New(outOfQuorum...).Has(name) {
+			status.Quorum = append(status.Quorum, rank)
+		}
+	}
+	return status
+}
+
+func TestFindAvailableZoneAfterZoneFailure(t *testing.T) {
+	c := newZoneFailoverTestCluster(&cephv1.StretchZoneFailoverSpec{Enabled: true})
+	assert.Empty(t, c.failedStretchZones())
+
+	// mon "d" of zone "c" was rescheduled into zone "b"
+	c.mapping.Schedule["d"] = &opcontroller.MonScheduleInfo{Zone: "b", HomeZone: "c"}
+	assert.Equal(t, sets.New("c"), c.failedStretchZones())
+
+	// the failed zone is never picked, even though it has the fewest mons
+	zone, err := c.findAvailableZone(c.clusterInfoToMonConfigWithExclude("e"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", zone)
+
+	// no data zone survives
+	_, err = c.findSurvivingDataZone(c.clusterInfoToMonConfig(), sets.New("b", "c"))
+	assert.Error(t, err)
+}
+
+func TestCheckStretchZoneFailure(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("disabled", func(t *testing.T) {
+		c := newZoneFailoverTestCluster(nil)
+		failedOver, waitingMons, err := c.checkStretchZoneFailure(ctx, quorumWithout("d", "e"))
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Empty(t, waitingMons)
+
+		c = newZoneFailoverTestCluster(&cephv1.StretchZoneFailoverSpec{Enabled: true, Timeout: &metav1.Duration{}})
+		failedOver, waitingMons, err = c.checkStretchZoneFailure(ctx, quorumWithout("d", "e"))
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Empty(t, waitingMons)
+	})
+
+	t.Run("waiting for the timeout", func(t *testing.T) {
+		c := newZoneFailoverTestCluster(&cephv1.StretchZoneFailoverSpec{Enabled: true, Timeout: &metav1.Duration{Duration: time.Hour}})
+
+		// a single mon of the zone is out of quorum
+		failedOver, waitingMons, err := c.checkStretchZoneFailure(ctx, quorumWithout("d"))
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Empty(t, waitingMons)
+		assert.Empty(t, c.zoneOutOfQuorumSince)
+
+		// all the mons of the zone are out of quorum
+		failedOver, waitingMons, err = c.checkStretchZoneFailure(ctx, quorumWithout("d", "e"))
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Equal(t, sets.New("d", "e"), waitingMons)
+		assert.Contains(t, c.zoneOutOfQuorumSince, "c")
+
+		// the zone is back in quorum
+		failedOver, waitingMons, err = c.checkStretchZoneFailure(ctx, quorumWithout())
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Empty(t, waitingMons)
+		assert.Empty(t, c.zoneOutOfQuorumSince)
+	})
+
+	t.Run("waiting for the zone to recover", func(t *testing.T) {
+		c := newZoneFailoverTestCluster(&cephv1.StretchZoneFailoverSpec{Enabled: true})
+		c.mapping.Schedule["d"] = &opcontroller.MonScheduleInfo{Zone: "b", HomeZone: "c"}
+		c.mapping.Schedule["e"] = &opcontroller.MonScheduleInfo{Zone: "b", HomeZone: "c"}
+
+		// the nodes of the zone are not ready
+		failedOver, _, err := c.checkStretchZoneFailure(ctx, quorumWithout())
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Empty(t, c.zoneRecoveredSince)
+
+		// the nodes of the zone are ready, waiting for the failback delay
+		_, err = c.context.Clientset.CoreV1().Nodes().Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-c", Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "c"}},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		failedOver, _, err = c.checkStretchZoneFailure(ctx, quorumWithout())
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+		assert.Contains(t, c.zoneRecoveredSince, "c")
+
+		// the mons are not moved back while a mon is out of quorum
+		c.zoneRecoveredSince["c"] = time.Now().Add(-time.Hour)
+		failedOver, _, err = c.checkStretchZoneFailure(ctx, quorumWithout("a"))
+		assert.NoError(t, err)
+		assert.False(t, failedOver)
+	})
+}
+
+func TestIsZoneReady(t *testing.T) {
+	ctx := context.TODO()
+	c := newZoneFailoverTestCluster(nil)
+	c.spec.Mon.StretchCluster.FailureDomainLabel = "topology.example.com/site"
+
+	newNode := func(name, zone string, ready, unschedulable bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		_, err := c.context.Clientset.CoreV1().Nodes().Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"topology.example.com/site": zone}},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	newNode("node1", "b", false, false)
+	newNode("node2", "b", true, true)
+	newNode("node3", "c", true, false)
+
+	ready, err := c.isZoneReady(ctx, "b")
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	ready, err = c.isZoneReady(ctx, "c")
+	assert.NoError(t, err)
+	assert.True(t, ready)
+}
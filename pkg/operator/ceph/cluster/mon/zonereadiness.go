@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxZoneExclusionReasons is how many nodes of a zone not schedulable for its mons are detailed in
+// the status of the zone
+const maxZoneExclusionReasons = 3
+
+// checkMonZones validates that the nodes carry the labels of the zones declared for the mons and
+// that each zone has the schedulable nodes to run its mons, so that mislabeled nodes are reported
+// instead of leaving the mons of the zone pending with no explanation
+func (c *Cluster) checkMonZones(ctx context.Context) {
+	if !c.spec.ZonesRequired() {
+		c.monZones = nil
+		return
+	}
+	nodes, err := c.context.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warningf("failed to list the nodes to check the readiness of the mon zones. %v", err)
+		return
+	}
+
+	zones := c.monZoneReadiness(nodes.Items)
+	previous := map[string]bool{}
+	for _, zone := range c.monZones {
+		previous[zone.Name] = zone.Ready
+	}
+	for _, zone := range zones {
+		wasReady, checked := previous[zone.Name]
+		if !zone.Ready && (!checked || wasReady) {
+			logger.Warningf("mon zone %q is not ready. %s", zone.Name, zone.Message)
+			c.recordEvent(v1.EventTypeWarning, MonZoneNotReadyReason, "mon zone %q is not ready. %s", zone.Name, zone.Message)
+		} else if zone.Ready && checked && !wasReady {
+			logger.Infof("mon zone %q is ready", zone.Name)
+			c.recordEvent(v1.EventTypeNormal, MonZoneReadyReason, "mon zone %q is ready", zone.Name)
+		}
+	}
+	c.monZones = zones
+}
+
+// monZoneReadiness returns the readiness of each zone of the mons: the nodes with the failure domain
+// label of the zone, and how many of them are ready, not cordoned, and match the placement of the
+// mons of the zone
+func (c *Cluster) monZoneReadiness(nodes []v1.Node) []cephv1.MonZoneStatus {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	label := GetFailureDomainLabel(c.spec)
+	zones := c.getMonZones()
+
+	var statuses []cephv1.MonZoneStatus
+	for _, zone := range zones {
+		status := cephv1.MonZoneStatus{Name: zone.Name, RequiredNodes: c.monNodesRequiredInZone(zone, len(zones))}
+		mon := &monConfig{Zone: zone.Name}
+		placement := c.getZonePlacement(zone.Name)
+		var reasons []string
+		for i := range nodes {
+			if nodes[i].Labels[label] != zone.Name {
+				continue
+			}
+			status.Nodes++
+			if reason := c.monNodeExclusionReason(&nodes[i], mon, placement); reason != "" {
+				reasons = append(reasons, fmt.Sprintf("node %q %s", nodes[i].Name, reason))
+				continue
+			}
+			status.SchedulableNodes++
+		}
+
+		status.Ready = status.SchedulableNodes >= status.RequiredNodes
+		switch {
+		case status.Nodes == 0:
+			status.Message = fmt.Sprintf("no node has the label %q with the value %q", label, zone.Name)
+		case !status.Ready:
+			if len(reasons) > maxZoneExclusionReasons {
+				reasons = append(reasons[:maxZoneExclusionReasons], fmt.Sprintf("%d more", len(reasons)-maxZoneExclusionReasons))
+			}
+			status.Message = fmt.Sprintf("%d of the %d nodes in the zone are schedulable for the mons, %d required: %s",
+				status.SchedulableNodes, status.Nodes, status.RequiredNodes, strings.Join(reasons, ", "))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// monNodesRequiredInZone returns the number of nodes needed to run the mons expected in the zone.
+// The arbiter zone of a stretch cluster runs a single mon and the mons are spread evenly across the
+// other zones.
+func (c *Cluster) monNodesRequiredInZone(zone cephv1.MonZoneSpec, zoneCount int) int {
+	if !requiredDuringScheduling(&c.spec) || zone.Arbiter {
+		return 1
+	}
+	count := c.spec.Mon.Count
+	if c.spec.IsStretchCluster() {
+		// one of the mons runs in the arbiter zone
+		count--
+		zoneCount--
+	}
+	if zoneCount <= 0 || count <= 0 {
+		return 1
+	}
+	return (count + zoneCount - 1) / zoneCount
+}
+
+// reportMonZones publishes the readiness of the mon zones on the CephCluster status when it changed
+func (c *Cluster) reportMonZones() {
+	if reflect.DeepEqual(c.monZones, c.monZonesReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the readiness of the mon zones. %v", err)
+		return
+	}
+	cephCluster.Status.MonZones = c.monZones
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the readiness of the mon zones in the CephCluster status. %v", err)
+		return
+	}
+	c.monZonesReported = c.monZones
+}
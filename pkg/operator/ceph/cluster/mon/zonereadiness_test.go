@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMonZoneReadiness(t *testing.T) {
+	c := &Cluster{spec: cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 5, StretchCluster: &cephv1.StretchClusterSpec{
+		Zones: []cephv1.MonZoneSpec{{Name: "a", Arbiter: true}, {Name: "b"}, {Name: "c"}},
+	}}}}
+	cordoned := testSchedulingNode("node4", "c")
+	cordoned.Spec.Unschedulable = true
+	nodes := []v1.Node{
+		testSchedulingNode("node1", "a"),
+		testSchedulingNode("node2", "b"),
+		testSchedulingNode("node3", "b"),
+		cordoned,
+		testSchedulingNode("node5", "c"),
+		// the zone label is misspelled
+		testSchedulingNode("node6", "C"),
+	}
+
+	zones := c.monZoneReadiness(nodes)
+	assert.Equal(t, []cephv1.MonZoneStatus{
+		{Name: "a", Nodes: 1, SchedulableNodes: 1, RequiredNodes: 1, Ready: true},
+		{Name: "b", Nodes: 2, SchedulableNodes: 2, RequiredNodes: 2, Ready: true},
+		{Name: "c", Nodes: 2, SchedulableNodes: 1, RequiredNodes: 2, Ready: false,
+			Message: `1 of the 2 nodes in the zone are schedulable for the mons, 2 required: node "node4" is cordoned`},
+	}, zones)
+
+	// a single node is enough with multiple mons per node
+	c.spec.Mon.AllowMultiplePerNode = true
+	assert.True(t, c.monZoneReadiness(nodes)[2].Ready)
+
+	// the zones of the mons without a stretch cluster
+	c.spec.Mon = cephv1.MonSpec{Count: 3, Zones: []cephv1.MonZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "d"}}}
+	zones = c.monZoneReadiness(nodes)
+	require.Len(t, zones, 3)
+	assert.True(t, zones[0].Ready)
+	assert.False(t, zones[2].Ready)
+	assert.Equal(t, `no node has the label "topology.kubernetes.io/zone" with the value "d"`, zones[2].Message)
+}
+
+func TestCheckMonZones(t *testing.T) {
+	clusterInfo := clienttest.CreateTestClusterInfo(3)
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	node1 := testSchedulingNode("node1", "a")
+	node2 := testSchedulingNode("node2", "b")
+	clientset := k8sfake.NewSimpleClientset(&node1, &node2)
+	recorder := record.NewFakeRecorder(10)
+	c := &Cluster{
+		ClusterInfo: clusterInfo,
+		Namespace:   "ns",
+		context:     &clusterd.Context{Client: cl, Clientset: clientset},
+		recorder:    recorder,
+		spec:        cephv1.ClusterSpec{Mon: cephv1.MonSpec{Count: 3, Zones: []cephv1.MonZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "c"}}}},
+	}
+	ctx := context.TODO()
+
+	// the zone without nodes is reported
+	c.checkMonZones(ctx)
+	require.Len(t, c.monZones, 3)
+	assert.False(t, c.monZones[2].Ready)
+	assert.Contains(t, <-recorder.Events, MonZoneNotReadyReason)
+	c.reportMonZones()
+	require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+	require.Len(t, cephCluster.Status.MonZones, 3)
+	assert.False(t, cephCluster.Status.MonZones[2].Ready)
+
+	// the event is not recorded again while the zone is still not ready
+	c.checkMonZones(ctx)
+	assert.Len(t, recorder.Events, 0)
+
+	// the zone is ready once a node is labeled
+	node3 := testSchedulingNode("node3", "c")
+	_, err := clientset.CoreV1().Nodes().Create(ctx, &node3, metav1.CreateOptions{})
+	require.NoError(t, err)
+	c.checkMonZones(ctx)
+	assert.True(t, c.monZones[2].Ready)
+	assert.Contains(t, <-recorder.Events, MonZoneReadyReason)
+	c.reportMonZones()
+	require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+	assert.True(t, cephCluster.Status.MonZones[2].Ready)
+
+	// the status is cleared without zones
+	c.spec.Mon.Zones = nil
+	c.checkMonZones(ctx)
+	c.reportMonZones()
+	require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+	assert.Empty(t, cephCluster.Status.MonZones)
+}
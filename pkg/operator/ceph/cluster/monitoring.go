@@ -22,12 +22,13 @@ import (
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mgr"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 )
 
-var monitorDaemonList = []string{"mon", "osd", "status"}
+var monitorDaemonList = []string{"mon", "osd", "status", "placement", "mgr"}
 
 func (c *ClusterController) configureCephMonitoring(cluster *cluster, clusterInfo *cephclient.ClusterInfo) {
 	var isEnabled bool
@@ -58,6 +59,13 @@ func (c *ClusterController) configureCephMonitoring(cluster *cluster, clusterInf
 			}
 		}
 	}
+
+	// The migration of the OSDs to PVCs and the scale down of the device sets may be requested
+	// after the OSD monitoring started
+	if c.osdChecker != nil {
+		c.osdChecker.UpdatePVCMigration(cluster.Spec, c.rookImage)
+		c.osdChecker.UpdateScaleDown(cluster.Spec.Storage.StorageClassDeviceSets)
+	}
 }
 
 func isMonitoringEnabled(daemon string, clusterSpec *cephv1.ClusterSpec) bool {
@@ -70,6 +78,13 @@ func isMonitoringEnabled(daemon string, clusterSpec *cephv1.ClusterSpec) bool {
 
 	case "status":
 		return !clusterSpec.HealthCheck.DaemonHealth.Status.Disabled
+
+	case "placement":
+		placementDrift := clusterSpec.HealthCheck.PlacementDrift
+		return placementDrift != nil && placementDrift.Enabled && !clusterSpec.External.Enable
+
+	case "mgr":
+		return !clusterSpec.External.Enable
 	}
 
 	return false
@@ -85,6 +100,7 @@ func (c *ClusterController) startMonitoringCheck(cluster *cluster, clusterInfo *
 	case "osd":
 		if !cluster.Spec.External.Enable {
 			c.osdChecker = osd.NewOSDHealthMonitor(c.context, clusterInfo, cluster.Spec.RemoveOSDsIfOutAndSafeToRemove, cluster.Spec.HealthCheck)
+			c.osdChecker.SetEventRecorder(c.recorder)
 			logger.Infof("enabling ceph %s monitoring goroutine for cluster %q", daemon, cluster.Namespace)
 			go c.osdChecker.Start(cluster.monitoringRoutines, daemon)
 		}
@@ -93,5 +109,19 @@ func (c *ClusterController) startMonitoringCheck(cluster *cluster, clusterInfo *
 		cephChecker := newCephStatusChecker(c.context, clusterInfo, cluster.Spec)
 		logger.Infof("enabling ceph %s monitoring goroutine for cluster %q", daemon, cluster.Namespace)
 		go cephChecker.checkCephStatus(cluster.monitoringRoutines, daemon)
+
+	case "placement":
+		var mons monFailoverRequester
+		if cluster.mons != nil {
+			mons = cluster.mons
+		}
+		placementChecker := newPlacementDriftChecker(c.context, clusterInfo, cluster.Spec.HealthCheck.PlacementDrift, mons)
+		logger.Infof("enabling ceph %s monitoring goroutine for cluster %q", daemon, cluster.Namespace)
+		go placementChecker.checkPlacementDrift(cluster.monitoringRoutines, daemon)
+
+	case "mgr":
+		activeMgrWatcher := mgr.NewActiveMgrWatcher(c.context, clusterInfo)
+		logger.Infof("enabling ceph %s monitoring goroutine for cluster %q", daemon, cluster.Namespace)
+		go activeMgrWatcher.Watch(cluster.monitoringRoutines, daemon)
 	}
 }
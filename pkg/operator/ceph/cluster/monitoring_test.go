@@ -34,7 +34,12 @@ func TestIsMonitoringEnabled(t *testing.T) {
 		want bool
 	}{
 		{"isEnabled", args{"mon", &cephv1.ClusterSpec{}}, true},
-		{"isDisabled", args{"mon", &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.HealthCheckSpec{Disabled: true}}}}}, false},
+		{"isDisabled", args{"mon", &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.MonHealthCheckSpec{HealthCheckSpec: cephv1.HealthCheckSpec{Disabled: true}}}}}}, false},
+		{"placementDisabledByDefault", args{"placement", &cephv1.ClusterSpec{}}, false},
+		{"placementEnabled", args{"placement", &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{PlacementDrift: &cephv1.PlacementDriftSpec{Enabled: true}}}}, true},
+		{"placementExternal", args{"placement", &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}, HealthCheck: cephv1.CephClusterHealthCheckSpec{PlacementDrift: &cephv1.PlacementDriftSpec{Enabled: true}}}}, false},
+		{"mgrEnabled", args{"mgr", &cephv1.ClusterSpec{}}, true},
+		{"mgrExternal", args{"mgr", &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
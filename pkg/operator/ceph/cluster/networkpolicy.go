@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mgr"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/nodedaemon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/rbd"
+	"github.com/rook/rook/pkg/operator/ceph/file/mirror"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// the default range of ports (ms_bind_port_min and ms_bind_port_max) that the osd, mgr and mds
+	// daemons bind to for the messenger
+	cephMsgrPortMin int32 = 6800
+	cephMsgrPortMax int32 = 7568
+
+	// the mds, rgw and nfs app names are defined by the file, object and nfs controllers, which the
+	// cluster controller does not import
+	mdsAppName = "rook-ceph-mds"
+	rgwAppName = "rook-ceph-rgw"
+	nfsAppName = "rook-ceph-nfs"
+
+	// the ports of the nfs-ganesha server and of its metrics
+	nfsPort        int32 = 2049
+	nfsMetricsPort int32 = 9587
+
+	exporterAppName = "rook-ceph-exporter"
+
+	// namespaceNameLabel is set by kubernetes on every namespace with the name of the namespace
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	// the label identifying the network policies generated by rook
+	managedByLabel    = "app.kubernetes.io/managed-by"
+	managedByOperator = "rook-ceph-operator"
+)
+
+// networkPolicyApps is the list of apps for which a network policy is generated. The names of the
+// policies are the same as the app names.
+var networkPolicyApps = []string{
+	mon.AppName,
+	mgr.AppName,
+	osd.AppName,
+	mdsAppName,
+	rgwAppName,
+	nodedaemon.CrashCollectorAppName,
+	exporterAppName,
+	nfsAppName,
+	rbd.AppName,
+	mirror.AppName,
+}
+
+// reconcileNetworkPolicies creates or updates the network policies for the ceph daemons if they are
+// enabled in the cluster CR, or removes them if they are disabled
+func (c *cluster) reconcileNetworkPolicies() error {
+	if !c.Spec.Network.NetworkPoliciesEnabled() {
+		c.removeNetworkPolicies()
+		return nil
+	}
+	if c.Spec.Network.IsHost() {
+		logger.Warning("network policies are enabled, but are not generated since they do not apply to pods with host networking")
+		c.removeNetworkPolicies()
+		return nil
+	}
+
+	for _, policy := range c.generateNetworkPolicies() {
+		if err := c.ownerInfo.SetControllerReference(policy); err != nil {
+			return errors.Wrapf(err, "failed to set owner reference on network policy %q", policy.Name)
+		}
+		if _, err := k8sutil.CreateOrUpdateNetworkPolicy(c.ClusterInfo.Context, c.context.Clientset, policy); err != nil {
+			return errors.Wrapf(err, "failed to reconcile network policy %q", policy.Name)
+		}
+	}
+	logger.Infof("reconciled network policies for the ceph daemons in namespace %q", c.Namespace)
+	return nil
+}
+
+// removeNetworkPolicies removes the network policies generated by rook. The policies created by
+// users with the same names are kept. The failures are only logged since the policies do not
+// prevent the daemons from running.
+func (c *cluster) removeNetworkPolicies() {
+	selector := fmt.Sprintf("%s=%s", k8sutil.ClusterAttr, c.Namespace)
+	policies, err := c.context.Clientset.NetworkingV1().NetworkPolicies(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logger.Warningf("failed to list the network policies to remove in namespace %q. %v", c.Namespace, err)
+		return
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !slices.Contains(networkPolicyApps, policy.Name) || !isGeneratedNetworkPolicy(policy) {
+			continue
+		}
+		if err := k8sutil.DeleteNetworkPolicy(c.ClusterInfo.Context, c.context.Clientset, c.Namespace, policy.Name); err != nil {
+			logger.Warningf("failed to remove the network policy of the ceph daemons. %v", err)
+			continue
+		}
+		logger.Infof("removed network policy %q", policy.Name)
+	}
+}
+
+// isGeneratedNetworkPolicy returns whether the network policy was generated by rook, labeled as
+// managed by the operator or owned by the CephCluster
+func isGeneratedNetworkPolicy(policy *networkingv1.NetworkPolicy) bool {
+	if policy.Labels[managedByLabel] == managedByOperator {
+		return true
+	}
+	for _, owner := range policy.OwnerReferences {
+		if owner.Kind == "CephCluster" && strings.HasPrefix(owner.APIVersion, cephv1.CustomResourceGroup+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// generateNetworkPolicies builds the network policies allowing only the ingress flows required by
+// each type of ceph daemon. Egress is not restricted.
+func (c *cluster) generateNetworkPolicies() []*networkingv1.NetworkPolicy {
+	msgrRange := portRange(cephMsgrPortMin, cephMsgrPortMax)
+	cephPeers := c.cephNetworkPolicyPeers()
+
+	// the ceph daemons, the operator and the csi driver connect to the mons on the msgr ports
+	monPorts := []networkingv1.NetworkPolicyPort{tcpPort(mon.Msgr2Port(c.Spec))}
+	if !c.Spec.RequireMsgr2() {
+		monPorts = append(monPorts, tcpPort(mon.Msgr1Port(c.Spec)))
+	}
+
+	// the metrics and the dashboard are reached from outside of the cluster, for example by prometheus
+	mgrServicePorts := []networkingv1.NetworkPolicyPort{tcpPort(int32(mgr.DefaultMetricsPort))}
+	if c.Spec.Dashboard.Enabled {
+		mgrServicePorts = append(mgrServicePorts, tcpPort(int32(mgr.DashboardInternalPort(c.Spec.Dashboard)))) // nolint:gosec // G115 port numbers will not overflow an int32
+	}
+
+	var rgwIngressFrom []networkingv1.NetworkPolicyPeer
+	if c.Spec.Network.NetworkPolicies != nil {
+		rgwIngressFrom = c.Spec.Network.NetworkPolicies.RGWIngressFrom
+	}
+
+	msgrRule := networkingv1.NetworkPolicyIngressRule{From: cephPeers, Ports: []networkingv1.NetworkPolicyPort{msgrRange}}
+	return []*networkingv1.NetworkPolicy{
+		c.newNetworkPolicy(mon.AppName, []networkingv1.NetworkPolicyIngressRule{{From: cephPeers, Ports: monPorts}}),
+		c.newNetworkPolicy(mgr.AppName, []networkingv1.NetworkPolicyIngressRule{msgrRule, {Ports: mgrServicePorts}}),
+		c.newNetworkPolicy(osd.AppName, []networkingv1.NetworkPolicyIngressRule{msgrRule}),
+		c.newNetworkPolicy(mdsAppName, []networkingv1.NetworkPolicyIngressRule{msgrRule}),
+		// the rgw ports are defined by each object store, so only the sources are restricted
+		c.newNetworkPolicy(rgwAppName, []networkingv1.NetworkPolicyIngressRule{{From: rgwIngressFrom}}),
+		// the nfs clients are outside of the cluster
+		c.newNetworkPolicy(nfsAppName, []networkingv1.NetworkPolicyIngressRule{{Ports: []networkingv1.NetworkPolicyPort{tcpPort(nfsPort), tcpPort(nfsMetricsPort)}}}),
+		c.newNetworkPolicy(exporterAppName, []networkingv1.NetworkPolicyIngressRule{{Ports: []networkingv1.NetworkPolicyPort{tcpPort(int32(nodedaemon.DefaultMetricsPort))}}}),
+		// the crash collector and the mirroring daemons only connect to other daemons and never
+		// accept connections
+		c.newNetworkPolicy(nodedaemon.CrashCollectorAppName, []networkingv1.NetworkPolicyIngressRule{}),
+		c.newNetworkPolicy(rbd.AppName, []networkingv1.NetworkPolicyIngressRule{}),
+		c.newNetworkPolicy(mirror.AppName, []networkingv1.NetworkPolicyIngressRule{}),
+	}
+}
+
+// cephNetworkPolicyPeers returns the sources allowed to connect to the ceph daemons with the ceph
+// protocol: the daemons of the cluster, the pods of the operator namespace (the operator and the
+// csi driver), and the clients configured in the cluster CR
+func (c *cluster) cephNetworkPolicyPeers() []networkingv1.NetworkPolicyPeer {
+	peers := []networkingv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{k8sutil.ClusterAttr: c.Namespace}}},
+	}
+	if operatorNamespace := os.Getenv(k8sutil.PodNamespaceEnvVar); operatorNamespace != "" {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: operatorNamespace}},
+		})
+	}
+	if c.Spec.Network.NetworkPolicies != nil {
+		peers = append(peers, c.Spec.Network.NetworkPolicies.ClientIngressFrom...)
+	}
+	return peers
+}
+
+func (c *cluster) newNetworkPolicy(app string, ingress []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app,
+			Namespace: c.Namespace,
+			Labels: map[string]string{
+				k8sutil.ClusterAttr: c.Namespace,
+				managedByLabel:      managedByOperator,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{k8sutil.AppAttr: app},
+			},
+			Ingress:     ingress,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	cephv1.GetClusterMetadataAnnotations(c.Spec.Annotations).ApplyToObjectMeta(&policy.ObjectMeta)
+	return policy
+}
+
+func tcpPort(port int32) networkingv1.NetworkPolicyPort {
+	p := intstr.FromInt32(port)
+	return networkingv1.NetworkPolicyPort{
+		Protocol: ptr.To(corev1.ProtocolTCP),
+		Port:     &p,
+	}
+}
+
+func portRange(start, end int32) networkingv1.NetworkPolicyPort {
+	p := tcpPort(start)
+	p.EndPort = ptr.To(end)
+	return p
+}
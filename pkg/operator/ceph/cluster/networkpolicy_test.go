@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReconcileNetworkPolicies(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	clientset := fake.NewSimpleClientset()
+	c := &cluster{
+		ClusterInfo:    cephclient.AdminTestClusterInfo(namespace),
+		Namespace:      namespace,
+		Spec:           &cephv1.ClusterSpec{},
+		context:        &clusterd.Context{Clientset: clientset},
+		namespacedName: types.NamespacedName{Namespace: namespace, Name: "my-cluster"},
+		ownerInfo:      k8sutil.NewOwnerInfoWithOwnerRef(&metav1.OwnerReference{}, ""),
+	}
+
+	listPolicies := func() []networkingv1.NetworkPolicy {
+		policies, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		return policies.Items
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Empty(t, listPolicies())
+	})
+
+	t.Run("policies of the users are kept", func(t *testing.T) {
+		userPolicies := []*networkingv1.NetworkPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon", Namespace: namespace}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mgr", Namespace: namespace, Labels: map[string]string{"rook_cluster": namespace}}},
+		}
+		for _, policy := range userPolicies {
+			_, err := clientset.NetworkingV1().NetworkPolicies(namespace).Create(ctx, policy, metav1.CreateOptions{})
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Len(t, listPolicies(), 2)
+
+		// the policies owned by the cluster are removed
+		userPolicies[1].OwnerReferences = []metav1.OwnerReference{{APIVersion: "ceph.rook.io/v1", Kind: "CephCluster", Name: "my-cluster"}}
+		_, err := clientset.NetworkingV1().NetworkPolicies(namespace).Update(ctx, userPolicies[1], metav1.UpdateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		policies := listPolicies()
+		assert.Len(t, policies, 1)
+		assert.Equal(t, "rook-ceph-mon", policies[0].Name)
+		assert.NoError(t, clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, "rook-ceph-mon", metav1.DeleteOptions{}))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv(k8sutil.PodNamespaceEnvVar, "rook-operator")
+		c.Spec.Network.NetworkPolicies = &cephv1.NetworkPoliciesSpec{Enabled: true}
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Len(t, listPolicies(), len(networkPolicyApps))
+
+		monPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-mon", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "rook-ceph-mon", monPolicy.Spec.PodSelector.MatchLabels["app"])
+		assert.Equal(t, "rook-ceph-operator", monPolicy.Labels["app.kubernetes.io/managed-by"])
+		assert.Len(t, monPolicy.Spec.Ingress, 1)
+		// msgr2 and msgr1 ports
+		assert.Len(t, monPolicy.Spec.Ingress[0].Ports, 2)
+		// the daemons of the cluster and the operator namespace
+		from := monPolicy.Spec.Ingress[0].From
+		assert.Len(t, from, 2)
+		assert.Equal(t, map[string]string{"rook_cluster": namespace}, from[0].PodSelector.MatchLabels)
+		assert.Nil(t, from[0].NamespaceSelector)
+		assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "rook-operator"}, from[1].NamespaceSelector.MatchLabels)
+
+		osdPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-osd", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, from, osdPolicy.Spec.Ingress[0].From)
+
+		for _, app := range []string{"rook-ceph-crashcollector", "rook-ceph-rbd-mirror", "rook-ceph-fs-mirror"} {
+			policy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, app, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Empty(t, policy.Spec.Ingress, app)
+		}
+
+		nfsPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-nfs", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, nfsPolicy.Spec.Ingress[0].From)
+		assert.Equal(t, int32(2049), nfsPolicy.Spec.Ingress[0].Ports[0].Port.IntVal)
+	})
+
+	t.Run("client sources", func(t *testing.T) {
+		c.Spec.Network.NetworkPolicies.ClientIngressFrom = []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/24"}},
+		}
+		assert.NoError(t, c.reconcileNetworkPolicies())
+
+		monPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-mon", metav1.GetOptions{})
+		assert.NoError(t, err)
+		from := monPolicy.Spec.Ingress[0].From
+		// no operator namespace is set in this test
+		assert.Len(t, from, 2)
+		assert.Equal(t, "192.168.0.0/24", from[1].IPBlock.CIDR)
+		c.Spec.Network.NetworkPolicies.ClientIngressFrom = nil
+	})
+
+	t.Run("msgr2 required and dashboard enabled", func(t *testing.T) {
+		c.Spec.Network.Connections = &cephv1.ConnectionsSpec{RequireMsgr2: true}
+		c.Spec.Dashboard = cephv1.DashboardSpec{Enabled: true, SSL: true}
+		assert.NoError(t, c.reconcileNetworkPolicies())
+
+		monPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-mon", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, monPolicy.Spec.Ingress[0].Ports, 1)
+		assert.Equal(t, int32(3300), monPolicy.Spec.Ingress[0].Ports[0].Port.IntVal)
+
+		mgrPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-mgr", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, mgrPolicy.Spec.Ingress, 2)
+		// the msgr ports are restricted to the ceph sources
+		ports := mgrPolicy.Spec.Ingress[0].Ports
+		assert.Len(t, ports, 1)
+		assert.NotEmpty(t, mgrPolicy.Spec.Ingress[0].From)
+		assert.Equal(t, int32(6800), ports[0].Port.IntVal)
+		assert.Equal(t, int32(7568), *ports[0].EndPort)
+		// the metrics and dashboard ports are open to any source
+		ports = mgrPolicy.Spec.Ingress[1].Ports
+		assert.Len(t, ports, 2)
+		assert.Empty(t, mgrPolicy.Spec.Ingress[1].From)
+		assert.Equal(t, int32(8443), ports[1].Port.IntVal)
+	})
+
+	t.Run("rgw ingress sources", func(t *testing.T) {
+		c.Spec.Network.NetworkPolicies.RGWIngressFrom = []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+		}
+		assert.NoError(t, c.reconcileNetworkPolicies())
+
+		rgwPolicy, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "rook-ceph-rgw", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, rgwPolicy.Spec.Ingress[0].From, 1)
+		assert.Empty(t, rgwPolicy.Spec.Ingress[0].Ports)
+	})
+
+	t.Run("removed with host networking", func(t *testing.T) {
+		c.Spec.Network.Provider = cephv1.NetworkProviderHost
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Empty(t, listPolicies())
+		c.Spec.Network.Provider = cephv1.NetworkProviderDefault
+	})
+
+	t.Run("removed when disabled", func(t *testing.T) {
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Len(t, listPolicies(), len(networkPolicyApps))
+
+		c.Spec.Network.NetworkPolicies.Enabled = false
+		assert.NoError(t, c.reconcileNetworkPolicies())
+		assert.Empty(t, listPolicies())
+	})
+}
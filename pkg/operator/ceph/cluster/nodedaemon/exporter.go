@@ -35,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -45,6 +46,7 @@ const (
 	defaultPrioLimit                 = "5"
 	defaultStatsPeriod               = "5"
 	DefaultMetricsPort        uint16 = 9926
+	DefaultMetricsTLSPort     uint16 = 9927
 	exporterServiceMetricName        = "ceph-exporter-http-metrics"
 	exporterKeyringUsername          = "client.ceph-exporter"
 	exporterKeyName                  = "rook-ceph-exporter-keyring"
@@ -52,6 +54,12 @@ const (
 
 var MinVersionForCephExporter = cephver.CephVersion{Major: 18, Minor: 0, Extra: 0}
 
+func init() {
+	controller.RegisterFieldImpacts(&cephv1.CephCluster{},
+		controller.FieldImpact{Field: "spec.monitoring.tls", Resources: []string{"deployment/rook-ceph-exporter-*", "service/rook-ceph-exporter", "servicemonitor/rook-ceph-exporter"}, Restart: true},
+	)
+}
+
 // createOrUpdateCephExporter is a wrapper around controllerutil.CreateOrUpdate
 func (r *ReconcileNode) createOrUpdateCephExporter(node corev1.Node, tolerations []corev1.Toleration, cephCluster cephv1.CephCluster, cephVersion *cephver.CephVersion) (controllerutil.OperationResult, error) {
 	// CephVersion change is done temporarily, as some regression was detected in Ceph version 17.2.6 which is summarised here https://github.com/ceph/ceph/pull/50718#issuecomment-1505608312.
@@ -145,6 +153,11 @@ func (r *ReconcileNode) createOrUpdateCephExporter(node corev1.Node, tolerations
 				ServiceAccountName:            k8sutil.DefaultServiceAccount,
 			},
 		}
+		// If the metrics are served over TLS we add the proxy side-car container
+		if controller.MetricsTLSEnabled(cephCluster.Spec.Monitoring) {
+			deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, controller.MetricsProxyContainer(cephCluster.Spec, DefaultMetricsTLSPort, DefaultMetricsPort))
+			deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, controller.MetricsProxyVolumes(cephCluster.Spec.Monitoring)...)
+		}
 		cephv1.GetCephExporterAnnotations(cephCluster.Spec.Annotations).ApplyToObjectMeta(&deploy.Spec.Template.ObjectMeta)
 		applyPrometheusAnnotations(cephCluster, &deploy.Spec.Template.ObjectMeta)
 
@@ -190,8 +203,11 @@ func getCephExporterDaemonContainer(cephCluster cephv1.CephCluster, cephVersion
 		"--stats-period", statsPeriod,
 	}
 
-	// If DualStack or IPv6 is enabled ensure ceph-exporter binds to both IPv6 and IPv4 interfaces.
-	if cephCluster.Spec.Network.DualStack || cephCluster.Spec.Network.IPFamily == "IPv6" {
+	// If the metrics are served over TLS by the proxy side-car, ensure ceph-exporter binds only to the localhost.
+	// Otherwise if DualStack or IPv6 is enabled ensure ceph-exporter binds to both IPv6 and IPv4 interfaces.
+	if controller.MetricsTLSEnabled(cephCluster.Spec.Monitoring) {
+		args = append(args, "--addrs", controller.MetricsUpstreamAddr)
+	} else if cephCluster.Spec.Network.DualStack || cephCluster.Spec.Network.IPFamily == "IPv6" {
 		args = append(args, "--addrs", "::")
 	}
 
@@ -239,6 +255,10 @@ func MakeCephExporterMetricsService(cephCluster cephv1.CephCluster, servicePortM
 			Selector: labels,
 		},
 	}
+	// the metrics served over TLS are scraped from the proxy side-car
+	if controller.MetricsTLSEnabled(cephCluster.Spec.Monitoring) {
+		svc.Spec.Ports[0].TargetPort = intstr.FromString(controller.MetricsTLSPortName)
+	}
 
 	err := controllerutil.SetControllerReference(&cephCluster, svc, scheme)
 	if err != nil {
@@ -255,10 +275,14 @@ func EnableCephExporterServiceMonitor(context *clusterd.Context, cephCluster cep
 		duration := cephCluster.Spec.Monitoring.Interval.Duration.String()
 		serviceMonitor.Spec.Endpoints[0].Interval = monitoringv1.Duration(duration)
 	}
+	err := controller.ApplyMetricsTLSToEndpoint(opManagerContext, context.Clientset, cephCluster.Namespace, cephExporterAppName, cephCluster.Spec.Monitoring, &serviceMonitor.Spec.Endpoints[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to configure the tls of the service monitor")
+	}
 
 	cephv1.GetCephExporterLabels(cephCluster.Spec.Labels).OverwriteApplyToObjectMeta(&serviceMonitor.ObjectMeta)
 
-	err := controllerutil.SetControllerReference(&cephCluster, serviceMonitor, scheme)
+	err = controllerutil.SetControllerReference(&cephCluster, serviceMonitor, scheme)
 	if err != nil {
 		return errors.Wrapf(err, "failed to set owner reference to service monitor %q", serviceMonitor.Name)
 	}
@@ -293,12 +317,7 @@ func applyCephExporterLabels(cephCluster cephv1.CephCluster, serviceMonitor *mon
 
 func applyPrometheusAnnotations(cephCluster cephv1.CephCluster, objectMeta *metav1.ObjectMeta) {
 	if len(cephv1.GetCephExporterAnnotations(cephCluster.Spec.Annotations)) == 0 {
-		t := cephv1.Annotations{
-			"prometheus.io/scrape": "true",
-			"prometheus.io/port":   strconv.Itoa(int(DefaultMetricsPort)),
-		}
-
-		t.ApplyToObjectMeta(objectMeta)
+		controller.ApplyMetricsPrometheusAnnotations(cephCluster.Spec.Monitoring, DefaultMetricsPort, DefaultMetricsTLSPort, objectMeta)
 	}
 }
 
@@ -144,6 +144,32 @@ func TestCreateOrUpdateCephExporter(t *testing.T) {
 		assert.Equal(t, "--stats-period", args[6])
 		assert.Equal(t, "7", args[7])
 	})
+
+	t.Run("metrics tls", func(t *testing.T) {
+		cephCluster.Spec.Monitoring.TLS = &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls", ClientCASecretName: "metrics-client-ca"}
+		res, err := r.createOrUpdateCephExporter(node, tolerations, cephCluster, cephVersion)
+		assert.NoError(t, err)
+		assert.Equal(t, controllerutil.OperationResult("updated"), res)
+
+		err = r.client.Get(ctx, types.NamespacedName{Namespace: "rook-ceph", Name: name}, &deploy)
+		assert.NoError(t, err)
+
+		podSpec := deploy.Spec.Template
+		assert.Len(t, podSpec.Spec.Containers, 2)
+		proxy := podSpec.Spec.Containers[1]
+		assert.Equal(t, "metrics-proxy", proxy.Name)
+		assert.Contains(t, proxy.Args, "--upstream=http://127.0.0.1:9926/")
+		assert.Contains(t, proxy.Args, "--client-ca-file=/etc/rook/metrics-client-ca/ca.crt")
+		assert.Equal(t, int32(DefaultMetricsTLSPort), proxy.Ports[0].ContainerPort)
+		secrets := []string{}
+		for _, volume := range podSpec.Spec.Volumes {
+			if volume.Secret != nil {
+				secrets = append(secrets, volume.Secret.SecretName)
+			}
+		}
+		assert.Contains(t, secrets, "metrics-tls")
+		assert.Contains(t, secrets, "metrics-client-ca")
+	})
 }
 
 func TestCephExporterBindAddress(t *testing.T) {
@@ -354,6 +380,27 @@ func TestServiceSpec(t *testing.T) {
 	assert.Equal(t, 2, len(s.Spec.Selector))
 }
 
+func TestCephExporterMetricsTLS(t *testing.T) {
+	cephCluster := cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "rook-ceph"}}
+	cephCluster.Spec.Network.DualStack = true
+	cephCluster.Spec.Monitoring.TLS = &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"}
+	cephVersion := cephver.CephVersion{Major: 18, Minor: 0, Extra: 0}
+
+	// the exporter only serves the metrics to the proxy on the localhost
+	exporterContainer := getCephExporterDaemonContainer(cephCluster, cephVersion)
+	assert.Equal(t, []string{"--addrs", "127.0.0.1"}, exporterContainer.Args[8:])
+
+	s, err := MakeCephExporterMetricsService(cephCluster, exporterServiceMetricName, scheme.Scheme)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(DefaultMetricsPort), s.Spec.Ports[0].Port)
+	assert.Equal(t, "https-metrics", s.Spec.Ports[0].TargetPort.String())
+
+	objectMeta := metav1.ObjectMeta{}
+	applyPrometheusAnnotations(cephCluster, &objectMeta)
+	assert.Equal(t, "9927", objectMeta.Annotations["prometheus.io/port"])
+	assert.Equal(t, "https", objectMeta.Annotations["prometheus.io/scheme"])
+}
+
 func TestApplyCephExporterLabels(t *testing.T) {
 	cephCluster := cephv1.CephCluster{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "rook-ceph"},
@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "rook_ceph"
+	metricsSubsystem = "notifications"
+
+	resultSent   = "sent"
+	resultFailed = "failed"
+)
+
+// The metrics of the notifier, exposed on the metrics endpoint of the operator
+var sent = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsSubsystem,
+	Name:      "total",
+	Help:      "Number of events sent to the notification sinks by sink and result",
+}, []string{"sink", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(sent)
+}
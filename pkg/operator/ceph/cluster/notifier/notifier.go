@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifier sends the events recorded by the operator on the custom resources to the
+// webhooks, Slack channels and PagerDuty services configured by the admins, so that the critical
+// operator actions reach humans without an alerting pipeline.
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	controllerName = "ceph-notifier"
+
+	sinksSecretSetting = "ROOK_NOTIFICATION_SINKS_SECRET"
+	// sinksKey is the key of the secret with the yaml list of the sinks
+	sinksKey = "sinks"
+	// the sinks are reloaded from the secret at most every minute
+	sinksTTL    = time.Minute
+	sendTimeout = 10 * time.Second
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+// SinkSpec is a destination of the notifications with the filters of the events sent to it
+type SinkSpec struct {
+	// Name identifies the sink in the logs and the metrics
+	Name string `json:"name"`
+	// Type is the type of the sink: webhook, slack or pagerduty
+	Type string `json:"type"`
+	// URL is the url of the webhook, of the Slack incoming webhook, or of the PagerDuty events API
+	URL string `json:"url,omitempty"`
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string `json:"routingKey,omitempty"`
+	// Severity is the lowest type of the events sent to the sink: Warning (default) or Normal
+	Severity string `json:"severity,omitempty"`
+	// Kinds are the kinds of the resources whose events are sent, all kinds when empty
+	Kinds []string `json:"kinds,omitempty"`
+	// Reasons are the reasons of the events that are sent, all reasons when empty
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Notification is the event sent to the sinks, and the body of the generic webhooks
+type Notification struct {
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Severity  string    `json:"severity"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+}
+
+// notifier sends the events of the custom resources to the sinks of the secret
+type notifier struct {
+	context    *clusterd.Context
+	namespace  string
+	secretName string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mutex    sync.Mutex
+	sinks    []SinkSpec
+	loadedAt time.Time
+}
+
+// Add watches the events of the custom resources while the operator is the leader, and sends
+// them to the sinks when a sinks secret is configured
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		secretName := k8sutil.GetOperatorSetting(sinksSecretSetting, "")
+		if secretName == "" {
+			logger.Debugf("the notifications are disabled, %q is not set", sinksSecretSetting)
+			return nil
+		}
+		n := &notifier{
+			context:    context,
+			namespace:  opConfig.OperatorNamespace,
+			secretName: secretName,
+			httpClient: &http.Client{Timeout: sendTimeout},
+			now:        time.Now,
+		}
+		n.run(ctx, opConfig.NamespaceToWatch)
+		return nil
+	}))
+}
+
+func (n *notifier) run(ctx context.Context, namespace string) {
+	logger.Infof("sending the events of the custom resources to the sinks of secret %q", n.secretName)
+	factory := informers.NewSharedInformerFactoryWithOptions(n.context.Clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = "involvedObject.apiVersion=" + cephv1.SchemeGroupVersion.String()
+		}))
+	informer := factory.Core().V1().Events().Informer()
+
+	// the events recorded before the start were already notified by the previous leader, or are
+	// too old to be relevant
+	started := n.now().Truncate(time.Second)
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			event, ok := obj.(*v1.Event)
+			if !ok || eventTime(event).Before(started) {
+				return
+			}
+			n.notify(ctx, event)
+		},
+	})
+	if err != nil {
+		logger.Errorf("failed to watch the events. %v", err)
+		return
+	}
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+}
+
+// notify sends the event to the sinks whose filters match it
+func (n *notifier) notify(ctx context.Context, event *v1.Event) {
+	notification := Notification{
+		Kind:      event.InvolvedObject.Kind,
+		Namespace: event.InvolvedObject.Namespace,
+		Name:      event.InvolvedObject.Name,
+		Severity:  event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Time:      eventTime(event),
+	}
+	for _, sink := range n.loadSinks(ctx) {
+		if !sink.matches(notification) {
+			continue
+		}
+		if err := n.send(ctx, sink, notification); err != nil {
+			logger.Warningf("failed to send the %q event of %s %s/%s to sink %q. %v", notification.Reason, notification.Kind, notification.Namespace, notification.Name, sink.Name, err)
+			sent.WithLabelValues(sink.Name, resultFailed).Inc()
+			continue
+		}
+		logger.Debugf("sent the %q event of %s %s/%s to sink %q", notification.Reason, notification.Kind, notification.Namespace, notification.Name, sink.Name)
+		sent.WithLabelValues(sink.Name, resultSent).Inc()
+	}
+}
+
+// loadSinks returns the valid sinks of the secret, which is read again when the sinks are older
+// than the TTL. The previous sinks are kept when the secret cannot be read.
+func (n *notifier) loadSinks(ctx context.Context) []SinkSpec {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if !n.loadedAt.IsZero() && n.now().Sub(n.loadedAt) < sinksTTL {
+		return n.sinks
+	}
+
+	sinks, err := n.readSinks(ctx)
+	if err != nil {
+		logger.Warningf("failed to load the notification sinks. %v", err)
+		return n.sinks
+	}
+	n.sinks = sinks
+	n.loadedAt = n.now()
+	return n.sinks
+}
+
+func (n *notifier) readSinks(ctx context.Context) ([]SinkSpec, error) {
+	secret, err := n.context.Clientset.CoreV1().Secrets(n.namespace).Get(ctx, n.secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %q", n.secretName)
+	}
+	var specs []SinkSpec
+	if err := yaml.Unmarshal(secret.Data[sinksKey], &specs); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the %q key of secret %q", sinksKey, n.secretName)
+	}
+	sinks := []SinkSpec{}
+	for _, sink := range specs {
+		if err := sink.validate(); err != nil {
+			logger.Warningf("ignoring notification sink %q. %v", sink.Name, err)
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+func (s *SinkSpec) validate() error {
+	switch s.Type {
+	case sinkTypeWebhook, sinkTypeSlack:
+		if s.URL == "" {
+			return errors.Errorf("the url of the %s sink is not set", s.Type)
+		}
+	case sinkTypePagerDuty:
+		if s.RoutingKey == "" {
+			return errors.New("the routing key of the pagerduty sink is not set")
+		}
+	default:
+		return errors.Errorf("unknown sink type %q", s.Type)
+	}
+	switch s.Severity {
+	case "", v1.EventTypeWarning, v1.EventTypeNormal:
+	default:
+		return errors.Errorf("invalid severity %q, expected %q or %q", s.Severity, v1.EventTypeWarning, v1.EventTypeNormal)
+	}
+	return nil
+}
+
+// matches returns whether the severity, the kind and the reason of the notification pass the
+// filters of the sink
+func (s *SinkSpec) matches(notification Notification) bool {
+	// only the warnings are sent by default, the normal events are sent too with the Normal severity
+	if s.Severity != v1.EventTypeNormal && notification.Severity != v1.EventTypeWarning {
+		return false
+	}
+	if len(s.Kinds) > 0 && !slices.Contains(s.Kinds, notification.Kind) {
+		return false
+	}
+	if len(s.Reasons) > 0 && !slices.Contains(s.Reasons, notification.Reason) {
+		return false
+	}
+	return true
+}
+
+// eventTime returns the last time the event was recorded
+func eventTime(event *v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
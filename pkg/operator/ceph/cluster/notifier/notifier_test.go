@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNotify(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// the bodies received by the sinks, by path
+	received := map[string][]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		received[r.URL.Path] = append(received[r.URL.Path], body)
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sinks := fmt.Sprintf(`
+- name: hook
+  type: webhook
+  url: %[1]s/hook
+  severity: Normal
+- name: slack
+  type: slack
+  url: %[1]s/slack
+  kinds: [CephCluster]
+- name: pager
+  type: pagerduty
+  url: %[1]s/pager
+  routingKey: key
+  reasons: [MonFailoverStarted]
+- name: broken
+  type: webhook
+  url: %[1]s/broken
+- name: invalid
+  type: email
+`, server.URL)
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sinks", Namespace: "rook-ceph"},
+		Data:       map[string][]byte{sinksKey: []byte(sinks)},
+	}
+	clientset := fake.NewSimpleClientset(secret)
+	n := &notifier{
+		context:    &clusterd.Context{Clientset: clientset},
+		namespace:  "rook-ceph",
+		secretName: "sinks",
+		httpClient: server.Client(),
+		now:        func() time.Time { return now },
+	}
+	event := func(kind, eventType, reason string) *v1.Event {
+		return &v1.Event{
+			InvolvedObject: v1.ObjectReference{Kind: kind, Namespace: "rook-ceph", Name: "my-cluster"},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        "failing over mon a",
+			LastTimestamp:  metav1.NewTime(now),
+		}
+	}
+
+	// a warning of the cluster goes to all the valid sinks
+	n.notify(ctx, event("CephCluster", v1.EventTypeWarning, "MonFailoverStarted"))
+	require.Len(t, received["/hook"], 1)
+	assert.Equal(t, map[string]interface{}{
+		"kind": "CephCluster", "namespace": "rook-ceph", "name": "my-cluster", "severity": "Warning",
+		"reason": "MonFailoverStarted", "message": "failing over mon a", "time": "2025-03-01T10:00:00Z",
+	}, received["/hook"][0])
+	require.Len(t, received["/slack"], 1)
+	assert.Equal(t, "[Warning] CephCluster rook-ceph/my-cluster MonFailoverStarted: failing over mon a", received["/slack"][0]["text"])
+	require.Len(t, received["/pager"], 1)
+	assert.Equal(t, "key", received["/pager"][0]["routing_key"])
+	assert.Equal(t, "trigger", received["/pager"][0]["event_action"])
+	payload := received["/pager"][0]["payload"].(map[string]interface{})
+	assert.Equal(t, "warning", payload["severity"])
+	assert.Equal(t, "rook-ceph/my-cluster", payload["source"])
+	assert.Len(t, received["/broken"], 1)
+
+	// the normal events only go to the sinks with the Normal severity, and the kinds and reasons
+	// filter the events
+	n.notify(ctx, event("CephCluster", v1.EventTypeNormal, "CephUpgradeCompleted"))
+	n.notify(ctx, event("CephBlockPool", v1.EventTypeWarning, "ReconcileFailed"))
+	assert.Len(t, received["/hook"], 3)
+	assert.Len(t, received["/slack"], 1)
+	assert.Len(t, received["/pager"], 1)
+	assert.Len(t, received["/broken"], 2)
+
+	// the sinks are reloaded from the secret after the ttl
+	secret.Data[sinksKey] = []byte(fmt.Sprintf("[{name: slack, type: slack, url: %s/slack}]", server.URL))
+	_, err := clientset.CoreV1().Secrets("rook-ceph").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Len(t, n.loadSinks(ctx), 4)
+	now = now.Add(2 * sinksTTL)
+	loaded := n.loadSinks(ctx)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, "slack", loaded[0].Name)
+
+	// the previous sinks are kept when the secret is not valid
+	secret.Data[sinksKey] = []byte("not a list")
+	_, err = clientset.CoreV1().Secrets("rook-ceph").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	now = now.Add(2 * sinksTTL)
+	assert.Len(t, n.loadSinks(ctx), 1)
+}
+
+func TestSinkMatches(t *testing.T) {
+	warning := Notification{Kind: "CephCluster", Severity: v1.EventTypeWarning, Reason: "MonFailoverStarted"}
+	normal := Notification{Kind: "CephCluster", Severity: v1.EventTypeNormal, Reason: "CephUpgradeCompleted"}
+
+	sink := SinkSpec{}
+	assert.True(t, sink.matches(warning))
+	assert.False(t, sink.matches(normal))
+
+	sink.Severity = v1.EventTypeNormal
+	assert.True(t, sink.matches(normal))
+
+	sink.Kinds = []string{"CephObjectStore"}
+	assert.False(t, sink.matches(warning))
+	sink.Kinds = append(sink.Kinds, "CephCluster")
+	assert.True(t, sink.matches(warning))
+
+	sink.Reasons = []string{"CephUpgradeCompleted"}
+	assert.False(t, sink.matches(warning))
+	assert.True(t, sink.matches(normal))
+}
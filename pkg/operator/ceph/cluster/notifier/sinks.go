@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	sinkTypeWebhook   = "webhook"
+	sinkTypeSlack     = "slack"
+	sinkTypePagerDuty = "pagerduty"
+
+	defaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// slackMessage is the body of the Slack incoming webhooks
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// pagerDutyEvent is the body of the PagerDuty events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string       `json:"summary"`
+	Source        string       `json:"source"`
+	Severity      string       `json:"severity"`
+	Timestamp     string       `json:"timestamp"`
+	Component     string       `json:"component"`
+	Class         string       `json:"class"`
+	CustomDetails Notification `json:"custom_details"`
+}
+
+// send posts the notification to the sink in the format of its type
+func (n *notifier) send(ctx context.Context, sink SinkSpec, notification Notification) error {
+	url := sink.URL
+	var body interface{}
+	switch sink.Type {
+	case sinkTypeWebhook:
+		body = notification
+	case sinkTypeSlack:
+		body = slackMessage{Text: notification.summary()}
+	case sinkTypePagerDuty:
+		if url == "" {
+			url = defaultPagerDutyURL
+		}
+		severity := "info"
+		if notification.Severity == v1.EventTypeWarning {
+			severity = "warning"
+		}
+		body = pagerDutyEvent{
+			RoutingKey:  sink.RoutingKey,
+			EventAction: "trigger",
+			Payload: pagerDutyPayload{
+				Summary:       notification.summary(),
+				Source:        fmt.Sprintf("%s/%s", notification.Namespace, notification.Name),
+				Severity:      severity,
+				Timestamp:     notification.Time.UTC().Format(time.RFC3339),
+				Component:     notification.Kind,
+				Class:         notification.Reason,
+				CustomDetails: notification,
+			},
+		}
+	default:
+		return errors.Errorf("unknown sink type %q", sink.Type)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the notification")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post the notification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// the error details of the sinks are in the body of the response
+		details, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.Errorf("the sink returned status %d: %s", resp.StatusCode, string(details))
+	}
+	return nil
+}
+
+// summary is the one line text of the notification for the chat and paging sinks
+func (n Notification) summary() string {
+	return fmt.Sprintf("[%s] %s %s/%s %s: %s", n.Severity, n.Kind, n.Namespace, n.Name, n.Reason, n.Message)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"strconv"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	compressionModeOption        = "bluestore_compression_mode"
+	compressionAlgorithmOption   = "bluestore_compression_algorithm"
+	compressionMinBlobSizeOption = "bluestore_compression_min_blob_size"
+)
+
+// compressionConfigured returns whether the storage spec, one of its nodes or one of its device
+// sets has compression settings
+func (c *Cluster) compressionConfigured() bool {
+	if c.ValidStorage.Compression != nil {
+		return true
+	}
+	for _, node := range c.ValidStorage.Nodes {
+		if node.Compression != nil {
+			return true
+		}
+	}
+	for _, deviceSet := range c.deviceSets {
+		if deviceSet.Compression != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// applyOSDCompression sets the bluestore compression settings of the node or the device set of
+// each OSD in the ceph config of the OSD. Only the settings that differ from the ceph config are
+// set. Like the settings of the cephConfig of the cluster, a setting removed from the spec is left
+// in the ceph config.
+func (c *Cluster) applyOSDCompression(deployments *appsv1.DeploymentList) {
+	if !c.compressionConfigured() {
+		return
+	}
+
+	monStore := opconfig.GetMonStore(c.context, c.clusterInfo)
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			logger.Warningf("failed to apply the compression settings of deployment %q. %v", d.Name, err)
+			continue
+		}
+		nodeOrPVCName, err := getNodeOrPVCName(d)
+		if err != nil {
+			logger.Warningf("failed to apply the compression settings of osd.%d. %v", osdID, err)
+			continue
+		}
+		var osdProps osdProperties
+		if osdIsOnPVC(d) {
+			osdProps, err = c.getOSDPropsForPVC(nodeOrPVCName)
+		} else {
+			osdProps, err = c.getOSDPropsForNode(nodeOrPVCName, d.Labels[deviceClass])
+		}
+		if err != nil {
+			logger.Warningf("failed to apply the compression settings of osd.%d. %v", osdID, err)
+			continue
+		}
+		settings := compressionSettings(osdProps.compression)
+		if len(settings) == 0 {
+			continue
+		}
+
+		who := fmt.Sprintf("osd.%d", osdID)
+		current, err := monStore.GetDaemon(who)
+		if err != nil {
+			logger.Warningf("failed to apply the compression settings of %s. %v", who, err)
+			continue
+		}
+		for _, option := range current {
+			if settings[option.Option] == option.Value {
+				delete(settings, option.Option)
+			}
+		}
+		for option, value := range settings {
+			if err := monStore.Set(who, option, value); err != nil {
+				logger.Warningf("failed to set %s=%s on %s. %v", option, value, who, err)
+				continue
+			}
+			logger.Infof("set the compression setting %s=%s on %s", option, value, who)
+		}
+	}
+}
+
+// compressionSettings returns the ceph config options of the compression settings
+func compressionSettings(compression *cephv1.OSDCompressionSpec) map[string]string {
+	settings := map[string]string{}
+	if compression == nil {
+		return settings
+	}
+	if compression.Mode != "" {
+		settings[compressionModeOption] = compression.Mode
+	}
+	if compression.Algorithm != "" {
+		settings[compressionAlgorithmOption] = compression.Algorithm
+	}
+	if compression.MinBlobSize != nil {
+		settings[compressionMinBlobSizeOption] = strconv.FormatInt(compression.MinBlobSize.Value(), 10)
+	}
+	return settings
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyOSDCompression(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	newDeployment := func(osdID int, nodeName string, labels map[string]string) {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentName(osdID), Namespace: clusterInfo.Namespace, Labels: map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", osdID)}},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				NodeSelector: map[string]string{k8sutil.LabelHostname(): nodeName},
+			}}},
+		}
+		for k, v := range labels {
+			d.Labels[k] = v
+		}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	newDeployment(0, "node1", nil)
+	newDeployment(1, "node2", nil)
+	newDeployment(2, "node3", map[string]string{OSDOverPVCLabelKey: "set1-data-0"})
+
+	// osd.0 already has the compression mode of its node
+	config := map[string]map[string]string{"osd.0": {"bluestore_compression_mode": "aggressive"}}
+	var sets []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch args[1] {
+			case "get":
+				options := []string{}
+				for option, value := range config[args[2]] {
+					options = append(options, fmt.Sprintf(`%q:{"value":%q,"section":%q}`, option, value, args[2]))
+				}
+				return fmt.Sprintf("{%s}", strings.Join(options, ",")), nil
+			case "set":
+				sets = append(sets, strings.Join(args[2:5], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	minBlobSize := resource.MustParse("64Ki")
+	c := &Cluster{
+		context:     &clusterd.Context{Clientset: clientset, Executor: executor},
+		clusterInfo: clusterInfo,
+		ValidStorage: cephv1.StorageScopeSpec{
+			Selection: cephv1.Selection{Compression: &cephv1.OSDCompressionSpec{Mode: "passive", Algorithm: "lz4"}},
+			Nodes: []cephv1.Node{
+				{Name: "node1", Selection: cephv1.Selection{Compression: &cephv1.OSDCompressionSpec{Mode: "aggressive"}}},
+				{Name: "node2"},
+			},
+		},
+		deviceSets: []deviceSet{{
+			Name:        "set1",
+			PVCSources:  map[string]v1.PersistentVolumeClaimVolumeSource{bluestorePVCData: {ClaimName: "set1-data-0"}},
+			Portable:    true,
+			Compression: &cephv1.OSDCompressionSpec{Algorithm: "zstd", MinBlobSize: &minBlobSize},
+		}},
+	}
+	deployments, err := c.getOSDDeployments()
+	require.NoError(t, err)
+
+	// the settings of the node override the settings of the cluster, and only the changed settings are set
+	c.applyOSDCompression(deployments)
+	assert.ElementsMatch(t, []string{
+		"osd.1 bluestore_compression_mode passive",
+		"osd.1 bluestore_compression_algorithm lz4",
+		"osd.2 bluestore_compression_algorithm zstd",
+		"osd.2 bluestore_compression_min_blob_size 65536",
+	}, sets)
+
+	// nothing is set without compression settings
+	sets = nil
+	c.ValidStorage = cephv1.StorageScopeSpec{Nodes: []cephv1.Node{{Name: "node1"}, {Name: "node2"}}}
+	c.deviceSets[0].Compression = nil
+	c.applyOSDCompression(deployments)
+	assert.Empty(t, sets)
+}
@@ -21,31 +21,46 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 )
 
 const (
-	WalSizeMBKey       = "walSizeMB"
-	DatabaseSizeMBKey  = "databaseSizeMB"
-	OSDsPerDeviceKey   = "osdsPerDevice"
-	EncryptedDeviceKey = "encryptedDevice"
-	MetadataDeviceKey  = "metadataDevice"
-	DeviceClassKey     = "deviceClass"
-	InitialWeightKey   = "initialWeight"
-	PrimaryAffinityKey = "primaryAffinity"
+	WalSizeMBKey           = "walSizeMB"
+	DatabaseSizeMBKey      = "databaseSizeMB"
+	OSDsPerDeviceKey       = "osdsPerDevice"
+	OSDsPerDeviceModeKey   = "osdsPerDeviceMode"
+	EncryptedDeviceKey     = "encryptedDevice"
+	MetadataDeviceKey      = "metadataDevice"
+	MetadataDeviceSlotsKey = "metadataDeviceSlots"
+	ZapPurgedOSDsKey       = "zapPurgedOSDs"
+	DeviceClassKey         = "deviceClass"
+	InitialWeightKey       = "initialWeight"
+	PrimaryAffinityKey     = "primaryAffinity"
+)
+
+const (
+	// OSDsPerDeviceModeLVM creates the OSDs of a device as LVM logical volumes with ceph-volume lvm
+	OSDsPerDeviceModeLVM = "lvm"
+	// OSDsPerDeviceModeRaw carves a device into a partition per OSD and creates the OSDs with
+	// ceph-volume raw
+	OSDsPerDeviceModeRaw = "raw"
 )
 
 // StoreConfig represents the configuration of an OSD on a device.
 type StoreConfig struct {
-	WalSizeMB       int    `json:"walSizeMB,omitempty"`
-	DatabaseSizeMB  int    `json:"databaseSizeMB,omitempty"`
-	OSDsPerDevice   int    `json:"osdsPerDevice,omitempty"`
-	EncryptedDevice bool   `json:"encryptedDevice,omitempty"`
-	MetadataDevice  string `json:"metadataDevice,omitempty"`
-	DeviceClass     string `json:"deviceClass,omitempty"`
-	InitialWeight   string `json:"initialWeight,omitempty"`
-	PrimaryAffinity string `json:"primaryAffinity,omitempty"`
-	StoreType       string `json:"storeType,omitempty"`
+	WalSizeMB           int    `json:"walSizeMB,omitempty"`
+	DatabaseSizeMB      int    `json:"databaseSizeMB,omitempty"`
+	OSDsPerDevice       int    `json:"osdsPerDevice,omitempty"`
+	OSDsPerDeviceMode   string `json:"osdsPerDeviceMode,omitempty"`
+	EncryptedDevice     bool   `json:"encryptedDevice,omitempty"`
+	MetadataDevice      string `json:"metadataDevice,omitempty"`
+	MetadataDeviceSlots int    `json:"metadataDeviceSlots,omitempty"`
+	ZapPurgedOSDs       bool   `json:"zapPurgedOSDs,omitempty"`
+	DeviceClass         string `json:"deviceClass,omitempty"`
+	InitialWeight       string `json:"initialWeight,omitempty"`
+	PrimaryAffinity     string `json:"primaryAffinity,omitempty"`
+	StoreType           string `json:"storeType,omitempty"`
 }
 
 func (s StoreConfig) IsValidStoreType() bool {
@@ -81,10 +96,16 @@ func ToStoreConfig(config map[string]string) StoreConfig {
 			if i > 0 { // only allow values 1 or more to be set
 				storeConfig.OSDsPerDevice = i
 			}
+		case OSDsPerDeviceModeKey:
+			storeConfig.OSDsPerDeviceMode = v
 		case EncryptedDeviceKey:
 			storeConfig.EncryptedDevice = (v == "true")
 		case MetadataDeviceKey:
 			storeConfig.MetadataDevice = v
+		case MetadataDeviceSlotsKey:
+			storeConfig.MetadataDeviceSlots = convertToIntIgnoreErr(v)
+		case ZapPurgedOSDsKey:
+			storeConfig.ZapPurgedOSDs = (v == "true")
 		case DeviceClassKey:
 			storeConfig.DeviceClass = v
 		case InitialWeightKey:
@@ -97,6 +118,15 @@ func ToStoreConfig(config map[string]string) StoreConfig {
 	return storeConfig
 }
 
+// ValidateOSDsPerDeviceMode returns an error if the mode of the OSDs per device is unknown
+func ValidateOSDsPerDeviceMode(mode string) error {
+	switch mode {
+	case "", OSDsPerDeviceModeLVM, OSDsPerDeviceModeRaw:
+		return nil
+	}
+	return errors.Errorf("invalid osds per device mode %q, must be %q or %q", mode, OSDsPerDeviceModeLVM, OSDsPerDeviceModeRaw)
+}
+
 func MetadataDevice(config map[string]string) string {
 	for k, v := range config {
 		switch k {
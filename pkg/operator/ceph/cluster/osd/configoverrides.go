@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// osdConfigOverridesConfigMap records the options set by the OSD config overrides, so that the
+	// options removed from the overrides are removed from the mon store
+	osdConfigOverridesConfigMap = "rook-ceph-osd-config-overrides"
+	osdConfigOverridesKey       = "overrides"
+)
+
+// ApplyOSDConfigOverrides sets the options of the OSD config overrides in the mon store, for the OSD
+// IDs and with a device class mask for the device classes, and removes the options of the previous
+// overrides that are no longer set
+func ApplyOSDConfigOverrides(context *clusterd.Context, clusterInfo *client.ClusterInfo, overrides []cephv1.OSDConfigOverride) error {
+	desired, err := osdConfigOverrideSections(overrides)
+	if err != nil {
+		return err
+	}
+	applied, err := getAppliedOSDConfigOverrides(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(desired, applied) {
+		return nil
+	}
+
+	monStore := opconfig.GetMonStore(context, clusterInfo)
+	for _, who := range sortedSections(applied) {
+		for _, option := range sortedKeys(applied[who]) {
+			if _, ok := desired[who][option]; ok {
+				continue
+			}
+			if err := monStore.Delete(who, option); err != nil {
+				return errors.Wrapf(err, "failed to remove the osd config override %q of %q", option, who)
+			}
+		}
+	}
+	// the options are set one by one since the sections with a mask cannot be assimilated
+	for _, who := range sortedSections(desired) {
+		for _, option := range sortedKeys(desired[who]) {
+			value := desired[who][option]
+			if previous, ok := applied[who][option]; ok && previous == value {
+				continue
+			}
+			if err := monStore.Set(who, option, value); err != nil {
+				return errors.Wrapf(err, "failed to set the osd config override %q of %q", option, who)
+			}
+		}
+	}
+	logger.Infof("applied the osd config overrides of %v", sortedSections(desired))
+	return saveAppliedOSDConfigOverrides(context, clusterInfo, desired)
+}
+
+// osdConfigOverrideSections returns the options of the overrides by mon store section, osd.<id> for
+// the OSD IDs and osd/class:<device class> for the device classes. The options of a section
+// overridden more than once are merged, the later overrides taking precedence.
+func osdConfigOverrideSections(overrides []cephv1.OSDConfigOverride) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	add := func(who string, options map[string]string) {
+		if sections[who] == nil {
+			sections[who] = map[string]string{}
+		}
+		for option, value := range normalizeOptions(options) {
+			sections[who][option] = value
+		}
+	}
+	for i, override := range overrides {
+		if len(override.OSDs) == 0 && override.DeviceClass == "" {
+			return nil, errors.Errorf("osd config override %d selects no osd, set its osds or its device class", i)
+		}
+		if len(override.Config) == 0 {
+			continue
+		}
+		for _, osdID := range override.OSDs {
+			add(fmt.Sprintf("osd.%d", osdID), override.Config)
+		}
+		if override.DeviceClass != "" {
+			add(fmt.Sprintf("osd/class:%s", override.DeviceClass), override.Config)
+		}
+	}
+	return sections, nil
+}
+
+func sortedSections(sections map[string]map[string]string) []string {
+	keys := make([]string, 0, len(sections))
+	for key := range sections {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func getAppliedOSDConfigOverrides(context *clusterd.Context, clusterInfo *client.ClusterInfo) (map[string]map[string]string, error) {
+	applied := map[string]map[string]string{}
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, osdConfigOverridesConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return applied, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get configmap %q", osdConfigOverridesConfigMap)
+	}
+	if err := json.Unmarshal([]byte(cm.Data[osdConfigOverridesKey]), &applied); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the osd config overrides from configmap %q", osdConfigOverridesConfigMap)
+	}
+	return applied, nil
+}
+
+func saveAppliedOSDConfigOverrides(context *clusterd.Context, clusterInfo *client.ClusterInfo, applied map[string]map[string]string) error {
+	raw, err := json.Marshal(applied)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the osd config overrides")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: osdConfigOverridesConfigMap, Namespace: clusterInfo.Namespace},
+		Data:       map[string]string{osdConfigOverridesKey: string(raw)},
+	}
+	if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on configmap %q", osdConfigOverridesConfigMap)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", osdConfigOverridesConfigMap)
+	}
+	return nil
+}
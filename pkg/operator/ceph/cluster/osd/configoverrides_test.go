@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyOSDConfigOverrides(t *testing.T) {
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "config" && (args[1] == "set" || args[1] == "rm") {
+				commands = append(commands, strings.Join(args[1:], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	context := &clusterd.Context{Clientset: fake.NewSimpleClientset(), Executor: executor}
+	apply := func(overrides []cephv1.OSDConfigOverride) {
+		commands = nil
+		require.NoError(t, ApplyOSDConfigOverrides(context, clusterInfo, overrides))
+	}
+
+	// the options are set for the osd ids and with a mask for the device class
+	overrides := []cephv1.OSDConfigOverride{
+		{OSDs: []int{3, 1}, Config: map[string]string{"osd recovery max active": "5"}},
+		{DeviceClass: "ssd", Config: map[string]string{"osd_recovery_max_active": "3", "osd_max_backfills": "2"}},
+	}
+	apply(overrides)
+	assert.Equal(t, []string{
+		"set osd.1 osd_recovery_max_active 5",
+		"set osd.3 osd_recovery_max_active 5",
+		"set osd/class:ssd osd_max_backfills 2",
+		"set osd/class:ssd osd_recovery_max_active 3",
+	}, commands)
+
+	// nothing changes if the overrides are the same
+	apply(overrides)
+	assert.Empty(t, commands)
+
+	// only the changed options are set, and the options no longer set are removed
+	overrides = []cephv1.OSDConfigOverride{
+		{OSDs: []int{1}, Config: map[string]string{"osd_recovery_max_active": "5"}},
+		{DeviceClass: "ssd", Config: map[string]string{"osd_max_backfills": "4"}},
+	}
+	apply(overrides)
+	assert.Equal(t, []string{
+		"rm osd.3 osd_recovery_max_active",
+		"rm osd/class:ssd osd_recovery_max_active",
+		"set osd/class:ssd osd_max_backfills 4",
+	}, commands)
+
+	apply(nil)
+	assert.Equal(t, []string{"rm osd.1 osd_recovery_max_active", "rm osd/class:ssd osd_max_backfills"}, commands)
+
+	// an override must select osds
+	err := ApplyOSDConfigOverrides(context, clusterInfo, []cephv1.OSDConfigOverride{{Config: map[string]string{"osd_max_backfills": "1"}}})
+	assert.Error(t, err)
+}
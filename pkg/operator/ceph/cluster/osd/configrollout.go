@@ -0,0 +1,405 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// cephConfigRolloutConfigMap records the rollout of the osd config through the canary OSD
+	cephConfigRolloutConfigMap = "rook-ceph-config-rollout"
+	cephConfigRolloutKey       = "rollout"
+	defaultConfigRolloutSoak   = 10 * time.Minute
+	defaultMaxLatencyRatio     = 3
+	// minCommitLatencyMs is the commit latency below which the canary is never considered regressed,
+	// the latencies of idle OSDs are too close to zero to be compared
+	minCommitLatencyMs = 1
+
+	configRolloutSoaking    = "Soaking"
+	configRolloutRolledBack = "RolledBack"
+	configRolloutRolledOut  = "RolledOut"
+)
+
+// configRollout is the state of the rollout of the osd config through the canary OSD
+type configRollout struct {
+	// Options are all the options of the osd section of the CephConfig
+	Options map[string]string `json:"options"`
+	// Changed are the options applied to the canary
+	Changed map[string]string `json:"changed,omitempty"`
+	// Previous are the values set for the canary itself before the rollout, an empty value if it
+	// had none
+	Previous        map[string]string `json:"previous,omitempty"`
+	Canary          int               `json:"canary"`
+	Started         time.Time         `json:"started"`
+	SoakTime        time.Duration     `json:"soakTime"`
+	MaxLatencyRatio int               `json:"maxLatencyRatio"`
+	Phase           string            `json:"phase"`
+}
+
+func (r *configRollout) canaryName() string {
+	return fmt.Sprintf("osd.%d", r.Canary)
+}
+
+// ApplyOSDConfig applies the options of the osd section of the CephConfig. With the rollout enabled,
+// the changed options are applied to a canary OSD only, and to all the OSDs by the OSD health
+// monitor once the canary stayed healthy for the soak time.
+func ApplyOSDConfig(context *clusterd.Context, clusterInfo *client.ClusterInfo, options map[string]string, spec *cephv1.CephConfigRolloutSpec) error {
+	monStore := opconfig.GetMonStore(context, clusterInfo)
+	options = normalizeOptions(options)
+	rollout, err := getConfigRollout(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+	if rollout != nil && rollout.Phase != configRolloutRolledOut {
+		if spec != nil && spec.Enabled && reflect.DeepEqual(rollout.Options, options) {
+			logger.Debugf("the osd config is already being rolled out to the canary %s (%s)", rollout.canaryName(), rollout.Phase)
+			return nil
+		}
+		// the options changed or the rollout was disabled since the rollout started
+		logger.Infof("aborting the rollout of the osd config to the canary %s", rollout.canaryName())
+		if err := restoreCanaryConfig(monStore, rollout); err != nil {
+			return errors.Wrapf(err, "failed to abort the rollout of the osd config to the canary %s", rollout.canaryName())
+		}
+		if rollout.Phase == configRolloutRolledBack {
+			updateConditionFunc(clusterInfo.Context, context, clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionCephConfigRolledBack,
+				v1.ConditionFalse, cephv1.CephConfigRolledOutReason, "the osd config rolled back on the canary was replaced")
+		}
+		if err := deleteConfigRollout(context, clusterInfo); err != nil {
+			return err
+		}
+		rollout = nil
+	}
+
+	if spec == nil || !spec.Enabled {
+		if rollout != nil {
+			// the options applied while the rollout is disabled are not recorded
+			if err := deleteConfigRollout(context, clusterInfo); err != nil {
+				return err
+			}
+		}
+		if len(options) == 0 {
+			return nil
+		}
+		return monStore.SetAll("osd", options)
+	}
+
+	changed, err := changedOSDOptions(monStore, rollout, options)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	canary, found, err := selectCanaryOSD(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+	if !found {
+		logger.Infof("no osd is up to roll out the osd config to, applying it to all the osds")
+		return monStore.SetAll("osd", options)
+	}
+
+	rollout = &configRollout{
+		Options:         options,
+		Changed:         changed,
+		Previous:        map[string]string{},
+		Canary:          canary,
+		Started:         time.Now(),
+		SoakTime:        defaultConfigRolloutSoak,
+		MaxLatencyRatio: defaultMaxLatencyRatio,
+		Phase:           configRolloutSoaking,
+	}
+	if spec.SoakTime != nil && spec.SoakTime.Duration > 0 {
+		rollout.SoakTime = spec.SoakTime.Duration
+	}
+	if spec.MaxLatencyRatio > 0 {
+		rollout.MaxLatencyRatio = spec.MaxLatencyRatio
+	}
+	current, err := monStore.GetDaemon(rollout.canaryName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the config of the canary %s", rollout.canaryName())
+	}
+	for option := range changed {
+		rollout.Previous[option] = ""
+	}
+	for _, option := range current {
+		if _, ok := changed[option.Option]; ok {
+			rollout.Previous[option.Option] = option.Value
+		}
+	}
+
+	// the values to restore must be recorded before the canary is changed
+	if err := saveConfigRollout(context, clusterInfo, rollout); err != nil {
+		return err
+	}
+	logger.Infof("rolling out the osd config options %v to the canary %s for %s", sortedKeys(changed), rollout.canaryName(), rollout.SoakTime.String())
+	for option, value := range changed {
+		if err := monStore.Set(rollout.canaryName(), option, value); err != nil {
+			return errors.Wrapf(err, "failed to roll out the osd config to the canary %s", rollout.canaryName())
+		}
+	}
+	return nil
+}
+
+// changedOSDOptions returns the options that differ from the last rolled out osd config, or from
+// the osd section of the mon store if none was rolled out yet
+func changedOSDOptions(monStore *opconfig.MonStore, rollout *configRollout, options map[string]string) (map[string]string, error) {
+	current := map[string]string{}
+	if rollout != nil {
+		current = rollout.Options
+	} else {
+		osdOptions, err := monStore.GetDaemon("osd")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the osd config")
+		}
+		for _, option := range osdOptions {
+			current[option.Option] = option.Value
+		}
+	}
+	changed := map[string]string{}
+	for option, value := range options {
+		if currentValue, ok := current[option]; !ok || currentValue != value {
+			changed[option] = value
+		}
+	}
+	return changed, nil
+}
+
+// selectCanaryOSD returns the up OSD with the lowest ID
+func selectCanaryOSD(context *clusterd.Context, clusterInfo *client.ClusterInfo) (int, bool, error) {
+	osdDump, err := client.GetOSDDump(context, clusterInfo)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to get osd dump to select the canary osd")
+	}
+	canary, found := 0, false
+	for _, osdStatus := range osdDump.OSDs {
+		id, err := osdStatus.OSD.Int64()
+		if err != nil {
+			continue
+		}
+		up, err := osdStatus.Up.Int64()
+		if err != nil || up != upStatus {
+			continue
+		}
+		if !found || int(id) < canary {
+			canary, found = int(id), true
+		}
+	}
+	return canary, found, nil
+}
+
+// checkCephConfigRollout rolls back the osd config on the canary OSD if it regressed, or rolls it
+// out to all the OSDs once the canary stayed healthy for the soak time
+func (m *OSDHealthMonitor) checkCephConfigRollout() error {
+	rollout, err := getConfigRollout(m.context, m.clusterInfo)
+	if err != nil {
+		return err
+	}
+	if rollout == nil || rollout.Phase != configRolloutSoaking {
+		return nil
+	}
+
+	reason, err := canaryRegression(m.context, m.clusterInfo, rollout)
+	if err != nil {
+		return err
+	}
+	monStore := opconfig.GetMonStore(m.context, m.clusterInfo)
+	if reason != "" {
+		message := fmt.Sprintf("rolled back the osd config options %v on the canary %s since %s", sortedKeys(rollout.Changed), rollout.canaryName(), reason)
+		logger.Warning(message)
+		if err := restoreCanaryConfig(monStore, rollout); err != nil {
+			return errors.Wrapf(err, "failed to roll back the osd config on the canary %s", rollout.canaryName())
+		}
+		rollout.Phase = configRolloutRolledBack
+		if err := saveConfigRollout(m.context, m.clusterInfo, rollout); err != nil {
+			return err
+		}
+		updateConditionFunc(m.clusterInfo.Context, m.context, m.clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionCephConfigRolledBack,
+			v1.ConditionTrue, cephv1.CephConfigRolledBackReason, message)
+		return nil
+	}
+
+	if time.Since(rollout.Started) < rollout.SoakTime {
+		logger.Debugf("the canary %s is healthy with the osd config options %v", rollout.canaryName(), sortedKeys(rollout.Changed))
+		return nil
+	}
+	logger.Infof("the canary %s stayed healthy for %s, rolling out the osd config options %v to all the osds", rollout.canaryName(), rollout.SoakTime.String(), sortedKeys(rollout.Changed))
+	for option, value := range rollout.Changed {
+		if err := monStore.Set("osd", option, value); err != nil {
+			return errors.Wrap(err, "failed to roll out the osd config to all the osds")
+		}
+	}
+	if err := restoreCanaryConfig(monStore, rollout); err != nil {
+		return errors.Wrapf(err, "failed to restore the config of the canary %s", rollout.canaryName())
+	}
+	rollout.Phase = configRolloutRolledOut
+	if err := saveConfigRollout(m.context, m.clusterInfo, rollout); err != nil {
+		return err
+	}
+	updateConditionFunc(m.clusterInfo.Context, m.context, m.clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionCephConfigRolledBack,
+		v1.ConditionFalse, cephv1.CephConfigRolledOutReason, fmt.Sprintf("rolled out the osd config options %v to all the osds", sortedKeys(rollout.Changed)))
+	return nil
+}
+
+// canaryRegression returns why the canary regressed with the rolled out options, or an empty string
+// if it is healthy
+func canaryRegression(context *clusterd.Context, clusterInfo *client.ClusterInfo, rollout *configRollout) (string, error) {
+	osdDump, err := client.GetOSDDump(context, clusterInfo)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get osd dump")
+	}
+	up, _, err := osdDump.StatusByID(int64(rollout.Canary))
+	if err != nil {
+		return "", err
+	}
+	if up != upStatus {
+		return "it is down", nil
+	}
+
+	status, err := client.Status(context, clusterInfo)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get ceph status")
+	}
+	if status.Health.Status == client.CephHealthErr {
+		return "the ceph health is " + client.CephHealthErr, nil
+	}
+
+	perfStats, err := client.GetOSDPerfStats(context, clusterInfo)
+	if err != nil {
+		return "", err
+	}
+	canaryLatency := int64(-1)
+	others := []int64{}
+	for _, info := range perfStats.PerfInfo {
+		id, err := info.ID.Int64()
+		if err != nil {
+			continue
+		}
+		latency, err := info.Stats.CommitLatency.Int64()
+		if err != nil {
+			continue
+		}
+		if int(id) == rollout.Canary {
+			canaryLatency = latency
+		} else {
+			others = append(others, latency)
+		}
+	}
+	if canaryLatency < 0 || len(others) == 0 {
+		return "", nil
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	median := others[len(others)/2]
+	if median < minCommitLatencyMs {
+		median = minCommitLatencyMs
+	}
+	if canaryLatency > median*int64(rollout.MaxLatencyRatio) {
+		return fmt.Sprintf("its commit latency of %dms is above %d times the median of %dms of the other osds", canaryLatency, rollout.MaxLatencyRatio, median), nil
+	}
+	return "", nil
+}
+
+// restoreCanaryConfig restores the values the canary had for the rolled out options before the
+// rollout, or removes them if it had none
+func restoreCanaryConfig(monStore *opconfig.MonStore, rollout *configRollout) error {
+	for option, previous := range rollout.Previous {
+		if previous == "" {
+			if err := monStore.Delete(rollout.canaryName(), option); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := monStore.Set(rollout.canaryName(), option, previous); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getConfigRollout(context *clusterd.Context, clusterInfo *client.ClusterInfo) (*configRollout, error) {
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, cephConfigRolloutConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get configmap %q", cephConfigRolloutConfigMap)
+	}
+	rollout := &configRollout{}
+	if err := json.Unmarshal([]byte(cm.Data[cephConfigRolloutKey]), rollout); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the osd config rollout from configmap %q", cephConfigRolloutConfigMap)
+	}
+	return rollout, nil
+}
+
+func saveConfigRollout(context *clusterd.Context, clusterInfo *client.ClusterInfo, rollout *configRollout) error {
+	raw, err := json.Marshal(rollout)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the osd config rollout")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cephConfigRolloutConfigMap, Namespace: clusterInfo.Namespace},
+		Data:       map[string]string{cephConfigRolloutKey: string(raw)},
+	}
+	if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on configmap %q", cephConfigRolloutConfigMap)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", cephConfigRolloutConfigMap)
+	}
+	return nil
+}
+
+func deleteConfigRollout(context *clusterd.Context, clusterInfo *client.ClusterInfo) error {
+	err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Delete(clusterInfo.Context, cephConfigRolloutConfigMap, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete configmap %q", cephConfigRolloutConfigMap)
+	}
+	return nil
+}
+
+func normalizeOptions(options map[string]string) map[string]string {
+	normalized := map[string]string{}
+	for option, value := range options {
+		normalized[opconfig.NormalizeKey(option)] = value
+	}
+	return normalized
+}
+
+func sortedKeys(options map[string]string) []string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
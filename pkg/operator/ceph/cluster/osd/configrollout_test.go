@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCephConfigRollout(t *testing.T) {
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+
+	store := map[string]map[string]string{
+		"osd":   {"bluestore_cache_size": "1"},
+		"osd.1": {"osd_max_backfills": "5"},
+	}
+	up := map[int]int{0: 0, 1: 1, 2: 1}
+	latency := map[int]int{1: 2, 2: 1, 3: 2}
+	health := "HEALTH_OK"
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "dump":
+				dump := `{"osds":[`
+				for id := 0; id < 3; id++ {
+					if id > 0 {
+						dump += ","
+					}
+					dump += fmt.Sprintf(`{"osd":%d,"up":%d,"in":1}`, id, up[id])
+				}
+				return dump + `]}`, nil
+			case args[0] == "osd" && args[1] == "perf":
+				perf := `{"osd_perf_infos":[`
+				for id := 1; id < 4; id++ {
+					if id > 1 {
+						perf += ","
+					}
+					perf += fmt.Sprintf(`{"id":%d,"perf_stats":{"commit_latency_ms":%d,"apply_latency_ms":0}}`, id, latency[id])
+				}
+				return perf + `]}`, nil
+			case args[0] == "status":
+				return fmt.Sprintf(`{"health":{"status":%q}}`, health), nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			who := args[2]
+			switch args[1] {
+			case "get":
+				options := map[string]interface{}{}
+				for option, value := range store[who] {
+					options[option] = map[string]string{"value": value, "section": who}
+				}
+				raw, _ := json.Marshal(options)
+				return string(raw), nil
+			case "set":
+				if store[who] == nil {
+					store[who] = map[string]string{}
+				}
+				store[who][args[3]] = args[4]
+				return "", nil
+			case "rm":
+				delete(store[who], args[3])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	c := &clusterd.Context{Clientset: clientset, Executor: executor}
+	m := &OSDHealthMonitor{context: c, clusterInfo: clusterInfo}
+
+	var conditionStatus v1.ConditionStatus
+	var conditionMessage string
+	originalUpdateCondition := updateConditionFunc
+	t.Cleanup(func() { updateConditionFunc = originalUpdateCondition })
+	updateConditionFunc = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status v1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+		assert.Equal(t, cephv1.ConditionCephConfigRolledBack, conditionType)
+		conditionStatus = status
+		conditionMessage = message
+	}
+
+	getRollout := func() *configRollout {
+		rollout, err := getConfigRollout(c, clusterInfo)
+		assert.NoError(t, err)
+		return rollout
+	}
+	soaked := func() {
+		rollout := getRollout()
+		rollout.Started = time.Now().Add(-2 * time.Hour)
+		assert.NoError(t, saveConfigRollout(c, clusterInfo, rollout))
+	}
+	spec := &cephv1.CephConfigRolloutSpec{Enabled: true, SoakTime: &metav1.Duration{Duration: time.Hour}}
+
+	t.Run("only the changed options are applied to the lowest up osd", func(t *testing.T) {
+		options := map[string]string{"bluestore-cache-size": "1", "osd max backfills": "2"}
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, options, spec))
+		assert.Equal(t, map[string]string{"bluestore_cache_size": "1"}, store["osd"])
+		assert.Equal(t, map[string]string{"osd_max_backfills": "2"}, store["osd.1"])
+		rollout := getRollout()
+		assert.Equal(t, 1, rollout.Canary)
+		assert.Equal(t, configRolloutSoaking, rollout.Phase)
+		assert.Equal(t, map[string]string{"osd_max_backfills": "5"}, rollout.Previous)
+		assert.Equal(t, time.Hour, rollout.SoakTime)
+		assert.Equal(t, defaultMaxLatencyRatio, rollout.MaxLatencyRatio)
+
+		// the same options are not rolled out again
+		store["osd.1"]["osd_max_backfills"] = "9"
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, options, spec))
+		assert.Equal(t, "9", store["osd.1"]["osd_max_backfills"])
+		store["osd.1"]["osd_max_backfills"] = "2"
+	})
+
+	t.Run("the canary is healthy during the soak time", func(t *testing.T) {
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, configRolloutSoaking, getRollout().Phase)
+		assert.Equal(t, "2", store["osd.1"]["osd_max_backfills"])
+	})
+
+	t.Run("rolled back when the commit latency of the canary regresses", func(t *testing.T) {
+		latency[1] = 7
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, configRolloutRolledBack, getRollout().Phase)
+		assert.Equal(t, "5", store["osd.1"]["osd_max_backfills"])
+		assert.Equal(t, v1.ConditionTrue, conditionStatus)
+		assert.Contains(t, conditionMessage, "commit latency of 7ms")
+		latency[1] = 2
+
+		// the rolled back options are not rolled out again
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, map[string]string{"bluestore_cache_size": "1", "osd_max_backfills": "2"}, spec))
+		assert.Equal(t, configRolloutRolledBack, getRollout().Phase)
+		assert.Equal(t, "5", store["osd.1"]["osd_max_backfills"])
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, "5", store["osd.1"]["osd_max_backfills"])
+	})
+
+	t.Run("rolled out to all the osds after the soak time", func(t *testing.T) {
+		options := map[string]string{"bluestore_cache_size": "1", "osd_max_backfills": "3"}
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, options, spec))
+		assert.Equal(t, v1.ConditionFalse, conditionStatus)
+		assert.Equal(t, configRolloutSoaking, getRollout().Phase)
+		assert.Equal(t, "3", store["osd.1"]["osd_max_backfills"])
+
+		soaked()
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, configRolloutRolledOut, getRollout().Phase)
+		assert.Equal(t, map[string]string{"bluestore_cache_size": "1", "osd_max_backfills": "3"}, store["osd"])
+		assert.Equal(t, map[string]string{"osd_max_backfills": "5"}, store["osd.1"])
+		assert.Equal(t, v1.ConditionFalse, conditionStatus)
+
+		// the rolled out options are not rolled out again
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, options, spec))
+		assert.Equal(t, configRolloutRolledOut, getRollout().Phase)
+	})
+
+	t.Run("rolled back when the canary is down or the cluster health is error", func(t *testing.T) {
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, map[string]string{"osd_max_backfills": "4"}, spec))
+		assert.Equal(t, "4", store["osd.1"]["osd_max_backfills"])
+		up[1] = 0
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, configRolloutRolledBack, getRollout().Phase)
+		assert.Equal(t, "5", store["osd.1"]["osd_max_backfills"])
+		assert.Contains(t, conditionMessage, "it is down")
+
+		// osd.1 is down, the next rollout goes to osd.2
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, map[string]string{"osd_max_backfills": "6"}, spec))
+		assert.Equal(t, 2, getRollout().Canary)
+		assert.Equal(t, map[string]string{"osd_max_backfills": "6"}, store["osd.2"])
+		health = "HEALTH_ERR"
+		assert.NoError(t, m.checkCephConfigRollout())
+		assert.Equal(t, configRolloutRolledBack, getRollout().Phase)
+		assert.Empty(t, store["osd.2"])
+		assert.Contains(t, conditionMessage, "HEALTH_ERR")
+		health = "HEALTH_OK"
+		up[1] = 1
+	})
+
+	t.Run("disabling the rollout aborts it", func(t *testing.T) {
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, map[string]string{"osd_max_backfills": "7"}, spec))
+		assert.Equal(t, "7", store["osd.1"]["osd_max_backfills"])
+		assert.NoError(t, ApplyOSDConfig(c, clusterInfo, map[string]string{}, nil))
+		assert.Equal(t, "5", store["osd.1"]["osd_max_backfills"])
+		assert.Nil(t, getRollout())
+	})
+}
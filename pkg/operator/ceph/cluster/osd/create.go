@@ -19,6 +19,7 @@ package osd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
@@ -88,25 +89,44 @@ func (c *createConfig) createNewOSDsFromStatus(
 		return
 	}
 
+	created := []OSDInfo{}
 	for i, osd := range status.OSDs {
+		c.cluster.reportOSD(osd)
 		if c.deployments.Exists(osd.ID) {
 			// This OSD will be handled by the updater
 			logger.Debugf("not creating deployment for OSD %d which already exists", osd.ID)
 			continue
 		}
+		if c.cluster.replacedOSDs.Has(osd.UUID) {
+			// The OSD is left on the failing device after it was replaced
+			logger.Infof("not creating deployment for OSD %d with UUID %q that was replaced because its device is predicted to fail", osd.ID, osd.UUID)
+			continue
+		}
+		if c.cluster.migratedOSDs.Has(osd.UUID) {
+			// The OSD is left on the device of the node after it was migrated to a PVC
+			logger.Infof("not creating deployment for OSD %d with UUID %q that was migrated to a PVC", osd.ID, osd.UUID)
+			continue
+		}
 		if status.PvcBackedOSD {
 			logger.Infof("creating OSD %d on PVC %q", osd.ID, nodeOrPVCName)
 			err := createDaemonOnPVCFunc(c.cluster, &status.OSDs[i], nodeOrPVCName, c.provisionConfig)
 			if err != nil {
 				errs.addError("%v", errors.Wrapf(err, "failed to create OSD %d on PVC %q", osd.ID, nodeOrPVCName))
+				continue
 			}
 		} else {
 			logger.Infof("creating OSD %d on node %q", osd.ID, nodeOrPVCName)
 			err := createDaemonOnNodeFunc(c.cluster, &status.OSDs[i], nodeOrPVCName, c.provisionConfig)
 			if err != nil {
 				errs.addError("%v", errors.Wrapf(err, "failed to create OSD %d on node %q", osd.ID, nodeOrPVCName))
+				continue
 			}
 		}
+		created = append(created, osd)
+	}
+
+	if err := c.cluster.recordOSDWeightRampUps(created); err != nil {
+		errs.addError("%v", errors.Wrapf(err, "failed to record the new OSDs on %q to ramp up their crush weight", nodeOrPVCName))
 	}
 
 	c.doneWithStatus(nodeOrPVCName)
@@ -165,17 +185,18 @@ func (c *Cluster) startProvisioningOverPVCs(config *provisionConfig, errs *provi
 		}
 
 		osdProps := osdProperties{
-			crushHostname:    dataSource.ClaimName,
-			pvc:              dataSource,
-			metadataPVC:      metadataSource,
-			walPVC:           walSource,
-			resources:        volume.Resources,
-			placement:        volume.Placement,
-			preparePlacement: volume.PreparePlacement,
-			portable:         volume.Portable,
-			schedulerName:    volume.SchedulerName,
-			encrypted:        volume.Encrypted,
-			deviceSetName:    volume.Name,
+			crushHostname:           dataSource.ClaimName,
+			pvc:                     dataSource,
+			metadataPVC:             metadataSource,
+			walPVC:                  walSource,
+			resources:               volume.Resources,
+			placement:               volume.Placement,
+			preparePlacement:        volume.PreparePlacement,
+			portable:                volume.Portable,
+			schedulerName:           volume.SchedulerName,
+			encrypted:               volume.Encrypted,
+			deviceSetName:           volume.Name,
+			ephemeralMetadataDevice: volume.EphemeralMetadataDevice,
 		}
 		osdProps.storeConfig.DeviceClass = volume.CrushDeviceClass
 
@@ -186,6 +207,16 @@ func (c *Cluster) startProvisioningOverPVCs(config *provisionConfig, errs *provi
 			skipPreparePod = true
 		}
 
+		// The OSD cannot start anymore without its metadata device, the prepare pod rebuilds it
+		if volume.MetadataDeviceLost {
+			logger.Infof("rebuilding the OSD on PVC %q since its ephemeral metadata device was lost", dataSource.ClaimName)
+			if err := c.deleteOSDDeploymentsOnPVC(dataSource.ClaimName); err != nil {
+				errs.addError("failed to rebuild the OSD on PVC %q. %v", dataSource.ClaimName, err)
+				continue
+			}
+			skipPreparePod = false
+		}
+
 		// Allow updating OSD prepare pod if the OSD needs migration
 		if c.migrateOSD != nil {
 			if strings.Contains(c.migrateOSD.BlockPath, dataSource.ClaimName) {
@@ -199,6 +230,10 @@ func (c *Cluster) startProvisioningOverPVCs(config *provisionConfig, errs *provi
 			continue
 		}
 
+		if !c.checkPrepareRetry(&osdProps, errs, time.Now()) {
+			continue
+		}
+
 		if osdProps.encrypted {
 			// create encryption Kubernetes Secret if the PVC is encrypted
 			key, err := GenerateDmCryptKey()
@@ -234,7 +269,7 @@ func (c *Cluster) startProvisioningOverPVCs(config *provisionConfig, errs *provi
 		status := OrchestrationStatus{Status: OrchestrationStatusStarting, PvcBackedOSD: true}
 		cmName := c.updateOSDStatus(osdProps.crushHostname, status)
 
-		if err := c.runPrepareJob(&osdProps, config); err != nil {
+		if err := c.runOrQueuePrepareJob(&osdProps, config); err != nil {
 			c.handleOrchestrationFailure(errs, osdProps.crushHostname, "%v", err)
 			c.deleteStatusConfigMap(osdProps.crushHostname)
 			continue // do not record the status CM's name
@@ -330,11 +365,15 @@ func (c *Cluster) startProvisioningOverNodes(config *provisionConfig, errs *prov
 			metadataDevice: metadataDevice,
 		}
 
+		if !c.checkPrepareRetry(&osdProps, errs, time.Now()) {
+			continue
+		}
+
 		// update the orchestration status of this node to the starting state
 		status := OrchestrationStatus{Status: OrchestrationStatusStarting}
 		cmName := c.updateOSDStatus(n.Name, status)
 
-		if err := c.runPrepareJob(&osdProps, config); err != nil {
+		if err := c.runOrQueuePrepareJob(&osdProps, config); err != nil {
 			c.handleOrchestrationFailure(errs, n.Name, "%v", err)
 			c.deleteStatusConfigMap(n.Name)
 			continue // do not record the status CM's name
@@ -30,6 +30,7 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -70,6 +71,13 @@ type deviceSet struct {
 	SchedulerName string
 	// Whether to encrypt the deviceSet
 	Encrypted bool
+	// Whether the metadata and wal devices are ephemeral
+	EphemeralMetadataDevice bool
+	// Compression is the bluestore compression of the OSDs
+	Compression *cephv1.OSDCompressionSpec
+	// MetadataDeviceLost indicates that the ephemeral metadata or wal PVC of an existing OSD was
+	// lost and is created again, so the OSD must be prepared again to be rebuilt
+	MetadataDeviceLost bool
 }
 
 // PrepareStorageClassDeviceSets is only exposed for testing purposes
@@ -120,7 +128,16 @@ func (c *Cluster) prepareStorageClassDeviceSets(errs *provisionErrors) {
 				if pvcID > highestExistingID {
 					highestExistingID = pvcID
 				}
+				metadataDeviceLost := false
+				if deviceSet.EphemeralMetadataDevice {
+					metadataDeviceLost, err = c.checkEphemeralMetadataPVCs(deviceSet, existingPVCs, pvcID)
+					if err != nil {
+						errs.addError("failed to check the ephemeral metadata PVCs of device set %q index %d. %v", deviceSet.Name, pvcID, err)
+						continue
+					}
+				}
 				deviceSet := c.createDeviceSetPVCsForIndex(deviceSet, existingPVCs, pvcID, errs, pvcResizeMap)
+				deviceSet.MetadataDeviceLost = metadataDeviceLost
 				c.deviceSets = append(c.deviceSets, deviceSet)
 			}
 			countInDeviceSet = existingIDs.Len()
@@ -197,22 +214,140 @@ func (c *Cluster) createDeviceSetPVCsForIndex(newDeviceSet cephv1.StorageClassDe
 	}
 
 	return deviceSet{
-		Name:                 newDeviceSet.Name,
-		Resources:            newDeviceSet.Resources,
-		Placement:            newDeviceSet.Placement,
-		PreparePlacement:     newDeviceSet.PreparePlacement,
-		Config:               newDeviceSet.Config,
-		Size:                 dataSize,
-		PVCSources:           pvcSources,
-		Portable:             newDeviceSet.Portable,
-		TuneSlowDeviceClass:  newDeviceSet.TuneSlowDeviceClass,
-		TuneFastDeviceClass:  newDeviceSet.TuneFastDeviceClass,
-		SchedulerName:        newDeviceSet.SchedulerName,
-		CrushDeviceClass:     crushDeviceClass,
-		CrushInitialWeight:   crushInitialWeight,
-		CrushPrimaryAffinity: crushPrimaryAffinity,
-		Encrypted:            newDeviceSet.Encrypted,
+		Name:                    newDeviceSet.Name,
+		Resources:               newDeviceSet.Resources,
+		Placement:               newDeviceSet.Placement,
+		PreparePlacement:        newDeviceSet.PreparePlacement,
+		Config:                  newDeviceSet.Config,
+		Size:                    dataSize,
+		PVCSources:              pvcSources,
+		Portable:                newDeviceSet.Portable,
+		TuneSlowDeviceClass:     newDeviceSet.TuneSlowDeviceClass,
+		TuneFastDeviceClass:     newDeviceSet.TuneFastDeviceClass,
+		SchedulerName:           newDeviceSet.SchedulerName,
+		CrushDeviceClass:        crushDeviceClass,
+		CrushInitialWeight:      crushInitialWeight,
+		CrushPrimaryAffinity:    crushPrimaryAffinity,
+		Encrypted:               newDeviceSet.Encrypted,
+		EphemeralMetadataDevice: newDeviceSet.EphemeralMetadataDevice,
+		Compression:             newDeviceSet.Compression,
+	}
+}
+
+// checkEphemeralMetadataPVCs returns whether the ephemeral metadata or wal PVC of an existing OSD
+// was lost. A PVC bound to a volume that is lost is deleted so that it is created again, once the
+// OSD deployment mounting it is removed.
+func (c *Cluster) checkEphemeralMetadataPVCs(deviceSet cephv1.StorageClassDeviceSet, existingPVCs map[string]*v1.PersistentVolumeClaim, setIndex int) (bool, error) {
+	if _, ok := existingPVCs[legacyDeviceSetPVCID(deviceSet.Name, setIndex)]; ok {
+		// the legacy PVCs are only created for a single data template
+		return false, nil
+	}
+	dataPVC, ok := existingPVCs[deviceSetPVCID(deviceSet.Name, bluestorePVCData, setIndex)]
+	if !ok {
+		return false, nil
+	}
+
+	lost := false
+	for _, pvcTemplate := range deviceSet.VolumeClaimTemplates {
+		if pvcTemplate.Name != bluestorePVCMetadata && pvcTemplate.Name != bluestorePVCWal {
+			continue
+		}
+		pvcID := deviceSetPVCID(deviceSet.Name, pvcTemplate.Name, setIndex)
+		pvc, ok := existingPVCs[pvcID]
+		if !ok {
+			logger.Warningf("ephemeral %s PVC of OSD PVC %q is missing, it will be created again and the OSD rebuilt", pvcTemplate.Name, pvcID)
+			lost = true
+			continue
+		}
+		pvcLost, err := c.isEphemeralPVCLost(pvc)
+		if err != nil {
+			return false, err
+		}
+		if !pvcLost {
+			continue
+		}
+		if pvc.DeletionTimestamp != nil {
+			return false, errors.Errorf("waiting for the lost PVC %q to be deleted before rebuilding the OSD", pvc.Name)
+		}
+
+		// the PVC stays terminating as long as the OSD pod mounts it, so the OSD is removed first
+		logger.Warningf("the volume of ephemeral %s PVC %q is lost, removing the OSD on PVC %q before deleting it", pvcTemplate.Name, pvc.Name, dataPVC.Name)
+		if err := c.deleteOSDDeploymentsOnPVC(dataPVC.Name); err != nil {
+			return false, errors.Wrapf(err, "failed to remove the OSD mounting lost PVC %q", pvc.Name)
+		}
+		inUse, err := c.isPVCMounted(dataPVC.Name, pvc.Name)
+		if err != nil {
+			return false, err
+		}
+		if inUse {
+			return false, errors.Errorf("waiting for the OSD pod mounting the lost PVC %q to terminate before deleting the PVC", pvc.Name)
+		}
+
+		logger.Warningf("deleting lost ephemeral %s PVC %q", pvcTemplate.Name, pvc.Name)
+		err = c.context.Clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(c.clusterInfo.Context, pvc.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to delete lost PVC %q", pvc.Name)
+		}
+		return false, errors.Errorf("waiting for the lost PVC %q to be deleted before rebuilding the OSD", pvc.Name)
+	}
+	return lost, nil
+}
+
+// isPVCMounted returns whether a pod of the OSD on the data PVC still mounts the given PVC
+func (c *Cluster) isPVCMounted(dataPVCName, pvcName string) (bool, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", OSDOverPVCLabelKey, dataPVCName)}
+	pods, err := c.context.Clientset.CoreV1().Pods(c.clusterInfo.Namespace).List(c.clusterInfo.Context, listOpts)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list the pods of the OSD on PVC %q", dataPVCName)
+	}
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isEphemeralPVCLost returns whether the volume bound to the PVC is lost, as happens to the local
+// volumes of an instance that is replaced
+func (c *Cluster) isEphemeralPVCLost(pvc *v1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Status.Phase == v1.ClaimLost {
+		return true, nil
+	}
+	if pvc.Spec.VolumeName == "" {
+		return false, nil
+	}
+	pv, err := c.context.Clientset.CoreV1().PersistentVolumes().Get(c.clusterInfo.Context, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to get PV %q", pvc.Spec.VolumeName)
+	}
+
+	// a local volume is lost when none of the nodes it is bound to exists anymore
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false, nil
+	}
+	hostnames := []string{}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == v1.LabelHostname && expr.Operator == v1.NodeSelectorOpIn {
+				hostnames = append(hostnames, expr.Values...)
+			}
+		}
+	}
+	if len(hostnames) == 0 {
+		return false, nil
+	}
+	selector := fmt.Sprintf("%s in (%s)", v1.LabelHostname, strings.Join(hostnames, ","))
+	nodes, err := c.context.Clientset.CoreV1().Nodes().List(c.clusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list the nodes of PV %q", pv.Name)
 	}
+	return len(nodes.Items) == 0, nil
 }
 
 func (c *Cluster) createDeviceSetPVC(existingPVCs map[string]*v1.PersistentVolumeClaim, deviceSetName string, pvcTemplate v1.PersistentVolumeClaim, setIndex int, pvcResizeMap map[string]pvcResize) (*v1.PersistentVolumeClaim, error) {
@@ -23,9 +23,12 @@ import (
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
 	testexec "github.com/rook/rook/pkg/operator/test"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -328,3 +331,106 @@ func TestCheckAllPvcResize(t *testing.T) {
 
 	assert.True(t, checkAllPvcResize(ctx, client, namespace, pvcResizeMap))
 }
+
+func TestCheckEphemeralMetadataPVCs(t *testing.T) {
+	ctx := context.TODO()
+	clientset := testexec.New(t, 1)
+	cluster := &Cluster{
+		context:     &clusterd.Context{Clientset: clientset},
+		clusterInfo: client.AdminTestClusterInfo("testns"),
+	}
+	deviceSet := cephv1.StorageClassDeviceSet{
+		Name:                    "mydata",
+		Count:                   1,
+		VolumeClaimTemplates:    []cephv1.VolumeClaimTemplate{testVolumeClaim("data"), testVolumeClaim("metadata")},
+		EphemeralMetadataDevice: true,
+	}
+	localPV := func(name, hostname string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{
+				NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{hostname}},
+					}}},
+				}},
+			},
+		}
+	}
+	newPVC := func(name, volumeName string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testns"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+	}
+	dataPVC := newPVC("mydata-data-0-abc", "")
+
+	t.Run("no OSD on the data PVC yet", func(t *testing.T) {
+		lost, err := cluster.checkEphemeralMetadataPVCs(deviceSet, map[string]*corev1.PersistentVolumeClaim{}, 0)
+		assert.NoError(t, err)
+		assert.False(t, lost)
+	})
+
+	t.Run("metadata PVC missing", func(t *testing.T) {
+		existing := map[string]*corev1.PersistentVolumeClaim{"mydata-data-0": dataPVC}
+		lost, err := cluster.checkEphemeralMetadataPVCs(deviceSet, existing, 0)
+		assert.NoError(t, err)
+		assert.True(t, lost)
+	})
+
+	t.Run("metadata volume on an existing node", func(t *testing.T) {
+		_, err := clientset.CoreV1().PersistentVolumes().Create(ctx, localPV("pv-node0", "node0"), metav1.CreateOptions{})
+		assert.NoError(t, err)
+		existing := map[string]*corev1.PersistentVolumeClaim{
+			"mydata-data-0":     dataPVC,
+			"mydata-metadata-0": newPVC("mydata-metadata-0-abc", "pv-node0"),
+		}
+		lost, err := cluster.checkEphemeralMetadataPVCs(deviceSet, existing, 0)
+		assert.NoError(t, err)
+		assert.False(t, lost)
+	})
+
+	t.Run("metadata volume on a replaced node", func(t *testing.T) {
+		_, err := clientset.CoreV1().PersistentVolumes().Create(ctx, localPV("pv-gone", "gone"), metav1.CreateOptions{})
+		assert.NoError(t, err)
+		metadataPVC := newPVC("mydata-metadata-0-def", "pv-gone")
+		_, err = clientset.CoreV1().PersistentVolumeClaims("testns").Create(ctx, metadataPVC, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		existing := map[string]*corev1.PersistentVolumeClaim{
+			"mydata-data-0":     dataPVC,
+			"mydata-metadata-0": metadataPVC,
+		}
+		osdLabels := map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: "0", OSDOverPVCLabelKey: dataPVC.Name}
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-0", Namespace: "testns", Labels: osdLabels}}
+		_, err = clientset.AppsV1().Deployments("testns").Create(ctx, deployment, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-0-abc", Namespace: "testns", Labels: osdLabels},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "metadata",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: metadataPVC.Name}},
+			}}},
+		}
+		_, err = clientset.CoreV1().Pods("testns").Create(ctx, pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		// the OSD deployment is removed first and the PVC is kept while the OSD pod mounts it
+		lost, err := cluster.checkEphemeralMetadataPVCs(deviceSet, existing, 0)
+		assert.Error(t, err)
+		assert.False(t, lost)
+		_, err = clientset.AppsV1().Deployments("testns").Get(ctx, deployment.Name, metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+		_, err = clientset.CoreV1().PersistentVolumeClaims("testns").Get(ctx, metadataPVC.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		// the PVC is deleted once the OSD pod terminated
+		err = clientset.CoreV1().Pods("testns").Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		assert.NoError(t, err)
+		lost, err = cluster.checkEphemeralMetadataPVCs(deviceSet, existing, 0)
+		assert.Error(t, err)
+		assert.False(t, lost)
+		_, err = clientset.CoreV1().PersistentVolumeClaims("testns").Get(ctx, metadataPVC.Name, metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+}
@@ -18,6 +18,7 @@ package osd
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	opmon "github.com/rook/rook/pkg/operator/ceph/cluster/mon"
@@ -27,11 +28,14 @@ import (
 )
 
 const (
-	osdDatabaseSizeEnvVarName = "ROOK_OSD_DATABASE_SIZE"
-	osdWalSizeEnvVarName      = "ROOK_OSD_WAL_SIZE"
-	osdsPerDeviceEnvVarName   = "ROOK_OSDS_PER_DEVICE"
-	osdDeviceClassEnvVarName  = "ROOK_OSD_DEVICE_CLASS"
-	osdConfigMapOverrideName  = "rook-ceph-osd-env-override"
+	osdDatabaseSizeEnvVarName        = "ROOK_OSD_DATABASE_SIZE"
+	osdWalSizeEnvVarName             = "ROOK_OSD_WAL_SIZE"
+	osdsPerDeviceEnvVarName          = "ROOK_OSDS_PER_DEVICE"
+	osdsPerDeviceModeEnvVarName      = "ROOK_OSDS_PER_DEVICE_MODE"
+	osdMetadataDeviceSlotsEnvVarName = "ROOK_OSD_METADATA_DEVICE_SLOTS"
+	osdZapPurgedOSDsEnvVarName       = "ROOK_OSD_ZAP_PURGED_OSDS"
+	osdDeviceClassEnvVarName         = "ROOK_OSD_DEVICE_CLASS"
+	osdConfigMapOverrideName         = "rook-ceph-osd-env-override"
 	// EncryptedDeviceEnvVarName is used in the pod spec to indicate whether the OSD is encrypted or not
 	EncryptedDeviceEnvVarName = "ROOK_ENCRYPTED_DEVICE"
 	PVCNameEnvVarName         = "ROOK_PVC_NAME"
@@ -52,6 +56,7 @@ const (
 	CrushRootVarName                    = "ROOK_CRUSHMAP_ROOT"
 	tcmallocMaxTotalThreadCacheBytesEnv = "TCMALLOC_MAX_TOTAL_THREAD_CACHE_BYTES"
 	wipeDevicesFromOtherClustersVarName = "ROOK_WIPE_DEVICES_FROM_OTHER_CLUSTERS"
+	ephemeralMetadataDeviceVarName      = "ROOK_EPHEMERAL_METADATA_DEVICE"
 )
 
 var cephEnvConfigFile = "/etc/sysconfig/ceph"
@@ -107,6 +112,18 @@ func (c *Cluster) getConfigEnvVars(osdProps osdProperties, dataDir string, prepa
 		envVars = append(envVars, v1.EnvVar{Name: osdsPerDeviceEnvVarName, Value: strconv.Itoa(osdProps.storeConfig.OSDsPerDevice)})
 	}
 
+	if osdProps.storeConfig.OSDsPerDeviceMode != "" {
+		envVars = append(envVars, v1.EnvVar{Name: osdsPerDeviceModeEnvVarName, Value: osdProps.storeConfig.OSDsPerDeviceMode})
+	}
+
+	if osdProps.storeConfig.MetadataDeviceSlots != 0 {
+		envVars = append(envVars, v1.EnvVar{Name: osdMetadataDeviceSlotsEnvVarName, Value: strconv.Itoa(osdProps.storeConfig.MetadataDeviceSlots)})
+	}
+
+	if osdProps.storeConfig.ZapPurgedOSDs {
+		envVars = append(envVars, v1.EnvVar{Name: osdZapPurgedOSDsEnvVarName, Value: "true"})
+	}
+
 	if osdProps.storeConfig.EncryptedDevice {
 		envVars = append(envVars, v1.EnvVar{Name: EncryptedDeviceEnvVarName, Value: "true"})
 	}
@@ -130,6 +147,14 @@ func devicePathFilterEnvVar(filter string) v1.EnvVar {
 	return v1.EnvVar{Name: "ROOK_DATA_DEVICE_PATH_FILTER", Value: filter}
 }
 
+func deviceIdentityFilterEnvVar(filter string) v1.EnvVar {
+	return v1.EnvVar{Name: "ROOK_DATA_DEVICE_IDENTITY_FILTER", Value: filter}
+}
+
+func quarantinedDevicesEnvVar(devices []string) v1.EnvVar {
+	return v1.EnvVar{Name: "ROOK_QUARANTINED_DEVICES", Value: strings.Join(devices, ",")}
+}
+
 func dataDeviceClassEnvVar(deviceClass string) v1.EnvVar {
 	return v1.EnvVar{Name: osdDeviceClassEnvVarName, Value: deviceClass}
 }
@@ -150,6 +175,10 @@ func wipeDevicesFromOtherClustersEnvVar() v1.EnvVar {
 	return v1.EnvVar{Name: wipeDevicesFromOtherClustersVarName, Value: "true"}
 }
 
+func ephemeralMetadataDeviceEnvVar() v1.EnvVar {
+	return v1.EnvVar{Name: ephemeralMetadataDeviceVarName, Value: "true"}
+}
+
 func setDebugLogLevelEnvVar(debug bool) v1.EnvVar {
 	level := "INFO"
 	if debug {
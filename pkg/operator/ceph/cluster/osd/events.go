@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"reflect"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// The reasons of the events recorded on the CephCluster for the OSD health
+const (
+	OSDFlappingReason                = "OSDFlapping"
+	OSDFlappingResolvedReason        = "OSDFlappingResolved"
+	OSDFlappingMarkedOutReason       = "OSDFlappingMarkedOut"
+	OSDFlappingReplacedReason        = "OSDFlappingReplaced"
+	NodeMaintenanceStartedReason     = "NodeMaintenanceStarted"
+	NodeMaintenanceNotOkToStopReason = "NodeMaintenanceNotOkToStop"
+	NodeMaintenanceCompletedReason   = "NodeMaintenanceCompleted"
+	NodeMaintenanceExpiredReason     = "NodeMaintenanceExpired"
+)
+
+// SetEventRecorder sets the recorder of the events of the OSD health on the CephCluster
+func (m *OSDHealthMonitor) SetEventRecorder(recorder record.EventRecorder) {
+	m.recorder = recorder
+}
+
+// recordEvent records an event on the CephCluster so that admins can alert on the OSD health
+// without scraping the operator log
+func (m *OSDHealthMonitor) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if m.recorder == nil {
+		return
+	}
+	name := m.clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       reflect.TypeOf(cephv1.CephCluster{}).Name(),
+			APIVersion: cephv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+	}
+	if m.clusterInfo.OwnerInfo != nil {
+		cephCluster.UID = m.clusterInfo.OwnerInfo.GetUID()
+	}
+	m.recorder.Eventf(cephCluster, eventType, reason, messageFmt, args...)
+}
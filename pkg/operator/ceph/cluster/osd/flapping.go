@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultFlappingThreshold = 5
+	defaultFlappingPeriod    = 10 * time.Minute
+)
+
+// osdFlaps are the times an OSD was found marked up again by the health checks within the
+// flapping period
+type osdFlaps struct {
+	// upFrom is the epoch the OSD was last marked up at the last health check
+	upFrom   int64
+	times    []time.Time
+	flapping bool
+}
+
+// checkOSDFlapping detects the OSDs marked up again more times than the threshold within the
+// period, which means they are repeatedly marked down and up again. A warning event is recorded
+// on the CephCluster when an OSD starts flapping, and the flapping OSDs are marked out or replaced
+// with AutoOut, one OSD per check.
+func (m *OSDHealthMonitor) checkOSDFlapping() error {
+	spec := m.flapping
+	if spec == nil || !spec.Enabled {
+		return nil
+	}
+	threshold := defaultFlappingThreshold
+	if spec.Threshold > 0 {
+		threshold = spec.Threshold
+	}
+	period := defaultFlappingPeriod
+	if spec.Period != nil {
+		period = spec.Period.Duration
+	}
+
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+
+	started, resolved := m.recordOSDFlaps(osdDump, time.Now(), threshold, period)
+	for _, id := range resolved {
+		logger.Infof("osd.%d is no longer flapping", id)
+		m.recordEvent(v1.EventTypeNormal, OSDFlappingResolvedReason, "osd.%d is no longer flapping", id)
+	}
+	for _, id := range started {
+		logger.Warningf("osd.%d is flapping, it was marked up again %d times in %s", id, len(m.osdFlaps[id].times), period)
+		m.recordEvent(v1.EventTypeWarning, OSDFlappingReason, "osd.%d was marked up again %d times in %s", id, len(m.osdFlaps[id].times), period)
+	}
+
+	if !spec.AutoOut {
+		return nil
+	}
+	return m.markFlappingOSDOut(osdDump, spec.Replace)
+}
+
+// recordOSDFlaps records the OSDs marked up again since the last check, and returns the OSDs that
+// started flapping and the OSDs no longer flapping
+func (m *OSDHealthMonitor) recordOSDFlaps(osdDump *client.OSDDump, now time.Time, threshold int, period time.Duration) ([]int, []int) {
+	started := []int{}
+	resolved := []int{}
+	found := map[int]bool{}
+	for _, osd := range osdDump.OSDs {
+		id64, err := osd.OSD.Int64()
+		if err != nil {
+			continue
+		}
+		upFrom, err := osd.UpFrom.Int64()
+		if err != nil {
+			continue
+		}
+		id := int(id64)
+		found[id] = true
+
+		flaps, ok := m.osdFlaps[id]
+		if !ok {
+			m.osdFlaps[id] = &osdFlaps{upFrom: upFrom}
+			continue
+		}
+		if upFrom > flaps.upFrom {
+			flaps.times = append(flaps.times, now)
+		}
+		flaps.upFrom = upFrom
+		flaps.times = slices.DeleteFunc(flaps.times, func(t time.Time) bool {
+			return !t.After(now.Add(-period))
+		})
+
+		flapping := len(flaps.times) >= threshold
+		if flapping && !flaps.flapping {
+			started = append(started, id)
+		} else if !flapping && flaps.flapping {
+			resolved = append(resolved, id)
+		}
+		flaps.flapping = flapping
+	}
+
+	// forget the purged OSDs
+	for id := range m.osdFlaps {
+		if !found[id] {
+			delete(m.osdFlaps, id)
+		}
+	}
+	slices.Sort(started)
+	slices.Sort(resolved)
+	return started, resolved
+}
+
+// markFlappingOSDOut marks out the first flapping OSD still in, or starts its replacement when the
+// flapping OSDs are replaced
+func (m *OSDHealthMonitor) markFlappingOSDOut(osdDump *client.OSDDump, replace bool) error {
+	flappingOSDs := []int{}
+	for id, flaps := range m.osdFlaps {
+		if flaps.flapping {
+			flappingOSDs = append(flappingOSDs, id)
+		}
+	}
+	slices.Sort(flappingOSDs)
+
+	for _, id := range flappingOSDs {
+		_, in, err := osdDump.StatusByID(int64(id))
+		if err != nil || in != inStatus {
+			continue
+		}
+
+		if replace {
+			cm, replacements, err := loadOSDReplacements(m.context, m.clusterInfo)
+			if err != nil {
+				return err
+			}
+			// the OSDs are replaced one at a time
+			if osdReplacementInProgress(replacements) {
+				logger.Infof("waiting for the replacement in progress before replacing flapping osd.%d", id)
+				return nil
+			}
+			replacement := OSDReplacement{OSD: id, UUID: osdUUID(osdDump, id), Reason: OSDReplacementReasonFlapping, Phase: OSDReplacementDraining, Time: metav1.Now()}
+			started, err := m.startOSDReplacement(cm, replacements, replacement)
+			if err != nil {
+				return errors.Wrapf(err, "failed to start the replacement of flapping osd.%d", id)
+			}
+			if started {
+				m.recordEvent(v1.EventTypeNormal, OSDFlappingReplacedReason, "marked flapping osd.%d out to replace it", id)
+				return nil
+			}
+			// the OSDs on PVCs are only marked out
+		}
+
+		if _, err := client.OSDOut(m.context, m.clusterInfo, id); err != nil {
+			return errors.Wrapf(err, "failed to mark flapping osd.%d out", id)
+		}
+		logger.Infof("marked flapping osd.%d out", id)
+		m.recordEvent(v1.EventTypeNormal, OSDFlappingMarkedOutReason, "marked flapping osd.%d out", id)
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordOSDFlaps(t *testing.T) {
+	dump := func(upFrom0, upFrom1 int) *client.OSDDump {
+		osdDump := &client.OSDDump{}
+		raw := fmt.Sprintf(`{"osds":[{"osd":0,"up":1,"in":1,"up_from":%d},{"osd":1,"up":1,"in":1,"up_from":%d}]}`, upFrom0, upFrom1)
+		require.NoError(t, json.Unmarshal([]byte(raw), osdDump))
+		return osdDump
+	}
+	m := &OSDHealthMonitor{osdFlaps: map[int]*osdFlaps{}}
+	start := time.Now()
+	period := 10 * time.Minute
+
+	// the first check records the epochs the osds were marked up
+	started, resolved := m.recordOSDFlaps(dump(10, 10), start, 2, period)
+	assert.Empty(t, started)
+	assert.Empty(t, resolved)
+
+	// osd.0 is flapping once it was marked up again twice within the period
+	started, _ = m.recordOSDFlaps(dump(12, 10), start.Add(time.Minute), 2, period)
+	assert.Empty(t, started)
+	started, _ = m.recordOSDFlaps(dump(14, 10), start.Add(2*time.Minute), 2, period)
+	assert.Equal(t, []int{0}, started)
+
+	// the osd is reported once while it is flapping
+	started, resolved = m.recordOSDFlaps(dump(14, 10), start.Add(3*time.Minute), 2, period)
+	assert.Empty(t, started)
+	assert.Empty(t, resolved)
+
+	// the osd is no longer flapping once the times it was marked up again are out of the period
+	started, resolved = m.recordOSDFlaps(dump(14, 10), start.Add(12*time.Minute), 2, period)
+	assert.Empty(t, started)
+	assert.Equal(t, []int{0}, resolved)
+
+	// the purged osds are forgotten
+	osdDump := &client.OSDDump{}
+	require.NoError(t, json.Unmarshal([]byte(`{"osds":[{"osd":0,"up":1,"in":1,"up_from":14}]}`), osdDump))
+	m.recordOSDFlaps(osdDump, start.Add(13*time.Minute), 2, period)
+	assert.NotContains(t, m.osdFlaps, 1)
+}
+
+func TestCheckOSDFlapping(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	for _, d := range []*appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-0", Namespace: clusterInfo.Namespace, Labels: map[string]string{"topology-location-host": "node1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-1", Namespace: clusterInfo.Namespace, Labels: map[string]string{OSDOverPVCLabelKey: "set1-data-0"}}},
+	} {
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	upFrom := 10
+	out := map[string]bool{}
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "dump":
+				osds := []string{}
+				for _, id := range []string{"0", "1"} {
+					in := 1
+					if out[id] {
+						in = 0
+					}
+					osds = append(osds, fmt.Sprintf(`{"osd":%s,"up":1,"in":%d,"uuid":"uuid%s","up_from":%d}`, id, in, id, upFrom))
+				}
+				return fmt.Sprintf(`{"osds":[%s]}`, strings.Join(osds, ",")), nil
+			case args[0] == "osd" && args[1] == "out":
+				out[args[2]] = true
+				commands = append(commands, strings.Join(args[:3], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	clusterdContext := &clusterd.Context{Clientset: clientset, Executor: executor}
+	m := &OSDHealthMonitor{
+		context:     clusterdContext,
+		clusterInfo: clusterInfo,
+		flapping:    &cephv1.OSDFlappingSpec{Enabled: true, Threshold: 2, AutoOut: true, Replace: true},
+		osdFlaps:    map[int]*osdFlaps{},
+		recorder:    recorder,
+	}
+
+	// the osds are marked up again twice
+	for i := 0; i < 3; i++ {
+		require.NoError(t, m.checkOSDFlapping())
+		upFrom += 2
+	}
+	assert.Equal(t, "Warning OSDFlapping osd.0 was marked up again 2 times in 10m0s", <-recorder.Events)
+	assert.Equal(t, "Warning OSDFlapping osd.1 was marked up again 2 times in 10m0s", <-recorder.Events)
+
+	// the flapping osd on the node is marked out to be replaced
+	assert.Equal(t, "Normal OSDFlappingReplaced marked flapping osd.0 out to replace it", <-recorder.Events)
+	assert.Equal(t, []string{"osd out 0"}, commands)
+	_, replacements, err := loadOSDReplacements(clusterdContext, clusterInfo)
+	require.NoError(t, err)
+	require.Len(t, replacements, 1)
+	assert.Equal(t, OSDReplacementReasonFlapping, replacements[0].Reason)
+	assert.Equal(t, "uuid0", replacements[0].UUID)
+	assert.Equal(t, "node1", replacements[0].Host)
+	assert.Equal(t, OSDReplacementDraining, replacements[0].Phase)
+
+	// the other flapping osd waits for the replacement in progress
+	require.NoError(t, m.checkOSDFlapping())
+	assert.Len(t, commands, 1)
+
+	// the flapping osd on a PVC is only marked out without replacement
+	m.flapping.Replace = false
+	require.NoError(t, m.checkOSDFlapping())
+	assert.Equal(t, []string{"osd out 0", "osd out 1"}, commands)
+	assert.Equal(t, "Normal OSDFlappingMarkedOut marked flapping osd.1 out", <-recorder.Events)
+
+	// disabled
+	m.flapping.Enabled = false
+	upFrom += 2
+	require.NoError(t, m.checkOSDFlapping())
+	assert.Len(t, commands, 2)
+}
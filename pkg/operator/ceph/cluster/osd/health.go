@@ -27,6 +27,7 @@ import (
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -43,6 +44,17 @@ type OSDHealthMonitor struct {
 	clusterInfo                    *client.ClusterInfo
 	removeOSDsIfOUTAndSafeToRemove bool
 	interval                       *time.Duration
+	memoryPressure                 *cephv1.OSDMemoryPressureSpec
+	replacement                    *cephv1.OSDReplacementSpec
+	flapping                       *cephv1.OSDFlappingSpec
+	osdFlaps                       map[int]*osdFlaps
+	recorder                       record.EventRecorder
+	pvcMigration                   *cephv1.OSDHostToPVCMigrationSpec
+	pvcMigrationReported           *cephv1.OSDPVCMigrationStatus
+	clusterSpec                    *cephv1.ClusterSpec
+	rookImage                      string
+	deviceSets                     []cephv1.StorageClassDeviceSet
+	scaleDownMessage               string
 }
 
 // NewOSDHealthMonitor instantiates OSD monitoring
@@ -52,6 +64,10 @@ func NewOSDHealthMonitor(context *clusterd.Context, clusterInfo *client.ClusterI
 		clusterInfo:                    clusterInfo,
 		removeOSDsIfOUTAndSafeToRemove: removeOSDsIfOUTAndSafeToRemove,
 		interval:                       &defaultHealthCheckInterval,
+		memoryPressure:                 healthCheck.OSDMemoryPressure,
+		replacement:                    healthCheck.OSDReplacement,
+		flapping:                       healthCheck.OSDFlapping,
+		osdFlaps:                       map[int]*osdFlaps{},
 	}
 
 	// allow overriding the check interval
@@ -97,6 +113,38 @@ func (m *OSDHealthMonitor) checkOSDHealth() {
 	if err != nil {
 		logger.Debugf("failed to check OSD Dump. %v", err)
 	}
+
+	if err := m.checkOSDMemoryPressure(); err != nil {
+		logger.Warningf("failed to check the OSD memory pressure. %v", err)
+	}
+
+	if err := m.checkCephConfigRollout(); err != nil {
+		logger.Warningf("failed to check the rollout of the OSD config. %v", err)
+	}
+
+	if err := m.checkOSDFlapping(); err != nil {
+		logger.Warningf("failed to check the flapping OSDs. %v", err)
+	}
+
+	if err := m.checkOSDReplacement(); err != nil {
+		logger.Warningf("failed to check the replacement of the OSDs on failing devices. %v", err)
+	}
+
+	if err := m.checkOSDPVCMigration(); err != nil {
+		logger.Warningf("failed to check the migration of the OSDs on the nodes to PVCs. %v", err)
+	}
+
+	if err := m.checkOSDScaleDown(); err != nil {
+		logger.Warningf("failed to check the scale down of the OSDs of the device sets. %v", err)
+	}
+
+	if err := m.checkOSDWeightRampUp(); err != nil {
+		logger.Warningf("failed to check the ramp up of the crush weight of the new OSDs. %v", err)
+	}
+
+	if err := m.checkNodeMaintenance(); err != nil {
+		logger.Warningf("failed to check the maintenance of the nodes. %v", err)
+	}
 }
 
 func (m *OSDHealthMonitor) checkOSDDump() error {
@@ -149,17 +197,17 @@ func (m *OSDHealthMonitor) removeOSDDeploymentIfSafeToDestroy(outOSDid int) erro
 		return errors.Wrapf(err, "failed to get osd deployment of osd id %d", outOSDid)
 	}
 	if len(dp.Items) != 0 {
-		safeToDestroyOSD, err := client.OsdSafeToDestroy(m.context, m.clusterInfo, outOSDid)
+		safety, err := client.CheckOSDSafety(m.context, m.clusterInfo, client.OSDSafetyCheck{OSDs: []int{outOSDid}, Remove: true})
 		if err != nil {
-			return errors.Wrapf(err, "failed to get osd deployment of osd id %d", outOSDid)
+			return errors.Wrapf(err, "failed to check if osd.%d is safe to remove", outOSDid)
 		}
 
-		if safeToDestroyOSD {
+		if safety.Safe {
 			podCreationTimestamp := dp.Items[0].GetCreationTimestamp()
 			podDeletionTimeStamp := podCreationTimestamp.Add(graceTime)
 			currentTime := time.Now().UTC()
 			if podDeletionTimeStamp.Before(currentTime) {
-				logger.Infof("osd.%d is 'safe-to-destroy'. removing the osd deployment.", outOSDid)
+				logger.Infof("osd.%d is safe to remove. removing the osd deployment.", outOSDid)
 				if err := k8sutil.DeleteDeployment(m.clusterInfo.Context, m.context.Clientset, dp.Items[0].Namespace, dp.Items[0].Name); err != nil {
 					return errors.Wrapf(err, "failed to delete osd deployment %s", dp.Items[0].Name)
 				}
@@ -44,7 +44,9 @@ func TestOSDHealthCheck(t *testing.T) {
 		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
 			logger.Infof("ExecuteCommandWithOutputFile: %s %v", command, args)
 			execCount++
-			if args[1] == "dump" {
+			if args[0] == "status" {
+				return `{"pgmap":{"num_pgs":100,"pgs_by_state":[{"state_name":"active+clean","count":100}]}}`, nil
+			} else if args[1] == "dump" {
 				// Mock executor for OSD Dump command, returning an osd in Down state
 				return `{"OSDs": [{"OSD": 0, "Up": 0, "In": 0}]}`, nil
 			} else if args[1] == "safe-to-destroy" {
@@ -90,8 +92,9 @@ func TestOSDHealthCheck(t *testing.T) {
 	// Run OSD monitoring routine
 	err := osdMon.checkOSDDump()
 	assert.Nil(t, err)
-	// After creating an OSD, the dump has 1 mocked cmd and safe to destroy has 1 mocked cmd
-	assert.Equal(t, 2, execCount)
+	// After creating an OSD, the dump has 1 mocked cmd and the safety check has 3 mocked cmds for
+	// the status, ok to stop and safe to destroy
+	assert.Equal(t, 4, execCount)
 
 	// Check if the osd deployment was deleted
 	dp, _ = context.Clientset.AppsV1().Deployments(clusterInfo.Namespace).List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%v=%d", OsdIdLabelKey, 0)})
@@ -126,8 +129,8 @@ func TestNewOSDHealthMonitor(t *testing.T) {
 		args args
 		want *OSDHealthMonitor
 	}{
-		{"default-interval", args{c, false, cephv1.CephClusterHealthCheckSpec{}}, &OSDHealthMonitor{c, clusterInfo, false, &defaultHealthCheckInterval}},
-		{"10s-interval", args{c, false, cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{ObjectStorageDaemon: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}, &OSDHealthMonitor{c, clusterInfo, false, &time10s}},
+		{"default-interval", args{c, false, cephv1.CephClusterHealthCheckSpec{}}, &OSDHealthMonitor{c, clusterInfo, false, &defaultHealthCheckInterval, nil}},
+		{"10s-interval", args{c, false, cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{ObjectStorageDaemon: cephv1.HealthCheckSpec{Interval: &metav1.Duration{Duration: time10s}}}}}, &OSDHealthMonitor{c, clusterInfo, false, &time10s, nil}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,10 +19,12 @@ package osd
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	kms "github.com/rook/rook/pkg/daemon/ceph/osd/kms"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	batch "k8s.io/api/batch/v1"
@@ -64,7 +66,7 @@ func applyKeyRotationPlacement(spec *v1.PodSpec, labels map[string]string) {
 }
 
 // getKeyRotationContainer returns the container spec for the key rotation job.
-func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts []v1.VolumeMount, devices []string) (v1.Container, error) {
+func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts []v1.VolumeMount, args []string) (v1.Container, error) {
 	envVars := c.getConfigEnvVars(osdProps, k8sutil.DataDir, true)
 
 	// enable debug logging
@@ -78,9 +80,6 @@ func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts [
 	runAsNonRoot := false
 	readOnlyRootFilesystem := false
 
-	args := []string{"key-management", "rotate-key", osdProps.pvc.ClaimName}
-	args = append(args, devices...)
-
 	osdProvisionContainer := v1.Container{
 		Args:            args,
 		Name:            keyRotationCronJobAppName,
@@ -112,44 +111,69 @@ func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDI
 	// create a volume on /dev so the pod can access devices on the host
 	devVolume := v1.Volume{Name: "devices", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/dev"}}}
 	udevVolume := v1.Volume{Name: "udev", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/run/udev"}}}
-	hostPathType := v1.HostPathDirectory
-	hostPath := filepath.Join(c.spec.DataDirHostPath, c.clusterInfo.Namespace, osdProps.pvc.ClaimName, fmt.Sprintf("ceph-%d", osd.ID))
-	hostPathVolume := v1.Volume{
-		Name: "bridge",
-		VolumeSource: v1.VolumeSource{
-			HostPath: &v1.HostPathVolumeSource{
-				Path: hostPath,
-				Type: &hostPathType,
-			},
-		},
-	}
-	devicesBasePath := "/var/lib/ceph/osd/"
 	volumes := []v1.Volume{
 		udevVolume,
 		devVolume,
-		hostPathVolume,
 	}
 	volumeMounts := []v1.VolumeMount{
 		{Name: "devices", MountPath: "/dev"},
 		{Name: "udev", MountPath: "/run/udev"},
-		{Name: "bridge", MountPath: devicesBasePath},
 	}
 
-	devices := []string{encryptionBlockDestinationCopy(devicesBasePath, bluestoreBlockName)}
-	if osdProps.metadataPVC.ClaimName != "" {
-		devices = append(devices, encryptionBlockDestinationCopy(devicesBasePath, bluestoreMetadataName))
-	}
-	if osdProps.walPVC.ClaimName != "" {
-		devices = append(devices, encryptionBlockDestinationCopy(devicesBasePath, bluestoreWalName))
-	}
+	var args []string
+	if osdProps.onPVC() {
+		hostPathType := v1.HostPathDirectory
+		hostPath := filepath.Join(c.spec.DataDirHostPath, c.clusterInfo.Namespace, osdProps.pvc.ClaimName, fmt.Sprintf("ceph-%d", osd.ID))
+		hostPathVolume := v1.Volume{
+			Name: "bridge",
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: hostPath,
+					Type: &hostPathType,
+				},
+			},
+		}
+		devicesBasePath := "/var/lib/ceph/osd/"
+		volumes = append(volumes, hostPathVolume)
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: "bridge", MountPath: devicesBasePath})
 
-	if c.spec.Security.KeyManagementService.IsVaultKMS() {
-		volumeTLS, volumeMountTLS := kms.VaultVolumeAndMount(c.spec.Security.KeyManagementService.ConnectionDetails, "")
-		volumes = append(volumes, volumeTLS)
-		volumeMounts = append(volumeMounts, volumeMountTLS)
+		devices := []string{encryptionBlockDestinationCopy(devicesBasePath, bluestoreBlockName)}
+		if osdProps.metadataPVC.ClaimName != "" {
+			devices = append(devices, encryptionBlockDestinationCopy(devicesBasePath, bluestoreMetadataName))
+		}
+		if osdProps.walPVC.ClaimName != "" {
+			devices = append(devices, encryptionBlockDestinationCopy(devicesBasePath, bluestoreWalName))
+		}
+		args = append([]string{"key-management", "rotate-key", osdProps.pvc.ClaimName}, devices...)
+
+		if c.spec.Security.KeyManagementService.IsVaultKMS() {
+			volumeTLS, volumeMountTLS := kms.VaultVolumeAndMount(c.spec.Security.KeyManagementService.ConnectionDetails, "")
+			volumes = append(volumes, volumeTLS)
+			volumeMounts = append(volumeMounts, volumeMountTLS)
+		}
+	} else {
+		// The key of the OSDs on nodes is stored by ceph-volume in the mon config-key store, so the
+		// job connects to the cluster with the admin key
+		volumes = append(volumes,
+			v1.Volume{Name: k8sutil.DataDirVolume, VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			mon.CephSecretVolume(),
+		)
+		volumeMounts = append(volumeMounts,
+			v1.VolumeMount{Name: k8sutil.DataDirVolume, MountPath: k8sutil.DataDir},
+			mon.CephSecretVolumeMount(),
+		)
+
+		blockPaths := []string{osd.BlockPath}
+		if osd.MetadataPath != "" {
+			blockPaths = append(blockPaths, osd.MetadataPath)
+		}
+		if osd.WalPath != "" {
+			blockPaths = append(blockPaths, osd.WalPath)
+		}
+		args = []string{"ceph", "osd", "rotate-key", "--osd-uuid", osd.UUID, "--block-paths", strings.Join(blockPaths, ",")}
 	}
 
-	keyRotationContainer, err := c.getKeyRotationContainer(osdProps, volumeMounts, devices)
+	keyRotationContainer, err := c.getKeyRotationContainer(osdProps, volumeMounts, args)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate key rotation container")
 	}
@@ -200,12 +224,12 @@ func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDI
 }
 
 // makeKeyRotationCronJob creates a key rotation cron job for the given OSD.
-func (c *Cluster) makeKeyRotationCronJob(pvcName string, osd OSDInfo, osdProps osdProperties) (*batch.CronJob, error) {
+func (c *Cluster) makeKeyRotationCronJob(osd OSDInfo, osdProps osdProperties) (*batch.CronJob, error) {
 	podSpec, err := c.getKeyRotationPodTemplateSpec(osdProps, osd, v1.RestartPolicyOnFailure)
 	if err != nil {
 		return nil, err
 	}
-	c.applyResourcesToAllContainers(&podSpec.Spec, cephv1.GetOSDResources(c.spec.Resources, osd.DeviceClass))
+	c.applyResourcesToAllContainers(&podSpec.Spec, c.osdResources(osd.DeviceClass))
 	schedule := c.spec.Security.KeyRotation.Schedule
 	if schedule == "" {
 		// default to rotate keyrings weekly (default is in code since default in crds causes issues)
@@ -252,9 +276,8 @@ func (c *Cluster) reconcileKeyRotationCronJob() error {
 		return nil
 	}
 
-	// Get the list of OSDs backed by pvc.
-	osdListOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s,%s", k8sutil.AppAttr, AppName, OSDOverPVCLabelKey)}
-	deployments, err := c.context.Clientset.AppsV1().Deployments(c.clusterInfo.Namespace).List(c.clusterInfo.Context, osdListOpts)
+	// Get the list of OSDs, both on PVCs and on nodes
+	deployments, err := c.getOSDDeployments()
 	if err != nil {
 		return errors.Wrap(err, "failed to query existing OSD deployments")
 	}
@@ -266,21 +289,37 @@ func (c *Cluster) reconcileKeyRotationCronJob() error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to get osd info for osd %q", osdDep.Name)
 		}
-		pvcName := osdDep.Labels[OSDOverPVCLabelKey]
-		if pvcName == "" {
-			return errors.Errorf("pvc name label %q for osd %q is empty",
-				OSDOverPVCLabelKey, osdDep.Name)
-		}
-		osdProps, err := c.getOSDPropsForPVC(pvcName)
-		if err != nil {
-			return errors.Wrapf(err, "failed to generate config for osd %q", osdDep.Name)
-		}
-		if !osdProps.encrypted {
-			continue
+		var osdProps osdProperties
+		if osdIsOnPVC(&osdDep) {
+			pvcName := osdDep.Labels[OSDOverPVCLabelKey]
+			if pvcName == "" {
+				return errors.Errorf("pvc name label %q for osd %q is empty",
+					OSDOverPVCLabelKey, osdDep.Name)
+			}
+			osdProps, err = c.getOSDPropsForPVC(pvcName)
+			if err != nil {
+				return errors.Wrapf(err, "failed to generate config for osd %q", osdDep.Name)
+			}
+			if !osdProps.encrypted {
+				continue
+			}
+		} else {
+			// the OSDs on nodes are encrypted by ceph-volume when the node has encryptedDevice set
+			if !osd.Encrypted {
+				continue
+			}
+			nodeName, err := getNodeOrPVCName(&osdDep)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the node of osd %q", osdDep.Name)
+			}
+			osdProps, err = c.getOSDPropsForNode(nodeName, osd.DeviceClass)
+			if err != nil {
+				return errors.Wrapf(err, "failed to generate config for osd %q", osdDep.Name)
+			}
 		}
 
 		logger.Infof("starting OSD key rotation cron job for osd %d", osd.ID)
-		cj, err := c.makeKeyRotationCronJob(pvcName, osd, osdProps)
+		cj, err := c.makeKeyRotationCronJob(osd, osdProps)
 		if err != nil {
 			return errors.Wrap(err, "failed to make key rotation cron job")
 		}
@@ -300,3 +339,31 @@ func (c *Cluster) reconcileKeyRotationCronJob() error {
 
 	return nil
 }
+
+// keyRotationStatus returns the status of the key rotation cron jobs of the encrypted OSDs, keyed
+// by OSD
+func (c *Cluster) keyRotationStatus() (map[string]cephv1.OSDKeyRotationStatus, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, keyRotationCronJobAppName)}
+	cronJobs, err := c.context.Clientset.BatchV1().CronJobs(c.clusterInfo.Namespace).List(c.clusterInfo.Context, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list key rotation cron jobs")
+	}
+
+	status := map[string]cephv1.OSDKeyRotationStatus{}
+	for i := range cronJobs.Items {
+		cj := &cronJobs.Items[i]
+		var osdID int
+		if _, err := fmt.Sscanf(cj.Name, keyRotationCronJobAppNameFmt, &osdID); err != nil {
+			logger.Debugf("skipping key rotation cron job %q. %v", cj.Name, err)
+			continue
+		}
+		status[fmt.Sprintf("osd.%d", osdID)] = cephv1.OSDKeyRotationStatus{
+			LastScheduleTime:   cj.Status.LastScheduleTime,
+			LastSuccessfulTime: cj.Status.LastSuccessfulTime,
+		}
+	}
+	if len(status) == 0 {
+		return nil, nil
+	}
+	return status, nil
+}
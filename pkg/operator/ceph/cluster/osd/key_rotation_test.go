@@ -17,12 +17,21 @@ limitations under the License.
 package osd
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batch "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_keyRotationCronJobName(t *testing.T) {
@@ -114,3 +123,39 @@ func Test_applyKeyRotationPlacement(t *testing.T) {
 		})
 	}
 }
+
+func Test_keyRotationStatus(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clusterInfo := &cephclient.ClusterInfo{Namespace: "ns", Context: context.TODO()}
+	c := &Cluster{context: &clusterd.Context{Clientset: clientset}, clusterInfo: clusterInfo}
+
+	// no status without key rotation cron jobs
+	status, err := c.keyRotationStatus()
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	scheduled := metav1.NewTime(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	succeeded := metav1.NewTime(time.Date(2025, 6, 1, 0, 1, 0, 0, time.UTC))
+	for _, cj := range []batch.CronJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: keyRotationCronJobName(0), Namespace: "ns", Labels: map[string]string{k8sutil.AppAttr: keyRotationCronJobAppName}},
+			Status:     batch.CronJobStatus{LastScheduleTime: &scheduled, LastSuccessfulTime: &succeeded},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: keyRotationCronJobName(3), Namespace: "ns", Labels: map[string]string{k8sutil.AppAttr: keyRotationCronJobAppName}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"},
+		},
+	} {
+		_, err := clientset.BatchV1().CronJobs("ns").Create(context.TODO(), &cj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	status, err = c.keyRotationStatus()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cephv1.OSDKeyRotationStatus{
+		"osd.0": {LastScheduleTime: &scheduled, LastSuccessfulTime: &succeeded},
+		"osd.3": {},
+	}, status)
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NodeMaintenanceUntilAnnotation on a node requests to keep the OSDs of the node in the cluster
+	// while the node is rebooted, at most until the RFC3339 time of the annotation
+	NodeMaintenanceUntilAnnotation = "ceph.rook.io/maintenance-until"
+	// NodeMaintenanceLabel set to "true" on a node requests the maintenance of the node for at most
+	// the osdMaintenanceTimeout of the disruption management, for tools like kured that label the
+	// nodes before they are rebooted
+	NodeMaintenanceLabel = "ceph.rook.io/maintenance"
+
+	// NodeMaintenanceConfigMap records the maintenance of the nodes
+	NodeMaintenanceConfigMap = "rook-ceph-node-maintenance"
+	nodeMaintenanceKey       = "nodes"
+
+	// NodeMaintenanceInProgress is the phase of a maintenance until the node returns
+	NodeMaintenanceInProgress = "InProgress"
+	// NodeMaintenanceCompleted is the phase of a maintenance once the node rebooted and its OSDs are up
+	NodeMaintenanceCompleted = "Completed"
+	// NodeMaintenanceExpired is the phase of a maintenance whose node did not return in time
+	NodeMaintenanceExpired = "Expired"
+
+	nodeMaintenanceLabelRequest   = "label"
+	defaultNodeMaintenanceTimeout = 30 * time.Minute
+	nooutFlag                     = "noout"
+)
+
+// NodeMaintenance is the maintenance of a node during which noout is set on its OSDs
+type NodeMaintenance struct {
+	Node string `json:"node"`
+	// Request is the value of the annotation that requested the maintenance, or "label"
+	Request string      `json:"request"`
+	Until   metav1.Time `json:"until"`
+	// BootID of the node when the maintenance started, the node returned once it changed
+	BootID string `json:"bootID,omitempty"`
+	OSDs   []int  `json:"osds"`
+	// CrushUnits are the CRUSH hosts, or the OSDs, that noout is set on
+	CrushUnits []string    `json:"crushUnits"`
+	OkToStop   bool        `json:"okToStop"`
+	Phase      string      `json:"phase"`
+	Time       metav1.Time `json:"time"`
+}
+
+// nodeMaintenanceRequest is the maintenance requested on a node
+type nodeMaintenanceRequest struct {
+	node    *v1.Node
+	request string
+	until   time.Time
+}
+
+// checkNodeMaintenance sets noout on the OSDs of the nodes annotated for a maintenance, so that the
+// data of the OSDs is not moved to the other OSDs while the nodes are rebooted, and checks whether
+// the OSDs are ok to stop. The noout flag is cleared once the node rebooted and its OSDs are up
+// again, when the maintenance is no longer requested, or when the maintenance expires.
+func (m *OSDHealthMonitor) checkNodeMaintenance() error {
+	nodes, err := m.context.Clientset.CoreV1().Nodes().List(m.clusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list nodes")
+	}
+	now := time.Now()
+	requests := map[string]nodeMaintenanceRequest{}
+	for i, node := range nodes.Items {
+		if value, ok := node.Annotations[NodeMaintenanceUntilAnnotation]; ok {
+			until, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				logger.Warningf("invalid annotation %q on node %q, must be an RFC3339 time. %v", NodeMaintenanceUntilAnnotation, node.Name, err)
+				continue
+			}
+			requests[node.Name] = nodeMaintenanceRequest{node: &nodes.Items[i], request: value, until: until}
+		} else if node.Labels[NodeMaintenanceLabel] == "true" {
+			requests[node.Name] = nodeMaintenanceRequest{node: &nodes.Items[i], request: nodeMaintenanceLabelRequest, until: now.Add(m.nodeMaintenanceTimeout())}
+		}
+	}
+
+	cm, maintenances, err := loadNodeMaintenances(m)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 && len(maintenances) == 0 {
+		return nil
+	}
+
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+
+	remaining := []NodeMaintenance{}
+	for _, maintenance := range maintenances {
+		request, ok := requests[maintenance.Node]
+		if !ok || request.request != maintenance.Request {
+			// the maintenance is no longer requested, or a new maintenance is requested
+			if maintenance.Phase == NodeMaintenanceInProgress {
+				if err := m.endNodeMaintenance(&maintenance, NodeMaintenanceCompleted); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		delete(requests, maintenance.Node)
+
+		if maintenance.Phase == NodeMaintenanceInProgress {
+			if !now.Before(maintenance.Until.Time) {
+				if err := m.endNodeMaintenance(&maintenance, NodeMaintenanceExpired); err != nil {
+					return err
+				}
+			} else if nodeReturned(request.node, &maintenance, osdDump) {
+				if err := m.endNodeMaintenance(&maintenance, NodeMaintenanceCompleted); err != nil {
+					return err
+				}
+			}
+		}
+		// the ended maintenances are kept so that they are not started again while requested
+		remaining = append(remaining, maintenance)
+	}
+
+	for _, name := range sortedNodeMaintenanceRequests(requests) {
+		request := requests[name]
+		if !now.Before(request.until) {
+			logger.Debugf("not starting the maintenance of node %q that expired at %s", name, request.until.Format(time.RFC3339))
+			continue
+		}
+		maintenance, err := m.startNodeMaintenance(request, now)
+		if err != nil {
+			return errors.Wrapf(err, "failed to start the maintenance of node %q", name)
+		}
+		if maintenance != nil {
+			remaining = append(remaining, *maintenance)
+		}
+	}
+
+	return saveNodeMaintenances(m, cm, remaining)
+}
+
+// startNodeMaintenance sets noout on the OSDs of the node and checks if they are ok to stop. It
+// returns nil if the node has no OSDs of the cluster.
+func (m *OSDHealthMonitor) startNodeMaintenance(request nodeMaintenanceRequest, now time.Time) (*NodeMaintenance, error) {
+	osds, crushUnits, err := m.nodeOSDs(request.node)
+	if err != nil {
+		return nil, err
+	}
+	if len(osds) == 0 {
+		return nil, nil
+	}
+
+	for _, unit := range crushUnits {
+		if err := client.SetFlagOnCrushUnit(m.context, m.clusterInfo, unit, nooutFlag); err != nil {
+			return nil, err
+		}
+	}
+	maintenance := &NodeMaintenance{
+		Node:       request.node.Name,
+		Request:    request.request,
+		Until:      metav1.NewTime(request.until),
+		BootID:     request.node.Status.NodeInfo.BootID,
+		OSDs:       osds,
+		CrushUnits: crushUnits,
+		Phase:      NodeMaintenanceInProgress,
+		Time:       metav1.NewTime(now),
+	}
+	logger.Infof("set noout on %v of node %q for its maintenance until %s", crushUnits, request.node.Name, request.until.Format(time.RFC3339))
+	m.recordEvent(v1.EventTypeNormal, NodeMaintenanceStartedReason, "set noout on the osds %v of node %q for its maintenance until %s", osds, request.node.Name, request.until.Format(time.RFC3339))
+
+	safety, err := client.CheckOSDSafety(m.context, m.clusterInfo, client.OSDSafetyCheck{OSDs: osds})
+	if err != nil {
+		logger.Warningf("failed to check if the osds of node %q are ok to stop for its maintenance. %v", request.node.Name, err)
+	} else if !safety.Safe {
+		logger.Warningf("the osds of node %q are not ok to stop for its maintenance. %s", request.node.Name, safety)
+		m.recordEvent(v1.EventTypeWarning, NodeMaintenanceNotOkToStopReason, "the osds %v of node %q are not ok to stop for its maintenance", osds, request.node.Name)
+	} else {
+		maintenance.OkToStop = true
+	}
+	return maintenance, nil
+}
+
+// endNodeMaintenance clears noout on the OSDs of the node
+func (m *OSDHealthMonitor) endNodeMaintenance(maintenance *NodeMaintenance, phase string) error {
+	for _, unit := range maintenance.CrushUnits {
+		if err := client.UnsetFlagOnCrushUnit(m.context, m.clusterInfo, unit, nooutFlag); err != nil {
+			return errors.Wrapf(err, "failed to end the maintenance of node %q", maintenance.Node)
+		}
+	}
+	maintenance.Phase = phase
+	if phase == NodeMaintenanceExpired {
+		logger.Warningf("cleared noout on %v of node %q since its maintenance expired", maintenance.CrushUnits, maintenance.Node)
+		m.recordEvent(v1.EventTypeWarning, NodeMaintenanceExpiredReason, "cleared noout on the osds %v of node %q since its maintenance expired", maintenance.OSDs, maintenance.Node)
+		return nil
+	}
+	logger.Infof("cleared noout on %v of node %q after its maintenance", maintenance.CrushUnits, maintenance.Node)
+	m.recordEvent(v1.EventTypeNormal, NodeMaintenanceCompletedReason, "cleared noout on the osds %v of node %q after its maintenance", maintenance.OSDs, maintenance.Node)
+	return nil
+}
+
+// nodeOSDs returns the OSDs of the node and the CRUSH hosts, or the OSDs without a host, to set
+// noout on. The OSDs on nodes are found from their deployments even if the node was drained, and
+// the OSDs on PVCs from their pods running on the node.
+func (m *OSDHealthMonitor) nodeOSDs(node *v1.Node) ([]int, []string, error) {
+	hostname := node.Labels[k8sutil.LabelHostname()]
+	if hostname == "" {
+		hostname = node.Name
+	}
+	crushHosts := map[int]string{}
+
+	deployments, err := k8sutil.GetDeployments(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get the osd deployments")
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if _, ok := d.Labels[OSDOverPVCLabelKey]; ok {
+			continue
+		}
+		if name, err := getNodeOrPVCName(d); err != nil || name != hostname {
+			continue
+		}
+		if id, err := strconv.Atoi(d.Labels[OsdIdLabelKey]); err == nil {
+			crushHosts[id] = d.Labels[fmt.Sprintf(TopologyLocationLabel, "host")]
+		}
+	}
+
+	pods, err := m.context.Clientset.CoreV1().Pods(m.clusterInfo.Namespace).List(m.clusterInfo.Context, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName),
+		FieldSelector: "spec.nodeName=" + node.Name,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list the osd pods")
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node.Name {
+			continue
+		}
+		if id, err := strconv.Atoi(pod.Labels[OsdIdLabelKey]); err == nil {
+			crushHosts[id] = pod.Labels[fmt.Sprintf(TopologyLocationLabel, "host")]
+		}
+	}
+
+	osds := []int{}
+	crushUnits := []string{}
+	for id, host := range crushHosts {
+		osds = append(osds, id)
+		unit := host
+		if unit == "" {
+			unit = fmt.Sprintf("osd.%d", id)
+		}
+		if !slices.Contains(crushUnits, unit) {
+			crushUnits = append(crushUnits, unit)
+		}
+	}
+	slices.Sort(osds)
+	slices.Sort(crushUnits)
+	return osds, crushUnits, nil
+}
+
+// nodeReturned returns whether the node rebooted since the maintenance started, is ready, and the
+// OSDs of the node are up
+func nodeReturned(node *v1.Node, maintenance *NodeMaintenance, osdDump *client.OSDDump) bool {
+	if node.Status.NodeInfo.BootID == maintenance.BootID || !k8sutil.NodeIsReady(*node) {
+		return false
+	}
+	for _, id := range maintenance.OSDs {
+		up, _, err := osdDump.StatusByID(int64(id))
+		if err == nil && up != upStatus {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *OSDHealthMonitor) nodeMaintenanceTimeout() time.Duration {
+	if m.clusterSpec != nil && m.clusterSpec.DisruptionManagement.OSDMaintenanceTimeout > 0 {
+		return m.clusterSpec.DisruptionManagement.OSDMaintenanceTimeout * time.Minute
+	}
+	return defaultNodeMaintenanceTimeout
+}
+
+func sortedNodeMaintenanceRequests(requests map[string]nodeMaintenanceRequest) []string {
+	keys := []string{}
+	for key := range requests {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func loadNodeMaintenances(m *OSDHealthMonitor) (*v1.ConfigMap, []NodeMaintenance, error) {
+	maintenances := []NodeMaintenance{}
+	cm, err := m.context.Clientset.CoreV1().ConfigMaps(m.clusterInfo.Namespace).Get(m.clusterInfo.Context, NodeMaintenanceConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, nil, errors.Wrapf(err, "failed to get configmap %q", NodeMaintenanceConfigMap)
+		}
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: NodeMaintenanceConfigMap, Namespace: m.clusterInfo.Namespace}}
+		if err := m.clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to set owner reference on configmap %q", NodeMaintenanceConfigMap)
+		}
+		return cm, maintenances, nil
+	}
+	if cm.Data[nodeMaintenanceKey] == "" {
+		return cm, maintenances, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[nodeMaintenanceKey]), &maintenances); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal the node maintenances in configmap %q", NodeMaintenanceConfigMap)
+	}
+	return cm, maintenances, nil
+}
+
+func saveNodeMaintenances(m *OSDHealthMonitor, cm *v1.ConfigMap, maintenances []NodeMaintenance) error {
+	raw, err := json.Marshal(maintenances)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the node maintenances")
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[nodeMaintenanceKey] = string(raw)
+	if _, err := k8sutil.CreateOrUpdateConfigMap(m.clusterInfo.Context, m.context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", NodeMaintenanceConfigMap)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestCheckNodeMaintenance(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	for _, d := range []*appsv1.Deployment{
+		osdDeploymentOnNode(clusterInfo.Namespace, 0, "node1"),
+		osdDeploymentOnNode(clusterInfo.Namespace, 1, "node1"),
+		osdDeploymentOnNode(clusterInfo.Namespace, 2, "node2"),
+	} {
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	readyNode := func(name, bootID string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{v1.LabelHostname: name}},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				NodeInfo:   v1.NodeSystemInfo{BootID: bootID},
+			},
+		}
+	}
+	for _, node := range []*v1.Node{readyNode("node1", "boot1"), readyNode("node2", "boot1"), readyNode("node3", "boot1")} {
+		_, err := clientset.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	osdUp := map[int]int{0: 1, 1: 1, 2: 1}
+	okToStop := true
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "dump":
+				osds := []string{}
+				for _, id := range []int{0, 1, 2} {
+					osds = append(osds, fmt.Sprintf(`{"osd":%d,"up":%d,"in":1}`, id, osdUp[id]))
+				}
+				return fmt.Sprintf(`{"osds":[%s]}`, strings.Join(osds, ",")), nil
+			case args[0] == "osd" && (args[1] == "set-group" || args[1] == "unset-group"):
+				commands = append(commands, strings.Join(args[:4], " "))
+				return "", nil
+			case args[0] == "status":
+				return `{"pgmap":{"num_pgs":0}}`, nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				if !okToStop {
+					return "", errors.New("not ok to stop")
+				}
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	m := &OSDHealthMonitor{
+		context:     &clusterd.Context{Clientset: clientset, Executor: executor},
+		clusterInfo: clusterInfo,
+		recorder:    recorder,
+	}
+	annotate := func(name, value string) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		node.Annotations = map[string]string{}
+		if value != "" {
+			node.Annotations[NodeMaintenanceUntilAnnotation] = value
+		}
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	// nothing to do without a maintenance
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Empty(t, commands)
+
+	// noout is set on the host of the osds of the node in maintenance
+	until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	annotate("node1", until)
+	// the maintenance of a node without osds is ignored
+	annotate("node3", until)
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Equal(t, []string{"osd set-group noout node1"}, commands)
+	assert.Equal(t, fmt.Sprintf("Normal NodeMaintenanceStarted set noout on the osds [0 1] of node \"node1\" for its maintenance until %s", until), <-recorder.Events)
+	_, maintenances, err := loadNodeMaintenances(m)
+	require.NoError(t, err)
+	require.Len(t, maintenances, 1)
+	assert.Equal(t, "node1", maintenances[0].Node)
+	assert.Equal(t, []int{0, 1}, maintenances[0].OSDs)
+	assert.True(t, maintenances[0].OkToStop)
+	assert.Equal(t, NodeMaintenanceInProgress, maintenances[0].Phase)
+
+	// the maintenance goes on until the node rebooted and its osds are up
+	osdUp[0] = 0
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Len(t, commands, 1)
+	node, err := clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+	require.NoError(t, err)
+	node.Status.NodeInfo.BootID = "boot2"
+	_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Len(t, commands, 1)
+	osdUp[0] = 1
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Equal(t, []string{"osd set-group noout node1", "osd unset-group noout node1"}, commands)
+	assert.Equal(t, "Normal NodeMaintenanceCompleted cleared noout on the osds [0 1] of node \"node1\" after its maintenance", <-recorder.Events)
+
+	// the completed maintenance is not started again while the node is annotated
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Len(t, commands, 2)
+
+	// the maintenance is started again with a new annotation, even if the osds are not ok to stop
+	okToStop = false
+	annotate("node1", time.Now().Add(2*time.Hour).UTC().Format(time.RFC3339))
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Len(t, commands, 3)
+	<-recorder.Events
+	assert.Equal(t, "Warning NodeMaintenanceNotOkToStop the osds [0 1] of node \"node1\" are not ok to stop for its maintenance", <-recorder.Events)
+
+	// noout is cleared when the annotation is removed
+	annotate("node1", "")
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Equal(t, "osd unset-group noout node1", commands[3])
+	_, maintenances, err = loadNodeMaintenances(m)
+	require.NoError(t, err)
+	assert.Empty(t, maintenances)
+
+	// noout is cleared when the maintenance expires
+	annotate("node2", time.Now().Add(2*time.Second).UTC().Format(time.RFC3339))
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Equal(t, "osd set-group noout node2", commands[4])
+	time.Sleep(2 * time.Second)
+	require.NoError(t, m.checkNodeMaintenance())
+	assert.Equal(t, "osd unset-group noout node2", commands[5])
+	_, maintenances, err = loadNodeMaintenances(m)
+	require.NoError(t, err)
+	require.Len(t, maintenances, 1)
+	assert.Equal(t, NodeMaintenanceExpired, maintenances[0].Phase)
+}
+
+func osdDeploymentOnNode(namespace string, id int, node string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("rook-ceph-osd-%d", id),
+			Namespace: namespace,
+			Labels: map[string]string{
+				k8sutil.AppAttr:          AppName,
+				OsdIdLabelKey:            fmt.Sprintf("%d", id),
+				"topology-location-host": node,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+			NodeSelector: map[string]string{v1.LabelHostname: node},
+		}}},
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultMemoryPressureThreshold = 20
+	defaultMemoryTargetReduction   = 25
+	// memoryTargetCgroupLimitRatio is the default osd_memory_target_cgroup_limit_ratio, ceph sets
+	// the memory target of the OSDs to this ratio of their memory limit
+	memoryTargetCgroupLimitRatio = 0.8
+	osdMemoryTargetOption        = "osd_memory_target"
+	// osdMemoryTargetsConfigMap records the memory targets of the OSDs before they were lowered
+	osdMemoryTargetsConfigMap = "rook-ceph-osd-memory-targets"
+)
+
+// kubeletSummary is the subset of the kubelet stats summary with the memory pressure of the pods.
+// The pressure is only reported on the cgroup v2 nodes with the KubeletPSI feature.
+type kubeletSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Memory *struct {
+			PSI *struct {
+				Some struct {
+					Avg10 float64 `json:"avg10"`
+				} `json:"some"`
+			} `json:"psi"`
+		} `json:"memory"`
+	} `json:"pods"`
+}
+
+var getKubeletSummary = func(ctx context.Context, clientset kubernetes.Interface, nodeName string) (*kubeletSummary, error) {
+	raw, err := clientset.CoreV1().RESTClient().Get().Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats/summary").DoRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the kubelet stats summary of node %q", nodeName)
+	}
+	summary := &kubeletSummary{}
+	if err := json.Unmarshal(raw, summary); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the kubelet stats summary of node %q", nodeName)
+	}
+	return summary, nil
+}
+
+// podMemoryPressure returns the percentage of the last 10 seconds the pod was stalled waiting for
+// memory, and whether the pressure is reported for the pod
+func (s *kubeletSummary) podMemoryPressure(pod *v1.Pod) (float64, bool) {
+	for _, p := range s.Pods {
+		if p.PodRef.Name != pod.Name || p.PodRef.Namespace != pod.Namespace {
+			continue
+		}
+		if p.Memory == nil || p.Memory.PSI == nil {
+			return 0, false
+		}
+		return p.Memory.PSI.Some.Avg10, true
+	}
+	return 0, false
+}
+
+// throttledMemoryTarget returns the lowered memory target of an OSD with the given memory limit
+func throttledMemoryTarget(memoryLimit int64, spec *cephv1.OSDMemoryPressureSpec) string {
+	reduction := defaultMemoryTargetReduction
+	if spec.TargetReduction > 0 {
+		reduction = spec.TargetReduction
+	}
+	target := float64(memoryLimit) * memoryTargetCgroupLimitRatio * float64(100-reduction) / 100
+	return strconv.FormatInt(int64(target), 10)
+}
+
+// checkOSDMemoryPressure lowers the memory target of the OSDs whose pods are under memory pressure,
+// and restores it once the pressure is gone. The memory target set for the OSD before it was lowered
+// is recorded in a configmap, so that it is restored after an operator restart as well.
+func (m *OSDHealthMonitor) checkOSDMemoryPressure() error {
+	spec := m.memoryPressure
+	if spec == nil || !spec.Enabled {
+		return nil
+	}
+	threshold := float64(defaultMemoryPressureThreshold)
+	if spec.Threshold > 0 {
+		threshold = float64(spec.Threshold)
+	}
+
+	pods, err := m.context.Clientset.CoreV1().Pods(m.clusterInfo.Namespace).List(m.clusterInfo.Context, metav1.ListOptions{LabelSelector: fmt.Sprintf("app=%s", AppName)})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the osd pods")
+	}
+	previousTargets, err := m.getPreviousMemoryTargets()
+	if err != nil {
+		return err
+	}
+
+	monStore := opconfig.GetMonStore(m.context, m.clusterInfo)
+	summaries := map[string]*kubeletSummary{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		osdID := pod.Labels[OsdIdLabelKey]
+		if pod.Status.Phase != v1.PodRunning || pod.Spec.NodeName == "" || osdID == "" {
+			continue
+		}
+		memoryLimit := osdMemoryLimit(pod)
+		if memoryLimit == 0 {
+			logger.Debugf("osd.%s has no memory limit, skipping the memory pressure check", osdID)
+			continue
+		}
+
+		summary, ok := summaries[pod.Spec.NodeName]
+		if !ok {
+			summary, err = getKubeletSummary(m.clusterInfo.Context, m.context.Clientset, pod.Spec.NodeName)
+			if err != nil {
+				logger.Warningf("failed to check the memory pressure of the osds on node %q. %v", pod.Spec.NodeName, err)
+			}
+			summaries[pod.Spec.NodeName] = summary
+		}
+		if summary == nil {
+			continue
+		}
+		pressure, ok := summary.podMemoryPressure(pod)
+		if !ok {
+			logger.Debugf("the memory pressure of osd.%s is not reported, cgroup v2 and the KubeletPSI feature are required on node %q", osdID, pod.Spec.NodeName)
+			continue
+		}
+
+		who := fmt.Sprintf("osd.%s", osdID)
+		current, err := getDaemonMemoryTarget(monStore, who)
+		if err != nil {
+			logger.Warningf("failed to check the memory pressure of %s. %v", who, err)
+			continue
+		}
+		throttled := throttledMemoryTarget(memoryLimit, spec)
+		previous, lowered := previousTargets.Data[who]
+		if pressure >= threshold && current != throttled {
+			logger.Warningf("%s is under memory pressure (%.2f%%), lowering its memory target to %s bytes", who, pressure, throttled)
+			if !lowered {
+				// the target to restore must be recorded before it is lowered
+				previousTargets.Data[who] = current
+				if err := m.savePreviousMemoryTargets(previousTargets); err != nil {
+					logger.Warningf("failed to lower the memory target of %s. %v", who, err)
+					delete(previousTargets.Data, who)
+					continue
+				}
+			}
+			if err := monStore.Set(who, osdMemoryTargetOption, throttled); err != nil {
+				logger.Warningf("failed to lower the memory target of %s. %v", who, err)
+			}
+		} else if pressure < threshold/2 && lowered {
+			if err := restoreMemoryTarget(monStore, who, current, throttled, previous); err != nil {
+				logger.Warningf("failed to restore the memory target of %s. %v", who, err)
+				continue
+			}
+			logger.Infof("%s is not under memory pressure anymore (%.2f%%), restored its memory target", who, pressure)
+			delete(previousTargets.Data, who)
+			if err := m.savePreviousMemoryTargets(previousTargets); err != nil {
+				logger.Warningf("failed to remove the previous memory target of %s. %v", who, err)
+			}
+		}
+	}
+	return nil
+}
+
+// restoreMemoryTarget restores the memory target the OSD had before it was lowered, or removes it if
+// the OSD had none. The target is left unchanged if it was changed since it was lowered.
+func restoreMemoryTarget(monStore *opconfig.MonStore, who, current, throttled, previous string) error {
+	if current != throttled {
+		logger.Infof("the memory target of %s was changed since it was lowered, keeping it", who)
+		return nil
+	}
+	if previous == "" {
+		return monStore.Delete(who, osdMemoryTargetOption)
+	}
+	return monStore.Set(who, osdMemoryTargetOption, previous)
+}
+
+// getDaemonMemoryTarget returns the memory target set for the OSD itself, or an empty string if the
+// OSD uses the target of the osd section or the default
+func getDaemonMemoryTarget(monStore *opconfig.MonStore, who string) (string, error) {
+	options, err := monStore.GetDaemon(who)
+	if err != nil {
+		return "", err
+	}
+	for _, option := range options {
+		if option.Option == osdMemoryTargetOption {
+			return option.Value, nil
+		}
+	}
+	return "", nil
+}
+
+// getPreviousMemoryTargets returns the configmap with the memory targets of the OSDs before they
+// were lowered, keyed by OSD
+func (m *OSDHealthMonitor) getPreviousMemoryTargets() (*v1.ConfigMap, error) {
+	cm, err := m.context.Clientset.CoreV1().ConfigMaps(m.clusterInfo.Namespace).Get(m.clusterInfo.Context, osdMemoryTargetsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get configmap %q", osdMemoryTargetsConfigMap)
+		}
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: osdMemoryTargetsConfigMap, Namespace: m.clusterInfo.Namespace}}
+		if err := m.clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+			return nil, errors.Wrapf(err, "failed to set owner reference on configmap %q", osdMemoryTargetsConfigMap)
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	return cm, nil
+}
+
+func (m *OSDHealthMonitor) savePreviousMemoryTargets(cm *v1.ConfigMap) error {
+	if _, err := k8sutil.CreateOrUpdateConfigMap(m.clusterInfo.Context, m.context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", osdMemoryTargetsConfigMap)
+	}
+	return nil
+}
+
+// osdMemoryLimit returns the memory limit of the osd container in bytes, or zero if it has none
+func osdMemoryLimit(pod *v1.Pod) int64 {
+	return podSpecMemoryLimit(&pod.Spec)
+}
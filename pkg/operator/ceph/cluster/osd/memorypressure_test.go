@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestThrottledMemoryTarget(t *testing.T) {
+	// 80% of the 4Gi limit lowered by the default 25%
+	assert.Equal(t, "2576980377", throttledMemoryTarget(4<<30, &cephv1.OSDMemoryPressureSpec{}))
+	assert.Equal(t, "1717986918", throttledMemoryTarget(4<<30, &cephv1.OSDMemoryPressureSpec{TargetReduction: 50}))
+}
+
+func TestCheckOSDMemoryPressure(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+
+	newPod := func(name, osdID string, limit string) {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: clusterInfo.Namespace, Labels: map[string]string{"app": AppName, OsdIdLabelKey: osdID}},
+			Spec: v1.PodSpec{
+				NodeName:   "node1",
+				Containers: []v1.Container{{Name: "osd"}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if limit != "" {
+			pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse(limit)}
+		}
+		_, err := clientset.CoreV1().Pods(clusterInfo.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	newPod("osd0", "0", "4Gi")
+	newPod("osd1", "1", "")
+	newPod("osd2", "2", "4Gi")
+	newPod("osd3", "3", "4Gi")
+
+	pressure := 0.0
+	originalGetKubeletSummary := getKubeletSummary
+	t.Cleanup(func() { getKubeletSummary = originalGetKubeletSummary })
+	getKubeletSummary = func(ctx context.Context, clientset kubernetes.Interface, nodeName string) (*kubeletSummary, error) {
+		// the pressure of osd2 is not reported
+		raw, _ := json.Marshal(map[string]interface{}{"pods": []interface{}{
+			map[string]interface{}{
+				"podRef": map[string]string{"name": "osd0", "namespace": clusterInfo.Namespace},
+				"memory": map[string]interface{}{"psi": map[string]interface{}{"some": map[string]float64{"avg10": pressure}}},
+			},
+			map[string]interface{}{
+				"podRef": map[string]string{"name": "osd1", "namespace": clusterInfo.Namespace},
+				"memory": map[string]interface{}{"psi": map[string]interface{}{"some": map[string]float64{"avg10": 90}}},
+			},
+			map[string]interface{}{
+				"podRef": map[string]string{"name": "osd2", "namespace": clusterInfo.Namespace},
+				"memory": map[string]interface{}{},
+			},
+			map[string]interface{}{
+				"podRef": map[string]string{"name": "osd3", "namespace": clusterInfo.Namespace},
+				"memory": map[string]interface{}{"psi": map[string]interface{}{"some": map[string]float64{"avg10": pressure}}},
+			},
+		}})
+		summary := &kubeletSummary{}
+		return summary, json.Unmarshal(raw, summary)
+	}
+
+	memoryTarget := map[string]string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch args[1] {
+			case "get":
+				if args[2] == "osd.3" {
+					return "", errors.New("failed to get the config of osd.3")
+				}
+				if target, ok := memoryTarget[args[2]]; ok {
+					return fmt.Sprintf(`{"osd_memory_target":{"value":%q,"section":%q}}`, target, args[2]), nil
+				}
+				return "{}", nil
+			case "set":
+				memoryTarget[args[2]] = args[4]
+				return "", nil
+			case "rm":
+				delete(memoryTarget, args[2])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	m := &OSDHealthMonitor{
+		context:        &clusterd.Context{Clientset: clientset, Executor: executor},
+		clusterInfo:    clusterInfo,
+		memoryPressure: &cephv1.OSDMemoryPressureSpec{Enabled: true, Threshold: 30},
+	}
+
+	previousTargets := func() map[string]string {
+		cm, err := clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(ctx, osdMemoryTargetsConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		return cm.Data
+	}
+
+	// below the threshold
+	pressure = 25
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Empty(t, memoryTarget)
+
+	// above the threshold, only the osd with a memory limit and a reported pressure is throttled, and
+	// the failure to get the config of osd.3 does not prevent it
+	pressure = 40
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Equal(t, map[string]string{"osd.0": "2576980377"}, memoryTarget)
+	assert.Equal(t, map[string]string{"osd.0": ""}, previousTargets())
+
+	// the target is kept until the pressure is below half the threshold
+	pressure = 20
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Equal(t, map[string]string{"osd.0": "2576980377"}, memoryTarget)
+	pressure = 10
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Empty(t, memoryTarget)
+	assert.Empty(t, previousTargets())
+
+	// the memory target set by the user is restored
+	memoryTarget["osd.0"] = "3000000000"
+	pressure = 40
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Equal(t, map[string]string{"osd.0": "2576980377"}, memoryTarget)
+	assert.Equal(t, map[string]string{"osd.0": "3000000000"}, previousTargets())
+	pressure = 10
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Equal(t, map[string]string{"osd.0": "3000000000"}, memoryTarget)
+	assert.Empty(t, previousTargets())
+
+	// the memory target changed by the user while lowered is kept
+	pressure = 40
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	memoryTarget["osd.0"] = "2000000000"
+	pressure = 10
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Equal(t, map[string]string{"osd.0": "2000000000"}, memoryTarget)
+	assert.Empty(t, previousTargets())
+	delete(memoryTarget, "osd.0")
+
+	// nothing is checked when disabled
+	pressure = 40
+	m.memoryPressure.Enabled = false
+	assert.NoError(t, m.checkOSDMemoryPressure())
+	assert.Empty(t, memoryTarget)
+}
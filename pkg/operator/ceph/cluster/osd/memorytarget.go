@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"strconv"
+
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// memoryTarget returns the memory target ceph sets for an OSD with the given memory limit, or an
+// empty string if the OSD has no memory limit
+func memoryTarget(memoryLimit int64) string {
+	if memoryLimit == 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64(float64(memoryLimit)*memoryTargetCgroupLimitRatio), 10)
+}
+
+// retuneOSDMemoryTargets applies the memory target of the OSDs whose memory limit was changed in the
+// spec. Ceph only derives the memory target from the memory limit when the OSD starts, so the target
+// is set in the config of the running OSDs for them to use it right away rather than once their pods
+// are updated. The target is not changed if it was set by the user, by the resource profile of the
+// device class of the OSD, or lowered under memory pressure.
+func (c *Cluster) retuneOSDMemoryTargets(deployments *appsv1.DeploymentList) {
+	c.appliedMemoryTargets = map[string]string{}
+	if len(deployments.Items) == 0 {
+		return
+	}
+
+	var lowered map[string]string
+	cm, err := c.context.Clientset.CoreV1().ConfigMaps(c.clusterInfo.Namespace).Get(c.clusterInfo.Context, osdMemoryTargetsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to retune the osd memory targets. failed to get configmap %q. %v", osdMemoryTargetsConfigMap, err)
+			return
+		}
+	} else {
+		lowered = cm.Data
+	}
+
+	monStore := opconfig.GetMonStore(c.context, c.clusterInfo)
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			logger.Warningf("failed to retune the memory target of deployment %q. %v", d.Name, err)
+			continue
+		}
+		currentLimit := podSpecMemoryLimit(&d.Spec.Template.Spec)
+		desiredLimit, err := c.desiredOSDMemoryLimit(d)
+		if err != nil {
+			logger.Warningf("failed to retune the memory target of osd.%d. %v", osdID, err)
+			continue
+		}
+		if currentLimit == desiredLimit {
+			continue
+		}
+
+		who := fmt.Sprintf("osd.%d", osdID)
+		if c.deviceClassMemoryTarget(d.Labels[deviceClass]) != "" {
+			logger.Debugf("not retuning the memory target of %s set by the resource profile of its device class", who)
+			continue
+		}
+		if _, ok := lowered[who]; ok {
+			// the target is restored from the new memory limit once the memory pressure is gone
+			logger.Infof("not retuning the memory target of %s lowered under memory pressure", who)
+			continue
+		}
+		current, err := getDaemonMemoryTarget(monStore, who)
+		if err != nil {
+			logger.Warningf("failed to retune the memory target of %s. %v", who, err)
+			continue
+		}
+		if current != "" && current != memoryTarget(currentLimit) {
+			logger.Infof("not retuning the memory target of %s set to %s bytes in the ceph config", who, current)
+			continue
+		}
+
+		target := memoryTarget(desiredLimit)
+		if target == "" {
+			if current == "" {
+				continue
+			}
+			err = monStore.Delete(who, osdMemoryTargetOption)
+		} else {
+			err = monStore.Set(who, osdMemoryTargetOption, target)
+		}
+		if err != nil {
+			logger.Warningf("failed to retune the memory target of %s. %v", who, err)
+			continue
+		}
+		logger.Infof("retuned the memory target of %s to %q for its memory limit of %d bytes", who, target, desiredLimit)
+		c.appliedMemoryTargets[who] = target
+	}
+}
+
+// desiredOSDMemoryLimit returns the memory limit of the OSD of the deployment in the spec
+func (c *Cluster) desiredOSDMemoryLimit(d *appsv1.Deployment) (int64, error) {
+	nodeOrPVCName, err := getNodeOrPVCName(d)
+	if err != nil {
+		return 0, err
+	}
+	var osdProps osdProperties
+	if osdIsOnPVC(d) {
+		osdProps, err = c.getOSDPropsForPVC(nodeOrPVCName)
+	} else {
+		osdProps, err = c.getOSDPropsForNode(nodeOrPVCName, d.Labels[deviceClass])
+	}
+	if err != nil {
+		return 0, err
+	}
+	return osdProps.resources.Limits.Memory().Value(), nil
+}
+
+// memoryTargetsStatus returns the memory targets applied to the existing OSDs, with the targets
+// applied in this reconcile overriding the previous ones
+func (c *Cluster) memoryTargetsStatus(previous map[string]string) (map[string]string, error) {
+	deployments, err := c.getOSDDeployments()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]string{}
+	for k, v := range previous {
+		targets[k] = v
+	}
+	for k, v := range c.appliedMemoryTargets {
+		targets[k] = v
+	}
+	existing := map[string]bool{}
+	for i := range deployments.Items {
+		if osdID, err := GetOSDID(&deployments.Items[i]); err == nil {
+			existing[fmt.Sprintf("osd.%d", osdID)] = true
+		}
+	}
+	for who, target := range targets {
+		if !existing[who] || target == "" {
+			delete(targets, who)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	return targets, nil
+}
+
+// podSpecMemoryLimit returns the memory limit of the osd container in bytes, or zero if it has none
+func podSpecMemoryLimit(spec *v1.PodSpec) int64 {
+	for _, c := range spec.Containers {
+		if c.Name == "osd" {
+			return c.Resources.Limits.Memory().Value()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMemoryTarget(t *testing.T) {
+	assert.Equal(t, "", memoryTarget(0))
+	assert.Equal(t, "3435973836", memoryTarget(4<<30))
+}
+
+func TestRetuneOSDMemoryTargets(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+
+	newDeployment := func(osdID int, limit string, labels map[string]string) {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentName(osdID), Namespace: clusterInfo.Namespace, Labels: map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", osdID)}},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				NodeSelector: map[string]string{k8sutil.LabelHostname(): "node1"},
+				Containers:   []v1.Container{{Name: "osd", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse(limit)}}}},
+			}}},
+		}
+		for k, v := range labels {
+			d.Labels[k] = v
+		}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	newDeployment(0, "4Gi", nil)
+	newDeployment(1, "8Gi", nil)
+	newDeployment(2, "4Gi", nil)
+	newDeployment(3, "4Gi", nil)
+	newDeployment(4, "4Gi", map[string]string{OSDOverPVCLabelKey: "set1-data-0"})
+	_, err := clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Create(ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: osdMemoryTargetsConfigMap, Namespace: clusterInfo.Namespace},
+		Data:       map[string]string{"osd.3": ""},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// osd.2 has a target set by the user, and osd.4 the target derived from its current limit
+	memoryTargets := map[string]string{"osd.2": "1000", "osd.4": "3435973836"}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch args[1] {
+			case "get":
+				if target, ok := memoryTargets[args[2]]; ok {
+					return fmt.Sprintf(`{"osd_memory_target":{"value":%q,"section":%q}}`, target, args[2]), nil
+				}
+				return "{}", nil
+			case "set":
+				memoryTargets[args[2]] = args[4]
+				return "", nil
+			case "rm":
+				delete(memoryTargets, args[2])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+
+	c := &Cluster{
+		context:     &clusterd.Context{Clientset: clientset, Executor: executor},
+		clusterInfo: clusterInfo,
+		spec: cephv1.ClusterSpec{Resources: cephv1.ResourceSpec{
+			cephv1.ResourcesKeyOSD: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("8Gi")}},
+		}},
+		ValidStorage: cephv1.StorageScopeSpec{Nodes: []cephv1.Node{{Name: "node1"}}},
+		// the osd on the pvc has no memory limit anymore
+		deviceSets: []deviceSet{{
+			Name:       "set1",
+			PVCSources: map[string]v1.PersistentVolumeClaimVolumeSource{bluestorePVCData: {ClaimName: "set1-data-0"}},
+			Resources:  v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")}},
+		}},
+	}
+	deployments, err := c.getOSDDeployments()
+	require.NoError(t, err)
+	c.retuneOSDMemoryTargets(deployments)
+
+	// osd.0 is retuned to the new limit, osd.1 is not changed, the target of osd.2 set by the user and
+	// of osd.3 lowered under memory pressure are kept, and the target of osd.4 is removed
+	assert.Equal(t, map[string]string{"osd.0": "6871947673", "osd.2": "1000"}, memoryTargets)
+	assert.Equal(t, map[string]string{"osd.0": "6871947673", "osd.4": ""}, c.appliedMemoryTargets)
+
+	// the previous targets of the removed osds and the removed targets are not reported
+	status, err := c.memoryTargetsStatus(map[string]string{"osd.1": "6871947673", "osd.4": "3435973836", "osd.9": "3435973836"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"osd.0": "6871947673", "osd.1": "6871947673"}, status)
+}
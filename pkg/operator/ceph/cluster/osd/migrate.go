@@ -25,6 +25,7 @@ import (
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	osdconfig "github.com/rook/rook/pkg/operator/ceph/cluster/osd/config"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -82,13 +83,20 @@ func (m *migrationConfig) migrateForEncryption(c *Cluster, osdDeployments *appsv
 	}
 
 	for i := range osdDeployments.Items {
-		osdDeviceSetName := osdDeployments.Items[i].Labels[CephDeviceSetLabelKey]
-		requestedEncryptionSetting := deviceSetMap[osdDeviceSetName].Encrypted
 		actualEncryptedSetting := false
 		if osdDeployments.Items[i].Labels["encrypted"] == "true" {
 			actualEncryptedSetting = true
 		}
 
+		osdDeviceSetName, onDeviceSet := osdDeployments.Items[i].Labels[CephDeviceSetLabelKey]
+		if !onDeviceSet {
+			if err := m.migrateNodeOSDForEncryption(c, &osdDeployments.Items[i], actualEncryptedSetting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		requestedEncryptionSetting := deviceSetMap[osdDeviceSetName].Encrypted
 		if requestedEncryptionSetting != actualEncryptedSetting {
 			osdInfo, err := c.getOSDInfo(&osdDeployments.Items[i])
 			if err != nil {
@@ -103,6 +111,46 @@ func (m *migrationConfig) migrateForEncryption(c *Cluster, osdDeployments *appsv
 	return nil
 }
 
+// migrateNodeOSDForEncryption adds the OSD on a node to the OSDs that require migration if the
+// encryptedDevice setting resolved for its node changed. Only the OSDs in raw mode are migrated
+// since the encryption of the OSDs in lvm mode is not reported.
+func (m *migrationConfig) migrateNodeOSDForEncryption(c *Cluster, d *appsv1.Deployment, actualEncryptedSetting bool) error {
+	nodeName, err := getNodeOrPVCName(d)
+	if err != nil {
+		logger.Debugf("skipping encryption migration check for OSD deployment %q. %v", d.Name, err)
+		return nil
+	}
+	// the valid storage is not computed yet when the migration starts, so the node is resolved from
+	// a copy of the storage spec the same way the nodes are resolved for provisioning
+	storage := c.spec.Storage.DeepCopy()
+	if storage.UseAllNodes {
+		storage.Nodes = []cephv1.Node{{Name: nodeName}}
+	}
+	node := storage.ResolveNode(nodeName)
+	if node == nil {
+		logger.Debugf("skipping encryption migration check for OSD deployment %q since node %q is not in the storage spec", d.Name, nodeName)
+		return nil
+	}
+	requestedEncryptionSetting := osdconfig.ToStoreConfig(node.Config).EncryptedDevice
+	if requestedEncryptionSetting == actualEncryptedSetting {
+		return nil
+	}
+
+	osdInfo, err := c.getOSDInfo(d)
+	if err != nil {
+		return errors.Wrapf(err, "failed to details about the OSD %q", d.Name)
+	}
+	if osdInfo.CVMode != "raw" {
+		logger.Debugf("skipping encryption migration of OSD.%d in %q mode on node %q", osdInfo.ID, osdInfo.CVMode, nodeName)
+		return nil
+	}
+	logger.Infof("migration is required for OSD.%d due to change in encryption settings from %t to %t on node %q", osdInfo.ID, actualEncryptedSetting, requestedEncryptionSetting, nodeName)
+	if _, exists := m.osds[osdInfo.ID]; !exists {
+		m.osds[osdInfo.ID] = &osdInfo
+	}
+	return nil
+}
+
 // migrateForOSDStore gets all the OSDs that require migration due to change in the cephCluster OSD storeType setting
 func (m *migrationConfig) migrateForOSDStore(c *Cluster, osdDeployments *appsv1.DeploymentList) error {
 	desiredOSDStore := c.spec.Storage.GetOSDStore()
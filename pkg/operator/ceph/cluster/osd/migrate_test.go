@@ -106,6 +106,53 @@ func TestMigrateForEncryption(t *testing.T) {
 		assert.Equal(t, 1, len(mc.osds))
 		assert.Equal(t, 1, mc.osds[1].ID)
 	})
+	t.Run("osd.1 on node1 needs migration", func(t *testing.T) {
+		c.clusterInfo.Namespace = "rook-ceph3"
+		c.spec.Storage.StorageClassDeviceSets = nil
+		c.spec.Storage.Nodes = []cephv1.Node{
+			{Name: "node1", Config: map[string]string{"encryptedDevice": "true"}},
+			{Name: "node2"},
+		}
+
+		d1 := getDummyDeploymentOnNode(clientset, c, "node1", 1)
+		d1.Labels["encrypted"] = "false"
+		createDeploymentOrPanic(clientset, d1)
+
+		d2 := getDummyDeploymentOnNode(clientset, c, "node1", 2)
+		d2.Labels["encrypted"] = "true"
+		createDeploymentOrPanic(clientset, d2)
+
+		// the node does not request encryption
+		d3 := getDummyDeploymentOnNode(clientset, c, "node2", 3)
+		d3.Labels["encrypted"] = "false"
+		createDeploymentOrPanic(clientset, d3)
+
+		// the node is not in the storage spec anymore
+		d4 := getDummyDeploymentOnNode(clientset, c, "node3", 4)
+		d4.Labels["encrypted"] = "false"
+		createDeploymentOrPanic(clientset, d4)
+
+		deployments, err := c.getOSDDeployments()
+		assert.NoError(t, err)
+
+		mc := migrationConfig{
+			osds: map[int]*OSDInfo{},
+		}
+
+		err = mc.migrateForEncryption(c, deployments)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(mc.osds))
+		assert.Equal(t, 1, mc.osds[1].ID)
+
+		// the encryption setting of the cluster applies to all the nodes
+		c.spec.Storage.Nodes = nil
+		c.spec.Storage.UseAllNodes = true
+		c.spec.Storage.Config = map[string]string{"encryptedDevice": "true"}
+		mc.osds = map[int]*OSDInfo{}
+		err = mc.migrateForEncryption(c, deployments)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []int{1, 3, 4}, mc.getOSDIds())
+	})
 }
 
 func TestMigrationForOSDStore(t *testing.T) {
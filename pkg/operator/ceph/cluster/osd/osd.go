@@ -89,6 +89,20 @@ type Cluster struct {
 	migrateOSD     *OSDInfo
 	deprecatedOSDs map[string][]int
 	nodeConfigmaps map[string]struct{}
+	// replacedOSDs are the UUIDs of the OSDs replaced because their device is predicted to fail
+	replacedOSDs sets.Set[string]
+	// reportedReplacedOSDs are the UUIDs of the replaced OSDs still found on their device by the
+	// prepare jobs, and reportedOSDHosts are the hosts of the OSDs found by the prepare jobs
+	reportedReplacedOSDs sets.Set[string]
+	reportedOSDHosts     sets.Set[string]
+	// migratedOSDs are the UUIDs of the OSDs on the nodes migrated to PVCs
+	migratedOSDs sets.Set[string]
+	// appliedMemoryTargets are the memory targets retuned for the changed memory limits of the OSDs
+	appliedMemoryTargets map[string]string
+	// prepareJobs queues the OSD prepare jobs beyond the prepare concurrency of the storage spec
+	prepareJobs *prepareJobQueue
+	// prepareFailures are the failures of the OSD prepare jobs reported in the CephCluster status
+	prepareFailures *prepareFailures
 }
 
 // New creates an instance of the OSD manager
@@ -133,6 +147,8 @@ type OrchestrationStatus struct {
 	Status       string    `json:"status"`
 	PvcBackedOSD bool      `json:"pvc-backed-osd"`
 	Message      string    `json:"message"`
+	// Devices are the devices the provisioning failed on, if known
+	Devices []string `json:"devices,omitempty"`
 }
 
 type osdProperties struct {
@@ -155,6 +171,12 @@ type osdProperties struct {
 	schedulerName       string
 	encrypted           bool
 	deviceSetName       string
+	// whether the metadata and wal devices are ephemeral and the OSD must be rebuilt when they are lost
+	ephemeralMetadataDevice bool
+	// the bluestore compression applied in the ceph config of the OSD
+	compression *cephv1.OSDCompressionSpec
+	// the devices the prepare job skips since their previous prepare jobs failed
+	quarantinedDevices []string
 }
 
 func (osdProps osdProperties) onPVC() bool {
@@ -188,6 +210,14 @@ func (c *Cluster) validateOSDSettings() error {
 			}
 		}
 	}
+	for deviceClass, profile := range c.spec.Storage.DeviceClassResources {
+		if err := controller.CheckPodMemory(deviceClass, profile.Resources, cephOsdPodMinimumMemory); err != nil {
+			return errors.Wrapf(err, "failed to check pod memory of device class %q", deviceClass)
+		}
+		if profile.MemoryTarget != nil && profile.MemoryTarget.Value() <= 0 {
+			return errors.Errorf("invalid memory target %q of device class %q", profile.MemoryTarget.String(), deviceClass)
+		}
+	}
 	deviceSetNames := map[string]bool{}
 	for _, deviceSet := range c.spec.Storage.StorageClassDeviceSets {
 		if deviceSetNames[deviceSet.Name] {
@@ -195,6 +225,14 @@ func (c *Cluster) validateOSDSettings() error {
 		}
 		deviceSetNames[deviceSet.Name] = true
 	}
+	if err := c.spec.Storage.Selection.DeviceIdentityFilter.Validate(); err != nil {
+		return errors.Wrap(err, "invalid storage device identity filter")
+	}
+	for _, node := range c.spec.Storage.Nodes {
+		if err := node.Selection.DeviceIdentityFilter.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid device identity filter of node %q", node.Name)
+		}
+	}
 	return nil
 }
 
@@ -216,6 +254,11 @@ func (c *Cluster) Start() error {
 		logger.Warningf("useAllNodes is set to false and no nodes, storageClassDevicesets or volumeSources are specified, no OSD pods are going to be created")
 	}
 
+	// a change of the storage selection orphaning osds is not applied until it is confirmed
+	if err := c.checkStorageChanges(); err != nil {
+		return err
+	}
+
 	if c.spec.WaitTimeoutForHealthyOSDInMinutes != 0 {
 		c.clusterInfo.OsdUpgradeTimeout = c.spec.WaitTimeoutForHealthyOSDInMinutes * time.Minute
 	} else {
@@ -223,6 +266,20 @@ func (c *Cluster) Start() error {
 	}
 	logger.Infof("wait timeout for healthy OSDs during upgrade or restart is %q", c.clusterInfo.OsdUpgradeTimeout)
 
+	replacedOSDs, err := c.replacedOSDUUIDs()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the replaced osds")
+	}
+	c.replacedOSDs = replacedOSDs
+	c.reportedReplacedOSDs = sets.New[string]()
+	c.reportedOSDHosts = sets.New[string]()
+
+	migratedOSDs, err := c.migratedOSDUUIDs()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the osds migrated to pvcs")
+	}
+	c.migratedOSDs = migratedOSDs
+
 	osdsToSkipReconcile, err := controller.GetDaemonsToSkipReconcile(c.clusterInfo.Context, c.context, c.clusterInfo.Namespace, OsdIdLabelKey, AppName)
 	if err != nil {
 		logger.Warningf("failed to get osds to skip reconcile. %v", err)
@@ -251,6 +308,8 @@ func (c *Cluster) Start() error {
 
 	// prepare for creating new OSDs
 	statusConfigMaps := sets.New[string]()
+	c.prepareJobs = newPrepareJobQueue(c.spec.Storage.PrepareConcurrency)
+	c.loadPrepareFailures()
 
 	logger.Info("start provisioning the OSDs on PVCs, if needed")
 	pvcConfigMaps, err := c.startProvisioningOverPVCs(config, errs)
@@ -266,6 +325,18 @@ func (c *Cluster) Start() error {
 	}
 	statusConfigMaps = statusConfigMaps.Union(nodeConfigMaps)
 
+	if err := c.applyDeviceClassMemoryTargets(); err != nil {
+		return errors.Wrap(err, "failed to apply the memory targets of the device class resource profiles")
+	}
+
+	// the running OSDs use the memory target of their new memory limit while they wait to be updated
+	existingDeployments, err := c.getOSDDeployments()
+	if err != nil {
+		logger.Warningf("failed to retune the osd memory targets. %v", err)
+	} else {
+		c.retuneOSDMemoryTargets(existingDeployments)
+	}
+
 	createConfig := c.newCreateConfig(config, statusConfigMaps, deployments)
 
 	// do the update and create operations
@@ -274,11 +345,22 @@ func (c *Cluster) Start() error {
 		return errors.Wrapf(err, "failed to update/create OSDs")
 	}
 
+	// the compression is applied to the OSDs created in this reconcile as well
+	if provisionedDeployments, err := c.getOSDDeployments(); err != nil {
+		logger.Warningf("failed to apply the osd compression settings. %v", err)
+	} else {
+		c.applyOSDCompression(provisionedDeployments)
+	}
+
 	if errs.len() > 0 {
 		return errors.Errorf("%d failures encountered while running osds on nodes in namespace %q. %s",
 			errs.len(), namespace, errs.asMessages())
 	}
 
+	if err := c.completeOSDReplacements(); err != nil {
+		return errors.Wrap(err, "failed to complete the osd replacements")
+	}
+
 	// clean up status configmaps that might be dangling from previous reconciles
 	// for example, if the storage spec changed from or a node failed in a previous failed reconcile
 	c.deleteAllStatusConfigMaps()
@@ -341,6 +423,13 @@ func (c *Cluster) startOSDMigration() (*migrationConfig, error) {
 	// delete deployment of the osd that needs migration
 	if migrationConfig != nil && len(migrationConfig.osds) > 0 {
 		osdToMigrate := migrationConfig.getOSDToMigrate()
+		// the osd is only destroyed if its pgs remain available without it
+		if _, err := cephclient.OSDOkToStop(c.context, c.clusterInfo, osdToMigrate.ID, 1); err != nil {
+			logger.Infof("deferring the migration of OSD.%d until it is ok to stop. %v", osdToMigrate.ID, err)
+			// keep the osd pending so it is still not updated
+			migrationConfig.osds[osdToMigrate.ID] = osdToMigrate
+			return migrationConfig, nil
+		}
 		logger.Infof("deleting OSD.%d deployment for migration ", osdToMigrate.ID)
 		err = c.deleteOSDDeployment(osdToMigrate.ID)
 		if err != nil {
@@ -369,9 +458,17 @@ func (c *Cluster) postReconcileUpdateOSDProperties(desiredOSDs map[int]*OSDInfo)
 	if err != nil {
 		return errors.Wrap(err, "failed to get osd usage")
 	}
+	// the crush weight of the new OSDs is raised in steps by the OSD health checks
+	rampingUp := map[int]bool{}
+	if c.spec.Storage.AllowOsdCrushWeightUpdate {
+		rampingUp, err = osdsRampingUp(c.context, c.clusterInfo)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the osds ramping up their crush weight")
+		}
+	}
 	logger.Debugf("post processing osd properties with %d actual osds from ceph osd df and %d existing osds found during reconcile", len(osdUsage.OSDNodes), len(desiredOSDs))
 	for _, actualOSD := range osdUsage.OSDNodes {
-		if c.spec.Storage.AllowOsdCrushWeightUpdate {
+		if c.spec.Storage.AllowOsdCrushWeightUpdate && !rampingUp[actualOSD.ID] {
 			_, err := cephclient.ResizeOsdCrushWeight(actualOSD, c.context, c.clusterInfo)
 			if err != nil {
 				// Log the error and allow other updates to continue
@@ -485,7 +582,7 @@ func (c *Cluster) resolveNode(nodeName, deviceClass string) *cephv1.Node {
 	if rookNode == nil {
 		return nil
 	}
-	rookNode.Resources = k8sutil.MergeResourceRequirements(rookNode.Resources, cephv1.GetOSDResources(c.spec.Resources, deviceClass))
+	rookNode.Resources = k8sutil.MergeResourceRequirements(rookNode.Resources, c.osdResources(deviceClass))
 
 	return rookNode
 }
@@ -506,6 +603,7 @@ func (c *Cluster) getOSDPropsForNode(nodeName, deviceClass string) (osdPropertie
 		resources:      n.Resources,
 		storeConfig:    storeConfig,
 		metadataDevice: metadataDevice,
+		compression:    n.Selection.Compression,
 	}
 
 	return osdProps, nil
@@ -534,7 +632,7 @@ func (c *Cluster) getOSDPropsForPVC(pvcName string) (osdProperties, error) {
 			}
 
 			if deviceSet.Resources.Limits == nil && deviceSet.Resources.Requests == nil {
-				deviceSet.Resources = cephv1.GetOSDResources(c.spec.Resources, deviceSet.CrushDeviceClass)
+				deviceSet.Resources = c.osdResources(deviceSet.CrushDeviceClass)
 			}
 
 			osdProps := osdProperties{
@@ -552,6 +650,7 @@ func (c *Cluster) getOSDPropsForPVC(pvcName string) (osdProperties, error) {
 				schedulerName:       deviceSet.SchedulerName,
 				encrypted:           deviceSet.Encrypted,
 				deviceSetName:       deviceSet.Name,
+				compression:         deviceSet.Compression,
 			}
 			osdProps.storeConfig.InitialWeight = deviceSet.CrushInitialWeight
 			osdProps.storeConfig.PrimaryAffinity = deviceSet.CrushPrimaryAffinity
@@ -949,6 +1048,25 @@ func (c *Cluster) deleteOSDDeployment(osdID int) error {
 	return nil
 }
 
+// deleteOSDDeploymentsOnPVC deletes the deployment of the OSD on the given PVC
+func (c *Cluster) deleteOSDDeploymentsOnPVC(claimName string) error {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, OSDOverPVCLabelKey, claimName)}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.clusterInfo.Namespace).List(c.clusterInfo.Context, listOpts)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the OSD deployments on PVC %q", claimName)
+	}
+	for _, d := range deployments.Items {
+		osdID, err := GetOSDID(&d)
+		if err != nil {
+			return err
+		}
+		if err := c.deleteOSDDeployment(osdID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Cluster) waitForHealthyPGs() (bool, error) {
 	waitFunc := func() (done bool, err error) {
 		pgHealthMsg, pgClean, err := cephclient.IsClusterClean(c.context, c.clusterInfo, c.spec.DisruptionManagement.PGHealthyRegex)
@@ -1010,6 +1128,18 @@ func (c *Cluster) updateCephStorageStatus() error {
 		}
 		return errors.Wrapf(err, "failed to retrieve ceph cluster %q to update ceph Storage", c.clusterInfo.NamespacedName().Name)
 	}
+	var previousTargets map[string]string
+	if cephCluster.Status.CephStorage != nil {
+		previousTargets = cephCluster.Status.CephStorage.OSD.MemoryTargets
+	}
+	cephClusterStorage.OSD.MemoryTargets, err = c.memoryTargetsStatus(previousTargets)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the osd memory targets status")
+	}
+	cephClusterStorage.OSD.KeyRotation, err = c.keyRotationStatus()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the osd key rotation status")
+	}
 	if !reflect.DeepEqual(cephCluster.Status.CephStorage, cephClusterStorage) {
 		cephCluster.Status.CephStorage = &cephClusterStorage
 		if err := reporting.UpdateStatus(c.context.Client, &cephCluster); err != nil {
@@ -434,6 +434,7 @@ func TestPostReconcileUpdateOSDProperties(t *testing.T) {
 		Context:     context.TODO(),
 	}
 	clusterInfo.SetName("rook-ceph-test")
+	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
 	context := &clusterd.Context{Clientset: clientset, Client: client, ConfigDir: "/var/lib/rook", Executor: executor}
 	c := New(context, clusterInfo, cephCluster.Spec, "myversion")
 
@@ -459,6 +460,16 @@ func TestPostReconcileUpdateOSDProperties(t *testing.T) {
 		assert.Equal(t, []string([]string{"osd.3", "osd.4"}), osdID)
 		assert.Equal(t, []string([]string{"9.166024", "9.305722"}), crushWeight)
 	})
+	t.Run("test resize skips the osds ramping up their crush weight", func(t *testing.T) {
+		osdID = nil
+		cm, rampUps, err := loadOSDWeightRampUps(context, clusterInfo)
+		assert.NoError(t, err)
+		rampUps = append(rampUps, OSDWeightRampUp{OSD: 3, UUID: "uuid3"})
+		assert.NoError(t, saveOSDWeightRampUps(context, clusterInfo, cm, rampUps))
+		err = c.postReconcileUpdateOSDProperties(desiredOSDs)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"osd.4"}, osdID)
+	})
 }
 
 func TestAddNodeFailure(t *testing.T) {
@@ -485,7 +496,8 @@ func TestAddNodeFailure(t *testing.T) {
 	}
 	clusterInfo.SetName("testcluster")
 	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
-	context := &clusterd.Context{Clientset: clientset, ConfigDir: "/var/lib/rook", Executor: &exectest.MockExecutor{}}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	context := &clusterd.Context{Clientset: clientset, Client: client, ConfigDir: "/var/lib/rook", Executor: &exectest.MockExecutor{}}
 	spec := cephv1.ClusterSpec{
 		DataDirHostPath: context.ConfigDir,
 		Storage: cephv1.StorageScopeSpec{
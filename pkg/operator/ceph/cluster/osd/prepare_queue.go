@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// prepareJobQueue holds the OSD prepare jobs that cannot start yet because of the prepare
+// concurrency of the storage spec, and the jobs that are running
+type prepareJobQueue struct {
+	maxJobs        int
+	maxJobsPerNode int
+	pending        []queuedPrepareJob
+	// running maps the node or PVC name of the running jobs to their node, empty if unknown
+	running map[string]string
+}
+
+type queuedPrepareJob struct {
+	osdProps osdProperties
+	node     string
+}
+
+// newPrepareJobQueue returns the queue of the OSD prepare jobs, or nil if the number of jobs is not
+// limited
+func newPrepareJobQueue(spec *cephv1.OSDPrepareConcurrencySpec) *prepareJobQueue {
+	if spec == nil || (spec.MaxJobs <= 0 && spec.MaxJobsPerNode <= 0) {
+		return nil
+	}
+	return &prepareJobQueue{
+		maxJobs:        spec.MaxJobs,
+		maxJobsPerNode: spec.MaxJobsPerNode,
+		running:        map[string]string{},
+	}
+}
+
+// canStart returns whether another job can start on the node
+func (q *prepareJobQueue) canStart(node string) bool {
+	if q.maxJobs > 0 && len(q.running) >= q.maxJobs {
+		return false
+	}
+	if q.maxJobsPerNode > 0 && node != "" {
+		jobs := 0
+		for _, runningNode := range q.running {
+			if runningNode == node {
+				jobs++
+			}
+		}
+		if jobs >= q.maxJobsPerNode {
+			return false
+		}
+	}
+	return true
+}
+
+// finished releases the slot of the job of the node or PVC
+func (q *prepareJobQueue) finished(nodeOrPVCName string) {
+	if q == nil {
+		return
+	}
+	delete(q.running, nodeOrPVCName)
+}
+
+// runOrQueuePrepareJob runs the OSD prepare job if the prepare concurrency allows it, otherwise the
+// job is queued until running jobs finished
+func (c *Cluster) runOrQueuePrepareJob(osdProps *osdProperties, config *provisionConfig) error {
+	q := c.prepareJobs
+	if q == nil {
+		return c.runPrepareJob(osdProps, config)
+	}
+
+	node := ""
+	if q.maxJobsPerNode > 0 {
+		node = c.prepareJobNode(osdProps)
+	}
+	if !q.canStart(node) {
+		logger.Infof("queued OSD prepare job for %q, %d OSD prepare jobs are running", osdProps.crushHostname, len(q.running))
+		q.pending = append(q.pending, queuedPrepareJob{osdProps: *osdProps, node: node})
+		return nil
+	}
+	if err := c.runPrepareJob(osdProps, config); err != nil {
+		return err
+	}
+	q.running[osdProps.crushHostname] = node
+	return nil
+}
+
+// startQueuedPrepareJobs starts the queued OSD prepare jobs that the prepare concurrency allows
+func (c *Cluster) startQueuedPrepareJobs(createConfig *createConfig, errs *provisionErrors) {
+	q := c.prepareJobs
+	if q == nil || len(q.pending) == 0 {
+		return
+	}
+
+	pending := []queuedPrepareJob{}
+	for i := range q.pending {
+		job := &q.pending[i]
+		if !q.canStart(job.node) {
+			pending = append(pending, *job)
+			continue
+		}
+		nodeOrPVCName := job.osdProps.crushHostname
+		if err := c.runPrepareJob(&job.osdProps, createConfig.provisionConfig); err != nil {
+			c.handleOrchestrationFailure(errs, nodeOrPVCName, "%v", err)
+			c.deleteStatusConfigMap(nodeOrPVCName)
+			createConfig.doneWithStatus(nodeOrPVCName)
+			continue
+		}
+		q.running[nodeOrPVCName] = job.node
+	}
+	q.pending = pending
+	if len(pending) > 0 {
+		logger.Infof("%d OSD prepare jobs are queued", len(pending))
+	}
+}
+
+// prepareJobNode returns the node the OSD prepare job will run on. The node of a job on a PVC is
+// only known if the PVC is bound to a local volume, otherwise an empty string is returned.
+func (c *Cluster) prepareJobNode(osdProps *osdProperties) string {
+	if !osdProps.onPVC() {
+		return osdProps.crushHostname
+	}
+
+	ctx := c.clusterInfo.Context
+	pvc, err := c.context.Clientset.CoreV1().PersistentVolumeClaims(c.clusterInfo.Namespace).Get(ctx, osdProps.pvc.ClaimName, metav1.GetOptions{})
+	if err != nil || pvc.Spec.VolumeName == "" {
+		return ""
+	}
+	pv, err := c.context.Clientset.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil || pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == k8sutil.LabelHostname() && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+				return expr.Values[0]
+			}
+		}
+	}
+	return ""
+}
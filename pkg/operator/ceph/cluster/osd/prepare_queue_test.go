@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrepareJobQueue(t *testing.T) {
+	namespace := "rook-ceph"
+	clientset := test.New(t, 3)
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, CephVersion: cephver.Squid}
+	clusterInfo.SetName("mycluster")
+	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
+	clusterInfo.Context = context.TODO()
+	useAllDevices := true
+	spec := cephv1.ClusterSpec{
+		DataDirHostPath: "/var/lib/rook",
+		Storage: cephv1.StorageScopeSpec{
+			Nodes:              []cephv1.Node{{Name: "node0"}, {Name: "node1"}, {Name: "node2"}},
+			Selection:          cephv1.Selection{UseAllDevices: &useAllDevices},
+			PrepareConcurrency: &cephv1.OSDPrepareConcurrencySpec{MaxJobs: 2},
+		},
+	}
+	c := New(&clusterd.Context{Clientset: clientset}, clusterInfo, spec, "rook/rook:master")
+	c.prepareJobs = newPrepareJobQueue(c.spec.Storage.PrepareConcurrency)
+	config := c.newProvisionConfig()
+	errs := newProvisionErrors()
+	runningJobs := func() int {
+		jobs, err := clientset.BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		require.NoError(t, err)
+		return len(jobs.Items)
+	}
+
+	// the job of the third node is queued
+	statusConfigMaps, err := c.startProvisioningOverNodes(config, errs)
+	require.NoError(t, err)
+	assert.Zero(t, errs.len())
+	assert.Equal(t, 3, statusConfigMaps.Len())
+	assert.Equal(t, 2, runningJobs())
+	require.Len(t, c.prepareJobs.pending, 1)
+	assert.Equal(t, "node2", c.prepareJobs.pending[0].osdProps.crushHostname)
+
+	// the queued job starts once a running job completed
+	createConfig := c.newCreateConfig(config, statusConfigMaps, newExistenceListWithCapacity(0))
+	status, err := json.Marshal(OrchestrationStatus{Status: OrchestrationStatusCompleted})
+	require.NoError(t, err)
+	c.createOSDsForStatusMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: statusConfigMapName("node0"), Labels: map[string]string{nodeLabelKey: "node0"}},
+		Data:       map[string]string{orchestrationStatusKey: string(status)},
+	}, createConfig, errs)
+	assert.Zero(t, errs.len())
+	assert.Equal(t, 3, runningJobs())
+	assert.Empty(t, c.prepareJobs.pending)
+	assert.Len(t, c.prepareJobs.running, 2)
+	assert.False(t, createConfig.doneCreating())
+
+	// the jobs are not limited without a prepare concurrency
+	assert.Nil(t, newPrepareJobQueue(nil))
+	assert.Nil(t, newPrepareJobQueue(&cephv1.OSDPrepareConcurrencySpec{}))
+}
+
+func TestPrepareJobQueueCanStart(t *testing.T) {
+	q := newPrepareJobQueue(&cephv1.OSDPrepareConcurrencySpec{MaxJobsPerNode: 1})
+	q.running["pvc0"] = "node0"
+	q.running["pvc1"] = ""
+	assert.False(t, q.canStart("node0"))
+	assert.True(t, q.canStart("node1"))
+	// the node of a job on a pvc that is not bound to a local volume is unknown
+	assert.True(t, q.canStart(""))
+
+	q.maxJobs = 2
+	assert.False(t, q.canStart("node1"))
+	q.finished("pvc1")
+	assert.True(t, q.canStart("node1"))
+}
+
+func TestPrepareJobNode(t *testing.T) {
+	namespace := "rook-ceph"
+	clientset := test.New(t, 1)
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, Context: context.TODO()}
+	c := New(&clusterd.Context{Clientset: clientset}, clusterInfo, cephv1.ClusterSpec{}, "rook/rook:master")
+
+	assert.Equal(t, "node0", c.prepareJobNode(&osdProperties{crushHostname: "node0"}))
+
+	osdProps := &osdProperties{crushHostname: "set1-data-0", pvc: corev1.PersistentVolumeClaimVolumeSource{ClaimName: "set1-data-0"}}
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "set1-data-0", Namespace: namespace}}
+	_, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+	require.NoError(t, err)
+	// the node is unknown while the pvc is not bound
+	assert.Equal(t, "", c.prepareJobNode(osdProps))
+
+	pvc.Spec.VolumeName = "local-pv-1"
+	_, err = clientset.CoreV1().PersistentVolumeClaims(namespace).Update(context.TODO(), pvc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "local-pv-1"},
+		Spec: corev1.PersistentVolumeSpec{NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"node0"}},
+			}}},
+		}}},
+	}
+	_, err = clientset.CoreV1().PersistentVolumes().Create(context.TODO(), pv, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "node0", c.prepareJobNode(osdProps))
+}
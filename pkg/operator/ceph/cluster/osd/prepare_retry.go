@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReleaseOSDQuarantineAnnotation on the CephCluster is the comma separated list of the nodes and
+	// PVCs whose quarantined devices are prepared again. The operator removes the annotation once
+	// the quarantine is released.
+	ReleaseOSDQuarantineAnnotation = "ceph.rook.io/release-osd-quarantine"
+
+	defaultPrepareInitialBackoff = time.Minute
+	defaultPrepareMaxBackoff     = 30 * time.Minute
+	maxPrepareErrorLength        = 1024
+)
+
+// prepareFailures tracks the failures of the OSD prepare jobs, which are published in the
+// CephCluster status. A failure without a device is the failure of the whole job of a node or PVC.
+type prepareFailures struct {
+	spec    *cephv1.OSDPrepareRetrySpec
+	records []cephv1.OSDPrepareFailureStatus
+	// skipped maps the node name of the started jobs to the devices the jobs do not prepare
+	skipped map[string][]string
+}
+
+// loadPrepareFailures loads the failures of the OSD prepare jobs from the CephCluster status and
+// releases the quarantine of the nodes and PVCs listed by the release annotation
+func (c *Cluster) loadPrepareFailures() {
+	c.prepareFailures = &prepareFailures{spec: c.spec.Storage.PrepareRetry, skipped: map[string][]string{}}
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to load the failures of the OSD prepare jobs. %v", err)
+		return
+	}
+	c.prepareFailures.records = cephCluster.Status.OSDPrepareFailures
+
+	release, ok := cephCluster.Annotations[ReleaseOSDQuarantineAnnotation]
+	if !ok {
+		return
+	}
+	for _, name := range strings.Split(release, ",") {
+		c.prepareFailures.release(strings.TrimSpace(name))
+	}
+	cephCluster.Status.OSDPrepareFailures = c.prepareFailures.records
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to release the quarantine of the OSD prepare jobs in the CephCluster status. %v", err)
+		return
+	}
+	delete(cephCluster.Annotations, ReleaseOSDQuarantineAnnotation)
+	if err := c.context.Client.Update(c.clusterInfo.Context, cephCluster); err != nil {
+		logger.Warningf("failed to remove annotation %q from the CephCluster. %v", ReleaseOSDQuarantineAnnotation, err)
+	}
+}
+
+// release removes the failures of the node or PVC if any of them is quarantined
+func (f *prepareFailures) release(nodeOrPVCName string) {
+	if !slices.ContainsFunc(f.records, func(r cephv1.OSDPrepareFailureStatus) bool {
+		return r.Node == nodeOrPVCName && r.Quarantined
+	}) {
+		return
+	}
+	logger.Infof("releasing the quarantine of the OSD prepare jobs of %q", nodeOrPVCName)
+	f.records = slices.DeleteFunc(f.records, func(r cephv1.OSDPrepareFailureStatus) bool {
+		return r.Node == nodeOrPVCName
+	})
+}
+
+// checkPrepareRetry returns whether the OSD prepare job of the node or PVC starts, and sets the
+// devices the job skips since they are quarantined or waiting for their retry. A job waiting for its
+// retry adds an error so that the reconcile is requeued.
+func (c *Cluster) checkPrepareRetry(osdProps *osdProperties, errs *provisionErrors, now time.Time) bool {
+	f := c.prepareFailures
+	if f == nil {
+		return true
+	}
+	nodeOrPVCName := osdProps.crushHostname
+	skipped := []string{}
+	for _, r := range f.records {
+		if r.Node != nodeOrPVCName {
+			continue
+		}
+		if r.Quarantined {
+			if r.Device == "" {
+				logger.Warningf("skipping the OSD prepare job of %q, it failed %d times and is quarantined. last error: %s", nodeOrPVCName, r.Failures, r.LastError)
+				return false
+			}
+			skipped = append(skipped, r.Device)
+			continue
+		}
+		if r.NextRetryTime == nil || !now.Before(r.NextRetryTime.Time) {
+			continue
+		}
+		if r.Device == "" {
+			errs.addError("the OSD prepare job of %q failed %d times, retrying after %s", nodeOrPVCName, r.Failures, r.NextRetryTime.UTC().Format(time.RFC3339))
+			return false
+		}
+		errs.addError("the OSD prepare job of %q failed %d times on device %q, retrying the device after %s", nodeOrPVCName, r.Failures, r.Device, r.NextRetryTime.UTC().Format(time.RFC3339))
+		skipped = append(skipped, r.Device)
+	}
+
+	if len(skipped) > 0 && !osdProps.onPVC() {
+		logger.Infof("the OSD prepare job of node %q skips the devices %v whose previous prepare jobs failed", nodeOrPVCName, skipped)
+		osdProps.quarantinedDevices = skipped
+	}
+	f.skipped[nodeOrPVCName] = skipped
+	return true
+}
+
+// recordPrepareStatus records the failure of the OSD prepare job of the node or PVC, or clears the
+// failures of the devices the job prepared once it completed
+func (c *Cluster) recordPrepareStatus(nodeOrPVCName string, status *OrchestrationStatus, now time.Time) {
+	f := c.prepareFailures
+	if f == nil {
+		return
+	}
+
+	changed := false
+	switch status.Status {
+	case OrchestrationStatusCompleted:
+		skipped := f.skipped[nodeOrPVCName]
+		records := slices.DeleteFunc(slices.Clone(f.records), func(r cephv1.OSDPrepareFailureStatus) bool {
+			return r.Node == nodeOrPVCName && !slices.Contains(skipped, r.Device)
+		})
+		changed = len(records) != len(f.records)
+		f.records = records
+	case OrchestrationStatusFailed:
+		devices := status.Devices
+		if len(devices) == 0 || status.PvcBackedOSD {
+			devices = []string{""}
+		}
+		for _, device := range devices {
+			f.recordFailure(nodeOrPVCName, device, status.Message, now)
+		}
+		changed = true
+	}
+	delete(f.skipped, nodeOrPVCName)
+
+	if changed {
+		c.savePrepareFailures()
+	}
+}
+
+// recordFailure counts the failure of the device of the node or PVC and sets the time of its next
+// retry, doubling the backoff after each failure. The device is quarantined once its failures
+// exceed the max retries.
+func (f *prepareFailures) recordFailure(nodeOrPVCName, device, message string, now time.Time) {
+	i := slices.IndexFunc(f.records, func(r cephv1.OSDPrepareFailureStatus) bool {
+		return r.Node == nodeOrPVCName && r.Device == device
+	})
+	if i < 0 {
+		f.records = append(f.records, cephv1.OSDPrepareFailureStatus{Node: nodeOrPVCName, Device: device})
+		i = len(f.records) - 1
+	}
+	r := &f.records[i]
+	r.Failures++
+	r.LastError = truncatePrepareError(message)
+	r.LastFailureTime = metav1.NewTime(now)
+	if f.spec == nil {
+		return
+	}
+
+	if f.spec.MaxRetries > 0 && r.Failures > f.spec.MaxRetries {
+		quarantined := fmt.Sprintf("device %q of %q", device, nodeOrPVCName)
+		if device == "" {
+			quarantined = fmt.Sprintf("%q", nodeOrPVCName)
+		}
+		logger.Warningf("quarantining %s after %d failures of its OSD prepare jobs. last error: %s", quarantined, r.Failures, r.LastError)
+		r.Quarantined = true
+		r.NextRetryTime = nil
+		return
+	}
+	nextRetry := metav1.NewTime(now.Add(prepareBackoff(f.spec, r.Failures)))
+	r.NextRetryTime = &nextRetry
+}
+
+// prepareBackoff returns the time to wait before retrying the OSD prepare job after its given
+// number of failures
+func prepareBackoff(spec *cephv1.OSDPrepareRetrySpec, failures int) time.Duration {
+	backoff := defaultPrepareInitialBackoff
+	if spec.InitialBackoff != nil && spec.InitialBackoff.Duration > 0 {
+		backoff = spec.InitialBackoff.Duration
+	}
+	maxBackoff := defaultPrepareMaxBackoff
+	if spec.MaxBackoff != nil && spec.MaxBackoff.Duration > 0 {
+		maxBackoff = spec.MaxBackoff.Duration
+	}
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+func truncatePrepareError(message string) string {
+	if len(message) <= maxPrepareErrorLength {
+		return message
+	}
+	return message[:maxPrepareErrorLength] + "..."
+}
+
+// savePrepareFailures publishes the failures of the OSD prepare jobs in the CephCluster status
+func (c *Cluster) savePrepareFailures() {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the failures of the OSD prepare jobs. %v", err)
+		return
+	}
+	cephCluster.Status.OSDPrepareFailures = c.prepareFailures.records
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the failures of the OSD prepare jobs in the CephCluster status. %v", err)
+	}
+}
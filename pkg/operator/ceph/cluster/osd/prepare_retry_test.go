@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPrepareRetry(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: clusterInfo.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	k8sClient := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	getCephCluster := func() *cephv1.CephCluster {
+		cephCluster := &cephv1.CephCluster{}
+		require.NoError(t, k8sClient.Get(ctx, clusterInfo.NamespacedName(), cephCluster))
+		return cephCluster
+	}
+
+	spec := cephv1.ClusterSpec{Storage: cephv1.StorageScopeSpec{PrepareRetry: &cephv1.OSDPrepareRetrySpec{MaxRetries: 2}}}
+	c := New(&clusterd.Context{Clientset: test.New(t, 1), Client: k8sClient}, clusterInfo, spec, "rook/rook:master")
+	c.loadPrepareFailures()
+	now := time.Now()
+	failed := &OrchestrationStatus{Status: OrchestrationStatusFailed, Message: "failed to configure devices", Devices: []string{"sdb"}}
+	checkRetry := func(now time.Time) (*osdProperties, bool, int) {
+		osdProps := &osdProperties{crushHostname: "node0"}
+		errs := newProvisionErrors()
+		start := c.checkPrepareRetry(osdProps, errs, now)
+		return osdProps, start, errs.len()
+	}
+
+	// the failed device is skipped until its retry
+	c.recordPrepareStatus("node0", failed, now)
+	failures := getCephCluster().Status.OSDPrepareFailures
+	require.Len(t, failures, 1)
+	assert.Equal(t, "node0", failures[0].Node)
+	assert.Equal(t, "sdb", failures[0].Device)
+	assert.Equal(t, 1, failures[0].Failures)
+	assert.Equal(t, "failed to configure devices", failures[0].LastError)
+	assert.Equal(t, now.Add(time.Minute).Unix(), failures[0].NextRetryTime.Unix())
+	osdProps, start, errCount := checkRetry(now)
+	assert.True(t, start)
+	assert.Equal(t, 1, errCount)
+	assert.Equal(t, []string{"sdb"}, osdProps.quarantinedDevices)
+	osdProps, start, errCount = checkRetry(now.Add(time.Minute))
+	assert.True(t, start)
+	assert.Zero(t, errCount)
+	assert.Empty(t, osdProps.quarantinedDevices)
+
+	// the device is quarantined once its failures exceed the max retries
+	c.recordPrepareStatus("node0", failed, now)
+	assert.Equal(t, now.Add(2*time.Minute).Unix(), c.prepareFailures.records[0].NextRetryTime.Unix())
+	c.recordPrepareStatus("node0", failed, now)
+	failures = getCephCluster().Status.OSDPrepareFailures
+	assert.Equal(t, 3, failures[0].Failures)
+	assert.True(t, failures[0].Quarantined)
+	assert.Nil(t, failures[0].NextRetryTime)
+	osdProps, start, errCount = checkRetry(now.Add(time.Hour))
+	assert.True(t, start)
+	assert.Zero(t, errCount)
+	assert.Equal(t, []string{"sdb"}, osdProps.quarantinedDevices)
+
+	// a failure of the job without a device delays the whole job
+	c.recordPrepareStatus("node0", &OrchestrationStatus{Status: OrchestrationStatusFailed, Message: "failed to list devices"}, now)
+	_, start, errCount = checkRetry(now)
+	assert.False(t, start)
+	assert.Equal(t, 1, errCount)
+
+	// a completed job clears the failures of the devices it prepared
+	_, start, _ = checkRetry(now.Add(time.Minute))
+	assert.True(t, start)
+	c.recordPrepareStatus("node0", &OrchestrationStatus{Status: OrchestrationStatusCompleted}, now)
+	failures = getCephCluster().Status.OSDPrepareFailures
+	require.Len(t, failures, 1)
+	assert.Equal(t, "sdb", failures[0].Device)
+
+	// the quarantine is released with the annotation, which is then removed
+	cephCluster = getCephCluster()
+	cephCluster.Annotations = map[string]string{ReleaseOSDQuarantineAnnotation: "node1, node0"}
+	require.NoError(t, k8sClient.Update(ctx, cephCluster))
+	c.loadPrepareFailures()
+	assert.Empty(t, c.prepareFailures.records)
+	cephCluster = getCephCluster()
+	assert.Empty(t, cephCluster.Status.OSDPrepareFailures)
+	assert.NotContains(t, cephCluster.Annotations, ReleaseOSDQuarantineAnnotation)
+}
+
+func TestPrepareBackoff(t *testing.T) {
+	spec := &cephv1.OSDPrepareRetrySpec{}
+	assert.Equal(t, time.Minute, prepareBackoff(spec, 1))
+	assert.Equal(t, 16*time.Minute, prepareBackoff(spec, 5))
+	assert.Equal(t, 30*time.Minute, prepareBackoff(spec, 6))
+	assert.Equal(t, 30*time.Minute, prepareBackoff(spec, 100))
+
+	spec.InitialBackoff = &metav1.Duration{Duration: 10 * time.Second}
+	spec.MaxBackoff = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, 10*time.Second, prepareBackoff(spec, 1))
+	assert.Equal(t, 40*time.Second, prepareBackoff(spec, 3))
+	assert.Equal(t, time.Minute, prepareBackoff(spec, 4))
+}
@@ -234,6 +234,16 @@ func (c *Cluster) provisionOSDContainer(osdProps osdProperties, copyBinariesMoun
 	} else if osdProps.selection.GetUseAllDevices() {
 		envVars = append(envVars, deviceFilterEnvVar("all"))
 	}
+	if osdProps.selection.DeviceIdentityFilter != nil && !osdProps.onPVC() {
+		filter, err := json.Marshal(osdProps.selection.DeviceIdentityFilter)
+		if err != nil {
+			return v1.Container{}, errors.Wrapf(err, "failed to JSON marshal device identity filter for node %q", osdProps.crushHostname)
+		}
+		envVars = append(envVars, deviceIdentityFilterEnvVar(string(filter)))
+	}
+	if len(osdProps.quarantinedDevices) > 0 && !osdProps.onPVC() {
+		envVars = append(envVars, quarantinedDevicesEnvVar(osdProps.quarantinedDevices))
+	}
 	envVars = append(envVars, v1.EnvVar{Name: "ROOK_CEPH_VERSION", Value: c.clusterInfo.CephVersion.CephVersionFormatted()})
 	envVars = append(envVars, crushDeviceClassEnvVar(osdProps.storeConfig.DeviceClass))
 	envVars = append(envVars, crushInitialWeightEnvVar(osdProps.storeConfig.InitialWeight))
@@ -287,6 +297,9 @@ func (c *Cluster) provisionOSDContainer(osdProps osdProperties, copyBinariesMoun
 		envVars = append(envVars, pvcBackedOSDEnvVar("true"))
 		envVars = append(envVars, encryptedDeviceEnvVar(osdProps.encrypted))
 		envVars = append(envVars, pvcNameEnvVar(osdProps.pvc.ClaimName))
+		if osdProps.ephemeralMetadataDevice && (osdProps.onPVCWithMetadata() || osdProps.onPVCWithWal()) {
+			envVars = append(envVars, ephemeralMetadataDeviceEnvVar())
+		}
 
 		if osdProps.encrypted {
 			// If a KMS is configured we populate volume mounts and env variables
@@ -0,0 +1,462 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// OSDHostToPVCMigrationConfirmation is the confirmation provided by the user to migrate the OSDs
+	// on the nodes to PVCs
+	OSDHostToPVCMigrationConfirmation = "yes-really-migrate-osds-to-pvcs"
+	// OSDPVCMigrationsConfigMap records the failure domains whose OSDs were migrated to PVCs
+	OSDPVCMigrationsConfigMap = "rook-ceph-osd-pvc-migrations"
+	osdPVCMigrationsKey       = "migrations"
+	defaultMigrationDomain    = "host"
+	// osdMigrationDomain is the failure domain to migrate one OSD at a time
+	osdMigrationDomain    = "osd"
+	osdDirsCleanupAppName = "rook-ceph-osd-dirs-cleanup"
+	osdDirsCleanupJobFmt  = "rook-ceph-osd-dirs-cleanup-%s"
+
+	// OSDPVCMigrationDraining is the phase of a failure domain whose OSDs are marked out until their
+	// data is moved to the other OSDs
+	OSDPVCMigrationDraining = "Draining"
+	// OSDPVCMigrationCleaningUp is the phase of a failure domain whose OSDs are purged until their
+	// directories are removed from the dataDirHostPath of their nodes
+	OSDPVCMigrationCleaningUp = "CleaningUp"
+	// OSDPVCMigrationMigrated is the phase of a failure domain once its OSDs are purged and cleaned up
+	OSDPVCMigrationMigrated = "Migrated"
+)
+
+// OSDPVCMigration is the migration of the OSDs on the nodes of a failure domain to PVCs
+type OSDPVCMigration struct {
+	// FailureDomain is the CRUSH bucket of the OSDs, for example "host=node1"
+	FailureDomain string         `json:"failureDomain"`
+	OSDs          map[int]string `json:"osds"`
+	// Nodes are the nodes of the OSDs, where their directories are cleaned up once they are purged
+	Nodes map[int]string `json:"nodes,omitempty"`
+	Phase string         `json:"phase"`
+	Time  metav1.Time    `json:"time"`
+}
+
+// UpdatePVCMigration updates the migration of the OSDs on the nodes to PVCs requested in the spec,
+// and the settings of the jobs cleaning up the directories of the migrated OSDs
+func (m *OSDHealthMonitor) UpdatePVCMigration(clusterSpec *cephv1.ClusterSpec, rookImage string) {
+	m.pvcMigration = clusterSpec.Storage.Migration.HostToPVC
+	m.clusterSpec = clusterSpec
+	m.rookImage = rookImage
+}
+
+// checkOSDPVCMigration migrates the OSDs on the nodes to the OSDs on PVCs, one failure domain at a
+// time. The OSDs of the failure domain are marked out, then their deployments are deleted and the
+// OSDs are purged once they are safe to destroy, and their directories are removed from their
+// nodes. The next failure domain is drained once the PGs are healthy again. The progress is
+// recorded in a configmap so the migration resumes where it stopped after a restart.
+func (m *OSDHealthMonitor) checkOSDPVCMigration() error {
+	spec := m.pvcMigration
+	if spec == nil || spec.Confirmation != OSDHostToPVCMigrationConfirmation {
+		m.reportOSDPVCMigration(nil)
+		return nil
+	}
+	domainType := spec.FailureDomain
+	if domainType == "" {
+		domainType = defaultMigrationDomain
+	}
+
+	cm, migrations, err := loadOSDPVCMigrations(m.context, m.clusterInfo)
+	if err != nil {
+		return err
+	}
+	deployments, err := k8sutil.GetDeployments(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName))
+	if err != nil {
+		return errors.Wrap(err, "failed to get the osd deployments")
+	}
+	domains, pvcOSDs := hostOSDsByFailureDomain(deployments.Items, domainType)
+	status := &cephv1.OSDPVCMigrationStatus{FailureDomains: sortedFailureDomains(domains)}
+	defer func() { m.reportOSDPVCMigration(status) }()
+
+	for i := range migrations {
+		switch migrations[i].Phase {
+		case OSDPVCMigrationDraining:
+			status.Message = fmt.Sprintf("draining the osds of failure domain %q", migrations[i].FailureDomain)
+			return m.purgeMigratedOSDs(cm, migrations, &migrations[i])
+		case OSDPVCMigrationCleaningUp:
+			status.Message = fmt.Sprintf("cleaning up the directories of the osds of failure domain %q", migrations[i].FailureDomain)
+			return m.cleanUpMigratedOSDs(cm, migrations, &migrations[i])
+		}
+	}
+
+	if len(domains) == 0 {
+		if len(migrations) > 0 {
+			logger.Info("all the osds on the nodes were migrated to pvcs")
+		}
+		status = nil
+		return nil
+	}
+	if pvcOSDs == 0 {
+		status.Message = "waiting for osds on pvcs to migrate the osds on the nodes to"
+		logger.Warningf("osd migration to pvcs is %s, the storageClassDeviceSets must be configured", status.Message)
+		return nil
+	}
+	msg, clean, err := client.IsClusterClean(m.context, m.clusterInfo, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to check if the pgs are healthy")
+	}
+	next := status.FailureDomains[0]
+	if !clean {
+		status.Message = fmt.Sprintf("waiting for the pgs to be healthy before migrating failure domain %q. %s", next, msg)
+		logger.Infof("osd migration to pvcs is %s", status.Message)
+		return nil
+	}
+
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	nodes := hostOSDNodes(deployments.Items)
+	migration := OSDPVCMigration{FailureDomain: next, OSDs: map[int]string{}, Nodes: map[int]string{}, Phase: OSDPVCMigrationDraining, Time: metav1.Now()}
+	for _, osdID := range domains[next] {
+		if uuid := osdUUID(osdDump, osdID); uuid != "" {
+			migration.OSDs[osdID] = uuid
+			if node, ok := nodes[osdID]; ok {
+				migration.Nodes[osdID] = node
+			}
+		}
+	}
+	logger.Infof("migrating the osds %v of failure domain %q to pvcs", domains[next], next)
+	// the migration is recorded before the OSDs are marked out to find them again after a restart
+	if err := saveOSDPVCMigrations(m.context, m.clusterInfo, cm, append(migrations, migration)); err != nil {
+		return err
+	}
+	status.Message = fmt.Sprintf("draining the osds of failure domain %q", next)
+	for osdID := range migration.OSDs {
+		if _, err := client.OSDOut(m.context, m.clusterInfo, osdID); err != nil {
+			// the OSD is marked out again while it is drained
+			logger.Warningf("failed to mark osd.%d out. %v", osdID, err)
+		}
+	}
+	return nil
+}
+
+// purgeMigratedOSDs deletes the deployments of the OSDs of the failure domain marked out and purges
+// them once they are all safe to destroy
+func (m *OSDHealthMonitor) purgeMigratedOSDs(cm *v1.ConfigMap, migrations []OSDPVCMigration, migration *OSDPVCMigration) error {
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	remaining := []int{}
+	for osdID, uuid := range migration.OSDs {
+		if osdUUID(osdDump, osdID) == uuid {
+			remaining = append(remaining, osdID)
+		}
+	}
+	sort.Ints(remaining)
+
+	for _, osdID := range remaining {
+		// the OSD is marked out again in case it was marked in since
+		if _, err := client.OSDOut(m.context, m.clusterInfo, osdID); err != nil {
+			return errors.Wrapf(err, "failed to mark osd.%d out", osdID)
+		}
+	}
+	if len(remaining) > 0 {
+		safety, err := client.CheckOSDSafety(m.context, m.clusterInfo, client.OSDSafetyCheck{OSDs: remaining, Remove: true})
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if osds %v are safe to remove", remaining)
+		}
+		if !safety.Safe {
+			logger.Infof("waiting for the data of osds %v to move to the other osds before migrating failure domain %q. %s", remaining, migration.FailureDomain, safety)
+			return nil
+		}
+	}
+
+	for _, osdID := range remaining {
+		deploymentName := fmt.Sprintf(osdAppNameFmt, osdID)
+		if err := k8sutil.DeleteDeployment(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, deploymentName); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the deployment of osd.%d", osdID)
+		}
+		args := []string{"osd", "purge", fmt.Sprintf("osd.%d", osdID), "--force", "--yes-i-really-mean-it"}
+		if _, err := client.NewCephCommand(m.context, m.clusterInfo, args).Run(); err != nil {
+			return errors.Wrapf(err, "failed to purge osd.%d", osdID)
+		}
+		logger.Infof("purged osd.%d of failure domain %q migrated to pvcs", osdID, migration.FailureDomain)
+	}
+
+	migration.Phase = OSDPVCMigrationCleaningUp
+	migration.Time = metav1.Now()
+	if err := saveOSDPVCMigrations(m.context, m.clusterInfo, cm, migrations); err != nil {
+		return err
+	}
+	return m.cleanUpMigratedOSDs(cm, migrations, migration)
+}
+
+// cleanUpMigratedOSDs runs a job on each node of the purged OSDs of the failure domain to remove
+// their directories from the dataDirHostPath, and completes the migration of the failure domain
+// once the jobs succeeded
+func (m *OSDHealthMonitor) cleanUpMigratedOSDs(cm *v1.ConfigMap, migrations []OSDPVCMigration, migration *OSDPVCMigration) error {
+	osdDirs := map[string][]string{}
+	if m.clusterSpec != nil && m.clusterSpec.DataDirHostPath != "" {
+		for osdID, node := range migration.Nodes {
+			osdDirs[node] = append(osdDirs[node], m.clusterInfo.FSID+"_"+migration.OSDs[osdID])
+		}
+	}
+
+	succeeded := true
+	for node, dirs := range osdDirs {
+		jobName := k8sutil.TruncateNodeNameForJob(osdDirsCleanupJobFmt, node)
+		job, err := m.context.Clientset.BatchV1().Jobs(m.clusterInfo.Namespace).Get(m.clusterInfo.Context, jobName, metav1.GetOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get job %q", jobName)
+		}
+		if err == nil {
+			if job.Status.Succeeded == 0 {
+				logger.Infof("waiting for job %q to clean up the directories of the migrated osds on node %q", jobName, node)
+				succeeded = false
+			}
+			continue
+		}
+		if _, err := m.context.Clientset.CoreV1().Nodes().Get(m.clusterInfo.Context, node, metav1.GetOptions{}); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Infof("not cleaning up the directories of the migrated osds on node %q since the node does not exist", node)
+				delete(osdDirs, node)
+				continue
+			}
+			return errors.Wrapf(err, "failed to get node %q", node)
+		}
+		succeeded = false
+		sort.Strings(dirs)
+		if err := k8sutil.RunReplaceableJob(m.clusterInfo.Context, m.context.Clientset, m.osdDirsCleanupJob(jobName, node, dirs), false); err != nil {
+			return errors.Wrapf(err, "failed to run job %q to clean up the directories of the migrated osds on node %q", jobName, node)
+		}
+		logger.Infof("started job %q to clean up the directories %v of the migrated osds on node %q", jobName, dirs, node)
+	}
+	if !succeeded {
+		return nil
+	}
+
+	for node := range osdDirs {
+		jobName := k8sutil.TruncateNodeNameForJob(osdDirsCleanupJobFmt, node)
+		if err := k8sutil.DeleteBatchJob(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, jobName, false); err != nil && !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to delete job %q. %v", jobName, err)
+		}
+	}
+	logger.Infof("migrated the osds of failure domain %q to pvcs", migration.FailureDomain)
+	migration.Phase = OSDPVCMigrationMigrated
+	migration.Time = metav1.Now()
+	return saveOSDPVCMigrations(m.context, m.clusterInfo, cm, migrations)
+}
+
+// osdDirsCleanupJob returns the job removing the directories of the migrated OSDs on a node
+func (m *OSDHealthMonitor) osdDirsCleanupJob(jobName, node string, osdDirs []string) *batch.Job {
+	dataDirHostPath := m.clusterSpec.DataDirHostPath
+	podSpec := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   jobName,
+			Labels: map[string]string{k8sutil.AppAttr: osdDirsCleanupAppName},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "osd-dirs-cleanup",
+				Image: m.rookImage,
+				Args: []string{"ceph", "clean", "osd-dirs",
+					"--data-dir-host-path", dataDirHostPath,
+					"--namespace-dir", m.clusterInfo.Namespace,
+					"--osd-dirs", strings.Join(osdDirs, ","),
+				},
+				SecurityContext: controller.PrivilegedContext(true),
+				VolumeMounts:    []v1.VolumeMount{{Name: "data-dir-host-path", MountPath: dataDirHostPath}},
+				Resources:       cephv1.GetCleanupResources(m.clusterSpec.Resources),
+			}},
+			Volumes:            []v1.Volume{{Name: "data-dir-host-path", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: dataDirHostPath}}}},
+			NodeSelector:       map[string]string{k8sutil.LabelHostname(): node},
+			RestartPolicy:      v1.RestartPolicyOnFailure,
+			PriorityClassName:  cephv1.GetCleanupPriorityClassName(m.clusterSpec.PriorityClassNames),
+			ServiceAccountName: k8sutil.DefaultServiceAccount,
+		},
+	}
+	// the job runs on the node of the OSDs, only their tolerations are needed
+	cephv1.Placement{Tolerations: cephv1.GetOSDPlacement(m.clusterSpec.Placement).Tolerations}.ApplyToPodSpec(&podSpec.Spec)
+
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: m.clusterInfo.Namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: osdDirsCleanupAppName},
+		},
+		Spec: batch.JobSpec{Template: podSpec},
+	}
+	cephv1.GetCleanupAnnotations(m.clusterSpec.Annotations).ApplyToObjectMeta(&job.ObjectMeta)
+	cephv1.GetCleanupLabels(m.clusterSpec.Labels).ApplyToObjectMeta(&job.ObjectMeta)
+	k8sutil.AddRookVersionLabelToJob(job)
+	if err := m.clusterInfo.OwnerInfo.SetControllerReference(job); err != nil {
+		logger.Warningf("failed to set owner reference on job %q. %v", jobName, err)
+	}
+	return job
+}
+
+// reportOSDPVCMigration publishes the progress of the migration of the OSDs to PVCs on the
+// CephCluster status when it changed
+func (m *OSDHealthMonitor) reportOSDPVCMigration(status *cephv1.OSDPVCMigrationStatus) {
+	if reflect.DeepEqual(status, m.pvcMigrationReported) {
+		return
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := m.context.Client.Get(m.clusterInfo.Context, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the migration of the osds to pvcs. %v", err)
+		return
+	}
+	cephCluster.Status.OSDPVCMigration = status
+	if err := reporting.UpdateStatus(m.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the migration of the osds to pvcs in the CephCluster status. %v", err)
+		return
+	}
+	m.pvcMigrationReported = status
+}
+
+// hostOSDsByFailureDomain returns the IDs of the OSDs on the nodes by failure domain, and the number
+// of OSDs on PVCs
+func hostOSDsByFailureDomain(deployments []appsv1.Deployment, domainType string) (map[string][]int, int) {
+	domains := map[string][]int{}
+	pvcOSDs := 0
+	for i := range deployments {
+		d := &deployments[i]
+		if osdIsOnPVC(d) {
+			pvcOSDs++
+			continue
+		}
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			logger.Debugf("not migrating deployment %q to a pvc. %v", d.Name, err)
+			continue
+		}
+		domain := fmt.Sprintf("%s=%d", osdMigrationDomain, osdID)
+		if domainType != osdMigrationDomain {
+			bucket, ok := d.Labels[fmt.Sprintf(TopologyLocationLabel, domainType)]
+			if !ok {
+				logger.Warningf("not migrating osd.%d to a pvc, its %s is unknown", osdID, domainType)
+				continue
+			}
+			domain = fmt.Sprintf("%s=%s", domainType, bucket)
+		}
+		domains[domain] = append(domains[domain], osdID)
+	}
+	for _, osds := range domains {
+		sort.Ints(osds)
+	}
+	return domains, pvcOSDs
+}
+
+// hostOSDNodes returns the nodes of the OSDs on the nodes by OSD ID
+func hostOSDNodes(deployments []appsv1.Deployment) map[int]string {
+	nodes := map[int]string{}
+	for i := range deployments {
+		d := &deployments[i]
+		if osdIsOnPVC(d) {
+			continue
+		}
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			continue
+		}
+		node, err := getNodeOrPVCName(d)
+		if err != nil {
+			logger.Warningf("the directory of osd.%d will not be cleaned up after its migration to a pvc. %v", osdID, err)
+			continue
+		}
+		nodes[osdID] = node
+	}
+	return nodes
+}
+
+func sortedFailureDomains(domains map[string][]int) []string {
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// migratedOSDUUIDs returns the UUIDs of the OSDs on the nodes migrated to PVCs
+func (c *Cluster) migratedOSDUUIDs() (sets.Set[string], error) {
+	_, migrations, err := loadOSDPVCMigrations(c.context, c.clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+	uuids := sets.New[string]()
+	for _, migration := range migrations {
+		for _, uuid := range migration.OSDs {
+			uuids.Insert(uuid)
+		}
+	}
+	return uuids, nil
+}
+
+func loadOSDPVCMigrations(context *clusterd.Context, clusterInfo *client.ClusterInfo) (*v1.ConfigMap, []OSDPVCMigration, error) {
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, OSDPVCMigrationsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, nil, errors.Wrapf(err, "failed to get configmap %q", OSDPVCMigrationsConfigMap)
+		}
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: OSDPVCMigrationsConfigMap, Namespace: clusterInfo.Namespace}}
+		if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to set owner reference on configmap %q", OSDPVCMigrationsConfigMap)
+		}
+	}
+	migrations := []OSDPVCMigration{}
+	if cm.Data[osdPVCMigrationsKey] != "" {
+		if err := json.Unmarshal([]byte(cm.Data[osdPVCMigrationsKey]), &migrations); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal the osd migrations in configmap %q", OSDPVCMigrationsConfigMap)
+		}
+	}
+	return cm, migrations, nil
+}
+
+func saveOSDPVCMigrations(context *clusterd.Context, clusterInfo *client.ClusterInfo, cm *v1.ConfigMap, migrations []OSDPVCMigration) error {
+	raw, err := json.Marshal(migrations)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the osd migrations")
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[osdPVCMigrationsKey] = string(raw)
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", OSDPVCMigrationsConfigMap)
+	}
+	return nil
+}
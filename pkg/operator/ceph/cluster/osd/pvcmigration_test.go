@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckOSDPVCMigration(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	newDeployment := func(osdID int, labels map[string]string) {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(osdAppNameFmt, osdID),
+			Namespace: clusterInfo.Namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", osdID)},
+		}}
+		for k, v := range labels {
+			d.Labels[k] = v
+		}
+		if node, ok := labels["topology-location-host"]; ok {
+			d.Spec.Template.Spec.NodeSelector = map[string]string{k8sutil.LabelHostname(): node}
+		}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	newDeployment(0, map[string]string{"topology-location-host": "node1"})
+	newDeployment(1, map[string]string{"topology-location-host": "node1"})
+	newDeployment(2, map[string]string{"topology-location-host": "node2"})
+	// node2 was removed from the cluster, the directory of its osd is not cleaned up
+	_, err := clientset.CoreV1().Nodes().Create(ctx, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: clusterInfo.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	k8sClient := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+
+	clean := false
+	safe := false
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "status":
+				if clean {
+					return `{"pgmap":{"num_pgs":10,"pgs_by_state":[{"state_name":"active+clean","count":10}]}}`, nil
+				}
+				return `{"pgmap":{"num_pgs":10,"pgs_by_state":[{"state_name":"active+clean","count":8},{"state_name":"active+remapped+backfilling","count":2}]}}`, nil
+			case args[0] == "osd" && args[1] == "dump":
+				return `{"osds":[{"osd":0,"up":1,"in":1,"uuid":"uuid0"},{"osd":1,"up":1,"in":1,"uuid":"uuid1"},{"osd":2,"up":1,"in":1,"uuid":"uuid2"},{"osd":3,"up":1,"in":1,"uuid":"uuid3"}]}`, nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				return "", nil
+			case args[0] == "osd" && args[1] == "safe-to-destroy":
+				if safe {
+					return fmt.Sprintf(`{"safe_to_destroy":[%s]}`, args[2]), nil
+				}
+				return `{"safe_to_destroy":[]}`, nil
+			case args[0] == "osd" && (args[1] == "out" || args[1] == "purge"):
+				commands = append(commands, strings.Join(args[:3], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	clusterdContext := &clusterd.Context{Clientset: clientset, Client: k8sClient, Executor: executor}
+	m := &OSDHealthMonitor{context: clusterdContext, clusterInfo: clusterInfo}
+	migrations := func() []OSDPVCMigration {
+		_, migrations, err := loadOSDPVCMigrations(clusterdContext, clusterInfo)
+		require.NoError(t, err)
+		return migrations
+	}
+	status := func() *cephv1.OSDPVCMigrationStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, k8sClient.Get(ctx, clusterInfo.NamespacedName(), cluster))
+		return cluster.Status.OSDPVCMigration
+	}
+
+	// not confirmed
+	clusterSpec := &cephv1.ClusterSpec{DataDirHostPath: "/var/lib/rook"}
+	clusterSpec.Storage.Migration.HostToPVC = &cephv1.OSDHostToPVCMigrationSpec{}
+	m.UpdatePVCMigration(clusterSpec, "rook/ceph:test")
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Empty(t, migrations())
+	assert.Nil(t, status())
+
+	// no osds on pvcs to migrate to
+	clusterSpec.Storage.Migration.HostToPVC = &cephv1.OSDHostToPVCMigrationSpec{Confirmation: OSDHostToPVCMigrationConfirmation}
+	m.UpdatePVCMigration(clusterSpec, "rook/ceph:test")
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Empty(t, migrations())
+	assert.Equal(t, []string{"host=node1", "host=node2"}, status().FailureDomains)
+	assert.Contains(t, status().Message, "waiting for osds on pvcs")
+
+	// waiting for the pgs to be healthy
+	newDeployment(3, map[string]string{OSDOverPVCLabelKey: "set1-data-0"})
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Empty(t, migrations())
+	assert.Contains(t, status().Message, "waiting for the pgs to be healthy")
+
+	// the osds of the first host are marked out
+	clean = true
+	require.NoError(t, m.checkOSDPVCMigration())
+	require.Len(t, migrations(), 1)
+	assert.Equal(t, "host=node1", migrations()[0].FailureDomain)
+	assert.Equal(t, map[int]string{0: "uuid0", 1: "uuid1"}, migrations()[0].OSDs)
+	assert.Equal(t, map[int]string{0: "node1", 1: "node1"}, migrations()[0].Nodes)
+	assert.Equal(t, OSDPVCMigrationDraining, migrations()[0].Phase)
+	assert.ElementsMatch(t, []string{"osd out 0", "osd out 1"}, commands)
+
+	// the osds are purged once they are all safe to destroy
+	commands = nil
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Equal(t, OSDPVCMigrationDraining, migrations()[0].Phase)
+	assert.Equal(t, []string{"osd out 0"}, commands)
+	safe = true
+	commands = nil
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Equal(t, OSDPVCMigrationCleaningUp, migrations()[0].Phase)
+	assert.Equal(t, []string{"osd out 0", "osd out 1", "osd purge osd.0", "osd purge osd.1"}, commands)
+	_, err = clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-0", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// the directories of the purged osds are cleaned up on their node
+	job, err := clientset.BatchV1().Jobs(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-dirs-cleanup-node1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{k8sutil.LabelHostname(): "node1"}, job.Spec.Template.Spec.NodeSelector)
+	assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, clusterInfo.FSID+"_uuid0,"+clusterInfo.FSID+"_uuid1")
+	commands = nil
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Equal(t, OSDPVCMigrationCleaningUp, migrations()[0].Phase)
+	assert.Empty(t, commands)
+	assert.Contains(t, status().Message, "cleaning up the directories")
+
+	// the failure domain is migrated once the directories are cleaned up
+	job.Status.Succeeded = 1
+	_, err = clientset.BatchV1().Jobs(clusterInfo.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Equal(t, OSDPVCMigrationMigrated, migrations()[0].Phase)
+	_, err = clientset.BatchV1().Jobs(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-dirs-cleanup-node1", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// the migrated osds are not started again from the devices of the node
+	c := &Cluster{context: clusterdContext, clusterInfo: clusterInfo}
+	uuids, err := c.migratedOSDUUIDs()
+	require.NoError(t, err)
+	assert.True(t, uuids.Has("uuid0"))
+	assert.True(t, uuids.Has("uuid1"))
+	assert.False(t, uuids.Has("uuid2"))
+
+	// the next host is migrated
+	commands = nil
+	require.NoError(t, m.checkOSDPVCMigration())
+	require.Len(t, migrations(), 2)
+	assert.Equal(t, "host=node2", migrations()[1].FailureDomain)
+	assert.Equal(t, []string{"osd out 2"}, commands)
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Equal(t, OSDPVCMigrationMigrated, migrations()[1].Phase)
+
+	// the status is cleared once all the osds are migrated
+	require.NoError(t, m.checkOSDPVCMigration())
+	assert.Nil(t, status())
+}
+
+func TestHostOSDsByFailureDomain(t *testing.T) {
+	newDeployment := func(osdID int, labels map[string]string) appsv1.Deployment {
+		d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf(osdAppNameFmt, osdID),
+			Labels: map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", osdID)},
+		}}
+		for k, v := range labels {
+			d.Labels[k] = v
+		}
+		return d
+	}
+	deployments := []appsv1.Deployment{
+		newDeployment(0, map[string]string{"topology-location-host": "node1", "topology-location-rack": "rack1"}),
+		newDeployment(1, map[string]string{"topology-location-host": "node1", "topology-location-rack": "rack1"}),
+		newDeployment(2, map[string]string{"topology-location-host": "node2"}),
+		newDeployment(3, map[string]string{OSDOverPVCLabelKey: "set1-data-0"}),
+	}
+
+	domains, pvcOSDs := hostOSDsByFailureDomain(deployments, "host")
+	assert.Equal(t, map[string][]int{"host=node1": {0, 1}, "host=node2": {2}}, domains)
+	assert.Equal(t, 1, pvcOSDs)
+
+	// the osds without the failure domain are not migrated
+	domains, _ = hostOSDsByFailureDomain(deployments, "rack")
+	assert.Equal(t, map[string][]int{"rack=rack1": {0, 1}}, domains)
+
+	// the osds are migrated one at a time
+	domains, _ = hostOSDsByFailureDomain(deployments, "osd")
+	assert.Equal(t, map[string][]int{"osd=0": {0}, "osd=1": {1}, "osd=2": {2}}, domains)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package removal reconciles the CephOSDRemoval resources to purge OSDs from the cluster.
+package removal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/daemon/ceph/osd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "ceph-osd-removal-controller"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+var cephOSDRemovalKind = reflect.TypeOf(cephv1.CephOSDRemoval{}).Name()
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       cephOSDRemovalKind,
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// the removal is checked again while OSDs are waiting to be down or safe to destroy
+var waitForRemovalRequeue = reconcile.Result{Requeue: true, RequeueAfter: 15 * time.Second}
+
+// ReconcileCephOSDRemoval reconciles a CephOSDRemoval object
+type ReconcileCephOSDRemoval struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// Add creates a new CephOSDRemoval Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileCephOSDRemoval{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor("rook-" + controllerName),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephOSDRemoval CRD object
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephOSDRemoval{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephOSDRemoval]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephOSDRemoval](mgr.GetScheme()),
+		),
+	)
+}
+
+// Reconcile reads that state of the cluster for a CephOSDRemoval object and removes the OSDs of the
+// CephOSDRemoval.Spec from the cluster
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileCephOSDRemoval) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, cephOSDRemoval, err := r.reconcile(request)
+	if err != nil {
+		r.updateStatus(cephOSDRemoval.Generation, request.NamespacedName, cephv1.OSDRemovalFailed, nil)
+		logger.Errorf("failed to reconcile %v", err)
+	}
+
+	return reporting.ReportReconcileResult(logger, r.recorder, request, &cephOSDRemoval, reconcileResponse, err)
+}
+
+func (r *ReconcileCephOSDRemoval) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephOSDRemoval, error) {
+	// Fetch the CephOSDRemoval instance
+	cephOSDRemoval := &cephv1.CephOSDRemoval{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephOSDRemoval)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephOSDRemoval resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, *cephOSDRemoval, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, *cephOSDRemoval, errors.Wrap(err, "failed to get CephOSDRemoval")
+	}
+
+	// The IDs of the purged OSDs may be reused by new OSDs, so a completed removal is never run again
+	if cephOSDRemoval.Status != nil && cephOSDRemoval.Status.Phase == cephv1.OSDRemovalCompleted {
+		logger.Debugf("removal of the osds of CephOSDRemoval %q is completed", request.NamespacedName)
+		return reconcile.Result{}, *cephOSDRemoval, nil
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, _, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		logger.Debugf("CephCluster resource not ready in namespace %q, retrying in %q.", request.NamespacedName.Namespace, reconcileResponse.RequeueAfter.String())
+		return reconcileResponse, *cephOSDRemoval, nil
+	}
+	if cephCluster.Spec.External.Enable {
+		return reconcile.Result{}, *cephOSDRemoval, errors.New("the osds of an external cluster cannot be removed by rook")
+	}
+
+	// Populate clusterInfo
+	clusterInfo, _, _, err := opcontroller.LoadClusterInfo(r.context, r.opManagerContext, request.NamespacedName.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, *cephOSDRemoval, errors.Wrap(err, "failed to populate cluster info")
+	}
+
+	phase, osds, err := removeOSDs(r.context, clusterInfo, cephOSDRemoval)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, *cephOSDRemoval, errors.Wrap(err, "failed to remove the osds")
+	}
+	r.updateStatus(cephOSDRemoval.Generation, request.NamespacedName, phase, osds)
+
+	if phase != cephv1.OSDRemovalCompleted {
+		logger.Debugf("waiting to remove the osds of CephOSDRemoval %q", request.NamespacedName)
+		return waitForRemovalRequeue, *cephOSDRemoval, nil
+	}
+	logger.Infof("removed the osds of CephOSDRemoval %q", request.NamespacedName)
+	return reconcile.Result{}, *cephOSDRemoval, nil
+}
+
+// removeOSDs progresses the removal of each OSD of the CephOSDRemoval and returns the phase of the
+// removal with the progress of each OSD
+func removeOSDs(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, cephOSDRemoval *cephv1.CephOSDRemoval) (cephv1.OSDRemovalPhase, []cephv1.OSDRemovalProgress, error) {
+	removed := map[int]bool{}
+	if cephOSDRemoval.Status != nil {
+		for _, progress := range cephOSDRemoval.Status.OSDs {
+			removed[progress.ID] = progress.Phase == cephv1.OSDRemovalRemoved
+		}
+	}
+
+	osdDump, err := cephclient.GetOSDDump(context, clusterInfo)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get osd dump")
+	}
+
+	phase := cephv1.OSDRemovalCompleted
+	osds := []cephv1.OSDRemovalProgress{}
+	checked := map[int]bool{}
+	for _, osdID := range cephOSDRemoval.Spec.OSDIDs {
+		if checked[osdID] {
+			continue
+		}
+		checked[osdID] = true
+
+		progress := cephv1.OSDRemovalProgress{ID: osdID, Phase: cephv1.OSDRemovalRemoved}
+		if !removed[osdID] {
+			progress = removeOSD(context, clusterInfo, osdDump, osdID, &cephOSDRemoval.Spec)
+		}
+		if progress.Phase != cephv1.OSDRemovalRemoved {
+			phase = cephv1.OSDRemovalProgressing
+		}
+		osds = append(osds, progress)
+	}
+	return phase, osds, nil
+}
+
+// removeOSD marks the OSD out once it is down and purges it once it is safe to destroy. The OSD is
+// purged without waiting for it to be safe to destroy if the removal is forced.
+func removeOSD(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, osdDump *cephclient.OSDDump, osdID int, spec *cephv1.OSDRemovalSpec) cephv1.OSDRemovalProgress {
+	progress := cephv1.OSDRemovalProgress{ID: osdID}
+	up, _, err := osdDump.StatusByID(int64(osdID))
+	if err != nil {
+		progress.Phase = cephv1.OSDRemovalRemoved
+		progress.Message = fmt.Sprintf("osd.%d is not in the osd map", osdID)
+		return progress
+	}
+	const upStatus int64 = 1
+	if up == upStatus {
+		progress.Phase = cephv1.OSDRemovalWaitingForDown
+		progress.Message = fmt.Sprintf("osd.%d is up, it cannot be removed unless it is down", osdID)
+		return progress
+	}
+
+	// the OSD is marked out again in case it was marked in since
+	if _, err := cephclient.OSDOut(context, clusterInfo, osdID); err != nil {
+		progress.Phase = cephv1.OSDRemovalPending
+		progress.Message = fmt.Sprintf("failed to mark osd.%d out. %v", osdID, err)
+		return progress
+	}
+
+	progress.Phase = cephv1.OSDRemovalDraining
+	safety, err := cephclient.CheckOSDSafety(context, clusterInfo, cephclient.OSDSafetyCheck{OSDs: []int{osdID}, Remove: true})
+	if !spec.ForceOSDRemoval {
+		if err != nil {
+			progress.Message = fmt.Sprintf("failed to check if osd.%d is safe to remove. %v", osdID, err)
+			return progress
+		}
+		if !safety.Safe {
+			logger.Infof("osd.%d is not safe to remove yet. %s", osdID, safety)
+			progress.Message = fmt.Sprintf("waiting for the data of osd.%d to move to the other osds", osdID)
+			return progress
+		}
+	} else if err != nil || !safety.Safe {
+		logger.Warningf("osd.%d is not safe to remove but force removal is enabled so proceeding with removal", osdID)
+	}
+
+	if err := osd.PurgeOSD(context, clusterInfo, osdID, spec.PreservePVC); err != nil {
+		progress.Message = err.Error()
+		return progress
+	}
+	progress.Phase = cephv1.OSDRemovalRemoved
+	return progress
+}
+
+// updateStatus updates the phase of the removal, and the progress of the OSDs if not nil
+func (r *ReconcileCephOSDRemoval) updateStatus(observedGeneration int64, name types.NamespacedName, phase cephv1.OSDRemovalPhase, osds []cephv1.OSDRemovalProgress) {
+	cephOSDRemoval := &cephv1.CephOSDRemoval{}
+	err := r.client.Get(r.opManagerContext, name, cephOSDRemoval)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephOSDRemoval resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve CephOSDRemoval %q to update status to %q. %v", name, phase, err)
+		return
+	}
+
+	if cephOSDRemoval.Status == nil {
+		cephOSDRemoval.Status = &cephv1.OSDRemovalStatus{}
+	}
+	cephOSDRemoval.Status.Phase = phase
+	if osds != nil {
+		cephOSDRemoval.Status.OSDs = osds
+	}
+	cephOSDRemoval.Status.ObservedGeneration = observedGeneration
+	if err := reporting.UpdateStatus(r.client, cephOSDRemoval); err != nil {
+		logger.Errorf("failed to set CephOSDRemoval %q status to %q. %v", name, phase, err)
+		return
+	}
+	logger.Debugf("CephOSDRemoval %q status updated to %q", name, phase)
+}
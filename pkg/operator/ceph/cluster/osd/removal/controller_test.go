@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRemoveOSDs(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	clientset := fake.NewSimpleClientset()
+	for _, osdID := range []int{1, 2} {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("rook-ceph-osd-%d", osdID), Namespace: clusterInfo.Namespace}}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	osdDump := `{"osds":[{"osd":0,"up":1,"in":1},{"osd":1,"up":0,"in":1},{"osd":2,"up":0,"in":1}]}`
+	safe := false
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "dump":
+				return osdDump, nil
+			case args[0] == "osd" && args[1] == "safe-to-destroy":
+				if safe {
+					return fmt.Sprintf(`{"safe_to_destroy":[%s]}`, args[2]), nil
+				}
+				return `{"safe_to_destroy":[]}`, nil
+			case args[0] == "status":
+				return `{"pgmap":{"num_pgs":0}}`, nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				return "", nil
+			case args[0] == "osd" && args[1] == "find":
+				return fmt.Sprintf(`{"osd":%s,"crush_location":{"host":"node1"}}`, args[2]), nil
+			case args[0] == "osd" && (args[1] == "out" || args[1] == "purge"):
+				commands = append(commands, strings.Join(args[:3], " "))
+				return "", nil
+			case args[0] == "osd" && args[1] == "crush":
+				return "", nil
+			case args[0] == "crash":
+				return "[]", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	context := &clusterd.Context{Clientset: clientset, Executor: executor}
+	removal := &cephv1.CephOSDRemoval{Spec: cephv1.OSDRemovalSpec{OSDIDs: []int{0, 1, 1, 5}}}
+
+	// the up osd is not removed and the osd is drained until it is safe to destroy
+	phase, osds, err := removeOSDs(context, clusterInfo, removal)
+	require.NoError(t, err)
+	assert.Equal(t, cephv1.OSDRemovalProgressing, phase)
+	assert.Equal(t, []cephv1.OSDRemovalProgress{
+		{ID: 0, Phase: cephv1.OSDRemovalWaitingForDown, Message: "osd.0 is up, it cannot be removed unless it is down"},
+		{ID: 1, Phase: cephv1.OSDRemovalDraining, Message: "waiting for the data of osd.1 to move to the other osds"},
+		{ID: 5, Phase: cephv1.OSDRemovalRemoved, Message: "osd.5 is not in the osd map"},
+	}, osds)
+	assert.Equal(t, []string{"osd out 1"}, commands)
+
+	// the osd is purged once it is safe to destroy
+	safe = true
+	commands = nil
+	removal.Status = &cephv1.OSDRemovalStatus{OSDs: osds}
+	phase, osds, err = removeOSDs(context, clusterInfo, removal)
+	require.NoError(t, err)
+	assert.Equal(t, cephv1.OSDRemovalProgressing, phase)
+	assert.Equal(t, cephv1.OSDRemovalRemoved, osds[1].Phase)
+	assert.Equal(t, []string{"osd out 1", "osd purge osd.1"}, commands)
+	_, err = clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-1", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// the removed osd is not purged again when its id is reused by a new osd
+	osdDump = `{"osds":[{"osd":0,"up":0,"in":1},{"osd":1,"up":0,"in":1},{"osd":2,"up":0,"in":1}]}`
+	commands = nil
+	removal.Status.OSDs = osds
+	phase, osds, err = removeOSDs(context, clusterInfo, removal)
+	require.NoError(t, err)
+	assert.Equal(t, cephv1.OSDRemovalCompleted, phase)
+	require.Len(t, osds, 3)
+	assert.Equal(t, []string{"osd out 0", "osd purge osd.0"}, commands)
+
+	// the osd is purged when it is not safe to destroy if the removal is forced
+	safe = false
+	commands = nil
+	removal = &cephv1.CephOSDRemoval{Spec: cephv1.OSDRemovalSpec{OSDIDs: []int{2}, ForceOSDRemoval: true}}
+	phase, osds, err = removeOSDs(context, clusterInfo, removal)
+	require.NoError(t, err)
+	assert.Equal(t, cephv1.OSDRemovalCompleted, phase)
+	assert.Equal(t, []cephv1.OSDRemovalProgress{{ID: 2, Phase: cephv1.OSDRemovalRemoved}}, osds)
+	assert.Equal(t, []string{"osd out 2", "osd purge osd.2"}, commands)
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// OSDReplacementsConfigMap records the OSDs replaced because their device is predicted to fail
+	OSDReplacementsConfigMap = "rook-ceph-osd-replacements"
+	osdReplacementsKey       = "replacements"
+	// the default of the devicehealth mgr module to mark out the OSDs on the failing devices
+	defaultLifeExpectancyThreshold = 4 * 7 * 24 * time.Hour
+
+	// OSDReplacementDraining is the phase of an OSD marked out until its data is moved to the other OSDs
+	OSDReplacementDraining = "Draining"
+	// OSDReplacementProvisioning is the phase of a purged OSD until the OSDs are provisioned again
+	OSDReplacementProvisioning = "Provisioning"
+	// OSDReplacementReplaced is the phase of an OSD once a new OSD was provisioned on a spare device
+	OSDReplacementReplaced = "Replaced"
+
+	// OSDReplacementReasonFlapping is the reason of the replacement of a flapping OSD. The OSDs
+	// without a reason are replaced because their device is predicted to fail.
+	OSDReplacementReasonFlapping = "Flapping"
+)
+
+// OSDReplacement is the replacement of an OSD on a device predicted to fail, or of a flapping OSD
+type OSDReplacement struct {
+	OSD int `json:"osd"`
+	// UUID of the replaced OSD, the OSD is not started again from the failing device since it is
+	// left untouched on the node
+	UUID             string      `json:"uuid"`
+	Device           string      `json:"device,omitempty"`
+	DeviceClass      string      `json:"deviceClass,omitempty"`
+	Host             string      `json:"host,omitempty"`
+	PredictedFailure string      `json:"predictedFailure,omitempty"`
+	Reason           string      `json:"reason,omitempty"`
+	Phase            string      `json:"phase"`
+	Time             metav1.Time `json:"time"`
+}
+
+// checkOSDReplacement replaces the OSDs on the devices predicted to fail, one OSD at a time. The
+// OSD is marked out, then its deployment is deleted and the OSD is purged once it is safe to
+// destroy, and the OSDs are provisioned again to create a new OSD on a spare device of the node.
+func (m *OSDHealthMonitor) checkOSDReplacement() error {
+	spec := m.replacement
+	enabled := spec != nil && spec.Enabled
+	// the replacements of the flapping OSDs are completed here too
+	replaceFlapping := m.flapping != nil && m.flapping.Enabled && m.flapping.AutoOut && m.flapping.Replace
+	if !enabled && !replaceFlapping {
+		return nil
+	}
+	cm, replacements, err := loadOSDReplacements(m.context, m.clusterInfo)
+	if err != nil {
+		return err
+	}
+	for i := range replacements {
+		switch replacements[i].Phase {
+		case OSDReplacementDraining:
+			return m.purgeReplacedOSD(cm, replacements, &replacements[i])
+		case OSDReplacementProvisioning:
+			logger.Debugf("waiting for the OSDs to be provisioned to replace osd.%d", replacements[i].OSD)
+			return nil
+		}
+	}
+	if !enabled {
+		return nil
+	}
+
+	threshold := defaultLifeExpectancyThreshold
+	if spec.LifeExpectancyThreshold != nil {
+		threshold = spec.LifeExpectancyThreshold.Duration
+	}
+	devices, err := client.ListDevices(m.context, m.clusterInfo)
+	if err != nil {
+		return err
+	}
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	replaced := sets.New[string]()
+	for _, r := range replacements {
+		replaced.Insert(r.UUID)
+	}
+
+	deadline := time.Now().UTC().Add(threshold)
+	for _, device := range devices {
+		failure, ok := device.PredictedFailure()
+		if !ok || failure.After(deadline) {
+			continue
+		}
+		for _, id := range device.OSDs() {
+			osdID, err := strconv.Atoi(id)
+			if err != nil {
+				continue
+			}
+			uuid := osdUUID(osdDump, osdID)
+			if uuid == "" || replaced.Has(uuid) {
+				continue
+			}
+			replacement := OSDReplacement{OSD: osdID, UUID: uuid, Device: device.ID, PredictedFailure: failure.Format(time.RFC3339), Phase: OSDReplacementDraining, Time: metav1.Now()}
+			if len(device.Location) > 0 {
+				replacement.Host = device.Location[0].Host
+			}
+			started, err := m.startOSDReplacement(cm, replacements, replacement)
+			if err != nil {
+				logger.Warningf("failed to start the replacement of osd.%d. %v", osdID, err)
+				continue
+			}
+			if started {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// startOSDReplacement marks out the OSD on a device predicted to fail, and returns whether the
+// replacement started. The OSDs on PVCs are not replaced.
+func (m *OSDHealthMonitor) startOSDReplacement(cm *v1.ConfigMap, replacements []OSDReplacement, replacement OSDReplacement) (bool, error) {
+	deployment, err := m.context.Clientset.AppsV1().Deployments(m.clusterInfo.Namespace).Get(m.clusterInfo.Context, fmt.Sprintf(osdAppNameFmt, replacement.OSD), metav1.GetOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the deployment of osd.%d", replacement.OSD)
+	}
+	if osdIsOnPVC(deployment) {
+		logger.Debugf("not replacing osd.%d on a PVC, the device of the PVC is predicted to fail", replacement.OSD)
+		return false, nil
+	}
+
+	if replacement.Host == "" {
+		replacement.Host = deployment.Labels[fmt.Sprintf(TopologyLocationLabel, "host")]
+	}
+	// the new OSD is expected on a spare device of the same class
+	replacement.DeviceClass = deployment.Labels[deviceClass]
+	if replacement.Reason == OSDReplacementReasonFlapping {
+		logger.Warningf("osd.%d on host %q is flapping, replacing the osd", replacement.OSD, replacement.Host)
+	} else {
+		logger.Warningf("device %q of osd.%d on host %q is predicted to fail by %s, replacing the osd", replacement.Device, replacement.OSD, replacement.Host, replacement.PredictedFailure)
+	}
+	// the replacement is recorded before the OSD is marked out to find it again after a restart
+	if err := saveOSDReplacements(m.context, m.clusterInfo, cm, append(replacements, replacement)); err != nil {
+		return false, err
+	}
+	if _, err := client.OSDOut(m.context, m.clusterInfo, replacement.OSD); err != nil {
+		// the OSD is marked out again while it is drained
+		logger.Warningf("failed to mark osd.%d out. %v", replacement.OSD, err)
+	}
+	return true, nil
+}
+
+// osdReplacementInProgress returns whether an OSD is being replaced
+func osdReplacementInProgress(replacements []OSDReplacement) bool {
+	for _, r := range replacements {
+		if r.Phase == OSDReplacementDraining || r.Phase == OSDReplacementProvisioning {
+			return true
+		}
+	}
+	return false
+}
+
+// purgeReplacedOSD deletes the deployment of the OSD marked out and purges it once its data is
+// moved to the other OSDs
+func (m *OSDHealthMonitor) purgeReplacedOSD(cm *v1.ConfigMap, replacements []OSDReplacement, replacement *OSDReplacement) error {
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	if osdUUID(osdDump, replacement.OSD) != replacement.UUID {
+		logger.Infof("replaced osd.%d was already removed", replacement.OSD)
+	} else {
+		// the OSD is marked out again in case it was marked in since
+		if _, err := client.OSDOut(m.context, m.clusterInfo, replacement.OSD); err != nil {
+			return errors.Wrapf(err, "failed to mark osd.%d out", replacement.OSD)
+		}
+		safety, err := client.CheckOSDSafety(m.context, m.clusterInfo, client.OSDSafetyCheck{OSDs: []int{replacement.OSD}, Remove: true})
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if osd.%d is safe to remove", replacement.OSD)
+		}
+		if !safety.Safe {
+			logger.Infof("waiting for the data of osd.%d to move to the other osds before replacing it. %s", replacement.OSD, safety)
+			return nil
+		}
+
+		deploymentName := fmt.Sprintf(osdAppNameFmt, replacement.OSD)
+		if err := k8sutil.DeleteDeployment(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, deploymentName); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the deployment of osd.%d", replacement.OSD)
+		}
+		args := []string{"osd", "purge", fmt.Sprintf("osd.%d", replacement.OSD), "--force", "--yes-i-really-mean-it"}
+		if _, err := client.NewCephCommand(m.context, m.clusterInfo, args).Run(); err != nil {
+			return errors.Wrapf(err, "failed to purge osd.%d", replacement.OSD)
+		}
+		logger.Infof("purged replaced osd.%d", replacement.OSD)
+	}
+	logger.Infof("provisioning a new osd on a spare device of host %q to replace osd.%d", replacement.Host, replacement.OSD)
+
+	// the cluster is reconciled to provision the new OSD once the phase is saved
+	replacement.Phase = OSDReplacementProvisioning
+	replacement.Time = metav1.Now()
+	return saveOSDReplacements(m.context, m.clusterInfo, cm, replacements)
+}
+
+// completeOSDReplacements marks the purged OSDs replaced once a new OSD was provisioned on their
+// host. The replaced OSDs are forgotten once the prepare jobs of their host no longer find them on
+// their device.
+func (c *Cluster) completeOSDReplacements() error {
+	cm, replacements, err := loadOSDReplacements(c.context, c.clusterInfo)
+	if err != nil {
+		return err
+	}
+	changed := false
+	kept := []OSDReplacement{}
+	for _, r := range replacements {
+		switch r.Phase {
+		case OSDReplacementProvisioning:
+			provisioned, err := c.osdReplacementProvisioned(r)
+			if err != nil {
+				return errors.Wrapf(err, "failed to check the new osd replacing osd.%d", r.OSD)
+			}
+			if !provisioned {
+				logger.Infof("waiting for a new osd on host %q to replace osd.%d", r.Host, r.OSD)
+				break
+			}
+			logger.Infof("provisioned a new osd on host %q to replace osd.%d", r.Host, r.OSD)
+			r.Phase = OSDReplacementReplaced
+			r.Time = metav1.Now()
+			changed = true
+		case OSDReplacementReplaced:
+			if c.reportedOSDHosts.Has(client.NormalizeCrushName(r.Host)) && !c.reportedReplacedOSDs.Has(r.UUID) {
+				logger.Infof("replaced osd.%d with UUID %q is no longer found on host %q", r.OSD, r.UUID, r.Host)
+				changed = true
+				continue
+			}
+		}
+		kept = append(kept, r)
+	}
+	if !changed {
+		return nil
+	}
+	return saveOSDReplacements(c.context, c.clusterInfo, cm, kept)
+}
+
+// osdReplacementProvisioned returns whether a new OSD with the device class of the replaced OSD was
+// created on its host after the replaced OSD was purged
+func (c *Cluster) osdReplacementProvisioned(replacement OSDReplacement) (bool, error) {
+	selector := fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)
+	if replacement.Host != "" {
+		selector += fmt.Sprintf(",%s=%s", fmt.Sprintf(TopologyLocationLabel, "host"), client.NormalizeCrushName(replacement.Host))
+	}
+	if replacement.DeviceClass != "" {
+		selector += fmt.Sprintf(",%s=%s", deviceClass, replacement.DeviceClass)
+	}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.clusterInfo.Namespace).List(c.clusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list the osd deployments on host %q", replacement.Host)
+	}
+	if len(deployments.Items) == 0 {
+		return false, nil
+	}
+	osdDump, err := client.GetOSDDump(c.context, c.clusterInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get osd dump")
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if d.CreationTimestamp.Before(&replacement.Time) {
+			continue
+		}
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			logger.Warningf("failed to get the id of osd deployment %q. %v", d.Name, err)
+			continue
+		}
+		// the new OSD may reuse the ID of the purged OSD
+		if uuid := osdUUID(osdDump, osdID); uuid != "" && uuid != replacement.UUID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reportOSD records an OSD found on its device by a prepare job
+func (c *Cluster) reportOSD(osd OSDInfo) {
+	if c.reportedOSDHosts == nil {
+		c.reportedOSDHosts = sets.New[string]()
+		c.reportedReplacedOSDs = sets.New[string]()
+	}
+	if host := locationHost(osd.Location); host != "" {
+		c.reportedOSDHosts.Insert(host)
+	}
+	if c.replacedOSDs.Has(osd.UUID) {
+		c.reportedReplacedOSDs.Insert(osd.UUID)
+	}
+}
+
+// locationHost returns the host of a crush location
+func locationHost(location string) string {
+	return getOSDTopologyLocationLabels(location)[fmt.Sprintf(TopologyLocationLabel, "host")]
+}
+
+// replacedOSDUUIDs returns the UUIDs of the OSDs replaced because their device is predicted to fail
+func (c *Cluster) replacedOSDUUIDs() (sets.Set[string], error) {
+	return GetReplacedOSDUUIDs(c.context, c.clusterInfo)
+}
+
+// GetReplacedOSDUUIDs returns the UUIDs of the replaced OSDs, which are left untouched on their
+// device
+func GetReplacedOSDUUIDs(context *clusterd.Context, clusterInfo *client.ClusterInfo) (sets.Set[string], error) {
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, OSDReplacementsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return sets.New[string](), nil
+		}
+		return nil, errors.Wrapf(err, "failed to get configmap %q", OSDReplacementsConfigMap)
+	}
+	replacements, err := parseOSDReplacements(cm)
+	if err != nil {
+		return nil, err
+	}
+	uuids := sets.New[string]()
+	for _, r := range replacements {
+		uuids.Insert(r.UUID)
+	}
+	return uuids, nil
+}
+
+// OSDReplacementProvisioningStarted returns whether a replaced OSD started waiting for the OSDs to
+// be provisioned between the two versions of the replacements configmap
+func OSDReplacementProvisioningStarted(oldCM, newCM *v1.ConfigMap) bool {
+	provisioning := func(cm *v1.ConfigMap) sets.Set[string] {
+		uuids := sets.New[string]()
+		replacements, err := parseOSDReplacements(cm)
+		if err != nil {
+			logger.Debugf("failed to parse the osd replacements. %v", err)
+			return uuids
+		}
+		for _, r := range replacements {
+			if r.Phase == OSDReplacementProvisioning {
+				uuids.Insert(r.UUID)
+			}
+		}
+		return uuids
+	}
+	return provisioning(newCM).Difference(provisioning(oldCM)).Len() > 0
+}
+
+func loadOSDReplacements(context *clusterd.Context, clusterInfo *client.ClusterInfo) (*v1.ConfigMap, []OSDReplacement, error) {
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, OSDReplacementsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, nil, errors.Wrapf(err, "failed to get configmap %q", OSDReplacementsConfigMap)
+		}
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: OSDReplacementsConfigMap, Namespace: clusterInfo.Namespace}}
+		if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to set owner reference on configmap %q", OSDReplacementsConfigMap)
+		}
+	}
+	replacements, err := parseOSDReplacements(cm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cm, replacements, nil
+}
+
+func parseOSDReplacements(cm *v1.ConfigMap) ([]OSDReplacement, error) {
+	replacements := []OSDReplacement{}
+	if cm == nil || cm.Data[osdReplacementsKey] == "" {
+		return replacements, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[osdReplacementsKey]), &replacements); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the osd replacements in configmap %q", OSDReplacementsConfigMap)
+	}
+	return replacements, nil
+}
+
+func saveOSDReplacements(context *clusterd.Context, clusterInfo *client.ClusterInfo, cm *v1.ConfigMap, replacements []OSDReplacement) error {
+	raw, err := json.Marshal(replacements)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the osd replacements")
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[osdReplacementsKey] = string(raw)
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", OSDReplacementsConfigMap)
+	}
+	return nil
+}
+
+// osdUUID returns the UUID of the OSD in the osd dump, or an empty string if the OSD is not found
+func osdUUID(osdDump *client.OSDDump, osdID int) string {
+	for _, osd := range osdDump.OSDs {
+		if id, err := osd.OSD.Int64(); err == nil && int(id) == osdID {
+			return osd.UUID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckOSDReplacement(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	for _, d := range []*appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-0", Namespace: clusterInfo.Namespace, Labels: map[string]string{deviceClass: "hdd"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-1", Namespace: clusterInfo.Namespace, Labels: map[string]string{OSDOverPVCLabelKey: "set1-data-0"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-osd-2", Namespace: clusterInfo.Namespace}},
+	} {
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	soon := time.Now().UTC().Add(7 * 24 * time.Hour).Format("2006-01-02 15:04:05.000000")
+	later := time.Now().UTC().Add(10 * 7 * 24 * time.Hour).Format("2006-01-02 15:04:05.000000")
+	safe := false
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "device" && args[1] == "ls":
+				// osd.0 and osd.1 on a PVC are predicted to fail within the threshold, osd.2 later
+				return fmt.Sprintf(`[
+					{"devid":"disk0","location":[{"host":"node1","dev":"sdb"}],"daemons":["osd.0"],"life_expectancy_max":%q},
+					{"devid":"disk1","location":[{"host":"node1","dev":"sdc"}],"daemons":["osd.1"],"life_expectancy_max":%q},
+					{"devid":"disk2","location":[{"host":"node2","dev":"sdb"}],"daemons":["osd.2"],"life_expectancy_max":%q},
+					{"devid":"disk3","location":[{"host":"node2","dev":"sdc"}],"daemons":["osd.3"]}]`, soon, soon, later), nil
+			case args[0] == "osd" && args[1] == "dump":
+				return `{"osds":[{"osd":0,"up":1,"in":1,"uuid":"uuid0"},{"osd":1,"up":1,"in":1,"uuid":"uuid1"},{"osd":2,"up":1,"in":1,"uuid":"uuid2"},{"osd":3,"up":1,"in":1,"uuid":"uuid3"},{"osd":4,"up":1,"in":1,"uuid":"uuid4"},{"osd":5,"up":1,"in":1,"uuid":"uuid5"}]}`, nil
+			case args[0] == "status":
+				return `{"pgmap":{"num_pgs":0}}`, nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				return "", nil
+			case args[0] == "osd" && args[1] == "safe-to-destroy":
+				if safe {
+					return `{"safe_to_destroy":[0]}`, nil
+				}
+				return `{"safe_to_destroy":[]}`, nil
+			case args[0] == "osd" && (args[1] == "out" || args[1] == "purge"):
+				commands = append(commands, strings.Join(args[:3], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	clusterdContext := &clusterd.Context{Clientset: clientset, Executor: executor}
+	m := &OSDHealthMonitor{context: clusterdContext, clusterInfo: clusterInfo, replacement: &cephv1.OSDReplacementSpec{}}
+	replacements := func() []OSDReplacement {
+		_, replacements, err := loadOSDReplacements(clusterdContext, clusterInfo)
+		require.NoError(t, err)
+		return replacements
+	}
+
+	// disabled
+	require.NoError(t, m.checkOSDReplacement())
+	assert.Empty(t, replacements())
+
+	// the osd on the node is marked out
+	m.replacement.Enabled = true
+	require.NoError(t, m.checkOSDReplacement())
+	require.Len(t, replacements(), 1)
+	assert.Equal(t, 0, replacements()[0].OSD)
+	assert.Equal(t, "uuid0", replacements()[0].UUID)
+	assert.Equal(t, "node1", replacements()[0].Host)
+	assert.Equal(t, "hdd", replacements()[0].DeviceClass)
+	assert.Equal(t, OSDReplacementDraining, replacements()[0].Phase)
+	assert.Equal(t, []string{"osd out 0"}, commands)
+
+	// the osd is purged once it is safe to destroy
+	require.NoError(t, m.checkOSDReplacement())
+	assert.Equal(t, OSDReplacementDraining, replacements()[0].Phase)
+	safe = true
+	require.NoError(t, m.checkOSDReplacement())
+	assert.Equal(t, OSDReplacementProvisioning, replacements()[0].Phase)
+	assert.Equal(t, []string{"osd out 0", "osd out 0", "osd out 0", "osd purge osd.0"}, commands)
+	_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-0", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// no other osd is replaced until the osds are provisioned
+	require.NoError(t, m.checkOSDReplacement())
+	assert.Len(t, commands, 4)
+
+	// the replacement completes once a new osd of the same device class runs on the host
+	c := &Cluster{context: clusterdContext, clusterInfo: clusterInfo}
+	newOSD := func(id int, class string) {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:              fmt.Sprintf(osdAppNameFmt, id),
+			Namespace:         clusterInfo.Namespace,
+			CreationTimestamp: metav1.Now(),
+			Labels:            map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: strconv.Itoa(id), fmt.Sprintf(TopologyLocationLabel, "host"): "node1", deviceClass: class},
+		}}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.completeOSDReplacements())
+	assert.Equal(t, OSDReplacementProvisioning, replacements()[0].Phase)
+	newOSD(5, "ssd")
+	require.NoError(t, c.completeOSDReplacements())
+	assert.Equal(t, OSDReplacementProvisioning, replacements()[0].Phase)
+	newOSD(4, "hdd")
+	require.NoError(t, c.completeOSDReplacements())
+	assert.Equal(t, OSDReplacementReplaced, replacements()[0].Phase)
+	uuids, err := c.replacedOSDUUIDs()
+	require.NoError(t, err)
+	assert.True(t, uuids.Has("uuid0"))
+
+	// the replaced osd, the osd on a PVC and the osd predicted to fail later are not replaced
+	require.NoError(t, m.checkOSDReplacement())
+	assert.Len(t, replacements(), 1)
+	assert.Len(t, commands, 4)
+
+	// a longer threshold replaces the osd predicted to fail later
+	m.replacement.LifeExpectancyThreshold = &metav1.Duration{Duration: 12 * 7 * 24 * time.Hour}
+	require.NoError(t, m.checkOSDReplacement())
+	require.Len(t, replacements(), 2)
+	assert.Equal(t, 2, replacements()[1].OSD)
+
+	// the replaced osd is forgotten once the prepare job of its host no longer finds it
+	c.replacedOSDs, err = c.replacedOSDUUIDs()
+	require.NoError(t, err)
+	c.reportOSD(OSDInfo{ID: 0, UUID: "uuid0", Location: "root=default host=node1"})
+	c.reportOSD(OSDInfo{ID: 4, UUID: "uuid4", Location: "root=default host=node1"})
+	require.NoError(t, c.completeOSDReplacements())
+	require.Len(t, replacements(), 2)
+	c.reportedOSDHosts, c.reportedReplacedOSDs = nil, nil
+	c.reportOSD(OSDInfo{ID: 4, UUID: "uuid4", Location: "root=default host=node1"})
+	require.NoError(t, c.completeOSDReplacements())
+	require.Len(t, replacements(), 1)
+	assert.Equal(t, 2, replacements()[0].OSD)
+}
+
+func TestOSDReplacementProvisioningStarted(t *testing.T) {
+	cm := func(phase string) *v1.ConfigMap {
+		return &v1.ConfigMap{Data: map[string]string{osdReplacementsKey: fmt.Sprintf(`[{"osd":0,"uuid":"uuid0","phase":%q}]`, phase)}}
+	}
+	assert.True(t, OSDReplacementProvisioningStarted(cm(OSDReplacementDraining), cm(OSDReplacementProvisioning)))
+	assert.False(t, OSDReplacementProvisioningStarted(cm(OSDReplacementProvisioning), cm(OSDReplacementProvisioning)))
+	assert.False(t, OSDReplacementProvisioningStarted(cm(OSDReplacementProvisioning), cm(OSDReplacementReplaced)))
+	assert.False(t, OSDReplacementProvisioningStarted(&v1.ConfigMap{}, cm(OSDReplacementDraining)))
+}
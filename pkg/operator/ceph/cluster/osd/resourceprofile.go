@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	v1 "k8s.io/api/core/v1"
+)
+
+// osdResources returns the resources of the OSDs of the device class, from the resource profile of
+// the device class in the storage spec, or else from the resources of the cluster
+func (c *Cluster) osdResources(deviceClass string) v1.ResourceRequirements {
+	if profile, ok := c.spec.Storage.DeviceClassResources[deviceClass]; ok && deviceClass != "" {
+		return profile.Resources
+	}
+	return cephv1.GetOSDResources(c.spec.Resources, deviceClass)
+}
+
+// deviceClassMemoryTarget returns the memory target of the resource profile of the device class in
+// bytes, or an empty string if the profile has none
+func (c *Cluster) deviceClassMemoryTarget(deviceClass string) string {
+	profile, ok := c.spec.Storage.DeviceClassResources[deviceClass]
+	if !ok || deviceClass == "" || profile.MemoryTarget == nil {
+		return ""
+	}
+	return strconv.FormatInt(profile.MemoryTarget.Value(), 10)
+}
+
+// deviceClassConfigSection returns the section of the ceph config applied to the OSDs of the device
+// class
+func deviceClassConfigSection(deviceClass string) string {
+	return fmt.Sprintf("osd/class:%s", deviceClass)
+}
+
+// applyDeviceClassMemoryTargets sets the memory targets of the resource profiles in the ceph config
+// of their device class. Like the settings of the cephConfig of the cluster, a memory target removed
+// from the profiles is left in the ceph config.
+func (c *Cluster) applyDeviceClassMemoryTargets() error {
+	monStore := opconfig.GetMonStore(c.context, c.clusterInfo)
+	for deviceClass := range c.spec.Storage.DeviceClassResources {
+		target := c.deviceClassMemoryTarget(deviceClass)
+		if target == "" {
+			continue
+		}
+		if err := monStore.Set(deviceClassConfigSection(deviceClass), osdMemoryTargetOption, target); err != nil {
+			return errors.Wrapf(err, "failed to set the memory target of device class %q", deviceClass)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func memoryLimitResources(limit string) v1.ResourceRequirements {
+	return v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse(limit)}}
+}
+
+func TestOSDResources(t *testing.T) {
+	target := resource.MustParse("8Gi")
+	c := &Cluster{spec: cephv1.ClusterSpec{
+		Resources: cephv1.ResourceSpec{
+			"osd":     memoryLimitResources("4Gi"),
+			"osd-ssd": memoryLimitResources("6Gi"),
+			"osd-hdd": memoryLimitResources("5Gi"),
+		},
+		Storage: cephv1.StorageScopeSpec{
+			Nodes: []cephv1.Node{{Name: "node1", Resources: memoryLimitResources("16Gi")}, {Name: "node2"}},
+			DeviceClassResources: map[string]cephv1.OSDResourceProfile{
+				"hdd":  {Resources: memoryLimitResources("3Gi")},
+				"nvme": {Resources: memoryLimitResources("12Gi"), MemoryTarget: &target},
+			},
+		},
+	}}
+	c.ValidStorage = *c.spec.Storage.DeepCopy()
+
+	// the profile of the device class takes precedence over the resources of the cluster
+	assert.Equal(t, "3Gi", c.osdResources("hdd").Limits.Memory().String())
+	assert.Equal(t, "12Gi", c.osdResources("nvme").Limits.Memory().String())
+	assert.Equal(t, "6Gi", c.osdResources("ssd").Limits.Memory().String())
+	assert.Equal(t, "4Gi", c.osdResources("").Limits.Memory().String())
+
+	// the resources of the node take precedence over the profile
+	props, err := c.getOSDPropsForNode("node1", "nvme")
+	require.NoError(t, err)
+	assert.Equal(t, "16Gi", props.resources.Limits.Memory().String())
+	props, err = c.getOSDPropsForNode("node2", "nvme")
+	require.NoError(t, err)
+	assert.Equal(t, "12Gi", props.resources.Limits.Memory().String())
+
+	assert.Equal(t, "8589934592", c.deviceClassMemoryTarget("nvme"))
+	assert.Equal(t, "", c.deviceClassMemoryTarget("hdd"))
+	assert.Equal(t, "", c.deviceClassMemoryTarget("ssd"))
+}
+
+func TestApplyDeviceClassMemoryTargets(t *testing.T) {
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "config" && args[1] == "set" {
+				commands = append(commands, strings.Join(args[:5], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	target := resource.MustParse("8Gi")
+	c := &Cluster{
+		context:     &clusterd.Context{Executor: executor},
+		clusterInfo: client.AdminTestClusterInfo("fake"),
+		spec: cephv1.ClusterSpec{Storage: cephv1.StorageScopeSpec{
+			DeviceClassResources: map[string]cephv1.OSDResourceProfile{
+				"hdd":  {Resources: memoryLimitResources("3Gi")},
+				"nvme": {Resources: memoryLimitResources("12Gi"), MemoryTarget: &target},
+			},
+		}},
+	}
+	require.NoError(t, c.applyDeviceClassMemoryTargets())
+	assert.Equal(t, []string{"config set osd/class:nvme osd_memory_target 8589934592"}, commands)
+}
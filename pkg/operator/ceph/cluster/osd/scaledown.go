@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// UpdateScaleDown updates the storageClassDeviceSets of the spec whose OSDs above the count are
+// removed
+func (m *OSDHealthMonitor) UpdateScaleDown(deviceSets []cephv1.StorageClassDeviceSet) {
+	m.deviceSets = deviceSets
+}
+
+// checkOSDScaleDown removes the OSDs of the indexes above the count of the storageClassDeviceSets
+// with scaleDownOSDs. The OSDs are reweighted to 0 so their data migrates to the other OSDs, then
+// they are purged and their PVCs are deleted once they are safe to destroy. The progress is
+// reported with the OSDScaleDown condition.
+func (m *OSDHealthMonitor) checkOSDScaleDown() error {
+	existingPVCs, indexesPerDeviceSet, err := GetExistingPVCs(m.clusterInfo.Context, m.context, m.clusterInfo.Namespace)
+	if err != nil {
+		return err
+	}
+	var usage *client.OSDUsage
+	var deployments map[string]*appsv1.Deployment
+	pending := []string{}
+	for _, deviceSet := range m.deviceSets {
+		if !deviceSet.ScaleDownOSDs {
+			continue
+		}
+		indexes := scaleDownIndexes(indexesPerDeviceSet[deviceSet.Name].UnsortedList(), deviceSet.Count)
+		if len(indexes) == 0 {
+			continue
+		}
+
+		// the osd usage and deployments are only loaded when there are osds to remove
+		if usage == nil {
+			usage, err = client.GetOSDUsage(m.context, m.clusterInfo)
+			if err != nil {
+				return errors.Wrap(err, "failed to get the osd usage")
+			}
+			deployments, err = m.osdDeploymentsByPVC()
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, index := range indexes {
+			pvcs := deviceSetIndexPVCs(existingPVCs, deviceSet.Name, index)
+			msg, err := m.scaleDownOSD(deviceSet.Name, pvcs, deployments, usage)
+			if err != nil {
+				return errors.Wrapf(err, "failed to remove the osd of index %d of device set %q", index, deviceSet.Name)
+			}
+			if msg != "" {
+				pending = append(pending, msg)
+			}
+		}
+	}
+	m.reportOSDScaleDown(pending)
+	return nil
+}
+
+// scaleDownOSD reweights the OSD of the PVCs of a device set index to 0, and purges it and deletes
+// the PVCs once it is safe to destroy. It returns the progress of the removal until the PVCs are
+// deleted.
+func (m *OSDHealthMonitor) scaleDownOSD(deviceSetName string, pvcs []*v1.PersistentVolumeClaim, deployments map[string]*appsv1.Deployment, usage *client.OSDUsage) (string, error) {
+	var deployment *appsv1.Deployment
+	for _, pvc := range pvcs {
+		if d, ok := deployments[pvc.Name]; ok {
+			deployment = d
+			break
+		}
+	}
+	if deployment != nil {
+		osdID, err := GetOSDID(deployment)
+		if err != nil {
+			return "", err
+		}
+		if node := osdNodeUsage(usage, osdID); node != nil {
+			weight, err := node.CrushWeight.Float64()
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to parse the crush weight %q of osd.%d", node.CrushWeight, osdID)
+			}
+			if weight != 0 {
+				logger.Infof("reweighting osd.%d of device set %q to 0 to remove it", osdID, deviceSetName)
+				args := []string{"osd", "crush", "reweight", fmt.Sprintf("osd.%d", osdID), "0"}
+				if _, err := client.NewCephCommand(m.context, m.clusterInfo, args).Run(); err != nil {
+					return "", errors.Wrapf(err, "failed to reweight osd.%d to 0", osdID)
+				}
+			}
+			safety, err := client.CheckOSDSafety(m.context, m.clusterInfo, client.OSDSafetyCheck{OSDs: []int{osdID}, Remove: true})
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to check if osd.%d is safe to remove", osdID)
+			}
+			if !safety.Safe {
+				msg := fmt.Sprintf("osd.%d of device set %q has %s pgs left to migrate", osdID, deviceSetName, node.Pgs)
+				logger.Infof("waiting for the data to migrate before removing the osd, %s. %s", msg, safety)
+				return msg, nil
+			}
+
+			deploymentName := fmt.Sprintf(osdAppNameFmt, osdID)
+			if err := k8sutil.DeleteDeployment(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, deploymentName); err != nil && !kerrors.IsNotFound(err) {
+				return "", errors.Wrapf(err, "failed to delete the deployment of osd.%d", osdID)
+			}
+			args := []string{"osd", "purge", fmt.Sprintf("osd.%d", osdID), "--force", "--yes-i-really-mean-it"}
+			if _, err := client.NewCephCommand(m.context, m.clusterInfo, args).Run(); err != nil {
+				return "", errors.Wrapf(err, "failed to purge osd.%d", osdID)
+			}
+			logger.Infof("purged osd.%d of device set %q", osdID, deviceSetName)
+		} else {
+			// the osd was already purged, only its deployment is left
+			if err := k8sutil.DeleteDeployment(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, deployment.Name); err != nil && !kerrors.IsNotFound(err) {
+				return "", errors.Wrapf(err, "failed to delete the deployment of osd.%d", osdID)
+			}
+		}
+	} else {
+		// the deployment may only be gone for a while, for example while the osd is rebuilt, so the
+		// pvcs are kept until no osd may still store data on them
+		osdIDs, err := m.osdsWithoutDeployment(pvcs)
+		if err != nil {
+			return "", err
+		}
+		if len(osdIDs) > 0 {
+			msg := fmt.Sprintf("osds %v without a deployment may still be on the pvcs of device set %q", osdIDs, deviceSetName)
+			logger.Warningf("not deleting the pvcs until the osds are removed from the cluster, %s", msg)
+			return msg, nil
+		}
+	}
+
+	for _, pvc := range pvcs {
+		if err := m.deletePrepareJobs(pvc.Name); err != nil {
+			return "", err
+		}
+		logger.Infof("deleting pvc %q of device set %q", pvc.Name, deviceSetName)
+		if err := m.context.Clientset.CoreV1().PersistentVolumeClaims(m.clusterInfo.Namespace).Delete(m.clusterInfo.Context, pvc.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "failed to delete pvc %q", pvc.Name)
+		}
+	}
+	return "", nil
+}
+
+// osdsWithoutDeployment returns the osds of the cluster that may be on the PVCs of a device set
+// index without a deployment. The osds are resolved from the prepare status of the PVCs. When the
+// status is not found, any osd of the cluster without a deployment may be on the PVCs.
+func (m *OSDHealthMonitor) osdsWithoutDeployment(pvcs []*v1.PersistentVolumeClaim) ([]int, error) {
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the osd dump")
+	}
+	inCluster := sets.New[int]()
+	for _, osd := range osdDump.OSDs {
+		id, err := osd.OSD.Int64()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the id of osd %q", osd.OSD)
+		}
+		inCluster.Insert(int(id))
+	}
+
+	statusFound := false
+	osdIDs := sets.New[int]()
+	for _, pvc := range pvcs {
+		cm, err := m.context.Clientset.CoreV1().ConfigMaps(m.clusterInfo.Namespace).Get(m.clusterInfo.Context, statusConfigMapName(pvc.Name), metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get the prepare status of pvc %q", pvc.Name)
+		}
+		status := parseOrchestrationStatus(cm.Data)
+		if status == nil {
+			continue
+		}
+		statusFound = true
+		for _, osd := range status.OSDs {
+			if inCluster.Has(osd.ID) {
+				osdIDs.Insert(osd.ID)
+			}
+		}
+	}
+	if statusFound {
+		return sets.List(osdIDs), nil
+	}
+
+	deployments, err := k8sutil.GetDeployments(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the osd deployments")
+	}
+	for i := range deployments.Items {
+		id, err := GetOSDID(&deployments.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		inCluster.Delete(id)
+	}
+	return sets.List(inCluster), nil
+}
+
+func (m *OSDHealthMonitor) deletePrepareJobs(pvcName string) error {
+	selector := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", OSDOverPVCLabelKey, pvcName)}
+	jobs, err := m.context.Clientset.BatchV1().Jobs(m.clusterInfo.Namespace).List(m.clusterInfo.Context, selector)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the osd prepare jobs of pvc %q", pvcName)
+	}
+	for _, job := range jobs.Items {
+		if err := k8sutil.DeleteBatchJob(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, job.Name, false); err != nil {
+			return errors.Wrapf(err, "failed to delete the osd prepare job %q", job.Name)
+		}
+	}
+	return nil
+}
+
+// reportOSDScaleDown sets the OSDScaleDown condition while OSDs are removed, and clears it once
+// they are all removed
+func (m *OSDHealthMonitor) reportOSDScaleDown(pending []string) {
+	status := v1.ConditionTrue
+	reason := cephv1.OSDScaleDownProgressingReason
+	message := fmt.Sprintf("removing the osds above the count of the device sets: %s", strings.Join(pending, "; "))
+	if len(pending) == 0 {
+		if m.scaleDownMessage == "" {
+			// no osd was removed since the operator started
+			return
+		}
+		status = v1.ConditionFalse
+		reason = cephv1.OSDScaleDownCompletedReason
+		message = "the osds above the count of the device sets were removed"
+	}
+	if message == m.scaleDownMessage {
+		return
+	}
+	m.scaleDownMessage = message
+	updateConditionFunc(m.clusterInfo.Context, m.context, m.clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionOSDScaleDown, status, reason, message)
+}
+
+// scaleDownIndexes returns the indexes of a device set above its count, which are the highest ones
+func scaleDownIndexes(existing []string, count int) []int {
+	indexes := []int{}
+	for _, index := range existing {
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			logger.Warningf("not scaling down invalid pvc index %q", index)
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	if len(indexes) <= count {
+		return nil
+	}
+	sort.Ints(indexes)
+	return indexes[count:]
+}
+
+func deviceSetIndexPVCs(existingPVCs map[string]*v1.PersistentVolumeClaim, deviceSetName string, index int) []*v1.PersistentVolumeClaim {
+	pvcs := []*v1.PersistentVolumeClaim{}
+	for _, pvc := range existingPVCs {
+		if pvc.Labels[CephDeviceSetLabelKey] == deviceSetName && pvc.Labels[CephSetIndexLabelKey] == strconv.Itoa(index) {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	sort.Slice(pvcs, func(i, j int) bool { return pvcs[i].Name < pvcs[j].Name })
+	return pvcs
+}
+
+// osdDeploymentsByPVC returns the deployments of the OSDs on PVCs by name of their data PVC
+func (m *OSDHealthMonitor) osdDeploymentsByPVC() (map[string]*appsv1.Deployment, error) {
+	deployments, err := k8sutil.GetDeployments(m.clusterInfo.Context, m.context.Clientset, m.clusterInfo.Namespace, OSDOverPVCLabelKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the osd deployments on pvcs")
+	}
+	result := map[string]*appsv1.Deployment{}
+	for i, d := range deployments.Items {
+		result[d.Labels[OSDOverPVCLabelKey]] = &deployments.Items[i]
+	}
+	return result, nil
+}
+
+func osdNodeUsage(usage *client.OSDUsage, osdID int) *client.OSDNodeUsage {
+	for i := range usage.OSDNodes {
+		if usage.OSDNodes[i].ID == osdID {
+			return &usage.OSDNodes[i]
+		}
+	}
+	return nil
+}
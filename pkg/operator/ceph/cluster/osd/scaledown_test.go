@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckOSDScaleDown(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	newPVC := func(template string, index int) string {
+		name := fmt.Sprintf("set1-%s-%d", template, index)
+		pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: clusterInfo.Namespace,
+			Labels:    makeStorageClassDeviceSetPVCLabel("set1", name, index, "", ""),
+		}}
+		_, err := clientset.CoreV1().PersistentVolumeClaims(clusterInfo.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
+		require.NoError(t, err)
+		return name
+	}
+	for osdID := 0; osdID < 3; osdID++ {
+		pvcName := newPVC("data", osdID)
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(osdAppNameFmt, osdID),
+			Namespace: clusterInfo.Namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", osdID), OSDOverPVCLabelKey: pvcName},
+		}}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	newPVC("metadata", 2)
+
+	weights := map[string]string{"0": "0.5", "1": "0.5", "2": "0.5"}
+	safe := false
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "df":
+				nodes := []string{}
+				for _, osdID := range []string{"0", "1", "2"} {
+					if weight, ok := weights[osdID]; ok {
+						nodes = append(nodes, fmt.Sprintf(`{"id":%s,"crush_weight":%s,"pgs":10}`, osdID, weight))
+					}
+				}
+				return fmt.Sprintf(`{"nodes":[%s]}`, strings.Join(nodes, ",")), nil
+			case args[0] == "osd" && args[1] == "crush" && args[2] == "reweight":
+				commands = append(commands, strings.Join(args[:5], " "))
+				weights[strings.TrimPrefix(args[3], "osd.")] = args[4]
+				return "", nil
+			case args[0] == "status":
+				return `{"pgmap":{"num_pgs":0}}`, nil
+			case args[0] == "osd" && args[1] == "ok-to-stop":
+				return "", nil
+			case args[0] == "osd" && args[1] == "safe-to-destroy":
+				if safe {
+					return fmt.Sprintf(`{"safe_to_destroy":[%s]}`, args[2]), nil
+				}
+				return `{"safe_to_destroy":[]}`, nil
+			case args[0] == "osd" && args[1] == "purge":
+				commands = append(commands, strings.Join(args[:3], " "))
+				delete(weights, strings.TrimPrefix(args[2], "osd."))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	m := &OSDHealthMonitor{context: &clusterd.Context{Clientset: clientset, Executor: executor}, clusterInfo: clusterInfo}
+
+	var conditions []string
+	originalUpdateCondition := updateConditionFunc
+	t.Cleanup(func() { updateConditionFunc = originalUpdateCondition })
+	updateConditionFunc = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status v1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+		assert.Equal(t, cephv1.ConditionOSDScaleDown, conditionType)
+		conditions = append(conditions, fmt.Sprintf("%s: %s", status, message))
+	}
+	pvcs := func() []string {
+		list, err := clientset.CoreV1().PersistentVolumeClaims(clusterInfo.Namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		names := []string{}
+		for _, pvc := range list.Items {
+			names = append(names, pvc.Name)
+		}
+		return names
+	}
+	deviceSet := cephv1.StorageClassDeviceSet{Name: "set1", Count: 1}
+
+	// the osds above the count are not removed without scaleDownOSDs
+	m.UpdateScaleDown([]cephv1.StorageClassDeviceSet{deviceSet})
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.Empty(t, commands)
+	assert.Empty(t, conditions)
+
+	// the osds of the highest indexes are reweighted to 0 until their data migrated
+	deviceSet.ScaleDownOSDs = true
+	m.UpdateScaleDown([]cephv1.StorageClassDeviceSet{deviceSet})
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.Equal(t, []string{"osd crush reweight osd.1 0", "osd crush reweight osd.2 0"}, commands)
+	assert.Equal(t, []string{`True: removing the osds above the count of the device sets: osd.1 of device set "set1" has 10 pgs left to migrate; osd.2 of device set "set1" has 10 pgs left to migrate`}, conditions)
+	assert.Len(t, pvcs(), 4)
+
+	// the osds are not reweighted again and the condition is not updated while the data migrates
+	commands = nil
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.Empty(t, commands)
+	assert.Len(t, conditions, 1)
+
+	// the osds are purged with their pvcs once they are safe to destroy
+	safe = true
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.Equal(t, []string{"osd purge osd.1", "osd purge osd.2"}, commands)
+	assert.Equal(t, []string{"set1-data-0"}, pvcs())
+	_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-0", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = clientset.AppsV1().Deployments(clusterInfo.Namespace).Get(ctx, "rook-ceph-osd-1", metav1.GetOptions{})
+	assert.Error(t, err)
+	require.Len(t, conditions, 2)
+	assert.Equal(t, "False: the osds above the count of the device sets were removed", conditions[1])
+
+	// nothing is left to remove
+	commands = nil
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.Empty(t, commands)
+	assert.Len(t, conditions, 2)
+}
+
+func TestScaleDownOSDWithoutDeployment(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	clientset := fake.NewSimpleClientset()
+	for index := 0; index < 2; index++ {
+		name := fmt.Sprintf("set1-data-%d", index)
+		pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: clusterInfo.Namespace,
+			Labels:    makeStorageClassDeviceSetPVCLabel("set1", name, index, "", ""),
+		}}
+		_, err := clientset.CoreV1().PersistentVolumeClaims(clusterInfo.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	// the deployment of osd.1 on the pvc of index 1 is gone, for example while the osd is rebuilt
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:      "rook-ceph-osd-0",
+		Namespace: clusterInfo.Namespace,
+		Labels:    map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: "0", OSDOverPVCLabelKey: "set1-data-0"},
+	}}
+	_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	osdsInCluster := []string{"0", "1"}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "df":
+				return `{"nodes":[{"id":0,"crush_weight":0.5,"pgs":10}]}`, nil
+			case args[0] == "osd" && args[1] == "dump":
+				osds := []string{}
+				for _, id := range osdsInCluster {
+					osds = append(osds, fmt.Sprintf(`{"osd":%s,"up":0,"in":0}`, id))
+				}
+				return fmt.Sprintf(`{"osds":[%s]}`, strings.Join(osds, ",")), nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	m := &OSDHealthMonitor{context: &clusterd.Context{Clientset: clientset, Executor: executor}, clusterInfo: clusterInfo}
+	m.UpdateScaleDown([]cephv1.StorageClassDeviceSet{{Name: "set1", Count: 1, ScaleDownOSDs: true}})
+
+	var conditions []string
+	originalUpdateCondition := updateConditionFunc
+	t.Cleanup(func() { updateConditionFunc = originalUpdateCondition })
+	updateConditionFunc = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status v1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+		conditions = append(conditions, fmt.Sprintf("%s: %s", status, message))
+	}
+	pvcExists := func() bool {
+		_, err := clientset.CoreV1().PersistentVolumeClaims(clusterInfo.Namespace).Get(ctx, "set1-data-1", metav1.GetOptions{})
+		return err == nil
+	}
+
+	// without the prepare status, the osd without a deployment may be on the pvc
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.True(t, pvcExists())
+	assert.Equal(t, []string{`True: removing the osds above the count of the device sets: osds [1] without a deployment may still be on the pvcs of device set "set1"`}, conditions)
+
+	// the prepare status of the pvc reports the osd
+	status := OrchestrationStatus{Status: OrchestrationStatusCompleted, PvcBackedOSD: true, OSDs: []OSDInfo{{ID: 1}}}
+	data, err := json.Marshal(status)
+	require.NoError(t, err)
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: statusConfigMapName("set1-data-1"), Namespace: clusterInfo.Namespace},
+		Data:       map[string]string{orchestrationStatusKey: string(data)},
+	}
+	_, err = clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.True(t, pvcExists())
+
+	// another osd without a deployment is not on the pvc
+	osdsInCluster = []string{"0", "2"}
+	require.NoError(t, m.checkOSDScaleDown())
+	assert.False(t, pvcExists())
+	assert.Equal(t, "False: the osds above the count of the device sets were removed", conditions[len(conditions)-1])
+}
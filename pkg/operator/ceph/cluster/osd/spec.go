@@ -291,6 +291,33 @@ var defaultTuneSlowSettings = []string{
 	"--osd-delete-sleep=2",     // Time in seconds to sleep before next removal transaction
 }
 
+func init() {
+	osdDeployments := []string{"deployment/rook-ceph-osd-*"}
+	controller.RegisterFieldImpacts(&cephv1.CephCluster{},
+		controller.FieldImpact{Field: "spec.cephVersion.image", Resources: osdDeployments, Restart: true, Description: "The OSDs are updated by failure domain, with the health checks of upgradeOSDRequiresHealthyPGs."},
+		controller.FieldImpact{Field: "spec.dataDirHostPath", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.network", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.resources.osd", Resources: osdDeployments, Restart: true, Description: "The osd_memory_target of the running OSDs is retuned to a changed memory limit right away."},
+		controller.FieldImpact{Field: "spec.placement.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.priorityClassNames.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.annotations.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.labels.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.healthCheck.livenessProbe.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.healthCheck.startupProbe.osd", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.security.kms", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.storage.nodes", Resources: []string{"job/rook-ceph-osd-prepare-*"}, Description: "The new devices are prepared as OSDs, the existing OSDs are not removed."},
+		controller.FieldImpact{Field: "spec.storage.useAllNodes", Resources: []string{"job/rook-ceph-osd-prepare-*"}},
+		controller.FieldImpact{Field: "spec.storage.useAllDevices", Resources: []string{"job/rook-ceph-osd-prepare-*"}},
+		controller.FieldImpact{Field: "spec.storage.devices", Resources: []string{"job/rook-ceph-osd-prepare-*"}, Description: "The new devices are prepared as OSDs, the existing OSDs are not removed."},
+		controller.FieldImpact{Field: "spec.storage.deviceFilter", Resources: []string{"job/rook-ceph-osd-prepare-*"}},
+		controller.FieldImpact{Field: "spec.storage.storageClassDeviceSets", Resources: []string{"job/rook-ceph-osd-prepare-*", "persistentvolumeclaim/*"}, Description: "The OSDs are added to match the count of the device sets, the existing OSDs are only removed with scaleDownOSDs."},
+		controller.FieldImpact{Field: "spec.storage.onlyApplyOSDPlacement", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.storage.flappingRestartIntervalHours", Resources: osdDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.storage.deviceClassResources", Resources: osdDeployments, Restart: true, Description: "The memory targets of the device classes are set in the ceph config right away."},
+		controller.FieldImpact{Field: "spec.storage.config", Resources: []string{"job/rook-ceph-osd-prepare-*"}, Description: "Applies to the OSDs prepared after the change."},
+	)
+}
+
 func deploymentName(osdID int) string {
 	return fmt.Sprintf(osdAppNameFmt, osdID)
 }
@@ -544,6 +571,10 @@ func (c *Cluster) makeDeployment(osdProps osdProperties, osd *OSDInfo, provision
 	}
 
 	initContainers := make([]v1.Container, 0, 4)
+	// wait for an activation slot of the node before the OSD is activated
+	if activationGateContainer := c.getActivationGateInitContainer(*osd); activationGateContainer != nil {
+		initContainers = append(initContainers, *activationGateContainer)
+	}
 	if doConfigInit {
 		initContainers = append(initContainers,
 			v1.Container{
@@ -872,6 +903,32 @@ func (c *Cluster) getCopyBinariesContainer() (v1.Volume, *v1.Container) {
 	}
 }
 
+// getActivationGateInitContainer returns the init container waiting for an activation slot of the
+// node, when the OSDs of the device class of the OSD are limited. The slot is held until the OSD pod
+// is ready.
+func (c *Cluster) getActivationGateInitContainer(osd OSDInfo) *v1.Container {
+	maxConcurrent, deviceClass := c.spec.Storage.GetActivationLimit(osd.DeviceClass)
+	if maxConcurrent <= 0 {
+		return nil
+	}
+
+	args := []string{"ceph", "osd", "activation-gate", fmt.Sprintf("--max-concurrent=%d", maxConcurrent)}
+	if deviceClass != "" {
+		args = append(args, fmt.Sprintf("--device-class=%s", deviceClass))
+	}
+	if c.spec.Storage.ActivationLimit.Timeout != nil {
+		args = append(args, fmt.Sprintf("--timeout=%s", c.spec.Storage.ActivationLimit.Timeout.Duration.String()))
+	}
+	return &v1.Container{
+		Args:            args,
+		Name:            "activation-gate",
+		Image:           c.rookVersion,
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
+		Env:             []v1.EnvVar{k8sutil.NodeEnvVar(), k8sutil.NameEnvVar(), k8sutil.NamespaceEnvVar()},
+		SecurityContext: controller.DefaultContainerSecurityContext(),
+	}
+}
+
 // This container runs all the actions needed to activate an OSD before we can run the OSD process
 func (c *Cluster) getActivateOSDInitContainer(configDir, namespace, osdID string, osdInfo OSDInfo, osdProps osdProperties) ([]v1.Volume, *v1.Container) {
 	// We need to use hostPath because the same reason as written in the comment of getDataBridgeVolumeSource()
@@ -19,6 +19,7 @@ package osd
 
 import (
 	"testing"
+	"time"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
@@ -30,9 +31,11 @@ import (
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -718,6 +721,32 @@ func TestClusterGetPVCEncryptionOpenInitContainerActivate(t *testing.T) {
 	assert.Equal(t, 3, len(containers))
 }
 
+func TestGetActivationGateInitContainer(t *testing.T) {
+	c := New(&clusterd.Context{}, &cephclient.ClusterInfo{OwnerInfo: &k8sutil.OwnerInfo{}}, cephv1.ClusterSpec{}, "rook/rook:myversion")
+	osd := OSDInfo{ID: 0, DeviceClass: "hdd"}
+
+	// the OSDs are not limited by default
+	assert.Nil(t, c.getActivationGateInitContainer(osd))
+
+	c.spec.Storage.ActivationLimit = &cephv1.OSDActivationLimitSpec{MaxConcurrent: 4}
+	container := c.getActivationGateInitContainer(osd)
+	require.NotNil(t, container)
+	assert.Equal(t, "activation-gate", container.Name)
+	assert.Equal(t, "rook/rook:myversion", container.Image)
+	assert.Equal(t, []string{"ceph", "osd", "activation-gate", "--max-concurrent=4"}, container.Args)
+
+	// the device class with its own limit waits for its own slots
+	c.spec.Storage.ActivationLimit.DeviceClasses = map[string]int{"hdd": 2, "ssd": 0}
+	c.spec.Storage.ActivationLimit.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
+	container = c.getActivationGateInitContainer(osd)
+	require.NotNil(t, container)
+	assert.Equal(t, []string{"ceph", "osd", "activation-gate", "--max-concurrent=2", "--device-class=hdd", "--timeout=5m0s"}, container.Args)
+
+	// the device class excluded from the limit
+	osd.DeviceClass = "ssd"
+	assert.Nil(t, c.getActivationGateInitContainer(osd))
+}
+
 func TestClusterGetPVCEncryptionInitContainerActivate(t *testing.T) {
 	c := New(&clusterd.Context{}, &cephclient.ClusterInfo{OwnerInfo: &k8sutil.OwnerInfo{}}, cephv1.ClusterSpec{}, "rook/rook:myversion")
 	osdProperties := osdProperties{
@@ -325,15 +325,21 @@ func (c *Cluster) createOSDsForStatusMap(
 	logger.Infof("OSD orchestration status for %s %s is %q", nodeOrPVC, nodeOrPVCName, status.Status)
 
 	if status.Status == OrchestrationStatusCompleted {
+		c.recordPrepareStatus(nodeOrPVCName, status, time.Now())
 		createConfig.createNewOSDsFromStatus(status, nodeOrPVCName, errs)
 		c.deleteStatusConfigMap(nodeOrPVCName) // remove the provisioning status configmap
+		c.prepareJobs.finished(nodeOrPVCName)
+		c.startQueuedPrepareJobs(createConfig, errs)
 		return
 	}
 
 	if status.Status == OrchestrationStatusFailed {
+		c.recordPrepareStatus(nodeOrPVCName, status, time.Now())
 		createConfig.doneWithStatus(nodeOrPVCName)
 		errs.addError("failed to provision OSD(s) on %s %s. %+v", nodeOrPVC, nodeOrPVCName, status)
 		c.deleteStatusConfigMap(nodeOrPVCName) // remove the provisioning status configmap
+		c.prepareJobs.finished(nodeOrPVCName)
+		c.startQueuedPrepareJobs(createConfig, errs)
 		return
 	}
 }
@@ -0,0 +1,363 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// ConfirmStorageChangesAnnotation is set on the CephCluster to the ID of the storage changes
+	// report to apply a change of the storage selection that orphans OSDs
+	ConfirmStorageChangesAnnotation = "ceph.rook.io/confirm-storage-changes"
+	// StorageSelectionConfigMap records the selection of the nodes and devices of the storage spec
+	// that was last applied
+	StorageSelectionConfigMap = "rook-ceph-osd-storage-selection"
+	storageSelectionKey       = "selection"
+
+	// StorageChangesPendingConfirmation is the phase of a change of the storage selection that
+	// orphans OSDs until it is confirmed
+	StorageChangesPendingConfirmation = "PendingConfirmation"
+	// StorageChangesApplied is the phase of a change of the storage selection once it is applied
+	StorageChangesApplied = "Applied"
+)
+
+// checkStorageChanges reports the OSDs created and orphaned when the selection of the nodes and
+// devices of the storage spec changed since it was last applied. A change orphaning OSDs returns an
+// error until the CephCluster is annotated with the ID of its report, so that no OSD is provisioned
+// or updated with the new selection before it is confirmed.
+func (c *Cluster) checkStorageChanges() error {
+	desired := storageSelection(&c.spec.Storage)
+	serialized, err := json.Marshal(desired)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the storage selection")
+	}
+
+	cm, err := c.context.Clientset.CoreV1().ConfigMaps(c.clusterInfo.Namespace).Get(c.clusterInfo.Context, StorageSelectionConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get configmap %q", StorageSelectionConfigMap)
+		}
+		// nothing was applied before, so no existing osd is orphaned by a change
+		if !desired.UseAllNodes && len(desired.Nodes) == 0 {
+			return nil
+		}
+		return c.saveStorageSelection(string(serialized))
+	}
+	if cm.Data[storageSelectionKey] == string(serialized) {
+		c.clearPendingStorageChanges()
+		return nil
+	}
+	applied := cephv1.StorageScopeSpec{}
+	if err := json.Unmarshal([]byte(cm.Data[storageSelectionKey]), &applied); err != nil {
+		logger.Warningf("failed to unmarshal the applied storage selection, the storage changes are not reported. %v", err)
+		return c.saveStorageSelection(string(serialized))
+	}
+
+	report, err := c.storageChangesReport(&applied, &desired)
+	if err != nil {
+		return errors.Wrap(err, "failed to report the storage changes")
+	}
+	report.ID = k8sutil.Hash(string(serialized))[:12]
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Warningf("failed to get the CephCluster to report the storage changes. %v", err)
+		cephCluster = nil
+	}
+	confirmed := cephCluster != nil && cephCluster.Annotations[ConfirmStorageChangesAnnotation] == report.ID
+
+	if len(report.Orphaned) > 0 && !confirmed {
+		report.Phase = StorageChangesPendingConfirmation
+		c.reportStorageChanges(cephCluster, report)
+		return errors.Errorf("the change of the storage selection orphans %d osds, see the storage changes in the CephCluster status. annotate the CephCluster with %s=%s to apply it",
+			len(report.Orphaned), ConfirmStorageChangesAnnotation, report.ID)
+	}
+
+	report.Phase = StorageChangesApplied
+	logger.Infof("applying the change of the storage selection creating osds on %d nodes and orphaning %d osds", len(report.Created), len(report.Orphaned))
+	c.reportStorageChanges(cephCluster, report)
+	return c.saveStorageSelection(string(serialized))
+}
+
+// storageChangesReport returns the nodes where the desired storage selection creates OSDs and the
+// existing OSDs selected by the applied selection that the desired selection no longer selects
+func (c *Cluster) storageChangesReport(applied, desired *cephv1.StorageScopeSpec) (*cephv1.StorageChangesStatus, error) {
+	allNodes := sets.New[string]()
+	if applied.UseAllNodes || desired.UseAllNodes {
+		hostnames, err := k8sutil.GetNodeHostNames(c.clusterInfo.Context, c.context.Clientset)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the node hostnames")
+		}
+		for _, hostname := range hostnames {
+			allNodes.Insert(hostname)
+		}
+	}
+	appliedNodes := selectedNodes(applied, allNodes)
+	desiredNodes := selectedNodes(desired, allNodes)
+
+	deployments, err := c.getOSDDeployments()
+	if err != nil {
+		return nil, err
+	}
+	report := &cephv1.StorageChangesStatus{ReportTime: metav1.Now()}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if osdIsOnPVC(d) {
+			continue
+		}
+		osdID, err := GetOSDID(d)
+		if err != nil {
+			logger.Warningf("%v", err)
+			continue
+		}
+		node, err := getNodeOrPVCName(d)
+		if err != nil {
+			logger.Warningf("%v", err)
+			continue
+		}
+		if !appliedNodes.Has(node) {
+			// the osd was not selected before the change either
+			continue
+		}
+		orphaned := cephv1.OrphanedOSDStatus{ID: osdID, Node: node, Device: osdBlockPath(d)}
+		if !desiredNodes.Has(node) {
+			orphaned.Reason = "the node is no longer selected"
+			report.Orphaned = append(report.Orphaned, orphaned)
+			continue
+		}
+		wasSelected, wasKnown := deviceSelected(resolveSelectedNode(applied, node), orphaned.Device)
+		selected, known := deviceSelected(resolveSelectedNode(desired, node), orphaned.Device)
+		if wasSelected && wasKnown && !selected && known {
+			orphaned.Reason = "the device is no longer selected"
+			report.Orphaned = append(report.Orphaned, orphaned)
+		}
+	}
+	sort.Slice(report.Orphaned, func(i, j int) bool { return report.Orphaned[i].ID < report.Orphaned[j].ID })
+
+	for _, node := range sets.List(desiredNodes) {
+		desiredNode := resolveSelectedNode(desired, node)
+		created := cephv1.StorageChangeNodeStatus{Node: node}
+		if !appliedNodes.Has(node) {
+			created.Devices = deviceNames(desiredNode.Devices)
+			created.Selection = describeDeviceSelection(&desiredNode.Selection)
+		} else {
+			appliedNode := resolveSelectedNode(applied, node)
+			appliedDevices := sets.New(deviceNames(appliedNode.Devices)...)
+			for _, name := range deviceNames(desiredNode.Devices) {
+				if !appliedDevices.Has(name) {
+					created.Devices = append(created.Devices, name)
+				}
+			}
+			if describeDeviceSelection(&appliedNode.Selection) != describeDeviceSelection(&desiredNode.Selection) {
+				created.Selection = describeDeviceSelection(&desiredNode.Selection)
+			}
+		}
+		if len(created.Devices) > 0 || created.Selection != "" {
+			report.Created = append(report.Created, created)
+		}
+	}
+	return report, nil
+}
+
+// reportStorageChanges publishes the storage changes in the CephCluster status, unless the same
+// report was already published
+func (c *Cluster) reportStorageChanges(cephCluster *cephv1.CephCluster, report *cephv1.StorageChangesStatus) {
+	logger.Infof("storage changes %q are %s: osds created on nodes %+v, osds orphaned %+v", report.ID, report.Phase, report.Created, report.Orphaned)
+	if cephCluster == nil {
+		return
+	}
+	reported := cephCluster.Status.StorageChanges
+	if reported != nil && reported.ID == report.ID && reported.Phase == report.Phase {
+		return
+	}
+	cephCluster.Status.StorageChanges = report
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to report the storage changes in the CephCluster status. %v", err)
+	}
+}
+
+// clearPendingStorageChanges removes the report of a change that was pending confirmation from the
+// CephCluster status once the storage selection was reverted to the applied selection
+func (c *Cluster) clearPendingStorageChanges() {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		logger.Debugf("failed to get the CephCluster to clear the pending storage changes. %v", err)
+		return
+	}
+	reported := cephCluster.Status.StorageChanges
+	if reported == nil || reported.Phase != StorageChangesPendingConfirmation {
+		return
+	}
+	logger.Infof("the storage selection was reverted, the storage changes %q are no longer pending", reported.ID)
+	cephCluster.Status.StorageChanges = nil
+	if err := reporting.UpdateStatus(c.context.Client, cephCluster); err != nil {
+		logger.Warningf("failed to clear the pending storage changes in the CephCluster status. %v", err)
+	}
+}
+
+func (c *Cluster) saveStorageSelection(selection string) error {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: StorageSelectionConfigMap, Namespace: c.clusterInfo.Namespace},
+		Data:       map[string]string{storageSelectionKey: selection},
+	}
+	if err := c.clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on configmap %q", StorageSelectionConfigMap)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(c.clusterInfo.Context, c.context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", StorageSelectionConfigMap)
+	}
+	return nil
+}
+
+// storageSelection returns the settings of the storage spec that select the nodes and devices of
+// the OSDs on nodes
+func storageSelection(spec *cephv1.StorageScopeSpec) cephv1.StorageScopeSpec {
+	selection := cephv1.StorageScopeSpec{UseAllNodes: spec.UseAllNodes, Selection: deviceSelection(&spec.Selection)}
+	if spec.UseAllNodes {
+		// the nodes are ignored when all the nodes are used
+		return selection
+	}
+	for i := range spec.Nodes {
+		selection.Nodes = append(selection.Nodes, cephv1.Node{Name: spec.Nodes[i].Name, Selection: deviceSelection(&spec.Nodes[i].Selection)})
+	}
+	return selection
+}
+
+func deviceSelection(s *cephv1.Selection) cephv1.Selection {
+	selection := cephv1.Selection{
+		UseAllDevices:        s.UseAllDevices,
+		DeviceFilter:         s.DeviceFilter,
+		DevicePathFilter:     s.DevicePathFilter,
+		DeviceIdentityFilter: s.DeviceIdentityFilter,
+	}
+	for _, device := range s.Devices {
+		selection.Devices = append(selection.Devices, cephv1.Device{Name: device.Name, FullPath: device.FullPath})
+	}
+	return selection
+}
+
+func selectedNodes(spec *cephv1.StorageScopeSpec, allNodes sets.Set[string]) sets.Set[string] {
+	if spec.UseAllNodes {
+		return allNodes
+	}
+	nodes := sets.New[string]()
+	for _, node := range spec.Nodes {
+		nodes.Insert(node.Name)
+	}
+	return nodes
+}
+
+// resolveSelectedNode returns the device selection of the node, with the settings of the cluster for
+// the settings the node does not override
+func resolveSelectedNode(spec *cephv1.StorageScopeSpec, name string) *cephv1.Node {
+	spec = spec.DeepCopy()
+	if spec.UseAllNodes {
+		spec.Nodes = []cephv1.Node{{Name: name}}
+	}
+	if node := spec.ResolveNode(name); node != nil {
+		return node
+	}
+	return &cephv1.Node{Name: name}
+}
+
+// deviceSelected returns whether the device is selected on the node, and whether this is known. It
+// is not known for the logical volumes of the OSDs in lvm mode, or when the devices are selected by
+// their path or identity rather than their name.
+func deviceSelected(node *cephv1.Node, path string) (selected, known bool) {
+	if path == "" {
+		return false, false
+	}
+	for _, device := range node.Devices {
+		if device.Name == path || "/dev/"+device.Name == path || device.FullPath == path {
+			return true, true
+		}
+	}
+	if node.UseAllDevices != nil && *node.UseAllDevices {
+		return true, true
+	}
+	name := strings.TrimPrefix(path, "/dev/")
+	if name == path || strings.Contains(name, "/") {
+		return false, false
+	}
+	if node.DeviceFilter != "" {
+		if re, err := regexp.Compile(node.DeviceFilter); err == nil && re.MatchString(name) {
+			return true, true
+		}
+	}
+	if node.DevicePathFilter != "" || node.DeviceIdentityFilter != nil {
+		return false, false
+	}
+	return false, true
+}
+
+func deviceNames(devices []cephv1.Device) []string {
+	var names []string
+	for _, device := range devices {
+		name := device.Name
+		if name == "" {
+			name = device.FullPath
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// describeDeviceSelection describes the filters selecting the devices, whose devices are only known
+// once the OSD prepare job ran
+func describeDeviceSelection(s *cephv1.Selection) string {
+	filters := []string{}
+	if s.UseAllDevices != nil && *s.UseAllDevices {
+		filters = append(filters, "all devices")
+	}
+	if s.DeviceFilter != "" {
+		filters = append(filters, fmt.Sprintf("deviceFilter %q", s.DeviceFilter))
+	}
+	if s.DevicePathFilter != "" {
+		filters = append(filters, fmt.Sprintf("devicePathFilter %q", s.DevicePathFilter))
+	}
+	if s.DeviceIdentityFilter != nil {
+		filters = append(filters, "deviceIdentityFilter")
+	}
+	return strings.Join(filters, ", ")
+}
+
+// osdBlockPath returns the device of the OSD deployment
+func osdBlockPath(d *appsv1.Deployment) string {
+	for _, container := range d.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == "ROOK_BLOCK_PATH" {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckStorageChanges(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
+	clientset := test.New(t, 3)
+	for _, osd := range []struct {
+		id     int
+		node   string
+		device string
+	}{{0, "node0", "/dev/sdb"}, {1, "node0", "/dev/sdc"}, {2, "node1", "/dev/sdb"}} {
+		d := osdDeploymentOnNode(clusterInfo.Namespace, osd.id, osd.node)
+		d.Spec.Template.Spec.Containers = []v1.Container{{Env: []v1.EnvVar{{Name: "ROOK_BLOCK_PATH", Value: osd.device}}}}
+		_, err := clientset.AppsV1().Deployments(clusterInfo.Namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: clusterInfo.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	k8sClient := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	getCephCluster := func() *cephv1.CephCluster {
+		cephCluster := &cephv1.CephCluster{}
+		require.NoError(t, k8sClient.Get(ctx, clusterInfo.NamespacedName(), cephCluster))
+		return cephCluster
+	}
+
+	newCluster := func(storage cephv1.StorageScopeSpec) *Cluster {
+		return New(&clusterd.Context{Clientset: clientset, Client: k8sClient}, clusterInfo, cephv1.ClusterSpec{Storage: storage}, "rook/rook:master")
+	}
+	selection := cephv1.Selection{DeviceFilter: "^sd[bc]"}
+
+	// the first selection is applied without a report
+	c := newCluster(cephv1.StorageScopeSpec{Nodes: []cephv1.Node{{Name: "node0"}, {Name: "node1"}}, Selection: selection})
+	require.NoError(t, c.checkStorageChanges())
+	assert.Nil(t, getCephCluster().Status.StorageChanges)
+
+	// a new node and a new device create osds
+	c = newCluster(cephv1.StorageScopeSpec{
+		Nodes: []cephv1.Node{
+			{Name: "node0"},
+			{Name: "node1", Selection: cephv1.Selection{Devices: []cephv1.Device{{Name: "sdb"}, {Name: "sdd"}}}},
+			{Name: "node2"},
+		},
+		Selection: selection,
+	})
+	require.NoError(t, c.checkStorageChanges())
+	report := getCephCluster().Status.StorageChanges
+	require.NotNil(t, report)
+	assert.Equal(t, StorageChangesApplied, report.Phase)
+	assert.Empty(t, report.Orphaned)
+	assert.Equal(t, []cephv1.StorageChangeNodeStatus{
+		{Node: "node1", Devices: []string{"sdb", "sdd"}},
+		{Node: "node2", Selection: `deviceFilter "^sd[bc]"`},
+	}, report.Created)
+
+	// removing a node and a device orphans their osds until the change is confirmed
+	desired := cephv1.StorageScopeSpec{Nodes: []cephv1.Node{{Name: "node0"}, {Name: "node2"}}, Selection: cephv1.Selection{DeviceFilter: "^sdb"}}
+	c = newCluster(desired)
+	err := c.checkStorageChanges()
+	assert.ErrorContains(t, err, "orphans 2 osds")
+	report = getCephCluster().Status.StorageChanges
+	assert.Equal(t, StorageChangesPendingConfirmation, report.Phase)
+	assert.Equal(t, []cephv1.OrphanedOSDStatus{
+		{ID: 1, Node: "node0", Device: "/dev/sdc", Reason: "the device is no longer selected"},
+		{ID: 2, Node: "node1", Device: "/dev/sdb", Reason: "the node is no longer selected"},
+	}, report.Orphaned)
+
+	// reverting the change clears the pending report
+	c = newCluster(cephv1.StorageScopeSpec{
+		Nodes: []cephv1.Node{
+			{Name: "node0"},
+			{Name: "node1", Selection: cephv1.Selection{Devices: []cephv1.Device{{Name: "sdb"}, {Name: "sdd"}}}},
+			{Name: "node2"},
+		},
+		Selection: selection,
+	})
+	require.NoError(t, c.checkStorageChanges())
+	assert.Nil(t, getCephCluster().Status.StorageChanges)
+
+	// the change is applied once it is confirmed with the id of the report
+	c = newCluster(desired)
+	require.Error(t, c.checkStorageChanges())
+	cephCluster = getCephCluster()
+	id := cephCluster.Status.StorageChanges.ID
+	cephCluster.Annotations = map[string]string{ConfirmStorageChangesAnnotation: id}
+	require.NoError(t, k8sClient.Update(ctx, cephCluster))
+	require.NoError(t, c.checkStorageChanges())
+	assert.Equal(t, StorageChangesApplied, getCephCluster().Status.StorageChanges.Phase)
+	assert.Equal(t, id, getCephCluster().Status.StorageChanges.ID)
+	require.NoError(t, c.checkStorageChanges())
+}
+
+func TestDeviceSelected(t *testing.T) {
+	useAllDevices := true
+	for _, tc := range []struct {
+		name      string
+		selection cephv1.Selection
+		path      string
+		selected  bool
+		known     bool
+	}{
+		{"device by name", cephv1.Selection{Devices: []cephv1.Device{{Name: "sdb"}}}, "/dev/sdb", true, true},
+		{"other device by name", cephv1.Selection{Devices: []cephv1.Device{{Name: "sdc"}}}, "/dev/sdb", false, true},
+		{"all devices", cephv1.Selection{UseAllDevices: &useAllDevices}, "/dev/sdb", true, true},
+		{"device filter", cephv1.Selection{DeviceFilter: "^sd[a-c]"}, "/dev/sdb", true, true},
+		{"other device filter", cephv1.Selection{DeviceFilter: "^nvme"}, "/dev/sdb", false, true},
+		{"device path filter", cephv1.Selection{DevicePathFilter: "^/dev/disk/by-path/pci-.*"}, "/dev/sdb", false, false},
+		{"logical volume", cephv1.Selection{DeviceFilter: "^sdb"}, "/dev/ceph-1234/osd-block-5678", false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, known := deviceSelected(&cephv1.Node{Name: "node0", Selection: tc.selection}, tc.path)
+			assert.Equal(t, tc.selected, selected)
+			assert.Equal(t, tc.known, known)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -94,17 +95,36 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 		logger.Infof("PGs are healthy to proceed updating OSDs. %v", pgHealthMsg)
 	}
 
+	// an OSD update is one disruption of the cluster however many OSDs are updated in parallel
+	release, err := k8sutil.AcquireDisruption(c.cluster.clusterInfo.Namespace, k8sutil.DisruptionOSD, "osd")
+	if err != nil {
+		logger.Infof("OSD updates are delayed by the disruption budget, will try updating them again later. %v", err)
+		return
+	}
+	defer release()
+
 	osdIDQuery, _ := c.queue.Pop()
 
+	// the restarts to apply config changes are deferred while the cluster is recovering, unlike the
+	// restarts of an upgrade
+	deferral := c.cluster.spec.HealthCheck.RecoveryDeferral
+	if deferral != nil && deferral.Enabled && !c.cluster.cephVersionChanged(osdIDQuery) &&
+		controller.DeferForRecovery(c.cluster.context, c.cluster.clusterInfo, deferral, controller.MaintenanceOSDUpdate) {
+		c.queue.Push(osdIDQuery) // push back onto queue to make sure we retry it later
+		return
+	}
+
 	var osdIDs []int
-	var err error
 	if c.cluster.spec.SkipUpgradeChecks || !shouldCheckOkToStopFunc(c.cluster.context, c.cluster.clusterInfo) {
 		// If we should not check ok-to-stop, then only process one OSD at a time. There are likely
 		// less than 3 OSDs in the cluster or the cluster is on a single node. E.g., in CI :wink:.
 		logger.Infof("skipping osd checks for ok-to-stop")
 		osdIDs = []int{osdIDQuery}
 	} else {
-		osdIDs, err = cephclient.OSDOkToStop(c.cluster.context, c.cluster.clusterInfo, osdIDQuery, maxUpdatesInParallel)
+		osdIDs, err = cephclient.OSDOkToStop(c.cluster.context, c.cluster.clusterInfo, osdIDQuery, c.maxUpdatesInParallel())
+		if err == nil && c.cluster.spec.Storage.UpdateStrategy != nil {
+			osdIDs = c.limitUpdatesToFailureDomain(osdIDQuery, osdIDs)
+		}
 		if err != nil {
 			if c.cluster.spec.ContinueUpgradeAfterChecksEvenIfNotHealthy {
 				logger.Infof("OSD %d is not ok-to-stop but 'continueUpgradeAfterChecksEvenIfNotHealthy' is true, so continuing to update it", osdIDQuery)
@@ -218,6 +238,86 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 	c.queue.Remove(osdIDs)
 }
 
+// maxUpdatesInParallel returns the maximum number of OSDs reported ok-to-stop with the queried OSD
+func (c *updateConfig) maxUpdatesInParallel() int {
+	if strategy := c.cluster.spec.Storage.UpdateStrategy; strategy != nil {
+		return max(maxUpdatesInParallel, strategy.MaxUnavailable)
+	}
+	return maxUpdatesInParallel
+}
+
+// limitUpdatesToFailureDomain returns the OSDs to update of the failure domain of the queried OSD,
+// up to the max unavailable OSDs of the update strategy. Ceph reports the OSDs that are ok-to-stop
+// with the queried OSD by walking up the CRUSH tree, so they may span several failure domains.
+func (c *updateConfig) limitUpdatesToFailureDomain(osdIDQuery int, osdIDs []int) []int {
+	strategy := c.cluster.spec.Storage.UpdateStrategy
+	failureDomain := strategy.FailureDomain
+	if failureDomain == "" {
+		failureDomain = "host"
+	}
+	tree, err := cephclient.HostTree(c.cluster.context, c.cluster.clusterInfo)
+	if err != nil {
+		logger.Warningf("failed to get the osd tree to update the osds of the %s of osd %d in parallel, updating only osd %d. %v", failureDomain, osdIDQuery, osdIDQuery, err)
+		return []int{osdIDQuery}
+	}
+	domains := osdFailureDomains(tree, failureDomain)
+	domain, ok := domains[osdIDQuery]
+	if !ok {
+		logger.Warningf("osd %d is not in a %s of the crush map, updating only osd %d", osdIDQuery, failureDomain, osdIDQuery)
+		return []int{osdIDQuery}
+	}
+
+	limited := []int{osdIDQuery}
+	for _, osdID := range osdIDs {
+		if strategy.MaxUnavailable > 0 && len(limited) >= strategy.MaxUnavailable {
+			break
+		}
+		// only the osds that need to be updated count towards the max unavailable osds
+		if osdID == osdIDQuery || domains[osdID] != domain || !c.queue.Exists(osdID) || !c.deployments.Exists(osdID) {
+			continue
+		}
+		limited = append(limited, osdID)
+	}
+	logger.Infof("updating %d osds of %s %q in parallel", len(limited), failureDomain, domain)
+	return limited
+}
+
+// osdFailureDomains returns the name of the CRUSH bucket of the failure domain type of each OSD
+func osdFailureDomains(tree cephclient.OsdTree, failureDomain string) map[int]string {
+	children := map[int][]int{}
+	for _, node := range tree.Nodes {
+		children[node.ID] = node.Children
+	}
+	domains := map[int]string{}
+	var walk func(id int, domain string)
+	walk = func(id int, domain string) {
+		// the osds are the leaves of the tree with a positive id
+		if id >= 0 {
+			domains[id] = domain
+			return
+		}
+		for _, child := range children[id] {
+			walk(child, domain)
+		}
+	}
+	for _, node := range tree.Nodes {
+		if node.Type == failureDomain {
+			walk(node.ID, node.Name)
+		}
+	}
+	return domains
+}
+
+// cephVersionChanged returns whether the OSD is updated to a new Ceph version, or whether its
+// version is unknown
+func (c *Cluster) cephVersionChanged(osdID int) bool {
+	d, err := c.context.Clientset.AppsV1().Deployments(c.clusterInfo.Namespace).Get(c.clusterInfo.Context, deploymentName(osdID), metav1.GetOptions{})
+	if err != nil {
+		return true
+	}
+	return d.Labels[controller.CephVersionLabelKey] != controller.GetCephVersionLabel(c.clusterInfo.CephVersion)
+}
+
 // getOSDUpdateInfo returns an update queue of OSDs which need updated and an existence list of OSD
 // Deployments which already exist.
 func (c *Cluster) getOSDUpdateInfo(errs *provisionErrors) (*updateQueue, *existenceList, error) {
@@ -401,6 +401,36 @@ func Test_updateExistingOSDs(t *testing.T) {
 		assert.Equal(t, 0, updateQueue.Len()) // should be done with updates
 	})
 
+	t.Run("disruption budget used by a mon failover", func(t *testing.T) {
+		clientset = fake.NewSimpleClientset()
+		updateQueue = newUpdateQueueWithIDs(0)
+		existingDeployments = newExistenceListWithIDs(0)
+		requiresHealthyPGs = false
+		forceUpgradeIfUnhealthy = false
+		updateInjectFailures = k8sutil.Failures{}
+		doSetup()
+		addDeploymentOnNode("node0", 0)
+
+		k8sutil.SetDisruptionBudget(namespace, 1, nil)
+		defer k8sutil.ResetDisruptionBudget(namespace)
+		release, err := k8sutil.AcquireDisruption(namespace, k8sutil.DisruptionMon, "mon.a")
+		assert.NoError(t, err)
+
+		osdToBeQueried = -1 // the OSDs must not be queried while the budget is used
+		updateConfig.updateExistingOSDs(errs)
+		assert.Zero(t, errs.len())
+		assert.ElementsMatch(t, deploymentsUpdated, []string{})
+		assert.Equal(t, 1, updateQueue.Len()) // the OSD should remain
+
+		release()
+		osdToBeQueried = 0
+		returnOkToStopIDs = []int{0}
+		updateConfig.updateExistingOSDs(errs)
+		assert.Zero(t, errs.len())
+		assert.ElementsMatch(t, deploymentsUpdated, []string{deploymentName(0)})
+		assert.Equal(t, 0, updateQueue.Len()) // should be done with updates
+	})
+
 	t.Run("continueUpgradesAfterChecksEvenIfUnhealthy = true", func(t *testing.T) {
 		clientset = fake.NewSimpleClientset()
 		updateQueue = newUpdateQueueWithIDs(2)
@@ -672,6 +702,52 @@ func createDeploymentOrPanic(clientset *fake.Clientset, d *appsv1.Deployment) {
 	}
 }
 
+func Test_limitUpdatesToFailureDomain(t *testing.T) {
+	// rack0 has host0 with osds 0-2 and host1 with osds 3-4, rack1 has host2 with osd 5
+	osdTree := `{"nodes":[
+		{"id":-1,"name":"default","type":"root","children":[-2,-3]},
+		{"id":-2,"name":"rack0","type":"rack","children":[-4,-5]},
+		{"id":-3,"name":"rack1","type":"rack","children":[-6]},
+		{"id":-4,"name":"host0","type":"host","children":[0,1,2]},
+		{"id":-5,"name":"host1","type":"host","children":[3,4]},
+		{"id":-6,"name":"host2","type":"host","children":[5]},
+		{"id":0,"name":"osd.0","type":"osd"},{"id":1,"name":"osd.1","type":"osd"},{"id":2,"name":"osd.2","type":"osd"},
+		{"id":3,"name":"osd.3","type":"osd"},{"id":4,"name":"osd.4","type":"osd"},{"id":5,"name":"osd.5","type":"osd"}]}`
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "tree" {
+				return osdTree, nil
+			}
+			return "", errors.Errorf("unexpected command %q", args)
+		},
+	}
+	clusterInfo := cephclient.AdminTestClusterInfo("mycluster")
+	strategy := &cephv1.OSDUpdateStrategySpec{}
+	spec := cephv1.ClusterSpec{Storage: cephv1.StorageScopeSpec{UpdateStrategy: strategy}}
+	c := New(&clusterd.Context{Executor: executor}, clusterInfo, spec, "rook/rook:master")
+	// osd 0 has been popped off the queue and osd 2 is already updated
+	updateConfig := c.newUpdateConfig(nil, newUpdateQueueWithIDs(1, 3, 4, 5), newExistenceListWithIDs(0, 1, 2, 3, 4, 5), sets.New[string]())
+	okToStop := []int{0, 1, 2, 3, 4, 5}
+
+	// the osds of the host of the queried osd are updated
+	assert.Equal(t, []int{0, 1}, updateConfig.limitUpdatesToFailureDomain(0, okToStop))
+	assert.Equal(t, 20, updateConfig.maxUpdatesInParallel())
+
+	// the osds of the rack of the queried osd are updated, up to the max unavailable osds
+	strategy.FailureDomain = "rack"
+	assert.Equal(t, []int{0, 1, 3, 4}, updateConfig.limitUpdatesToFailureDomain(0, okToStop))
+	strategy.MaxUnavailable = 3
+	assert.Equal(t, []int{0, 1, 3}, updateConfig.limitUpdatesToFailureDomain(0, okToStop))
+	assert.Equal(t, []int{5}, updateConfig.limitUpdatesToFailureDomain(5, okToStop))
+
+	// only the queried osd is updated if its failure domain is unknown
+	strategy.FailureDomain = "zone"
+	assert.Equal(t, []int{0}, updateConfig.limitUpdatesToFailureDomain(0, okToStop))
+
+	strategy.MaxUnavailable = 50
+	assert.Equal(t, 50, updateConfig.maxUpdatesInParallel())
+}
+
 func Test_updateQueue(t *testing.T) {
 	q := newUpdateQueueWithCapacity(2)
 	assert.Equal(t, 2, cap(q.q))
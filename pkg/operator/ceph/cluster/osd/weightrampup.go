@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OSDWeightRampUpsConfigMap records the new OSDs whose CRUSH weight is raised in steps
+	OSDWeightRampUpsConfigMap = "rook-ceph-osd-weight-ramp-up"
+	osdWeightRampUpsKey       = "osds"
+
+	defaultWeightRampUpStepPercent = 10
+	defaultWeightRampUpInterval    = 30 * time.Minute
+)
+
+// OSDWeightRampUp is a new OSD whose CRUSH weight is raised in steps up to the weight of its size
+type OSDWeightRampUp struct {
+	OSD  int    `json:"osd"`
+	UUID string `json:"uuid"`
+	// TargetWeight is the weight of the size of the OSD in TiB, unknown until the OSD is started
+	TargetWeight float64      `json:"targetWeight,omitempty"`
+	Weight       float64      `json:"weight"`
+	LastStep     *metav1.Time `json:"lastStep,omitempty"`
+}
+
+// weightRampUpEnabled returns whether the CRUSH weight of the new OSDs is raised in steps
+func weightRampUpEnabled(spec *cephv1.StorageScopeSpec) bool {
+	return spec != nil && spec.WeightRampUp != nil && spec.WeightRampUp.Enabled
+}
+
+// recordOSDWeightRampUps records the new OSDs to raise their CRUSH weight in steps. The OSDs join
+// with a CRUSH weight of 0 since osd_crush_initial_weight is set in the mon store.
+func (c *Cluster) recordOSDWeightRampUps(osds []OSDInfo) error {
+	if !weightRampUpEnabled(&c.spec.Storage) || len(osds) == 0 {
+		return nil
+	}
+	cm, rampUps, err := loadOSDWeightRampUps(c.context, c.clusterInfo)
+	if err != nil {
+		return err
+	}
+	for _, osd := range osds {
+		found := false
+		for i := range rampUps {
+			if rampUps[i].OSD == osd.ID {
+				// the ID of a purged OSD was reused
+				rampUps[i] = OSDWeightRampUp{OSD: osd.ID, UUID: osd.UUID}
+				found = true
+				break
+			}
+		}
+		if !found {
+			rampUps = append(rampUps, OSDWeightRampUp{OSD: osd.ID, UUID: osd.UUID})
+		}
+		logger.Infof("ramping up the crush weight of new osd.%d", osd.ID)
+	}
+	return saveOSDWeightRampUps(c.context, c.clusterInfo, cm, rampUps)
+}
+
+// checkOSDWeightRampUp raises the CRUSH weight of the new OSDs by a step of the weight of their
+// size once the interval elapsed and the placement groups are clean again, so that the data moves
+// to the new OSDs gradually. When the ramp-up is disabled, the OSDs still ramping up are given the
+// weight of their size at once.
+func (m *OSDHealthMonitor) checkOSDWeightRampUp() error {
+	cm, rampUps, err := loadOSDWeightRampUps(m.context, m.clusterInfo)
+	if err != nil {
+		return err
+	}
+	if len(rampUps) == 0 {
+		return nil
+	}
+
+	enabled := false
+	stepPercent := defaultWeightRampUpStepPercent
+	interval := defaultWeightRampUpInterval
+	if m.clusterSpec != nil && weightRampUpEnabled(&m.clusterSpec.Storage) {
+		enabled = true
+		spec := m.clusterSpec.Storage.WeightRampUp
+		if spec.StepPercent > 0 {
+			stepPercent = spec.StepPercent
+		}
+		if spec.Interval != nil {
+			interval = spec.Interval.Duration
+		}
+	}
+
+	osdDump, err := client.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	osdUsage, err := client.GetOSDUsage(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd usage")
+	}
+	usage := map[int]client.OSDNodeUsage{}
+	for _, u := range osdUsage.OSDNodes {
+		usage[u.ID] = u
+	}
+
+	now := time.Now()
+	var clean *bool
+	remaining := []OSDWeightRampUp{}
+	for _, rampUp := range rampUps {
+		if osdUUID(osdDump, rampUp.OSD) != rampUp.UUID {
+			logger.Infof("osd.%d was removed, no longer ramping up its crush weight", rampUp.OSD)
+			continue
+		}
+
+		if rampUp.TargetWeight == 0 {
+			targetWeight, crushWeight, ok := osdWeights(usage, rampUp.OSD)
+			if !ok {
+				// the OSD is not started yet
+				remaining = append(remaining, rampUp)
+				continue
+			}
+			if crushWeight > 0 {
+				logger.Infof("osd.%d joined with crush weight %f, not ramping up its crush weight", rampUp.OSD, crushWeight)
+				continue
+			}
+			rampUp.TargetWeight = targetWeight
+		}
+
+		weight := rampUp.TargetWeight
+		if enabled {
+			if rampUp.LastStep != nil && now.Sub(rampUp.LastStep.Time) < interval {
+				remaining = append(remaining, rampUp)
+				continue
+			}
+			if clean == nil {
+				msg, isClean, err := client.IsClusterClean(m.context, m.clusterInfo, "")
+				if err != nil {
+					return errors.Wrap(err, "failed to check if the placement groups are clean")
+				}
+				if !isClean {
+					logger.Infof("waiting for the placement groups to be clean to ramp up the crush weight of the new osds. %s", msg)
+				}
+				clean = &isClean
+			}
+			if !*clean {
+				remaining = append(remaining, rampUp)
+				continue
+			}
+			weight = math.Min(rampUp.Weight+rampUp.TargetWeight*float64(stepPercent)/100, rampUp.TargetWeight)
+		}
+
+		if err := client.SetOSDCrushWeight(m.context, m.clusterInfo, rampUp.OSD, weight); err != nil {
+			logger.Warningf("failed to ramp up the crush weight of osd.%d. %v", rampUp.OSD, err)
+			remaining = append(remaining, rampUp)
+			continue
+		}
+		if weight >= rampUp.TargetWeight {
+			logger.Infof("completed the ramp up of the crush weight of osd.%d to %f", rampUp.OSD, weight)
+			continue
+		}
+		logger.Infof("ramped up the crush weight of osd.%d to %f of %f", rampUp.OSD, weight, rampUp.TargetWeight)
+		rampUp.Weight = weight
+		rampUp.LastStep = &metav1.Time{Time: now}
+		remaining = append(remaining, rampUp)
+	}
+
+	return saveOSDWeightRampUps(m.context, m.clusterInfo, cm, remaining)
+}
+
+// osdWeights returns the weight of the size of the OSD and its CRUSH weight, in TiB, or false if
+// the size of the OSD is not known yet
+func osdWeights(usage map[int]client.OSDNodeUsage, osdID int) (float64, float64, bool) {
+	u, ok := usage[osdID]
+	if !ok {
+		return 0, 0, false
+	}
+	kb, err := strconv.ParseFloat(u.KB.String(), 64)
+	if err != nil || kb == 0 {
+		return 0, 0, false
+	}
+	crushWeight, err := strconv.ParseFloat(u.CrushWeight.String(), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return kb / float64(1024*1024*1024), crushWeight, true
+}
+
+// osdsRampingUp returns the IDs of the OSDs whose CRUSH weight is ramping up
+func osdsRampingUp(context *clusterd.Context, clusterInfo *client.ClusterInfo) (map[int]bool, error) {
+	_, rampUps, err := loadOSDWeightRampUps(context, clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+	ids := map[int]bool{}
+	for _, rampUp := range rampUps {
+		ids[rampUp.OSD] = true
+	}
+	return ids, nil
+}
+
+func loadOSDWeightRampUps(context *clusterd.Context, clusterInfo *client.ClusterInfo) (*v1.ConfigMap, []OSDWeightRampUp, error) {
+	rampUps := []OSDWeightRampUp{}
+	cm, err := context.Clientset.CoreV1().ConfigMaps(clusterInfo.Namespace).Get(clusterInfo.Context, OSDWeightRampUpsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, nil, errors.Wrapf(err, "failed to get configmap %q", OSDWeightRampUpsConfigMap)
+		}
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: OSDWeightRampUpsConfigMap, Namespace: clusterInfo.Namespace}}
+		if err := clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to set owner reference on configmap %q", OSDWeightRampUpsConfigMap)
+		}
+		return cm, rampUps, nil
+	}
+	if cm.Data[osdWeightRampUpsKey] == "" {
+		return cm, rampUps, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[osdWeightRampUpsKey]), &rampUps); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal the osd weight ramp ups in configmap %q", OSDWeightRampUpsConfigMap)
+	}
+	return cm, rampUps, nil
+}
+
+func saveOSDWeightRampUps(context *clusterd.Context, clusterInfo *client.ClusterInfo, cm *v1.ConfigMap, rampUps []OSDWeightRampUp) error {
+	raw, err := json.Marshal(rampUps)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the osd weight ramp ups")
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[osdWeightRampUpsKey] = string(raw)
+	if _, err := k8sutil.CreateOrUpdateConfigMap(clusterInfo.Context, context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to save configmap %q", OSDWeightRampUpsConfigMap)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckOSDWeightRampUp(t *testing.T) {
+	clusterInfo := client.AdminTestClusterInfo("fake")
+	// osd.1 joined with the weight of its size, osd.2 is not started yet
+	crushWeights := map[int]string{0: "0", 1: "1"}
+	cephStatus := healthyCephStatus
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "status":
+				return cephStatus, nil
+			case args[0] == "osd" && args[1] == "dump":
+				return `{"osds":[{"osd":0,"up":1,"in":1,"uuid":"uuid0"},{"osd":1,"up":1,"in":1,"uuid":"uuid1"},{"osd":2,"up":0,"in":1,"uuid":"uuid2"}]}`, nil
+			case args[0] == "osd" && args[1] == "df":
+				nodes := []string{}
+				for _, id := range []int{0, 1} {
+					nodes = append(nodes, fmt.Sprintf(`{"id":%d,"crush_weight":%s,"kb":1073741824}`, id, crushWeights[id]))
+				}
+				return fmt.Sprintf(`{"nodes":[%s]}`, strings.Join(nodes, ",")), nil
+			case args[0] == "osd" && args[1] == "crush" && args[2] == "reweight":
+				commands = append(commands, strings.Join(args[:5], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	clusterdContext := &clusterd.Context{Clientset: fake.NewSimpleClientset(), Executor: executor}
+	spec := cephv1.ClusterSpec{Storage: cephv1.StorageScopeSpec{
+		WeightRampUp: &cephv1.OSDWeightRampUpSpec{Enabled: true, StepPercent: 50, Interval: &metav1.Duration{Duration: time.Hour}},
+	}}
+	c := New(clusterdContext, clusterInfo, spec, "myversion")
+	require.NoError(t, c.recordOSDWeightRampUps([]OSDInfo{{ID: 0, UUID: "uuid0"}, {ID: 1, UUID: "uuid1"}, {ID: 2, UUID: "uuid2"}}))
+	m := &OSDHealthMonitor{context: clusterdContext, clusterInfo: clusterInfo, clusterSpec: &spec}
+
+	// the weight of osd.0 is raised by a step, osd.1 is not ramped up
+	require.NoError(t, m.checkOSDWeightRampUp())
+	assert.Equal(t, []string{"osd crush reweight osd.0 0.500000"}, commands)
+	_, rampUps, err := loadOSDWeightRampUps(clusterdContext, clusterInfo)
+	require.NoError(t, err)
+	require.Len(t, rampUps, 2)
+	assert.Equal(t, 0, rampUps[0].OSD)
+	assert.Equal(t, float64(1), rampUps[0].TargetWeight)
+	assert.Equal(t, 0.5, rampUps[0].Weight)
+	assert.Equal(t, 2, rampUps[1].OSD)
+
+	// the interval did not elapse
+	require.NoError(t, m.checkOSDWeightRampUp())
+	assert.Len(t, commands, 1)
+
+	// the placement groups are not clean
+	spec.Storage.WeightRampUp.Interval = &metav1.Duration{}
+	cephStatus = unHealthyCephStatus
+	require.NoError(t, m.checkOSDWeightRampUp())
+	assert.Len(t, commands, 1)
+
+	// the ramp up of osd.0 is completed
+	cephStatus = healthyCephStatus
+	require.NoError(t, m.checkOSDWeightRampUp())
+	assert.Equal(t, []string{"osd crush reweight osd.0 0.500000", "osd crush reweight osd.0 1.000000"}, commands)
+	ids, err := osdsRampingUp(clusterdContext, clusterInfo)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{2: true}, ids)
+
+	// the osds are given the weight of their size at once when the ramp up is disabled
+	crushWeights[1] = "0"
+	require.NoError(t, c.recordOSDWeightRampUps([]OSDInfo{{ID: 1, UUID: "uuid1"}}))
+	spec.Storage.WeightRampUp.Enabled = false
+	// the new osds are not recorded
+	require.NoError(t, c.recordOSDWeightRampUps([]OSDInfo{{ID: 0, UUID: "uuid0"}}))
+	require.NoError(t, m.checkOSDWeightRampUp())
+	require.Len(t, commands, 3)
+	assert.Equal(t, "osd crush reweight osd.1 1.000000", commands[2])
+	ids, err = osdsRampingUp(clusterdContext, clusterInfo)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{2: true}, ids)
+}
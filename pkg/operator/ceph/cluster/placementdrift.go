@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultPlacementDriftCheckInterval is the interval to check the placement of the daemons
+var defaultPlacementDriftCheckInterval = 10 * time.Minute
+
+// monFailoverRequester fails over the mons whose node no longer satisfies their placement
+type monFailoverRequester interface {
+	RequestFailover(name string) bool
+}
+
+// placementDriftChecker checks whether the nodes the daemons of the cluster are running on still
+// satisfy the placement of the daemons
+type placementDriftChecker struct {
+	context       *clusterd.Context
+	clusterInfo   *cephclient.ClusterInfo
+	interval      time.Duration
+	autoRemediate bool
+	mons          monFailoverRequester
+	// the message of the PlacementDrift condition last reported
+	message string
+}
+
+// placementViolation is a daemon pod running on a node that no longer satisfies its placement
+type placementViolation struct {
+	pod     v1.Pod
+	reasons []string
+}
+
+func (v placementViolation) String() string {
+	return fmt.Sprintf("%s on node %s (%s)", v.pod.Name, v.pod.Spec.NodeName, strings.Join(v.reasons, ", "))
+}
+
+func newPlacementDriftChecker(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.PlacementDriftSpec, mons monFailoverRequester) *placementDriftChecker {
+	c := &placementDriftChecker{
+		context:       context,
+		clusterInfo:   clusterInfo,
+		interval:      defaultPlacementDriftCheckInterval,
+		autoRemediate: spec.AutoRemediate,
+		mons:          mons,
+	}
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		logger.Infof("daemon placement check interval is %s", spec.Interval.Duration.String())
+		c.interval = spec.Interval.Duration
+	}
+	return c
+}
+
+// checkPlacementDrift periodically checks the placement of the daemons
+func (c *placementDriftChecker) checkPlacementDrift(monitoringRoutines map[string]*opcontroller.ClusterHealth, daemon string) {
+	for {
+		// We must perform this check otherwise the case will check an index that does not exist anymore and
+		// we will get an invalid pointer error and the go routine will panic
+		if _, ok := monitoringRoutines[daemon]; !ok {
+			logger.Infof("ceph cluster %q has been deleted. stopping daemon placement check", c.clusterInfo.Namespace)
+			return
+		}
+		select {
+		case <-monitoringRoutines[daemon].InternalCtx.Done():
+			logger.Infof("stopping monitoring of daemon placement")
+			delete(monitoringRoutines, daemon)
+			return
+
+		case <-time.After(c.interval):
+			if err := c.checkPlacement(monitoringRoutines[daemon].InternalCtx); err != nil {
+				logger.Errorf("failed to check the placement of the daemons. %v", err)
+			}
+		}
+	}
+}
+
+// checkPlacement reports the daemons whose node no longer satisfies their placement in the
+// PlacementDrift condition, and moves one of them with the auto remediation
+func (c *placementDriftChecker) checkPlacement(ctx context.Context) error {
+	pods, err := c.context.Clientset.CoreV1().Pods(c.clusterInfo.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8sutil.ClusterAttr, c.clusterInfo.Namespace),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the daemon pods")
+	}
+
+	nodes := map[string]*v1.Node{}
+	violations := []placementViolation{}
+	pending := false
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodPending {
+			pending = true
+		}
+		if pod.Status.Phase != v1.PodRunning || pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		node, ok := nodes[pod.Spec.NodeName]
+		if !ok {
+			node, err = c.context.Clientset.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{})
+			if err != nil {
+				if !kerrors.IsNotFound(err) {
+					return errors.Wrapf(err, "failed to get node %q", pod.Spec.NodeName)
+				}
+				node = nil
+			}
+			nodes[pod.Spec.NodeName] = node
+		}
+		if node == nil {
+			// the pods of a deleted node are taken care of by the daemon health checks
+			continue
+		}
+		if reasons := placementViolations(pod, *node); len(reasons) > 0 {
+			violations = append(violations, placementViolation{pod: pod, reasons: reasons})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].pod.Name < violations[j].pod.Name })
+
+	c.reportPlacementDrift(ctx, violations)
+	if !c.autoRemediate || len(violations) == 0 {
+		return nil
+	}
+	if pending {
+		logger.Infof("not moving the daemons violating their placement while daemon pods are pending")
+		return nil
+	}
+	for _, violation := range violations {
+		moved, err := c.moveDaemon(ctx, violation.pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to move the daemon pod %q", violation.pod.Name)
+		}
+		if moved {
+			// a single daemon is moved per check
+			return nil
+		}
+	}
+	return nil
+}
+
+// placementViolations returns why the node no longer satisfies the placement of the pod, or nothing
+// if it does
+func placementViolations(pod v1.Pod, node v1.Node) []string {
+	reasons := []string{}
+	for key, value := range pod.Spec.NodeSelector {
+		if nodeValue, ok := node.Labels[key]; !ok || nodeValue != value {
+			reasons = append(reasons, fmt.Sprintf("node selector %s=%s", key, value))
+		}
+	}
+	sort.Strings(reasons)
+
+	if pod.Spec.Affinity != nil {
+		meets, err := k8sutil.NodeMeetsAffinityTerms(node, pod.Spec.Affinity.NodeAffinity)
+		if err != nil {
+			logger.Warningf("failed to check the node affinity of pod %q. %v", pod.Name, err)
+		} else if !meets {
+			reasons = append(reasons, "node affinity")
+		}
+	}
+
+	// the taints added with the PreferNoSchedule effect do not prevent the pods from being scheduled
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tainted := v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{taint}}}
+		if !k8sutil.NodeIsTolerable(tainted, pod.Spec.Tolerations, true) {
+			reasons = append(reasons, fmt.Sprintf("taint %s", taint.ToString()))
+		}
+	}
+	return reasons
+}
+
+func (c *placementDriftChecker) reportPlacementDrift(ctx context.Context, violations []placementViolation) {
+	status := v1.ConditionTrue
+	reason := cephv1.PlacementDriftReason
+	daemons := []string{}
+	for _, violation := range violations {
+		daemons = append(daemons, violation.String())
+	}
+	message := fmt.Sprintf("daemons are running on nodes that no longer satisfy their placement: %s", strings.Join(daemons, "; "))
+	if len(violations) == 0 {
+		status = v1.ConditionFalse
+		reason = cephv1.PlacementSatisfiedReason
+		message = "all the daemons are running on nodes that satisfy their placement"
+	}
+	if message == c.message {
+		return
+	}
+	c.message = message
+	if len(violations) > 0 {
+		logger.Warning(message)
+	}
+	opcontroller.UpdateCondition(ctx, c.context, c.clusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionPlacementDrift, status, reason, message)
+}
+
+// moveDaemon moves the daemon of the pod to a node satisfying its placement. The mons are failed
+// over and the pods of the other daemons are deleted to be rescheduled. It returns false if the
+// daemon cannot be moved.
+func (c *placementDriftChecker) moveDaemon(ctx context.Context, pod v1.Pod) (bool, error) {
+	app := pod.Labels[k8sutil.AppAttr]
+	switch {
+	case app == mon.AppName:
+		if c.mons == nil {
+			return false, nil
+		}
+		name := pod.Labels[opcontroller.DaemonIDLabel]
+		if !c.mons.RequestFailover(name) {
+			return false, nil
+		}
+		logger.Infof("failing over mon %q running on node %q that no longer satisfies its placement", name, pod.Spec.NodeName)
+		return true, nil
+
+	case app == osd.AppName:
+		// the OSDs cannot move away from their disks
+		return false, nil
+
+	case pod.Spec.NodeSelector[k8sutil.LabelHostname()] != "":
+		// the daemons bound to their node would be rescheduled on the same node
+		return false, nil
+
+	case !isOwnedByReplicaSet(pod):
+		return false, nil
+	}
+
+	logger.Infof("deleting pod %q running on node %q that no longer satisfies its placement to reschedule it", pod.Name, pod.Spec.NodeName)
+	if err := c.context.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to delete pod %q", pod.Name)
+	}
+	return true, nil
+}
+
+func isOwnedByReplicaSet(pod v1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "ReplicaSet" {
+			return true
+		}
+	}
+	return false
+}
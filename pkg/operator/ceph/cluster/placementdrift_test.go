@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeMonFailoverRequester struct {
+	requested []string
+}
+
+func (r *fakeMonFailoverRequester) RequestFailover(name string) bool {
+	r.requested = append(r.requested, name)
+	return true
+}
+
+func TestPlacementViolations(t *testing.T) {
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"role": "storage"}}}
+	pod := v1.Pod{Spec: v1.PodSpec{NodeSelector: map[string]string{"role": "storage"}}}
+	assert.Empty(t, placementViolations(pod, node))
+
+	// relabeled node
+	pod.Spec.NodeSelector = map[string]string{"role": "storage", "zone": "a"}
+	assert.Equal(t, []string{"node selector zone=a"}, placementViolations(pod, node))
+	pod.Spec.NodeSelector = nil
+
+	pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{
+			MatchExpressions: []v1.NodeSelectorRequirement{{Key: "role", Operator: v1.NodeSelectorOpIn, Values: []string{"ceph"}}},
+		}}},
+	}}
+	assert.Equal(t, []string{"node affinity"}, placementViolations(pod, node))
+	pod.Spec.Affinity = nil
+
+	// tainted node
+	node.Spec.Taints = []v1.Taint{
+		{Key: "maintenance", Value: "true", Effect: v1.TaintEffectNoSchedule},
+		{Key: "busy", Effect: v1.TaintEffectPreferNoSchedule},
+		{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute},
+	}
+	assert.Equal(t, []string{"taint maintenance=true:NoSchedule"}, placementViolations(pod, node))
+	pod.Spec.Tolerations = []v1.Toleration{{Key: "maintenance", Operator: v1.TolerationOpExists}}
+	assert.Empty(t, placementViolations(pod, node))
+}
+
+func TestCheckPlacement(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("placement-ns")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+
+	tainted := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: "maintenance", Effect: v1.TaintEffectNoSchedule}}},
+	}
+	newPod := func(name, app string, owner string, nodeSelector map[string]string) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsName.Namespace,
+				Labels:    map[string]string{"app": app, "rook_cluster": nsName.Namespace, "ceph_daemon_id": name},
+			},
+			Spec:   v1.PodSpec{NodeName: "node1", NodeSelector: nodeSelector},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: owner, Name: name}}
+		}
+		return pod
+	}
+	clientset := fake.NewSimpleClientset(tainted,
+		newPod("osd0", "rook-ceph-osd", "ReplicaSet", nil),
+		newPod("crash", "rook-ceph-crashcollector", "ReplicaSet", map[string]string{v1.LabelHostname: "node1"}),
+		newPod("mgr-a", "rook-ceph-mgr", "ReplicaSet", nil),
+		newPod("a", "rook-ceph-mon", "ReplicaSet", nil),
+	)
+	mons := &fakeMonFailoverRequester{}
+	c := &clusterd.Context{Clientset: clientset, Client: client}
+	checker := newPlacementDriftChecker(c, clusterInfo, &cephv1.PlacementDriftSpec{Enabled: true, AutoRemediate: true, Interval: &metav1.Duration{Duration: time.Minute}}, mons)
+	assert.Equal(t, time.Minute, checker.interval)
+
+	getCondition := func() *cephv1.Condition {
+		cluster := &cephv1.CephCluster{}
+		assert.NoError(t, client.Get(ctx, nsName, cluster))
+		return cephv1.FindStatusCondition(cluster.Status.Conditions, cephv1.ConditionPlacementDrift)
+	}
+	podExists := func(name string) bool {
+		_, err := clientset.CoreV1().Pods(nsName.Namespace).Get(ctx, name, metav1.GetOptions{})
+		return err == nil
+	}
+
+	t.Run("the mon is failed over first", func(t *testing.T) {
+		assert.NoError(t, checker.checkPlacement(ctx))
+		condition := getCondition()
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, cephv1.PlacementDriftReason, condition.Reason)
+		assert.Contains(t, condition.Message, "mgr-a on node node1 (taint maintenance:NoSchedule)")
+		assert.Equal(t, []string{"a"}, mons.requested)
+		assert.True(t, podExists("mgr-a"))
+	})
+
+	t.Run("one daemon is moved per check", func(t *testing.T) {
+		assert.NoError(t, clientset.CoreV1().Pods(nsName.Namespace).Delete(ctx, "a", metav1.DeleteOptions{}))
+		assert.NoError(t, checker.checkPlacement(ctx))
+		assert.False(t, podExists("mgr-a"))
+		assert.True(t, podExists("crash"))
+		assert.True(t, podExists("osd0"))
+
+		// the osd and the daemons bound to their node are only reported
+		assert.NoError(t, checker.checkPlacement(ctx))
+		assert.True(t, podExists("crash"))
+		assert.True(t, podExists("osd0"))
+		assert.Equal(t, []string{"a"}, mons.requested)
+	})
+
+	t.Run("no daemon is moved while a pod is pending", func(t *testing.T) {
+		pending := newPod("mgr-b", "rook-ceph-mgr", "ReplicaSet", nil)
+		pending.Status.Phase = v1.PodPending
+		_, err := clientset.CoreV1().Pods(nsName.Namespace).Create(ctx, pending, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		_, err = clientset.CoreV1().Pods(nsName.Namespace).Create(ctx, newPod("mgr-a", "rook-ceph-mgr", "ReplicaSet", nil), metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, checker.checkPlacement(ctx))
+		assert.True(t, podExists("mgr-a"))
+	})
+
+	t.Run("the condition is cleared once the node is untainted", func(t *testing.T) {
+		tainted.Spec.Taints = nil
+		_, err := clientset.CoreV1().Nodes().Update(ctx, tainted, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, checker.checkPlacement(ctx))
+		condition := getCondition()
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, cephv1.PlacementSatisfiedReason, condition.Reason)
+	})
+}
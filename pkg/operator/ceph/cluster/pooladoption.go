@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// adoptPoolsAnnotation is set on the CephCluster with a comma-separated list of the pools created
+	// outside of rook to adopt, or "*" to adopt all of them
+	adoptPoolsAnnotation = "ceph.rook.io/adopt-pools"
+	adoptAllPools        = "*"
+	// adoptedPoolAnnotation is set on the CephBlockPools generated for the adopted pools
+	adoptedPoolAnnotation = "ceph.rook.io/adopted-pool"
+	rbdApplication        = "rbd"
+)
+
+// reconcilePoolAdoption generates a CephBlockPool for the rbd pools requested with the adoption
+// annotation that are not managed by a CephBlockPool. The generated pool spec matches the current
+// settings of the pool so that the pool controller does not change the pool.
+func (c *cluster) reconcilePoolAdoption() error {
+	requested := c.clusterMetadata.Annotations[adoptPoolsAnnotation]
+	if requested == "" {
+		return nil
+	}
+	var requestedPools []string
+	if requested != adoptAllPools {
+		requestedPools = strings.Split(requested, ",")
+		for i := range requestedPools {
+			requestedPools[i] = strings.TrimSpace(requestedPools[i])
+		}
+	}
+
+	unmanaged, err := c.findUnmanagedPools(requestedPools)
+	if err != nil {
+		return err
+	}
+	for _, poolName := range unmanaged {
+		if err := c.adoptPool(poolName); err != nil {
+			return errors.Wrapf(err, "failed to adopt pool %q", poolName)
+		}
+	}
+	return nil
+}
+
+// findUnmanagedPools returns the requested pools with the rbd application that are not managed by
+// a CephBlockPool. All the pools are candidates if none are requested. The pools of the filesystems
+// and object stores are never adopted, even before their application is set. A pool without an
+// application is only adopted if it is requested by name.
+func (c *cluster) findUnmanagedPools(requestedPools []string) ([]string, error) {
+	pools, err := client.ListPoolSummaries(c.context, c.ClusterInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pools")
+	}
+
+	blockPools, err := c.context.RookClientset.CephV1().CephBlockPools(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list CephBlockPools")
+	}
+	managed := map[string]bool{}
+	for i := range blockPools.Items {
+		managed[blockPools.Items[i].ToNamedPoolSpec().Name] = true
+	}
+	ownedByOtherCR, err := c.filesystemAndObjectStorePools()
+	if err != nil {
+		return nil, err
+	}
+
+	unmanaged := []string{}
+	for _, pool := range pools {
+		// the builtin pools are created by ceph or by rook
+		if managed[pool.Name] || strings.HasPrefix(pool.Name, ".") || ownedByOtherCR(pool.Name) {
+			continue
+		}
+		if len(requestedPools) > 0 && !slices.Contains(requestedPools, pool.Name) {
+			continue
+		}
+		application, err := client.GetPoolApplication(c.context, c.ClusterInfo, pool.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get application of pool %q", pool.Name)
+		}
+		if application != rbdApplication && (application != "" || len(requestedPools) == 0) {
+			continue
+		}
+		unmanaged = append(unmanaged, pool.Name)
+	}
+	return unmanaged, nil
+}
+
+// filesystemAndObjectStorePools returns whether a pool belongs to a CephFilesystem or a
+// CephObjectStore of the cluster. The pools are matched by the prefix of their generated names,
+// or by their name when it is set in the spec.
+func (c *cluster) filesystemAndObjectStorePools() (func(string) bool, error) {
+	ctx := c.ClusterInfo.Context
+	prefixes := []string{}
+	names := map[string]bool{}
+
+	filesystems, err := c.context.RookClientset.CephV1().CephFilesystems(c.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list CephFilesystems")
+	}
+	for _, fs := range filesystems.Items {
+		prefixes = append(prefixes, fs.Name+"-")
+		if fs.Spec.PreservePoolNames {
+			names[fs.Spec.MetadataPool.Name] = true
+			for _, pool := range fs.Spec.DataPools {
+				names[pool.Name] = true
+			}
+		}
+	}
+
+	objectStores, err := c.context.RookClientset.CephV1().CephObjectStores(c.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list CephObjectStores")
+	}
+	for _, store := range objectStores.Items {
+		prefixes = append(prefixes, store.Name+".")
+		sharedPools := store.Spec.SharedPools
+		names[sharedPools.MetadataPoolName] = true
+		names[sharedPools.DataPoolName] = true
+		for _, placement := range sharedPools.PoolPlacements {
+			names[placement.MetadataPoolName] = true
+			names[placement.DataPoolName] = true
+			names[placement.DataNonECPoolName] = true
+			for _, storageClass := range placement.StorageClasses {
+				names[storageClass.DataPoolName] = true
+			}
+		}
+	}
+	delete(names, "")
+
+	return func(poolName string) bool {
+		if names[poolName] {
+			return true
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(poolName, prefix) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func (c *cluster) adoptPool(poolName string) error {
+	if errs := validation.IsDNS1123Subdomain(poolName); len(errs) > 0 {
+		logger.Warningf("cannot adopt pool %q since its name is not a valid CephBlockPool name. %v", poolName, errs)
+		return nil
+	}
+
+	details, err := client.GetPoolDetails(c.context, c.ClusterInfo, poolName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pool details")
+	}
+	if details.ErasureCodeProfile != "" {
+		logger.Warningf("cannot adopt erasure coded pool %q, only replicated pools can be adopted", poolName)
+		return nil
+	}
+	crushSettings, err := client.GetPoolCrushRuleSettings(c.context, c.ClusterInfo, details)
+	if err != nil {
+		return err
+	}
+
+	blockPool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: c.Namespace,
+			Annotations: map[string]string{
+				adoptedPoolAnnotation: "true",
+			},
+		},
+		Spec: cephv1.NamedBlockPoolSpec{
+			PoolSpec: cephv1.PoolSpec{
+				FailureDomain: crushSettings.FailureDomain,
+				CrushRoot:     crushSettings.CrushRoot,
+				DeviceClass:   crushSettings.DeviceClass,
+				Replicated: cephv1.ReplicatedSpec{
+					Size: details.Size,
+					// the pools without replicas can only be managed if the unsafe size is allowed
+					RequireSafeReplicaSize: details.Size > 1,
+				},
+			},
+		},
+	}
+	if _, err := c.context.RookClientset.CephV1().CephBlockPools(c.Namespace).Create(c.ClusterInfo.Context, blockPool, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to create CephBlockPool")
+	}
+	logger.Infof("adopted pool %q with CephBlockPool %q (size %d, failure domain %q, crush root %q)", poolName, blockPool.Name, details.Size, crushSettings.FailureDomain, crushSettings.CrushRoot)
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcilePoolAdoption(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	commandCount := 0
+	poolSize := 2
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("Command: %s %v", command, args)
+			commandCount++
+			if args[0] == "osd" {
+				if args[1] == "lspools" {
+					return `[{"poolnum":1,"poolname":".mgr"},{"poolnum":2,"poolname":"managed"},{"poolnum":3,"poolname":"manual"},
+						{"poolnum":4,"poolname":"myfs-data"},{"poolnum":5,"poolname":"ec"},{"poolnum":6,"poolname":"other"},
+						{"poolnum":7,"poolname":"noapp"},{"poolnum":8,"poolname":"myfs-metadata"},{"poolnum":9,"poolname":"shared-data"},
+						{"poolnum":10,"poolname":"mystore.rgw.buckets.data"}]`, nil
+				}
+				if args[1] == "pool" && args[2] == "application" {
+					switch args[4] {
+					case "myfs-data":
+						return `{"cephfs":{}}`, nil
+					case "noapp", "myfs-metadata", "shared-data", "mystore.rgw.buckets.data":
+						// the application is not set yet
+						return `{}`, nil
+					}
+					return `{"rbd":{}}`, nil
+				}
+				if args[1] == "pool" && args[2] == "get" {
+					if args[3] == "ec" {
+						return `{"pool":"ec","size":3}{"pool":"ec","erasure_code_profile":"default"}`, nil
+					}
+					return fmt.Sprintf(`{"pool":%q,"size":%d}{"pool":%q,"crush_rule":"replicated_rule"}`, args[3], poolSize, args[3]), nil
+				}
+				if args[1] == "crush" && args[2] == "rule" {
+					return `{"rule_name":"replicated_rule","steps":[{"op":"take","item":-2,"item_name":"myroot~ssd"},{"op":"chooseleaf_firstn","num":0,"type":"rack"},{"op":"emit"}]}`, nil
+				}
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	managedPool := &cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: namespace}}
+	filesystem := &cephv1.CephFilesystem{ObjectMeta: metav1.ObjectMeta{Name: "myfs", Namespace: namespace}}
+	objectStore := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "mystore", Namespace: namespace},
+		Spec:       cephv1.ObjectStoreSpec{SharedPools: cephv1.ObjectSharedPoolsSpec{DataPoolName: "shared-data"}},
+	}
+	rookClientset := rookclient.NewSimpleClientset(managedPool, filesystem, objectStore)
+	c := &cluster{
+		ClusterInfo: cephclient.AdminTestClusterInfo(namespace),
+		Namespace:   namespace,
+		Spec:        &cephv1.ClusterSpec{},
+		context:     &clusterd.Context{Executor: executor, RookClientset: rookClientset},
+	}
+
+	listPools := func() []cephv1.CephBlockPool {
+		pools, err := rookClientset.CephV1().CephBlockPools(namespace).List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		return pools.Items
+	}
+
+	t.Run("unmanaged pools detected", func(t *testing.T) {
+		unmanaged, err := c.findUnmanagedPools(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"manual", "ec", "other"}, unmanaged)
+
+		unmanaged, err = c.findUnmanagedPools([]string{"other", "myfs-data"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"other"}, unmanaged)
+
+		// a pool without application is only adopted by name, unless it belongs to a filesystem or object store
+		unmanaged, err = c.findUnmanagedPools([]string{"noapp", "myfs-metadata", "shared-data", "mystore.rgw.buckets.data"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"noapp"}, unmanaged)
+	})
+
+	t.Run("no scan without annotation", func(t *testing.T) {
+		commandCount = 0
+		assert.NoError(t, c.reconcilePoolAdoption())
+		assert.Len(t, listPools(), 1)
+		assert.Equal(t, 0, commandCount)
+	})
+
+	t.Run("adopt requested pool", func(t *testing.T) {
+		c.clusterMetadata.Annotations = map[string]string{adoptPoolsAnnotation: "manual, ec"}
+		assert.NoError(t, c.reconcilePoolAdoption())
+		// the erasure coded pool is not adopted
+		assert.Len(t, listPools(), 2)
+
+		pool, err := rookClientset.CephV1().CephBlockPools(namespace).Get(ctx, "manual", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "true", pool.Annotations[adoptedPoolAnnotation])
+		assert.Equal(t, uint(2), pool.Spec.Replicated.Size)
+		assert.Equal(t, "rack", pool.Spec.FailureDomain)
+		assert.Equal(t, "myroot", pool.Spec.CrushRoot)
+		assert.Equal(t, "ssd", pool.Spec.DeviceClass)
+		assert.True(t, pool.Spec.Replicated.RequireSafeReplicaSize)
+
+		// adopting again is a no-op since the pool is now managed
+		assert.NoError(t, c.reconcilePoolAdoption())
+		assert.Len(t, listPools(), 2)
+	})
+
+	t.Run("adopt all pools", func(t *testing.T) {
+		poolSize = 1
+		c.clusterMetadata.Annotations = map[string]string{adoptPoolsAnnotation: adoptAllPools}
+		assert.NoError(t, c.reconcilePoolAdoption())
+		assert.Len(t, listPools(), 3)
+		pool, err := rookClientset.CephV1().CephBlockPools(namespace).Get(ctx, "other", metav1.GetOptions{})
+		assert.NoError(t, err)
+		// a pool without replicas is not rejected by the pool validation
+		assert.Equal(t, uint(1), pool.Spec.Replicated.Size)
+		assert.False(t, pool.Spec.Replicated.RequireSafeReplicaSize)
+	})
+}
@@ -25,6 +25,9 @@ import (
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	discoverDaemon "github.com/rook/rook/pkg/daemon/discover"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/supportbundle"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	corev1 "k8s.io/api/core/v1"
@@ -114,6 +117,33 @@ func predicateForHotPlugCMWatcher[T *corev1.ConfigMap](client client.Client) pre
 	}
 }
 
+// predicateForOSDReplacementCMWatcher is the predicate function to trigger reconcile to provision
+// the OSDs once an OSD on a failing device is purged
+func predicateForOSDReplacementCMWatcher[T *corev1.ConfigMap]() predicate.TypedFuncs[T] {
+	return predicate.TypedFuncs[T]{
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			objOld := (*corev1.ConfigMap)(e.ObjectOld)
+			objNew := (*corev1.ConfigMap)(e.ObjectNew)
+			if objNew.GetName() != osd.OSDReplacementsConfigMap {
+				return false
+			}
+			return osd.OSDReplacementProvisioningStarted(objOld, objNew)
+		},
+
+		DeleteFunc: func(e event.TypedDeleteEvent[T]) bool {
+			return false
+		},
+
+		CreateFunc: func(e event.TypedCreateEvent[T]) bool {
+			return false
+		},
+
+		GenericFunc: func(e event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
 // isHotPlugCM informs whether the object is the cm for hot-plug disk
 func isHotPlugCM(cm *corev1.ConfigMap) bool {
 	// Get the labels
@@ -185,6 +215,15 @@ func watchControllerPredicate[T *cephv1.CephCluster](ctx context.Context, c clie
 			} else if objOld.GetGeneration() != objNew.GetGeneration() {
 				logger.Debugf("reconciling CephCluster %q with changed generation", objNew.Name)
 				return true
+			} else if objOld.GetAnnotations()[adoptPoolsAnnotation] != objNew.GetAnnotations()[adoptPoolsAnnotation] {
+				logger.Infof("reconciling CephCluster %q with changed pool adoption request", objNew.Name)
+				return true
+			} else if preview := objNew.GetAnnotations()[mon.PreviewMonFailoverAnnotation]; preview != "" && preview != objOld.GetAnnotations()[mon.PreviewMonFailoverAnnotation] {
+				logger.Infof("reconciling CephCluster %q to preview the failover of mon %q", objNew.Name, preview)
+				return true
+			} else if bundle := objNew.GetAnnotations()[supportbundle.Annotation]; bundle != "" && bundle != objOld.GetAnnotations()[supportbundle.Annotation] {
+				logger.Infof("reconciling CephCluster %q to generate a support bundle to %q", objNew.Name, bundle)
+				return true
 			}
 
 			return false
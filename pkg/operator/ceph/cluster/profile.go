@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapProfileToClusters enqueues the clusters referencing a profile so they are kept in sync with it
+func mapProfileToClusters(k8sClient client.Client) handler.TypedMapFunc[*cephv1.RookClusterProfile, reconcile.Request] {
+	return func(ctx context.Context, profile *cephv1.RookClusterProfile) []reconcile.Request {
+		cephClusters := cephv1.CephClusterList{}
+		err := k8sClient.List(ctx, &cephClusters)
+		if err != nil {
+			logger.Errorf("failed to list ceph clusters for profile %q. %v", profile.Name, err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cephCluster := range cephClusters.Items {
+			if cephCluster.Spec.ProfileRef != nil && cephCluster.Spec.ProfileRef.Name == profile.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      cephCluster.Name,
+						Namespace: cephCluster.Namespace,
+					},
+				})
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The metrics of the events of the mgr progress module, exposed on the metrics endpoint of the operator
+var (
+	progressEventRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rook_ceph",
+		Subsystem: "progress",
+		Name:      "event_ratio",
+		Help:      "Completion between 0 and 1 of the events in progress of the mgr progress module",
+	}, []string{"namespace", "id", "message"})
+
+	progressEventRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rook_ceph",
+		Subsystem: "progress",
+		Name:      "event_remaining_seconds",
+		Help:      "Estimated number of seconds until the events in progress of the mgr progress module complete",
+	}, []string{"namespace", "id", "message"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(progressEventRatio, progressEventRemaining)
+}
+
+// progressEvent is an event in progress of the mgr progress module with the pools it refers to
+type progressEvent struct {
+	status    cephv1.ProgressEventStatus
+	remaining *time.Duration
+	pools     []int
+}
+
+// getProgressEvents returns the events in progress of the mgr progress module, sorted by start
+// time. The details of the events are only queried when the ceph status reports events in progress.
+func (c *cephStatusChecker) getProgressEvents(status *cephclient.CephStatus) []progressEvent {
+	if len(status.ProgressEvents) == 0 {
+		return nil
+	}
+
+	events, err := cephclient.GetProgressEvents(c.context, c.clusterInfo)
+	if err != nil {
+		// the events of the ceph status have no start time nor pools
+		logger.Warningf("failed to get the details of the progress events. %v", err)
+		var result []progressEvent
+		for id, event := range status.ProgressEvents {
+			result = append(result, progressEvent{status: cephv1.ProgressEventStatus{ID: id, Message: event.Message, Percent: progressPercent(event.Progress)}})
+		}
+		sortProgressEvents(result)
+		return result
+	}
+	return toProgressEvents(events, time.Now())
+}
+
+// toProgressEvents converts the events of the mgr progress module, estimating the time remaining
+// from the progress since the start of the event when the mgr does not report it
+func toProgressEvents(events *cephclient.ProgressEvents, now time.Time) []progressEvent {
+	result := make([]progressEvent, 0, len(events.Events))
+	for i := range events.Events {
+		event := &events.Events[i]
+		e := progressEvent{
+			status: cephv1.ProgressEventStatus{ID: event.ID, Message: event.Message, Percent: progressPercent(event.Progress)},
+			pools:  event.Pools(),
+		}
+		var startedAt time.Time
+		if event.StartedAt > 0 {
+			startedAt = time.Unix(0, int64(event.StartedAt*float64(time.Second))).UTC()
+			e.status.StartedAt = formatTime(startedAt)
+		}
+		switch {
+		case event.TimeRemaining != nil && *event.TimeRemaining >= 0:
+			remaining := time.Duration(*event.TimeRemaining * float64(time.Second))
+			e.remaining = &remaining
+		case !startedAt.IsZero() && event.Progress > 0 && event.Progress < 1:
+			elapsed := now.Sub(startedAt)
+			remaining := time.Duration(float64(elapsed) * (1 - event.Progress) / event.Progress)
+			e.remaining = &remaining
+		}
+		if e.remaining != nil {
+			e.status.EstimatedTimeRemaining = formatTimeRemaining(*e.remaining)
+		}
+		result = append(result, e)
+	}
+	sortProgressEvents(result)
+	return result
+}
+
+func sortProgressEvents(events []progressEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].status.StartedAt != events[j].status.StartedAt {
+			return events[i].status.StartedAt < events[j].status.StartedAt
+		}
+		return events[i].status.ID < events[j].status.ID
+	})
+}
+
+func progressPercent(progress float64) int {
+	return int(math.Round(math.Min(math.Max(progress, 0), 1) * 100))
+}
+
+// formatTimeRemaining formats the estimated time remaining to the minute, for example "2h5m"
+func formatTimeRemaining(remaining time.Duration) string {
+	remaining = remaining.Round(time.Minute)
+	if remaining < time.Minute {
+		return "<1m"
+	}
+	hours := remaining / time.Hour
+	minutes := (remaining % time.Hour) / time.Minute
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+// progressSummary summarizes the oldest event in progress for the CephCluster status, for example
+// "Global Recovery Event 37% (ETA 2h5m)"
+func progressSummary(events []progressEvent) string {
+	if len(events) == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("%s %d%%", events[0].status.Message, events[0].status.Percent)
+	if events[0].status.EstimatedTimeRemaining != "" {
+		summary += fmt.Sprintf(" (ETA %s)", events[0].status.EstimatedTimeRemaining)
+	}
+	if len(events) > 1 {
+		summary += fmt.Sprintf(" and %d more", len(events)-1)
+	}
+	return summary
+}
+
+func progressEventStatuses(events []progressEvent) []cephv1.ProgressEventStatus {
+	var statuses []cephv1.ProgressEventStatus
+	for _, event := range events {
+		statuses = append(statuses, event.status)
+	}
+	return statuses
+}
+
+// reportProgress records the metrics of the events in progress and reports the events in the
+// status of the CephBlockPools they refer to
+func (c *cephStatusChecker) reportProgress(ctx context.Context, events []progressEvent) {
+	namespace := c.clusterInfo.Namespace
+	progressEventRatio.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	progressEventRemaining.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	for _, event := range events {
+		progressEventRatio.WithLabelValues(namespace, event.status.ID, event.status.Message).Set(float64(event.status.Percent) / 100)
+		if event.remaining != nil {
+			progressEventRemaining.WithLabelValues(namespace, event.status.ID, event.status.Message).Set(event.remaining.Seconds())
+		}
+	}
+
+	pools := &cephv1.CephBlockPoolList{}
+	if err := c.client.List(ctx, pools, client.InNamespace(namespace)); err != nil {
+		logger.Warningf("failed to list the CephBlockPools to report the progress events. %v", err)
+		return
+	}
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Status == nil {
+			continue
+		}
+		var poolEvents []cephv1.ProgressEventStatus
+		for _, event := range events {
+			if slices.Contains(event.pools, pool.Status.PoolID) {
+				poolEvents = append(poolEvents, event.status)
+			}
+		}
+		if reflect.DeepEqual(poolEvents, pool.Status.ProgressEvents) {
+			continue
+		}
+		pool.Status.ProgressEvents = poolEvents
+		if err := reporting.UpdateStatus(c.client, pool); err != nil {
+			logger.Warningf("failed to report the progress events of pool %q. %v", pool.Name, err)
+		}
+	}
+}
+
+// deleteProgressMetrics removes the metrics of the progress events of the cluster when the cluster
+// is deleted
+func deleteProgressMetrics(namespace string) {
+	progressEventRatio.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	progressEventRemaining.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const progressJSON = `{"events":[
+{"id":"b1","message":"PG autoscaler increasing pool 2 PGs from 32 to 128","progress":0.25,"started_at":1735787045,"refs":[["pool",2]]},
+{"id":"a1","message":"Global Recovery Event","progress":0.37,"started_at":1735786800,"time_remaining":7500,"refs":[["global",""]]}
+],"completed":[]}`
+
+func TestToProgressEvents(t *testing.T) {
+	var events cephclient.ProgressEvents
+	require.NoError(t, json.Unmarshal([]byte(progressJSON), &events))
+	now := time.Unix(1735787105, 0)
+
+	result := toProgressEvents(&events, now)
+	require.Len(t, result, 2)
+
+	// the oldest event comes first with the time remaining reported by the mgr
+	assert.Equal(t, cephv1.ProgressEventStatus{ID: "a1", Message: "Global Recovery Event", Percent: 37, StartedAt: "2025-01-02T03:00:00Z", EstimatedTimeRemaining: "2h5m"}, result[0].status)
+	assert.Empty(t, result[0].pools)
+
+	// the time remaining is estimated from the progress since the start: 60s for 25%
+	assert.Equal(t, 25, result[1].status.Percent)
+	assert.Equal(t, "3m", result[1].status.EstimatedTimeRemaining)
+	assert.Equal(t, 3*time.Minute, *result[1].remaining)
+	assert.Equal(t, []int{2}, result[1].pools)
+
+	assert.Equal(t, "Global Recovery Event 37% (ETA 2h5m) and 1 more", progressSummary(result))
+	assert.Equal(t, "", progressSummary(nil))
+}
+
+func TestFormatTimeRemaining(t *testing.T) {
+	assert.Equal(t, "<1m", formatTimeRemaining(20*time.Second))
+	assert.Equal(t, "1m", formatTimeRemaining(50*time.Second))
+	assert.Equal(t, "59m", formatTimeRemaining(59*time.Minute))
+	assert.Equal(t, "2h0m", formatTimeRemaining(2*time.Hour))
+	assert.Equal(t, "26h3m", formatTimeRemaining(26*time.Hour+3*time.Minute))
+}
+
+func TestReportProgress(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("progress-ns")
+	pool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicapool", Namespace: "progress-ns"},
+		Status:     &cephv1.CephBlockPoolStatus{Phase: cephv1.ConditionReady, PoolID: 2},
+	}
+	otherPool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "progress-ns"},
+		Status:     &cephv1.CephBlockPoolStatus{Phase: cephv1.ConditionReady, PoolID: 3},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pool, otherPool).WithStatusSubresource(pool, otherPool).Build()
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "progress" && args[1] == "json" {
+				return progressJSON, nil
+			}
+			return "", nil
+		},
+	}
+	checker := &cephStatusChecker{context: &clusterd.Context{Client: client, Executor: executor}, clusterInfo: clusterInfo, client: client}
+	t.Cleanup(func() { deleteProgressMetrics("progress-ns") })
+
+	getPool := func(name string) *cephv1.CephBlockPool {
+		p := &cephv1.CephBlockPool{}
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: "progress-ns", Name: name}, p))
+		return p
+	}
+
+	// no progress event is queried when the ceph status reports none
+	assert.Nil(t, checker.getProgressEvents(&cephclient.CephStatus{}))
+
+	events := checker.getProgressEvents(&cephclient.CephStatus{ProgressEvents: map[string]cephclient.ProgressEventSummary{"a1": {}, "b1": {}}})
+	require.Len(t, events, 2)
+	checker.reportProgress(ctx, events)
+	assert.Equal(t, 2, testutil.CollectAndCount(progressEventRatio))
+	assert.Equal(t, 0.37, testutil.ToFloat64(progressEventRatio.WithLabelValues("progress-ns", "a1", "Global Recovery Event")))
+	assert.Equal(t, 7500.0, testutil.ToFloat64(progressEventRemaining.WithLabelValues("progress-ns", "a1", "Global Recovery Event")))
+
+	// only the pool the event refers to reports it
+	p := getPool("replicapool")
+	require.Len(t, p.Status.ProgressEvents, 1)
+	assert.Equal(t, "b1", p.Status.ProgressEvents[0].ID)
+	assert.Equal(t, cephv1.ConditionReady, p.Status.Phase)
+	assert.Empty(t, getPool("other").Status.ProgressEvents)
+
+	// the events are cleared once completed
+	checker.reportProgress(ctx, nil)
+	assert.Empty(t, getPool("replicapool").Status.ProgressEvents)
+	assert.Equal(t, 0, testutil.CollectAndCount(progressEventRatio))
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supportbundle generates the support bundles of a ceph cluster, with the sanitized
+// resources, the logs and the ceph status of the cluster.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "op-support-bundle")
+
+// logTailLines is the number of the last lines of the log of each container in the bundle
+var logTailLines = int64(10000)
+
+// the kinds of the resources of the cluster in the bundle, the secrets are never collected
+var cephKinds = []string{
+	"CephCluster",
+	"CephBlockPool",
+	"CephBlockPoolRadosNamespace",
+	"CephFilesystem",
+	"CephFilesystemSubVolumeGroup",
+	"CephFilesystemMirror",
+	"CephObjectStore",
+	"CephObjectStoreUser",
+	"CephObjectRealm",
+	"CephObjectZoneGroup",
+	"CephObjectZone",
+	"CephBucketTopic",
+	"CephBucketNotification",
+	"CephNFS",
+	"CephClient",
+	"CephRBDMirror",
+	"CephCOSIDriver",
+}
+
+// the ceph commands whose output is in the bundle
+var cephCommands = []struct {
+	name string
+	args []string
+}{
+	{"status", []string{"status"}},
+	{"health-detail", []string{"health", "detail"}},
+	{"osd-tree", []string{"osd", "tree"}},
+	{"osd-df", []string{"osd", "df"}},
+	{"versions", []string{"versions"}},
+	{"crash-ls", []string{"crash", "ls"}},
+}
+
+// collector writes the content of a support bundle to a tar archive
+type collector struct {
+	ctx         context.Context
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	namespace   string
+	// dir is the directory of the archive all the files are written to
+	dir  string
+	tar  *tar.Writer
+	time time.Time
+	// failures are the items that could not be collected, written to the bundle
+	failures []string
+}
+
+// bundleName returns the name of the bundle generated at the given time
+func bundleName(namespace string, generated time.Time) string {
+	return fmt.Sprintf("rook-ceph-support-bundle-%s-%s", namespace, generated.UTC().Format("20060102-150405"))
+}
+
+// writeBundle writes the support bundle of the cluster to w as a gzipped tar archive. The ceph
+// status is only collected if the cluster info is known. The items that cannot be collected are
+// listed in the failures.txt file of the bundle.
+func writeBundle(ctx context.Context, clusterdContext *clusterd.Context, clusterInfo *cephclient.ClusterInfo, namespace, name string, generated time.Time, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	c := &collector{
+		ctx:         ctx,
+		context:     clusterdContext,
+		clusterInfo: clusterInfo,
+		namespace:   namespace,
+		dir:         name,
+		tar:         tar.NewWriter(gz),
+		time:        generated,
+	}
+
+	collectors := []func() error{
+		c.collectResources,
+		func() error {
+			return c.collectLogs(namespace, fmt.Sprintf("%s=%s", k8sutil.ClusterAttr, namespace))
+		},
+		func() error {
+			operatorNamespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+			if operatorNamespace == "" {
+				return nil
+			}
+			return c.collectLogs(operatorNamespace, fmt.Sprintf("%s=rook-ceph-operator", k8sutil.AppAttr))
+		},
+		c.collectCephStatus,
+	}
+	for _, collect := range collectors {
+		if err := collect(); err != nil {
+			return err
+		}
+	}
+	if len(c.failures) > 0 {
+		if err := c.add("failures.txt", []byte(strings.Join(c.failures, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := c.tar.Close(); err != nil {
+		return errors.Wrap(err, "failed to close the support bundle archive")
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress the support bundle")
+	}
+	return nil
+}
+
+// add writes a redacted file to the bundle
+func (c *collector) add(name string, data []byte) error {
+	data = redact(data)
+	header := &tar.Header{
+		Name:    path.Join(c.dir, name),
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: c.time,
+	}
+	if err := c.tar.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed to add %q to the support bundle", name)
+	}
+	if _, err := c.tar.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to add %q to the support bundle", name)
+	}
+	return nil
+}
+
+func (c *collector) failed(item string, err error) {
+	logger.Warningf("failed to collect %s for the support bundle. %v", item, err)
+	c.failures = append(c.failures, fmt.Sprintf("%s: %v", item, err))
+}
+
+// collectResources adds the rook resources, the configmaps and the pods of the cluster namespace,
+// without their managed fields. The data of the configmaps not owned by rook and the literal env
+// values of the pods are redacted.
+func (c *collector) collectResources() error {
+	for _, kind := range cephKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(cephv1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := c.context.Client.List(c.ctx, list, client.InNamespace(c.namespace)); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			c.failed(fmt.Sprintf("the %s resources", kind), err)
+			continue
+		}
+		for _, item := range list.Items {
+			unstructured.RemoveNestedField(item.Object, "metadata", "managedFields")
+			if err := c.addYAML(fmt.Sprintf("resources/%s/%s.yaml", strings.ToLower(kind), item.GetName()), item.Object); err != nil {
+				return err
+			}
+		}
+	}
+
+	configMaps, err := c.context.Clientset.CoreV1().ConfigMaps(c.namespace).List(c.ctx, metav1.ListOptions{})
+	if err != nil {
+		c.failed("the configmaps", err)
+		return nil
+	}
+	for _, configMap := range configMaps.Items {
+		configMap.ManagedFields = nil
+		redactConfigMap(&configMap)
+		if err := c.addYAML(fmt.Sprintf("resources/configmap/%s.yaml", configMap.Name), configMap); err != nil {
+			return err
+		}
+	}
+
+	pods, err := c.context.Clientset.CoreV1().Pods(c.namespace).List(c.ctx, metav1.ListOptions{})
+	if err != nil {
+		c.failed("the pods", err)
+		return nil
+	}
+	for _, pod := range pods.Items {
+		pod.ManagedFields = nil
+		redactPod(&pod)
+		if err := c.addYAML(fmt.Sprintf("resources/pod/%s.yaml", pod.Name), pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *collector) addYAML(name string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		c.failed(name, err)
+		return nil
+	}
+	return c.add(name, data)
+}
+
+// collectLogs adds the last lines of the logs of the containers of the pods matching the selector
+func (c *collector) collectLogs(namespace, selector string) error {
+	pods, err := c.context.Clientset.CoreV1().Pods(namespace).List(c.ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		c.failed(fmt.Sprintf("the pods in namespace %q", namespace), err)
+		return nil
+	}
+	for _, pod := range pods.Items {
+		containers := []string{}
+		for _, container := range pod.Spec.InitContainers {
+			containers = append(containers, container.Name)
+		}
+		for _, container := range pod.Spec.Containers {
+			containers = append(containers, container.Name)
+		}
+		for _, container := range containers {
+			logs, err := c.context.Clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+				Container: container,
+				TailLines: &logTailLines,
+			}).DoRaw(c.ctx)
+			if err != nil {
+				c.failed(fmt.Sprintf("the logs of container %q of pod %q", container, pod.Name), err)
+				continue
+			}
+			if err := c.add(fmt.Sprintf("logs/%s/%s/%s.log", namespace, pod.Name, container), logs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// collectCephStatus adds the output of the ceph commands, including the crash reports
+func (c *collector) collectCephStatus() error {
+	if c.clusterInfo == nil {
+		c.failed("the ceph status", errors.New("the operator is not connected to the cluster"))
+		return nil
+	}
+	for _, command := range cephCommands {
+		output, err := cephclient.NewCephCommand(c.context, c.clusterInfo, command.args).Run()
+		if err != nil {
+			c.failed(fmt.Sprintf("the output of \"ceph %s\"", strings.Join(command.args, " ")), err)
+			continue
+		}
+		if err := c.add(fmt.Sprintf("ceph/%s.json", command.name), output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
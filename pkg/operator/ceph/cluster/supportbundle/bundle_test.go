@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ceph key", "key = AQBFp2Vm8kR3LhAAzbd0OvCSUu3ksW6IbjB1Bw==\n", "key = <redacted>\n"},
+		{"yaml secret", "secretKey: abc123\n", "secretKey: <redacted>\n"},
+		{"json password", `{"password":"hunter2","user":"admin"}`, `{"password":"<redacted>","user":"admin"}`},
+		{"access key", "access_key=XYZ", "access_key=<redacted>"},
+		{"secret name is kept", "secretName: rook-ceph-mon\n", "secretName: rook-ceph-mon\n"},
+		{"token ref is kept", "tokenSecretRef: vault-token\n", "tokenSecretRef: vault-token\n"},
+		{"no credential", "mon_host = 10.0.0.1\n", "mon_host = 10.0.0.1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(redact([]byte(tt.input))))
+		})
+	}
+}
+
+func TestParseDestination(t *testing.T) {
+	kind, name, err := parseDestination("pvc/bundles")
+	assert.NoError(t, err)
+	assert.Equal(t, pvcDestination, kind)
+	assert.Equal(t, "bundles", name)
+
+	kind, name, err = parseDestination("s3/bundle-bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, s3Destination, kind)
+	assert.Equal(t, "bundle-bucket", name)
+
+	for _, destination := range []string{"bundles", "pvc/", "nfs/bundles", "pvc/a/b"} {
+		_, _, err = parseDestination(destination)
+		assert.Error(t, err, destination)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "bundle-ns"
+	t.Setenv(k8sutil.PodNamespaceEnvVar, "rook-ceph-system")
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: namespace}}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).Build()
+
+	clientset := fake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "rook-ceph-config",
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "ceph.rook.io/v1", Kind: "CephCluster", Name: "my-cluster"}},
+			},
+			Data: map[string]string{"config": "[global]\nkey = AQBFp2Vm8kR3LhAAzbd0OvCSUu3ksW6IbjB1Bw==\nmon_host = 10.0.0.1\n"},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-settings", Namespace: namespace},
+			Data:       map[string]string{"endpoint": "https://backup.example.com?auth=c3VwZXJzZWNyZXQ"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon-a", Namespace: namespace, Labels: map[string]string{k8sutil.ClusterAttr: namespace}},
+			Spec: v1.PodSpec{Containers: []v1.Container{{
+				Name: "mon",
+				Env: []v1.EnvVar{
+					{Name: "S3_CREDENTIAL", Value: "hunter2"},
+					{Name: "ROOK_CEPH_MON_SECRET", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "rook-ceph-mon"},
+						Key:                  "mon-secret",
+					}}},
+				},
+			}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-operator", Namespace: "rook-ceph-system", Labels: map[string]string{k8sutil.AppAttr: "rook-ceph-operator"}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "rook-ceph-operator"}}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon", Namespace: namespace},
+			Data:       map[string][]byte{"mon-secret": []byte("secret")},
+		},
+	)
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			return "{}", nil
+		},
+	}
+	context := &clusterd.Context{Client: client, Clientset: clientset, Executor: executor}
+
+	read := func(t *testing.T, buf *bytes.Buffer) map[string]string {
+		gz, err := gzip.NewReader(buf)
+		assert.NoError(t, err)
+		reader := tar.NewReader(gz)
+		files := map[string]string{}
+		for {
+			header, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			data, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			files[header.Name] = string(data)
+		}
+		return files
+	}
+
+	t.Run("full bundle", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeBundle(ctx, context, cephclient.AdminTestClusterInfo(namespace), namespace, "bundle", time.Now(), buf)
+		assert.NoError(t, err)
+		files := read(t, buf)
+
+		assert.Contains(t, files, "bundle/resources/cephcluster/my-cluster.yaml")
+		assert.Contains(t, files, "bundle/resources/pod/rook-ceph-mon-a.yaml")
+		assert.Contains(t, files, "bundle/logs/bundle-ns/rook-ceph-mon-a/mon.log")
+		assert.Contains(t, files, "bundle/logs/rook-ceph-system/rook-ceph-operator/rook-ceph-operator.log")
+		for _, command := range cephCommands {
+			assert.Contains(t, files, "bundle/ceph/"+command.name+".json")
+		}
+		assert.NotContains(t, files, "bundle/failures.txt")
+
+		// the keys are redacted and the secrets are not collected
+		config := files["bundle/resources/configmap/rook-ceph-config.yaml"]
+		assert.Contains(t, config, "key = <redacted>")
+		assert.NotContains(t, config, "AQBFp2Vm8kR3LhAAzbd0OvCSUu3ksW6IbjB1Bw==")
+		assert.Contains(t, config, "mon_host = 10.0.0.1")
+		for name, data := range files {
+			assert.NotContains(t, data, "hunter2", name)
+			assert.NotContains(t, data, "c3VwZXJzZWNyZXQ", name)
+		}
+
+		// the literal env values are redacted, the references to the secrets are kept
+		pod := files["bundle/resources/pod/rook-ceph-mon-a.yaml"]
+		assert.Contains(t, pod, "S3_CREDENTIAL")
+		assert.Contains(t, pod, "value: <redacted>")
+		assert.Contains(t, pod, "name: rook-ceph-mon")
+
+		// the data of the configmaps not owned by rook is redacted
+		assert.Contains(t, files["bundle/resources/configmap/backup-settings.yaml"], "endpoint: <redacted>")
+		for name := range files {
+			assert.NotContains(t, name, "secret")
+		}
+	})
+
+	t.Run("ceph status is not collected without the cluster info", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeBundle(ctx, context, nil, namespace, "bundle", time.Now(), buf)
+		assert.NoError(t, err)
+		files := read(t, buf)
+
+		assert.NotContains(t, files, "bundle/ceph/status.json")
+		assert.Contains(t, files["bundle/failures.txt"], "the ceph status")
+		assert.Contains(t, files, "bundle/resources/cephcluster/my-cluster.yaml")
+	})
+}
+
+func TestGenerateIfRequested(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "bundle-ns"
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:        "my-cluster",
+		Namespace:   namespace,
+		Annotations: map[string]string{Annotation: "nfs/bundles"},
+	}}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	context := &clusterd.Context{Client: client, Clientset: fake.NewSimpleClientset()}
+
+	// the bundle is generated in the background and the failure is published in the status
+	GenerateIfRequested(ctx, context, cephCluster, nil)
+	nsName := types.NamespacedName{Namespace: namespace, Name: cephCluster.Name}
+	updated := &cephv1.CephCluster{}
+	assert.Eventually(t, func() bool {
+		assert.NoError(t, client.Get(ctx, nsName, updated))
+		return updated.Status.SupportBundle != nil && len(updated.Annotations) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "nfs/bundles", updated.Status.SupportBundle.Destination)
+	assert.Contains(t, updated.Status.SupportBundle.Message, "invalid support bundle destination")
+	assert.Eventually(t, func() bool {
+		generatingLock.Lock()
+		defer generatingLock.Unlock()
+		return !generating[namespace]
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// a bundle is not generated twice while in progress
+	generatingLock.Lock()
+	generating[namespace] = true
+	generatingLock.Unlock()
+	updated.Annotations = map[string]string{Annotation: "nfs/other"}
+	GenerateIfRequested(ctx, context, updated, nil)
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, client.Get(ctx, nsName, updated))
+	assert.Equal(t, "nfs/bundles", updated.Status.SupportBundle.Destination)
+	generatingLock.Lock()
+	delete(generating, namespace)
+	generatingLock.Unlock()
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/exec"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// copyPodName is the name of the pod mounting the PVC the bundle is copied to
+	copyPodName = "rook-ceph-support-bundle"
+	bundleDir   = "/bundle"
+
+	// the keys of the secret configuring the bucket the bundle is uploaded to
+	endpointKey  = "endpoint"
+	bucketKey    = "bucket"
+	accessKeyKey = "accessKey"
+	secretKeyKey = "secretKey"
+	caBundleKey  = "caBundle"
+)
+
+var copyPodTimeout = 5 * time.Minute
+
+// copyToPVC copies the bundle to the PVC with a pod mounting the PVC
+func copyToPVC(ctx context.Context, clusterdContext *clusterd.Context, namespace, claim, image, name string, bundle io.Reader) error {
+	if clusterdContext.RemoteExecutor.ClientSet == nil {
+		return errors.New("cannot copy the support bundle without a remote executor")
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      copyPodName,
+			Namespace: namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: copyPodName},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:    "copy",
+					Image:   image,
+					Command: []string{"sleep", "3600"},
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "bundle", MountPath: bundleDir},
+					},
+				},
+			},
+			Volumes: []v1.Volume{
+				{
+					Name: "bundle",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+	pods := clusterdContext.Clientset.CoreV1().Pods(namespace)
+	// a pod left by a previous request that failed to be deleted is replaced
+	if err := pods.Delete(ctx, copyPodName, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete the previous pod %q", copyPodName)
+	}
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, copyPodTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+		if kerrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the pod %q to copy the support bundle to PVC %q", copyPodName, claim)
+	}
+	defer func() {
+		if err := pods.Delete(ctx, copyPodName, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to delete the pod %q copying the support bundle. %v", copyPodName, err)
+		}
+	}()
+
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, copyPodTimeout, true, func(ctx context.Context) (bool, error) {
+		p, err := pods.Get(ctx, copyPodName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return p.Status.Phase == v1.PodRunning, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "the pod %q to copy the support bundle to PVC %q is not running", copyPodName, claim)
+	}
+
+	_, stderr, err := clusterdContext.RemoteExecutor.ExecWithOptions(ctx, exec.ExecOptions{
+		Command:       []string{"sh", "-c", fmt.Sprintf("cat > %s/%s", bundleDir, name)},
+		Namespace:     namespace,
+		PodName:       copyPodName,
+		ContainerName: "copy",
+		Stdin:         bundle,
+		CaptureStdout: true,
+		CaptureStderr: true,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy the support bundle to PVC %q. %s", claim, stderr)
+	}
+	return nil
+}
+
+// uploadToS3 uploads the bundle to the bucket configured in the secret
+func uploadToS3(ctx context.Context, clusterdContext *clusterd.Context, namespace, secretName, name string, bundle io.ReadSeeker) error {
+	secret, err := clusterdContext.Clientset.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the secret %q of the support bundle bucket", secretName)
+	}
+	for _, key := range []string{endpointKey, bucketKey, accessKeyKey, secretKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return errors.Errorf("the secret %q of the support bundle bucket has no %q", secretName, key)
+		}
+	}
+
+	s3Agent, err := object.NewS3Agent(string(secret.Data[accessKeyKey]), string(secret.Data[secretKeyKey]), string(secret.Data[endpointKey]), false, secret.Data[caBundleKey], false, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the s3 client of the support bundle bucket")
+	}
+	_, err = s3Agent.Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(string(secret.Data[bucketKey])),
+		Key:         aws.String(name),
+		Body:        bundle,
+		ContentType: aws.String("application/gzip"),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload the support bundle to bucket %q", string(secret.Data[bucketKey]))
+	}
+	return nil
+}
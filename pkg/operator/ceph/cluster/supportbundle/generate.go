@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotation is set on the CephCluster to generate a support bundle, with the destination of the
+// bundle: "pvc/<claim>" to copy it to a PVC of the cluster namespace, or "s3/<secret>" to upload it
+// to the bucket configured in a secret of the cluster namespace
+const Annotation = "ceph.rook.io/support-bundle"
+
+const (
+	pvcDestination = "pvc"
+	s3Destination  = "s3"
+)
+
+var (
+	// generating are the namespaces of the clusters whose support bundle is being generated
+	generating     = map[string]bool{}
+	generatingLock sync.Mutex
+)
+
+// GenerateIfRequested starts generating the support bundle requested by the annotation of the
+// CephCluster. The bundle is generated in the background since collecting the logs and storing the
+// bundle may take minutes. Once stored at the requested destination, the result is published in the
+// CephCluster status and the annotation is removed. The ceph status is only collected if the cluster
+// info is given.
+func GenerateIfRequested(ctx context.Context, clusterdContext *clusterd.Context, cephCluster *cephv1.CephCluster, clusterInfo *cephclient.ClusterInfo) {
+	destination := strings.TrimSpace(cephCluster.Annotations[Annotation])
+	if destination == "" {
+		return
+	}
+
+	namespace := cephCluster.Namespace
+	generatingLock.Lock()
+	defer generatingLock.Unlock()
+	if generating[namespace] {
+		logger.Debugf("the support bundle of cluster %q is already being generated", namespace)
+		return
+	}
+	generating[namespace] = true
+
+	nsName := types.NamespacedName{Namespace: namespace, Name: cephCluster.Name}
+	cephCluster = cephCluster.DeepCopy()
+	go func() {
+		defer func() {
+			generatingLock.Lock()
+			delete(generating, namespace)
+			generatingLock.Unlock()
+		}()
+
+		status := generate(ctx, clusterdContext, cephCluster, destination, clusterInfo)
+		if status.Message != "" {
+			logger.Errorf("failed to generate the support bundle of cluster %q. %s", namespace, status.Message)
+		} else {
+			logger.Infof("generated the support bundle %q of cluster %q to %q", status.Name, namespace, destination)
+		}
+		if err := publish(ctx, clusterdContext.Client, nsName, destination, status); err != nil {
+			logger.Errorf("failed to publish the support bundle of cluster %q. %v", namespace, err)
+		}
+	}()
+}
+
+// publish updates the support bundle in the CephCluster status and removes the annotation, unless
+// it was changed to request another bundle in the meantime
+func publish(ctx context.Context, c client.Client, nsName types.NamespacedName, destination string, status *cephv1.SupportBundleStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := c.Get(ctx, nsName, cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve ceph cluster %q to publish the support bundle", nsName.String())
+		}
+
+		cephCluster.Status.SupportBundle = status
+		if err := reporting.UpdateStatus(c, cephCluster); err != nil {
+			return errors.Wrap(err, "failed to publish the support bundle in the CephCluster status")
+		}
+
+		// the bundle is generated once per request
+		if strings.TrimSpace(cephCluster.Annotations[Annotation]) != destination {
+			return nil
+		}
+		original := cephCluster.DeepCopy()
+		delete(cephCluster.Annotations, Annotation)
+		if err := c.Patch(ctx, cephCluster, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "failed to remove the %q annotation from the CephCluster", Annotation)
+		}
+		return nil
+	})
+}
+
+// generate writes the bundle to a temporary file and stores it at the destination
+func generate(ctx context.Context, clusterdContext *clusterd.Context, cephCluster *cephv1.CephCluster, destination string, clusterInfo *cephclient.ClusterInfo) *cephv1.SupportBundleStatus {
+	namespace := cephCluster.Namespace
+	generated := time.Now()
+	status := &cephv1.SupportBundleStatus{
+		Destination:   destination,
+		Name:          bundleName(namespace, generated) + ".tar.gz",
+		GeneratedTime: metav1.NewTime(generated),
+	}
+
+	kind, name, err := parseDestination(destination)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+
+	file, err := os.CreateTemp("", "rook-ceph-support-bundle-*.tar.gz")
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to create the support bundle file. %v", err)
+		return status
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	if clusterInfo != nil && clusterInfo.IsInitialized() != nil {
+		clusterInfo = nil
+	}
+	if err := writeBundle(ctx, clusterdContext, clusterInfo, namespace, bundleName(namespace, generated), generated, file); err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		status.Message = fmt.Sprintf("failed to read the support bundle file. %v", err)
+		return status
+	}
+
+	switch kind {
+	case pvcDestination:
+		err = copyToPVC(ctx, clusterdContext, namespace, name, cephCluster.Spec.CephVersion.Image, status.Name, file)
+	case s3Destination:
+		err = uploadToS3(ctx, clusterdContext, namespace, name, status.Name, file)
+	}
+	if err != nil {
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// parseDestination returns the kind and the name of the destination of the bundle
+func parseDestination(destination string) (string, string, error) {
+	kind, name, found := strings.Cut(destination, "/")
+	if !found || name == "" || strings.Contains(name, "/") || (kind != pvcDestination && kind != s3Destination) {
+		return "", "", errors.Errorf("invalid support bundle destination %q, expected \"pvc/<claim>\" or \"s3/<secret>\"", destination)
+	}
+	return kind, name, nil
+}
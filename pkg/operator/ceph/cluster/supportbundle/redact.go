@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"regexp"
+	"strings"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const redacted = "<redacted>"
+
+var (
+	// cephKeyRegex matches the base64 encoding of the ceph keys
+	cephKeyRegex = regexp.MustCompile(`AQ[A-Za-z0-9+/]{36}==`)
+
+	// credentialRegex matches the values of the fields whose name looks like a credential, in the
+	// yaml, json and ceph config formats
+	credentialRegex = regexp.MustCompile(`(?i)([\w.-]*(?:secret|password|passwd|token|access[_-]?key)[\w.-]*)(["']?[ \t]*[:=][ \t]*["']?)([^"'\s,}\]]+)`)
+)
+
+// redact replaces the ceph keys and the credentials in the data
+func redact(data []byte) []byte {
+	data = cephKeyRegex.ReplaceAll(data, []byte(redacted))
+	return credentialRegex.ReplaceAllFunc(data, func(match []byte) []byte {
+		groups := credentialRegex.FindSubmatch(match)
+		name := strings.ToLower(string(groups[1]))
+		// the fields naming or referencing a secret do not contain the credential itself
+		if strings.HasSuffix(name, "name") || strings.HasSuffix(name, "ref") {
+			return match
+		}
+		return []byte(string(groups[1]) + string(groups[2]) + redacted)
+	})
+}
+
+// redactPod replaces the literal values of the env vars of the containers of the pod, which may
+// hold credentials under any name
+func redactPod(pod *v1.Pod) {
+	for i := range pod.Spec.InitContainers {
+		redactEnv(pod.Spec.InitContainers[i].Env)
+	}
+	for i := range pod.Spec.Containers {
+		redactEnv(pod.Spec.Containers[i].Env)
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		redactEnv(pod.Spec.EphemeralContainers[i].Env)
+	}
+}
+
+func redactEnv(env []v1.EnvVar) {
+	for i := range env {
+		if env[i].Value != "" {
+			env[i].Value = redacted
+		}
+	}
+}
+
+// redactConfigMap replaces the data of the configmaps not owned by a rook resource. The configmaps
+// created by other applications of the namespace may hold credentials under any key.
+func redactConfigMap(configMap *v1.ConfigMap) {
+	for _, owner := range configMap.OwnerReferences {
+		if strings.HasPrefix(owner.APIVersion, cephv1.CustomResourceGroup+"/") {
+			return
+		}
+	}
+	for key := range configMap.Data {
+		configMap.Data[key] = redacted
+	}
+	for key := range configMap.BinaryData {
+		configMap.BinaryData[key] = []byte(redacted)
+	}
+}
@@ -18,12 +18,22 @@ limitations under the License.
 package cluster
 
 import (
+	"reflect"
+
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	daemonclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The reasons of the events recorded on the CephCluster at the end of an upgrade
+const (
+	CephUpgradeCompletedReason  = "CephUpgradeCompleted"
+	CephUpgradeIncompleteReason = "CephUpgradeIncomplete"
 )
 
 func (c *ClusterController) detectAndValidateCephVersion(cluster *cluster) (*cephver.CephVersion, bool, error) {
@@ -67,11 +77,31 @@ func (c *cluster) printOverallCephVersion() {
 			}
 			vv := *version
 			logger.Infof("successfully upgraded cluster to version: %q", vv.String())
+			c.recordEvent(v1.EventTypeNormal, CephUpgradeCompletedReason, "upgraded the cluster to ceph version %q", vv.String())
 		}
 	} else {
 		// This shouldn't happen, but let's log just in case
 		logger.Warningf("upgrade orchestration completed but somehow we still have more than one Ceph version running. %v:", versions.Overall)
+		c.recordEvent(v1.EventTypeWarning, CephUpgradeIncompleteReason, "the upgrade completed with more than one ceph version running: %v", versions.Overall)
+	}
+}
+
+// recordEvent records an event on the CephCluster, like the events of the mon health
+func (c *cluster) recordEvent(eventType, reason, messageFmt string, args ...interface{}) {
+	if c.recorder == nil {
+		return
+	}
+	cephCluster := &cephv1.CephCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       reflect.TypeOf(cephv1.CephCluster{}).Name(),
+			APIVersion: cephv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: c.namespacedName.Name, Namespace: c.namespacedName.Namespace},
+	}
+	if c.ownerInfo != nil {
+		cephCluster.UID = c.ownerInfo.GetUID()
 	}
+	c.recorder.Eventf(cephCluster, eventType, reason, messageFmt, args...)
 }
 
 // This function compare the Ceph spec image and the cluster running version
@@ -85,13 +85,13 @@ var (
 	VarLibCephCrashDir = path.Join(VarLibCephDir, "crash")
 )
 
-// normalizeKey converts a key in any format to a key with underscores.
+// NormalizeKey converts a key in any format to a key with underscores.
 //
 // The internal representation of Ceph config keys uses underscores only, where Ceph supports both
 // spaces, underscores, and hyphens. This is so that Rook can properly match and override keys even
 // when they are specified as "some config key" in one section, "some_config_key" in another
 // section, and "some-config-key" in yet another section.
-func normalizeKey(key string) string {
+func NormalizeKey(key string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(key, " ", "_"), "-", "_")
 }
 
@@ -99,7 +99,7 @@ func normalizeKey(key string) string {
 func NewFlag(key, value string) string {
 	// A flag is a normalized key with underscores replaced by dashes.
 	// "debug default" ~normalize~> "debug_default" ~to~flag~> "debug-default"
-	n := normalizeKey(key)
+	n := NormalizeKey(key)
 	f := strings.ReplaceAll(n, "_", "-")
 	return fmt.Sprintf("--%s=%s", f, value)
 }
@@ -139,6 +139,16 @@ func SetOrRemoveDefaultConfigs(
 		}
 	}
 
+	// The new OSDs join with a CRUSH weight of 0 when their weight is ramped up, setting the option in
+	// the mon store does not restart the existing OSDs
+	if clusterSpec.Storage.WeightRampUp != nil && clusterSpec.Storage.WeightRampUp.Enabled {
+		if err := monStore.Set("osd", "osd_crush_initial_weight", "0"); err != nil {
+			return errors.Wrap(err, "failed to set the initial crush weight of the new osds")
+		}
+	} else if err := monStore.Delete("osd", "osd_crush_initial_weight"); err != nil {
+		return errors.Wrap(err, "failed to remove the initial crush weight of the new osds")
+	}
+
 	// This section will remove any previously configured option(s) from the mon centralized store
 	// This is useful for scenarios where options are not needed anymore and we just want to reset to internal's default
 	// On upgrade, the flag will be removed
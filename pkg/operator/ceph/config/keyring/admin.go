@@ -18,6 +18,7 @@ package keyring
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/pkg/errors"
 	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
@@ -74,11 +75,20 @@ func ApplyClusterMetadataToSecret(c *cephclient.ClusterInfo, secretName string,
 		return errors.Wrapf(err, "failed to get %s secrets", secretName)
 	}
 	// We would need to reset the annotations back to empty, then reapply the annotations this is because the in some rook-ceph-mon secret is retrieved
-	// and then updated, instead of a new secret being generated.
-	secret.Annotations = map[string]string{}
-	v1.GetClusterMetadataAnnotations(annotation).ApplyToObjectMeta(&secret.ObjectMeta)
+	// and then updated, instead of a new secret being generated. The content hash of the keyring is kept.
+	annotations := map[string]string{}
+	if hash, ok := secret.Annotations[ContentHashAnnotation]; ok {
+		annotations[ContentHashAnnotation] = hash
+	}
+	meta := metav1.ObjectMeta{Annotations: annotations}
+	v1.GetClusterMetadataAnnotations(annotation).ApplyToObjectMeta(&meta)
+	if (len(secret.Annotations) == 0 && len(meta.Annotations) == 0) || reflect.DeepEqual(secret.Annotations, meta.Annotations) {
+		secretWritesAvoided.WithLabelValues(c.Namespace, avoidedUnchanged).Inc()
+		return nil
+	}
+	secret.Annotations = meta.Annotations
 
-	_, err = context.Clientset.CoreV1().Secrets(c.Namespace).Update(c.Context, secret, metav1.UpdateOptions{})
+	_, err = writer.write(c.Context, context.Clientset, secret, false)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update %s secret.", secretName)
 	}
@@ -61,7 +61,9 @@ func TestAdminKeyringStore(t *testing.T) {
 	// test annotation
 	secret, err := ctx.Clientset.CoreV1().Secrets(clusterInfo.Namespace).Get(clusterInfo.Context, keyringSecretName(adminKeyringResourceName), metav1.GetOptions{})
 	assert.NoError(t, err)
-	assert.Equal(t, map[string]string{"key": "value"}, secret.Annotations)
+	assert.Len(t, secret.Annotations, 2)
+	assert.Equal(t, "value", secret.Annotations["key"])
+	assert.NotEmpty(t, secret.Annotations[ContentHashAnnotation])
 
 	// update key
 	clusterInfo.CephCred.Secret = "differentsecretkey"
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyring
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "rook_ceph"
+	metricsSubsystem = "keyring"
+
+	// avoidedUnchanged is the reason of the writes avoided because the secret did not change
+	avoidedUnchanged = "unchanged"
+	// avoidedCoalesced is the reason of the writes replaced by a later write of the same secret
+	avoidedCoalesced = "coalesced"
+)
+
+// The metrics of the keyring secret writes, exposed on the metrics endpoint of the operator
+var (
+	secretWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "secret_writes_total",
+		Help:      "Number of keyring secrets created or updated",
+	}, []string{"namespace"})
+
+	secretWritesAvoided = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "secret_writes_avoided_total",
+		Help:      "Number of keyring secret writes avoided by reason",
+	}, []string{"namespace", "reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(secretWrites, secretWritesAvoided)
+}
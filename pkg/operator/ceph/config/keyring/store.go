@@ -125,27 +125,36 @@ func (k *SecretStore) Delete(resourceName string) error {
 	return nil
 }
 
-// CreateSecret creates or update a kubernetes secret.
+// CreateSecret creates or update a kubernetes secret. The secret is not written if its content did
+// not change, and is otherwise queued to be written at a limited rate by the keyring secret writer.
 // Returns the resource version of the secret.
 func (k *SecretStore) CreateSecret(secret *v1.Secret) (string, error) {
 	secretName := secret.ObjectMeta.Name
-	_, err := k.context.Clientset.CoreV1().Secrets(k.clusterInfo.Namespace).Get(k.clusterInfo.Context, secretName, metav1.GetOptions{})
+	secret = withContentHash(secret)
+	secret.Namespace = k.clusterInfo.Namespace
+	existing, err := k.context.Clientset.CoreV1().Secrets(k.clusterInfo.Namespace).Get(k.clusterInfo.Context, secretName, metav1.GetOptions{})
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			logger.Debugf("creating secret for %s", secretName)
-			s, err := k.context.Clientset.CoreV1().Secrets(k.clusterInfo.Namespace).Create(k.clusterInfo.Context, secret, metav1.CreateOptions{})
+			resourceVersion, err := writer.write(k.clusterInfo.Context, k.context.Clientset, secret, true)
 			if err != nil {
 				return "", errors.Wrapf(err, "failed to create secret for %s", secretName)
 			}
-			return s.ResourceVersion, nil
+			return resourceVersion, nil
 		}
 		return "", errors.Wrapf(err, "failed to get secret for %s", secretName)
 	}
 
+	if secretUnchanged(existing, secret) {
+		logger.Debugf("secret for %s is unchanged", secretName)
+		secretWritesAvoided.WithLabelValues(k.clusterInfo.Namespace, avoidedUnchanged).Inc()
+		return existing.ResourceVersion, nil
+	}
+
 	logger.Debugf("updating secret for %s", secretName)
-	s, err := k.context.Clientset.CoreV1().Secrets(k.clusterInfo.Namespace).Update(k.clusterInfo.Context, secret, metav1.UpdateOptions{})
+	resourceVersion, err := writer.write(k.clusterInfo.Context, k.context.Clientset, secret, false)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to update secret for %s", secretName)
 	}
-	return s.ResourceVersion, nil
+	return resourceVersion, nil
 }
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyring
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// ContentHashAnnotation records the hash of the content of a secret written by the operator, so
+	// that the secret is not written again when its content did not change
+	ContentHashAnnotation = "ceph.rook.io/content-hash"
+
+	// the secrets are written at a limited rate to spare etcd when many daemons are reconciled
+	secretWriteQPS   = 10
+	secretWriteBurst = 100
+)
+
+// writer writes the keyring secrets of all the clusters
+var writer = newSecretWriter(secretWriteQPS, secretWriteBurst)
+
+// secretKey identifies a secret queued to be written
+type secretKey struct {
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// secretWrite is a queued write of a secret, shared by all the callers writing the secret until it
+// is written
+type secretWrite struct {
+	ctx    context.Context
+	secret *v1.Secret
+	create bool
+
+	done            chan struct{}
+	resourceVersion string
+	err             error
+}
+
+// secretWriter writes the queued secrets one at a time at a limited rate. The writes of a secret
+// queued before the secret is written are coalesced into a single write of the latest content.
+type secretWriter struct {
+	limiter flowcontrol.RateLimiter
+	start   sync.Once
+	ready   chan struct{}
+
+	mu      sync.Mutex
+	pending map[secretKey]*secretWrite
+	queue   []secretKey
+}
+
+func newSecretWriter(qps float32, burst int) *secretWriter {
+	return &secretWriter{
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		ready:   make(chan struct{}, 1),
+		pending: map[secretKey]*secretWrite{},
+	}
+}
+
+// write queues the creation or the update of the secret and waits until it is written. Returns the
+// resource version of the secret.
+func (w *secretWriter) write(ctx context.Context, clientset kubernetes.Interface, secret *v1.Secret, create bool) (string, error) {
+	w.start.Do(func() { go w.run() })
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	key := secretKey{clientset: clientset, namespace: secret.Namespace, name: secret.Name}
+
+	w.mu.Lock()
+	pending, ok := w.pending[key]
+	if ok {
+		logger.Debugf("coalescing the queued write of secret %q", secret.Name)
+		secretWritesAvoided.WithLabelValues(secret.Namespace, avoidedCoalesced).Inc()
+		pending.ctx = ctx
+		pending.secret = secret
+		pending.create = create
+	} else {
+		pending = &secretWrite{ctx: ctx, secret: secret, create: create, done: make(chan struct{})}
+		w.pending[key] = pending
+		w.queue = append(w.queue, key)
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-pending.done:
+		return pending.resourceVersion, pending.err
+	case <-ctx.Done():
+		return "", errors.Wrapf(ctx.Err(), "failed to wait for secret %q to be written", secret.Name)
+	}
+}
+
+func (w *secretWriter) run() {
+	for range w.ready {
+		for {
+			w.mu.Lock()
+			if len(w.queue) == 0 {
+				w.mu.Unlock()
+				break
+			}
+			w.mu.Unlock()
+
+			// the writes of the secret are still coalesced while waiting for the limiter
+			_ = w.limiter.Wait(context.Background())
+
+			w.mu.Lock()
+			key := w.queue[0]
+			w.queue = w.queue[1:]
+			pending := w.pending[key]
+			delete(w.pending, key)
+			w.mu.Unlock()
+
+			pending.resourceVersion, pending.err = writeSecret(pending.ctx, key.clientset, pending.secret, pending.create)
+			close(pending.done)
+		}
+	}
+}
+
+// writeSecret creates or updates the secret
+func writeSecret(ctx context.Context, clientset kubernetes.Interface, secret *v1.Secret, create bool) (string, error) {
+	secrets := clientset.CoreV1().Secrets(secret.Namespace)
+	var s *v1.Secret
+	var err error
+	if create {
+		s, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		if kerrors.IsAlreadyExists(err) {
+			s, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		}
+	} else {
+		s, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		if kerrors.IsNotFound(err) {
+			s, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	secretWrites.WithLabelValues(secret.Namespace).Inc()
+	return s.ResourceVersion, nil
+}
+
+// secretData returns the data of the secret, including the string data not converted by the API
+// server yet
+func secretData(secret *v1.Secret) map[string][]byte {
+	data := map[string][]byte{}
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	return data
+}
+
+// contentHash returns the hash of the content of the secret written by the operator
+func contentHash(secret *v1.Secret) string {
+	annotations := map[string]string{}
+	for k, v := range secret.Annotations {
+		if k != ContentHashAnnotation {
+			annotations[k] = v
+		}
+	}
+	content := struct {
+		Type            v1.SecretType
+		Labels          map[string]string
+		Annotations     map[string]string
+		OwnerReferences []metav1.OwnerReference
+		Data            map[string][]byte
+	}{secret.Type, secret.Labels, annotations, secret.OwnerReferences, secretData(secret)}
+	// the keys of the maps are sorted by the encoding, so the hash is stable
+	raw, _ := json.Marshal(content)
+	return fmt.Sprintf("%x", sha256.Sum256(raw))
+}
+
+// withContentHash returns a copy of the secret annotated with the hash of its content
+func withContentHash(secret *v1.Secret) *v1.Secret {
+	secret = secret.DeepCopy()
+	hash := contentHash(secret)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[ContentHashAnnotation] = hash
+	return secret
+}
+
+// secretUnchanged returns whether the existing secret was written with the content of the desired
+// secret annotated with its hash. The data is compared as well in case it was changed by the user.
+func secretUnchanged(existing, desired *v1.Secret) bool {
+	hash, ok := existing.Annotations[ContentHashAnnotation]
+	if !ok || hash != desired.Annotations[ContentHashAnnotation] {
+		return false
+	}
+	return reflect.DeepEqual(secretData(existing), secretData(desired))
+}
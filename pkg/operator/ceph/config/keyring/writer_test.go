@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+func countWrites(clientset *fake.Clientset) int {
+	writes := 0
+	for _, action := range clientset.Actions() {
+		if action.GetResource().Resource == "secrets" && (action.GetVerb() == "create" || action.GetVerb() == "update") {
+			writes++
+		}
+	}
+	return writes
+}
+
+func TestCreateSecretUnchanged(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	ownerInfo := cephclient.NewMinimumOwnerInfoWithOwnerRef()
+	k := GetSecretStore(&clusterd.Context{Clientset: clientset}, &cephclient.ClusterInfo{Namespace: "ns", Context: context.TODO()}, ownerInfo)
+
+	_, err := k.CreateOrUpdate("test-resource", "qwertyuiop")
+	require.NoError(t, err)
+	assert.Equal(t, 1, countWrites(clientset))
+
+	// the same keyring is not written again
+	_, err = k.CreateOrUpdate("test-resource", "qwertyuiop")
+	require.NoError(t, err)
+	assert.Equal(t, 1, countWrites(clientset))
+
+	// a changed keyring is written
+	_, err = k.CreateOrUpdate("test-resource", "asdfghjkl")
+	require.NoError(t, err)
+	assert.Equal(t, 2, countWrites(clientset))
+
+	// the keyring changed by the user is written again
+	secret, err := clientset.CoreV1().Secrets("ns").Get(context.TODO(), "test-resource-keyring", metav1.GetOptions{})
+	require.NoError(t, err)
+	secret.StringData[keyringFileName] = "changed"
+	_, err = clientset.CoreV1().Secrets("ns").Update(context.TODO(), secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = k.CreateOrUpdate("test-resource", "asdfghjkl")
+	require.NoError(t, err)
+	assert.Equal(t, 4, countWrites(clientset))
+	secret, err = clientset.CoreV1().Secrets("ns").Get(context.TODO(), "test-resource-keyring", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "asdfghjkl", secret.StringData[keyringFileName])
+}
+
+// blockingRateLimiter blocks the writes until released
+type blockingRateLimiter struct {
+	flowcontrol.RateLimiter
+	release chan struct{}
+}
+
+func (l *blockingRateLimiter) Wait(ctx context.Context) error {
+	<-l.release
+	return nil
+}
+
+func TestSecretWriterCoalesces(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	limiter := &blockingRateLimiter{release: make(chan struct{})}
+	w := newSecretWriter(1, 1)
+	w.limiter = limiter
+
+	newSecret := func(keyring string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-keyring", Namespace: "ns"},
+			StringData: map[string]string{keyringFileName: keyring},
+		}
+	}
+	type result struct {
+		resourceVersion string
+		err             error
+	}
+	write := func(secret *v1.Secret) chan result {
+		results := make(chan result, 1)
+		go func() {
+			resourceVersion, err := w.write(context.TODO(), clientset, secret, true)
+			results <- result{resourceVersion, err}
+		}()
+		return results
+	}
+	queued := func(keyring string) func() bool {
+		return func() bool {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			pending, ok := w.pending[secretKey{clientset: clientset, namespace: "ns", name: "test-keyring"}]
+			return ok && pending.secret.StringData[keyringFileName] == keyring
+		}
+	}
+
+	// the second write replaces the first one queued while the writer is rate limited
+	first := write(newSecret("first"))
+	require.Eventually(t, queued("first"), 5*time.Second, 10*time.Millisecond)
+	second := write(newSecret("second"))
+	require.Eventually(t, queued("second"), 5*time.Second, 10*time.Millisecond)
+	close(limiter.release)
+
+	r1, r2 := <-first, <-second
+	require.NoError(t, r1.err)
+	require.NoError(t, r2.err)
+	assert.Equal(t, r1.resourceVersion, r2.resourceVersion)
+	assert.Equal(t, 1, countWrites(clientset))
+	secret, err := clientset.CoreV1().Secrets("ns").Get(context.TODO(), "test-keyring", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "second", secret.StringData[keyringFileName])
+}
@@ -96,7 +96,7 @@ func (m *MonStore) Set(who, option, value string) error {
 	logger.Infof("setting option %q (user %q) to the mon configuration database", option, who)
 	logger.Tracef("setting option %q = %q (user %q) to the mon configuration database", option, value, who)
 
-	args := []string{"config", "set", who, normalizeKey(option), value}
+	args := []string{"config", "set", who, NormalizeKey(option), value}
 	cephCmd := client.NewCephCommand(m.context, m.clusterInfo, args)
 	out, err := cephCmd.RunWithTimeout(exec.CephCommandsTimeout)
 	if err != nil {
@@ -112,7 +112,7 @@ func (m *MonStore) Set(who, option, value string) error {
 // Delete a config in the centralized mon configuration database.
 func (m *MonStore) Delete(who, option string) error {
 	logger.Infof("deleting %q %q option from the mon configuration database", who, option)
-	args := []string{"config", "rm", who, normalizeKey(option)}
+	args := []string{"config", "rm", who, NormalizeKey(option)}
 	cephCmd := client.NewCephCommand(m.context, m.clusterInfo, args)
 	out, err := cephCmd.RunWithTimeout(exec.CephCommandsTimeout)
 	if err != nil {
@@ -127,7 +127,7 @@ func (m *MonStore) Delete(who, option string) error {
 // Get retrieves a config in the centralized mon configuration database.
 // https://docs.ceph.com/docs/master/rados/configuration/ceph-conf/#monitor-configuration-database
 func (m *MonStore) Get(who, option string) (string, error) {
-	args := []string{"config", "get", who, normalizeKey(option)}
+	args := []string{"config", "get", who, NormalizeKey(option)}
 	cephCmd := client.NewCephCommand(m.context, m.clusterInfo, args)
 	out, err := cephCmd.RunWithTimeout(exec.CephCommandsTimeout)
 	if err != nil {
@@ -213,6 +213,19 @@ func (m *MonStore) SetKeyValue(key, value string) error {
 	return nil
 }
 
+// GetKeyValue gets the value of a key in Ceph's general purpose key/value store.
+// See: https://docs.ceph.com/en/latest/man/8/ceph/#config-key
+func (m *MonStore) GetKeyValue(key string) (string, error) {
+	logger.Debugf("getting %q option from the mon config-key store", key)
+	args := []string{"config-key", "get", key}
+	cephCmd := client.NewCephCommand(m.context, m.clusterInfo, args)
+	out, err := cephCmd.RunWithTimeout(exec.CephCommandsTimeout)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get %q from the mon config-key store", key)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (m *MonStore) SetAllMultiple(settings map[string]map[string]string) error {
 	for who, options := range settings {
 		if err := m.SetAll(who, options); err != nil {
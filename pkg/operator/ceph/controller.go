@@ -127,6 +127,13 @@ func (r *ReconcileConfig) reconcile(request reconcile.Request) (reconcile.Result
 	opcontroller.SetEnforceHostNetwork()
 	opcontroller.SetRevisionHistoryLimit()
 	opcontroller.SetObcAllowAdditionalConfigFields()
+	opcontroller.SetFeatureGates()
+	if err := opcontroller.PublishFeatureGates(r.opManagerContext, r.context.Clientset); err != nil {
+		logger.Warningf("failed to publish the feature gates. %v", err)
+	}
+	if err := opcontroller.PublishFieldImpacts(r.opManagerContext, r.context.Clientset, r.config.OperatorNamespace); err != nil {
+		logger.Warningf("failed to publish the field impacts. %v", err)
+	}
 
 	logger.Infof("%s done reconciling", controllerName)
 	return reconcile.Result{}, nil
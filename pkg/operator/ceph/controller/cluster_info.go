@@ -49,6 +49,9 @@ const (
 	OperatorCreds     = "rook-ceph-operator-creds"
 	fsidSecretNameKey = "fsid"
 	MonSecretNameKey  = "mon-secret"
+	// MonPreviousSecretsNameKey is the name of the key with the previous mon secrets, comma-separated,
+	// when the mon key was rotated. It is emptied once all the mons restarted with the rotated key.
+	MonPreviousSecretsNameKey = "mon-secret-previous"
 	// AdminSecretName is the name of the admin secret
 	AdminSecretNameKey = "admin-secret"
 	CephUsernameKey    = "ceph-username"
@@ -65,6 +68,9 @@ const (
 	MaxMonIDKey = "maxMonId"
 	// MappingKey is the name of the mapping for the mon->node and node->port
 	MappingKey = "mapping"
+	// Msgr2PortsKey is the name of the key for the msgr2 port of the mons not listening on the
+	// default port
+	Msgr2PortsKey = "msgr2Ports"
 	// AppName is the name of the secret storing cluster mon.admin key, fsid and name
 	AppName                         = "rook-ceph-mon"
 	DisasterProtectionFinalizerName = cephv1.CustomResourceGroup + "/disaster-protection"
@@ -79,6 +85,9 @@ var (
 type Mapping struct {
 	// This isn't really node info since it could also be for zones, but we leave it as "node" for backward compatibility.
 	Schedule map[string]*MonScheduleInfo `json:"node"`
+	// Retired are the mons removed by the operator whose IDs can be reused, with the hostnames of
+	// the nodes where they left their data dir
+	Retired map[string][]string `json:"retired,omitempty"`
 }
 
 // MonScheduleInfo contains name and address of a node.
@@ -88,6 +97,15 @@ type MonScheduleInfo struct {
 	Hostname string `json:"Hostname,omitempty"`
 	Address  string `json:"Address,omitempty"`
 	Zone     string `json:"zone,omitempty"`
+	// HomeZone is the failed stretch zone the mon was rescheduled from, the mon is moved back into
+	// this zone once it recovers
+	HomeZone string `json:"homeZone,omitempty"`
+	// HomeNode is the node that was not ready when the mon was failed over, the mon is moved back to
+	// this node once it is ready again if the mon failback is enabled
+	HomeNode string `json:"homeNode,omitempty"`
+	// ClusterAddress is the cluster address of a mon with host networking selected by the mon bind
+	// addresses
+	ClusterAddress string `json:"clusterAddress,omitempty"`
 }
 
 // LoadClusterInfo constructs or loads a clusterinfo and returns it along with the maxMonID
@@ -316,6 +334,23 @@ func loadMonConfig(clientset kubernetes.Interface, namespace string) (extMons ma
 		}
 	}
 
+	// Parse the msgr2 port of the mons not listening on the default port
+	if msgr2Ports, ok := cm.Data[Msgr2PortsKey]; ok && len(msgr2Ports) > 0 {
+		for _, rawPort := range strings.Split(msgr2Ports, ",") {
+			parts := strings.Split(rawPort, "=")
+			port, err := strconv.ParseInt(parts[len(parts)-1], 10, 32)
+			if len(parts) != 2 || err != nil {
+				logger.Warningf("ignoring invalid msgr2 port %q of a mon", rawPort)
+				continue
+			}
+			if monInfo, ok := internalMons[parts[0]]; ok {
+				monInfo.Msgr2Port = int32(port)
+			} else {
+				logger.Warningf("did not find mon %q to set its msgr2 port in the cluster info", parts[0])
+			}
+		}
+	}
+
 	// Parse the max monitor id
 	storedMaxMonID := -1
 	if id, ok := cm.Data[MaxMonIDKey]; ok {
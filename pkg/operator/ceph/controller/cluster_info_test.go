@@ -140,3 +140,25 @@ func TestCreateClusterSecrets(t *testing.T) {
 	assert.Equal(t, "testid", info.CephCred.Username)
 	assert.Equal(t, "testkey", info.CephCred.Secret)
 }
+
+func TestLoadMonConfigMsgr2Ports(t *testing.T) {
+	clientset := test.New(t, 1)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: EndpointConfigMapName, Namespace: "ns"},
+		Data: map[string]string{
+			EndpointDataKey: "a=1.2.3.1:6790,b=1.2.3.2:3301,c=1.2.3.3:6789",
+			MappingKey:      `{"node":{}}`,
+			Msgr2PortsKey:   "a=3301,b=3301,d=3301,e=invalid",
+		},
+	}
+	_, err := clientset.CoreV1().ConfigMaps("ns").Create(context.TODO(), cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, mons, _, _, err := loadMonConfig(clientset, "ns")
+	require.NoError(t, err)
+	require.Len(t, mons, 3)
+	assert.Equal(t, int32(3301), mons["a"].Msgr2Port)
+	assert.Equal(t, int32(3301), mons["b"].Msgr2Port)
+	assert.Equal(t, int32(0), mons["c"].Msgr2Port)
+	assert.Equal(t, int32(cephclient.Msgr2port), mons["c"].GetMsgr2Port())
+}
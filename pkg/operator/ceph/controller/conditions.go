@@ -59,7 +59,8 @@ func UpdateClusterCondition(c *clusterd.Context, cluster *cephv1.CephCluster, na
 			condition.Reason == cephv1.ClusterCreatedReason ||
 			condition.Reason == cephv1.ClusterConnectedReason ||
 			condition.Type == cephv1.ConditionDeleting ||
-			condition.Type == cephv1.ConditionDeletionIsBlocked {
+			condition.Type == cephv1.ConditionDeletionIsBlocked ||
+			isWarningCondition(condition.Type) {
 			if conditionType != condition.Type {
 				conditions = append(conditions, condition)
 				continue
@@ -94,8 +95,9 @@ func UpdateClusterCondition(c *clusterd.Context, cluster *cephv1.CephCluster, na
 		cluster.Status.ObservedGeneration = observedGeneration
 	}
 
-	// Once the cluster begins deleting, the phase should not revert back to any other phase
-	if cluster.Status.Phase != cephv1.ConditionDeleting {
+	// Once the cluster begins deleting, the phase should not revert back to any other phase.
+	// The warnings are reported alongside the phase and do not change it.
+	if cluster.Status.Phase != cephv1.ConditionDeleting && !isWarningCondition(conditionType) {
 		cluster.Status.Phase = conditionType
 		if state := translatePhasetoState(conditionType, status); state != "" {
 			cluster.Status.State = state
@@ -109,6 +111,22 @@ func UpdateClusterCondition(c *clusterd.Context, cluster *cephv1.CephCluster, na
 	}
 }
 
+// isWarningCondition returns whether the condition is a persisted warning about the cluster that is
+// not a phase of the cluster
+func isWarningCondition(conditionType cephv1.ConditionType) bool {
+	return conditionType == cephv1.ConditionMonCountEven ||
+		conditionType == cephv1.ConditionMonStoreCorrupted ||
+		conditionType == cephv1.ConditionRestartBudgetExceeded ||
+		conditionType == cephv1.ConditionCephUnreachable ||
+		conditionType == cephv1.ConditionStaleMonEndpoints ||
+		conditionType == cephv1.ConditionCephConfigRolledBack ||
+		conditionType == cephv1.ConditionExternalMonMismatch ||
+		conditionType == cephv1.ConditionPlacementDrift ||
+		conditionType == cephv1.ConditionMonSchedulingBlocked ||
+		conditionType == cephv1.ConditionOSDScaleDown ||
+		conditionType == cephv1.ConditionDebugLogging
+}
+
 // translatePhasetoState convert the Phases to corresponding State
 // 1. We still need to set the State in case someone is still using it
 // instead of Phase. If we stopped setting the State it would be a
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/rook/rook/pkg/util/exec"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -78,9 +79,16 @@ var (
 	// WaitForRequeueIfCephClusterNotReady waits for the CephCluster to be ready
 	WaitForRequeueIfCephClusterNotReady = reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}
 
+	// WaitForRequeueIfCephClusterUnreachable waits longer for the CephCluster to be reachable when the ceph
+	// commands keep failing, to avoid adding load to a degraded cluster
+	WaitForRequeueIfCephClusterUnreachable = reconcile.Result{Requeue: true, RequeueAfter: 2 * time.Minute}
+
 	// WaitForRequeueIfCephClusterIsUpgrading waits until the upgrade is complete
 	WaitForRequeueIfCephClusterIsUpgrading = reconcile.Result{Requeue: true, RequeueAfter: time.Minute}
 
+	// WaitForRequeueIfRestartBudgetExceeded waits for the restart budget to allow the delayed daemon restarts
+	WaitForRequeueIfRestartBudgetExceeded = reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Minute}
+
 	// WaitForRequeueIfFinalizerBlocked waits for resources to be cleaned up before the finalizer can be removed
 	WaitForRequeueIfFinalizerBlocked = reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}
 
@@ -227,6 +235,10 @@ func IsReadyToReconcile(ctx context.Context, c client.Client, namespacedName typ
 		return cephCluster, false, cephClusterExists, WaitForRequeueIfCephClusterNotReady
 	}
 	cephCluster = clusterList.Items[0]
+	if err := ApplyClusterProfile(ctx, c, &cephCluster); err != nil {
+		logger.Errorf("%q: %v", controllerName, err)
+		return cephCluster, false, cephClusterExists, WaitForRequeueIfCephClusterNotReady
+	}
 	// If the cluster has a cleanup policy to destroy the cluster and it has been marked for deletion, treat it as if it does not exist
 	if cephCluster.Spec.CleanupPolicy.HasDataDirCleanPolicy() && !cephCluster.DeletionTimestamp.IsZero() {
 		logger.Infof("%q: CephCluster has a destructive cleanup policy, allowing %q to be deleted", controllerName, namespacedName)
@@ -236,6 +248,13 @@ func IsReadyToReconcile(ctx context.Context, c client.Client, namespacedName typ
 	cephClusterExists = true
 	logger.Debugf("%q: CephCluster resource %q found in namespace %q", controllerName, cephCluster.Name, namespacedName.Namespace)
 
+	// Shed the load of the controllers while the ceph commands keep failing to reach the cluster.
+	// The breaker is reported once by the command runner, so only log at debug level here.
+	if cephclient.IsCommandBreakerOpen(namespacedName.Namespace) {
+		logger.Debugf("%q: ceph commands are failing to reach CephCluster %q, delaying the reconcile", controllerName, cephCluster.Name)
+		return cephCluster, false, cephClusterExists, WaitForRequeueIfCephClusterUnreachable
+	}
+
 	// read the CR status of the cluster
 	if cephCluster.Status.CephStatus != nil {
 		operatorDeploymentOk := cephCluster.Status.CephStatus.Health == "HEALTH_OK" || cephCluster.Status.CephStatus.Health == "HEALTH_WARN"
@@ -22,9 +22,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/util/exec"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -284,6 +288,45 @@ func TestIsReadyToReconcile(t *testing.T) {
 		assert.False(t, ready)
 		assert.False(t, clusterExists)
 	})
+
+	t.Run("healthy cephcluster with failing ceph commands", func(t *testing.T) {
+		cephCluster := &cephv1.CephCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName.Name,
+				Namespace: clusterName.Namespace,
+			},
+			Status: cephv1.ClusterStatus{
+				CephStatus: &cephv1.CephStatus{Health: "HEALTH_OK"},
+			},
+		}
+		objects := []runtime.Object{cephCluster}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+		_, ready, clusterExists, reconcileResult := IsReadyToReconcile(ctx.TODO(), client, clusterName, controllerName)
+		assert.True(t, ready)
+		assert.True(t, clusterExists)
+		assert.Equal(t, WaitForRequeueIfCephClusterNotReady, reconcileResult)
+
+		// open the command breaker with a command failing to reach the cluster
+		threshold, duration := cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration
+		cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration = 1, 0
+		defer func() {
+			cephclient.CommandBreakerFailureThreshold, cephclient.CommandBreakerFailureDuration = threshold, duration
+			cephclient.ResetCommandBreaker(clusterName.Namespace)
+		}()
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				return "", errors.New(exec.TimeoutWaitingForMessage)
+			},
+		}
+		clusterInfo := cephclient.AdminTestClusterInfo(clusterName.Namespace)
+		_, err := cephclient.NewCephCommand(&clusterd.Context{Executor: executor}, clusterInfo, []string{"status"}).Run()
+		assert.Error(t, err)
+
+		_, ready, clusterExists, reconcileResult = IsReadyToReconcile(ctx.TODO(), client, clusterName, controllerName)
+		assert.False(t, ready)
+		assert.True(t, clusterExists)
+		assert.Equal(t, WaitForRequeueIfCephClusterUnreachable, reconcileResult)
+	})
 }
 
 func TestObcAllowAdditionalConfigFields(t *testing.T) {
@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// kmipEndpointKey is the connection detail of the address of a KMIP server
+	kmipEndpointKey = "KMIP_ENDPOINT"
+)
+
+var (
+	// EndpointCheckInterval is the minimum time between two checks of the same endpoints of a resource
+	EndpointCheckInterval = 10 * time.Minute
+	// endpointCheckTimeout is the timeout of each check of an endpoint
+	endpointCheckTimeout = 10 * time.Second
+
+	endpointChecksMutex sync.Mutex
+	// endpointChecks are the last checks started for each resource
+	endpointChecks = map[string]*endpointCheckRun{}
+
+	// hooks for tests to override
+	lookupHost = net.DefaultResolver.LookupHost
+	dialer     = &net.Dialer{}
+)
+
+// EndpointCheck is an endpoint declared in the spec of a resource. The host name is resolved, and
+// the connection to the port and the TLS handshake are checked when they are set.
+type EndpointCheck struct {
+	// Source is where the endpoint is declared in the spec, for example "hosting.dnsNames"
+	Source string
+	Host   string
+	// Port is the port to connect to, or 0 to only resolve the host name
+	Port int32
+	// TLS is whether to check the TLS handshake on the port
+	TLS bool
+}
+
+type endpointCheckRun struct {
+	endpoints []EndpointCheck
+	started   time.Time
+	running   bool
+}
+
+// String returns the host and the port of the endpoint
+func (e EndpointCheck) String() string {
+	if e.Port == 0 {
+		return e.Host
+	}
+	return net.JoinHostPort(e.Host, strconv.Itoa(int(e.Port)))
+}
+
+// CheckEndpoints starts the checks of the endpoints of a resource in the background, and passes
+// the results to report once all the endpoints are checked. The checks are not started again
+// while they are running, or when the same endpoints were checked less than the check interval
+// ago. It returns whether the checks were started.
+func CheckEndpoints(ctx context.Context, resource string, endpoints []EndpointCheck, report func([]cephv1.EndpointCheckStatus)) bool {
+	endpointChecksMutex.Lock()
+	defer endpointChecksMutex.Unlock()
+
+	last, ok := endpointChecks[resource]
+	if ok && (last.running || (reflect.DeepEqual(last.endpoints, endpoints) && time.Since(last.started) < EndpointCheckInterval)) {
+		return false
+	}
+	run := &endpointCheckRun{endpoints: endpoints, started: time.Now(), running: true}
+	endpointChecks[resource] = run
+
+	go func() {
+		results := make([]cephv1.EndpointCheckStatus, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			results = append(results, checkEndpoint(ctx, endpoint))
+		}
+		if ctx.Err() == nil {
+			report(results)
+		}
+
+		endpointChecksMutex.Lock()
+		defer endpointChecksMutex.Unlock()
+		run.running = false
+	}()
+	return true
+}
+
+// ForgetEndpointChecks forgets the checks of a deleted resource
+func ForgetEndpointChecks(resource string) {
+	endpointChecksMutex.Lock()
+	defer endpointChecksMutex.Unlock()
+	delete(endpointChecks, resource)
+}
+
+// checkEndpoint resolves the host name of the endpoint, connects to its port and checks the TLS
+// handshake, and stops at the first check that fails
+func checkEndpoint(ctx context.Context, endpoint EndpointCheck) cephv1.EndpointCheckStatus {
+	status := cephv1.EndpointCheckStatus{
+		Source:      endpoint.Source,
+		Endpoint:    endpoint.String(),
+		Healthy:     true,
+		LastChecked: metav1.Now(),
+	}
+	fail := func(check cephv1.EndpointCheckType, err error) cephv1.EndpointCheckStatus {
+		logger.Warningf("endpoint %q of %q failed the %s check. %v", status.Endpoint, endpoint.Source, check, err)
+		status.Healthy = false
+		status.FailedCheck = check
+		status.Message = err.Error()
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, endpointCheckTimeout)
+	defer cancel()
+
+	if net.ParseIP(endpoint.Host) == nil {
+		addresses, err := lookupHost(ctx, endpoint.Host)
+		if err != nil {
+			return fail(cephv1.EndpointCheckDNS, err)
+		}
+		if len(addresses) == 0 {
+			return fail(cephv1.EndpointCheckDNS, errors.Errorf("no address found for host %q", endpoint.Host))
+		}
+	}
+	if endpoint.Port == 0 {
+		return status
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint.String())
+	if err != nil {
+		return fail(cephv1.EndpointCheckTCP, err)
+	}
+	defer conn.Close()
+	if !endpoint.TLS {
+		return status
+	}
+
+	// the certificate chain is verified by the clients with their own CA, only the handshake, the
+	// host name and the expiration of the certificate are checked
+	//nolint:gosec // the chain of the certificate is not verified on purpose
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: endpoint.Host, InsecureSkipVerify: true})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fail(cephv1.EndpointCheckTLS, err)
+	}
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return fail(cephv1.EndpointCheckTLS, errors.New("no certificate presented"))
+	}
+	if time.Now().After(certs[0].NotAfter) {
+		return fail(cephv1.EndpointCheckTLS, errors.Errorf("certificate expired on %s", certs[0].NotAfter.UTC().Format(time.RFC3339)))
+	}
+	if err := certs[0].VerifyHostname(endpoint.Host); err != nil {
+		return fail(cephv1.EndpointCheckTLS, err)
+	}
+	return status
+}
+
+// KMSEndpointCheck returns the endpoint of the Vault or KMIP server of a KMS, if any
+func KMSEndpointCheck(source string, kms cephv1.KeyManagementServiceSpec) (EndpointCheck, error) {
+	if kms.IsVaultKMS() {
+		address := kms.ConnectionDetails[api.EnvVaultAddress]
+		if address == "" {
+			return EndpointCheck{}, nil
+		}
+		vaultURL, err := url.Parse(address)
+		if err != nil || vaultURL.Hostname() == "" {
+			return EndpointCheck{}, errors.Errorf("invalid vault address %q of %q", address, source)
+		}
+		endpoint := EndpointCheck{Source: source, Host: vaultURL.Hostname(), TLS: vaultURL.Scheme == "https"}
+		port := vaultURL.Port()
+		switch {
+		case port != "":
+		case endpoint.TLS:
+			port = "443"
+		default:
+			port = "80"
+		}
+		if endpoint.Port, err = parseEndpointPort(port); err != nil {
+			return EndpointCheck{}, errors.Wrapf(err, "invalid vault address %q of %q", address, source)
+		}
+		return endpoint, nil
+	}
+	if kms.IsKMIPKMS() {
+		address := kms.ConnectionDetails[kmipEndpointKey]
+		if address == "" {
+			return EndpointCheck{}, nil
+		}
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return EndpointCheck{}, errors.Wrapf(err, "invalid kmip endpoint %q of %q", address, source)
+		}
+		endpoint := EndpointCheck{Source: source, Host: host, TLS: true}
+		if endpoint.Port, err = parseEndpointPort(port); err != nil {
+			return EndpointCheck{}, errors.Wrapf(err, "invalid kmip endpoint %q of %q", address, source)
+		}
+		return endpoint, nil
+	}
+	// the other providers are cloud services without an address in the spec
+	return EndpointCheck{}, nil
+}
+
+func parseEndpointPort(port string) (int32, error) {
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return 0, errors.Errorf("invalid port %q", port)
+	}
+	return int32(p), nil
+}
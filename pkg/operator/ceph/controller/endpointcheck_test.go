@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKMSEndpointCheck(t *testing.T) {
+	vault := func(address string) cephv1.KeyManagementServiceSpec {
+		return cephv1.KeyManagementServiceSpec{ConnectionDetails: map[string]string{"KMS_PROVIDER": "vault", "VAULT_ADDR": address}}
+	}
+
+	endpoint, err := KMSEndpointCheck("security.kms", vault("https://vault.example.com:8200"))
+	assert.NoError(t, err)
+	assert.Equal(t, EndpointCheck{Source: "security.kms", Host: "vault.example.com", Port: 8200, TLS: true}, endpoint)
+
+	endpoint, err = KMSEndpointCheck("security.kms", vault("https://vault.example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(443), endpoint.Port)
+
+	endpoint, err = KMSEndpointCheck("security.kms", vault("http://10.0.0.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, EndpointCheck{Source: "security.kms", Host: "10.0.0.1", Port: 80}, endpoint)
+
+	_, err = KMSEndpointCheck("security.kms", vault("vault:8200"))
+	assert.Error(t, err)
+
+	endpoint, err = KMSEndpointCheck("security.s3", cephv1.KeyManagementServiceSpec{ConnectionDetails: map[string]string{"KMS_PROVIDER": "kmip", "KMIP_ENDPOINT": "kmip.example.com:5696"}})
+	assert.NoError(t, err)
+	assert.Equal(t, EndpointCheck{Source: "security.s3", Host: "kmip.example.com", Port: 5696, TLS: true}, endpoint)
+
+	_, err = KMSEndpointCheck("security.s3", cephv1.KeyManagementServiceSpec{ConnectionDetails: map[string]string{"KMS_PROVIDER": "kmip", "KMIP_ENDPOINT": "kmip.example.com"}})
+	assert.Error(t, err)
+
+	// no address to check
+	endpoint, err = KMSEndpointCheck("security.kms", cephv1.KeyManagementServiceSpec{})
+	assert.NoError(t, err)
+	assert.Equal(t, EndpointCheck{}, endpoint)
+}
+
+func TestCheckEndpoint(t *testing.T) {
+	ctx := context.TODO()
+	defer func() { lookupHost = net.DefaultResolver.LookupHost }()
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if host == "localhost" {
+			return []string{"127.0.0.1"}, nil
+		}
+		return nil, errors.Errorf("no such host %q", host)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	tcpPort := listener.Addr().(*net.TCPAddr).Port
+
+	tlsServer := httptest.NewTLSServer(nil)
+	defer tlsServer.Close()
+	tlsURL, err := url.Parse(tlsServer.URL)
+	require.NoError(t, err)
+	tlsPort, _ := strconv.Atoi(tlsURL.Port())
+
+	t.Run("dns only", func(t *testing.T) {
+		status := checkEndpoint(ctx, EndpointCheck{Source: "hosting.dnsNames", Host: "localhost"})
+		assert.True(t, status.Healthy)
+		assert.Equal(t, "localhost", status.Endpoint)
+		assert.Equal(t, "hosting.dnsNames", status.Source)
+	})
+
+	t.Run("dns failure", func(t *testing.T) {
+		status := checkEndpoint(ctx, EndpointCheck{Host: "unknown.example.com", Port: int32(tcpPort)})
+		assert.False(t, status.Healthy)
+		assert.Equal(t, cephv1.EndpointCheckDNS, status.FailedCheck)
+		assert.Contains(t, status.Message, "no such host")
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		status := checkEndpoint(ctx, EndpointCheck{Host: "127.0.0.1", Port: int32(tcpPort)})
+		assert.True(t, status.Healthy)
+		assert.Equal(t, net.JoinHostPort("127.0.0.1", strconv.Itoa(tcpPort)), status.Endpoint)
+	})
+
+	t.Run("tcp failure", func(t *testing.T) {
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		closedPort := closed.Addr().(*net.TCPAddr).Port
+		closed.Close()
+		status := checkEndpoint(ctx, EndpointCheck{Host: "127.0.0.1", Port: int32(closedPort)})
+		assert.False(t, status.Healthy)
+		assert.Equal(t, cephv1.EndpointCheckTCP, status.FailedCheck)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		// the test certificate is issued for 127.0.0.1
+		status := checkEndpoint(ctx, EndpointCheck{Host: "127.0.0.1", Port: int32(tlsPort), TLS: true})
+		assert.True(t, status.Healthy, status.Message)
+	})
+
+	t.Run("tls host name mismatch", func(t *testing.T) {
+		// the test certificate is not issued for localhost
+		status := checkEndpoint(ctx, EndpointCheck{Host: "localhost", Port: int32(tlsPort), TLS: true})
+		assert.False(t, status.Healthy)
+		assert.Equal(t, cephv1.EndpointCheckTLS, status.FailedCheck)
+	})
+
+	t.Run("tls failure", func(t *testing.T) {
+		status := checkEndpoint(ctx, EndpointCheck{Host: "127.0.0.1", Port: int32(tcpPort), TLS: true})
+		assert.False(t, status.Healthy)
+		assert.Equal(t, cephv1.EndpointCheckTLS, status.FailedCheck)
+	})
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	ctx := context.TODO()
+	defer func() { lookupHost = net.DefaultResolver.LookupHost }()
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		return []string{"10.0.0.1"}, nil
+	}
+	resource := "CephObjectStore/ns/store"
+	defer ForgetEndpointChecks(resource)
+
+	reported := make(chan []cephv1.EndpointCheckStatus, 1)
+	report := func(results []cephv1.EndpointCheckStatus) { reported <- results }
+	endpoints := []EndpointCheck{{Source: "hosting.dnsNames", Host: "s3.example.com"}}
+
+	assert.True(t, CheckEndpoints(ctx, resource, endpoints, report))
+	select {
+	case results := <-reported:
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Healthy)
+		assert.Equal(t, "s3.example.com", results[0].Endpoint)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the endpoint checks were not reported")
+	}
+	assert.Eventually(t, func() bool {
+		endpointChecksMutex.Lock()
+		defer endpointChecksMutex.Unlock()
+		return !endpointChecks[resource].running
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// the same endpoints are not checked again within the interval
+	assert.False(t, CheckEndpoints(ctx, resource, endpoints, report))
+
+	// the endpoints are checked again when they change
+	endpoints = append(endpoints, EndpointCheck{Source: "hosting.dnsNames", Host: "s3.other.example.com"})
+	assert.True(t, CheckEndpoints(ctx, resource, endpoints, report))
+	results := <-reported
+	assert.Len(t, results, 2)
+
+	// the endpoints are checked again after the resource is forgotten
+	assert.Eventually(t, func() bool {
+		endpointChecksMutex.Lock()
+		defer endpointChecksMutex.Unlock()
+		return !endpointChecks[resource].running
+	}, 10*time.Second, 10*time.Millisecond)
+	ForgetEndpointChecks(resource)
+	assert.True(t, CheckEndpoints(ctx, resource, endpoints, report))
+	<-reported
+}
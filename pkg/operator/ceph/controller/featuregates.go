@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FeatureStage is the maturity of a gated operator feature
+type FeatureStage string
+
+const (
+	// Alpha features are disabled by default
+	Alpha FeatureStage = "Alpha"
+	// Beta features are enabled by default
+	Beta FeatureStage = "Beta"
+	// GA features are always enabled, their gate is kept until it is removed from the operator
+	GA FeatureStage = "GA"
+)
+
+// Feature is the name of a gated operator feature
+type Feature string
+
+const (
+	// MonQuorumRestore restores the mon quorum from a surviving mon on request of the
+	// "ceph.rook.io/restore-mon-quorum" annotation of the CephCluster
+	MonQuorumRestore Feature = "MonQuorumRestore"
+)
+
+const (
+	featureGatesSettingName = "ROOK_FEATURE_GATES"
+	// FeatureGatesAnnotation is set on the operator pod with the state of the feature gates
+	FeatureGatesAnnotation = "ceph.rook.io/feature-gates"
+)
+
+// featureStages are the gated operator features and their stage
+var featureStages = map[Feature]FeatureStage{
+	MonQuorumRestore: Beta,
+}
+
+// enabledFeatures are the features enabled by the feature gates of the operator settings
+var enabledFeatures = defaultFeatureGates()
+
+func defaultFeatureGates() map[Feature]bool {
+	features := map[Feature]bool{}
+	for feature, stage := range featureStages {
+		features[feature] = stage != Alpha
+	}
+	return features
+}
+
+// SetFeatureGates sets the feature gates from the operator settings, in the format
+// "Feature1=true,Feature2=false". The features not set keep the default of their stage.
+func SetFeatureGates() {
+	features := defaultFeatureGates()
+	strval := k8sutil.GetOperatorSetting(featureGatesSettingName, "")
+	for _, gate := range strings.Split(strval, ",") {
+		gate = strings.TrimSpace(gate)
+		if gate == "" {
+			continue
+		}
+		name, value, found := strings.Cut(gate, "=")
+		feature := Feature(strings.TrimSpace(name))
+		stage, ok := featureStages[feature]
+		if !ok {
+			logger.Warningf("ignoring unknown feature gate %q in %q", feature, featureGatesSettingName)
+			continue
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if !found || err != nil {
+			logger.Warningf("ignoring feature gate %q with invalid value %q in %q", feature, value, featureGatesSettingName)
+			continue
+		}
+		if stage == GA && !enabled {
+			logger.Warningf("feature %q is GA and cannot be disabled", feature)
+			continue
+		}
+		features[feature] = enabled
+	}
+	enabledFeatures = features
+	logger.Infof("feature gates: %s", FeatureGatesString())
+}
+
+// FeatureEnabled returns whether the feature is enabled by the feature gates
+func FeatureEnabled(feature Feature) bool {
+	return enabledFeatures[feature]
+}
+
+// FeatureGatesString returns the state and stage of the feature gates, sorted by feature
+func FeatureGatesString() string {
+	gates := []string{}
+	for feature, enabled := range enabledFeatures {
+		gates = append(gates, fmt.Sprintf("%s=%t (%s)", feature, enabled, featureStages[feature]))
+	}
+	sort.Strings(gates)
+	return strings.Join(gates, ",")
+}
+
+// PublishFeatureGates sets the state of the feature gates on the annotation of the operator pod
+func PublishFeatureGates(ctx context.Context, clientset kubernetes.Interface) error {
+	pod, err := k8sutil.GetRunningPod(ctx, clientset)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the operator pod to publish the feature gates")
+	}
+	if pod.Annotations[FeatureGatesAnnotation] == FeatureGatesString() {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{FeatureGatesAnnotation: FeatureGatesString()},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the feature gates annotation")
+	}
+	if _, err := clientset.CoreV1().Pods(pod.Namespace).Patch(observerExempt(ctx), pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to publish the feature gates on the operator pod %q", pod.Name)
+	}
+	return nil
+}
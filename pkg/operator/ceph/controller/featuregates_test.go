@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetFeatureGates(t *testing.T) {
+	originalStages := featureStages
+	t.Cleanup(func() {
+		featureStages = originalStages
+		os.Unsetenv(featureGatesSettingName)
+		SetFeatureGates()
+	})
+	featureStages = map[Feature]FeatureStage{"AlphaFeature": Alpha, "BetaFeature": Beta, "GAFeature": GA}
+
+	// the defaults of the stages
+	SetFeatureGates()
+	assert.False(t, FeatureEnabled("AlphaFeature"))
+	assert.True(t, FeatureEnabled("BetaFeature"))
+	assert.True(t, FeatureEnabled("GAFeature"))
+	assert.False(t, FeatureEnabled("UnknownFeature"))
+	assert.Equal(t, "AlphaFeature=false (Alpha),BetaFeature=true (Beta),GAFeature=true (GA)", FeatureGatesString())
+
+	os.Setenv(featureGatesSettingName, "AlphaFeature=true, BetaFeature=false")
+	SetFeatureGates()
+	assert.True(t, FeatureEnabled("AlphaFeature"))
+	assert.False(t, FeatureEnabled("BetaFeature"))
+
+	// the GA features cannot be disabled, the unknown and invalid gates are ignored
+	os.Setenv(featureGatesSettingName, "GAFeature=false,UnknownFeature=true,AlphaFeature=maybe,BetaFeature")
+	SetFeatureGates()
+	assert.True(t, FeatureEnabled("GAFeature"))
+	assert.False(t, FeatureEnabled("UnknownFeature"))
+	assert.False(t, FeatureEnabled("AlphaFeature"))
+	assert.True(t, FeatureEnabled("BetaFeature"))
+}
+
+func TestPublishFeatureGates(t *testing.T) {
+	ctx := context.TODO()
+	clientset := fake.NewSimpleClientset(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-operator", Namespace: "rook-ceph"}})
+
+	// the operator pod is unknown
+	assert.Error(t, PublishFeatureGates(ctx, clientset))
+
+	t.Setenv(k8sutil.PodNameEnvVar, "rook-ceph-operator")
+	t.Setenv(k8sutil.PodNamespaceEnvVar, "rook-ceph")
+	assert.NoError(t, PublishFeatureGates(ctx, clientset))
+	pod, err := clientset.CoreV1().Pods("rook-ceph").Get(ctx, "rook-ceph-operator", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, FeatureGatesString(), pod.Annotations[FeatureGatesAnnotation])
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FieldImpactConfigMapName is the ConfigMap published by the operator with the impact of the fields
+// of the custom resources on the resources applied by the controllers
+const FieldImpactConfigMapName = "rook-ceph-field-impact"
+
+// FieldImpact is the impact of a change of a field of a custom resource on the resources applied
+// by its controller
+type FieldImpact struct {
+	Kind string `json:"kind"`
+	// Field is the path of the field in the custom resource, for example "spec.mon.count"
+	Field string `json:"field"`
+	// Resources are the resources applied from the field, for example "deployment/rook-ceph-mon-*"
+	Resources []string `json:"resources"`
+	// Restart is whether a change of the field restarts the pods of the daemons
+	Restart bool `json:"restart"`
+	// Description is how the controller applies the field
+	Description string `json:"description,omitempty"`
+}
+
+var (
+	fieldImpacts     = map[string]FieldImpact{}
+	fieldImpactsLock sync.Mutex
+)
+
+// RegisterFieldImpacts registers the impact of the fields of the custom resource applied by a
+// controller. It is called by the controllers from their apply logic at init, and panics when a
+// field is not in the custom resource so that the impacts do not get out of date with the API.
+func RegisterFieldImpacts(obj interface{}, impacts ...FieldImpact) {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fieldImpactsLock.Lock()
+	defer fieldImpactsLock.Unlock()
+	for _, impact := range impacts {
+		impact.Kind = t.Name()
+		if err := validateFieldPath(t, impact.Field); err != nil {
+			panic(fmt.Sprintf("invalid field impact of %s. %v", impact.Kind, err))
+		}
+		key := impact.Kind + "/" + impact.Field
+		// the field may be applied by several controllers, the impacts are merged
+		if existing, ok := fieldImpacts[key]; ok {
+			impact.Resources = append(existing.Resources, impact.Resources...)
+			impact.Restart = impact.Restart || existing.Restart
+			if existing.Description != "" && impact.Description != "" {
+				impact.Description = existing.Description + " " + impact.Description
+			} else if impact.Description == "" {
+				impact.Description = existing.Description
+			}
+		}
+		fieldImpacts[key] = impact
+	}
+}
+
+// validateFieldPath returns an error if the path of JSON field names is not in the type. The keys
+// of the maps are accepted as a field name.
+func validateFieldPath(t reflect.Type, path string) error {
+	current := t
+	for _, name := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Ptr || current.Kind() == reflect.Slice {
+			current = current.Elem()
+		}
+		switch current.Kind() {
+		case reflect.Map:
+			current = current.Elem()
+		case reflect.Struct:
+			field, ok := jsonField(current, name)
+			if !ok {
+				return errors.Errorf("field %q of %q not found in %s", name, path, current.Name())
+			}
+			current = field.Type
+		default:
+			return errors.Errorf("field %q of %q is not in a struct or a map", name, path)
+		}
+	}
+	return nil
+}
+
+// jsonField returns the field of the struct with the JSON name, including the inline fields
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "" && (field.Anonymous || len(tag) > 1 && tag[1] == "inline") {
+			inline := field.Type
+			if inline.Kind() == reflect.Ptr {
+				inline = inline.Elem()
+			}
+			if inline.Kind() == reflect.Struct {
+				if f, ok := jsonField(inline, name); ok {
+					return f, true
+				}
+			}
+			continue
+		}
+		if tag[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// FieldImpacts returns the registered impacts of the fields of the kind of custom resource, or of
+// all the kinds if the kind is empty, sorted by kind and field
+func FieldImpacts(kind string) []FieldImpact {
+	fieldImpactsLock.Lock()
+	defer fieldImpactsLock.Unlock()
+	impacts := []FieldImpact{}
+	for _, impact := range fieldImpacts {
+		if kind == "" || impact.Kind == kind {
+			impacts = append(impacts, impact)
+		}
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].Kind != impacts[j].Kind {
+			return impacts[i].Kind < impacts[j].Kind
+		}
+		return impacts[i].Field < impacts[j].Field
+	})
+	return impacts
+}
+
+// PublishFieldImpacts publishes the impacts of the fields in the field impact ConfigMap of the
+// operator namespace, with a key per kind of custom resource
+func PublishFieldImpacts(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	byKind := map[string][]FieldImpact{}
+	for _, impact := range FieldImpacts("") {
+		byKind[impact.Kind] = append(byKind[impact.Kind], impact)
+	}
+	data := map[string]string{}
+	for kind, impacts := range byKind {
+		raw, err := json.MarshalIndent(impacts, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize the field impacts of %s", kind)
+		}
+		data[kind] = string(raw)
+	}
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: FieldImpactConfigMapName, Namespace: namespace},
+		Data:       data,
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(observerExempt(ctx), clientset, cm); err != nil {
+		return errors.Wrap(err, "failed to publish the field impacts")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegisterFieldImpacts(t *testing.T) {
+	originalImpacts := fieldImpacts
+	t.Cleanup(func() { fieldImpacts = originalImpacts })
+	fieldImpacts = map[string]FieldImpact{}
+
+	RegisterFieldImpacts(&cephv1.CephCluster{},
+		FieldImpact{Field: "spec.mon.count", Resources: []string{"deployment/rook-ceph-mon-*"}},
+		FieldImpact{Field: "spec.resources.mon", Resources: []string{"deployment/rook-ceph-mon-*"}, Restart: true},
+		FieldImpact{Field: "spec.cephVersion.image", Resources: []string{"deployment/rook-ceph-mon-*"}, Restart: true},
+	)
+	// the fields applied by several controllers are merged
+	RegisterFieldImpacts(&cephv1.CephCluster{},
+		FieldImpact{Field: "spec.cephVersion.image", Resources: []string{"deployment/rook-ceph-osd-*"}, Restart: true, Description: "OSDs"},
+		// the inline fields are found
+		FieldImpact{Field: "spec.storage.devices", Resources: []string{"job/rook-ceph-osd-prepare-*"}},
+	)
+	RegisterFieldImpacts(&cephv1.CephFilesystem{}, FieldImpact{Field: "spec.metadataServer.activeCount"})
+
+	impacts := FieldImpacts("CephCluster")
+	require.Len(t, impacts, 4)
+	assert.Equal(t, "spec.cephVersion.image", impacts[0].Field)
+	assert.Equal(t, "CephCluster", impacts[0].Kind)
+	assert.Equal(t, []string{"deployment/rook-ceph-mon-*", "deployment/rook-ceph-osd-*"}, impacts[0].Resources)
+	assert.True(t, impacts[0].Restart)
+	assert.Equal(t, "OSDs", impacts[0].Description)
+	assert.Equal(t, "spec.mon.count", impacts[1].Field)
+	assert.False(t, impacts[1].Restart)
+	assert.Len(t, FieldImpacts(""), 5)
+	assert.Empty(t, FieldImpacts("CephNFS"))
+
+	// the fields not in the custom resource are rejected
+	assert.Panics(t, func() { RegisterFieldImpacts(&cephv1.CephCluster{}, FieldImpact{Field: "spec.mon.unknown"}) })
+	assert.Panics(t, func() { RegisterFieldImpacts(&cephv1.CephCluster{}, FieldImpact{Field: "spec.mon.count.value"}) })
+
+	clientset := fake.NewSimpleClientset()
+	require.NoError(t, PublishFieldImpacts(context.TODO(), clientset, "rook-ceph"))
+	cm, err := clientset.CoreV1().ConfigMaps("rook-ceph").Get(context.TODO(), FieldImpactConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cm.Data, 2)
+	published := []FieldImpact{}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["CephFilesystem"]), &published))
+	assert.Equal(t, []FieldImpact{{Kind: "CephFilesystem", Field: "spec.metadataServer.activeCount"}}, published)
+}
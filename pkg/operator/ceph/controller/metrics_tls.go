@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultMetricsProxyImage is the image of the proxy serving the metrics over TLS if the
+	// monitoring spec does not set one
+	DefaultMetricsProxyImage = "quay.io/brancz/kube-rbac-proxy:v0.19.1"
+	// MetricsUpstreamAddr is the address the daemons serve their metrics on when the metrics proxy
+	// serves them over TLS
+	MetricsUpstreamAddr = "127.0.0.1"
+	// MetricsTLSPortName is the name of the container port of the metrics proxy
+	MetricsTLSPortName = "https-metrics"
+
+	metricsProxyContainerName  = "metrics-proxy"
+	metricsTLSVolumeName       = "rook-metrics-tls"
+	metricsTLSMountPath        = "/etc/rook/metrics-tls"
+	metricsClientCAVolumeName  = "rook-metrics-client-ca"
+	metricsClientCAMountPath   = "/etc/rook/metrics-client-ca"
+	metricsCAKey               = "ca.crt"
+	serviceAccountTokenFile    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	metricsProxyAllowedPath    = "/metrics"
+	metricsTLSServerNameFormat = "%s.%s.svc"
+)
+
+// MetricsTLSEnabled returns whether the metrics of the mgr and Ceph exporter are served over TLS
+func MetricsTLSEnabled(spec cephv1.MonitoringSpec) bool {
+	return !spec.MetricsDisabled && spec.TLS != nil && spec.TLS.Enabled
+}
+
+// ValidateMetricsTLS validates the TLS settings of the metrics endpoints
+func ValidateMetricsTLS(spec cephv1.MonitoringSpec) error {
+	if !MetricsTLSEnabled(spec) {
+		return nil
+	}
+	if spec.TLS.SecretName == "" {
+		return errors.New("monitoring.tls.secretName is required to serve the metrics over TLS")
+	}
+	return nil
+}
+
+// MetricsProxyContainer returns the sidecar that serves over TLS on the given port the metrics the
+// daemon serves on the upstream port of the localhost. The clients are authenticated with a bearer
+// token or, if the client CA is set, with a client certificate, and must be authorized to get the
+// /metrics non-resource URL.
+func MetricsProxyContainer(spec cephv1.ClusterSpec, port, upstreamPort uint16) v1.Container {
+	tls := spec.Monitoring.TLS
+	image := tls.ProxyImage
+	if image == "" {
+		image = DefaultMetricsProxyImage
+	}
+
+	args := []string{
+		fmt.Sprintf("--secure-listen-address=:%d", port),
+		fmt.Sprintf("--upstream=http://%s:%d/", MetricsUpstreamAddr, upstreamPort),
+		fmt.Sprintf("--tls-cert-file=%s/%s", metricsTLSMountPath, v1.TLSCertKey),
+		fmt.Sprintf("--tls-private-key-file=%s/%s", metricsTLSMountPath, v1.TLSPrivateKeyKey),
+		fmt.Sprintf("--allow-paths=%s", metricsProxyAllowedPath),
+	}
+	mounts := []v1.VolumeMount{{Name: metricsTLSVolumeName, MountPath: metricsTLSMountPath, ReadOnly: true}}
+	if tls.ClientCASecretName != "" {
+		args = append(args, fmt.Sprintf("--client-ca-file=%s/%s", metricsClientCAMountPath, metricsCAKey))
+		mounts = append(mounts, v1.VolumeMount{Name: metricsClientCAVolumeName, MountPath: metricsClientCAMountPath, ReadOnly: true})
+	}
+
+	return v1.Container{
+		Name:            metricsProxyContainerName,
+		Image:           image,
+		ImagePullPolicy: GetContainerImagePullPolicy(spec.CephVersion.ImagePullPolicy),
+		Args:            args,
+		Ports: []v1.ContainerPort{
+			{
+				Name:          MetricsTLSPortName,
+				ContainerPort: int32(port),
+				Protocol:      v1.ProtocolTCP,
+			},
+		},
+		VolumeMounts:    mounts,
+		SecurityContext: DefaultContainerSecurityContext(),
+	}
+}
+
+// MetricsProxyVolumes returns the volumes of the secrets mounted by the metrics proxy sidecar
+func MetricsProxyVolumes(spec cephv1.MonitoringSpec) []v1.Volume {
+	volumes := []v1.Volume{
+		{
+			Name:         metricsTLSVolumeName,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: spec.TLS.SecretName}},
+		},
+	}
+	if spec.TLS.ClientCASecretName != "" {
+		volumes = append(volumes, v1.Volume{
+			Name:         metricsClientCAVolumeName,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: spec.TLS.ClientCASecretName}},
+		})
+	}
+	return volumes
+}
+
+// ApplyMetricsPrometheusAnnotations sets the port and scheme the metrics are scraped with from the
+// prometheus annotations of a pod
+func ApplyMetricsPrometheusAnnotations(spec cephv1.MonitoringSpec, port, tlsPort uint16, objectMeta *metav1.ObjectMeta) {
+	annotations := cephv1.Annotations{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   strconv.Itoa(int(port)),
+	}
+	if MetricsTLSEnabled(spec) {
+		annotations["prometheus.io/port"] = strconv.Itoa(int(tlsPort))
+		annotations["prometheus.io/scheme"] = "https"
+	}
+	annotations.ApplyToObjectMeta(objectMeta)
+}
+
+// ApplyMetricsTLSToEndpoint configures the ServiceMonitor endpoint to scrape the metrics over TLS
+// from the service, with the client certificate of the monitoring spec or else with the service
+// account token of Prometheus
+func ApplyMetricsTLSToEndpoint(ctx context.Context, clientset kubernetes.Interface, namespace, serviceName string, spec cephv1.MonitoringSpec, endpoint *monitoringv1.Endpoint) error {
+	if !MetricsTLSEnabled(spec) {
+		return nil
+	}
+
+	serverName := fmt.Sprintf(metricsTLSServerNameFormat, serviceName, namespace)
+	tlsConfig := &monitoringv1.TLSConfig{SafeTLSConfig: monitoringv1.SafeTLSConfig{ServerName: &serverName}}
+
+	// trust the CA of the certificate of the metrics endpoints if the secret has one, otherwise
+	// the certificate must be signed by a CA that Prometheus trusts
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, spec.TLS.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get metrics tls secret %q", spec.TLS.SecretName)
+	}
+	if _, ok := secret.Data[metricsCAKey]; ok {
+		tlsConfig.CA = monitoringv1.SecretOrConfigMap{Secret: secretKeySelector(spec.TLS.SecretName, metricsCAKey)}
+	}
+
+	if spec.TLS.ClientCertSecretName != "" {
+		tlsConfig.Cert = monitoringv1.SecretOrConfigMap{Secret: secretKeySelector(spec.TLS.ClientCertSecretName, v1.TLSCertKey)}
+		tlsConfig.KeySecret = secretKeySelector(spec.TLS.ClientCertSecretName, v1.TLSPrivateKeyKey)
+	} else {
+		endpoint.BearerTokenFile = serviceAccountTokenFile
+	}
+
+	endpoint.Scheme = "https"
+	endpoint.TLSConfig = tlsConfig
+	return nil
+}
+
+func secretKeySelector(name, key string) *v1.SecretKeySelector {
+	return &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: name}, Key: key}
+}
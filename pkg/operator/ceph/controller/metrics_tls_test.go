@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateMetricsTLS(t *testing.T) {
+	assert.NoError(t, ValidateMetricsTLS(cephv1.MonitoringSpec{}))
+	assert.NoError(t, ValidateMetricsTLS(cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{}}))
+	assert.Error(t, ValidateMetricsTLS(cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{Enabled: true}}))
+	assert.NoError(t, ValidateMetricsTLS(cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"}}))
+	// the metrics are not served when they are disabled
+	assert.NoError(t, ValidateMetricsTLS(cephv1.MonitoringSpec{MetricsDisabled: true, TLS: &cephv1.MetricsTLSSpec{Enabled: true}}))
+}
+
+func TestMetricsProxyContainer(t *testing.T) {
+	spec := cephv1.ClusterSpec{Monitoring: cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"}}}
+
+	container := MetricsProxyContainer(spec, 9284, 9283)
+	assert.Equal(t, DefaultMetricsProxyImage, container.Image)
+	assert.Equal(t, []string{
+		"--secure-listen-address=:9284",
+		"--upstream=http://127.0.0.1:9283/",
+		"--tls-cert-file=/etc/rook/metrics-tls/tls.crt",
+		"--tls-private-key-file=/etc/rook/metrics-tls/tls.key",
+		"--allow-paths=/metrics",
+	}, container.Args)
+	assert.Equal(t, int32(9284), container.Ports[0].ContainerPort)
+	assert.Equal(t, MetricsTLSPortName, container.Ports[0].Name)
+	volumes := MetricsProxyVolumes(spec.Monitoring)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "metrics-tls", volumes[0].Secret.SecretName)
+	assert.Len(t, container.VolumeMounts, 1)
+
+	// the client certificates are verified with the client ca
+	spec.Monitoring.TLS.ClientCASecretName = "metrics-client-ca"
+	spec.Monitoring.TLS.ProxyImage = "registry.example.com/kube-rbac-proxy:v1"
+	container = MetricsProxyContainer(spec, 9284, 9283)
+	assert.Equal(t, "registry.example.com/kube-rbac-proxy:v1", container.Image)
+	assert.Contains(t, container.Args, "--client-ca-file=/etc/rook/metrics-client-ca/ca.crt")
+	volumes = MetricsProxyVolumes(spec.Monitoring)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "metrics-client-ca", volumes[1].Secret.SecretName)
+	assert.Len(t, container.VolumeMounts, 2)
+}
+
+func TestApplyMetricsPrometheusAnnotations(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{}
+	ApplyMetricsPrometheusAnnotations(cephv1.MonitoringSpec{}, 9283, 9284, &objectMeta)
+	assert.Equal(t, map[string]string{"prometheus.io/scrape": "true", "prometheus.io/port": "9283"}, objectMeta.Annotations)
+
+	objectMeta = metav1.ObjectMeta{}
+	ApplyMetricsPrometheusAnnotations(cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{Enabled: true}}, 9283, 9284, &objectMeta)
+	assert.Equal(t, map[string]string{"prometheus.io/scrape": "true", "prometheus.io/port": "9284", "prometheus.io/scheme": "https"}, objectMeta.Annotations)
+}
+
+func TestApplyMetricsTLSToEndpoint(t *testing.T) {
+	ctx := context.TODO()
+	clientset := test.New(t, 1)
+	spec := cephv1.MonitoringSpec{TLS: &cephv1.MetricsTLSSpec{Enabled: true, SecretName: "metrics-tls"}}
+
+	// the endpoint is not changed without tls
+	endpoint := monitoringv1.Endpoint{}
+	require.NoError(t, ApplyMetricsTLSToEndpoint(ctx, clientset, "rook-ceph", "rook-ceph-mgr", cephv1.MonitoringSpec{}, &endpoint))
+	assert.Equal(t, monitoringv1.Endpoint{}, endpoint)
+
+	// the secret must exist
+	assert.Error(t, ApplyMetricsTLSToEndpoint(ctx, clientset, "rook-ceph", "rook-ceph-mgr", spec, &endpoint))
+
+	// the ca of the secret is trusted and prometheus authenticates with its token
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-tls", Namespace: "rook-ceph"},
+		Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")},
+	}
+	_, err := clientset.CoreV1().Secrets("rook-ceph").Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, ApplyMetricsTLSToEndpoint(ctx, clientset, "rook-ceph", "rook-ceph-mgr", spec, &endpoint))
+	assert.Equal(t, "https", endpoint.Scheme)
+	assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount/token", endpoint.BearerTokenFile)
+	assert.Equal(t, "rook-ceph-mgr.rook-ceph.svc", *endpoint.TLSConfig.ServerName)
+	assert.Equal(t, "metrics-tls", endpoint.TLSConfig.CA.Secret.Name)
+	assert.Equal(t, "ca.crt", endpoint.TLSConfig.CA.Secret.Key)
+	assert.Nil(t, endpoint.TLSConfig.KeySecret)
+
+	// prometheus authenticates with the client certificate
+	spec.TLS.ClientCertSecretName = "prometheus-client"
+	endpoint = monitoringv1.Endpoint{}
+	require.NoError(t, ApplyMetricsTLSToEndpoint(ctx, clientset, "rook-ceph", "rook-ceph-exporter", spec, &endpoint))
+	assert.Empty(t, endpoint.BearerTokenFile)
+	assert.Equal(t, "rook-ceph-exporter.rook-ceph.svc", *endpoint.TLSConfig.ServerName)
+	assert.Equal(t, "prometheus-client", endpoint.TLSConfig.Cert.Secret.Name)
+	assert.Equal(t, "tls.crt", endpoint.TLSConfig.Cert.Secret.Key)
+	assert.Equal(t, "prometheus-client", endpoint.TLSConfig.KeySecret.Name)
+	assert.Equal(t, "tls.key", endpoint.TLSConfig.KeySecret.Key)
+}
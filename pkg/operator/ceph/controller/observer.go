@@ -0,0 +1,404 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/exec"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// ObserverActionsConfigMapName is the ConfigMap published by the operator in observer mode with
+	// the actions the controllers would have taken
+	ObserverActionsConfigMapName = "rook-ceph-observer-actions"
+	observerActionsKey           = "actions"
+	observerActionsPublishPeriod = time.Minute
+	// the actions are deduplicated, the number of distinct actions is capped to keep the ConfigMap small
+	maxObserverActions = 500
+	// the actions only keep the first words of the commands to not publish the secrets in their args
+	observerCommandWords = 3
+)
+
+// ObserverMode is set by the operator flag to run the controllers in read-only mode. The controllers
+// still compute and report the status and health of the resources, but the changes to the
+// kubernetes resources are only dry runs and the ceph commands changing the cluster are not run.
+var ObserverMode = false
+
+// ErrObserverMode is returned for the commands that are not run in observer mode
+var ErrObserverMode = errors.New("the operator is in observer mode")
+
+// ObserverAction is an action the controllers would have taken without the observer mode
+type ObserverAction struct {
+	Action   string      `json:"action"`
+	Count    int         `json:"count"`
+	LastSeen metav1.Time `json:"lastSeen"`
+}
+
+var (
+	observerActions     = map[string]*ObserverAction{}
+	observerActionsLock sync.Mutex
+)
+
+type observerExemptKey struct{}
+
+// the ceph subcommands that only read the state of the cluster
+var readOnlyCephCommands = map[string]bool{
+	"df": true, "du": true, "dump": true, "exists": true, "get": true, "getcrushmap": true, "getpath": true,
+	"health": true, "info": true, "list": true, "ls": true, "lspools": true, "metadata": true,
+	"ok-to-stop": true, "quorum_status": true, "report": true, "safe-to-destroy": true, "services": true,
+	"stat": true, "stats": true, "status": true, "tree": true, "version": true, "versions": true,
+}
+
+// the ceph subcommands that change the cluster, checked in case an object is named like a read-only
+// subcommand, for example "ceph osd pool set ls size 3"
+var mutatingCephCommands = map[string]bool{
+	"add": true, "assimilate": true, "commit": true, "create": true, "delete": true, "destroy": true,
+	"disable": true, "enable": true, "get-or-create": true, "get-or-create-key": true, "import": true,
+	"link": true, "modify": true, "mv": true, "purge": true, "remove": true, "rename": true, "reweight": true,
+	"rm": true, "set": true, "unlink": true, "unset": true, "update": true,
+}
+
+// the flags of the commands that are followed by a value
+var valueFlags = map[string]bool{"--format": true, "-f": true, "--out-file": true, "-o": true, "-i": true, "--infile": true, "--outfile": true}
+
+// the tools whose commands can change the ceph cluster
+var cephTools = map[string]bool{
+	"ceph": true, "rbd": true, "rados": true, "radosgw-admin": true, "ganesha-rados-grace": true,
+}
+
+// RecordObserverAction records an action the controllers would have taken without the observer mode
+func RecordObserverAction(action string) {
+	observerActionsLock.Lock()
+	defer observerActionsLock.Unlock()
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	if existing, ok := observerActions[action]; ok {
+		existing.Count++
+		existing.LastSeen = now
+		return
+	}
+	if len(observerActions) >= maxObserverActions {
+		logger.Debugf("observer mode: not recording action %q, %d actions were already recorded", action, maxObserverActions)
+		return
+	}
+	logger.Infof("observer mode: would %s", action)
+	observerActions[action] = &ObserverAction{Action: action, Count: 1, LastSeen: now}
+}
+
+// ObserverActions returns the actions recorded in observer mode, sorted by action
+func ObserverActions() []ObserverAction {
+	observerActionsLock.Lock()
+	defer observerActionsLock.Unlock()
+	actions := []ObserverAction{}
+	for _, action := range observerActions {
+		actions = append(actions, *action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Action < actions[j].Action })
+	return actions
+}
+
+// PublishObserverActions publishes the actions recorded in observer mode in the observer actions
+// ConfigMap of the operator namespace
+func PublishObserverActions(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	raw, err := json.MarshalIndent(ObserverActions(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the observer actions")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ObserverActionsConfigMapName, Namespace: namespace},
+		Data:       map[string]string{observerActionsKey: string(raw)},
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(observerExempt(ctx), clientset, cm); err != nil {
+		return errors.Wrap(err, "failed to publish the observer actions")
+	}
+	return nil
+}
+
+// RunObserverActionsPublisher publishes the observer actions periodically until the context is done
+func RunObserverActionsPublisher(ctx context.Context, clientset kubernetes.Interface, namespace string) {
+	if !ObserverMode {
+		return
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := PublishObserverActions(ctx, clientset, namespace); err != nil {
+			logger.Warningf("failed to publish the observer actions. %v", err)
+		}
+	}, observerActionsPublishPeriod)
+}
+
+// observerExempt returns a context whose requests are not dry runs in observer mode, for the
+// resources the operator publishes to be observed
+func observerExempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, observerExemptKey{}, true)
+}
+
+// WrapObserverConfig makes the requests changing the kubernetes resources dry runs in observer mode
+func WrapObserverConfig(config *rest.Config) {
+	if !ObserverMode {
+		return
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &observerTransport{next: rt}
+	})
+}
+
+// observerTransport sends the requests changing the kubernetes resources as dry runs and records
+// them as observer actions. The status updates, events and leases are still sent so that the
+// controllers report the status of the resources and the operator keeps its leadership.
+type observerTransport struct {
+	next http.RoundTripper
+}
+
+func (t *observerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isExecRequest(req.URL.Path) {
+		// the commands run in the pods cannot be dry runs, only the read-only commands are run
+		command := req.URL.Query()["command"]
+		if len(command) > 0 && commandChangesCluster(command[0], command[1:], true) {
+			action := fmt.Sprintf("run %q in %s", describeCommand(command[0], command[1:]), requestResource(req.URL.Path))
+			RecordObserverAction(action)
+			return nil, errors.Wrapf(ErrObserverMode, "not running %q", describeCommand(command[0], command[1:]))
+		}
+		return t.next.RoundTrip(req)
+	}
+	if !isMutatingRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	RecordObserverAction(fmt.Sprintf("%s %s", requestVerb(req.Method), requestResource(req.URL.Path)))
+	dryRun := req.Clone(req.Context())
+	query := dryRun.URL.Query()
+	query.Set("dryRun", metav1.DryRunAll)
+	dryRun.URL.RawQuery = query.Encode()
+	return t.next.RoundTrip(dryRun)
+}
+
+func isMutatingRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+	if exempt, ok := req.Context().Value(observerExemptKey{}).(bool); ok && exempt {
+		return false
+	}
+	path := req.URL.Path
+	if strings.HasSuffix(path, "/status") || strings.HasPrefix(path, "/apis/coordination.k8s.io/") {
+		return false
+	}
+	if strings.HasSuffix(path, "/events") || strings.Contains(path, "/events/") {
+		return false
+	}
+	// the token and access reviews do not change any resource
+	return !strings.HasPrefix(path, "/apis/authentication.k8s.io/") && !strings.HasPrefix(path, "/apis/authorization.k8s.io/")
+}
+
+func isExecRequest(path string) bool {
+	return strings.HasSuffix(path, "/exec") || strings.HasSuffix(path, "/attach")
+}
+
+func requestVerb(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	default:
+		return "delete"
+	}
+}
+
+// requestResource returns the resource of the request path as "<resource> <namespace>/<name>"
+func requestResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// skip the "api/<version>" or "apis/<group>/<version>" prefix
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	}
+	namespace := ""
+	if len(parts) > 2 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) == 0 {
+		return path
+	}
+	resource := parts[0]
+	if len(parts) > 2 {
+		resource += "/" + strings.Join(parts[2:], "/")
+	}
+	name := ""
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+	switch {
+	case namespace != "" && name != "":
+		return fmt.Sprintf("%s %s/%s", resource, namespace, name)
+	case namespace != "":
+		return fmt.Sprintf("%s in %s", resource, namespace)
+	case name != "":
+		return fmt.Sprintf("%s %s", resource, name)
+	}
+	return resource
+}
+
+// commandChangesCluster returns whether the command can change the ceph cluster. The commands of the
+// other tools only change the local files of the operator, unless they are run in a pod.
+func commandChangesCluster(command string, args []string, inPod bool) bool {
+	command, args = unwrapCommand(command, args)
+	if !cephTools[command] {
+		return inPod
+	}
+	for _, word := range commandWords(args, len(args)) {
+		if mutatingCephCommands[word] {
+			return true
+		}
+		if readOnlyCephCommands[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// unwrapCommand returns the command run by the "timeout" and "kubectl exec" wrappers
+func unwrapCommand(command string, args []string) (string, []string) {
+	for {
+		switch {
+		case command == "timeout" && len(args) > 1:
+			command, args = args[1], args[2:]
+		case command == "kubectl":
+			i := 0
+			for i < len(args) && args[i] != "--" {
+				i++
+			}
+			if i+1 >= len(args) {
+				return command, args
+			}
+			command, args = args[i+1], args[i+2:]
+		default:
+			return command, args
+		}
+	}
+}
+
+// commandWords returns the first words of the command that are not flags
+func commandWords(args []string, max int) []string {
+	words := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if valueFlags[arg] {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		words = append(words, arg)
+		if len(words) == max {
+			break
+		}
+	}
+	return words
+}
+
+func describeCommand(command string, args []string) string {
+	command, args = unwrapCommand(command, args)
+	return strings.Join(append([]string{command}, commandWords(args, observerCommandWords)...), " ")
+}
+
+// NewObserverExecutor returns the executor of the operator in observer mode, which does not run the
+// ceph commands that can change the cluster
+func NewObserverExecutor(executor exec.Executor) exec.Executor {
+	if !ObserverMode {
+		return executor
+	}
+	return &observerExecutor{executor: executor}
+}
+
+type observerExecutor struct {
+	executor exec.Executor
+}
+
+// blocked records the commands that change the cluster and returns the error to return instead of
+// running them
+func (e *observerExecutor) blocked(command string, args []string) error {
+	if !commandChangesCluster(command, args, false) {
+		return nil
+	}
+	RecordObserverAction(fmt.Sprintf("run %q", describeCommand(command, args)))
+	return errors.Wrapf(ErrObserverMode, "not running %q", describeCommand(command, args))
+}
+
+func (e *observerExecutor) ExecuteCommand(command string, arg ...string) error {
+	if err := e.blocked(command, arg); err != nil {
+		return err
+	}
+	return e.executor.ExecuteCommand(command, arg...)
+}
+
+func (e *observerExecutor) ExecuteCommandWithEnv(env []string, command string, arg ...string) error {
+	if err := e.blocked(command, arg); err != nil {
+		return err
+	}
+	return e.executor.ExecuteCommandWithEnv(env, command, arg...)
+}
+
+func (e *observerExecutor) ExecuteCommandWithOutput(command string, arg ...string) (string, error) {
+	if err := e.blocked(command, arg); err != nil {
+		return "", err
+	}
+	return e.executor.ExecuteCommandWithOutput(command, arg...)
+}
+
+func (e *observerExecutor) ExecuteCommandWithCombinedOutput(command string, arg ...string) (string, error) {
+	if err := e.blocked(command, arg); err != nil {
+		return "", err
+	}
+	return e.executor.ExecuteCommandWithCombinedOutput(command, arg...)
+}
+
+func (e *observerExecutor) ExecuteCommandWithTimeout(timeout time.Duration, command string, arg ...string) (string, error) {
+	if err := e.blocked(command, arg); err != nil {
+		return "", err
+	}
+	return e.executor.ExecuteCommandWithTimeout(timeout, command, arg...)
+}
+
+func (e *observerExecutor) ExecuteCommandWithStdin(timeout time.Duration, command string, stdin *string, arg ...string) error {
+	if err := e.blocked(command, arg); err != nil {
+		return err
+	}
+	return e.executor.ExecuteCommandWithStdin(timeout, command, stdin, arg...)
+}
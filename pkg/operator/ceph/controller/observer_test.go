@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func resetObserverActions(t *testing.T) {
+	originalActions := observerActions
+	t.Cleanup(func() { observerActions = originalActions })
+	observerActions = map[string]*ObserverAction{}
+}
+
+func TestCommandChangesCluster(t *testing.T) {
+	tests := []struct {
+		command string
+		args    []string
+		inPod   bool
+		changes bool
+	}{
+		{"ceph", []string{"status", "--connect-timeout=15", "--format", "json"}, false, false},
+		{"ceph", []string{"osd", "pool", "get", "replicapool", "all"}, false, false},
+		{"ceph", []string{"osd", "pool", "set", "ls", "size", "3"}, false, true},
+		{"ceph", []string{"auth", "get-or-create-key", "client.admin"}, false, true},
+		{"ceph", []string{"config", "set", "global", "mon_warn_on_pool_no_redundancy", "false"}, false, true},
+		{"radosgw-admin", []string{"period", "get", "--rgw-realm=realm"}, false, false},
+		{"radosgw-admin", []string{"period", "update", "--commit"}, false, true},
+		{"rbd", []string{"mirror", "pool", "info", "replicapool"}, false, false},
+		{"ceph", []string{"osd", "pool", "application", "get", "replicapool"}, false, false},
+		// the ceph commands run in the toolbox are checked
+		{"kubectl", []string{"exec", "-i", "toolbox", "-n", "ns", "--", "timeout", "15", "ceph", "osd", "out", "0"}, false, true},
+		{"kubectl", []string{"exec", "-i", "toolbox", "-n", "ns", "--", "timeout", "15", "ceph", "osd", "tree"}, false, false},
+		// the other tools only change the files of the operator, but may change the pods they run in
+		{"ceph-authtool", []string{"--create-keyring", "/tmp/keyring"}, false, false},
+		{"ceph-authtool", []string{"--create-keyring", "/tmp/keyring"}, true, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.changes, commandChangesCluster(tt.command, tt.args, tt.inPod), "%s %v", tt.command, tt.args)
+	}
+
+	// the values of the flags and the args after the first words are not reported
+	assert.Equal(t, "ceph config-key set rgw/cert", describeCommand("ceph", []string{"config-key", "set", "rgw/cert", "secret", "--format", "json"}))
+	assert.Equal(t, "ceph osd set noout", describeCommand("ceph", []string{"osd", "set", "noout", "--format", "json"}))
+}
+
+func TestObserverTransport(t *testing.T) {
+	resetObserverActions(t)
+	next := &recordingTransport{}
+	transport := &observerTransport{next: next}
+	ctx := context.TODO()
+	send := func(ctx context.Context, method, url string) error {
+		req, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		return err
+	}
+
+	// the reads are sent as is
+	require.NoError(t, send(ctx, http.MethodGet, "https://k8s/apis/apps/v1/namespaces/rook-ceph/deployments/rook-ceph-mon-a"))
+	assert.Empty(t, next.requests[0].URL.Query().Get("dryRun"))
+
+	// the changes are dry runs
+	require.NoError(t, send(ctx, http.MethodPut, "https://k8s/apis/apps/v1/namespaces/rook-ceph/deployments/rook-ceph-mon-a"))
+	assert.Equal(t, metav1.DryRunAll, next.requests[1].URL.Query().Get("dryRun"))
+	require.NoError(t, send(ctx, http.MethodPost, "https://k8s/api/v1/namespaces/rook-ceph/services"))
+	assert.Equal(t, metav1.DryRunAll, next.requests[2].URL.Query().Get("dryRun"))
+	require.NoError(t, send(ctx, http.MethodDelete, "https://k8s/api/v1/nodes/node1"))
+	assert.Equal(t, metav1.DryRunAll, next.requests[3].URL.Query().Get("dryRun"))
+
+	// the status, events, leases and the resources published by the operator are updated
+	require.NoError(t, send(ctx, http.MethodPut, "https://k8s/apis/ceph.rook.io/v1/namespaces/rook-ceph/cephclusters/my-cluster/status"))
+	require.NoError(t, send(ctx, http.MethodPost, "https://k8s/api/v1/namespaces/rook-ceph/events"))
+	require.NoError(t, send(ctx, http.MethodPut, "https://k8s/apis/coordination.k8s.io/v1/namespaces/rook-ceph/leases/rook-ceph-operator"))
+	require.NoError(t, send(observerExempt(ctx), http.MethodPut, "https://k8s/api/v1/namespaces/rook-ceph/configmaps/rook-ceph-field-impact"))
+	for _, req := range next.requests[4:] {
+		assert.Empty(t, req.URL.Query().Get("dryRun"), req.URL.Path)
+	}
+
+	// only the read-only commands are run in the pods
+	require.NoError(t, send(ctx, http.MethodPost, "https://k8s/api/v1/namespaces/rook-ceph/pods/tools/exec?command=ceph&command=status"))
+	err := send(ctx, http.MethodPost, "https://k8s/api/v1/namespaces/rook-ceph/pods/tools/exec?command=ceph&command=osd&command=purge&command=0")
+	assert.True(t, errors.Is(err, ErrObserverMode))
+	assert.Len(t, next.requests, 9)
+
+	actions := []string{}
+	for _, action := range ObserverActions() {
+		actions = append(actions, action.Action)
+	}
+	assert.Equal(t, []string{
+		"create services in rook-ceph",
+		"delete nodes node1",
+		`run "ceph osd purge 0" in pods/exec rook-ceph/tools`,
+		"update deployments rook-ceph/rook-ceph-mon-a",
+	}, actions)
+}
+
+func TestObserverExecutor(t *testing.T) {
+	resetObserverActions(t)
+	original := ObserverMode
+	t.Cleanup(func() { ObserverMode = original })
+
+	runs := 0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			runs++
+			return "", nil
+		},
+	}
+	ObserverMode = false
+	assert.Equal(t, executor, NewObserverExecutor(executor))
+
+	ObserverMode = true
+	observer := NewObserverExecutor(executor)
+	_, err := observer.ExecuteCommandWithOutput("ceph", "osd", "pool", "ls", "--format", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runs)
+
+	_, err = observer.ExecuteCommandWithOutput("ceph", "osd", "pool", "create", "replicapool", "--format", "json")
+	assert.True(t, errors.Is(err, ErrObserverMode))
+	assert.Equal(t, 1, runs)
+	_, err = observer.ExecuteCommandWithOutput("ceph", "osd", "pool", "create", "replicapool", "--format", "json")
+	assert.Error(t, err)
+
+	actions := ObserverActions()
+	require.Len(t, actions, 1)
+	assert.Equal(t, `run "ceph osd pool create"`, actions[0].Action)
+	assert.Equal(t, 2, actions[0].Count)
+}
+
+func TestPublishObserverActions(t *testing.T) {
+	resetObserverActions(t)
+	ctx := context.TODO()
+	clientset := fake.NewSimpleClientset()
+	RecordObserverAction("update deployments rook-ceph/rook-ceph-mon-a")
+
+	require.NoError(t, PublishObserverActions(ctx, clientset, "rook-ceph"))
+	cm, err := clientset.CoreV1().ConfigMaps("rook-ceph").Get(ctx, ObserverActionsConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	actions := []ObserverAction{}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[observerActionsKey]), &actions))
+	require.Len(t, actions, 1)
+	assert.Equal(t, "update deployments rook-ceph/rook-ceph-mon-a", actions[0].Action)
+	assert.Equal(t, 1, actions[0].Count)
+}
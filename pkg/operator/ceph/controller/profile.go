@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyClusterProfile merges the defaults of the RookClusterProfile referenced by the CephCluster
+// into its spec. The spec is only changed in memory, the settings of the CephCluster override the
+// settings of the profile.
+func ApplyClusterProfile(ctx context.Context, c client.Client, cephCluster *cephv1.CephCluster) error {
+	if cephCluster.Spec.ProfileRef == nil || cephCluster.Spec.ProfileRef.Name == "" {
+		return nil
+	}
+	profile := &cephv1.RookClusterProfile{}
+	if err := c.Get(ctx, types.NamespacedName{Name: cephCluster.Spec.ProfileRef.Name}, profile); err != nil {
+		return errors.Wrapf(err, "failed to get the cluster profile %q", cephCluster.Spec.ProfileRef.Name)
+	}
+	if err := mergeClusterProfile(&cephCluster.Spec, &profile.Spec); err != nil {
+		return errors.Wrapf(err, "failed to apply the cluster profile %q", profile.Name)
+	}
+	logger.Debugf("applied the cluster profile %q to CephCluster %q", profile.Name, cephCluster.Name)
+	return nil
+}
+
+// mergeClusterProfile merges the profile settings into the cluster spec. The settings are merged
+// key by key, so that for example the placement of all the daemons in the profile and the
+// placement of the mons in the cluster both apply. Lists are not merged, a list in the cluster
+// replaces the list in the profile.
+func mergeClusterProfile(spec *cephv1.ClusterSpec, profile *cephv1.RookClusterProfileSpec) error {
+	clusterSettings := cephv1.RookClusterProfileSpec{
+		CephVersion: &spec.CephVersion,
+		Resources:   spec.Resources,
+		Placement:   spec.Placement,
+		Monitoring:  &spec.Monitoring,
+	}
+	defaults, err := toJSONMap(profile)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the profile settings")
+	}
+	overrides, err := toJSONMap(&clusterSettings)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the cluster settings")
+	}
+
+	raw, err := json.Marshal(mergeJSONMaps(defaults, overrides))
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the merged settings")
+	}
+	merged := cephv1.RookClusterProfileSpec{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return errors.Wrap(err, "failed to parse the merged settings")
+	}
+
+	if merged.CephVersion != nil {
+		spec.CephVersion = *merged.CephVersion
+	}
+	spec.Resources = merged.Resources
+	spec.Placement = merged.Placement
+	if merged.Monitoring != nil {
+		spec.Monitoring = *merged.Monitoring
+	}
+	return nil
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mergeJSONMaps merges the overrides into the defaults, recursing into the nested objects
+func mergeJSONMaps(defaults, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, override := range overrides {
+		defaultMap, defaultIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := override.(map[string]interface{})
+		if defaultIsMap && overrideIsMap {
+			merged[key] = mergeJSONMaps(defaultMap, overrideMap)
+			continue
+		}
+		merged[key] = override
+	}
+	return merged
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyClusterProfile(t *testing.T) {
+	profile := &cephv1.RookClusterProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: cephv1.RookClusterProfileSpec{
+			CephVersion: &cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v19", ImagePullPolicy: v1.PullIfNotPresent},
+			Resources: cephv1.ResourceSpec{
+				"mon": v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")}},
+				"osd": v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")}},
+			},
+			Placement: cephv1.PlacementSpec{
+				cephv1.KeyAll: cephv1.Placement{Tolerations: []v1.Toleration{{Key: "storage-node", Operator: v1.TolerationOpExists}}},
+			},
+			Monitoring: &cephv1.MonitoringSpec{Enabled: true, Port: 9283},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(profile).Build()
+
+	t.Run("no profile", func(t *testing.T) {
+		cephCluster := &cephv1.CephCluster{Spec: cephv1.ClusterSpec{CephVersion: cephv1.CephVersionSpec{Image: "ceph:v18"}}}
+		assert.NoError(t, ApplyClusterProfile(context.TODO(), cl, cephCluster))
+		assert.Equal(t, "ceph:v18", cephCluster.Spec.CephVersion.Image)
+		assert.Nil(t, cephCluster.Spec.Resources)
+	})
+
+	t.Run("missing profile", func(t *testing.T) {
+		cephCluster := &cephv1.CephCluster{Spec: cephv1.ClusterSpec{ProfileRef: &cephv1.ClusterProfileReference{Name: "missing"}}}
+		err := ApplyClusterProfile(context.TODO(), cl, cephCluster)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get the cluster profile "missing"`)
+	})
+
+	t.Run("inherited settings", func(t *testing.T) {
+		cephCluster := &cephv1.CephCluster{Spec: cephv1.ClusterSpec{ProfileRef: &cephv1.ClusterProfileReference{Name: "production"}}}
+		assert.NoError(t, ApplyClusterProfile(context.TODO(), cl, cephCluster))
+		assert.Equal(t, "quay.io/ceph/ceph:v19", cephCluster.Spec.CephVersion.Image)
+		assert.Equal(t, v1.PullIfNotPresent, cephCluster.Spec.CephVersion.ImagePullPolicy)
+		assert.Equal(t, "2Gi", cephCluster.Spec.Resources["mon"].Limits.Memory().String())
+		assert.Equal(t, "storage-node", cephCluster.Spec.Placement[cephv1.KeyAll].Tolerations[0].Key)
+		assert.True(t, cephCluster.Spec.Monitoring.Enabled)
+		assert.Equal(t, 9283, cephCluster.Spec.Monitoring.Port)
+	})
+
+	t.Run("overridden settings", func(t *testing.T) {
+		cephCluster := &cephv1.CephCluster{Spec: cephv1.ClusterSpec{
+			ProfileRef:  &cephv1.ClusterProfileReference{Name: "production"},
+			CephVersion: cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v19.2.1"},
+			Resources: cephv1.ResourceSpec{
+				"mon": v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}},
+			},
+			Placement: cephv1.PlacementSpec{
+				cephv1.KeyMon: cephv1.Placement{PodAntiAffinity: &v1.PodAntiAffinity{}},
+			},
+			Monitoring: cephv1.MonitoringSpec{Port: 9284},
+		}}
+		assert.NoError(t, ApplyClusterProfile(context.TODO(), cl, cephCluster))
+		spec := cephCluster.Spec
+		// the cluster settings override the profile
+		assert.Equal(t, "quay.io/ceph/ceph:v19.2.1", spec.CephVersion.Image)
+		assert.Equal(t, "1Gi", spec.Resources["mon"].Limits.Memory().String())
+		assert.Equal(t, 9284, spec.Monitoring.Port)
+		// the settings not set in the cluster are inherited
+		assert.Equal(t, v1.PullIfNotPresent, spec.CephVersion.ImagePullPolicy)
+		assert.Equal(t, "4Gi", spec.Resources["osd"].Limits.Memory().String())
+		assert.True(t, spec.Monitoring.Enabled)
+		assert.Len(t, spec.Placement, 2)
+		assert.NotNil(t, spec.Placement[cephv1.KeyMon].PodAntiAffinity)
+		assert.Equal(t, "storage-node", spec.Placement[cephv1.KeyAll].Tolerations[0].Key)
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// The optional maintenance actions of the operator deferred while the cluster is recovering
+const (
+	MaintenanceOSDUpdate          = "OSDUpdate"
+	MaintenanceMonFailback        = "MonFailback"
+	MaintenanceMonPVCMigration    = "MonPVCMigration"
+	MaintenanceMonStoreCompaction = "MonStoreCompaction"
+)
+
+var (
+	// recoveringPGStates are the parts of the states of the PGs recovering or backfilling, including
+	// the PGs waiting to recover or backfill
+	recoveringPGStates = []string{"recover", "backfill"}
+
+	// the deferred actions of the CephClusters are updated by the reconciles and the health checks
+	deferredMaintenanceMutex sync.Mutex
+
+	// hook for tests to override
+	cephStatus = cephclient.Status
+)
+
+// DeferForRecovery returns whether the optional maintenance action must be deferred because the
+// recovery or backfill load of the cluster exceeds the thresholds of the spec. The deferred actions
+// are listed in the CephCluster status until the recovery load is below the thresholds again.
+func DeferForRecovery(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.RecoveryDeferralSpec, action string) bool {
+	if spec == nil || !spec.Enabled {
+		return false
+	}
+	var message string
+	status, err := cephStatus(context, clusterInfo)
+	if err != nil {
+		// the action is optional, it waits until the recovery load is known
+		message = fmt.Sprintf("failed to check the recovery load. %v", err)
+	} else {
+		message = recoveryLoadExceeded(status.PgMap, spec)
+	}
+
+	if message == "" {
+		reportDeferredMaintenance(context, clusterInfo, "", "")
+		return false
+	}
+	logger.Infof("deferring the %s action while the cluster is recovering. %s", action, message)
+	reportDeferredMaintenance(context, clusterInfo, action, message)
+	return true
+}
+
+// recoveryLoadExceeded returns how the recovery load exceeds the thresholds, or an empty string
+func recoveryLoadExceeded(pgMap cephclient.PgMap, spec *cephv1.RecoveryDeferralSpec) string {
+	recovering := 0
+	for _, pgs := range pgMap.PgsByState {
+		for _, state := range recoveringPGStates {
+			if strings.Contains(pgs.StateName, state) {
+				recovering += pgs.Count
+				break
+			}
+		}
+	}
+	if recovering > 0 && recovering*100 > spec.MaxRecoveringPGsPercent*pgMap.NumPgs {
+		return fmt.Sprintf("%d of the %d PGs are recovering or backfilling, above the threshold of %d%%", recovering, pgMap.NumPgs, spec.MaxRecoveringPGsPercent)
+	}
+	if spec.MaxRecoveryBytesPerSecond != nil && pgMap.RecoveryBps > uint64(spec.MaxRecoveryBytesPerSecond.Value()) {
+		throughput := resource.NewQuantity(int64(pgMap.RecoveryBps), resource.BinarySI)
+		return fmt.Sprintf("the recovery throughput of %s/s is above the threshold of %s/s", throughput.String(), spec.MaxRecoveryBytesPerSecond.String())
+	}
+	return ""
+}
+
+// reportDeferredMaintenance publishes the deferred action on the CephCluster status with the
+// recovery load that defers it. The deferred actions are cleared when the action is empty.
+func reportDeferredMaintenance(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, action, message string) {
+	deferredMaintenanceMutex.Lock()
+	defer deferredMaintenanceMutex.Unlock()
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := context.Client.Get(clusterInfo.Context, clusterInfo.NamespacedName(), cephCluster); err != nil {
+			return err
+		}
+		deferred := updateDeferredMaintenance(cephCluster.Status.DeferredMaintenance, action, message, time.Now())
+		if reflect.DeepEqual(deferred, cephCluster.Status.DeferredMaintenance) {
+			return nil
+		}
+		cephCluster.Status.DeferredMaintenance = deferred
+		return reporting.UpdateStatus(context.Client, cephCluster)
+	})
+	if err != nil {
+		logger.Warningf("failed to report the deferred maintenance actions in the CephCluster status. %v", err)
+	}
+}
+
+// updateDeferredMaintenance returns the deferred actions with the action deferred by the message.
+// The time an action was first deferred is kept while the action is still deferred.
+func updateDeferredMaintenance(deferred []cephv1.DeferredMaintenanceStatus, action, message string, now time.Time) []cephv1.DeferredMaintenanceStatus {
+	if action == "" {
+		return nil
+	}
+	updated := make([]cephv1.DeferredMaintenanceStatus, 0, len(deferred)+1)
+	found := false
+	for _, d := range deferred {
+		if d.Action == action {
+			d.Message = message
+			found = true
+		}
+		updated = append(updated, d)
+	}
+	if !found {
+		updated = append(updated, cephv1.DeferredMaintenanceStatus{Action: action, Message: message, Since: metav1.NewTime(now.UTC().Truncate(time.Second))})
+	}
+	return updated
+}
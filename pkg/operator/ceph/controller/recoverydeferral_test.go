@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecoveryLoadExceeded(t *testing.T) {
+	pgMap := cephclient.PgMap{
+		NumPgs: 100,
+		PgsByState: []cephclient.PgStateEntry{
+			{StateName: "active+clean", Count: 90},
+			{StateName: "active+remapped+backfill_wait", Count: 6},
+			{StateName: "active+recovering+degraded", Count: 4},
+		},
+		RecoveryBps: 200 * 1024 * 1024,
+	}
+
+	spec := &cephv1.RecoveryDeferralSpec{Enabled: true}
+	assert.Equal(t, "10 of the 100 PGs are recovering or backfilling, above the threshold of 0%", recoveryLoadExceeded(pgMap, spec))
+	spec.MaxRecoveringPGsPercent = 10
+	assert.Equal(t, "", recoveryLoadExceeded(pgMap, spec))
+
+	limit := resource.MustParse("100Mi")
+	spec.MaxRecoveryBytesPerSecond = &limit
+	assert.Equal(t, "the recovery throughput of 200Mi/s is above the threshold of 100Mi/s", recoveryLoadExceeded(pgMap, spec))
+
+	// no pg is recovering
+	assert.Equal(t, "", recoveryLoadExceeded(cephclient.PgMap{NumPgs: 100, PgsByState: []cephclient.PgStateEntry{{StateName: "active+clean", Count: 100}}}, &cephv1.RecoveryDeferralSpec{Enabled: true}))
+}
+
+func TestDeferForRecovery(t *testing.T) {
+	ctx := context.TODO()
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	nsName := clusterInfo.NamespacedName()
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(scheme))
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clusterdContext := &clusterd.Context{Client: client}
+
+	recovering := true
+	var statusErr error
+	cephStatus = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) (cephclient.CephStatus, error) {
+		state := "active+clean"
+		if recovering {
+			state = "active+recovering"
+		}
+		return cephclient.CephStatus{PgMap: cephclient.PgMap{NumPgs: 10, PgsByState: []cephclient.PgStateEntry{{StateName: state, Count: 10}}}}, statusErr
+	}
+	defer func() { cephStatus = cephclient.Status }()
+	deferred := func() []cephv1.DeferredMaintenanceStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(ctx, nsName, cluster))
+		return cluster.Status.DeferredMaintenance
+	}
+
+	// the actions are not deferred without the spec
+	assert.False(t, DeferForRecovery(clusterdContext, clusterInfo, nil, MaintenanceOSDUpdate))
+	assert.False(t, DeferForRecovery(clusterdContext, clusterInfo, &cephv1.RecoveryDeferralSpec{}, MaintenanceOSDUpdate))
+	assert.Empty(t, deferred())
+
+	spec := &cephv1.RecoveryDeferralSpec{Enabled: true}
+	assert.True(t, DeferForRecovery(clusterdContext, clusterInfo, spec, MaintenanceOSDUpdate))
+	assert.True(t, DeferForRecovery(clusterdContext, clusterInfo, spec, MaintenanceMonStoreCompaction))
+	require.Len(t, deferred(), 2)
+	assert.Equal(t, MaintenanceOSDUpdate, deferred()[0].Action)
+	assert.Equal(t, "10 of the 10 PGs are recovering or backfilling, above the threshold of 0%", deferred()[0].Message)
+	assert.Equal(t, MaintenanceMonStoreCompaction, deferred()[1].Action)
+
+	// the action is deferred while the recovery load is unknown
+	statusErr = errors.New("timeout")
+	since := deferred()[0].Since
+	assert.True(t, DeferForRecovery(clusterdContext, clusterInfo, spec, MaintenanceOSDUpdate))
+	assert.Contains(t, deferred()[0].Message, "failed to check the recovery load")
+	assert.Equal(t, since, deferred()[0].Since)
+
+	// the deferred actions are cleared once the recovery is done
+	statusErr = nil
+	recovering = false
+	assert.False(t, DeferForRecovery(clusterdContext, clusterInfo, spec, MaintenanceMonFailback))
+	assert.Empty(t, deferred())
+}
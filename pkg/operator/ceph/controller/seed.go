@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SeedAppName = "resource-seed"
+
+	// SeedSpecEnv is the seed settings of the custom resource in JSON
+	SeedSpecEnv = "ROOK_SEED_SPEC"
+
+	// CephObjectStore seed env resources
+	CephObjectStoreNameEnv   = "OBJECT_STORE_NAME"
+	CephObjectRealmEnv       = "OBJECT_STORE_REALM"
+	CephObjectZoneGroupEnv   = "OBJECT_STORE_ZONE_GROUP"
+	CephObjectZoneEnv        = "OBJECT_STORE_ZONE"
+	CephObjectEndpointEnv    = "OBJECT_STORE_ENDPOINT"
+	CephObjectTLSCACertEnv   = "OBJECT_STORE_TLS_CA_CERT"
+	CephObjectTLSInsecureEnv = "OBJECT_STORE_TLS_INSECURE"
+
+	SeedPhaseRunning   = "Running"
+	SeedPhaseSucceeded = "Succeeded"
+	SeedPhaseFailed    = "Failed"
+)
+
+// SeedRequeue is the interval to check the seed job while it is running
+var SeedRequeue = 15 * time.Second
+
+// ResourceSeed defines a rook ceph resource to seed with data once it is created
+type ResourceSeed struct {
+	resource  k8sClient.Object
+	kind      string
+	cluster   *cephv1.CephCluster
+	ownerInfo *k8sutil.OwnerInfo
+	rookImage string
+	// spec is the seed settings of the custom resource
+	spec interface{}
+	// config defines the attributes of the custom resource passed in as environment variables in the seed job
+	config map[string]string
+}
+
+func NewResourceSeed(obj k8sClient.Object, kind string, cluster *cephv1.CephCluster, ownerInfo *k8sutil.OwnerInfo, rookImage string, spec interface{}, config map[string]string) *ResourceSeed {
+	return &ResourceSeed{
+		resource:  obj,
+		kind:      kind,
+		cluster:   cluster,
+		ownerInfo: ownerInfo,
+		rookImage: rookImage,
+		spec:      spec,
+		config:    config,
+	}
+}
+
+// Reconcile starts the seed job when the seed settings changed since they were last applied, and
+// checks the seed job while it is running. It returns the seed status to report on the resource.
+func (s *ResourceSeed) Reconcile(ctx context.Context, clientset kubernetes.Interface, jobName string, current *cephv1.SeedStatus) (*cephv1.SeedStatus, error) {
+	rawSpec, err := json.Marshal(s.spec)
+	if err != nil {
+		return current, errors.Wrapf(err, "failed to serialize the seed settings of %s %q", s.kind, s.resource.GetName())
+	}
+	specHash := k8sutil.Hash(string(rawSpec))
+
+	if current == nil || current.SpecHash != specHash {
+		return s.startJob(ctx, clientset, jobName, rawSpec, specHash)
+	}
+	if current.Phase != SeedPhaseRunning {
+		return current, nil
+	}
+
+	job, err := clientset.BatchV1().Jobs(s.resource.GetNamespace()).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Infof("seed job %q of %s %q not found, starting it again", jobName, s.kind, s.resource.GetName())
+			return s.startJob(ctx, clientset, jobName, rawSpec, specHash)
+		}
+		return current, errors.Wrapf(err, "failed to get the seed job %q", jobName)
+	}
+
+	status := current.DeepCopy()
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batch.JobComplete:
+			logger.Infof("successfully seeded %s %q", s.kind, s.resource.GetName())
+			status.Phase = SeedPhaseSucceeded
+			status.Message = ""
+		case batch.JobFailed:
+			logger.Errorf("failed to seed %s %q. %s", s.kind, s.resource.GetName(), condition.Message)
+			status.Phase = SeedPhaseFailed
+			status.Message = condition.Message
+		default:
+			continue
+		}
+		status.CompletionTime = condition.LastTransitionTime.UTC().Format(time.RFC3339)
+		break
+	}
+	return status, nil
+}
+
+// startJob replaces the seed job with a job applying the current seed settings
+func (s *ResourceSeed) startJob(ctx context.Context, clientset kubernetes.Interface, jobName string, rawSpec []byte, specHash string) (*cephv1.SeedStatus, error) {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: s.resource.GetNamespace(),
+			Labels:    map[string]string{k8sutil.AppAttr: SeedAppName},
+		},
+		Spec: batch.JobSpec{
+			Template: s.jobTemplateSpec(string(rawSpec)),
+		},
+	}
+	if s.ownerInfo != nil {
+		if err := s.ownerInfo.SetControllerReference(job); err != nil {
+			return nil, errors.Wrapf(err, "failed to set owner reference of the seed job %q", jobName)
+		}
+	}
+
+	logger.Infof("starting seed job %q of %s %q", jobName, s.kind, s.resource.GetName())
+	if err := k8sutil.RunReplaceableJob(ctx, clientset, job, true); err != nil {
+		return nil, errors.Wrapf(err, "failed to run seed job for %q resource named %q in namespace %q",
+			s.kind, s.resource.GetName(), s.resource.GetNamespace())
+	}
+	return &cephv1.SeedStatus{
+		Phase:     SeedPhaseRunning,
+		SpecHash:  specHash,
+		StartTime: time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *ResourceSeed) jobContainer(rawSpec string) v1.Container {
+	volumeMounts := []v1.VolumeMount{}
+	envVars := []v1.EnvVar{
+		{Name: "ROOK_LOG_LEVEL", Value: "DEBUG"},
+		{Name: k8sutil.PodNamespaceEnvVar, Value: s.resource.GetNamespace()},
+		{Name: SeedSpecEnv, Value: rawSpec},
+	}
+	if s.cluster.Spec.DataDirHostPath != "" {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: volumeName, MountPath: s.cluster.Spec.DataDirHostPath})
+		envVars = append(envVars, v1.EnvVar{Name: dataDirHostPath, Value: s.cluster.Spec.DataDirHostPath})
+	}
+	// append all the resource attributes as env variables.
+	for k, v := range s.config {
+		envVars = append(envVars, v1.EnvVar{Name: k, Value: v})
+	}
+	return v1.Container{
+		Name:            SeedAppName,
+		Image:           s.rookImage,
+		SecurityContext: PrivilegedContext(true),
+		VolumeMounts:    volumeMounts,
+		Env:             envVars,
+		Args:            []string{"ceph", "seed", s.kind},
+		Resources:       cephv1.GetCleanupResources(s.cluster.Spec.Resources),
+	}
+}
+
+func (s *ResourceSeed) jobTemplateSpec(rawSpec string) v1.PodTemplateSpec {
+	volumes := []v1.Volume{}
+	if s.cluster.Spec.DataDirHostPath != "" {
+		volumes = append(volumes, v1.Volume{Name: volumeName, VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: s.cluster.Spec.DataDirHostPath}}})
+	}
+
+	return v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SeedAppName,
+			Labels: map[string]string{k8sutil.AppAttr: SeedAppName},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				s.jobContainer(rawSpec),
+			},
+			Volumes:            volumes,
+			RestartPolicy:      v1.RestartPolicyOnFailure,
+			PriorityClassName:  cephv1.GetCleanupPriorityClassName(s.cluster.Spec.PriorityClassNames),
+			SecurityContext:    &v1.PodSecurityContext{},
+			ServiceAccountName: k8sutil.DefaultServiceAccount,
+		},
+	}
+}
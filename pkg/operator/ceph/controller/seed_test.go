@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResourceSeed(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	jobName := "seed-cephblockpool-replicapool"
+	cluster := &cephv1.CephCluster{Spec: cephv1.ClusterSpec{DataDirHostPath: "/var/lib/rook"}}
+	pool := &cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Name: "replicapool", Namespace: namespace}}
+	spec := &cephv1.BlockPoolSeedSpec{Images: []cephv1.SeedImageSpec{{Name: "disk", Size: resource.MustParse("1Gi")}}}
+	config := map[string]string{CephBlockPoolNameEnv: "replicapool"}
+	clientset := fake.NewSimpleClientset()
+
+	getJob := func() *batch.Job {
+		job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return job
+	}
+	setJobCondition := func(conditionType batch.JobConditionType, message string) {
+		job := getJob()
+		job.Status.Conditions = []batch.JobCondition{{Type: conditionType, Status: v1.ConditionTrue, Message: message}}
+		_, err := clientset.BatchV1().Jobs(namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	seed := NewResourceSeed(pool, "CephBlockPool", cluster, nil, "rook/ceph:test", spec, config)
+	status, err := seed.Reconcile(ctx, clientset, jobName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, SeedPhaseRunning, status.Phase)
+	assert.NotEmpty(t, status.SpecHash)
+	container := getJob().Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"ceph", "seed", "CephBlockPool"}, container.Args)
+	assert.Contains(t, container.Env, v1.EnvVar{Name: SeedSpecEnv, Value: `{"images":[{"name":"disk","size":"1Gi"}]}`})
+	assert.Contains(t, container.Env, v1.EnvVar{Name: CephBlockPoolNameEnv, Value: "replicapool"})
+
+	t.Run("job running", func(t *testing.T) {
+		running, err := seed.Reconcile(ctx, clientset, jobName, status)
+		assert.NoError(t, err)
+		assert.Equal(t, status, running)
+	})
+
+	t.Run("job failed", func(t *testing.T) {
+		setJobCondition(batch.JobFailed, "BackoffLimitExceeded")
+		failed, err := seed.Reconcile(ctx, clientset, jobName, status)
+		assert.NoError(t, err)
+		assert.Equal(t, SeedPhaseFailed, failed.Phase)
+		assert.Equal(t, "BackoffLimitExceeded", failed.Message)
+		assert.NotEmpty(t, failed.CompletionTime)
+
+		// the failed seed is not retried until the settings change
+		unchanged, err := seed.Reconcile(ctx, clientset, jobName, failed)
+		assert.NoError(t, err)
+		assert.Equal(t, failed, unchanged)
+	})
+
+	t.Run("settings changed", func(t *testing.T) {
+		spec.Images = append(spec.Images, cephv1.SeedImageSpec{Name: "disk2", Size: resource.MustParse("2Gi")})
+		restarted, err := seed.Reconcile(ctx, clientset, jobName, status)
+		assert.NoError(t, err)
+		assert.Equal(t, SeedPhaseRunning, restarted.Phase)
+		assert.NotEqual(t, status.SpecHash, restarted.SpecHash)
+		assert.Empty(t, getJob().Status.Conditions)
+
+		setJobCondition(batch.JobComplete, "")
+		succeeded, err := seed.Reconcile(ctx, clientset, jobName, restarted)
+		assert.NoError(t, err)
+		assert.Equal(t, SeedPhaseSucceeded, succeeded.Phase)
+		assert.Equal(t, restarted.SpecHash, succeeded.SpecHash)
+	})
+
+	t.Run("job removed while running", func(t *testing.T) {
+		running := &cephv1.SeedStatus{Phase: SeedPhaseRunning, SpecHash: status.SpecHash}
+		spec.Images = spec.Images[:1]
+		require.NoError(t, clientset.BatchV1().Jobs(namespace).Delete(ctx, jobName, metav1.DeleteOptions{}))
+		restarted, err := seed.Reconcile(ctx, clientset, jobName, running)
+		assert.NoError(t, err)
+		assert.Equal(t, SeedPhaseRunning, restarted.Phase)
+		getJob()
+	})
+}
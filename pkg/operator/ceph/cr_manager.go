@@ -22,8 +22,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/operator/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/client/blocklist"
 	"github.com/rook/rook/pkg/operator/ceph/cluster"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/fleet"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/inventory"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/janitor"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/nodedaemon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/notifier"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd/removal"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/rbd"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/ceph/csi"
@@ -91,6 +97,12 @@ var AddToManagerFuncs = []func(manager.Manager, *clusterd.Context, context.Conte
 	subvolumegroup.Add,
 	radosnamespace.Add,
 	cosi.Add,
+	janitor.Add,
+	notifier.Add,
+	removal.Add,
+	inventory.Add,
+	blocklist.Add,
+	fleet.Add,
 }
 
 // AddToManagerOpFunc is a list of functions to add all Controllers to the Manager (entrypoint for
@@ -161,7 +173,9 @@ func (o *Operator) startCRDManager(context context.Context, mgrErrorCh chan erro
 	}
 
 	logger.Info("setting up the controller-runtime manager")
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOpts)
+	restConfig := ctrl.GetConfigOrDie()
+	opcontroller.WrapObserverConfig(restConfig)
+	mgr, err := ctrl.NewManager(restConfig, mgrOpts)
 	if err != nil {
 		mgrErrorCh <- errors.Wrap(err, "failed to set up overall controller-runtime manager")
 		return
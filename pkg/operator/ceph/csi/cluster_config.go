@@ -32,6 +32,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 
 	cephcsi "github.com/ceph/ceph-csi/api/deploy/kubernetes"
@@ -94,7 +95,7 @@ func MonEndpoints(mons map[string]*cephclient.MonInfo, requireMsgr2 bool) []stri
 			msgr1Suffix := fmt.Sprintf(":%d", cephclient.Msgr1port)
 			if strings.HasSuffix(m.Endpoint, msgr1Suffix) {
 				address := m.Endpoint[0:strings.LastIndex(m.Endpoint, msgr1Suffix)]
-				endpoint = fmt.Sprintf("%s:%d", address, cephclient.Msgr2port)
+				endpoint = fmt.Sprintf("%s:%d", address, m.GetMsgr2Port())
 				logger.Debugf("mon %q will use the msgrv2 port: %q", m.Name, endpoint)
 			}
 		}
@@ -440,3 +441,88 @@ func SaveCSIDriverOptions(clientset kubernetes.Interface, clusterNamespace strin
 
 	return nil
 }
+
+// GetClusterIDs returns the cluster IDs of the csi config map entries belonging to the cluster, which
+// include the cluster namespace and the IDs of the rados namespaces and subvolume groups of the cluster
+func GetClusterIDs(ctx context.Context, clientset kubernetes.Interface, clusterNamespace string) ([]string, error) {
+	clusterIDs := []string{clusterNamespace}
+	csiNamespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	if EnableCSIOperator() || csiNamespace == "" {
+		return clusterIDs, nil
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps(csiNamespace).Get(ctx, ConfigName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return clusterIDs, nil
+		}
+		return nil, errors.Wrap(err, "failed to fetch current csi config map")
+	}
+	currData := configMap.Data[ConfigKey]
+	if currData == "" {
+		return clusterIDs, nil
+	}
+	cc, err := parseCsiClusterConfig(currData)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range cc {
+		if entry.Namespace == clusterNamespace && entry.ClusterID != clusterNamespace {
+			clusterIDs = append(clusterIDs, entry.ClusterID)
+		}
+	}
+	return clusterIDs, nil
+}
+
+// RemoveStaleClusterConfigs removes the csi config map entries of the clusters whose namespace is
+// not in the given cluster namespaces, left behind when the clusters were deleted. The namespaces
+// of the removed entries are returned.
+func RemoveStaleClusterConfigs(ctx context.Context, clientset kubernetes.Interface, clusterNamespaces sets.Set[string]) ([]string, error) {
+	csiNamespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	if EnableCSIOperator() || csiNamespace == "" {
+		return nil, nil
+	}
+
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	configMap, err := clientset.CoreV1().ConfigMaps(csiNamespace).Get(ctx, ConfigName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to fetch current csi config map")
+	}
+	currData := configMap.Data[ConfigKey]
+	if currData == "" {
+		return nil, nil
+	}
+	cc, err := parseCsiClusterConfig(currData)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	kept := make(csiClusterConfig, 0, len(cc))
+	for _, entry := range cc {
+		if entry.Namespace != "" && !clusterNamespaces.Has(entry.Namespace) {
+			logger.Infof("removing the csi config of cluster ID %q of the deleted cluster in namespace %q", entry.ClusterID, entry.Namespace)
+			removed = append(removed, entry.Namespace)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	newData, err := formatCsiClusterConfig(kept)
+	if err != nil {
+		return nil, err
+	}
+	configMap.Data[ConfigKey] = newData
+	if _, err := clientset.CoreV1().ConfigMaps(csiNamespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return nil, errors.Wrap(err, "failed to remove the stale entries of the csi config map")
+	}
+	return removed, nil
+}
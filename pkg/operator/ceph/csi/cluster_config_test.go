@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -522,6 +523,14 @@ func TestMonEndpoints(t *testing.T) {
 		verifyEndpointPort(t, endpoints, "3300")
 	})
 
+	t.Run("convert to a custom msgr2 port", func(t *testing.T) {
+		monInfo := map[string]*cephclient.MonInfo{
+			"a": {Name: "a", Endpoint: "1.2.3.4:6789", Msgr2Port: 3301},
+		}
+		endpoints := MonEndpoints(monInfo, true)
+		assert.Equal(t, []string{"1.2.3.4:3301"}, endpoints)
+	})
+
 	t.Run("ipv6 endpoint conversion", func(t *testing.T) {
 		monInfo := map[string]*cephclient.MonInfo{
 			"a": {Name: "a", Endpoint: "[fd07:aaaa:bbbb:cccc::11]:6789"},
@@ -1062,3 +1071,36 @@ func Test_updateCsiConfigMapOwnerRefs(t *testing.T) {
 		assertOwner(t, clientset)
 	})
 }
+
+func TestRemoveStaleClusterConfigs(t *testing.T) {
+	ctx := context.TODO()
+	t.Setenv(k8sutil.PodNamespaceEnvVar, "rook-ceph")
+	clientset := test.New(t, 1)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigName, Namespace: "rook-ceph"},
+		Data: map[string]string{ConfigKey: `[
+			{"clusterID":"ns1","namespace":"ns1","monitors":["1.1.1.1:6789"]},
+			{"clusterID":"ns1-rados","namespace":"ns1","monitors":["1.1.1.1:6789"]},
+			{"clusterID":"ns2","namespace":"ns2","monitors":["2.2.2.2:6789"]},
+			{"clusterID":"external","monitors":["3.3.3.3:6789"]}]`},
+	}
+	_, err := clientset.CoreV1().ConfigMaps("rook-ceph").Create(ctx, cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	// the entries of the deleted cluster are removed, the entries without a namespace are kept
+	removed, err := RemoveStaleClusterConfigs(ctx, clientset, sets.New("ns2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1", "ns1"}, removed)
+	cm, err = clientset.CoreV1().ConfigMaps("rook-ceph").Get(ctx, ConfigName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	cc, err := parseCsiClusterConfig(cm.Data[ConfigKey])
+	assert.NoError(t, err)
+	assert.Len(t, cc, 2)
+	assert.Equal(t, "ns2", cc[0].ClusterID)
+	assert.Equal(t, "external", cc[1].ClusterID)
+
+	// nothing is removed while the clusters exist
+	removed, err = RemoveStaleClusterConfigs(ctx, clientset, sets.New("ns2"))
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
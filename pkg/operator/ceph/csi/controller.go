@@ -211,6 +211,7 @@ func (r *ReconcileCSI) reconcile(request reconcile.Request) (reconcile.Result, e
 	// if at least one cephcluster is present update the csi lograte sidecar
 	// with the first listed ceph cluster specs with logrotate enabled
 	r.setCSILogrotateParams(cephClusters.Items)
+	r.setCSIPlacementParams(cephClusters.Items)
 
 	err = peermap.CreateOrUpdateConfig(r.opManagerContext, r.context, &peermap.PeerIDMappings{})
 	if err != nil {
@@ -330,3 +331,24 @@ func (r *ReconcileCSI) setCSILogrotateParams(cephClustersItems []cephv1.CephClus
 		CSIParam.CSILogRotationPeriod = period
 	}
 }
+
+// setCSIPlacementParams sets the placement of the CSI pods from the first ceph cluster that
+// configures it. The CSI drivers are shared by all the clusters of the operator, so the placement
+// of the other clusters is ignored.
+func (r *ReconcileCSI) setCSIPlacementParams(cephClustersItems []cephv1.CephCluster) {
+	CSIParam.ProvisionerPlacement, CSIParam.PluginPlacement = nil, nil
+	configuredBy := ""
+	for _, cluster := range cephClustersItems {
+		csiSpec := cluster.Spec.CSI
+		if csiSpec.ProvisionerPlacement == nil && csiSpec.PluginPlacement == nil {
+			continue
+		}
+		if configuredBy != "" {
+			logger.Warningf("ignoring the CSI placement of cluster %q in namespace %q, the CSI placement of cluster %q is already applied", cluster.Name, cluster.Namespace, configuredBy)
+			continue
+		}
+		configuredBy = cluster.Name
+		CSIParam.ProvisionerPlacement = csiSpec.ProvisionerPlacement
+		CSIParam.PluginPlacement = csiSpec.PluginPlacement
+	}
+}
@@ -271,3 +271,23 @@ func TestCephCSIController(t *testing.T) {
 		assert.Equal(t, []string{namespace}, saveCSIDriverOptionsCalledForClusterNS)
 	})
 }
+
+func TestSetCSIPlacementParams(t *testing.T) {
+	r := &ReconcileCSI{}
+	placementA := &cephv1.Placement{Tolerations: []v1.Toleration{{Key: "a", Operator: v1.TolerationOpExists}}}
+	placementB := &cephv1.Placement{Tolerations: []v1.Toleration{{Key: "b", Operator: v1.TolerationOpExists}}}
+	clusters := []cephv1.CephCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "none", Namespace: "ns-none"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns-a"}, Spec: cephv1.ClusterSpec{CSI: cephv1.CSIDriverSpec{ProvisionerPlacement: placementA}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns-b"}, Spec: cephv1.ClusterSpec{CSI: cephv1.CSIDriverSpec{ProvisionerPlacement: placementB, PluginPlacement: placementB}}},
+	}
+
+	r.setCSIPlacementParams(clusters)
+	assert.Equal(t, placementA, CSIParam.ProvisionerPlacement)
+	assert.Nil(t, CSIParam.PluginPlacement)
+
+	// the placement is cleared when no cluster configures it anymore
+	r.setCSIPlacementParams(clusters[:1])
+	assert.Nil(t, CSIParam.ProvisionerPlacement)
+	assert.Nil(t, CSIParam.PluginPlacement)
+}
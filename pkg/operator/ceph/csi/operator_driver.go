@@ -76,7 +76,7 @@ func (r *ReconcileCSI) createOrUpdateDriverResources(cluster cephv1.CephCluster,
 
 func (r *ReconcileCSI) createOrUpdateRBDDriverResource(cluster cephv1.CephCluster, clusterInfo *cephclient.ClusterInfo) error {
 	resourceName := fmt.Sprintf("%s.rbd.csi.ceph.com", r.opConfig.OperatorNamespace)
-	spec, err := r.generateDriverSpec(cluster.Name)
+	spec, err := r.generateDriverSpec(cluster)
 	if err != nil {
 		return err
 	}
@@ -122,7 +122,7 @@ func (r *ReconcileCSI) createOrUpdateRBDDriverResource(cluster cephv1.CephCluste
 
 func (r *ReconcileCSI) createOrUpdateCephFSDriverResource(cluster cephv1.CephCluster, clusterInfo *cephclient.ClusterInfo) error {
 	resourceName := fmt.Sprintf("%s.cephfs.csi.ceph.com", r.opConfig.OperatorNamespace)
-	spec, err := r.generateDriverSpec(cluster.Name)
+	spec, err := r.generateDriverSpec(cluster)
 	if err != nil {
 		return err
 	}
@@ -174,7 +174,7 @@ func (r *ReconcileCSI) createOrUpdateCephFSDriverResource(cluster cephv1.CephClu
 
 func (r *ReconcileCSI) createOrUpdateNFSDriverResource(cluster cephv1.CephCluster, clusterInfo *cephclient.ClusterInfo) error {
 	resourceName := fmt.Sprintf("%s.nfs.csi.ceph.com", r.opConfig.OperatorNamespace)
-	spec, err := r.generateDriverSpec(cluster.Name)
+	spec, err := r.generateDriverSpec(cluster)
 	if err != nil {
 		return err
 	}
@@ -243,7 +243,7 @@ func (r ReconcileCSI) createOrUpdateDriverResource(clusterInfo *cephclient.Clust
 	return nil
 }
 
-func (r *ReconcileCSI) generateDriverSpec(clusterName string) (csiopv1a1.DriverSpec, error) {
+func (r *ReconcileCSI) generateDriverSpec(cluster cephv1.CephCluster) (csiopv1a1.DriverSpec, error) {
 	cephfsClientType := csiopv1a1.KernelCephFsClient
 	if CSIParam.ForceCephFSKernelClient == "false" {
 		cephfsClientType = csiopv1a1.AutoDetectCephFsClient
@@ -253,14 +253,14 @@ func (r *ReconcileCSI) generateDriverSpec(clusterName string) (csiopv1a1.DriverS
 		return csiopv1a1.DriverSpec{}, errors.Wrapf(err, "failed to create ceph-CSI operator config ImageSetConfigmap for CR %s", opConfigCRName)
 	}
 
-	return csiopv1a1.DriverSpec{
+	spec := csiopv1a1.DriverSpec{
 		Log: &csiopv1a1.LogSpec{
 			Verbosity: int(CSIParam.LogLevel),
 		},
 		ImageSet: &corev1.LocalObjectReference{
 			Name: imageSetCmName,
 		},
-		ClusterName:      &clusterName,
+		ClusterName:      &cluster.Name,
 		EnableMetadata:   &CSIParam.CSIEnableMetadata,
 		GenerateOMapInfo: &CSIParam.EnableOMAPGenerator,
 		FsGroupPolicy:    k8scsiv1.FileFSGroupPolicy,
@@ -289,7 +289,26 @@ func (r *ReconcileCSI) generateDriverSpec(clusterName string) (csiopv1a1.DriverS
 		},
 		DeployCsiAddons:  &CSIParam.EnableCSIAddonsSideCar,
 		CephFsClientType: cephfsClientType,
-	}, nil
+	}
+	// the drivers are shared by all the clusters, the placement is resolved by setCSIPlacementParams
+	// from the first cluster that configures it like for the drivers deployed by rook
+	applyClusterPlacementToPodCommonSpec(&spec.NodePlugin.PodCommonSpec, CSIParam.PluginPlacement)
+	applyClusterPlacementToPodCommonSpec(&spec.ControllerPlugin.PodCommonSpec, CSIParam.ProvisionerPlacement)
+
+	return spec, nil
+}
+
+// applyClusterPlacementToPodCommonSpec applies the CSI placement of the CephCluster to the pods of a
+// CSI operator driver. The topology spread constraints are rejected by ValidateCSIPlacement since
+// the drivers do not support them.
+func applyClusterPlacementToPodCommonSpec(spec *csiopv1a1.PodCommonSpec, placement *cephv1.Placement) {
+	if placement == nil {
+		return
+	}
+	podSpec := corev1.PodSpec{Affinity: spec.Affinity, Tolerations: spec.Tolerations}
+	applyClusterPlacement(&podSpec, placement)
+	spec.Affinity = podSpec.Affinity
+	spec.Tolerations = podSpec.Tolerations
 }
 
 func createDriverControllerPluginResources(key string) csiopv1a1.ControllerPluginResourcesSpec {
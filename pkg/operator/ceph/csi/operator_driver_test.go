@@ -63,6 +63,9 @@ func TestReconcileCSI_createOrUpdateDriverResources(t *testing.T) {
 				CephFS: cephv1.CSICephFSSpec{
 					KernelMountOptions: "ms_mode=crc",
 				},
+				ProvisionerPlacement: &cephv1.Placement{
+					Tolerations: []v1.Toleration{{Key: "provisioner", Operator: v1.TolerationOpExists}},
+				},
 			},
 			Network: cephv1.NetworkSpec{
 				Connections: &cephv1.ConnectionsSpec{
@@ -93,11 +96,24 @@ func TestReconcileCSI_createOrUpdateDriverResources(t *testing.T) {
 	c.SetName("testcluster")
 	c.NamespacedName()
 
+	// the placement of the first cluster that configures it is applied, even when the drivers are
+	// reconciled for another cluster
+	otherCluster := cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-ns"}}
+	r.setCSIPlacementParams([]cephv1.CephCluster{*cluster, otherCluster})
+	defer r.setCSIPlacementParams(nil)
+
 	err := r.createOrUpdateDriverResources(*cluster, c)
 	assert.NoError(t, err)
 
 	err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s.rbd.csi.ceph.com", c.Namespace), Namespace: ns}, driver)
 	assert.NoError(t, err)
+	assert.Equal(t, []v1.Toleration{{Key: "provisioner", Operator: v1.TolerationOpExists}}, driver.Spec.ControllerPlugin.Tolerations)
+
+	err = r.createOrUpdateDriverResources(otherCluster, c)
+	assert.NoError(t, err)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s.rbd.csi.ceph.com", c.Namespace), Namespace: ns}, driver)
+	assert.NoError(t, err)
+	assert.Equal(t, []v1.Toleration{{Key: "provisioner", Operator: v1.TolerationOpExists}}, driver.Spec.ControllerPlugin.Tolerations)
 
 	err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s.cephfs.csi.ceph.com", c.Namespace), Namespace: ns}, driver)
 	assert.NoError(t, err)
@@ -105,3 +121,19 @@ func TestReconcileCSI_createOrUpdateDriverResources(t *testing.T) {
 	err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s.nfs.csi.ceph.com", c.Namespace), Namespace: ns}, driver)
 	assert.NoError(t, err)
 }
+
+func TestValidateCSIPlacement(t *testing.T) {
+	defer func() { enableCSIOperator = false }()
+	topologySpread := &cephv1.Placement{
+		TopologySpreadConstraints: []v1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+	}
+
+	enableCSIOperator = false
+	assert.NoError(t, ValidateCSIPlacement(cephv1.CSIDriverSpec{ProvisionerPlacement: topologySpread}))
+
+	enableCSIOperator = true
+	assert.NoError(t, ValidateCSIPlacement(cephv1.CSIDriverSpec{}))
+	assert.NoError(t, ValidateCSIPlacement(cephv1.CSIDriverSpec{PluginPlacement: &cephv1.Placement{}}))
+	assert.Error(t, ValidateCSIPlacement(cephv1.CSIDriverSpec{ProvisionerPlacement: topologySpread}))
+	assert.Error(t, ValidateCSIPlacement(cephv1.CSIDriverSpec{PluginPlacement: topologySpread}))
+}
@@ -24,6 +24,7 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	k8sutil "github.com/rook/rook/pkg/operator/k8sutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -168,6 +169,36 @@ func applyToPodSpec(pod *corev1.PodSpec, n *corev1.NodeAffinity, t []corev1.Tole
 	}
 }
 
+// applyClusterPlacement applies the CSI placement of the CephCluster, which replaces the tolerations
+// and node affinity of the operator settings
+func applyClusterPlacement(pod *corev1.PodSpec, placement *cephv1.Placement) {
+	if placement == nil {
+		return
+	}
+	if placement.NodeAffinity != nil && pod.Affinity != nil {
+		pod.Affinity.NodeAffinity = nil
+	}
+	if placement.Tolerations != nil {
+		pod.Tolerations = nil
+	}
+	placement.ApplyToPodSpec(pod)
+}
+
+// ValidateCSIPlacement validates the CSI placement of the CephCluster. The drivers deployed by the
+// CSI operator cannot apply topology spread constraints.
+func ValidateCSIPlacement(csiSpec cephv1.CSIDriverSpec) error {
+	if !EnableCSIOperator() {
+		return nil
+	}
+	if csiSpec.ProvisionerPlacement != nil && csiSpec.ProvisionerPlacement.TopologySpreadConstraints != nil {
+		return errors.New("the topologySpreadConstraints of csi.provisionerPlacement are not supported when the CSI drivers are deployed by the CSI operator")
+	}
+	if csiSpec.PluginPlacement != nil && csiSpec.PluginPlacement.TopologySpreadConstraints != nil {
+		return errors.New("the topologySpreadConstraints of csi.pluginPlacement are not supported when the CSI drivers are deployed by the CSI operator")
+	}
+	return nil
+}
+
 func getPortFromConfig(env string, defaultPort uint16) (uint16, error) {
 	port := k8sutil.GetOperatorSetting(env, strconv.Itoa(int(defaultPort)))
 	if strings.TrimSpace(k8sutil.GetOperatorSetting(env, strconv.Itoa(int(defaultPort)))) == "" {
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -312,3 +313,51 @@ func Test_getImage(t *testing.T) {
 		})
 	}
 }
+
+func Test_applyClusterPlacement(t *testing.T) {
+	operatorToleration := corev1.Toleration{Key: "operator", Operator: corev1.TolerationOpExists}
+	operatorAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "role", Operator: corev1.NodeSelectorOpIn, Values: []string{"storage"}}}}},
+		},
+	}
+	newPodSpec := func() *corev1.PodSpec {
+		pod := &corev1.PodSpec{}
+		applyToPodSpec(pod, operatorAffinity.DeepCopy(), []corev1.Toleration{operatorToleration})
+		return pod
+	}
+
+	t.Run("no cluster placement", func(t *testing.T) {
+		pod := newPodSpec()
+		applyClusterPlacement(pod, nil)
+		assert.Equal(t, []corev1.Toleration{operatorToleration}, pod.Tolerations)
+		assert.Equal(t, operatorAffinity, pod.Affinity.NodeAffinity)
+	})
+
+	t.Run("cluster placement replaces the operator settings", func(t *testing.T) {
+		clusterToleration := corev1.Toleration{Key: "cluster-a", Operator: corev1.TolerationOpExists}
+		clusterAffinity := &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"cluster-a"}}}}},
+			},
+		}
+		spread := []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule}}
+		pod := newPodSpec()
+		applyClusterPlacement(pod, &cephv1.Placement{
+			NodeAffinity:              clusterAffinity,
+			Tolerations:               []corev1.Toleration{clusterToleration},
+			TopologySpreadConstraints: spread,
+		})
+		assert.Equal(t, []corev1.Toleration{clusterToleration}, pod.Tolerations)
+		assert.Equal(t, clusterAffinity, pod.Affinity.NodeAffinity)
+		assert.Equal(t, spread, pod.TopologySpreadConstraints)
+	})
+
+	t.Run("cluster placement with only tolerations", func(t *testing.T) {
+		clusterToleration := corev1.Toleration{Key: "cluster-a", Operator: corev1.TolerationOpExists}
+		pod := newPodSpec()
+		applyClusterPlacement(pod, &cephv1.Placement{Tolerations: []corev1.Toleration{clusterToleration}})
+		assert.Equal(t, []corev1.Toleration{clusterToleration}, pod.Tolerations)
+		assert.Equal(t, operatorAffinity, pod.Affinity.NodeAffinity)
+	})
+}
@@ -232,7 +232,8 @@ func (r *ReconcileClusterDisruption) reconcilePDBsForOSDs(
 	downOSDs []int,
 	pgHealthyRegex string,
 ) (reconcile.Result, error) {
-	pgHealthMsg, pgClean, err := cephclient.IsClusterClean(r.context.ClusterdContext, clusterInfo, pgHealthyRegex)
+	// the PGs are clean if the cluster is safe for the disruption of the OSDs
+	safety, err := cephclient.CheckOSDSafety(r.context.ClusterdContext, clusterInfo, cephclient.OSDSafetyCheck{RequireCleanPGs: true, PGHealthyRegex: pgHealthyRegex})
 	if err != nil {
 		// If the error contains that message, this means the cluster is not up and running
 		// No monitors are present and thus no ceph configuration has been created
@@ -243,6 +244,10 @@ func (r *ReconcileClusterDisruption) reconcilePDBsForOSDs(
 		logger.Debugf("ceph %q cluster failed to check cluster health. %v", request.Namespace, err)
 		return opcontroller.WaitForRequeueIfCephClusterNotReady, nil
 	}
+	pgHealthMsg, pgClean := safety.String(), safety.Safe
+	if pgClean {
+		pgHealthMsg = safety.PGHealthMsg
+	}
 
 	osdDown := len(downOSDs) > 0
 	maxUnavailableOSDCount := 1
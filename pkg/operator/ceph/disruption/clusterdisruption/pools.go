@@ -114,7 +114,7 @@ func (r *ReconcileClusterDisruption) reconcileCephObjectStore(cephObjectStoreLis
 			MatchLabels: map[string]string{"rgw": storeName},
 		}
 
-		rgwCount := objectStore.Spec.Gateway.Instances
+		rgwCount := objectStore.Spec.Gateway.TotalInstances()
 		minAvailable := &intstr.IntOrString{IntVal: rgwCount - 1}
 		if minAvailable.IntVal < 1 {
 			continue
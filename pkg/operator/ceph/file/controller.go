@@ -364,6 +364,33 @@ func (r *ReconcileCephFilesystem) reconcile(request reconcile.Request) (reconcil
 		return reconcileResponse, *cephFilesystem, err
 	}
 
+	// Pin the subtrees of the filesystem to the MDS ranks
+	// the reconcile is requeued until the missing pinned subtrees are created and pinned
+	result := reconcile.Result{}
+	if len(cephFilesystem.Spec.PinnedSubtrees) > 0 {
+		managedGroups, err := getManagedSubVolumeGroups(r.opManagerContext, r.client, cephFilesystem)
+		if err != nil {
+			return reconcile.Result{}, *cephFilesystem, err
+		}
+		missing, err := reconcilePinnedSubtrees(r.context, r.clusterInfo, cephFilesystem, managedGroups)
+		if err != nil {
+			return reconcile.Result{}, *cephFilesystem, err
+		}
+		if missing {
+			result = waitForPinnedSubtreeCreation
+		}
+	}
+
+	// Create the seed directories of the filesystem
+	// the reconcile is requeued to check the seed job until it completes
+	seedRunning, err := r.reconcileSeed(request.NamespacedName, cephFilesystem, &cephCluster)
+	if err != nil {
+		return reconcile.Result{}, *cephFilesystem, errors.Wrapf(err, "failed to seed filesystem %q", cephFilesystem.Name)
+	}
+	if seedRunning && (result.RequeueAfter == 0 || result.RequeueAfter > opcontroller.SeedRequeue) {
+		result = reconcile.Result{RequeueAfter: opcontroller.SeedRequeue}
+	}
+
 	statusUpdated := false
 
 	// Enable mirroring if needed
@@ -426,7 +453,7 @@ func (r *ReconcileCephFilesystem) reconcile(request reconcile.Request) (reconcil
 		r.updateStatus(observedGeneration, request.NamespacedName, cephv1.ConditionReady, nil)
 	}
 
-	return reconcile.Result{}, *cephFilesystem, nil
+	return result, *cephFilesystem, nil
 }
 
 func (r *ReconcileCephFilesystem) reconcileCreateFilesystem(cephFilesystem *cephv1.CephFilesystem) (reconcile.Result, error) {
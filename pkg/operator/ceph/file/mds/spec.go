@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	cephconfig "github.com/rook/rook/pkg/operator/ceph/config"
 	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
@@ -42,6 +43,23 @@ const (
 	mdsCacheMemoryResourceFactor = 0.8
 )
 
+func init() {
+	mdsDeployments := []string{"deployment/rook-ceph-mds-*"}
+	controller.RegisterFieldImpacts(&cephv1.CephFilesystem{},
+		controller.FieldImpact{Field: "spec.metadataServer.activeCount", Resources: mdsDeployments, Description: "The mdses are added or removed, the existing mdses are not restarted."},
+		controller.FieldImpact{Field: "spec.metadataServer.activeStandby", Resources: mdsDeployments},
+		controller.FieldImpact{Field: "spec.metadataServer.resources", Resources: mdsDeployments, Restart: true, Description: "The mds cache memory limit is set from the memory limit."},
+		controller.FieldImpact{Field: "spec.metadataServer.placement", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataServer.priorityClassName", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataServer.annotations", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataServer.labels", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataServer.livenessProbe", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataServer.startupProbe", Resources: mdsDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataPool", Description: "The pool is updated in ceph without a restart."},
+		controller.FieldImpact{Field: "spec.dataPools", Description: "The pools are created or updated in ceph without a restart."},
+	)
+}
+
 func (c *Cluster) makeDeployment(mdsConfig *mdsConfig, fsNamespacedname types.NamespacedName) (*apps.Deployment, error) {
 	mdsContainer := c.makeMdsDaemonContainer(mdsConfig, fsNamespacedname.Name)
 	mdsContainer = cephconfig.ConfigureStartupProbe(mdsContainer, c.fs.Spec.MetadataServer.StartupProbe)
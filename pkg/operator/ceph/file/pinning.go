@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/util/exec"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// waitForPinnedSubtreeCreation is the requeue result when a pinned subtree does not exist yet
+var waitForPinnedSubtreeCreation = reconcile.Result{Requeue: true, RequeueAfter: time.Minute}
+
+// reconcilePinnedSubtrees pins the subvolume groups and subvolumes of the filesystem to the MDS
+// ranks. The subtrees that do not exist yet, for example a subvolume group created later by the
+// CSI driver, are skipped and it returns true so that the reconcile is requeued until they are
+// pinned. The subvolume groups managed by a CephFilesystemSubVolumeGroup are pinned by its own
+// pinning settings, so they are skipped.
+func reconcilePinnedSubtrees(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, fs *cephv1.CephFilesystem, managedGroups []string) (bool, error) {
+	if len(fs.Spec.PinnedSubtrees) == 0 {
+		return false, nil
+	}
+	if fs.Spec.MetadataServer.ActiveCount < 2 {
+		logger.Infof("filesystem %q has a single active MDS, pinning its subtrees has no effect until more MDS are active", fs.Name)
+	}
+
+	missing := false
+	for _, subtree := range fs.Spec.PinnedSubtrees {
+		if subtree.SubVolume == "" && slices.Contains(managedGroups, subtree.SubVolumeGroup) {
+			logger.Warningf("skipping the pinning of %s of filesystem %q, which is pinned by its CephFilesystemSubVolumeGroup", describeSubtree(subtree), fs.Name)
+			continue
+		}
+		err := cephclient.PinCephFSSubtree(context, clusterInfo, fs.Name, subtree.SubVolumeGroup, subtree.SubVolume, subtree.Pinning)
+		if err != nil {
+			if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+				logger.Infof("%s of filesystem %q does not exist yet, it will be pinned once created", describeSubtree(subtree), fs.Name)
+				missing = true
+				continue
+			}
+			return false, errors.Wrapf(err, "failed to pin %s of filesystem %q", describeSubtree(subtree), fs.Name)
+		}
+	}
+	return missing, nil
+}
+
+// describeSubtree returns the description of the pinned subtree used in the messages
+func describeSubtree(subtree cephv1.FilesystemPinnedSubtreeSpec) string {
+	if subtree.SubVolume == "" {
+		return fmt.Sprintf("subvolume group %q", subtree.SubVolumeGroup)
+	}
+	return fmt.Sprintf("subvolume %q of group %q", subtree.SubVolume, subtree.SubVolumeGroup)
+}
+
+// getManagedSubVolumeGroups returns the names of the subvolume groups of the filesystem that are
+// managed by a CephFilesystemSubVolumeGroup
+func getManagedSubVolumeGroups(ctx context.Context, c client.Client, fs *cephv1.CephFilesystem) ([]string, error) {
+	svgs := &cephv1.CephFilesystemSubVolumeGroupList{}
+	if err := c.List(ctx, svgs, client.InNamespace(fs.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the subvolume groups of filesystem %q", fs.Name)
+	}
+	groups := []string{}
+	for _, svg := range svgs.Items {
+		if svg.Spec.FilesystemName != fs.Name {
+			continue
+		}
+		name := svg.Name
+		if svg.Spec.Name != "" {
+			name = svg.Spec.Name
+		}
+		groups = append(groups, name)
+	}
+	return groups, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	ctx "context"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcilePinnedSubtrees(t *testing.T) {
+	pinned := [][]string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "fs" && args[2] == "pin" {
+				if args[4] == "missing" {
+					return "", syscall.ENOENT
+				}
+				if args[4] == "broken" {
+					return "", errors.New("failed to pin")
+				}
+				pinned = append(pinned, args[:5])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+	fs := &cephv1.CephFilesystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "myfs", Namespace: "rook-ceph"},
+		Spec: cephv1.FilesystemSpec{
+			MetadataServer: cephv1.MetadataServerSpec{ActiveCount: 2},
+		},
+	}
+
+	// nothing to pin
+	missing, err := reconcilePinnedSubtrees(context, clusterInfo, fs, nil)
+	assert.NoError(t, err)
+	assert.False(t, missing)
+	assert.Empty(t, pinned)
+
+	fs.Spec.PinnedSubtrees = []cephv1.FilesystemPinnedSubtreeSpec{
+		{SubVolumeGroup: "group-a"},
+		{SubVolumeGroup: "missing"},
+		{SubVolumeGroup: "group-a", SubVolume: "vol1"},
+	}
+	// the missing group is reported to requeue the reconcile
+	missing, err = reconcilePinnedSubtrees(context, clusterInfo, fs, nil)
+	assert.NoError(t, err)
+	assert.True(t, missing)
+	assert.Equal(t, [][]string{
+		{"fs", "subvolumegroup", "pin", "myfs", "group-a"},
+		{"fs", "subvolume", "pin", "myfs", "vol1"},
+	}, pinned)
+
+	// the groups managed by a CephFilesystemSubVolumeGroup are not pinned, but their subvolumes are
+	pinned = [][]string{}
+	fs.Spec.PinnedSubtrees = []cephv1.FilesystemPinnedSubtreeSpec{
+		{SubVolumeGroup: "csi"},
+		{SubVolumeGroup: "csi", SubVolume: "vol1"},
+	}
+	missing, err = reconcilePinnedSubtrees(context, clusterInfo, fs, []string{"csi"})
+	assert.NoError(t, err)
+	assert.False(t, missing)
+	assert.Equal(t, [][]string{{"fs", "subvolume", "pin", "myfs", "vol1"}}, pinned)
+
+	fs.Spec.PinnedSubtrees = []cephv1.FilesystemPinnedSubtreeSpec{{SubVolumeGroup: "broken"}}
+	_, err = reconcilePinnedSubtrees(context, clusterInfo, fs, nil)
+	assert.Error(t, err)
+}
+
+func TestDescribeSubtree(t *testing.T) {
+	assert.Equal(t, `subvolume group "csi"`, describeSubtree(cephv1.FilesystemPinnedSubtreeSpec{SubVolumeGroup: "csi"}))
+	assert.Equal(t, `subvolume "vol1" of group "csi"`, describeSubtree(cephv1.FilesystemPinnedSubtreeSpec{SubVolumeGroup: "csi", SubVolume: "vol1"}))
+}
+
+func TestGetManagedSubVolumeGroups(t *testing.T) {
+	fs := &cephv1.CephFilesystem{ObjectMeta: metav1.ObjectMeta{Name: "myfs", Namespace: "rook-ceph"}}
+	svgs := []runtime.Object{
+		&cephv1.CephFilesystemSubVolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "csi", Namespace: "rook-ceph"},
+			Spec:       cephv1.CephFilesystemSubVolumeGroupSpec{FilesystemName: "myfs"},
+		},
+		&cephv1.CephFilesystemSubVolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "named", Namespace: "rook-ceph"},
+			Spec:       cephv1.CephFilesystemSubVolumeGroupSpec{FilesystemName: "myfs", Name: "group-b"},
+		},
+		&cephv1.CephFilesystemSubVolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-fs", Namespace: "rook-ceph"},
+			Spec:       cephv1.CephFilesystemSubVolumeGroupSpec{FilesystemName: "otherfs"},
+		},
+		&cephv1.CephFilesystemSubVolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "other-ns"},
+			Spec:       cephv1.CephFilesystemSubVolumeGroupSpec{FilesystemName: "myfs"},
+		},
+	}
+	s := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(svgs...).Build()
+
+	groups, err := getManagedSubVolumeGroups(ctx.TODO(), cl, fs)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"csi", "group-b"}, groups)
+}
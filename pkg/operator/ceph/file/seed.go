@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// reconcileSeed runs the job creating the seed directories of the filesystem when the seed settings changed,
+// and returns true while the job is running
+func (r *ReconcileCephFilesystem) reconcileSeed(fsName types.NamespacedName, cephFilesystem *cephv1.CephFilesystem, cephCluster *cephv1.CephCluster) (bool, error) {
+	var current *cephv1.SeedStatus
+	if cephFilesystem.Status != nil {
+		current = cephFilesystem.Status.Seed
+	}
+	if cephFilesystem.Spec.Seed == nil || r.cephClusterSpec.External.Enable {
+		if current != nil {
+			return false, r.updateSeedStatus(fsName, nil)
+		}
+		return false, nil
+	}
+
+	seedConfig := map[string]string{
+		opcontroller.CephFSNameEnv: cephFilesystem.Name,
+	}
+	seed := opcontroller.NewResourceSeed(cephFilesystem, "CephFilesystem", cephCluster, k8sutil.NewOwnerInfo(cephFilesystem, r.scheme), r.opConfig.Image, cephFilesystem.Spec.Seed, seedConfig)
+	jobName := k8sutil.TruncateNodeNameForJob("seed-cephfilesystem-%s", cephFilesystem.Name)
+	status, err := seed.Reconcile(r.opManagerContext, r.context.Clientset, jobName, current)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(status, current) {
+		if err := r.updateSeedStatus(fsName, status); err != nil {
+			return false, err
+		}
+	}
+	return status.Phase == opcontroller.SeedPhaseRunning, nil
+}
+
+// updateSeedStatus updates the seed status of the filesystem
+func (r *ReconcileCephFilesystem) updateSeedStatus(fsName types.NamespacedName, status *cephv1.SeedStatus) error {
+	fs := &cephv1.CephFilesystem{}
+	if err := r.client.Get(r.opManagerContext, fsName, fs); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephFilesystem resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve filesystem %q to update the seed status", fsName)
+	}
+	if fs.Status == nil {
+		fs.Status = &cephv1.CephFilesystemStatus{}
+	}
+	fs.Status.Seed = status
+	if err := reporting.UpdateStatus(r.client, fs); err != nil {
+		return errors.Wrapf(err, "failed to update the seed status of filesystem %q", fsName)
+	}
+	return nil
+}
@@ -215,6 +215,18 @@ func (c *clusterConfig) generateMonConfigOptions(rgwConfig *rgwConfig) (map[stri
 		}
 	}
 
+	if gc := c.store.Spec.GarbageCollection; gc != nil {
+		if gc.MaxObjects != nil {
+			configOptions["rgw_gc_max_objs"] = strconv.Itoa(int(*gc.MaxObjects))
+		}
+		if gc.ProcessorPeriod != nil {
+			configOptions["rgw_gc_processor_period"] = strconv.Itoa(int(gc.ProcessorPeriod.Duration.Seconds()))
+		}
+		if gc.ObjectMinWait != nil {
+			configOptions["rgw_gc_obj_min_wait"] = strconv.Itoa(int(gc.ObjectMinWait.Duration.Seconds()))
+		}
+	}
+
 	for flag, val := range c.store.Spec.Gateway.RgwConfig {
 		if currVal, ok := configOptions[flag]; ok {
 			// RGW might break with some user-specified config overrides; log clearly to help triage
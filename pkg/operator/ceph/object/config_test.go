@@ -19,6 +19,7 @@ package object
 import (
 	"context"
 	"testing"
+	"time"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
@@ -127,6 +128,8 @@ func Test_clusterConfig_generateMonConfigOptions(t *testing.T) {
 		return out
 	}
 
+	gcMaxObjects := int32(64)
+
 	tests := []struct {
 		name            string // test name
 		objectStoreSpec *cephv1.ObjectStoreSpec
@@ -152,6 +155,16 @@ func Test_clusterConfig_generateMonConfigOptions(t *testing.T) {
 		{"rgwCommandFlags set", &cephv1.ObjectStoreSpec{
 			Gateway: cephv1.GatewaySpec{RgwCommandFlags: map[string]string{"one": "add", "rgw_enable_usage_log": "false"}},
 		}, defaultConfigs, false}, // verifies rgwCommandFlags don't affect mon config store
+		{"garbage collection tuning", &cephv1.ObjectStoreSpec{
+			GarbageCollection: &cephv1.ObjectGarbageCollectionSpec{
+				MaxObjects:      &gcMaxObjects,
+				ProcessorPeriod: &metav1.Duration{Duration: 30 * time.Minute},
+				ObjectMinWait:   &metav1.Duration{Duration: time.Hour},
+			},
+		}, overlayOnDefaultConfigs("rgw_gc_max_objs", "64", "rgw_gc_processor_period", "1800", "rgw_gc_obj_min_wait", "3600"), false},
+		{"garbage collection run only", &cephv1.ObjectStoreSpec{
+			GarbageCollection: &cephv1.ObjectGarbageCollectionSpec{Run: "now"},
+		}, defaultConfigs, false},
 		{"test all configs", &cephv1.ObjectStoreSpec{
 			Gateway: cephv1.GatewaySpec{
 				DisableMultisiteSyncTraffic: true,
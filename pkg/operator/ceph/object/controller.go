@@ -95,6 +95,7 @@ type ReconcileCephObjectStore struct {
 	recorder         record.EventRecorder
 	opManagerContext context.Context
 	opConfig         opcontroller.OperatorConfig
+	gcRuns           gcRuns
 }
 
 // Add creates a new cephObjectStore Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -390,6 +391,8 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 			clusterInfo: r.clusterInfo,
 		}
 		cfg.deleteStore()
+		r.stopTrackingGCRun(request.NamespacedName)
+		opcontroller.ForgetEndpointChecks(endpointCheckResource(request.NamespacedName))
 
 		// Remove finalizer
 		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStore)
@@ -401,6 +404,10 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		return reconcile.Result{}, *cephObjectStore, nil
 	}
 
+	// The endpoints declared in the spec are checked in the background so that an unreachable
+	// endpoint is reported even when the orchestration fails
+	r.checkEndpoints(cephObjectStore)
+
 	shouldRotateCephxKeys := false
 	if cephObjectStore.Spec.IsExternal() {
 		// Check the ceph version of the running monitors
@@ -481,14 +488,54 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		return result, *cephObjectStore, err
 	}
 
+	// Report the instances of the gateway groups
+	gatewayGroups, err := cfg.gatewayGroupStatus()
+	hadGatewayGroups := cephObjectStore.Status != nil && len(cephObjectStore.Status.GatewayGroups) > 0
+	if err == nil && (len(gatewayGroups) > 0 || hadGatewayGroups) {
+		err = updateGatewayGroupStatus(r.opManagerContext, r.client, request.NamespacedName, gatewayGroups)
+	}
+	if err != nil {
+		// the status of the groups is informational, it does not fail the reconcile
+		logger.Warningf("failed to update the gateway group status of object store %q. %v", request.NamespacedName.String(), err)
+	}
+
+	// Publish the endpoints of the object store
+	if err := cfg.reconcileEndpointPublication(); err != nil {
+		result, err := r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, "failed to publish object store endpoints", err)
+		return result, *cephObjectStore, err
+	}
+
+	// Report the garbage collection backlog and run the requested garbage collection
+	requeue := time.Duration(0)
+	if !cephObjectStore.Spec.IsExternal() && cephObjectStore.Spec.GarbageCollection != nil {
+		objContext, err := NewMultisiteContext(r.context, r.clusterInfo, cephObjectStore)
+		if err == nil {
+			requeue, err = r.reconcileGarbageCollection(cephObjectStore, objContext)
+		}
+		if err != nil {
+			// the garbage collection is informational, it does not fail the reconcile
+			logger.Warningf("failed to reconcile the garbage collection of object store %q. %v", request.NamespacedName.String(), err)
+		}
+	}
+
+	// Create the seed users and buckets of the object store
+	seedRunning, err := r.reconcileSeed(cephObjectStore, &cephCluster)
+	if err != nil {
+		result, err := r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, "failed to seed object store", err)
+		return result, *cephObjectStore, err
+	}
+	if seedRunning && (requeue == 0 || requeue > opcontroller.SeedRequeue) {
+		requeue = opcontroller.SeedRequeue
+	}
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Progressing status, we are done reconciling, the health check go routine will update the status
 	cephxStatus := keyring.UpdatedCephxStatus(shouldRotateCephxKeys, cephCluster.Spec.Security.CephX.Daemon, r.clusterInfo.CephVersion, cephObjectStore.Status.Cephx.Daemon)
 	updateStatus(r.opManagerContext, observedGeneration, r.client, request.NamespacedName, cephv1.ConditionReady, buildStatusInfo(cephObjectStore), &cephxStatus)
 
-	// Return and do not requeue
+	// Requeue to check the garbage collection and the seed job if needed
 	logger.Debug("done reconciling")
-	return reconcile.Result{}, *cephObjectStore, nil
+	return reconcile.Result{RequeueAfter: requeue}, *cephObjectStore, nil
 }
 
 func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *cephv1.CephObjectStore, namespacedName types.NamespacedName, cfg clusterConfig) (reconcile.Result, error) {
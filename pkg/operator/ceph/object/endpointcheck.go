@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// endpointCheckResource is the key of the endpoint checks of an object store
+func endpointCheckResource(nsName types.NamespacedName) string {
+	return "CephObjectStore/" + nsName.String()
+}
+
+// storeEndpointChecks returns the endpoints declared in the spec of the object store: the external
+// gateways, the DNS names and the advertise endpoint, and the KMS servers. The DNS names are only
+// resolved since they may be served by a load balancer outside of the cluster.
+func storeEndpointChecks(store *cephv1.CephObjectStore) []opcontroller.EndpointCheck {
+	var endpoints []opcontroller.EndpointCheck
+	if store.Spec.IsExternal() {
+		port, err := store.Spec.GetPort()
+		if err != nil {
+			port = 0
+		}
+		for _, endpoint := range store.Spec.Gateway.ExternalRgwEndpoints {
+			endpoints = append(endpoints, opcontroller.EndpointCheck{
+				Source: "gateway.externalRgwEndpoints",
+				Host:   endpoint.String(),
+				Port:   port,
+				TLS:    store.Spec.IsTLSEnabled(),
+			})
+		}
+	}
+
+	if hosting := store.Spec.Hosting; hosting != nil {
+		for _, dnsName := range hosting.DNSNames {
+			endpoints = append(endpoints, opcontroller.EndpointCheck{Source: "hosting.dnsNames", Host: dnsName})
+		}
+		if advertise := hosting.AdvertiseEndpoint; advertise != nil {
+			endpoints = append(endpoints, opcontroller.EndpointCheck{
+				Source: "hosting.advertiseEndpoint",
+				Host:   advertise.DnsName,
+				Port:   advertise.Port,
+				TLS:    advertise.UseTls,
+			})
+		}
+	}
+
+	if security := store.Spec.Security; security != nil {
+		for _, kms := range []struct {
+			source string
+			spec   cephv1.KeyManagementServiceSpec
+		}{
+			{"security.kms", security.KeyManagementService},
+			{"security.s3", security.ServerSideEncryptionS3},
+		} {
+			kmsEndpoint, err := opcontroller.KMSEndpointCheck(kms.source, kms.spec)
+			if err != nil {
+				logger.Warningf("failed to get the kms endpoint of object store %q to check. %v", store.Name, err)
+				continue
+			}
+			if kmsEndpoint.Host != "" {
+				endpoints = append(endpoints, kmsEndpoint)
+			}
+		}
+	}
+	return endpoints
+}
+
+// checkEndpoints starts the checks of the endpoints declared in the spec of the object store in the
+// background, the results are reported in the object store status
+func (r *ReconcileCephObjectStore) checkEndpoints(store *cephv1.CephObjectStore) {
+	nsName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	endpoints := storeEndpointChecks(store)
+	if len(endpoints) == 0 && (store.Status == nil || len(store.Status.EndpointChecks) == 0) {
+		return
+	}
+	opcontroller.CheckEndpoints(r.opManagerContext, endpointCheckResource(nsName), endpoints, func(results []cephv1.EndpointCheckStatus) {
+		if err := updateEndpointCheckStatus(r.opManagerContext, r.client, nsName, results); err != nil {
+			logger.Warningf("failed to report the endpoint checks of object store %q. %v", nsName.String(), err)
+		}
+	})
+}
+
+// updateEndpointCheckStatus updates the endpoint checks in the object store status
+func updateEndpointCheckStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, results []cephv1.EndpointCheckStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the endpoint checks", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.EndpointChecks = results
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to update the endpoint checks of object store %q", namespacedName.String())
+		}
+		return nil
+	})
+}
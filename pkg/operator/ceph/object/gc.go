@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	gcRunRunning   = "Running"
+	gcRunCompleted = "Completed"
+	gcRunFailed    = "Failed"
+)
+
+var (
+	// gcRunTimeout is the time a run of the garbage collection of all the pending objects can take
+	gcRunTimeout = time.Hour
+	// gcRunCheckInterval is the interval to report the progress of a running garbage collection
+	gcRunCheckInterval = 30 * time.Second
+	// defaultGCBacklogCheckInterval is the interval to report the garbage collection backlog
+	defaultGCBacklogCheckInterval = 10 * time.Minute
+)
+
+// gcRun is an on demand run of the garbage collection of an object store
+type gcRun struct {
+	run  string
+	done bool
+	err  error
+}
+
+// gcRuns tracks the on demand runs of the garbage collection of the object stores
+type gcRuns struct {
+	lock sync.Mutex
+	runs map[types.NamespacedName]*gcRun
+}
+
+// gcListEntry is an entry of the garbage collection queue listed by "radosgw-admin gc list"
+type gcListEntry struct {
+	Tag  string            `json:"tag"`
+	Objs []json.RawMessage `json:"objs"`
+}
+
+// pendingGCObjects returns the number of deleted objects that have waited the min wait and are
+// not collected yet
+func pendingGCObjects(objContext *Context) (int64, error) {
+	output, err := runAdminCommand(objContext, true, "gc", "list")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list the garbage collection queue")
+	}
+	entries := []gcListEntry{}
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return 0, errors.Wrap(err, "failed to parse the garbage collection queue")
+	}
+	pending := int64(0)
+	for _, entry := range entries {
+		pending += int64(len(entry.Objs))
+	}
+	return pending, nil
+}
+
+// reconcileGarbageCollection reports the garbage collection backlog of the object store, starts
+// the runs requested in the spec and reports their result. It returns the time after which the
+// backlog or the run must be checked again.
+func (r *ReconcileCephObjectStore) reconcileGarbageCollection(store *cephv1.CephObjectStore, objContext *Context) (time.Duration, error) {
+	gc := store.Spec.GarbageCollection
+	if gc == nil {
+		return 0, nil
+	}
+	nsName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+
+	status := &cephv1.ObjectGarbageCollectionStatus{}
+	if store.Status != nil && store.Status.GarbageCollection != nil {
+		status = store.Status.GarbageCollection.DeepCopy()
+	}
+	pending, err := pendingGCObjects(objContext)
+	if err != nil {
+		// the previous backlog is kept, the run requests are still handled
+		logger.Warningf("failed to check the garbage collection backlog of object store %q. %v", nsName.String(), err)
+	} else {
+		status.PendingObjects = pending
+		status.BacklogCheckTime = metav1.Now()
+	}
+
+	r.gcRuns.lock.Lock()
+	if status.LastRun != nil && status.LastRun.Phase == gcRunRunning {
+		run, tracked := r.gcRuns.runs[nsName]
+		switch {
+		case !tracked && status.LastRun.Run == gc.Run:
+			// the run was interrupted by a restart of the operator
+			logger.Infof("resuming the garbage collection of all the pending objects of object store %q", nsName.String())
+			r.startGCRun(nsName, objContext, status.LastRun.Run)
+		case !tracked:
+			status.LastRun.Phase = gcRunFailed
+			status.LastRun.CompletionTime = metav1.Now()
+			status.LastRun.Message = "the run was interrupted by a restart of the operator"
+		case run.done:
+			status.LastRun.Phase = gcRunCompleted
+			status.LastRun.CompletionTime = metav1.Now()
+			if run.err != nil {
+				status.LastRun.Phase = gcRunFailed
+				status.LastRun.Message = run.err.Error()
+			}
+			delete(r.gcRuns.runs, nsName)
+			logger.Infof("garbage collection of object store %q %s", nsName.String(), strings.ToLower(status.LastRun.Phase))
+		}
+	}
+	// a new run requested before the completion of the previous run starts after it
+	newRun := gc.Run != "" && (status.LastRun == nil || status.LastRun.Run != gc.Run)
+	if newRun && status.LastRun != nil && status.LastRun.Phase == gcRunRunning {
+		logger.Infof("garbage collection of object store %q is requested again and will run after the running garbage collection", nsName.String())
+	} else if newRun {
+		logger.Infof("running the garbage collection of all the pending objects of object store %q", nsName.String())
+		status.LastRun = &cephv1.ObjectGarbageCollectionRunStatus{
+			Run:                   gc.Run,
+			Phase:                 gcRunRunning,
+			PendingObjectsAtStart: status.PendingObjects,
+			StartTime:             metav1.Now(),
+		}
+		r.startGCRun(nsName, objContext, gc.Run)
+	}
+	r.gcRuns.lock.Unlock()
+
+	if err := updateGarbageCollectionStatus(r.opManagerContext, r.client, nsName, status); err != nil {
+		return 0, err
+	}
+
+	if status.LastRun != nil && status.LastRun.Phase == gcRunRunning {
+		return gcRunCheckInterval, nil
+	}
+	if gc.BacklogCheckInterval != nil && gc.BacklogCheckInterval.Duration > 0 {
+		return gc.BacklogCheckInterval.Duration, nil
+	}
+	return defaultGCBacklogCheckInterval, nil
+}
+
+// startGCRun runs the garbage collection of all the pending objects in the background. It must be
+// called with the lock of the runs.
+func (r *ReconcileCephObjectStore) startGCRun(nsName types.NamespacedName, objContext *Context, request string) {
+	run := &gcRun{run: request}
+	if r.gcRuns.runs == nil {
+		r.gcRuns.runs = map[types.NamespacedName]*gcRun{}
+	}
+	r.gcRuns.runs[nsName] = run
+	go func() {
+		_, err := runAdminCommandWithTimeout(objContext, gcRunTimeout, false, "gc", "process", "--include-all")
+		if err != nil {
+			err = errors.Wrap(err, "failed to run the garbage collection")
+		}
+		r.gcRuns.lock.Lock()
+		defer r.gcRuns.lock.Unlock()
+		run.done = true
+		run.err = err
+	}()
+}
+
+// stopTrackingGCRun forgets the run of a deleted object store
+func (r *ReconcileCephObjectStore) stopTrackingGCRun(nsName types.NamespacedName) {
+	r.gcRuns.lock.Lock()
+	defer r.gcRuns.lock.Unlock()
+	delete(r.gcRuns.runs, nsName)
+}
+
+// updateGarbageCollectionStatus updates the garbage collection status of an object store
+func updateGarbageCollectionStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, gc *cephv1.ObjectGarbageCollectionStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the garbage collection status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.GarbageCollection = gc
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to update the garbage collection status of object store %q", namespacedName.String())
+		}
+		return nil
+	})
+}
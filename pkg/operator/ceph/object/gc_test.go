@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const gcListOutput = `[
+    {
+        "tag": "a1b2c3.1234.5",
+        "time": "2025-06-02T10:12:45.000000Z",
+        "objs": [
+            {"pool": "my-store.rgw.buckets.data", "oid": "obj1", "key": "", "instance": ""},
+            {"pool": "my-store.rgw.buckets.data", "oid": "obj2", "key": "", "instance": ""}
+        ]
+    },
+    {
+        "tag": "a1b2c3.1234.6",
+        "time": "2025-06-02T10:12:46.000000Z",
+        "objs": [
+            {"pool": "my-store.rgw.buckets.data", "oid": "obj3", "key": "", "instance": ""}
+        ]
+    }
+]`
+
+func TestPendingGCObjects(t *testing.T) {
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "gc" && args[1] == "list" {
+				return gcListOutput, nil
+			}
+			return "", errors.New("unexpected command")
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor}, client.AdminTestClusterInfo("mycluster"), "my-store")
+
+	pending, err := pendingGCObjects(objContext)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pending)
+}
+
+func TestReconcileGarbageCollection(t *testing.T) {
+	nsName := types.NamespacedName{Namespace: "mycluster", Name: "my-store"}
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace},
+		Spec: cephv1.ObjectStoreSpec{
+			GarbageCollection: &cephv1.ObjectGarbageCollectionSpec{
+				BacklogCheckInterval: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(scheme))
+	cl := clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(store).WithStatusSubresource(store).Build()
+
+	processed := make(chan struct{})
+	processResult := make(chan error)
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "gc" && args[1] == "list":
+				return gcListOutput, nil
+			case args[0] == "gc" && args[1] == "process":
+				assert.Equal(t, gcRunTimeout, timeout)
+				assert.Contains(t, args, "--include-all")
+				processed <- struct{}{}
+				return "", <-processResult
+			}
+			return "", errors.New("unexpected command")
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor}, client.AdminTestClusterInfo("mycluster"), "my-store")
+	r := &ReconcileCephObjectStore{client: cl, opManagerContext: context.TODO()}
+
+	reconcileGC := func() (time.Duration, *cephv1.ObjectGarbageCollectionStatus) {
+		current := &cephv1.CephObjectStore{}
+		assert.NoError(t, cl.Get(context.TODO(), nsName, current))
+		requeue, err := r.reconcileGarbageCollection(current, objContext)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), nsName, current))
+		return requeue, current.Status.GarbageCollection
+	}
+	requestRun := func(run string) {
+		current := &cephv1.CephObjectStore{}
+		assert.NoError(t, cl.Get(context.TODO(), nsName, current))
+		current.Spec.GarbageCollection.Run = run
+		assert.NoError(t, cl.Update(context.TODO(), current))
+	}
+	waitForRun := func() {
+		assert.Eventually(t, func() bool {
+			r.gcRuns.lock.Lock()
+			defer r.gcRuns.lock.Unlock()
+			run, ok := r.gcRuns.runs[nsName]
+			return ok && run.done
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	t.Run("backlog is reported", func(t *testing.T) {
+		requeue, status := reconcileGC()
+		assert.Equal(t, time.Hour, requeue)
+		assert.Equal(t, int64(3), status.PendingObjects)
+		assert.False(t, status.BacklogCheckTime.IsZero())
+		assert.Nil(t, status.LastRun)
+	})
+
+	t.Run("requested run completes", func(t *testing.T) {
+		requestRun("run-1")
+		requeue, status := reconcileGC()
+		assert.Equal(t, gcRunCheckInterval, requeue)
+		assert.Equal(t, "run-1", status.LastRun.Run)
+		assert.Equal(t, gcRunRunning, status.LastRun.Phase)
+		assert.Equal(t, int64(3), status.LastRun.PendingObjectsAtStart)
+
+		<-processed
+		processResult <- nil
+		waitForRun()
+		requeue, status = reconcileGC()
+		assert.Equal(t, time.Hour, requeue)
+		assert.Equal(t, gcRunCompleted, status.LastRun.Phase)
+		assert.False(t, status.LastRun.CompletionTime.IsZero())
+
+		// the same request does not run again
+		requeue, status = reconcileGC()
+		assert.Equal(t, time.Hour, requeue)
+		assert.Equal(t, gcRunCompleted, status.LastRun.Phase)
+	})
+
+	t.Run("failed run", func(t *testing.T) {
+		requestRun("run-2")
+		_, status := reconcileGC()
+		assert.Equal(t, gcRunRunning, status.LastRun.Phase)
+
+		<-processed
+		processResult <- errors.New("gc failed")
+		waitForRun()
+		_, status = reconcileGC()
+		assert.Equal(t, gcRunFailed, status.LastRun.Phase)
+		assert.Contains(t, status.LastRun.Message, "gc failed")
+	})
+
+	t.Run("run interrupted by an operator restart is resumed", func(t *testing.T) {
+		r.gcRuns.runs = nil
+		current := &cephv1.CephObjectStore{}
+		assert.NoError(t, cl.Get(context.TODO(), nsName, current))
+		current.Status.GarbageCollection.LastRun = &cephv1.ObjectGarbageCollectionRunStatus{Run: "run-2", Phase: gcRunRunning}
+		assert.NoError(t, cl.Status().Update(context.TODO(), current))
+
+		_, status := reconcileGC()
+		assert.Equal(t, gcRunRunning, status.LastRun.Phase)
+		<-processed
+		processResult <- nil
+		waitForRun()
+		_, status = reconcileGC()
+		assert.Equal(t, gcRunCompleted, status.LastRun.Phase)
+	})
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultMultisiteDriftInterval is the interval to compare the multisite config in RGW with the CRs
+const defaultMultisiteDriftInterval = 10 * time.Minute
+
+// MultisiteConfigDrift is a setting of the multisite config in RGW that differs from the zone or
+// zone group CR
+type MultisiteConfigDrift struct {
+	// Message describes the drift of the setting
+	Message string
+	// revert sets the setting back to the CR, the period is committed after all the drifts are
+	// reverted
+	revert func() error
+}
+
+type zoneGroupPlacementType struct {
+	DefaultPlacement string `json:"default_placement"`
+	PlacementTargets []struct {
+		Name string `json:"name"`
+	} `json:"placement_targets"`
+}
+
+type periodMapType struct {
+	PeriodMap struct {
+		ZoneGroups []struct {
+			Name string `json:"name"`
+		} `json:"zonegroups"`
+	} `json:"period_map"`
+}
+
+// MultisiteDriftInterval returns the interval to compare the multisite config in RGW with the CR,
+// or zero if the drift detection is disabled
+func MultisiteDriftInterval(spec *cephv1.MultisiteDriftDetectionSpec) time.Duration {
+	if spec == nil {
+		return 0
+	}
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		return spec.Interval.Duration
+	}
+	return defaultMultisiteDriftInterval
+}
+
+// ZoneConfigDrift compares the zone in RGW with the CephObjectZone: the zone must be in its zone
+// group, with the custom endpoints of the CR, and with the default placement of its pools. The
+// realm, zone group and zone of the context must be set.
+func ZoneConfigDrift(objContext *Context, zone *cephv1.CephObjectZone) ([]MultisiteConfigDrift, error) {
+	realmArg := fmt.Sprintf("--rgw-realm=%s", objContext.Realm)
+	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", objContext.ZoneGroup)
+	zoneArg := fmt.Sprintf("--rgw-zone=%s", objContext.Zone)
+	zoneEndpoints := strings.Join(zone.Spec.CustomEndpoints, ",")
+	endpointArg := fmt.Sprintf("--endpoints=%s", zoneEndpoints)
+
+	output, err := RunAdminCommandNoMultisite(objContext, true, "zonegroup", "get", realmArg, zoneGroupArg)
+	if err != nil {
+		return nil, errorOrIsNotFound(err, "failed to get rgw zone group %q", objContext.ZoneGroup)
+	}
+	zoneGroup, err := DecodeZoneGroupConfig(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse `radosgw-admin zonegroup get` output")
+	}
+
+	exists, endpoints := findZoneEndpoints(objContext.Zone, zoneGroup.Zones)
+	if !exists {
+		// the other settings are compared once the zone is back in the zone group
+		return []MultisiteConfigDrift{{
+			Message: fmt.Sprintf("zone %q is missing from zone group %q", objContext.Zone, objContext.ZoneGroup),
+			revert: func() error {
+				args := []string{"zonegroup", "add", realmArg, zoneGroupArg, zoneArg}
+				if zoneEndpoints != "" {
+					args = append(args, endpointArg)
+				}
+				if output, err := RunAdminCommandNoMultisite(objContext, false, args...); err != nil {
+					return errors.Wrapf(err, "failed to add zone %q to zone group %q for reason %q", objContext.Zone, objContext.ZoneGroup, output)
+				}
+				return nil
+			},
+		}}, nil
+	}
+
+	zoneConfig, err := getZoneJSON(objContext)
+	if err != nil {
+		return nil, err
+	}
+	zoneID, err := getObjProperty[string](zoneConfig, "id")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the id of the zone")
+	}
+
+	drifts := []MultisiteConfigDrift{}
+	if zoneEndpoints != "" {
+		// the endpoints of the master zone are also the endpoints of the zone group
+		var messages []string
+		if !listsAreEqual(zone.Spec.CustomEndpoints, endpoints) {
+			messages = append(messages, fmt.Sprintf("endpoints of zone %q are %q instead of %q", objContext.Zone, strings.Join(endpoints, ","), zoneEndpoints))
+		}
+		if zoneGroup.MasterZoneID == zoneID && !listsAreEqual(zone.Spec.CustomEndpoints, zoneGroup.Endpoints) {
+			messages = append(messages, fmt.Sprintf("endpoints of zone group %q are %q instead of %q", objContext.ZoneGroup, strings.Join(zoneGroup.Endpoints, ","), zoneEndpoints))
+		}
+		if len(messages) > 0 {
+			drifts = append(drifts, MultisiteConfigDrift{
+				Message: strings.Join(messages, ", "),
+				revert: func() error {
+					return JoinMultisite(objContext, endpointArg, zoneEndpoints, zone.Namespace)
+				},
+			})
+		}
+	}
+
+	placements, err := getObjProperty[[]interface{}](zoneConfig, "placement_pools")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the placement pools of the zone")
+	}
+	if !hasPlacement(placements, defaultPlacementCephConfigName) {
+		drifts = append(drifts, MultisiteConfigDrift{
+			Message: fmt.Sprintf("placement %q is missing from zone %q", defaultPlacementCephConfigName, objContext.Zone),
+			revert: func() error {
+				if !IsNeedToCreateObjectStorePools(zone.Spec.SharedPools) {
+					return ConfigureSharedPoolsForZone(objContext, zone.Spec.SharedPools)
+				}
+				output, err := RunAdminCommandNoMultisite(objContext, false, "zone", "placement", "add", realmArg, zoneGroupArg, zoneArg,
+					fmt.Sprintf("--placement-id=%s", defaultPlacementCephConfigName),
+					fmt.Sprintf("--index-pool=%s", poolName(objContext.Name, "rgw.buckets.index")),
+					fmt.Sprintf("--data-extra-pool=%s", poolName(objContext.Name, "rgw.buckets.non-ec")),
+					fmt.Sprintf("--data-pool=%s", poolName(objContext.Name, dataPoolName)))
+				if err != nil {
+					return errors.Wrapf(err, "failed to add placement %q to zone %q for reason %q", defaultPlacementCephConfigName, objContext.Zone, output)
+				}
+				return nil
+			},
+		})
+	}
+	return drifts, nil
+}
+
+// hasPlacement returns whether the placement pools of a zone contain the placement
+func hasPlacement(placements []interface{}, name string) bool {
+	for _, placement := range placements {
+		if p, ok := placement.(map[string]interface{}); ok && p["key"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ZoneGroupConfigDrift compares the zone group in RGW with the CephObjectZoneGroup: the zone group
+// must be in the current period of its realm, with the default placement target. The realm and
+// zone group of the context must be set.
+func ZoneGroupConfigDrift(objContext *Context) ([]MultisiteConfigDrift, error) {
+	realmArg := fmt.Sprintf("--rgw-realm=%s", objContext.Realm)
+	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", objContext.ZoneGroup)
+	placementArg := fmt.Sprintf("--placement-id=%s", defaultPlacementCephConfigName)
+
+	drifts := []MultisiteConfigDrift{}
+	output, err := RunAdminCommandNoMultisite(objContext, true, "period", "get", realmArg)
+	if err != nil {
+		return nil, errorOrIsNotFound(err, "failed to get the period of realm %q", objContext.Realm)
+	}
+	var period periodMapType
+	if err := json.Unmarshal([]byte(output), &period); err != nil {
+		return nil, errors.Wrap(err, "failed to parse `radosgw-admin period get` output")
+	}
+	inPeriod := false
+	for _, zoneGroup := range period.PeriodMap.ZoneGroups {
+		if zoneGroup.Name == objContext.ZoneGroup {
+			inPeriod = true
+		}
+	}
+	if !inPeriod {
+		drifts = append(drifts, MultisiteConfigDrift{
+			Message: fmt.Sprintf("zone group %q is not in the current period of realm %q", objContext.ZoneGroup, objContext.Realm),
+			// committing the period adds the zone group
+			revert: func() error { return nil },
+		})
+	}
+
+	output, err = RunAdminCommandNoMultisite(objContext, true, "zonegroup", "get", realmArg, zoneGroupArg)
+	if err != nil {
+		return nil, errorOrIsNotFound(err, "failed to get rgw zone group %q", objContext.ZoneGroup)
+	}
+	var zoneGroup zoneGroupPlacementType
+	if err := json.Unmarshal([]byte(output), &zoneGroup); err != nil {
+		return nil, errors.Wrap(err, "failed to parse `radosgw-admin zonegroup get` output")
+	}
+	hasTarget := false
+	for _, target := range zoneGroup.PlacementTargets {
+		if target.Name == defaultPlacementCephConfigName {
+			hasTarget = true
+		}
+	}
+	if !hasTarget || zoneGroup.DefaultPlacement == "" {
+		drifts = append(drifts, MultisiteConfigDrift{
+			Message: fmt.Sprintf("default placement target %q is missing from zone group %q", defaultPlacementCephConfigName, objContext.ZoneGroup),
+			revert: func() error {
+				if !hasTarget {
+					if output, err := RunAdminCommandNoMultisite(objContext, false, "zonegroup", "placement", "add", realmArg, zoneGroupArg, placementArg); err != nil {
+						return errors.Wrapf(err, "failed to add placement target to zone group %q for reason %q", objContext.ZoneGroup, output)
+					}
+				}
+				if output, err := RunAdminCommandNoMultisite(objContext, false, "zonegroup", "placement", "default", realmArg, zoneGroupArg, placementArg); err != nil {
+					return errors.Wrapf(err, "failed to set the default placement target of zone group %q for reason %q", objContext.ZoneGroup, output)
+				}
+				return nil
+			},
+		})
+	}
+	return drifts, nil
+}
+
+// ReconcileMultisiteConfigDrift reverts the drifts of the multisite config to the CR and commits the
+// period, unless the drift detection is in the dry run mode. It returns the MultisiteConfigDrift
+// condition to report on the CR, and the type of the event to record if the drift is reported.
+func ReconcileMultisiteConfigDrift(objContext *Context, spec *cephv1.MultisiteDriftDetectionSpec, drifts []MultisiteConfigDrift) (cephv1.Condition, string, error) {
+	condition := cephv1.Condition{
+		Type:    cephv1.ConditionMultisiteConfigDrift,
+		Status:  v1.ConditionFalse,
+		Reason:  cephv1.MultisiteConfigInSyncReason,
+		Message: "the multisite config matches the CR",
+	}
+	if len(drifts) == 0 {
+		return condition, "", nil
+	}
+
+	messages := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		messages = append(messages, drift.Message)
+	}
+	message := strings.Join(messages, "; ")
+	if spec.Mode == cephv1.MultisiteDriftModeDryRun {
+		logger.Warningf("multisite config of %q differs from the CR: %s", objContext.Name, message)
+		condition.Status = v1.ConditionTrue
+		condition.Reason = cephv1.MultisiteConfigDriftReason
+		condition.Message = "dry run, not reverted: " + message
+		return condition, v1.EventTypeWarning, nil
+	}
+
+	logger.Infof("reverting the multisite config of %q to the CR: %s", objContext.Name, message)
+	for _, drift := range drifts {
+		if err := drift.revert(); err != nil {
+			condition.Status = v1.ConditionTrue
+			condition.Reason = cephv1.MultisiteConfigDriftReason
+			condition.Message = message
+			return condition, v1.EventTypeWarning, errors.Wrapf(err, "failed to revert the multisite config drift %q", drift.Message)
+		}
+	}
+	if err := commitConfigChanges(objContext); err != nil {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = cephv1.MultisiteConfigDriftReason
+		condition.Message = message
+		return condition, v1.EventTypeWarning, errors.Wrap(err, "failed to commit the reverted multisite config")
+	}
+	condition.Reason = cephv1.MultisiteConfigReconciledReason
+	condition.Message = "reverted: " + message
+	return condition, v1.EventTypeNormal, nil
+}
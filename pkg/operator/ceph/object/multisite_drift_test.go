@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMultisiteDriftInterval(t *testing.T) {
+	assert.Equal(t, time.Duration(0), MultisiteDriftInterval(nil))
+	assert.Equal(t, defaultMultisiteDriftInterval, MultisiteDriftInterval(&cephv1.MultisiteDriftDetectionSpec{}))
+	assert.Equal(t, time.Minute, MultisiteDriftInterval(&cephv1.MultisiteDriftDetectionSpec{Interval: &metav1.Duration{Duration: time.Minute}}))
+}
+
+func TestZoneConfigDrift(t *testing.T) {
+	zoneGroupJSON := `{"master_zone":"zone-a-id","endpoints":["http://a:80"],"zones":[{"name":"zone-a","endpoints":["http://a:80"]}]}`
+	zoneJSON := `{"id":"zone-a-id","placement_pools":[{"key":"default-placement","val":{}}]}`
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			commands = append(commands, strings.Join(args[0:3], " "))
+			switch {
+			case args[0] == "zonegroup" && args[1] == "get":
+				return zoneGroupJSON, nil
+			case args[0] == "zone" && args[1] == "get":
+				return zoneJSON, nil
+			}
+			return "", nil
+		},
+	}
+	objContext := &Context{
+		Context:     &clusterd.Context{Executor: executor},
+		Name:        "zone-a",
+		Realm:       "realm-a",
+		ZoneGroup:   "zonegroup-a",
+		Zone:        "zone-a",
+		clusterInfo: client.AdminTestClusterInfo("mycluster"),
+	}
+	zone := &cephv1.CephObjectZone{Spec: cephv1.ObjectZoneSpec{ZoneGroup: "zonegroup-a", CustomEndpoints: []string{"http://a:80"}}}
+
+	commitCalled := false
+	commitConfigChangesOrig := commitConfigChanges
+	defer func() { commitConfigChanges = commitConfigChangesOrig }()
+	commitConfigChanges = func(c *Context) error {
+		commitCalled = true
+		return nil
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		drifts, err := ZoneConfigDrift(objContext, zone)
+		require.NoError(t, err)
+		assert.Empty(t, drifts)
+		condition, eventType, err := ReconcileMultisiteConfigDrift(objContext, &cephv1.MultisiteDriftDetectionSpec{}, drifts)
+		require.NoError(t, err)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, cephv1.MultisiteConfigInSyncReason, condition.Reason)
+		assert.Empty(t, eventType)
+		assert.False(t, commitCalled)
+	})
+
+	t.Run("endpoints and placement changed", func(t *testing.T) {
+		zoneGroupJSON = `{"master_zone":"zone-a-id","endpoints":["http://b:80"],"zones":[{"name":"zone-a","endpoints":["http://b:80"]}]}`
+		zoneJSON = `{"id":"zone-a-id","placement_pools":[]}`
+		drifts, err := ZoneConfigDrift(objContext, zone)
+		require.NoError(t, err)
+		require.Len(t, drifts, 2)
+		assert.Contains(t, drifts[0].Message, `endpoints of zone "zone-a" are "http://b:80" instead of "http://a:80"`)
+		assert.Contains(t, drifts[0].Message, `endpoints of zone group "zonegroup-a"`)
+		assert.Contains(t, drifts[1].Message, `placement "default-placement" is missing from zone "zone-a"`)
+
+		// the drift is only reported in the dry run mode
+		commands = []string{}
+		condition, eventType, err := ReconcileMultisiteConfigDrift(objContext, &cephv1.MultisiteDriftDetectionSpec{Mode: cephv1.MultisiteDriftModeDryRun}, drifts)
+		require.NoError(t, err)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, cephv1.MultisiteConfigDriftReason, condition.Reason)
+		assert.Equal(t, v1.EventTypeWarning, eventType)
+		assert.Empty(t, commands)
+		assert.False(t, commitCalled)
+
+		// the placement is added back and the period committed
+		condition, eventType, err = ReconcileMultisiteConfigDrift(objContext, &cephv1.MultisiteDriftDetectionSpec{}, drifts[1:])
+		require.NoError(t, err)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, cephv1.MultisiteConfigReconciledReason, condition.Reason)
+		assert.Equal(t, v1.EventTypeNormal, eventType)
+		assert.Contains(t, commands, "zone placement add")
+		assert.True(t, commitCalled)
+	})
+
+	t.Run("zone removed from the zone group", func(t *testing.T) {
+		zoneGroupJSON = `{"master_zone":"zone-b-id","zones":[{"name":"zone-b"}]}`
+		drifts, err := ZoneConfigDrift(objContext, zone)
+		require.NoError(t, err)
+		require.Len(t, drifts, 1)
+		assert.Equal(t, `zone "zone-a" is missing from zone group "zonegroup-a"`, drifts[0].Message)
+
+		commands = []string{}
+		_, _, err = ReconcileMultisiteConfigDrift(objContext, &cephv1.MultisiteDriftDetectionSpec{Mode: cephv1.MultisiteDriftModeReconcile}, drifts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup add --rgw-realm=realm-a"}, commands)
+	})
+}
+
+func TestZoneGroupConfigDrift(t *testing.T) {
+	periodJSON := `{"period_map":{"zonegroups":[{"name":"zonegroup-a"}]}}`
+	zoneGroupJSON := `{"placement_targets":[{"name":"default-placement"}],"default_placement":"default-placement"}`
+	commands := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			commands = append(commands, strings.Join(args[0:3], " "))
+			switch {
+			case args[0] == "period" && args[1] == "get":
+				return periodJSON, nil
+			case args[0] == "zonegroup" && args[1] == "get":
+				return zoneGroupJSON, nil
+			}
+			return "", nil
+		},
+	}
+	objContext := &Context{
+		Context:     &clusterd.Context{Executor: executor},
+		Name:        "zonegroup-a",
+		Realm:       "realm-a",
+		ZoneGroup:   "zonegroup-a",
+		clusterInfo: client.AdminTestClusterInfo("mycluster"),
+	}
+	commitConfigChangesOrig := commitConfigChanges
+	defer func() { commitConfigChanges = commitConfigChangesOrig }()
+	commitConfigChanges = func(c *Context) error { return nil }
+
+	drifts, err := ZoneGroupConfigDrift(objContext)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	periodJSON = `{"period_map":{"zonegroups":[]}}`
+	zoneGroupJSON = `{"placement_targets":[],"default_placement":""}`
+	drifts, err = ZoneGroupConfigDrift(objContext)
+	require.NoError(t, err)
+	require.Len(t, drifts, 2)
+	assert.Equal(t, `zone group "zonegroup-a" is not in the current period of realm "realm-a"`, drifts[0].Message)
+	assert.Equal(t, `default placement target "default-placement" is missing from zone group "zonegroup-a"`, drifts[1].Message)
+
+	commands = []string{}
+	_, _, err = ReconcileMultisiteConfigDrift(objContext, &cephv1.MultisiteDriftDetectionSpec{}, drifts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zonegroup placement add", "zonegroup placement default"}, commands)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	endpointsConfigMapCAKey = "ca.crt"
+)
+
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+func endpointsConfigMapName(store *cephv1.CephObjectStore) string {
+	return fmt.Sprintf("%s-endpoints", instanceName(store.Name))
+}
+
+// reconcileEndpointPublication publishes the endpoints of the object store in a ConfigMap and a
+// Gateway API HTTPRoute if requested, and removes them once they are not requested anymore
+func (c *clusterConfig) reconcileEndpointPublication() error {
+	publication := c.store.Spec.EndpointPublication
+	if publication == nil {
+		publication = &cephv1.ObjectEndpointPublicationSpec{}
+	}
+
+	if publication.ConfigMap {
+		if err := c.publishEndpointsConfigMap(); err != nil {
+			return err
+		}
+	} else {
+		err := k8sutil.DeleteConfigMap(c.clusterInfo.Context, c.context.Clientset, endpointsConfigMapName(c.store), c.store.Namespace, &k8sutil.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the endpoints configmap of object store %q", c.store.Name)
+		}
+	}
+
+	if publication.HTTPRoute != nil {
+		return c.publishHTTPRoute(publication.HTTPRoute)
+	}
+	return c.deleteHTTPRoute()
+}
+
+func (c *clusterConfig) publishEndpointsConfigMap() error {
+	cm, err := c.generateEndpointsConfigMap()
+	if err != nil {
+		return err
+	}
+	if err := c.ownerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on the endpoints configmap of object store %q", c.store.Name)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(c.clusterInfo.Context, c.context.Clientset, cm); err != nil {
+		return errors.Wrapf(err, "failed to publish the endpoints of object store %q", c.store.Name)
+	}
+	return nil
+}
+
+// generateEndpointsConfigMap generates the ConfigMap with the internal and external endpoints of
+// the object store, and the TLS CA to verify them when the store serves HTTPS
+func (c *clusterConfig) generateEndpointsConfigMap() (*v1.ConfigMap, error) {
+	data := map[string]string{}
+	for k, v := range buildStatusInfo(c.store) {
+		data[k] = v
+	}
+	if c.store.Spec.Gateway.Port > 0 {
+		data["insecureEndpoints"] = strings.Join(getAllDNSEndpoints(c.store, c.store.Spec.Gateway.Port, false), ",")
+	}
+	if c.store.Spec.Gateway.SecurePort > 0 {
+		data["secureEndpoints"] = strings.Join(getAllDNSEndpoints(c.store, c.store.Spec.Gateway.SecurePort, true), ",")
+	}
+	if hostnames := c.externalHostnames(); len(hostnames) > 0 {
+		data["externalHostnames"] = strings.Join(hostnames, ",")
+	}
+
+	if c.store.Spec.IsTLSEnabled() {
+		caCert, _, err := GetTlsCaCert(NewContext(c.context, c.clusterInfo, c.store.Name), &c.store.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the TLS CA of object store %q", c.store.Name)
+		}
+		if len(caCert) > 0 {
+			data[endpointsConfigMapCAKey] = string(caCert)
+		}
+	}
+
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      endpointsConfigMapName(c.store),
+			Namespace: c.store.Namespace,
+			Labels:    getLabels(c.store.Name, c.store.Namespace, true),
+		},
+		Data: data,
+	}, nil
+}
+
+// externalHostnames returns the host names the object store is reachable with from outside of the
+// cluster, without any scheme or port since they depend on the ingress exposing them
+func (c *clusterConfig) externalHostnames() []string {
+	hostnames := []string{}
+	if c.store.Spec.Hosting != nil {
+		hostnames = append(hostnames, c.store.Spec.Hosting.DNSNames...)
+	}
+	if publication := c.store.Spec.EndpointPublication; publication != nil && publication.HTTPRoute != nil {
+		for _, h := range publication.HTTPRoute.Hostnames {
+			if !slices.Contains(hostnames, h) {
+				hostnames = append(hostnames, h)
+			}
+		}
+	}
+	return hostnames
+}
+
+// generateHTTPRoute generates the Gateway API HTTPRoute routing to the object store service. The
+// route is unstructured to avoid depending on the Gateway API types.
+func (c *clusterConfig) generateHTTPRoute(spec *cephv1.ObjectHTTPRouteSpec) *unstructured.Unstructured {
+	parentRefs := []interface{}{}
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]interface{}{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	// prefer the HTTP port, the gateway terminates TLS for the clients
+	port := c.store.Spec.Gateway.Port
+	if port == 0 {
+		port = c.store.Spec.Gateway.SecurePort
+	}
+
+	routeSpec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": instanceName(c.store.Name),
+						"port": int64(port),
+					},
+				},
+			},
+		},
+	}
+	if len(spec.Hostnames) > 0 {
+		hostnames := []interface{}{}
+		for _, h := range spec.Hostnames {
+			hostnames = append(hostnames, h)
+		}
+		routeSpec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(instanceName(c.store.Name))
+	route.SetNamespace(c.store.Namespace)
+	route.SetLabels(getLabels(c.store.Name, c.store.Namespace, true))
+	route.Object["spec"] = routeSpec
+	return route
+}
+
+func (c *clusterConfig) publishHTTPRoute(spec *cephv1.ObjectHTTPRouteSpec) error {
+	route := c.generateHTTPRoute(spec)
+	if err := c.ownerInfo.SetControllerReference(route); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference on the HTTPRoute of object store %q", c.store.Name)
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(httpRouteGVK)
+	err := c.client.Get(c.clusterInfo.Context, types.NamespacedName{Name: route.GetName(), Namespace: route.GetNamespace()}, existing)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "failed to create the HTTPRoute of object store %q, the Gateway API CRDs are not installed", c.store.Name)
+		}
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the HTTPRoute of object store %q", c.store.Name)
+		}
+		if err := c.client.Create(c.clusterInfo.Context, route); err != nil {
+			return errors.Wrapf(err, "failed to create the HTTPRoute of object store %q", c.store.Name)
+		}
+		logger.Infof("created HTTPRoute %q for object store %q", route.GetName(), c.store.Name)
+		return nil
+	}
+
+	route.SetResourceVersion(existing.GetResourceVersion())
+	if err := c.client.Update(c.clusterInfo.Context, route); err != nil {
+		return errors.Wrapf(err, "failed to update the HTTPRoute of object store %q", c.store.Name)
+	}
+	return nil
+}
+
+func (c *clusterConfig) deleteHTTPRoute() error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(instanceName(c.store.Name))
+	route.SetNamespace(c.store.Namespace)
+	err := c.client.Delete(c.clusterInfo.Context, route)
+	if err != nil && !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return errors.Wrapf(err, "failed to delete the HTTPRoute of object store %q", c.store.Name)
+	}
+	return nil
+}
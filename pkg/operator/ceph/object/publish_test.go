@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPublicationTestConfig(t *testing.T, store *cephv1.CephObjectStore) *clusterConfig {
+	s := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(s))
+	clusterInfo := clienttest.CreateTestClusterInfo(1)
+	clusterInfo.Context = context.TODO()
+	return &clusterConfig{
+		context:     &clusterd.Context{Clientset: test.New(t, 1)},
+		clusterInfo: clusterInfo,
+		store:       store,
+		client:      fake.NewClientBuilder().WithScheme(s).Build(),
+		ownerInfo:   k8sutil.NewOwnerInfoWithOwnerRef(&metav1.OwnerReference{Name: store.Name, UID: "uid"}, store.Namespace),
+	}
+}
+
+func TestGenerateEndpointsConfigMap(t *testing.T) {
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph"},
+		Spec: cephv1.ObjectStoreSpec{
+			Gateway: cephv1.GatewaySpec{Port: 80},
+			Hosting: &cephv1.ObjectStoreHostingSpec{DNSNames: []string{"s3.example.com"}},
+			EndpointPublication: &cephv1.ObjectEndpointPublicationSpec{
+				ConfigMap: true,
+				HTTPRoute: &cephv1.ObjectHTTPRouteSpec{
+					ParentRefs: []cephv1.ObjectHTTPRouteParentRef{{Name: "gw"}},
+					Hostnames:  []string{"s3.example.com", "objects.example.com"},
+				},
+			},
+		},
+	}
+	c := newPublicationTestConfig(t, store)
+
+	cm, err := c.generateEndpointsConfigMap()
+	assert.NoError(t, err)
+	assert.Equal(t, "rook-ceph-rgw-my-store-endpoints", cm.Name)
+	assert.Equal(t, "rook-ceph", cm.Namespace)
+	assert.Equal(t, map[string]string{
+		"endpoint":          "http://rook-ceph-rgw-my-store.rook-ceph.svc:80",
+		"insecureEndpoints": "http://rook-ceph-rgw-my-store.rook-ceph.svc:80",
+		"externalHostnames": "s3.example.com,objects.example.com",
+	}, cm.Data)
+}
+
+func TestGenerateHTTPRoute(t *testing.T) {
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph"},
+		Spec: cephv1.ObjectStoreSpec{
+			Gateway: cephv1.GatewaySpec{SecurePort: 443},
+		},
+	}
+	c := newPublicationTestConfig(t, store)
+
+	route := c.generateHTTPRoute(&cephv1.ObjectHTTPRouteSpec{
+		ParentRefs: []cephv1.ObjectHTTPRouteParentRef{{Name: "gw", Namespace: "gateways", SectionName: "https"}},
+		Hostnames:  []string{"s3.example.com"},
+	})
+	assert.Equal(t, "HTTPRoute", route.GetKind())
+	assert.Equal(t, "gateway.networking.k8s.io/v1", route.GetAPIVersion())
+	assert.Equal(t, "rook-ceph-rgw-my-store", route.GetName())
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gw", "namespace": "gateways", "sectionName": "https"}}, parentRefs)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"s3.example.com"}, hostnames)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	backendRefs := rules[0].(map[string]interface{})["backendRefs"].([]interface{})
+	// only the secure port is served
+	assert.Equal(t, map[string]interface{}{"name": "rook-ceph-rgw-my-store", "port": int64(443)}, backendRefs[0])
+}
+
+func TestReconcileEndpointPublication(t *testing.T) {
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph"},
+		Spec: cephv1.ObjectStoreSpec{
+			Gateway:             cephv1.GatewaySpec{Port: 80},
+			EndpointPublication: &cephv1.ObjectEndpointPublicationSpec{ConfigMap: true},
+		},
+	}
+	c := newPublicationTestConfig(t, store)
+	ctx := context.TODO()
+
+	assert.NoError(t, c.reconcileEndpointPublication())
+	cm, err := c.context.Clientset.CoreV1().ConfigMaps("rook-ceph").Get(ctx, "rook-ceph-rgw-my-store-endpoints", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://rook-ceph-rgw-my-store.rook-ceph.svc:80", cm.Data["endpoint"])
+	assert.Len(t, cm.OwnerReferences, 1)
+
+	// the configmap is removed once the publication is disabled
+	store.Spec.EndpointPublication = nil
+	assert.NoError(t, c.reconcileEndpointPublication())
+	_, err = c.context.Clientset.CoreV1().ConfigMaps("rook-ceph").Get(ctx, "rook-ceph-rgw-my-store-endpoints", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+}
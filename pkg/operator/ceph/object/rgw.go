@@ -67,6 +67,8 @@ type rgwConfig struct {
 	Auth           cephv1.AuthSpec
 	KeystoneSecret *v1.Secret
 	Protocols      cephv1.ProtocolSpec
+	// Group is the gateway group of the deployment, nil when the store has no gateway groups
+	Group *cephv1.GatewayGroupSpec
 }
 
 var updateDeploymentAndWait = mon.UpdateCephDeploymentAndWait
@@ -117,17 +119,17 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 	}
 
 	// start a new deployment and scale up
-	// We force a single deployment and later set the deployment replica to the "instances" value
-	desiredRgwInstances := 1
-	for i := 0; i < desiredRgwInstances; i++ {
+	// We force a single deployment per gateway group and later set the deployment replica to the
+	// "instances" value
+	desiredDeployments := map[string]bool{}
+	for i, daemonID := range c.rgwDaemonIDs() {
 		var err error
 
-		daemonLetterID := k8sutil.IndexToName(i)
-
-		// Each rgw is id'ed by <store_name>-<letterID>
-		daemonName := fmt.Sprintf("%s-%s", c.store.Name, daemonLetterID)
+		// Each rgw is id'ed by <store_name>-<daemonID>
+		daemonName := fmt.Sprintf("%s-%s", c.store.Name, daemonID)
 		// resource name is rook-ceph-rgw-<store_name>-<daemon_name>
-		resourceName := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, daemonLetterID)
+		resourceName := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, daemonID)
+		desiredDeployments[resourceName] = true
 
 		rgwConfig := &rgwConfig{
 			ResourceName:   resourceName,
@@ -139,6 +141,9 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 			Protocols:      c.store.Spec.Protocols,
 			KeystoneSecret: keystoneSecret,
 		}
+		if len(c.store.Spec.Gateway.Groups) > 0 {
+			rgwConfig.Group = &c.store.Spec.Gateway.Groups[i]
+		}
 
 		// We set the owner reference of the Secret to the Object controller instead of the replicaset
 		// because we watch for that resource and reconcile if anything happens to it
@@ -191,7 +196,7 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 				return errors.Wrap(createErr, "failed to create rgw deployment")
 			}
 			logger.Infof("object store %q deployment %q already exists. updating if needed", c.store.Name, deployment.Name)
-			if err := updateDeploymentAndWait(c.context, c.clusterInfo, deployment, config.RgwType, daemonLetterID, c.clusterSpec.SkipUpgradeChecks, c.clusterSpec.ContinueUpgradeAfterChecksEvenIfNotHealthy); err != nil {
+			if err := updateDeploymentAndWait(c.context, c.clusterInfo, deployment, config.RgwType, daemonID, c.clusterSpec.SkipUpgradeChecks, c.clusterSpec.ContinueUpgradeAfterChecksEvenIfNotHealthy); err != nil {
 				return errors.Wrapf(err, "failed to update object store %q deployment %q", c.store.Name, deployment.Name)
 			}
 		}
@@ -202,46 +207,31 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 		}
 	}
 
-	// scale down scenario
+	// scale down scenario, remove the deployments of the removed gateway groups
 	deps, err := k8sutil.GetDeployments(c.clusterInfo.Context, c.context.Clientset, c.store.Namespace, c.storeLabelSelector())
 	if err != nil {
 		logger.Warningf("could not get deployments for object store %q (matching label selector %q). %v", c.store.Name, c.storeLabelSelector(), err)
+		return nil
 	}
 
-	currentRgwInstances := int(len(deps.Items))
-	if currentRgwInstances > desiredRgwInstances {
-		logger.Infof("found more rgw deployments %d than desired %d in object store %q, scaling down", currentRgwInstances, c.store.Spec.Gateway.Instances, c.store.Name)
-		diffCount := currentRgwInstances - desiredRgwInstances
-		for i := 0; i < diffCount; {
-			depIDToRemove := currentRgwInstances - 1
-			depNameToRemove := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, k8sutil.IndexToName(depIDToRemove))
-			if err := k8sutil.DeleteDeployment(c.clusterInfo.Context, c.context.Clientset, c.store.Namespace, depNameToRemove); err != nil {
-				logger.Warningf("error during deletion of deployment %q resource. %v", depNameToRemove, err)
-			}
-			currentRgwInstances = currentRgwInstances - 1
-			i++
-
-			// Delete the Secret key
-			secretToRemove := c.generateSecretName(k8sutil.IndexToName(depIDToRemove))
-			err = c.context.Clientset.CoreV1().Secrets(c.store.Namespace).Delete(c.clusterInfo.Context, secretToRemove, metav1.DeleteOptions{})
-			if err != nil && !kerrors.IsNotFound(err) {
-				logger.Warningf("failed to delete rgw secret %q. %v", secretToRemove, err)
-			}
-
-			err := c.deleteRgwCephObjects(depNameToRemove)
-			if err != nil {
-				logger.Warningf("%v", err)
-			}
+	for _, dep := range deps.Items {
+		if desiredDeployments[dep.Name] {
+			continue
 		}
-		// verify scale down was successful
-		deps, err = k8sutil.GetDeployments(c.clusterInfo.Context, c.context.Clientset, c.store.Namespace, c.storeLabelSelector())
-		if err != nil {
-			logger.Warningf("could not get deployments for object store %q (matching label selector %q). %v", c.store.Name, c.storeLabelSelector(), err)
+		logger.Infof("found rgw deployment %q that is not desired in object store %q, scaling down", dep.Name, c.store.Name)
+		if err := k8sutil.DeleteDeployment(c.clusterInfo.Context, c.context.Clientset, c.store.Namespace, dep.Name); err != nil {
+			logger.Warningf("error during deletion of deployment %q resource. %v", dep.Name, err)
+		}
+
+		// Delete the Secret key
+		secretToRemove := c.generateSecretName(strings.TrimPrefix(dep.Name, fmt.Sprintf("%s-%s-", AppName, c.store.Name)))
+		err = c.context.Clientset.CoreV1().Secrets(c.store.Namespace).Delete(c.clusterInfo.Context, secretToRemove, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to delete rgw secret %q. %v", secretToRemove, err)
 		}
 
-		currentRgwInstances = len(deps.Items)
-		if currentRgwInstances == desiredRgwInstances {
-			logger.Infof("successfully scaled down rgw deployments to %d in object store %q", desiredRgwInstances, c.store.Name)
+		if err := c.deleteRgwCephObjects(dep.Name); err != nil {
+			logger.Warningf("%v", err)
 		}
 	}
 
@@ -255,9 +245,8 @@ func (c *clusterConfig) deleteStore() {
 
 	if !c.store.Spec.IsExternal() {
 		// Delete rgw CephX keys and configuration in centralized mon database
-		for i := 0; i < int(c.store.Spec.Gateway.Instances); i++ {
-			daemonLetterID := k8sutil.IndexToName(i)
-			depNameToRemove := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, daemonLetterID)
+		for _, daemonID := range c.rgwDaemonIDs() {
+			depNameToRemove := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, daemonID)
 
 			err := c.deleteRgwCephObjects(depNameToRemove)
 			if err != nil {
@@ -302,6 +291,40 @@ func (c *clusterConfig) deleteRgwCephObjects(depNameToRemove string) error {
 	return nil
 }
 
+// rgwDaemonIDs returns the IDs of the rgw deployments of the store, the names of the gateway
+// groups, or the single ID "a" without gateway groups
+func (c *clusterConfig) rgwDaemonIDs() []string {
+	if len(c.store.Spec.Gateway.Groups) == 0 {
+		return []string{k8sutil.IndexToName(0)}
+	}
+	ids := []string{}
+	for _, group := range c.store.Spec.Gateway.Groups {
+		ids = append(ids, group.Name)
+	}
+	return ids
+}
+
+// gatewayGroupStatus returns the desired and ready instances of the gateway groups of the store
+func (c *clusterConfig) gatewayGroupStatus() ([]cephv1.GatewayGroupStatus, error) {
+	if len(c.store.Spec.Gateway.Groups) == 0 {
+		return nil, nil
+	}
+	statuses := []cephv1.GatewayGroupStatus{}
+	for _, group := range c.store.Spec.Gateway.Groups {
+		status := cephv1.GatewayGroupStatus{Name: group.Name, Instances: max(group.Instances, 1)}
+		name := fmt.Sprintf("%s-%s-%s", AppName, c.store.Name, group.Name)
+		d, err := c.context.Clientset.AppsV1().Deployments(c.store.Namespace).Get(c.clusterInfo.Context, name, metav1.GetOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get the deployment of gateway group %q", group.Name)
+		}
+		if err == nil {
+			status.ReadyInstances = d.Status.ReadyReplicas
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
 func instanceName(name string) string {
 	return fmt.Sprintf("%s-%s", AppName, name)
 }
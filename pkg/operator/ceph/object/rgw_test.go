@@ -33,6 +33,7 @@ import (
 	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fclient "k8s.io/client-go/kubernetes/fake"
@@ -88,6 +89,42 @@ func TestStartRGW(t *testing.T) {
 
 		validateStart(ctx, t, c, clientset)
 	})
+
+	t.Run("Deployments of the gateway groups", func(t *testing.T) {
+		store.Spec.Gateway.Groups = []cephv1.GatewayGroupSpec{
+			{Name: "zone-a", Instances: 2, Placement: cephv1.Placement{Tolerations: []v1.Toleration{{Key: "zone-a"}}}},
+			{Name: "zone-b"},
+		}
+		err := c.startRGWPods(store.Name, store.Name, store.Name, nil)
+		assert.NoError(t, err)
+
+		d, err := clientset.AppsV1().Deployments(store.Namespace).Get(ctx, instanceName(store.Name)+"-zone-a", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), *d.Spec.Replicas)
+		assert.Equal(t, "zone-a", d.Spec.Selector.MatchLabels[gatewayGroupLabelKey])
+		assert.Equal(t, "zone-a", d.Spec.Template.Labels[gatewayGroupLabelKey])
+		assert.Equal(t, []v1.Toleration{{Key: "zone-a"}}, d.Spec.Template.Spec.Tolerations)
+
+		d, err = clientset.AppsV1().Deployments(store.Namespace).Get(ctx, instanceName(store.Name)+"-zone-b", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), *d.Spec.Replicas)
+		assert.Empty(t, d.Spec.Template.Spec.Tolerations)
+
+		// the deployment of the gateways without groups is removed
+		_, err = clientset.AppsV1().Deployments(store.Namespace).Get(ctx, instanceName(store.Name)+"-a", metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+
+		groups, err := c.gatewayGroupStatus()
+		assert.NoError(t, err)
+		assert.Equal(t, []cephv1.GatewayGroupStatus{{Name: "zone-a", Instances: 2}, {Name: "zone-b", Instances: 1}}, groups)
+
+		// the deployment of a removed group is removed
+		store.Spec.Gateway.Groups = store.Spec.Gateway.Groups[:1]
+		err = c.startRGWPods(store.Name, store.Name, store.Name, nil)
+		assert.NoError(t, err)
+		_, err = clientset.AppsV1().Deployments(store.Namespace).Get(ctx, instanceName(store.Name)+"-zone-b", metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+	})
 }
 
 func validateStart(ctx context.Context, t *testing.T, c *clusterConfig, clientset *fclient.Clientset) {
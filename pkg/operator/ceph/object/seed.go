@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileSeed runs the job creating the seed users and buckets of the object store when the seed
+// settings changed, and returns true while the job is running
+func (r *ReconcileCephObjectStore) reconcileSeed(store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster) (bool, error) {
+	nsName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	var current *cephv1.SeedStatus
+	if store.Status != nil {
+		current = store.Status.Seed
+	}
+	if store.Spec.Seed == nil || store.Spec.IsExternal() {
+		if current != nil {
+			return false, updateSeedStatus(r.opManagerContext, r.client, nsName, nil)
+		}
+		return false, nil
+	}
+
+	seedConfig, err := r.seedConfig(store)
+	if err != nil {
+		return false, err
+	}
+	seed := opcontroller.NewResourceSeed(store, "CephObjectStore", cephCluster, k8sutil.NewOwnerInfo(store, r.scheme), r.opConfig.Image, store.Spec.Seed, seedConfig)
+	jobName := k8sutil.TruncateNodeNameForJob("seed-cephobjectstore-%s", store.Name)
+	status, err := seed.Reconcile(r.opManagerContext, r.context.Clientset, jobName, current)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(status, current) {
+		if err := updateSeedStatus(r.opManagerContext, r.client, nsName, status); err != nil {
+			return false, err
+		}
+	}
+	return status.Phase == opcontroller.SeedPhaseRunning, nil
+}
+
+// seedConfig returns the settings of the seed job to reach the object store
+func (r *ReconcileCephObjectStore) seedConfig(store *cephv1.CephObjectStore) (map[string]string, error) {
+	objContext, err := NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the context of object store %q", store.Name)
+	}
+	config := map[string]string{
+		opcontroller.CephObjectStoreNameEnv: store.Name,
+		opcontroller.CephObjectRealmEnv:     objContext.Realm,
+		opcontroller.CephObjectZoneGroupEnv: objContext.ZoneGroup,
+		opcontroller.CephObjectZoneEnv:      objContext.Zone,
+		opcontroller.CephObjectEndpointEnv:  objContext.Endpoint,
+	}
+	if store.Spec.IsTLSEnabled() {
+		tlsCert, insecure, err := GetTlsCaCert(objContext, &store.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the TLS certificate of object store %q", store.Name)
+		}
+		config[opcontroller.CephObjectTLSCACertEnv] = string(tlsCert)
+		config[opcontroller.CephObjectTLSInsecureEnv] = strconv.FormatBool(insecure)
+	}
+	return config, nil
+}
+
+// updateSeedStatus updates the seed status of an object store
+func updateSeedStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, status *cephv1.SeedStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the seed status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.Seed = status
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to update the seed status of object store %q", namespacedName.String())
+		}
+		return nil
+	})
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -48,6 +49,9 @@ const (
 	sseS3              = "sses3"
 	vaultPrefix        = "/v1/"
 
+	// gatewayGroupLabelKey is the label of the gateways of a gateway group
+	gatewayGroupLabelKey = "rook_object_store_gateway_group"
+
 	// Read Affinity settings for RGW clients to reduce cross-zone traffic
 	radosReadReplicaPolicy = "rados_replica_read_policy"
 	// read from a random OSD from the PG's active set
@@ -131,6 +135,30 @@ type rgwProbeConfig struct {
 	Path     string
 }
 
+func init() {
+	rgwDeployments := []string{"deployment/rook-ceph-rgw-*"}
+	controller.RegisterFieldImpacts(&cephv1.CephObjectStore{},
+		controller.FieldImpact{Field: "spec.gateway.instances", Resources: rgwDeployments, Description: "The replicas of the rgw deployment are updated, the existing rgws are not restarted."},
+		controller.FieldImpact{Field: "spec.gateway.port", Resources: append([]string{"service/rook-ceph-rgw-*"}, rgwDeployments...), Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.securePort", Resources: append([]string{"service/rook-ceph-rgw-*"}, rgwDeployments...), Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.sslCertificateRef", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.caBundleRef", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.resources", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.placement", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.priorityClassName", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.annotations", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.labels", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.hostNetwork", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.rgwConfig", Description: "The options are set in the ceph config database of the rgws without a restart."},
+		controller.FieldImpact{Field: "spec.gateway.rgwCommandFlags", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.opsLogSidecar", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.gateway.service", Resources: []string{"service/rook-ceph-rgw-*"}},
+		controller.FieldImpact{Field: "spec.protocols", Resources: rgwDeployments, Restart: true},
+		controller.FieldImpact{Field: "spec.metadataPool", Description: "The pools are updated in ceph without a restart."},
+		controller.FieldImpact{Field: "spec.dataPool", Description: "The pool is updated in ceph without a restart."},
+	)
+}
+
 func (c *clusterConfig) createDeployment(rgwConfig *rgwConfig) (*apps.Deployment, error) {
 	pod, err := c.makeRGWPodSpec(rgwConfig)
 	if err != nil {
@@ -141,6 +169,9 @@ func (c *clusterConfig) createDeployment(rgwConfig *rgwConfig) (*apps.Deployment
 	}
 	// Use the same keyring and have dedicated rgw instances reflected in the service map
 	replicas := c.store.Spec.Gateway.Instances
+	if rgwConfig.Group != nil {
+		replicas = max(rgwConfig.Group.Instances, 1)
+	}
 
 	strategy.Type = apps.RollingUpdateDeploymentStrategyType
 	strategy.RollingUpdate = &apps.RollingUpdateDeployment{
@@ -151,12 +182,12 @@ func (c *clusterConfig) createDeployment(rgwConfig *rgwConfig) (*apps.Deployment
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      rgwConfig.ResourceName,
 			Namespace: c.store.Namespace,
-			Labels:    getLabels(c.store.Name, c.store.Namespace, true),
+			Labels:    getGroupLabels(c.store.Name, c.store.Namespace, rgwConfig.Group, true),
 		},
 		Spec: apps.DeploymentSpec{
 			RevisionHistoryLimit: controller.RevisionHistoryLimit(),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: getLabels(c.store.Name, c.store.Namespace, false),
+				MatchLabels: getGroupLabels(c.store.Name, c.store.Namespace, rgwConfig.Group, false),
 			},
 			Template: pod,
 			Replicas: &replicas,
@@ -282,7 +313,11 @@ func (c *clusterConfig) makeRGWPodSpec(rgwConfig *rgwConfig) (v1.PodTemplateSpec
 		podSpec.InitContainers = append(podSpec.InitContainers,
 			c.vaultTokenInitContainer(rgwConfig, kmsEnabled, s3Enabled))
 	}
-	c.store.Spec.Gateway.Placement.ApplyToPodSpec(&podSpec)
+	placement := c.store.Spec.Gateway.Placement
+	if rgwConfig.Group != nil {
+		placement = placement.Merge(rgwConfig.Group.Placement)
+	}
+	placement.ApplyToPodSpec(&podSpec)
 
 	// If host networking is not enabled, preferred pod anti-affinity is added to the rgw daemons
 	labels := getLabels(c.store.Name, c.store.Namespace, false)
@@ -291,7 +326,7 @@ func (c *clusterConfig) makeRGWPodSpec(rgwConfig *rgwConfig) (v1.PodTemplateSpec
 	podTemplateSpec := v1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   rgwConfig.ResourceName,
-			Labels: getLabels(c.store.Name, c.store.Namespace, true),
+			Labels: getGroupLabels(c.store.Name, c.store.Namespace, rgwConfig.Group, true),
 		},
 		Spec: podSpec,
 	}
@@ -789,6 +824,49 @@ func (c *clusterConfig) reconcileService(store *cephv1.CephObjectStore) error {
 
 	logger.Infof("ceph object store gateway service running at %s", svc.Spec.ClusterIP)
 
+	return c.reconcileGroupServices(store)
+}
+
+// generateGroupService returns the service of the gateways of a group, so that the clients can
+// reach the gateways of their zone
+func (c *clusterConfig) generateGroupService(cephObjectStore *cephv1.CephObjectStore, group *cephv1.GatewayGroupSpec) *v1.Service {
+	svc := c.generateService(cephObjectStore)
+	svc.Name = cephObjectStore.GetGroupServiceName(group.Name)
+	svc.Labels = getGroupLabels(cephObjectStore.Name, cephObjectStore.Namespace, group, true)
+	svc.Spec.Selector = getGroupLabels(cephObjectStore.Name, cephObjectStore.Namespace, group, false)
+	return svc
+}
+
+// reconcileGroupServices creates the services of the gateway groups and removes the services of
+// the removed groups
+func (c *clusterConfig) reconcileGroupServices(store *cephv1.CephObjectStore) error {
+	desiredServices := map[string]bool{}
+	for i := range store.Spec.Gateway.Groups {
+		service := c.generateGroupService(store, &store.Spec.Gateway.Groups[i])
+		desiredServices[service.Name] = true
+		if err := c.ownerInfo.SetControllerReference(service); err != nil {
+			return errors.Wrapf(err, "failed to set owner reference to ceph object store service %q", service.Name)
+		}
+		if _, err := k8sutil.CreateOrUpdateService(c.clusterInfo.Context, c.context.Clientset, store.Namespace, service); err != nil {
+			return errors.Wrapf(err, "failed to create or update object store %q gateway group %q service", store.Name, store.Spec.Gateway.Groups[i].Name)
+		}
+	}
+
+	services, err := c.context.Clientset.CoreV1().Services(store.Namespace).List(c.clusterInfo.Context, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s,%s", c.storeLabelSelector(), gatewayGroupLabelKey),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the gateway group services of object store %q", store.Name)
+	}
+	for _, service := range services.Items {
+		if desiredServices[service.Name] {
+			continue
+		}
+		logger.Infof("deleting service %q of a removed gateway group of object store %q", service.Name, store.Name)
+		if err := c.context.Clientset.CoreV1().Services(store.Namespace).Delete(c.clusterInfo.Context, service.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the service %q of a removed gateway group", service.Name)
+		}
+	}
 	return nil
 }
 
@@ -886,6 +964,16 @@ func getLabels(name, namespace string, includeNewLabels bool) map[string]string
 	return labels
 }
 
+// getGroupLabels returns the labels of the gateways of a group, or of all the gateways of the store
+// without group
+func getGroupLabels(name, namespace string, group *cephv1.GatewayGroupSpec, includeNewLabels bool) map[string]string {
+	labels := getLabels(name, namespace, includeNewLabels)
+	if group != nil {
+		labels[gatewayGroupLabelKey] = group.Name
+	}
+	return labels
+}
+
 func (c *clusterConfig) generateVolumeSourceWithTLSSecret() (*v1.SecretVolumeSource, error) {
 	// Keep the TLS secret as secure as possible in the container. Give only user read perms.
 	// Because the Secret mount is owned by "root" and fsGroup breaks on OCP since we cannot predict it
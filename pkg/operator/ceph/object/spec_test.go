@@ -1715,3 +1715,30 @@ func TestRgwReadAffinity(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileGroupServices(t *testing.T) {
+	ctx := context.TODO()
+	store := simpleStore()
+	store.Spec.Gateway.Groups = []cephv1.GatewayGroupSpec{{Name: "zone-a"}, {Name: "zone-b"}}
+	c := newPublicationTestConfig(t, store)
+	c.clusterSpec = &cephv1.ClusterSpec{}
+
+	assert.NoError(t, c.reconcileService(store))
+	svc, err := c.context.Clientset.CoreV1().Services(store.Namespace).Get(ctx, "rook-ceph-rgw-default-zone-a", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "zone-a", svc.Spec.Selector[gatewayGroupLabelKey])
+	assert.Equal(t, "default", svc.Spec.Selector["rook_object_store"])
+	assert.Equal(t, int32(123), svc.Spec.Ports[0].Port)
+	// the store service selects the gateways of all the groups
+	svc, err = c.context.Clientset.CoreV1().Services(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, svc.Spec.Selector, gatewayGroupLabelKey)
+
+	// the service of a removed group is removed
+	store.Spec.Gateway.Groups = store.Spec.Gateway.Groups[:1]
+	assert.NoError(t, c.reconcileService(store))
+	_, err = c.context.Clientset.CoreV1().Services(store.Namespace).Get(ctx, "rook-ceph-rgw-default-zone-b", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = c.context.Clientset.CoreV1().Services(store.Namespace).Get(ctx, "rook-ceph-rgw-default-zone-a", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
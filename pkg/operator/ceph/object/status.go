@@ -94,6 +94,28 @@ func updateStatus(ctx context.Context, observedGeneration int64, client client.C
 	logger.Debugf("object store %q status updated to %q", namespacedName.String(), status)
 }
 
+// updateGatewayGroupStatus updates the status of the gateway groups of an object store
+func updateGatewayGroupStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, groups []cephv1.GatewayGroupStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				logger.Debug("CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the gateway group status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.GatewayGroups = groups
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to update the gateway group status of object store %q", namespacedName.String())
+		}
+		return nil
+	})
+}
+
 func buildStatusInfo(cephObjectStore *cephv1.CephObjectStore) map[string]string {
 	nsName := fmt.Sprintf("%s/%s", cephObjectStore.Namespace, cephObjectStore.Name)
 
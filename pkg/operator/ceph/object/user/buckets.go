@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// bucketStatusRefreshInterval is how often the buckets of a user are listed again while its spec
+// does not change
+var bucketStatusRefreshInterval = 10 * time.Minute
+
+// transferUserBuckets makes the user set in the spec the owner of the buckets of the user, so that
+// the buckets are not orphaned when the user is deleted
+func (r *ReconcileObjectStoreUser) transferUserBuckets(u *cephv1.CephObjectStoreUser) error {
+	buckets, err := r.objContext.AdminOpsClient.ListUsersBuckets(r.opManagerContext, u.Name)
+	if err != nil {
+		if errors.Is(err, admin.ErrNoSuchUser) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to list buckets of user %q", u.Name)
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	target := u.Spec.TransferBucketsTo
+	if target == "" {
+		return errors.Errorf("user %q owns buckets %v. delete the buckets or set \"transferBucketsTo\" to transfer them to another user before deleting the user", u.Name, buckets)
+	}
+	if _, err := r.objContext.AdminOpsClient.GetUser(r.opManagerContext, admin.User{ID: target}); err != nil {
+		return errors.Wrapf(err, "failed to get user %q to transfer the buckets to", target)
+	}
+
+	for _, bucket := range buckets {
+		// linking the bucket to another user also unlinks it from its current owner
+		err := r.objContext.AdminOpsClient.LinkBucket(r.opManagerContext, admin.BucketLinkInput{Bucket: bucket, UID: target})
+		if err != nil {
+			return errors.Wrapf(err, "failed to transfer bucket %q to user %q", bucket, target)
+		}
+		logger.Infof("transferred bucket %q of object user %q to user %q", bucket, u.Name, target)
+		r.recorder.Eventf(u, corev1.EventTypeNormal, "BucketTransferred", "transferred bucket %q to user %q", bucket, target)
+	}
+	return nil
+}
+
+// bucketStatusNeedsRefresh returns whether the buckets of the user must be listed again, which is
+// when the spec of the user changed or the buckets were not listed for the refresh interval
+func (r *ReconcileObjectStoreUser) bucketStatusNeedsRefresh(u *cephv1.CephObjectStoreUser) bool {
+	if u.Status == nil || u.Status.ObservedGeneration != u.Generation {
+		return true
+	}
+	lastRefresh, ok := r.bucketStatusRefreshed[types.NamespacedName{Name: u.Name, Namespace: u.Namespace}]
+	return !ok || time.Since(lastRefresh) >= bucketStatusRefreshInterval
+}
+
+// updateBucketStatus updates `.status.buckets` with the buckets owned by the user
+func (r *ReconcileObjectStoreUser) updateBucketStatus(name types.NamespacedName) {
+	buckets, err := r.objContext.AdminOpsClient.ListUsersBuckets(r.opManagerContext, name.Name)
+	if err != nil {
+		logger.Warningf("failed to list buckets of object user %q. %v", name, err)
+		return
+	}
+	slices.Sort(buckets)
+	if r.bucketStatusRefreshed == nil {
+		r.bucketStatusRefreshed = map[types.NamespacedName]time.Time{}
+	}
+	r.bucketStatusRefreshed[name] = time.Now()
+
+	user := &cephv1.CephObjectStoreUser{}
+	if err := r.client.Get(r.opManagerContext, name, user); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreUser resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve CephObjectStoreUser %q to update .status.buckets. %v", name, err)
+		return
+	}
+	if user.Status == nil {
+		user.Status = &cephv1.ObjectStoreUserStatus{}
+	}
+	if slices.Equal(user.Status.Buckets, buckets) {
+		return
+	}
+
+	user.Status.Buckets = buckets
+	if err := reporting.UpdateStatus(r.client, user); err != nil {
+		logger.Warningf("failed to update CephObjectStoreUser %q .status.buckets. %v", name, err)
+		return
+	}
+	logger.Debugf("updated CephObjectStoreUser %q .status.buckets.", name)
+}
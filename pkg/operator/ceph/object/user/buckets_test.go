@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephobject "github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestTransferUserBuckets(t *testing.T) {
+	userBuckets := `["bucket-a","bucket-b"]`
+	linked := map[string]string{}
+	mockClient := &cephobject.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "rook-ceph-rgw-my-store.mycluster.svc/admin/bucket" && query.Get("uid") == name:
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(userBuckets)))}, nil
+			case req.Method == http.MethodGet && req.URL.Path == "rook-ceph-rgw-my-store.mycluster.svc/admin/user" && query.Get("uid") == "new-owner":
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(userCreateJSON)))}, nil
+			case req.Method == http.MethodGet && req.URL.Path == "rook-ceph-rgw-my-store.mycluster.svc/admin/user":
+				return &http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewReader([]byte(`{"Code":"NoSuchUser"}`)))}, nil
+			case req.Method == http.MethodPut && req.URL.Path == "rook-ceph-rgw-my-store.mycluster.svc/admin/bucket":
+				linked[query.Get("bucket")] = query.Get("uid")
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`)))}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+		},
+	}
+	adminClient, err := admin.New("rook-ceph-rgw-my-store.mycluster.svc", "53S6B9S809NUP19IJ2K3", "1bXPegzsGClvoGAiJdHQD1uOW2sQBLAZM9j9VtXR", mockClient)
+	assert.NoError(t, err)
+	r := &ReconcileObjectStoreUser{
+		objContext:       &cephobject.AdminOpsContext{AdminOpsClient: adminClient},
+		opManagerContext: context.TODO(),
+		recorder:         record.NewFakeRecorder(5),
+	}
+	objectUser := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       cephv1.ObjectStoreUserSpec{Store: store},
+	}
+
+	t.Run("deletion blocked while the user owns buckets", func(t *testing.T) {
+		err := r.transferUserBuckets(objectUser)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "transferBucketsTo")
+		assert.Empty(t, linked)
+	})
+
+	t.Run("transfer to a missing user", func(t *testing.T) {
+		objectUser.Spec.TransferBucketsTo = "missing"
+		err := r.transferUserBuckets(objectUser)
+		assert.Error(t, err)
+		assert.Empty(t, linked)
+	})
+
+	t.Run("buckets transferred", func(t *testing.T) {
+		objectUser.Spec.TransferBucketsTo = "new-owner"
+		err := r.transferUserBuckets(objectUser)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"bucket-a": "new-owner", "bucket-b": "new-owner"}, linked)
+	})
+
+	t.Run("user without buckets", func(t *testing.T) {
+		userBuckets = `[]`
+		objectUser.Spec.TransferBucketsTo = ""
+		err := r.transferUserBuckets(objectUser)
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidateTransferBucketsTo(t *testing.T) {
+	r := &ReconcileObjectStoreUser{}
+	objectUser := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       cephv1.ObjectStoreUserSpec{Store: store, TransferBucketsTo: "new-owner"},
+	}
+	assert.NoError(t, r.validateUser(objectUser))
+
+	objectUser.Spec.TransferBucketsTo = name
+	err := r.validateUser(objectUser)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "itself")
+}
+
+func TestBucketStatusNeedsRefresh(t *testing.T) {
+	r := &ReconcileObjectStoreUser{}
+	objectUser := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 2},
+	}
+	nsName := types.NamespacedName{Name: name, Namespace: namespace}
+
+	// new user
+	assert.True(t, r.bucketStatusNeedsRefresh(objectUser))
+
+	// spec changed since the last reconcile
+	objectUser.Status = &cephv1.ObjectStoreUserStatus{ObservedGeneration: 1}
+	r.bucketStatusRefreshed = map[types.NamespacedName]time.Time{nsName: time.Now()}
+	assert.True(t, r.bucketStatusNeedsRefresh(objectUser))
+
+	// spec unchanged and buckets listed recently
+	objectUser.Status.ObservedGeneration = 2
+	assert.False(t, r.bucketStatusNeedsRefresh(objectUser))
+
+	// buckets not listed since the operator restarted
+	delete(r.bucketStatusRefreshed, nsName)
+	assert.True(t, r.bucketStatusNeedsRefresh(objectUser))
+
+	// periodic refresh
+	r.bucketStatusRefreshed[nsName] = time.Now().Add(-bucketStatusRefreshInterval)
+	assert.True(t, r.bucketStatusNeedsRefresh(objectUser))
+}
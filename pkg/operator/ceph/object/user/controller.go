@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/coreos/pkg/capnslog"
@@ -82,6 +83,8 @@ type ReconcileObjectStoreUser struct {
 	clusterInfo       *cephclient.ClusterInfo
 	opManagerContext  context.Context
 	recorder          record.EventRecorder
+	// last time the buckets of each user were listed in its status
+	bucketStatusRefreshed map[types.NamespacedName]time.Time
 }
 
 // Add creates a new CephObjectStoreUser Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -330,10 +333,16 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 		logger.Debugf("deleting object store user %q", request.NamespacedName)
 		r.recorder.Eventf(cephObjectStoreUser, corev1.EventTypeNormal, string(cephv1.ReconcileStarted), "deleting CephObjectStoreUser %q", cephObjectStoreUser.Name)
 
-		err := r.deleteUser(cephObjectStoreUser)
+		err := r.transferUserBuckets(cephObjectStoreUser)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to transfer the buckets of ceph object user %q", cephObjectStoreUser.Name)
+		}
+
+		err = r.deleteUser(cephObjectStoreUser)
 		if err != nil {
 			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to delete ceph object user %q", cephObjectStoreUser.Name)
 		}
+		delete(r.bucketStatusRefreshed, request.NamespacedName)
 
 		// Remove finalizer
 		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreUser)
@@ -362,6 +371,9 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 	// reconciled. Update even when no secrets are referenced as this could be a
 	// transition from explicit keys -> automatic secret generation.
 	r.updateKeyStatus(request.NamespacedName, referencedSecrets)
+	if r.bucketStatusNeedsRefresh(cephObjectStoreUser) {
+		r.updateBucketStatus(request.NamespacedName)
+	}
 
 	// CREATE/UPDATE KUBERNETES SECRET
 	store, err := r.getObjectStore(cephObjectStoreUser)
@@ -379,9 +391,9 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
-	// Return and do not requeue
+	// Requeue to refresh the buckets owned by the user
 	logger.Debug("done reconciling")
-	return reconcile.Result{}, *cephObjectStoreUser, nil
+	return reconcile.Result{RequeueAfter: bucketStatusRefreshInterval}, *cephObjectStoreUser, nil
 }
 
 func (r *ReconcileObjectStoreUser) reconcileCephUser(cephObjectStoreUser *cephv1.CephObjectStoreUser, userConfig *admin.User) (reconcile.Result, error) {
@@ -784,6 +796,9 @@ func (r *ReconcileObjectStoreUser) validateUser(u *cephv1.CephObjectStoreUser) e
 	if u.Spec.Store == "" {
 		return errors.New("missing store")
 	}
+	if u.Spec.TransferBucketsTo == u.Name {
+		return errors.New("cannot transfer the buckets of the user to itself")
+	}
 	return nil
 }
 
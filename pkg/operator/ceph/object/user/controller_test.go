@@ -308,6 +308,12 @@ func TestCephObjectStoreUserController(t *testing.T) {
 							Body:       io.NopCloser(bytes.NewReader([]byte(`[]`))),
 						}, nil
 					}
+					if req.Method == http.MethodGet && req.URL.Path == "rook-ceph-rgw-my-store.mycluster.svc/admin/bucket" && req.URL.Query().Get("uid") == "my-user" {
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewReader([]byte(`["my-bucket"]`))),
+						}, nil
+					}
 					return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
 				},
 			}
@@ -332,6 +338,7 @@ func TestCephObjectStoreUserController(t *testing.T) {
 		err = r.client.Get(context.TODO(), req.NamespacedName, objectUser)
 		assert.NoError(t, err)
 		assert.Equal(t, "Ready", objectUser.Status.Phase, objectUser)
+		assert.Equal(t, []string{"my-bucket"}, objectUser.Status.Buckets)
 	})
 
 	t.Run("cluster and object store in different namespace", func(t *testing.T) {
@@ -230,13 +230,19 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, cephObjectZone, request.NamespacedName, "failed to create ceph zone", err)
 	}
 
+	// Revert or report the changes of the zone made outside of Rook
+	err = r.checkConfigDrift(cephObjectZone, realmName)
+	if err != nil {
+		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, cephObjectZone, request.NamespacedName, "failed to reconcile the drift of the ceph zone", err)
+	}
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
-	// Return and do not requeue
+	// Requeue to compare the zone with the CR again if the drift detection is enabled
 	logger.Debug("zone done reconciling")
-	return reconcile.Result{}, *cephObjectZone, nil
+	return reconcile.Result{RequeueAfter: object.MultisiteDriftInterval(cephObjectZone.Spec.DriftDetection)}, *cephObjectZone, nil
 }
 
 func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone, realmName string) (reconcile.Result, error) {
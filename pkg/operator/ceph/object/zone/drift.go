@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// checkConfigDrift compares the zone in RGW with the CR, reverts or reports the drift, and reports
+// the MultisiteConfigDrift condition on the CR
+func (r *ReconcileObjectZone) checkConfigDrift(zone *cephv1.CephObjectZone, realmName string) error {
+	if zone.Spec.DriftDetection == nil {
+		return nil
+	}
+
+	objContext := object.NewContext(r.context, r.clusterInfo, zone.Name)
+	objContext.Realm = realmName
+	objContext.ZoneGroup = zone.Spec.ZoneGroup
+	objContext.Zone = zone.Name
+
+	drifts, err := object.ZoneConfigDrift(objContext, zone)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compare zone %q with the CR", zone.Name)
+	}
+	condition, eventType, err := object.ReconcileMultisiteConfigDrift(objContext, zone.Spec.DriftDetection, drifts)
+	if eventType != "" {
+		r.recorder.Event(zone, eventType, string(condition.Reason), condition.Message)
+	}
+	r.updateDriftCondition(types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}, condition)
+	return err
+}
+
+// updateDriftCondition sets the MultisiteConfigDrift condition of the zone
+func (r *ReconcileObjectZone) updateDriftCondition(name types.NamespacedName, condition cephv1.Condition) {
+	objectZone := &cephv1.CephObjectZone{}
+	if err := r.client.Get(r.opManagerContext, name, objectZone); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectZone resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve object zone %q to update the multisite config drift condition. %v", name, err)
+		return
+	}
+	if objectZone.Status == nil {
+		objectZone.Status = &cephv1.Status{}
+	}
+	cephv1.SetStatusCondition(&objectZone.Status.Conditions, condition)
+	if err := reporting.UpdateStatus(r.client, objectZone); err != nil {
+		logger.Errorf("failed to set the multisite config drift condition of object zone %q. %v", name, err)
+	}
+}
@@ -201,13 +201,19 @@ func (r *ReconcileObjectZoneGroup) reconcile(request reconcile.Request) (reconci
 		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, "failed to create ceph zone group", err)
 	}
 
+	// Revert or report the changes of the zone group made outside of Rook
+	err = r.checkConfigDrift(cephObjectZoneGroup)
+	if err != nil {
+		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, "failed to reconcile the drift of the ceph zone group", err)
+	}
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
-	// Return and do not requeue
+	// Requeue to compare the zone group with the CR again if the drift detection is enabled
 	logger.Debug("zone group done reconciling")
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: object.MultisiteDriftInterval(cephObjectZoneGroup.Spec.DriftDetection)}, nil
 }
 
 func (r *ReconcileObjectZoneGroup) createCephZoneGroup(zoneGroup *cephv1.CephObjectZoneGroup) (reconcile.Result, error) {
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonegroup
+
+import (
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// checkConfigDrift compares the zone group in RGW with the CR, reverts or reports the drift, and
+// reports the MultisiteConfigDrift condition on the CR
+func (r *ReconcileObjectZoneGroup) checkConfigDrift(zoneGroup *cephv1.CephObjectZoneGroup) error {
+	if zoneGroup.Spec.DriftDetection == nil {
+		return nil
+	}
+
+	objContext := object.NewContext(r.context, r.clusterInfo, zoneGroup.Name)
+	objContext.Realm = zoneGroup.Spec.Realm
+	objContext.ZoneGroup = zoneGroup.Name
+
+	drifts, err := object.ZoneGroupConfigDrift(objContext)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compare zone group %q with the CR", zoneGroup.Name)
+	}
+	condition, _, err := object.ReconcileMultisiteConfigDrift(objContext, zoneGroup.Spec.DriftDetection, drifts)
+	r.updateDriftCondition(types.NamespacedName{Namespace: zoneGroup.Namespace, Name: zoneGroup.Name}, condition)
+	return err
+}
+
+// updateDriftCondition sets the MultisiteConfigDrift condition of the zone group
+func (r *ReconcileObjectZoneGroup) updateDriftCondition(name types.NamespacedName, condition cephv1.Condition) {
+	objectZoneGroup := &cephv1.CephObjectZoneGroup{}
+	if err := r.client.Get(r.opManagerContext, name, objectZoneGroup); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectZoneGroup resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve object zone group %q to update the multisite config drift condition. %v", name, err)
+		return
+	}
+	if objectZoneGroup.Status == nil {
+		objectZoneGroup.Status = &cephv1.Status{}
+	}
+	cephv1.SetStatusCondition(&objectZoneGroup.Status.Conditions, condition)
+	if err := reporting.UpdateStatus(r.client, objectZoneGroup); err != nil {
+		logger.Errorf("failed to set the multisite config drift condition of object zone group %q. %v", name, err)
+	}
+}
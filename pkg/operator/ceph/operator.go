@@ -145,6 +145,9 @@ func (o *Operator) runCRDManager() {
 	// Run the operator CRD manager
 	go o.startCRDManager(opManagerContext, mgrCRDErrorChan)
 
+	// Publish the actions the controllers would have taken in observer mode
+	go opcontroller.RunObserverActionsPublisher(opManagerContext, o.context.Clientset, o.config.OperatorNamespace)
+
 	// Run an informative go routine that prints the number of goroutines
 	go func() {
 		// Let's wait a bit to make sure most of the reconcilers are done
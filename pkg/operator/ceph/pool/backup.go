@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// BackupQuiesceAnnotation is set on the CephBlockPool by a backup tool with the name of a backup
+	// to quiesce the pool for the backup, and removed to unquiesce the pool once the backup is done
+	BackupQuiesceAnnotation = "ceph.rook.io/backup-quiesce"
+
+	// BackupQuiesced is the phase of a pool quiesced for a backup
+	BackupQuiesced = "Quiesced"
+	// BackupQuiesceFailed is the phase of a pool that failed to be quiesced for a backup
+	BackupQuiesceFailed = "Failed"
+
+	// backupSnapshotPrefix is the prefix of the group snapshots taken for the backups
+	backupSnapshotPrefix = "rook-backup-"
+)
+
+// backupQuiesceRequested returns the backup the pool is requested to be quiesced for
+func backupQuiesceRequested(cephBlockPool *cephv1.CephBlockPool) string {
+	return cephBlockPool.GetAnnotations()[BackupQuiesceAnnotation]
+}
+
+// predicateBackupQuiesceChanged reconciles the pools when the backup quiesce annotation changes
+func predicateBackupQuiesceChanged() predicate.TypedFuncs[*cephv1.CephBlockPool] {
+	return predicate.TypedFuncs[*cephv1.CephBlockPool]{
+		CreateFunc: func(e event.TypedCreateEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*cephv1.CephBlockPool]) bool {
+			return backupQuiesceRequested(e.ObjectOld) != backupQuiesceRequested(e.ObjectNew)
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+	}
+}
+
+// reconcileBackupQuiesce quiesces the pool for the backup requested in the backup quiesce
+// annotation, and unquiesces the pool from the previous backup. The mirroring snapshot schedules of
+// the pool are restored by the reconcile of the pool once the annotation is removed.
+func (r *ReconcileCephBlockPool) reconcileBackupQuiesce(poolName types.NamespacedName, cephBlockPool *cephv1.CephBlockPool) error {
+	backup := backupQuiesceRequested(cephBlockPool)
+	var current *cephv1.BackupQuiesceStatus
+	if cephBlockPool.Status != nil {
+		current = cephBlockPool.Status.BackupQuiesce
+	}
+	if current == nil && backup == "" {
+		return nil
+	}
+	if current != nil && current.Backup == backup && current.Phase == BackupQuiesced {
+		return nil
+	}
+
+	pool := cephBlockPool.ToNamedPoolSpec().Name
+	if current != nil {
+		// remove the snapshots of the previous backup, or of the failed attempt
+		if err := unquiescePool(r.context, r.clusterInfo, pool, current); err != nil {
+			return err
+		}
+		logger.Infof("unquiesced pool %q from backup %q", cephBlockPool.Name, current.Backup)
+	}
+
+	var status *cephv1.BackupQuiesceStatus
+	var err error
+	if backup != "" {
+		status, err = quiescePool(r.context, r.clusterInfo, pool, backup, timeNow())
+		if err == nil {
+			logger.Infof("quiesced pool %q for backup %q with %d group snapshots", cephBlockPool.Name, backup, len(status.GroupSnapshots))
+		}
+	}
+	if statusErr := r.updateBackupQuiesceStatus(poolName, status); statusErr != nil {
+		return statusErr
+	}
+	return err
+}
+
+// quiescePool pauses the mirroring snapshot schedules of the pool and takes a crash-consistent
+// snapshot of each rbd group of the pool. The clients of the images are asked to quiesce and flush
+// their caches by the group snapshots.
+func quiescePool(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, poolName, backup string, now time.Time) (*cephv1.BackupQuiesceStatus, error) {
+	status := &cephv1.BackupQuiesceStatus{Backup: backup, Phase: BackupQuiesceFailed}
+	if strings.ContainsAny(backup, "@/") {
+		// the request is not retried until the annotation is changed
+		status.Message = fmt.Sprintf("invalid backup name %q, the name must not contain '@' or '/'", backup)
+		return status, nil
+	}
+
+	if err := cephclient.RemoveSnapshotSchedules(context, clusterInfo, poolName); err != nil {
+		status.Message = err.Error()
+		return status, errors.Wrapf(err, "failed to pause the mirroring snapshot schedules of pool %q", poolName)
+	}
+
+	groups, err := cephclient.ListImageGroups(context, clusterInfo, poolName)
+	if err != nil {
+		status.Message = err.Error()
+		return status, err
+	}
+	snapshot := backupSnapshotPrefix + backup
+	for _, group := range groups {
+		if err := cephclient.CreateGroupSnapshot(context, clusterInfo, poolName, group, snapshot); err != nil {
+			status.Message = err.Error()
+			return status, err
+		}
+		status.GroupSnapshots = append(status.GroupSnapshots, fmt.Sprintf("%s@%s", group, snapshot))
+	}
+
+	status.Phase = BackupQuiesced
+	status.QuiesceTime = now.UTC().Format(time.RFC3339)
+	return status, nil
+}
+
+// unquiescePool removes the group snapshots taken for the backup
+func unquiescePool(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, poolName string, status *cephv1.BackupQuiesceStatus) error {
+	for _, groupSnapshot := range status.GroupSnapshots {
+		group, snapshot, ok := strings.Cut(groupSnapshot, "@")
+		if !ok || !strings.HasPrefix(snapshot, backupSnapshotPrefix) {
+			continue
+		}
+		if err := cephclient.DeleteGroupSnapshot(context, clusterInfo, poolName, group, snapshot); err != nil {
+			return errors.Wrapf(err, "failed to unquiesce pool %q from backup %q", poolName, status.Backup)
+		}
+	}
+	return nil
+}
+
+// updateBackupQuiesceStatus updates the backup quiesce status of the pool
+func (r *ReconcileCephBlockPool) updateBackupQuiesceStatus(poolName types.NamespacedName, status *cephv1.BackupQuiesceStatus) error {
+	pool := &cephv1.CephBlockPool{}
+	if err := r.client.Get(r.opManagerContext, poolName, pool); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephBlockPool resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve pool %q to update the backup quiesce status", poolName)
+	}
+	if pool.Status == nil {
+		pool.Status = &cephv1.CephBlockPoolStatus{}
+	}
+	pool.Status.BackupQuiesce = status
+	if err := reporting.UpdateStatus(r.client, pool); err != nil {
+		return errors.Wrapf(err, "failed to update the backup quiesce status of pool %q", poolName)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestReconcileBackupQuiesce(t *testing.T) {
+	var commands []string
+	failSnapshot := false
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "mirror" && args[3] == "ls":
+				return `[{"interval":"1h"}]`, nil
+			case args[0] == "mirror" && args[3] == "remove":
+				commands = append(commands, strings.Join(args[:7], " "))
+				return "", nil
+			case args[0] == "group" && args[1] == "list":
+				return `["db","logs"]`, nil
+			case args[0] == "group" && args[1] == "snap":
+				if failSnapshot && args[3] == "vms/logs@rook-backup-backup2" {
+					return "", errors.New("group is busy")
+				}
+				commands = append(commands, strings.Join(args[:4], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected rbd command %q", args)
+		},
+	}
+	pool := &cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Name: "vms", Namespace: "rook-ceph"}}
+	nsName := types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(pool).WithStatusSubresource(pool).Build()
+	r := &ReconcileCephBlockPool{
+		client:           cl,
+		context:          &clusterd.Context{Executor: executor},
+		clusterInfo:      cephclient.AdminTestClusterInfo("mycluster"),
+		opManagerContext: context.TODO(),
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+	reconcileBackup := func(backup string) error {
+		require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+		pool.Annotations = map[string]string{}
+		if backup != "" {
+			pool.Annotations[BackupQuiesceAnnotation] = backup
+		}
+		return r.reconcileBackupQuiesce(nsName, pool)
+	}
+
+	// no backup is requested
+	require.NoError(t, reconcileBackup(""))
+	assert.Empty(t, commands)
+
+	// the schedules are paused and the groups are snapshotted
+	require.NoError(t, reconcileBackup("backup1"))
+	assert.Equal(t, []string{
+		"mirror snapshot schedule remove --pool vms 1h",
+		"group snap create vms/db@rook-backup-backup1",
+		"group snap create vms/logs@rook-backup-backup1",
+	}, commands)
+	require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Equal(t, &cephv1.BackupQuiesceStatus{
+		Backup:         "backup1",
+		Phase:          BackupQuiesced,
+		QuiesceTime:    "2025-01-02T03:04:05Z",
+		GroupSnapshots: []string{"db@rook-backup-backup1", "logs@rook-backup-backup1"},
+	}, pool.Status.BackupQuiesce)
+
+	// the pool stays quiesced for the same backup
+	commands = nil
+	require.NoError(t, reconcileBackup("backup1"))
+	assert.Empty(t, commands)
+
+	// the snapshots of the previous backup are removed for the next backup
+	failSnapshot = true
+	assert.Error(t, reconcileBackup("backup2"))
+	assert.Equal(t, []string{
+		"group snap rm vms/db@rook-backup-backup1",
+		"group snap rm vms/logs@rook-backup-backup1",
+		"mirror snapshot schedule remove --pool vms 1h",
+		"group snap create vms/db@rook-backup-backup2",
+	}, commands)
+	require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Equal(t, BackupQuiesceFailed, pool.Status.BackupQuiesce.Phase)
+	assert.Contains(t, pool.Status.BackupQuiesce.Message, "group is busy")
+	assert.Equal(t, []string{"db@rook-backup-backup2"}, pool.Status.BackupQuiesce.GroupSnapshots)
+
+	// the snapshots of the failed attempt are removed when the pool is unquiesced
+	commands = nil
+	require.NoError(t, reconcileBackup(""))
+	assert.Equal(t, []string{"group snap rm vms/db@rook-backup-backup2"}, commands)
+	require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Nil(t, pool.Status.BackupQuiesce)
+
+	// an invalid backup name is not retried
+	commands = nil
+	require.NoError(t, reconcileBackup("ns/backup3"))
+	assert.Empty(t, commands)
+	require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Equal(t, BackupQuiesceFailed, pool.Status.BackupQuiesce.Phase)
+	assert.Contains(t, pool.Status.BackupQuiesce.Message, "invalid backup name")
+}
+
+func TestPredicateBackupQuiesceChanged(t *testing.T) {
+	p := predicateBackupQuiesceChanged()
+	update := func(oldBackup, newBackup string) event.TypedUpdateEvent[*cephv1.CephBlockPool] {
+		pool := func(backup string) *cephv1.CephBlockPool {
+			return &cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{BackupQuiesceAnnotation: backup}}}
+		}
+		return event.TypedUpdateEvent[*cephv1.CephBlockPool]{ObjectOld: pool(oldBackup), ObjectNew: pool(newBackup)}
+	}
+	assert.True(t, p.Update(update("", "backup1")))
+	assert.True(t, p.Update(update("backup1", "")))
+	assert.False(t, p.Update(update("backup1", "backup1")))
+	assert.False(t, p.Generic(event.TypedGenericEvent[*cephv1.CephBlockPool]{Object: &cephv1.CephBlockPool{}}))
+}
@@ -48,6 +48,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -120,7 +121,11 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 			mgr.GetCache(),
 			&cephv1.CephBlockPool{TypeMeta: controllerTypeMeta},
 			&handler.TypedEnqueueRequestForObject[*cephv1.CephBlockPool]{},
-			opcontroller.WatchControllerPredicate[*cephv1.CephBlockPool](mgr.GetScheme()),
+			predicate.Or[*cephv1.CephBlockPool](
+				opcontroller.WatchControllerPredicate[*cephv1.CephBlockPool](mgr.GetScheme()),
+				predicateBackupQuiesceChanged(),
+				predicatePGRecommendationApproved(),
+			),
 		),
 	)
 	if err != nil {
@@ -364,6 +369,7 @@ func (r *ReconcileCephBlockPool) reconcile(request reconcile.Request) (reconcile
 				r.cancelMirrorMonitoring(cephBlockPool)
 				// Reset the MirrorHealthCheckSpec
 				checker.UpdateStatusMirroring(nil, nil, nil, "")
+				r.deletePVCMirroringStatus(cephBlockPool)
 			}
 		} else {
 			// Start monitoring of the pool
@@ -375,6 +381,8 @@ func (r *ReconcileCephBlockPool) reconcile(request reconcile.Request) (reconcile
 					// Run the goroutine to update the mirroring status and skip when blockpool mirroing mode in init-only as radosnamespace mirroring is the right place to check
 					// mirroring status when blockpool mirroring mode is init-only.
 					go checker.CheckMirroring(r.blockPoolContexts[blockPoolChannelKey].internalCtx)
+					pvcChecker := newPVCMirroringChecker(r.context, r.clusterInfo, cephBlockPool, k8sutil.NewOwnerInfo(cephBlockPool, r.scheme))
+					go pvcChecker.checkPVCMirroring(r.blockPoolContexts[blockPoolChannelKey].internalCtx)
 				}
 			}
 		}
@@ -395,6 +403,7 @@ func (r *ReconcileCephBlockPool) reconcile(request reconcile.Request) (reconcile
 			r.cancelMirrorMonitoring(cephBlockPool)
 			// Reset the MirrorHealthCheckSpec
 			checker.UpdateStatusMirroring(nil, nil, nil, "")
+			r.deletePVCMirroringStatus(cephBlockPool)
 		}
 	}
 
@@ -402,6 +411,50 @@ func (r *ReconcileCephBlockPool) reconcile(request reconcile.Request) (reconcile
 		return opcontroller.ImmediateRetryResult, *cephBlockPool, errors.Wrapf(statusErr, "failed to update status of pool %q to %q.", cephBlockPool.Name, cephv1.ConditionReady)
 	}
 
+	// Quiesce the pool for the backup requested by a backup tool
+	if err := r.reconcileBackupQuiesce(request.NamespacedName, cephBlockPool); err != nil {
+		return opcontroller.ImmediateRetryResult, *cephBlockPool, errors.Wrapf(err, "failed to quiesce pool %q for backup", cephBlockPool.Name)
+	}
+
+	// Migrate the data of the pool gradually to its new crush rule
+	migrating, err := r.reconcileMigration(request.NamespacedName, cephBlockPool, &cephCluster.Spec)
+	if err != nil {
+		return reconcile.Result{}, *cephBlockPool, errors.Wrapf(err, "failed to migrate pool %q", cephBlockPool.Name)
+	}
+
+	// Recommend the number of pgs of the pool, and apply the approved recommendation
+	if err := r.reconcilePGAdvisor(request.NamespacedName, cephBlockPool); err != nil {
+		return reconcile.Result{}, *cephBlockPool, errors.Wrapf(err, "failed to recommend the number of pgs of pool %q", cephBlockPool.Name)
+	}
+
+	// Create the seed images of the pool
+	seedRunning, err := r.reconcileSeed(request.NamespacedName, cephBlockPool, &cephCluster)
+	if err != nil {
+		return reconcile.Result{}, *cephBlockPool, errors.Wrapf(err, "failed to seed pool %q", cephBlockPool.Name)
+	}
+
+	// Take the scheduled snapshots of the pool images
+	nextSnapshot, err := r.reconcileImageSnapshots(request.NamespacedName, cephBlockPool)
+	if err != nil {
+		return reconcile.Result{}, *cephBlockPool, errors.Wrapf(err, "failed to snapshot the images of pool %q", cephBlockPool.Name)
+	}
+	if seedRunning && (nextSnapshot == 0 || nextSnapshot > opcontroller.SeedRequeue) {
+		logger.Debugf("done reconciling, the seed job of pool %q is checked again in %s", cephBlockPool.Name, opcontroller.SeedRequeue.String())
+		return reconcile.Result{RequeueAfter: opcontroller.SeedRequeue}, *cephBlockPool, nil
+	}
+	if migrating && (nextSnapshot == 0 || nextSnapshot > migrationRequeue) {
+		logger.Debugf("done reconciling, the migration of pool %q is checked again in %s", cephBlockPool.Name, migrationRequeue.String())
+		return reconcile.Result{RequeueAfter: migrationRequeue}, *cephBlockPool, nil
+	}
+	if pgAdvisorEnabled(cephBlockPool) && (nextSnapshot == 0 || nextSnapshot > pgAdvisorRequeue) {
+		logger.Debugf("done reconciling, the number of pgs of pool %q is checked again in %s", cephBlockPool.Name, pgAdvisorRequeue.String())
+		return reconcile.Result{RequeueAfter: pgAdvisorRequeue}, *cephBlockPool, nil
+	}
+	if nextSnapshot > 0 {
+		logger.Debugf("done reconciling, the images of pool %q are snapshotted again in %s", cephBlockPool.Name, nextSnapshot.String())
+		return reconcile.Result{RequeueAfter: nextSnapshot}, *cephBlockPool, nil
+	}
+
 	// Return and do not requeue
 	logger.Debug("done reconciling")
 	return reconcile.Result{}, *cephBlockPool, nil
@@ -467,6 +520,14 @@ func (r *ReconcileCephBlockPool) handleDeletionBlocked(cephBlockPool *cephv1.Cep
 
 func (r *ReconcileCephBlockPool) reconcileCreatePool(clusterInfo *cephclient.ClusterInfo, cephCluster *cephv1.ClusterSpec, cephBlockPool *cephv1.CephBlockPool) (reconcile.Result, error) {
 	poolSpec := cephBlockPool.ToNamedPoolSpec()
+	if backupQuiesceRequested(cephBlockPool) != "" {
+		// the mirroring snapshot schedules are paused while the pool is quiesced for a backup
+		poolSpec.Mirroring.SnapshotSchedules = nil
+	}
+	if poolMigrationEnabled(cephBlockPool) || poolMigrationInProgress(cephBlockPool) {
+		// the crush rule of the pool is replaced by the migration of its data
+		poolSpec.EnableCrushUpdates = false
+	}
 	err := createPool(r.context, clusterInfo, cephCluster, &poolSpec)
 	if err != nil {
 		return opcontroller.ImmediateRetryResult, errors.Wrapf(err, "failed to configure pool %q.", cephBlockPool.GetName())
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "rook_ceph"
+	metricsSubsystem = "rbd_mirror"
+)
+
+var pvcMirroringLabels = []string{"namespace", "pool", "pvc_namespace", "pvc"}
+
+// The metrics of the replication of the mirrored PVCs, exposed on the metrics endpoint of the operator
+var (
+	pvcMirroringReplaying = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "pvc_replaying",
+		Help:      "Whether the image of the PVC is up and replaying to or from the peer site",
+	}, pvcMirroringLabels)
+
+	pvcMirroringLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "pvc_lag_seconds",
+		Help:      "Seconds between the latest snapshot of the image of the PVC and the last snapshot synced to the peer site",
+	}, pvcMirroringLabels)
+
+	pvcMirroringLastSync = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "pvc_last_snapshot_sync_timestamp_seconds",
+		Help:      "Unix time of the last snapshot of the image of the PVC synced to the peer site",
+	}, pvcMirroringLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(pvcMirroringReplaying, pvcMirroringLag, pvcMirroringLastSync)
+}
+
+// recordPVCMirroringMetrics replaces the metrics of the mirrored PVCs of the pool
+func recordPVCMirroringMetrics(namespace, poolName string, statuses []pvcMirroringStatus) {
+	deletePVCMirroringMetrics(namespace, poolName)
+	for _, status := range statuses {
+		labels := []string{namespace, poolName, status.PVCNamespace, status.PVCName}
+		replaying := 0.0
+		if status.State == imageStateReplaying {
+			replaying = 1
+		}
+		pvcMirroringReplaying.WithLabelValues(labels...).Set(replaying)
+		pvcMirroringLag.WithLabelValues(labels...).Set(float64(status.LagSeconds))
+		if status.lastSync > 0 {
+			pvcMirroringLastSync.WithLabelValues(labels...).Set(float64(status.lastSync))
+		}
+	}
+}
+
+// deletePVCMirroringMetrics removes the metrics of the mirrored PVCs of the pool
+func deletePVCMirroringMetrics(namespace, poolName string) {
+	labels := prometheus.Labels{"namespace": namespace, "pool": poolName}
+	pvcMirroringReplaying.DeletePartialMatch(labels)
+	pvcMirroringLag.DeletePartialMatch(labels)
+	pvcMirroringLastSync.DeletePartialMatch(labels)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// PoolMigrationMigrating is the phase of a migration while the data of the pool is moving
+	PoolMigrationMigrating = "Migrating"
+	// PoolMigrationCompleted is the phase of a migration once all the data of the pool moved
+	PoolMigrationCompleted = "Completed"
+	// PoolMigrationRolledBack is the phase of a migration after the previous CRUSH rule of the pool
+	// was restored
+	PoolMigrationRolledBack = "RolledBack"
+
+	defaultMaxMovingPGsPercent = 5
+	// migrationRequeue is the interval at which the progress of a migration is checked
+	migrationRequeue = time.Minute
+
+	poolMigrationStartedReason    = "PoolMigrationStarted"
+	poolMigrationCompletedReason  = "PoolMigrationCompleted"
+	poolMigrationRolledBackReason = "PoolMigrationRolledBack"
+)
+
+// poolMigrationEnabled returns whether the data of the pool is migrated gradually to a new CRUSH rule
+func poolMigrationEnabled(cephBlockPool *cephv1.CephBlockPool) bool {
+	return cephBlockPool.Spec.Migration != nil && cephBlockPool.Spec.Migration.Enabled
+}
+
+// poolMigrationInProgress returns whether the data of the pool is migrating to a new CRUSH rule
+func poolMigrationInProgress(cephBlockPool *cephv1.CephBlockPool) bool {
+	return cephBlockPool.Status != nil && cephBlockPool.Status.Migration != nil &&
+		cephBlockPool.Status.Migration.Phase == PoolMigrationMigrating
+}
+
+// reconcileMigration migrates the data of the pool gradually when its CRUSH rule changes. When the
+// CRUSH rule of the pool is replaced, the PGs of the pool are mapped back to the OSDs they are
+// stored on with upmap exceptions, which are then removed a few PGs at a time so that only a
+// fraction of the data of the pool moves at the same time. It returns true while the migration is
+// in progress.
+func (r *ReconcileCephBlockPool) reconcileMigration(poolName types.NamespacedName, cephBlockPool *cephv1.CephBlockPool, clusterSpec *cephv1.ClusterSpec) (bool, error) {
+	var current *cephv1.PoolMigrationStatus
+	if cephBlockPool.Status != nil {
+		current = cephBlockPool.Status.Migration
+	}
+	poolSpec := cephBlockPool.ToNamedPoolSpec()
+
+	var status *cephv1.PoolMigrationStatus
+	if poolMigrationInProgress(cephBlockPool) {
+		var err error
+		status, err = r.throttleMigration(cephBlockPool, current.DeepCopy(), true)
+		if err != nil {
+			return true, err
+		}
+	} else {
+		if !poolMigrationEnabled(cephBlockPool) {
+			return false, nil
+		}
+		from, to, err := cephclient.PreparePoolCrushRuleUpdate(r.context, r.clusterInfo, clusterSpec, poolSpec)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to prepare the crush rule of pool %q", poolSpec.Name)
+		}
+		if to == "" {
+			return false, nil
+		}
+		if current != nil && current.Phase == PoolMigrationRolledBack && current.ToCrushRule == to {
+			logger.Debugf("not migrating pool %q to crush rule %q again after it was rolled back", poolSpec.Name, to)
+			return false, nil
+		}
+
+		logger.Infof("migrating the data of pool %q from crush rule %q to %q", poolSpec.Name, from, to)
+		if err := cephclient.SetPoolCrushRule(r.context, r.clusterInfo, poolSpec.Name, to); err != nil {
+			return false, errors.Wrapf(err, "failed to set crush rule %q on pool %q", to, poolSpec.Name)
+		}
+		r.recorder.Eventf(cephBlockPool, corev1.EventTypeNormal, poolMigrationStartedReason, "migrating the data of the pool from crush rule %q to %q", from, to)
+		status = &cephv1.PoolMigrationStatus{
+			Phase:         PoolMigrationMigrating,
+			FromCrushRule: from,
+			ToCrushRule:   to,
+			StartTime:     timeNow().UTC().Format(time.RFC3339),
+		}
+		// the PGs the mgr already reports as remapped are pinned right away, the others are
+		// pinned at the next check
+		if status, err = r.throttleMigration(cephBlockPool, status, false); err != nil {
+			return true, err
+		}
+	}
+
+	if !reflect.DeepEqual(status, current) {
+		if err := r.updateMigrationStatus(poolName, status); err != nil {
+			return true, err
+		}
+	}
+	return status.Phase == PoolMigrationMigrating, nil
+}
+
+// throttleMigration keeps the number of PGs of the pool moving under the limit of the migration,
+// by pinning the PGs waiting to move to the OSDs they are stored on, or by releasing pinned PGs.
+// The migration is rolled back if PGs of the pool are inactive and the rollback is enabled.
+func (r *ReconcileCephBlockPool) throttleMigration(cephBlockPool *cephv1.CephBlockPool, status *cephv1.PoolMigrationStatus, canComplete bool) (*cephv1.PoolMigrationStatus, error) {
+	poolName := cephBlockPool.ToNamedPoolSpec().Name
+	details, err := cephclient.GetPoolDetails(r.context, r.clusterInfo, poolName)
+	if err != nil {
+		return status, errors.Wrapf(err, "failed to get the details of pool %q", poolName)
+	}
+	pgs, err := cephclient.GetPoolPGStats(r.context, r.clusterInfo, poolName)
+	if err != nil {
+		return status, err
+	}
+	upmaps, err := cephclient.GetPoolPGUpmapItems(r.context, r.clusterInfo, details.Number)
+	if err != nil {
+		return status, err
+	}
+	pinned := []string{}
+	for _, item := range upmaps {
+		pinned = append(pinned, item.PGID)
+	}
+	slices.Sort(pinned)
+
+	moving := []cephclient.PGStat{}
+	inactive := 0
+	for _, pg := range pgs {
+		if !pg.IsActive() {
+			inactive++
+		}
+		if pg.IsRemapped() && !slices.Contains(pinned, pg.PGID) {
+			moving = append(moving, pg)
+		}
+	}
+
+	spec := cephBlockPool.Spec.Migration
+	if inactive > 0 && spec != nil && spec.RollbackOnFailure {
+		return r.rollbackMigration(cephBlockPool, status, pinned, fmt.Sprintf("%d pgs of the pool were inactive", inactive))
+	}
+
+	maxMoving := len(pgs) * defaultMaxMovingPGsPercent / 100
+	if spec != nil && spec.MaxMovingPGsPercent > 0 {
+		maxMoving = len(pgs) * spec.MaxMovingPGsPercent / 100
+	}
+	maxMoving = max(maxMoving, 1)
+	if !poolMigrationEnabled(cephBlockPool) {
+		// the remaining data of the pool moves at once when the migration is disabled
+		maxMoving = len(pgs)
+	}
+
+	movingCount := len(moving)
+	if len(moving) > maxMoving {
+		// pin the PGs beyond the limit, the PGs already backfilling keep moving
+		for _, pg := range moving {
+			if movingCount <= maxMoving {
+				break
+			}
+			if strings.Contains(pg.State, "backfilling") {
+				continue
+			}
+			if err := r.pinPG(pg); err != nil {
+				logger.Warningf("failed to pin pg %q to its osds for the migration of pool %q. %v", pg.PGID, poolName, err)
+				continue
+			}
+			pinned = append(pinned, pg.PGID)
+			movingCount--
+		}
+	} else if len(moving) < maxMoving && len(pinned) > 0 && inactive == 0 {
+		throttled, err := r.migrationThroughputExceeded(spec)
+		if err != nil {
+			return status, err
+		}
+		if !throttled {
+			release := min(maxMoving-len(moving), len(pinned))
+			for _, pgID := range pinned[:release] {
+				if err := cephclient.RemovePGUpmapItems(r.context, r.clusterInfo, pgID); err != nil {
+					return status, err
+				}
+			}
+			logger.Infof("moving %d more pgs of pool %q to crush rule %q", release, poolName, status.ToCrushRule)
+			pinned = pinned[release:]
+			movingCount += release
+		}
+	}
+
+	status.RemainingPGs = len(pinned) + movingCount
+	status.PGs = max(status.PGs, status.RemainingPGs)
+	if canComplete && status.RemainingPGs == 0 {
+		status.Phase = PoolMigrationCompleted
+		status.CompletionTime = timeNow().UTC().Format(time.RFC3339)
+		logger.Infof("completed the migration of the data of pool %q to crush rule %q", poolName, status.ToCrushRule)
+		r.recorder.Eventf(cephBlockPool, corev1.EventTypeNormal, poolMigrationCompletedReason, "migrated the data of the pool to crush rule %q", status.ToCrushRule)
+	}
+	return status, nil
+}
+
+// rollbackMigration restores the previous CRUSH rule of the pool and removes the upmap exceptions
+// of its PGs
+func (r *ReconcileCephBlockPool) rollbackMigration(cephBlockPool *cephv1.CephBlockPool, status *cephv1.PoolMigrationStatus, pinned []string, reason string) (*cephv1.PoolMigrationStatus, error) {
+	poolName := cephBlockPool.ToNamedPoolSpec().Name
+	logger.Warningf("rolling back the migration of pool %q to crush rule %q since %s", poolName, status.ToCrushRule, reason)
+	if err := cephclient.SetPoolCrushRule(r.context, r.clusterInfo, poolName, status.FromCrushRule); err != nil {
+		return status, errors.Wrapf(err, "failed to roll back the crush rule of pool %q to %q", poolName, status.FromCrushRule)
+	}
+	for _, pgID := range pinned {
+		if err := cephclient.RemovePGUpmapItems(r.context, r.clusterInfo, pgID); err != nil {
+			return status, err
+		}
+	}
+	status.Phase = PoolMigrationRolledBack
+	status.Message = reason
+	status.CompletionTime = timeNow().UTC().Format(time.RFC3339)
+	r.recorder.Eventf(cephBlockPool, corev1.EventTypeWarning, poolMigrationRolledBackReason, "rolled back the migration of the pool to crush rule %q since %s", status.ToCrushRule, reason)
+	return status, nil
+}
+
+// migrationThroughputExceeded returns whether the recovery throughput of the cluster is above the
+// limit of the migration
+func (r *ReconcileCephBlockPool) migrationThroughputExceeded(spec *cephv1.PoolMigrationSpec) (bool, error) {
+	if spec == nil || spec.MaxRecoveryBytesPerSecond == nil {
+		return false, nil
+	}
+	cephStatus, err := cephclient.Status(r.context, r.clusterInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get ceph status")
+	}
+	if cephStatus.PgMap.RecoveryBps > uint64(spec.MaxRecoveryBytesPerSecond.Value()) {
+		logger.Infof("waiting for the recovery throughput of %d bytes per second to decrease to move more pgs", cephStatus.PgMap.RecoveryBps)
+		return true, nil
+	}
+	return false, nil
+}
+
+// pinPG maps the PG back to the OSDs it is stored on
+func (r *ReconcileCephBlockPool) pinPG(pg cephclient.PGStat) error {
+	var from, to []int
+	for _, osd := range pg.Up {
+		if !slices.Contains(pg.Acting, osd) {
+			from = append(from, osd)
+		}
+	}
+	for _, osd := range pg.Acting {
+		if !slices.Contains(pg.Up, osd) {
+			to = append(to, osd)
+		}
+	}
+	mappings := [][2]int{}
+	for i := 0; i < len(from) && i < len(to); i++ {
+		mappings = append(mappings, [2]int{from[i], to[i]})
+	}
+	if len(mappings) == 0 {
+		return errors.Errorf("no osds to map pg %q back to", pg.PGID)
+	}
+	return cephclient.SetPGUpmapItems(r.context, r.clusterInfo, pg.PGID, mappings)
+}
+
+// updateMigrationStatus updates the migration status of the pool
+func (r *ReconcileCephBlockPool) updateMigrationStatus(poolName types.NamespacedName, status *cephv1.PoolMigrationStatus) error {
+	pool := &cephv1.CephBlockPool{}
+	if err := r.client.Get(r.opManagerContext, poolName, pool); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephBlockPool resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve pool %q to update the migration status", poolName)
+	}
+	if pool.Status == nil {
+		pool.Status = &cephv1.CephBlockPoolStatus{}
+	}
+	pool.Status.Migration = status
+	if err := reporting.UpdateStatus(r.client, pool); err != nil {
+		return errors.Wrapf(err, "failed to update the migration status of pool %q", poolName)
+	}
+	return nil
+}
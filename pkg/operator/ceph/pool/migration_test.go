@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileMigration(t *testing.T) {
+	// the pgs of the pool are moving from osds 0-2 to osds 3-5
+	const pgCount = 20
+	remapped := map[string]bool{}
+	pinned := map[string]bool{}
+	inactive := ""
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "pool" && args[2] == "get":
+				return `{"pool":"replicapool","pool_id":3,"size":3}`, nil
+			case args[0] == "pg" && args[1] == "ls-by-pool":
+				pgs := []string{}
+				for i := 0; i < pgCount; i++ {
+					pgID := fmt.Sprintf("3.%d", i)
+					up, acting, state := "[3,4,5]", "[3,4,5]", "active+clean"
+					if pinned[pgID] {
+						up = "[0,1,2]"
+						acting = "[0,1,2]"
+					} else if remapped[pgID] {
+						acting = "[0,1,2]"
+						state = "active+remapped+backfill_wait"
+					}
+					if pgID == inactive {
+						state = "peering"
+					}
+					pgs = append(pgs, fmt.Sprintf(`{"pgid":%q,"state":%q,"up":%s,"acting":%s}`, pgID, state, up, acting))
+				}
+				return fmt.Sprintf(`{"pg_ready":true,"pg_stats":[%s]}`, strings.Join(pgs, ",")), nil
+			case args[0] == "osd" && args[1] == "dump":
+				items := []string{}
+				for pgID := range pinned {
+					items = append(items, fmt.Sprintf(`{"pgid":%q,"mappings":[{"from":3,"to":0}]}`, pgID))
+				}
+				// the upmap items of the other pools are ignored
+				items = append(items, `{"pgid":"30.1","mappings":[{"from":3,"to":0}]}`)
+				return fmt.Sprintf(`{"pg_upmap_items":[%s]}`, strings.Join(items, ",")), nil
+			case args[0] == "osd" && args[1] == "pg-upmap-items":
+				assert.Equal(t, []string{"3", "0", "4", "1", "5", "2"}, args[3:9])
+				pinned[args[2]] = true
+				commands = append(commands, "pin "+args[2])
+				return "", nil
+			case args[0] == "osd" && args[1] == "rm-pg-upmap-items":
+				delete(pinned, args[2])
+				remapped[args[2]] = true
+				commands = append(commands, "release "+args[2])
+				return "", nil
+			case args[0] == "osd" && args[1] == "pool" && args[2] == "set":
+				commands = append(commands, strings.Join(args[:6], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	for i := 0; i < pgCount; i++ {
+		remapped[fmt.Sprintf("3.%d", i)] = true
+	}
+
+	pool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicapool", Namespace: "rook-ceph"},
+		Spec: cephv1.NamedBlockPoolSpec{
+			PoolSpec:  cephv1.PoolSpec{Replicated: cephv1.ReplicatedSpec{Size: 3}, DeviceClass: "ssd"},
+			Migration: &cephv1.PoolMigrationSpec{Enabled: true, MaxMovingPGsPercent: 10, RollbackOnFailure: true},
+		},
+		Status: &cephv1.CephBlockPoolStatus{Migration: &cephv1.PoolMigrationStatus{
+			Phase:         PoolMigrationMigrating,
+			FromCrushRule: "replicapool",
+			ToCrushRule:   "replicapool_host_ssd",
+		}},
+	}
+	nsName := types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(pool).WithStatusSubresource(pool).Build()
+	r := &ReconcileCephBlockPool{
+		client:           cl,
+		context:          &clusterd.Context{Executor: executor},
+		clusterInfo:      cephclient.AdminTestClusterInfo("mycluster"),
+		opManagerContext: context.TODO(),
+		recorder:         record.NewFakeRecorder(10),
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+	reconcileMigration := func() bool {
+		require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+		migrating, err := r.reconcileMigration(nsName, pool, &cephv1.ClusterSpec{})
+		require.NoError(t, err)
+		require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+		return migrating
+	}
+
+	// only two pgs keep moving, the others are pinned to their osds
+	assert.True(t, reconcileMigration())
+	assert.Len(t, commands, pgCount-2)
+	assert.Equal(t, "pin 3.0", commands[0])
+	assert.Equal(t, 20, pool.Status.Migration.PGs)
+	assert.Equal(t, 20, pool.Status.Migration.RemainingPGs)
+
+	// two more pgs move once the first ones moved
+	delete(remapped, "3.18")
+	delete(remapped, "3.19")
+	commands = nil
+	assert.True(t, reconcileMigration())
+	assert.Equal(t, []string{"release 3.0", "release 3.1"}, commands)
+	assert.Equal(t, 18, pool.Status.Migration.RemainingPGs)
+
+	// no more pgs move while two pgs are moving
+	commands = nil
+	assert.True(t, reconcileMigration())
+	assert.Empty(t, commands)
+
+	// the migration is rolled back when pgs of the pool are inactive
+	inactive = "3.5"
+	assert.False(t, reconcileMigration())
+	require.Len(t, commands, 17)
+	assert.Equal(t, "osd pool set replicapool crush_rule replicapool", commands[0])
+	assert.Empty(t, pinned)
+	assert.Equal(t, PoolMigrationRolledBack, pool.Status.Migration.Phase)
+	assert.Equal(t, "1 pgs of the pool were inactive", pool.Status.Migration.Message)
+	assert.Equal(t, "2025-01-02T03:04:05Z", pool.Status.Migration.CompletionTime)
+
+	// the migration completes once no pg of the pool is pinned or moving
+	inactive = ""
+	remapped = map[string]bool{"3.0": true}
+	pool.Status.Migration = &cephv1.PoolMigrationStatus{Phase: PoolMigrationMigrating, FromCrushRule: "replicapool", ToCrushRule: "replicapool_host_ssd", PGs: 20}
+	require.NoError(t, r.updateMigrationStatus(nsName, pool.Status.Migration))
+	assert.True(t, reconcileMigration())
+	assert.Equal(t, 1, pool.Status.Migration.RemainingPGs)
+	delete(remapped, "3.0")
+	assert.False(t, reconcileMigration())
+	assert.Equal(t, PoolMigrationCompleted, pool.Status.Migration.Phase)
+	assert.Equal(t, 0, pool.Status.Migration.RemainingPGs)
+}
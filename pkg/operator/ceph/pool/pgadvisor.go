@@ -0,0 +1,269 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// ApplyPGRecommendationAnnotation is set on the CephBlockPool with the recommended number of PGs
+	// of the pool to approve the recommendation of the PG advisor
+	ApplyPGRecommendationAnnotation = "ceph.rook.io/apply-pg-recommendation"
+
+	// PGRecommendationOptimal is the phase of a pool that has the recommended number of PGs
+	PGRecommendationOptimal = "Optimal"
+	// PGRecommendationPendingApproval is the phase of a recommendation waiting for its approval
+	PGRecommendationPendingApproval = "PendingApproval"
+	// PGRecommendationApplying is the phase of an approved recommendation until the pool has the
+	// recommended number of PGs
+	PGRecommendationApplying = "Applying"
+
+	defaultTargetPGsPerOSD = 100
+	// minRecommendedPGs is the smallest number of PGs recommended for a pool
+	minRecommendedPGs = 32
+	// pgAdvisorThreshold is the factor by which the ideal number of PGs of a pool must differ from
+	// its number of PGs for a change to be recommended
+	pgAdvisorThreshold = 2.0
+	// pgAdvisorRequeue is the interval at which the recommendation is computed again
+	pgAdvisorRequeue = time.Hour
+
+	pgRecommendationAppliedReason = "PGRecommendationApplied"
+)
+
+// pgAdvisorEnabled returns whether the number of PGs of the pool is recommended
+func pgAdvisorEnabled(cephBlockPool *cephv1.CephBlockPool) bool {
+	return cephBlockPool.Spec.PGAdvisor != nil && cephBlockPool.Spec.PGAdvisor.Enabled
+}
+
+// pgRecommendationApproved returns the number of PGs approved for the pool
+func pgRecommendationApproved(cephBlockPool *cephv1.CephBlockPool) string {
+	return cephBlockPool.GetAnnotations()[ApplyPGRecommendationAnnotation]
+}
+
+// predicatePGRecommendationApproved reconciles the pools when the approval of their PG
+// recommendation changes
+func predicatePGRecommendationApproved() predicate.TypedFuncs[*cephv1.CephBlockPool] {
+	return predicate.TypedFuncs[*cephv1.CephBlockPool]{
+		CreateFunc: func(e event.TypedCreateEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*cephv1.CephBlockPool]) bool {
+			return pgRecommendationApproved(e.ObjectOld) != pgRecommendationApproved(e.ObjectNew)
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*cephv1.CephBlockPool]) bool {
+			return false
+		},
+	}
+}
+
+// reconcilePGAdvisor publishes the number of PGs recommended for the pool in its status, and
+// applies the recommendation once the pool is annotated with the recommended number of PGs
+func (r *ReconcileCephBlockPool) reconcilePGAdvisor(poolName types.NamespacedName, cephBlockPool *cephv1.CephBlockPool) error {
+	var current *cephv1.PGRecommendationStatus
+	if cephBlockPool.Status != nil {
+		current = cephBlockPool.Status.PGRecommendation
+	}
+	if !pgAdvisorEnabled(cephBlockPool) {
+		if current == nil {
+			return nil
+		}
+		return r.updatePGRecommendationStatus(poolName, nil)
+	}
+
+	status, err := r.recommendPGs(cephBlockPool)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		status.AppliedPGs = current.AppliedPGs
+	}
+
+	approved := pgRecommendationApproved(cephBlockPool) == strconv.Itoa(status.RecommendedPGs)
+	if approved && status.RecommendedPGs != status.CurrentPGs && status.AppliedPGs != status.RecommendedPGs {
+		if err := r.applyPGRecommendation(cephBlockPool, status); err != nil {
+			return err
+		}
+		status.AppliedPGs = status.RecommendedPGs
+	}
+
+	switch {
+	case status.CurrentPGs == status.RecommendedPGs:
+		status.Phase = PGRecommendationOptimal
+	case status.AppliedPGs == status.RecommendedPGs:
+		status.Phase = PGRecommendationApplying
+	default:
+		status.Phase = PGRecommendationPendingApproval
+	}
+
+	if current != nil {
+		// the status is only updated when the recommendation changes
+		status.UpdateTime = current.UpdateTime
+		if reflect.DeepEqual(status, current) {
+			return nil
+		}
+	}
+	status.UpdateTime = timeNow().UTC().Format(time.RFC3339)
+	if status.Phase == PGRecommendationPendingApproval {
+		logger.Infof("recommending %d pgs instead of %d for pool %q, annotate the CephBlockPool with %s=%d to apply the recommendation",
+			status.RecommendedPGs, status.CurrentPGs, cephBlockPool.Name, ApplyPGRecommendationAnnotation, status.RecommendedPGs)
+	}
+	return r.updatePGRecommendationStatus(poolName, status)
+}
+
+// recommendPGs returns the number of PGs that places the target number of PGs per OSD on the OSDs
+// of the device class of the pool, for the share of their used capacity that the pool uses. The PGs
+// of an erasure coded pool count as many times as their chunks, like the replicas of a replicated
+// pool.
+func (r *ReconcileCephBlockPool) recommendPGs(cephBlockPool *cephv1.CephBlockPool) (*cephv1.PGRecommendationStatus, error) {
+	poolName := cephBlockPool.ToNamedPoolSpec().Name
+	details, err := cephclient.GetPoolDetails(r.context, r.clusterInfo, poolName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the details of pool %q", poolName)
+	}
+	usage, err := cephclient.GetOSDUsage(r.context, r.clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := cephclient.GetPoolStats(r.context, r.clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// the osds that are out store no data
+	deviceClass := cephBlockPool.Spec.DeviceClass
+	osds := 0
+	usedBytes := 0.0
+	for _, osd := range usage.OSDNodes {
+		if deviceClass != "" && osd.DeviceClass != deviceClass {
+			continue
+		}
+		if reweight, err := osd.Reweight.Float64(); err != nil || reweight == 0 {
+			continue
+		}
+		osds++
+		if usedKB, err := osd.UsedKB.Float64(); err == nil {
+			usedBytes += usedKB * 1024
+		}
+	}
+	poolBytes := 0.0
+	for _, pool := range stats.Pools {
+		if pool.Name == poolName {
+			poolBytes = pool.Stats.BytesUsed
+		}
+	}
+	// the target size ratio of the pool is its expected share of the capacity
+	share := details.TargetSizeRatio
+	if usedBytes > 0 {
+		share = max(share, poolBytes/usedBytes)
+	}
+	share = min(share, 1)
+
+	status := &cephv1.PGRecommendationStatus{
+		CurrentPGs:     details.PGNum,
+		RecommendedPGs: details.PGNum,
+		OSDs:           osds,
+		DeviceClass:    deviceClass,
+		UsagePercent:   int(math.Round(share * 100)),
+	}
+	if osds == 0 {
+		status.Message = "no osd of the device class of the pool is in"
+		return status, nil
+	}
+	if share == 0 {
+		status.Message = "the pool stores no data yet"
+		return status, nil
+	}
+
+	target := defaultTargetPGsPerOSD
+	if cephBlockPool.Spec.PGAdvisor.TargetPGsPerOSD > 0 {
+		target = cephBlockPool.Spec.PGAdvisor.TargetPGsPerOSD
+	}
+	size := max(details.Size, 1)
+	ideal := float64(target*osds) * share / float64(size)
+	status.RecommendedPGs = recommendPGCount(details.PGNum, ideal)
+	status.Message = fmt.Sprintf("the pool uses %d%% of the used capacity of %d osds and needs about %.0f pgs of size %d to place %d pgs per osd",
+		status.UsagePercent, osds, ideal, size, target)
+	return status, nil
+}
+
+// recommendPGCount returns the power of two closest to the ideal number of PGs, or the current
+// number of PGs if it does not differ enough from the ideal number
+func recommendPGCount(current int, ideal float64) int {
+	ideal = max(ideal, minRecommendedPGs)
+	if current > 0 && ideal/float64(current) < pgAdvisorThreshold && float64(current)/ideal < pgAdvisorThreshold {
+		return current
+	}
+	return 1 << int(math.Round(math.Log2(ideal)))
+}
+
+// applyPGRecommendation changes the number of PGs of the pool to the recommendation. The minimum
+// number of PGs of the pool is set to the recommendation so that the autoscaler does not reduce it.
+func (r *ReconcileCephBlockPool) applyPGRecommendation(cephBlockPool *cephv1.CephBlockPool, status *cephv1.PGRecommendationStatus) error {
+	poolName := cephBlockPool.ToNamedPoolSpec().Name
+	pgs := strconv.Itoa(status.RecommendedPGs)
+	properties := []string{"pg_num", "pg_num_min"}
+	if status.RecommendedPGs < status.CurrentPGs {
+		// the minimum cannot be above the number of pgs
+		properties = []string{"pg_num_min", "pg_num"}
+	}
+	for _, property := range properties {
+		if err := cephclient.SetPoolProperty(r.context, r.clusterInfo, poolName, property, pgs); err != nil {
+			return errors.Wrapf(err, "failed to set %s of pool %q to %s", property, poolName, pgs)
+		}
+	}
+	logger.Infof("changing the number of pgs of pool %q from %d to %d", poolName, status.CurrentPGs, status.RecommendedPGs)
+	r.recorder.Eventf(cephBlockPool, corev1.EventTypeNormal, pgRecommendationAppliedReason, "changing the number of pgs of the pool from %d to %d", status.CurrentPGs, status.RecommendedPGs)
+	return nil
+}
+
+// updatePGRecommendationStatus updates the PG recommendation of the pool
+func (r *ReconcileCephBlockPool) updatePGRecommendationStatus(poolName types.NamespacedName, status *cephv1.PGRecommendationStatus) error {
+	pool := &cephv1.CephBlockPool{}
+	if err := r.client.Get(r.opManagerContext, poolName, pool); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephBlockPool resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve pool %q to update the pg recommendation", poolName)
+	}
+	if pool.Status == nil {
+		pool.Status = &cephv1.CephBlockPoolStatus{}
+	}
+	pool.Status.PGRecommendation = status
+	if err := reporting.UpdateStatus(r.client, pool); err != nil {
+		return errors.Wrapf(err, "failed to update the pg recommendation of pool %q", poolName)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcilePGAdvisor(t *testing.T) {
+	// the pool uses half of the used capacity of the 10 ssd osds
+	pgNum := 32
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "pool" && args[2] == "get":
+				return fmt.Sprintf(`{"pool":"replicapool","pool_id":3,"size":3}{"pool":"replicapool","pg_num":%d}`, pgNum), nil
+			case args[0] == "osd" && args[1] == "df":
+				nodes := []string{}
+				for i := 0; i < 12; i++ {
+					class := "ssd"
+					if i >= 10 {
+						class = "hdd"
+					}
+					nodes = append(nodes, fmt.Sprintf(`{"id":%d,"device_class":%q,"reweight":1,"kb_used":1048576}`, i, class))
+				}
+				// an osd that is out is not counted
+				nodes = append(nodes, `{"id":12,"device_class":"ssd","reweight":0,"kb_used":0}`)
+				return fmt.Sprintf(`{"nodes":[%s]}`, strings.Join(nodes, ",")), nil
+			case args[0] == "df" && args[1] == "detail":
+				return `{"pools":[{"name":"replicapool","id":3,"stats":{"bytes_used":5368709120}}]}`, nil
+			case args[0] == "osd" && args[1] == "pool" && args[2] == "set":
+				commands = append(commands, strings.Join(args[:6], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+
+	pool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicapool", Namespace: "rook-ceph"},
+		Spec: cephv1.NamedBlockPoolSpec{
+			PoolSpec:  cephv1.PoolSpec{Replicated: cephv1.ReplicatedSpec{Size: 3}, DeviceClass: "ssd"},
+			PGAdvisor: &cephv1.PGAdvisorSpec{Enabled: true},
+		},
+	}
+	nsName := types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(pool).WithStatusSubresource(pool).Build()
+	r := &ReconcileCephBlockPool{
+		client:           cl,
+		context:          &clusterd.Context{Executor: executor},
+		clusterInfo:      cephclient.AdminTestClusterInfo("mycluster"),
+		opManagerContext: context.TODO(),
+		recorder:         record.NewFakeRecorder(10),
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+	reconcilePGAdvisor := func() *cephv1.PGRecommendationStatus {
+		require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+		require.NoError(t, r.reconcilePGAdvisor(nsName, pool))
+		require.NoError(t, cl.Get(context.TODO(), nsName, pool))
+		return pool.Status.PGRecommendation
+	}
+
+	// about 167 pgs place 100 pgs per osd for half of the data of the ssd osds
+	status := reconcilePGAdvisor()
+	require.NotNil(t, status)
+	assert.Equal(t, PGRecommendationPendingApproval, status.Phase)
+	assert.Equal(t, 32, status.CurrentPGs)
+	assert.Equal(t, 128, status.RecommendedPGs)
+	assert.Equal(t, 10, status.OSDs)
+	assert.Equal(t, "ssd", status.DeviceClass)
+	assert.Equal(t, 50, status.UsagePercent)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status.UpdateTime)
+	assert.Empty(t, commands)
+
+	// an approval of another number of pgs is ignored
+	pool.Annotations = map[string]string{ApplyPGRecommendationAnnotation: "256"}
+	require.NoError(t, cl.Update(context.TODO(), pool))
+	assert.Equal(t, PGRecommendationPendingApproval, reconcilePGAdvisor().Phase)
+	assert.Empty(t, commands)
+
+	// the approved recommendation is applied once
+	pool.Annotations = map[string]string{ApplyPGRecommendationAnnotation: "128"}
+	require.NoError(t, cl.Update(context.TODO(), pool))
+	status = reconcilePGAdvisor()
+	assert.Equal(t, PGRecommendationApplying, status.Phase)
+	assert.Equal(t, 128, status.AppliedPGs)
+	assert.Equal(t, []string{"osd pool set replicapool pg_num 128", "osd pool set replicapool pg_num_min 128"}, commands)
+	commands = nil
+	assert.Equal(t, PGRecommendationApplying, reconcilePGAdvisor().Phase)
+	assert.Empty(t, commands)
+
+	pgNum = 128
+	assert.Equal(t, PGRecommendationOptimal, reconcilePGAdvisor().Phase)
+
+	// the recommendation is removed when the advisor is disabled
+	pool.Spec.PGAdvisor.Enabled = false
+	require.NoError(t, cl.Update(context.TODO(), pool))
+	assert.Nil(t, reconcilePGAdvisor())
+}
+
+func TestRecommendPGCount(t *testing.T) {
+	// the number of pgs is not changed if it is close enough to the ideal number
+	assert.Equal(t, 32, recommendPGCount(32, 60))
+	assert.Equal(t, 128, recommendPGCount(32, 167))
+	assert.Equal(t, 64, recommendPGCount(256, 80))
+	// at least the minimum number of pgs is recommended
+	assert.Equal(t, 32, recommendPGCount(256, 3))
+	assert.Equal(t, 1024, recommendPGCount(0, 1100))
+}
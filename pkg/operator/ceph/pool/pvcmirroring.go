@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// pvcMirroringConfigMapPrefix is the prefix of the ConfigMaps with the replication state of the
+	// mirrored PVCs of a pool
+	pvcMirroringConfigMapPrefix = "rook-ceph-pvc-mirroring-"
+	// imageStateReplaying is the state of a mirrored image replaying to or from the peer site
+	imageStateReplaying = "up+replaying"
+)
+
+var defaultPVCMirroringInterval = 1 * time.Minute
+
+// pvcMirroringStatus is the replication state of the image of a mirrored PVC
+type pvcMirroringStatus struct {
+	PVCNamespace string `json:"pvcNamespace"`
+	PVCName      string `json:"pvcName"`
+	Image        string `json:"image"`
+	// State is the mirroring state of the image, "up+replaying" when the image is replicated
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	// LagSeconds is the time between the latest snapshot and the last snapshot synced to the peer site
+	LagSeconds int64 `json:"lagSeconds"`
+	// LastSnapshotSync is the time of the last snapshot synced to the peer site
+	LastSnapshotSync string `json:"lastSnapshotSync,omitempty"`
+	LastChecked      string `json:"lastChecked"`
+	lastSync         int64
+}
+
+// pvcMirroringChecker periodically correlates the mirrored images of a pool with the PVCs of the
+// images, so that the replication of the PVCs can be verified without access to ceph
+type pvcMirroringChecker struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	// poolName is the name of the pool in ceph
+	poolName string
+	// configMapName is the name of the ConfigMap with the replication state of the PVCs
+	configMapName string
+	ownerInfo     *k8sutil.OwnerInfo
+	interval      time.Duration
+}
+
+func newPVCMirroringChecker(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, cephBlockPool *cephv1.CephBlockPool, ownerInfo *k8sutil.OwnerInfo) *pvcMirroringChecker {
+	c := &pvcMirroringChecker{
+		context:       context,
+		clusterInfo:   clusterInfo,
+		poolName:      cephBlockPool.ToNamedPoolSpec().Name,
+		configMapName: pvcMirroringConfigMapPrefix + cephBlockPool.Name,
+		ownerInfo:     ownerInfo,
+		interval:      defaultPVCMirroringInterval,
+	}
+	// the PVCs are checked at the interval of the mirroring status of the pool
+	if interval := cephBlockPool.Spec.StatusCheck.Mirror.Interval; interval != nil {
+		c.interval = interval.Duration
+	}
+	return c
+}
+
+// checkPVCMirroring periodically publishes the replication state of the mirrored PVCs of the pool
+// until the context is canceled
+func (c *pvcMirroringChecker) checkPVCMirroring(ctx context.Context) {
+	for {
+		if err := c.checkPVCMirroringStatus(); err != nil {
+			logger.Debugf("failed to check the mirroring status of the PVCs of pool %q. %v", c.poolName, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Infof("stopping monitoring the mirroring status of the PVCs of pool %q", c.poolName)
+			deletePVCMirroringMetrics(c.clusterInfo.Namespace, c.poolName)
+			return
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+func (c *pvcMirroringChecker) checkPVCMirroringStatus() error {
+	images, err := cephclient.GetMirroredPoolImages(c.context, c.clusterInfo, c.poolName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the mirrored images of pool %q", c.poolName)
+	}
+	claims, err := c.imageClaims()
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the PVCs of the images of pool %q", c.poolName)
+	}
+
+	statuses := pvcMirroringStatuses(images, claims, time.Now())
+	recordPVCMirroringMetrics(c.clusterInfo.Namespace, c.poolName, statuses)
+	return c.saveStatusConfigMap(statuses)
+}
+
+// imageClaims returns the PVCs of the RBD images of the pool provisioned by the CSI driver for the
+// cluster, by image name
+func (c *pvcMirroringChecker) imageClaims() (map[string]*v1.ObjectReference, error) {
+	clusterIDs, err := csi.GetClusterIDs(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the csi cluster IDs")
+	}
+	isClusterID := map[string]bool{}
+	for _, clusterID := range clusterIDs {
+		isClusterID[clusterID] = true
+	}
+
+	pvs, err := c.context.Clientset.CoreV1().PersistentVolumes().List(c.clusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list PVs")
+	}
+	claims := map[string]*v1.ObjectReference{}
+	for i := range pvs.Items {
+		pv := &pvs.Items[i]
+		if pv.Spec.CSI == nil || pv.Spec.ClaimRef == nil || pv.Spec.CSI.Driver != csi.RBDDriverName {
+			continue
+		}
+		attributes := pv.Spec.CSI.VolumeAttributes
+		if !isClusterID[attributes["clusterID"]] || attributes["pool"] != c.poolName || attributes["imageName"] == "" {
+			continue
+		}
+		claims[attributes["imageName"]] = pv.Spec.ClaimRef
+	}
+	return claims, nil
+}
+
+// pvcMirroringStatuses returns the replication state of the mirrored images with a PVC, sorted by PVC
+func pvcMirroringStatuses(images *cephclient.MirroredImages, claims map[string]*v1.ObjectReference, now time.Time) []pvcMirroringStatus {
+	statuses := []pvcMirroringStatus{}
+	if images == nil || images.Images == nil {
+		return statuses
+	}
+	for _, image := range *images.Images {
+		claim, ok := claims[image.Name]
+		if !ok {
+			continue
+		}
+		status := pvcMirroringStatus{
+			PVCNamespace: claim.Namespace,
+			PVCName:      claim.Name,
+			Image:        image.Name,
+			State:        image.State,
+			Description:  image.Description,
+			LastChecked:  now.UTC().Format(time.RFC3339),
+		}
+		// the primary image is replayed by the peer site, and the secondary image replays the primary
+		for _, peer := range image.PeerSites {
+			if status.State != imageStateReplaying && peer.State == imageStateReplaying {
+				status.State = peer.State
+				status.Description = peer.Description
+			}
+		}
+		if replay := cephclient.ParseImageReplayStatus(status.Description); replay != nil {
+			status.lastSync = replay.RemoteSnapshotTimestamp
+			if replay.LocalSnapshotTimestamp > 0 {
+				status.lastSync = replay.LocalSnapshotTimestamp
+				if replay.RemoteSnapshotTimestamp > replay.LocalSnapshotTimestamp {
+					status.LagSeconds = replay.RemoteSnapshotTimestamp - replay.LocalSnapshotTimestamp
+				}
+			}
+			if status.lastSync > 0 {
+				status.LastSnapshotSync = time.Unix(status.lastSync, 0).UTC().Format(time.RFC3339)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].PVCNamespace != statuses[j].PVCNamespace {
+			return statuses[i].PVCNamespace < statuses[j].PVCNamespace
+		}
+		return statuses[i].PVCName < statuses[j].PVCName
+	})
+	return statuses
+}
+
+// saveStatusConfigMap publishes the replication state of the PVCs in the status ConfigMap of the
+// pool, with a key "<pvc namespace>.<pvc name>" per PVC
+func (c *pvcMirroringChecker) saveStatusConfigMap(statuses []pvcMirroringStatus) error {
+	data := map[string]string{}
+	for _, status := range statuses {
+		raw, err := json.Marshal(status)
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize the mirroring status of PVC %s/%s", status.PVCNamespace, status.PVCName)
+		}
+		data[status.PVCNamespace+"."+status.PVCName] = string(raw)
+	}
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: c.configMapName, Namespace: c.clusterInfo.Namespace},
+		Data:       data,
+	}
+	if err := c.ownerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set the owner reference of configmap %q", c.configMapName)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(c.clusterInfo.Context, c.context.Clientset, cm); err != nil {
+		return errors.Wrap(err, "failed to save the mirroring status of the PVCs")
+	}
+	return nil
+}
+
+// deletePVCMirroringStatus removes the status ConfigMap of the mirrored PVCs once the mirroring status
+// of the pool is no longer monitored
+func (r *ReconcileCephBlockPool) deletePVCMirroringStatus(cephBlockPool *cephv1.CephBlockPool) {
+	name := pvcMirroringConfigMapPrefix + cephBlockPool.Name
+	err := r.context.Clientset.CoreV1().ConfigMaps(cephBlockPool.Namespace).Delete(r.opManagerContext, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		logger.Warningf("failed to delete the mirroring status of the PVCs of pool %q. %v", cephBlockPool.Name, err)
+	}
+}
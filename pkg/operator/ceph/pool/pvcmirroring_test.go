@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const mirroredImagesVerbose = `{"images":[
+{"name":"csi-vol-primary","state":"up+stopped","description":"local image is primary","peer_sites":[{"site_name":"site-b","state":"up+replaying","description":"replaying, {\"last_snapshot_sync_seconds\":2,\"local_snapshot_timestamp\":1710734800,\"remote_snapshot_timestamp\":1710734899,\"replay_state\":\"syncing\"}"}]},
+{"name":"csi-vol-secondary","state":"up+replaying","description":"replaying, {\"remote_snapshot_timestamp\":1710734899,\"replay_state\":\"idle\"}","peer_sites":[{"site_name":"site-a","state":"up+stopped","description":"local image is primary"}]},
+{"name":"csi-vol-error","state":"up+error","description":"split-brain"},
+{"name":"csi-vol-static","state":"up+replaying"}]}`
+
+func TestPVCMirroringStatus(t *testing.T) {
+	originalRBDDriverName := csi.RBDDriverName
+	t.Cleanup(func() { csi.RBDDriverName = originalRBDDriverName })
+	csi.RBDDriverName = "rook-ceph.rbd.csi.ceph.com"
+
+	newPV := func(name, pool, image, claimNamespace, claimName string) *v1.PersistentVolume {
+		return &v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PersistentVolumeSpec{
+				ClaimRef: &v1.ObjectReference{Namespace: claimNamespace, Name: claimName},
+				PersistentVolumeSource: v1.PersistentVolumeSource{CSI: &v1.CSIPersistentVolumeSource{
+					Driver:           csi.RBDDriverName,
+					VolumeAttributes: map[string]string{"clusterID": "rook-ceph", "pool": pool, "imageName": image},
+				}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		newPV("pv-1", "replicapool", "csi-vol-primary", "app", "data"),
+		newPV("pv-2", "replicapool", "csi-vol-secondary", "app", "logs"),
+		newPV("pv-3", "replicapool", "csi-vol-error", "other", "db"),
+		newPV("pv-4", "otherpool", "csi-vol-static", "app", "static"),
+	)
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "mirror" && args[1] == "pool" && args[2] == "status" {
+				return mirroredImagesVerbose, nil
+			}
+			return "", errors.New("unknown command")
+		},
+	}
+	cephBlockPool := &cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Name: "replicapool", Namespace: "rook-ceph"}}
+	c := newPVCMirroringChecker(&clusterd.Context{Clientset: clientset, Executor: executor}, cephclient.AdminTestClusterInfo("rook-ceph"), cephBlockPool, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	defer deletePVCMirroringMetrics("rook-ceph", "replicapool")
+
+	require.NoError(t, c.checkPVCMirroringStatus())
+
+	cm, err := clientset.CoreV1().ConfigMaps("rook-ceph").Get(context.TODO(), "rook-ceph-pvc-mirroring-replicapool", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cm.Data, 3)
+
+	var status pvcMirroringStatus
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["app.data"]), &status))
+	assert.Equal(t, "csi-vol-primary", status.Image)
+	assert.Equal(t, imageStateReplaying, status.State)
+	assert.Equal(t, int64(99), status.LagSeconds)
+	assert.Equal(t, time.Unix(1710734800, 0).UTC().Format(time.RFC3339), status.LastSnapshotSync)
+
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["app.logs"]), &status))
+	assert.Equal(t, imageStateReplaying, status.State)
+	assert.Zero(t, status.LagSeconds)
+	assert.Equal(t, time.Unix(1710734899, 0).UTC().Format(time.RFC3339), status.LastSnapshotSync)
+
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["other.db"]), &status))
+	assert.Equal(t, "up+error", status.State)
+	assert.Equal(t, "split-brain", status.Description)
+	assert.Empty(t, status.LastSnapshotSync)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(pvcMirroringReplaying.WithLabelValues("rook-ceph", "replicapool", "app", "data")))
+	assert.Equal(t, float64(99), testutil.ToFloat64(pvcMirroringLag.WithLabelValues("rook-ceph", "replicapool", "app", "data")))
+	assert.Equal(t, float64(1710734800), testutil.ToFloat64(pvcMirroringLastSync.WithLabelValues("rook-ceph", "replicapool", "app", "data")))
+	assert.Zero(t, testutil.ToFloat64(pvcMirroringReplaying.WithLabelValues("rook-ceph", "replicapool", "other", "db")))
+
+	// the PVCs that are deleted are removed from the status
+	require.NoError(t, clientset.CoreV1().PersistentVolumes().Delete(context.TODO(), "pv-3", metav1.DeleteOptions{}))
+	require.NoError(t, c.checkPVCMirroringStatus())
+	cm, err = clientset.CoreV1().ConfigMaps("rook-ceph").Get(context.TODO(), "rook-ceph-pvc-mirroring-replicapool", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cm.Data, 2)
+	assert.NotContains(t, cm.Data, "other.db")
+	assert.Equal(t, 2, testutil.CollectAndCount(pvcMirroringReplaying))
+}
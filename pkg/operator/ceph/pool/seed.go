@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// reconcileSeed runs the job creating the seed images of the pool when the seed settings changed,
+// and returns true while the job is running
+func (r *ReconcileCephBlockPool) reconcileSeed(poolName types.NamespacedName, cephBlockPool *cephv1.CephBlockPool, cephCluster *cephv1.CephCluster) (bool, error) {
+	var current *cephv1.SeedStatus
+	if cephBlockPool.Status != nil {
+		current = cephBlockPool.Status.Seed
+	}
+	if cephBlockPool.Spec.Seed == nil {
+		if current != nil {
+			return false, r.updateSeedStatus(poolName, nil)
+		}
+		return false, nil
+	}
+
+	seedConfig := map[string]string{
+		opcontroller.CephBlockPoolNameEnv: cephBlockPool.ToNamedPoolSpec().Name,
+	}
+	seed := opcontroller.NewResourceSeed(cephBlockPool, "CephBlockPool", cephCluster, k8sutil.NewOwnerInfo(cephBlockPool, r.scheme), r.opConfig.Image, cephBlockPool.Spec.Seed, seedConfig)
+	jobName := k8sutil.TruncateNodeNameForJob("seed-cephblockpool-%s", cephBlockPool.Name)
+	status, err := seed.Reconcile(r.opManagerContext, r.context.Clientset, jobName, current)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(status, current) {
+		if err := r.updateSeedStatus(poolName, status); err != nil {
+			return false, err
+		}
+	}
+	return status.Phase == opcontroller.SeedPhaseRunning, nil
+}
+
+// updateSeedStatus updates the seed status of the pool
+func (r *ReconcileCephBlockPool) updateSeedStatus(poolName types.NamespacedName, status *cephv1.SeedStatus) error {
+	pool := &cephv1.CephBlockPool{}
+	if err := r.client.Get(r.opManagerContext, poolName, pool); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephBlockPool resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve pool %q to update the seed status", poolName)
+	}
+	if pool.Status == nil {
+		pool.Status = &cephv1.CephBlockPoolStatus{}
+	}
+	pool.Status.Seed = status
+	if err := reporting.UpdateStatus(r.client, pool); err != nil {
+		return errors.Wrapf(err, "failed to update the seed status of pool %q", poolName)
+	}
+	return nil
+}
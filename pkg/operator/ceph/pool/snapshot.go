@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// scheduledSnapshotPrefix is the prefix of the image snapshots taken by the schedule, the
+	// other snapshots of the images are never removed
+	scheduledSnapshotPrefix = "rook-scheduled-"
+	// the snapshot names sort in the order they were taken
+	scheduledSnapshotTimeFormat = "20060102-150405"
+	// csiImagePrefix is the prefix of the images and snapshots created by the CSI driver
+	csiImagePrefix = "csi-"
+)
+
+var timeNow = time.Now
+
+// reconcileImageSnapshots takes the scheduled snapshots of the pool images when they are due, and
+// returns the time until the next snapshots are due. A zero duration means there is no schedule.
+func (r *ReconcileCephBlockPool) reconcileImageSnapshots(poolName types.NamespacedName, cephBlockPool *cephv1.CephBlockPool) (time.Duration, error) {
+	schedule := cephBlockPool.Spec.ImageSnapshotSchedule
+	if schedule == nil {
+		if cephBlockPool.Status != nil && cephBlockPool.Status.ImageSnapshotScheduleStatus != nil {
+			return 0, r.updateImageSnapshotStatus(poolName, nil)
+		}
+		return 0, nil
+	}
+
+	now := timeNow()
+	if next := nextImageSnapshotTime(cephBlockPool); now.Before(next) {
+		return next.Sub(now), nil
+	}
+
+	status, err := takeImageSnapshots(r.context, r.clusterInfo, cephBlockPool.ToNamedPoolSpec().Name, schedule, now)
+	if err != nil {
+		return 0, err
+	}
+	if status.FailedImages > 0 {
+		logger.Warningf("failed to snapshot %d images of pool %q. %s", status.FailedImages, cephBlockPool.Name, status.LastFailure)
+	}
+	if err := r.updateImageSnapshotStatus(poolName, status); err != nil {
+		return 0, err
+	}
+	return schedule.Interval.Duration, nil
+}
+
+// nextImageSnapshotTime returns the time the pool images are due to be snapshotted
+func nextImageSnapshotTime(cephBlockPool *cephv1.CephBlockPool) time.Time {
+	if cephBlockPool.Status == nil || cephBlockPool.Status.ImageSnapshotScheduleStatus == nil {
+		return time.Time{}
+	}
+	last, err := time.Parse(time.RFC3339, cephBlockPool.Status.ImageSnapshotScheduleStatus.LastSnapshotTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return last.Add(cephBlockPool.Spec.ImageSnapshotSchedule.Interval.Duration)
+}
+
+// takeImageSnapshots snapshots the scheduled images of the pool and removes their scheduled
+// snapshots exceeding the retention. A failure of an image does not prevent snapshotting the
+// other images, the failures are counted in the returned status.
+func takeImageSnapshots(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, poolName string, schedule *cephv1.ImageSnapshotScheduleSpec, now time.Time) (*cephv1.ImageSnapshotScheduleStatus, error) {
+	images, err := cephclient.ListImagesInPool(context, clusterInfo, poolName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the images of pool %q to snapshot", poolName)
+	}
+
+	status := &cephv1.ImageSnapshotScheduleStatus{LastSnapshotTime: now.UTC().Format(time.RFC3339)}
+	snapshotName := scheduledSnapshotPrefix + now.UTC().Format(scheduledSnapshotTimeFormat)
+	for _, image := range images {
+		if !isImageScheduled(image.Name, schedule) {
+			continue
+		}
+		err := cephclient.CreateSnapshotInRadosNamespace(context, clusterInfo, poolName, image.Name, snapshotName, "")
+		if err == nil {
+			err = pruneImageSnapshots(context, clusterInfo, poolName, image.Name, schedule.Retention)
+		}
+		if err != nil {
+			status.FailedImages++
+			status.LastFailure = err.Error()
+			continue
+		}
+		status.SnapshottedImages++
+	}
+	return status, nil
+}
+
+func isImageScheduled(imageName string, schedule *cephv1.ImageSnapshotScheduleSpec) bool {
+	if len(schedule.Images) > 0 {
+		return slices.Contains(schedule.Images, imageName)
+	}
+	return !strings.HasPrefix(imageName, csiImagePrefix)
+}
+
+// pruneImageSnapshots removes the oldest scheduled snapshots of the image exceeding the retention
+func pruneImageSnapshots(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, poolName, imageName string, retention int) error {
+	snapshots, err := cephclient.ListSnapshotsInRadosNamespace(context, clusterInfo, poolName, imageName, "")
+	if err != nil {
+		return err
+	}
+	scheduled := []string{}
+	for _, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.Name, scheduledSnapshotPrefix) {
+			scheduled = append(scheduled, snapshot.Name)
+		}
+	}
+	sort.Strings(scheduled)
+	for len(scheduled) > retention {
+		if err := cephclient.DeleteSnapshotInRadosNamespace(context, clusterInfo, poolName, imageName, scheduled[0], ""); err != nil {
+			return err
+		}
+		scheduled = scheduled[1:]
+	}
+	return nil
+}
+
+// updateImageSnapshotStatus updates the image snapshot schedule status of the pool
+func (r *ReconcileCephBlockPool) updateImageSnapshotStatus(poolName types.NamespacedName, status *cephv1.ImageSnapshotScheduleStatus) error {
+	pool := &cephv1.CephBlockPool{}
+	if err := r.client.Get(r.opManagerContext, poolName, pool); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephBlockPool resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve pool %q to update the image snapshot status", poolName)
+	}
+	if pool.Status == nil {
+		pool.Status = &cephv1.CephBlockPoolStatus{}
+	}
+	pool.Status.ImageSnapshotScheduleStatus = status
+	if err := reporting.UpdateStatus(r.client, pool); err != nil {
+		return errors.Wrapf(err, "failed to update the image snapshot status of pool %q", poolName)
+	}
+	return nil
+}
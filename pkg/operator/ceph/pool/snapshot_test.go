@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTakeImageSnapshots(t *testing.T) {
+	created := []string{}
+	removed := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "ls":
+				return `[{"image":"vm-disk"},{"image":"broken-disk"},{"image":"csi-vol-1234"}]`, nil
+			case args[0] == "snap" && args[1] == "create":
+				if args[2] == "vms/broken-disk@rook-scheduled-20250102-030405" {
+					return "", errors.New("image is locked")
+				}
+				created = append(created, args[2])
+				return "", nil
+			case args[0] == "snap" && args[1] == "ls":
+				return `[{"name":"rook-scheduled-20250101-030405"},{"name":"manual"},{"name":"rook-scheduled-20241231-030405"},{"name":"rook-scheduled-20250102-030405"}]`, nil
+			case args[0] == "snap" && args[1] == "rm":
+				removed = append(removed, args[2])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected rbd command %q", args)
+		},
+	}
+	c := &clusterd.Context{Executor: executor}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	schedule := &cephv1.ImageSnapshotScheduleSpec{Interval: &metav1.Duration{Duration: 24 * time.Hour}, Retention: 2}
+
+	status, err := takeImageSnapshots(c, cephclient.AdminTestClusterInfo("mycluster"), "vms", schedule, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status.LastSnapshotTime)
+	assert.Equal(t, 1, status.SnapshottedImages)
+	assert.Equal(t, 1, status.FailedImages)
+	assert.Contains(t, status.LastFailure, "broken-disk")
+	// the csi images are not snapshotted
+	assert.Equal(t, []string{"vms/vm-disk@rook-scheduled-20250102-030405"}, created)
+	// only the oldest scheduled snapshot exceeds the retention
+	assert.Equal(t, []string{"vms/vm-disk@rook-scheduled-20241231-030405"}, removed)
+
+	// only the listed images are snapshotted
+	created = []string{}
+	schedule.Images = []string{"csi-vol-1234"}
+	status, err = takeImageSnapshots(c, cephclient.AdminTestClusterInfo("mycluster"), "vms", schedule, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.SnapshottedImages)
+	assert.Equal(t, []string{"vms/csi-vol-1234@rook-scheduled-20250102-030405"}, created)
+}
+
+func TestReconcileImageSnapshots(t *testing.T) {
+	snapshots := 0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "ls":
+				return `[{"image":"vm-disk"}]`, nil
+			case args[0] == "snap" && args[1] == "create":
+				snapshots++
+				return "", nil
+			case args[0] == "snap" && args[1] == "ls":
+				return `[]`, nil
+			}
+			return "", errors.Errorf("unexpected rbd command %q", args)
+		},
+	}
+	pool := &cephv1.CephBlockPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "vms", Namespace: "rook-ceph"},
+		Spec: cephv1.NamedBlockPoolSpec{
+			ImageSnapshotSchedule: &cephv1.ImageSnapshotScheduleSpec{Interval: &metav1.Duration{Duration: time.Hour}, Retention: 1},
+		},
+	}
+	nsName := types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(pool).WithStatusSubresource(pool).Build()
+	r := &ReconcileCephBlockPool{
+		client:           cl,
+		context:          &clusterd.Context{Executor: executor},
+		clusterInfo:      cephclient.AdminTestClusterInfo("mycluster"),
+		opManagerContext: context.TODO(),
+	}
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	// the images were never snapshotted
+	next, err := r.reconcileImageSnapshots(nsName, pool)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, next)
+	assert.Equal(t, 1, snapshots)
+	assert.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Equal(t, &cephv1.ImageSnapshotScheduleStatus{LastSnapshotTime: "2025-01-02T03:04:05Z", SnapshottedImages: 1}, pool.Status.ImageSnapshotScheduleStatus)
+
+	// the snapshots are not due yet
+	now = now.Add(20 * time.Minute)
+	next, err = r.reconcileImageSnapshots(nsName, pool)
+	assert.NoError(t, err)
+	assert.Equal(t, 40*time.Minute, next)
+	assert.Equal(t, 1, snapshots)
+
+	// the snapshots are due again
+	now = now.Add(40 * time.Minute)
+	next, err = r.reconcileImageSnapshots(nsName, pool)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, next)
+	assert.Equal(t, 2, snapshots)
+
+	// the status is cleared once the schedule is removed
+	assert.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	pool.Spec.ImageSnapshotSchedule = nil
+	next, err = r.reconcileImageSnapshots(nsName, pool)
+	assert.NoError(t, err)
+	assert.Zero(t, next)
+	assert.NoError(t, cl.Get(context.TODO(), nsName, pool))
+	assert.Nil(t, pool.Status.ImageSnapshotScheduleStatus)
+}
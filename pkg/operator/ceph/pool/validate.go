@@ -65,8 +65,10 @@ func ValidatePoolSpec(context *clusterd.Context, clusterInfo *cephclient.Cluster
 	// validate pools for stretch clusters
 	if clusterSpec.IsStretchCluster() {
 		if p.IsReplicated() {
-			if p.Replicated.Size != 4 {
-				return errors.New("pools in a stretch cluster must have replication size 4")
+			// the stretch CRUSH rule places two replicas in each data zone
+			expectedSize := uint(2 * clusterSpec.StretchDataZoneCount())
+			if p.Replicated.Size != expectedSize {
+				return errors.Errorf("pools in a stretch cluster with %d data zones must have replication size %d", clusterSpec.StretchDataZoneCount(), expectedSize)
 			}
 		}
 		if p.IsErasureCoded() {
@@ -206,6 +206,24 @@ func TestValidatePool(t *testing.T) {
 		assert.Error(t, err)
 		assert.EqualError(t, err, "failure and subfailure domain cannot be identical")
 	})
+
+	t.Run("stretch cluster replication size", func(t *testing.T) {
+		stretchSpec := &cephv1.ClusterSpec{Mon: cephv1.MonSpec{StretchCluster: &cephv1.StretchClusterSpec{Zones: []cephv1.MonZoneSpec{
+			{Name: "a", Arbiter: true},
+			{Name: "b"},
+			{Name: "c"},
+		}}}}
+		p := cephv1.CephBlockPool{ObjectMeta: metav1.ObjectMeta{Name: "mypool", Namespace: clusterInfo.Namespace}}
+		p.Spec.Replicated.Size = 4
+		assert.NoError(t, validatePool(context, clusterInfo, stretchSpec, &p))
+
+		// two replicas in each of the three data zones
+		stretchSpec.Mon.StretchCluster.Zones = append(stretchSpec.Mon.StretchCluster.Zones, cephv1.MonZoneSpec{Name: "d"})
+		err := validatePool(context, clusterInfo, stretchSpec, &p)
+		assert.EqualError(t, err, "pools in a stretch cluster with 3 data zones must have replication size 6")
+		p.Spec.Replicated.Size = 6
+		assert.NoError(t, validatePool(context, clusterInfo, stretchSpec, &p))
+	})
 }
 
 func TestValidateCrushProperties(t *testing.T) {
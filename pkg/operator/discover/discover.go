@@ -148,6 +148,7 @@ func (d *Discover) createDiscoverDaemonSet(ctx context.Context, namespace, disco
 								k8sutil.NodeEnvVar(),
 								k8sutil.NameEnvVar(),
 								{Name: discoverDaemon.DiscoverDaemonUdev, Value: os.Getenv(discoverDaemon.DiscoverDaemonUdev)},
+								{Name: discoverDaemon.DiscoverDaemonDeviceIdentityFilter, Value: os.Getenv(discoverDaemon.DiscoverDaemonDeviceIdentityFilter)},
 							},
 						},
 					},
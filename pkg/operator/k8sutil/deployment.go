@@ -73,6 +73,7 @@ func UpdateDeploymentAndWait(ctx context.Context, clusterContext *clusterd.Conte
 	}
 
 	// Check whether the current deployment and newly generated one are identical
+	carryOverRestartReason(currentDeployment, modifiedDeployment)
 	patchChanged := false
 	patchResult, err := patch.DefaultPatchMaker.Calculate(currentDeployment, modifiedDeployment)
 	if err != nil {
@@ -87,6 +88,18 @@ func UpdateDeploymentAndWait(ctx context.Context, clusterContext *clusterd.Conte
 		return nil
 	}
 
+	release, err := acquireDeploymentDisruption(namespace, currentDeployment, modifiedDeployment, patchResult)
+	if err != nil {
+		// the caller retries the update once the disruptions in progress are done
+		return errors.Wrapf(err, "failed to update deployment %q", modifiedDeployment.Name)
+	}
+	defer release()
+
+	if err := prepareDeploymentRestart(namespace, currentDeployment, modifiedDeployment, patchResult); err != nil {
+		// the budget error is returned as is so that the caller requeues the reconcile
+		return err
+	}
+
 	// If deployments are different, let's update!
 	logger.Infof("updating deployment %q after verifying it is safe to stop", modifiedDeployment.Name)
 
@@ -340,6 +353,7 @@ func updateDeployment(
 	}
 
 	// Check whether the current deployment and newly generated one are identical
+	carryOverRestartReason(oldDeployment, deployment)
 	patchChanged := false
 	patchResult, err := patch.DefaultPatchMaker.Calculate(oldDeployment, deployment)
 	if err != nil {
@@ -350,6 +364,10 @@ func updateDeployment(
 	}
 
 	if patchChanged {
+		if err := prepareDeploymentRestart(namespace, oldDeployment, deployment, patchResult); err != nil {
+			return nil, nil, err
+		}
+
 		// Set hash annotation to the newly generated deployment
 		err := patch.DefaultAnnotator.SetLastAppliedAnnotation(deployment)
 		if err != nil {
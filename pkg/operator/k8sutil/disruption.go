@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	// DisruptionMon is the daemon type of the mon failovers in the disruption budget
+	DisruptionMon = "mon"
+	// DisruptionOSD is the daemon type of the OSD updates in the disruption budget
+	DisruptionOSD = "osd"
+	// DisruptionMDS is the daemon type of the MDS restarts in the disruption budget
+	DisruptionMDS = "mds"
+)
+
+// ErrDisruptionBudgetExceeded is matched by the DisruptionBudgetExceededError returned when a
+// disruption must wait for the disruptions in progress in the cluster
+var ErrDisruptionBudgetExceeded = errors.New("cluster disruption budget exceeded")
+
+// DisruptionBudgetExceededError is returned when a disruption is delayed by the disruption budget of
+// the cluster. The caller must retry the disruption later.
+type DisruptionBudgetExceededError struct {
+	DaemonType string
+	Holder     string
+	// InProgress are the disruptions holding the budget
+	InProgress []string
+	// WaitingFor is the waiting disruption with a higher priority that is granted first, if any
+	WaitingFor string
+}
+
+func (e *DisruptionBudgetExceededError) Error() string {
+	if e.WaitingFor != "" {
+		return fmt.Sprintf("%s: delaying the disruption of %q until the disruption of %q with a higher priority is done",
+			ErrDisruptionBudgetExceeded.Error(), e.Holder, e.WaitingFor)
+	}
+	return fmt.Sprintf("%s: delaying the disruption of %q while the disruption of %s is in progress",
+		ErrDisruptionBudgetExceeded.Error(), e.Holder, strings.Join(e.InProgress, ", "))
+}
+
+// Is matches ErrDisruptionBudgetExceeded so that the callers can check the error with errors.Is
+func (e *DisruptionBudgetExceededError) Is(target error) bool {
+	return target == ErrDisruptionBudgetExceeded
+}
+
+var (
+	disruptionBudgets     = map[string]*disruptionBudget{}
+	disruptionBudgetMutex sync.Mutex
+
+	// defaultDisruptionPriorities grant the mon failovers first since they restore the quorum, then
+	// the OSD updates and the MDS restarts
+	defaultDisruptionPriorities = map[string]int{
+		DisruptionMon: 300,
+		DisruptionOSD: 200,
+		DisruptionMDS: 100,
+	}
+
+	// disruptionWaiterTimeout is how long a denied disruption keeps its place in the queue when it is
+	// not retried, so that a controller that gave up does not block the other disruptions
+	disruptionWaiterTimeout = 5 * time.Minute
+
+	// deploymentDisruptions are the daemon types of the ceph deployments whose restarts are limited by
+	// the disruption budget, by daemon class. The OSDs and the mons acquire the budget themselves.
+	deploymentDisruptions = map[string]string{
+		"rook-ceph-mds": DisruptionMDS,
+	}
+)
+
+type disruptionBudget struct {
+	maxConcurrent int
+	priorities    map[string]int
+	// inProgress are the daemon types of the disruptions holding the budget, by holder
+	inProgress map[string]string
+	// waiting are the denied disruptions, by holder
+	waiting  map[string]*disruptionWaiter
+	sequence uint64
+}
+
+type disruptionWaiter struct {
+	priority int
+	sequence uint64
+	lastSeen time.Time
+}
+
+// SetDisruptionBudget sets the number of disruptions that the operator can run at the same time in
+// the cluster namespace across all the daemon types, and the priorities of the daemon types whose
+// disruptions wait for the budget. Zero means the disruptions are not limited.
+func SetDisruptionBudget(namespace string, maxConcurrent int, priorities map[string]int) {
+	disruptionBudgetMutex.Lock()
+	defer disruptionBudgetMutex.Unlock()
+
+	budget, ok := disruptionBudgets[namespace]
+	if !ok {
+		budget = &disruptionBudget{inProgress: map[string]string{}, waiting: map[string]*disruptionWaiter{}}
+		disruptionBudgets[namespace] = budget
+	}
+	budget.maxConcurrent = maxConcurrent
+	budget.priorities = map[string]int{}
+	for daemonType, priority := range defaultDisruptionPriorities {
+		budget.priorities[daemonType] = priority
+	}
+	for daemonType, priority := range priorities {
+		budget.priorities[daemonType] = priority
+	}
+}
+
+// ResetDisruptionBudget forgets the budget and the disruptions of the cluster in the namespace
+func ResetDisruptionBudget(namespace string) {
+	disruptionBudgetMutex.Lock()
+	defer disruptionBudgetMutex.Unlock()
+
+	delete(disruptionBudgets, namespace)
+}
+
+// AcquireDisruption reserves the disruption budget of the cluster for the disruption of the holder,
+// for example "mon.a", and returns the function releasing it once the disruption is done. A
+// DisruptionBudgetExceededError is returned if the budget is used by other disruptions, or if a
+// waiting disruption with a higher priority must be granted first. The denied disruption keeps its
+// place in the queue while it is retried.
+func AcquireDisruption(namespace, daemonType, holder string) (func(), error) {
+	disruptionBudgetMutex.Lock()
+	defer disruptionBudgetMutex.Unlock()
+
+	budget, ok := disruptionBudgets[namespace]
+	if !ok || budget.maxConcurrent <= 0 {
+		// no budget was configured for the namespace
+		return func() {}, nil
+	}
+	if _, ok := budget.inProgress[holder]; ok {
+		// the holder already reserved the budget and releases it when its disruption is done
+		return func() {}, nil
+	}
+
+	now := time.Now()
+	for name, waiter := range budget.waiting {
+		if now.Sub(waiter.lastSeen) > disruptionWaiterTimeout {
+			delete(budget.waiting, name)
+		}
+	}
+	waiter, ok := budget.waiting[holder]
+	if !ok {
+		budget.sequence++
+		waiter = &disruptionWaiter{priority: budget.priorities[daemonType], sequence: budget.sequence}
+	}
+	waiter.lastSeen = now
+
+	first := ""
+	for name, other := range budget.waiting {
+		if name != holder && other.before(waiter) && (first == "" || other.before(budget.waiting[first])) {
+			first = name
+		}
+	}
+	if len(budget.inProgress) >= budget.maxConcurrent || first != "" {
+		budget.waiting[holder] = waiter
+		inProgress := []string{}
+		for name := range budget.inProgress {
+			inProgress = append(inProgress, name)
+		}
+		sort.Strings(inProgress)
+		err := &DisruptionBudgetExceededError{DaemonType: daemonType, Holder: holder, InProgress: inProgress}
+		if len(budget.inProgress) < budget.maxConcurrent {
+			err.WaitingFor = first
+		}
+		return nil, err
+	}
+
+	delete(budget.waiting, holder)
+	budget.inProgress[holder] = daemonType
+	logger.Debugf("disruption of %q started in namespace %q", holder, namespace)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			disruptionBudgetMutex.Lock()
+			defer disruptionBudgetMutex.Unlock()
+			delete(budget.inProgress, holder)
+			logger.Debugf("disruption of %q finished in namespace %q", holder, namespace)
+		})
+	}, nil
+}
+
+// before returns whether the waiter is granted before the other waiter, the waiters with the highest
+// priority first and in the order they were denied
+func (w *disruptionWaiter) before(other *disruptionWaiter) bool {
+	if w.priority != other.priority {
+		return w.priority > other.priority
+	}
+	return w.sequence < other.sequence
+}
+
+// acquireDeploymentDisruption reserves the disruption budget before restarting the pods of a ceph
+// deployment whose daemon type is limited by the budget
+func acquireDeploymentDisruption(namespace string, current, modified *appsv1.Deployment, patchResult *patch.PatchResult) (func(), error) {
+	daemonType, ok := deploymentDisruptions[daemonClass(modified)]
+	if !ok || !isCephDaemon(namespace, modified.Labels) || getRestartReason(current, modified, patchResult) == "" {
+		return func() {}, nil
+	}
+	return AcquireDisruption(namespace, daemonType, modified.Name)
+}
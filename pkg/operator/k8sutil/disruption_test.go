@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAcquireDisruption(t *testing.T) {
+	namespace := "disruption-ns"
+	defer ResetDisruptionBudget(namespace)
+
+	// no budget configured
+	release, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+	assert.NoError(t, err)
+	release()
+
+	SetDisruptionBudget(namespace, 1, nil)
+	releaseOSD, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+	assert.NoError(t, err)
+
+	// the holder of the budget can acquire it again
+	release, err = AcquireDisruption(namespace, DisruptionOSD, "osd")
+	assert.NoError(t, err)
+	release()
+
+	// the budget is used by the OSD update
+	_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+	assert.True(t, errors.Is(err, ErrDisruptionBudgetExceeded))
+	var budgetErr *DisruptionBudgetExceededError
+	assert.True(t, errors.As(err, &budgetErr))
+	assert.Equal(t, []string{"osd"}, budgetErr.InProgress)
+	assert.Equal(t, "", budgetErr.WaitingFor)
+	_, err = AcquireDisruption(namespace, DisruptionMon, "mon.a")
+	assert.True(t, errors.Is(err, ErrDisruptionBudgetExceeded))
+
+	// the mon failover waiting with a higher priority is granted before the MDS restart
+	releaseOSD()
+	releaseOSD() // releasing twice is harmless
+	_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+	assert.True(t, errors.As(err, &budgetErr))
+	assert.Equal(t, "mon.a", budgetErr.WaitingFor)
+	releaseMon, err := AcquireDisruption(namespace, DisruptionMon, "mon.a")
+	assert.NoError(t, err)
+	releaseMon()
+	release, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+	assert.NoError(t, err)
+	release()
+
+	t.Run("same priority in the order of the denials", func(t *testing.T) {
+		release, err := AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+		assert.NoError(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-b")
+		assert.Error(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-c")
+		assert.Error(t, err)
+		release()
+
+		_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-c")
+		assert.True(t, errors.As(err, &budgetErr))
+		assert.Equal(t, "rook-ceph-mds-myfs-b", budgetErr.WaitingFor)
+		release, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-b")
+		assert.NoError(t, err)
+		release()
+		release, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-c")
+		assert.NoError(t, err)
+		release()
+	})
+
+	t.Run("custom priorities", func(t *testing.T) {
+		SetDisruptionBudget(namespace, 1, map[string]int{DisruptionMDS: 500})
+		defer SetDisruptionBudget(namespace, 1, nil)
+
+		release, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+		assert.NoError(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMon, "mon.b")
+		assert.Error(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+		assert.Error(t, err)
+		release()
+
+		_, err = AcquireDisruption(namespace, DisruptionMon, "mon.b")
+		assert.True(t, errors.As(err, &budgetErr))
+		assert.Equal(t, "rook-ceph-mds-myfs-a", budgetErr.WaitingFor)
+		release, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+		assert.NoError(t, err)
+		release()
+		release, err = AcquireDisruption(namespace, DisruptionMon, "mon.b")
+		assert.NoError(t, err)
+		release()
+	})
+
+	t.Run("waiters expire", func(t *testing.T) {
+		release, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+		assert.NoError(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMon, "mon.c")
+		assert.Error(t, err)
+		release()
+
+		disruptionBudgets[namespace].waiting["mon.c"].lastSeen = time.Now().Add(-2 * disruptionWaiterTimeout)
+		release, err = AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+		assert.NoError(t, err)
+		release()
+		assert.Empty(t, disruptionBudgets[namespace].waiting)
+	})
+
+	t.Run("concurrent disruptions", func(t *testing.T) {
+		SetDisruptionBudget(namespace, 2, nil)
+		defer SetDisruptionBudget(namespace, 1, nil)
+
+		releaseOSD, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+		assert.NoError(t, err)
+		releaseMDS, err := AcquireDisruption(namespace, DisruptionMDS, "rook-ceph-mds-myfs-a")
+		assert.NoError(t, err)
+		_, err = AcquireDisruption(namespace, DisruptionMon, "mon.d")
+		assert.True(t, errors.As(err, &budgetErr))
+		assert.Equal(t, []string{"osd", "rook-ceph-mds-myfs-a"}, budgetErr.InProgress)
+		releaseOSD()
+		releaseMDS()
+	})
+
+	// zero disables the budget
+	SetDisruptionBudget(namespace, 0, nil)
+	for i := 0; i < 3; i++ {
+		_, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+		assert.NoError(t, err)
+	}
+}
+
+func TestUpdateDeploymentDisruptionBudget(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "ns"
+	defer ResetDisruptionBudget(namespace)
+	clientset := fake.NewSimpleClientset()
+	clusterContext := &clusterd.Context{Clientset: clientset}
+
+	current := restartTestDeployment("rook-ceph-mds-myfs-a", "ceph:v19")
+	current.Labels[AppAttr] = "rook-ceph-mds"
+	assert.NoError(t, patch.DefaultAnnotator.SetLastAppliedAnnotation(current))
+	createDeploymentOrDie(clientset, current)
+
+	SetDisruptionBudget(namespace, 1, nil)
+	release, err := AcquireDisruption(namespace, DisruptionOSD, "osd")
+	assert.NoError(t, err)
+	defer release()
+
+	// the MDS restart waits for the OSD update
+	modified := restartTestDeployment("rook-ceph-mds-myfs-a", "ceph:v20")
+	modified.Labels[AppAttr] = "rook-ceph-mds"
+	verified := false
+	err = UpdateDeploymentAndWait(ctx, clusterContext, modified, namespace, func(action string) error {
+		verified = true
+		return nil
+	})
+	assert.True(t, errors.Is(err, ErrDisruptionBudgetExceeded))
+	assert.False(t, verified)
+	d, err := clientset.AppsV1().Deployments(namespace).Get(ctx, modified.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ceph:v19", d.Spec.Template.Spec.Containers[0].Image)
+
+	// the deployments whose pods do not restart do not wait for the budget
+	modified = restartTestDeployment("rook-ceph-mds-myfs-a", "ceph:v19")
+	modified.Labels[AppAttr] = "rook-ceph-mds"
+	_, err = acquireDeploymentDisruption(namespace, current, modified, nil)
+	assert.True(t, errors.Is(err, ErrDisruptionBudgetExceeded), "the restart is assumed when the diff is unknown")
+	patchResult, err := patch.DefaultPatchMaker.Calculate(current, modified)
+	assert.NoError(t, err)
+	release, err = acquireDeploymentDisruption(namespace, current, modified, patchResult)
+	assert.NoError(t, err)
+	release()
+
+	// the daemon types acquiring the budget themselves do not wait for it when their deployment is updated
+	mon := restartTestDeployment("a", "ceph:v20")
+	release, err = acquireDeploymentDisruption(namespace, restartTestDeployment("a", "ceph:v19"), mon, nil)
+	assert.NoError(t, err)
+	release()
+}
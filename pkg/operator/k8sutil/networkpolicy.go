@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CreateOrUpdateNetworkPolicy creates a NetworkPolicy or updates the existing one with the given spec
+func CreateOrUpdateNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	name := policy.GetName()
+	namespace := policy.GetNamespace()
+	existing, err := clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			created, err := clientset.NetworkingV1().NetworkPolicies(namespace).Create(ctx, policy, metav1.CreateOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create network policy %q", name)
+			}
+			logger.Infof("created network policy %q", name)
+			return created, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get network policy %q", name)
+	}
+
+	existing.Labels = policy.Labels
+	existing.OwnerReferences = policy.OwnerReferences
+	existing.Spec = policy.Spec
+	updated, err := clientset.NetworkingV1().NetworkPolicies(namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update network policy %q", name)
+	}
+	logger.Debugf("updated network policy %q", name)
+	return updated, nil
+}
+
+// DeleteNetworkPolicy deletes a NetworkPolicy. It is not an error if the policy does not exist.
+func DeleteNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	err := clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete network policy %q", name)
+	}
+	return nil
+}
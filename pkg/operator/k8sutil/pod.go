@@ -363,7 +363,8 @@ func ForceDeletePodIfStuck(ctx context.Context, clusterdContext *clusterd.Contex
 		return nil
 	}
 
-	logger.Infof("force deleting pod %q that appears to be stuck terminating", pod.Name)
+	// the restart budget does not delay the remediation of the pods stuck on a node that is not ready
+	logger.Infof("force deleting pod %q that appears to be stuck terminating. reason: %s", pod.Name, RestartReasonHealthRemediation)
 	var gracePeriod int64
 	deleteOpts := metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
 	if err := clusterdContext.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOpts); err != nil {
@@ -371,6 +372,7 @@ func ForceDeletePodIfStuck(ctx context.Context, clusterdContext *clusterd.Contex
 		return nil
 	}
 	logger.Infof("pod %q deletion succeeded", pod.Name)
+	annotateReplacementPod(ctx, clusterdContext.Clientset, pod, RestartReasonHealthRemediation)
 	return nil
 }
 
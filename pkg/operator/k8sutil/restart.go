@@ -0,0 +1,356 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// RestartReasonAnnotation is the annotation set on the pods restarted by the operator with the
+	// reason of the restart
+	RestartReasonAnnotation = "ceph.rook.io/restart-reason"
+	// RestartReasonUpgrade is the restart reason of the pods whose image changed
+	RestartReasonUpgrade = "upgrade"
+	// RestartReasonSpecChange is the restart reason of the pods whose spec changed. It is followed
+	// by the keys of the pod spec that changed, for example "spec-change:containers,volumes".
+	RestartReasonSpecChange = "spec-change"
+	// RestartReasonFailover is the restart reason of the pods started to replace a failed daemon
+	RestartReasonFailover = "failover"
+	// RestartReasonHealthRemediation is the restart reason of the pods force deleted because they
+	// were stuck on a node that is not ready
+	RestartReasonHealthRemediation = "health-remediation"
+	// RestartReasonRecovery is the restart reason of the pods restarted by the operator to recover
+	// the cluster, for example to restore the mon quorum
+	RestartReasonRecovery = "recovery"
+
+	restartBudgetWindow = time.Hour
+
+	// cephDaemonIDLabel is the label of the ceph daemons run by rook, whose restarts are limited by
+	// the restart budget
+	cephDaemonIDLabel = "ceph_daemon_id"
+)
+
+// ErrRestartBudgetExceeded is matched by the RestartBudgetExceededError returned when restarting a
+// daemon would exceed the restart budget of its daemon class
+var ErrRestartBudgetExceeded = errors.New("daemon restart budget exceeded")
+
+// RestartBudgetExceededError is returned when the restart of a daemon is delayed by the restart
+// budget. The caller must requeue the reconcile to retry the restart once the budget allows it.
+type RestartBudgetExceededError struct {
+	DaemonClass string
+	Reason      string
+	// Restarts is the number of restarts of the daemon class within the last hour
+	Restarts int
+	// RetryAfter is the time when the budget allows the next restart of the daemon class
+	RetryAfter time.Time
+}
+
+func (e *RestartBudgetExceededError) Error() string {
+	return fmt.Sprintf("%s: %d %q pods were already restarted in the last hour, delaying the %q restart until %s",
+		ErrRestartBudgetExceeded.Error(), e.Restarts, e.DaemonClass, e.Reason, e.RetryAfter.Format(time.RFC3339))
+}
+
+// Is matches ErrRestartBudgetExceeded so that the callers can check the error with errors.Is
+func (e *RestartBudgetExceededError) Is(target error) bool {
+	return target == ErrRestartBudgetExceeded
+}
+
+var (
+	restartBudgets     = map[string]*restartBudget{}
+	restartBudgetMutex sync.Mutex
+)
+
+type restartBudget struct {
+	perHour int
+	// restarts is the time of the restarts of the last hour, by daemon class
+	restarts map[string][]time.Time
+	// denied is the time of the last restart denied by the budget, by daemon class
+	denied map[string]time.Time
+}
+
+// SetRestartBudget sets the number of pods of each daemon class that the operator can restart
+// within an hour in the cluster namespace. Zero means the restarts are not limited.
+func SetRestartBudget(namespace string, perHour int) {
+	restartBudgetMutex.Lock()
+	defer restartBudgetMutex.Unlock()
+
+	budget, ok := restartBudgets[namespace]
+	if !ok {
+		budget = &restartBudget{restarts: map[string][]time.Time{}, denied: map[string]time.Time{}}
+		restartBudgets[namespace] = budget
+	}
+	budget.perHour = perHour
+}
+
+// ResetRestartBudget forgets the budget and the restarts of the cluster in the namespace
+func ResetRestartBudget(namespace string) {
+	restartBudgetMutex.Lock()
+	defer restartBudgetMutex.Unlock()
+
+	delete(restartBudgets, namespace)
+}
+
+// IsRestartBudgetExceeded returns whether a daemon class of the cluster in the namespace has a
+// restart that was delayed within the last hour because its restart budget is exhausted
+func IsRestartBudgetExceeded(namespace string) bool {
+	restartBudgetMutex.Lock()
+	defer restartBudgetMutex.Unlock()
+
+	budget, ok := restartBudgets[namespace]
+	if !ok {
+		return false
+	}
+	for _, denied := range budget.denied {
+		if time.Since(denied) < restartBudgetWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// ConsumeRestartBudget records the restart of a pod of the daemon class, or returns a
+// RestartBudgetExceededError if the daemon class was restarted too many times within the last hour.
+// The upgrades and the restarts recovering the daemons are never delayed by the budget.
+func ConsumeRestartBudget(namespace, daemonClass, reason string) error {
+	if isExemptFromRestartBudget(reason) {
+		return nil
+	}
+
+	restartBudgetMutex.Lock()
+	defer restartBudgetMutex.Unlock()
+
+	budget, ok := restartBudgets[namespace]
+	if !ok {
+		// no budget was configured for the namespace
+		return nil
+	}
+
+	now := time.Now()
+	restarts := []time.Time{}
+	for _, t := range budget.restarts[daemonClass] {
+		if now.Sub(t) < restartBudgetWindow {
+			restarts = append(restarts, t)
+		}
+	}
+
+	if budget.perHour > 0 && len(restarts) >= budget.perHour {
+		budget.restarts[daemonClass] = restarts
+		budget.denied[daemonClass] = now
+		return &RestartBudgetExceededError{
+			DaemonClass: daemonClass,
+			Reason:      reason,
+			Restarts:    len(restarts),
+			RetryAfter:  restarts[0].Add(restartBudgetWindow),
+		}
+	}
+
+	budget.restarts[daemonClass] = append(restarts, now)
+	delete(budget.denied, daemonClass)
+	return nil
+}
+
+// isExemptFromRestartBudget returns whether the restarts with the reason are not limited by the
+// restart budget. Delaying an upgrade would break the upgrade order of the daemons, and delaying a
+// recovery would leave the cluster unhealthy.
+func isExemptFromRestartBudget(reason string) bool {
+	switch reason {
+	case RestartReasonUpgrade, RestartReasonFailover, RestartReasonHealthRemediation, RestartReasonRecovery:
+		return true
+	}
+	return false
+}
+
+// SetRestartReason annotates the pod template with the reason the operator restarts its pods
+func SetRestartReason(template *v1.PodTemplateSpec, reason string) {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[RestartReasonAnnotation] = reason
+}
+
+// isCephDaemon returns whether the labels are the labels of a ceph daemon run by rook for the cluster
+// in the namespace
+func isCephDaemon(namespace string, labels map[string]string) bool {
+	_, ok := labels[cephDaemonIDLabel]
+	return ok && labels[ClusterAttr] == namespace
+}
+
+// daemonClass returns the class of the daemon run by the deployment, which is its app label
+func daemonClass(d *appsv1.Deployment) string {
+	if app, ok := d.Labels[AppAttr]; ok {
+		return app
+	}
+	return d.Name
+}
+
+// carryOverRestartReason keeps the restart reason of the current pods in the generated deployment
+// so that the annotation does not restart the pods by itself
+func carryOverRestartReason(current, modified *appsv1.Deployment) {
+	reason, ok := current.Spec.Template.Annotations[RestartReasonAnnotation]
+	if !ok {
+		return
+	}
+	if _, ok := modified.Spec.Template.Annotations[RestartReasonAnnotation]; ok {
+		return
+	}
+	SetRestartReason(&modified.Spec.Template, reason)
+}
+
+// getRestartReason returns why updating the current deployment to the modified one restarts its
+// pods, or an empty string if the pod template did not change
+func getRestartReason(current, modified *appsv1.Deployment, patchResult *patch.PatchResult) string {
+	// the init containers run the rook image, which is updated without the ceph image by a rook upgrade
+	if imageChanged(current.Spec.Template.Spec.Containers, modified.Spec.Template.Spec.Containers) ||
+		imageChanged(current.Spec.Template.Spec.InitContainers, modified.Spec.Template.Spec.InitContainers) {
+		return RestartReasonUpgrade
+	}
+
+	if patchResult == nil {
+		// the diff could not be calculated
+		return RestartReasonSpecChange
+	}
+
+	var diff struct {
+		Spec struct {
+			Template *struct {
+				Metadata map[string]interface{} `json:"metadata"`
+				Spec     map[string]interface{} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(patchResult.Patch, &diff); err != nil {
+		logger.Debugf("failed to parse the diff of deployment %q. %v", modified.Name, err)
+		return RestartReasonSpecChange
+	}
+	if diff.Spec.Template == nil {
+		return ""
+	}
+
+	changed := map[string]bool{}
+	if len(diff.Spec.Template.Metadata) > 0 {
+		changed["metadata"] = true
+	}
+	for key := range diff.Spec.Template.Spec {
+		if strings.HasPrefix(key, "$setElementOrder/") {
+			key = strings.TrimPrefix(key, "$setElementOrder/")
+		} else if strings.HasPrefix(key, "$") {
+			continue
+		}
+		changed[key] = true
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	keys := []string{}
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return RestartReasonSpecChange + ":" + strings.Join(keys, ",")
+}
+
+// imageChanged returns whether the image of a current container changed in the modified containers
+func imageChanged(current, modified []v1.Container) bool {
+	currentImages := map[string]string{}
+	for _, c := range current {
+		currentImages[c.Name] = c.Image
+	}
+	for _, c := range modified {
+		if image, ok := currentImages[c.Name]; ok && image != c.Image {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareDeploymentRestart annotates the modified deployment with the reason its pods are restarted
+// and consumes the restart budget of its daemon class if it runs a ceph daemon
+func prepareDeploymentRestart(namespace string, current, modified *appsv1.Deployment, patchResult *patch.PatchResult) error {
+	reason := getRestartReason(current, modified, patchResult)
+	if reason == "" {
+		return nil
+	}
+	if isCephDaemon(namespace, modified.Labels) {
+		if err := ConsumeRestartBudget(namespace, daemonClass(modified), reason); err != nil {
+			return errors.Wrapf(err, "failed to restart the pods of deployment %q", modified.Name)
+		}
+	}
+	logger.Infof("restarting the pods of deployment %q. reason: %s", modified.Name, reason)
+	SetRestartReason(&modified.Spec.Template, reason)
+	return nil
+}
+
+// annotateReplacementPod annotates the pods that replace the deleted pod with the restart reason.
+// The replacement pods are created by the controller of the deleted pod, for example its replica
+// set, as soon as the pod is terminating, so they usually exist when a stuck pod is force deleted.
+// The annotation is best-effort: a replacement pod that was not created yet is not annotated.
+func annotateReplacementPod(ctx context.Context, clientset kubernetes.Interface, deleted v1.Pod, reason string) {
+	owner := metav1.GetControllerOf(&deleted)
+	if owner == nil {
+		return
+	}
+	annotation, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{RestartReasonAnnotation: reason},
+		},
+	})
+	if err != nil {
+		logger.Warningf("failed to generate the restart reason annotation of the pod replacing pod %q. %v", deleted.Name, err)
+		return
+	}
+
+	selector := labels.SelectorFromSet(deleted.Labels).String()
+	pods, err := clientset.CoreV1().Pods(deleted.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logger.Warningf("failed to list the pods replacing pod %q. %v", deleted.Name, err)
+		return
+	}
+	annotated := false
+	for _, pod := range pods.Items {
+		podOwner := metav1.GetControllerOf(&pod)
+		if pod.UID == deleted.UID || podOwner == nil || podOwner.UID != owner.UID {
+			continue
+		}
+		_, err := clientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, annotation, metav1.PatchOptions{})
+		if err != nil {
+			logger.Warningf("failed to annotate pod %q with the restart reason %q. %v", pod.Name, reason, err)
+			continue
+		}
+		logger.Infof("annotated pod %q replacing pod %q with the restart reason %q", pod.Name, deleted.Name, reason)
+		annotated = true
+	}
+	if !annotated {
+		logger.Infof("no pod replacing pod %q was found to annotate with the restart reason %q", deleted.Name, reason)
+	}
+}
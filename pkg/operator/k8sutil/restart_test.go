@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func restartTestDeployment(name, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels:    map[string]string{AppAttr: "rook-ceph-mon", ClusterAttr: "ns", cephDaemonIDLabel: name},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "mon", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestGetRestartReason(t *testing.T) {
+	current := restartTestDeployment("a", "ceph:v19")
+	assert.NoError(t, patch.DefaultAnnotator.SetLastAppliedAnnotation(current))
+
+	reason := func(modified *appsv1.Deployment) string {
+		patchResult, err := patch.DefaultPatchMaker.Calculate(current, modified)
+		assert.NoError(t, err)
+		return getRestartReason(current, modified, patchResult)
+	}
+
+	t.Run("image change", func(t *testing.T) {
+		assert.Equal(t, RestartReasonUpgrade, reason(restartTestDeployment("a", "ceph:v20")))
+	})
+
+	t.Run("init container image change", func(t *testing.T) {
+		current := restartTestDeployment("a", "ceph:v19")
+		current.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "chown", Image: "rook:v1.17"}}
+		modified := restartTestDeployment("a", "ceph:v19")
+		modified.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "chown", Image: "rook:v1.18"}}
+		assert.Equal(t, RestartReasonUpgrade, getRestartReason(current, modified, nil))
+	})
+
+	t.Run("pod spec change", func(t *testing.T) {
+		modified := restartTestDeployment("a", "ceph:v19")
+		modified.Spec.Template.Spec.HostNetwork = true
+		modified.Spec.Template.Spec.Volumes = []v1.Volume{{Name: "data"}}
+		assert.Equal(t, "spec-change:hostNetwork,volumes", reason(modified))
+	})
+
+	t.Run("pod template metadata change", func(t *testing.T) {
+		modified := restartTestDeployment("a", "ceph:v19")
+		modified.Spec.Template.Labels = map[string]string{"foo": "bar"}
+		assert.Equal(t, "spec-change:metadata", reason(modified))
+	})
+
+	t.Run("no pod restart", func(t *testing.T) {
+		modified := restartTestDeployment("a", "ceph:v19")
+		modified.Labels["foo"] = "bar"
+		assert.Equal(t, "", reason(modified))
+	})
+
+	t.Run("unknown diff", func(t *testing.T) {
+		assert.Equal(t, RestartReasonSpecChange, getRestartReason(current, restartTestDeployment("a", "ceph:v19"), nil))
+	})
+}
+
+func TestConsumeRestartBudget(t *testing.T) {
+	namespace := "budget-ns"
+	defer ResetRestartBudget(namespace)
+
+	// no budget configured
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+
+	SetRestartBudget(namespace, 2)
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+	assert.False(t, IsRestartBudgetExceeded(namespace))
+
+	err := ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange)
+	assert.True(t, errors.Is(err, ErrRestartBudgetExceeded))
+	var budgetErr *RestartBudgetExceededError
+	assert.True(t, errors.As(err, &budgetErr))
+	assert.Equal(t, "rook-ceph-mon", budgetErr.DaemonClass)
+	assert.Equal(t, 2, budgetErr.Restarts)
+	assert.True(t, IsRestartBudgetExceeded(namespace))
+
+	// the upgrades and the recoveries are not delayed by the budget
+	for _, reason := range []string{RestartReasonUpgrade, RestartReasonFailover, RestartReasonHealthRemediation, RestartReasonRecovery} {
+		assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", reason))
+	}
+
+	// the budget is per daemon class
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-osd", RestartReasonSpecChange))
+
+	// the restarts older than an hour are not counted
+	restartBudgets[namespace].restarts["rook-ceph-mon"] = []time.Time{time.Now().Add(-2 * time.Hour), time.Now()}
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+	assert.False(t, IsRestartBudgetExceeded(namespace))
+
+	// zero disables the budget
+	SetRestartBudget(namespace, 0)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+	}
+}
+
+func TestUpdateDeploymentRestartReason(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "ns"
+	defer ResetRestartBudget(namespace)
+	clientset := fake.NewSimpleClientset()
+	current := restartTestDeployment("a", "ceph:v19")
+	assert.NoError(t, patch.DefaultAnnotator.SetLastAppliedAnnotation(current))
+	createDeploymentOrDie(clientset, current)
+
+	SetRestartBudget(namespace, 1)
+	withHostNetwork := func(d *appsv1.Deployment) *appsv1.Deployment {
+		d.Spec.Template.Spec.HostNetwork = true
+		return d
+	}
+	_, updated, err := updateDeployment(ctx, clientset, withHostNetwork(restartTestDeployment("a", "ceph:v19")))
+	assert.NoError(t, err)
+	assert.Equal(t, "spec-change:hostNetwork", updated.Spec.Template.Annotations[RestartReasonAnnotation])
+
+	// the restart reason of the running pods does not restart them again
+	_, updated, err = updateDeployment(ctx, clientset, withHostNetwork(restartTestDeployment("a", "ceph:v19")))
+	assert.NoError(t, err)
+	assert.Nil(t, updated)
+
+	// the budget of the mons is exhausted, the update fails so that the caller retries it later
+	modified := withHostNetwork(restartTestDeployment("a", "ceph:v19"))
+	modified.Spec.Template.Spec.Volumes = []v1.Volume{{Name: "data"}}
+	_, updated, err = updateDeployment(ctx, clientset, modified)
+	assert.True(t, errors.Is(err, ErrRestartBudgetExceeded))
+	assert.Nil(t, updated)
+	assert.True(t, IsRestartBudgetExceeded(namespace))
+	d, err := clientset.AppsV1().Deployments(namespace).Get(ctx, "a", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, d.Spec.Template.Spec.Volumes)
+
+	// the upgrade is not delayed by the exhausted budget
+	_, updated, err = updateDeployment(ctx, clientset, withHostNetwork(restartTestDeployment("a", "ceph:v20")))
+	assert.NoError(t, err)
+	assert.NotNil(t, updated)
+	assert.Equal(t, RestartReasonUpgrade, updated.Spec.Template.Annotations[RestartReasonAnnotation])
+
+	// the deployments that do not run a ceph daemon are not limited by the budget
+	other := restartTestDeployment("b", "ceph:v19")
+	delete(other.Labels, cephDaemonIDLabel)
+	assert.NoError(t, patch.DefaultAnnotator.SetLastAppliedAnnotation(other))
+	createDeploymentOrDie(clientset, other)
+	other = withHostNetwork(restartTestDeployment("b", "ceph:v19"))
+	delete(other.Labels, cephDaemonIDLabel)
+	_, updated, err = updateDeployment(ctx, clientset, other)
+	assert.NoError(t, err)
+	assert.NotNil(t, updated)
+	assert.Equal(t, "spec-change:hostNetwork", updated.Spec.Template.Annotations[RestartReasonAnnotation])
+}
+
+func TestIsCephDaemon(t *testing.T) {
+	assert.True(t, isCephDaemon("ns", map[string]string{ClusterAttr: "ns", cephDaemonIDLabel: "a"}))
+	assert.False(t, isCephDaemon("ns", map[string]string{ClusterAttr: "other-ns", cephDaemonIDLabel: "a"}))
+	assert.False(t, isCephDaemon("ns", map[string]string{ClusterAttr: "ns"}))
+	assert.False(t, isCephDaemon("ns", nil))
+}
+
+func TestRestartBudgetDenialExpires(t *testing.T) {
+	namespace := "budget-ns"
+	defer ResetRestartBudget(namespace)
+
+	SetRestartBudget(namespace, 1)
+	restartBudgets[namespace].denied["rook-ceph-mon"] = time.Now().Add(-2 * time.Hour)
+	assert.False(t, IsRestartBudgetExceeded(namespace))
+	restartBudgets[namespace].denied["rook-ceph-mon"] = time.Now()
+	assert.True(t, IsRestartBudgetExceeded(namespace))
+}
+
+func TestForceDeletePodIfStuck(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "ns"
+	defer ResetRestartBudget(namespace)
+
+	clientset := fake.NewSimpleClientset()
+	clusterdContext := &clusterd.Context{Clientset: clientset}
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}},
+	}
+	_, err := clientset.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	isController := true
+	owner := metav1.OwnerReference{Kind: "ReplicaSet", Name: "rook-ceph-mon-a-123", UID: "rs-uid", Controller: &isController}
+	podLabels := map[string]string{AppAttr: "rook-ceph-mon", ClusterAttr: namespace, cephDaemonIDLabel: "a"}
+	stuck := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rook-ceph-mon-a-123-stuck", Namespace: namespace, UID: "stuck-uid", Labels: podLabels,
+			OwnerReferences: []metav1.OwnerReference{owner}, DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: v1.PodSpec{NodeName: "node"},
+	}
+	replacement := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rook-ceph-mon-a-123-new", Namespace: namespace, UID: "new-uid", Labels: podLabels,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+	}
+	for _, pod := range []*v1.Pod{&stuck, replacement} {
+		_, err := clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	// the exhausted budget does not prevent the remediation
+	SetRestartBudget(namespace, 1)
+	assert.NoError(t, ConsumeRestartBudget(namespace, "rook-ceph-mon", RestartReasonSpecChange))
+
+	assert.NoError(t, ForceDeletePodIfStuck(ctx, clusterdContext, stuck))
+	_, err = clientset.CoreV1().Pods(namespace).Get(ctx, stuck.Name, metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, replacement.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, RestartReasonHealthRemediation, pod.Annotations[RestartReasonAnnotation])
+}
@@ -120,6 +120,8 @@ type LocalDisk struct {
 	KernelName string `json:"kernel-name,omitempty"`
 	// Whether this device should be encrypted
 	Encrypted bool `json:"encrypted,omitempty"`
+	// SmartStatus is the overall SMART health of the device, PASSED or FAILED, empty if unknown
+	SmartStatus string `json:"smartStatus,omitempty"`
 }
 
 // ListDevices list all devices available on a machine
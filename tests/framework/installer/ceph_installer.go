@@ -749,8 +749,11 @@ func (h *CephInstaller) UninstallRookFromMultipleNS(manifests ...CephManifests)
 			}
 		} else {
 			h.k8shelper.PrintResources(namespace, "cephblockpools.ceph.rook.io")
+			h.k8shelper.PrintResources(namespace, "cephclientblocklists.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephclients.ceph.rook.io")
+			h.k8shelper.PrintResources(namespace, "cephclienttemplates.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephclusters.ceph.rook.io")
+			h.k8shelper.PrintResources(namespace, "cephdeviceinventories.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephfilesystemmirrors.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephfilesystems.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephnfses.ceph.rook.io")
@@ -759,6 +762,7 @@ func (h *CephInstaller) UninstallRookFromMultipleNS(manifests ...CephManifests)
 			h.k8shelper.PrintResources(namespace, "cephobjectstoreusers.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephobjectzonegroups.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephobjectzones.ceph.rook.io")
+			h.k8shelper.PrintResources(namespace, "cephosdremovals.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "cephrbdmirrors.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "objectbucketclaims.ceph.rook.io")
 			h.k8shelper.PrintResources(namespace, "objectbuckets.ceph.rook.io")